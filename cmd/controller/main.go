@@ -25,6 +25,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun"
+	"github.com/tektoncd/pipeline/pkg/reconciler/ratelimit"
 	"github.com/tektoncd/pipeline/pkg/reconciler/resolutionrequest"
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun"
 	corev1 "k8s.io/api/core/v1"
@@ -57,6 +58,7 @@ func main() {
 	flag.StringVar(&opts.Images.ShellImageWin, "shell-image-win", "", "The container image containing a windows shell")
 	flag.StringVar(&opts.Images.WorkingDirInitImage, "workingdirinit-image", "", "The container image containing our working dir init binary.")
 	flag.DurationVar(&opts.ResyncPeriod, "resync-period", controller.DefaultResyncPeriod, "The period between two resync run (going through all objects)")
+	flag.StringVar(&opts.KeylessSigningRoots, "keyless-signing-roots", "", "The PEM file of the root certificates the keyless signatures of the step images must chain to for their signer to be recorded.")
 
 	// This parses flags.
 	cfg := injection.ParseAndGetRESTConfigOrDie()
@@ -70,12 +72,19 @@ func main() {
 	if cfg.Burst == 0 {
 		cfg.Burst = rest.DefaultBurst
 	}
+	// The client rate limits configured per controller in config-defaults
+	// default to the QPS and burst of a single controller.
+	clientQPS, clientBurst := float64(cfg.QPS), cfg.Burst
 	// FIXME(vdemeester): this is here to not break current behavior
 	// multiply by 2, no of controllers being created
 	cfg.QPS = 2 * cfg.QPS
 	cfg.Burst = 2 * cfg.Burst
 
 	ctx := injection.WithNamespaceScope(signals.NewContext(), *namespace)
+	ctx = ratelimit.WithClientDefaults(ctx, clientQPS, clientBurst)
+	// The pod and TaskRun creations of both controllers are paused together
+	// while the API server throttles them.
+	ctx = ratelimit.WithCreationBreaker(ctx, ratelimit.NewCreationBreaker(clock.RealClock{}))
 	if *disableHighAvailability {
 		ctx = sharedmain.WithHADisabled(ctx)
 	}
@@ -36,12 +36,14 @@ import (
 	"github.com/tektoncd/pipeline/pkg/entrypoint"
 	"github.com/tektoncd/pipeline/pkg/platforms"
 	"github.com/tektoncd/pipeline/pkg/termination"
+	"k8s.io/utils/clock"
 )
 
 var (
 	ep                  = flag.String("entrypoint", "", "Original specified entrypoint to execute")
 	waitFiles           = flag.String("wait_file", "", "Comma-separated list of paths to wait for")
 	waitFileContent     = flag.Bool("wait_file_content", false, "If specified, expect wait_file to have content")
+	readyFiles          = flag.String("ready_file", "", "Comma-separated list of paths to wait for to have content, after the wait_file paths")
 	postFile            = flag.String("post_file", "", "If specified, file to write upon completion")
 	terminationPath     = flag.String("termination_path", "/tekton/termination", "If specified, file to write upon termination")
 	results             = flag.String("results", "", "If specified, list of file names that might contain task results")
@@ -50,12 +52,19 @@ var (
 	timeout             = flag.Duration("timeout", time.Duration(0), "If specified, sets timeout for step")
 	stdoutPath          = flag.String("stdout_path", "", "If specified, file to copy stdout to")
 	stderrPath          = flag.String("stderr_path", "", "If specified, file to copy stderr to")
+	stdinPath           = flag.String("stdin_path", "", "If specified, file to feed to stdin")
+	stdinFromEnv        = flag.String("stdin_from_env", "", "If specified, env var holding the value to feed to stdin")
+	scriptFile          = flag.String("script_file", "", "If specified, file holding the script to execute, with the args of the step")
 	breakpointOnFailure = flag.Bool("breakpoint_on_failure", false, "If specified, expect steps to not skip on failure")
 	debugBeforeStep     = flag.Bool("debug_before_step", false, "If specified, wait for a debugger to attach before executing the step")
 	onError             = flag.String("on_error", "", "Set to \"continue\" to ignore an error and continue when a container terminates with a non-zero exit code."+
 		" Set to \"stopAndFail\" to declare a failure with a step error and stop executing the rest of the steps.")
-	stepMetadataDir        = flag.String("step_metadata_dir", "", "If specified, create directory to store the step metadata e.g. /tekton/steps/<step-name>/")
-	resultExtractionMethod = flag.String("result_from", entrypoint.ResultExtractionMethodTerminationMessage, "The method using which to extract results from tasks. Default is using the termination message.")
+	stepMetadataDir          = flag.String("step_metadata_dir", "", "If specified, create directory to store the step metadata e.g. /tekton/steps/<step-name>/")
+	resultExtractionMethod   = flag.String("result_from", entrypoint.ResultExtractionMethodTerminationMessage, "The method using which to extract results from tasks. Default is using the termination message.")
+	umask                    = flag.String("umask", "", "If specified, octal umask to set before writing files and running the step, e.g. \"0002\" to make them group writable")
+	reportHermeticViolations = flag.Bool("report_hermetic_violations", false, "If specified, write the number of network failures of the step run hermetically to the termination message")
+	pauseFile                = flag.String("pause_file", "", "If specified, wait for the file to be empty before starting the step")
+	terminationGracePeriod   = flag.Duration("termination_grace_period", 0, "If specified, the termination grace period of the pod within which the step is terminated with a SIGTERM before being killed when the TaskRun is cancelled")
 )
 
 const (
@@ -87,6 +96,12 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Set the umask before writing any file, so that the credentials, results
+	// and step files are created with the expected permissions.
+	if err := setUmask(*umask); err != nil {
+		log.Fatal(err)
+	}
+
 	// Copy credentials we're expecting from the legacy credentials helper (creds-init)
 	// from secret volume mounts to /tekton/creds. This is done to support the expansion
 	// of a variable, $(credentials.path), that resolves to a single place with all the
@@ -99,9 +114,12 @@ func main() {
 	}
 
 	var cmd []string
-	if *ep != "" {
+	switch {
+	case *scriptFile != "":
+		// The script is executed with the args of the step.
+	case *ep != "":
 		cmd = []string{*ep}
-	} else {
+	default:
 		env := os.Getenv(TektonPlatformCommandsEnv)
 		var cmds map[string][]string
 		if err := json.Unmarshal([]byte(env), &cmds); err != nil {
@@ -127,16 +145,36 @@ func main() {
 
 	spireWorkloadAPI := initializeSpireAPI()
 
+	// The value to feed to stdin isn't inherited by the step.
+	var stdinValue string
+	if *stdinFromEnv != "" {
+		stdinValue = os.Getenv(*stdinFromEnv)
+		if err := os.Unsetenv(*stdinFromEnv); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	var terminationGrace *entrypoint.TerminationGrace
+	if *terminationGracePeriod > 0 {
+		terminationGrace = &entrypoint.TerminationGrace{Period: *terminationGracePeriod, Clock: clock.RealClock{}}
+	}
+
 	e := entrypoint.Entrypointer{
 		Command:         append(cmd, commandArgs...),
+		ScriptFile:      *scriptFile,
 		WaitFiles:       strings.Split(*waitFiles, ","),
 		WaitFileContent: *waitFileContent,
+		ReadyFiles:      strings.Split(*readyFiles, ","),
 		PostFile:        *postFile,
 		TerminationPath: *terminationPath,
 		Waiter:          &realWaiter{waitPollingInterval: defaultWaitPollingInterval, breakpointOnFailure: *breakpointOnFailure},
 		Runner: &realRunner{
 			stdoutPath: *stdoutPath,
 			stderrPath: *stderrPath,
+			stdinPath:  *stdinPath,
+			stdinValue: stdinValue,
+
+			terminationGrace: terminationGrace,
 		},
 		PostWriter:             &realPostWriter{},
 		Results:                strings.Split(*results, ","),
@@ -149,6 +187,9 @@ func main() {
 		StepMetadataDir:        *stepMetadataDir,
 		SpireWorkloadAPI:       spireWorkloadAPI,
 		ResultExtractionMethod: *resultExtractionMethod,
+
+		ReportHermeticViolations: *reportHermeticViolations,
+		PauseFile:                *pauseFile,
 	}
 
 	// Copy any creds injected by the controller into the $HOME directory of the current
@@ -26,3 +26,16 @@ import "os/exec"
 func dropNetworking(cmd *exec.Cmd) { //nolint:deadcode
 	panic("only implemented on linux")
 }
+
+// networkCounters are the SNMP counters of the network namespace of a step.
+type networkCounters struct{}
+
+func openNetworkCounters(pid int) (*networkCounters, error) { //nolint:deadcode
+	panic("only implemented on linux")
+}
+
+func (c *networkCounters) networkFailures() (int, error) {
+	panic("only implemented on linux")
+}
+
+func (c *networkCounters) close() {}
@@ -17,9 +17,16 @@ limitations under the License.
 package main
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
 	"math"
+	"os"
 	"os/exec"
 	"syscall"
+
+	"github.com/tektoncd/pipeline/pkg/entrypoint"
 )
 
 // We need the max value of an unsigned 32 bit integer (4294967295), but we also need this number
@@ -79,3 +86,48 @@ func dropNetworking(cmd *exec.Cmd) {
 		},
 	}
 }
+
+// networkCounters are the SNMP counters of the network namespace of a step.
+// Each open file holds a reference to the namespace, so they can still be
+// read once the step exited.
+type networkCounters struct {
+	snmp  *os.File
+	snmp6 *os.File
+}
+
+// openNetworkCounters opens the SNMP counters of the network namespace of the
+// process with the given pid. The IPv6 counters are missing when IPv6 is
+// disabled.
+func openNetworkCounters(pid int) (*networkCounters, error) {
+	snmp, err := os.Open(fmt.Sprintf("/proc/%d/net/snmp", pid))
+	if err != nil {
+		return nil, err
+	}
+	c := &networkCounters{snmp: snmp}
+	snmp6, err := os.Open(fmt.Sprintf("/proc/%d/net/snmp6", pid))
+	switch {
+	case err == nil:
+		c.snmp6 = snmp6
+	case !errors.Is(err, fs.ErrNotExist):
+		c.close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// networkFailures returns the number of packets which couldn't be routed out
+// of the network namespace.
+func (c *networkCounters) networkFailures() (int, error) {
+	var snmp6 io.Reader
+	if c.snmp6 != nil {
+		snmp6 = c.snmp6
+	}
+	return entrypoint.CountNetworkFailures(c.snmp, snmp6)
+}
+
+func (c *networkCounters) close() {
+	c.snmp.Close()
+	if c.snmp6 != nil {
+		c.snmp6.Close()
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log"
 	"os"
 	"os/exec"
 	"os/signal"
@@ -47,9 +48,22 @@ type realRunner struct {
 	signalsClosed bool
 	stdoutPath    string
 	stderrPath    string
+	stdinPath     string
+	stdinValue    string
+
+	// terminationGrace terminates the step gracefully when the TaskRun is
+	// cancelled. The step is killed right away when it is nil.
+	terminationGrace *entrypoint.TerminationGrace
+
+	// hermeticViolations is the number of network failures of the step, when
+	// it was run hermetically and its network counters could be read.
+	hermeticViolations *int
 }
 
-var _ entrypoint.Runner = (*realRunner)(nil)
+var (
+	_ entrypoint.Runner                    = (*realRunner)(nil)
+	_ entrypoint.HermeticViolationsCounter = (*realRunner)(nil)
+)
 
 // close closes the signals channel which is used to receive system signals.
 func (rr *realRunner) close() {
@@ -87,6 +101,15 @@ func (rr *realRunner) Run(ctx context.Context, args ...string) error {
 
 	cmd := exec.CommandContext(ctx, name, args...)
 
+	stdin, err := openStdin(rr.stdinPath, rr.stdinValue)
+	if err != nil {
+		return err
+	}
+	if stdin != nil {
+		defer stdin.Close()
+		cmd.Stdin = stdin
+	}
+
 	// if a standard output file is specified
 	// create the log file and add to the std multi writer
 	if rr.stdoutPath != "" {
@@ -114,7 +137,28 @@ func (rr *realRunner) Run(ctx context.Context, args ...string) error {
 	// main process and all children
 	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
 
-	if os.Getenv("TEKTON_RESOURCE_NAME") == "" && os.Getenv(TektonHermeticEnvVar) == "1" {
+	// When the TaskRun is cancelled, the step is sent a SIGTERM and killed
+	// once the grace period of the pod elapsed, while it keeps being killed
+	// right away when it times out.
+	exited := make(chan struct{})
+	defer close(exited)
+	if rr.terminationGrace != nil {
+		cmd.Cancel = func() error {
+			if !errors.Is(ctx.Err(), context.Canceled) {
+				return cmd.Process.Kill()
+			}
+			cancelledAt := entrypoint.CancellationTime(rr.terminationGrace.Clock)
+			go func() {
+				_ = rr.terminationGrace.Terminate(cancelledAt, exited,
+					func() error { return syscall.Kill(-cmd.Process.Pid, syscall.SIGTERM) },
+					func() error { return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL) })
+			}()
+			return nil
+		}
+	}
+
+	hermetic := os.Getenv("TEKTON_RESOURCE_NAME") == "" && os.Getenv(TektonHermeticEnvVar) == "1"
+	if hermetic {
 		dropNetworking(cmd)
 	}
 
@@ -129,6 +173,18 @@ func (rr *realRunner) Run(ctx context.Context, args ...string) error {
 		return err
 	}
 
+	// The network counters of the step are opened as soon as it started, which
+	// keeps its network namespace alive until they are read once it exited.
+	var counters *networkCounters
+	if hermetic {
+		counters, err = openNetworkCounters(cmd.Process.Pid)
+		if err != nil {
+			log.Printf("Failed to open the network counters of the step, its hermetic violations won't be reported: %v", err)
+		} else {
+			defer counters.close()
+		}
+	}
+
 	// Goroutine for signals forwarding
 	go func() {
 		for s := range rr.signals {
@@ -143,7 +199,11 @@ func (rr *realRunner) Run(ctx context.Context, args ...string) error {
 	// as os.exec [note](https://github.com/golang/go/blob/ee522e2cdad04a43bc9374776483b6249eb97ec9/src/os/exec/exec.go#L897-L906)
 	// cmd.Wait prefer Process error over context error
 	// but we want to return context error instead
-	if err := cmd.Wait(); err != nil {
+	err = cmd.Wait()
+	if counters != nil {
+		rr.countHermeticViolations(counters)
+	}
+	if err != nil {
 		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
 			return entrypoint.ErrContextDeadlineExceeded
 		}
@@ -156,6 +216,26 @@ func (rr *realRunner) Run(ctx context.Context, args ...string) error {
 	return nil
 }
 
+// countHermeticViolations reads the number of network failures of the step
+// from the counters of its network namespace.
+func (rr *realRunner) countHermeticViolations(counters *networkCounters) {
+	violations, err := counters.networkFailures()
+	if err != nil {
+		log.Printf("Failed to read the network counters of the step, its hermetic violations won't be reported: %v", err)
+		return
+	}
+	rr.hermeticViolations = &violations
+}
+
+// HermeticViolations returns the number of network failures of the step, if
+// it was run hermetically.
+func (rr *realRunner) HermeticViolations() (int, bool) {
+	if rr.hermeticViolations == nil {
+		return 0, false
+	}
+	return *rr.hermeticViolations, true
+}
+
 // newStdLogWriter create a new file writer that used for collecting std log
 // the file is opened with os.O_WRONLY|os.O_CREATE|os.O_APPEND, and will not
 // override any existing content in the path. This means that the same file can
@@ -24,6 +24,7 @@ import (
 	"io"
 	"math/rand"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"syscall"
@@ -31,6 +32,7 @@ import (
 	"time"
 
 	"github.com/tektoncd/pipeline/pkg/entrypoint"
+	"k8s.io/utils/clock"
 )
 
 // TestRealRunnerSignalForwarding will artificially put an interrupt signal (SIGINT) in the rr.signals chan.
@@ -129,6 +131,55 @@ func TestRealRunnerStdoutAndStderrSamePath(t *testing.T) {
 	}
 }
 
+func TestRealRunnerStdin(t *testing.T) {
+	tmp := t.TempDir()
+	inputPath := filepath.Join(tmp, "input.json")
+	if err := os.WriteFile(inputPath, []byte(`{"from":"file"}`), 0o644); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, tc := range []struct {
+		name       string
+		stdinPath  string
+		stdinValue string
+		want       string
+	}{{
+		name:       "stdin fed from a param",
+		stdinValue: `{"from":"param"}`,
+		want:       `{"from":"param"}`,
+	}, {
+		name:      "stdin fed from a workspace file",
+		stdinPath: inputPath,
+		want:      `{"from":"file"}`,
+	}, {
+		name: "no stdin",
+		want: "",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			outputPath := filepath.Join(t.TempDir(), "output")
+			rr := realRunner{stdinPath: tc.stdinPath, stdinValue: tc.stdinValue}
+			if err := rr.Run(t.Context(), "sh", "-c", "cat > "+outputPath); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got, err := os.ReadFile(outputPath); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			} else if string(got) != tc.want {
+				t.Errorf("got stdin: %q, wanted: %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRealRunnerStdinMissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing.json")
+	rr := realRunner{stdinPath: path}
+	err := rr.Run(t.Context(), "cat")
+	want := fmt.Sprintf("the file %q to feed to the standard input of the step doesn't exist", path)
+	if err == nil || err.Error() != want {
+		t.Errorf("expected error %q but got %v", want, err)
+	}
+}
+
 func TestRealRunnerStdoutPathWithSignal(t *testing.T) {
 	tmp := t.TempDir()
 
@@ -223,3 +274,81 @@ func TestRealRunnerCancel(t *testing.T) {
 		}
 	}
 }
+
+func TestRealRunnerCancelTerminationGrace(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		script        string
+		wantTerminate bool
+	}{{
+		name:          "step terminating gracefully",
+		script:        `trap 'echo terminated > "$MARKER"; exit 0' TERM; while true; do sleep 0.1; done`,
+		wantTerminate: true,
+	}, {
+		name:   "step ignoring the SIGTERM",
+		script: `trap '' TERM; while true; do sleep 0.1; done`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			marker := filepath.Join(t.TempDir(), "marker")
+			t.Setenv("MARKER", marker)
+			rr := realRunner{
+				terminationGrace: &entrypoint.TerminationGrace{Period: time.Second, Clock: clock.RealClock{}},
+			}
+			ctx, cancel := context.WithCancel(t.Context())
+			go func() {
+				// Let the shell install its trap before cancelling.
+				time.Sleep(500 * time.Millisecond)
+				cancel()
+			}()
+
+			start := time.Now()
+			if err := rr.Run(ctx, "sh", "-c", tc.script); !errors.Is(err, entrypoint.ErrContextCanceled) {
+				t.Fatalf("unexpected error received: %v", err)
+			}
+			if elapsed := time.Since(start); elapsed > 10*time.Second {
+				t.Errorf("expected the step to be killed once the grace period elapsed, ran for %v", elapsed)
+			}
+			if _, err := os.Stat(marker); (err == nil) != tc.wantTerminate {
+				t.Errorf("expected the step to handle the SIGTERM: %t, got marker error %v", tc.wantTerminate, err)
+			}
+		})
+	}
+}
+
+func TestRealRunnerHermeticViolations(t *testing.T) {
+	testCmd := exec.Command("true")
+	dropNetworking(testCmd)
+	if _, err := testCmd.CombinedOutput(); err != nil {
+		t.Skipf("skipping test as required namespace features are not available: %v", err)
+	}
+	t.Setenv("TEKTON_RESOURCE_NAME", "")
+
+	for _, tc := range []struct {
+		desc         string
+		hermetic     string
+		wantCount    int
+		wantHermetic bool
+	}{{
+		desc:         "run hermetically",
+		hermetic:     "1",
+		wantCount:    2,
+		wantHermetic: true,
+	}, {
+		desc:     "not run hermetically",
+		hermetic: "",
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Setenv(TektonHermeticEnvVar, tc.hermetic)
+			rr := realRunner{}
+			// Only the attempts to reach the network are counted, not what
+			// the step prints.
+			if err := rr.Run(t.Context(), "bash", "-c", "echo 'connect: Network is unreachable'; (: <>/dev/udp/192.0.2.1/53) 2>/dev/null; (: <>/dev/udp/192.0.2.2/53) 2>/dev/null; true"); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			count, hermetic := rr.HermeticViolations()
+			if count != tc.wantCount || hermetic != tc.wantHermetic {
+				t.Errorf("got %d violations (hermetic: %t), wanted %d (hermetic: %t)", count, hermetic, tc.wantCount, tc.wantHermetic)
+			}
+		})
+	}
+}
@@ -35,6 +35,12 @@ import (
 type realRunner struct {
 	stdoutPath string
 	stderrPath string
+	stdinPath  string
+	stdinValue string
+
+	// terminationGrace is ignored on Windows, where the steps can't be sent
+	// a SIGTERM and are killed right away when the TaskRun is cancelled.
+	terminationGrace *entrypoint.TerminationGrace
 }
 
 var _ entrypoint.Runner = (*realRunner)(nil)
@@ -51,6 +57,14 @@ func (rr *realRunner) Run(ctx context.Context, args ...string) error {
 	cmd := exec.CommandContext(ctx, name, args...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
+	stdin, err := openStdin(rr.stdinPath, rr.stdinValue)
+	if err != nil {
+		return err
+	}
+	if stdin != nil {
+		defer stdin.Close()
+		cmd.Stdin = stdin
+	}
 
 	// Run the defined command
 	if err := cmd.Run(); err != nil {
@@ -0,0 +1,46 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// openStdin returns the reader fed to the standard input of the step: the
+// file at stdinPath if it is set, the stdinValue otherwise. It returns nil if
+// neither is set, leaving the standard input of the step empty.
+func openStdin(stdinPath, stdinValue string) (io.ReadCloser, error) {
+	if stdinPath == "" {
+		if stdinValue == "" {
+			return nil, nil
+		}
+		return io.NopCloser(strings.NewReader(stdinValue)), nil
+	}
+	f, err := os.Open(stdinPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("the file %q to feed to the standard input of the step doesn't exist", stdinPath)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the file %q to feed to the standard input of the step: %w", stdinPath, err)
+	}
+	return f, nil
+}
@@ -0,0 +1,42 @@
+//go:build !windows
+// +build !windows
+
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"syscall"
+)
+
+// setUmask sets the umask of the entrypoint from the given octal mask, e.g.
+// "0002". It applies both to the files written by the entrypoint, such as the
+// post file and the step metadata, and to the files written by the step, which
+// inherits it. The umask is left unchanged when the mask is empty.
+func setUmask(mask string) error {
+	if mask == "" {
+		return nil
+	}
+	m, err := strconv.ParseUint(mask, 8, 32)
+	if err != nil || m > 0o777 {
+		return fmt.Errorf("invalid umask %q", mask)
+	}
+	syscall.Umask(int(m))
+	return nil
+}
@@ -0,0 +1,106 @@
+//go:build !windows
+// +build !windows
+
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+)
+
+func TestSetUmask_FileModes(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		umask    string
+		wantFile os.FileMode
+		wantDir  os.FileMode
+	}{{
+		name:     "group writable",
+		umask:    "0002",
+		wantFile: 0o664,
+		wantDir:  0o775,
+	}, {
+		name:     "group readable",
+		umask:    "022",
+		wantFile: 0o644,
+		wantDir:  0o755,
+	}, {
+		name:     "private",
+		umask:    "0077",
+		wantFile: 0o600,
+		wantDir:  0o700,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			// The umask is process wide, restore it once the test is done.
+			original := syscall.Umask(0)
+			defer syscall.Umask(original)
+
+			if err := setUmask(tc.umask); err != nil {
+				t.Fatalf("setUmask(%q) = %v", tc.umask, err)
+			}
+			dir := filepath.Join(t.TempDir(), "run", "0", "status")
+			file := filepath.Join(dir, "out")
+			rw := realPostWriter{}
+			rw.Write(file, "content")
+
+			fi, err := os.Stat(file)
+			if err != nil {
+				t.Fatalf("Failed to stat %q: %v", file, err)
+			}
+			if got := fi.Mode().Perm(); got != tc.wantFile {
+				t.Errorf("file mode = %o, want %o", got, tc.wantFile)
+			}
+			di, err := os.Stat(dir)
+			if err != nil {
+				t.Fatalf("Failed to stat %q: %v", dir, err)
+			}
+			if got := di.Mode().Perm(); got != tc.wantDir {
+				t.Errorf("directory mode = %o, want %o", got, tc.wantDir)
+			}
+		})
+	}
+}
+
+func TestSetUmask_Empty(t *testing.T) {
+	original := syscall.Umask(0o022)
+	defer syscall.Umask(original)
+
+	if err := setUmask(""); err != nil {
+		t.Fatalf("setUmask(\"\") = %v", err)
+	}
+	if got := syscall.Umask(0o022); got != 0o022 {
+		t.Errorf("umask = %o, want it unchanged to 022", got)
+	}
+}
+
+func TestSetUmask_Invalid(t *testing.T) {
+	original := syscall.Umask(0o022)
+	defer syscall.Umask(original)
+
+	for _, mask := range []string{"foo", "0999", "01000"} {
+		if err := setUmask(mask); err == nil {
+			t.Errorf("setUmask(%q) expected an error", mask)
+		}
+	}
+	if got := syscall.Umask(0o022); got != 0o022 {
+		t.Errorf("umask = %o, want it unchanged to 022", got)
+	}
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	"github.com/tektoncd/pipeline/pkg/logproxy"
+	"go.uber.org/zap"
+	"k8s.io/client-go/kubernetes"
+	"knative.dev/pkg/injection"
+	"knative.dev/pkg/signals"
+)
+
+func main() {
+	var (
+		address     = flag.String("address", ":8443", "The address the log proxy listens on.")
+		tlsCertFile = flag.String("tls-cert-file", "", "The certificate the log proxy serves. Required unless --insecure is set.")
+		tlsKeyFile  = flag.String("tls-key-file", "", "The private key of the certificate the log proxy serves. Required unless --insecure is set.")
+		insecure    = flag.Bool("insecure", false, "Serve plain HTTP instead of HTTPS. The bearer tokens of the requests are then sent in clear, so only set it behind a proxy terminating TLS.")
+		logsBackend = flag.String("logs-backend-url", "", "The URL of the system storing the logs of the TaskRuns whose pods are gone. The requests are only redirected to the tekton.dev/logs-location annotations under this URL.")
+		opts        logproxy.Options
+	)
+	flag.Float64Var(&opts.RequestsPerSecond, "requests-per-second", 1, "The number of requests per second a user can make.")
+	flag.IntVar(&opts.Burst, "burst", 5, "The number of requests a user can make at once.")
+	flag.IntVar(&opts.MaxConcurrentStreams, "max-concurrent-streams", 100, "The number of logs streamed at the same time, across all users.")
+
+	// This parses flags.
+	cfg := injection.ParseAndGetRESTConfigOrDie()
+
+	logger, err := zap.NewProduction()
+	if err != nil {
+		log.Fatalf("Failed to create the logger: %v", err)
+	}
+	sugared := logger.Sugar()
+
+	if !*insecure && (*tlsCertFile == "" || *tlsKeyFile == "") {
+		sugared.Fatal("The log proxy requires --tls-cert-file and --tls-key-file, unless --insecure is set")
+	}
+
+	if *logsBackend != "" {
+		opts.LogsBackend, err = url.Parse(*logsBackend)
+		if err != nil || opts.LogsBackend.Scheme == "" || opts.LogsBackend.Host == "" {
+			sugared.Fatalf("Invalid --logs-backend-url %q, it must be an absolute URL", *logsBackend)
+		}
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		sugared.Fatalf("Failed to create the Kubernetes client: %v", err)
+	}
+	pipelineClient, err := versioned.NewForConfig(cfg)
+	if err != nil {
+		sugared.Fatalf("Failed to create the Tekton client: %v", err)
+	}
+
+	server := &http.Server{
+		Addr:              *address,
+		Handler:           logproxy.NewHandler(kubeClient, pipelineClient, sugared, opts),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx := signals.NewContext()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			sugared.Errorf("Failed to shut down the log proxy: %v", err)
+		}
+	}()
+
+	sugared.Infof("Log proxy listening on %s", *address)
+	if *insecure {
+		sugared.Warn("The log proxy serves plain HTTP, the bearer tokens of the requests aren't encrypted")
+		err = server.ListenAndServe()
+	} else {
+		err = server.ListenAndServeTLS(*tlsCertFile, *tlsKeyFile)
+	}
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		sugared.Fatalf("Log proxy failed: %v", err)
+	}
+}
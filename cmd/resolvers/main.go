@@ -17,10 +17,16 @@ limitations under the License.
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
+	"log"
+	nethttp "net/http"
 	"os"
 	"strings"
+	"time"
 
+	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/tektoncd/pipeline/pkg/apis/resolution/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/remoteresolution/resolver/bundle"
 	"github.com/tektoncd/pipeline/pkg/remoteresolution/resolver/cluster"
@@ -28,6 +34,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/remoteresolution/resolver/git"
 	"github.com/tektoncd/pipeline/pkg/remoteresolution/resolver/http"
 	"github.com/tektoncd/pipeline/pkg/remoteresolution/resolver/hub"
+	gitresolution "github.com/tektoncd/pipeline/pkg/resolution/resolver/git"
 	hubresolution "github.com/tektoncd/pipeline/pkg/resolution/resolver/hub"
 	"k8s.io/client-go/rest"
 	filteredinformerfactory "knative.dev/pkg/client/injection/kube/informers/factory/filtered"
@@ -37,6 +44,10 @@ import (
 	"knative.dev/pkg/signals"
 )
 
+// defaultDiscoveryPort is the port on which the description of the
+// params of the resolvers is served, unless DISCOVERY_PORT is set.
+const defaultDiscoveryPort = "8081"
+
 func main() {
 	flag.IntVar(&controller.DefaultThreadsPerController, "threads-per-controller", controller.DefaultThreadsPerController, "Threads (goroutines) to create per controller")
 
@@ -57,12 +68,58 @@ func main() {
 	cfg.QPS = 5 * cfg.QPS
 	cfg.Burst = 5 * cfg.Burst
 
-	sharedmain.MainWithConfig(ctx, "controller", cfg,
-		framework.NewController(ctx, &git.Resolver{}),
-		framework.NewController(ctx, &hub.Resolver{TektonHubURL: tektonHubURL, ArtifactHubURL: artifactHubURL}),
-		framework.NewController(ctx, &bundle.Resolver{}),
-		framework.NewController(ctx, &cluster.Resolver{}),
-		framework.NewController(ctx, &http.Resolver{}))
+	gitResolver := &git.Resolver{}
+	resolvers := []framework.Resolver{
+		gitResolver,
+		&hub.Resolver{TektonHubURL: tektonHubURL, ArtifactHubURL: artifactHubURL},
+		&bundle.Resolver{},
+		&cluster.Resolver{},
+		&http.Resolver{},
+	}
+	var modifiers []framework.ReconcilerModifier
+	if repository := os.Getenv("RESOLUTION_ARCHIVE_REPOSITORY"); repository != "" {
+		// The credentials of the repository are read from the docker config
+		// at $DOCKER_CONFIG.
+		archiver, err := framework.NewOCIArchiver(repository, authn.DefaultKeychain)
+		if err != nil {
+			log.Fatal(err)
+		}
+		modifiers = append(modifiers, framework.WithArchiver(archiver))
+	}
+	controllers := make([]injection.ControllerConstructor, 0, len(resolvers))
+	for _, r := range resolvers {
+		controllers = append(controllers, framework.NewController(ctx, r, modifiers...))
+	}
+
+	mux := nethttp.NewServeMux()
+	discovery := framework.NewDiscoveryHandler(ctx, resolvers...)
+	mux.Handle(framework.DiscoveryPath, discovery)
+	mux.Handle(framework.DiscoveryPath+"/", discovery)
+	mux.Handle(gitresolution.InvalidatePath, gitresolution.NewInvalidationHandler(ctx, gitResolver, []byte(os.Getenv("GIT_WEBHOOK_SECRET"))))
+	go serveDiscovery(ctx, os.Getenv("DISCOVERY_PORT"), mux)
+
+	sharedmain.MainWithConfig(ctx, "controller", cfg, controllers...)
+}
+
+// serveDiscovery serves the description of the params of the resolvers,
+// and the cache invalidation webhooks of the git resolver, on the given
+// port, until the context is done.
+func serveDiscovery(ctx context.Context, port string, handler nethttp.Handler) {
+	if port == "" {
+		port = defaultDiscoveryPort
+	}
+	server := &nethttp.Server{
+		Addr:              ":" + port,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		_ = server.Close()
+	}()
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, nethttp.ErrServerClosed) {
+		log.Printf("resolvers discovery server failed: %v", err)
+	}
 }
 
 func buildHubURL(configAPI, defaultURL string) string {
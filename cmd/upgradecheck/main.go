@@ -0,0 +1,113 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	"github.com/tektoncd/pipeline/pkg/upgradecheck"
+	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	"knative.dev/pkg/injection"
+	"knative.dev/pkg/signals"
+)
+
+func main() {
+	var (
+		checker upgradecheck.Checker
+		output  = flag.String("output", "text", "The format of the reports, text or json.")
+	)
+	flag.StringVar(&checker.Namespace, "namespace", "", "The namespace to check. All the namespaces are checked if empty.")
+	flag.BoolVar(&checker.RewriteStorageVersions, "rewrite-storage-versions", false,
+		"Rewrite the objects which convert without dropping any field in the storage version, and update the stored versions of the CRDs once all their objects are rewritten.")
+
+	// This parses flags.
+	cfg := injection.ParseAndGetRESTConfigOrDie()
+	if *output != "text" && *output != "json" {
+		log.Fatalf("Unknown output format %q, expected text or json", *output)
+	}
+
+	client, err := versioned.NewForConfig(cfg)
+	if err != nil {
+		log.Fatalf("Failed to create the Tekton client: %v", err)
+	}
+	checker.Client = client
+	if checker.RewriteStorageVersions {
+		crdClient, err := apiextensionsclient.NewForConfig(cfg)
+		if err != nil {
+			log.Fatalf("Failed to create the CRD client: %v", err)
+		}
+		checker.CRDClient = crdClient.ApiextensionsV1()
+	}
+
+	reports, err := checker.Run(signals.NewContext())
+	if *output == "json" {
+		err = writeJSON(os.Stdout, reports, err)
+	} else {
+		err = writeText(os.Stdout, reports, err)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, r := range reports {
+		if r.Error != "" || r.HasDrops() {
+			os.Exit(1)
+		}
+	}
+}
+
+// writeJSON writes the reports as a JSON array, and returns the error of the
+// check once they're written.
+func writeJSON(w io.Writer, reports []upgradecheck.Report, checkErr error) error {
+	if reports == nil {
+		reports = []upgradecheck.Report{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(reports); err != nil {
+		return fmt.Errorf("failed to write the reports: %w", err)
+	}
+	return checkErr
+}
+
+// writeText writes a line per finding or error of the objects, and returns
+// the error of the check once they're written.
+func writeText(w io.Writer, reports []upgradecheck.Report, checkErr error) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "KIND\tNAMESPACE\tNAME\tFIELD\tCHANGE\tTARGET")
+	for _, r := range reports {
+		for _, f := range r.Findings {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", r.Kind, r.Namespace, r.Name, f.Field, f.Change, f.Target)
+		}
+		if r.Error != "" {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t\tError\t%s\n", r.Kind, r.Namespace, r.Name, r.Error)
+		}
+		if r.Rewritten {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t\tRewritten\t\n", r.Kind, r.Namespace, r.Name)
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("failed to write the reports: %w", err)
+	}
+	return checkErr
+}
@@ -22,6 +22,7 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"sync"
 
 	defaultconfig "github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
@@ -31,7 +32,11 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/resolution"
 	resolutionv1alpha1 "github.com/tektoncd/pipeline/pkg/apis/resolution/v1alpha1"
 	resolutionv1beta1 "github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/informers"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/injection"
@@ -53,6 +58,7 @@ var types = map[schema.GroupVersionKind]resourcesemantics.GenericCRD{
 	// v1alpha1
 	v1alpha1.SchemeGroupVersion.WithKind("VerificationPolicy"): &v1alpha1.VerificationPolicy{},
 	v1alpha1.SchemeGroupVersion.WithKind("StepAction"):         &v1alpha1.StepAction{},
+	v1alpha1.SchemeGroupVersion.WithKind("ParameterSet"):       &v1alpha1.ParameterSet{},
 	// v1beta1
 	v1beta1.SchemeGroupVersion.WithKind("Pipeline"):    &v1beta1.Pipeline{},
 	v1beta1.SchemeGroupVersion.WithKind("Task"):        &v1beta1.Task{},
@@ -73,11 +79,39 @@ var types = map[schema.GroupVersionKind]resourcesemantics.GenericCRD{
 	resolutionv1beta1.SchemeGroupVersion.WithKind("ResolutionRequest"): &resolutionv1beta1.ResolutionRequest{},
 }
 
+var (
+	namespaceDefaultsOnce sync.Once
+	namespaceDefaults     defaultconfig.NamespaceDefaultsGetter
+)
+
+// namespaceDefaultsGetter returns the getter of the ConfigMaps overriding the
+// defaults of the runs of their namespace, backed by an informer of these
+// ConfigMaps only, which is shared by the admission controllers. It waits for
+// the informer to be synced, so that the first admissions get the defaults of
+// the namespaces.
+func namespaceDefaultsGetter(ctx context.Context) defaultconfig.NamespaceDefaultsGetter {
+	namespaceDefaultsOnce.Do(func() {
+		factory := informers.NewSharedInformerFactoryWithOptions(kubeclient.Get(ctx), controller.GetResyncPeriod(ctx),
+			informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+				opts.FieldSelector = fields.OneTermEqualSelector("metadata.name", defaultconfig.NamespaceDefaultsConfigName).String()
+			}))
+		namespaceDefaults = defaultconfig.NamespaceDefaultsGetterFromInformer(factory.Core().V1().ConfigMaps())
+		factory.Start(ctx.Done())
+		factory.WaitForCacheSync(ctx.Done())
+	})
+	return namespaceDefaults
+}
+
 func newDefaultingAdmissionController(name string) func(context.Context, configmap.Watcher) *controller.Impl {
 	return func(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
 		// Decorate contexts with the current state of the config.
 		store := defaultconfig.NewStore(logging.FromContext(ctx).Named("config-store"))
 		store.WatchConfigs(cmw)
+		// The defaults of the runs can be overridden by a ConfigMap in their namespace.
+		namespaceDefaults := namespaceDefaultsGetter(ctx)
+		// The decisions on the approval gates of PipelineRuns are authorized
+		// with SubjectAccessReviews.
+		approvalAuthorizer := v1.ApprovalAuthorizerFromClient(kubeclient.Get(ctx))
 		return defaulting.NewAdmissionController(ctx,
 
 			// Name of the resource webhook, it is the value of the environment variable WEBHOOK_ADMISSION_CONTROLLER_NAME
@@ -92,7 +126,7 @@ func newDefaultingAdmissionController(name string) func(context.Context, configm
 
 			// A function that infuses the context passed to Validate/SetDefaults with custom metadata.
 			func(ctx context.Context) context.Context {
-				return store.ToContext(ctx)
+				return v1.WithApprovalAuthorizer(defaultconfig.WithNamespaceDefaultsGetter(store.ToContext(ctx), namespaceDefaults), approvalAuthorizer)
 			},
 
 			// Whether to disallow unknown fields.
@@ -106,6 +140,11 @@ func newValidationAdmissionController(name string) func(context.Context, configm
 		// Decorate contexts with the current state of the config.
 		store := defaultconfig.NewStore(logging.FromContext(ctx).Named("config-store"))
 		store.WatchConfigs(cmw)
+		// The defaults of the runs can be overridden by a ConfigMap in their namespace.
+		namespaceDefaults := namespaceDefaultsGetter(ctx)
+		// The decisions on the approval gates of PipelineRuns are authorized
+		// with SubjectAccessReviews.
+		approvalAuthorizer := v1.ApprovalAuthorizerFromClient(kubeclient.Get(ctx))
 		return validation.NewAdmissionController(ctx,
 
 			// Name of the validation webhook, it is based on the value of the environment variable WEBHOOK_ADMISSION_CONTROLLER_NAME
@@ -120,7 +159,7 @@ func newValidationAdmissionController(name string) func(context.Context, configm
 
 			// A function that infuses the context passed to Validate/SetDefaults with custom metadata.
 			func(ctx context.Context) context.Context {
-				return store.ToContext(ctx)
+				return v1.WithApprovalAuthorizer(defaultconfig.WithNamespaceDefaultsGetter(store.ToContext(ctx), namespaceDefaults), approvalAuthorizer)
 			},
 
 			// Whether to disallow unknown fields.
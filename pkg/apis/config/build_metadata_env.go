@@ -0,0 +1,52 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// BuildMetadataEnvContextVariables are the context variables which the
+// values of the build metadata environment variables can reference.
+var BuildMetadataEnvContextVariables = []string{
+	"context.taskRun.name",
+	"context.taskRun.namespace",
+	"context.taskRun.uid",
+	"context.task.name",
+	"context.task.retry-count",
+	"context.pipelineRun.name",
+	"context.pipelineRun.uid",
+	"context.pipeline.name",
+}
+
+func validateBuildMetadataEnv(env map[string]string) error {
+	for name, value := range env {
+		if errs := validation.IsEnvVarName(name); len(errs) > 0 {
+			return fmt.Errorf("invalid build metadata environment variable name %q: %s", name, strings.Join(errs, "; "))
+		}
+		for _, match := range variableRegex.FindAllStringSubmatch(value, -1) {
+			if !slices.Contains(BuildMetadataEnvContextVariables, match[1]) {
+				return fmt.Errorf("build metadata environment variable %q can only reference the context variables %s, got %q", name, strings.Join(BuildMetadataEnvContextVariables, ", "), match[0])
+			}
+		}
+	}
+	return nil
+}
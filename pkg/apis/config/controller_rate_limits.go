@@ -0,0 +1,140 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ControllerRateLimitsDefaultKey is the key of the rate limits applying
+	// to all the controllers in the default-controller-rate-limits config.
+	ControllerRateLimitsDefaultKey = "default"
+	// ControllerRateLimitsTaskRunKey is the key of the rate limits of the
+	// TaskRun controller in the default-controller-rate-limits config.
+	ControllerRateLimitsTaskRunKey = "taskrun"
+	// ControllerRateLimitsPipelineRunKey is the key of the rate limits of the
+	// PipelineRun controller in the default-controller-rate-limits config.
+	ControllerRateLimitsPipelineRunKey = "pipelinerun"
+
+	// DefaultWorkQueueBaseDelay is the delay before retrying a key which
+	// failed to be reconciled for the first time.
+	DefaultWorkQueueBaseDelay = 5 * time.Millisecond
+	// DefaultWorkQueueMaxDelay is the maximum delay before retrying a key
+	// which failed to be reconciled.
+	DefaultWorkQueueMaxDelay = 1000 * time.Second
+	// DefaultWorkQueueQPS is the rate at which the keys which failed to be
+	// reconciled are retried.
+	DefaultWorkQueueQPS = 10
+	// DefaultWorkQueueBurst is the number of keys which failed to be
+	// reconciled which can be retried at once.
+	DefaultWorkQueueBurst = 100
+)
+
+// ControllerRateLimits are the rate limits of the work queue and API clients
+// of a controller. Zero values are inherited from the rate limits of all the
+// controllers, then from the built-in defaults.
+// +k8s:deepcopy-gen=true
+type ControllerRateLimits struct {
+	// WorkQueueBaseDelay is the delay before retrying a key which failed to
+	// be reconciled for the first time. It doubles with each failure.
+	// +optional
+	WorkQueueBaseDelay metav1.Duration `json:"workQueueBaseDelay,omitempty"`
+	// WorkQueueMaxDelay is the maximum delay before retrying a key which
+	// failed to be reconciled.
+	// +optional
+	WorkQueueMaxDelay metav1.Duration `json:"workQueueMaxDelay,omitempty"`
+	// WorkQueueQPS is the rate at which the keys which failed to be
+	// reconciled are retried.
+	// +optional
+	WorkQueueQPS float64 `json:"workQueueQPS,omitempty"`
+	// WorkQueueBurst is the number of keys which failed to be reconciled
+	// which can be retried at once.
+	// +optional
+	WorkQueueBurst int `json:"workQueueBurst,omitempty"`
+	// ClientQPS is the rate of the requests of the controller to the API
+	// server, which the other controllers don't share. It defaults to the
+	// QPS the controller was started with.
+	// +optional
+	ClientQPS float64 `json:"clientQPS,omitempty"`
+	// ClientBurst is the number of requests of the controller which can be
+	// sent to the API server at once. It defaults to the burst the controller
+	// was started with.
+	// +optional
+	ClientBurst int `json:"clientBurst,omitempty"`
+}
+
+// ControllerRateLimitsFor returns the rate limits of the controller of the
+// given kind: its own ones, completed by the ones of all the controllers and
+// by the built-in defaults of the work queue. The client rate limits are left
+// to zero if they aren't configured.
+func (cfg *Defaults) ControllerRateLimitsFor(kind string) ControllerRateLimits {
+	limits := ControllerRateLimits{
+		WorkQueueBaseDelay: metav1.Duration{Duration: DefaultWorkQueueBaseDelay},
+		WorkQueueMaxDelay:  metav1.Duration{Duration: DefaultWorkQueueMaxDelay},
+		WorkQueueQPS:       DefaultWorkQueueQPS,
+		WorkQueueBurst:     DefaultWorkQueueBurst,
+	}
+	for _, key := range []string{ControllerRateLimitsDefaultKey, kind} {
+		overrides, ok := cfg.DefaultControllerRateLimits[key]
+		if !ok {
+			continue
+		}
+		if overrides.WorkQueueBaseDelay.Duration != 0 {
+			limits.WorkQueueBaseDelay = overrides.WorkQueueBaseDelay
+		}
+		if overrides.WorkQueueMaxDelay.Duration != 0 {
+			limits.WorkQueueMaxDelay = overrides.WorkQueueMaxDelay
+		}
+		if overrides.WorkQueueQPS != 0 {
+			limits.WorkQueueQPS = overrides.WorkQueueQPS
+		}
+		if overrides.WorkQueueBurst != 0 {
+			limits.WorkQueueBurst = overrides.WorkQueueBurst
+		}
+		if overrides.ClientQPS != 0 {
+			limits.ClientQPS = overrides.ClientQPS
+		}
+		if overrides.ClientBurst != 0 {
+			limits.ClientBurst = overrides.ClientBurst
+		}
+	}
+	return limits
+}
+
+func validateControllerRateLimits(limitsByKind map[string]ControllerRateLimits) error {
+	for kind, limits := range limitsByKind {
+		switch kind {
+		case ControllerRateLimitsDefaultKey, ControllerRateLimitsTaskRunKey, ControllerRateLimitsPipelineRunKey:
+		default:
+			return fmt.Errorf("unknown controller %q, must be one of %q, %q or %q", kind, ControllerRateLimitsDefaultKey, ControllerRateLimitsTaskRunKey, ControllerRateLimitsPipelineRunKey)
+		}
+		if limits.WorkQueueBaseDelay.Duration < 0 || limits.WorkQueueMaxDelay.Duration < 0 || limits.WorkQueueQPS < 0 || limits.WorkQueueBurst < 0 || limits.ClientQPS < 0 || limits.ClientBurst < 0 {
+			return fmt.Errorf("rate limits of controller %q can't be negative", kind)
+		}
+	}
+	for _, kind := range []string{ControllerRateLimitsTaskRunKey, ControllerRateLimitsPipelineRunKey} {
+		cfg := &Defaults{DefaultControllerRateLimits: limitsByKind}
+		if limits := cfg.ControllerRateLimitsFor(kind); limits.WorkQueueBaseDelay.Duration > limits.WorkQueueMaxDelay.Duration {
+			return fmt.Errorf("work queue base delay of controller %q can't be greater than its max delay", kind)
+		}
+	}
+	return nil
+}
@@ -54,6 +54,12 @@ const (
 	// Default maximum resolution timeout used by the resolution controller before timing out when exceeded
 	DefaultMaximumResolutionTimeout = 1 * time.Minute
 
+	// DefaultMaxResolutionDepth is used when no max resolution depth is specified.
+	DefaultMaxResolutionDepth = 5
+
+	// DefaultMaxTerminationGracePeriodSeconds is used when no max termination grace period is specified.
+	DefaultMaxTerminationGracePeriodSeconds = 3600
+
 	defaultTimeoutMinutesKey                = "default-timeout-minutes"
 	defaultServiceAccountKey                = "default-service-account"
 	defaultManagedByLabelValueKey           = "default-managed-by-label-value"
@@ -67,6 +73,18 @@ const (
 	defaultContainerResourceRequirementsKey = "default-container-resource-requirements"
 	defaultImagePullBackOffTimeout          = "default-imagepullbackoff-timeout"
 	defaultMaximumResolutionTimeout         = "default-maximum-resolution-timeout"
+	defaultFSGroupKey                       = "default-fs-group"
+	defaultInjectedSidecarsKey              = "default-injected-sidecars"
+	defaultInjectedSidecarsByNamespaceKey   = "default-injected-sidecars-by-namespace"
+	defaultHermeticNetworkSidecarKey        = "default-hermetic-network-sidecar"
+	defaultControllerRateLimitsKey          = "default-controller-rate-limits"
+	defaultStepActionResolverKey            = "default-step-action-resolver"
+	defaultResultSanitizationKey            = "default-result-sanitization"
+	defaultInjectedFinallyTasksKey          = "default-injected-finally-tasks"
+	forbidLocalhostProfilesKey              = "forbid-localhost-profiles"
+	defaultBuildMetadataEnvKey              = "default-build-metadata-env"
+	defaultMaxResolutionDepthKey            = "default-max-resolution-depth"
+	maxTerminationGracePeriodSecondsKey     = "max-termination-grace-period-seconds"
 )
 
 // DefaultConfig holds all the default configurations for the config.
@@ -88,6 +106,48 @@ type Defaults struct {
 	DefaultContainerResourceRequirements map[string]corev1.ResourceRequirements
 	DefaultImagePullBackOffTimeout       time.Duration
 	DefaultMaximumResolutionTimeout      time.Duration
+	// DefaultMaxResolutionDepth is the maximum number of nested remote
+	// references resolved to run a PipelineRun, the referenced Pipeline
+	// counting as the first one.
+	DefaultMaxResolutionDepth int
+	// MaxTerminationGracePeriodSeconds is the maximum
+	// terminationGracePeriodSeconds of the pod templates of the runs.
+	MaxTerminationGracePeriodSeconds int64
+	// DefaultFSGroup is the fsGroup set on TaskRun pods whose pod template
+	// doesn't specify one, so that files written by a step running as one
+	// user are readable by later steps running as another.
+	DefaultFSGroup *int64
+	// DefaultInjectedSidecars are the sidecars added to the pod of every
+	// TaskRun, unless DefaultInjectedSidecarsByNamespace overrides them for
+	// the namespace of the TaskRun.
+	DefaultInjectedSidecars            []InjectedSidecar
+	DefaultInjectedSidecarsByNamespace map[string][]InjectedSidecar
+	// DefaultHermeticNetworkSidecar is the sidecar added to the pod of the
+	// TaskRuns run hermetically when the enable-hermetic-hardening feature
+	// flag is set, to deny the network of the whole pod.
+	DefaultHermeticNetworkSidecar *InjectedSidecar
+	// DefaultControllerRateLimits are the rate limits of the work queues and
+	// API clients of the controllers, keyed by the kind of their runs or
+	// "default" for all of them.
+	DefaultControllerRateLimits map[string]ControllerRateLimits
+	// DefaultStepActionResolver is the resolver of the StepActions
+	// referenced by a name and a version rather than an explicit resolver.
+	DefaultStepActionResolver *StepActionResolver
+	// DefaultResultSanitization redacts the secrets leaked into the results
+	// of the TaskRuns. The results aren't sanitized when it is nil.
+	DefaultResultSanitization *ResultSanitization
+	// DefaultInjectedFinallyTasks are the finally tasks appended to the
+	// PipelineSpec of every PipelineRun outside of their exempted namespaces.
+	DefaultInjectedFinallyTasks *InjectedFinallyTasks
+	// ForbidLocalhostProfiles rejects the Localhost seccomp and AppArmor
+	// profiles in the pod templates and the securityContext of the steps and
+	// sidecars, for the runs not to rely on the profiles loaded on the nodes.
+	ForbidLocalhostProfiles bool
+	// DefaultBuildMetadataEnv are the environment variables injected into
+	// the steps and sidecars of the TaskRuns in addition to the built-in
+	// TEKTON_* ones when the enable-build-metadata-env feature flag is set,
+	// keyed by their name. Their values can reference the context variables.
+	DefaultBuildMetadataEnv map[string]string
 }
 
 // GetDefaultsConfigName returns the name of the configmap containing all
@@ -120,7 +180,19 @@ func (cfg *Defaults) Equals(other *Defaults) bool {
 		other.DefaultResolverType == cfg.DefaultResolverType &&
 		other.DefaultImagePullBackOffTimeout == cfg.DefaultImagePullBackOffTimeout &&
 		other.DefaultMaximumResolutionTimeout == cfg.DefaultMaximumResolutionTimeout &&
-		reflect.DeepEqual(other.DefaultForbiddenEnv, cfg.DefaultForbiddenEnv)
+		other.DefaultMaxResolutionDepth == cfg.DefaultMaxResolutionDepth &&
+		other.MaxTerminationGracePeriodSeconds == cfg.MaxTerminationGracePeriodSeconds &&
+		reflect.DeepEqual(other.DefaultFSGroup, cfg.DefaultFSGroup) &&
+		reflect.DeepEqual(other.DefaultInjectedSidecars, cfg.DefaultInjectedSidecars) &&
+		reflect.DeepEqual(other.DefaultInjectedSidecarsByNamespace, cfg.DefaultInjectedSidecarsByNamespace) &&
+		reflect.DeepEqual(other.DefaultHermeticNetworkSidecar, cfg.DefaultHermeticNetworkSidecar) &&
+		reflect.DeepEqual(other.DefaultControllerRateLimits, cfg.DefaultControllerRateLimits) &&
+		reflect.DeepEqual(other.DefaultStepActionResolver, cfg.DefaultStepActionResolver) &&
+		reflect.DeepEqual(other.DefaultResultSanitization, cfg.DefaultResultSanitization) &&
+		reflect.DeepEqual(other.DefaultInjectedFinallyTasks, cfg.DefaultInjectedFinallyTasks) &&
+		reflect.DeepEqual(other.DefaultForbiddenEnv, cfg.DefaultForbiddenEnv) &&
+		other.ForbidLocalhostProfiles == cfg.ForbidLocalhostProfiles &&
+		reflect.DeepEqual(other.DefaultBuildMetadataEnv, cfg.DefaultBuildMetadataEnv)
 }
 
 // NewDefaultsFromMap returns a Config given a map corresponding to a ConfigMap
@@ -134,6 +206,8 @@ func NewDefaultsFromMap(cfgMap map[string]string) (*Defaults, error) {
 		DefaultResolverType:               DefaultResolverTypeValue,
 		DefaultImagePullBackOffTimeout:    DefaultImagePullBackOffTimeout,
 		DefaultMaximumResolutionTimeout:   DefaultMaximumResolutionTimeout,
+		DefaultMaxResolutionDepth:         DefaultMaxResolutionDepth,
+		MaxTerminationGracePeriodSeconds:  DefaultMaxTerminationGracePeriodSeconds,
 	}
 
 	if defaultTimeoutMin, ok := cfgMap[defaultTimeoutMinutesKey]; ok {
@@ -220,6 +294,128 @@ func NewDefaultsFromMap(cfgMap map[string]string) (*Defaults, error) {
 		tc.DefaultMaximumResolutionTimeout = timeout
 	}
 
+	if defaultMaxResolutionDepth, ok := cfgMap[defaultMaxResolutionDepthKey]; ok {
+		depth, err := strconv.ParseInt(defaultMaxResolutionDepth, 10, 0)
+		if err != nil || depth < 1 {
+			return nil, fmt.Errorf("failed parsing default config %q: must be a positive number", defaultMaxResolutionDepthKey)
+		}
+		tc.DefaultMaxResolutionDepth = int(depth)
+	}
+
+	if maxTerminationGracePeriodSeconds, ok := cfgMap[maxTerminationGracePeriodSecondsKey]; ok {
+		seconds, err := strconv.ParseInt(maxTerminationGracePeriodSeconds, 10, 64)
+		if err != nil || seconds < 0 {
+			return nil, fmt.Errorf("failed parsing default config %q: must be a non-negative number", maxTerminationGracePeriodSecondsKey)
+		}
+		tc.MaxTerminationGracePeriodSeconds = seconds
+	}
+
+	if defaultFSGroup, ok := cfgMap[defaultFSGroupKey]; ok && defaultFSGroup != "" {
+		fsGroup, err := strconv.ParseInt(defaultFSGroup, 10, 64)
+		if err != nil || fsGroup < 0 {
+			return nil, fmt.Errorf("failed parsing default config %q", defaultFSGroupKey)
+		}
+		tc.DefaultFSGroup = &fsGroup
+	}
+
+	if injectedSidecars, ok := cfgMap[defaultInjectedSidecarsKey]; ok {
+		var sidecars []InjectedSidecar
+		if err := yamlUnmarshal(injectedSidecars, defaultInjectedSidecarsKey, &sidecars); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %v", injectedSidecars)
+		}
+		if err := validateInjectedSidecars(sidecars); err != nil {
+			return nil, fmt.Errorf("failed parsing default config %q: %w", defaultInjectedSidecarsKey, err)
+		}
+		tc.DefaultInjectedSidecars = sidecars
+	}
+
+	if injectedSidecarsByNamespace, ok := cfgMap[defaultInjectedSidecarsByNamespaceKey]; ok {
+		var sidecarsByNamespace map[string][]InjectedSidecar
+		if err := yamlUnmarshal(injectedSidecarsByNamespace, defaultInjectedSidecarsByNamespaceKey, &sidecarsByNamespace); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %v", injectedSidecarsByNamespace)
+		}
+		for _, sidecars := range sidecarsByNamespace {
+			if err := validateInjectedSidecars(sidecars); err != nil {
+				return nil, fmt.Errorf("failed parsing default config %q: %w", defaultInjectedSidecarsByNamespaceKey, err)
+			}
+		}
+		tc.DefaultInjectedSidecarsByNamespace = sidecarsByNamespace
+	}
+
+	if hermeticNetworkSidecar, ok := cfgMap[defaultHermeticNetworkSidecarKey]; ok {
+		var sidecar InjectedSidecar
+		if err := yamlUnmarshal(hermeticNetworkSidecar, defaultHermeticNetworkSidecarKey, &sidecar); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %v", hermeticNetworkSidecar)
+		}
+		if err := validateInjectedSidecars([]InjectedSidecar{sidecar}); err != nil {
+			return nil, fmt.Errorf("failed parsing default config %q: %w", defaultHermeticNetworkSidecarKey, err)
+		}
+		tc.DefaultHermeticNetworkSidecar = &sidecar
+	}
+
+	if controllerRateLimits, ok := cfgMap[defaultControllerRateLimitsKey]; ok {
+		var limitsByKind map[string]ControllerRateLimits
+		if err := yamlUnmarshal(controllerRateLimits, defaultControllerRateLimitsKey, &limitsByKind); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %v", controllerRateLimits)
+		}
+		if err := validateControllerRateLimits(limitsByKind); err != nil {
+			return nil, fmt.Errorf("failed parsing default config %q: %w", defaultControllerRateLimitsKey, err)
+		}
+		tc.DefaultControllerRateLimits = limitsByKind
+	}
+
+	if stepActionResolver, ok := cfgMap[defaultStepActionResolverKey]; ok {
+		var resolver StepActionResolver
+		if err := yamlUnmarshal(stepActionResolver, defaultStepActionResolverKey, &resolver); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %v", stepActionResolver)
+		}
+		if err := validateStepActionResolver(&resolver); err != nil {
+			return nil, fmt.Errorf("failed parsing default config %q: %w", defaultStepActionResolverKey, err)
+		}
+		tc.DefaultStepActionResolver = &resolver
+	}
+
+	if resultSanitization, ok := cfgMap[defaultResultSanitizationKey]; ok {
+		var sanitization ResultSanitization
+		if err := yamlUnmarshal(resultSanitization, defaultResultSanitizationKey, &sanitization); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %v", resultSanitization)
+		}
+		if err := validateResultSanitization(&sanitization); err != nil {
+			return nil, fmt.Errorf("failed parsing default config %q: %w", defaultResultSanitizationKey, err)
+		}
+		tc.DefaultResultSanitization = &sanitization
+	}
+
+	if injectedFinallyTasks, ok := cfgMap[defaultInjectedFinallyTasksKey]; ok {
+		var injected InjectedFinallyTasks
+		if err := yamlUnmarshal(injectedFinallyTasks, defaultInjectedFinallyTasksKey, &injected); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %v", injectedFinallyTasks)
+		}
+		if err := validateInjectedFinallyTasks(&injected); err != nil {
+			return nil, fmt.Errorf("failed parsing default config %q: %w", defaultInjectedFinallyTasksKey, err)
+		}
+		tc.DefaultInjectedFinallyTasks = &injected
+	}
+
+	if forbidLocalhostProfiles, ok := cfgMap[forbidLocalhostProfilesKey]; ok {
+		forbid, err := strconv.ParseBool(forbidLocalhostProfiles)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing default config %q", forbidLocalhostProfilesKey)
+		}
+		tc.ForbidLocalhostProfiles = forbid
+	}
+
+	if buildMetadataEnv, ok := cfgMap[defaultBuildMetadataEnvKey]; ok {
+		var env map[string]string
+		if err := yamlUnmarshal(buildMetadataEnv, defaultBuildMetadataEnvKey, &env); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %v", buildMetadataEnv)
+		}
+		if err := validateBuildMetadataEnv(env); err != nil {
+			return nil, fmt.Errorf("failed parsing default config %q: %w", defaultBuildMetadataEnvKey, err)
+		}
+		tc.DefaultBuildMetadataEnv = env
+	}
+
 	return &tc, nil
 }
 
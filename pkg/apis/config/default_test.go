@@ -27,6 +27,7 @@ import (
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestNewDefaultsFromConfigMap(t *testing.T) {
@@ -35,6 +36,7 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 		expectedError  bool
 		fileName       string
 	}
+	fsGroup := int64(65532)
 
 	testCases := []testCase{
 		{
@@ -46,6 +48,8 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 				DefaultResolverType:               "git",
 				DefaultImagePullBackOffTimeout:    time.Duration(5) * time.Second,
 				DefaultMaximumResolutionTimeout:   1 * time.Minute,
+				DefaultMaxResolutionDepth:         5,
+				MaxTerminationGracePeriodSeconds:  3600,
 			},
 			fileName: config.GetDefaultsConfigName(),
 		},
@@ -67,6 +71,8 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 				DefaultMaxMatrixCombinationsCount: 256,
 				DefaultImagePullBackOffTimeout:    0,
 				DefaultMaximumResolutionTimeout:   1 * time.Minute,
+				DefaultMaxResolutionDepth:         5,
+				MaxTerminationGracePeriodSeconds:  3600,
 			},
 			fileName: "config-defaults-with-pod-template",
 		},
@@ -91,6 +97,8 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 				DefaultMaxMatrixCombinationsCount: 256,
 				DefaultImagePullBackOffTimeout:    0,
 				DefaultMaximumResolutionTimeout:   1 * time.Minute,
+				DefaultMaxResolutionDepth:         5,
+				MaxTerminationGracePeriodSeconds:  3600,
 			},
 		},
 		{
@@ -104,6 +112,8 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 				DefaultMaxMatrixCombinationsCount: 256,
 				DefaultImagePullBackOffTimeout:    0,
 				DefaultMaximumResolutionTimeout:   1 * time.Minute,
+				DefaultMaxResolutionDepth:         5,
+				MaxTerminationGracePeriodSeconds:  3600,
 			},
 		},
 		{
@@ -120,6 +130,254 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
 				DefaultImagePullBackOffTimeout:    0,
 				DefaultMaximumResolutionTimeout:   1 * time.Minute,
+				DefaultMaxResolutionDepth:         5,
+				MaxTerminationGracePeriodSeconds:  3600,
+			},
+		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-fs-group-err",
+		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-injected-sidecars-err",
+		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-hermetic-network-sidecar-err",
+		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-injected-sidecars",
+			expectedConfig: &config.Defaults{
+				DefaultMaxMatrixCombinationsCount: 256,
+				DefaultTimeoutMinutes:             60,
+				DefaultServiceAccount:             "default",
+				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
+				DefaultImagePullBackOffTimeout:    0,
+				DefaultMaximumResolutionTimeout:   1 * time.Minute,
+				DefaultMaxResolutionDepth:         5,
+				MaxTerminationGracePeriodSeconds:  3600,
+				DefaultInjectedSidecars: []config.InjectedSidecar{{
+					Name:  "log-forwarder",
+					Image: "fluent/fluent-bit",
+					Args:  []string{"-c", "/fluent-bit/etc/fluent-bit.conf"},
+					ComputeResources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("50m")},
+					},
+					Workspaces: []string{"logs"},
+				}},
+				DefaultInjectedSidecarsByNamespace: map[string][]config.InjectedSidecar{
+					"sandbox": {},
+				},
+				DefaultHermeticNetworkSidecar: &config.InjectedSidecar{
+					Name:           "network-deny",
+					Image:          "example.com/network-deny",
+					AwaitReadiness: true,
+				},
+			},
+		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-fs-group",
+			expectedConfig: &config.Defaults{
+				DefaultMaxMatrixCombinationsCount: 256,
+				DefaultTimeoutMinutes:             60,
+				DefaultServiceAccount:             "default",
+				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
+				DefaultImagePullBackOffTimeout:    0,
+				DefaultMaximumResolutionTimeout:   1 * time.Minute,
+				DefaultMaxResolutionDepth:         5,
+				MaxTerminationGracePeriodSeconds:  3600,
+				DefaultFSGroup:                    &fsGroup,
+			},
+		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-forbid-localhost-profiles",
+			expectedConfig: &config.Defaults{
+				DefaultMaxMatrixCombinationsCount: 256,
+				DefaultTimeoutMinutes:             60,
+				DefaultServiceAccount:             "default",
+				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
+				DefaultImagePullBackOffTimeout:    0,
+				DefaultMaximumResolutionTimeout:   1 * time.Minute,
+				DefaultMaxResolutionDepth:         5,
+				MaxTerminationGracePeriodSeconds:  3600,
+				ForbidLocalhostProfiles:           true,
+			},
+		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-forbid-localhost-profiles-err",
+		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-controller-rate-limits-err",
+		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-controller-rate-limits",
+			expectedConfig: &config.Defaults{
+				DefaultMaxMatrixCombinationsCount: 256,
+				DefaultTimeoutMinutes:             60,
+				DefaultServiceAccount:             "default",
+				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
+				DefaultImagePullBackOffTimeout:    0,
+				DefaultMaximumResolutionTimeout:   1 * time.Minute,
+				DefaultMaxResolutionDepth:         5,
+				MaxTerminationGracePeriodSeconds:  3600,
+				DefaultControllerRateLimits: map[string]config.ControllerRateLimits{
+					"default": {
+						WorkQueueMaxDelay: metav1.Duration{Duration: 5 * time.Minute},
+						WorkQueueQPS:      5,
+					},
+					"taskrun": {
+						WorkQueueBurst: 20,
+						ClientQPS:      25,
+						ClientBurst:    50,
+					},
+				},
+			},
+		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-step-action-resolver-err",
+		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-step-action-resolver",
+			expectedConfig: &config.Defaults{
+				DefaultMaxMatrixCombinationsCount: 256,
+				DefaultTimeoutMinutes:             60,
+				DefaultServiceAccount:             "default",
+				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
+				DefaultImagePullBackOffTimeout:    0,
+				DefaultMaximumResolutionTimeout:   1 * time.Minute,
+				DefaultMaxResolutionDepth:         5,
+				MaxTerminationGracePeriodSeconds:  3600,
+				DefaultStepActionResolver: &config.StepActionResolver{
+					Resolver: "hub",
+					Params: map[string]string{
+						"catalog": "tekton-catalog-stepactions",
+						"kind":    "stepaction",
+						"name":    "$(ref.name)",
+						"version": "$(ref.version)",
+					},
+				},
+			},
+		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-result-sanitization-err",
+		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-result-sanitization",
+			expectedConfig: &config.Defaults{
+				DefaultMaxMatrixCombinationsCount: 256,
+				DefaultTimeoutMinutes:             60,
+				DefaultServiceAccount:             "default",
+				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
+				DefaultImagePullBackOffTimeout:    0,
+				DefaultMaximumResolutionTimeout:   1 * time.Minute,
+				DefaultMaxResolutionDepth:         5,
+				MaxTerminationGracePeriodSeconds:  3600,
+				DefaultResultSanitization: &config.ResultSanitization{
+					Patterns:         []string{"ghp_[A-Za-z0-9]{36}", "AKIA[0-9A-Z]{16}"},
+					EntropyThreshold: 4.5,
+					MinEntropyLength: 24,
+				},
+			},
+		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-max-resolution-depth-err",
+		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-max-resolution-depth",
+			expectedConfig: &config.Defaults{
+				DefaultMaxMatrixCombinationsCount: 256,
+				DefaultTimeoutMinutes:             60,
+				DefaultServiceAccount:             "default",
+				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
+				DefaultImagePullBackOffTimeout:    0,
+				DefaultMaximumResolutionTimeout:   1 * time.Minute,
+				DefaultMaxResolutionDepth:         2,
+				MaxTerminationGracePeriodSeconds:  3600,
+			},
+		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-max-termination-grace-period-err",
+		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-max-termination-grace-period",
+			expectedConfig: &config.Defaults{
+				DefaultMaxMatrixCombinationsCount: 256,
+				DefaultTimeoutMinutes:             60,
+				DefaultServiceAccount:             "default",
+				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
+				DefaultImagePullBackOffTimeout:    0,
+				DefaultMaximumResolutionTimeout:   1 * time.Minute,
+				DefaultMaxResolutionDepth:         5,
+				MaxTerminationGracePeriodSeconds:  600,
+			},
+		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-build-metadata-env-err",
+		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-build-metadata-env",
+			expectedConfig: &config.Defaults{
+				DefaultMaxMatrixCombinationsCount: 256,
+				DefaultTimeoutMinutes:             60,
+				DefaultServiceAccount:             "default",
+				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
+				DefaultImagePullBackOffTimeout:    0,
+				DefaultMaximumResolutionTimeout:   1 * time.Minute,
+				DefaultMaxResolutionDepth:         5,
+				MaxTerminationGracePeriodSeconds:  3600,
+				DefaultBuildMetadataEnv: map[string]string{
+					"BUILD_ID":   "$(context.pipelineRun.uid)",
+					"BUILD_NAME": "$(context.pipeline.name)/$(context.pipelineRun.name)",
+				},
+			},
+		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-injected-finally-tasks-err",
+		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-injected-finally-tasks",
+			expectedConfig: &config.Defaults{
+				DefaultMaxMatrixCombinationsCount: 256,
+				DefaultTimeoutMinutes:             60,
+				DefaultServiceAccount:             "default",
+				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
+				DefaultImagePullBackOffTimeout:    0,
+				DefaultMaximumResolutionTimeout:   1 * time.Minute,
+				DefaultMaxResolutionDepth:         5,
+				MaxTerminationGracePeriodSeconds:  3600,
+				DefaultInjectedFinallyTasks: &config.InjectedFinallyTasks{
+					Tasks: []config.InjectedFinallyTask{{
+						Name: "audit-report",
+						TaskRef: config.InjectedTaskRef{
+							Resolver: "cluster",
+							Params:   map[string]string{"kind": "task", "name": "audit-report", "namespace": "compliance"},
+						},
+						Params: map[string]string{
+							"pipelinerun": "$(context.pipelineRun.name)",
+							"namespace":   "$(context.pipelineRun.namespace)",
+						},
+					}},
+					ExemptNamespaces: []string{"kube-system"},
+				},
 			},
 		},
 		{
@@ -133,6 +391,8 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 				DefaultForbiddenEnv:               []string{"TEKTON_POWER_MODE", "TEST_ENV", "TEST_TEKTON"},
 				DefaultImagePullBackOffTimeout:    time.Duration(15) * time.Second,
 				DefaultMaximumResolutionTimeout:   1 * time.Minute,
+				DefaultMaxResolutionDepth:         5,
+				MaxTerminationGracePeriodSeconds:  3600,
 			},
 		},
 		{
@@ -146,6 +406,8 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 				DefaultContainerResourceRequirements: map[string]corev1.ResourceRequirements{},
 				DefaultImagePullBackOffTimeout:       0,
 				DefaultMaximumResolutionTimeout:      1 * time.Minute,
+				DefaultMaxResolutionDepth:            5,
+				MaxTerminationGracePeriodSeconds:     3600,
 			},
 		},
 		{
@@ -162,6 +424,8 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 				DefaultMaxMatrixCombinationsCount: 256,
 				DefaultImagePullBackOffTimeout:    0,
 				DefaultMaximumResolutionTimeout:   1 * time.Minute,
+				DefaultMaxResolutionDepth:         5,
+				MaxTerminationGracePeriodSeconds:  3600,
 				DefaultContainerResourceRequirements: map[string]corev1.ResourceRequirements{
 					config.ResourceRequirementDefaultContainerKey: {
 						Requests: corev1.ResourceList{
@@ -219,10 +483,73 @@ func TestNewDefaultsFromEmptyConfigMap(t *testing.T) {
 		DefaultMaxMatrixCombinationsCount: 256,
 		DefaultImagePullBackOffTimeout:    0,
 		DefaultMaximumResolutionTimeout:   1 * time.Minute,
+		DefaultMaxResolutionDepth:         5,
+		MaxTerminationGracePeriodSeconds:  3600,
 	}
 	verifyConfigFileWithExpectedConfig(t, DefaultsConfigEmptyName, expectedConfig)
 }
 
+func TestControllerRateLimitsFor(t *testing.T) {
+	defaults := &config.Defaults{
+		DefaultControllerRateLimits: map[string]config.ControllerRateLimits{
+			"default": {
+				WorkQueueMaxDelay: metav1.Duration{Duration: 5 * time.Minute},
+				WorkQueueQPS:      5,
+			},
+			"taskrun": {
+				WorkQueueBurst: 20,
+				ClientQPS:      25,
+				ClientBurst:    50,
+			},
+		},
+	}
+	for _, tc := range []struct {
+		name     string
+		defaults *config.Defaults
+		kind     string
+		want     config.ControllerRateLimits
+	}{{
+		name:     "no rate limits",
+		defaults: &config.Defaults{},
+		kind:     "taskrun",
+		want: config.ControllerRateLimits{
+			WorkQueueBaseDelay: metav1.Duration{Duration: config.DefaultWorkQueueBaseDelay},
+			WorkQueueMaxDelay:  metav1.Duration{Duration: config.DefaultWorkQueueMaxDelay},
+			WorkQueueQPS:       config.DefaultWorkQueueQPS,
+			WorkQueueBurst:     config.DefaultWorkQueueBurst,
+		},
+	}, {
+		name:     "rate limits of all the controllers",
+		defaults: defaults,
+		kind:     "pipelinerun",
+		want: config.ControllerRateLimits{
+			WorkQueueBaseDelay: metav1.Duration{Duration: config.DefaultWorkQueueBaseDelay},
+			WorkQueueMaxDelay:  metav1.Duration{Duration: 5 * time.Minute},
+			WorkQueueQPS:       5,
+			WorkQueueBurst:     config.DefaultWorkQueueBurst,
+		},
+	}, {
+		name:     "rate limits of the controller",
+		defaults: defaults,
+		kind:     "taskrun",
+		want: config.ControllerRateLimits{
+			WorkQueueBaseDelay: metav1.Duration{Duration: config.DefaultWorkQueueBaseDelay},
+			WorkQueueMaxDelay:  metav1.Duration{Duration: 5 * time.Minute},
+			WorkQueueQPS:       5,
+			WorkQueueBurst:     20,
+			ClientQPS:          25,
+			ClientBurst:        50,
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.defaults.ControllerRateLimitsFor(tc.kind)
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("unexpected rate limits %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestEquals(t *testing.T) {
 	testCases := []struct {
 		name     string
@@ -91,6 +91,9 @@ const (
 	DefaultSetSecurityContext = false
 	// DefaultSetSecurityContextReadOnlyRootFilesystem is the default value for "set-security-context-read-only-root-filesystem"
 	DefaultSetSecurityContextReadOnlyRootFilesystem = false
+	// DefaultEntrypointUmask is the default value for "entrypoint-umask", which
+	// leaves the umask of the step containers unchanged.
+	DefaultEntrypointUmask = ""
 	// DefaultCoschedule is the default value for coschedule
 	DefaultCoschedule = CoscheduleWorkspaces
 	// KeepPodOnCancel is the flag used to enable cancelling a pod using the entrypoint, and keep pod on cancel
@@ -107,6 +110,68 @@ const (
 	EnableKubernetesSidecar = "enable-kubernetes-sidecar"
 	// DefaultEnableKubernetesSidecar is the default value for EnableKubernetesSidecar
 	DefaultEnableKubernetesSidecar = false
+	// EnableHermeticHardening is the flag to harden the pods of the TaskRuns
+	// run hermetically and report the network failures of their steps
+	EnableHermeticHardening = "enable-hermetic-hardening"
+	// DefaultEnableHermeticHardening is the default value for EnableHermeticHardening
+	DefaultEnableHermeticHardening = false
+	// EnableResultSchemaValidation is the flag to validate the object results
+	// against the properties they declare
+	EnableResultSchemaValidation = "enable-result-schema-validation"
+	// DefaultEnableResultSchemaValidation is the default value for EnableResultSchemaValidation
+	DefaultEnableResultSchemaValidation = false
+	// StartFinallyOnCancel is the flag to start the finally tasks of the
+	// gracefully cancelled PipelineRuns without waiting for their running
+	// tasks to stop
+	StartFinallyOnCancel = "start-finally-on-cancel"
+	// DefaultStartFinallyOnCancel is the default value for StartFinallyOnCancel
+	DefaultStartFinallyOnCancel = false
+	// EnableInterfaceDigest is the flag to annotate the Tasks, Pipelines and
+	// StepActions with the digest of their interface
+	EnableInterfaceDigest = "enable-interface-digest"
+	// DefaultEnableInterfaceDigest is the default value for EnableInterfaceDigest
+	DefaultEnableInterfaceDigest = false
+	// EnableTaskRunPause is the flag to gate the steps of the TaskRuns so that
+	// they can be paused and resumed
+	EnableTaskRunPause = "enable-taskrun-pause"
+	// DefaultEnableTaskRunPause is the default value for EnableTaskRunPause
+	DefaultEnableTaskRunPause = false
+	// ReuseWorkspacePVCOnRetry is the flag to reuse the PVCs created from the
+	// volumeClaimTemplates of the TaskRuns across their retries
+	ReuseWorkspacePVCOnRetry = "reuse-workspace-pvc-on-retry"
+	// DefaultReuseWorkspacePVCOnRetry is the default value for ReuseWorkspacePVCOnRetry
+	DefaultReuseWorkspacePVCOnRetry = true
+	// RecordStepImageSignatures is the flag to record in the status of the
+	// TaskRuns whether the images of their steps have a cosign signature
+	RecordStepImageSignatures = "record-step-image-signatures"
+	// DefaultRecordStepImageSignatures is the default value for RecordStepImageSignatures
+	DefaultRecordStepImageSignatures = false
+	// EnableBuildMetadataEnv is the flag to inject the TEKTON_* environment
+	// variables describing the run into the steps and sidecars of the TaskRuns
+	EnableBuildMetadataEnv = "enable-build-metadata-env"
+	// DefaultEnableBuildMetadataEnv is the default value for EnableBuildMetadataEnv
+	DefaultEnableBuildMetadataEnv = false
+	// RecordResolvedParams is the flag to record the resolved values of the
+	// params of the TaskRuns and CustomRuns of the PipelineRuns in an annotation
+	RecordResolvedParams = "record-resolved-params"
+	// DefaultRecordResolvedParams is the default value for RecordResolvedParams
+	DefaultRecordResolvedParams = false
+	// EnableWorkspaceBoundValidation is the flag to reject the Pipelines using
+	// $(workspaces.<name>.bound) for their required workspaces, rather than
+	// only warn about them
+	EnableWorkspaceBoundValidation = "enable-workspace-bound-validation"
+	// DefaultEnableWorkspaceBoundValidation is the default value for EnableWorkspaceBoundValidation
+	DefaultEnableWorkspaceBoundValidation = false
+	// PinStepImagesDisabled is the value used for "pin-step-images" to run the images of the Steps as they are referenced
+	PinStepImagesDisabled = "disabled"
+	// PinStepImagesFail is the value used for "pin-step-images" to pin the images of the Steps referenced by tag to
+	// their digests, failing the TaskRun when an image can't be resolved
+	PinStepImagesFail = "fail"
+	// PinStepImagesProceed is the value used for "pin-step-images" to pin the images of the Steps referenced by tag to
+	// their digests, running the images referenced by tag when they can't be resolved
+	PinStepImagesProceed = "proceed"
+	// DefaultPinStepImages is the default value for "pin-step-images"
+	DefaultPinStepImages = PinStepImagesDisabled
 	// EnableStepActions is the flag to enable step actions (no-op since it's stable)
 	EnableStepActions = "enable-step-actions"
 
@@ -130,6 +195,8 @@ const (
 	setSecurityContextKey                       = "set-security-context"
 	setSecurityContextReadOnlyRootFilesystemKey = "set-security-context-read-only-root-filesystem"
 	coscheduleKey                               = "coschedule"
+	entrypointUmaskKey                          = "entrypoint-umask"
+	pinStepImagesKey                            = "pin-step-images"
 )
 
 // DefaultFeatureFlags holds all the default configurations for the feature flags configmap.
@@ -204,6 +271,66 @@ type FeatureFlags struct {
 	DisableInlineSpec           string `json:"disableInlineSpec,omitempty"`
 	EnableConciseResolverSyntax bool   `json:"enableConciseResolverSyntax,omitempty"`
 	EnableKubernetesSidecar     bool   `json:"enableKubernetesSidecar,omitempty"`
+	// EntrypointUmask is the octal umask applied by the entrypoint before
+	// running the step, e.g. "0002" for result and step files to be group
+	// writable. The umask of the step is left unchanged when empty.
+	EntrypointUmask string `json:"entrypointUmask,omitempty"`
+	// EnableHermeticHardening denies the network of the pods of the TaskRuns
+	// run hermetically beyond the network namespace of their steps, and
+	// makes the entrypoint report the network failures of the steps.
+	EnableHermeticHardening bool `json:"enableHermeticHardening,omitempty"`
+	// EnableResultSchemaValidation fails the TaskRuns and PipelineRuns whose
+	// object results miss declared properties or have values of the wrong
+	// type, instead of dropping the invalid results.
+	EnableResultSchemaValidation bool `json:"enableResultSchemaValidation,omitempty"`
+	// StartFinallyOnCancel starts the finally tasks of the PipelineRuns
+	// cancelled with "CancelledRunFinally" as soon as their running tasks are
+	// signalled, instead of once they stopped. The PipelineRuns still finish
+	// once all their tasks are done.
+	StartFinallyOnCancel bool `json:"startFinallyOnCancel,omitempty"`
+	// EnableInterfaceDigest annotates the Tasks, Pipelines and StepActions
+	// with the digest of their params, results and workspaces when they are
+	// created or updated, so that the changes of their interface can be
+	// detected without comparing their specs.
+	EnableInterfaceDigest bool `json:"enableInterfaceDigest,omitempty"`
+	// EnableTaskRunPause gates the start of every step of the pods of the
+	// TaskRuns on the pause annotation of their pod, so that the TaskRuns can
+	// be paused with the "TaskRunPause" spec status and resumed with the
+	// "TaskRunResume" one.
+	EnableTaskRunPause bool `json:"enableTaskRunPause,omitempty"`
+	// ReuseWorkspacePVCOnRetry mounts the PVCs created from the
+	// volumeClaimTemplates of a TaskRun in all its retries, so that the data
+	// produced by a failed attempt is available to the next one. When
+	// disabled, every attempt gets new PVCs and the PVCs of the failed
+	// attempts are deleted.
+	ReuseWorkspacePVCOnRetry bool `json:"reuseWorkspacePVCOnRetry,omitempty"`
+	// PinStepImages is the feature flag for "pin-step-images", which can be
+	// set to "disabled", "fail" and "proceed". When not disabled, the images
+	// of the Steps referenced by tag are pinned to their digests when the Pod
+	// of a TaskRun is first created and the pinned digests are reused by its
+	// retries. "fail" fails the TaskRun when an image can't be resolved while
+	// "proceed" runs the image referenced by tag.
+	PinStepImages string `json:"pinStepImages,omitempty"`
+	// RecordStepImageSignatures looks up whether the images of the steps
+	// have a cosign signature when the Pod of a TaskRun is created, and
+	// records it with the identity of the keyless signers in the status of
+	// the steps. Failing to look up a signature never fails the TaskRun.
+	RecordStepImageSignatures bool `json:"recordStepImageSignatures,omitempty"`
+	// EnableBuildMetadataEnv injects the TEKTON_* environment variables
+	// describing the run, like the names of the TaskRun and of its
+	// PipelineRun, into the steps and sidecars of the TaskRuns. The variables
+	// defined by the users are never overridden.
+	EnableBuildMetadataEnv bool `json:"enableBuildMetadataEnv,omitempty"`
+	// RecordResolvedParams records the values of the params of the TaskRuns
+	// and CustomRuns of the PipelineRuns, once the defaults, the params of
+	// the PipelineRuns, the results and the matrix combinations are applied,
+	// in an annotation of the runs.
+	RecordResolvedParams bool `json:"recordResolvedParams,omitempty"`
+	// EnableWorkspaceBoundValidation rejects the Pipelines using
+	// $(workspaces.<name>.bound) in the params and when expressions of their
+	// tasks for a workspace which isn't optional, and so is always bound. They
+	// are only warned about when it is false.
+	EnableWorkspaceBoundValidation bool `json:"enableWorkspaceBoundValidation,omitempty"`
 }
 
 // GetFeatureFlagsConfigName returns the name of the configmap containing all
@@ -308,6 +435,42 @@ func NewFeatureFlagsFromMap(cfgMap map[string]string) (*FeatureFlags, error) {
 	if err := setFeature(EnableKubernetesSidecar, DefaultEnableKubernetesSidecar, &tc.EnableKubernetesSidecar); err != nil {
 		return nil, err
 	}
+	if err := setEntrypointUmask(cfgMap, DefaultEntrypointUmask, &tc.EntrypointUmask); err != nil {
+		return nil, err
+	}
+	if err := setFeature(EnableHermeticHardening, DefaultEnableHermeticHardening, &tc.EnableHermeticHardening); err != nil {
+		return nil, err
+	}
+	if err := setFeature(EnableResultSchemaValidation, DefaultEnableResultSchemaValidation, &tc.EnableResultSchemaValidation); err != nil {
+		return nil, err
+	}
+	if err := setFeature(StartFinallyOnCancel, DefaultStartFinallyOnCancel, &tc.StartFinallyOnCancel); err != nil {
+		return nil, err
+	}
+	if err := setFeature(EnableInterfaceDigest, DefaultEnableInterfaceDigest, &tc.EnableInterfaceDigest); err != nil {
+		return nil, err
+	}
+	if err := setFeature(EnableTaskRunPause, DefaultEnableTaskRunPause, &tc.EnableTaskRunPause); err != nil {
+		return nil, err
+	}
+	if err := setFeature(ReuseWorkspacePVCOnRetry, DefaultReuseWorkspacePVCOnRetry, &tc.ReuseWorkspacePVCOnRetry); err != nil {
+		return nil, err
+	}
+	if err := setPinStepImages(cfgMap, DefaultPinStepImages, &tc.PinStepImages); err != nil {
+		return nil, err
+	}
+	if err := setFeature(RecordStepImageSignatures, DefaultRecordStepImageSignatures, &tc.RecordStepImageSignatures); err != nil {
+		return nil, err
+	}
+	if err := setFeature(EnableBuildMetadataEnv, DefaultEnableBuildMetadataEnv, &tc.EnableBuildMetadataEnv); err != nil {
+		return nil, err
+	}
+	if err := setFeature(RecordResolvedParams, DefaultRecordResolvedParams, &tc.RecordResolvedParams); err != nil {
+		return nil, err
+	}
+	if err := setFeature(EnableWorkspaceBoundValidation, DefaultEnableWorkspaceBoundValidation, &tc.EnableWorkspaceBoundValidation); err != nil {
+		return nil, err
+	}
 
 	return &tc, nil
 }
@@ -407,6 +570,38 @@ func setMaxResultSize(cfgMap map[string]string, defaultValue int, feature *int)
 	return nil
 }
 
+// setEntrypointUmask sets the "entrypoint-umask" flag based on the content of a given map.
+// If the value isn't an octal permission mask then an error is returned.
+func setEntrypointUmask(cfgMap map[string]string, defaultValue string, feature *string) error {
+	value := defaultValue
+	if cfg, ok := cfgMap[entrypointUmaskKey]; ok {
+		value = strings.TrimSpace(cfg)
+	}
+	if value != "" {
+		if v, err := strconv.ParseUint(value, 8, 32); err != nil || v > 0o777 {
+			return fmt.Errorf("invalid value for feature flag %q: %q", entrypointUmaskKey, value)
+		}
+	}
+	*feature = value
+	return nil
+}
+
+// setPinStepImages sets the "pin-step-images" flag based on the content of a given map.
+// If the value is invalid then an error is returned.
+func setPinStepImages(cfgMap map[string]string, defaultValue string, feature *string) error {
+	value := defaultValue
+	if cfg, ok := cfgMap[pinStepImagesKey]; ok {
+		value = strings.ToLower(cfg)
+	}
+	switch value {
+	case PinStepImagesDisabled, PinStepImagesFail, PinStepImagesProceed:
+		*feature = value
+	default:
+		return fmt.Errorf("invalid value for feature flag %q: %q", pinStepImagesKey, value)
+	}
+	return nil
+}
+
 // setVerificationNoMatchPolicy sets the "trusted-resources-verification-no-match-policy" flag based on the content of a given map.
 // If the value is invalid or missing then an error is returned.
 func setVerificationNoMatchPolicy(cfgMap map[string]string, defaultValue string, feature *string) error {
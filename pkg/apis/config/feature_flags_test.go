@@ -41,6 +41,7 @@ func TestNewFeatureFlagsFromConfigMap(t *testing.T) {
 				RequireGitSSHSecretKnownHosts:    false,
 				DisableCredsInit:                 config.DefaultDisableCredsInit,
 				AwaitSidecarReadiness:            config.DefaultAwaitSidecarReadiness,
+				ReuseWorkspacePVCOnRetry:         config.DefaultReuseWorkspacePVCOnRetry,
 				EnableAPIFields:                  config.DefaultEnableAPIFields,
 				SendCloudEventsForRuns:           config.DefaultSendCloudEventsForRuns,
 				VerificationNoMatchPolicy:        config.DefaultNoMatchPolicyConfig,
@@ -49,6 +50,7 @@ func TestNewFeatureFlagsFromConfigMap(t *testing.T) {
 				MaxResultSize:                    config.DefaultMaxResultSize,
 				SetSecurityContext:               config.DefaultSetSecurityContext,
 				Coschedule:                       config.DefaultCoschedule,
+				PinStepImages:                    config.DefaultPinStepImages,
 				EnforceNonfalsifiability:         config.DefaultEnforceNonfalsifiability,
 				EnableKeepPodOnCancel:            config.DefaultEnableKeepPodOnCancel.Enabled,
 				EnableCELInWhenExpression:        config.DefaultEnableCELInWhenExpression.Enabled,
@@ -80,6 +82,18 @@ func TestNewFeatureFlagsFromConfigMap(t *testing.T) {
 				DisableInlineSpec:                        "pipeline,pipelinerun,taskrun",
 				EnableConciseResolverSyntax:              true,
 				EnableKubernetesSidecar:                  true,
+				EntrypointUmask:                          "0002",
+				EnableHermeticHardening:                  true,
+				EnableResultSchemaValidation:             true,
+				StartFinallyOnCancel:                     true,
+				EnableInterfaceDigest:                    true,
+				EnableTaskRunPause:                       true,
+				ReuseWorkspacePVCOnRetry:                 false,
+				PinStepImages:                            config.PinStepImagesFail,
+				RecordStepImageSignatures:                true,
+				EnableBuildMetadataEnv:                   true,
+				RecordResolvedParams:                     true,
+				EnableWorkspaceBoundValidation:           true,
 			},
 			fileName: "feature-flags-all-flags-set",
 		},
@@ -92,6 +106,7 @@ func TestNewFeatureFlagsFromConfigMap(t *testing.T) {
 				DisableCredsInit:                 config.DefaultDisableCredsInit,
 				RunningInEnvWithInjectedSidecars: config.DefaultRunningInEnvWithInjectedSidecars,
 				AwaitSidecarReadiness:            config.DefaultAwaitSidecarReadiness,
+				ReuseWorkspacePVCOnRetry:         config.DefaultReuseWorkspacePVCOnRetry,
 				RequireGitSSHSecretKnownHosts:    config.DefaultRequireGitSSHSecretKnownHosts,
 				SendCloudEventsForRuns:           config.DefaultSendCloudEventsForRuns,
 				VerificationNoMatchPolicy:        config.DefaultNoMatchPolicyConfig,
@@ -100,6 +115,7 @@ func TestNewFeatureFlagsFromConfigMap(t *testing.T) {
 				MaxResultSize:                    config.DefaultMaxResultSize,
 				SetSecurityContext:               config.DefaultSetSecurityContext,
 				Coschedule:                       config.DefaultCoschedule,
+				PinStepImages:                    config.DefaultPinStepImages,
 				EnableKeepPodOnCancel:            config.DefaultEnableKeepPodOnCancel.Enabled,
 				EnableCELInWhenExpression:        config.DefaultEnableCELInWhenExpression.Enabled,
 				EnableParamEnum:                  config.DefaultEnableParamEnum.Enabled,
@@ -115,6 +131,7 @@ func TestNewFeatureFlagsFromConfigMap(t *testing.T) {
 				DisableCredsInit:                 config.DefaultDisableCredsInit,
 				RunningInEnvWithInjectedSidecars: config.DefaultRunningInEnvWithInjectedSidecars,
 				AwaitSidecarReadiness:            config.DefaultAwaitSidecarReadiness,
+				ReuseWorkspacePVCOnRetry:         config.DefaultReuseWorkspacePVCOnRetry,
 				RequireGitSSHSecretKnownHosts:    config.DefaultRequireGitSSHSecretKnownHosts,
 				SendCloudEventsForRuns:           config.DefaultSendCloudEventsForRuns,
 				VerificationNoMatchPolicy:        config.DefaultNoMatchPolicyConfig,
@@ -123,6 +140,7 @@ func TestNewFeatureFlagsFromConfigMap(t *testing.T) {
 				MaxResultSize:                    config.DefaultMaxResultSize,
 				SetSecurityContext:               config.DefaultSetSecurityContext,
 				Coschedule:                       config.DefaultCoschedule,
+				PinStepImages:                    config.DefaultPinStepImages,
 				EnableParamEnum:                  config.DefaultEnableParamEnum.Enabled,
 				DisableInlineSpec:                config.DefaultDisableInlineSpec,
 			},
@@ -135,6 +153,7 @@ func TestNewFeatureFlagsFromConfigMap(t *testing.T) {
 				DisableCredsInit:                 config.DefaultDisableCredsInit,
 				RunningInEnvWithInjectedSidecars: config.DefaultRunningInEnvWithInjectedSidecars,
 				AwaitSidecarReadiness:            config.DefaultAwaitSidecarReadiness,
+				ReuseWorkspacePVCOnRetry:         config.DefaultReuseWorkspacePVCOnRetry,
 				RequireGitSSHSecretKnownHosts:    config.DefaultRequireGitSSHSecretKnownHosts,
 				SendCloudEventsForRuns:           config.DefaultSendCloudEventsForRuns,
 				VerificationNoMatchPolicy:        config.DefaultNoMatchPolicyConfig,
@@ -143,6 +162,7 @@ func TestNewFeatureFlagsFromConfigMap(t *testing.T) {
 				MaxResultSize:                    config.DefaultMaxResultSize,
 				SetSecurityContext:               config.DefaultSetSecurityContext,
 				Coschedule:                       config.DefaultCoschedule,
+				PinStepImages:                    config.DefaultPinStepImages,
 				EnableParamEnum:                  config.DefaultEnableParamEnum.Enabled,
 				DisableInlineSpec:                config.DefaultDisableInlineSpec,
 			},
@@ -155,11 +175,13 @@ func TestNewFeatureFlagsFromConfigMap(t *testing.T) {
 				VerificationNoMatchPolicy:        config.DefaultNoMatchPolicyConfig,
 				RunningInEnvWithInjectedSidecars: config.DefaultRunningInEnvWithInjectedSidecars,
 				AwaitSidecarReadiness:            config.DefaultAwaitSidecarReadiness,
+				ReuseWorkspacePVCOnRetry:         config.DefaultReuseWorkspacePVCOnRetry,
 				EnableProvenanceInStatus:         config.DefaultEnableProvenanceInStatus,
 				ResultExtractionMethod:           config.DefaultResultExtractionMethod,
 				MaxResultSize:                    config.DefaultMaxResultSize,
 				SetSecurityContext:               config.DefaultSetSecurityContext,
 				Coschedule:                       config.DefaultCoschedule,
+				PinStepImages:                    config.DefaultPinStepImages,
 				EnableKeepPodOnCancel:            config.DefaultEnableKeepPodOnCancel.Enabled,
 				EnableCELInWhenExpression:        config.DefaultEnableCELInWhenExpression.Enabled,
 				EnableParamEnum:                  config.DefaultEnableParamEnum.Enabled,
@@ -174,11 +196,13 @@ func TestNewFeatureFlagsFromConfigMap(t *testing.T) {
 				VerificationNoMatchPolicy:        config.DefaultNoMatchPolicyConfig,
 				RunningInEnvWithInjectedSidecars: config.DefaultRunningInEnvWithInjectedSidecars,
 				AwaitSidecarReadiness:            config.DefaultAwaitSidecarReadiness,
+				ReuseWorkspacePVCOnRetry:         config.DefaultReuseWorkspacePVCOnRetry,
 				EnableProvenanceInStatus:         config.DefaultEnableProvenanceInStatus,
 				ResultExtractionMethod:           config.ResultExtractionMethodSidecarLogs,
 				MaxResultSize:                    8192,
 				SetSecurityContext:               config.DefaultSetSecurityContext,
 				Coschedule:                       config.DefaultCoschedule,
+				PinStepImages:                    config.DefaultPinStepImages,
 				EnableKeepPodOnCancel:            config.DefaultEnableKeepPodOnCancel.Enabled,
 				EnableCELInWhenExpression:        config.DefaultEnableCELInWhenExpression.Enabled,
 				EnableParamEnum:                  config.DefaultEnableParamEnum.Enabled,
@@ -203,6 +227,7 @@ func TestNewFeatureFlagsFromEmptyConfigMap(t *testing.T) {
 		DisableCredsInit:                 config.DefaultDisableCredsInit,
 		RunningInEnvWithInjectedSidecars: config.DefaultRunningInEnvWithInjectedSidecars,
 		AwaitSidecarReadiness:            config.DefaultAwaitSidecarReadiness,
+		ReuseWorkspacePVCOnRetry:         config.DefaultReuseWorkspacePVCOnRetry,
 		RequireGitSSHSecretKnownHosts:    config.DefaultRequireGitSSHSecretKnownHosts,
 		EnableAPIFields:                  config.DefaultEnableAPIFields,
 		SendCloudEventsForRuns:           config.DefaultSendCloudEventsForRuns,
@@ -213,6 +238,7 @@ func TestNewFeatureFlagsFromEmptyConfigMap(t *testing.T) {
 		MaxResultSize:                    config.DefaultMaxResultSize,
 		SetSecurityContext:               config.DefaultSetSecurityContext,
 		Coschedule:                       config.DefaultCoschedule,
+		PinStepImages:                    config.DefaultPinStepImages,
 		EnableKeepPodOnCancel:            config.DefaultEnableKeepPodOnCancel.Enabled,
 		EnableCELInWhenExpression:        config.DefaultEnableCELInWhenExpression.Enabled,
 		EnableParamEnum:                  config.DefaultEnableParamEnum.Enabled,
@@ -264,6 +290,12 @@ func TestNewFeatureFlagsConfigMapErrors(t *testing.T) {
 	}, {
 		fileName: "feature-flags-invalid-results-from",
 		want:     `invalid value for feature flag "results-from": "im-not-a-valid-results-from"`,
+	}, {
+		fileName: "feature-flags-invalid-entrypoint-umask",
+		want:     `invalid value for feature flag "entrypoint-umask": "0999"`,
+	}, {
+		fileName: "feature-flags-invalid-pin-step-images",
+		want:     `invalid value for feature flag "pin-step-images": "always"`,
 	}, {
 		fileName: "feature-flags-invalid-max-result-size-too-large",
 		want:     `invalid value for feature flag "results-from": "10000000000000". This is exceeding the CRD limit`,
@@ -297,6 +329,27 @@ func TestNewFeatureFlagsConfigMapErrors(t *testing.T) {
 	}, {
 		fileName: "feature-flags-invalid-enable-kubernetes-sidecar",
 		want:     `failed parsing feature flags config "invalid": strconv.ParseBool: parsing "invalid": invalid syntax`,
+	}, {
+		fileName: "feature-flags-invalid-enable-hermetic-hardening",
+		want:     `failed parsing feature flags config "invalid": strconv.ParseBool: parsing "invalid": invalid syntax`,
+	}, {
+		fileName: "feature-flags-invalid-enable-result-schema-validation",
+		want:     `failed parsing feature flags config "invalid": strconv.ParseBool: parsing "invalid": invalid syntax`,
+	}, {
+		fileName: "feature-flags-invalid-start-finally-on-cancel",
+		want:     `failed parsing feature flags config "invalid": strconv.ParseBool: parsing "invalid": invalid syntax`,
+	}, {
+		fileName: "feature-flags-invalid-enable-interface-digest",
+		want:     `failed parsing feature flags config "invalid": strconv.ParseBool: parsing "invalid": invalid syntax`,
+	}, {
+		fileName: "feature-flags-invalid-enable-taskrun-pause",
+		want:     `failed parsing feature flags config "invalid": strconv.ParseBool: parsing "invalid": invalid syntax`,
+	}, {
+		fileName: "feature-flags-invalid-reuse-workspace-pvc-on-retry",
+		want:     `failed parsing feature flags config "invalid": strconv.ParseBool: parsing "invalid": invalid syntax`,
+	}, {
+		fileName: "feature-flags-invalid-enable-workspace-bound-validation",
+		want:     `failed parsing feature flags config "invalid": strconv.ParseBool: parsing "invalid": invalid syntax`,
 	}, {
 		fileName: "feature-flags-invalid-set_security_context_read_only_root_filesystem",
 		want:     `failed parsing feature flags config "invalid read only root filesystem flag": strconv.ParseBool: parsing "invalid read only root filesystem flag": invalid syntax`,
@@ -0,0 +1,108 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// variableRegex matches the variables referenced by the params of the
+// injected finally tasks and by the build metadata environment variables.
+var variableRegex = regexp.MustCompile(`\$\(([^)]*)\)`)
+
+// InjectedFinallyTasks are the finally tasks appended to the PipelineSpec of
+// every PipelineRun, e.g. to report the runs to an audit system.
+// +k8s:deepcopy-gen=true
+type InjectedFinallyTasks struct {
+	// Tasks are the finally tasks to inject, in order.
+	Tasks []InjectedFinallyTask `json:"tasks"`
+	// ExemptNamespaces are the namespaces of the PipelineRuns into which the
+	// finally tasks aren't injected.
+	// +optional
+	ExemptNamespaces []string `json:"exemptNamespaces,omitempty"`
+}
+
+// InjectedFinallyTask is a finally task appended to the PipelineSpec of the
+// PipelineRuns.
+// +k8s:deepcopy-gen=true
+type InjectedFinallyTask struct {
+	// Name is the name of the finally task. The task isn't injected into the
+	// Pipelines which already declare a task with the same name.
+	Name string `json:"name"`
+	// TaskRef references the Task run by the finally task.
+	TaskRef InjectedTaskRef `json:"taskRef"`
+	// Params are the string params passed to the Task. They can only
+	// reference the context variables, e.g. $(context.pipelineRun.name).
+	// +optional
+	Params map[string]string `json:"params,omitempty"`
+}
+
+// InjectedTaskRef references the Task of an injected finally task, either by
+// its name or with a resolver.
+// +k8s:deepcopy-gen=true
+type InjectedTaskRef struct {
+	// Name is the name of the Task in the namespace of the PipelineRun.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Kind is the kind of the Task, "Task" by default.
+	// +optional
+	Kind string `json:"kind,omitempty"`
+	// Resolver is the name of the resolver of the Task, such as "cluster" or
+	// "git".
+	// +optional
+	Resolver string `json:"resolver,omitempty"`
+	// Params are the params passed to the resolver.
+	// +optional
+	Params map[string]string `json:"params,omitempty"`
+}
+
+// InjectedFinallyTasksForNamespace returns the finally tasks to inject into
+// the PipelineRuns of the given namespace, none if it's exempted.
+func (cfg *Defaults) InjectedFinallyTasksForNamespace(namespace string) []InjectedFinallyTask {
+	if cfg.DefaultInjectedFinallyTasks == nil || slices.Contains(cfg.DefaultInjectedFinallyTasks.ExemptNamespaces, namespace) {
+		return nil
+	}
+	return cfg.DefaultInjectedFinallyTasks.Tasks
+}
+
+func validateInjectedFinallyTasks(injected *InjectedFinallyTasks) error {
+	seen := map[string]bool{}
+	for _, t := range injected.Tasks {
+		if t.Name == "" {
+			return errors.New("injected finally tasks must have a name")
+		}
+		if seen[t.Name] {
+			return fmt.Errorf("injected finally task %q is defined more than once", t.Name)
+		}
+		seen[t.Name] = true
+		if (t.TaskRef.Name == "") == (t.TaskRef.Resolver == "") {
+			return fmt.Errorf("injected finally task %q must reference a Task either by name or with a resolver", t.Name)
+		}
+		for name, value := range t.Params {
+			for _, match := range variableRegex.FindAllStringSubmatch(value, -1) {
+				if !strings.HasPrefix(match[1], "context.") {
+					return fmt.Errorf("param %q of injected finally task %q can only reference context variables, got %q", name, t.Name, match[0])
+				}
+			}
+		}
+	}
+	return nil
+}
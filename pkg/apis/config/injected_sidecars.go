@@ -0,0 +1,81 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// InjectedSidecar is a sidecar added to the pod of every TaskRun, e.g. to
+// forward the logs of the steps.
+// +k8s:deepcopy-gen=true
+type InjectedSidecar struct {
+	// Name is the name of the sidecar. It is suffixed if a sidecar of the
+	// Task has the same name.
+	Name string `json:"name"`
+	// Image is the image of the sidecar.
+	Image string `json:"image"`
+	// Command is the entrypoint of the sidecar.
+	// +optional
+	Command []string `json:"command,omitempty"`
+	// Args are the arguments of the entrypoint of the sidecar.
+	// +optional
+	Args []string `json:"args,omitempty"`
+	// Env is the list of environment variables of the sidecar.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+	// ComputeResources are the compute resources required by the sidecar.
+	// +optional
+	ComputeResources corev1.ResourceRequirements `json:"computeResources,omitempty"`
+	// Workspaces are the names of the workspaces of the Task to mount in the
+	// sidecar, at the same path as in the steps. Workspaces which aren't
+	// declared by the Task, or aren't bound, are ignored.
+	// +optional
+	Workspaces []string `json:"workspaces,omitempty"`
+	// AwaitReadiness makes the steps wait for the sidecar to be ready before
+	// starting, like they do for the sidecars of the Task. Injected sidecars
+	// aren't awaited by default.
+	// +optional
+	AwaitReadiness bool `json:"awaitReadiness,omitempty"`
+}
+
+// InjectedSidecarsForNamespace returns the sidecars to inject into the pods
+// of the TaskRuns of the given namespace: the ones configured for the
+// namespace if any, the ones configured for all namespaces otherwise.
+func (cfg *Defaults) InjectedSidecarsForNamespace(namespace string) []InjectedSidecar {
+	if sidecars, ok := cfg.DefaultInjectedSidecarsByNamespace[namespace]; ok {
+		return sidecars
+	}
+	return cfg.DefaultInjectedSidecars
+}
+
+func validateInjectedSidecars(sidecars []InjectedSidecar) error {
+	seen := map[string]bool{}
+	for _, s := range sidecars {
+		if s.Name == "" || s.Image == "" {
+			return errors.New("injected sidecars must have a name and an image")
+		}
+		if seen[s.Name] {
+			return fmt.Errorf("injected sidecar %q is defined more than once", s.Name)
+		}
+		seen[s.Name] = true
+	}
+	return nil
+}
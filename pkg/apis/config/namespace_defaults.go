@@ -0,0 +1,203 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	corev1informers "k8s.io/client-go/informers/core/v1"
+	"knative.dev/pkg/logging"
+)
+
+// NamespaceDefaultsConfigName is the name of the ConfigMap which can be
+// created in a namespace to override some of the defaults of the
+// config-defaults ConfigMap for the runs created in the namespace.
+const NamespaceDefaultsConfigName = "tekton-config-defaults"
+
+// DefaultContainerResourceRequirementsAnnotation records on a TaskRun the
+// default-container-resource-requirements of the ConfigMap of its namespace
+// when it was created, which are applied when its pods are created.
+const DefaultContainerResourceRequirementsAnnotation = "tekton.dev/default-container-resource-requirements"
+
+// namespaceDefaultsKeys are the keys of config-defaults which can be
+// overridden by the ConfigMap of a namespace.
+var namespaceDefaultsKeys = []string{
+	defaultTimeoutMinutesKey,
+	defaultServiceAccountKey,
+	defaultPodTemplateKey,
+	defaultManagedByLabelValueKey,
+	defaultContainerResourceRequirementsKey,
+}
+
+// NamespaceDefaultsGetter returns the data of the NamespaceDefaultsConfigName
+// ConfigMap of a namespace, or nil if the namespace doesn't have one.
+type NamespaceDefaultsGetter func(ctx context.Context, namespace string) (map[string]string, error)
+
+type namespaceDefaultsGetterKey struct{}
+
+// WithNamespaceDefaultsGetter attaches the getter of the namespace defaults
+// to the context, making DefaultsForNamespace consult them.
+func WithNamespaceDefaultsGetter(ctx context.Context, getter NamespaceDefaultsGetter) context.Context {
+	return context.WithValue(ctx, namespaceDefaultsGetterKey{}, getter)
+}
+
+// NamespaceDefaultsGetterFromInformer returns a NamespaceDefaultsGetter which
+// gets the ConfigMap of the namespace from the given informer, so that the
+// admission of the runs doesn't request the API server. Until the informer
+// is synced, the namespaces are reported as not having one, so that the runs
+// get the cluster defaults rather than being rejected. The informer is
+// registered with its factory, which must be started afterwards.
+func NamespaceDefaultsGetterFromInformer(informer corev1informers.ConfigMapInformer) NamespaceDefaultsGetter {
+	hasSynced := informer.Informer().HasSynced
+	lister := informer.Lister()
+	return func(ctx context.Context, namespace string) (map[string]string, error) {
+		if !hasSynced() {
+			logging.FromContext(ctx).Warnf("The %s ConfigMaps aren't synced yet, using the cluster defaults for namespace %s", NamespaceDefaultsConfigName, namespace)
+			return nil, nil
+		}
+		cm, err := lister.ConfigMaps(namespace).Get(NamespaceDefaultsConfigName)
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if cm.Data == nil {
+			return map[string]string{}, nil
+		}
+		return cm.Data, nil
+	}
+}
+
+// WithNamespaceDefaults returns a copy of the defaults overridden by the data
+// of the ConfigMap of a namespace. It fails if the data sets a key which
+// can't be overridden by a namespace or holds an invalid value.
+func (cfg *Defaults) WithNamespaceDefaults(data map[string]string) (*Defaults, error) {
+	for k := range data {
+		if !slices.Contains(namespaceDefaultsKeys, k) {
+			return nil, fmt.Errorf("%q can't be set in the %s ConfigMap of a namespace", k, NamespaceDefaultsConfigName)
+		}
+	}
+	parsed, err := NewDefaultsFromMap(data)
+	if err != nil {
+		return nil, err
+	}
+
+	defaults := cfg.DeepCopy()
+	if _, ok := data[defaultTimeoutMinutesKey]; ok {
+		defaults.DefaultTimeoutMinutes = parsed.DefaultTimeoutMinutes
+	}
+	if _, ok := data[defaultServiceAccountKey]; ok {
+		defaults.DefaultServiceAccount = parsed.DefaultServiceAccount
+	}
+	if _, ok := data[defaultPodTemplateKey]; ok {
+		defaults.DefaultPodTemplate = parsed.DefaultPodTemplate
+	}
+	if _, ok := data[defaultManagedByLabelValueKey]; ok {
+		defaults.DefaultManagedByLabelValue = parsed.DefaultManagedByLabelValue
+	}
+	if _, ok := data[defaultContainerResourceRequirementsKey]; ok {
+		defaults.DefaultContainerResourceRequirements = parsed.DefaultContainerResourceRequirements
+	}
+	return defaults, nil
+}
+
+// DefaultsForNamespace returns the defaults of the runs of a namespace: the
+// defaults of the config in the context, overridden by the ConfigMap of the
+// namespace if the context has a NamespaceDefaultsGetter and the namespace
+// has one.
+func DefaultsForNamespace(ctx context.Context, namespace string) (*Defaults, error) {
+	defaults := FromContextOrDefaults(ctx).Defaults
+	getter, ok := ctx.Value(namespaceDefaultsGetterKey{}).(NamespaceDefaultsGetter)
+	if !ok || getter == nil || namespace == "" {
+		return defaults, nil
+	}
+	data, err := getter(ctx, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the %s ConfigMap of namespace %s: %w", NamespaceDefaultsConfigName, namespace, err)
+	}
+	if data == nil {
+		return defaults, nil
+	}
+	defaults, err = defaults.WithNamespaceDefaults(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s ConfigMap in namespace %s: %w", NamespaceDefaultsConfigName, namespace, err)
+	}
+	return defaults, nil
+}
+
+// ToContextForNamespace returns a context whose config has the defaults of
+// the runs of a namespace, as returned by DefaultsForNamespace. The context
+// is returned unchanged if the ConfigMap of the namespace can't be used,
+// which is logged here and reported by the validation of the runs.
+func ToContextForNamespace(ctx context.Context, namespace string) context.Context {
+	defaults, err := DefaultsForNamespace(ctx, namespace)
+	if err != nil {
+		logging.FromContext(ctx).Warnf("Ignoring the defaults of namespace %s: %v", namespace, err)
+		return ctx
+	}
+	cfg := *FromContextOrDefaults(ctx)
+	cfg.Defaults = defaults
+	return ToContext(ctx, &cfg)
+}
+
+// RecordNamespaceDefaults records in the annotations of a new TaskRun the
+// defaults of its namespace which are only applied once its pods are
+// created, so that its pods and the pods of its retries get the defaults of
+// the namespace when it was created. It returns the annotations with the
+// DefaultContainerResourceRequirementsAnnotation set if the ConfigMap of the
+// namespace overrides them, and removed otherwise.
+func RecordNamespaceDefaults(ctx context.Context, namespace string, annotations map[string]string) map[string]string {
+	delete(annotations, DefaultContainerResourceRequirementsAnnotation)
+	getter, ok := ctx.Value(namespaceDefaultsGetterKey{}).(NamespaceDefaultsGetter)
+	if !ok || getter == nil || namespace == "" {
+		return annotations
+	}
+	data, err := getter(ctx, namespace)
+	if err != nil {
+		logging.FromContext(ctx).Warnf("Failed to get the %s ConfigMap of namespace %s: %v", NamespaceDefaultsConfigName, namespace, err)
+		return annotations
+	}
+	if value, ok := data[defaultContainerResourceRequirementsKey]; ok {
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[DefaultContainerResourceRequirementsAnnotation] = value
+	}
+	return annotations
+}
+
+// ToContextForRecordedDefaults returns a context whose config has the
+// defaults of the namespace recorded in the annotations of a TaskRun by
+// RecordNamespaceDefaults, if any.
+func ToContextForRecordedDefaults(ctx context.Context, annotations map[string]string) (context.Context, error) {
+	value, ok := annotations[DefaultContainerResourceRequirementsAnnotation]
+	if !ok {
+		return ctx, nil
+	}
+	parsed, err := NewDefaultsFromMap(map[string]string{defaultContainerResourceRequirementsKey: value})
+	if err != nil {
+		return ctx, fmt.Errorf("invalid %s annotation: %w", DefaultContainerResourceRequirementsAnnotation, err)
+	}
+	cfg := *FromContextOrDefaults(ctx)
+	cfg.Defaults = cfg.Defaults.DeepCopy()
+	cfg.Defaults.DefaultContainerResourceRequirements = parsed.DefaultContainerResourceRequirements
+	return ToContext(ctx, &cfg), nil
+}
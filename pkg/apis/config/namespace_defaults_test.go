@@ -0,0 +1,243 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	cfgtesting "github.com/tektoncd/pipeline/pkg/apis/config/testing"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	fakekubeclient "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestDefaultsForNamespace(t *testing.T) {
+	clusterDefaults := map[string]string{
+		"default-timeout-minutes":        "30",
+		"default-service-account":        "cluster-sa",
+		"default-managed-by-label-value": "cluster",
+	}
+	cluster := &config.Defaults{
+		DefaultTimeoutMinutes:             30,
+		DefaultServiceAccount:             "cluster-sa",
+		DefaultManagedByLabelValue:        "cluster",
+		DefaultMaxMatrixCombinationsCount: config.DefaultMaxMatrixCombinationsCount,
+		DefaultMaximumResolutionTimeout:   config.DefaultMaximumResolutionTimeout,
+		DefaultMaxResolutionDepth:         config.DefaultMaxResolutionDepth,
+		MaxTerminationGracePeriodSeconds:  config.DefaultMaxTerminationGracePeriodSeconds,
+	}
+	withOverrides := cluster.DeepCopy()
+	withOverrides.DefaultTimeoutMinutes = 10
+	withOverrides.DefaultServiceAccount = "team-sa"
+	withOverrides.DefaultPodTemplate = &pod.Template{NodeSelector: map[string]string{"team": "a"}}
+	withOverrides.DefaultContainerResourceRequirements = map[string]corev1.ResourceRequirements{
+		"default": {Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Mi")}},
+	}
+
+	for _, tc := range []struct {
+		name      string
+		getter    config.NamespaceDefaultsGetter
+		namespace string
+		want      *config.Defaults
+		wantErr   string
+	}{{
+		name:      "no namespace defaults getter",
+		namespace: "team-a",
+		want:      cluster,
+	}, {
+		name:      "namespace without defaults",
+		getter:    getter(nil, nil),
+		namespace: "team-a",
+		want:      cluster,
+	}, {
+		name: "namespace overriding some defaults",
+		getter: getter(map[string]string{
+			"default-timeout-minutes":                 "10",
+			"default-service-account":                 "team-sa",
+			"default-pod-template":                    "nodeSelector:\n  team: a\n",
+			"default-container-resource-requirements": "default:\n  requests:\n    memory: 64Mi\n",
+		}, nil),
+		namespace: "team-a",
+		want:      withOverrides,
+	}, {
+		name:      "key which can't be overridden",
+		getter:    getter(map[string]string{"default-cloud-events-sink": "http://sink"}, nil),
+		namespace: "team-a",
+		wantErr:   `invalid tekton-config-defaults ConfigMap in namespace team-a: "default-cloud-events-sink" can't be set in the tekton-config-defaults ConfigMap of a namespace`,
+	}, {
+		name:      "invalid value",
+		getter:    getter(map[string]string{"default-timeout-minutes": "ten"}, nil),
+		namespace: "team-a",
+		wantErr:   `invalid tekton-config-defaults ConfigMap in namespace team-a: failed parsing default config "default-timeout-minutes"`,
+	}, {
+		name:      "namespace defaults can't be got",
+		getter:    getter(nil, errors.New("forbidden")),
+		namespace: "team-a",
+		wantErr:   "failed to get the tekton-config-defaults ConfigMap of namespace team-a: forbidden",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := cfgtesting.SetDefaults(t.Context(), t, clusterDefaults)
+			if tc.getter != nil {
+				ctx = config.WithNamespaceDefaultsGetter(ctx, tc.getter)
+			}
+			got, err := config.DefaultsForNamespace(ctx, tc.namespace)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("expected error %q but got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("unexpected defaults %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestNamespaceDefaultsGetterFromInformer(t *testing.T) {
+	client := fakekubeclient.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: config.NamespaceDefaultsConfigName, Namespace: "team-a"},
+		Data:       map[string]string{"default-service-account": "team-sa"},
+	})
+	factory := informers.NewSharedInformerFactory(client, 0)
+	getter := config.NamespaceDefaultsGetterFromInformer(factory.Core().V1().ConfigMaps())
+
+	// The cluster defaults are used until the informer is synced.
+	if got, err := getter(t.Context(), "team-a"); err != nil || got != nil {
+		t.Errorf("expected no data before the informer is synced but got %v, %v", got, err)
+	}
+
+	factory.Start(t.Context().Done())
+	factory.WaitForCacheSync(t.Context().Done())
+	got, err := getter(t.Context(), "team-a")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d := cmp.Diff(map[string]string{"default-service-account": "team-sa"}, got); d != "" {
+		t.Errorf("unexpected data %s", diff.PrintWantGot(d))
+	}
+
+	got, err = getter(t.Context(), "team-b")
+	if err != nil || got != nil {
+		t.Errorf("expected no data for a namespace without the ConfigMap but got %v, %v", got, err)
+	}
+	for _, action := range client.Actions() {
+		if action.GetVerb() == "get" {
+			t.Errorf("expected the ConfigMaps to be got from the informer, got %v", action)
+		}
+	}
+}
+
+func TestRecordNamespaceDefaults(t *testing.T) {
+	resources := "default:\n  requests:\n    memory: 64Mi\n"
+	for _, tc := range []struct {
+		name        string
+		getter      config.NamespaceDefaultsGetter
+		annotations map[string]string
+		want        map[string]string
+	}{{
+		name:        "no namespace defaults getter",
+		annotations: map[string]string{"foo": "bar"},
+		want:        map[string]string{"foo": "bar"},
+	}, {
+		name:   "namespace overriding the container resource requirements",
+		getter: getter(map[string]string{"default-container-resource-requirements": resources}, nil),
+		want:   map[string]string{config.DefaultContainerResourceRequirementsAnnotation: resources},
+	}, {
+		name:   "namespace not overriding the container resource requirements",
+		getter: getter(map[string]string{"default-service-account": "team-sa"}, nil),
+		annotations: map[string]string{
+			"foo": "bar",
+			config.DefaultContainerResourceRequirementsAnnotation: resources,
+		},
+		want: map[string]string{"foo": "bar"},
+	}, {
+		name:        "namespace defaults can't be got",
+		getter:      getter(nil, errors.New("forbidden")),
+		annotations: map[string]string{"foo": "bar"},
+		want:        map[string]string{"foo": "bar"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := t.Context()
+			if tc.getter != nil {
+				ctx = config.WithNamespaceDefaultsGetter(ctx, tc.getter)
+			}
+			got := config.RecordNamespaceDefaults(ctx, "team-a", tc.annotations)
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("unexpected annotations %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestToContextForRecordedDefaults(t *testing.T) {
+	ctx := cfgtesting.SetDefaults(t.Context(), t, map[string]string{
+		"default-service-account":                 "cluster-sa",
+		"default-container-resource-requirements": "default:\n  requests:\n    memory: 32Mi\n",
+	})
+
+	got, err := config.ToContextForRecordedDefaults(ctx, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != ctx {
+		t.Error("expected the context to be unchanged without recorded defaults")
+	}
+
+	got, err = config.ToContextForRecordedDefaults(ctx, map[string]string{
+		config.DefaultContainerResourceRequirementsAnnotation: "default:\n  requests:\n    memory: 64Mi\n",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defaults := config.FromContextOrDefaults(got).Defaults
+	want := map[string]corev1.ResourceRequirements{
+		"default": {Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Mi")}},
+	}
+	if d := cmp.Diff(want, defaults.DefaultContainerResourceRequirements); d != "" {
+		t.Errorf("unexpected container resource requirements %s", diff.PrintWantGot(d))
+	}
+	if defaults.DefaultServiceAccount != "cluster-sa" {
+		t.Errorf("expected the other defaults to be kept, got the service account %q", defaults.DefaultServiceAccount)
+	}
+	if d := config.FromContextOrDefaults(ctx).Defaults.DefaultContainerResourceRequirements["default"]; d.Requests.Memory().String() != "32Mi" {
+		t.Errorf("expected the defaults of the original context to be unchanged, got %v", d)
+	}
+
+	if _, err := config.ToContextForRecordedDefaults(ctx, map[string]string{
+		config.DefaultContainerResourceRequirementsAnnotation: "not: [valid",
+	}); err == nil {
+		t.Error("expected an error for an invalid recorded value")
+	}
+}
+
+func getter(data map[string]string, err error) config.NamespaceDefaultsGetter {
+	return func(context.Context, string) (map[string]string, error) {
+		return data, err
+	}
+}
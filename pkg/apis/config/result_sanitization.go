@@ -0,0 +1,71 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// DefaultMinEntropyLength is the length from which the words of the results
+// are checked for a high entropy when no minEntropyLength is configured.
+const DefaultMinEntropyLength = 20
+
+// ResultSanitization configures the redaction of the secrets leaked by the
+// steps into the results of the TaskRuns, before they are recorded in their
+// status and emitted in events.
+// +k8s:deepcopy-gen=true
+type ResultSanitization struct {
+	// Patterns are the regular expressions whose matches in the results are
+	// redacted.
+	// +optional
+	Patterns []string `json:"patterns,omitempty"`
+	// EntropyThreshold is the Shannon entropy, in bits per character, from
+	// which the words of the results are considered random tokens and are
+	// redacted. The words are not checked for their entropy when it is 0.
+	// +optional
+	EntropyThreshold float64 `json:"entropyThreshold,omitempty"`
+	// MinEntropyLength is the length from which the words of the results are
+	// checked for their entropy, DefaultMinEntropyLength if unset.
+	// +optional
+	MinEntropyLength int `json:"minEntropyLength,omitempty"`
+}
+
+// CompilePatterns returns the compiled regular expressions of the patterns.
+func (s *ResultSanitization) CompilePatterns() ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(s.Patterns))
+	for _, p := range s.Patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", p, err)
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+func validateResultSanitization(s *ResultSanitization) error {
+	if len(s.Patterns) == 0 && s.EntropyThreshold == 0 {
+		return errors.New("patterns or entropyThreshold must be set")
+	}
+	if s.EntropyThreshold < 0 || s.MinEntropyLength < 0 {
+		return errors.New("entropyThreshold and minEntropyLength must not be negative")
+	}
+	_, err := s.CompilePatterns()
+	return err
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"errors"
+	"strings"
+)
+
+const (
+	// StepActionRefNameVariable is replaced by the name of the StepAction in
+	// the params of the default-step-action-resolver config.
+	StepActionRefNameVariable = "$(ref.name)"
+	// StepActionRefVersionVariable is replaced by the version of the
+	// StepAction in the params of the default-step-action-resolver config.
+	StepActionRefVersionVariable = "$(ref.version)"
+)
+
+// StepActionResolver is the resolver of the StepActions referenced by a name
+// and a version, such as the hub or the git resolver, with the params mapping
+// the name and the version to the params of the resolver.
+// +k8s:deepcopy-gen=true
+type StepActionResolver struct {
+	// Resolver is the name of the resolver, such as "hub" or "git".
+	Resolver string `json:"resolver"`
+	// Params are the params passed to the resolver, in which
+	// StepActionRefNameVariable and StepActionRefVersionVariable are replaced
+	// by the name and the version of the StepAction.
+	// +optional
+	Params map[string]string `json:"params,omitempty"`
+}
+
+// ParamsFor returns the params passed to the resolver to resolve the given
+// version of the StepAction.
+func (r *StepActionResolver) ParamsFor(name, version string) map[string]string {
+	replacer := strings.NewReplacer(StepActionRefNameVariable, name, StepActionRefVersionVariable, version)
+	params := make(map[string]string, len(r.Params))
+	for k, v := range r.Params {
+		params[k] = replacer.Replace(v)
+	}
+	return params
+}
+
+func validateStepActionResolver(r *StepActionResolver) error {
+	if r.Resolver == "" {
+		return errors.New("resolver must be set")
+	}
+	return nil
+}
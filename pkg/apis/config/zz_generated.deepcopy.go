@@ -26,6 +26,24 @@ import (
 	v1 "k8s.io/api/core/v1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerRateLimits) DeepCopyInto(out *ControllerRateLimits) {
+	*out = *in
+	out.WorkQueueBaseDelay = in.WorkQueueBaseDelay
+	out.WorkQueueMaxDelay = in.WorkQueueMaxDelay
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerRateLimits.
+func (in *ControllerRateLimits) DeepCopy() *ControllerRateLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerRateLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Defaults) DeepCopyInto(out *Defaults) {
 	*out = *in
@@ -51,6 +69,69 @@ func (in *Defaults) DeepCopyInto(out *Defaults) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.DefaultFSGroup != nil {
+		in, out := &in.DefaultFSGroup, &out.DefaultFSGroup
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DefaultInjectedSidecars != nil {
+		in, out := &in.DefaultInjectedSidecars, &out.DefaultInjectedSidecars
+		*out = make([]InjectedSidecar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DefaultInjectedSidecarsByNamespace != nil {
+		in, out := &in.DefaultInjectedSidecarsByNamespace, &out.DefaultInjectedSidecarsByNamespace
+		*out = make(map[string][]InjectedSidecar, len(*in))
+		for key, val := range *in {
+			var outVal []InjectedSidecar
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]InjectedSidecar, len(*in))
+				for i := range *in {
+					(*in)[i].DeepCopyInto(&(*out)[i])
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.DefaultHermeticNetworkSidecar != nil {
+		in, out := &in.DefaultHermeticNetworkSidecar, &out.DefaultHermeticNetworkSidecar
+		*out = new(InjectedSidecar)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DefaultControllerRateLimits != nil {
+		in, out := &in.DefaultControllerRateLimits, &out.DefaultControllerRateLimits
+		*out = make(map[string]ControllerRateLimits, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.DefaultStepActionResolver != nil {
+		in, out := &in.DefaultStepActionResolver, &out.DefaultStepActionResolver
+		*out = new(StepActionResolver)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DefaultResultSanitization != nil {
+		in, out := &in.DefaultResultSanitization, &out.DefaultResultSanitization
+		*out = new(ResultSanitization)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DefaultInjectedFinallyTasks != nil {
+		in, out := &in.DefaultInjectedFinallyTasks, &out.DefaultInjectedFinallyTasks
+		*out = new(InjectedFinallyTasks)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DefaultBuildMetadataEnv != nil {
+		in, out := &in.DefaultBuildMetadataEnv, &out.DefaultBuildMetadataEnv
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -103,6 +184,120 @@ func (in *FeatureFlags) DeepCopy() *FeatureFlags {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InjectedFinallyTask) DeepCopyInto(out *InjectedFinallyTask) {
+	*out = *in
+	in.TaskRef.DeepCopyInto(&out.TaskRef)
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InjectedFinallyTask.
+func (in *InjectedFinallyTask) DeepCopy() *InjectedFinallyTask {
+	if in == nil {
+		return nil
+	}
+	out := new(InjectedFinallyTask)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InjectedFinallyTasks) DeepCopyInto(out *InjectedFinallyTasks) {
+	*out = *in
+	if in.Tasks != nil {
+		in, out := &in.Tasks, &out.Tasks
+		*out = make([]InjectedFinallyTask, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExemptNamespaces != nil {
+		in, out := &in.ExemptNamespaces, &out.ExemptNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InjectedFinallyTasks.
+func (in *InjectedFinallyTasks) DeepCopy() *InjectedFinallyTasks {
+	if in == nil {
+		return nil
+	}
+	out := new(InjectedFinallyTasks)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InjectedSidecar) DeepCopyInto(out *InjectedSidecar) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.ComputeResources.DeepCopyInto(&out.ComputeResources)
+	if in.Workspaces != nil {
+		in, out := &in.Workspaces, &out.Workspaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InjectedSidecar.
+func (in *InjectedSidecar) DeepCopy() *InjectedSidecar {
+	if in == nil {
+		return nil
+	}
+	out := new(InjectedSidecar)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InjectedTaskRef) DeepCopyInto(out *InjectedTaskRef) {
+	*out = *in
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InjectedTaskRef.
+func (in *InjectedTaskRef) DeepCopy() *InjectedTaskRef {
+	if in == nil {
+		return nil
+	}
+	out := new(InjectedTaskRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Metrics) DeepCopyInto(out *Metrics) {
 	*out = *in
@@ -119,6 +314,50 @@ func (in *Metrics) DeepCopy() *Metrics {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResultSanitization) DeepCopyInto(out *ResultSanitization) {
+	*out = *in
+	if in.Patterns != nil {
+		in, out := &in.Patterns, &out.Patterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResultSanitization.
+func (in *ResultSanitization) DeepCopy() *ResultSanitization {
+	if in == nil {
+		return nil
+	}
+	out := new(ResultSanitization)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StepActionResolver) DeepCopyInto(out *StepActionResolver) {
+	*out = *in
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepActionResolver.
+func (in *StepActionResolver) DeepCopy() *StepActionResolver {
+	if in == nil {
+		return nil
+	}
+	out := new(StepActionResolver)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Tracing) DeepCopyInto(out *Tracing) {
 	*out = *in
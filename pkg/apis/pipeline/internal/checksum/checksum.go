@@ -11,6 +11,9 @@ import (
 const (
 	// SignatureAnnotation is the key of signature in annotation map
 	SignatureAnnotation = "tekton.dev/signature"
+	// InterfaceDigestAnnotation is the key of the interface digest set by the
+	// webhook in annotation map
+	InterfaceDigestAnnotation = "tekton.dev/interface-digest"
 )
 
 // PrepareObjectMeta will remove annotations not configured from user side -- "kubectl-client-side-apply" and "kubectl.kubernetes.io/last-applied-configuration"
@@ -41,6 +44,8 @@ func PrepareObjectMeta(in metav1.Object) metav1.ObjectMeta {
 	delete(outMeta.Annotations, "kubectl-client-side-apply")
 	delete(outMeta.Annotations, "kubectl.kubernetes.io/last-applied-configuration")
 	delete(outMeta.Annotations, SignatureAnnotation)
+	// the interface digest is set by the webhook after the object is signed
+	delete(outMeta.Annotations, InterfaceDigestAnnotation)
 
 	return outMeta
 }
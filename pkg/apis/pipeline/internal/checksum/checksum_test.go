@@ -43,6 +43,9 @@ func TestPrepareObjectMeta(t *testing.T) {
 	signedWithExtraAnnotations.Annotations["kubectl-client-side-apply"] = "client"
 	signedWithExtraAnnotations.Annotations["kubectl.kubernetes.io/last-applied-configuration"] = "config"
 
+	signedWithInterfaceDigest := signed.DeepCopy()
+	signedWithInterfaceDigest.Annotations[InterfaceDigestAnnotation] = "sha256:0123"
+
 	tcs := []struct {
 		name       string
 		objectmeta *metav1.ObjectMeta
@@ -72,6 +75,14 @@ func TestPrepareObjectMeta(t *testing.T) {
 			Namespace:   namespace,
 			Annotations: map[string]string{},
 		},
+	}, {
+		name:       "Prepare signed objectmeta with interface digest",
+		objectmeta: signedWithInterfaceDigest,
+		expected: metav1.ObjectMeta{
+			Name:        "test-task",
+			Namespace:   namespace,
+			Annotations: map[string]string{},
+		},
 	}, {
 		name:       "resource without signature shouldn't fail",
 		objectmeta: &unsigned,
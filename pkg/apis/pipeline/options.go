@@ -23,4 +23,8 @@ import "time"
 type Options struct {
 	Images       Images
 	ResyncPeriod time.Duration
+	// KeylessSigningRoots is the path of the PEM encoded root certificates
+	// the certificates of the keyless signatures of the step images must
+	// chain to for their signer to be recorded.
+	KeylessSigningRoots string
 }
@@ -16,6 +16,11 @@ limitations under the License.
 
 package pipeline
 
+import (
+	"path"
+	"strings"
+)
+
 const (
 	// WorkspaceDir is the root directory used for PipelineResources and (by default) Workspaces
 	WorkspaceDir = "/workspace"
@@ -33,3 +38,13 @@ const (
 
 	ArtifactsDir = "/tekton/artifacts"
 )
+
+// IsRelativeSubPath returns whether p is a relative path that, once cleaned,
+// stays within the directory it is relative to.
+func IsRelativeSubPath(p string) bool {
+	if path.IsAbs(p) {
+		return false
+	}
+	c := path.Clean(p)
+	return c != ".." && !strings.HasPrefix(c, "../")
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipeline_test
+
+import (
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+)
+
+func TestIsRelativeSubPath(t *testing.T) {
+	for _, tc := range []struct {
+		path string
+		want bool
+	}{
+		{path: "script.sh", want: true},
+		{path: "scripts/build.sh", want: true},
+		{path: "./script.sh", want: true},
+		{path: "..script.sh", want: true},
+		{path: "scripts/../script.sh", want: true},
+		{path: "/script.sh", want: false},
+		{path: "..", want: false},
+		{path: "../script.sh", want: false},
+		{path: "scripts/../../script.sh", want: false},
+	} {
+		if got := pipeline.IsRelativeSubPath(tc.path); got != tc.want {
+			t.Errorf("IsRelativeSubPath(%q) = %v, want %v", tc.path, got, tc.want)
+		}
+	}
+}
@@ -137,6 +137,31 @@ type Template struct {
 	// +optional
 	// +listType=atomic
 	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// SeccompProfile is the seccomp profile of the containers of the pod,
+	// unless the securityContext of the pod or of a container sets another one.
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	SeccompProfile *corev1.SeccompProfile `json:"seccompProfile,omitempty"`
+
+	// AppArmorProfile is the AppArmor profile of the containers of the pod,
+	// unless the securityContext of the pod or of a container sets another one.
+	// It is set with the AppArmor annotations of the containers on Kubernetes
+	// versions older than 1.30.
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	AppArmorProfile *corev1.AppArmorProfile `json:"appArmorProfile,omitempty"`
+
+	// TerminationGracePeriodSeconds is the duration in seconds the pod needs
+	// to terminate gracefully. The steps are sent a SIGTERM when the run is
+	// cancelled, and a SIGKILL once the grace period elapsed. Defaults to 30
+	// seconds when the pod is deleted, and to killing the steps right away
+	// when it's kept on cancellation.
+	// See Pod.spec.terminationGracePeriodSeconds (API version: v1)
+	// +optional
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
 }
 
 // Equals checks if this Template is identical to the given Template.
@@ -232,6 +257,15 @@ func MergePodTemplateWithDefault(tpl, defaultTpl *PodTemplate) *PodTemplate {
 		if tpl.TopologySpreadConstraints == nil {
 			tpl.TopologySpreadConstraints = defaultTpl.TopologySpreadConstraints
 		}
+		if tpl.SeccompProfile == nil {
+			tpl.SeccompProfile = defaultTpl.SeccompProfile
+		}
+		if tpl.AppArmorProfile == nil {
+			tpl.AppArmorProfile = defaultTpl.AppArmorProfile
+		}
+		if tpl.TerminationGracePeriodSeconds == nil {
+			tpl.TerminationGracePeriodSeconds = defaultTpl.TerminationGracePeriodSeconds
+		}
 		return tpl
 	}
 }
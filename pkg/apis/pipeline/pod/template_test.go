@@ -90,6 +90,7 @@ func TestMergeByName(t *testing.T) {
 }
 
 func TestMergePodTemplateWithDefault(t *testing.T) {
+	var defaultTerminationGracePeriod, terminationGracePeriod int64 = 30, 300
 	type testCase struct {
 		name       string
 		tpl        *PodTemplate
@@ -157,6 +158,62 @@ func TestMergePodTemplateWithDefault(t *testing.T) {
 				HostNetwork: true,
 			},
 		},
+		{
+			name: "override host aliases and default dns config",
+			tpl: &PodTemplate{
+				HostAliases: []corev1.HostAlias{{IP: "10.0.0.20", Hostnames: []string{"git.internal.example.com"}}},
+			},
+			defaultTpl: &PodTemplate{
+				HostAliases: []corev1.HostAlias{
+					{IP: "10.0.0.10", Hostnames: []string{"git.internal.example.com"}},
+					{IP: "10.0.0.11", Hostnames: []string{"registry.internal.example.com"}},
+				},
+				DNSConfig: &corev1.PodDNSConfig{Searches: []string{"internal.example.com"}},
+			},
+			expected: &PodTemplate{
+				HostAliases: []corev1.HostAlias{{IP: "10.0.0.20", Hostnames: []string{"git.internal.example.com"}}},
+				DNSConfig:   &corev1.PodDNSConfig{Searches: []string{"internal.example.com"}},
+			},
+		},
+		{
+			name: "default termination grace period",
+			tpl: &PodTemplate{
+				NodeSelector: map[string]string{"foo": "bar"},
+			},
+			defaultTpl: &PodTemplate{
+				TerminationGracePeriodSeconds: &defaultTerminationGracePeriod,
+			},
+			expected: &PodTemplate{
+				NodeSelector:                  map[string]string{"foo": "bar"},
+				TerminationGracePeriodSeconds: &defaultTerminationGracePeriod,
+			},
+		},
+		{
+			name: "override termination grace period",
+			tpl: &PodTemplate{
+				TerminationGracePeriodSeconds: &terminationGracePeriod,
+			},
+			defaultTpl: &PodTemplate{
+				TerminationGracePeriodSeconds: &defaultTerminationGracePeriod,
+			},
+			expected: &PodTemplate{
+				TerminationGracePeriodSeconds: &terminationGracePeriod,
+			},
+		},
+		{
+			name: "default security profiles",
+			tpl: &PodTemplate{
+				SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeUnconfined},
+			},
+			defaultTpl: &PodTemplate{
+				SeccompProfile:  &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+				AppArmorProfile: &corev1.AppArmorProfile{Type: corev1.AppArmorProfileTypeRuntimeDefault},
+			},
+			expected: &PodTemplate{
+				SeccompProfile:  &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeUnconfined},
+				AppArmorProfile: &corev1.AppArmorProfile{Type: corev1.AppArmorProfileTypeRuntimeDefault},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
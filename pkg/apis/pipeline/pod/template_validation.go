@@ -0,0 +1,62 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"net"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"knative.dev/pkg/apis"
+)
+
+// ValidateHostAliasesAndDNS validates that the IPs of the hostAliases and of
+// the nameservers of the dnsConfig of the template are IP addresses, and that
+// the hostnames of the hostAliases and the search domains of the dnsConfig are
+// DNS subdomains, so that the pods of the template can be created.
+func (tpl *Template) ValidateHostAliasesAndDNS() (errs *apis.FieldError) {
+	if tpl == nil {
+		return nil
+	}
+	for i, ha := range tpl.HostAliases {
+		if net.ParseIP(ha.IP) == nil {
+			errs = errs.Also(apis.ErrInvalidValue(ha.IP+" must be a valid IP address", "ip").ViaFieldIndex("hostAliases", i))
+		}
+		if len(ha.Hostnames) == 0 {
+			errs = errs.Also(apis.ErrMissingField("hostnames").ViaFieldIndex("hostAliases", i))
+		}
+		for j, hostname := range ha.Hostnames {
+			for _, msg := range validation.IsDNS1123Subdomain(hostname) {
+				errs = errs.Also(apis.ErrInvalidValue(hostname+" must be a valid hostname: "+msg, "").ViaFieldIndex("hostnames", j).ViaFieldIndex("hostAliases", i))
+			}
+		}
+	}
+	if tpl.DNSConfig != nil {
+		for i, ns := range tpl.DNSConfig.Nameservers {
+			if net.ParseIP(ns) == nil {
+				errs = errs.Also(apis.ErrInvalidValue(ns+" must be a valid IP address", "").ViaFieldIndex("nameservers", i).ViaField("dnsConfig"))
+			}
+		}
+		for i, search := range tpl.DNSConfig.Searches {
+			// The search domains may be fully qualified, with a trailing dot.
+			for _, msg := range validation.IsDNS1123Subdomain(strings.TrimSuffix(search, ".")) {
+				errs = errs.Also(apis.ErrInvalidValue(search+" must be a valid search domain: "+msg, "").ViaFieldIndex("searches", i).ViaField("dnsConfig"))
+			}
+		}
+	}
+	return errs
+}
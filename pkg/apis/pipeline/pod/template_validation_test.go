@@ -0,0 +1,88 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestValidateHostAliasesAndDNS(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		tpl     *Template
+		wantErr *apis.FieldError
+	}{{
+		name: "nil template",
+	}, {
+		name: "valid host aliases and dns config",
+		tpl: &Template{
+			HostAliases: []corev1.HostAlias{
+				{IP: "10.0.0.10", Hostnames: []string{"git.internal.example.com", "registry"}},
+				{IP: "fd00::10", Hostnames: []string{"cache.internal.example.com"}},
+			},
+			DNSConfig: &corev1.PodDNSConfig{
+				Nameservers: []string{"10.0.0.2", "fd00::2"},
+				Searches:    []string{"internal.example.com", "example.com."},
+			},
+		},
+	}, {
+		name: "invalid ip",
+		tpl: &Template{
+			HostAliases: []corev1.HostAlias{
+				{IP: "10.0.0.10", Hostnames: []string{"git.internal.example.com"}},
+				{IP: "10.0.0.300", Hostnames: []string{"registry.internal.example.com"}},
+			},
+		},
+		wantErr: apis.ErrInvalidValue("10.0.0.300 must be a valid IP address", "hostAliases[1].ip"),
+	}, {
+		name: "missing hostnames",
+		tpl: &Template{
+			HostAliases: []corev1.HostAlias{{IP: "10.0.0.10"}},
+		},
+		wantErr: apis.ErrMissingField("hostAliases[0].hostnames"),
+	}, {
+		name: "invalid hostname",
+		tpl: &Template{
+			HostAliases: []corev1.HostAlias{{IP: "10.0.0.10", Hostnames: []string{"git.internal.example.com", "Git_Server"}}},
+		},
+		wantErr: apis.ErrInvalidValue(`Git_Server must be a valid hostname: a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')`, "hostAliases[0].hostnames[1]"),
+	}, {
+		name: "invalid nameserver",
+		tpl: &Template{
+			DNSConfig: &corev1.PodDNSConfig{Nameservers: []string{"dns.example.com"}},
+		},
+		wantErr: apis.ErrInvalidValue("dns.example.com must be a valid IP address", "dnsConfig.nameservers[0]"),
+	}, {
+		name: "invalid search domain",
+		tpl: &Template{
+			DNSConfig: &corev1.PodDNSConfig{Searches: []string{"internal.example.com", "-internal.example.com"}},
+		},
+		wantErr: apis.ErrInvalidValue(`-internal.example.com must be a valid search domain: a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')`, "dnsConfig.searches[1]"),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.tpl.ValidateHostAliasesAndDNS()
+			if d := cmp.Diff(tc.wantErr.Error(), err.Error(), cmpopts.EquateEmpty()); d != "" {
+				t.Errorf("ValidateHostAliasesAndDNS() error mismatch (-want, +got): %s", d)
+			}
+		})
+	}
+}
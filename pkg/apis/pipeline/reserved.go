@@ -0,0 +1,71 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipeline
+
+import (
+	"path"
+	"slices"
+	"strings"
+)
+
+const (
+	// TektonHermeticEnvVar is the env var set in the containers of the steps
+	// to indicate they should be run hermetically
+	TektonHermeticEnvVar = "TEKTON_HERMETIC"
+	// TektonPlatformCommandsEnvVar is the env var holding the commands of the
+	// images of the steps for each platform, for the entrypoint to pick the
+	// one of the platform it runs on
+	TektonPlatformCommandsEnvVar = "TEKTON_PLATFORM_COMMANDS"
+	// TektonStdinValueEnvVar is the env var holding the value to feed to the
+	// standard input of the step, for the entrypoint to read
+	TektonStdinValueEnvVar = "TEKTON_STEP_STDIN_VALUE"
+	// TektonResourceNameEnvVar is the env var which, when set, stops the
+	// entrypoint from running the step hermetically
+	TektonResourceNameEnvVar = "TEKTON_RESOURCE_NAME"
+)
+
+// ReservedMountPathPrefixes are the directories under which Tekton mounts its
+// volumes in the containers of the steps and sidecars, e.g. the results and
+// the binaries of the entrypoint.
+var ReservedMountPathPrefixes = []string{"/tekton", "/var/run/tekton"}
+
+// ReservedEnvVars are the env vars injected by Tekton in the containers of
+// the steps, or read by the entrypoint. The steps can't set them, and the pod
+// builder drops them from the env of the pod templates.
+var ReservedEnvVars = []string{TektonHermeticEnvVar, TektonPlatformCommandsEnvVar, TektonStdinValueEnvVar, TektonResourceNameEnvVar}
+
+// ReservedMountPathPrefix returns the directory reserved by Tekton a volume
+// mounted at the given path would shadow, or "" if it wouldn't shadow any.
+// The HomeDir can be mounted, for the steps to be able to mount their own home.
+func ReservedMountPathPrefix(mountPath string) string {
+	p := path.Clean(mountPath)
+	if p == HomeDir || strings.HasPrefix(p, HomeDir+"/") {
+		return ""
+	}
+	for _, prefix := range ReservedMountPathPrefixes {
+		if p == prefix || strings.HasPrefix(p, prefix+"/") {
+			return prefix
+		}
+	}
+	return ""
+}
+
+// IsReservedEnvVar returns whether the env var with the given name is
+// injected by Tekton.
+func IsReservedEnvVar(name string) bool {
+	return slices.Contains(ReservedEnvVars, name)
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipeline_test
+
+import (
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+)
+
+func TestReservedMountPathPrefix(t *testing.T) {
+	for _, tc := range []struct {
+		mountPath string
+		want      string
+	}{
+		{mountPath: "/tekton", want: "/tekton"},
+		{mountPath: "/tekton/", want: "/tekton"},
+		{mountPath: "/tekton/results", want: "/tekton"},
+		{mountPath: "/tekton/results/", want: "/tekton"},
+		{mountPath: "/workspace/../tekton/bin", want: "/tekton"},
+		{mountPath: "/var/run/tekton", want: "/var/run/tekton"},
+		{mountPath: "/var/run/../run/tekton", want: "/var/run/tekton"},
+		{mountPath: "/var/run/tekton/foo", want: "/var/run/tekton"},
+		{mountPath: "/tekton/home", want: ""},
+		{mountPath: "/tekton/home/.ssh", want: ""},
+		{mountPath: "/tektonfoo", want: ""},
+		{mountPath: "/var/run/secrets", want: ""},
+		{mountPath: "/workspace/source", want: ""},
+	} {
+		if got := pipeline.ReservedMountPathPrefix(tc.mountPath); got != tc.want {
+			t.Errorf("ReservedMountPathPrefix(%q) = %q, want %q", tc.mountPath, got, tc.want)
+		}
+	}
+}
+
+func TestIsReservedEnvVar(t *testing.T) {
+	for _, name := range pipeline.ReservedEnvVars {
+		if !pipeline.IsReservedEnvVar(name) {
+			t.Errorf("expected %s to be reserved", name)
+		}
+	}
+	if !pipeline.IsReservedEnvVar("TEKTON_STEP_STDIN_VALUE") {
+		t.Error("expected TEKTON_STEP_STDIN_VALUE to be reserved")
+	}
+	if pipeline.IsReservedEnvVar("HOME") {
+		t.Error("expected HOME not to be reserved")
+	}
+}
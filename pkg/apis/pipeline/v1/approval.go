@@ -0,0 +1,213 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"knative.dev/pkg/apis"
+)
+
+const (
+	// ApprovalAnnotationKeyPrefix is the prefix of the annotations of a
+	// PipelineRun holding the decision on its approval gates, followed by the
+	// name of the PipelineTask of the gate, e.g. "approvals.tekton.dev/deploy".
+	ApprovalAnnotationKeyPrefix = "approvals.tekton.dev/"
+	// ApproverAnnotationKeyPrefix is the prefix of the annotations of a
+	// PipelineRun holding the user who decided on its approval gates, followed
+	// by the name of the PipelineTask of the gate. They are set by the webhook
+	// from the user who set the decision.
+	ApproverAnnotationKeyPrefix = "approvers.tekton.dev/"
+
+	// ApprovalDecisionApproved is the decision approving an approval gate.
+	ApprovalDecisionApproved = "approved"
+	// ApprovalDecisionRejected is the decision rejecting an approval gate.
+	ApprovalDecisionRejected = "rejected"
+
+	// ApprovalVerb is the verb of the RBAC rules allowing users to decide on
+	// the approval gates of PipelineRuns, on the pipelineruns resource of the
+	// tekton.dev group. Being allowed to update a PipelineRun isn't enough.
+	ApprovalVerb = "approve"
+)
+
+// ApprovalAuthorizer returns whether the user is allowed to decide on the
+// approval gates of the PipelineRun of the namespace.
+type ApprovalAuthorizer func(ctx context.Context, user *authenticationv1.UserInfo, namespace, name string) (bool, error)
+
+// +k8s:openapi-gen=false
+// +k8s:deepcopy-gen=false
+type approvalAuthorizerKey struct{}
+
+// WithApprovalAuthorizer attaches the authorizer of the decisions on the
+// approval gates to the context, which ValidateApprovalAnnotations checks
+// the users setting them against.
+func WithApprovalAuthorizer(ctx context.Context, authorizer ApprovalAuthorizer) context.Context {
+	return context.WithValue(ctx, approvalAuthorizerKey{}, authorizer)
+}
+
+// ApprovalAuthorizerFromClient returns an ApprovalAuthorizer checking with a
+// SubjectAccessReview that the user is allowed the ApprovalVerb on the
+// PipelineRun.
+func ApprovalAuthorizerFromClient(client kubernetes.Interface) ApprovalAuthorizer {
+	return func(ctx context.Context, user *authenticationv1.UserInfo, namespace, name string) (bool, error) {
+		extra := map[string]authorizationv1.ExtraValue{}
+		for k, v := range user.Extra {
+			extra[k] = authorizationv1.ExtraValue(v)
+		}
+		review, err := client.AuthorizationV1().SubjectAccessReviews().Create(ctx, &authorizationv1.SubjectAccessReview{
+			Spec: authorizationv1.SubjectAccessReviewSpec{
+				User:   user.Username,
+				UID:    user.UID,
+				Groups: user.Groups,
+				Extra:  extra,
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Namespace: namespace,
+					Verb:      ApprovalVerb,
+					Group:     pipeline.PipelineRunResource.Group,
+					Resource:  pipeline.PipelineRunResource.Resource,
+					Name:      name,
+				},
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return false, err
+		}
+		return review.Status.Allowed, nil
+	}
+}
+
+// ApprovalDecision returns the decision on the approval gate of the given
+// PipelineTask from the annotations of its PipelineRun, and the user who made
+// it. The decision is empty while the gate is waiting for approval.
+func ApprovalDecision(annotations map[string]string, pipelineTaskName string) (decision, approver string) {
+	return annotations[ApprovalAnnotationKeyPrefix+pipelineTaskName], annotations[ApproverAnnotationKeyPrefix+pipelineTaskName]
+}
+
+// SetApprovers records the user who set each decision on the approval gates
+// of a PipelineRun in its approver annotations, and reverts any other change
+// of the approver annotations. It does nothing outside of the admission of the
+// PipelineRun, where the user is unknown.
+func SetApprovers(ctx context.Context, meta metav1.Object) {
+	user := apis.GetUserInfo(ctx)
+	if user == nil {
+		return
+	}
+	oldAnnotations := baselineAnnotations(ctx)
+
+	annotations := meta.GetAnnotations()
+	for key := range annotations {
+		if strings.HasPrefix(key, ApproverAnnotationKeyPrefix) {
+			delete(annotations, key)
+		}
+	}
+	for key, value := range oldAnnotations {
+		if strings.HasPrefix(key, ApproverAnnotationKeyPrefix) {
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+			annotations[key] = value
+		}
+	}
+	for key, decision := range annotations {
+		name, ok := strings.CutPrefix(key, ApprovalAnnotationKeyPrefix)
+		if !ok || decision == oldAnnotations[key] {
+			continue
+		}
+		annotations[ApproverAnnotationKeyPrefix+name] = user.Username
+	}
+	meta.SetAnnotations(annotations)
+}
+
+// ValidateApprovalAnnotations validates the decisions on the approval gates of
+// a PipelineRun: they can't be set at its creation, can only be set by the
+// users allowed the ApprovalVerb on it, and can't be changed or removed once
+// set.
+func ValidateApprovalAnnotations(ctx context.Context, meta metav1.Object) (errs *apis.FieldError) {
+	annotations := meta.GetAnnotations()
+	oldAnnotations := baselineAnnotations(ctx)
+	var decided []string
+	for key, decision := range annotations {
+		if !strings.HasPrefix(key, ApprovalAnnotationKeyPrefix) {
+			continue
+		}
+		if decision != ApprovalDecisionApproved && decision != ApprovalDecisionRejected {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s must be %q or %q but is %q", key, ApprovalDecisionApproved, ApprovalDecisionRejected, decision), "annotations"))
+		}
+		if apis.IsInCreate(ctx) {
+			errs = errs.Also(apis.ErrInvalidValue(key+" can't be set at the creation of the PipelineRun", "annotations"))
+		} else if _, ok := oldAnnotations[key]; !ok {
+			decided = append(decided, key)
+		}
+	}
+	if len(decided) > 0 {
+		errs = errs.Also(authorizeApprovals(ctx, meta, decided))
+	}
+
+	for key, oldDecision := range oldAnnotations {
+		if !strings.HasPrefix(key, ApprovalAnnotationKeyPrefix) {
+			continue
+		}
+		if decision, ok := annotations[key]; !ok || decision != oldDecision {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s can't be changed or removed once set", key), "annotations"))
+		}
+	}
+	return errs
+}
+
+// authorizeApprovals checks that the user of the admission, if any, is
+// allowed to set the decisions of the keys.
+func authorizeApprovals(ctx context.Context, meta metav1.Object, keys []string) *apis.FieldError {
+	user := apis.GetUserInfo(ctx)
+	if user == nil {
+		return nil
+	}
+	sort.Strings(keys)
+	authorize, ok := ctx.Value(approvalAuthorizerKey{}).(ApprovalAuthorizer)
+	if !ok || authorize == nil {
+		return apis.ErrInvalidValue(fmt.Sprintf("%s can't be set, the approvers can't be authorized", strings.Join(keys, ", ")), "annotations")
+	}
+	allowed, err := authorize(ctx, user, meta.GetNamespace(), meta.GetName())
+	if err != nil {
+		return apis.ErrInvalidValue(fmt.Sprintf("%s can't be set, failed to authorize %q: %v", strings.Join(keys, ", "), user.Username, err), "annotations")
+	}
+	if !allowed {
+		return apis.ErrInvalidValue(fmt.Sprintf("%s can't be set, %q isn't allowed to %s the PipelineRun", strings.Join(keys, ", "), user.Username, ApprovalVerb), "annotations")
+	}
+	return nil
+}
+
+// baselineAnnotations returns the annotations of the object being updated,
+// nil outside of an update.
+func baselineAnnotations(ctx context.Context) map[string]string {
+	if !apis.IsInUpdate(ctx) {
+		return nil
+	}
+	old, ok := apis.GetBaseline(ctx).(metav1.Object)
+	if !ok || old == nil || reflect.ValueOf(old).IsNil() {
+		return nil
+	}
+	return old.GetAnnotations()
+}
@@ -0,0 +1,274 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test/diff"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"knative.dev/pkg/apis"
+)
+
+func approvalPipelineRun(annotations map[string]string) *v1.PipelineRun {
+	return &v1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "pipelinerun",
+			Annotations: annotations,
+		},
+		Spec: v1.PipelineRunSpec{
+			PipelineRef: &v1.PipelineRef{Name: "pipeline"},
+		},
+	}
+}
+
+func TestPipelineRun_SetDefaults_Approvers(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		baseline    map[string]string
+		annotations map[string]string
+		want        map[string]string
+	}{{
+		name:        "decision set on create",
+		annotations: map[string]string{"approvals.tekton.dev/deploy": "approved"},
+		want: map[string]string{
+			"approvals.tekton.dev/deploy": "approved",
+			"approvers.tekton.dev/deploy": "alice",
+		},
+	}, {
+		name:     "decision set on update",
+		baseline: map[string]string{"foo": "bar"},
+		annotations: map[string]string{
+			"foo":                         "bar",
+			"approvals.tekton.dev/deploy": "rejected",
+		},
+		want: map[string]string{
+			"foo":                         "bar",
+			"approvals.tekton.dev/deploy": "rejected",
+			"approvers.tekton.dev/deploy": "alice",
+		},
+	}, {
+		name: "approver of an unchanged decision kept",
+		baseline: map[string]string{
+			"approvals.tekton.dev/deploy": "approved",
+			"approvers.tekton.dev/deploy": "bob",
+		},
+		annotations: map[string]string{
+			"approvals.tekton.dev/deploy": "approved",
+			"approvers.tekton.dev/deploy": "mallory",
+		},
+		want: map[string]string{
+			"approvals.tekton.dev/deploy": "approved",
+			"approvers.tekton.dev/deploy": "bob",
+		},
+	}, {
+		name:        "approver without a decision removed",
+		annotations: map[string]string{"approvers.tekton.dev/deploy": "mallory"},
+		want:        map[string]string{},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := apis.WithUserInfo(t.Context(), &authenticationv1.UserInfo{Username: "alice"})
+			if tc.baseline != nil {
+				ctx = apis.WithinUpdate(ctx, approvalPipelineRun(tc.baseline))
+			} else {
+				ctx = apis.WithinCreate(ctx)
+			}
+			pr := approvalPipelineRun(tc.annotations)
+			pr.SetDefaults(ctx)
+			if d := cmp.Diff(tc.want, pr.Annotations); d != "" {
+				t.Errorf("unexpected annotations: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineRun_SetDefaults_ApproversWithoutUser(t *testing.T) {
+	annotations := map[string]string{
+		"approvals.tekton.dev/deploy": "approved",
+		"approvers.tekton.dev/deploy": "bob",
+	}
+	pr := approvalPipelineRun(annotations)
+	pr.SetDefaults(t.Context())
+	if d := cmp.Diff(annotations, pr.Annotations); d != "" {
+		t.Errorf("unexpected annotations: %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestPipelineRun_Validate_ApprovalAnnotations(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		baseline    map[string]string
+		annotations map[string]string
+		wantErr     string
+	}{{
+		name:        "approved",
+		baseline:    map[string]string{},
+		annotations: map[string]string{"approvals.tekton.dev/deploy": "approved"},
+	}, {
+		name:        "rejected on update",
+		baseline:    map[string]string{"approvals.tekton.dev/test": "approved"},
+		annotations: map[string]string{"approvals.tekton.dev/test": "approved", "approvals.tekton.dev/deploy": "rejected"},
+	}, {
+		name:        "invalid decision",
+		baseline:    map[string]string{},
+		annotations: map[string]string{"approvals.tekton.dev/deploy": "yes"},
+		wantErr:     `invalid value: approvals.tekton.dev/deploy must be "approved" or "rejected" but is "yes": metadata.annotations`,
+	}, {
+		name:        "decision changed",
+		baseline:    map[string]string{"approvals.tekton.dev/deploy": "approved"},
+		annotations: map[string]string{"approvals.tekton.dev/deploy": "rejected"},
+		wantErr:     "invalid value: approvals.tekton.dev/deploy can't be changed or removed once set: metadata.annotations",
+	}, {
+		name:        "decision removed",
+		baseline:    map[string]string{"approvals.tekton.dev/deploy": "rejected"},
+		annotations: map[string]string{},
+		wantErr:     "invalid value: approvals.tekton.dev/deploy can't be changed or removed once set: metadata.annotations",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := t.Context()
+			if tc.baseline != nil {
+				ctx = apis.WithinUpdate(ctx, approvalPipelineRun(tc.baseline))
+			}
+			err := approvalPipelineRun(tc.annotations).Validate(ctx)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error %q, got nil", tc.wantErr)
+			}
+			if d := cmp.Diff(tc.wantErr, err.Error()); d != "" {
+				t.Errorf("unexpected error: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineRun_Validate_ApprovalAuthorization(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		create      bool
+		baseline    map[string]string
+		annotations map[string]string
+		authorizer  v1.ApprovalAuthorizer
+		wantErr     string
+	}{{
+		name:        "decision set on create",
+		create:      true,
+		annotations: map[string]string{"approvals.tekton.dev/deploy": "approved"},
+		authorizer:  allowApprovals(true),
+		wantErr:     "invalid value: approvals.tekton.dev/deploy can't be set at the creation of the PipelineRun: metadata.annotations",
+	}, {
+		name:        "allowed approver",
+		baseline:    map[string]string{},
+		annotations: map[string]string{"approvals.tekton.dev/deploy": "approved"},
+		authorizer:  allowApprovals(true),
+	}, {
+		name:        "unchanged decision of another approver",
+		baseline:    map[string]string{"approvals.tekton.dev/deploy": "approved"},
+		annotations: map[string]string{"approvals.tekton.dev/deploy": "approved", "foo": "bar"},
+		authorizer:  allowApprovals(false),
+	}, {
+		name:        "approver not allowed",
+		baseline:    map[string]string{},
+		annotations: map[string]string{"approvals.tekton.dev/deploy": "approved"},
+		authorizer:  allowApprovals(false),
+		wantErr:     `invalid value: approvals.tekton.dev/deploy can't be set, "alice" isn't allowed to approve the PipelineRun: metadata.annotations`,
+	}, {
+		name:        "no authorizer",
+		baseline:    map[string]string{},
+		annotations: map[string]string{"approvals.tekton.dev/deploy": "approved"},
+		wantErr:     "invalid value: approvals.tekton.dev/deploy can't be set, the approvers can't be authorized: metadata.annotations",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := apis.WithUserInfo(t.Context(), &authenticationv1.UserInfo{Username: "alice"})
+			if tc.authorizer != nil {
+				ctx = v1.WithApprovalAuthorizer(ctx, tc.authorizer)
+			}
+			if tc.create {
+				ctx = apis.WithinCreate(ctx)
+			} else {
+				ctx = apis.WithinUpdate(ctx, approvalPipelineRun(tc.baseline))
+			}
+			err := approvalPipelineRun(tc.annotations).Validate(ctx)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error %q, got nil", tc.wantErr)
+			}
+			if d := cmp.Diff(tc.wantErr, err.Error()); d != "" {
+				t.Errorf("unexpected error: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+// allowApprovals returns an ApprovalAuthorizer allowing the decisions of
+// alice on the PipelineRun, or not.
+func allowApprovals(allowed bool) v1.ApprovalAuthorizer {
+	return func(_ context.Context, user *authenticationv1.UserInfo, _, name string) (bool, error) {
+		return allowed && user.Username == "alice" && name == "pipelinerun", nil
+	}
+}
+
+func TestApprovalAuthorizerFromClient(t *testing.T) {
+	client := fakek8s.NewSimpleClientset()
+	var got *authorizationv1.SubjectAccessReview
+	client.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		got = action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		review := got.DeepCopy()
+		review.Status.Allowed = true
+		return true, review, nil
+	})
+	user := &authenticationv1.UserInfo{Username: "alice", Groups: []string{"approvers"}}
+	allowed, err := v1.ApprovalAuthorizerFromClient(client)(t.Context(), user, "ns", "pipelinerun")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !allowed {
+		t.Error("expected the approver to be allowed")
+	}
+	want := authorizationv1.SubjectAccessReviewSpec{
+		User:   "alice",
+		Groups: []string{"approvers"},
+		Extra:  map[string]authorizationv1.ExtraValue{},
+		ResourceAttributes: &authorizationv1.ResourceAttributes{
+			Namespace: "ns",
+			Verb:      "approve",
+			Group:     "tekton.dev",
+			Resource:  "pipelineruns",
+			Name:      "pipelinerun",
+		},
+	}
+	if d := cmp.Diff(want, got.Spec); d != "" {
+		t.Errorf("unexpected SubjectAccessReview: %s", diff.PrintWantGot(d))
+	}
+}
@@ -28,7 +28,9 @@ import (
 	"github.com/tektoncd/pipeline/internal/artifactref"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/internal/computeresources/compare"
 	"github.com/tektoncd/pipeline/pkg/internal/resultref"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"knative.dev/pkg/apis"
 )
@@ -39,9 +41,30 @@ func (ref *Ref) Validate(ctx context.Context) (errs *apis.FieldError) {
 	if ref == nil {
 		return errs
 	}
+	if ref.Version != "" {
+		return ref.validateVersion()
+	}
 	return validateRef(ctx, ref.Name, ref.Resolver, ref.Params)
 }
 
+// validateVersion validates a Ref to a version of a StepAction, which is
+// resolved with the default StepAction resolver rather than an explicit one.
+func (ref *Ref) validateVersion() (errs *apis.FieldError) {
+	if ref.Resolver != "" {
+		errs = errs.Also(apis.ErrMultipleOneOf("version", "resolver"))
+	}
+	if ref.Params != nil {
+		errs = errs.Also(apis.ErrMultipleOneOf("version", "params"))
+	}
+	if ref.Name == "" {
+		return errs.Also(apis.ErrMissingField("name"))
+	}
+	if errSlice := validation.IsQualifiedName(ref.Name); len(errSlice) != 0 {
+		errs = errs.Also(apis.ErrInvalidValue(strings.Join(errSlice, ","), "name"))
+	}
+	return errs
+}
+
 func validateRef(ctx context.Context, refName string, refResolver ResolverName, refParams Params) (errs *apis.FieldError) {
 	switch {
 	case refResolver != "" || refParams != nil:
@@ -135,6 +158,12 @@ func (s *Step) Validate(ctx context.Context) (errs *apis.FieldError) {
 				Paths:   []string{"script"},
 			})
 		}
+		if s.ScriptFrom != nil {
+			errs = errs.Also(&apis.FieldError{
+				Message: "scriptFrom cannot be used with Ref",
+				Paths:   []string{"scriptFrom"},
+			})
+		}
 		if s.WorkingDir != "" {
 			errs = errs.Also(&apis.FieldError{
 				Message: "working dir cannot be used with Ref",
@@ -190,21 +219,18 @@ func (s *Step) Validate(ctx context.Context) (errs *apis.FieldError) {
 		}
 	}
 
+	errs = errs.Also(validateStepContainerName(s.ContainerName))
+
 	if s.Timeout != nil {
 		if s.Timeout.Duration < time.Duration(0) {
 			return apis.ErrInvalidValue(s.Timeout.Duration, "negative timeout")
 		}
 	}
 
-	for j, vm := range s.VolumeMounts {
-		if strings.HasPrefix(vm.MountPath, "/tekton/") &&
-			!strings.HasPrefix(vm.MountPath, "/tekton/home") {
-			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("volumeMount cannot be mounted under /tekton/ (volumeMount %q mounted at %q)", vm.Name, vm.MountPath), "mountPath").ViaFieldIndex("volumeMounts", j))
-		}
-		if strings.HasPrefix(vm.Name, "tekton-internal-") {
-			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf(`volumeMount name %q cannot start with "tekton-internal-"`, vm.Name), "name").ViaFieldIndex("volumeMounts", j))
-		}
-	}
+	errs = errs.Also(validateReservedVolumeMounts(s.VolumeMounts))
+	errs = errs.Also(validateReservedEnvVars(s.Env))
+	errs = errs.Also(validateSecurityContextProfiles(ctx, s.SecurityContext))
+	errs = errs.Also(validateExtendedResources(s.ComputeResources).ViaField("computeResources"))
 
 	if s.OnError != "" {
 		if !isParamRefs(string(s.OnError)) && s.OnError != Continue && s.OnError != StopAndFail {
@@ -233,6 +259,18 @@ func (s *Step) Validate(ctx context.Context) (errs *apis.FieldError) {
 	if s.StderrConfig != nil {
 		errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "step stderr stream support", config.AlphaAPIFields).ViaField("stderrconfig"))
 	}
+	// StdinFrom is an alpha feature and will fail validation if it's used in a task spec
+	// when the enable-api-fields feature gate is not "alpha".
+	if s.StdinFrom != nil {
+		errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "step stdin support", config.AlphaAPIFields).ViaField("stdinFrom"))
+		errs = errs.Also(validateStepStdinFrom(s.StdinFrom).ViaField("stdinFrom"))
+	}
+	// ScriptFrom is an alpha feature and will fail validation if it's used in a task spec
+	// when the enable-api-fields feature gate is not "alpha".
+	if s.ScriptFrom != nil {
+		errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "step scriptFrom", config.AlphaAPIFields).ViaField("scriptFrom"))
+		errs = errs.Also(validateStepScriptFrom(s))
+	}
 
 	// Validate usage of step result reference.
 	// Referencing previous step's results are only allowed in `env`, `command` and `args`.
@@ -377,6 +415,10 @@ func (sc *Sidecar) Validate(ctx context.Context) (errs *apis.FieldError) {
 		errs = errs.Also(apis.ErrMissingField("image"))
 	}
 
+	errs = errs.Also(validateReservedVolumeMounts(sc.VolumeMounts))
+	errs = errs.Also(validateSecurityContextProfiles(ctx, sc.SecurityContext))
+	errs = errs.Also(validateExtendedResources(sc.ComputeResources).ViaField("computeResources"))
+
 	if sc.Script != "" {
 		if len(sc.Command) > 0 {
 			errs = errs.Also(&apis.FieldError{
@@ -387,3 +429,136 @@ func (sc *Sidecar) Validate(ctx context.Context) (errs *apis.FieldError) {
 	}
 	return errs
 }
+
+// validateExtendedResources validates that the extended resources, like
+// nvidia.com/gpu, are requested in integer amounts, as device plugins can't
+// allocate a fraction of a device.
+func validateExtendedResources(resources corev1.ResourceRequirements) (errs *apis.FieldError) {
+	for field, list := range map[string]corev1.ResourceList{"requests": resources.Requests, "limits": resources.Limits} {
+		for name, q := range list {
+			if compare.IsExtendedResource(name) && q.MilliValue()%1000 != 0 {
+				errs = errs.Also(apis.ErrInvalidValue(q.String(), field+"."+string(name), "extended resources must be requested in integer amounts"))
+			}
+		}
+	}
+	return errs
+}
+
+// validateStepContainerName validates the custom name of the container of a
+// Step, if any.
+func validateStepContainerName(name string) *apis.FieldError {
+	if name == "" {
+		return nil
+	}
+	if e := validation.IsDNS1123Label(name); len(e) > 0 {
+		return &apis.FieldError{
+			Message: fmt.Sprintf("invalid value %q", name),
+			Paths:   []string{"containerName"},
+			Details: "Step container name must be a valid DNS Label, For more info refer to https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names",
+		}
+	}
+	if strings.HasPrefix(name, sidecarContainerPrefix) {
+		return apis.ErrInvalidValue(fmt.Sprintf("%q must not start with %q which is reserved for the containers of sidecars", name, sidecarContainerPrefix), "containerName")
+	}
+	return nil
+}
+
+// validateStepStdinFrom validates that exactly one source of the standard
+// input of a step is set.
+func validateStepStdinFrom(stdin *StepStdinSource) *apis.FieldError {
+	switch {
+	case stdin.Value != "" && stdin.Path != "":
+		return apis.ErrMultipleOneOf("value", "path")
+	case stdin.Value == "" && stdin.Path == "":
+		return apis.ErrMissingOneOf("value", "path")
+	}
+	return nil
+}
+
+// validateStepScriptFrom validates that exactly one source of the script of a
+// step is set, and that the step has neither an inline script nor a command.
+func validateStepScriptFrom(s *Step) (errs *apis.FieldError) {
+	if s.Script != "" {
+		errs = errs.Also(apis.ErrMultipleOneOf("script", "scriptFrom"))
+	}
+	if len(s.Command) > 0 {
+		errs = errs.Also(&apis.FieldError{
+			Message: "scriptFrom cannot be used with command",
+			Paths:   []string{"scriptFrom"},
+		})
+	}
+	source := s.ScriptFrom
+	switch {
+	case source.Workspace != nil && source.ConfigMap != nil:
+		errs = errs.Also(apis.ErrMultipleOneOf("scriptFrom.workspace", "scriptFrom.configMap"))
+	case source.Workspace != nil:
+		if source.Workspace.Name == "" {
+			errs = errs.Also(apis.ErrMissingField("scriptFrom.workspace.name"))
+		}
+		if p := source.Workspace.Path; p == "" {
+			errs = errs.Also(apis.ErrMissingField("scriptFrom.workspace.path"))
+		} else if !pipeline.IsRelativeSubPath(p) {
+			errs = errs.Also(apis.ErrInvalidValue(p, "scriptFrom.workspace.path", "must be a relative path within the workspace"))
+		}
+	case source.ConfigMap != nil:
+		if source.ConfigMap.Name == "" {
+			errs = errs.Also(apis.ErrMissingField("scriptFrom.configMap.name"))
+		}
+		if source.ConfigMap.Key == "" {
+			errs = errs.Also(apis.ErrMissingField("scriptFrom.configMap.key"))
+		}
+	default:
+		errs = errs.Also(apis.ErrMissingOneOf("scriptFrom.workspace", "scriptFrom.configMap"))
+	}
+	return errs
+}
+
+// validateReservedVolumeMounts validates that the volumeMounts of a Step or
+// Sidecar don't shadow the volumes mounted by Tekton.
+func validateReservedVolumeMounts(volumeMounts []corev1.VolumeMount) (errs *apis.FieldError) {
+	for j, vm := range volumeMounts {
+		if prefix := pipeline.ReservedMountPathPrefix(vm.MountPath); prefix != "" {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("volumeMount cannot be mounted under %s/ (volumeMount %q mounted at %q)", prefix, vm.Name, vm.MountPath), "mountPath").ViaFieldIndex("volumeMounts", j))
+		}
+		if strings.HasPrefix(vm.Name, "tekton-internal-") {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf(`volumeMount name %q cannot start with "tekton-internal-"`, vm.Name), "name").ViaFieldIndex("volumeMounts", j))
+		}
+	}
+	return errs
+}
+
+// validateReservedEnvVars validates that the env vars of a Step don't override
+// the ones injected by Tekton for the entrypoint.
+func validateReservedEnvVars(env []corev1.EnvVar) (errs *apis.FieldError) {
+	for j, e := range env {
+		if pipeline.IsReservedEnvVar(e.Name) {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("env var %q is reserved for Tekton", e.Name), "name").ViaFieldIndex("env", j))
+		}
+	}
+	return errs
+}
+
+// validateSecurityContextProfiles validates that the securityContext of a
+// Step or Sidecar doesn't use Localhost profiles when they are forbidden.
+func validateSecurityContextProfiles(ctx context.Context, securityContext *corev1.SecurityContext) *apis.FieldError {
+	if securityContext == nil {
+		return nil
+	}
+	return validateLocalhostProfiles(ctx, securityContext.SeccompProfile, securityContext.AppArmorProfile).ViaField("securityContext")
+}
+
+// validateLocalhostProfiles validates that the seccomp and AppArmor profiles
+// aren't Localhost profiles, loaded on the nodes, when the
+// forbid-localhost-profiles config is set.
+func validateLocalhostProfiles(ctx context.Context, seccompProfile *corev1.SeccompProfile, appArmorProfile *corev1.AppArmorProfile) (errs *apis.FieldError) {
+	if !config.FromContextOrDefaults(ctx).Defaults.ForbidLocalhostProfiles {
+		return nil
+	}
+	if seccompProfile != nil && seccompProfile.Type == corev1.SeccompProfileTypeLocalhost {
+		errs = errs.Also(apis.ErrInvalidValue("Localhost profiles are forbidden by the forbid-localhost-profiles config", "seccompProfile.type"))
+	}
+	if appArmorProfile != nil && appArmorProfile.Type == corev1.AppArmorProfileTypeLocalhost {
+		errs = errs.Also(apis.ErrInvalidValue("Localhost profiles are forbidden by the forbid-localhost-profiles config", "appArmorProfile.type"))
+	}
+	return errs
+}
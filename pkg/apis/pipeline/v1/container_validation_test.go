@@ -30,6 +30,7 @@ import (
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 )
@@ -53,6 +54,9 @@ func TestRef_Valid(t *testing.T) {
 	}, {
 		name: "simple ref",
 		ref:  &v1.Ref{Name: "refname"},
+	}, {
+		name: "versioned ref",
+		ref:  &v1.Ref{Name: "build-push", Version: "0.3"},
 	}, {
 		name: "ref name - concise syntax",
 		ref:  &v1.Ref{Name: "foo://baz:ver", ResolverRef: v1.ResolverRef{Resolver: "git"}},
@@ -104,6 +108,29 @@ func TestRef_Invalid(t *testing.T) {
 		name:    "missing ref name",
 		ref:     &v1.Ref{},
 		wantErr: apis.ErrMissingField("name"),
+	}, {
+		name:    "versioned ref without name",
+		ref:     &v1.Ref{Version: "0.3"},
+		wantErr: apis.ErrMissingField("name"),
+	}, {
+		name: "versioned ref with resolver",
+		ref: &v1.Ref{
+			Name:        "build-push",
+			Version:     "0.3",
+			ResolverRef: v1.ResolverRef{Resolver: "git"},
+		},
+		wantErr: apis.ErrMultipleOneOf("version", "resolver"),
+	}, {
+		name: "versioned ref with resolver params",
+		ref: &v1.Ref{
+			Name:    "build-push",
+			Version: "0.3",
+			ResolverRef: v1.ResolverRef{
+				Resolver: "hub",
+				Params:   v1.Params{{Name: "name", Value: v1.ParamValue{Type: v1.ParamTypeString, StringVal: "build-push"}}},
+			},
+		},
+		wantErr: apis.ErrMultipleOneOf("version", "resolver").Also(apis.ErrMultipleOneOf("version", "params")),
 	}, {
 		name: "ref params disallowed without resolver",
 		ref: &v1.Ref{
@@ -209,6 +236,24 @@ func TestStepValidate(t *testing.T) {
 				MountPath: "/tekton/home",
 			}},
 		},
+	}, {
+		name: "valid step with volumeMount under /workspace",
+		Step: v1.Step{
+			Image: "myimage",
+			VolumeMounts: []corev1.VolumeMount{{
+				Name:      "foo",
+				MountPath: "/workspace/foo",
+			}},
+		},
+	}, {
+		name: "valid step with a GPU",
+		Step: v1.Step{
+			Image: "myimage",
+			ComputeResources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), "nvidia.com/gpu": resource.MustParse("1")},
+				Limits:   corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("1")},
+			},
+		},
 	}}
 	for _, st := range tests {
 		t.Run(st.name, func(t *testing.T) {
@@ -267,6 +312,61 @@ func TestStepValidateError(t *testing.T) {
 			Message: `volumeMount cannot be mounted under /tekton/ (volumeMount "foo" mounted at "/tekton/foo")`,
 			Paths:   []string{"volumeMounts[0].mountPath"},
 		},
+	}, {
+		name: "step volume mounts at /tekton/results",
+		Step: v1.Step{
+			Image: "myimage",
+			VolumeMounts: []corev1.VolumeMount{{
+				Name:      "foo",
+				MountPath: "/tekton/results/",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `volumeMount cannot be mounted under /tekton/ (volumeMount "foo" mounted at "/tekton/results/")`,
+			Paths:   []string{"volumeMounts[0].mountPath"},
+		},
+	}, {
+		name: "step volume mounts under /var/run/tekton/",
+		Step: v1.Step{
+			Image: "myimage",
+			VolumeMounts: []corev1.VolumeMount{{
+				Name:      "foo",
+				MountPath: "/var/run/tekton/foo",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `volumeMount cannot be mounted under /var/run/tekton/ (volumeMount "foo" mounted at "/var/run/tekton/foo")`,
+			Paths:   []string{"volumeMounts[0].mountPath"},
+		},
+	}, {
+		name: "step volume mount at /tekton",
+		Step: v1.Step{
+			Image: "myimage",
+			VolumeMounts: []corev1.VolumeMount{{
+				Name:      "foo",
+				MountPath: "/tekton",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `volumeMount cannot be mounted under /tekton/ (volumeMount "foo" mounted at "/tekton")`,
+			Paths:   []string{"volumeMounts[0].mountPath"},
+		},
+	}, {
+		name: "step env var injected by Tekton",
+		Step: v1.Step{
+			Image: "myimage",
+			Env: []corev1.EnvVar{{
+				Name:  "FOO",
+				Value: "bar",
+			}, {
+				Name:  "TEKTON_HERMETIC",
+				Value: "0",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `env var "TEKTON_HERMETIC" is reserved for Tekton`,
+			Paths:   []string{"env[1].name"},
+		},
 	}, {
 		name: "step volume mount name starts with tekton-internal-",
 		Step: v1.Step{
@@ -289,6 +389,108 @@ func TestStepValidateError(t *testing.T) {
 			Message: "invalid value: -10s",
 			Paths:   []string{"negative timeout"},
 		},
+	}, {
+		name: "stdin fed from both a value and a file",
+		Step: v1.Step{
+			Image: "myimage",
+			StdinFrom: &v1.StepStdinSource{
+				Value: "$(params.input)",
+				Path:  "$(workspaces.source.path)/input.json",
+			},
+		},
+		expectedError: apis.FieldError{
+			Message: "expected exactly one, got both",
+			Paths:   []string{"stdinFrom.path", "stdinFrom.value"},
+		},
+	}, {
+		name: "stdin without source",
+		Step: v1.Step{
+			Image:     "myimage",
+			StdinFrom: &v1.StepStdinSource{},
+		},
+		expectedError: apis.FieldError{
+			Message: "expected exactly one, got neither",
+			Paths:   []string{"stdinFrom.path", "stdinFrom.value"},
+		},
+	}, {
+		name: "script from both a workspace and a configMap",
+		Step: v1.Step{
+			Image: "myimage",
+			ScriptFrom: &v1.StepScriptSource{
+				Workspace: &v1.WorkspaceFileSelector{Name: "source", Path: "build.sh"},
+				ConfigMap: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "scripts"}, Key: "build.sh"},
+			},
+		},
+		expectedError: apis.FieldError{
+			Message: "expected exactly one, got both",
+			Paths:   []string{"scriptFrom.configMap", "scriptFrom.workspace"},
+		},
+	}, {
+		name: "script from without source",
+		Step: v1.Step{
+			Image:      "myimage",
+			ScriptFrom: &v1.StepScriptSource{},
+		},
+		expectedError: apis.FieldError{
+			Message: "expected exactly one, got neither",
+			Paths:   []string{"scriptFrom.configMap", "scriptFrom.workspace"},
+		},
+	}, {
+		name: "script from combined with an inline script",
+		Step: v1.Step{
+			Image:      "myimage",
+			Script:     "echo hello",
+			ScriptFrom: &v1.StepScriptSource{Workspace: &v1.WorkspaceFileSelector{Name: "source", Path: "build.sh"}},
+		},
+		expectedError: apis.FieldError{
+			Message: "expected exactly one, got both",
+			Paths:   []string{"script", "scriptFrom"},
+		},
+	}, {
+		name: "script from combined with a command",
+		Step: v1.Step{
+			Image:      "myimage",
+			Command:    []string{"make"},
+			ScriptFrom: &v1.StepScriptSource{Workspace: &v1.WorkspaceFileSelector{Name: "source", Path: "build.sh"}},
+		},
+		expectedError: apis.FieldError{
+			Message: "scriptFrom cannot be used with command",
+			Paths:   []string{"scriptFrom"},
+		},
+	}, {
+		name: "script from a path outside of the workspace",
+		Step: v1.Step{
+			Image:      "myimage",
+			ScriptFrom: &v1.StepScriptSource{Workspace: &v1.WorkspaceFileSelector{Name: "source", Path: "../build.sh"}},
+		},
+		expectedError: apis.FieldError{
+			Message: "invalid value: ../build.sh",
+			Paths:   []string{"scriptFrom.workspace.path"},
+			Details: "must be a relative path within the workspace",
+		},
+	}, {
+		name: "script from a configMap without key",
+		Step: v1.Step{
+			Image:      "myimage",
+			ScriptFrom: &v1.StepScriptSource{ConfigMap: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "scripts"}}},
+		},
+		expectedError: apis.FieldError{
+			Message: "missing field(s)",
+			Paths:   []string{"scriptFrom.configMap.key"},
+		},
+	}, {
+		name: "fraction of a GPU",
+		Step: v1.Step{
+			Image: "myimage",
+			ComputeResources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("500m")},
+			},
+		},
+		expectedError: apis.FieldError{
+			Message: "invalid value: 500m",
+			Paths:   []string{"computeResources.requests.nvidia.com/gpu"},
+			Details: "extended resources must be requested in integer amounts",
+		},
 	}}
 	for _, st := range tests {
 		t.Run(st.name, func(t *testing.T) {
@@ -758,6 +960,24 @@ func TestStepIncompatibleAPIVersions(t *testing.T) {
 					Path: "/tmp/stderr.txt",
 				},
 			},
+		}, {
+			name:            "stdin support requires alpha",
+			requiredVersion: "alpha",
+			step: v1.Step{
+				Image: "foo",
+				StdinFrom: &v1.StepStdinSource{
+					Path: "/tmp/stdin.txt",
+				},
+			},
+		}, {
+			name:            "scriptFrom requires alpha",
+			requiredVersion: "alpha",
+			step: v1.Step{
+				Image: "foo",
+				ScriptFrom: &v1.StepScriptSource{
+					Workspace: &v1.WorkspaceFileSelector{Name: "source", Path: "build.sh"},
+				},
+			},
 		},
 	} {
 		for _, version := range versions {
@@ -1032,6 +1252,16 @@ func TestSidecarValidate(t *testing.T) {
 			Name:  "my-sidecar",
 			Image: "my-image",
 		},
+	}, {
+		name: "valid sidecar with volumeMount under /workspace",
+		sidecar: v1.Sidecar{
+			Name:  "my-sidecar",
+			Image: "my-image",
+			VolumeMounts: []corev1.VolumeMount{{
+				Name:      "foo",
+				MountPath: "/workspace/foo",
+			}},
+		},
 	}}
 
 	for _, sct := range tests {
@@ -1082,6 +1312,20 @@ func TestSidecarValidateError(t *testing.T) {
 			Message: "script cannot be used with command",
 			Paths:   []string{"script"},
 		},
+	}, {
+		name: "sidecar volume mounts under /tekton/",
+		sidecar: v1.Sidecar{
+			Name:  "my-sidecar",
+			Image: "my-image",
+			VolumeMounts: []corev1.VolumeMount{{
+				Name:      "foo",
+				MountPath: "/tekton/run",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `volumeMount cannot be mounted under /tekton/ (volumeMount "foo" mounted at "/tekton/run")`,
+			Paths:   []string{"volumeMounts[0].mountPath"},
+		},
 	}}
 
 	for _, sct := range tests {
@@ -0,0 +1,211 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// InterfaceDigestAnnotationKey is the annotation set to the digest of the
+// interface of the Tasks, Pipelines and StepActions when the
+// "enable-interface-digest" feature flag is enabled.
+const InterfaceDigestAnnotationKey = pipeline.GroupName + "/interface-digest"
+
+// InterfaceDoc is the machine-readable description of the interface of a
+// Task, Pipeline or StepAction: the params it accepts, the results it
+// produces and the workspaces it needs. The params, results and workspaces
+// are sorted by name and their types are defaulted, so that two specs
+// declaring the same interface have the same InterfaceDoc.
+// +k8s:openapi-gen=false
+// +k8s:deepcopy-gen=false
+type InterfaceDoc struct {
+	Params []InterfaceParam `json:"params,omitempty"`
+	// ParamsFrom are the names of the ParameterSets whose params are imported
+	// into the spec when it runs.
+	ParamsFrom []string             `json:"paramsFrom,omitempty"`
+	Results    []InterfaceResult    `json:"results,omitempty"`
+	Workspaces []InterfaceWorkspace `json:"workspaces,omitempty"`
+}
+
+// InterfaceParam describes a param of an InterfaceDoc.
+// +k8s:openapi-gen=false
+// +k8s:deepcopy-gen=false
+type InterfaceParam struct {
+	Name        string                  `json:"name"`
+	Type        ParamType               `json:"type"`
+	Description string                  `json:"description,omitempty"`
+	Properties  map[string]PropertySpec `json:"properties,omitempty"`
+	Default     *ParamValue             `json:"default,omitempty"`
+	Enum        []string                `json:"enum,omitempty"`
+}
+
+// InterfaceResult describes a result of an InterfaceDoc.
+// +k8s:openapi-gen=false
+// +k8s:deepcopy-gen=false
+type InterfaceResult struct {
+	Name        string                  `json:"name"`
+	Type        ResultsType             `json:"type"`
+	Description string                  `json:"description,omitempty"`
+	Properties  map[string]PropertySpec `json:"properties,omitempty"`
+}
+
+// InterfaceWorkspace describes a workspace of an InterfaceDoc.
+// +k8s:openapi-gen=false
+// +k8s:deepcopy-gen=false
+type InterfaceWorkspace struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Optional    bool   `json:"optional,omitempty"`
+	ReadOnly    bool   `json:"readOnly,omitempty"`
+}
+
+// InterfaceOf returns the InterfaceDoc of the TaskSpec.
+func InterfaceOf(ts TaskSpec) InterfaceDoc {
+	doc := InterfaceDoc{
+		Params:     interfaceParams(ts.Params),
+		ParamsFrom: paramSetNames(ts.ParamsFrom),
+	}
+	for _, r := range ts.Results {
+		r := *r.DeepCopy()
+		r.SetDefaults(context.Background())
+		doc.Results = append(doc.Results, InterfaceResult{
+			Name:        r.Name,
+			Type:        r.Type,
+			Description: r.Description,
+			Properties:  r.Properties,
+		})
+	}
+	for _, w := range ts.Workspaces {
+		doc.Workspaces = append(doc.Workspaces, InterfaceWorkspace{
+			Name:        w.Name,
+			Description: w.Description,
+			Optional:    w.Optional,
+			ReadOnly:    w.ReadOnly,
+		})
+	}
+	return doc.sorted()
+}
+
+// PipelineInterfaceOf returns the InterfaceDoc of the PipelineSpec.
+func PipelineInterfaceOf(ps PipelineSpec) InterfaceDoc {
+	doc := InterfaceDoc{
+		Params:     interfaceParams(ps.Params),
+		ParamsFrom: paramSetNames(ps.ParamsFrom),
+	}
+	for _, r := range ps.Results {
+		t := r.Type
+		if t == "" {
+			t = ResultsTypeString
+		}
+		doc.Results = append(doc.Results, InterfaceResult{
+			Name:        r.Name,
+			Type:        t,
+			Description: r.Description,
+		})
+	}
+	for _, w := range ps.Workspaces {
+		doc.Workspaces = append(doc.Workspaces, InterfaceWorkspace{
+			Name:        w.Name,
+			Description: w.Description,
+			Optional:    w.Optional,
+		})
+	}
+	return doc.sorted()
+}
+
+// StepInterfaceOf returns the InterfaceDoc of the params and results of a
+// StepAction.
+func StepInterfaceOf(params ParamSpecs, results []StepResult) InterfaceDoc {
+	doc := InterfaceDoc{Params: interfaceParams(params)}
+	for _, r := range results {
+		r := *r.DeepCopy()
+		r.SetDefaults(context.Background())
+		doc.Results = append(doc.Results, InterfaceResult{
+			Name:        r.Name,
+			Type:        r.Type,
+			Description: r.Description,
+			Properties:  r.Properties,
+		})
+	}
+	return doc.sorted()
+}
+
+// Digest returns the sha256 digest of the JSON of the InterfaceDoc, in the
+// "sha256:<hex>" format.
+func (doc InterfaceDoc) Digest() string {
+	// The InterfaceDoc only holds types which are always marshalled.
+	b, _ := json.Marshal(doc)
+	sum := sha256.Sum256(b)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// SetInterfaceDigest sets the interface digest annotation of the object to
+// the digest of the InterfaceDoc when the "enable-interface-digest" feature
+// flag is enabled, and removes it otherwise so that it is never stale.
+func SetInterfaceDigest(ctx context.Context, meta *metav1.ObjectMeta, doc InterfaceDoc) {
+	if !config.FromContextOrDefaults(ctx).FeatureFlags.EnableInterfaceDigest {
+		delete(meta.Annotations, InterfaceDigestAnnotationKey)
+		return
+	}
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+	meta.Annotations[InterfaceDigestAnnotationKey] = doc.Digest()
+}
+
+func interfaceParams(params ParamSpecs) []InterfaceParam {
+	var ips []InterfaceParam
+	for _, p := range params {
+		p := *p.DeepCopy()
+		p.SetDefaults(context.Background())
+		ips = append(ips, InterfaceParam{
+			Name:        p.Name,
+			Type:        p.Type,
+			Description: p.Description,
+			Properties:  p.Properties,
+			Default:     p.Default,
+			Enum:        p.Enum,
+		})
+	}
+	return ips
+}
+
+func paramSetNames(refs []ParameterSetRef) []string {
+	var names []string
+	for _, ref := range refs {
+		names = append(names, ref.Name)
+	}
+	return names
+}
+
+// sorted sorts the params, results and workspaces of the InterfaceDoc by
+// name. The ParameterSets are kept in order, as the params of a ParameterSet
+// take precedence over the ones of the next ParameterSets.
+func (doc InterfaceDoc) sorted() InterfaceDoc {
+	sort.Slice(doc.Params, func(i, j int) bool { return doc.Params[i].Name < doc.Params[j].Name })
+	sort.Slice(doc.Results, func(i, j int) bool { return doc.Results[i].Name < doc.Results[j].Name })
+	sort.Slice(doc.Workspaces, func(i, j int) bool { return doc.Workspaces[i].Name < doc.Workspaces[j].Name })
+	return doc
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	cfgtesting "github.com/tektoncd/pipeline/pkg/apis/config/testing"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func assertGoldenInterface(t *testing.T, doc v1.InterfaceDoc, golden string) {
+	t.Helper()
+	got, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		t.Fatalf("couldn't marshal the interface: %v", err)
+	}
+	want, err := os.ReadFile(filepath.Join("testdata", "interface", golden))
+	if err != nil {
+		t.Fatalf("couldn't read the golden file: %v", err)
+	}
+	if d := cmp.Diff(string(want), string(got)+"\n"); d != "" {
+		t.Errorf("unexpected interface %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestInterfaceOf(t *testing.T) {
+	ts := v1.TaskSpec{
+		Params: v1.ParamSpecs{{
+			Name:        "url",
+			Description: "The URL of the repo",
+		}, {
+			Name:    "flags",
+			Default: v1.NewStructuredValues("--verbose", "--depth=1"),
+		}, {
+			Name:        "mode",
+			Type:        v1.ParamTypeString,
+			Description: "The mode of the clone",
+			Default:     v1.NewStructuredValues("shallow"),
+			Enum:        []string{"shallow", "full"},
+		}, {
+			Name: "auth",
+			Properties: map[string]v1.PropertySpec{
+				"user":  {},
+				"token": {Type: v1.ParamTypeString},
+			},
+		}},
+		ParamsFrom: []v1.ParameterSetRef{{Name: "org-defaults"}, {Name: "team-defaults"}},
+		Results: []v1.TaskResult{{
+			Name:        "commit",
+			Description: "The SHA of the cloned commit",
+		}, {
+			Name:       "source",
+			Properties: map[string]v1.PropertySpec{"url": {}, "commit": {}},
+		}},
+		Workspaces: []v1.WorkspaceDeclaration{{
+			Name:        "output",
+			Description: "The workspace the repo is cloned into",
+			MountPath:   "/workspace/output",
+		}, {
+			Name:     "ssh-directory",
+			ReadOnly: true,
+			Optional: true,
+		}},
+		Steps: []v1.Step{{
+			Name:   "clone",
+			Image:  "alpine/git",
+			Script: "git clone $(params.url)",
+		}},
+	}
+	assertGoldenInterface(t, v1.InterfaceOf(ts), "task.json")
+}
+
+func TestPipelineInterfaceOf(t *testing.T) {
+	ps := v1.PipelineSpec{
+		Params: v1.ParamSpecs{{
+			Name:        "revision",
+			Description: "The revision to build",
+			Default:     v1.NewStructuredValues("main"),
+		}, {
+			Name:        "image",
+			Description: "The image to build",
+		}},
+		Results: []v1.PipelineResult{{
+			Name:        "digest",
+			Description: "The digest of the built image",
+			Value:       *v1.NewStructuredValues("$(tasks.build.results.digest)"),
+		}, {
+			Name:  "tags",
+			Type:  v1.ResultsTypeArray,
+			Value: *v1.NewStructuredValues("$(tasks.build.results.tags[*])"),
+		}},
+		Workspaces: []v1.PipelineWorkspaceDeclaration{{
+			Name:        "source",
+			Description: "The workspace holding the sources",
+		}, {
+			Name:     "cache",
+			Optional: true,
+		}},
+		Tasks: []v1.PipelineTask{{
+			Name:    "build",
+			TaskRef: &v1.TaskRef{Name: "build"},
+		}},
+	}
+	assertGoldenInterface(t, v1.PipelineInterfaceOf(ps), "pipeline.json")
+}
+
+func TestStepInterfaceOf(t *testing.T) {
+	params := v1.ParamSpecs{{
+		Name:        "path",
+		Description: "The path of the file to hash",
+	}, {
+		Name:    "algorithms",
+		Type:    v1.ParamTypeArray,
+		Default: v1.NewStructuredValues("sha256", "sha512"),
+	}}
+	results := []v1.StepResult{{
+		Name:        "digest",
+		Description: "The digest of the file",
+	}, {
+		Name: "file",
+		Type: v1.ResultsTypeObject,
+		Properties: map[string]v1.PropertySpec{
+			"path": {Type: v1.ParamTypeString},
+			"size": {},
+		},
+	}}
+	assertGoldenInterface(t, v1.StepInterfaceOf(params, results), "stepaction.json")
+}
+
+func TestInterfaceDigest(t *testing.T) {
+	ts := v1.TaskSpec{
+		Params: v1.ParamSpecs{{Name: "a"}, {Name: "b", Default: v1.NewStructuredValues("x", "y")}},
+		Results: []v1.TaskResult{{
+			Name:        "out",
+			Description: "The output",
+		}},
+	}
+	// The same interface declared in another order and with the types given.
+	same := v1.TaskSpec{
+		Params: v1.ParamSpecs{{
+			Name:    "b",
+			Type:    v1.ParamTypeArray,
+			Default: v1.NewStructuredValues("x", "y"),
+		}, {
+			Name: "a",
+			Type: v1.ParamTypeString,
+		}},
+		Results: []v1.TaskResult{{
+			Name:        "out",
+			Type:        v1.ResultsTypeString,
+			Description: "The output",
+		}},
+		Steps: []v1.Step{{Image: "busybox"}},
+	}
+	if v1.InterfaceOf(ts).Digest() != v1.InterfaceOf(same).Digest() {
+		t.Error("expected the specs declaring the same interface to have the same digest")
+	}
+
+	changed := same.DeepCopy()
+	changed.Results[0].Description = "The updated output"
+	if v1.InterfaceOf(ts).Digest() == v1.InterfaceOf(*changed).Digest() {
+		t.Error("expected the digest to change with the description of a result")
+	}
+}
+
+func TestSetInterfaceDigest(t *testing.T) {
+	task := &v1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "task"},
+		Spec: v1.TaskSpec{
+			Params: v1.ParamSpecs{{Name: "a"}},
+			Steps:  []v1.Step{{Image: "busybox"}},
+		},
+	}
+	want := v1.InterfaceOf(task.Spec).Digest()
+
+	ctx := cfgtesting.SetFeatureFlags(t.Context(), t, map[string]string{"enable-interface-digest": "true"})
+	task.SetDefaults(ctx)
+	if got := task.Annotations[v1.InterfaceDigestAnnotationKey]; got != want {
+		t.Errorf("expected the interface digest annotation %q, got %q", want, got)
+	}
+
+	pipeline := &v1.Pipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "pipeline"},
+		Spec: v1.PipelineSpec{
+			Params: v1.ParamSpecs{{Name: "a"}},
+			Tasks:  []v1.PipelineTask{{Name: "task", TaskRef: &v1.TaskRef{Name: "task"}}},
+		},
+	}
+	pipeline.SetDefaults(ctx)
+	if got := pipeline.Annotations[v1.InterfaceDigestAnnotationKey]; got != want {
+		t.Errorf("expected the pipeline with the same params to have the interface digest annotation %q, got %q", want, got)
+	}
+
+	// The annotation is removed once the feature flag is disabled.
+	task.SetDefaults(t.Context())
+	if _, ok := task.Annotations[v1.InterfaceDigestAnnotationKey]; ok {
+		t.Errorf("expected no interface digest annotation with the feature flag disabled, got %v", task.Annotations)
+	}
+}
@@ -66,16 +66,20 @@ func MergeStepsWithStepTemplate(template *StepTemplate, steps []Step) ([]Step, e
 
 		// Pass through original step Script, for later conversion.
 		newStep := Step{
-			Script:       s.Script,
-			OnError:      s.OnError,
-			Timeout:      s.Timeout,
-			StdoutConfig: s.StdoutConfig,
-			StderrConfig: s.StderrConfig,
-			Results:      s.Results,
-			Params:       s.Params,
-			Ref:          s.Ref,
-			When:         s.When,
-			Workspaces:   s.Workspaces,
+			Script:            s.Script,
+			OnError:           s.OnError,
+			Timeout:           s.Timeout,
+			StdoutConfig:      s.StdoutConfig,
+			StderrConfig:      s.StderrConfig,
+			StdinFrom:         s.StdinFrom,
+			ScriptFrom:        s.ScriptFrom,
+			Results:           s.Results,
+			Params:            s.Params,
+			Ref:               s.Ref,
+			When:              s.When,
+			Workspaces:        s.Workspaces,
+			ContainerName:     s.ContainerName,
+			DependsOnSidecars: s.DependsOnSidecars,
 		}
 		newStep.SetContainerFields(merged)
 		steps[i] = newStep
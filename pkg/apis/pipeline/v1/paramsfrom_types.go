@@ -0,0 +1,45 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+// ParameterSetRef references a ParameterSet, in the namespace of the run,
+// whose params are imported by a Task, a Pipeline or another ParameterSet.
+type ParameterSetRef struct {
+	// Name is the name of the referenced ParameterSet.
+	Name string `json:"name"`
+}
+
+// ExpandedParameterSet records a ParameterSet whose params were imported into
+// the spec of a run, for the run to be reproducible.
+type ExpandedParameterSet struct {
+	// Name is the name of the ParameterSet.
+	Name string `json:"name"`
+	// ResourceVersion is the resourceVersion of the ParameterSet when its
+	// params were imported.
+	// +optional
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+	// Params are the names of the params imported from the ParameterSet,
+	// without the ones which were already declared.
+	// +optional
+	// +listType=atomic
+	Params []string `json:"params,omitempty"`
+	// Results are the names of the results imported from the ParameterSet,
+	// without the ones which were already declared.
+	// +optional
+	// +listType=atomic
+	Results []string `json:"results,omitempty"`
+}
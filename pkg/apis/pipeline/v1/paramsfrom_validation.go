@@ -0,0 +1,58 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"knative.dev/pkg/apis"
+)
+
+// ValidateParameterSetRefs validates the references to ParameterSets of a
+// paramsFrom field.
+func ValidateParameterSetRefs(refs []ParameterSetRef) (errs *apis.FieldError) {
+	names := sets.NewString()
+	for i, ref := range refs {
+		switch {
+		case ref.Name == "":
+			errs = errs.Also(apis.ErrMissingField("name").ViaIndex(i))
+		case names.Has(ref.Name):
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("ParameterSet %q is referenced more than once", ref.Name), "name").ViaIndex(i))
+		default:
+			if msgs := validation.IsDNS1123Subdomain(ref.Name); len(msgs) > 0 {
+				errs = errs.Also(apis.ErrInvalidValue(ref.Name, "name", strings.Join(msgs, ", ")).ViaIndex(i))
+			}
+		}
+		names.Insert(ref.Name)
+	}
+	return errs
+}
+
+// validateParamsFrom validates the paramsFrom of a Task or a Pipeline, which
+// requires the alpha API fields.
+func validateParamsFrom(ctx context.Context, refs []ParameterSetRef) *apis.FieldError {
+	if len(refs) == 0 {
+		return nil
+	}
+	errs := config.ValidateEnabledAPIFields(ctx, "paramsFrom", config.AlphaAPIFields)
+	return errs.Also(ValidateParameterSetRefs(refs)).ViaField("paramsFrom")
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	cfgtesting "github.com/tektoncd/pipeline/pkg/apis/config/testing"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestTaskValidate_ParamsFrom(t *testing.T) {
+	task := &v1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "build"},
+		Spec: v1.TaskSpec{
+			ParamsFrom: []v1.ParameterSetRef{{Name: "common"}},
+			Steps: []v1.Step{{
+				Name:   "build",
+				Image:  "$(params.builder-image)",
+				Args:   []string{"--registry", "$(params.registry)"},
+				Script: "build > $(results.IMAGE_DIGEST.path)",
+			}},
+		},
+	}
+	ctx := cfgtesting.EnableAlphaAPIFields(t.Context())
+	task.SetDefaults(ctx)
+	// The params and results imported from the ParameterSets are only known when the Task is run.
+	if err := task.Validate(ctx); err != nil {
+		t.Errorf("Task.Validate() returned error for valid Task: %v", err)
+	}
+}
+
+func TestPipelineValidate_ParamsFrom(t *testing.T) {
+	pipeline := &v1.Pipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "build"},
+		Spec: v1.PipelineSpec{
+			ParamsFrom: []v1.ParameterSetRef{{Name: "common"}},
+			Tasks: []v1.PipelineTask{{
+				Name:    "build",
+				TaskRef: &v1.TaskRef{Name: "build"},
+				Params: v1.Params{{
+					Name:  "registry",
+					Value: *v1.NewStructuredValues("$(params.registry)"),
+				}},
+			}},
+		},
+	}
+	ctx := cfgtesting.EnableAlphaAPIFields(t.Context())
+	pipeline.SetDefaults(ctx)
+	// The params imported from the ParameterSets are only known when the Pipeline is run.
+	if err := pipeline.Validate(ctx); err != nil {
+		t.Errorf("Pipeline.Validate() returned error for valid Pipeline: %v", err)
+	}
+}
+
+func TestTaskSpecValidate_ParamsFromError(t *testing.T) {
+	tests := []struct {
+		name          string
+		paramsFrom    []v1.ParameterSetRef
+		alpha         bool
+		expectedError apis.FieldError
+	}{{
+		name:       "alpha API fields not enabled",
+		paramsFrom: []v1.ParameterSetRef{{Name: "common"}},
+		expectedError: apis.FieldError{
+			Message: `paramsFrom requires "enable-api-fields" feature gate to be "alpha" but it is "beta"`,
+		},
+	}, {
+		name:       "missing name",
+		paramsFrom: []v1.ParameterSetRef{{}},
+		alpha:      true,
+		expectedError: apis.FieldError{
+			Message: "missing field(s)",
+			Paths:   []string{"paramsFrom[0].name"},
+		},
+	}, {
+		name:       "ParameterSet referenced more than once",
+		paramsFrom: []v1.ParameterSetRef{{Name: "common"}, {Name: "images"}, {Name: "common"}},
+		alpha:      true,
+		expectedError: apis.FieldError{
+			Message: `ParameterSet "common" is referenced more than once`,
+			Paths:   []string{"paramsFrom[2].name"},
+		},
+	}, {
+		name:       "invalid name",
+		paramsFrom: []v1.ParameterSetRef{{Name: "Common"}},
+		alpha:      true,
+		expectedError: apis.FieldError{
+			Message: "invalid value: Common",
+			Paths:   []string{"paramsFrom[0].name"},
+			Details: "a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')",
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				ParamsFrom: tt.paramsFrom,
+				Steps:      []v1.Step{{Image: "my-image"}},
+			}
+			ctx := t.Context()
+			if tt.alpha {
+				ctx = cfgtesting.EnableAlphaAPIFields(ctx)
+			}
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", ts)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -28,6 +28,7 @@ var _ apis.Defaultable = (*Pipeline)(nil)
 // SetDefaults sets default values on the Pipeline's Spec
 func (p *Pipeline) SetDefaults(ctx context.Context) {
 	p.Spec.SetDefaults(ctx)
+	SetInterfaceDigest(ctx, &p.ObjectMeta, PipelineInterfaceOf(p.Spec))
 }
 
 // SetDefaults sets default values for the PipelineSpec's Params, Tasks, and Finally
@@ -36,13 +37,13 @@ func (ps *PipelineSpec) SetDefaults(ctx context.Context) {
 		ps.Params[i].SetDefaults(ctx)
 	}
 
-	for _, pt := range ps.Tasks {
-		pt.SetDefaults(ctx)
+	for i := range ps.Tasks {
+		ps.Tasks[i].SetDefaults(ctx)
 	}
 
-	for _, ft := range ps.Finally {
+	for i := range ps.Finally {
 		ctx := ctx // Ensure local scoping per Task
-		ft.SetDefaults(ctx)
+		ps.Finally[i].SetDefaults(ctx)
 	}
 }
 
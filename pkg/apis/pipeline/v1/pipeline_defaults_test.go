@@ -18,11 +18,13 @@ package v1_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	dfttesting "github.com/tektoncd/pipeline/pkg/apis/config/testing"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestPipeline_SetDefaults(t *testing.T) {
@@ -143,6 +145,40 @@ func TestPipelineSpec_SetDefaults(t *testing.T) {
 				},
 			},
 		},
+	}, {
+		desc: "task defaults and finally defaults are only applied when the PipelineRun resolves the spec",
+		ps: &v1.PipelineSpec{
+			Tasks: []v1.PipelineTask{{
+				Name:    "task-without-values",
+				TaskRef: &v1.TaskRef{Name: "bar-task-1"},
+			}},
+			Finally: []v1.PipelineTask{{
+				Name:    "final-task",
+				TaskRef: &v1.TaskRef{Name: "foo-task-1"},
+			}},
+			TaskDefaults: &v1.PipelineTaskDefaults{
+				Retries: 2,
+			},
+			FinallyDefaults: &v1.PipelineTaskDefaults{
+				OnError: v1.PipelineTaskContinue,
+			},
+		},
+		want: &v1.PipelineSpec{
+			Tasks: []v1.PipelineTask{{
+				Name:    "task-without-values",
+				TaskRef: &v1.TaskRef{Name: "bar-task-1", Kind: v1.NamespacedTaskKind},
+			}},
+			Finally: []v1.PipelineTask{{
+				Name:    "final-task",
+				TaskRef: &v1.TaskRef{Name: "foo-task-1", Kind: v1.NamespacedTaskKind},
+			}},
+			TaskDefaults: &v1.PipelineTaskDefaults{
+				Retries: 2,
+			},
+			FinallyDefaults: &v1.PipelineTaskDefaults{
+				OnError: v1.PipelineTaskContinue,
+			},
+		},
 	}}
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -155,6 +191,83 @@ func TestPipelineSpec_SetDefaults(t *testing.T) {
 	}
 }
 
+func TestPipelineSpec_ApplyTaskDefaults(t *testing.T) {
+	ps := &v1.PipelineSpec{
+		Tasks: []v1.PipelineTask{{
+			Name:    "task-without-values",
+			TaskRef: &v1.TaskRef{Name: "bar-task-1"},
+		}, {
+			Name:    "task-with-values",
+			TaskRef: &v1.TaskRef{Name: "bar-task-2"},
+			Retries: 5,
+			Timeout: &metav1.Duration{Duration: 2 * time.Minute},
+			OnError: v1.PipelineTaskStopAndFail,
+		}, {
+			Name:    "task-with-continue",
+			TaskRef: &v1.TaskRef{Name: "bar-task-3"},
+			OnError: v1.PipelineTaskContinue,
+		}, {
+			Name:                  "task-without-retries",
+			TaskRef:               &v1.TaskRef{Name: "bar-task-4"},
+			DisableDefaultRetries: true,
+		}},
+		Finally: []v1.PipelineTask{{
+			Name:    "final-task",
+			TaskRef: &v1.TaskRef{Name: "foo-task-1"},
+		}},
+		TaskDefaults: &v1.PipelineTaskDefaults{
+			Retries: 2,
+			Timeout: &metav1.Duration{Duration: 10 * time.Minute},
+		},
+		FinallyDefaults: &v1.PipelineTaskDefaults{
+			Timeout: &metav1.Duration{Duration: time.Minute},
+			OnError: v1.PipelineTaskContinue,
+		},
+	}
+	want := &v1.PipelineSpec{
+		Tasks: []v1.PipelineTask{{
+			Name:    "task-without-values",
+			TaskRef: &v1.TaskRef{Name: "bar-task-1"},
+			Retries: 2,
+			Timeout: &metav1.Duration{Duration: 10 * time.Minute},
+		}, {
+			Name:    "task-with-values",
+			TaskRef: &v1.TaskRef{Name: "bar-task-2"},
+			Retries: 5,
+			Timeout: &metav1.Duration{Duration: 2 * time.Minute},
+			OnError: v1.PipelineTaskStopAndFail,
+		}, {
+			Name:    "task-with-continue",
+			TaskRef: &v1.TaskRef{Name: "bar-task-3"},
+			Timeout: &metav1.Duration{Duration: 10 * time.Minute},
+			OnError: v1.PipelineTaskContinue,
+		}, {
+			Name:                  "task-without-retries",
+			TaskRef:               &v1.TaskRef{Name: "bar-task-4"},
+			DisableDefaultRetries: true,
+			Timeout:               &metav1.Duration{Duration: 10 * time.Minute},
+		}},
+		Finally: []v1.PipelineTask{{
+			Name:    "final-task",
+			TaskRef: &v1.TaskRef{Name: "foo-task-1"},
+			Timeout: &metav1.Duration{Duration: time.Minute},
+			OnError: v1.PipelineTaskContinue,
+		}},
+		TaskDefaults: &v1.PipelineTaskDefaults{
+			Retries: 2,
+			Timeout: &metav1.Duration{Duration: 10 * time.Minute},
+		},
+		FinallyDefaults: &v1.PipelineTaskDefaults{
+			Timeout: &metav1.Duration{Duration: time.Minute},
+			OnError: v1.PipelineTaskContinue,
+		},
+	}
+	ps.ApplyTaskDefaults()
+	if d := cmp.Diff(want, ps); d != "" {
+		t.Errorf("Mismatch of pipelineSpec after applying the task defaults: %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestPipelineTask_SetDefaults(t *testing.T) {
 	cases := []struct {
 		desc     string
@@ -116,6 +116,13 @@ type PipelineSpec struct {
 	// Params declares a list of input parameters that must be supplied when
 	// this Pipeline is run.
 	Params ParamSpecs `json:"params,omitempty"`
+	// ParamsFrom references ParameterSets whose params are imported into
+	// Params when the Pipeline is run. The params declared in Params take
+	// precedence over the imported ones, and the params of a ParameterSet
+	// over the ones of the next ParameterSets.
+	// +optional
+	// +listType=atomic
+	ParamsFrom []ParameterSetRef `json:"paramsFrom,omitempty"`
 	// Workspaces declares a set of named workspaces that are expected to be
 	// provided by a PipelineRun.
 	// +optional
@@ -130,6 +137,69 @@ type PipelineSpec struct {
 	// or after a failure which would result in ending the Pipeline
 	// +listType=atomic
 	Finally []PipelineTask `json:"finally,omitempty"`
+	// TaskDefaults declares the default retries, timeout and onError of the
+	// Tasks of the Pipeline. The values specified by a PipelineTask take
+	// precedence over the defaults.
+	// +optional
+	TaskDefaults *PipelineTaskDefaults `json:"taskDefaults,omitempty"`
+	// FinallyDefaults declares the default retries, timeout and onError of the
+	// Finally Tasks of the Pipeline. The values specified by a PipelineTask take
+	// precedence over the defaults.
+	// +optional
+	FinallyDefaults *PipelineTaskDefaults `json:"finallyDefaults,omitempty"`
+}
+
+// PipelineTaskDefaults holds the values applied to the PipelineTasks of a
+// Pipeline which don't specify their own.
+type PipelineTaskDefaults struct {
+	// Retries is the default number of times a PipelineTask is retried in
+	// case of failure.
+	// +optional
+	Retries int `json:"retries,omitempty"`
+	// Timeout is the default time after which the TaskRun of a PipelineTask
+	// times out.
+	// Refer Go's ParseDuration documentation for expected format: https://golang.org/pkg/time/#ParseDuration
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// OnError is the default exiting behavior of a PipelineRun when a
+	// PipelineTask fails. Can be set to [ continue | stopAndFail ]
+	// +optional
+	OnError PipelineTaskOnErrorType `json:"onError,omitempty"`
+}
+
+// ApplyTaskDefaults sets the TaskDefaults on the Tasks and the FinallyDefaults
+// on the Finally Tasks of the PipelineSpec. They aren't applied when the
+// Pipeline is defaulted but when a PipelineRun resolves its spec, so that the
+// stored Pipelines keep the values specified by their PipelineTasks.
+func (ps *PipelineSpec) ApplyTaskDefaults() {
+	for i := range ps.Tasks {
+		ps.TaskDefaults.ApplyTo(&ps.Tasks[i])
+	}
+	for i := range ps.Finally {
+		ps.FinallyDefaults.ApplyTo(&ps.Finally[i])
+	}
+}
+
+// ApplyTo sets the defaults on the given PipelineTask for the fields it
+// doesn't specify. Retries and an onError of "continue" can't be combined, so
+// a default is skipped when it would conflict with the value of the other
+// field specified by the PipelineTask. The default retries are also skipped
+// when the PipelineTask disables them.
+func (d *PipelineTaskDefaults) ApplyTo(pt *PipelineTask) {
+	if d == nil {
+		return
+	}
+	retries, onError := pt.Retries, pt.OnError
+	if retries == 0 && !pt.DisableDefaultRetries && onError != PipelineTaskContinue {
+		pt.Retries = d.Retries
+	}
+	if pt.Timeout == nil && d.Timeout != nil {
+		timeout := *d.Timeout
+		pt.Timeout = &timeout
+	}
+	if onError == "" && !(d.OnError == PipelineTaskContinue && retries > 0) {
+		pt.OnError = d.OnError
+	}
 }
 
 // PipelineResult used to describe the results of a pipeline
@@ -150,6 +220,13 @@ type PipelineResult struct {
 	// +kubebuilder:pruning:PreserveUnknownFields
 	// +kubebuilder:validation:Schemaless
 	Value ResultValue `json:"value"`
+
+	// Default is the value of the result when one of the PipelineTasks it
+	// references was skipped. It must have the type of the result.
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	Default *ResultValue `json:"default,omitempty"`
 }
 
 // PipelineTaskMetadata contains the labels or annotations for an EmbeddedTask
@@ -217,6 +294,12 @@ type PipelineTask struct {
 	// +optional
 	Retries int `json:"retries,omitempty"`
 
+	// DisableDefaultRetries keeps the retries of the taskDefaults or
+	// finallyDefaults of the Pipeline from being applied to this task, which
+	// isn't retried unless it sets Retries.
+	// +optional
+	DisableDefaultRetries bool `json:"disableDefaultRetries,omitempty"`
+
 	// RunAfter is the list of PipelineTask names that should be executed before
 	// this Task executes. (Used to force a specific ordering in graph execution.)
 	// +optional
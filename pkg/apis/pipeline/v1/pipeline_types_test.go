@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/hex"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -497,6 +498,52 @@ func TestPipelineTask_ValidateCustomTask(t *testing.T) {
 	}
 }
 
+func TestPipelineTask_ValidateApprovalGate(t *testing.T) {
+	tests := []struct {
+		name          string
+		task          PipelineTask
+		wc            func(context.Context) context.Context
+		expectedError string
+	}{{
+		name: "valid approval gate",
+		task: PipelineTask{Name: "approve", TaskRef: &TaskRef{Kind: ApprovalGateKind}, Timeout: &metav1.Duration{Duration: time.Hour}},
+		wc:   cfgtesting.EnableAlphaAPIFields,
+	}, {
+		name:          "approval gate without alpha",
+		task:          PipelineTask{Name: "approve", TaskRef: &TaskRef{Kind: ApprovalGateKind}},
+		wc:            cfgtesting.EnableBetaAPIFields,
+		expectedError: `approval gates requires "enable-api-fields" feature gate to be "alpha" but it is "beta": `,
+	}, {
+		name:          "approval gate with name",
+		task:          PipelineTask{Name: "approve", TaskRef: &TaskRef{Kind: ApprovalGateKind, Name: "gate"}},
+		wc:            cfgtesting.EnableAlphaAPIFields,
+		expectedError: `must not set the field(s): taskRef.name`,
+	}, {
+		name:          "approval gate with retries",
+		task:          PipelineTask{Name: "approve", TaskRef: &TaskRef{Kind: ApprovalGateKind}, Retries: 1},
+		wc:            cfgtesting.EnableAlphaAPIFields,
+		expectedError: `must not set the field(s): retries`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := tt.wc(t.Context())
+			err := tt.task.Validate(ctx)
+			if tt.expectedError == "" {
+				if err != nil {
+					t.Fatalf("PipelineTask.Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("PipelineTask.Validate() did not return error for invalid approval gate")
+			}
+			if d := cmp.Diff(tt.expectedError, err.Error()); d != "" {
+				t.Errorf("PipelineTask.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestPipelineTask_ValidateRegularTask_Success(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -704,6 +751,10 @@ func TestPipelineTask_Validate_Failure(t *testing.T) {
 				APIVersion: "example.com",
 			}}},
 		expectedError: *apis.ErrInvalidValue("custom task spec must specify kind", "taskSpec.kind"),
+	}, {
+		name:          "disableDefaultRetries with retries",
+		p:             PipelineTask{Name: "foo", TaskRef: &TaskRef{Name: "foo"}, Retries: 1, DisableDefaultRetries: true},
+		expectedError: *apis.ErrGeneric("disableDefaultRetries cannot be set when retries is greater than 0", "disableDefaultRetries", "retries"),
 	}, {
 		name:          "custom task reference in taskref missing apiversion",
 		p:             PipelineTask{Name: "foo", TaskRef: &TaskRef{Kind: "Example", Name: ""}},
@@ -785,6 +836,21 @@ func TestPipelineTaskList_Deps(t *testing.T) {
 		expectedDeps: map[string][]string{
 			"task-2": {"task-1"},
 		},
+	}, {
+		name: "valid pipeline with Task Results in workspace subPath deps",
+		tasks: []PipelineTask{{
+			Name: "extract",
+		}, {
+			Name: "build",
+			Workspaces: []WorkspacePipelineTaskBinding{{
+				Name:      "src",
+				Workspace: "source",
+				SubPath:   "$(tasks.extract.results.rootdir)",
+			}},
+		}},
+		expectedDeps: map[string][]string{
+			"build": {"extract"},
+		},
 	}, {
 		name: "valid pipeline with Task Results in Matrix deps",
 		tasks: []PipelineTask{{
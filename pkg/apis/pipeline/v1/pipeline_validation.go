@@ -61,7 +61,10 @@ func (p *Pipeline) Validate(ctx context.Context) *apis.FieldError {
 	// When a Pipeline is created directly, instead of declared inline in a PipelineRun,
 	// we do not support propagated parameters and workspaces.
 	// Validate that all params and workspaces it uses are declared.
-	errs = errs.Also(p.Spec.validatePipelineParameterUsage(ctx).ViaField("spec"))
+	// The params imported with paramsFrom are only known, and validated, when the Pipeline is run.
+	if len(p.Spec.ParamsFrom) == 0 {
+		errs = errs.Also(p.Spec.ValidateParameterUsage(ctx).ViaField("spec"))
+	}
 	errs = errs.Also(p.Spec.validatePipelineWorkspacesUsage().ViaField("spec"))
 	return errs
 }
@@ -80,11 +83,20 @@ func (ps *PipelineSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	// The parameter variables should be valid
 	errs = errs.Also(ValidatePipelineParameterVariables(ctx, ps.Tasks, ps.Params).ViaField("tasks"))
 	errs = errs.Also(ValidatePipelineParameterVariables(ctx, ps.Finally, ps.Params).ViaField("finally"))
+	errs = errs.Also(validateParamsFrom(ctx, ps.ParamsFrom))
 	errs = errs.Also(validatePipelineContextVariables(ps.Tasks).ViaField("tasks"))
 	errs = errs.Also(validatePipelineContextVariables(ps.Finally).ViaField("finally"))
 	errs = errs.Also(validateExecutionStatusVariables(ps.Tasks, ps.Finally))
 	// Validate the pipeline's workspaces.
 	errs = errs.Also(validatePipelineWorkspacesDeclarations(ps.Workspaces))
+	// The bound variables of the required workspaces are only warned about
+	// until enable-workspace-bound-validation is turned on.
+	boundErrs := validatePipelineWorkspacesBoundVariables(ps.Workspaces, ps.Tasks).ViaField("tasks").
+		Also(validatePipelineWorkspacesBoundVariables(ps.Workspaces, ps.Finally).ViaField("finally"))
+	if !config.FromContextOrDefaults(ctx).FeatureFlags.EnableWorkspaceBoundValidation {
+		boundErrs = boundErrs.At(apis.WarningLevel)
+	}
+	errs = errs.Also(boundErrs)
 	// Validate the pipeline's results
 	errs = errs.Also(validatePipelineResults(ps.Results, ps.Tasks, ps.Finally))
 	errs = errs.Also(validateTasksAndFinallySection(ps))
@@ -93,6 +105,27 @@ func (ps *PipelineSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	errs = errs.Also(validateArtifactReference(ctx, ps.Tasks, ps.Finally))
 	errs = errs.Also(validateMatrix(ctx, ps.Tasks).ViaField("tasks"))
 	errs = errs.Also(validateMatrix(ctx, ps.Finally).ViaField("finally"))
+	errs = errs.Also(ps.TaskDefaults.validate(ctx).ViaField("taskDefaults"))
+	errs = errs.Also(ps.FinallyDefaults.validate(ctx).ViaField("finallyDefaults"))
+	return errs
+}
+
+// validate validates the defaults of the PipelineTasks the same way as the
+// fields of a PipelineTask they are applied to.
+func (d *PipelineTaskDefaults) validate(ctx context.Context) (errs *apis.FieldError) {
+	if d == nil {
+		return nil
+	}
+	if d.Retries < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%d should be >= 0", d.Retries), "retries"))
+	}
+	if d.Timeout != nil && d.Timeout.Duration < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(d.Timeout.Duration.String()+" should be >= 0", "timeout"))
+	}
+	errs = errs.Also(PipelineTask{OnError: d.OnError}.ValidateOnError(ctx))
+	if d.OnError == PipelineTaskContinue && d.Retries > 0 {
+		errs = errs.Also(apis.ErrGeneric(`onError cannot be set to "continue" when retries is greater than 0`, "onError", "retries"))
+	}
 	return errs
 }
 
@@ -155,7 +188,7 @@ func (l PipelineTaskList) Validate(ctx context.Context, taskNames sets.String, p
 // validateUsageOfDeclaredPipelineTaskParameters validates that all parameters referenced in the pipeline Task are declared by the pipeline Task.
 func (l PipelineTaskList) validateUsageOfDeclaredPipelineTaskParameters(ctx context.Context, additionalParams []ParamSpec, path string) (errs *apis.FieldError) {
 	for i, t := range l {
-		if t.TaskSpec != nil {
+		if t.TaskSpec != nil && len(t.TaskSpec.ParamsFrom) == 0 {
 			errs = errs.Also(ValidateUsageOfDeclaredParameters(ctx, t.TaskSpec.Steps, append(t.TaskSpec.Params, additionalParams...)).ViaFieldIndex(path, i))
 		}
 	}
@@ -192,8 +225,14 @@ func (pt PipelineTask) Validate(ctx context.Context) (errs *apis.FieldError) {
 
 	errs = errs.Also(pt.ValidateOnError(ctx))
 
+	if pt.DisableDefaultRetries && pt.Retries > 0 {
+		errs = errs.Also(apis.ErrGeneric("disableDefaultRetries cannot be set when retries is greater than 0", "disableDefaultRetries", "retries"))
+	}
+
 	// Pipeline task having taskRef/taskSpec with APIVersion is classified as custom task
 	switch {
+	case pt.TaskRef.IsApprovalGate():
+		errs = errs.Also(pt.validateApprovalGate(ctx))
 	case pt.TaskRef != nil && !taskKinds[pt.TaskRef.Kind]:
 		errs = errs.Also(pt.validateCustomTask())
 	case pt.TaskRef != nil && pt.TaskRef.APIVersion != "":
@@ -276,6 +315,8 @@ func (pt *PipelineTask) validateWorkspaces(workspaceNames sets.String) (errs *ap
 			).ViaFieldIndex("workspaces", i))
 		}
 
+		errs = errs.Also(ws.ValidateSubPath().ViaFieldIndex("workspaces", i))
+
 		workspaceBindingNames.Insert(ws.Name)
 	}
 	return errs
@@ -353,6 +394,25 @@ func (pt PipelineTask) validateCustomTask() (errs *apis.FieldError) {
 	return errs
 }
 
+// validateApprovalGate validates an approval gate, which has nothing to
+// resolve, run or retry
+func (pt PipelineTask) validateApprovalGate(ctx context.Context) (errs *apis.FieldError) {
+	errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "approval gates", config.AlphaAPIFields))
+	if pt.TaskRef.Name != "" {
+		errs = errs.Also(apis.ErrDisallowedFields("taskRef.name"))
+	}
+	if pt.TaskRef.Resolver != "" || pt.TaskRef.Params != nil {
+		errs = errs.Also(apis.ErrDisallowedFields("taskRef.resolver", "taskRef.params"))
+	}
+	if pt.IsMatrixed() {
+		errs = errs.Also(apis.ErrDisallowedFields("matrix"))
+	}
+	if pt.Retries > 0 {
+		errs = errs.Also(apis.ErrDisallowedFields("retries"))
+	}
+	return errs
+}
+
 // validateTask validates a pipeline task or a final task for taskRef and taskSpec
 func (pt PipelineTask) validateTask(ctx context.Context) (errs *apis.FieldError) {
 	// Validate TaskSpec if it's present
@@ -390,8 +450,51 @@ func validatePipelineWorkspacesDeclarations(wss []PipelineWorkspaceDeclaration)
 	return errs
 }
 
-// validatePipelineParameterUsage validates that parameters referenced in the Pipeline are declared by the Pipeline
-func (ps *PipelineSpec) validatePipelineParameterUsage(ctx context.Context) (errs *apis.FieldError) {
+// validatePipelineWorkspacesBoundVariables validates that $(workspaces.<name>.bound) is only used in the
+// params and when expressions of the pipeline tasks for the optional workspaces of the pipeline, the other
+// workspaces being always bound.
+func validatePipelineWorkspacesBoundVariables(wss []PipelineWorkspaceDeclaration, tasks []PipelineTask) (errs *apis.FieldError) {
+	requiredWorkspaces := sets.NewString()
+	for _, ws := range wss {
+		if !ws.Optional {
+			requiredWorkspaces.Insert(ws.Name)
+		}
+	}
+	if requiredWorkspaces.Len() == 0 {
+		return nil
+	}
+	for i, pt := range tasks {
+		for _, param := range pt.extractAllParams() {
+			if expressions, ok := param.GetVarSubstitutionExpressions(); ok {
+				errs = errs.Also(validateWorkspacesBoundExpressions(expressions, requiredWorkspaces, "value").
+					ViaFieldKey("params", param.Name).ViaIndex(i))
+			}
+		}
+		for j, we := range pt.When {
+			if expressions, ok := we.GetVarSubstitutionExpressions(); ok {
+				errs = errs.Also(validateWorkspacesBoundExpressions(expressions, requiredWorkspaces, "").
+					ViaFieldIndex("when", j).ViaIndex(i))
+			}
+		}
+	}
+	return errs
+}
+
+func validateWorkspacesBoundExpressions(expressions []string, requiredWorkspaces sets.String, fieldPath string) (errs *apis.FieldError) {
+	for _, expression := range expressions {
+		if !strings.HasPrefix(expression, "workspaces.") || !strings.HasSuffix(expression, ".bound") {
+			continue
+		}
+		ws := strings.TrimSuffix(strings.TrimPrefix(expression, "workspaces."), ".bound")
+		if requiredWorkspaces.Has(ws) {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("$(%s) can only be used with optional workspaces but workspace %q is not optional", expression, ws), fieldPath))
+		}
+	}
+	return errs
+}
+
+// ValidateParameterUsage validates that parameters referenced in the Pipeline are declared by the Pipeline
+func (ps *PipelineSpec) ValidateParameterUsage(ctx context.Context) (errs *apis.FieldError) {
 	errs = errs.Also(PipelineTaskList(ps.Tasks).validateUsageOfDeclaredPipelineTaskParameters(ctx, ps.Params, "tasks"))
 	errs = errs.Also(PipelineTaskList(ps.Finally).validateUsageOfDeclaredPipelineTaskParameters(ctx, ps.Params, "finally"))
 	errs = errs.Also(validatePipelineTaskParameterUsage(ps.Tasks, ps.Params).ViaField("tasks"))
@@ -681,11 +784,32 @@ func validatePipelineResults(results []PipelineResult, tasks []PipelineTask, fin
 			errs = errs.Also(apis.ErrInvalidValue("referencing a nonexistent task",
 				"value").ViaFieldIndex("results", idx))
 		}
+
+		errs = errs.Also(validatePipelineResultDefault(result).ViaFieldIndex("results", idx))
 	}
 
 	return errs
 }
 
+// validatePipelineResultDefault ensures the default of the pipeline result
+// has the type of the result, or the type of its value if it has none.
+func validatePipelineResultDefault(result PipelineResult) *apis.FieldError {
+	if result.Default == nil {
+		return nil
+	}
+	want := ParamType(result.Type)
+	if want == "" {
+		want = result.Value.Type
+	}
+	if want == "" {
+		want = ParamTypeString
+	}
+	if result.Default.Type != want {
+		return apis.ErrInvalidValue(fmt.Sprintf("the default of the result %s must be of type %s but is of type %s", result.Name, want, result.Default.Type), "default")
+	}
+	return nil
+}
+
 // put task names in a set
 func getPipelineTasksNames(pipelineTasks []PipelineTask) sets.String {
 	pipelineTaskNames := make(sets.String)
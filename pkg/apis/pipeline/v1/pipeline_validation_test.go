@@ -19,6 +19,7 @@ package v1
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -315,6 +316,31 @@ func TestPipeline_Validate_Success(t *testing.T) {
 					"enable-artifacts":  "true",
 					"enable-api-fields": "alpha"})
 		},
+	}, {
+		name: "optional workspace bound variable in when expressions and params",
+		p: &Pipeline{
+			ObjectMeta: metav1.ObjectMeta{Name: "pipeline"},
+			Spec: PipelineSpec{
+				Workspaces: []PipelineWorkspaceDeclaration{{Name: "source"}, {Name: "cache", Optional: true}},
+				Tasks: []PipelineTask{{
+					Name:    "restore-cache",
+					TaskRef: &TaskRef{Name: "restore-cache-task"},
+					When: WhenExpressions{{
+						Input:    "$(workspaces.cache.bound)",
+						Operator: selection.In,
+						Values:   []string{"true"},
+					}},
+					Params: Params{{Name: "cache-bound", Value: ParamValue{
+						Type:      ParamTypeString,
+						StringVal: "$(workspaces.cache.bound)",
+					}}},
+					Workspaces: []WorkspacePipelineTaskBinding{{Name: "source", Workspace: "source"}, {Name: "cache", Workspace: "cache"}},
+				}},
+			},
+		},
+		wc: func(ctx context.Context) context.Context {
+			return cfgtesting.SetFeatureFlags(ctx, t, map[string]string{"enable-workspace-bound-validation": "true"})
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -330,6 +356,35 @@ func TestPipeline_Validate_Success(t *testing.T) {
 	}
 }
 
+func TestPipeline_Validate_WorkspaceBoundWarning(t *testing.T) {
+	p := &Pipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "pipeline"},
+		Spec: PipelineSpec{
+			Workspaces: []PipelineWorkspaceDeclaration{{Name: "cache"}},
+			Tasks: []PipelineTask{{
+				Name:    "restore-cache",
+				TaskRef: &TaskRef{Name: "restore-cache-task"},
+				When: WhenExpressions{{
+					Input:    "$(workspaces.cache.bound)",
+					Operator: selection.In,
+					Values:   []string{"true"},
+				}},
+				Workspaces: []WorkspacePipelineTaskBinding{{Name: "cache", Workspace: "cache"}},
+			}},
+		},
+	}
+	// The bound variable of a required workspace is only warned about without
+	// enable-workspace-bound-validation.
+	err := p.Validate(t.Context())
+	if err.Filter(apis.ErrorLevel) != nil {
+		t.Errorf("Pipeline.Validate() returned error without enable-workspace-bound-validation: %v", err)
+	}
+	wantWarning := `invalid value: $(workspaces.cache.bound) can only be used with optional workspaces but workspace "cache" is not optional: spec.tasks[0].when[0]`
+	if warning := err.Filter(apis.WarningLevel); warning == nil || warning.Error() != wantWarning {
+		t.Errorf("Pipeline.Validate() expected the warning %q but got %v", wantWarning, warning)
+	}
+}
+
 func TestPipeline_Validate_Failure(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -1351,6 +1406,86 @@ func TestPipelineSpec_Validate_Failure(t *testing.T) {
 			Message: `feature flag enable-artifacts should be set to true to use artifacts feature.`,
 			Paths:   []string{"finally[0].params"},
 		},
+	}, {
+		name: "invalid task defaults with negative retries",
+		ps: &PipelineSpec{
+			Tasks: []PipelineTask{{
+				Name: "foo", TaskRef: &TaskRef{Name: "foo-task"},
+			}},
+			TaskDefaults: &PipelineTaskDefaults{Retries: -1},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: -1 should be >= 0`,
+			Paths:   []string{"taskDefaults.retries"},
+		},
+	}, {
+		name: "invalid finally defaults with negative timeout",
+		ps: &PipelineSpec{
+			Tasks: []PipelineTask{{
+				Name: "foo", TaskRef: &TaskRef{Name: "foo-task"},
+			}},
+			FinallyDefaults: &PipelineTaskDefaults{Timeout: &metav1.Duration{Duration: -time.Minute}},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: -1m0s should be >= 0`,
+			Paths:   []string{"finallyDefaults.timeout"},
+		},
+	}, {
+		name: "invalid task defaults with onError continue and retries",
+		ps: &PipelineSpec{
+			Tasks: []PipelineTask{{
+				Name: "foo", TaskRef: &TaskRef{Name: "foo-task"},
+			}},
+			TaskDefaults: &PipelineTaskDefaults{Retries: 1, OnError: PipelineTaskContinue},
+		},
+		expectedError: apis.FieldError{
+			Message: `onError cannot be set to "continue" when retries is greater than 0`,
+			Paths:   []string{"taskDefaults.onError", "taskDefaults.retries"},
+		},
+	}, {
+		name: "bound variable of a required workspace in when expressions",
+		ps: &PipelineSpec{
+			Workspaces: []PipelineWorkspaceDeclaration{{Name: "cache"}},
+			Tasks: []PipelineTask{{
+				Name:    "restore-cache",
+				TaskRef: &TaskRef{Name: "restore-cache-task"},
+				When: WhenExpressions{{
+					Input:    "$(workspaces.cache.bound)",
+					Operator: selection.In,
+					Values:   []string{"true"},
+				}},
+			}},
+		},
+		wc: func(ctx context.Context) context.Context {
+			return cfgtesting.SetFeatureFlags(ctx, t, map[string]string{"enable-workspace-bound-validation": "true"})
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: $(workspaces.cache.bound) can only be used with optional workspaces but workspace "cache" is not optional`,
+			Paths:   []string{"tasks[0].when[0]"},
+		},
+	}, {
+		name: "bound variable of a required workspace in finally params",
+		ps: &PipelineSpec{
+			Workspaces: []PipelineWorkspaceDeclaration{{Name: "cache"}},
+			Tasks: []PipelineTask{{
+				Name: "foo", TaskRef: &TaskRef{Name: "foo-task"},
+			}},
+			Finally: []PipelineTask{{
+				Name:    "save-cache",
+				TaskRef: &TaskRef{Name: "save-cache-task"},
+				Params: Params{{Name: "cache-bound", Value: ParamValue{
+					Type:      ParamTypeString,
+					StringVal: "$(workspaces.cache.bound)",
+				}}},
+			}},
+		},
+		wc: func(ctx context.Context) context.Context {
+			return cfgtesting.SetFeatureFlags(ctx, t, map[string]string{"enable-workspace-bound-validation": "true"})
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: $(workspaces.cache.bound) can only be used with optional workspaces but workspace "cache" is not optional`,
+			Paths:   []string{"finally[0].params[cache-bound].value"},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1505,6 +1640,15 @@ func TestValidatePipelineResults_Success(t *testing.T) {
 		Name:        "my-pipeline-object-result",
 		Description: "this is my pipeline result",
 		Value:       *NewStructuredValues("$(tasks.a-task.results.gitrepo.commit)"),
+	}, {
+		Name:    "my-pipeline-result-with-default",
+		Value:   *NewStructuredValues("$(tasks.a-task.results.output)"),
+		Default: NewStructuredValues("none"),
+	}, {
+		Name:    "my-pipeline-array-result-with-default",
+		Type:    ResultsTypeArray,
+		Value:   *NewStructuredValues("$(tasks.a-task.results.images[*])"),
+		Default: NewStructuredValues("busybox", "alpine"),
 	}}
 	if err := validatePipelineResults(results, []PipelineTask{{Name: "a-task"}}, []PipelineTask{}); err != nil {
 		t.Errorf("Pipeline.validatePipelineResults() returned error for valid pipeline: %s: %v", desc, err)
@@ -1553,6 +1697,23 @@ func TestValidatePipelineResults_Failure(t *testing.T) {
 		}},
 		expectedError: *apis.ErrInvalidValue(`expected pipeline results to be task result expressions but an invalid expressions was found`, "results[0].value").Also(
 			apis.ErrInvalidValue("referencing a nonexistent task", "results[0].value")),
+	}, {
+		desc: "array default of a string result",
+		results: []PipelineResult{{
+			Name:    "my-pipeline-result",
+			Value:   *NewStructuredValues("$(tasks.a-task.results.output)"),
+			Default: NewStructuredValues("a", "b"),
+		}},
+		expectedError: *apis.ErrInvalidValue(`the default of the result my-pipeline-result must be of type string but is of type array`, "results[0].default"),
+	}, {
+		desc: "string default of an array result",
+		results: []PipelineResult{{
+			Name:    "my-pipeline-result",
+			Type:    ResultsTypeArray,
+			Value:   *NewStructuredValues("$(tasks.a-task.results.images[*])"),
+			Default: NewStructuredValues("busybox"),
+		}},
+		expectedError: *apis.ErrInvalidValue(`the default of the result my-pipeline-result must be of type array but is of type string`, "results[0].default"),
 	}}
 	for _, tt := range tests {
 		err := validatePipelineResults(tt.results, []PipelineTask{{Name: "a-task"}}, []PipelineTask{})
@@ -2715,6 +2876,22 @@ func TestValidatePipelineWorkspacesUsage_Success(t *testing.T) {
 			}},
 		}},
 		skipValidation: false,
+	}, {
+		name: "workspace subPath templated with the result of another pipeline task",
+		workspaces: []PipelineWorkspaceDeclaration{{
+			Name: "source",
+		}},
+		tasks: []PipelineTask{{
+			Name: "extract", TaskRef: &TaskRef{Name: "extract"},
+		}, {
+			Name: "build", TaskRef: &TaskRef{Name: "build"},
+			Workspaces: []WorkspacePipelineTaskBinding{{
+				Name:      "src",
+				Workspace: "source",
+				SubPath:   "$(tasks.extract.results.rootdir)/src",
+			}},
+		}},
+		skipValidation: false,
 	}, {
 		name: "skip validating workspace usage",
 		workspaces: []PipelineWorkspaceDeclaration{{
@@ -2842,6 +3019,24 @@ func TestValidatePipelineWorkspacesUsage_Failure(t *testing.T) {
 			Message: `workspace name "repo" must be unique`,
 			Paths:   []string{"tasks[0].workspaces[1]"},
 		},
+	}, {
+		name: "workspace subPath escaping the pipeline workspace",
+		workspaces: []PipelineWorkspaceDeclaration{{
+			Name: "source",
+		}},
+		tasks: []PipelineTask{{
+			Name:    "foo",
+			TaskRef: &TaskRef{Name: "foo"},
+			Workspaces: []WorkspacePipelineTaskBinding{{
+				Name:      "src",
+				Workspace: "source",
+				SubPath:   "$(tasks.extract.results.rootdir)/../other",
+			}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `invalid value: "$(tasks.extract.results.rootdir)/../other" must not contain ".." path segments`,
+			Paths:   []string{"tasks[0].workspaces[0].subPath"},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -36,6 +36,10 @@ var (
 
 // SetDefaults implements apis.Defaultable
 func (pr *PipelineRun) SetDefaults(ctx context.Context) {
+	// New PipelineRuns get the defaults of their namespace, if it overrides them
+	if apis.IsInCreate(ctx) {
+		ctx = config.ToContextForNamespace(ctx, pr.Namespace)
+	}
 	pr.Spec.SetDefaults(ctx)
 
 	// Silently filtering out Tekton Reserved annotations at creation
@@ -44,6 +48,8 @@ func (pr *PipelineRun) SetDefaults(ctx context.Context) {
 			return filterReservedAnnotationRegexp.MatchString(s)
 		})
 	}
+
+	SetApprovers(ctx, pr.GetObjectMeta())
 }
 
 // SetDefaults implements apis.Defaultable
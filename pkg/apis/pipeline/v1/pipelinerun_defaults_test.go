@@ -17,6 +17,7 @@ limitations under the License.
 package v1_test
 
 import (
+	"context"
 	"strconv"
 	"testing"
 	"time"
@@ -491,3 +492,94 @@ func TestPipelineRunDefaultingOnCreate(t *testing.T) {
 		})
 	}
 }
+
+func TestPipelineRunDefaultingWithNamespaceDefaults(t *testing.T) {
+	clusterDefaults := map[string]string{
+		"default-timeout-minutes": "30",
+		"default-service-account": "cluster-sa",
+		"default-pod-template":    "nodeSelector:\n  pool: cluster\n",
+	}
+	namespaceDefaults := func(context.Context, string) (map[string]string, error) {
+		return map[string]string{
+			"default-timeout-minutes": "10",
+			"default-pod-template":    "nodeSelector:\n  pool: team\n",
+		}, nil
+	}
+	tests := []struct {
+		name     string
+		in       *v1.PipelineRun
+		inCreate bool
+		want     *v1.PipelineRun
+	}{{
+		name: "explicit spec over namespace defaults",
+		in: &v1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-a"},
+			Spec: v1.PipelineRunSpec{
+				PipelineRef:     &v1.PipelineRef{Name: "foo"},
+				Timeouts:        &v1.TimeoutFields{Pipeline: &metav1.Duration{Duration: 5 * time.Minute}},
+				TaskRunTemplate: v1.PipelineTaskRunTemplate{PodTemplate: &pod.Template{NodeSelector: map[string]string{"pool": "mine"}}},
+			},
+		},
+		inCreate: true,
+		want: &v1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Annotations: map[string]string{}},
+			Spec: v1.PipelineRunSpec{
+				PipelineRef: &v1.PipelineRef{Name: "foo"},
+				Timeouts:    &v1.TimeoutFields{Pipeline: &metav1.Duration{Duration: 5 * time.Minute}},
+				TaskRunTemplate: v1.PipelineTaskRunTemplate{
+					ServiceAccountName: "cluster-sa",
+					PodTemplate:        &pod.Template{NodeSelector: map[string]string{"pool": "mine"}},
+				},
+			},
+		},
+	}, {
+		name: "namespace defaults over cluster defaults",
+		in: &v1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-a"},
+			Spec:       v1.PipelineRunSpec{PipelineRef: &v1.PipelineRef{Name: "foo"}},
+		},
+		inCreate: true,
+		want: &v1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Annotations: map[string]string{}},
+			Spec: v1.PipelineRunSpec{
+				PipelineRef: &v1.PipelineRef{Name: "foo"},
+				Timeouts:    &v1.TimeoutFields{Pipeline: &metav1.Duration{Duration: 10 * time.Minute}},
+				TaskRunTemplate: v1.PipelineTaskRunTemplate{
+					ServiceAccountName: "cluster-sa",
+					PodTemplate:        &pod.Template{NodeSelector: map[string]string{"pool": "team"}},
+				},
+			},
+		},
+	}, {
+		name: "cluster defaults for existing PipelineRuns",
+		in: &v1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-a"},
+			Spec:       v1.PipelineRunSpec{PipelineRef: &v1.PipelineRef{Name: "foo"}},
+		},
+		want: &v1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-a"},
+			Spec: v1.PipelineRunSpec{
+				PipelineRef: &v1.PipelineRef{Name: "foo"},
+				Timeouts:    &v1.TimeoutFields{Pipeline: &metav1.Duration{Duration: 30 * time.Minute}},
+				TaskRunTemplate: v1.PipelineTaskRunTemplate{
+					ServiceAccountName: "cluster-sa",
+					PodTemplate:        &pod.Template{NodeSelector: map[string]string{"pool": "cluster"}},
+				},
+			},
+		},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := cfgtesting.SetDefaults(t.Context(), t, clusterDefaults)
+			ctx = config.WithNamespaceDefaultsGetter(ctx, namespaceDefaults)
+			if tc.inCreate {
+				ctx = apis.WithinCreate(ctx)
+			}
+			got := tc.in
+			got.SetDefaults(ctx)
+			if d := cmp.Diff(tc.want, got, ignoreUnexportedResources); d != "" {
+				t.Errorf("SetDefaults %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ package v1
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
@@ -89,6 +90,11 @@ func (pr *PipelineRun) IsCancelled() bool {
 	return pr.Spec.Status == PipelineRunSpecStatusCancelled
 }
 
+// IsSuperseded returns true if the PipelineRun's spec status is set to Superseded state
+func (pr *PipelineRun) IsSuperseded() bool {
+	return pr.Spec.Status == PipelineRunSpecStatusSuperseded
+}
+
 // IsGracefullyCancelled returns true if the PipelineRun's spec status is set to CancelledRunFinally state
 func (pr *PipelineRun) IsGracefullyCancelled() bool {
 	return pr.Spec.Status == PipelineRunSpecStatusCancelledRunFinally
@@ -283,6 +289,10 @@ type PipelineRunSpec struct {
 	// +optional
 	// +listType=atomic
 	TaskRunSpecs []PipelineTaskRunSpec `json:"taskRunSpecs,omitempty"`
+	// TasksFilter runs only a subset of the PipelineTasks of the Pipeline,
+	// skipping the other ones.
+	// +optional
+	TasksFilter *PipelineRunTasksFilter `json:"tasksFilter,omitempty"`
 }
 
 // TimeoutFields allows granular specification of pipeline, task, and finally timeouts
@@ -303,6 +313,10 @@ const (
 	// if not already cancelled or terminated
 	PipelineRunSpecStatusCancelled = "Cancelled"
 
+	// PipelineRunSpecStatusSuperseded indicates that the user wants to cancel the pipeline run
+	// because a newer run replaces it, if not already cancelled or terminated
+	PipelineRunSpecStatusSuperseded = "Superseded"
+
 	// PipelineRunSpecStatusCancelledRunFinally indicates that the user wants to cancel the pipeline run,
 	// if not already cancelled or terminated, but ensure finally is run normally
 	PipelineRunSpecStatusCancelledRunFinally = "CancelledRunFinally"
@@ -343,6 +357,8 @@ const (
 	// This reason may be found with a corev1.ConditionFalse status, if the cancellation was processed successfully
 	// This reason may be found with a corev1.ConditionUnknown status, if the cancellation is being processed or failed
 	PipelineRunReasonCancelled PipelineRunReason = "Cancelled"
+	// PipelineRunReasonSuperseded is the reason set when the PipelineRun is cancelled because a newer run replaces it
+	PipelineRunReasonSuperseded PipelineRunReason = "Superseded"
 	// PipelineRunReasonPending is the reason set when the PipelineRun is in the pending state
 	PipelineRunReasonPending PipelineRunReason = "PipelineRunPending"
 	// PipelineRunReasonTimedOut is the reason set when the PipelineRun has timed out
@@ -407,6 +423,9 @@ const (
 	// PipelineRunReasonInvalidPipelineResultReference indicates a pipeline result was declared
 	// by the pipeline but not initialized in the pipelineTask
 	PipelineRunReasonInvalidPipelineResultReference PipelineRunReason = "InvalidPipelineResultReference"
+	// PipelineRunReasonResultSchemaMismatch indicates an object pipeline result propagates a task result
+	// which misses a property declared by the task or has a property whose value is of the wrong type
+	PipelineRunReasonResultSchemaMismatch PipelineRunReason = "PipelineRunResultSchemaMismatch"
 	// ReasonRequiredWorkspaceMarkedOptional indicates an optional workspace
 	// has been passed to a Task that is expecting a non-optional workspace
 	PipelineRunReasonRequiredWorkspaceMarkedOptional PipelineRunReason = "RequiredWorkspaceMarkedOptional"
@@ -422,11 +441,32 @@ const (
 	PipelineRunReasonCELEvaluationFailed PipelineRunReason = "CELEvaluationFailed"
 	// PipelineRunReasonInvalidParamValue indicates that the PipelineRun Param input value is not allowed.
 	PipelineRunReasonInvalidParamValue PipelineRunReason = "InvalidParamValue"
+	// PipelineRunReasonExceedsResourceBudget indicates that the peak resource requests
+	// of the PipelineRun exceed the resource budget of its namespace.
+	PipelineRunReasonExceedsResourceBudget PipelineRunReason = "PipelineRunExceedsResourceBudget"
+	// PipelineRunReasonInvalidParameterSet indicates that a ParameterSet imported with
+	// the paramsFrom of the Pipeline doesn't exist or is part of a circular reference.
+	PipelineRunReasonInvalidParameterSet PipelineRunReason = "InvalidParameterSet"
+	// PipelineRunReasonInvalidTasksFilter indicates that the tasksFilter of the PipelineRun doesn't
+	// select any PipelineTask of the Pipeline, or that the PipelineTasks it selects consume results
+	// which the filtered out PipelineTasks would have produced and which aren't overridden.
+	PipelineRunReasonInvalidTasksFilter PipelineRunReason = "InvalidTasksFilter"
+	// PipelineRunReasonWaiting indicates that the creation of the TaskRuns of the PipelineRun
+	// is paused or throttled because the API server is overloaded, and will be retried.
+	PipelineRunReasonWaiting PipelineRunReason = "Waiting"
 )
 
 // PipelineTaskOnErrorAnnotation is used to pass the failure strategy to TaskRun pods from PipelineTask OnError field
 const PipelineTaskOnErrorAnnotation = "pipeline.tekton.dev/pipeline-task-on-error"
 
+// MatrixCombinationIndexAnnotation is used to pass the index of the matrix combination of the TaskRuns of a
+// matrixed PipelineTask to their pods, when the build metadata environment variables are injected
+const MatrixCombinationIndexAnnotation = "pipeline.tekton.dev/matrix-combination-index"
+
+// ResolvedParamsAnnotation records the values of the params of the TaskRuns and CustomRuns of a PipelineRun, once the
+// defaults, the params of the PipelineRun, the results and the matrix combinations are applied
+const ResolvedParamsAnnotation = "pipeline.tekton.dev/resolved-params"
+
 func (t PipelineRunReason) String() string {
 	return string(t)
 }
@@ -522,6 +562,12 @@ type PipelineRunStatusFields struct {
 	// +kubebuilder:validation:Schemaless
 	PipelineSpec *PipelineSpec `json:"pipelineSpec,omitempty"`
 
+	// ExpandedParameterSets are the ParameterSets whose params were imported
+	// into the PipelineSpec with paramsFrom.
+	// +optional
+	// +listType=atomic
+	ExpandedParameterSets []ExpandedParameterSet `json:"expandedParameterSets,omitempty"`
+
 	// list of tasks that were skipped due to when expressions evaluating to false
 	// +optional
 	// +listType=atomic
@@ -542,6 +588,114 @@ type PipelineRunStatusFields struct {
 
 	// SpanContext contains tracing span context fields
 	SpanContext map[string]string `json:"spanContext,omitempty"`
+
+	// Summary is a compact view of the progress of the PipelineRun. It is kept
+	// up to date on every reconcile so that it can be cheaply printed by clients.
+	// +optional
+	Summary *PipelineRunSummary `json:"summary,omitempty"`
+
+	// PeakResourceRequests is the highest amount of compute resources the
+	// PipelineRun may request at any point in time, i.e. the sum of the
+	// requests of the PipelineTasks which can run concurrently. It is
+	// computed before the first PipelineTask starts.
+	// +optional
+	PeakResourceRequests corev1.ResourceList `json:"peakResourceRequests,omitempty"`
+}
+
+// PipelineRunSummary holds the fields used to summarize the progress of a
+// PipelineRun, e.g. in the additional printer columns of `kubectl get`.
+type PipelineRunSummary struct {
+	// Duration is the total execution time of the PipelineRun, between its start
+	// and completion times. It is set once the PipelineRun has completed.
+	// +optional
+	Duration string `json:"duration,omitempty"`
+	// CompletedTasks is the number of PipelineTasks that are done executing,
+	// including the ones which failed, were cancelled or were skipped.
+	// +optional
+	CompletedTasks int `json:"completedTasks,omitempty"`
+	// TotalTasks is the number of PipelineTasks, including finally tasks.
+	// +optional
+	TotalTasks int `json:"totalTasks,omitempty"`
+	// FailedTask is the name of the first PipelineTask which failed, if any.
+	// +optional
+	FailedTask string `json:"failedTask,omitempty"`
+	// FailedTasks are the PipelineTasks which failed, ordered by name, with the
+	// reason and message of their failure. At most MaxPipelineTaskFailures are
+	// listed.
+	// +optional
+	// +listType=atomic
+	FailedTasks []PipelineTaskFailure `json:"failedTasks,omitempty"`
+	// Resolver is the name of the resolver used to fetch the Pipeline, if any.
+	// +optional
+	Resolver string `json:"resolver,omitempty"`
+	// QueuedTasks are the times the child TaskRuns of the PipelineRun waited
+	// between their creation and the start of their Pod, ordered by name.
+	// +optional
+	// +listType=atomic
+	QueuedTasks []PipelineTaskQueueTime `json:"queuedTasks,omitempty"`
+}
+
+// PipelineTaskQueueTime describes the time a child TaskRun of a PipelineRun
+// was queued before its Pod started.
+type PipelineTaskQueueTime struct {
+	// Name is the name of the TaskRun.
+	Name string `json:"name"`
+	// PipelineTaskName is the name of the PipelineTask of the TaskRun.
+	// +optional
+	PipelineTaskName string `json:"pipelineTaskName,omitempty"`
+	// CreationTime is the time the TaskRun was created at.
+	// +optional
+	CreationTime *metav1.Time `json:"creationTime,omitempty"`
+	// PodCreationTime is the time the Pod of the TaskRun was created at.
+	// +optional
+	PodCreationTime *metav1.Time `json:"podCreationTime,omitempty"`
+	// PodStartTime is the time the Pod of the TaskRun was started at by the
+	// kubelet.
+	// +optional
+	PodStartTime *metav1.Time `json:"podStartTime,omitempty"`
+	// QueuedDuration is the time elapsed between the creation of the TaskRun
+	// and the start of its Pod. It is unset until the Pod starts.
+	// +optional
+	QueuedDuration string `json:"queuedDuration,omitempty"`
+}
+
+// MaxPipelineTaskFailures is the maximum number of failed PipelineTasks listed
+// in the summary and the failure message of a PipelineRun.
+const MaxPipelineTaskFailures = 10
+
+// PipelineTaskFailure describes the failure of a PipelineTask.
+type PipelineTaskFailure struct {
+	// Name is the name of the PipelineTask.
+	Name string `json:"name"`
+	// Reason is the reason of the failure of the run of the PipelineTask.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Message is the first line of the message of the failure of the run of
+	// the PipelineTask, truncated if it is long.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// PipelineTaskFailuresMessage returns the message listing the given failures
+// of PipelineTasks, one per line, followed by the number of the failures which
+// aren't listed out of the given number of failed PipelineTasks. It is shared
+// by the condition of the PipelineRuns and their CloudEvents.
+func PipelineTaskFailuresMessage(failures []PipelineTaskFailure, failed int) string {
+	var b strings.Builder
+	b.WriteString("Failed tasks:")
+	for _, f := range failures {
+		b.WriteString("\n- " + f.Name)
+		if f.Reason != "" {
+			b.WriteString(" (" + f.Reason + ")")
+		}
+		if f.Message != "" {
+			b.WriteString(": " + f.Message)
+		}
+	}
+	if more := failed - len(failures); more > 0 {
+		fmt.Fprintf(&b, "\n- ... and %d more", more)
+	}
+	return b.String()
 }
 
 // SkippedTask is used to describe the Tasks that were skipped due to their When Expressions
@@ -556,6 +710,17 @@ type SkippedTask struct {
 	// +optional
 	// +listType=atomic
 	WhenExpressions []WhenExpression `json:"whenExpressions,omitempty"`
+	// EvaluatedWhenExpressions are the when expressions of the PipelineTask with the outcome
+	// of their evaluation, when it was skipped because one of them evaluated to false.
+	// Long inputs and values are truncated.
+	// +optional
+	// +listType=atomic
+	EvaluatedWhenExpressions []EvaluatedWhenExpression `json:"evaluatedWhenExpressions,omitempty"`
+	// FailedAncestor is the name of the nearest ancestor of the PipelineTask which failed,
+	// when the PipelineTask was skipped because of its parents, the results they didn't
+	// produce or the PipelineRun stopping.
+	// +optional
+	FailedAncestor string `json:"failedAncestor,omitempty"`
 }
 
 // SkippingReason explains why a PipelineTask was skipped.
@@ -582,6 +747,8 @@ const (
 	FinallyTimedOutSkip SkippingReason = "PipelineRun Finally timeout has been reached"
 	// EmptyArrayInMatrixParams means the task was skipped because Matrix parameters contain empty array.
 	EmptyArrayInMatrixParams SkippingReason = "Matrix Parameters have an empty array"
+	// FilteredOutSkip means the task was skipped because it isn't selected by the tasksFilter of the PipelineRun
+	FilteredOutSkip SkippingReason = "FilteredOut"
 	// None means the task was not skipped
 	None SkippingReason = "None"
 )
@@ -595,6 +762,11 @@ type PipelineRunResult struct {
 	// +kubebuilder:pruning:PreserveUnknownFields
 	// +kubebuilder:validation:Schemaless
 	Value ResultValue `json:"value"`
+
+	// Defaulted is true if the Value is the default of the result, because
+	// one of the PipelineTasks it references was skipped.
+	// +optional
+	Defaulted bool `json:"defaulted,omitempty"`
 }
 
 // PipelineRunTaskRunStatus contains the name of the PipelineTask for this TaskRun and the TaskRun's Status
@@ -46,11 +46,17 @@ func (pr *PipelineRun) SupportedVerbs() []admissionregistrationv1.OperationType
 // Validate pipelinerun
 func (pr *PipelineRun) Validate(ctx context.Context) *apis.FieldError {
 	errs := validate.ObjectMetadata(pr.GetObjectMeta()).ViaField("metadata")
+	errs = errs.Also(ValidateSourceEventAnnotations(ctx, pr.GetObjectMeta()).ViaField("metadata"))
+	errs = errs.Also(ValidateApprovalAnnotations(ctx, pr.GetObjectMeta()).ViaField("metadata"))
 
 	if pr.IsPending() && pr.HasStarted() {
 		errs = errs.Also(apis.ErrInvalidValue("PipelineRun cannot be Pending after it is started", "spec.status"))
 	}
 
+	if apis.IsInCreate(ctx) {
+		errs = errs.Also(validateNamespaceDefaults(ctx, pr.Namespace))
+	}
+
 	return errs.Also(pr.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
 }
 
@@ -120,9 +126,15 @@ func (ps *PipelineRunSpec) Validate(ctx context.Context) (errs *apis.FieldError)
 	for idx, trs := range ps.TaskRunSpecs {
 		errs = errs.Also(validateTaskRunSpec(ctx, trs).ViaIndex(idx).ViaField("taskRunSpecs"))
 	}
+	if ps.TasksFilter != nil {
+		errs = errs.Also(ps.TasksFilter.Validate(ctx).ViaField("tasksFilter"))
+	}
 
 	if ps.TaskRunTemplate.PodTemplate != nil {
 		errs = errs.Also(validatePodTemplateEnv(ctx, *ps.TaskRunTemplate.PodTemplate).ViaField("taskRunTemplate"))
+		errs = errs.Also(validatePodTemplateSecurityProfiles(ctx, *ps.TaskRunTemplate.PodTemplate).ViaField("taskRunTemplate"))
+		errs = errs.Also(validatePodTemplateTerminationGracePeriod(ctx, *ps.TaskRunTemplate.PodTemplate).ViaField("taskRunTemplate"))
+		errs = errs.Also(ps.TaskRunTemplate.PodTemplate.ValidateHostAliasesAndDNS().ViaField("podTemplate").ViaField("taskRunTemplate"))
 	}
 
 	return errs
@@ -220,11 +232,15 @@ func (ps *PipelineRunSpec) validateInlineParameters(ctx context.Context) (errs *
 			if pt.TaskSpec != nil && pt.TaskSpec.Steps != nil {
 				errs = errs.Also(ValidateParameterTypes(ctx, paramSpec))
 				errs = errs.Also(ValidateParameterVariables(ctx, pt.TaskSpec.Steps, paramSpec))
-				errs = errs.Also(ValidateUsageOfDeclaredParameters(ctx, pt.TaskSpec.Steps, paramSpec))
+				if len(ps.PipelineSpec.ParamsFrom) == 0 && len(pt.TaskSpec.ParamsFrom) == 0 {
+					errs = errs.Also(ValidateUsageOfDeclaredParameters(ctx, pt.TaskSpec.Steps, paramSpec))
+				}
 			}
 		}
 		errs = errs.Also(ValidatePipelineParameterVariables(ctx, ps.PipelineSpec.Tasks, paramSpec))
-		errs = errs.Also(validatePipelineTaskParameterUsage(ps.PipelineSpec.Tasks, paramSpec))
+		if len(ps.PipelineSpec.ParamsFrom) == 0 {
+			errs = errs.Also(validatePipelineTaskParameterUsage(ps.PipelineSpec.Tasks, paramSpec))
+		}
 	}
 	return errs
 }
@@ -254,14 +270,16 @@ func validateSpecStatus(status PipelineRunSpecStatus) *apis.FieldError {
 		return nil
 	case PipelineRunSpecStatusCancelled,
 		PipelineRunSpecStatusCancelledRunFinally,
-		PipelineRunSpecStatusStoppedRunFinally:
+		PipelineRunSpecStatusStoppedRunFinally,
+		PipelineRunSpecStatusSuperseded:
 		return nil
 	}
 
-	return apis.ErrInvalidValue(fmt.Sprintf("%s should be %s, %s, %s or %s", status,
+	return apis.ErrInvalidValue(fmt.Sprintf("%s should be %s, %s, %s, %s or %s", status,
 		PipelineRunSpecStatusCancelled,
 		PipelineRunSpecStatusCancelledRunFinally,
 		PipelineRunSpecStatusStoppedRunFinally,
+		PipelineRunSpecStatusSuperseded,
 		PipelineRunSpecStatusPending), "status")
 }
 
@@ -328,6 +346,9 @@ func validateTaskRunSpec(ctx context.Context, trs PipelineTaskRunSpec) (errs *ap
 	}
 	if trs.PodTemplate != nil {
 		errs = errs.Also(validatePodTemplateEnv(ctx, *trs.PodTemplate))
+		errs = errs.Also(validatePodTemplateSecurityProfiles(ctx, *trs.PodTemplate))
+		errs = errs.Also(validatePodTemplateTerminationGracePeriod(ctx, *trs.PodTemplate))
+		errs = errs.Also(trs.PodTemplate.ValidateHostAliasesAndDNS().ViaField("podTemplate"))
 	}
 	return errs
 }
@@ -83,7 +83,7 @@ func TestPipelineRun_Invalid(t *testing.T) {
 				Status: "PipelineRunCancell",
 			},
 		},
-		want: apis.ErrInvalidValue("PipelineRunCancell should be Cancelled, CancelledRunFinally, StoppedRunFinally or PipelineRunPending", "spec.status"),
+		want: apis.ErrInvalidValue("PipelineRunCancell should be Cancelled, CancelledRunFinally, StoppedRunFinally, Superseded or PipelineRunPending", "spec.status"),
 	}, {
 		name: "propagating params with pipelinespec and taskspec params not provided",
 		pr: v1.PipelineRun{
@@ -345,6 +345,71 @@ func TestPipelineRun_Invalid(t *testing.T) {
 			Message: "invalid value: PipelineRun cannot be Pending after it is started",
 			Paths:   []string{"spec.status"},
 		},
+	}, {
+		name: "invalid defaults in the namespace of a new PipelineRun",
+		pr: v1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "pipelinelinename",
+				Namespace: "team-a",
+			},
+			Spec: v1.PipelineRunSpec{
+				PipelineRef: &v1.PipelineRef{
+					Name: "prname",
+				},
+			},
+		},
+		want: apis.ErrGeneric(`invalid tekton-config-defaults ConfigMap in namespace team-a: "default-resolver-type" can't be set in the tekton-config-defaults ConfigMap of a namespace`, "metadata.namespace"),
+		wc: func(ctx context.Context) context.Context {
+			return apis.WithinCreate(config.WithNamespaceDefaultsGetter(ctx, func(context.Context, string) (map[string]string, error) {
+				return map[string]string{"default-resolver-type": "git"}, nil
+			}))
+		},
+	}, {
+		name: "invalid source event annotations",
+		pr: v1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pipelinerunname",
+				Annotations: map[string]string{
+					v1.SourceEventIDAnnotationKey:   "",
+					v1.SourceEventTypeAnnotationKey: "push",
+					v1.SourceURLAnnotationKey:       "github.com/tektoncd/pipeline",
+				},
+			},
+			Spec: v1.PipelineRunSpec{
+				PipelineRef: &v1.PipelineRef{Name: "prname"},
+			},
+		},
+		want: apis.ErrInvalidValue("tekton.dev/source-event-id must be a non-empty string of at most 256 characters", "metadata.annotations").Also(
+			apis.ErrInvalidValue(`tekton.dev/source-url must be an absolute URL but is "github.com/tektoncd/pipeline"`, "metadata.annotations")),
+	}, {
+		name: "source event annotations changed on update",
+		pr: v1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pipelinerunname",
+				Annotations: map[string]string{
+					v1.SourceEventIDAnnotationKey: "2",
+				},
+			},
+			Spec: v1.PipelineRunSpec{
+				PipelineRef: &v1.PipelineRef{Name: "prname"},
+			},
+		},
+		want: apis.ErrInvalidValue("tekton.dev/source-event-id can't be changed or removed once set", "metadata.annotations").Also(
+			apis.ErrInvalidValue("tekton.dev/source-event-type can't be changed or removed once set", "metadata.annotations")),
+		wc: func(ctx context.Context) context.Context {
+			return apis.WithinUpdate(ctx, &v1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pipelinerunname",
+					Annotations: map[string]string{
+						v1.SourceEventIDAnnotationKey:   "1",
+						v1.SourceEventTypeAnnotationKey: "push",
+					},
+				},
+				Spec: v1.PipelineRunSpec{
+					PipelineRef: &v1.PipelineRef{Name: "prname"},
+				},
+			})
+		},
 	}}
 
 	for _, tc := range tests {
@@ -378,6 +443,23 @@ func TestPipelineRun_Validate(t *testing.T) {
 				},
 			},
 		},
+	}, {
+		name: "source event annotations",
+		pr: v1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pipelinename",
+				Annotations: map[string]string{
+					v1.SourceEventIDAnnotationKey:   "72d3162e-cc78-11e3-81ab-4c9367dc0958",
+					v1.SourceEventTypeAnnotationKey: "push",
+					v1.SourceURLAnnotationKey:       "https://github.com/tektoncd/pipeline",
+				},
+			},
+			Spec: v1.PipelineRunSpec{
+				PipelineRef: &v1.PipelineRef{
+					Name: "prname",
+				},
+			},
+		},
 	}, {
 		name: "no timeout",
 		pr: v1.PipelineRun{
@@ -777,6 +859,19 @@ func TestPipelineRun_Validate(t *testing.T) {
 				},
 			},
 		},
+	}, {
+		name: "pipelinerun superseded",
+		pr: v1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pipelinerunname",
+			},
+			Spec: v1.PipelineRunSpec{
+				Status: v1.PipelineRunSpecStatusSuperseded,
+				PipelineRef: &v1.PipelineRef{
+					Name: "prname",
+				},
+			},
+		},
 	}, {
 		name: "alpha feature: sidecar and step specs",
 		pr: v1.PipelineRun{
@@ -865,6 +960,24 @@ func TestPipelineRunSpec_Invalidate(t *testing.T) {
 		},
 		withContext: EnableForbiddenEnv,
 		wantErr:     apis.ErrInvalidValue("PodTemplate cannot update a forbidden env: TEST_ENV", "taskRunTemplate.PodTemplate.Env"),
+	}, {
+		name: "invalid host aliases in the pod templates",
+		spec: v1.PipelineRunSpec{
+			PipelineRef: &v1.PipelineRef{Name: "foo"},
+			TaskRunTemplate: v1.PipelineTaskRunTemplate{
+				PodTemplate: &pod.PodTemplate{
+					HostAliases: []corev1.HostAlias{{IP: "git.internal.example.com", Hostnames: []string{"git"}}},
+				},
+			},
+			TaskRunSpecs: []v1.PipelineTaskRunSpec{{
+				PipelineTaskName: "task-1",
+				PodTemplate: &pod.PodTemplate{
+					HostAliases: []corev1.HostAlias{{IP: "10.0.0.10"}},
+				},
+			}},
+		},
+		wantErr: apis.ErrInvalidValue("git.internal.example.com must be a valid IP address", "taskRunTemplate.podTemplate.hostAliases[0].ip").Also(
+			apis.ErrMissingField("taskRunSpecs[0].podTemplate.hostAliases[0].hostnames")),
 	}, {
 		name: "pipelineRef and pipelineSpec together",
 		spec: v1.PipelineRunSpec{
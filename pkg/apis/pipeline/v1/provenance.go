@@ -25,6 +25,10 @@ type Provenance struct {
 
 	// FeatureFlags identifies the feature flags that were used during the task/pipeline run
 	FeatureFlags *config.FeatureFlags `json:"featureFlags,omitempty"`
+
+	// SourceEvent identifies the event which caused the run, as described by
+	// the source event annotations of the run.
+	SourceEvent *SourceEvent `json:"sourceEvent,omitempty"`
 }
 
 // RefSource contains the information that can uniquely identify where a remote
@@ -133,6 +133,9 @@ func PipelineTaskResultRefs(pt *PipelineTask) []*ResultRef {
 		expressions, _ := whenExpression.GetVarSubstitutionExpressions()
 		refs = append(refs, NewResultRefs(expressions)...)
 	}
+	for _, ws := range pt.Workspaces {
+		refs = append(refs, NewResultRefs(validateString(ws.SubPath))...)
+	}
 	taskSubExpressions := pt.GetVarSubstitutionExpressions()
 	refs = append(refs, NewResultRefs(taskSubExpressions)...)
 	return refs
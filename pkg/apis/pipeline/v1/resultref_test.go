@@ -872,6 +872,10 @@ func TestPipelineTaskResultRefs(t *testing.T) {
 				Value: *v1.NewStructuredValues("$(tasks.pt7.results.r7)", "$(tasks.pt8.results.r8)"),
 			}},
 		},
+		Workspaces: []v1.WorkspacePipelineTaskBinding{{
+			Name:    "src",
+			SubPath: "$(tasks.pt15.results.r15)/src",
+		}},
 		TaskSpec: &v1.EmbeddedTask{
 			TaskSpec: v1.TaskSpec{
 				Steps: []v1.Step{
@@ -936,6 +940,9 @@ func TestPipelineTaskResultRefs(t *testing.T) {
 	}, {
 		PipelineTask: "pt14",
 		Result:       "r14",
+	}, {
+		PipelineTask: "pt15",
+		Result:       "r15",
 	}}
 	if d := cmp.Diff(refs, expectedRefs, cmpopts.SortSlices(lessResultRef)); d != "" {
 		t.Errorf("%v", d)
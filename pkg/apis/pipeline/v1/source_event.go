@@ -0,0 +1,115 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+const (
+	// SourceEventIDAnnotationKey is the annotation holding the ID of the event
+	// which caused a run, e.g. the delivery ID of a webhook.
+	SourceEventIDAnnotationKey = "tekton.dev/source-event-id"
+	// SourceEventTypeAnnotationKey is the annotation holding the type of the
+	// event which caused a run, e.g. "push" or "pull_request".
+	SourceEventTypeAnnotationKey = "tekton.dev/source-event-type"
+	// SourceURLAnnotationKey is the annotation holding the URL of the source
+	// of the event which caused a run, e.g. the URL of a repository.
+	SourceURLAnnotationKey = "tekton.dev/source-url"
+
+	// maxSourceEventValueLength is the maximum length of the ID and type of
+	// the source event.
+	maxSourceEventValueLength = 256
+)
+
+// SourceEvent identifies the event which caused a run, as set by the
+// frontend which created it, e.g. Triggers, with the source event annotations.
+type SourceEvent struct {
+	// ID is the ID of the event, from the tekton.dev/source-event-id annotation.
+	// +optional
+	ID string `json:"id,omitempty"`
+	// Type is the type of the event, from the tekton.dev/source-event-type
+	// annotation.
+	// +optional
+	Type string `json:"type,omitempty"`
+	// URL is the URL of the source of the event, from the
+	// tekton.dev/source-url annotation.
+	// +optional
+	URL string `json:"url,omitempty"`
+}
+
+// SourceEventFromAnnotations returns the source event described by the
+// annotations of a run, or nil if they don't describe any.
+func SourceEventFromAnnotations(annotations map[string]string) *SourceEvent {
+	event := SourceEvent{
+		ID:   annotations[SourceEventIDAnnotationKey],
+		Type: annotations[SourceEventTypeAnnotationKey],
+		URL:  annotations[SourceURLAnnotationKey],
+	}
+	if event == (SourceEvent{}) {
+		return nil
+	}
+	return &event
+}
+
+// SourceEventAnnotations returns the source event annotations among the
+// given annotations.
+func SourceEventAnnotations(annotations map[string]string) map[string]string {
+	sourceEventAnnotations := map[string]string{}
+	for _, key := range []string{SourceEventIDAnnotationKey, SourceEventTypeAnnotationKey, SourceURLAnnotationKey} {
+		if value, ok := annotations[key]; ok {
+			sourceEventAnnotations[key] = value
+		}
+	}
+	return sourceEventAnnotations
+}
+
+// ValidateSourceEventAnnotations validates the source event annotations of a
+// run, and that they aren't changed or removed once set.
+func ValidateSourceEventAnnotations(ctx context.Context, meta metav1.Object) (errs *apis.FieldError) {
+	annotations := meta.GetAnnotations()
+	for _, key := range []string{SourceEventIDAnnotationKey, SourceEventTypeAnnotationKey} {
+		if value, ok := annotations[key]; ok && (value == "" || len(value) > maxSourceEventValueLength) {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s must be a non-empty string of at most %d characters", key, maxSourceEventValueLength), "annotations"))
+		}
+	}
+	if value, ok := annotations[SourceURLAnnotationKey]; ok {
+		if u, err := url.Parse(value); err != nil || u.Scheme == "" || u.Host == "" {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s must be an absolute URL but is %q", SourceURLAnnotationKey, value), "annotations"))
+		}
+	}
+
+	if !apis.IsInUpdate(ctx) {
+		return errs
+	}
+	old, ok := apis.GetBaseline(ctx).(metav1.Object)
+	if !ok || old == nil || reflect.ValueOf(old).IsNil() {
+		return errs
+	}
+	for key, oldValue := range SourceEventAnnotations(old.GetAnnotations()) {
+		if value, ok := annotations[key]; !ok || value != oldValue {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s can't be changed or removed once set", key), "annotations"))
+		}
+	}
+	return errs
+}
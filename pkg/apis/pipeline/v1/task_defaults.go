@@ -28,6 +28,7 @@ var _ apis.Defaultable = (*Task)(nil)
 // SetDefaults implements apis.Defaultable
 func (t *Task) SetDefaults(ctx context.Context) {
 	t.Spec.SetDefaults(ctx)
+	SetInterfaceDigest(ctx, &t.ObjectMeta, InterfaceOf(t.Spec))
 }
 
 // SetDefaults set any defaults for the task spec
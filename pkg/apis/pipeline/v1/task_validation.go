@@ -65,7 +65,10 @@ func (t *Task) Validate(ctx context.Context) *apis.FieldError {
 	errs = errs.Also(t.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
 	// When a Task is created directly, instead of declared inline in a TaskRun or PipelineRun,
 	// we do not support propagated parameters. Validate that all params it uses are declared.
-	errs = errs.Also(ValidateUsageOfDeclaredParameters(ctx, t.Spec.Steps, t.Spec.Params).ViaField("spec"))
+	// The params imported with paramsFrom are only known, and validated, when the Task is run.
+	if len(t.Spec.ParamsFrom) == 0 {
+		errs = errs.Also(ValidateUsageOfDeclaredParameters(ctx, t.Spec.Steps, t.Spec.Params).ViaField("spec"))
+	}
 	return errs
 }
 
@@ -78,6 +81,7 @@ func (ts *TaskSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	errs = errs.Also(ValidateVolumes(ts.Volumes).ViaField("volumes"))
 	errs = errs.Also(validateDeclaredWorkspaces(ts.Workspaces, ts.Steps, ts.StepTemplate).ViaField("workspaces"))
 	errs = errs.Also(validateWorkspaceUsages(ctx, ts))
+	errs = errs.Also(validateSidecarDependencies(ctx, ts))
 	mergedSteps, err := MergeStepsWithStepTemplate(ts.StepTemplate, ts.Steps)
 	if err != nil {
 		errs = errs.Also(&apis.FieldError{
@@ -88,11 +92,16 @@ func (ts *TaskSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	}
 
 	errs = errs.Also(StepList(mergedSteps).Validate(ctx).ViaField("steps"))
+	errs = errs.Also(validateStepContainerNames(mergedSteps).ViaField("steps"))
 	errs = errs.Also(SidecarList(ts.Sidecars).Validate(ctx).ViaField("sidecars"))
 	errs = errs.Also(ValidateParameterTypes(ctx, ts.Params).ViaField("params"))
+	errs = errs.Also(validateParamsFrom(ctx, ts.ParamsFrom))
 	errs = errs.Also(ValidateParameterVariables(ctx, ts.Steps, ts.Params))
 	errs = errs.Also(validateTaskContextVariables(ctx, ts.Steps))
-	errs = errs.Also(validateTaskResultsVariables(ctx, ts.Steps, ts.Results))
+	// The results imported with paramsFrom are only known, and validated, when the Task is run.
+	if len(ts.ParamsFrom) == 0 {
+		errs = errs.Also(validateTaskResultsVariables(ctx, ts.Steps, ts.Results))
+	}
 	errs = errs.Also(validateResults(ctx, ts.Results).ViaField("results"))
 	return errs
 }
@@ -183,6 +192,11 @@ func validateWorkspaceUsages(ctx context.Context, ts *TaskSpec) (errs *apis.Fiel
 				errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("undefined workspace %q", w.Name), "name").ViaIndex(workspaceIdx).ViaField("workspaces").ViaIndex(stepIdx).ViaField("steps"))
 			}
 		}
+		if step.ScriptFrom != nil && step.ScriptFrom.Workspace != nil && step.ScriptFrom.Workspace.Name != "" {
+			if name := step.ScriptFrom.Workspace.Name; !wsNames.Has(name) {
+				errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("undefined workspace %q", name), "scriptFrom.workspace.name").ViaIndex(stepIdx).ViaField("steps"))
+			}
+		}
 	}
 
 	for sidecarIdx, sidecar := range sidecars {
@@ -199,6 +213,50 @@ func validateWorkspaceUsages(ctx context.Context, ts *TaskSpec) (errs *apis.Fiel
 	return errs
 }
 
+// validateSidecarDependencies checks that the Steps only depend on the
+// Sidecars of the Task, and that the Sidecars are only stopped after the
+// Steps of the Task.
+//
+// This is an alpha feature and will fail validation if it's used by a step
+// or sidecar when the enable-api-fields feature gate is not "alpha".
+func validateSidecarDependencies(ctx context.Context, ts *TaskSpec) (errs *apis.FieldError) {
+	sidecarNames := sets.NewString()
+	for _, sidecar := range ts.Sidecars {
+		sidecarNames.Insert(sidecar.Name)
+	}
+	stepNames := sets.NewString()
+	for _, step := range ts.Steps {
+		stepNames.Insert(step.Name)
+	}
+
+	for stepIdx, step := range ts.Steps {
+		if len(step.DependsOnSidecars) != 0 {
+			errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "step dependsOnSidecars", config.AlphaAPIFields).ViaIndex(stepIdx).ViaField("steps"))
+		}
+		for i, name := range step.DependsOnSidecars {
+			if !sidecarNames.Has(name) {
+				errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("undefined sidecar %q", name), "").ViaFieldIndex("dependsOnSidecars", i).ViaIndex(stepIdx).ViaField("steps"))
+			}
+		}
+	}
+
+	for sidecarIdx, sidecar := range ts.Sidecars {
+		if len(sidecar.StopAfterSteps) != 0 {
+			errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "sidecar stopAfterSteps", config.AlphaAPIFields).ViaIndex(sidecarIdx).ViaField("sidecars"))
+		}
+		if sidecar.FailTaskRunOnTermination {
+			errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "sidecar failTaskRunOnTermination", config.AlphaAPIFields).ViaIndex(sidecarIdx).ViaField("sidecars"))
+		}
+		for i, name := range sidecar.StopAfterSteps {
+			if !stepNames.Has(name) {
+				errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("undefined step %q", name), "").ViaFieldIndex("stopAfterSteps", i).ViaIndex(sidecarIdx).ViaField("sidecars"))
+			}
+		}
+	}
+
+	return errs
+}
+
 // ValidateVolumes validates a slice of volumes to make sure there are no duplicate names
 func ValidateVolumes(volumes []corev1.Volume) (errs *apis.FieldError) {
 	// Task must not have duplicate volume names.
@@ -238,6 +296,33 @@ func (l StepList) Validate(ctx context.Context) (errs *apis.FieldError) {
 	return errs
 }
 
+// validateStepContainerNames validates that the custom container names of the
+// Steps don't collide with the names of the containers of the other Steps. They
+// can't collide with the containers of the Sidecars, whose prefix is reserved.
+func validateStepContainerNames(steps []Step) (errs *apis.FieldError) {
+	containerNames := sets.NewString()
+	for i, s := range steps {
+		if s.ContainerName != "" {
+			continue
+		}
+		if s.Name != "" {
+			containerNames.Insert(stepContainerPrefix + s.Name)
+		} else {
+			containerNames.Insert(fmt.Sprintf("%sunnamed-%d", stepContainerPrefix, i))
+		}
+	}
+	for i, s := range steps {
+		if s.ContainerName == "" {
+			continue
+		}
+		if containerNames.Has(s.ContainerName) {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("container name %q is used by another container of the pod", s.ContainerName), "containerName").ViaIndex(i))
+		}
+		containerNames.Insert(s.ContainerName)
+	}
+	return errs
+}
+
 // ValidateStepResults validates that all of the declared StepResults are valid.
 func ValidateStepResults(ctx context.Context, results []StepResult) (errs *apis.FieldError) {
 	for index, result := range results {
@@ -155,6 +155,22 @@ func TestTaskSpecValidate(t *testing.T) {
 		name   string
 		fields fields
 	}{{
+		name: "valid step container names",
+		fields: fields{
+			Steps: []v1.Step{{
+				Name:          "build",
+				ContainerName: "builder",
+				Image:         "my-image",
+			}, {
+				Name:          "test",
+				ContainerName: "step-tester",
+				Image:         "my-image",
+			}, {
+				Name:  "push",
+				Image: "my-image",
+			}},
+		},
+	}, {
 		name: "valid params type implied",
 		fields: fields{
 			Params: []v1.ParamSpec{{
@@ -1340,6 +1356,66 @@ func TestTaskSpecValidateError(t *testing.T) {
 			Message: `non-existent variable in "\n\t\t\t\t#!/usr/bin/env  bash\n\t\t\t\thello \"$(context.task.missing)\""`,
 			Paths:   []string{"steps[0].script"},
 		},
+	}, {
+		name: "invalid step container name",
+		fields: fields{
+			Steps: []v1.Step{{
+				Name:          "build",
+				ContainerName: "Builder",
+				Image:         "my-image",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value "Builder"`,
+			Paths:   []string{"steps[0].containerName"},
+			Details: "Step container name must be a valid DNS Label, For more info refer to https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names",
+		},
+	}, {
+		name: "step container name with the sidecar prefix",
+		fields: fields{
+			Steps: []v1.Step{{
+				Name:          "build",
+				ContainerName: "sidecar-builder",
+				Image:         "my-image",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: "sidecar-builder" must not start with "sidecar-" which is reserved for the containers of sidecars`,
+			Paths:   []string{"steps[0].containerName"},
+		},
+	}, {
+		name: "step container name used by another step",
+		fields: fields{
+			Steps: []v1.Step{{
+				Name:  "build",
+				Image: "my-image",
+			}, {
+				Name:          "test",
+				ContainerName: "step-build",
+				Image:         "my-image",
+			}, {
+				Name:          "push",
+				ContainerName: "step-build",
+				Image:         "my-image",
+			}},
+		},
+		expectedError: *apis.ErrInvalidValue(`container name "step-build" is used by another container of the pod`, "steps[1].containerName").
+			Also(apis.ErrInvalidValue(`container name "step-build" is used by another container of the pod`, "steps[2].containerName")),
+	}, {
+		name: "step container name used by the container of an unnamed step",
+		fields: fields{
+			Steps: []v1.Step{{
+				Image: "my-image",
+			}, {
+				Name:          "test",
+				ContainerName: "step-unnamed-0",
+				Image:         "my-image",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: container name "step-unnamed-0" is used by another container of the pod`,
+			Paths:   []string{"steps[1].containerName"},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1447,6 +1523,21 @@ func TestStepAndSidecarWorkspacesErrors(t *testing.T) {
 			Message: `undefined workspace "foo"`,
 			Paths:   []string{"sidecars[0].workspaces[0].name"},
 		},
+	}, {
+		name: "step script from a non-existent workspace declaration fails",
+		fields: fields{
+			Steps: []v1.Step{{
+				Image: "foo",
+				ScriptFrom: &v1.StepScriptSource{Workspace: &v1.WorkspaceFileSelector{
+					Name: "foo",
+					Path: "build.sh",
+				}},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `undefined workspace "foo"`,
+			Paths:   []string{"steps[0].scriptFrom.workspace.name"},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1469,6 +1560,87 @@ func TestStepAndSidecarWorkspacesErrors(t *testing.T) {
 	}
 }
 
+func TestSidecarDependencies(t *testing.T) {
+	tests := []struct {
+		name          string
+		steps         []v1.Step
+		sidecars      []v1.Sidecar
+		expectedError *apis.FieldError
+	}{{
+		name: "steps depend on sidecars stopped after steps",
+		steps: []v1.Step{{
+			Name:              "migrate",
+			Image:             "my-image",
+			DependsOnSidecars: []string{"db"},
+		}, {
+			Name:  "test",
+			Image: "my-image",
+		}},
+		sidecars: []v1.Sidecar{{
+			Name:           "db",
+			Image:          "postgres",
+			StopAfterSteps: []string{"migrate"},
+		}, {
+			Name:                     "cache",
+			Image:                    "redis",
+			FailTaskRunOnTermination: true,
+		}},
+	}, {
+		name: "step depending on an undefined sidecar",
+		steps: []v1.Step{{
+			Name:              "migrate",
+			Image:             "my-image",
+			DependsOnSidecars: []string{"db", "cache"},
+		}},
+		sidecars: []v1.Sidecar{{
+			Name:  "db",
+			Image: "postgres",
+		}},
+		expectedError: &apis.FieldError{
+			Message: `invalid value: undefined sidecar "cache"`,
+			Paths:   []string{"steps[0].dependsOnSidecars[1]"},
+		},
+	}, {
+		name: "sidecar stopped after an undefined step",
+		steps: []v1.Step{{
+			Name:  "migrate",
+			Image: "my-image",
+		}},
+		sidecars: []v1.Sidecar{{
+			Name:           "db",
+			Image:          "postgres",
+			StopAfterSteps: []string{"test"},
+		}},
+		expectedError: &apis.FieldError{
+			Message: `invalid value: undefined step "test"`,
+			Paths:   []string{"sidecars[0].stopAfterSteps[0]"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps:    tt.steps,
+				Sidecars: tt.sidecars,
+			}
+			ctx := cfgtesting.EnableAlphaAPIFields(t.Context())
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("TaskSpec.Validate() = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", ts)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 // TestIncompatibleAPIVersions exercises validation of fields that
 // require a specific feature gate version in order to work.
 func TestIncompatibleAPIVersions(t *testing.T) {
@@ -1506,6 +1678,48 @@ func TestIncompatibleAPIVersions(t *testing.T) {
 					}},
 				}},
 			},
+		}, {
+			name:            "step dependsOnSidecars requires alpha",
+			requiredVersion: "alpha",
+			spec: v1.TaskSpec{
+				Steps: []v1.Step{{
+					Name:              "foo",
+					Image:             "foo",
+					DependsOnSidecars: []string{"bar"},
+				}},
+				Sidecars: []v1.Sidecar{{
+					Name:  "bar",
+					Image: "bar",
+				}},
+			},
+		}, {
+			name:            "sidecar stopAfterSteps requires alpha",
+			requiredVersion: "alpha",
+			spec: v1.TaskSpec{
+				Steps: []v1.Step{{
+					Name:  "foo",
+					Image: "foo",
+				}},
+				Sidecars: []v1.Sidecar{{
+					Name:           "bar",
+					Image:          "bar",
+					StopAfterSteps: []string{"foo"},
+				}},
+			},
+		}, {
+			name:            "sidecar failTaskRunOnTermination requires alpha",
+			requiredVersion: "alpha",
+			spec: v1.TaskSpec{
+				Steps: []v1.Step{{
+					Name:  "foo",
+					Image: "foo",
+				}},
+				Sidecars: []v1.Sidecar{{
+					Name:                     "bar",
+					Image:                    "bar",
+					FailTaskRunOnTermination: true,
+				}},
+			},
 		}, {
 			name:            "sidecar workspace requires beta",
 			requiredVersion: "beta",
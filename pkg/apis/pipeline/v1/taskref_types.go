@@ -42,6 +42,10 @@ type TaskKind string
 const (
 	// NamespacedTaskKind indicates that the task type has a namespaced scope.
 	NamespacedTaskKind TaskKind = "Task"
+	// ApprovalGateKind indicates that the PipelineTask is an approval gate
+	// implemented by the PipelineRun controller, which waits for the decision
+	// on the approval of the PipelineRun.
+	ApprovalGateKind TaskKind = "ApprovalGate"
 )
 
 // IsCustomTask checks whether the reference is to a Custom Task
@@ -50,3 +54,8 @@ func (tr *TaskRef) IsCustomTask() bool {
 	// the reference will be considered a Custom Task - https://github.com/tektoncd/pipeline/issues/6457
 	return tr != nil && tr.APIVersion != "" && tr.Kind != ""
 }
+
+// IsApprovalGate checks whether the reference is to an approval gate
+func (tr *TaskRef) IsApprovalGate() bool {
+	return tr != nil && tr.Kind == ApprovalGateKind && tr.APIVersion == ""
+}
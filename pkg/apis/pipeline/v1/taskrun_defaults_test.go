@@ -16,6 +16,7 @@ limitations under the License.
 package v1_test
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -472,3 +473,112 @@ func TestTaskRunDefaultingOnCreate(t *testing.T) {
 		})
 	}
 }
+
+func TestTaskRunDefaultingWithNamespaceDefaults(t *testing.T) {
+	clusterDefaults := map[string]string{
+		"default-timeout-minutes":        "30",
+		"default-service-account":        "cluster-sa",
+		"default-pod-template":           "nodeSelector:\n  pool: cluster\n",
+		"default-managed-by-label-value": "cluster",
+	}
+	namespaceDefaults := func(_ context.Context, namespace string) (map[string]string, error) {
+		if namespace != "team-a" {
+			return nil, nil
+		}
+		return map[string]string{
+			"default-timeout-minutes":                 "10",
+			"default-service-account":                 "team-sa",
+			"default-managed-by-label-value":          "team",
+			"default-container-resource-requirements": "default:\n  requests:\n    memory: 64Mi\n",
+		}, nil
+	}
+	recorded := map[string]string{config.DefaultContainerResourceRequirementsAnnotation: "default:\n  requests:\n    memory: 64Mi\n"}
+	tests := []struct {
+		name     string
+		in       *v1.TaskRun
+		inCreate bool
+		want     *v1.TaskRun
+	}{{
+		name: "explicit spec over namespace defaults",
+		in: &v1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Labels: map[string]string{"app.kubernetes.io/managed-by": "me"}},
+			Spec: v1.TaskRunSpec{
+				TaskRef:            &v1.TaskRef{Name: "foo"},
+				Timeout:            &metav1.Duration{Duration: 5 * time.Minute},
+				ServiceAccountName: "my-sa",
+				PodTemplate:        &pod.Template{NodeSelector: map[string]string{"pool": "mine"}},
+			},
+		},
+		inCreate: true,
+		want: &v1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Labels: map[string]string{"app.kubernetes.io/managed-by": "me"}, Annotations: recorded},
+			Spec: v1.TaskRunSpec{
+				TaskRef:            &v1.TaskRef{Name: "foo", Kind: "Task"},
+				Timeout:            &metav1.Duration{Duration: 5 * time.Minute},
+				ServiceAccountName: "my-sa",
+				PodTemplate:        &pod.Template{NodeSelector: map[string]string{"pool": "mine"}},
+			},
+		},
+	}, {
+		name: "namespace defaults over cluster defaults",
+		in: &v1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-a"},
+			Spec:       v1.TaskRunSpec{TaskRef: &v1.TaskRef{Name: "foo"}},
+		},
+		inCreate: true,
+		want: &v1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Labels: map[string]string{"app.kubernetes.io/managed-by": "team"}, Annotations: recorded},
+			Spec: v1.TaskRunSpec{
+				TaskRef:            &v1.TaskRef{Name: "foo", Kind: "Task"},
+				Timeout:            &metav1.Duration{Duration: 10 * time.Minute},
+				ServiceAccountName: "team-sa",
+				PodTemplate:        &pod.Template{NodeSelector: map[string]string{"pool": "cluster"}},
+			},
+		},
+	}, {
+		name: "recorded namespace defaults can't be set on create",
+		in: &v1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-b", Annotations: map[string]string{config.DefaultContainerResourceRequirementsAnnotation: "default:\n  requests:\n    memory: 1Gi\n"}},
+			Spec:       v1.TaskRunSpec{TaskRef: &v1.TaskRef{Name: "foo"}},
+		},
+		inCreate: true,
+		want: &v1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-b", Labels: map[string]string{"app.kubernetes.io/managed-by": "cluster"}, Annotations: map[string]string{}},
+			Spec: v1.TaskRunSpec{
+				TaskRef:            &v1.TaskRef{Name: "foo", Kind: "Task"},
+				Timeout:            &metav1.Duration{Duration: 30 * time.Minute},
+				ServiceAccountName: "cluster-sa",
+				PodTemplate:        &pod.Template{NodeSelector: map[string]string{"pool": "cluster"}},
+			},
+		},
+	}, {
+		name: "cluster defaults for existing TaskRuns",
+		in: &v1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-a"},
+			Spec:       v1.TaskRunSpec{TaskRef: &v1.TaskRef{Name: "foo"}},
+		},
+		want: &v1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Labels: map[string]string{"app.kubernetes.io/managed-by": "cluster"}},
+			Spec: v1.TaskRunSpec{
+				TaskRef:            &v1.TaskRef{Name: "foo", Kind: "Task"},
+				Timeout:            &metav1.Duration{Duration: 30 * time.Minute},
+				ServiceAccountName: "cluster-sa",
+				PodTemplate:        &pod.Template{NodeSelector: map[string]string{"pool": "cluster"}},
+			},
+		},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := cfgtesting.SetDefaults(t.Context(), t, clusterDefaults)
+			ctx = config.WithNamespaceDefaultsGetter(ctx, namespaceDefaults)
+			if tc.inCreate {
+				ctx = apis.WithinCreate(ctx)
+			}
+			got := tc.in
+			got.SetDefaults(ctx)
+			if d := cmp.Diff(tc.want, got, ignoreUnexportedResources); d != "" {
+				t.Errorf("SetDefaults %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -362,6 +362,33 @@ func TestHasTimedOut(t *testing.T) {
 			},
 		},
 		expectedStatus: true,
+	}, {
+		name: "TaskRun paused past its timeout",
+		taskRun: &v1.TaskRun{
+			Spec: v1.TaskRunSpec{
+				Timeout: &metav1.Duration{
+					Duration: 10 * time.Second,
+				},
+			},
+			Status: v1.TaskRunStatus{
+				Status: duckv1.Status{
+					Conditions: []apis.Condition{{
+						Type:   apis.ConditionSucceeded,
+						Status: corev1.ConditionUnknown,
+					}},
+				},
+				TaskRunStatusFields: v1.TaskRunStatusFields{
+					StartTime: &metav1.Time{Time: now.Add(-15 * time.Second)},
+					PauseWindows: []v1.PauseWindow{{
+						StartTime: metav1.Time{Time: now.Add(-12 * time.Second)},
+						EndTime:   &metav1.Time{Time: now.Add(-9 * time.Second)},
+					}, {
+						StartTime: metav1.Time{Time: now.Add(-3 * time.Second)},
+					}},
+				},
+			},
+		},
+		expectedStatus: false,
 	}}
 
 	for _, tc := range testCases {
@@ -374,6 +401,24 @@ func TestHasTimedOut(t *testing.T) {
 	}
 }
 
+func TestPausedDuration(t *testing.T) {
+	tr := &v1.TaskRun{
+		Status: v1.TaskRunStatus{
+			TaskRunStatusFields: v1.TaskRunStatusFields{
+				PauseWindows: []v1.PauseWindow{{
+					StartTime: metav1.Time{Time: now.Add(-time.Hour)},
+					EndTime:   &metav1.Time{Time: now.Add(-50 * time.Minute)},
+				}, {
+					StartTime: metav1.Time{Time: now.Add(-5 * time.Minute)},
+				}},
+			},
+		},
+	}
+	if d := cmp.Diff(15*time.Minute, tr.PausedDuration(testClock)); d != "" {
+		t.Error(diff.PrintWantGot(d))
+	}
+}
+
 func TestInitializeTaskRunConditions(t *testing.T) {
 	tr := &v1.TaskRun{
 		ObjectMeta: metav1.ObjectMeta{
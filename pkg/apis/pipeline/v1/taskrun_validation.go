@@ -46,6 +46,10 @@ func (tr *TaskRun) SupportedVerbs() []admissionregistrationv1.OperationType {
 // Validate taskrun
 func (tr *TaskRun) Validate(ctx context.Context) *apis.FieldError {
 	errs := validate.ObjectMetadata(tr.GetObjectMeta()).ViaField("metadata")
+	errs = errs.Also(ValidateSourceEventAnnotations(ctx, tr.GetObjectMeta()).ViaField("metadata"))
+	if apis.IsInCreate(ctx) {
+		errs = errs.Also(validateNamespaceDefaults(ctx, tr.Namespace))
+	}
 	return errs.Also(tr.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
 }
 
@@ -97,8 +101,14 @@ func (ts *TaskRunSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	}
 
 	if ts.Status != "" {
-		if ts.Status != TaskRunSpecStatusCancelled {
-			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be %s", ts.Status, TaskRunSpecStatusCancelled), "status"))
+		switch ts.Status {
+		case TaskRunSpecStatusCancelled, TaskRunSpecStatusSuperseded:
+		case TaskRunSpecStatusPause, TaskRunSpecStatusResume:
+			if !config.FromContextOrDefaults(ctx).FeatureFlags.EnableTaskRunPause {
+				errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("%s requires \"%s\" feature flag to be \"true\"", ts.Status, config.EnableTaskRunPause), "status"))
+			}
+		default:
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be %s, %s, %s or %s", ts.Status, TaskRunSpecStatusCancelled, TaskRunSpecStatusSuperseded, TaskRunSpecStatusPause, TaskRunSpecStatusResume), "status"))
 		}
 	}
 	if ts.Status == "" {
@@ -118,6 +128,9 @@ func (ts *TaskRunSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 
 	if ts.PodTemplate != nil {
 		errs = errs.Also(validatePodTemplateEnv(ctx, *ts.PodTemplate))
+		errs = errs.Also(validatePodTemplateSecurityProfiles(ctx, *ts.PodTemplate))
+		errs = errs.Also(validatePodTemplateTerminationGracePeriod(ctx, *ts.PodTemplate))
+		errs = errs.Also(ts.PodTemplate.ValidateHostAliasesAndDNS().ViaField("podTemplate"))
 	}
 	return errs
 }
@@ -179,7 +192,10 @@ func (ts *TaskRunSpec) validateInlineParameters(ctx context.Context) (errs *apis
 	if ts.TaskSpec != nil && ts.TaskSpec.Steps != nil {
 		errs = errs.Also(ValidateParameterTypes(ctx, paramSpec))
 		errs = errs.Also(ValidateParameterVariables(ctx, ts.TaskSpec.Steps, paramSpec))
-		errs = errs.Also(ValidateUsageOfDeclaredParameters(ctx, ts.TaskSpec.Steps, paramSpec))
+		// The params imported with paramsFrom are only known, and validated, when the TaskRun is run.
+		if len(ts.TaskSpec.ParamsFrom) == 0 {
+			errs = errs.Also(ValidateUsageOfDeclaredParameters(ctx, ts.TaskSpec.Steps, paramSpec))
+		}
 	}
 	return errs
 }
@@ -197,6 +213,31 @@ func validatePodTemplateEnv(ctx context.Context, podTemplate pod.Template) (errs
 	return errs
 }
 
+// validatePodTemplateSecurityProfiles validates that the pod template doesn't
+// use Localhost profiles when they are forbidden.
+func validatePodTemplateSecurityProfiles(ctx context.Context, podTemplate pod.Template) (errs *apis.FieldError) {
+	errs = validateLocalhostProfiles(ctx, podTemplate.SeccompProfile, podTemplate.AppArmorProfile)
+	if sc := podTemplate.SecurityContext; sc != nil {
+		errs = errs.Also(validateLocalhostProfiles(ctx, sc.SeccompProfile, sc.AppArmorProfile).ViaField("securityContext"))
+	}
+	return errs.ViaField("podTemplate")
+}
+
+// validatePodTemplateTerminationGracePeriod validates that the termination
+// grace period of the pod template doesn't exceed the
+// max-termination-grace-period-seconds config.
+func validatePodTemplateTerminationGracePeriod(ctx context.Context, podTemplate pod.Template) *apis.FieldError {
+	seconds := podTemplate.TerminationGracePeriodSeconds
+	if seconds == nil {
+		return nil
+	}
+	maxSeconds := config.FromContextOrDefaults(ctx).Defaults.MaxTerminationGracePeriodSeconds
+	if *seconds < 0 || *seconds > maxSeconds {
+		return apis.ErrOutOfBoundsValue(*seconds, 0, maxSeconds, "terminationGracePeriodSeconds").ViaField("podTemplate")
+	}
+	return nil
+}
+
 func createParamSpecFromParam(p Param, paramSpecForValidation map[string]ParamSpec) map[string]ParamSpec {
 	value := p.Value
 	pSpec := ParamSpec{
@@ -302,12 +343,14 @@ func validateStepSpecs(specs []TaskRunStepSpec) (errs *apis.FieldError) {
 		} else {
 			names = append(names, o.Name)
 		}
+		errs = errs.Also(validateExtendedResources(o.ComputeResources).ViaField("computeResources").ViaIndex(i))
 	}
 	errs = errs.Also(validateNoDuplicateNames(names, true))
 	return errs
 }
 
-// validateTaskRunComputeResources ensures that compute resources are not configured at both the step level and the task level
+// validateTaskRunComputeResources ensures that compute resources are not configured at both the step level and the task level,
+// and that the task-level extended resources are integers
 func validateTaskRunComputeResources(computeResources *corev1.ResourceRequirements, specs []TaskRunStepSpec) (errs *apis.FieldError) {
 	for _, spec := range specs {
 		if spec.ComputeResources.Size() != 0 && computeResources != nil {
@@ -317,7 +360,10 @@ func validateTaskRunComputeResources(computeResources *corev1.ResourceRequiremen
 			)
 		}
 	}
-	return nil
+	if computeResources != nil {
+		errs = errs.Also(validateExtendedResources(*computeResources).ViaField("computeResources"))
+	}
+	return errs
 }
 
 func validateSidecarSpecs(specs []TaskRunSidecarSpec) (errs *apis.FieldError) {
@@ -328,6 +374,7 @@ func validateSidecarSpecs(specs []TaskRunSidecarSpec) (errs *apis.FieldError) {
 		} else {
 			names = append(names, o.Name)
 		}
+		errs = errs.Also(validateExtendedResources(o.ComputeResources).ViaField("computeResources").ViaIndex(i))
 	}
 	errs = errs.Also(validateNoDuplicateNames(names, true))
 	return errs
@@ -350,3 +397,12 @@ func validateNoDuplicateNames(names []string, byIndex bool) (errs *apis.FieldErr
 	}
 	return errs
 }
+
+// validateNamespaceDefaults returns an error if the namespace of a new run
+// has a ConfigMap overriding the defaults which can't be used.
+func validateNamespaceDefaults(ctx context.Context, namespace string) *apis.FieldError {
+	if _, err := config.DefaultsForNamespace(ctx, namespace); err != nil {
+		return apis.ErrGeneric(err.Error(), "metadata.namespace")
+	}
+	return nil
+}
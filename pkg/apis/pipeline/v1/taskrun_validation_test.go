@@ -31,6 +31,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	corev1resources "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 )
@@ -121,6 +122,20 @@ func TestTaskRun_Invalidate(t *testing.T) {
 			Paths:   []string{"spec.task-words.properties"},
 		},
 		wc: cfgtesting.EnableAlphaAPIFields,
+	}, {
+		name: "invalid defaults in the namespace of a new TaskRun",
+		taskRun: &v1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "tr", Namespace: "team-a"},
+			Spec: v1.TaskRunSpec{
+				TaskRef: &v1.TaskRef{Name: "task"},
+			},
+		},
+		want: apis.ErrGeneric(`invalid tekton-config-defaults ConfigMap in namespace team-a: failed parsing default config "default-timeout-minutes"`, "metadata.namespace"),
+		wc: func(ctx context.Context) context.Context {
+			return apis.WithinCreate(config.WithNamespaceDefaultsGetter(ctx, func(context.Context, string) (map[string]string, error) {
+				return map[string]string{"default-timeout-minutes": "ten"}, nil
+			}))
+		},
 	}}
 	for _, ts := range tests {
 		t.Run(ts.name, func(t *testing.T) {
@@ -507,8 +522,15 @@ func EnableForbiddenEnv(ctx context.Context) context.Context {
 	return config.ToContext(ctx, c)
 }
 
+func forbidLocalhostProfiles(ctx context.Context) context.Context {
+	c := config.FromContextOrDefaults(ctx)
+	c.Defaults.ForbidLocalhostProfiles = true
+	return config.ToContext(ctx, c)
+}
+
 func TestTaskRunSpec_Invalidate(t *testing.T) {
 	invalidStatusMessage := "status message without status"
+	localhostProfile := "custom"
 	tests := []struct {
 		name    string
 		spec    v1.TaskRunSpec
@@ -536,6 +558,60 @@ func TestTaskRunSpec_Invalidate(t *testing.T) {
 		},
 		wc:      EnableForbiddenEnv,
 		wantErr: apis.ErrInvalidValue("PodTemplate cannot update a forbidden env: TEST_ENV", "PodTemplate.Env"),
+	}, {
+		name: "Localhost profiles when forbidden",
+		spec: v1.TaskRunSpec{
+			TaskSpec: &v1.TaskSpec{
+				Steps: []v1.Step{{
+					Name:  "mystep",
+					Image: "myimage",
+					SecurityContext: &corev1.SecurityContext{
+						AppArmorProfile: &corev1.AppArmorProfile{Type: corev1.AppArmorProfileTypeLocalhost, LocalhostProfile: &localhostProfile},
+					},
+				}},
+			},
+			PodTemplate: &pod.Template{
+				SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeLocalhost, LocalhostProfile: &localhostProfile},
+				SecurityContext: &corev1.PodSecurityContext{
+					AppArmorProfile: &corev1.AppArmorProfile{Type: corev1.AppArmorProfileTypeLocalhost, LocalhostProfile: &localhostProfile},
+				},
+			},
+		},
+		wc: forbidLocalhostProfiles,
+		wantErr: &apis.FieldError{
+			Message: "invalid value: Localhost profiles are forbidden by the forbid-localhost-profiles config",
+			Paths:   []string{"podTemplate.seccompProfile.type", "podTemplate.securityContext.appArmorProfile.type", "taskSpec.steps[0].securityContext.appArmorProfile.type"},
+		},
+	}, {
+		name: "invalid host aliases and dns search domains",
+		spec: v1.TaskRunSpec{
+			TaskRef: &v1.TaskRef{Name: "task"},
+			PodTemplate: &pod.Template{
+				HostAliases: []corev1.HostAlias{{IP: "10.0.0", Hostnames: []string{"git.internal.example.com"}}},
+				DNSConfig:   &corev1.PodDNSConfig{Searches: []string{"internal..example.com"}},
+			},
+		},
+		wantErr: apis.ErrInvalidValue("10.0.0 must be a valid IP address", "podTemplate.hostAliases[0].ip").Also(
+			apis.ErrInvalidValue(`internal..example.com must be a valid search domain: a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')`, "podTemplate.dnsConfig.searches[0]")),
+	}, {
+		name: "termination grace period above the maximum",
+		spec: v1.TaskRunSpec{
+			TaskRef:     &v1.TaskRef{Name: "task"},
+			PodTemplate: &pod.Template{TerminationGracePeriodSeconds: pointer.Int64(600)},
+		},
+		wc: func(ctx context.Context) context.Context {
+			c := config.FromContextOrDefaults(ctx)
+			c.Defaults.MaxTerminationGracePeriodSeconds = 300
+			return config.ToContext(ctx, c)
+		},
+		wantErr: apis.ErrOutOfBoundsValue(600, 0, 300, "podTemplate.terminationGracePeriodSeconds"),
+	}, {
+		name: "negative termination grace period",
+		spec: v1.TaskRunSpec{
+			TaskRef:     &v1.TaskRef{Name: "task"},
+			PodTemplate: &pod.Template{TerminationGracePeriodSeconds: pointer.Int64(-1)},
+		},
+		wantErr: apis.ErrOutOfBoundsValue(-1, 0, 3600, "podTemplate.terminationGracePeriodSeconds"),
 	}, {
 		name: "invalid taskref and taskspec together",
 		spec: v1.TaskRunSpec{
@@ -612,7 +688,16 @@ func TestTaskRunSpec_Invalidate(t *testing.T) {
 			},
 			Status: "TaskRunCancell",
 		},
-		wantErr: apis.ErrInvalidValue("TaskRunCancell should be TaskRunCancelled", "status"),
+		wantErr: apis.ErrInvalidValue("TaskRunCancell should be TaskRunCancelled, TaskRunSuperseded, TaskRunPause or TaskRunResume", "status"),
+	}, {
+		name: "taskrun pause without the feature flag",
+		spec: v1.TaskRunSpec{
+			TaskRef: &v1.TaskRef{
+				Name: "taskrefname",
+			},
+			Status: v1.TaskRunSpecStatusPause,
+		},
+		wantErr: apis.ErrGeneric(`TaskRunPause requires "enable-taskrun-pause" feature flag to be "true"`, "status"),
 	}, {
 		name: "incorrectly set statusMesage",
 		spec: v1.TaskRunSpec{
@@ -861,6 +946,29 @@ func TestTaskRunSpec_Invalidate(t *testing.T) {
 		},
 		wc:      cfgtesting.EnableStableAPIFields,
 		wantErr: apis.ErrGeneric("computeResources requires \"enable-api-fields\" feature gate to be \"alpha\" or \"beta\" but it is \"stable\""),
+	}, {
+		name: "fraction of a GPU in task-level computeResources",
+		spec: v1.TaskRunSpec{
+			TaskRef: &v1.TaskRef{Name: "foo"},
+			ComputeResources: &corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{"nvidia.com/gpu": corev1resources.MustParse("1.5")},
+			},
+		},
+		wc:      cfgtesting.EnableBetaAPIFields,
+		wantErr: apis.ErrInvalidValue("1500m", "computeResources.limits.nvidia.com/gpu", "extended resources must be requested in integer amounts"),
+	}, {
+		name: "fraction of a GPU in stepSpecs",
+		spec: v1.TaskRunSpec{
+			TaskRef: &v1.TaskRef{Name: "foo"},
+			StepSpecs: []v1.TaskRunStepSpec{{
+				Name: "train",
+				ComputeResources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{"nvidia.com/gpu": corev1resources.MustParse("100m")},
+				},
+			}},
+		},
+		wc:      cfgtesting.EnableBetaAPIFields,
+		wantErr: apis.ErrInvalidValue("100m", "stepSpecs[0].computeResources.requests.nvidia.com/gpu", "extended resources must be requested in integer amounts"),
 	}}
 
 	for _, ts := range tests {
@@ -892,6 +1000,37 @@ func TestTaskRunSpec_Validate(t *testing.T) {
 				}},
 			},
 		},
+	}, {
+		name: "termination grace period",
+		spec: v1.TaskRunSpec{
+			TaskRef:     &v1.TaskRef{Name: "task"},
+			PodTemplate: &pod.Template{TerminationGracePeriodSeconds: pointer.Int64(300)},
+		},
+	}, {
+		name: "superseded",
+		spec: v1.TaskRunSpec{
+			Status:        v1.TaskRunSpecStatusSuperseded,
+			StatusMessage: "TaskRun is superseded",
+			TaskRef:       &v1.TaskRef{Name: "task"},
+		},
+	}, {
+		name: "paused",
+		spec: v1.TaskRunSpec{
+			Status:  v1.TaskRunSpecStatusPause,
+			TaskRef: &v1.TaskRef{Name: "task"},
+		},
+		wc: func(ctx context.Context) context.Context {
+			return cfgtesting.SetFeatureFlags(ctx, t, map[string]string{"enable-taskrun-pause": "true"})
+		},
+	}, {
+		name: "resumed",
+		spec: v1.TaskRunSpec{
+			Status:  v1.TaskRunSpecStatusResume,
+			TaskRef: &v1.TaskRef{Name: "task"},
+		},
+		wc: func(ctx context.Context) context.Context {
+			return cfgtesting.SetFeatureFlags(ctx, t, map[string]string{"enable-taskrun-pause": "true"})
+		},
 	}, {
 		name: "no timeout",
 		spec: v1.TaskRunSpec{
@@ -0,0 +1,79 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"knative.dev/pkg/apis"
+)
+
+// Validate validates the tasksFilter of a PipelineRun, which requires the
+// alpha API fields.
+func (f *PipelineRunTasksFilter) Validate(ctx context.Context) (errs *apis.FieldError) {
+	errs = config.ValidateEnabledAPIFields(ctx, "tasksFilter", config.AlphaAPIFields)
+
+	if len(f.Include) == 0 {
+		errs = errs.Also(apis.ErrMissingField("include"))
+	}
+	included := sets.NewString()
+	for i, entry := range f.Include {
+		switch {
+		case entry == "":
+			errs = errs.Also(apis.ErrInvalidValue("empty entry", "").ViaFieldIndex("include", i))
+		case included.Has(entry):
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("%q is included more than once", entry), "").ViaFieldIndex("include", i))
+		case !IsPipelineTaskName(entry):
+			if _, err := labels.Parse(entry); err != nil {
+				errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%q is neither the name of a PipelineTask nor a label selector: %v", entry, err), "").ViaFieldIndex("include", i))
+			}
+		}
+		included.Insert(entry)
+	}
+
+	excluded := sets.NewString()
+	for i, name := range f.ExcludeFinally {
+		if msgs := validation.IsDNS1123Label(name); len(msgs) > 0 {
+			errs = errs.Also(apis.ErrInvalidValue(name, "", strings.Join(msgs, ", ")).ViaFieldIndex("excludeFinally", i))
+		} else if excluded.Has(name) {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("finally task %q is excluded more than once", name), "").ViaFieldIndex("excludeFinally", i))
+		}
+		excluded.Insert(name)
+	}
+
+	overridden := sets.NewString()
+	for i, o := range f.ResultOverrides {
+		if o.PipelineTask == "" {
+			errs = errs.Also(apis.ErrMissingField("pipelineTask").ViaFieldIndex("resultOverrides", i))
+		}
+		if o.Name == "" {
+			errs = errs.Also(apis.ErrMissingField("name").ViaFieldIndex("resultOverrides", i))
+		}
+		key := o.PipelineTask + "." + o.Name
+		if overridden.Has(key) {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("result %q of PipelineTask %q is overridden more than once", o.Name, o.PipelineTask), "name").ViaFieldIndex("resultOverrides", i))
+		}
+		overridden.Insert(key)
+	}
+	return errs
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	cfgtesting "github.com/tektoncd/pipeline/pkg/apis/config/testing"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test/diff"
+	"knative.dev/pkg/apis"
+)
+
+func TestPipelineRunTasksFilter_Validate(t *testing.T) {
+	filter := v1.PipelineRunTasksFilter{
+		Include:          []string{"unit-tests", "tier=lint", "stage in (test, build)"},
+		WithDependencies: true,
+		ExcludeFinally:   []string{"notify"},
+		ResultOverrides: []v1.PipelineTaskResultOverride{{
+			PipelineTask: "build",
+			Name:         "image",
+			Value:        *v1.NewStructuredValues("registry.example.com/app:dev"),
+		}},
+	}
+	if err := filter.Validate(cfgtesting.EnableAlphaAPIFields(t.Context())); err != nil {
+		t.Errorf("PipelineRunTasksFilter.Validate() returned error for valid filter: %v", err)
+	}
+}
+
+func TestPipelineRunTasksFilter_Invalid(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		filter  v1.PipelineRunTasksFilter
+		wantErr *apis.FieldError
+	}{{
+		name:    "no entry included",
+		filter:  v1.PipelineRunTasksFilter{},
+		wantErr: apis.ErrMissingField("include"),
+	}, {
+		name:    "empty entry",
+		filter:  v1.PipelineRunTasksFilter{Include: []string{""}},
+		wantErr: apis.ErrInvalidValue("empty entry", "include[0]"),
+	}, {
+		name:    "entry included twice",
+		filter:  v1.PipelineRunTasksFilter{Include: []string{"build", "build"}},
+		wantErr: apis.ErrGeneric(`"build" is included more than once`, "include[1]"),
+	}, {
+		name:    "invalid label selector",
+		filter:  v1.PipelineRunTasksFilter{Include: []string{"tier in (lint"}},
+		wantErr: apis.ErrInvalidValue(`"tier in (lint" is neither the name of a PipelineTask nor a label selector: unable to parse requirement: found '', expected: ',' or ')'`, "include[0]"),
+	}, {
+		name:    "invalid finally task name",
+		filter:  v1.PipelineRunTasksFilter{Include: []string{"build"}, ExcludeFinally: []string{"Notify"}},
+		wantErr: apis.ErrInvalidValue("Notify", "excludeFinally[0]", "a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')"),
+	}, {
+		name: "result overridden twice",
+		filter: v1.PipelineRunTasksFilter{
+			Include: []string{"unit-tests"},
+			ResultOverrides: []v1.PipelineTaskResultOverride{{
+				PipelineTask: "build",
+				Name:         "image",
+				Value:        *v1.NewStructuredValues("registry.example.com/app:dev"),
+			}, {
+				PipelineTask: "build",
+				Name:         "image",
+				Value:        *v1.NewStructuredValues("registry.example.com/app:latest"),
+			}},
+		},
+		wantErr: apis.ErrGeneric(`result "image" of PipelineTask "build" is overridden more than once`, "resultOverrides[1].name"),
+	}, {
+		name: "result override without its task",
+		filter: v1.PipelineRunTasksFilter{
+			Include: []string{"unit-tests"},
+			ResultOverrides: []v1.PipelineTaskResultOverride{{
+				Name:  "image",
+				Value: *v1.NewStructuredValues("registry.example.com/app:dev"),
+			}},
+		},
+		wantErr: apis.ErrMissingField("resultOverrides[0].pipelineTask"),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.filter.Validate(cfgtesting.EnableAlphaAPIFields(t.Context()))
+			if d := cmp.Diff(tc.wantErr.Error(), err.Error()); d != "" {
+				t.Errorf("PipelineRunTasksFilter.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineRunTasksFilter_RequiresAlpha(t *testing.T) {
+	filter := v1.PipelineRunTasksFilter{Include: []string{"build"}}
+	err := filter.Validate(cfgtesting.EnableBetaAPIFields(t.Context()))
+	want := `tasksFilter requires "enable-api-fields" feature gate to be "alpha" but it is "beta"`
+	if err == nil || err.Message != want {
+		t.Errorf("Expected error %q but got %v", want, err)
+	}
+}
@@ -44,6 +44,30 @@ type WhenExpression struct {
 	CEL string `json:"cel,omitempty"`
 }
 
+// EvaluatedWhenExpression is a WhenExpression of a skipped PipelineTask, with its variables
+// replaced, and the outcome of its evaluation
+type EvaluatedWhenExpression struct {
+	// Input is the string for guard checking which can be a static input or an output from a parent Task
+	// +optional
+	Input string `json:"input,omitempty"`
+
+	// Operator that represents an Input's relationship to the values
+	// +optional
+	Operator selection.Operator `json:"operator,omitempty"`
+
+	// Values is an array of strings, which is compared against the input, for guard checking
+	// +optional
+	// +listType=atomic
+	Values []string `json:"values,omitempty"`
+
+	// CEL is a string of Common Language Expression
+	// +optional
+	CEL string `json:"cel,omitempty"`
+
+	// Outcome is true if the WhenExpression allowed the execution of the PipelineTask
+	Outcome bool `json:"outcome"`
+}
+
 func (we *WhenExpression) isInputInValues() bool {
 	for i := range we.Values {
 		if we.Values[i] == we.Input {
@@ -114,6 +138,22 @@ func (wes WhenExpressions) AllowsExecution(evaluatedCEL map[string]bool) bool {
 	return true
 }
 
+// Evaluate returns the outcome of the evaluation of each When Expression, given the evaluated
+// CEL expressions.
+func (wes WhenExpressions) Evaluate(evaluatedCEL map[string]bool) []EvaluatedWhenExpression {
+	var evaluated []EvaluatedWhenExpression
+	for _, we := range wes {
+		evaluated = append(evaluated, EvaluatedWhenExpression{
+			Input:    we.Input,
+			Operator: we.Operator,
+			Values:   we.Values,
+			CEL:      we.CEL,
+			Outcome:  we.isTrue() && (we.CEL == "" || evaluatedCEL[we.CEL]),
+		})
+	}
+	return evaluated
+}
+
 // ReplaceVariables interpolates variables, such as Parameters and Results, in
 // the Input and Values.
 func (wes WhenExpressions) ReplaceVariables(replacements map[string]string, arrayReplacements map[string][]string) WhenExpressions {
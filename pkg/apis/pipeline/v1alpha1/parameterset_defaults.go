@@ -0,0 +1,40 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"knative.dev/pkg/apis"
+)
+
+var _ apis.Defaultable = (*ParameterSet)(nil)
+
+// SetDefaults implements apis.Defaultable
+func (p *ParameterSet) SetDefaults(ctx context.Context) {
+	p.Spec.SetDefaults(ctx)
+}
+
+// SetDefaults set any defaults for the ParameterSet spec
+func (ps *ParameterSetSpec) SetDefaults(ctx context.Context) {
+	for i := range ps.Params {
+		ps.Params[i].SetDefaults(ctx)
+	}
+	for i := range ps.Results {
+		ps.Results[i].SetDefaults(ctx)
+	}
+}
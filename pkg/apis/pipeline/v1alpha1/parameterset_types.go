@@ -0,0 +1,82 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/kmeta"
+)
+
+// +genclient
+// +genclient:noStatus
+// +genreconciler:krshapedlogic=false
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ParameterSet declares params shared by several Tasks and Pipelines, and
+// results shared by several Tasks, which import them with paramsFrom.
+//
+// +k8s:openapi-gen=true
+type ParameterSet struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec holds the params and results declared by the ParameterSet.
+	// +optional
+	Spec ParameterSetSpec `json:"spec"`
+}
+
+var _ kmeta.OwnerRefable = (*ParameterSet)(nil)
+
+// GetGroupVersionKind implements kmeta.OwnerRefable.
+func (*ParameterSet) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("ParameterSet")
+}
+
+// ParameterSetList contains a list of ParameterSets
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ParameterSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ParameterSet `json:"items"`
+}
+
+// ParameterSetSpec contains the params and results declared by a ParameterSet.
+type ParameterSetSpec struct {
+	// Description is a user-facing description of the ParameterSet that may
+	// be used to populate a UI.
+	// +optional
+	Description string `json:"description,omitempty"`
+	// Params declares the params imported by the Tasks and Pipelines
+	// referencing the ParameterSet.
+	// +optional
+	Params v1.ParamSpecs `json:"params,omitempty"`
+	// Results declares the results imported by the Tasks referencing the
+	// ParameterSet. The Pipelines only import its params.
+	// +optional
+	// +listType=atomic
+	Results []v1.TaskResult `json:"results,omitempty"`
+	// ParamsFrom references other ParameterSets whose params and results are
+	// imported into the ParameterSet. The params and results declared in
+	// Params and Results take precedence over the imported ones.
+	// +optional
+	// +listType=atomic
+	ParamsFrom []v1.ParameterSetRef `json:"paramsFrom,omitempty"`
+}
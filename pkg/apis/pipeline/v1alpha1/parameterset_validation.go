@@ -0,0 +1,79 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/validate"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/webhook/resourcesemantics"
+)
+
+var (
+	_ apis.Validatable              = (*ParameterSet)(nil)
+	_ resourcesemantics.VerbLimited = (*ParameterSet)(nil)
+)
+
+// SupportedVerbs returns the operations that validation should be called for
+func (p *ParameterSet) SupportedVerbs() []admissionregistrationv1.OperationType {
+	return []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update}
+}
+
+// Validate implements apis.Validatable
+func (p *ParameterSet) Validate(ctx context.Context) (errs *apis.FieldError) {
+	errs = validate.ObjectMetadata(p.GetObjectMeta()).ViaField("metadata")
+	errs = errs.Also(p.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
+	// The ParameterSets referencing each other in a cycle are only detected
+	// when they are imported, but a ParameterSet can't import itself.
+	for i, ref := range p.Spec.ParamsFrom {
+		if ref.Name == p.Name {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("ParameterSet %q can't import its own params", p.Name), "name").ViaFieldIndex("paramsFrom", i).ViaField("spec"))
+		}
+	}
+	return errs
+}
+
+// Validate implements apis.Validatable
+func (ps *ParameterSetSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if len(ps.Params) == 0 && len(ps.Results) == 0 && len(ps.ParamsFrom) == 0 {
+		errs = errs.Also(apis.ErrMissingOneOf("params", "results", "paramsFrom"))
+	}
+	errs = errs.Also(v1.ValidateParameterTypes(ctx, ps.Params).ViaField("params"))
+	errs = errs.Also(ps.Params.ValidateNoDuplicateNames())
+	errs = errs.Also(validateResults(ctx, ps.Results))
+	errs = errs.Also(v1.ValidateParameterSetRefs(ps.ParamsFrom).ViaField("paramsFrom"))
+	return errs
+}
+
+// validateResults validates the results declared by a ParameterSet, whose
+// names must be unique.
+func validateResults(ctx context.Context, results []v1.TaskResult) (errs *apis.FieldError) {
+	names := sets.New[string]()
+	for i, r := range results {
+		errs = errs.Also(r.Validate(ctx).ViaFieldIndex("results", i))
+		if names.Has(r.Name) {
+			errs = errs.Also(apis.ErrGeneric("result appears more than once", "").ViaFieldKey("results", r.Name))
+		}
+		names.Insert(r.Name)
+	}
+	return errs
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestParameterSetValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		ps   *v1alpha1.ParameterSet
+	}{{
+		name: "params",
+		ps: &v1alpha1.ParameterSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "common"},
+			Spec: v1alpha1.ParameterSetSpec{
+				Params: v1.ParamSpecs{{
+					Name:    "registry",
+					Default: v1.NewStructuredValues("registry.example.com"),
+				}, {
+					Name: "tags",
+					Type: v1.ParamTypeArray,
+				}},
+			},
+		},
+	}, {
+		name: "results",
+		ps: &v1alpha1.ParameterSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "image"},
+			Spec: v1alpha1.ParameterSetSpec{
+				Results: []v1.TaskResult{{
+					Name: "IMAGE_DIGEST",
+				}, {
+					Name: "IMAGE_URL",
+					Type: v1.ResultsTypeString,
+				}},
+			},
+		},
+	}, {
+		name: "params imported from other ParameterSets",
+		ps: &v1alpha1.ParameterSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "build"},
+			Spec: v1alpha1.ParameterSetSpec{
+				ParamsFrom: []v1.ParameterSetRef{{Name: "common"}, {Name: "images"}},
+			},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := t.Context()
+			tt.ps.SetDefaults(ctx)
+			if err := tt.ps.Validate(ctx); err != nil {
+				t.Errorf("ParameterSet.Validate() returned error for valid ParameterSet: %v", err)
+			}
+		})
+	}
+}
+
+func TestParameterSetValidateError(t *testing.T) {
+	tests := []struct {
+		name          string
+		ps            *v1alpha1.ParameterSet
+		expectedError apis.FieldError
+	}{{
+		name: "no params",
+		ps: &v1alpha1.ParameterSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "common"},
+		},
+		expectedError: apis.FieldError{
+			Message: "expected exactly one, got neither",
+			Paths:   []string{"spec.params", "spec.paramsFrom", "spec.results"},
+		},
+	}, {
+		name: "duplicate params",
+		ps: &v1alpha1.ParameterSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "common"},
+			Spec: v1alpha1.ParameterSetSpec{
+				Params: v1.ParamSpecs{{Name: "registry"}, {Name: "registry"}},
+			},
+		},
+		expectedError: apis.FieldError{
+			Message: "parameter appears more than once",
+			Paths:   []string{"spec.params[registry]"},
+		},
+	}, {
+		name: "duplicate results",
+		ps: &v1alpha1.ParameterSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "image"},
+			Spec: v1alpha1.ParameterSetSpec{
+				Results: []v1.TaskResult{{Name: "IMAGE_DIGEST"}, {Name: "IMAGE_DIGEST"}},
+			},
+		},
+		expectedError: apis.FieldError{
+			Message: "result appears more than once",
+			Paths:   []string{"spec.results[IMAGE_DIGEST]"},
+		},
+	}, {
+		name: "invalid result name",
+		ps: &v1alpha1.ParameterSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "image"},
+			Spec: v1alpha1.ParameterSetSpec{
+				Results: []v1.TaskResult{{Name: "image digest"}},
+			},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid key name "image digest"`,
+			Paths:   []string{"spec.results[0].name"},
+			Details: "Name must consist of alphanumeric characters, '-', '_', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my-name',  or 'my_name', regex used for validation is '^([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]$')",
+		},
+	}, {
+		name: "invalid param type",
+		ps: &v1alpha1.ParameterSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "common"},
+			Spec: v1alpha1.ParameterSetSpec{
+				Params: v1.ParamSpecs{{Name: "registry", Type: "number"}},
+			},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: number`,
+			Paths:   []string{"spec.params.registry.type"},
+		},
+	}, {
+		name: "ParameterSet referenced more than once",
+		ps: &v1alpha1.ParameterSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "build"},
+			Spec: v1alpha1.ParameterSetSpec{
+				ParamsFrom: []v1.ParameterSetRef{{Name: "common"}, {Name: "common"}},
+			},
+		},
+		expectedError: apis.FieldError{
+			Message: `ParameterSet "common" is referenced more than once`,
+			Paths:   []string{"spec.paramsFrom[1].name"},
+		},
+	}, {
+		name: "invalid ParameterSet reference",
+		ps: &v1alpha1.ParameterSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "build"},
+			Spec: v1alpha1.ParameterSetSpec{
+				ParamsFrom: []v1.ParameterSetRef{{}},
+			},
+		},
+		expectedError: apis.FieldError{
+			Message: "missing field(s)",
+			Paths:   []string{"spec.paramsFrom[0].name"},
+		},
+	}, {
+		name: "ParameterSet importing its own params",
+		ps: &v1alpha1.ParameterSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "common"},
+			Spec: v1alpha1.ParameterSetSpec{
+				Params:     v1.ParamSpecs{{Name: "registry"}},
+				ParamsFrom: []v1.ParameterSetRef{{Name: "common"}},
+			},
+		},
+		expectedError: apis.FieldError{
+			Message: `ParameterSet "common" can't import its own params`,
+			Paths:   []string{"spec.paramsFrom[0].name"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := t.Context()
+			tt.ps.SetDefaults(ctx)
+			err := tt.ps.Validate(ctx)
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", tt.ps)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("ParameterSet.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
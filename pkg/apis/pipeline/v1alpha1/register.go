@@ -52,6 +52,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&VerificationPolicyList{},
 		&StepAction{},
 		&StepActionList{},
+		&ParameterSet{},
+		&ParameterSetList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
@@ -16,6 +16,7 @@ package v1alpha1
 import (
 	"context"
 
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"knative.dev/pkg/apis"
 )
 
@@ -24,6 +25,7 @@ var _ apis.Defaultable = (*StepAction)(nil)
 // SetDefaults implements apis.Defaultable
 func (s *StepAction) SetDefaults(ctx context.Context) {
 	s.Spec.SetDefaults(ctx)
+	v1.SetInterfaceDigest(ctx, &s.ObjectMeta, StepActionInterfaceOf(s.Spec))
 }
 
 // SetDefaults set any defaults for the StepAction spec
@@ -35,3 +37,9 @@ func (ss *StepActionSpec) SetDefaults(ctx context.Context) {
 		ss.Results[i].SetDefaults(ctx)
 	}
 }
+
+// StepActionInterfaceOf returns the InterfaceDoc of the params and results of
+// the StepActionSpec.
+func StepActionInterfaceOf(ss StepActionSpec) v1.InterfaceDoc {
+	return v1.StepInterfaceOf(ss.Params, ss.Results)
+}
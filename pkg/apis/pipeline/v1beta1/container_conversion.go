@@ -24,6 +24,7 @@ import (
 
 func (r Ref) convertTo(ctx context.Context, sink *v1.Ref) {
 	sink.Name = r.Name
+	sink.Version = r.Version
 	new := v1.ResolverRef{}
 	r.ResolverRef.convertTo(ctx, &new)
 	sink.ResolverRef = new
@@ -31,6 +32,7 @@ func (r Ref) convertTo(ctx context.Context, sink *v1.Ref) {
 
 func (r *Ref) convertFrom(ctx context.Context, source v1.Ref) {
 	r.Name = source.Name
+	r.Version = source.Version
 	new := ResolverRef{}
 	new.convertFrom(ctx, source.ResolverRef)
 	r.ResolverRef = new
@@ -61,6 +63,13 @@ func (s Step) convertTo(ctx context.Context, sink *v1.Step) {
 	sink.OnError = (v1.OnErrorType)(s.OnError)
 	sink.StdoutConfig = (*v1.StepOutputConfig)(s.StdoutConfig)
 	sink.StderrConfig = (*v1.StepOutputConfig)(s.StderrConfig)
+	sink.StdinFrom = (*v1.StepStdinSource)(s.StdinFrom)
+	if s.ScriptFrom != nil {
+		sink.ScriptFrom = &v1.StepScriptSource{
+			Workspace: (*v1.WorkspaceFileSelector)(s.ScriptFrom.Workspace),
+			ConfigMap: s.ScriptFrom.ConfigMap,
+		}
+	}
 	if s.Ref != nil {
 		sink.Ref = &v1.Ref{}
 		s.Ref.convertTo(ctx, sink.Ref)
@@ -72,6 +81,8 @@ func (s Step) convertTo(ctx context.Context, sink *v1.Step) {
 		sink.Params = append(sink.Params, new)
 	}
 	sink.Results = s.Results
+	sink.ContainerName = s.ContainerName
+	sink.DependsOnSidecars = s.DependsOnSidecars
 	for _, w := range s.When {
 		new := v1.WhenExpression{}
 		w.convertTo(ctx, &new)
@@ -104,6 +115,13 @@ func (s *Step) convertFrom(ctx context.Context, source v1.Step) {
 	s.OnError = (OnErrorType)(source.OnError)
 	s.StdoutConfig = (*StepOutputConfig)(source.StdoutConfig)
 	s.StderrConfig = (*StepOutputConfig)(source.StderrConfig)
+	s.StdinFrom = (*StepStdinSource)(source.StdinFrom)
+	if source.ScriptFrom != nil {
+		s.ScriptFrom = &StepScriptSource{
+			Workspace: (*WorkspaceFileSelector)(source.ScriptFrom.Workspace),
+			ConfigMap: source.ScriptFrom.ConfigMap,
+		}
+	}
 	if source.Ref != nil {
 		newRef := Ref{}
 		newRef.convertFrom(ctx, *source.Ref)
@@ -116,6 +134,8 @@ func (s *Step) convertFrom(ctx context.Context, source v1.Step) {
 		s.Params = append(s.Params, new)
 	}
 	s.Results = source.Results
+	s.ContainerName = source.ContainerName
+	s.DependsOnSidecars = source.DependsOnSidecars
 	for _, w := range source.When {
 		new := WhenExpression{}
 		new.convertFrom(ctx, w)
@@ -184,6 +204,8 @@ func (s Sidecar) convertTo(ctx context.Context, sink *v1.Sidecar) {
 		w.convertTo(ctx, &new)
 		sink.Workspaces = append(sink.Workspaces, new)
 	}
+	sink.StopAfterSteps = s.StopAfterSteps
+	sink.FailTaskRunOnTermination = s.FailTaskRunOnTermination
 }
 
 func (s *Sidecar) convertFrom(ctx context.Context, source v1.Sidecar) {
@@ -216,4 +238,6 @@ func (s *Sidecar) convertFrom(ctx context.Context, source v1.Sidecar) {
 		new.convertFrom(ctx, w)
 		s.Workspaces = append(s.Workspaces, new)
 	}
+	s.StopAfterSteps = source.StopAfterSteps
+	s.FailTaskRunOnTermination = source.FailTaskRunOnTermination
 }
@@ -22,6 +22,15 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	// stepContainerPrefix is the prefix of the names of the containers of
+	// the Steps which don't set a container name.
+	stepContainerPrefix = "step-"
+	// sidecarContainerPrefix is the prefix of the names of the containers of
+	// the Sidecars.
+	sidecarContainerPrefix = "sidecar-"
+)
+
 // Step runs a subcomponent of a Task
 type Step struct {
 	// Name of the Step specified as a DNS_LABEL.
@@ -229,6 +238,19 @@ type Step struct {
 	// Stores configuration for the stderr stream of the step.
 	// +optional
 	StderrConfig *StepOutputConfig `json:"stderrConfig,omitempty"`
+	// StdinFrom is the source of the standard input of the step: a value,
+	// e.g. a reference to a param, or the path of a file, e.g. a file of a
+	// workspace.
+	// +optional
+	StdinFrom *StepStdinSource `json:"stdinFrom,omitempty"`
+	// ScriptFrom is the source of the script of the step, for the scripts too
+	// long to be inlined: a file of a workspace, read when the step starts, or
+	// a key of a ConfigMap.
+	//
+	// If ScriptFrom is set, the Step cannot have a Script or a Command and the
+	// Args will be passed to the script.
+	// +optional
+	ScriptFrom *StepScriptSource `json:"scriptFrom,omitempty"`
 
 	// Contains the reference to an existing StepAction.
 	//+optional
@@ -246,6 +268,20 @@ type Step struct {
 	Results []v1.StepResult `json:"results,omitempty"`
 
 	When StepWhenExpressions `json:"when,omitempty"`
+
+	// ContainerName is the name of the container of the Step in the pod of the
+	// TaskRun, specified as a DNS_LABEL. It defaults to the name of the Step
+	// prefixed with "step-", and must be unique within the pod.
+	// +optional
+	ContainerName string `json:"containerName,omitempty"`
+
+	// DependsOnSidecars is the list of the names of the Sidecars of the Task
+	// which must be ready before the Step starts. Once a Step of the Task
+	// declares it, the Steps only wait for the Sidecars they depend on,
+	// rather than the first Step waiting for all the Sidecars.
+	// +optional
+	// +listType=atomic
+	DependsOnSidecars []string `json:"dependsOnSidecars,omitempty"`
 }
 
 // Ref can be used to refer to a specific instance of a StepAction.
@@ -256,6 +292,11 @@ type Ref struct {
 	// like a git repo.
 	// +optional
 	ResolverRef `json:",omitempty"`
+	// Version of the referenced StepAction, resolved with the resolver
+	// configured in default-step-action-resolver. It can't be used with
+	// an explicit resolver.
+	// +optional
+	Version string `json:"version,omitempty"`
 }
 
 // OnErrorType defines a list of supported exiting behavior of a container on error
@@ -275,6 +316,37 @@ type StepOutputConfig struct {
 	Path string `json:"path,omitempty"`
 }
 
+// StepStdinSource is the source of the standard input of a step. Exactly one
+// of its fields must be set.
+type StepStdinSource struct {
+	// Value is fed to the standard input of the step, e.g. $(params.input).
+	// +optional
+	Value string `json:"value,omitempty"`
+	// Path is the path of the file fed to the standard input of the step,
+	// e.g. $(workspaces.source.path)/input.json.
+	// +optional
+	Path string `json:"path,omitempty"`
+}
+
+// StepScriptSource is the source of the script of a step. Exactly one of its
+// fields must be set.
+type StepScriptSource struct {
+	// Workspace is the file of a workspace holding the script.
+	// +optional
+	Workspace *WorkspaceFileSelector `json:"workspace,omitempty"`
+	// ConfigMap is the key of a ConfigMap holding the script.
+	// +optional
+	ConfigMap *corev1.ConfigMapKeySelector `json:"configMap,omitempty"`
+}
+
+// WorkspaceFileSelector selects a file of a workspace declared by the Task.
+type WorkspaceFileSelector struct {
+	// Name is the name of the workspace.
+	Name string `json:"name"`
+	// Path is the path of the file, relative to the root of the workspace.
+	Path string `json:"path"`
+}
+
 // ToK8sContainer converts the Step to a Kubernetes Container struct
 func (s *Step) ToK8sContainer() *corev1.Container {
 	return &corev1.Container{
@@ -751,6 +823,19 @@ type Sidecar struct {
 	// was introduced.
 	// +optional
 	RestartPolicy *corev1.ContainerRestartPolicy `json:"restartPolicy,omitempty"`
+
+	// StopAfterSteps is the list of the names of the Steps of the Task after
+	// which the Sidecar is stopped: it is stopped once all these Steps are
+	// finished, rather than once the TaskRun is done.
+	// +optional
+	// +listType=atomic
+	StopAfterSteps []string `json:"stopAfterSteps,omitempty"`
+
+	// FailTaskRunOnTermination fails the TaskRun when the Sidecar terminates
+	// while the Steps of the Task are running, rather than letting them run
+	// until the TaskRun times out.
+	// +optional
+	FailTaskRunOnTermination bool `json:"failTaskRunOnTermination,omitempty"`
 }
 
 // ToK8sContainer converts the Sidecar to a Kubernetes Container struct
@@ -86,9 +86,30 @@ func (ref *Ref) Validate(ctx context.Context) (errs *apis.FieldError) {
 	if ref == nil {
 		return errs
 	}
+	if ref.Version != "" {
+		return ref.validateVersion()
+	}
 	return validateRef(ctx, ref.Name, ref.Resolver, ref.Params)
 }
 
+// validateVersion validates a Ref to a version of a StepAction, which is
+// resolved with the default StepAction resolver rather than an explicit one.
+func (ref *Ref) validateVersion() (errs *apis.FieldError) {
+	if ref.Resolver != "" {
+		errs = errs.Also(apis.ErrMultipleOneOf("version", "resolver"))
+	}
+	if ref.Params != nil {
+		errs = errs.Also(apis.ErrMultipleOneOf("version", "params"))
+	}
+	if ref.Name == "" {
+		return errs.Also(apis.ErrMissingField("name"))
+	}
+	if errSlice := validation.IsQualifiedName(ref.Name); len(errSlice) != 0 {
+		errs = errs.Also(apis.ErrInvalidValue(strings.Join(errSlice, ","), "name"))
+	}
+	return errs
+}
+
 // RefNameLikeUrl checks if the name is url parsable and returns an error if it isn't.
 func RefNameLikeUrl(name string) error {
 	schemeRegex := regexp.MustCompile(`[\w-]+:\/\/*`)
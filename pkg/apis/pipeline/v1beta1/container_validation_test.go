@@ -47,6 +47,9 @@ func TestRef_Valid(t *testing.T) {
 	}, {
 		name: "simple ref",
 		ref:  &v1beta1.Ref{Name: "refname"},
+	}, {
+		name: "versioned ref",
+		ref:  &v1beta1.Ref{Name: "build-push", Version: "0.3"},
 	}, {
 		name: "ref name - consice syntax",
 		ref:  &v1beta1.Ref{Name: "foo://baz:ver", ResolverRef: v1beta1.ResolverRef{Resolver: "git"}},
@@ -98,6 +101,29 @@ func TestRef_Invalid(t *testing.T) {
 		name:    "missing ref name",
 		ref:     &v1beta1.Ref{},
 		wantErr: apis.ErrMissingField("name"),
+	}, {
+		name:    "versioned ref without name",
+		ref:     &v1beta1.Ref{Version: "0.3"},
+		wantErr: apis.ErrMissingField("name"),
+	}, {
+		name: "versioned ref with resolver",
+		ref: &v1beta1.Ref{
+			Name:        "build-push",
+			Version:     "0.3",
+			ResolverRef: v1beta1.ResolverRef{Resolver: "git"},
+		},
+		wantErr: apis.ErrMultipleOneOf("version", "resolver"),
+	}, {
+		name: "versioned ref with resolver params",
+		ref: &v1beta1.Ref{
+			Name:    "build-push",
+			Version: "0.3",
+			ResolverRef: v1beta1.ResolverRef{
+				Resolver: "hub",
+				Params:   v1beta1.Params{{Name: "name", Value: v1beta1.ParamValue{Type: v1beta1.ParamTypeString, StringVal: "build-push"}}},
+			},
+		},
+		wantErr: apis.ErrMultipleOneOf("version", "resolver").Also(apis.ErrMultipleOneOf("version", "params")),
 	}, {
 		name: "ref params disallowed without resolver",
 		ref: &v1beta1.Ref{
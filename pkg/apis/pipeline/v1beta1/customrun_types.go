@@ -18,11 +18,14 @@ package v1beta1
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	apisconfig "github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	runv1beta1 "github.com/tektoncd/pipeline/pkg/apis/run/v1beta1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -79,6 +82,21 @@ type CustomRunSpec struct {
 	// +optional
 	// +listType=atomic
 	Workspaces []WorkspaceBinding `json:"workspaces,omitempty"`
+
+	// PodTemplate is the pod template of the PipelineTask of the custom task,
+	// for the custom task controllers which create pods.
+	// +optional
+	PodTemplate *pod.PodTemplate `json:"podTemplate,omitempty"`
+
+	// ComputeResources are the compute resources of the PipelineTask of the
+	// custom task, for the custom task controllers which create pods.
+	// +optional
+	ComputeResources *corev1.ResourceRequirements `json:"computeResources,omitempty"`
+
+	// Timeouts are the timeouts of the PipelineRun the custom task belongs
+	// to, for the custom task controllers which create nested runs.
+	// +optional
+	Timeouts *TimeoutFields `json:"timeouts,omitempty"`
 }
 
 // CustomRunSpecStatus defines the taskrun spec status the user can provide
@@ -112,6 +130,38 @@ func (rs CustomRunSpec) GetParam(name string) *Param {
 	return nil
 }
 
+// CustomRunUnsupportedFieldsAnnotation can be set on a CustomRun by the
+// controller of its custom task to the comma-separated list of the optional
+// fields of its spec it doesn't support, among serviceAccountName, workspaces,
+// podTemplate, computeResources and timeouts. The PipelineRun the CustomRun
+// belongs to warns about the ones which are set.
+const CustomRunUnsupportedFieldsAnnotation = "custom.tekton.dev/unsupported-fields"
+
+// UnsupportedFields returns the fields of the spec of the CustomRun which are
+// set but which the controller of its custom task declares unsupported in
+// its CustomRunUnsupportedFieldsAnnotation.
+func (r *CustomRun) UnsupportedFields() []string {
+	declared, ok := r.Annotations[CustomRunUnsupportedFieldsAnnotation]
+	if !ok {
+		return nil
+	}
+	set := map[string]bool{
+		"serviceAccountName": r.Spec.ServiceAccountName != "",
+		"workspaces":         len(r.Spec.Workspaces) > 0,
+		"podTemplate":        r.Spec.PodTemplate != nil,
+		"computeResources":   r.Spec.ComputeResources != nil,
+		"timeouts":           r.Spec.Timeouts != nil,
+	}
+	var unsupported []string
+	for _, field := range strings.Split(declared, ",") {
+		field = strings.TrimSpace(field)
+		if set[field] {
+			unsupported = append(unsupported, field)
+		}
+	}
+	return unsupported
+}
+
 // CustomRunReason is an enum used to store all Run reason for the Succeeded condition that are controlled by the CustomRun itself.
 type CustomRunReason string
 
@@ -131,6 +181,13 @@ const (
 	// CustomRunReasonWorkspaceNotSupported can be used in the Condition Reason to indicate that the
 	// CustomRun contains a workspace which is not supported by this custom task.
 	CustomRunReasonWorkspaceNotSupported CustomRunReason = "CustomRunWorkspaceNotSupported"
+	// CustomRunReasonWaitingForApproval is the reason set when the CustomRun of an approval gate
+	// is waiting for the decision on the approval of its PipelineRun.
+	CustomRunReasonWaitingForApproval CustomRunReason = "WaitingForApproval"
+	// CustomRunReasonApproved is the reason set when the CustomRun of an approval gate was approved.
+	CustomRunReasonApproved CustomRunReason = "Approved"
+	// CustomRunReasonRejected is the reason set when the CustomRun of an approval gate was rejected.
+	CustomRunReasonRejected CustomRunReason = "Rejected"
 )
 
 func (t CustomRunReason) String() string {
@@ -22,6 +22,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	apisconfig "github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	v1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned/scheme"
 	"github.com/tektoncd/pipeline/test/diff"
@@ -354,6 +355,38 @@ func TestEncodeDecodeExtraFields(t *testing.T) {
 	}
 }
 
+func TestCustomRunUnsupportedFields(t *testing.T) {
+	spec := v1beta1.CustomRunSpec{
+		ServiceAccountName: "sa",
+		PodTemplate:        &pod.PodTemplate{HostNetwork: true},
+		Timeouts:           &v1beta1.TimeoutFields{Pipeline: &metav1.Duration{Duration: time.Hour}},
+	}
+	for _, tc := range []struct {
+		name        string
+		annotations map[string]string
+		want        []string
+	}{{
+		name: "no declared unsupported fields",
+	}, {
+		name:        "declared unsupported fields which are set",
+		annotations: map[string]string{v1beta1.CustomRunUnsupportedFieldsAnnotation: "timeouts, podTemplate"},
+		want:        []string{"timeouts", "podTemplate"},
+	}, {
+		name:        "declared unsupported fields which aren't set",
+		annotations: map[string]string{v1beta1.CustomRunUnsupportedFieldsAnnotation: "workspaces,computeResources,unknown"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &v1beta1.CustomRun{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations},
+				Spec:       spec,
+			}
+			if d := cmp.Diff(tc.want, r.UnsupportedFields()); d != "" {
+				t.Errorf("UnsupportedFields() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestRunGetTimeOut(t *testing.T) {
 	testCases := []struct {
 		name          string
@@ -66,7 +66,7 @@ func MergeStepsWithStepTemplate(template *StepTemplate, steps []Step) ([]Step, e
 		amendConflictingContainerFields(&merged, s)
 
 		// Pass through original step Script, for later conversion.
-		newStep := Step{Script: s.Script, OnError: s.OnError, Timeout: s.Timeout, StdoutConfig: s.StdoutConfig, StderrConfig: s.StderrConfig, When: s.When}
+		newStep := Step{Script: s.Script, OnError: s.OnError, Timeout: s.Timeout, StdoutConfig: s.StdoutConfig, StderrConfig: s.StderrConfig, StdinFrom: s.StdinFrom, ScriptFrom: s.ScriptFrom, When: s.When, ContainerName: s.ContainerName, DependsOnSidecars: s.DependsOnSidecars}
 		newStep.SetContainerFields(merged)
 		steps[i] = newStep
 	}
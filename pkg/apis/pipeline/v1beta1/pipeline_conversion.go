@@ -65,6 +65,10 @@ func (ps *PipelineSpec) ConvertTo(ctx context.Context, sink *v1.PipelineSpec, me
 		p.convertTo(ctx, &new)
 		sink.Params = append(sink.Params, new)
 	}
+	sink.ParamsFrom = nil
+	for _, r := range ps.ParamsFrom {
+		sink.ParamsFrom = append(sink.ParamsFrom, v1.ParameterSetRef(r))
+	}
 	sink.Workspaces = nil
 	for _, w := range ps.Workspaces {
 		new := v1.PipelineWorkspaceDeclaration{}
@@ -104,11 +108,14 @@ func (p *Pipeline) ConvertFrom(ctx context.Context, from apis.Convertible) error
 }
 
 // ConvertFrom implements apis.Convertible
+// The taskDefaults and finallyDefaults of the v1 PipelineSpec don't exist in
+// v1beta1, they are expanded into the PipelineTasks instead.
 func (ps *PipelineSpec) ConvertFrom(ctx context.Context, source *v1.PipelineSpec, meta *metav1.ObjectMeta) error {
 	ps.DisplayName = source.DisplayName
 	ps.Description = source.Description
 	ps.Tasks = nil
 	for _, t := range source.Tasks {
+		source.TaskDefaults.ApplyTo(&t)
 		new := PipelineTask{}
 		err := new.convertFrom(ctx, t, meta)
 		if err != nil {
@@ -122,6 +129,10 @@ func (ps *PipelineSpec) ConvertFrom(ctx context.Context, source *v1.PipelineSpec
 		new.convertFrom(ctx, p)
 		ps.Params = append(ps.Params, new)
 	}
+	ps.ParamsFrom = nil
+	for _, r := range source.ParamsFrom {
+		ps.ParamsFrom = append(ps.ParamsFrom, ParameterSetRef(r))
+	}
 	ps.Workspaces = nil
 	for _, w := range source.Workspaces {
 		new := PipelineWorkspaceDeclaration{}
@@ -136,6 +147,7 @@ func (ps *PipelineSpec) ConvertFrom(ctx context.Context, source *v1.PipelineSpec
 	}
 	ps.Finally = nil
 	for _, f := range source.Finally {
+		source.FinallyDefaults.ApplyTo(&f)
 		new := PipelineTask{}
 		err := new.convertFrom(ctx, f, meta)
 		if err != nil {
@@ -312,6 +324,11 @@ func (pr PipelineResult) convertTo(ctx context.Context, sink *v1.PipelineResult)
 	newValue := v1.ParamValue{}
 	pr.Value.convertTo(ctx, &newValue)
 	sink.Value = newValue
+	if pr.Default != nil {
+		newDefault := v1.ParamValue{}
+		pr.Default.convertTo(ctx, &newDefault)
+		sink.Default = &newDefault
+	}
 }
 
 func (pr *PipelineResult) convertFrom(ctx context.Context, source v1.PipelineResult) {
@@ -321,6 +338,11 @@ func (pr *PipelineResult) convertFrom(ctx context.Context, source v1.PipelineRes
 	newValue := ParamValue{}
 	newValue.convertFrom(ctx, source.Value)
 	pr.Value = newValue
+	if source.Default != nil {
+		newDefault := ParamValue{}
+		newDefault.convertFrom(ctx, *source.Default)
+		pr.Default = &newDefault
+	}
 }
 
 func (ptm PipelineTaskMetadata) convertTo(ctx context.Context, sink *v1.PipelineTaskMetadata) {
@@ -217,6 +217,10 @@ func TestPipelineConversion(t *testing.T) {
 					Type:        v1beta1.ResultsTypeObject,
 					Description: "this is my pipeline result",
 					Value:       *v1beta1.NewStructuredValues("foo.bar"),
+				}, {
+					Name:    "my-pipeline-result-with-default",
+					Value:   *v1beta1.NewStructuredValues("$(tasks.foo.results.bar)"),
+					Default: v1beta1.NewStructuredValues("none"),
 				}},
 				Finally: []v1beta1.PipelineTask{{
 					Name:        "final-task",
@@ -318,3 +322,77 @@ func TestPipelineConversionFromDeprecated(t *testing.T) {
 		}
 	}
 }
+
+func TestPipelineConversionFromV1TaskDefaults(t *testing.T) {
+	in := &v1.Pipeline{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "bar",
+		},
+		Spec: v1.PipelineSpec{
+			Tasks: []v1.PipelineTask{{
+				Name:    "task-without-values",
+				TaskRef: &v1.TaskRef{Name: "foo-task"},
+			}, {
+				Name:    "task-with-values",
+				TaskRef: &v1.TaskRef{Name: "foo-task"},
+				Retries: 5,
+				OnError: v1.PipelineTaskStopAndFail,
+			}, {
+				Name:                  "task-without-retries",
+				TaskRef:               &v1.TaskRef{Name: "foo-task"},
+				DisableDefaultRetries: true,
+			}},
+			Finally: []v1.PipelineTask{{
+				Name:    "final-task",
+				TaskRef: &v1.TaskRef{Name: "foo-task"},
+			}},
+			TaskDefaults: &v1.PipelineTaskDefaults{
+				Retries: 2,
+				Timeout: &metav1.Duration{Duration: 10 * time.Minute},
+			},
+			FinallyDefaults: &v1.PipelineTaskDefaults{
+				OnError: v1.PipelineTaskContinue,
+			},
+		},
+	}
+	want := &v1beta1.Pipeline{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "bar",
+		},
+		Spec: v1beta1.PipelineSpec{
+			Tasks: []v1beta1.PipelineTask{{
+				Name:    "task-without-values",
+				TaskRef: &v1beta1.TaskRef{Name: "foo-task"},
+				Retries: 2,
+				Timeout: &metav1.Duration{Duration: 10 * time.Minute},
+			}, {
+				Name:    "task-with-values",
+				TaskRef: &v1beta1.TaskRef{Name: "foo-task"},
+				Retries: 5,
+				Timeout: &metav1.Duration{Duration: 10 * time.Minute},
+				OnError: v1beta1.PipelineTaskStopAndFail,
+			}, {
+				Name:    "task-without-retries",
+				TaskRef: &v1beta1.TaskRef{Name: "foo-task"},
+				Timeout: &metav1.Duration{Duration: 10 * time.Minute},
+			}},
+			Finally: []v1beta1.PipelineTask{{
+				Name:    "final-task",
+				TaskRef: &v1beta1.TaskRef{Name: "foo-task"},
+				OnError: v1beta1.PipelineTaskContinue,
+			}},
+		},
+	}
+	got := &v1beta1.Pipeline{}
+	if err := got.ConvertFrom(t.Context(), in); err != nil {
+		t.Fatalf("ConvertFrom() = %v", err)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("ConvertFrom() %s", diff.PrintWantGot(d))
+	}
+	if in.Spec.Tasks[0].Retries != 0 || in.Spec.Tasks[0].Timeout != nil {
+		t.Errorf("ConvertFrom() must not modify the source tasks, got %#v", in.Spec.Tasks[0])
+	}
+}
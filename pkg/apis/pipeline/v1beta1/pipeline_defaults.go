@@ -20,6 +20,7 @@ import (
 	"context"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"knative.dev/pkg/apis"
 )
 
@@ -28,6 +29,7 @@ var _ apis.Defaultable = (*Pipeline)(nil)
 // SetDefaults sets default values on the Pipeline's Spec
 func (p *Pipeline) SetDefaults(ctx context.Context) {
 	p.Spec.SetDefaults(ctx)
+	v1.SetInterfaceDigest(ctx, &p.ObjectMeta, PipelineInterfaceOf(p.Spec))
 }
 
 // SetDefaults sets default values for the PipelineSpec's Params, Tasks, and Finally
@@ -61,3 +63,29 @@ func (pt *PipelineTask) SetDefaults(ctx context.Context) {
 		pt.TaskSpec.SetDefaults(ctx)
 	}
 }
+
+// PipelineInterfaceOf returns the InterfaceDoc of the PipelineSpec, which is
+// the one of the v1 PipelineSpec it converts to.
+func PipelineInterfaceOf(ps PipelineSpec) v1.InterfaceDoc {
+	ctx := context.Background()
+	spec := v1.PipelineSpec{}
+	for _, p := range ps.Params {
+		new := v1.ParamSpec{}
+		p.convertTo(ctx, &new)
+		spec.Params = append(spec.Params, new)
+	}
+	for _, r := range ps.ParamsFrom {
+		spec.ParamsFrom = append(spec.ParamsFrom, v1.ParameterSetRef(r))
+	}
+	for _, r := range ps.Results {
+		new := v1.PipelineResult{}
+		r.convertTo(ctx, &new)
+		spec.Results = append(spec.Results, new)
+	}
+	for _, w := range ps.Workspaces {
+		new := v1.PipelineWorkspaceDeclaration{}
+		w.convertTo(ctx, &new)
+		spec.Workspaces = append(spec.Workspaces, new)
+	}
+	return v1.PipelineInterfaceOf(spec)
+}
@@ -125,6 +125,13 @@ type PipelineSpec struct {
 	// Params declares a list of input parameters that must be supplied when
 	// this Pipeline is run.
 	Params ParamSpecs `json:"params,omitempty"`
+	// ParamsFrom references ParameterSets whose params are imported into
+	// Params when the Pipeline is run. The params declared in Params take
+	// precedence over the imported ones, and the params of a ParameterSet
+	// over the ones of the next ParameterSets.
+	// +optional
+	// +listType=atomic
+	ParamsFrom []ParameterSetRef `json:"paramsFrom,omitempty"`
 	// Workspaces declares a set of named workspaces that are expected to be
 	// provided by a PipelineRun.
 	// +optional
@@ -159,6 +166,13 @@ type PipelineResult struct {
 	// +kubebuilder:pruning:PreserveUnknownFields
 	// +kubebuilder:validation:Schemaless
 	Value ResultValue `json:"value"`
+
+	// Default is the value of the result when one of the PipelineTasks it
+	// references was skipped. It must have the type of the result.
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	Default *ResultValue `json:"default,omitempty"`
 }
 
 // PipelineTaskMetadata contains the labels or annotations for an EmbeddedTask
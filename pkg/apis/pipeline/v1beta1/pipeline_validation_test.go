@@ -1269,6 +1269,27 @@ func TestPipelineSpec_Validate_Failure(t *testing.T) {
 			Message: `feature flag enable-artifacts should be set to true to use artifacts feature.`,
 			Paths:   []string{"finally[0].params"},
 		},
+	}, {
+		name: "bound variable of a required workspace in when expressions",
+		ps: &PipelineSpec{
+			Workspaces: []PipelineWorkspaceDeclaration{{Name: "cache"}},
+			Tasks: []PipelineTask{{
+				Name:    "restore-cache",
+				TaskRef: &TaskRef{Name: "restore-cache-task"},
+				WhenExpressions: WhenExpressions{{
+					Input:    "$(workspaces.cache.bound)",
+					Operator: selection.In,
+					Values:   []string{"true"},
+				}},
+			}},
+		},
+		wc: func(ctx context.Context) context.Context {
+			return cfgtesting.SetFeatureFlags(ctx, t, map[string]string{"enable-workspace-bound-validation": "true"})
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: $(workspaces.cache.bound) can only be used with optional workspaces but workspace "cache" is not optional`,
+			Paths:   []string{"tasks[0].when[0]"},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1423,6 +1444,15 @@ func TestValidatePipelineResults_Success(t *testing.T) {
 		Name:        "my-pipeline-object-result",
 		Description: "this is my pipeline result",
 		Value:       *NewStructuredValues("$(tasks.a-task.results.gitrepo.commit)"),
+	}, {
+		Name:    "my-pipeline-result-with-default",
+		Value:   *NewStructuredValues("$(tasks.a-task.results.output)"),
+		Default: NewStructuredValues("none"),
+	}, {
+		Name:    "my-pipeline-array-result-with-default",
+		Type:    ResultsTypeArray,
+		Value:   *NewStructuredValues("$(tasks.a-task.results.images[*])"),
+		Default: NewStructuredValues("busybox", "alpine"),
 	}}
 	if err := validatePipelineResults(results, []PipelineTask{{Name: "a-task"}}, []PipelineTask{}); err != nil {
 		t.Errorf("Pipeline.validatePipelineResults() returned error for valid pipeline: %s: %v", desc, err)
@@ -1471,6 +1501,23 @@ func TestValidatePipelineResults_Failure(t *testing.T) {
 		}},
 		expectedError: *apis.ErrInvalidValue(`expected pipeline results to be task result expressions but an invalid expressions was found`, "results[0].value").Also(
 			apis.ErrInvalidValue("referencing a nonexistent task", "results[0].value")),
+	}, {
+		desc: "array default of a string result",
+		results: []PipelineResult{{
+			Name:    "my-pipeline-result",
+			Value:   *NewStructuredValues("$(tasks.a-task.results.output)"),
+			Default: NewStructuredValues("a", "b"),
+		}},
+		expectedError: *apis.ErrInvalidValue(`the default of the result my-pipeline-result must be of type string but is of type array`, "results[0].default"),
+	}, {
+		desc: "string default of an array result",
+		results: []PipelineResult{{
+			Name:    "my-pipeline-result",
+			Type:    ResultsTypeArray,
+			Value:   *NewStructuredValues("$(tasks.a-task.results.images[*])"),
+			Default: NewStructuredValues("busybox"),
+		}},
+		expectedError: *apis.ErrInvalidValue(`the default of the result my-pipeline-result must be of type array but is of type string`, "results[0].default"),
 	}}
 	for _, tt := range tests {
 		err := validatePipelineResults(tt.results, []PipelineTask{{Name: "a-task"}}, []PipelineTask{})
@@ -92,6 +92,10 @@ func (prs PipelineRunSpec) ConvertTo(ctx context.Context, sink *v1.PipelineRunSp
 		ptrs.convertTo(ctx, &new)
 		sink.TaskRunSpecs = append(sink.TaskRunSpecs, new)
 	}
+	if prs.TasksFilter != nil {
+		sink.TasksFilter = &v1.PipelineRunTasksFilter{}
+		prs.TasksFilter.convertTo(ctx, sink.TasksFilter)
+	}
 	return nil
 }
 
@@ -153,6 +157,11 @@ func (prs *PipelineRunSpec) ConvertFrom(ctx context.Context, source *v1.Pipeline
 		new.convertFrom(ctx, trs)
 		prs.TaskRunSpecs = append(prs.TaskRunSpecs, new)
 	}
+	if source.TasksFilter != nil {
+		newTasksFilter := PipelineRunTasksFilter{}
+		newTasksFilter.convertFrom(ctx, *source.TasksFilter)
+		prs.TasksFilter = &newTasksFilter
+	}
 	return nil
 }
 
@@ -168,6 +177,30 @@ func (tf *TimeoutFields) convertFrom(ctx context.Context, source v1.TimeoutField
 	tf.Finally = source.Finally
 }
 
+func (f PipelineRunTasksFilter) convertTo(ctx context.Context, sink *v1.PipelineRunTasksFilter) {
+	sink.Include = f.Include
+	sink.WithDependencies = f.WithDependencies
+	sink.ExcludeFinally = f.ExcludeFinally
+	sink.ResultOverrides = nil
+	for _, o := range f.ResultOverrides {
+		new := v1.PipelineTaskResultOverride{PipelineTask: o.PipelineTask, Name: o.Name}
+		o.Value.convertTo(ctx, &new.Value)
+		sink.ResultOverrides = append(sink.ResultOverrides, new)
+	}
+}
+
+func (f *PipelineRunTasksFilter) convertFrom(ctx context.Context, source v1.PipelineRunTasksFilter) {
+	f.Include = source.Include
+	f.WithDependencies = source.WithDependencies
+	f.ExcludeFinally = source.ExcludeFinally
+	f.ResultOverrides = nil
+	for _, o := range source.ResultOverrides {
+		new := PipelineTaskResultOverride{PipelineTask: o.PipelineTask, Name: o.Name}
+		new.Value.convertFrom(ctx, o.Value)
+		f.ResultOverrides = append(f.ResultOverrides, new)
+	}
+}
+
 func (ptrs PipelineTaskRunSpec) convertTo(ctx context.Context, sink *v1.PipelineTaskRunSpec) {
 	sink.PipelineTaskName = ptrs.PipelineTaskName
 	sink.ServiceAccountName = ptrs.TaskServiceAccountName
@@ -232,6 +265,10 @@ func (prs *PipelineRunStatus) convertTo(ctx context.Context, sink *v1.PipelineRu
 			return err
 		}
 	}
+	sink.ExpandedParameterSets = nil
+	for _, s := range prs.ExpandedParameterSets {
+		sink.ExpandedParameterSets = append(sink.ExpandedParameterSets, v1.ExpandedParameterSet(s))
+	}
 	sink.SkippedTasks = nil
 	for _, st := range prs.SkippedTasks {
 		new := v1.SkippedTask{}
@@ -250,6 +287,12 @@ func (prs *PipelineRunStatus) convertTo(ctx context.Context, sink *v1.PipelineRu
 		prs.Provenance.convertTo(ctx, &new)
 		sink.Provenance = &new
 	}
+	if prs.Summary != nil {
+		new := v1.PipelineRunSummary{}
+		prs.Summary.convertTo(ctx, &new)
+		sink.Summary = &new
+	}
+	sink.PeakResourceRequests = prs.PeakResourceRequests
 	return nil
 }
 
@@ -271,6 +314,10 @@ func (prs *PipelineRunStatus) convertFrom(ctx context.Context, source *v1.Pipeli
 		}
 		prs.PipelineSpec = &newPipelineSpec
 	}
+	prs.ExpandedParameterSets = nil
+	for _, s := range source.ExpandedParameterSets {
+		prs.ExpandedParameterSets = append(prs.ExpandedParameterSets, ExpandedParameterSet(s))
+	}
 	prs.SkippedTasks = nil
 	for _, st := range source.SkippedTasks {
 		new := SkippedTask{}
@@ -290,6 +337,12 @@ func (prs *PipelineRunStatus) convertFrom(ctx context.Context, source *v1.Pipeli
 		new.convertFrom(ctx, *source.Provenance)
 		prs.Provenance = &new
 	}
+	if source.Summary != nil {
+		new := PipelineRunSummary{}
+		new.convertFrom(ctx, *source.Summary)
+		prs.Summary = &new
+	}
+	prs.PeakResourceRequests = source.PeakResourceRequests
 	return nil
 }
 
@@ -298,6 +351,7 @@ func (prr PipelineRunResult) convertTo(ctx context.Context, sink *v1.PipelineRun
 	newValue := v1.ParamValue{}
 	prr.Value.convertTo(ctx, &newValue)
 	sink.Value = newValue
+	sink.Defaulted = prr.Defaulted
 }
 
 func (prr *PipelineRunResult) convertFrom(ctx context.Context, source v1.PipelineRunResult) {
@@ -305,6 +359,33 @@ func (prr *PipelineRunResult) convertFrom(ctx context.Context, source v1.Pipelin
 	newValue := ParamValue{}
 	newValue.convertFrom(ctx, source.Value)
 	prr.Value = newValue
+	prr.Defaulted = source.Defaulted
+}
+
+func (s PipelineRunSummary) convertTo(ctx context.Context, sink *v1.PipelineRunSummary) {
+	sink.Duration = s.Duration
+	sink.CompletedTasks = s.CompletedTasks
+	sink.TotalTasks = s.TotalTasks
+	sink.FailedTask = s.FailedTask
+	sink.FailedTasks = nil
+	for _, f := range s.FailedTasks {
+		sink.FailedTasks = append(sink.FailedTasks, v1.PipelineTaskFailure(f))
+	}
+	sink.Resolver = s.Resolver
+	sink.QueuedTasks = s.QueuedTasks
+}
+
+func (s *PipelineRunSummary) convertFrom(ctx context.Context, source v1.PipelineRunSummary) {
+	s.Duration = source.Duration
+	s.CompletedTasks = source.CompletedTasks
+	s.TotalTasks = source.TotalTasks
+	s.FailedTask = source.FailedTask
+	s.FailedTasks = nil
+	for _, f := range source.FailedTasks {
+		s.FailedTasks = append(s.FailedTasks, PipelineTaskFailure(f))
+	}
+	s.Resolver = source.Resolver
+	s.QueuedTasks = source.QueuedTasks
 }
 
 func (st SkippedTask) convertTo(ctx context.Context, sink *v1.SkippedTask) {
@@ -316,6 +397,11 @@ func (st SkippedTask) convertTo(ctx context.Context, sink *v1.SkippedTask) {
 		we.convertTo(ctx, &new)
 		sink.WhenExpressions = append(sink.WhenExpressions, new)
 	}
+	sink.EvaluatedWhenExpressions = nil
+	for _, we := range st.EvaluatedWhenExpressions {
+		sink.EvaluatedWhenExpressions = append(sink.EvaluatedWhenExpressions, v1.EvaluatedWhenExpression(we))
+	}
+	sink.FailedAncestor = st.FailedAncestor
 }
 
 func (st *SkippedTask) convertFrom(ctx context.Context, source v1.SkippedTask) {
@@ -327,6 +413,11 @@ func (st *SkippedTask) convertFrom(ctx context.Context, source v1.SkippedTask) {
 		new.convertFrom(ctx, we)
 		st.WhenExpressions = append(st.WhenExpressions, new)
 	}
+	st.EvaluatedWhenExpressions = nil
+	for _, we := range source.EvaluatedWhenExpressions {
+		st.EvaluatedWhenExpressions = append(st.EvaluatedWhenExpressions, EvaluatedWhenExpression(we))
+	}
+	st.FailedAncestor = source.FailedAncestor
 }
 
 func (csr ChildStatusReference) convertTo(ctx context.Context, sink *v1.ChildStatusReference) {
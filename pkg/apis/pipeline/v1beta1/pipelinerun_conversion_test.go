@@ -320,6 +320,10 @@ func TestPipelineRunConversion(t *testing.T) {
 							"pkey1": "val1",
 							"pkey2": "rae",
 						}),
+					}, {
+						Name:      "pipeline-result-defaulted",
+						Value:     *v1beta1.NewStructuredValues("none"),
+						Defaulted: true,
 					}, {
 						Name: "pipeline-result-2",
 						Value: *v1beta1.NewObject(map[string]string{
@@ -344,9 +348,16 @@ func TestPipelineRunConversion(t *testing.T) {
 								Operator: "notin",
 								Values:   []string{"foo", "bar"},
 							}},
+							EvaluatedWhenExpressions: []v1beta1.EvaluatedWhenExpression{{
+								Input:    "foo",
+								Operator: "notin",
+								Values:   []string{"foo", "bar"},
+								Outcome:  false,
+							}},
 						}, {
-							Name:   "skipped-2",
-							Reason: v1beta1.MissingResultsSkip,
+							Name:           "skipped-2",
+							Reason:         v1beta1.MissingResultsSkip,
+							FailedAncestor: "failed-task",
 						},
 					},
 					ChildReferences: []v1beta1.ChildStatusReference{
@@ -373,6 +384,35 @@ func TestPipelineRunConversion(t *testing.T) {
 							Digest: map[string]string{"sha256": "digest"},
 						},
 						FeatureFlags: config.DefaultFeatureFlags.DeepCopy(),
+						SourceEvent: &v1beta1.SourceEvent{
+							ID:   "72d3162e-cc78-11e3-81ab-4c9367dc0958",
+							Type: "push",
+							URL:  "https://github.com/tektoncd/pipeline",
+						},
+					},
+					Summary: &v1beta1.PipelineRunSummary{
+						Duration:       "2m30s",
+						CompletedTasks: 1,
+						TotalTasks:     2,
+						FailedTask:     "task-1",
+						FailedTasks: []v1beta1.PipelineTaskFailure{{
+							Name:    "task-1",
+							Reason:  "Failed",
+							Message: `"step-build" exited with code 1`,
+						}},
+						Resolver: "git",
+						QueuedTasks: []v1.PipelineTaskQueueTime{{
+							Name:             "pr-task-1",
+							PipelineTaskName: "task-1",
+							CreationTime:     &metav1.Time{Time: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)},
+							PodCreationTime:  &metav1.Time{Time: time.Date(2025, 1, 2, 3, 4, 6, 0, time.UTC)},
+							PodStartTime:     &metav1.Time{Time: time.Date(2025, 1, 2, 3, 4, 35, 0, time.UTC)},
+							QueuedDuration:   "30s",
+						}},
+					},
+					PeakResourceRequests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("2"),
+						corev1.ResourceMemory: resource.MustParse("1Gi"),
 					},
 				},
 			},
@@ -513,6 +553,59 @@ func TestPipelineRunConversionRoundTrip(t *testing.T) {
 				},
 			},
 		},
+	}, {
+		name: "expandedParameterSets",
+		in: &v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo",
+				Namespace: "bar",
+			},
+			Spec: v1beta1.PipelineRunSpec{
+				PipelineRef: &v1beta1.PipelineRef{
+					Name: "test-runs",
+				},
+			},
+			Status: v1beta1.PipelineRunStatus{
+				PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+					PipelineSpec: &v1beta1.PipelineSpec{
+						Params: v1beta1.ParamSpecs{{Name: "registry", Type: v1beta1.ParamTypeString}},
+						Tasks:  []v1beta1.PipelineTask{{Name: "build", TaskRef: &v1beta1.TaskRef{Name: "build"}}},
+					},
+					ExpandedParameterSets: []v1beta1.ExpandedParameterSet{{
+						Name:            "common",
+						ResourceVersion: "42",
+						Params:          []string{"registry"},
+					}},
+				},
+			},
+		},
+	}, {
+		name: "tasksFilter",
+		in: &v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo",
+				Namespace: "bar",
+			},
+			Spec: v1beta1.PipelineRunSpec{
+				PipelineRef: &v1beta1.PipelineRef{
+					Name: "test-runs",
+				},
+				TasksFilter: &v1beta1.PipelineRunTasksFilter{
+					Include:          []string{"unit-tests", "tier=lint"},
+					WithDependencies: true,
+					ExcludeFinally:   []string{"notify"},
+					ResultOverrides: []v1beta1.PipelineTaskResultOverride{{
+						PipelineTask: "build",
+						Name:         "image",
+						Value:        *v1beta1.NewStructuredValues("registry.example.com/app@sha256:abc"),
+					}, {
+						PipelineTask: "build",
+						Name:         "tags",
+						Value:        *v1beta1.NewStructuredValues("latest", "v1"),
+					}},
+				},
+			},
+		},
 	}}
 	for _, test := range tests {
 		versions := []apis.Convertible{&v1.PipelineRun{}}
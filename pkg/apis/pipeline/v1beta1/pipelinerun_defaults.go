@@ -24,6 +24,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	pod "github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/kmap"
@@ -36,6 +37,10 @@ var (
 
 // SetDefaults implements apis.Defaultable
 func (pr *PipelineRun) SetDefaults(ctx context.Context) {
+	// New PipelineRuns get the defaults of their namespace, if it overrides them
+	if apis.IsInCreate(ctx) {
+		ctx = config.ToContextForNamespace(ctx, pr.Namespace)
+	}
 	pr.Spec.SetDefaults(ctx)
 
 	// Silently filtering out Tekton Reserved annotations at creation
@@ -44,6 +49,8 @@ func (pr *PipelineRun) SetDefaults(ctx context.Context) {
 			return filterReservedAnnotationRegexp.MatchString(s)
 		})
 	}
+
+	v1.SetApprovers(ctx, pr.GetObjectMeta())
 }
 
 // SetDefaults implements apis.Defaultable
@@ -27,6 +27,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/test/diff"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
@@ -442,3 +443,38 @@ func TestPipelineRunDefaultingOnCreate(t *testing.T) {
 		})
 	}
 }
+
+func TestPipelineRun_SetDefaults_ApproversOnUpdate(t *testing.T) {
+	baseline := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pipelinerun",
+			Annotations: map[string]string{
+				"approvals.tekton.dev/test": "approved",
+				"approvers.tekton.dev/test": "bob",
+			},
+		},
+	}
+	pr := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pipelinerun",
+			Annotations: map[string]string{
+				"approvals.tekton.dev/test":   "approved",
+				"approvers.tekton.dev/test":   "mallory",
+				"approvals.tekton.dev/deploy": "approved",
+				"approvers.tekton.dev/deploy": "bob",
+			},
+		},
+	}
+	ctx := apis.WithUserInfo(t.Context(), &authenticationv1.UserInfo{Username: "alice"})
+	pr.SetDefaults(apis.WithinUpdate(ctx, baseline))
+
+	want := map[string]string{
+		"approvals.tekton.dev/test":   "approved",
+		"approvers.tekton.dev/test":   "bob",
+		"approvals.tekton.dev/deploy": "approved",
+		"approvers.tekton.dev/deploy": "alice",
+	}
+	if d := cmp.Diff(want, pr.Annotations); d != "" {
+		t.Errorf("unexpected annotations: %s", diff.PrintWantGot(d))
+	}
+}
@@ -25,6 +25,7 @@ import (
 	apisconfig "github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	pod "github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -88,6 +89,11 @@ func (pr *PipelineRun) IsCancelled() bool {
 	return pr.Spec.Status == PipelineRunSpecStatusCancelled
 }
 
+// IsSuperseded returns true if the PipelineRun's spec status is set to Superseded state
+func (pr *PipelineRun) IsSuperseded() bool {
+	return pr.Spec.Status == PipelineRunSpecStatusSuperseded
+}
+
 // IsGracefullyCancelled returns true if the PipelineRun's spec status is set to CancelledRunFinally state
 func (pr *PipelineRun) IsGracefullyCancelled() bool {
 	return pr.Spec.Status == PipelineRunSpecStatusCancelledRunFinally
@@ -300,6 +306,10 @@ type PipelineRunSpec struct {
 	// +optional
 	// +listType=atomic
 	TaskRunSpecs []PipelineTaskRunSpec `json:"taskRunSpecs,omitempty"`
+	// TasksFilter runs only a subset of the PipelineTasks of the Pipeline,
+	// skipping the other ones.
+	// +optional
+	TasksFilter *PipelineRunTasksFilter `json:"tasksFilter,omitempty"`
 }
 
 // TimeoutFields allows granular specification of pipeline, task, and finally timeouts
@@ -320,6 +330,10 @@ const (
 	// if not already cancelled or terminated
 	PipelineRunSpecStatusCancelled = "Cancelled"
 
+	// PipelineRunSpecStatusSuperseded indicates that the user wants to cancel the pipeline run
+	// because a newer run replaces it, if not already cancelled or terminated
+	PipelineRunSpecStatusSuperseded = "Superseded"
+
 	// PipelineRunSpecStatusCancelledRunFinally indicates that the user wants to cancel the pipeline run,
 	// if not already cancelled or terminated, but ensure finally is run normally
 	PipelineRunSpecStatusCancelledRunFinally = "CancelledRunFinally"
@@ -360,6 +374,8 @@ const (
 	// This reason may be found with a corev1.ConditionFalse status, if the cancellation was processed successfully
 	// This reason may be found with a corev1.ConditionUnknown status, if the cancellation is being processed or failed
 	PipelineRunReasonCancelled PipelineRunReason = "Cancelled"
+	// PipelineRunReasonSuperseded is the reason set when the PipelineRun is cancelled because a newer run replaces it
+	PipelineRunReasonSuperseded PipelineRunReason = "Superseded"
 	// PipelineRunReasonPending is the reason set when the PipelineRun is in the pending state
 	PipelineRunReasonPending PipelineRunReason = "PipelineRunPending"
 	// PipelineRunReasonTimedOut is the reason set when the PipelineRun has timed out
@@ -485,6 +501,12 @@ type PipelineRunStatusFields struct {
 	// +kubebuilder:validation:Schemaless
 	PipelineSpec *PipelineSpec `json:"pipelineSpec,omitempty"`
 
+	// ExpandedParameterSets are the ParameterSets whose params were imported
+	// into the PipelineSpec with paramsFrom.
+	// +optional
+	// +listType=atomic
+	ExpandedParameterSets []ExpandedParameterSet `json:"expandedParameterSets,omitempty"`
+
 	// list of tasks that were skipped due to when expressions evaluating to false
 	// +optional
 	// +listType=atomic
@@ -505,6 +527,63 @@ type PipelineRunStatusFields struct {
 
 	// SpanContext contains tracing span context fields
 	SpanContext map[string]string `json:"spanContext,omitempty"`
+
+	// Summary is a compact view of the progress of the PipelineRun. It is kept
+	// up to date on every reconcile so that it can be cheaply printed by clients.
+	// +optional
+	Summary *PipelineRunSummary `json:"summary,omitempty"`
+
+	// PeakResourceRequests is the highest amount of compute resources the
+	// PipelineRun may request at any point in time, i.e. the sum of the
+	// requests of the PipelineTasks which can run concurrently. It is
+	// computed before the first PipelineTask starts.
+	// +optional
+	PeakResourceRequests corev1.ResourceList `json:"peakResourceRequests,omitempty"`
+}
+
+// PipelineRunSummary holds the fields used to summarize the progress of a
+// PipelineRun, e.g. in the additional printer columns of `kubectl get`.
+type PipelineRunSummary struct {
+	// Duration is the total execution time of the PipelineRun, between its start
+	// and completion times. It is set once the PipelineRun has completed.
+	// +optional
+	Duration string `json:"duration,omitempty"`
+	// CompletedTasks is the number of PipelineTasks that are done executing,
+	// including the ones which failed, were cancelled or were skipped.
+	// +optional
+	CompletedTasks int `json:"completedTasks,omitempty"`
+	// TotalTasks is the number of PipelineTasks, including finally tasks.
+	// +optional
+	TotalTasks int `json:"totalTasks,omitempty"`
+	// FailedTask is the name of the first PipelineTask which failed, if any.
+	// +optional
+	FailedTask string `json:"failedTask,omitempty"`
+	// FailedTasks are the PipelineTasks which failed, ordered by name, with the
+	// reason and message of their failure. At most 10 are listed.
+	// +optional
+	// +listType=atomic
+	FailedTasks []PipelineTaskFailure `json:"failedTasks,omitempty"`
+	// Resolver is the name of the resolver used to fetch the Pipeline, if any.
+	// +optional
+	Resolver string `json:"resolver,omitempty"`
+	// QueuedTasks are the times the child TaskRuns of the PipelineRun waited
+	// between their creation and the start of their Pod, ordered by name.
+	// +optional
+	// +listType=atomic
+	QueuedTasks []v1.PipelineTaskQueueTime `json:"queuedTasks,omitempty"`
+}
+
+// PipelineTaskFailure describes the failure of a PipelineTask.
+type PipelineTaskFailure struct {
+	// Name is the name of the PipelineTask.
+	Name string `json:"name"`
+	// Reason is the reason of the failure of the run of the PipelineTask.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Message is the first line of the message of the failure of the run of
+	// the PipelineTask, truncated if it is long.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // SkippedTask is used to describe the Tasks that were skipped due to their When Expressions
@@ -519,6 +598,17 @@ type SkippedTask struct {
 	// +optional
 	// +listType=atomic
 	WhenExpressions []WhenExpression `json:"whenExpressions,omitempty"`
+	// EvaluatedWhenExpressions are the when expressions of the PipelineTask with the outcome
+	// of their evaluation, when it was skipped because one of them evaluated to false.
+	// Long inputs and values are truncated.
+	// +optional
+	// +listType=atomic
+	EvaluatedWhenExpressions []EvaluatedWhenExpression `json:"evaluatedWhenExpressions,omitempty"`
+	// FailedAncestor is the name of the nearest ancestor of the PipelineTask which failed,
+	// when the PipelineTask was skipped because of its parents, the results they didn't
+	// produce or the PipelineRun stopping.
+	// +optional
+	FailedAncestor string `json:"failedAncestor,omitempty"`
 }
 
 // SkippingReason explains why a PipelineTask was skipped.
@@ -545,6 +635,8 @@ const (
 	FinallyTimedOutSkip SkippingReason = "PipelineRun Finally timeout has been reached"
 	// EmptyArrayInMatrixParams means the task was skipped because Matrix parameters contain empty array.
 	EmptyArrayInMatrixParams SkippingReason = "Matrix Parameters have an empty array"
+	// FilteredOutSkip means the task was skipped because it isn't selected by the tasksFilter of the PipelineRun
+	FilteredOutSkip SkippingReason = "FilteredOut"
 	// None means the task was not skipped
 	None SkippingReason = "None"
 )
@@ -558,6 +650,11 @@ type PipelineRunResult struct {
 	// +kubebuilder:pruning:PreserveUnknownFields
 	// +kubebuilder:validation:Schemaless
 	Value ResultValue `json:"value"`
+
+	// Defaulted is true if the Value is the default of the result, because
+	// one of the PipelineTasks it references was skipped.
+	// +optional
+	Defaulted bool `json:"defaulted,omitempty"`
 }
 
 // PipelineRunTaskRunStatus contains the name of the PipelineTask for this TaskRun and the TaskRun's Status
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/apis/validate"
 	"github.com/tektoncd/pipeline/pkg/internal/resultref"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
@@ -51,11 +52,17 @@ func (pr *PipelineRun) Validate(ctx context.Context) *apis.FieldError {
 	}
 
 	errs := validate.ObjectMetadata(pr.GetObjectMeta()).ViaField("metadata")
+	errs = errs.Also(v1.ValidateSourceEventAnnotations(ctx, pr.GetObjectMeta()).ViaField("metadata"))
+	errs = errs.Also(v1.ValidateApprovalAnnotations(ctx, pr.GetObjectMeta()).ViaField("metadata"))
 
 	if pr.IsPending() && pr.HasStarted() {
 		errs = errs.Also(apis.ErrInvalidValue("PipelineRun cannot be Pending after it is started", "spec.status"))
 	}
 
+	if apis.IsInCreate(ctx) {
+		errs = errs.Also(validateNamespaceDefaults(ctx, pr.Namespace))
+	}
+
 	return errs.Also(pr.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
 }
 
@@ -139,8 +146,14 @@ func (ps *PipelineRunSpec) Validate(ctx context.Context) (errs *apis.FieldError)
 	for idx, trs := range ps.TaskRunSpecs {
 		errs = errs.Also(validateTaskRunSpec(ctx, trs).ViaIndex(idx).ViaField("taskRunSpecs"))
 	}
+	if ps.TasksFilter != nil {
+		errs = errs.Also(ps.TasksFilter.Validate(ctx).ViaField("tasksFilter"))
+	}
 	if ps.PodTemplate != nil {
 		errs = errs.Also(validatePodTemplateEnv(ctx, *ps.PodTemplate))
+		errs = errs.Also(validatePodTemplateSecurityProfiles(ctx, *ps.PodTemplate))
+		errs = errs.Also(validatePodTemplateTerminationGracePeriod(ctx, *ps.PodTemplate))
+		errs = errs.Also(ps.PodTemplate.ValidateHostAliasesAndDNS().ViaField("podTemplate"))
 	}
 	if ps.Resources != nil {
 		errs = errs.Also(apis.ErrDisallowedFields("resources"))
@@ -269,11 +282,15 @@ func (ps *PipelineRunSpec) validateInlineParameters(ctx context.Context) (errs *
 			if pt.TaskSpec != nil && pt.TaskSpec.Steps != nil {
 				errs = errs.Also(ValidateParameterTypes(ctx, paramSpec))
 				errs = errs.Also(ValidateParameterVariables(ctx, pt.TaskSpec.Steps, paramSpec))
-				errs = errs.Also(ValidateUsageOfDeclaredParameters(ctx, pt.TaskSpec.Steps, paramSpec))
+				if len(ps.PipelineSpec.ParamsFrom) == 0 && len(pt.TaskSpec.ParamsFrom) == 0 {
+					errs = errs.Also(ValidateUsageOfDeclaredParameters(ctx, pt.TaskSpec.Steps, paramSpec))
+				}
 			}
 		}
 		errs = errs.Also(ValidatePipelineParameterVariables(ctx, ps.PipelineSpec.Tasks, paramSpec))
-		errs = errs.Also(validatePipelineTaskParameterUsage(ps.PipelineSpec.Tasks, paramSpec))
+		if len(ps.PipelineSpec.ParamsFrom) == 0 {
+			errs = errs.Also(validatePipelineTaskParameterUsage(ps.PipelineSpec.Tasks, paramSpec))
+		}
 	}
 	return errs
 }
@@ -303,14 +320,16 @@ func validateSpecStatus(status PipelineRunSpecStatus) *apis.FieldError {
 		return nil
 	case PipelineRunSpecStatusCancelled,
 		PipelineRunSpecStatusCancelledRunFinally,
-		PipelineRunSpecStatusStoppedRunFinally:
+		PipelineRunSpecStatusStoppedRunFinally,
+		PipelineRunSpecStatusSuperseded:
 		return nil
 	}
 
-	return apis.ErrInvalidValue(fmt.Sprintf("%s should be %s, %s, %s or %s", status,
+	return apis.ErrInvalidValue(fmt.Sprintf("%s should be %s, %s, %s, %s or %s", status,
 		PipelineRunSpecStatusCancelled,
 		PipelineRunSpecStatusCancelledRunFinally,
 		PipelineRunSpecStatusStoppedRunFinally,
+		PipelineRunSpecStatusSuperseded,
 		PipelineRunSpecStatusPending), "status")
 }
 
@@ -377,6 +396,9 @@ func validateTaskRunSpec(ctx context.Context, trs PipelineTaskRunSpec) (errs *ap
 	}
 	if trs.TaskPodTemplate != nil {
 		errs = errs.Also(validatePodTemplateEnv(ctx, *trs.TaskPodTemplate))
+		errs = errs.Also(validatePodTemplateSecurityProfiles(ctx, *trs.TaskPodTemplate))
+		errs = errs.Also(validatePodTemplateTerminationGracePeriod(ctx, *trs.TaskPodTemplate))
+		errs = errs.Also(trs.TaskPodTemplate.ValidateHostAliasesAndDNS().ViaField("taskPodTemplate"))
 	}
 	return errs
 }
@@ -73,6 +73,29 @@ func TestPipelineRun_Invalid(t *testing.T) {
 		},
 		want: apis.ErrInvalidValue("PodTemplate cannot update a forbidden env: TEST_ENV", "spec.PodTemplate.Env"),
 		wc:   EnableForbiddenEnv,
+	}, {
+		name: "invalid host aliases in the pod templates",
+		pr: v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pipelinelinename",
+			},
+			Spec: v1beta1.PipelineRunSpec{
+				PipelineRef: &v1beta1.PipelineRef{
+					Name: "prname",
+				},
+				PodTemplate: &pod.Template{
+					HostAliases: []corev1.HostAlias{{IP: "10.0.0.10", Hostnames: []string{"Git"}}},
+				},
+				TaskRunSpecs: []v1beta1.PipelineTaskRunSpec{{
+					PipelineTaskName: "task-1",
+					TaskPodTemplate: &pod.Template{
+						DNSConfig: &corev1.PodDNSConfig{Searches: []string{"internal_example.com"}},
+					},
+				}},
+			},
+		},
+		want: apis.ErrInvalidValue(`Git must be a valid hostname: a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')`, "spec.podTemplate.hostAliases[0].hostnames[0]").Also(
+			apis.ErrInvalidValue(`internal_example.com must be a valid search domain: a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')`, "spec.taskRunSpecs[0].taskPodTemplate.dnsConfig.searches[0]")),
 	}, {
 		name: "invalid pipelinerun metadata",
 		pr: v1beta1.PipelineRun{
@@ -116,7 +139,7 @@ func TestPipelineRun_Invalid(t *testing.T) {
 				Status: "PipelineRunCancell",
 			},
 		},
-		want: apis.ErrInvalidValue("PipelineRunCancell should be Cancelled, CancelledRunFinally, StoppedRunFinally or PipelineRunPending", "spec.status"),
+		want: apis.ErrInvalidValue("PipelineRunCancell should be Cancelled, CancelledRunFinally, StoppedRunFinally, Superseded or PipelineRunPending", "spec.status"),
 	}, {
 		name: "propagating params with pipelinespec and taskspec params not provided",
 		pr: v1beta1.PipelineRun{
@@ -950,6 +973,19 @@ func TestPipelineRun_Validate(t *testing.T) {
 				},
 			},
 		},
+	}, {
+		name: "pipelinerun superseded",
+		pr: v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pipelinerunname",
+			},
+			Spec: v1beta1.PipelineRunSpec{
+				Status: v1beta1.PipelineRunSpecStatusSuperseded,
+				PipelineRef: &v1beta1.PipelineRef{
+					Name: "prname",
+				},
+			},
+		},
 	}, {
 		name: "beta feature: sidecar and step overrides",
 		pr: v1beta1.PipelineRun{
@@ -1870,3 +1906,54 @@ func TestPipelineRunSpec_ValidateUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestPipelineRun_Validate_ApprovalAnnotationsOnUpdate(t *testing.T) {
+	approvalPipelineRun := func(annotations map[string]string) *v1beta1.PipelineRun {
+		return &v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "pipelinerun",
+				Annotations: annotations,
+			},
+			Spec: v1beta1.PipelineRunSpec{
+				PipelineRef: &v1beta1.PipelineRef{Name: "pipeline"},
+			},
+		}
+	}
+	for _, tc := range []struct {
+		name        string
+		annotations map[string]string
+		wantErr     string
+	}{{
+		name:        "decision added",
+		annotations: map[string]string{"approvals.tekton.dev/test": "approved", "approvals.tekton.dev/deploy": "rejected"},
+	}, {
+		name:        "invalid decision",
+		annotations: map[string]string{"approvals.tekton.dev/test": "approved", "approvals.tekton.dev/deploy": "yes"},
+		wantErr:     `invalid value: approvals.tekton.dev/deploy must be "approved" or "rejected" but is "yes": metadata.annotations`,
+	}, {
+		name:        "decision changed",
+		annotations: map[string]string{"approvals.tekton.dev/test": "rejected"},
+		wantErr:     "invalid value: approvals.tekton.dev/test can't be changed or removed once set: metadata.annotations",
+	}, {
+		name:        "decision removed",
+		annotations: map[string]string{},
+		wantErr:     "invalid value: approvals.tekton.dev/test can't be changed or removed once set: metadata.annotations",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := apis.WithinUpdate(t.Context(), approvalPipelineRun(map[string]string{"approvals.tekton.dev/test": "approved"}))
+			err := approvalPipelineRun(tc.annotations).Validate(ctx)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error %q, got nil", tc.wantErr)
+			}
+			if d := cmp.Diff(tc.wantErr, err.Error()); d != "" {
+				t.Errorf("unexpected error: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -28,6 +28,26 @@ type Provenance struct {
 
 	// FeatureFlags identifies the feature flags that were used during the task/pipeline run
 	FeatureFlags *config.FeatureFlags `json:"featureFlags,omitempty"`
+
+	// SourceEvent identifies the event which caused the run, as described by
+	// the source event annotations of the run.
+	SourceEvent *SourceEvent `json:"sourceEvent,omitempty"`
+}
+
+// SourceEvent identifies the event which caused a run, as set by the
+// frontend which created it, e.g. Triggers, with the source event annotations.
+type SourceEvent struct {
+	// ID is the ID of the event, from the tekton.dev/source-event-id annotation.
+	// +optional
+	ID string `json:"id,omitempty"`
+	// Type is the type of the event, from the tekton.dev/source-event-type
+	// annotation.
+	// +optional
+	Type string `json:"type,omitempty"`
+	// URL is the URL of the source of the event, from the
+	// tekton.dev/source-url annotation.
+	// +optional
+	URL string `json:"url,omitempty"`
 }
 
 // RefSource contains the information that can uniquely identify where a remote
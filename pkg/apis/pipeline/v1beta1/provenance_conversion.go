@@ -28,6 +28,7 @@ func (p Provenance) convertTo(ctx context.Context, sink *v1.Provenance) {
 	if p.FeatureFlags != nil {
 		sink.FeatureFlags = p.FeatureFlags
 	}
+	sink.SourceEvent = (*v1.SourceEvent)(p.SourceEvent)
 }
 
 func (p *Provenance) convertFrom(ctx context.Context, source v1.Provenance) {
@@ -39,6 +40,7 @@ func (p *Provenance) convertFrom(ctx context.Context, source v1.Provenance) {
 	if source.FeatureFlags != nil {
 		p.FeatureFlags = source.FeatureFlags
 	}
+	p.SourceEvent = (*SourceEvent)(source.SourceEvent)
 }
 
 func (cs RefSource) convertTo(ctx context.Context, sink *v1.RefSource) {
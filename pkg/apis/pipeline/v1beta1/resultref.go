@@ -165,5 +165,8 @@ func PipelineTaskResultRefs(pt *PipelineTask) []*ResultRef {
 		expressions, _ := whenExpression.GetVarSubstitutionExpressions()
 		refs = append(refs, NewResultRefs(expressions)...)
 	}
+	for _, ws := range pt.Workspaces {
+		refs = append(refs, NewResultRefs(validateString(ws.SubPath))...)
+	}
 	return refs
 }
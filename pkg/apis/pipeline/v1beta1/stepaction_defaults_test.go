@@ -0,0 +1,51 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1_test
+
+import (
+	"testing"
+
+	cfgtesting "github.com/tektoncd/pipeline/pkg/apis/config/testing"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestStepActionSetDefaults_InterfaceDigest(t *testing.T) {
+	sa := &v1beta1.StepAction{
+		ObjectMeta: metav1.ObjectMeta{Name: "step-action"},
+		Spec: v1beta1.StepActionSpec{
+			Image:   "busybox",
+			Params:  v1.ParamSpecs{{Name: "path"}},
+			Results: []v1.StepResult{{Name: "digest"}},
+		},
+	}
+	want := v1.StepInterfaceOf(sa.Spec.Params, sa.Spec.Results).Digest()
+
+	sa.SetDefaults(t.Context())
+	if _, ok := sa.Annotations[v1.InterfaceDigestAnnotationKey]; ok {
+		t.Errorf("expected no interface digest annotation with the feature flag disabled, got %v", sa.Annotations)
+	}
+
+	sa.SetDefaults(cfgtesting.SetFeatureFlags(t.Context(), t, map[string]string{"enable-interface-digest": "true"}))
+	if got := sa.Annotations[v1.InterfaceDigestAnnotationKey]; got != want {
+		t.Errorf("expected the interface digest annotation %q, got %q", want, got)
+	}
+	if got := v1beta1.StepActionInterfaceOf(sa.Spec).Digest(); got != want {
+		t.Errorf("expected the digest of the interface of the StepAction %q, got %q", want, got)
+	}
+}
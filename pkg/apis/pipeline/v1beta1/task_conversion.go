@@ -119,6 +119,10 @@ func (ts *TaskSpec) ConvertTo(ctx context.Context, sink *v1.TaskSpec, meta *meta
 		p.convertTo(ctx, &new)
 		sink.Params = append(sink.Params, new)
 	}
+	sink.ParamsFrom = nil
+	for _, r := range ts.ParamsFrom {
+		sink.ParamsFrom = append(sink.ParamsFrom, v1.ParameterSetRef(r))
+	}
 	sink.DisplayName = ts.DisplayName
 	sink.Description = ts.Description
 	return nil
@@ -182,6 +186,10 @@ func (ts *TaskSpec) ConvertFrom(ctx context.Context, source *v1.TaskSpec, meta *
 		new.convertFrom(ctx, p)
 		ts.Params = append(ts.Params, new)
 	}
+	ts.ParamsFrom = nil
+	for _, r := range source.ParamsFrom {
+		ts.ParamsFrom = append(ts.ParamsFrom, ParameterSetRef(r))
+	}
 	ts.DisplayName = source.DisplayName
 	ts.Description = source.Description
 	return nil
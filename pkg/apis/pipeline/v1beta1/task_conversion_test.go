@@ -73,6 +73,7 @@ spec:
   steps:
   - image: foo
   - image: bar
+    containerName: builder
 `
 	stepResultTaskYAML := `
 metadata:
@@ -155,6 +156,21 @@ spec:
             value: main
 `
 
+	paramsFromTaskYAML := `
+metadata:
+  name: foo
+  namespace: bar
+spec:
+  params:
+  - name: param-1
+    type: string
+  paramsFrom:
+  - name: common
+  - name: images
+  steps:
+  - image: foo
+`
+
 	taskWithAllNoDeprecatedFieldsYAML := `
 metadata:
   name: foo
@@ -350,6 +366,8 @@ spec:
 
 	stepActionTaskV1beta1 := parse.MustParseV1beta1Task(t, stepActionTaskYAML)
 	stepActionTaskV1 := parse.MustParseV1Task(t, stepActionTaskYAML)
+	paramsFromTaskV1beta1 := parse.MustParseV1beta1Task(t, paramsFromTaskYAML)
+	paramsFromTaskV1 := parse.MustParseV1Task(t, paramsFromTaskYAML)
 
 	stepActionTaskResultV1beta1 := parse.MustParseV1beta1Task(t, stepActionTaskResultYAML)
 	stepActionTaskResultV1 := parse.MustParseV1Task(t, stepActionTaskResultYAML)
@@ -409,6 +427,10 @@ spec:
 		name:        "remote step action in task",
 		v1beta1Task: remoteStepActionTaskV1beta1,
 		v1Task:      remoteStepActionTaskV1,
+	}, {
+		name:        "params imported from parameter sets",
+		v1beta1Task: paramsFromTaskV1beta1,
+		v1Task:      paramsFromTaskV1,
 	}, {
 		name:        "task conversion deprecated fields",
 		v1beta1Task: taskWithDeprecatedFieldsV1beta1,
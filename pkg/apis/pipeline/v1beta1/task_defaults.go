@@ -19,6 +19,7 @@ package v1beta1
 import (
 	"context"
 
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"knative.dev/pkg/apis"
 )
 
@@ -27,6 +28,7 @@ var _ apis.Defaultable = (*Task)(nil)
 // SetDefaults implements apis.Defaultable
 func (t *Task) SetDefaults(ctx context.Context) {
 	t.Spec.SetDefaults(ctx)
+	v1.SetInterfaceDigest(ctx, &t.ObjectMeta, TaskInterfaceOf(t.Spec))
 }
 
 // SetDefaults set any defaults for the task spec
@@ -38,3 +40,29 @@ func (ts *TaskSpec) SetDefaults(ctx context.Context) {
 		ts.Results[i].SetDefaults(ctx)
 	}
 }
+
+// TaskInterfaceOf returns the InterfaceDoc of the TaskSpec, which is the one
+// of the v1 TaskSpec it converts to.
+func TaskInterfaceOf(ts TaskSpec) v1.InterfaceDoc {
+	ctx := context.Background()
+	spec := v1.TaskSpec{}
+	for _, p := range ts.Params {
+		new := v1.ParamSpec{}
+		p.convertTo(ctx, &new)
+		spec.Params = append(spec.Params, new)
+	}
+	for _, r := range ts.ParamsFrom {
+		spec.ParamsFrom = append(spec.ParamsFrom, v1.ParameterSetRef(r))
+	}
+	for _, r := range ts.Results {
+		new := v1.TaskResult{}
+		r.convertTo(ctx, &new)
+		spec.Results = append(spec.Results, new)
+	}
+	for _, w := range ts.Workspaces {
+		new := v1.WorkspaceDeclaration{}
+		w.convertTo(ctx, &new)
+		spec.Workspaces = append(spec.Workspaces, new)
+	}
+	return v1.InterfaceOf(spec)
+}
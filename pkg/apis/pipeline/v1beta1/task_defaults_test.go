@@ -0,0 +1,76 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1_test
+
+import (
+	"testing"
+
+	cfgtesting "github.com/tektoncd/pipeline/pkg/apis/config/testing"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestTaskSetDefaults_InterfaceDigest(t *testing.T) {
+	task := &v1beta1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "task"},
+		Spec: v1beta1.TaskSpec{
+			Params:     v1beta1.ParamSpecs{{Name: "url"}},
+			Results:    []v1beta1.TaskResult{{Name: "digest"}},
+			Workspaces: []v1beta1.WorkspaceDeclaration{{Name: "source"}},
+			Steps:      []v1beta1.Step{{Image: "busybox"}},
+		},
+	}
+	// The v1beta1 Task has the digest of the v1 Task it converts to.
+	want := v1.InterfaceOf(v1.TaskSpec{
+		Params:     v1.ParamSpecs{{Name: "url"}},
+		Results:    []v1.TaskResult{{Name: "digest"}},
+		Workspaces: []v1.WorkspaceDeclaration{{Name: "source"}},
+	}).Digest()
+
+	task.SetDefaults(t.Context())
+	if _, ok := task.Annotations[v1.InterfaceDigestAnnotationKey]; ok {
+		t.Errorf("expected no interface digest annotation with the feature flag disabled, got %v", task.Annotations)
+	}
+
+	task.SetDefaults(cfgtesting.SetFeatureFlags(t.Context(), t, map[string]string{"enable-interface-digest": "true"}))
+	if got := task.Annotations[v1.InterfaceDigestAnnotationKey]; got != want {
+		t.Errorf("expected the interface digest annotation %q, got %q", want, got)
+	}
+}
+
+func TestPipelineSetDefaults_InterfaceDigest(t *testing.T) {
+	pipeline := &v1beta1.Pipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "pipeline"},
+		Spec: v1beta1.PipelineSpec{
+			Params:     v1beta1.ParamSpecs{{Name: "url"}},
+			Results:    []v1beta1.PipelineResult{{Name: "digest", Value: *v1beta1.NewStructuredValues("$(tasks.build.results.digest)")}},
+			Workspaces: []v1beta1.PipelineWorkspaceDeclaration{{Name: "source"}},
+			Tasks:      []v1beta1.PipelineTask{{Name: "build", TaskRef: &v1beta1.TaskRef{Name: "build"}}},
+		},
+	}
+	want := v1.PipelineInterfaceOf(v1.PipelineSpec{
+		Params:     v1.ParamSpecs{{Name: "url"}},
+		Results:    []v1.PipelineResult{{Name: "digest", Value: *v1.NewStructuredValues("$(tasks.build.results.digest)")}},
+		Workspaces: []v1.PipelineWorkspaceDeclaration{{Name: "source"}},
+	}).Digest()
+
+	pipeline.SetDefaults(cfgtesting.SetFeatureFlags(t.Context(), t, map[string]string{"enable-interface-digest": "true"}))
+	if got := pipeline.Annotations[v1.InterfaceDigestAnnotationKey]; got != want {
+		t.Errorf("expected the interface digest annotation %q, got %q", want, got)
+	}
+}
@@ -109,6 +109,14 @@ type TaskSpec struct {
 	// +optional
 	Params ParamSpecs `json:"params,omitempty"`
 
+	// ParamsFrom references ParameterSets whose params are imported into
+	// Params when the Task is run. The params declared in Params take
+	// precedence over the imported ones, and the params of a ParameterSet
+	// over the ones of the next ParameterSets.
+	// +optional
+	// +listType=atomic
+	ParamsFrom []ParameterSetRef `json:"paramsFrom,omitempty"`
+
 	// DisplayName is a user-facing name of the task that may be
 	// used to populate a UI.
 	// +optional
@@ -73,7 +73,11 @@ func (t *Task) Validate(ctx context.Context) *apis.FieldError {
 	errs = errs.Also(t.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
 	// When a Task is created directly, instead of declared inline in a TaskRun or PipelineRun,
 	// we do not support propagated parameters. Validate that all params it uses are declared.
-	return errs.Also(ValidateUsageOfDeclaredParameters(ctx, t.Spec.Steps, t.Spec.Params).ViaField("spec"))
+	// The params imported with paramsFrom are only known, and validated, when the Task is run.
+	if len(t.Spec.ParamsFrom) == 0 {
+		errs = errs.Also(ValidateUsageOfDeclaredParameters(ctx, t.Spec.Steps, t.Spec.Params).ViaField("spec"))
+	}
+	return errs
 }
 
 // Validate implements apis.Validatable
@@ -85,6 +89,7 @@ func (ts *TaskSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	errs = errs.Also(ValidateVolumes(ts.Volumes).ViaField("volumes"))
 	errs = errs.Also(validateDeclaredWorkspaces(ts.Workspaces, ts.Steps, ts.StepTemplate).ViaField("workspaces"))
 	errs = errs.Also(validateWorkspaceUsages(ctx, ts))
+	errs = errs.Also(validateSidecarDependencies(ctx, ts))
 	mergedSteps, err := MergeStepsWithStepTemplate(ts.StepTemplate, ts.Steps)
 	if err != nil {
 		errs = errs.Also(&apis.FieldError{
@@ -95,11 +100,18 @@ func (ts *TaskSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	}
 
 	errs = errs.Also(validateSteps(ctx, mergedSteps).ViaField("steps"))
+	errs = errs.Also(validateStepContainerNames(mergedSteps).ViaField("steps"))
 	errs = errs.Also(validateSidecarNames(ts.Sidecars))
+	errs = errs.Also(validateSidecarVolumeMounts(ts.Sidecars).ViaField("sidecars"))
+	errs = errs.Also(validateSidecarSecurityProfiles(ctx, ts.Sidecars).ViaField("sidecars"))
 	errs = errs.Also(ValidateParameterTypes(ctx, ts.Params).ViaField("params"))
+	errs = errs.Also(validateParamsFrom(ctx, ts.ParamsFrom))
 	errs = errs.Also(ValidateParameterVariables(ctx, ts.Steps, ts.Params))
 	errs = errs.Also(validateTaskContextVariables(ctx, ts.Steps))
-	errs = errs.Also(validateTaskResultsVariables(ctx, ts.Steps, ts.Results))
+	// The results imported with paramsFrom are only known, and validated, when the Task is run.
+	if len(ts.ParamsFrom) == 0 {
+		errs = errs.Also(validateTaskResultsVariables(ctx, ts.Steps, ts.Results))
+	}
 	errs = errs.Also(validateResults(ctx, ts.Results).ViaField("results"))
 	if ts.Resources != nil {
 		errs = errs.Also(apis.ErrDisallowedFields("resources"))
@@ -141,6 +153,20 @@ func validateSidecarNames(sidecars []Sidecar) (errs *apis.FieldError) {
 	return errs
 }
 
+func validateSidecarVolumeMounts(sidecars []Sidecar) (errs *apis.FieldError) {
+	for i, sc := range sidecars {
+		errs = errs.Also(validateReservedVolumeMounts(sc.VolumeMounts).ViaIndex(i))
+	}
+	return errs
+}
+
+func validateSidecarSecurityProfiles(ctx context.Context, sidecars []Sidecar) (errs *apis.FieldError) {
+	for i, sc := range sidecars {
+		errs = errs.Also(validateSecurityContextProfiles(ctx, sc.SecurityContext).ViaIndex(i))
+	}
+	return errs
+}
+
 func validateResults(ctx context.Context, results []TaskResult) (errs *apis.FieldError) {
 	for index, result := range results {
 		errs = errs.Also(result.Validate(ctx).ViaIndex(index))
@@ -209,6 +235,11 @@ func validateWorkspaceUsages(ctx context.Context, ts *TaskSpec) (errs *apis.Fiel
 				errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("undefined workspace %q", w.Name), "name").ViaIndex(workspaceIdx).ViaField("workspaces").ViaIndex(stepIdx).ViaField("steps"))
 			}
 		}
+		if step.ScriptFrom != nil && step.ScriptFrom.Workspace != nil && step.ScriptFrom.Workspace.Name != "" {
+			if name := step.ScriptFrom.Workspace.Name; !wsNames.Has(name) {
+				errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("undefined workspace %q", name), "scriptFrom.workspace.name").ViaIndex(stepIdx).ViaField("steps"))
+			}
+		}
 	}
 
 	for sidecarIdx, sidecar := range sidecars {
@@ -225,6 +256,50 @@ func validateWorkspaceUsages(ctx context.Context, ts *TaskSpec) (errs *apis.Fiel
 	return errs
 }
 
+// validateSidecarDependencies checks that the Steps only depend on the
+// Sidecars of the Task, and that the Sidecars are only stopped after the
+// Steps of the Task.
+//
+// This is an alpha feature and will fail validation if it's used by a step
+// or sidecar when the enable-api-fields feature gate is not "alpha".
+func validateSidecarDependencies(ctx context.Context, ts *TaskSpec) (errs *apis.FieldError) {
+	sidecarNames := sets.NewString()
+	for _, sidecar := range ts.Sidecars {
+		sidecarNames.Insert(sidecar.Name)
+	}
+	stepNames := sets.NewString()
+	for _, step := range ts.Steps {
+		stepNames.Insert(step.Name)
+	}
+
+	for stepIdx, step := range ts.Steps {
+		if len(step.DependsOnSidecars) != 0 {
+			errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "step dependsOnSidecars", config.AlphaAPIFields).ViaIndex(stepIdx).ViaField("steps"))
+		}
+		for i, name := range step.DependsOnSidecars {
+			if !sidecarNames.Has(name) {
+				errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("undefined sidecar %q", name), "").ViaFieldIndex("dependsOnSidecars", i).ViaIndex(stepIdx).ViaField("steps"))
+			}
+		}
+	}
+
+	for sidecarIdx, sidecar := range ts.Sidecars {
+		if len(sidecar.StopAfterSteps) != 0 {
+			errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "sidecar stopAfterSteps", config.AlphaAPIFields).ViaIndex(sidecarIdx).ViaField("sidecars"))
+		}
+		if sidecar.FailTaskRunOnTermination {
+			errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "sidecar failTaskRunOnTermination", config.AlphaAPIFields).ViaIndex(sidecarIdx).ViaField("sidecars"))
+		}
+		for i, name := range sidecar.StopAfterSteps {
+			if !stepNames.Has(name) {
+				errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("undefined step %q", name), "").ViaFieldIndex("stopAfterSteps", i).ViaIndex(sidecarIdx).ViaField("sidecars"))
+			}
+		}
+	}
+
+	return errs
+}
+
 // ValidateVolumes validates a slice of volumes to make sure there are no dupilcate names
 func ValidateVolumes(volumes []corev1.Volume) (errs *apis.FieldError) {
 	// Task must not have duplicate volume names.
@@ -255,6 +330,52 @@ func validateSteps(ctx context.Context, steps []Step) (errs *apis.FieldError) {
 	return errs
 }
 
+// validateStepContainerName validates the custom name of the container of a
+// Step, if any.
+func validateStepContainerName(name string) *apis.FieldError {
+	if name == "" {
+		return nil
+	}
+	if e := validation.IsDNS1123Label(name); len(e) > 0 {
+		return &apis.FieldError{
+			Message: fmt.Sprintf("invalid value %q", name),
+			Paths:   []string{"containerName"},
+			Details: "Step container name must be a valid DNS Label, For more info refer to https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names",
+		}
+	}
+	if strings.HasPrefix(name, sidecarContainerPrefix) {
+		return apis.ErrInvalidValue(fmt.Sprintf("%q must not start with %q which is reserved for the containers of sidecars", name, sidecarContainerPrefix), "containerName")
+	}
+	return nil
+}
+
+// validateStepContainerNames validates that the custom container names of the
+// Steps don't collide with the names of the containers of the other Steps. They
+// can't collide with the containers of the Sidecars, whose prefix is reserved.
+func validateStepContainerNames(steps []Step) (errs *apis.FieldError) {
+	containerNames := sets.NewString()
+	for i, s := range steps {
+		if s.ContainerName != "" {
+			continue
+		}
+		if s.Name != "" {
+			containerNames.Insert(stepContainerPrefix + s.Name)
+		} else {
+			containerNames.Insert(fmt.Sprintf("%sunnamed-%d", stepContainerPrefix, i))
+		}
+	}
+	for i, s := range steps {
+		if s.ContainerName == "" {
+			continue
+		}
+		if containerNames.Has(s.ContainerName) {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("container name %q is used by another container of the pod", s.ContainerName), "containerName").ViaIndex(i))
+		}
+		containerNames.Insert(s.ContainerName)
+	}
+	return errs
+}
+
 func errorIfStepResultReferenceinField(value, fieldName string) (errs *apis.FieldError) {
 	matches := resultref.StepResultRegex.FindAllStringSubmatch(value, -1)
 	if len(matches) > 0 {
@@ -368,6 +489,12 @@ func validateStep(ctx context.Context, s Step, names sets.String) (errs *apis.Fi
 				Paths:   []string{"script"},
 			})
 		}
+		if s.ScriptFrom != nil {
+			errs = errs.Also(&apis.FieldError{
+				Message: "scriptFrom cannot be used with Ref",
+				Paths:   []string{"scriptFrom"},
+			})
+		}
 		if s.WorkingDir != "" {
 			errs = errs.Also(&apis.FieldError{
 				Message: "working dir cannot be used with Ref",
@@ -427,21 +554,17 @@ func validateStep(ctx context.Context, s Step, names sets.String) (errs *apis.Fi
 		names.Insert(s.Name)
 	}
 
+	errs = errs.Also(validateStepContainerName(s.ContainerName))
+
 	if s.Timeout != nil {
 		if s.Timeout.Duration < time.Duration(0) {
 			return apis.ErrInvalidValue(s.Timeout.Duration, "negative timeout")
 		}
 	}
 
-	for j, vm := range s.VolumeMounts {
-		if strings.HasPrefix(vm.MountPath, "/tekton/") &&
-			!strings.HasPrefix(vm.MountPath, "/tekton/home") {
-			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("volumeMount cannot be mounted under /tekton/ (volumeMount %q mounted at %q)", vm.Name, vm.MountPath), "mountPath").ViaFieldIndex("volumeMounts", j))
-		}
-		if strings.HasPrefix(vm.Name, "tekton-internal-") {
-			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf(`volumeMount name %q cannot start with "tekton-internal-"`, vm.Name), "name").ViaFieldIndex("volumeMounts", j))
-		}
-	}
+	errs = errs.Also(validateReservedVolumeMounts(s.VolumeMounts))
+	errs = errs.Also(validateReservedEnvVars(s.Env))
+	errs = errs.Also(validateSecurityContextProfiles(ctx, s.SecurityContext))
 
 	if s.OnError != "" {
 		if !isParamRefs(string(s.OnError)) && s.OnError != Continue && s.OnError != StopAndFail {
@@ -470,6 +593,21 @@ func validateStep(ctx context.Context, s Step, names sets.String) (errs *apis.Fi
 	if s.StderrConfig != nil {
 		errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "step stderr stream support", config.AlphaAPIFields).ViaField("stderrconfig"))
 	}
+	// StdinFrom is an alpha feature and will fail validation if it's used in a task spec
+	// when the enable-api-fields feature gate is not "alpha".
+	if s.StdinFrom != nil {
+		errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "step stdin support", config.AlphaAPIFields).ViaField("stdinFrom"))
+		errs = errs.Also(validateStepStdinFrom(s.StdinFrom).ViaField("stdinFrom"))
+		if s.DeprecatedStdin || s.DeprecatedStdinOnce || s.DeprecatedTTY {
+			errs = errs.Also(apis.ErrGeneric("stdinFrom can't be combined with the deprecated stdin, stdinOnce and tty fields", "stdinFrom"))
+		}
+	}
+	// ScriptFrom is an alpha feature and will fail validation if it's used in a task spec
+	// when the enable-api-fields feature gate is not "alpha".
+	if s.ScriptFrom != nil {
+		errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "step scriptFrom", config.AlphaAPIFields).ViaField("scriptFrom"))
+		errs = errs.Also(validateStepScriptFrom(&s))
+	}
 
 	// Validate usage of step result reference.
 	// Referencing previous step's results are only allowed in `env`, `command` and `args`.
@@ -786,3 +924,103 @@ func (ts *TaskSpec) GetIndexingReferencesToArrayParams() sets.String {
 	}
 	return sets.NewString(arrayIndexParamRefs...)
 }
+
+// validateStepStdinFrom validates that exactly one source of the standard
+// input of a step is set.
+func validateStepStdinFrom(stdin *StepStdinSource) *apis.FieldError {
+	switch {
+	case stdin.Value != "" && stdin.Path != "":
+		return apis.ErrMultipleOneOf("value", "path")
+	case stdin.Value == "" && stdin.Path == "":
+		return apis.ErrMissingOneOf("value", "path")
+	}
+	return nil
+}
+
+// validateStepScriptFrom validates that exactly one source of the script of a
+// step is set, and that the step has neither an inline script nor a command.
+func validateStepScriptFrom(s *Step) (errs *apis.FieldError) {
+	if s.Script != "" {
+		errs = errs.Also(apis.ErrMultipleOneOf("script", "scriptFrom"))
+	}
+	if len(s.Command) > 0 {
+		errs = errs.Also(&apis.FieldError{
+			Message: "scriptFrom cannot be used with command",
+			Paths:   []string{"scriptFrom"},
+		})
+	}
+	source := s.ScriptFrom
+	switch {
+	case source.Workspace != nil && source.ConfigMap != nil:
+		errs = errs.Also(apis.ErrMultipleOneOf("scriptFrom.workspace", "scriptFrom.configMap"))
+	case source.Workspace != nil:
+		if source.Workspace.Name == "" {
+			errs = errs.Also(apis.ErrMissingField("scriptFrom.workspace.name"))
+		}
+		if p := source.Workspace.Path; p == "" {
+			errs = errs.Also(apis.ErrMissingField("scriptFrom.workspace.path"))
+		} else if !pipeline.IsRelativeSubPath(p) {
+			errs = errs.Also(apis.ErrInvalidValue(p, "scriptFrom.workspace.path", "must be a relative path within the workspace"))
+		}
+	case source.ConfigMap != nil:
+		if source.ConfigMap.Name == "" {
+			errs = errs.Also(apis.ErrMissingField("scriptFrom.configMap.name"))
+		}
+		if source.ConfigMap.Key == "" {
+			errs = errs.Also(apis.ErrMissingField("scriptFrom.configMap.key"))
+		}
+	default:
+		errs = errs.Also(apis.ErrMissingOneOf("scriptFrom.workspace", "scriptFrom.configMap"))
+	}
+	return errs
+}
+
+// validateReservedVolumeMounts validates that the volumeMounts of a Step or
+// Sidecar don't shadow the volumes mounted by Tekton.
+func validateReservedVolumeMounts(volumeMounts []corev1.VolumeMount) (errs *apis.FieldError) {
+	for j, vm := range volumeMounts {
+		if prefix := pipeline.ReservedMountPathPrefix(vm.MountPath); prefix != "" {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("volumeMount cannot be mounted under %s/ (volumeMount %q mounted at %q)", prefix, vm.Name, vm.MountPath), "mountPath").ViaFieldIndex("volumeMounts", j))
+		}
+		if strings.HasPrefix(vm.Name, "tekton-internal-") {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf(`volumeMount name %q cannot start with "tekton-internal-"`, vm.Name), "name").ViaFieldIndex("volumeMounts", j))
+		}
+	}
+	return errs
+}
+
+// validateReservedEnvVars validates that the env vars of a Step don't override
+// the ones injected by Tekton for the entrypoint.
+func validateReservedEnvVars(env []corev1.EnvVar) (errs *apis.FieldError) {
+	for j, e := range env {
+		if pipeline.IsReservedEnvVar(e.Name) {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("env var %q is reserved for Tekton", e.Name), "name").ViaFieldIndex("env", j))
+		}
+	}
+	return errs
+}
+
+// validateSecurityContextProfiles validates that the securityContext of a
+// Step or Sidecar doesn't use Localhost profiles when they are forbidden.
+func validateSecurityContextProfiles(ctx context.Context, securityContext *corev1.SecurityContext) *apis.FieldError {
+	if securityContext == nil {
+		return nil
+	}
+	return validateLocalhostProfiles(ctx, securityContext.SeccompProfile, securityContext.AppArmorProfile).ViaField("securityContext")
+}
+
+// validateLocalhostProfiles validates that the seccomp and AppArmor profiles
+// aren't Localhost profiles, loaded on the nodes, when the
+// forbid-localhost-profiles config is set.
+func validateLocalhostProfiles(ctx context.Context, seccompProfile *corev1.SeccompProfile, appArmorProfile *corev1.AppArmorProfile) (errs *apis.FieldError) {
+	if !config.FromContextOrDefaults(ctx).Defaults.ForbidLocalhostProfiles {
+		return nil
+	}
+	if seccompProfile != nil && seccompProfile.Type == corev1.SeccompProfileTypeLocalhost {
+		errs = errs.Also(apis.ErrInvalidValue("Localhost profiles are forbidden by the forbid-localhost-profiles config", "seccompProfile.type"))
+	}
+	if appArmorProfile != nil && appArmorProfile.Type == corev1.AppArmorProfileTypeLocalhost {
+		errs = errs.Also(apis.ErrInvalidValue("Localhost profiles are forbidden by the forbid-localhost-profiles config", "appArmorProfile.type"))
+	}
+	return errs
+}
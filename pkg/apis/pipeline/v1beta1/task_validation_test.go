@@ -1248,6 +1248,36 @@ func TestTaskSpecValidateError(t *testing.T) {
 			Message: `volumeMount cannot be mounted under /tekton/ (volumeMount "foo" mounted at "/tekton/foo")`,
 			Paths:   []string{"steps[0].volumeMounts[0].mountPath"},
 		},
+	}, {
+		name: "step env var injected by Tekton",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Image: "myimage",
+				Env: []corev1.EnvVar{{
+					Name:  "TEKTON_PLATFORM_COMMANDS",
+					Value: "{}",
+				}},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `env var "TEKTON_PLATFORM_COMMANDS" is reserved for Tekton`,
+			Paths:   []string{"steps[0].env[0].name"},
+		},
+	}, {
+		name: "step stdin value env var injected by Tekton",
+		fields: fields{
+			Steps: []v1beta1.Step{{
+				Image: "myimage",
+				Env: []corev1.EnvVar{{
+					Name:  "TEKTON_STEP_STDIN_VALUE",
+					Value: "foo",
+				}},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `env var "TEKTON_STEP_STDIN_VALUE" is reserved for Tekton`,
+			Paths:   []string{"steps[0].env[0].name"},
+		},
 	}, {
 		name: "step volume mount name starts with tekton-internal-",
 		fields: fields{
@@ -1717,6 +1747,20 @@ func TestTaskSpecValidateErrorSidecarName(t *testing.T) {
 			Message: fmt.Sprintf("Invalid: cannot use reserved sidecar name %v ", pipeline.ReservedResultsSidecarName),
 			Paths:   []string{"sidecars"},
 		},
+	}, {
+		name: "cannot mount volumes under /var/run/tekton/",
+		sidecars: []v1beta1.Sidecar{{
+			Name:  "my-sidecar",
+			Image: "my-image",
+			VolumeMounts: []corev1.VolumeMount{{
+				Name:      "foo",
+				MountPath: "/var/run/tekton/foo",
+			}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `volumeMount cannot be mounted under /var/run/tekton/ (volumeMount "foo" mounted at "/var/run/tekton/foo")`,
+			Paths:   []string{"sidecars[0].volumeMounts[0].mountPath"},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1738,6 +1782,164 @@ func TestTaskSpecValidateErrorSidecarName(t *testing.T) {
 	}
 }
 
+func TestTaskSpecValidateErrorStepContainerName(t *testing.T) {
+	tests := []struct {
+		name          string
+		steps         []v1beta1.Step
+		expectedError apis.FieldError
+	}{{
+		name: "container name reserved for sidecars",
+		steps: []v1beta1.Step{{
+			Name:          "build",
+			Image:         "my-image",
+			ContainerName: "sidecar-build",
+		}},
+		expectedError: apis.FieldError{
+			Message: `invalid value: "sidecar-build" must not start with "sidecar-" which is reserved for the containers of sidecars`,
+			Paths:   []string{"steps[0].containerName"},
+		},
+	}, {
+		name: "container name used by another step",
+		steps: []v1beta1.Step{{
+			Name:  "build",
+			Image: "my-image",
+		}, {
+			Name:          "test",
+			Image:         "my-image",
+			ContainerName: "step-build",
+		}},
+		expectedError: apis.FieldError{
+			Message: `invalid value: container name "step-build" is used by another container of the pod`,
+			Paths:   []string{"steps[1].containerName"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1beta1.TaskSpec{
+				Steps: tt.steps,
+			}
+			err := ts.Validate(t.Context())
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", ts)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidateErrorStepStdinFrom(t *testing.T) {
+	tests := []struct {
+		name          string
+		step          v1beta1.Step
+		expectedError apis.FieldError
+	}{{
+		name: "stdin fed from both a value and a file",
+		step: v1beta1.Step{
+			Image:     "my-image",
+			StdinFrom: &v1beta1.StepStdinSource{Value: "$(params.input)", Path: "/tmp/input.json"},
+		},
+		expectedError: apis.FieldError{
+			Message: "expected exactly one, got both",
+			Paths:   []string{"steps[0].stdinFrom.path", "steps[0].stdinFrom.value"},
+		},
+	}, {
+		name: "stdin combined with the deprecated stdin field",
+		step: v1beta1.Step{
+			Image:           "my-image",
+			StdinFrom:       &v1beta1.StepStdinSource{Value: "$(params.input)"},
+			DeprecatedStdin: true,
+		},
+		expectedError: apis.FieldError{
+			Message: "stdinFrom can't be combined with the deprecated stdin, stdinOnce and tty fields",
+			Paths:   []string{"steps[0].stdinFrom"},
+		},
+	}, {
+		name: "stdin combined with the deprecated tty field",
+		step: v1beta1.Step{
+			Image:         "my-image",
+			StdinFrom:     &v1beta1.StepStdinSource{Path: "/tmp/input.json"},
+			DeprecatedTTY: true,
+		},
+		expectedError: apis.FieldError{
+			Message: "stdinFrom can't be combined with the deprecated stdin, stdinOnce and tty fields",
+			Paths:   []string{"steps[0].stdinFrom"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1beta1.TaskSpec{
+				Steps: []v1beta1.Step{tt.step},
+			}
+			err := ts.Validate(cfgtesting.EnableAlphaAPIFields(t.Context()))
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", ts)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidateErrorStepScriptFrom(t *testing.T) {
+	tests := []struct {
+		name          string
+		step          v1beta1.Step
+		expectedError apis.FieldError
+	}{{
+		name: "script from both a workspace and a configMap",
+		step: v1beta1.Step{
+			Image: "my-image",
+			ScriptFrom: &v1beta1.StepScriptSource{
+				Workspace: &v1beta1.WorkspaceFileSelector{Name: "source", Path: "build.sh"},
+				ConfigMap: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "scripts"}, Key: "build.sh"},
+			},
+		},
+		expectedError: apis.FieldError{
+			Message: "expected exactly one, got both",
+			Paths:   []string{"steps[0].scriptFrom.configMap", "steps[0].scriptFrom.workspace"},
+		},
+	}, {
+		name: "script from combined with an inline script",
+		step: v1beta1.Step{
+			Image:      "my-image",
+			Script:     "echo hello",
+			ScriptFrom: &v1beta1.StepScriptSource{ConfigMap: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "scripts"}, Key: "build.sh"}},
+		},
+		expectedError: apis.FieldError{
+			Message: "expected exactly one, got both",
+			Paths:   []string{"steps[0].script", "steps[0].scriptFrom"},
+		},
+	}, {
+		name: "script from a non-existent workspace declaration",
+		step: v1beta1.Step{
+			Image:      "my-image",
+			ScriptFrom: &v1beta1.StepScriptSource{Workspace: &v1beta1.WorkspaceFileSelector{Name: "scripts", Path: "build.sh"}},
+		},
+		expectedError: apis.FieldError{
+			Message: `undefined workspace "scripts"`,
+			Paths:   []string{"steps[0].scriptFrom.workspace.name"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1beta1.TaskSpec{
+				Steps:      []v1beta1.Step{tt.step},
+				Workspaces: []v1beta1.WorkspaceDeclaration{{Name: "source"}},
+			}
+			err := ts.Validate(cfgtesting.EnableAlphaAPIFields(t.Context()))
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", ts)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestStepAndSidecarWorkspaces(t *testing.T) {
 	type fields struct {
 		Steps      []v1beta1.Step
@@ -1909,6 +2111,87 @@ func TestStepOnError(t *testing.T) {
 	}
 }
 
+func TestSidecarDependencies(t *testing.T) {
+	tests := []struct {
+		name          string
+		steps         []v1beta1.Step
+		sidecars      []v1beta1.Sidecar
+		expectedError *apis.FieldError
+	}{{
+		name: "steps depend on sidecars stopped after steps",
+		steps: []v1beta1.Step{{
+			Name:              "migrate",
+			Image:             "my-image",
+			DependsOnSidecars: []string{"db"},
+		}, {
+			Name:  "test",
+			Image: "my-image",
+		}},
+		sidecars: []v1beta1.Sidecar{{
+			Name:           "db",
+			Image:          "postgres",
+			StopAfterSteps: []string{"migrate"},
+		}, {
+			Name:                     "cache",
+			Image:                    "redis",
+			FailTaskRunOnTermination: true,
+		}},
+	}, {
+		name: "step depending on an undefined sidecar",
+		steps: []v1beta1.Step{{
+			Name:              "migrate",
+			Image:             "my-image",
+			DependsOnSidecars: []string{"db", "cache"},
+		}},
+		sidecars: []v1beta1.Sidecar{{
+			Name:  "db",
+			Image: "postgres",
+		}},
+		expectedError: &apis.FieldError{
+			Message: `invalid value: undefined sidecar "cache"`,
+			Paths:   []string{"steps[0].dependsOnSidecars[1]"},
+		},
+	}, {
+		name: "sidecar stopped after an undefined step",
+		steps: []v1beta1.Step{{
+			Name:  "migrate",
+			Image: "my-image",
+		}},
+		sidecars: []v1beta1.Sidecar{{
+			Name:           "db",
+			Image:          "postgres",
+			StopAfterSteps: []string{"test"},
+		}},
+		expectedError: &apis.FieldError{
+			Message: `invalid value: undefined step "test"`,
+			Paths:   []string{"sidecars[0].stopAfterSteps[0]"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1beta1.TaskSpec{
+				Steps:    tt.steps,
+				Sidecars: tt.sidecars,
+			}
+			ctx := cfgtesting.EnableAlphaAPIFields(t.Context())
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("TaskSpec.Validate() = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", ts)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 // TestIncompatibleAPIVersions exercises validation of fields that
 // require a specific feature gate version in order to work.
 func TestIncompatibleAPIVersions(t *testing.T) {
@@ -1945,6 +2228,48 @@ func TestIncompatibleAPIVersions(t *testing.T) {
 				}},
 			}},
 		},
+	}, {
+		name:            "step dependsOnSidecars requires alpha",
+		requiredVersion: "alpha",
+		spec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Name:              "foo",
+				Image:             "foo",
+				DependsOnSidecars: []string{"bar"},
+			}},
+			Sidecars: []v1beta1.Sidecar{{
+				Name:  "bar",
+				Image: "bar",
+			}},
+		},
+	}, {
+		name:            "sidecar stopAfterSteps requires alpha",
+		requiredVersion: "alpha",
+		spec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Name:  "foo",
+				Image: "foo",
+			}},
+			Sidecars: []v1beta1.Sidecar{{
+				Name:           "bar",
+				Image:          "bar",
+				StopAfterSteps: []string{"foo"},
+			}},
+		},
+	}, {
+		name:            "sidecar failTaskRunOnTermination requires alpha",
+		requiredVersion: "alpha",
+		spec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Name:  "foo",
+				Image: "foo",
+			}},
+			Sidecars: []v1beta1.Sidecar{{
+				Name:                     "bar",
+				Image:                    "bar",
+				FailTaskRunOnTermination: true,
+			}},
+		},
 	}, {
 		name:            "sidecar workspace requires beta",
 		requiredVersion: "beta",
@@ -1994,6 +2319,17 @@ func TestIncompatibleAPIVersions(t *testing.T) {
 					Path: "/tmp/stderr.txt",
 				},
 			}},
+		},
+	}, {
+		name:            "stdin support requires alpha",
+		requiredVersion: "alpha",
+		spec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Image: "foo",
+				StdinFrom: &v1beta1.StepStdinSource{
+					Value: "$(params.input)",
+				},
+			}},
 		}},
 	} {
 		for _, version := range versions {
@@ -289,11 +289,33 @@ func (trs *TaskRunStatus) ConvertTo(ctx context.Context, sink *v1.TaskRunStatus,
 			return err
 		}
 	}
+	sink.ExpandedParameterSets = nil
+	for _, s := range trs.ExpandedParameterSets {
+		sink.ExpandedParameterSets = append(sink.ExpandedParameterSets, v1.ExpandedParameterSet(s))
+	}
 	if trs.Provenance != nil {
 		new := v1.Provenance{}
 		trs.Provenance.convertTo(ctx, &new)
 		sink.Provenance = &new
 	}
+	if trs.Summary != nil {
+		new := v1.TaskRunSummary(*trs.Summary)
+		sink.Summary = &new
+	}
+	sink.PinnedStepImages = nil
+	for _, p := range trs.PinnedStepImages {
+		sink.PinnedStepImages = append(sink.PinnedStepImages, v1.PinnedStepImage(p))
+	}
+	sink.PauseWindows = nil
+	for _, w := range trs.PauseWindows {
+		sink.PauseWindows = append(sink.PauseWindows, v1.PauseWindow(w))
+	}
+	sink.PodCreationTime = trs.PodCreationTime
+	sink.PodStartTime = trs.PodStartTime
+	if trs.ObservedConfig != nil {
+		new := v1.ObservedConfig(*trs.ObservedConfig)
+		sink.ObservedConfig = &new
+	}
 	return nil
 }
 
@@ -338,11 +360,33 @@ func (trs *TaskRunStatus) ConvertFrom(ctx context.Context, source v1.TaskRunStat
 			return err
 		}
 	}
+	trs.ExpandedParameterSets = nil
+	for _, s := range source.ExpandedParameterSets {
+		trs.ExpandedParameterSets = append(trs.ExpandedParameterSets, ExpandedParameterSet(s))
+	}
 	if source.Provenance != nil {
 		new := Provenance{}
 		new.convertFrom(ctx, *source.Provenance)
 		trs.Provenance = &new
 	}
+	if source.Summary != nil {
+		new := TaskRunSummary(*source.Summary)
+		trs.Summary = &new
+	}
+	trs.PinnedStepImages = nil
+	for _, p := range source.PinnedStepImages {
+		trs.PinnedStepImages = append(trs.PinnedStepImages, PinnedStepImage(p))
+	}
+	trs.PauseWindows = nil
+	for _, w := range source.PauseWindows {
+		trs.PauseWindows = append(trs.PauseWindows, PauseWindow(w))
+	}
+	trs.PodCreationTime = source.PodCreationTime
+	trs.PodStartTime = source.PodStartTime
+	if source.ObservedConfig != nil {
+		new := ObservedConfig(*source.ObservedConfig)
+		trs.ObservedConfig = &new
+	}
 	return nil
 }
 
@@ -363,6 +407,16 @@ func (ss StepState) convertTo(ctx context.Context, sink *v1.StepState) {
 		sink.TerminationReason = ss.ContainerState.Terminated.Reason
 	}
 
+	if ss.ImageSignature != nil {
+		new := v1.StepImageSignature(*ss.ImageSignature)
+		sink.ImageSignature = &new
+	}
+	if ss.OOMKilled != nil {
+		new := v1.StepOOMKilled(*ss.OOMKilled)
+		sink.OOMKilled = &new
+	}
+	sink.HermeticViolations = ss.HermeticViolations
+
 	for _, o := range ss.Outputs {
 		new := v1.TaskRunStepArtifact{}
 		o.convertTo(ctx, &new)
@@ -398,6 +452,15 @@ func (ss *StepState) convertFrom(ctx context.Context, source v1.StepState) {
 		new.convertFrom(ctx, *source.Provenance)
 		ss.Provenance = &new
 	}
+	if source.ImageSignature != nil {
+		new := StepImageSignature(*source.ImageSignature)
+		ss.ImageSignature = &new
+	}
+	if source.OOMKilled != nil {
+		new := StepOOMKilled(*source.OOMKilled)
+		ss.OOMKilled = &new
+	}
+	ss.HermeticViolations = source.HermeticViolations
 	for _, o := range source.Outputs {
 		new := TaskRunStepArtifact{}
 		new.convertFrom(ctx, o)
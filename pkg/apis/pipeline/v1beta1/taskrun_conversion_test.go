@@ -29,6 +29,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	corev1resources "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 )
@@ -146,6 +147,64 @@ func TestTaskRunConversion(t *testing.T) {
 					},
 				},
 			},
+		}, {
+			name: "taskrun with image signature in step state",
+			in: &v1beta1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo",
+					Namespace: "bar",
+				},
+				Spec: v1beta1.TaskRunSpec{},
+				Status: v1beta1.TaskRunStatus{
+					TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+						Steps: []v1beta1.StepState{{
+							Name: "build",
+							ImageSignature: &v1beta1.StepImageSignature{
+								Digest:  "sha256:0000000000000000000000000000000000000000000000000000000000000000",
+								Signed:  true,
+								Subject: "builder@example.com",
+							},
+						}},
+					},
+				},
+			},
+		}, {
+			name: "taskrun with oomkilled step state",
+			in: &v1beta1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo",
+					Namespace: "bar",
+				},
+				Spec: v1beta1.TaskRunSpec{},
+				Status: v1beta1.TaskRunStatus{
+					TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+						Steps: []v1beta1.StepState{{
+							Name: "build",
+							OOMKilled: &v1beta1.StepOOMKilled{
+								MemoryLimit: corev1resources.NewQuantity(256*1024*1024, corev1resources.BinarySI),
+								MemoryPeak:  corev1resources.NewQuantity(255*1024*1024, corev1resources.BinarySI),
+							},
+						}},
+					},
+				},
+			},
+		}, {
+			name: "taskrun with hermetic violations in step state",
+			in: &v1beta1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo",
+					Namespace: "bar",
+				},
+				Spec: v1beta1.TaskRunSpec{},
+				Status: v1beta1.TaskRunStatus{
+					TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+						Steps: []v1beta1.StepState{{
+							Name:               "fetch",
+							HermeticViolations: ptr.To(int32(2)),
+						}},
+					},
+				},
+			},
 		}, {
 			name: "taskrun conversion all non deprecated fields",
 			in: &v1beta1.TaskRun{
@@ -322,6 +381,32 @@ func TestTaskRunConversion(t *testing.T) {
 								Digest: map[string]string{"sha256": "digest"},
 							},
 							FeatureFlags: config.DefaultFeatureFlags.DeepCopy(),
+							SourceEvent: &v1beta1.SourceEvent{
+								ID:   "72d3162e-cc78-11e3-81ab-4c9367dc0958",
+								Type: "push",
+								URL:  "https://github.com/tektoncd/pipeline",
+							},
+						},
+						Summary: &v1beta1.TaskRunSummary{
+							Duration:       "1m0s",
+							CompletedSteps: 1,
+							TotalSteps:     1,
+							FailedStep:     "failure",
+							Resolver:       "bundles",
+						},
+						PinnedStepImages: []v1beta1.PinnedStepImage{{
+							Name:   "build",
+							Image:  "example.com/builder:v1",
+							Digest: "sha256:0000000000000000000000000000000000000000000000000000000000000000",
+						}},
+						PodCreationTime: &metav1.Time{Time: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)},
+						PodStartTime:    &metav1.Time{Time: time.Date(2025, 1, 2, 3, 4, 35, 0, time.UTC)},
+						ObservedConfig: &v1beta1.ObservedConfig{
+							Coschedule:               "workspaces",
+							EnforceNonfalsifiability: "none",
+							ResultExtractionMethod:   "termination-message",
+							MaxResultSize:            4096,
+							DefaultTimeout:           &metav1.Duration{Duration: time.Hour},
 						},
 					},
 				},
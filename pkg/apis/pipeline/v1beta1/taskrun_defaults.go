@@ -35,6 +35,10 @@ const ManagedByLabelKey = "app.kubernetes.io/managed-by"
 // SetDefaults implements apis.Defaultable
 func (tr *TaskRun) SetDefaults(ctx context.Context) {
 	ctx = apis.WithinParent(ctx, tr.ObjectMeta)
+	// New TaskRuns get the defaults of their namespace, if it overrides them
+	if apis.IsInCreate(ctx) {
+		ctx = config.ToContextForNamespace(ctx, tr.Namespace)
+	}
 	tr.Spec.SetDefaults(ctx)
 
 	// Silently filtering out Tekton Reserved annotations at creation
@@ -42,6 +46,9 @@ func (tr *TaskRun) SetDefaults(ctx context.Context) {
 		tr.ObjectMeta.Annotations = kmap.Filter(tr.ObjectMeta.Annotations, func(s string) bool {
 			return filterReservedAnnotationRegexp.MatchString(s)
 		})
+		// The defaults of the namespace applied to the pods are recorded
+		// now, so that they only apply to the TaskRuns created after them.
+		tr.ObjectMeta.Annotations = config.RecordNamespaceDefaults(ctx, tr.Namespace, tr.ObjectMeta.Annotations)
 	}
 
 	// If the TaskRun doesn't have a managed-by label, apply the default
@@ -25,6 +25,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	pod "github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -99,6 +100,18 @@ const (
 	// TaskRunSpecStatusCancelled indicates that the user wants to cancel the task,
 	// if not already cancelled or terminated
 	TaskRunSpecStatusCancelled = "TaskRunCancelled"
+
+	// TaskRunSpecStatusSuperseded indicates that the user wants to cancel the task
+	// because a newer run replaces it, if not already cancelled or terminated
+	TaskRunSpecStatusSuperseded = "TaskRunSuperseded"
+
+	// TaskRunSpecStatusPause indicates that the user wants to pause the task,
+	// so that its next steps only start once it is resumed
+	TaskRunSpecStatusPause = "TaskRunPause"
+
+	// TaskRunSpecStatusResume indicates that the user wants to resume the
+	// paused task
+	TaskRunSpecStatusResume = "TaskRunResume"
 )
 
 // TaskRunSpecStatusMessage defines human readable status messages for the TaskRun.
@@ -110,6 +123,9 @@ const (
 	TaskRunCancelledByPipelineMsg TaskRunSpecStatusMessage = "TaskRun cancelled as the PipelineRun it belongs to has been cancelled."
 	// TaskRunCancelledByPipelineTimeoutMsg indicates that the TaskRun was cancelled because the PipelineRun running it timed out.
 	TaskRunCancelledByPipelineTimeoutMsg TaskRunSpecStatusMessage = "TaskRun cancelled as the PipelineRun it belongs to has timed out."
+	// TaskRunSupersededByPipelineMsg indicates that the PipelineRun of which this
+	// TaskRun was a part of has been superseded.
+	TaskRunSupersededByPipelineMsg TaskRunSpecStatusMessage = "TaskRun superseded as the PipelineRun it belongs to has been superseded."
 )
 
 const (
@@ -207,6 +223,10 @@ const (
 	TaskRunReasonToBeRetried TaskRunReason = "ToBeRetried"
 	// TaskRunReasonCancelled is the reason set when the TaskRun is cancelled by the user
 	TaskRunReasonCancelled TaskRunReason = "TaskRunCancelled"
+	// TaskRunReasonSuperseded is the reason set when the TaskRun is cancelled because a newer run replaces it
+	TaskRunReasonSuperseded TaskRunReason = "Superseded"
+	// TaskRunReasonPaused is the reason set when the TaskRun is paused by the user
+	TaskRunReasonPaused TaskRunReason = "TaskRunPaused"
 	// TaskRunReasonTimedOut is the reason set when one TaskRun execution has timed out
 	TaskRunReasonTimedOut TaskRunReason = "TaskRunTimeout"
 	// TaskRunReasonResolvingTaskRef indicates that the TaskRun is waiting for
@@ -283,6 +303,15 @@ type TaskRunStatusFields struct {
 	// CompletionTime is the time the build completed.
 	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
 
+	// PodCreationTime is the time the pod of the TaskRun was created at.
+	// +optional
+	PodCreationTime *metav1.Time `json:"podCreationTime,omitempty"`
+
+	// PodStartTime is the time the pod of the TaskRun was started at by the
+	// kubelet.
+	// +optional
+	PodStartTime *metav1.Time `json:"podStartTime,omitempty"`
+
 	// Steps describes the state of each build step container.
 	// +optional
 	// +listType=atomic
@@ -328,12 +357,109 @@ type TaskRunStatusFields struct {
 	// +kubebuilder:validation:Schemaless
 	TaskSpec *TaskSpec `json:"taskSpec,omitempty"`
 
+	// ExpandedParameterSets are the ParameterSets whose params were imported
+	// into the TaskSpec with paramsFrom.
+	// +optional
+	// +listType=atomic
+	ExpandedParameterSets []ExpandedParameterSet `json:"expandedParameterSets,omitempty"`
+
 	// Provenance contains some key authenticated metadata about how a software artifact was built (what sources, what inputs/outputs, etc.).
 	// +optional
 	Provenance *Provenance `json:"provenance,omitempty"`
 
 	// SpanContext contains tracing span context fields
 	SpanContext map[string]string `json:"spanContext,omitempty"`
+
+	// Summary is a compact view of the progress of the TaskRun. It is kept
+	// up to date on every reconcile so that it can be cheaply printed by clients.
+	// +optional
+	Summary *TaskRunSummary `json:"summary,omitempty"`
+
+	// PinnedStepImages are the digests which the images of the Steps referenced
+	// by tag were pinned to when the Pod of the first attempt was created. They
+	// are reused by the retries so that all the attempts run the same images.
+	// +optional
+	// +listType=atomic
+	PinnedStepImages []PinnedStepImage `json:"pinnedStepImages,omitempty"`
+
+	// PauseWindows are the windows during which the TaskRun was paused. The
+	// time spent paused doesn't count towards the timeout of the TaskRun.
+	// +optional
+	// +listType=atomic
+	PauseWindows []PauseWindow `json:"pauseWindows,omitempty"`
+
+	// ObservedConfig is the configuration which affected the execution of the
+	// TaskRun, as observed when the TaskRun started.
+	// +optional
+	ObservedConfig *ObservedConfig `json:"observedConfig,omitempty"`
+}
+
+// ObservedConfig is the subset of the feature flags and defaults which
+// affected the execution of a TaskRun. Unlike the feature flags of the
+// Provenance, it is recorded whether the provenance is enabled or not.
+type ObservedConfig struct {
+	// Coschedule is the value of the "coschedule" feature flag.
+	// +optional
+	Coschedule string `json:"coschedule,omitempty"`
+	// EnforceNonfalsifiability is the value of the
+	// "enforce-nonfalsifiability" feature flag.
+	// +optional
+	EnforceNonfalsifiability string `json:"enforceNonfalsifiability,omitempty"`
+	// ResultExtractionMethod is the value of the "results-from" feature flag.
+	// +optional
+	ResultExtractionMethod string `json:"resultExtractionMethod,omitempty"`
+	// MaxResultSize is the value of the "max-result-size" feature flag.
+	// +optional
+	MaxResultSize int `json:"maxResultSize,omitempty"`
+	// DefaultTimeout is the timeout applied to the TaskRuns which don't set
+	// one, from the "default-timeout-minutes" default.
+	// +optional
+	DefaultTimeout *metav1.Duration `json:"defaultTimeout,omitempty"`
+}
+
+// PauseWindow is a window during which a TaskRun was paused.
+type PauseWindow struct {
+	// StartTime is the time the TaskRun was paused at.
+	StartTime metav1.Time `json:"startTime"`
+	// EndTime is the time the TaskRun was resumed at, unset while it is
+	// still paused.
+	// +optional
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+}
+
+// PinnedStepImage is the digest which the image of a Step was pinned to.
+type PinnedStepImage struct {
+	// Name is the name of the Step.
+	Name string `json:"name"`
+	// Image is the image of the Step, referenced by tag.
+	Image string `json:"image"`
+	// Digest is the digest of the image, e.g. "sha256:...".
+	Digest string `json:"digest"`
+}
+
+// TaskRunSummary holds the fields used to summarize the progress of a
+// TaskRun, e.g. in the additional printer columns of `kubectl get`.
+type TaskRunSummary struct {
+	// Duration is the total execution time of the TaskRun, between its start
+	// and completion times. It is set once the TaskRun has completed.
+	// +optional
+	Duration string `json:"duration,omitempty"`
+	// CompletedSteps is the number of Steps which have terminated.
+	// +optional
+	CompletedSteps int `json:"completedSteps,omitempty"`
+	// TotalSteps is the number of Steps of the TaskRun.
+	// +optional
+	TotalSteps int `json:"totalSteps,omitempty"`
+	// FailedStep is the name of the first Step which failed, if any.
+	// +optional
+	FailedStep string `json:"failedStep,omitempty"`
+	// Resolver is the name of the resolver used to fetch the Task, if any.
+	// +optional
+	Resolver string `json:"resolver,omitempty"`
+	// Devices are the extended resources, like nvidia.com/gpu, requested by
+	// the Steps of the TaskRun, summed over the Steps.
+	// +optional
+	Devices corev1.ResourceList `json:"devices,omitempty"`
 }
 
 // TaskRunStepOverride is used to override the values of a Step in the corresponding Task.
@@ -403,6 +529,45 @@ type StepState struct {
 	Provenance            *Provenance           `json:"provenance,omitempty"`
 	Inputs                []TaskRunStepArtifact `json:"inputs,omitempty"`
 	Outputs               []TaskRunStepArtifact `json:"outputs,omitempty"`
+	// ImageSignature summarizes the cosign signature of the image of the Step,
+	// looked up when the Pod was created.
+	// +optional
+	ImageSignature *StepImageSignature `json:"imageSignature,omitempty"`
+	// OOMKilled reports the memory limit and the peak memory usage of the
+	// Step when its container was OOMKilled.
+	// +optional
+	OOMKilled *StepOOMKilled `json:"oomKilled,omitempty"`
+	// HermeticViolations is the number of attempts of the Step to reach the
+	// network, counted by the entrypoint when the Step was run hermetically.
+	// +optional
+	HermeticViolations *int32 `json:"hermeticViolations,omitempty"`
+}
+
+// StepOOMKilled reports the memory of a Step whose container was OOMKilled,
+// so that users know how much to raise its memory request or limit.
+type StepOOMKilled struct {
+	// MemoryLimit is the memory limit of the container which was hit.
+	// +optional
+	MemoryLimit *resource.Quantity `json:"memoryLimit,omitempty"`
+	// MemoryPeak is the peak memory usage of the container, read from its
+	// cgroup on a best-effort basis before it was killed.
+	// +optional
+	MemoryPeak *resource.Quantity `json:"memoryPeak,omitempty"`
+}
+
+// StepImageSignature summarizes the cosign signature of the image of a Step,
+// so that policy engines and Chains don't need to query the registry again.
+type StepImageSignature struct {
+	// Digest is the digest of the image the signature was looked up for,
+	// e.g. "sha256:...".
+	Digest string `json:"digest"`
+	// Signed is true when a cosign signature was found for the image.
+	Signed bool `json:"signed"`
+	// Subject is the identity which signed the image keyless, i.e. the email
+	// or URI of its signing certificate. It is empty for the images signed
+	// with a key.
+	// +optional
+	Subject string `json:"subject,omitempty"`
 }
 
 // SidecarState reports the results of running a sidecar in a Task.
@@ -528,6 +693,30 @@ func (tr *TaskRun) IsCancelled() bool {
 	return tr.Spec.Status == TaskRunSpecStatusCancelled
 }
 
+// IsSuperseded returns true if the TaskRun's spec status is set to Superseded state
+func (tr *TaskRun) IsSuperseded() bool {
+	return tr.Spec.Status == TaskRunSpecStatusSuperseded
+}
+
+// IsPaused returns true if the TaskRun's spec status is set to Pause state
+func (tr *TaskRun) IsPaused() bool {
+	return tr.Spec.Status == TaskRunSpecStatusPause
+}
+
+// PausedDuration returns the time the TaskRun spent paused, including the
+// time since it was paused if it is still paused.
+func (tr *TaskRun) PausedDuration(c clock.PassiveClock) time.Duration {
+	var paused time.Duration
+	for _, w := range tr.Status.PauseWindows {
+		if w.EndTime == nil {
+			paused += c.Since(w.StartTime.Time)
+		} else {
+			paused += w.EndTime.Sub(w.StartTime.Time)
+		}
+	}
+	return paused
+}
+
 // IsTaskRunResultVerified returns true if the TaskRun's results have been validated by spire.
 func (tr *TaskRun) IsTaskRunResultVerified() bool {
 	return tr.Status.GetCondition(apis.ConditionType(TaskRunConditionResultsVerified.String())).IsTrue()
@@ -543,7 +732,8 @@ func (tr *TaskRun) IsRetriable() bool {
 	return len(tr.Status.RetriesStatus) < tr.Spec.Retries
 }
 
-// HasTimedOut returns true if the TaskRun runtime is beyond the allowed timeout
+// HasTimedOut returns true if the TaskRun runtime, without the time it spent
+// paused, is beyond the allowed timeout
 func (tr *TaskRun) HasTimedOut(ctx context.Context, c clock.PassiveClock) bool {
 	if tr.Status.StartTime.IsZero() {
 		return false
@@ -553,7 +743,7 @@ func (tr *TaskRun) HasTimedOut(ctx context.Context, c clock.PassiveClock) bool {
 	if timeout == apisconfig.NoTimeoutDuration {
 		return false
 	}
-	runtime := c.Since(tr.Status.StartTime.Time)
+	runtime := c.Since(tr.Status.StartTime.Time) - tr.PausedDuration(c)
 	return runtime > timeout
 }
 
@@ -23,6 +23,7 @@ import (
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	pod "github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/apis/validate"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -46,6 +47,10 @@ func (tr *TaskRun) SupportedVerbs() []admissionregistrationv1.OperationType {
 // Validate taskrun
 func (tr *TaskRun) Validate(ctx context.Context) *apis.FieldError {
 	errs := validate.ObjectMetadata(tr.GetObjectMeta()).ViaField("metadata")
+	errs = errs.Also(v1.ValidateSourceEventAnnotations(ctx, tr.GetObjectMeta()).ViaField("metadata"))
+	if apis.IsInCreate(ctx) {
+		errs = errs.Also(validateNamespaceDefaults(ctx, tr.Namespace))
+	}
 	return errs.Also(tr.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
 }
 
@@ -97,8 +102,14 @@ func (ts *TaskRunSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	}
 
 	if ts.Status != "" {
-		if ts.Status != TaskRunSpecStatusCancelled {
-			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be %s", ts.Status, TaskRunSpecStatusCancelled), "status"))
+		switch ts.Status {
+		case TaskRunSpecStatusCancelled, TaskRunSpecStatusSuperseded:
+		case TaskRunSpecStatusPause, TaskRunSpecStatusResume:
+			if !config.FromContextOrDefaults(ctx).FeatureFlags.EnableTaskRunPause {
+				errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("%s requires \"%s\" feature flag to be \"true\"", ts.Status, config.EnableTaskRunPause), "status"))
+			}
+		default:
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be %s, %s, %s or %s", ts.Status, TaskRunSpecStatusCancelled, TaskRunSpecStatusSuperseded, TaskRunSpecStatusPause, TaskRunSpecStatusResume), "status"))
 		}
 	}
 	if ts.Status == "" {
@@ -115,6 +126,9 @@ func (ts *TaskRunSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	}
 	if ts.PodTemplate != nil {
 		errs = errs.Also(validatePodTemplateEnv(ctx, *ts.PodTemplate))
+		errs = errs.Also(validatePodTemplateSecurityProfiles(ctx, *ts.PodTemplate))
+		errs = errs.Also(validatePodTemplateTerminationGracePeriod(ctx, *ts.PodTemplate))
+		errs = errs.Also(ts.PodTemplate.ValidateHostAliasesAndDNS().ViaField("podTemplate"))
 	}
 	if ts.Resources != nil {
 		errs = errs.Also(apis.ErrDisallowedFields("resources"))
@@ -179,7 +193,10 @@ func (ts *TaskRunSpec) validateInlineParameters(ctx context.Context) (errs *apis
 	if ts.TaskSpec != nil && ts.TaskSpec.Steps != nil {
 		errs = errs.Also(ValidateParameterTypes(ctx, paramSpec))
 		errs = errs.Also(ValidateParameterVariables(ctx, ts.TaskSpec.Steps, paramSpec))
-		errs = errs.Also(ValidateUsageOfDeclaredParameters(ctx, ts.TaskSpec.Steps, paramSpec))
+		// The params imported with paramsFrom are only known, and validated, when the TaskRun is run.
+		if len(ts.TaskSpec.ParamsFrom) == 0 {
+			errs = errs.Also(ValidateUsageOfDeclaredParameters(ctx, ts.TaskSpec.Steps, paramSpec))
+		}
 	}
 	return errs
 }
@@ -197,6 +214,31 @@ func validatePodTemplateEnv(ctx context.Context, podTemplate pod.Template) (errs
 	return errs
 }
 
+// validatePodTemplateSecurityProfiles validates that the pod template doesn't
+// use Localhost profiles when they are forbidden.
+func validatePodTemplateSecurityProfiles(ctx context.Context, podTemplate pod.Template) (errs *apis.FieldError) {
+	errs = validateLocalhostProfiles(ctx, podTemplate.SeccompProfile, podTemplate.AppArmorProfile)
+	if sc := podTemplate.SecurityContext; sc != nil {
+		errs = errs.Also(validateLocalhostProfiles(ctx, sc.SeccompProfile, sc.AppArmorProfile).ViaField("securityContext"))
+	}
+	return errs.ViaField("podTemplate")
+}
+
+// validatePodTemplateTerminationGracePeriod validates that the termination
+// grace period of the pod template doesn't exceed the
+// max-termination-grace-period-seconds config.
+func validatePodTemplateTerminationGracePeriod(ctx context.Context, podTemplate pod.Template) *apis.FieldError {
+	seconds := podTemplate.TerminationGracePeriodSeconds
+	if seconds == nil {
+		return nil
+	}
+	maxSeconds := config.FromContextOrDefaults(ctx).Defaults.MaxTerminationGracePeriodSeconds
+	if *seconds < 0 || *seconds > maxSeconds {
+		return apis.ErrOutOfBoundsValue(*seconds, 0, maxSeconds, "terminationGracePeriodSeconds").ViaField("podTemplate")
+	}
+	return nil
+}
+
 func createParamSpecFromParam(p Param, paramSpecForValidation map[string]ParamSpec) map[string]ParamSpec {
 	value := p.Value
 	pSpec := ParamSpec{
@@ -350,3 +392,12 @@ func validateNoDuplicateNames(names []string, byIndex bool) (errs *apis.FieldErr
 	}
 	return errs
 }
+
+// validateNamespaceDefaults returns an error if the namespace of a new run
+// has a ConfigMap overriding the defaults which can't be used.
+func validateNamespaceDefaults(ctx context.Context, namespace string) *apis.FieldError {
+	if _, err := config.DefaultsForNamespace(ctx, namespace); err != nil {
+		return apis.ErrGeneric(err.Error(), "metadata.namespace")
+	}
+	return nil
+}
@@ -26,11 +26,13 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	cfgtesting "github.com/tektoncd/pipeline/pkg/apis/config/testing"
 	pod "github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
 	corev1resources "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 )
@@ -41,7 +43,14 @@ func EnableForbiddenEnv(ctx context.Context) context.Context {
 	return config.ToContext(ctx, c)
 }
 
+func forbidLocalhostProfiles(ctx context.Context) context.Context {
+	c := config.FromContextOrDefaults(ctx)
+	c.Defaults.ForbidLocalhostProfiles = true
+	return config.ToContext(ctx, c)
+}
+
 func TestTaskRun_Invalidate(t *testing.T) {
+	localhostProfile := "custom"
 	tests := []struct {
 		name    string
 		taskRun *v1beta1.TaskRun
@@ -73,6 +82,61 @@ func TestTaskRun_Invalidate(t *testing.T) {
 		},
 		wc:   EnableForbiddenEnv,
 		want: apis.ErrInvalidValue("PodTemplate cannot update a forbidden env: TEST_ENV", "spec.PodTemplate.Env"),
+	}, {
+		name: "invalid host aliases",
+		taskRun: &v1beta1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "tr"},
+			Spec: v1beta1.TaskRunSpec{
+				TaskRef: &v1beta1.TaskRef{Name: "task"},
+				PodTemplate: &pod.Template{
+					HostAliases: []corev1.HostAlias{{IP: "10.0.0.10", Hostnames: []string{"git.internal.example.com"}}, {IP: "10.0.0.300", Hostnames: []string{"registry"}}},
+					DNSConfig:   &corev1.PodDNSConfig{Nameservers: []string{"dns.internal.example.com"}},
+				},
+			},
+		},
+		want: apis.ErrInvalidValue("10.0.0.300 must be a valid IP address", "spec.podTemplate.hostAliases[1].ip").Also(
+			apis.ErrInvalidValue("dns.internal.example.com must be a valid IP address", "spec.podTemplate.dnsConfig.nameservers[0]")),
+	}, {
+		name: "termination grace period above the maximum",
+		taskRun: &v1beta1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "tr"},
+			Spec: v1beta1.TaskRunSpec{
+				TaskRef:     &v1beta1.TaskRef{Name: "task"},
+				PodTemplate: &pod.Template{TerminationGracePeriodSeconds: pointer.Int64(7200)},
+			},
+		},
+		want: apis.ErrOutOfBoundsValue(7200, 0, 3600, "spec.podTemplate.terminationGracePeriodSeconds"),
+	}, {
+		name: "Localhost profiles when forbidden",
+		taskRun: &v1beta1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "tr"},
+			Spec: v1beta1.TaskRunSpec{
+				TaskSpec: &v1beta1.TaskSpec{
+					Steps: []v1beta1.Step{{
+						Name:  "echo",
+						Image: "ubuntu",
+						SecurityContext: &corev1.SecurityContext{
+							SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeLocalhost, LocalhostProfile: &localhostProfile},
+						},
+					}},
+					Sidecars: []v1beta1.Sidecar{{
+						Name:  "sidecar",
+						Image: "ubuntu",
+						SecurityContext: &corev1.SecurityContext{
+							AppArmorProfile: &corev1.AppArmorProfile{Type: corev1.AppArmorProfileTypeLocalhost, LocalhostProfile: &localhostProfile},
+						},
+					}},
+				},
+				PodTemplate: &pod.Template{
+					AppArmorProfile: &corev1.AppArmorProfile{Type: corev1.AppArmorProfileTypeLocalhost, LocalhostProfile: &localhostProfile},
+				},
+			},
+		},
+		wc: forbidLocalhostProfiles,
+		want: &apis.FieldError{
+			Message: "invalid value: Localhost profiles are forbidden by the forbid-localhost-profiles config",
+			Paths:   []string{"spec.podTemplate.appArmorProfile.type", "spec.taskSpec.sidecars[0].securityContext.appArmorProfile.type", "spec.taskSpec.steps[0].securityContext.seccompProfile.type"},
+		},
 	}, {
 		name: "propagating params not provided but used by step",
 		taskRun: &v1beta1.TaskRun{
@@ -160,6 +224,28 @@ func TestTaskRun_Invalidate(t *testing.T) {
 		},
 		want: &apis.FieldError{Message: "must not set the field(s)", Paths: []string{"spec.taskRef.bundle"}},
 		wc:   apis.WithinCreate,
+	}, {
+		name: "source event annotations removed on update",
+		taskRun: &v1beta1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "taskrunname",
+			},
+			Spec: v1beta1.TaskRunSpec{
+				TaskRef: &v1beta1.TaskRef{Name: "taskrefname"},
+			},
+		},
+		want: apis.ErrInvalidValue("tekton.dev/source-url can't be changed or removed once set", "metadata.annotations"),
+		wc: func(ctx context.Context) context.Context {
+			return apis.WithinUpdate(ctx, &v1beta1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "taskrunname",
+					Annotations: map[string]string{v1.SourceURLAnnotationKey: "https://github.com/tektoncd/pipeline"},
+				},
+				Spec: v1beta1.TaskRunSpec{
+					TaskRef: &v1beta1.TaskRef{Name: "taskrefname"},
+				},
+			})
+		},
 	}}
 	for _, ts := range tests {
 		t.Run(ts.name, func(t *testing.T) {
@@ -572,7 +658,7 @@ func TestTaskRunSpec_Invalidate(t *testing.T) {
 			},
 			Status: "TaskRunCancell",
 		},
-		wantErr: apis.ErrInvalidValue("TaskRunCancell should be TaskRunCancelled", "status"),
+		wantErr: apis.ErrInvalidValue("TaskRunCancell should be TaskRunCancelled, TaskRunSuperseded, TaskRunPause or TaskRunResume", "status"),
 	}, {
 		name: "incorrectly set statusMesage",
 		spec: v1beta1.TaskRunSpec{
@@ -0,0 +1,65 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// PipelineRunTasksFilter selects the PipelineTasks of the Pipeline that a
+// PipelineRun runs. The PipelineTasks which aren't selected are skipped.
+type PipelineRunTasksFilter struct {
+	// Include selects the PipelineTasks to run. Each entry is either the name
+	// of a PipelineTask or a label selector matching the labels of the
+	// metadata of the taskSpec of the PipelineTasks.
+	// +listType=atomic
+	Include []string `json:"include"`
+	// WithDependencies also runs the PipelineTasks which the selected ones
+	// depend on, through runAfter or the results they consume.
+	// +optional
+	WithDependencies bool `json:"withDependencies,omitempty"`
+	// ExcludeFinally are the names of the finally tasks not to run. The
+	// finally tasks run otherwise.
+	// +optional
+	// +listType=atomic
+	ExcludeFinally []string `json:"excludeFinally,omitempty"`
+	// ResultOverrides provide the results of the PipelineTasks which are
+	// filtered out and whose results are consumed by the ones which run.
+	// +optional
+	// +listType=atomic
+	ResultOverrides []PipelineTaskResultOverride `json:"resultOverrides,omitempty"`
+}
+
+// PipelineTaskResultOverride provides the value of a result of a PipelineTask
+// which doesn't run.
+type PipelineTaskResultOverride struct {
+	// PipelineTask is the name of the PipelineTask producing the result.
+	PipelineTask string `json:"pipelineTask"`
+	// Name is the name of the result.
+	Name string `json:"name"`
+	// Value is the value of the result.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	Value ResultValue `json:"value"`
+}
+
+// IsPipelineTaskName returns true if an entry of the include list of a
+// PipelineRunTasksFilter is the name of a PipelineTask, rather than a label
+// selector.
+func IsPipelineTaskName(entry string) bool {
+	return len(validation.IsDNS1123Label(entry)) == 0
+}
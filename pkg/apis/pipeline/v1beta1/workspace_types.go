@@ -88,6 +88,15 @@ type WorkspaceBinding struct {
 	CSI *corev1.CSIVolumeSource `json:"csi,omitempty"`
 }
 
+const (
+	// SecretsStoreCSIDriver is the name of the Secrets Store CSI driver, which
+	// mounts the secrets of an external store described by a SecretProviderClass.
+	SecretsStoreCSIDriver = "secrets-store.csi.k8s.io"
+	// SecretProviderClassAttribute is the volume attribute naming the
+	// SecretProviderClass used by the Secrets Store CSI driver.
+	SecretProviderClassAttribute = "secretProviderClass"
+)
+
 // WorkspacePipelineDeclaration creates a named slot in a Pipeline that a PipelineRun
 // is expected to populate with a workspace binding.
 //
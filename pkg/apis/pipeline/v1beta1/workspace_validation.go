@@ -18,7 +18,10 @@ package v1beta1
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"knative.dev/pkg/apis"
 )
@@ -72,13 +75,47 @@ func (b *WorkspaceBinding) Validate(ctx context.Context) (errs *apis.FieldError)
 	}
 
 	// For a CSI to work, you must provide and have installed the driver to use.
-	if b.CSI != nil && b.CSI.Driver == "" {
-		return apis.ErrMissingField("csi.driver")
+	if b.CSI != nil {
+		return validateCSI(b.CSI).ViaField("csi")
 	}
 
 	return nil
 }
 
+// validateCSI validates a CSI workspace binding. The driver to use must be
+// provided, and must be installed on the cluster. The Secrets Store CSI driver
+// only supports read-only volumes and needs the SecretProviderClass to mount.
+func validateCSI(csi *corev1.CSIVolumeSource) *apis.FieldError {
+	if csi.Driver == "" {
+		return apis.ErrMissingField("driver")
+	}
+	if csi.NodePublishSecretRef != nil && csi.NodePublishSecretRef.Name == "" {
+		return apis.ErrMissingField("nodePublishSecretRef.name")
+	}
+	if csi.Driver == SecretsStoreCSIDriver {
+		if csi.ReadOnly == nil || !*csi.ReadOnly {
+			return apis.ErrGeneric(fmt.Sprintf("readOnly must be true for the %s driver", SecretsStoreCSIDriver), "readOnly")
+		}
+		if csi.VolumeAttributes[SecretProviderClassAttribute] == "" {
+			return apis.ErrMissingField("volumeAttributes." + SecretProviderClassAttribute)
+		}
+	}
+	return nil
+}
+
+// ValidateSubPath makes sure the subPath of the binding doesn't have any ".."
+// segments, so that it can't escape the workspace of the Pipeline. The subPath
+// can reference the results of other PipelineTasks, so it is validated again
+// once they are substituted.
+func (b *WorkspacePipelineTaskBinding) ValidateSubPath() *apis.FieldError {
+	for _, segment := range strings.Split(b.SubPath, "/") {
+		if segment == ".." {
+			return apis.ErrInvalidValue(fmt.Sprintf("%q must not contain \"..\" path segments", b.SubPath), "subPath")
+		}
+	}
+	return nil
+}
+
 // numSources returns the total number of volume sources that this WorkspaceBinding
 // has been configured with.
 func (b *WorkspaceBinding) numSources() int {
@@ -27,6 +27,7 @@ import (
 )
 
 func TestWorkspaceBindingValidateValid(t *testing.T) {
+	readOnly := true
 	for _, tc := range []struct {
 		name    string
 		binding *v1beta1.WorkspaceBinding
@@ -109,6 +110,20 @@ func TestWorkspaceBindingValidateValid(t *testing.T) {
 				Driver: "my-csi",
 			},
 		},
+	}, {
+		name: "Valid secrets-store csi",
+		binding: &v1beta1.WorkspaceBinding{
+			Name: "beth",
+			CSI: &corev1.CSIVolumeSource{
+				Driver:   "secrets-store.csi.k8s.io",
+				ReadOnly: &readOnly,
+				VolumeAttributes: map[string]string{
+					"secretProviderClass":          "vault-database",
+					"csi.storage.k8s.io/ephemeral": "true",
+				},
+				NodePublishSecretRef: &corev1.LocalObjectReference{Name: "secrets-store-creds"},
+			},
+		},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			ctx := t.Context()
@@ -123,6 +138,8 @@ func TestWorkspaceBindingValidateValid(t *testing.T) {
 }
 
 func TestWorkspaceBindingValidateInvalid(t *testing.T) {
+	readOnly := true
+	notReadOnly := false
 	for _, tc := range []struct {
 		name    string
 		binding *v1beta1.WorkspaceBinding
@@ -176,6 +193,43 @@ func TestWorkspaceBindingValidateInvalid(t *testing.T) {
 				Driver: "",
 			},
 		},
+	}, {
+		name: "Provide csi with a nodePublishSecretRef without a name",
+		binding: &v1beta1.WorkspaceBinding{
+			Name: "beth",
+			CSI: &corev1.CSIVolumeSource{
+				Driver:               "my-csi",
+				NodePublishSecretRef: &corev1.LocalObjectReference{},
+			},
+		},
+	}, {
+		name: "Provide secrets-store csi without readOnly",
+		binding: &v1beta1.WorkspaceBinding{
+			Name: "beth",
+			CSI: &corev1.CSIVolumeSource{
+				Driver:           "secrets-store.csi.k8s.io",
+				VolumeAttributes: map[string]string{"secretProviderClass": "vault-database"},
+			},
+		},
+	}, {
+		name: "Provide secrets-store csi with readOnly false",
+		binding: &v1beta1.WorkspaceBinding{
+			Name: "beth",
+			CSI: &corev1.CSIVolumeSource{
+				Driver:           "secrets-store.csi.k8s.io",
+				ReadOnly:         &notReadOnly,
+				VolumeAttributes: map[string]string{"secretProviderClass": "vault-database"},
+			},
+		},
+	}, {
+		name: "Provide secrets-store csi without a secretProviderClass",
+		binding: &v1beta1.WorkspaceBinding{
+			Name: "beth",
+			CSI: &corev1.CSIVolumeSource{
+				Driver:   "secrets-store.csi.k8s.io",
+				ReadOnly: &readOnly,
+			},
+		},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			ctx := t.Context()
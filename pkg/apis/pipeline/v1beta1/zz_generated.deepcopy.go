@@ -356,6 +356,21 @@ func (in *CustomRunSpec) DeepCopyInto(out *CustomRunSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PodTemplate != nil {
+		in, out := &in.PodTemplate, &out.PodTemplate
+		*out = new(pod.Template)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ComputeResources != nil {
+		in, out := &in.ComputeResources, &out.ComputeResources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Timeouts != nil {
+		in, out := &in.Timeouts, &out.Timeouts
+		*out = new(TimeoutFields)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -408,6 +423,53 @@ func (in *EmbeddedTask) DeepCopy() *EmbeddedTask {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EvaluatedWhenExpression) DeepCopyInto(out *EvaluatedWhenExpression) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvaluatedWhenExpression.
+func (in *EvaluatedWhenExpression) DeepCopy() *EvaluatedWhenExpression {
+	if in == nil {
+		return nil
+	}
+	out := new(EvaluatedWhenExpression)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExpandedParameterSet) DeepCopyInto(out *ExpandedParameterSet) {
+	*out = *in
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExpandedParameterSet.
+func (in *ExpandedParameterSet) DeepCopy() *ExpandedParameterSet {
+	if in == nil {
+		return nil
+	}
+	out := new(ExpandedParameterSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IncludeParams) DeepCopyInto(out *IncludeParams) {
 	*out = *in
@@ -520,6 +582,27 @@ func (in *Matrix) DeepCopy() *Matrix {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObservedConfig) DeepCopyInto(out *ObservedConfig) {
+	*out = *in
+	if in.DefaultTimeout != nil {
+		in, out := &in.DefaultTimeout, &out.DefaultTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObservedConfig.
+func (in *ObservedConfig) DeepCopy() *ObservedConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ObservedConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Param) DeepCopyInto(out *Param) {
 	*out = *in
@@ -620,6 +703,22 @@ func (in *ParamValue) DeepCopy() *ParamValue {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ParameterSetRef) DeepCopyInto(out *ParameterSetRef) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParameterSetRef.
+func (in *ParameterSetRef) DeepCopy() *ParameterSetRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ParameterSetRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in Params) DeepCopyInto(out *Params) {
 	{
@@ -642,6 +741,43 @@ func (in Params) DeepCopy() Params {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PauseWindow) DeepCopyInto(out *PauseWindow) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PauseWindow.
+func (in *PauseWindow) DeepCopy() *PauseWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(PauseWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PinnedStepImage) DeepCopyInto(out *PinnedStepImage) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PinnedStepImage.
+func (in *PinnedStepImage) DeepCopy() *PinnedStepImage {
+	if in == nil {
+		return nil
+	}
+	out := new(PinnedStepImage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Pipeline) DeepCopyInto(out *Pipeline) {
 	*out = *in
@@ -781,6 +917,11 @@ func (in *PipelineResourceRef) DeepCopy() *PipelineResourceRef {
 func (in *PipelineResult) DeepCopyInto(out *PipelineResult) {
 	*out = *in
 	in.Value.DeepCopyInto(&out.Value)
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(ParamValue)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -956,6 +1097,11 @@ func (in *PipelineRunSpec) DeepCopyInto(out *PipelineRunSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.TasksFilter != nil {
+		in, out := &in.TasksFilter, &out.TasksFilter
+		*out = new(PipelineRunTasksFilter)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1040,6 +1186,13 @@ func (in *PipelineRunStatusFields) DeepCopyInto(out *PipelineRunStatusFields) {
 		*out = new(PipelineSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ExpandedParameterSets != nil {
+		in, out := &in.ExpandedParameterSets, &out.ExpandedParameterSets
+		*out = make([]ExpandedParameterSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.SkippedTasks != nil {
 		in, out := &in.SkippedTasks, &out.SkippedTasks
 		*out = make([]SkippedTask, len(*in))
@@ -1070,6 +1223,18 @@ func (in *PipelineRunStatusFields) DeepCopyInto(out *PipelineRunStatusFields) {
 			(*out)[key] = val
 		}
 	}
+	if in.Summary != nil {
+		in, out := &in.Summary, &out.Summary
+		*out = new(PipelineRunSummary)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PeakResourceRequests != nil {
+		in, out := &in.PeakResourceRequests, &out.PeakResourceRequests
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
 	return
 }
 
@@ -1083,6 +1248,34 @@ func (in *PipelineRunStatusFields) DeepCopy() *PipelineRunStatusFields {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineRunSummary) DeepCopyInto(out *PipelineRunSummary) {
+	*out = *in
+	if in.FailedTasks != nil {
+		in, out := &in.FailedTasks, &out.FailedTasks
+		*out = make([]PipelineTaskFailure, len(*in))
+		copy(*out, *in)
+	}
+	if in.QueuedTasks != nil {
+		in, out := &in.QueuedTasks, &out.QueuedTasks
+		*out = make([]pipelinev1.PipelineTaskQueueTime, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunSummary.
+func (in *PipelineRunSummary) DeepCopy() *PipelineRunSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineRunSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PipelineRunTaskRunStatus) DeepCopyInto(out *PipelineRunTaskRunStatus) {
 	*out = *in
@@ -1111,6 +1304,39 @@ func (in *PipelineRunTaskRunStatus) DeepCopy() *PipelineRunTaskRunStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineRunTasksFilter) DeepCopyInto(out *PipelineRunTasksFilter) {
+	*out = *in
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeFinally != nil {
+		in, out := &in.ExcludeFinally, &out.ExcludeFinally
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResultOverrides != nil {
+		in, out := &in.ResultOverrides, &out.ResultOverrides
+		*out = make([]PipelineTaskResultOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunTasksFilter.
+func (in *PipelineRunTasksFilter) DeepCopy() *PipelineRunTasksFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineRunTasksFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PipelineSpec) DeepCopyInto(out *PipelineSpec) {
 	*out = *in
@@ -1133,6 +1359,11 @@ func (in *PipelineSpec) DeepCopyInto(out *PipelineSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ParamsFrom != nil {
+		in, out := &in.ParamsFrom, &out.ParamsFrom
+		*out = make([]ParameterSetRef, len(*in))
+		copy(*out, *in)
+	}
 	if in.Workspaces != nil {
 		in, out := &in.Workspaces, &out.Workspaces
 		*out = make([]PipelineWorkspaceDeclaration, len(*in))
@@ -1240,6 +1471,22 @@ func (in *PipelineTask) DeepCopy() *PipelineTask {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineTaskFailure) DeepCopyInto(out *PipelineTaskFailure) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskFailure.
+func (in *PipelineTaskFailure) DeepCopy() *PipelineTaskFailure {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineTaskFailure)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PipelineTaskInputResource) DeepCopyInto(out *PipelineTaskInputResource) {
 	*out = *in
@@ -1373,6 +1620,23 @@ func (in *PipelineTaskResources) DeepCopy() *PipelineTaskResources {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineTaskResultOverride) DeepCopyInto(out *PipelineTaskResultOverride) {
+	*out = *in
+	in.Value.DeepCopyInto(&out.Value)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskResultOverride.
+func (in *PipelineTaskResultOverride) DeepCopy() *PipelineTaskResultOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineTaskResultOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PipelineTaskRun) DeepCopyInto(out *PipelineTaskRun) {
 	*out = *in
@@ -1484,6 +1748,11 @@ func (in *Provenance) DeepCopyInto(out *Provenance) {
 		*out = new(config.FeatureFlags)
 		**out = **in
 	}
+	if in.SourceEvent != nil {
+		in, out := &in.SourceEvent, &out.SourceEvent
+		*out = new(SourceEvent)
+		**out = **in
+	}
 	return
 }
 
@@ -1683,6 +1952,11 @@ func (in *Sidecar) DeepCopyInto(out *Sidecar) {
 		*out = new(corev1.ContainerRestartPolicy)
 		**out = **in
 	}
+	if in.StopAfterSteps != nil {
+		in, out := &in.StopAfterSteps, &out.StopAfterSteps
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1723,6 +1997,13 @@ func (in *SkippedTask) DeepCopyInto(out *SkippedTask) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.EvaluatedWhenExpressions != nil {
+		in, out := &in.EvaluatedWhenExpressions, &out.EvaluatedWhenExpressions
+		*out = make([]EvaluatedWhenExpression, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1736,6 +2017,22 @@ func (in *SkippedTask) DeepCopy() *SkippedTask {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SourceEvent) DeepCopyInto(out *SourceEvent) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceEvent.
+func (in *SourceEvent) DeepCopy() *SourceEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(SourceEvent)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Step) DeepCopyInto(out *Step) {
 	*out = *in
@@ -1826,6 +2123,16 @@ func (in *Step) DeepCopyInto(out *Step) {
 		*out = new(StepOutputConfig)
 		**out = **in
 	}
+	if in.StdinFrom != nil {
+		in, out := &in.StdinFrom, &out.StdinFrom
+		*out = new(StepStdinSource)
+		**out = **in
+	}
+	if in.ScriptFrom != nil {
+		in, out := &in.ScriptFrom, &out.ScriptFrom
+		*out = new(StepScriptSource)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Ref != nil {
 		in, out := &in.Ref, &out.Ref
 		*out = new(Ref)
@@ -1852,6 +2159,11 @@ func (in *Step) DeepCopyInto(out *Step) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DependsOnSidecars != nil {
+		in, out := &in.DependsOnSidecars, &out.DependsOnSidecars
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1984,6 +2296,48 @@ func (in *StepActionSpec) DeepCopy() *StepActionSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StepImageSignature) DeepCopyInto(out *StepImageSignature) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepImageSignature.
+func (in *StepImageSignature) DeepCopy() *StepImageSignature {
+	if in == nil {
+		return nil
+	}
+	out := new(StepImageSignature)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StepOOMKilled) DeepCopyInto(out *StepOOMKilled) {
+	*out = *in
+	if in.MemoryLimit != nil {
+		in, out := &in.MemoryLimit, &out.MemoryLimit
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MemoryPeak != nil {
+		in, out := &in.MemoryPeak, &out.MemoryPeak
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepOOMKilled.
+func (in *StepOOMKilled) DeepCopy() *StepOOMKilled {
+	if in == nil {
+		return nil
+	}
+	out := new(StepOOMKilled)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StepOutputConfig) DeepCopyInto(out *StepOutputConfig) {
 	*out = *in
@@ -2000,6 +2354,32 @@ func (in *StepOutputConfig) DeepCopy() *StepOutputConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StepScriptSource) DeepCopyInto(out *StepScriptSource) {
+	*out = *in
+	if in.Workspace != nil {
+		in, out := &in.Workspace, &out.Workspace
+		*out = new(WorkspaceFileSelector)
+		**out = **in
+	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepScriptSource.
+func (in *StepScriptSource) DeepCopy() *StepScriptSource {
+	if in == nil {
+		return nil
+	}
+	out := new(StepScriptSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StepState) DeepCopyInto(out *StepState) {
 	*out = *in
@@ -2030,6 +2410,21 @@ func (in *StepState) DeepCopyInto(out *StepState) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ImageSignature != nil {
+		in, out := &in.ImageSignature, &out.ImageSignature
+		*out = new(StepImageSignature)
+		**out = **in
+	}
+	if in.OOMKilled != nil {
+		in, out := &in.OOMKilled, &out.OOMKilled
+		*out = new(StepOOMKilled)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HermeticViolations != nil {
+		in, out := &in.HermeticViolations, &out.HermeticViolations
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
@@ -2043,6 +2438,22 @@ func (in *StepState) DeepCopy() *StepState {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StepStdinSource) DeepCopyInto(out *StepStdinSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepStdinSource.
+func (in *StepStdinSource) DeepCopy() *StepStdinSource {
+	if in == nil {
+		return nil
+	}
+	out := new(StepStdinSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StepTemplate) DeepCopyInto(out *StepTemplate) {
 	*out = *in
@@ -2624,6 +3035,14 @@ func (in *TaskRunStatusFields) DeepCopyInto(out *TaskRunStatusFields) {
 		in, out := &in.CompletionTime, &out.CompletionTime
 		*out = (*in).DeepCopy()
 	}
+	if in.PodCreationTime != nil {
+		in, out := &in.PodCreationTime, &out.PodCreationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PodStartTime != nil {
+		in, out := &in.PodStartTime, &out.PodStartTime
+		*out = (*in).DeepCopy()
+	}
 	if in.Steps != nil {
 		in, out := &in.Steps, &out.Steps
 		*out = make([]StepState, len(*in))
@@ -2669,6 +3088,13 @@ func (in *TaskRunStatusFields) DeepCopyInto(out *TaskRunStatusFields) {
 		*out = new(TaskSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ExpandedParameterSets != nil {
+		in, out := &in.ExpandedParameterSets, &out.ExpandedParameterSets
+		*out = make([]ExpandedParameterSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Provenance != nil {
 		in, out := &in.Provenance, &out.Provenance
 		*out = new(Provenance)
@@ -2681,6 +3107,28 @@ func (in *TaskRunStatusFields) DeepCopyInto(out *TaskRunStatusFields) {
 			(*out)[key] = val
 		}
 	}
+	if in.Summary != nil {
+		in, out := &in.Summary, &out.Summary
+		*out = new(TaskRunSummary)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PinnedStepImages != nil {
+		in, out := &in.PinnedStepImages, &out.PinnedStepImages
+		*out = make([]PinnedStepImage, len(*in))
+		copy(*out, *in)
+	}
+	if in.PauseWindows != nil {
+		in, out := &in.PauseWindows, &out.PauseWindows
+		*out = make([]PauseWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ObservedConfig != nil {
+		in, out := &in.ObservedConfig, &out.ObservedConfig
+		*out = new(ObservedConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2711,6 +3159,29 @@ func (in *TaskRunStepOverride) DeepCopy() *TaskRunStepOverride {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskRunSummary) DeepCopyInto(out *TaskRunSummary) {
+	*out = *in
+	if in.Devices != nil {
+		in, out := &in.Devices, &out.Devices
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskRunSummary.
+func (in *TaskRunSummary) DeepCopy() *TaskRunSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskRunSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaskSpec) DeepCopyInto(out *TaskSpec) {
 	*out = *in
@@ -2726,6 +3197,11 @@ func (in *TaskSpec) DeepCopyInto(out *TaskSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ParamsFrom != nil {
+		in, out := &in.ParamsFrom, &out.ParamsFrom
+		*out = make([]ParameterSetRef, len(*in))
+		copy(*out, *in)
+	}
 	if in.Steps != nil {
 		in, out := &in.Steps, &out.Steps
 		*out = make([]Step, len(*in))
@@ -2940,6 +3416,22 @@ func (in *WorkspaceDeclaration) DeepCopy() *WorkspaceDeclaration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceFileSelector) DeepCopyInto(out *WorkspaceFileSelector) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceFileSelector.
+func (in *WorkspaceFileSelector) DeepCopy() *WorkspaceFileSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspaceFileSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkspacePipelineTaskBinding) DeepCopyInto(out *WorkspacePipelineTaskBinding) {
 	*out = *in
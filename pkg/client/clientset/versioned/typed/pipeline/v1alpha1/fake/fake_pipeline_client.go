@@ -28,6 +28,10 @@ type FakeTektonV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeTektonV1alpha1) ParameterSets(namespace string) v1alpha1.ParameterSetInterface {
+	return newFakeParameterSets(c, namespace)
+}
+
 func (c *FakeTektonV1alpha1) Runs(namespace string) v1alpha1.RunInterface {
 	return newFakeRuns(c, namespace)
 }
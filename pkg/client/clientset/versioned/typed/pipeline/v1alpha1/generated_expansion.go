@@ -18,6 +18,8 @@ limitations under the License.
 
 package v1alpha1
 
+type ParameterSetExpansion interface{}
+
 type RunExpansion interface{}
 
 type StepActionExpansion interface{}
@@ -24,6 +24,8 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// ParameterSets returns a ParameterSetInformer.
+	ParameterSets() ParameterSetInformer
 	// Runs returns a RunInformer.
 	Runs() RunInformer
 	// StepActions returns a StepActionInformer.
@@ -43,6 +45,11 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// ParameterSets returns a ParameterSetInformer.
+func (v *version) ParameterSets() ParameterSetInformer {
+	return &parameterSetInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // Runs returns a RunInformer.
 func (v *version) Runs() RunInformer {
 	return &runInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -18,6 +18,14 @@ limitations under the License.
 
 package v1alpha1
 
+// ParameterSetListerExpansion allows custom methods to be added to
+// ParameterSetLister.
+type ParameterSetListerExpansion interface{}
+
+// ParameterSetNamespaceListerExpansion allows custom methods to be added to
+// ParameterSetNamespaceLister.
+type ParameterSetNamespaceListerExpansion interface{}
+
 // RunListerExpansion allows custom methods to be added to
 // RunLister.
 type RunListerExpansion interface{}
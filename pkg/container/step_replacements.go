@@ -31,6 +31,10 @@ func ApplyStepReplacements(step *v1.Step, stringReplacements map[string]string,
 	if step.StderrConfig != nil {
 		step.StderrConfig.Path = substitution.ApplyReplacements(step.StderrConfig.Path, stringReplacements)
 	}
+	if step.StdinFrom != nil {
+		step.StdinFrom.Value = substitution.ApplyReplacements(step.StdinFrom.Value, stringReplacements)
+		step.StdinFrom.Path = substitution.ApplyReplacements(step.StdinFrom.Path, stringReplacements)
+	}
 	step.When = step.When.ReplaceVariables(stringReplacements, arrayReplacements)
 	applyStepReplacements(step, stringReplacements, arrayReplacements)
 }
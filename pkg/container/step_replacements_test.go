@@ -91,6 +91,10 @@ func TestApplyStepReplacements(t *testing.T) {
 		StderrConfig: &v1.StepOutputConfig{
 			Path: "$(workspaces.data.path)/stderr.txt",
 		},
+		StdinFrom: &v1.StepStdinSource{
+			Value: "$(replace.me)",
+			Path:  "$(workspaces.data.path)/stdin.txt",
+		},
 	}
 
 	expected := v1.Step{
@@ -148,6 +152,10 @@ func TestApplyStepReplacements(t *testing.T) {
 		StderrConfig: &v1.StepOutputConfig{
 			Path: "/workspace/data/stderr.txt",
 		},
+		StdinFrom: &v1.StepStdinSource{
+			Value: "replaced!",
+			Path:  "/workspace/data/stdin.txt",
+		},
 	}
 	container.ApplyStepReplacements(&s, replacements, arrayReplacements)
 	if d := cmp.Diff(s, expected); d != "" {
@@ -28,6 +28,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"syscall"
@@ -125,6 +126,10 @@ func IsContextCanceledError(err error) bool {
 type Entrypointer struct {
 	// Command is the original specified command and args.
 	Command []string
+	// ScriptFile is the path of a file holding the script of the step, e.g. a
+	// file of a workspace. If set, the script is executed with Command as its
+	// args.
+	ScriptFile string
 
 	// WaitFiles is the set of files to wait for. If empty, execution
 	// begins immediately.
@@ -132,6 +137,10 @@ type Entrypointer struct {
 	// WaitFileContent indicates the WaitFile should have non-zero size
 	// before continuing with execution.
 	WaitFileContent bool
+	// ReadyFiles is the set of files to wait for to have non-zero size once
+	// the WaitFiles are found, e.g. the readiness of the sidecars the step
+	// depends on.
+	ReadyFiles []string
 	// PostFile is the file to write when complete. If not specified, no
 	// file is written.
 	PostFile string
@@ -174,8 +183,20 @@ type Entrypointer struct {
 
 	// ArtifactsDirectory is the directory to find artifacts, defaults to pipeline.ArtifactsDir
 	ArtifactsDirectory string
+
+	// ReportHermeticViolations writes the number of network failures of the
+	// step to the termination message, if the Runner counted them.
+	ReportHermeticViolations bool
+
+	// PauseFile is the file which has content while the TaskRun is paused.
+	// If specified, the step doesn't start until it is empty.
+	PauseFile string
 }
 
+// pausePollingInterval is the interval at which the pause file is read while
+// the TaskRun is paused.
+var pausePollingInterval = time.Second
+
 // Waiter encapsulates waiting for files to exist.
 type Waiter interface {
 	// Wait blocks until the specified file exists or the context is done.
@@ -209,8 +230,9 @@ func (e Entrypointer) Go() error {
 	if err := os.MkdirAll(filepath.Join(e.StepMetadataDir, "artifacts"), os.ModePerm); err != nil {
 		return err
 	}
-	for _, f := range e.WaitFiles {
-		if err := e.Waiter.Wait(context.Background(), f, e.WaitFileContent, e.BreakpointOnFailure); err != nil {
+	for i, f := range append(slices.Clip(e.WaitFiles), e.ReadyFiles...) {
+		expectContent := e.WaitFileContent || i >= len(e.WaitFiles)
+		if err := e.Waiter.Wait(context.Background(), f, expectContent, e.BreakpointOnFailure); err != nil {
 			// An error happened while waiting, so we bail
 			// *but* we write postfile to make next steps bail too.
 			// In case of breakpoint on failure do not write post file.
@@ -231,6 +253,10 @@ func (e Entrypointer) Go() error {
 		}
 	}
 
+	if e.PauseFile != "" {
+		e.waitWhilePaused()
+	}
+
 	var err error
 	if e.DebugBeforeStep {
 		err = e.waitBeforeStepDebug()
@@ -267,10 +293,19 @@ func (e Entrypointer) Go() error {
 			}
 		}()
 		allowExec, err1 := e.allowExec()
+		stopRecordingMemoryPeak := func() {}
+		if err1 == nil && allowExec {
+			stopRecordingMemoryPeak = e.recordMemoryPeak()
+		}
 
 		switch {
 		case err1 != nil:
 			err = err1
+		case allowExec && e.ScriptFile != "":
+			var script string
+			if script, err = e.placeScriptFile(); err == nil {
+				err = e.Runner.Run(ctx, append([]string{script}, e.Command...)...)
+			}
 		case allowExec:
 			err = e.Runner.Run(ctx, e.Command...)
 		default:
@@ -280,8 +315,14 @@ func (e Entrypointer) Go() error {
 			e.WriteExitCodeFile(e.StepMetadataDir, "0")
 			return nil
 		}
+		stopRecordingMemoryPeak()
 	}
 
+	if e.ReportHermeticViolations {
+		e.appendHermeticViolations(&output)
+	}
+	e.appendMemoryPeak(&output, err)
+
 	var ee *exec.ExitError
 	switch {
 	case err != nil && errors.Is(err, errDebugBeforeStep):
@@ -510,6 +551,22 @@ func (e Entrypointer) waitingCancellation(ctx context.Context, cancel context.Ca
 	return nil
 }
 
+// waitWhilePaused blocks while the pause file has content, i.e. while the
+// TaskRun is paused, so that the step only starts once it is resumed. The
+// steps which started before the TaskRun was paused run to completion.
+func (e Entrypointer) waitWhilePaused() {
+	for logged := false; ; logged = true {
+		content, err := os.ReadFile(e.PauseFile)
+		if err != nil || len(strings.TrimSpace(string(content))) == 0 {
+			return
+		}
+		if !logged {
+			slog.Info("TaskRun is paused, waiting for it to be resumed before starting the step")
+		}
+		time.Sleep(pausePollingInterval)
+	}
+}
+
 // CheckForBreakpointOnFailure if step up breakpoint on failure
 // waiting breakpointExitPostFile to be written
 func (e Entrypointer) CheckForBreakpointOnFailure() {
@@ -829,6 +829,73 @@ func TestEntrypointerStopOnCancel(t *testing.T) {
 	}
 }
 
+func TestEntrypointerWaitWhilePaused(t *testing.T) {
+	defer func(interval time.Duration) { pausePollingInterval = interval }(pausePollingInterval)
+	pausePollingInterval = 10 * time.Millisecond
+
+	pauseFile := filepath.Join(t.TempDir(), "pause")
+	if err := os.WriteFile(pauseFile, []byte("PAUSE"), 0o644); err != nil {
+		t.Fatalf("couldn't write the pause file: %v", err)
+	}
+	terminationFile, err := os.CreateTemp(t.TempDir(), "termination")
+	if err != nil {
+		t.Fatalf("unexpected error creating temporary termination file: %v", err)
+	}
+
+	fr := &fakeNotifyRunner{ran: make(chan struct{})}
+	errs := make(chan error, 1)
+	go func() {
+		errs <- Entrypointer{
+			Command:         []string{"echo", "some", "args"},
+			Waiter:          &fakeWaiter{},
+			Runner:          fr,
+			PostWriter:      &fakePostWriter{},
+			TerminationPath: terminationFile.Name(),
+			PauseFile:       pauseFile,
+		}.Go()
+	}()
+
+	select {
+	case <-fr.ran:
+		t.Fatal("expected the step not to start while the TaskRun is paused")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// The TaskRun is resumed: the downward API empties the pause file.
+	if err := os.WriteFile(pauseFile, nil, 0o644); err != nil {
+		t.Fatalf("couldn't empty the pause file: %v", err)
+	}
+	select {
+	case <-fr.ran:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the step to start once the TaskRun is resumed")
+	}
+	if err := <-errs; err != nil {
+		t.Errorf("Entrypointer failed: %v", err)
+	}
+}
+
+func TestEntrypointerWaitWhilePaused_NoPauseFile(t *testing.T) {
+	terminationFile, err := os.CreateTemp(t.TempDir(), "termination")
+	if err != nil {
+		t.Fatalf("unexpected error creating temporary termination file: %v", err)
+	}
+	fr := &fakeRunner{}
+	if err := (Entrypointer{
+		Command:         []string{"echo", "some", "args"},
+		Waiter:          &fakeWaiter{},
+		Runner:          fr,
+		PostWriter:      &fakePostWriter{},
+		TerminationPath: terminationFile.Name(),
+		PauseFile:       filepath.Join(t.TempDir(), "pause"),
+	}).Go(); err != nil {
+		t.Fatalf("Entrypointer failed: %v", err)
+	}
+	if fr.args == nil {
+		t.Error("expected the step to start when there is no pause file")
+	}
+}
+
 func TestApplyStepResultSubstitutions_Env(t *testing.T) {
 	testCases := []struct {
 		name       string
@@ -2154,6 +2221,49 @@ func getTermination(t *testing.T, terminationFile string) ([]result.RunResult, e
 	return terminationStatus, nil
 }
 
+func TestEntrypointerReadyFiles(t *testing.T) {
+	fw := &fakeContentWaiter{}
+	timeout := time.Duration(0)
+	err := Entrypointer{
+		Command:         []string{"echo"},
+		WaitFiles:       []string{"previous-step"},
+		ReadyFiles:      []string{"sidecar-db", "sidecar-cache"},
+		Waiter:          fw,
+		Runner:          &fakeRunner{},
+		PostWriter:      &fakePostWriter{},
+		TerminationPath: filepath.Join(t.TempDir(), "termination"),
+		Timeout:         &timeout,
+		StepMetadataDir: t.TempDir(),
+	}.Go()
+	if err != nil {
+		t.Fatalf("Entrypointer failed: %v", err)
+	}
+	want := map[string]bool{"previous-step": false, "sidecar-db": true, "sidecar-cache": true}
+	if d := cmp.Diff(want, fw.expectContent); d != "" {
+		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	}
+}
+
+// fakeContentWaiter records whether the files it waited for were expected
+// to have content.
+type fakeContentWaiter struct {
+	sync.Mutex
+	expectContent map[string]bool
+}
+
+func (f *fakeContentWaiter) Wait(_ context.Context, file string, expectContent bool, _ bool) error {
+	if file == pod.DownwardMountCancelFile {
+		return nil
+	}
+	f.Lock()
+	defer f.Unlock()
+	if f.expectContent == nil {
+		f.expectContent = map[string]bool{}
+	}
+	f.expectContent[file] = expectContent
+	return nil
+}
+
 type fakeWaiter struct {
 	sync.Mutex
 	waited             []string
@@ -2187,6 +2297,15 @@ func (f *fakeRunner) Run(ctx context.Context, args ...string) error {
 	return f.runError
 }
 
+type fakeNotifyRunner struct {
+	ran chan struct{}
+}
+
+func (f *fakeNotifyRunner) Run(ctx context.Context, args ...string) error {
+	close(f.ran)
+	return nil
+}
+
 type fakePostWriter struct {
 	wrote        *string
 	exitCodeFile *string
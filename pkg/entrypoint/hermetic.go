@@ -0,0 +1,121 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/result"
+)
+
+// HermeticViolationsCounter is implemented by the Runners which count the
+// network failures of the steps they run hermetically.
+type HermeticViolationsCounter interface {
+	// HermeticViolations returns the number of network failures of the last
+	// step run, and false if it wasn't run hermetically.
+	HermeticViolations() (int, bool)
+}
+
+// CountNetworkFailures returns the number of packets the kernel couldn't
+// route out of a network namespace, from its /proc/net/snmp and
+// /proc/net/snmp6 counters. A step run hermetically has no network interface,
+// so each of its attempts to connect to or send to an IPv4 address, including
+// the DNS queries, fails with no route and increments the OutNoRoutes
+// counters. snmp6 may be nil when IPv6 is disabled.
+func CountNetworkFailures(snmp, snmp6 io.Reader) (int, error) {
+	count, err := snmpCounter(snmp, "Ip:", "OutNoRoutes")
+	if err != nil {
+		return 0, err
+	}
+	if snmp6 != nil {
+		count6, err := snmp6Counter(snmp6, "Ip6OutNoRoutes")
+		if err != nil {
+			return 0, err
+		}
+		count += count6
+	}
+	return count, nil
+}
+
+// snmpCounter returns the counter with the given name from /proc/net/snmp,
+// where each protocol has a line of counter names followed by a line of
+// values, both prefixed with the protocol.
+func snmpCounter(r io.Reader, protocol, name string) (int, error) {
+	var names []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || fields[0] != protocol {
+			continue
+		}
+		if names == nil {
+			names = fields
+			continue
+		}
+		if len(fields) != len(names) {
+			return 0, fmt.Errorf("malformed %s counters: %d names but %d values", protocol, len(names), len(fields))
+		}
+		for i, n := range names {
+			if n == name {
+				return strconv.Atoi(fields[i])
+			}
+		}
+		return 0, fmt.Errorf("no %s %s counter", protocol, name)
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+	return 0, fmt.Errorf("no %s counters", protocol)
+}
+
+// snmp6Counter returns the counter with the given name from /proc/net/snmp6,
+// which has a line per counter with its name and value.
+func snmp6Counter(r io.Reader, name string) (int, error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[0] == name {
+			return strconv.Atoi(fields[1])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+	return 0, fmt.Errorf("no %s counter", name)
+}
+
+// appendHermeticViolations appends the number of network failures of the step
+// to the output, if the Runner counted them.
+func (e Entrypointer) appendHermeticViolations(output *[]result.RunResult) {
+	counter, ok := e.Runner.(HermeticViolationsCounter)
+	if !ok {
+		return
+	}
+	violations, ok := counter.HermeticViolations()
+	if !ok {
+		return
+	}
+	*output = append(*output, result.RunResult{
+		Key:        result.HermeticViolationsKey,
+		Value:      strconv.Itoa(violations),
+		ResultType: result.InternalTektonResultType,
+	})
+}
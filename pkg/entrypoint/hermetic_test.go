@@ -0,0 +1,208 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/result"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+const snmp = `Ip: Forwarding DefaultTTL InReceives InHdrErrors InAddrErrors ForwDatagrams InUnknownProtos InDiscards InDelivers OutRequests OutDiscards OutNoRoutes ReasmTimeout ReasmReqds ReasmOKs ReasmFails FragOKs FragFails FragCreates OutTransmits
+Ip: 2 64 0 0 0 0 0 0 0 0 0 3 0 0 0 0 0 0 0 0
+Icmp: InMsgs InErrors InCsumErrors
+Icmp: 0 0 0
+Udp: InDatagrams NoPorts InErrors OutDatagrams RcvbufErrors SndbufErrors InCsumErrors IgnoredMulti MemErrors
+Udp: 0 0 0 0 0 0 0 0 0
+`
+
+const snmp6 = `Ip6InReceives                   	0
+Ip6InNoRoutes                   	7
+Ip6OutNoRoutes                  	2
+Udp6OutDatagrams                	0
+`
+
+func TestCountNetworkFailures(t *testing.T) {
+	for _, tc := range []struct {
+		desc  string
+		snmp  string
+		snmp6 string
+		want  int
+	}{{
+		desc:  "IPv4 and IPv6 failures",
+		snmp:  snmp,
+		snmp6: snmp6,
+		want:  5,
+	}, {
+		desc: "IPv6 disabled",
+		snmp: snmp,
+		want: 3,
+	}, {
+		desc:  "no failure",
+		snmp:  strings.ReplaceAll(snmp, "0 3 0", "0 0 0"),
+		snmp6: strings.ReplaceAll(snmp6, "	2", "	0"),
+		want:  0,
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			var r6 io.Reader
+			if tc.snmp6 != "" {
+				r6 = strings.NewReader(tc.snmp6)
+			}
+			got, err := CountNetworkFailures(strings.NewReader(tc.snmp), r6)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("expected %d network failures but got %d", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestCountNetworkFailuresErrors(t *testing.T) {
+	for _, tc := range []struct {
+		desc  string
+		snmp  string
+		snmp6 string
+	}{{
+		desc:  "no IP counters",
+		snmp:  "Icmp: InMsgs\nIcmp: 0\n",
+		snmp6: snmp6,
+	}, {
+		desc:  "missing IP values",
+		snmp:  "Ip: Forwarding OutNoRoutes\nIp: 2\n",
+		snmp6: snmp6,
+	}, {
+		desc:  "invalid IP value",
+		snmp:  "Ip: Forwarding OutNoRoutes\nIp: 2 x\n",
+		snmp6: snmp6,
+	}, {
+		desc:  "no IPv6 counter",
+		snmp:  snmp,
+		snmp6: "Ip6InReceives 0\n",
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			if _, err := CountNetworkFailures(strings.NewReader(tc.snmp), strings.NewReader(tc.snmp6)); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+type fakeHermeticRunner struct {
+	violations int
+	hermetic   bool
+}
+
+func (f *fakeHermeticRunner) Run(ctx context.Context, args ...string) error {
+	return nil
+}
+
+func (f *fakeHermeticRunner) HermeticViolations() (int, bool) {
+	return f.violations, f.hermetic
+}
+
+func TestEntrypointer_HermeticViolations(t *testing.T) {
+	for _, tc := range []struct {
+		desc   string
+		report bool
+		runner Runner
+		want   []result.RunResult
+	}{{
+		desc:   "violations reported",
+		report: true,
+		runner: &fakeHermeticRunner{violations: 2, hermetic: true},
+		want: []result.RunResult{{
+			Key:        "StartedAt",
+			ResultType: result.InternalTektonResultType,
+		}, {
+			Key:        result.HermeticViolationsKey,
+			Value:      "2",
+			ResultType: result.InternalTektonResultType,
+		}},
+	}, {
+		desc:   "no violations reported",
+		report: true,
+		runner: &fakeHermeticRunner{hermetic: true},
+		want: []result.RunResult{{
+			Key:        "StartedAt",
+			ResultType: result.InternalTektonResultType,
+		}, {
+			Key:        result.HermeticViolationsKey,
+			Value:      "0",
+			ResultType: result.InternalTektonResultType,
+		}},
+	}, {
+		desc:   "step not run hermetically",
+		report: true,
+		runner: &fakeHermeticRunner{violations: 2},
+		want: []result.RunResult{{
+			Key:        "StartedAt",
+			ResultType: result.InternalTektonResultType,
+		}},
+	}, {
+		desc:   "runner not counting violations",
+		report: true,
+		runner: &fakeRunner{},
+		want: []result.RunResult{{
+			Key:        "StartedAt",
+			ResultType: result.InternalTektonResultType,
+		}},
+	}, {
+		desc:   "violations not reported",
+		runner: &fakeHermeticRunner{violations: 2, hermetic: true},
+		want: []result.RunResult{{
+			Key:        "StartedAt",
+			ResultType: result.InternalTektonResultType,
+		}},
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			tmpFolder := t.TempDir()
+			terminationFile, err := os.CreateTemp(tmpFolder, "termination")
+			if err != nil {
+				t.Fatalf("unexpected error creating termination file: %v", err)
+			}
+
+			e := Entrypointer{
+				Command:                  []string{"echo"},
+				Waiter:                   &fakeWaiter{},
+				Runner:                   tc.runner,
+				PostWriter:               &fakePostWriter{},
+				TerminationPath:          terminationFile.Name(),
+				StepMetadataDir:          tmpFolder,
+				ReportHermeticViolations: tc.report,
+			}
+			if err := e.Go(); err != nil {
+				t.Fatalf("unexpected error running the step: %v", err)
+			}
+
+			got, err := getTermination(t, terminationFile.Name())
+			if err != nil {
+				t.Fatalf("error getting termination output: %v", err)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("termination message doesn't match %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/result"
+)
+
+var (
+	// memoryPeakFiles are the files of the cgroup of the container holding its
+	// peak memory usage, for the cgroup v2 and v1 hierarchies.
+	memoryPeakFiles = []string{
+		"/sys/fs/cgroup/memory.peak",
+		"/sys/fs/cgroup/memory/memory.max_usage_in_bytes",
+	}
+	// memoryPeakInterval is the interval at which the peak memory usage of
+	// the container is recorded while the step runs.
+	memoryPeakInterval = time.Second
+)
+
+// recordMemoryPeak starts recording the peak memory usage of the container in
+// the termination message while the step runs, and returns the function
+// stopping it. When memory.oom.group is set on the cgroup v2 of the container,
+// the OOM killer kills the entrypoint along with the step, so it can't report
+// the peak once the step is killed. The kubelet still reads the termination
+// message of the container once it has terminated, and reports the last peak
+// recorded. The record is dropped once the step is done, so that it doesn't
+// show up next to the termination message of the entrypoint.
+func (e Entrypointer) recordMemoryPeak() func() {
+	if e.TerminationPath == "" {
+		return func() {}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan bool, 1)
+	go func() {
+		var recorded bool
+		defer func() { done <- recorded }()
+		ticker := time.NewTicker(memoryPeakInterval)
+		defer ticker.Stop()
+		var last uint64
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			peak, ok := readMemoryPeak()
+			if !ok {
+				return
+			}
+			if peak == last {
+				continue
+			}
+			if err := writeMemoryPeak(e.TerminationPath, peak); err != nil {
+				slog.Error("Error while recording the memory peak", slog.Any("error", err))
+				return
+			}
+			last, recorded = peak, true
+		}
+	}()
+	return func() {
+		cancel()
+		if <-done {
+			if err := os.Truncate(e.TerminationPath, 0); err != nil {
+				slog.Error("Error while dropping the recorded memory peak", slog.Any("error", err))
+			}
+		}
+	}
+}
+
+// writeMemoryPeak overwrites the termination message with the given peak
+// memory usage.
+func writeMemoryPeak(terminationPath string, peak uint64) error {
+	b, err := json.Marshal([]result.RunResult{memoryPeakResult(peak)})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(terminationPath, b, 0o666)
+}
+
+func memoryPeakResult(peak uint64) result.RunResult {
+	return result.RunResult{
+		Key:        result.MemoryPeakKey,
+		Value:      strconv.FormatUint(peak, 10),
+		ResultType: result.InternalTektonResultType,
+	}
+}
+
+// appendMemoryPeak appends the peak memory usage of the container to the
+// output when the step was killed by a SIGKILL, which is what the OOM killer
+// sends, so that it can be reported if the container was OOMKilled. The peak
+// is read on a best-effort basis and nothing is appended if the cgroup of the
+// container can't be read.
+func (e Entrypointer) appendMemoryPeak(output *[]result.RunResult, err error) {
+	var ee *exec.ExitError
+	if !errors.As(err, &ee) || ee.ProcessState == nil {
+		return
+	}
+	status, ok := ee.Sys().(syscall.WaitStatus)
+	if !ok || !status.Signaled() || status.Signal() != syscall.SIGKILL {
+		return
+	}
+	peak, ok := readMemoryPeak()
+	if !ok {
+		return
+	}
+	*output = append(*output, memoryPeakResult(peak))
+}
+
+func readMemoryPeak() (uint64, bool) {
+	for _, f := range memoryPeakFiles {
+		b, err := os.ReadFile(f)
+		if err != nil {
+			continue
+		}
+		peak, err := strconv.ParseUint(strings.TrimSpace(string(b)), 10, 64)
+		if err != nil {
+			continue
+		}
+		return peak, true
+	}
+	return 0, false
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/result"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func TestAppendMemoryPeak(t *testing.T) {
+	dir := t.TempDir()
+	v2 := filepath.Join(dir, "memory.peak")
+	v1 := filepath.Join(dir, "memory.max_usage_in_bytes")
+	if err := os.WriteFile(v1, []byte("268435456\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	orig := memoryPeakFiles
+	memoryPeakFiles = []string{v2, v1}
+	t.Cleanup(func() { memoryPeakFiles = orig })
+
+	killed := exec.Command("sh", "-c", "kill -9 $$").Run()
+	failed := exec.Command("sh", "-c", "exit 1").Run()
+
+	for _, tc := range []struct {
+		desc     string
+		err      error
+		cgroupV2 string
+		want     []result.RunResult
+	}{{
+		desc: "step succeeded",
+		err:  nil,
+		want: nil,
+	}, {
+		desc: "step failed",
+		err:  failed,
+		want: nil,
+	}, {
+		desc: "step killed, cgroup v1",
+		err:  killed,
+		want: []result.RunResult{{
+			Key:        result.MemoryPeakKey,
+			Value:      "268435456",
+			ResultType: result.InternalTektonResultType,
+		}},
+	}, {
+		desc:     "step killed, cgroup v2",
+		err:      killed,
+		cgroupV2: "536870912\n",
+		want: []result.RunResult{{
+			Key:        result.MemoryPeakKey,
+			Value:      "536870912",
+			ResultType: result.InternalTektonResultType,
+		}},
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			os.Remove(v2)
+			if tc.cgroupV2 != "" {
+				if err := os.WriteFile(v2, []byte(tc.cgroupV2), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			var output []result.RunResult
+			Entrypointer{}.appendMemoryPeak(&output, tc.err)
+			if d := cmp.Diff(tc.want, output); d != "" {
+				t.Errorf("appendMemoryPeak() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestAppendMemoryPeak_NoCgroup(t *testing.T) {
+	orig := memoryPeakFiles
+	memoryPeakFiles = []string{filepath.Join(t.TempDir(), "memory.peak")}
+	t.Cleanup(func() { memoryPeakFiles = orig })
+
+	var output []result.RunResult
+	Entrypointer{}.appendMemoryPeak(&output, exec.Command("sh", "-c", "kill -9 $$").Run())
+	if len(output) != 0 {
+		t.Errorf("expected no memory peak without a cgroup, got %v", output)
+	}
+}
+
+func TestRecordMemoryPeak(t *testing.T) {
+	dir := t.TempDir()
+	peakFile := filepath.Join(dir, "memory.peak")
+	terminationPath := filepath.Join(dir, "termination")
+	if err := os.WriteFile(peakFile, []byte("268435456\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	origFiles, origInterval := memoryPeakFiles, memoryPeakInterval
+	memoryPeakFiles, memoryPeakInterval = []string{peakFile}, 10*time.Millisecond
+	t.Cleanup(func() { memoryPeakFiles, memoryPeakInterval = origFiles, origInterval })
+
+	stop := Entrypointer{TerminationPath: terminationPath}.recordMemoryPeak()
+	// The recorded peak is what the kubelet reports if the entrypoint is
+	// killed along with the step.
+	want := []result.RunResult{{
+		Key:        result.MemoryPeakKey,
+		Value:      "268435456",
+		ResultType: result.InternalTektonResultType,
+	}}
+	var got []result.RunResult
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		b, err := os.ReadFile(terminationPath)
+		if err == nil && json.Unmarshal(b, &got) == nil {
+			break
+		}
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("recorded memory peak %s", diff.PrintWantGot(d))
+	}
+
+	// The record is dropped once the step is done.
+	stop()
+	b, err := os.ReadFile(terminationPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b) != 0 {
+		t.Errorf("expected the recorded memory peak to be dropped, got %q", b)
+	}
+}
+
+func TestRecordMemoryPeak_NoCgroup(t *testing.T) {
+	dir := t.TempDir()
+	terminationPath := filepath.Join(dir, "termination")
+	origFiles, origInterval := memoryPeakFiles, memoryPeakInterval
+	memoryPeakFiles, memoryPeakInterval = []string{filepath.Join(dir, "memory.peak")}, time.Millisecond
+	t.Cleanup(func() { memoryPeakFiles, memoryPeakInterval = origFiles, origInterval })
+
+	stop := Entrypointer{TerminationPath: terminationPath}.recordMemoryPeak()
+	time.Sleep(20 * time.Millisecond)
+	stop()
+	if _, err := os.Stat(terminationPath); !os.IsNotExist(err) {
+		t.Errorf("expected no termination message without a cgroup, got %v", err)
+	}
+}
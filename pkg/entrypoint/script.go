@@ -0,0 +1,55 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// defaultScriptPreamble is prepended to the scripts without a shebang, as it
+// is to the inline scripts of the steps.
+const defaultScriptPreamble = "#!/bin/sh\nset -e\n"
+
+// placeScriptFile copies the script file of the step, e.g. a file of a
+// workspace written by a previous step, into the step metadata directory so
+// that it can be executed even if the file isn't executable or has no
+// shebang. It returns the path of the copy.
+func (e Entrypointer) placeScriptFile() (string, error) {
+	script, err := os.ReadFile(e.ScriptFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", fmt.Errorf("script file %q of the step does not exist", e.ScriptFile)
+	}
+	if err != nil {
+		return "", fmt.Errorf("reading script file %q of the step: %w", e.ScriptFile, err)
+	}
+	if !bytes.HasPrefix(bytes.TrimSpace(script), []byte("#!")) {
+		script = append([]byte(defaultScriptPreamble), script...)
+	}
+	if err := os.MkdirAll(e.StepMetadataDir, os.ModePerm); err != nil {
+		return "", err
+	}
+	path := filepath.Join(e.StepMetadataDir, "script")
+	if err := os.WriteFile(path, script, 0o755); err != nil { //nolint:gosec // the script must be executable.
+		return "", fmt.Errorf("placing script file %q of the step: %w", e.ScriptFile, err)
+	}
+	return path, nil
+}
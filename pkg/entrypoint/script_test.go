@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func TestEntrypointerScriptFile(t *testing.T) {
+	for _, tc := range []struct {
+		desc   string
+		script string
+		want   string
+	}{{
+		desc:   "script without shebang",
+		script: "echo \"hello $1\"\n",
+		want:   "hello world\n",
+	}, {
+		desc:   "script with shebang",
+		script: "#!/bin/sh\nprintf 'hi %s' \"$1\"\n",
+		want:   "hi world",
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			workspace := t.TempDir()
+			scriptFile := filepath.Join(workspace, "build.sh")
+			// The script of the workspace needs not be executable.
+			if err := os.WriteFile(scriptFile, []byte(tc.script), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			stepMetadataDir := filepath.Join(t.TempDir(), "status")
+			fr := &fakeRunner{}
+			e := Entrypointer{
+				Command:         []string{"world"},
+				ScriptFile:      scriptFile,
+				Waiter:          &fakeWaiter{},
+				Runner:          fr,
+				PostWriter:      &fakePostWriter{},
+				TerminationPath: filepath.Join(t.TempDir(), "termination"),
+				StepMetadataDir: stepMetadataDir,
+			}
+			if err := e.Go(); err != nil {
+				t.Fatalf("Entrypointer failed: %v", err)
+			}
+			placed := filepath.Join(stepMetadataDir, "script")
+			if d := cmp.Diff([]string{placed, "world"}, *fr.args); d != "" {
+				t.Errorf("Runner args %s", diff.PrintWantGot(d))
+			}
+			out, err := exec.Command((*fr.args)[0], (*fr.args)[1:]...).Output()
+			if err != nil {
+				t.Fatalf("running the placed script: %v", err)
+			}
+			if d := cmp.Diff(tc.want, string(out)); d != "" {
+				t.Errorf("script output %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestEntrypointerScriptFileMissing(t *testing.T) {
+	scriptFile := filepath.Join(t.TempDir(), "build.sh")
+	fr := &fakeRunner{}
+	fpw := &fakePostWriter{}
+	e := Entrypointer{
+		Command:         []string{"world"},
+		ScriptFile:      scriptFile,
+		PostFile:        "step-one",
+		Waiter:          &fakeWaiter{},
+		Runner:          fr,
+		PostWriter:      fpw,
+		TerminationPath: filepath.Join(t.TempDir(), "termination"),
+		StepMetadataDir: t.TempDir(),
+	}
+	err := e.Go()
+	if err == nil {
+		t.Fatal("expected an error for the missing script file")
+	}
+	if want := `script file "` + scriptFile + `" of the step does not exist`; !strings.Contains(err.Error(), want) {
+		t.Errorf("error %q doesn't contain %q", err, want)
+	}
+	if fr.args != nil {
+		t.Errorf("the step ran with args %v, but it should not have", *fr.args)
+	}
+	if fpw.wrote == nil || *fpw.wrote != "step-one.err" {
+		t.Errorf("expected the error post file to be written, got %v", fpw.wrote)
+	}
+}
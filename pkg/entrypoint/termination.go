@@ -0,0 +1,78 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"os"
+	"time"
+
+	"k8s.io/utils/clock"
+)
+
+// TerminationGrace terminates the steps cancelled while their pod is kept:
+// a step is sent a SIGTERM, then a SIGKILL once the termination grace period
+// of the pod elapsed.
+//
+// The grace period is a budget of the whole pod rather than of each step. It
+// starts when the TaskRun is cancelled, so that the time a step takes to
+// notice the cancellation doesn't extend it.
+type TerminationGrace struct {
+	// Period is the termination grace period of the pod.
+	Period time.Duration
+	Clock  clock.Clock
+}
+
+// Remaining returns what remains of the grace period of a cancellation at
+// cancelledAt.
+func (g *TerminationGrace) Remaining(cancelledAt time.Time) time.Duration {
+	remaining := g.Period - g.Clock.Since(cancelledAt)
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// Terminate terminates a step cancelled at cancelledAt: terminate is called
+// right away, and kill once the remaining grace period elapsed, unless the
+// step exited before. The step is killed right away when no grace period
+// remains or it can't be terminated.
+func (g *TerminationGrace) Terminate(cancelledAt time.Time, exited <-chan struct{}, terminate, kill func() error) error {
+	remaining := g.Remaining(cancelledAt)
+	if remaining == 0 {
+		return kill()
+	}
+	if err := terminate(); err != nil {
+		return kill()
+	}
+	select {
+	case <-exited:
+		return nil
+	case <-g.Clock.After(remaining):
+		return kill()
+	}
+}
+
+// CancellationTime returns when the TaskRun was cancelled, which is when the
+// cancellation file was written, or now when it can't be read.
+func CancellationTime(c clock.PassiveClock) time.Time {
+	now := c.Now()
+	info, err := os.Stat(DownwardMountCancelFile)
+	if err != nil || info.ModTime().After(now) {
+		return now
+	}
+	return info.ModTime()
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/test/diff"
+	testclock "k8s.io/utils/clock/testing"
+)
+
+// fakeStep records the signals sent to a cancelled step.
+type fakeStep struct {
+	signals      chan string
+	terminateErr error
+}
+
+func newFakeStep() *fakeStep {
+	return &fakeStep{signals: make(chan string, 2)}
+}
+
+func (s *fakeStep) terminate() error {
+	s.signals <- "SIGTERM"
+	return s.terminateErr
+}
+
+func (s *fakeStep) kill() error {
+	s.signals <- "SIGKILL"
+	return nil
+}
+
+// received returns the signals received by the step so far.
+func (s *fakeStep) received() []string {
+	var signals []string
+	for {
+		select {
+		case signal := <-s.signals:
+			signals = append(signals, signal)
+		default:
+			return signals
+		}
+	}
+}
+
+// waitForTimer waits for Terminate to wait for the grace period.
+func waitForTimer(t *testing.T, c *testclock.FakeClock) {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for !c.HasWaiters() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the grace period timer")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestTerminationGrace_Terminate(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := testclock.NewFakeClock(now)
+	g := &TerminationGrace{Period: 30 * time.Second, Clock: c}
+	step := newFakeStep()
+	exited := make(chan struct{})
+	done := make(chan error)
+
+	// The step notices the cancellation 10s after it happened, so only 20s
+	// of the grace period of the pod remain.
+	go func() { done <- g.Terminate(now.Add(-10*time.Second), exited, step.terminate, step.kill) }()
+	waitForTimer(t, c)
+	if d := cmp.Diff([]string{"SIGTERM"}, step.received()); d != "" {
+		t.Errorf("unexpected signals before the grace period elapsed %s", diff.PrintWantGot(d))
+	}
+
+	c.Step(19 * time.Second)
+	if got := step.received(); len(got) != 0 {
+		t.Errorf("expected no signal within the grace period, got %v", got)
+	}
+
+	c.Step(time.Second)
+	if err := <-done; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d := cmp.Diff([]string{"SIGKILL"}, step.received()); d != "" {
+		t.Errorf("unexpected signals once the grace period elapsed %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestTerminationGrace_TerminateExited(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := testclock.NewFakeClock(now)
+	g := &TerminationGrace{Period: 30 * time.Second, Clock: c}
+	step := newFakeStep()
+	exited := make(chan struct{})
+	done := make(chan error)
+
+	go func() { done <- g.Terminate(now, exited, step.terminate, step.kill) }()
+	waitForTimer(t, c)
+	c.Step(5 * time.Second)
+	close(exited)
+
+	if err := <-done; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c.Step(time.Minute)
+	if d := cmp.Diff([]string{"SIGTERM"}, step.received()); d != "" {
+		t.Errorf("expected the step exiting within the grace period not to be killed %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestTerminationGrace_TerminateKilledRightAway(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		desc         string
+		cancelledAt  time.Time
+		terminateErr error
+		want         []string
+	}{{
+		desc:        "grace period elapsed",
+		cancelledAt: now.Add(-30 * time.Second),
+		want:        []string{"SIGKILL"},
+	}, {
+		desc:         "step can't be terminated",
+		cancelledAt:  now,
+		terminateErr: errors.New("no such process"),
+		want:         []string{"SIGTERM", "SIGKILL"},
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			g := &TerminationGrace{Period: 30 * time.Second, Clock: testclock.NewFakeClock(now)}
+			step := newFakeStep()
+			step.terminateErr = tc.terminateErr
+
+			if err := g.Terminate(tc.cancelledAt, make(chan struct{}), step.terminate, step.kill); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if d := cmp.Diff(tc.want, step.received()); d != "" {
+				t.Errorf("unexpected signals %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestCancellationTime(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := testclock.NewFakeClock(now)
+	orig := DownwardMountCancelFile
+	DownwardMountCancelFile = filepath.Join(t.TempDir(), "cancel")
+	t.Cleanup(func() { DownwardMountCancelFile = orig })
+
+	if got := CancellationTime(c); !got.Equal(now) {
+		t.Errorf("expected the cancellation without a cancellation file to be now, got %v", got)
+	}
+
+	if err := os.WriteFile(DownwardMountCancelFile, []byte("true"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cancelledAt := now.Add(-10 * time.Second)
+	if err := os.Chtimes(DownwardMountCancelFile, cancelledAt, cancelledAt); err != nil {
+		t.Fatal(err)
+	}
+	if got := CancellationTime(c); !got.Equal(cancelledAt) {
+		t.Errorf("expected the cancellation at the modification time of the cancellation file %v, got %v", cancelledAt, got)
+	}
+}
@@ -16,11 +16,24 @@ limitations under the License.
 package compare
 
 import (
+	"strings"
+
 	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 )
 
+// IsExtendedResource returns true if the resource is an extended resource,
+// like nvidia.com/gpu, which is advertised by a device plugin rather than
+// native to Kubernetes. Extended resources can only be requested in integer
+// amounts and are allocated to the container requesting them.
+func IsExtendedResource(name corev1.ResourceName) bool {
+	n := string(name)
+	return strings.Contains(n, "/") &&
+		!strings.Contains(n, corev1.ResourceDefaultNamespacePrefix) &&
+		!strings.HasPrefix(n, "requests.")
+}
+
 // IsZero returns true if the resource quantity has a zero value
 func IsZero(q resource.Quantity) bool {
 	return (&q).IsZero()
@@ -18,11 +18,15 @@ package tasklevel
 
 import (
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/internal/computeresources/compare"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 // ApplyTaskLevelComputeResources applies the task-level compute resource requirements to each Step.
+// Extended resources, like nvidia.com/gpu, are never divided between the Steps: the ones the Steps
+// declare are kept on them, and the task-level ones are requested by the first Step, unless a Step
+// already declares them.
 func ApplyTaskLevelComputeResources(steps []v1.Step, computeResources *corev1.ResourceRequirements) {
 	if computeResources == nil {
 		return
@@ -30,18 +34,89 @@ func ApplyTaskLevelComputeResources(steps []v1.Step, computeResources *corev1.Re
 	if computeResources.Requests == nil && computeResources.Limits == nil {
 		return
 	}
-	averageRequests := computeAverageRequests(computeResources.Requests, len(steps))
-	averageLimits := computeAverageRequests(computeResources.Limits, len(steps))
+	requests, extendedRequests := splitExtendedResources(computeResources.Requests)
+	limits, extendedLimits := splitExtendedResources(computeResources.Limits)
+	averageRequests := computeAverageRequests(requests, len(steps))
+	averageLimits := computeAverageRequests(limits, len(steps))
+	declared := map[corev1.ResourceName]bool{}
+	stepNativeRequests := make([]corev1.ResourceList, len(steps))
+	stepRequests := make([]corev1.ResourceList, len(steps))
+	stepLimits := make([]corev1.ResourceList, len(steps))
+	for i := range steps {
+		stepNativeRequests[i], stepRequests[i] = splitExtendedResources(steps[i].ComputeResources.Requests)
+		_, stepLimits[i] = splitExtendedResources(steps[i].ComputeResources.Limits)
+		for name := range stepRequests[i] {
+			declared[name] = true
+		}
+		for name := range stepLimits[i] {
+			declared[name] = true
+		}
+	}
 	for i := range steps {
 		// if no requests are specified in step or task level, the limits are used to avoid
 		// unnecessary higher requests by Kubernetes default behavior.
-		if steps[i].ComputeResources.Requests == nil && computeResources.Requests == nil {
-			steps[i].ComputeResources.Requests = averageLimits
+		if stepNativeRequests[i] == nil && requests == nil {
+			steps[i].ComputeResources.Requests = copyResourceList(averageLimits)
 		} else {
-			steps[i].ComputeResources.Requests = averageRequests
+			steps[i].ComputeResources.Requests = copyResourceList(averageRequests)
 		}
-		steps[i].ComputeResources.Limits = computeResources.Limits
+		steps[i].ComputeResources.Limits = copyResourceList(limits)
+		steps[i].ComputeResources.Requests = addResources(steps[i].ComputeResources.Requests, stepRequests[i])
+		steps[i].ComputeResources.Limits = addResources(steps[i].ComputeResources.Limits, stepLimits[i])
+	}
+	if len(steps) == 0 {
+		return
+	}
+	for name, q := range extendedRequests {
+		if !declared[name] {
+			steps[0].ComputeResources.Requests = addResources(steps[0].ComputeResources.Requests, corev1.ResourceList{name: q})
+		}
+	}
+	for name, q := range extendedLimits {
+		if !declared[name] {
+			steps[0].ComputeResources.Limits = addResources(steps[0].ComputeResources.Limits, corev1.ResourceList{name: q})
+		}
+	}
+}
+
+// splitExtendedResources splits the resource list into its native resources and its
+// extended resources.
+func splitExtendedResources(resources corev1.ResourceList) (native, extended corev1.ResourceList) {
+	for name, q := range resources {
+		if compare.IsExtendedResource(name) {
+			if extended == nil {
+				extended = corev1.ResourceList{}
+			}
+			extended[name] = q
+			continue
+		}
+		if native == nil {
+			native = corev1.ResourceList{}
+		}
+		native[name] = q
+	}
+	return native, extended
+}
+
+// addResources adds the resources to the resource list, allocating it if needed.
+func addResources(dst, src corev1.ResourceList) corev1.ResourceList {
+	if len(src) == 0 {
+		return dst
+	}
+	if dst == nil {
+		dst = corev1.ResourceList{}
+	}
+	for name, q := range src {
+		dst[name] = q
+	}
+	return dst
+}
+
+func copyResourceList(resources corev1.ResourceList) corev1.ResourceList {
+	if resources == nil {
+		return nil
 	}
+	return resources.DeepCopy()
 }
 
 // computeAverageRequests computes the average of the requests of all the steps.
@@ -28,6 +28,8 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 )
 
+const gpu corev1.ResourceName = "nvidia.com/gpu"
+
 func TestApplyTaskLevelResourceRequirements(t *testing.T) {
 	testcases := []struct {
 		desc                     string
@@ -221,6 +223,77 @@ func TestApplyTaskLevelResourceRequirements(t *testing.T) {
 			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("200m")},
 			Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
 		}},
+	}, {
+		desc: "extended resources of a step are kept on it",
+		Steps: []v1.Step{{
+			Name:    "1st-step",
+			Image:   "image",
+			Command: []string{"cmd"},
+			ComputeResources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{gpu: resource.MustParse("1")},
+				Limits:   corev1.ResourceList{gpu: resource.MustParse("1")},
+			},
+		}, {
+			Name:    "2nd-step",
+			Image:   "image",
+			Command: []string{"cmd"},
+		}},
+		ComputeResources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+			Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")},
+		},
+		expectedComputeResources: []corev1.ResourceRequirements{{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1"), gpu: resource.MustParse("1")},
+			Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4"), gpu: resource.MustParse("1")},
+		}, {
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+			Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")},
+		}},
+	}, {
+		desc: "task-level extended resources are requested by the first step",
+		Steps: []v1.Step{{
+			Name:    "1st-step",
+			Image:   "image",
+			Command: []string{"cmd"},
+		}, {
+			Name:    "2nd-step",
+			Image:   "image",
+			Command: []string{"cmd"},
+		}},
+		ComputeResources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2"), gpu: resource.MustParse("3")},
+			Limits:   corev1.ResourceList{gpu: resource.MustParse("3")},
+		},
+		expectedComputeResources: []corev1.ResourceRequirements{{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1"), gpu: resource.MustParse("3")},
+			Limits:   corev1.ResourceList{gpu: resource.MustParse("3")},
+		}, {
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+		}},
+	}, {
+		desc: "task-level extended resources declared by a step are left to it",
+		Steps: []v1.Step{{
+			Name:    "1st-step",
+			Image:   "image",
+			Command: []string{"cmd"},
+		}, {
+			Name:    "2nd-step",
+			Image:   "image",
+			Command: []string{"cmd"},
+			ComputeResources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{gpu: resource.MustParse("1")},
+			},
+		}},
+		ComputeResources: corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi"), gpu: resource.MustParse("2")},
+		},
+		expectedComputeResources: []corev1.ResourceRequirements{{
+			Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+			Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+		}, {
+			Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+			Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi"), gpu: resource.MustParse("1")},
+		}},
 	}}
 
 	for _, tc := range testcases {
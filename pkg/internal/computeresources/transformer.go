@@ -61,7 +61,7 @@ func transformPodBasedOnLimitRange(p *corev1.Pod, limitRange *corev1.LimitRange)
 	// This should help us find the smallest request to apply to containers
 	nbStepContainers := 0
 	for _, c := range p.Spec.Containers {
-		if pod.IsContainerStep(c.Name) {
+		if pod.IsPodContainerStep(p, c.Name) {
 			nbStepContainers++
 		}
 	}
@@ -70,7 +70,7 @@ func transformPodBasedOnLimitRange(p *corev1.Pod, limitRange *corev1.LimitRange)
 	defaultStepContainerRequests := getDefaultStepContainerRequest(limitRange, nbStepContainers)
 
 	for i, c := range p.Spec.Containers {
-		if !pod.IsContainerStep(c.Name) {
+		if !pod.IsPodContainerStep(p, c.Name) {
 			continue
 		}
 		if p.Spec.Containers[i].Resources.Requests == nil {
@@ -0,0 +1,91 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logproxy
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/cache"
+	testclock "k8s.io/utils/clock/testing"
+)
+
+func TestHandler_IdleLimitersEvicted(t *testing.T) {
+	clock := testclock.NewFakeClock(time.Now())
+	h := &Handler{
+		options:  Options{RequestsPerSecond: 1, Burst: 5},
+		limiters: cache.NewLRUExpireCacheWithClock(maxLimiters, clock),
+	}
+
+	if !h.limiter("alice").Allow() {
+		t.Fatal("expected the first request of alice to be allowed")
+	}
+	clock.Step(4 * time.Second)
+	h.limiter("bob")
+	if _, ok := h.limiters.Get("alice"); !ok {
+		t.Error("expected the limiter of alice to be kept before its burst refilled")
+	}
+
+	// The TTL counts from the last request of alice, not from the lookup
+	// above.
+	clock.Step(2 * time.Second)
+	if _, ok := h.limiters.Get("alice"); ok {
+		t.Error("expected the limiter of idle alice to be evicted")
+	}
+	if _, ok := h.limiters.Get("bob"); !ok {
+		t.Error("expected the limiter of bob to be kept")
+	}
+}
+
+func TestHandler_LimitersBounded(t *testing.T) {
+	h := &Handler{
+		options:  Options{RequestsPerSecond: 1, Burst: 5},
+		limiters: cache.NewLRUExpireCache(3),
+	}
+	for i := range 10 {
+		h.limiter(fmt.Sprintf("user-%d", i))
+	}
+	if got := len(h.limiters.Keys()); got != 3 {
+		t.Errorf("expected 3 limiters to be kept, got %d", got)
+	}
+}
+
+func TestOptions_LimiterTTL(t *testing.T) {
+	for _, tc := range []struct {
+		options Options
+		want    time.Duration
+	}{{
+		options: Options{RequestsPerSecond: 1, Burst: 5},
+		want:    5 * time.Second,
+	}, {
+		options: Options{RequestsPerSecond: 100, Burst: 1},
+		want:    time.Second,
+	}, {
+		options: Options{RequestsPerSecond: 0.0001, Burst: 1},
+		want:    maxLimiterTTL,
+	}, {
+		options: Options{RequestsPerSecond: 0, Burst: 1},
+		want:    maxLimiterTTL,
+	}} {
+		t.Run(fmt.Sprintf("%v/%d", tc.options.RequestsPerSecond, tc.options.Burst), func(t *testing.T) {
+			if got := tc.options.limiterTTL(); got != tc.want {
+				t.Errorf("expected the TTL %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
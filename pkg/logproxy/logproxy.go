@@ -0,0 +1,351 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package logproxy serves the logs of the steps of TaskRuns to the users who
+// can read the TaskRuns, without requiring them to be able to read their pods.
+package logproxy
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// LogsLocationAnnotation can be set on a TaskRun, e.g. by the system
+	// archiving its logs, to the URL of the logs of its steps once its pod is
+	// gone. The requests for the logs of a step are redirected to this URL
+	// followed by the name of the step, if it's within Options.LogsBackend.
+	LogsLocationAnnotation = "tekton.dev/logs-location"
+
+	// StepLogsPath is the path of the logs of a step of a TaskRun.
+	StepLogsPath = "/apis/v1/namespaces/{namespace}/taskruns/{name}/steps/{step}/logs"
+
+	// maxLimiters is the number of users whose rate limiters are kept. The
+	// limiters of the users who made the least recent requests are evicted
+	// first.
+	maxLimiters = 10000
+	// maxLimiterTTL is the longest time the rate limiter of an idle user is
+	// kept.
+	maxLimiterTTL = time.Hour
+)
+
+// Options configures the limits of the log proxy.
+type Options struct {
+	// RequestsPerSecond is the number of requests per second a user can make.
+	RequestsPerSecond float64
+	// Burst is the number of requests a user can make at once.
+	Burst int
+	// MaxConcurrentStreams is the number of logs streamed at the same time,
+	// across all users.
+	MaxConcurrentStreams int
+	// LogsBackend is the URL of the system storing the logs of the TaskRuns
+	// whose pods are gone. The requests are only redirected to the
+	// LogsLocationAnnotation of a TaskRun if it's under this URL, so that
+	// the users who can annotate a TaskRun can't redirect the others to any
+	// site. If it's nil, the requests are never redirected.
+	LogsBackend *url.URL
+}
+
+// Handler serves the logs of the steps of TaskRuns. The requests are
+// authenticated with their bearer token and authorized if the user can get
+// the TaskRun.
+type Handler struct {
+	kubeClient     kubernetes.Interface
+	pipelineClient versioned.Interface
+	logger         *zap.SugaredLogger
+	options        Options
+
+	// streams holds a token for each log being streamed.
+	streams chan struct{}
+
+	mu       sync.Mutex
+	limiters *cache.LRUExpireCache
+}
+
+// NewHandler returns a Handler serving the logs of the steps of TaskRuns at
+// StepLogsPath.
+func NewHandler(kubeClient kubernetes.Interface, pipelineClient versioned.Interface, logger *zap.SugaredLogger, options Options) http.Handler {
+	h := &Handler{
+		kubeClient:     kubeClient,
+		pipelineClient: pipelineClient,
+		logger:         logger,
+		options:        options,
+		streams:        make(chan struct{}, options.MaxConcurrentStreams),
+		limiters:       cache.NewLRUExpireCache(maxLimiters),
+	}
+	mux := http.NewServeMux()
+	mux.Handle("GET "+StepLogsPath, h)
+	return mux
+}
+
+// ServeHTTP streams the logs of the container of the requested step, following
+// them if the follow query parameter is true.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	namespace, name, step := r.PathValue("namespace"), r.PathValue("name"), r.PathValue("step")
+
+	// The requests are limited by client address before their token is
+	// reviewed, so that requests with invalid tokens can't flood the API
+	// server with TokenReviews.
+	if !h.limiter("address/" + clientAddress(r)).Allow() {
+		http.Error(w, "too many requests", http.StatusTooManyRequests)
+		return
+	}
+	user, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+	if !h.limiter("user/" + user.Username).Allow() {
+		http.Error(w, "too many requests", http.StatusTooManyRequests)
+		return
+	}
+	if !h.authorize(w, r, user, namespace, name) {
+		return
+	}
+
+	tr, err := h.pipelineClient.TektonV1().TaskRuns(namespace).Get(ctx, name, metav1.GetOptions{})
+	switch {
+	case k8serrors.IsNotFound(err):
+		http.Error(w, fmt.Sprintf("TaskRun %s/%s not found", namespace, name), http.StatusNotFound)
+		return
+	case err != nil:
+		h.logger.Errorf("Failed to get TaskRun %s/%s: %v", namespace, name, err)
+		http.Error(w, "failed to get the TaskRun", http.StatusInternalServerError)
+		return
+	}
+	container, ok := stepContainer(tr, step)
+	if !ok {
+		http.Error(w, fmt.Sprintf("TaskRun %s/%s has no step %q", namespace, name, step), http.StatusNotFound)
+		return
+	}
+
+	podGone := tr.Status.PodName == ""
+	if !podGone {
+		_, err := h.kubeClient.CoreV1().Pods(namespace).Get(ctx, tr.Status.PodName, metav1.GetOptions{})
+		switch {
+		case k8serrors.IsNotFound(err):
+			podGone = true
+		case err != nil:
+			h.logger.Errorf("Failed to get pod %s/%s: %v", namespace, tr.Status.PodName, err)
+			http.Error(w, "failed to get the pod of the TaskRun", http.StatusInternalServerError)
+			return
+		}
+	}
+	if podGone {
+		h.redirectToLogsLocation(w, r, tr, step)
+		return
+	}
+
+	select {
+	case h.streams <- struct{}{}:
+		defer func() { <-h.streams }()
+	default:
+		http.Error(w, "too many concurrent log streams", http.StatusTooManyRequests)
+		return
+	}
+
+	follow, _ := strconv.ParseBool(r.URL.Query().Get("follow"))
+	logs, err := h.kubeClient.CoreV1().Pods(namespace).GetLogs(tr.Status.PodName, &corev1.PodLogOptions{
+		Container: container,
+		Follow:    follow,
+	}).Stream(ctx)
+	if err != nil {
+		h.logger.Errorf("Failed to stream the logs of container %s of pod %s/%s: %v", container, namespace, tr.Status.PodName, err)
+		http.Error(w, "failed to stream the logs of the step", http.StatusInternalServerError)
+		return
+	}
+	defer logs.Close()
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(&flushWriter{w: w}, logs); err != nil {
+		h.logger.Debugf("Stopped streaming the logs of container %s of pod %s/%s: %v", container, namespace, tr.Status.PodName, err)
+	}
+}
+
+// authenticate returns the user of the bearer token of the request, or writes
+// an Unauthorized response if the token isn't valid.
+func (h *Handler) authenticate(w http.ResponseWriter, r *http.Request) (authenticationv1.UserInfo, bool) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		http.Error(w, "missing bearer token", http.StatusUnauthorized)
+		return authenticationv1.UserInfo{}, false
+	}
+	review, err := h.kubeClient.AuthenticationV1().TokenReviews().Create(r.Context(), &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		h.logger.Errorf("Failed to review the token of a request: %v", err)
+		http.Error(w, "failed to authenticate the request", http.StatusInternalServerError)
+		return authenticationv1.UserInfo{}, false
+	}
+	if !review.Status.Authenticated {
+		http.Error(w, "invalid bearer token", http.StatusUnauthorized)
+		return authenticationv1.UserInfo{}, false
+	}
+	return review.Status.User, true
+}
+
+// authorize returns true if the user can get the TaskRun, or writes a
+// Forbidden response otherwise.
+func (h *Handler) authorize(w http.ResponseWriter, r *http.Request, user authenticationv1.UserInfo, namespace, name string) bool {
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for k, v := range user.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+	review, err := h.kubeClient.AuthorizationV1().SubjectAccessReviews().Create(r.Context(), &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      "get",
+				Group:     pipeline.GroupName,
+				Resource:  pipeline.TaskRunResource.Resource,
+				Name:      name,
+			},
+			User:   user.Username,
+			Groups: user.Groups,
+			Extra:  extra,
+			UID:    user.UID,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		h.logger.Errorf("Failed to review the access of %s to TaskRun %s/%s: %v", user.Username, namespace, name, err)
+		http.Error(w, "failed to authorize the request", http.StatusInternalServerError)
+		return false
+	}
+	if !review.Status.Allowed {
+		http.Error(w, fmt.Sprintf("%s can't get TaskRun %s/%s", user.Username, namespace, name), http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+// limiter returns the rate limiter of the requests of the given client
+// address or user. The limiter is kept for limiterTTL after the last request
+// of the client or user.
+func (h *Handler) limiter(key string) *rate.Limiter {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var l *rate.Limiter
+	if cached, ok := h.limiters.Get(key); ok {
+		l = cached.(*rate.Limiter)
+	} else {
+		l = rate.NewLimiter(rate.Limit(h.options.RequestsPerSecond), h.options.Burst)
+	}
+	h.limiters.Add(key, l, h.options.limiterTTL())
+	return l
+}
+
+// clientAddress returns the host of the remote address of the request, without
+// its port, which changes with each connection of the client.
+func clientAddress(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// limiterTTL returns how long the rate limiter of an idle user is kept: the
+// time its burst takes to refill, after which it's the same as a new limiter,
+// up to maxLimiterTTL.
+func (o Options) limiterTTL() time.Duration {
+	if o.RequestsPerSecond <= 0 {
+		return maxLimiterTTL
+	}
+	ttl := time.Duration(float64(o.Burst) / o.RequestsPerSecond * float64(time.Second))
+	return min(max(ttl, time.Second), maxLimiterTTL)
+}
+
+// redirectToLogsLocation redirects the request to the location of the logs of
+// the step stored in the LogsLocationAnnotation of the TaskRun, if any and if
+// it's within the LogsBackend.
+func (h *Handler) redirectToLogsLocation(w http.ResponseWriter, r *http.Request, tr *v1.TaskRun, step string) {
+	location, ok := tr.Annotations[LogsLocationAnnotation]
+	if !ok || h.options.LogsBackend == nil {
+		http.Error(w, fmt.Sprintf("the pod of TaskRun %s/%s is gone and its logs weren't stored", tr.Namespace, tr.Name), http.StatusNotFound)
+		return
+	}
+	u, err := url.Parse(location)
+	if err != nil {
+		h.logger.Errorf("Invalid %s annotation of TaskRun %s/%s: %v", LogsLocationAnnotation, tr.Namespace, tr.Name, err)
+		http.Error(w, "invalid location of the stored logs", http.StatusInternalServerError)
+		return
+	}
+	u = u.JoinPath(step)
+	if !withinBackend(h.options.LogsBackend, u) {
+		h.logger.Warnf("The %s annotation of TaskRun %s/%s isn't within the logs backend %s", LogsLocationAnnotation, tr.Namespace, tr.Name, h.options.LogsBackend)
+		http.Error(w, "invalid location of the stored logs", http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, u.String(), http.StatusTemporaryRedirect)
+}
+
+// withinBackend returns true if the location has the scheme and host of the
+// backend and a path under the path of the backend. The path of the location
+// must have been cleaned, e.g. by URL.JoinPath, so that it can't climb out of
+// the path of the backend with "..".
+func withinBackend(backend, location *url.URL) bool {
+	if location.Scheme != backend.Scheme || location.Host != backend.Host || location.User != nil {
+		return false
+	}
+	return strings.HasPrefix(location.Path, strings.TrimSuffix(backend.Path, "/")+"/")
+}
+
+// stepContainer returns the name of the container of the given step of the
+// TaskRun, as reported in its status.
+func stepContainer(tr *v1.TaskRun, step string) (string, bool) {
+	for _, s := range tr.Status.Steps {
+		if s.Name == step {
+			return s.Container, true
+		}
+	}
+	return "", false
+}
+
+// flushWriter flushes the response after each write, so that the logs which
+// are followed reach the client as they are written.
+type flushWriter struct {
+	w http.ResponseWriter
+}
+
+func (fw *flushWriter) Write(p []byte) (int, error) {
+	n, err := fw.w.Write(p)
+	if f, ok := fw.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return n, err
+}
@@ -0,0 +1,333 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logproxy_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	fakepipelineclient "github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
+	"github.com/tektoncd/pipeline/pkg/logproxy"
+	"github.com/tektoncd/pipeline/test/diff"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakekubeclient "k8s.io/client-go/kubernetes/fake"
+	ktesting "k8s.io/client-go/testing"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+const validToken = "valid-token"
+
+var defaultOptions = logproxy.Options{
+	RequestsPerSecond:    10,
+	Burst:                10,
+	MaxConcurrentStreams: 10,
+	LogsBackend:          &url.URL{Scheme: "https", Host: "logs.example.com", Path: "/foo"},
+}
+
+func taskRun(annotations map[string]string, podName string) *v1.TaskRun {
+	return &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "taskrun",
+			Namespace:   "foo",
+			Annotations: annotations,
+		},
+		Status: v1.TaskRunStatus{
+			TaskRunStatusFields: v1.TaskRunStatusFields{
+				PodName: podName,
+				Steps: []v1.StepState{{
+					Name:      "build",
+					Container: "builder",
+				}},
+			},
+		},
+	}
+}
+
+// newKubeClient returns a fake client which authenticates validToken as the
+// user alice, who is allowed to get the TaskRuns if allowed is true.
+func newKubeClient(allowed bool, objects ...runtime.Object) *fakekubeclient.Clientset {
+	kubeClient := fakekubeclient.NewSimpleClientset(objects...)
+	kubeClient.PrependReactor("create", "tokenreviews", func(action ktesting.Action) (bool, runtime.Object, error) {
+		review := action.(ktesting.CreateAction).GetObject().(*authenticationv1.TokenReview).DeepCopy()
+		if review.Spec.Token == validToken {
+			review.Status.Authenticated = true
+			review.Status.User = authenticationv1.UserInfo{Username: "alice", Groups: []string{"developers"}}
+		}
+		return true, review, nil
+	})
+	kubeClient.PrependReactor("create", "subjectaccessreviews", func(action ktesting.Action) (bool, runtime.Object, error) {
+		review := action.(ktesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview).DeepCopy()
+		attrs := review.Spec.ResourceAttributes
+		review.Status.Allowed = allowed && review.Spec.User == "alice" && attrs.Verb == "get" &&
+			attrs.Group == "tekton.dev" && attrs.Resource == "taskruns" && attrs.Namespace == "foo" && attrs.Name == "taskrun"
+		return true, review, nil
+	})
+	return kubeClient
+}
+
+func TestHandler(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "taskrun-pod", Namespace: "foo"}}
+
+	for _, tc := range []struct {
+		name          string
+		path          string
+		token         string
+		allowed       bool
+		noLogsBackend bool
+		taskRun       *v1.TaskRun
+		pod           *corev1.Pod
+		wantStatus    int
+		wantBody      string
+		wantLocation  string
+		wantLogs      *corev1.PodLogOptions
+	}{{
+		name:       "logs of a step",
+		path:       "/apis/v1/namespaces/foo/taskruns/taskrun/steps/build/logs",
+		token:      validToken,
+		allowed:    true,
+		taskRun:    taskRun(nil, "taskrun-pod"),
+		pod:        pod,
+		wantStatus: http.StatusOK,
+		wantBody:   "fake logs",
+		wantLogs:   &corev1.PodLogOptions{Container: "builder"},
+	}, {
+		name:       "followed logs of a step",
+		path:       "/apis/v1/namespaces/foo/taskruns/taskrun/steps/build/logs?follow=true",
+		token:      validToken,
+		allowed:    true,
+		taskRun:    taskRun(nil, "taskrun-pod"),
+		pod:        pod,
+		wantStatus: http.StatusOK,
+		wantBody:   "fake logs",
+		wantLogs:   &corev1.PodLogOptions{Container: "builder", Follow: true},
+	}, {
+		name:       "missing token",
+		path:       "/apis/v1/namespaces/foo/taskruns/taskrun/steps/build/logs",
+		taskRun:    taskRun(nil, "taskrun-pod"),
+		pod:        pod,
+		wantStatus: http.StatusUnauthorized,
+		wantBody:   "missing bearer token\n",
+	}, {
+		name:       "invalid token",
+		path:       "/apis/v1/namespaces/foo/taskruns/taskrun/steps/build/logs",
+		token:      "invalid-token",
+		allowed:    true,
+		taskRun:    taskRun(nil, "taskrun-pod"),
+		pod:        pod,
+		wantStatus: http.StatusUnauthorized,
+		wantBody:   "invalid bearer token\n",
+	}, {
+		name:       "user can't get the TaskRun",
+		path:       "/apis/v1/namespaces/foo/taskruns/taskrun/steps/build/logs",
+		token:      validToken,
+		taskRun:    taskRun(nil, "taskrun-pod"),
+		pod:        pod,
+		wantStatus: http.StatusForbidden,
+		wantBody:   "alice can't get TaskRun foo/taskrun\n",
+	}, {
+		name:       "TaskRun not found",
+		path:       "/apis/v1/namespaces/foo/taskruns/taskrun/steps/build/logs",
+		token:      validToken,
+		allowed:    true,
+		wantStatus: http.StatusNotFound,
+		wantBody:   "TaskRun foo/taskrun not found\n",
+	}, {
+		name:       "unknown step",
+		path:       "/apis/v1/namespaces/foo/taskruns/taskrun/steps/test/logs",
+		token:      validToken,
+		allowed:    true,
+		taskRun:    taskRun(nil, "taskrun-pod"),
+		pod:        pod,
+		wantStatus: http.StatusNotFound,
+		wantBody:   "TaskRun foo/taskrun has no step \"test\"\n",
+	}, {
+		name:         "pod gone with stored logs",
+		path:         "/apis/v1/namespaces/foo/taskruns/taskrun/steps/build/logs",
+		token:        validToken,
+		allowed:      true,
+		taskRun:      taskRun(map[string]string{logproxy.LogsLocationAnnotation: "https://logs.example.com/foo/taskrun"}, "taskrun-pod"),
+		wantStatus:   http.StatusTemporaryRedirect,
+		wantLocation: "https://logs.example.com/foo/taskrun/build",
+	}, {
+		name:          "pod gone without logs backend",
+		path:          "/apis/v1/namespaces/foo/taskruns/taskrun/steps/build/logs",
+		token:         validToken,
+		allowed:       true,
+		noLogsBackend: true,
+		taskRun:       taskRun(map[string]string{logproxy.LogsLocationAnnotation: "https://logs.example.com/foo/taskrun"}, "taskrun-pod"),
+		wantStatus:    http.StatusNotFound,
+		wantBody:      "the pod of TaskRun foo/taskrun is gone and its logs weren't stored\n",
+	}, {
+		name:       "pod gone with stored logs on another host",
+		path:       "/apis/v1/namespaces/foo/taskruns/taskrun/steps/build/logs",
+		token:      validToken,
+		allowed:    true,
+		taskRun:    taskRun(map[string]string{logproxy.LogsLocationAnnotation: "https://evil.example.com/foo/taskrun"}, "taskrun-pod"),
+		wantStatus: http.StatusInternalServerError,
+		wantBody:   "invalid location of the stored logs\n",
+	}, {
+		name:       "pod gone with stored logs outside the logs backend path",
+		path:       "/apis/v1/namespaces/foo/taskruns/taskrun/steps/build/logs",
+		token:      validToken,
+		allowed:    true,
+		taskRun:    taskRun(map[string]string{logproxy.LogsLocationAnnotation: "https://logs.example.com/foo/../bar/taskrun"}, "taskrun-pod"),
+		wantStatus: http.StatusInternalServerError,
+		wantBody:   "invalid location of the stored logs\n",
+	}, {
+		name:       "pod gone with stored logs in a sibling of the logs backend path",
+		path:       "/apis/v1/namespaces/foo/taskruns/taskrun/steps/build/logs",
+		token:      validToken,
+		allowed:    true,
+		taskRun:    taskRun(map[string]string{logproxy.LogsLocationAnnotation: "https://logs.example.com/foobar/taskrun"}, "taskrun-pod"),
+		wantStatus: http.StatusInternalServerError,
+		wantBody:   "invalid location of the stored logs\n",
+	}, {
+		name:       "pod gone without stored logs",
+		path:       "/apis/v1/namespaces/foo/taskruns/taskrun/steps/build/logs",
+		token:      validToken,
+		allowed:    true,
+		taskRun:    taskRun(nil, "taskrun-pod"),
+		wantStatus: http.StatusNotFound,
+		wantBody:   "the pod of TaskRun foo/taskrun is gone and its logs weren't stored\n",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			var kubeObjects, pipelineObjects []runtime.Object
+			if tc.pod != nil {
+				kubeObjects = append(kubeObjects, tc.pod)
+			}
+			if tc.taskRun != nil {
+				pipelineObjects = append(pipelineObjects, tc.taskRun)
+			}
+			kubeClient := newKubeClient(tc.allowed, kubeObjects...)
+			pipelineClient := fakepipelineclient.NewSimpleClientset(pipelineObjects...)
+			options := defaultOptions
+			if tc.noLogsBackend {
+				options.LogsBackend = nil
+			}
+			handler := logproxy.NewHandler(kubeClient, pipelineClient, logtesting.TestLogger(t), options)
+
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			if tc.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tc.token)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tc.wantStatus {
+				t.Errorf("expected status %d but got %d: %s", tc.wantStatus, rec.Code, rec.Body.String())
+			}
+			if tc.wantBody != "" && rec.Body.String() != tc.wantBody {
+				t.Errorf("expected body %q but got %q", tc.wantBody, rec.Body.String())
+			}
+			if location := rec.Header().Get("Location"); location != tc.wantLocation {
+				t.Errorf("expected location %q but got %q", tc.wantLocation, location)
+			}
+			var gotLogs *corev1.PodLogOptions
+			for _, action := range kubeClient.Actions() {
+				if action.GetSubresource() == "log" {
+					gotLogs = action.(ktesting.GenericAction).GetValue().(*corev1.PodLogOptions)
+				}
+			}
+			if d := cmp.Diff(tc.wantLogs, gotLogs); d != "" {
+				t.Errorf("unexpected logs requested %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestHandler_Limits(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "taskrun-pod", Namespace: "foo"}}
+
+	for _, tc := range []struct {
+		name             string
+		options          logproxy.Options
+		token            string
+		requests         int
+		wantBody         string
+		wantTokenReviews int
+	}{{
+		name: "rate limited",
+		options: logproxy.Options{
+			RequestsPerSecond:    0.001,
+			Burst:                1,
+			MaxConcurrentStreams: 10,
+		},
+		token:            validToken,
+		requests:         2,
+		wantBody:         "too many requests\n",
+		wantTokenReviews: 1,
+	}, {
+		name: "rate limited before the token review",
+		options: logproxy.Options{
+			RequestsPerSecond:    0.001,
+			Burst:                1,
+			MaxConcurrentStreams: 10,
+		},
+		token:            "invalid-token",
+		requests:         3,
+		wantBody:         "too many requests\n",
+		wantTokenReviews: 1,
+	}, {
+		name: "too many concurrent streams",
+		options: logproxy.Options{
+			RequestsPerSecond:    10,
+			Burst:                10,
+			MaxConcurrentStreams: 0,
+		},
+		token:            validToken,
+		requests:         1,
+		wantBody:         "too many concurrent log streams\n",
+		wantTokenReviews: 1,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			kubeClient := newKubeClient(true, pod)
+			pipelineClient := fakepipelineclient.NewSimpleClientset(taskRun(nil, "taskrun-pod"))
+			handler := logproxy.NewHandler(kubeClient, pipelineClient, logtesting.TestLogger(t), tc.options)
+
+			var rec *httptest.ResponseRecorder
+			for range tc.requests {
+				req := httptest.NewRequest(http.MethodGet, "/apis/v1/namespaces/foo/taskruns/taskrun/steps/build/logs", nil)
+				req.Header.Set("Authorization", "Bearer "+tc.token)
+				rec = httptest.NewRecorder()
+				handler.ServeHTTP(rec, req)
+			}
+			if rec.Code != http.StatusTooManyRequests {
+				t.Errorf("expected status %d but got %d", http.StatusTooManyRequests, rec.Code)
+			}
+			if rec.Body.String() != tc.wantBody {
+				t.Errorf("expected body %q but got %q", tc.wantBody, rec.Body.String())
+			}
+			tokenReviews := 0
+			for _, action := range kubeClient.Actions() {
+				if action.Matches("create", "tokenreviews") {
+					tokenReviews++
+				}
+			}
+			if tokenReviews != tc.wantTokenReviews {
+				t.Errorf("expected %d token reviews but got %d", tc.wantTokenReviews, tokenReviews)
+			}
+		})
+	}
+}
@@ -98,6 +98,28 @@ var (
 		"Number of pipelineruns executing currently that are waiting on resolution requests for the task references of their taskrun children.",
 		stats.UnitDimensionless)
 	runningPRsWaitingOnTaskResolutionView *view.View
+
+	trQueuedDuration = stats.Float64("pipelinerun_taskrun_queued_duration_seconds",
+		"The time the TaskRuns of the PipelineRuns were queued between their creation and the start of their pod",
+		stats.UnitDimensionless)
+	trQueuedDurationView *view.View
+
+	prDAGDuration = stats.Float64(
+		"pipelinerun_dag_duration_seconds",
+		"The time the pipelinerun spent executing the tasks of its DAG in seconds",
+		stats.UnitDimensionless)
+	prDAGDurationView *view.View
+
+	prFinallyDuration = stats.Float64(
+		"pipelinerun_finally_duration_seconds",
+		"The time the pipelinerun spent executing its finally tasks in seconds",
+		stats.UnitDimensionless)
+	prFinallyDurationView *view.View
+
+	prFinallyTaskTotal = stats.Float64("pipelinerun_finally_task_total",
+		"Number of finally tasks of the pipelineruns by outcome",
+		stats.UnitDimensionless)
+	prFinallyTaskTotalView *view.View
 )
 
 const (
@@ -197,6 +219,9 @@ func viewRegister(cfg *config.Metrics) error {
 		}
 	}
 
+	// The outcomes of the finally tasks are not tagged with the reason of the PipelineRun.
+	finallyTaskTotalTags := append([]tag.Key{statusTag, namespaceTag}, prunTag...)
+
 	prCountViewTags := []tag.Key{statusTag}
 	if cfg.CountWithReason {
 		prCountViewTags = append(prCountViewTags, reasonTag)
@@ -257,6 +282,32 @@ func viewRegister(cfg *config.Metrics) error {
 		Aggregation: view.LastValue(),
 	}
 
+	trQueuedDurationView = &view.View{
+		Description: trQueuedDuration.Description(),
+		Measure:     trQueuedDuration,
+		Aggregation: view.Distribution(1, 5, 10, 30, 60, 120, 300, 600, 1800, 3600),
+		TagKeys:     []tag.Key{namespaceTag},
+	}
+
+	prDAGDurationView = &view.View{
+		Description: prDAGDuration.Description(),
+		Measure:     prDAGDuration,
+		Aggregation: distribution,
+		TagKeys:     append([]tag.Key{statusTag, namespaceTag}, prunTag...),
+	}
+	prFinallyDurationView = &view.View{
+		Description: prFinallyDuration.Description(),
+		Measure:     prFinallyDuration,
+		Aggregation: distribution,
+		TagKeys:     append([]tag.Key{statusTag, namespaceTag}, prunTag...),
+	}
+	prFinallyTaskTotalView = &view.View{
+		Description: prFinallyTaskTotal.Description(),
+		Measure:     prFinallyTaskTotal,
+		Aggregation: view.Count(),
+		TagKeys:     finallyTaskTotalTags,
+	}
+
 	return view.Register(
 		prDurationView,
 		prCountView,
@@ -267,6 +318,10 @@ func viewRegister(cfg *config.Metrics) error {
 		runningPRsWaitingOnPipelineResolutionView,
 		runningPRsWaitingOnTaskResolutionCountView,
 		runningPRsWaitingOnTaskResolutionView,
+		trQueuedDurationView,
+		prDAGDurationView,
+		prFinallyDurationView,
+		prFinallyTaskTotalView,
 	)
 }
 
@@ -279,7 +334,11 @@ func viewUnregister() {
 		runningPRsWaitingOnPipelineResolutionCountView,
 		runningPRsWaitingOnPipelineResolutionView,
 		runningPRsWaitingOnTaskResolutionCountView,
-		runningPRsWaitingOnTaskResolutionView)
+		runningPRsWaitingOnTaskResolutionView,
+		trQueuedDurationView,
+		prDAGDurationView,
+		prFinallyDurationView,
+		prFinallyTaskTotalView)
 }
 
 // OnStore returns a function that checks if metrics are configured for a config.Store, and registers it if so
@@ -369,32 +428,81 @@ func (r *Recorder) DurationAndCount(pr *v1.PipelineRun, beforeCondition *apis.Co
 		}
 	}
 
+	ctx, err := r.pipelineRunTagContext(pr)
+	if err != nil {
+		return err
+	}
+
+	metrics.Record(ctx, prDuration.M(duration.Seconds()))
+	metrics.Record(ctx, prCount.M(1))
+	metrics.Record(ctx, prTotal.M(1))
+
+	return nil
+}
+
+// pipelineRunTagContext returns a context holding the tags of the metrics of the done
+// PipelineRun: its namespace, status and reason, and its pipeline and name depending on
+// the configured level.
+func (r *Recorder) pipelineRunTagContext(pr *v1.PipelineRun) (context.Context, error) {
 	cond := pr.Status.GetCondition(apis.ConditionSucceeded)
 	status := "success"
 	if cond.Status == corev1.ConditionFalse {
 		status = "failed"
-		if cond.Reason == v1.PipelineRunReasonCancelled.String() {
+		switch cond.Reason {
+		case v1.PipelineRunReasonCancelled.String():
 			status = "cancelled"
+		case v1.PipelineRunReasonSuperseded.String():
+			status = "superseded"
 		}
 	}
 	reason := cond.Reason
 
 	pipelineName := getPipelineTagName(pr)
 
-	ctx, err := tag.New(
+	return tag.New(
 		context.Background(),
 		append([]tag.Mutator{
 			tag.Insert(namespaceTag, pr.Namespace),
 			tag.Insert(statusTag, status), tag.Insert(reasonTag, reason),
 		}, r.insertTag(pipelineName, pr.Name)...)...)
-	if err != nil {
-		return err
+}
+
+// TaskRunsQueuedDuration logs the time the child TaskRuns of the PipelineRun were
+// queued before their pod started. It is logged once per TaskRun, when its pod start
+// time first appears in the summary of the PipelineRun compared to beforeSummary.
+// returns an error if it fails to log the metrics
+func (r *Recorder) TaskRunsQueuedDuration(pr *v1.PipelineRun, beforeSummary *v1.PipelineRunSummary) error {
+	if !r.initialized {
+		return fmt.Errorf("ignoring the metrics recording for %s , failed to initialize the metrics recorder", pr.Name)
+	}
+	if pr.Status.Summary == nil {
+		return nil
 	}
 
-	metrics.Record(ctx, prDuration.M(duration.Seconds()))
-	metrics.Record(ctx, prCount.M(1))
-	metrics.Record(ctx, prTotal.M(1))
+	started := map[string]bool{}
+	if beforeSummary != nil {
+		for _, q := range beforeSummary.QueuedTasks {
+			started[q.Name] = q.PodStartTime != nil
+		}
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
 
+	ctx, err := tag.New(context.Background(), tag.Insert(namespaceTag, pr.Namespace))
+	if err != nil {
+		return err
+	}
+	for _, q := range pr.Status.Summary.QueuedTasks {
+		if q.CreationTime == nil || q.PodStartTime == nil || started[q.Name] {
+			continue
+		}
+		queued := q.PodStartTime.Sub(q.CreationTime.Time)
+		if queued < 0 {
+			queued = 0
+		}
+		metrics.Record(ctx, trQueuedDuration.M(queued.Seconds()))
+	}
 	return nil
 }
 
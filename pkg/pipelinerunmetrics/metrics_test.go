@@ -86,6 +86,9 @@ func TestUninitializedMetrics(t *testing.T) {
 	if err := metrics.RunningPipelineRuns(nil); err == nil {
 		t.Error("Current PR count recording expected to return error but got nil")
 	}
+	if err := metrics.TaskRunsQueuedDuration(&v1.PipelineRun{}, nil); err == nil {
+		t.Error("TaskRunsQueuedDuration recording expected to return error but got nil")
+	}
 }
 
 func TestOnStore(t *testing.T) {
@@ -272,6 +275,40 @@ func TestRecordPipelineRunDurationCount(t *testing.T) {
 		expectedCount:    1,
 		beforeCondition:  nil,
 		countWithReason:  false,
+	}, {
+		name: "for superseded pipeline",
+		pipelineRun: &v1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun-1", Namespace: "ns"},
+			Spec: v1.PipelineRunSpec{
+				PipelineRef: &v1.PipelineRef{Name: "pipeline-1"},
+			},
+			Status: v1.PipelineRunStatus{
+				Status: duckv1.Status{
+					Conditions: duckv1.Conditions{{
+						Type:   apis.ConditionSucceeded,
+						Status: corev1.ConditionFalse,
+						Reason: v1.PipelineRunReasonSuperseded.String(),
+					}},
+				},
+				PipelineRunStatusFields: v1.PipelineRunStatusFields{
+					StartTime:      &startTime,
+					CompletionTime: &completionTime,
+				},
+			},
+		},
+		expectedDurationTags: map[string]string{
+			"pipeline":    "pipeline-1",
+			"pipelinerun": "pipelinerun-1",
+			"namespace":   "ns",
+			"status":      "superseded",
+		},
+		expectedCountTags: map[string]string{
+			"status": "superseded",
+		},
+		expectedDuration: 60,
+		expectedCount:    1,
+		beforeCondition:  nil,
+		countWithReason:  false,
 	}, {
 		name: "for failed pipeline",
 		pipelineRun: &v1.PipelineRun{
@@ -480,6 +517,76 @@ func TestRecordPipelineRunDurationCount(t *testing.T) {
 	}
 }
 
+func TestRecordTaskRunsQueuedDuration(t *testing.T) {
+	created := metav1.NewTime(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC))
+	podCreated := metav1.NewTime(created.Add(2 * time.Second))
+	fastStart := metav1.NewTime(created.Add(10 * time.Second))
+	slowStart := metav1.NewTime(created.Add(90 * time.Second))
+	queued := func(name string, podStart *metav1.Time) v1.PipelineTaskQueueTime {
+		return v1.PipelineTaskQueueTime{
+			Name:            name,
+			CreationTime:    &created,
+			PodCreationTime: &podCreated,
+			PodStartTime:    podStart,
+		}
+	}
+	for _, test := range []struct {
+		name          string
+		beforeSummary *v1.PipelineRunSummary
+		queuedTasks   []v1.PipelineTaskQueueTime
+		expectedCount int64
+		expectedMin   float64
+		expectedMax   float64
+	}{{
+		name:        "pods not started",
+		queuedTasks: []v1.PipelineTaskQueueTime{queued("pr-a", nil), queued("pr-b", nil)},
+	}, {
+		name:          "pods started",
+		beforeSummary: &v1.PipelineRunSummary{QueuedTasks: []v1.PipelineTaskQueueTime{queued("pr-a", nil), queued("pr-b", nil)}},
+		queuedTasks:   []v1.PipelineTaskQueueTime{queued("pr-a", &fastStart), queued("pr-b", &slowStart)},
+		expectedCount: 2,
+		expectedMin:   10,
+		expectedMax:   90,
+	}, {
+		name:          "one pod started since the previous summary",
+		beforeSummary: &v1.PipelineRunSummary{QueuedTasks: []v1.PipelineTaskQueueTime{queued("pr-a", &fastStart), queued("pr-b", nil)}},
+		queuedTasks:   []v1.PipelineTaskQueueTime{queued("pr-a", &fastStart), queued("pr-b", &slowStart)},
+		expectedCount: 1,
+		expectedMin:   90,
+		expectedMax:   90,
+	}, {
+		name:          "pods already started",
+		beforeSummary: &v1.PipelineRunSummary{QueuedTasks: []v1.PipelineTaskQueueTime{queued("pr-a", &fastStart)}},
+		queuedTasks:   []v1.PipelineTaskQueueTime{queued("pr-a", &fastStart)},
+	}} {
+		t.Run(test.name, func(t *testing.T) {
+			unregisterMetrics()
+
+			metrics, err := NewRecorder(getConfigContext(false))
+			if err != nil {
+				t.Fatalf("NewRecorder: %v", err)
+			}
+
+			pr := &v1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "pr", Namespace: "ns"},
+				Status: v1.PipelineRunStatus{
+					PipelineRunStatusFields: v1.PipelineRunStatusFields{
+						Summary: &v1.PipelineRunSummary{QueuedTasks: test.queuedTasks},
+					},
+				},
+			}
+			if err := metrics.TaskRunsQueuedDuration(pr, test.beforeSummary); err != nil {
+				t.Errorf("TaskRunsQueuedDuration: %v", err)
+			}
+			if test.expectedCount == 0 {
+				metricstest.CheckStatsNotReported(t, "pipelinerun_taskrun_queued_duration_seconds")
+				return
+			}
+			metricstest.CheckDistributionData(t, "pipelinerun_taskrun_queued_duration_seconds", map[string]string{"namespace": "ns"}, test.expectedCount, test.expectedMin, test.expectedMax)
+		})
+	}
+}
+
 func TestRecordRunningPipelineRunsCount(t *testing.T) {
 	unregisterMetrics()
 
@@ -732,7 +839,7 @@ func TestRecordRunningPipelineRunsResolutionWaitCounts(t *testing.T) {
 }
 
 func unregisterMetrics() {
-	metricstest.Unregister("pipelinerun_duration_seconds", "pipelinerun_count", "pipelinerun_total", "running_pipelineruns_waiting_on_pipeline_resolution_count", "running_pipelineruns_waiting_on_pipeline_resolution", "running_pipelineruns_waiting_on_task_resolution_count", "running_pipelineruns_waiting_on_task_resolution", "running_pipelineruns_count", "running_pipelineruns")
+	metricstest.Unregister("pipelinerun_duration_seconds", "pipelinerun_count", "pipelinerun_total", "running_pipelineruns_waiting_on_pipeline_resolution_count", "running_pipelineruns_waiting_on_pipeline_resolution", "running_pipelineruns_waiting_on_task_resolution_count", "running_pipelineruns_waiting_on_task_resolution", "running_pipelineruns_count", "running_pipelineruns", "pipelinerun_taskrun_queued_duration_seconds", "pipelinerun_dag_duration_seconds", "pipelinerun_finally_duration_seconds", "pipelinerun_finally_task_total")
 
 	// Allow the recorder singleton to be recreated.
 	once = sync.Once{}
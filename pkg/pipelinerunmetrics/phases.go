@@ -0,0 +1,134 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerunmetrics
+
+import (
+	"fmt"
+	"time"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"go.opencensus.io/tag"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/metrics"
+)
+
+// ChildRun holds the timestamps and the outcome of a child TaskRun or CustomRun
+// of a PipelineRun.
+type ChildRun struct {
+	// PipelineTaskName is the name of the PipelineTask of the run.
+	PipelineTaskName string
+	// StartTime is the time the run started at.
+	StartTime *metav1.Time
+	// CompletionTime is the time the run completed at.
+	CompletionTime *metav1.Time
+	// Condition is the Succeeded condition of the run.
+	Condition *apis.Condition
+}
+
+// PhaseDurationsAndCount logs the time the PipelineRun spent executing the tasks of
+// its DAG and its finally tasks, derived from the timestamps of the child runs of its
+// finally tasks, and counts the outcomes of those finally tasks. The DAG phase lasts
+// until the first finally task started, or until the PipelineRun completed if no
+// finally task ran, in which case nothing is logged for the finally phase.
+// returns an error if it fails to log the metrics
+func (r *Recorder) PhaseDurationsAndCount(pr *v1.PipelineRun, beforeCondition *apis.Condition, finallyRuns []ChildRun) error {
+	if !r.initialized {
+		return fmt.Errorf("ignoring the metrics recording for %s , failed to initialize the metrics recorder", pr.Name)
+	}
+
+	afterCondition := pr.Status.GetCondition(apis.ConditionSucceeded)
+	// To avoid recount
+	if equality.Semantic.DeepEqual(beforeCondition, afterCondition) {
+		return nil
+	}
+	if afterCondition == nil || pr.Status.StartTime == nil || pr.Status.CompletionTime == nil {
+		return nil
+	}
+
+	var finallyStart, finallyEnd *metav1.Time
+	for _, run := range finallyRuns {
+		if run.StartTime != nil && (finallyStart == nil || run.StartTime.Before(finallyStart)) {
+			finallyStart = run.StartTime
+		}
+		if run.CompletionTime != nil && (finallyEnd == nil || finallyEnd.Before(run.CompletionTime)) {
+			finallyEnd = run.CompletionTime
+		}
+	}
+	if finallyEnd == nil || finallyEnd.After(pr.Status.CompletionTime.Time) {
+		finallyEnd = pr.Status.CompletionTime
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	ctx, err := r.pipelineRunTagContext(pr)
+	if err != nil {
+		return err
+	}
+
+	dagEnd := pr.Status.CompletionTime
+	if finallyStart != nil {
+		dagEnd = finallyStart
+	}
+	metrics.Record(ctx, prDAGDuration.M(phaseDuration(pr.Status.StartTime, dagEnd).Seconds()))
+	if finallyStart == nil {
+		return nil
+	}
+	metrics.Record(ctx, prFinallyDuration.M(phaseDuration(finallyStart, finallyEnd).Seconds()))
+
+	for _, run := range finallyRuns {
+		status, done := childRunStatus(run.Condition)
+		if !done {
+			continue
+		}
+		ctx, err := tag.New(ctx, tag.Upsert(statusTag, status))
+		if err != nil {
+			return err
+		}
+		metrics.Record(ctx, prFinallyTaskTotal.M(1))
+	}
+	return nil
+}
+
+// phaseDuration returns the time elapsed between start and end, or zero if end
+// is before start, e.g. because of a clock skew between the nodes.
+func phaseDuration(start, end *metav1.Time) time.Duration {
+	d := end.Sub(start.Time)
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
+// childRunStatus returns the status tag of a child run from its Succeeded
+// condition, and whether the run is done.
+func childRunStatus(cond *apis.Condition) (string, bool) {
+	switch {
+	case cond == nil || cond.Status == corev1.ConditionUnknown:
+		return "", false
+	case cond.Status == corev1.ConditionTrue:
+		return "success", true
+	case cond.Reason == v1.TaskRunReasonCancelled.String() || cond.Reason == v1beta1.CustomRunReasonCancelled.String():
+		return "cancelled", true
+	default:
+		return "failed", true
+	}
+}
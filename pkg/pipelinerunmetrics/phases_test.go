@@ -0,0 +1,200 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerunmetrics
+
+import (
+	"testing"
+	"time"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"go.opencensus.io/metric/metricproducer"
+	"go.opencensus.io/stats/view"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/metrics/metricstest"
+)
+
+func TestRecordPipelineRunPhaseDurationsAndCount(t *testing.T) {
+	start := metav1.NewTime(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC))
+	at := func(d time.Duration) *metav1.Time {
+		t := metav1.NewTime(start.Add(d))
+		return &t
+	}
+	succeeded := &apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue}
+	failed := &apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionFalse, Reason: v1.TaskRunReasonFailed.String()}
+	running := &apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionUnknown}
+	prTags := map[string]string{
+		"pipeline":    "pipeline-1",
+		"pipelinerun": "pipelinerun-1",
+		"namespace":   "ns",
+		"status":      "success",
+	}
+	finallyTaskTags := func(status string) map[string]string {
+		return map[string]string{
+			"pipeline":    "pipeline-1",
+			"pipelinerun": "pipelinerun-1",
+			"namespace":   "ns",
+			"status":      status,
+		}
+	}
+
+	for _, test := range []struct {
+		name                    string
+		finallyRuns             []ChildRun
+		beforeCondition         *apis.Condition
+		expectedDAGDuration     float64
+		expectedFinallyDuration float64
+		expectedFinallyCounts   map[string]int64
+	}{{
+		name:                "without finally tasks",
+		expectedDAGDuration: 600,
+	}, {
+		name: "with finally tasks",
+		finallyRuns: []ChildRun{{
+			PipelineTaskName: "cleanup",
+			StartTime:        at(8 * time.Minute),
+			CompletionTime:   at(9 * time.Minute),
+			Condition:        succeeded,
+		}, {
+			PipelineTaskName: "notify",
+			StartTime:        at(7 * time.Minute),
+			CompletionTime:   at(9*time.Minute + 30*time.Second),
+			Condition:        succeeded,
+		}},
+		expectedDAGDuration:     420,
+		expectedFinallyDuration: 150,
+		expectedFinallyCounts:   map[string]int64{"success": 2},
+	}, {
+		name: "with a failing finally task",
+		finallyRuns: []ChildRun{{
+			PipelineTaskName: "cleanup",
+			StartTime:        at(7 * time.Minute),
+			CompletionTime:   at(8 * time.Minute),
+			Condition:        failed,
+		}, {
+			PipelineTaskName: "notify",
+			StartTime:        at(7 * time.Minute),
+			CompletionTime:   at(9 * time.Minute),
+			Condition:        succeeded,
+		}},
+		expectedDAGDuration:     420,
+		expectedFinallyDuration: 120,
+		expectedFinallyCounts:   map[string]int64{"success": 1, "failed": 1},
+	}, {
+		name: "with a finally task still running",
+		finallyRuns: []ChildRun{{
+			PipelineTaskName: "cleanup",
+			StartTime:        at(5 * time.Minute),
+			Condition:        running,
+		}},
+		expectedDAGDuration:     300,
+		expectedFinallyDuration: 300,
+	}, {
+		name:            "condition unchanged",
+		beforeCondition: succeeded,
+		finallyRuns: []ChildRun{{
+			PipelineTaskName: "cleanup",
+			StartTime:        at(7 * time.Minute),
+			CompletionTime:   at(8 * time.Minute),
+			Condition:        succeeded,
+		}},
+	}} {
+		t.Run(test.name, func(t *testing.T) {
+			unregisterMetrics()
+
+			metrics, err := NewRecorder(getConfigContext(false))
+			if err != nil {
+				t.Fatalf("NewRecorder: %v", err)
+			}
+
+			pr := &v1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun-1", Namespace: "ns"},
+				Spec: v1.PipelineRunSpec{
+					PipelineRef: &v1.PipelineRef{Name: "pipeline-1"},
+				},
+				Status: v1.PipelineRunStatus{
+					Status: duckv1.Status{
+						Conditions: duckv1.Conditions{*succeeded},
+					},
+					PipelineRunStatusFields: v1.PipelineRunStatusFields{
+						StartTime:      &start,
+						CompletionTime: at(10 * time.Minute),
+					},
+				},
+			}
+			if err := metrics.PhaseDurationsAndCount(pr, test.beforeCondition, test.finallyRuns); err != nil {
+				t.Errorf("PhaseDurationsAndCount: %v", err)
+			}
+
+			if test.expectedDAGDuration == 0 {
+				metricstest.CheckStatsNotReported(t, "pipelinerun_dag_duration_seconds")
+			} else {
+				metricstest.CheckLastValueData(t, "pipelinerun_dag_duration_seconds", prTags, test.expectedDAGDuration)
+			}
+			if test.expectedFinallyDuration == 0 {
+				metricstest.CheckStatsNotReported(t, "pipelinerun_finally_duration_seconds")
+			} else {
+				metricstest.CheckLastValueData(t, "pipelinerun_finally_duration_seconds", prTags, test.expectedFinallyDuration)
+			}
+			if len(test.expectedFinallyCounts) == 0 {
+				metricstest.CheckStatsNotReported(t, "pipelinerun_finally_task_total")
+			}
+			for status, count := range test.expectedFinallyCounts {
+				checkCountDataForTags(t, "pipelinerun_finally_task_total", finallyTaskTags(status), count)
+			}
+		})
+	}
+}
+
+func checkCountDataForTags(t *testing.T, name string, wantTags map[string]string, expected int64) {
+	t.Helper()
+	for _, producer := range metricproducer.GlobalManager().GetAll() {
+		meter := producer.(view.Meter)
+		data, err := meter.RetrieveData(name)
+		if err != nil || len(data) == 0 {
+			continue
+		}
+		row := getRow(data, wantTags)
+		if row == nil {
+			t.Error("Found no data for ", name, wantTags)
+		} else if got := row.Data.(*view.CountData).Value; expected != got {
+			t.Error("Value did not match for ", name, wantTags, ", expected", expected, "got", got)
+		}
+	}
+}
+
+// Returns the row matching the tags, or nil if no row is matched.
+func getRow(rows []*view.Row, wantTags map[string]string) *view.Row {
+	for _, row := range rows {
+		if len(wantTags) != len(row.Tags) {
+			continue
+		}
+		matched := true
+		for _, got := range row.Tags {
+			if wantTags[got.Key.Name()] != got.Value {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return row
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/substitution"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// The build metadata environment variables injected into the steps and
+// sidecars when the enable-build-metadata-env feature flag is set.
+const (
+	TaskRunNameEnvVar            = "TEKTON_TASKRUN_NAME"
+	TaskRunNamespaceEnvVar       = "TEKTON_TASKRUN_NAMESPACE"
+	TaskRunUIDEnvVar             = "TEKTON_TASKRUN_UID"
+	TaskNameEnvVar               = "TEKTON_TASK_NAME"
+	TaskRunAttemptEnvVar         = "TEKTON_TASKRUN_ATTEMPT"
+	PipelineRunNameEnvVar        = "TEKTON_PIPELINERUN_NAME"
+	PipelineRunUIDEnvVar         = "TEKTON_PIPELINERUN_UID"
+	PipelineNameEnvVar           = "TEKTON_PIPELINE_NAME"
+	PipelineTaskNameEnvVar       = "TEKTON_PIPELINE_TASK_NAME"
+	MatrixCombinationIndexEnvVar = "TEKTON_MATRIX_COMBINATION_INDEX"
+)
+
+// buildMetadataEnv returns the build metadata environment variables of the
+// TaskRun: the built-in ones which have a value, followed by the configured
+// ones sorted by name, whose references to the context variables are
+// replaced. A configured variable replaces the built-in one of the same name.
+func buildMetadataEnv(taskRun *v1.TaskRun, configured map[string]string) []corev1.EnvVar {
+	taskName := taskRun.Labels[pipeline.TaskLabelKey]
+	if taskName == "" && taskRun.Spec.TaskRef == nil {
+		// The name of an embedded Task is the name of its TaskRun.
+		taskName = taskRun.Name
+	}
+	retryCount := len(taskRun.Status.RetriesStatus)
+	contextValues := map[string]string{
+		"context.taskRun.name":      taskRun.Name,
+		"context.taskRun.namespace": taskRun.Namespace,
+		"context.taskRun.uid":       string(taskRun.UID),
+		"context.task.name":         taskName,
+		"context.task.retry-count":  strconv.Itoa(retryCount),
+		"context.pipelineRun.name":  taskRun.Labels[pipeline.PipelineRunLabelKey],
+		"context.pipelineRun.uid":   taskRun.Labels[pipeline.PipelineRunUIDLabelKey],
+		"context.pipeline.name":     taskRun.Labels[pipeline.PipelineLabelKey],
+	}
+
+	builtin := []corev1.EnvVar{
+		{Name: TaskRunNameEnvVar, Value: taskRun.Name},
+		{Name: TaskRunNamespaceEnvVar, Value: taskRun.Namespace},
+		{Name: TaskRunUIDEnvVar, Value: string(taskRun.UID)},
+		{Name: TaskNameEnvVar, Value: taskName},
+		{Name: TaskRunAttemptEnvVar, Value: strconv.Itoa(retryCount + 1)},
+		{Name: PipelineRunNameEnvVar, Value: taskRun.Labels[pipeline.PipelineRunLabelKey]},
+		{Name: PipelineRunUIDEnvVar, Value: taskRun.Labels[pipeline.PipelineRunUIDLabelKey]},
+		{Name: PipelineNameEnvVar, Value: taskRun.Labels[pipeline.PipelineLabelKey]},
+		{Name: PipelineTaskNameEnvVar, Value: taskRun.Labels[pipeline.PipelineTaskLabelKey]},
+		{Name: MatrixCombinationIndexEnvVar, Value: taskRun.Annotations[v1.MatrixCombinationIndexAnnotation]},
+	}
+	env := []corev1.EnvVar{}
+	for _, e := range builtin {
+		if _, ok := configured[e.Name]; !ok && e.Value != "" {
+			env = append(env, e)
+		}
+	}
+	names := make([]string, 0, len(configured))
+	for name := range configured {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		env = append(env, corev1.EnvVar{Name: name, Value: substitution.ApplyReplacements(configured[name], contextValues)})
+	}
+	return env
+}
+
+// injectBuildMetadataEnv appends the build metadata environment variables to
+// the env of the containers, except the ones the containers already define so
+// that the variables set by the users are never overridden.
+func injectBuildMetadataEnv(containers []corev1.Container, env []corev1.EnvVar) {
+	for i, c := range containers {
+		defined := make(map[string]bool, len(c.Env))
+		for _, e := range c.Env {
+			defined[e.Name] = true
+		}
+		for _, e := range env {
+			if !defined[e.Name] {
+				containers[i].Env = append(containers[i].Env, e)
+			}
+		}
+	}
+}
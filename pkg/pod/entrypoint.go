@@ -63,6 +63,14 @@ const (
 	downwardMountCancelFile = "cancel"
 	cancelAnnotation        = "tekton.dev/cancel"
 	cancelAnnotationValue   = "CANCEL"
+
+	downwardMountPauseFile = "pause"
+	pauseAnnotation        = "tekton.dev/pause"
+	pauseAnnotationValue   = "PAUSE"
+
+	// stdinValueEnvVar is the env var through which the value fed to the
+	// standard input of a step is passed to the entrypoint.
+	stdinValueEnvVar = pipeline.TektonStdinValueEnvVar
 )
 
 var (
@@ -91,6 +99,12 @@ var (
 			FieldPath: fmt.Sprintf("metadata.annotations['%s']", cancelAnnotation),
 		},
 	}
+	downwardPauseVolumeItem = corev1.DownwardAPIVolumeFile{
+		Path: downwardMountPauseFile,
+		FieldRef: &corev1.ObjectFieldSelector{
+			FieldPath: fmt.Sprintf("metadata.annotations['%s']", pauseAnnotation),
+		},
+	}
 	// TODO(#1605): Signal sidecar readiness by injecting entrypoint,
 	// remove dependency on Downward API.
 	downwardVolume = corev1.Volume{
@@ -115,6 +129,9 @@ var (
 	}
 	// DownwardMountCancelFile is cancellation file mount to step, entrypoint will check this file to cancel the step.
 	DownwardMountCancelFile = filepath.Join(downwardMountPoint, downwardMountCancelFile)
+	// DownwardMountPauseFile is the pause file mounted to the steps, the entrypoint waits for it to be empty before
+	// starting the step.
+	DownwardMountPauseFile = filepath.Join(downwardMountPoint, downwardMountPauseFile)
 )
 
 // orderContainers returns the specified steps, modified so that they are
@@ -124,7 +141,7 @@ var (
 // command, we must have fetched the image's ENTRYPOINT before calling this
 // method, using entrypoint_lookup.go.
 // Additionally, Step timeouts are added as entrypoint flag.
-func orderContainers(ctx context.Context, commonExtraEntrypointArgs []string, steps []corev1.Container, taskSpec *v1.TaskSpec, breakpointConfig *v1.TaskRunDebug, waitForReadyAnnotation, enableKeepPodOnCancel bool) ([]corev1.Container, error) {
+func orderContainers(ctx context.Context, commonExtraEntrypointArgs []string, steps []corev1.Container, taskSpec *v1.TaskSpec, breakpointConfig *v1.TaskRunDebug, waitForReadyAnnotation, mountDownwardInAllSteps bool) ([]corev1.Container, error) {
 	if len(steps) == 0 {
 		return nil, errors.New("no steps specified")
 	}
@@ -143,6 +160,14 @@ func orderContainers(ctx context.Context, commonExtraEntrypointArgs []string, st
 		} else { // Not the first step - wait for previous
 			argsForEntrypoint = append(argsForEntrypoint, "-wait_file", filepath.Join(RunDir, strconv.Itoa(i-1), "out"))
 		}
+		var dependsOnSidecars []string
+		if taskSpec != nil && len(taskSpec.Steps) >= i+1 {
+			dependsOnSidecars = taskSpec.Steps[i].DependsOnSidecars
+		}
+		if len(dependsOnSidecars) > 0 {
+			// The step also waits for the sidecars it depends on.
+			argsForEntrypoint = append(argsForEntrypoint, "-ready_file", sidecarReadyFiles(dependsOnSidecars))
+		}
 		argsForEntrypoint = append(argsForEntrypoint,
 			// Start next step.
 			"-post_file", filepath.Join(RunDir, idx, "out"),
@@ -169,6 +194,16 @@ func orderContainers(ctx context.Context, commonExtraEntrypointArgs []string, st
 				if taskSpec.Steps[i].StderrConfig != nil {
 					argsForEntrypoint = append(argsForEntrypoint, "-stderr_path", taskSpec.Steps[i].StderrConfig.Path)
 				}
+				if stdin := taskSpec.Steps[i].StdinFrom; stdin != nil {
+					if stdin.Path != "" {
+						argsForEntrypoint = append(argsForEntrypoint, "-stdin_path", stdin.Path)
+					} else {
+						// The value is passed through the environment rather than
+						// the args, which are visible to any process of the pod.
+						argsForEntrypoint = append(argsForEntrypoint, "-stdin_from_env", stdinValueEnvVar)
+						steps[i].Env = append(steps[i].Env, corev1.EnvVar{Name: stdinValueEnvVar, Value: stdin.Value})
+					}
+				}
 				// add step results
 				stepResultArgs := stepResultArgument(taskSpec.Steps[i].Results)
 
@@ -194,7 +229,11 @@ func orderContainers(ctx context.Context, commonExtraEntrypointArgs []string, st
 		}
 
 		cmd, args := s.Command, s.Args
-		if len(cmd) > 0 {
+		switch {
+		case len(cmd) > 0 && isWorkspaceScriptStep(taskSpec, i):
+			// The entrypoint reads and executes the script of the workspace.
+			argsForEntrypoint = append(argsForEntrypoint, "-script_file", cmd[0])
+		case len(cmd) > 0:
 			argsForEntrypoint = append(argsForEntrypoint, "-entrypoint", cmd[0])
 		}
 		if len(cmd) > 1 {
@@ -206,9 +245,10 @@ func orderContainers(ctx context.Context, commonExtraEntrypointArgs []string, st
 		steps[i].Command = []string{entrypointBinary}
 		steps[i].Args = argsForEntrypoint
 		steps[i].TerminationMessagePath = terminationPath
-		if (i == 0 && waitForReadyAnnotation) || enableKeepPodOnCancel {
+		if (i == 0 && waitForReadyAnnotation) || mountDownwardInAllSteps || len(dependsOnSidecars) > 0 {
 			// Mount the Downward volume into the first step container.
-			// if enableKeepPodOnCancel is true, mount the Downward volume into all the steps.
+			// if keep-pod-on-cancel or the TaskRun pause is enabled, mount the Downward volume into all the steps.
+			// Mount it into the steps depending on sidecars as well.
 			steps[i].VolumeMounts = append(steps[i].VolumeMounts, downwardMount)
 		}
 	}
@@ -216,6 +256,15 @@ func orderContainers(ctx context.Context, commonExtraEntrypointArgs []string, st
 	return steps, nil
 }
 
+// isWorkspaceScriptStep reports whether the script of the step at index i is a file of a workspace.
+func isWorkspaceScriptStep(taskSpec *v1.TaskSpec, i int) bool {
+	if taskSpec == nil || len(taskSpec.Steps) < i+1 {
+		return false
+	}
+	scriptFrom := taskSpec.Steps[i].ScriptFrom
+	return scriptFrom != nil && scriptFrom.Workspace != nil
+}
+
 // stepResultArgument creates the cli arguments for step results to the entrypointer.
 func stepResultArgument(stepResults []v1.StepResult) []string {
 	if len(stepResults) == 0 {
@@ -279,6 +328,37 @@ func CancelPod(ctx context.Context, kubeClient kubernetes.Interface, namespace,
 	return err
 }
 
+// UpdatePause updates the Pod's pause annotation to signal the steps which
+// haven't started yet to wait for the TaskRun to be resumed, or to start once
+// it is resumed, by projecting the annotation via the Downward API.
+func UpdatePause(ctx context.Context, kubeclient kubernetes.Interface, pod *corev1.Pod, paused bool) error {
+	value := ""
+	if paused {
+		value = pauseAnnotationValue
+	}
+	// Don't PATCH if the annotation already has the value.
+	if pod.Annotations[pauseAnnotation] == value {
+		return nil
+	}
+
+	// PATCH the Pod's annotations with a merge patch, which removes the
+	// annotation when it is set to null, so that the pause file is empty.
+	var patchValue interface{}
+	if paused {
+		patchValue = value
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{pauseAnnotation: patchValue},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = kubeclient.CoreV1().Pods(pod.Namespace).Patch(ctx, pod.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
 // UpdateReady updates the Pod's annotations to signal the first step to start
 // by projecting the ready annotation via the Downward API.
 func UpdateReady(ctx context.Context, kubeclient kubernetes.Interface, pod corev1.Pod) error {
@@ -317,7 +397,7 @@ func StopSidecars(ctx context.Context, nopImage string, kubeclient kubernetes.In
 			// An injected sidecar container might not have the
 			// "sidecar-" prefix, so we can't just look for that
 			// prefix.
-			if !IsContainerStep(s.Name) && s.State.Running != nil {
+			if !IsPodContainerStep(newPod, s.Name) && s.State.Running != nil {
 				for j, c := range newPod.Spec.Containers {
 					if c.Name == s.Name && c.Image != nopImage {
 						updated = true
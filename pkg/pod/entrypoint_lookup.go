@@ -22,6 +22,7 @@ import (
 
 	"github.com/google/go-containerregistry/pkg/name"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -91,7 +92,7 @@ func resolveEntrypoints(ctx context.Context, cache EntrypointCache, namespace, s
 			return nil, err
 		}
 		steps[i].Env = append(steps[i].Env, corev1.EnvVar{
-			Name:  "TEKTON_PLATFORM_COMMANDS",
+			Name:  pipeline.TektonPlatformCommandsEnvVar,
 			Value: string(b),
 		})
 	}
@@ -802,6 +802,150 @@ func TestEntryPointStepOutputConfigs(t *testing.T) {
 	}
 }
 
+func TestEntryPointStepStdinFrom(t *testing.T) {
+	taskSpec := v1.TaskSpec{
+		Steps: []v1.Step{{
+			StdinFrom: &v1.StepStdinSource{
+				Value: `{"from":"param"}`,
+			},
+		}, {
+			StdinFrom: &v1.StepStdinSource{
+				Path: "/workspace/source/input.json",
+			},
+		}},
+	}
+
+	steps := []corev1.Container{{
+		Image:   "step-1",
+		Command: []string{"jq"},
+	}, {
+		Image:   "step-2",
+		Command: []string{"jq"},
+	}}
+	want := []corev1.Container{{
+		Image:   "step-1",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-wait_file", "/tekton/downward/ready",
+			"-wait_file_content",
+			"-post_file", "/tekton/run/0/out",
+			"-termination_path", "/tekton/termination",
+			"-step_metadata_dir", "/tekton/run/0/status",
+			"-stdin_from_env", "TEKTON_STEP_STDIN_VALUE",
+			"-entrypoint", "jq", "--",
+		},
+		Env:                    []corev1.EnvVar{{Name: "TEKTON_STEP_STDIN_VALUE", Value: `{"from":"param"}`}},
+		VolumeMounts:           []corev1.VolumeMount{downwardMount},
+		TerminationMessagePath: "/tekton/termination",
+	}, {
+		Image:   "step-2",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-wait_file", "/tekton/run/0/out",
+			"-post_file", "/tekton/run/1/out",
+			"-termination_path", "/tekton/termination",
+			"-step_metadata_dir", "/tekton/run/1/status",
+			"-stdin_path", "/workspace/source/input.json",
+			"-entrypoint", "jq", "--",
+		},
+		TerminationMessagePath: "/tekton/termination",
+	}}
+	got, err := orderContainers(t.Context(), []string{}, steps, &taskSpec, nil, true, false)
+	if err != nil {
+		t.Fatalf("orderContainers: %v", err)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestOrderContainersWithSidecarDependencies(t *testing.T) {
+	taskSpec := v1.TaskSpec{
+		Steps: []v1.Step{{
+			Name: "build",
+		}, {
+			Name:              "migrate",
+			DependsOnSidecars: []string{"db", "cache"},
+		}},
+		Sidecars: []v1.Sidecar{{Name: "db"}, {Name: "cache"}},
+	}
+
+	steps := []corev1.Container{{
+		Image:   "step-1",
+		Command: []string{"cmd"},
+	}, {
+		Image:   "step-2",
+		Command: []string{"cmd"},
+	}}
+	want := []corev1.Container{{
+		Image:   "step-1",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-post_file", "/tekton/run/0/out",
+			"-termination_path", "/tekton/termination",
+			"-step_metadata_dir", "/tekton/run/0/status",
+			"-entrypoint", "cmd", "--",
+		},
+		TerminationMessagePath: "/tekton/termination",
+	}, {
+		Image:   "step-2",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-wait_file", "/tekton/run/0/out",
+			"-ready_file", "/tekton/downward/sidecar-ready/sidecar-db,/tekton/downward/sidecar-ready/sidecar-cache",
+			"-post_file", "/tekton/run/1/out",
+			"-termination_path", "/tekton/termination",
+			"-step_metadata_dir", "/tekton/run/1/status",
+			"-entrypoint", "cmd", "--",
+		},
+		VolumeMounts:           []corev1.VolumeMount{downwardMount},
+		TerminationMessagePath: "/tekton/termination",
+	}}
+	got, err := orderContainers(t.Context(), []string{}, steps, &taskSpec, nil, false, false)
+	if err != nil {
+		t.Fatalf("orderContainers: %v", err)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestOrderContainersWithWorkspaceScript(t *testing.T) {
+	taskSpec := v1.TaskSpec{
+		Steps: []v1.Step{{
+			Name: "build",
+			ScriptFrom: &v1.StepScriptSource{Workspace: &v1.WorkspaceFileSelector{
+				Name: "source",
+				Path: "build.sh",
+			}},
+		}},
+	}
+	steps := []corev1.Container{{
+		Image:   "step-1",
+		Command: []string{"/workspace/source/build.sh"},
+		Args:    []string{"my", "args"},
+	}}
+	want := []corev1.Container{{
+		Image:   "step-1",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-post_file", "/tekton/run/0/out",
+			"-termination_path", "/tekton/termination",
+			"-step_metadata_dir", "/tekton/run/0/status",
+			"-script_file", "/workspace/source/build.sh", "--",
+			"my", "args",
+		},
+		TerminationMessagePath: "/tekton/termination",
+	}}
+	got, err := orderContainers(t.Context(), []string{}, steps, &taskSpec, nil, false, false)
+	if err != nil {
+		t.Fatalf("orderContainers: %v", err)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestUpdateReady(t *testing.T) {
 	for _, c := range []struct {
 		desc            string
@@ -1097,6 +1241,152 @@ func TestStopSidecars(t *testing.T) {
 	}
 }
 
+func TestUpdateSidecarsReady(t *testing.T) {
+	running := corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+	terminated := corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}}
+	for _, c := range []struct {
+		desc            string
+		pod             corev1.Pod
+		wantAnnotations map[string]string
+	}{{
+		desc: "ready and terminated sidecars are signaled",
+		pod: corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "pod",
+				Annotations: map[string]string{sidecarDependenciesAnnotation: "sidecar-db,sidecar-cache,sidecar-queue"},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name:  "step-migrate",
+					State: running,
+					Ready: true,
+				}, {
+					Name:  "sidecar-db",
+					State: running,
+					Ready: true,
+				}, {
+					Name:  "sidecar-cache",
+					State: terminated,
+				}, {
+					Name:  "sidecar-queue",
+					State: running,
+				}, {
+					Name:  "sidecar-proxy",
+					State: running,
+					Ready: true,
+				}},
+			},
+		},
+		wantAnnotations: map[string]string{
+			sidecarDependenciesAnnotation:                  "sidecar-db,sidecar-cache,sidecar-queue",
+			sidecarReadyAnnotationPrefix + "sidecar-db":    readyAnnotationValue,
+			sidecarReadyAnnotationPrefix + "sidecar-cache": readyAnnotationValue,
+		},
+	}, {
+		desc: "pod without sidecar dependencies isn't patched",
+		pod: corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "pod"},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name:  "sidecar-db",
+					State: running,
+					Ready: true,
+				}},
+			},
+		},
+	}, {
+		desc: "pending pod isn't patched",
+		pod: corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "pod",
+				Annotations: map[string]string{sidecarDependenciesAnnotation: "sidecar-db"},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodPending},
+		},
+		wantAnnotations: map[string]string{sidecarDependenciesAnnotation: "sidecar-db"},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			ctx := t.Context()
+			kubeclient := fakek8s.NewSimpleClientset(&c.pod)
+			if err := UpdateSidecarsReady(ctx, kubeclient, &c.pod); err != nil {
+				t.Fatalf("UpdateSidecarsReady: %v", err)
+			}
+			got, err := kubeclient.CoreV1().Pods(c.pod.Namespace).Get(ctx, c.pod.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Getting pod %q after update: %v", c.pod.Name, err)
+			}
+			if d := cmp.Diff(c.wantAnnotations, got.Annotations); d != "" {
+				t.Errorf("Annotations Diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+// TestStopSidecarsAfterSteps tests stopping the sidecars once the steps they
+// are stopped after are finished, while the other sidecars keep running.
+func TestStopSidecarsAfterSteps(t *testing.T) {
+	running := corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+	terminated := corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}}
+	containers := []corev1.Container{
+		{Name: "step-migrate", Image: "migrate"},
+		{Name: "seed", Image: "seed"},
+		{Name: "step-test", Image: "test"},
+		{Name: "sidecar-db", Image: "postgres"},
+		{Name: "sidecar-cache", Image: "redis"},
+	}
+	annotations := map[string]string{
+		sidecarStopAfterStepsAnnotation: "sidecar-db=step-migrate;seed",
+		stepContainerNamesAnnotation:    "seed=step-seed",
+	}
+	for _, c := range []struct {
+		desc       string
+		statuses   []corev1.ContainerStatus
+		wantImages []string
+	}{{
+		desc: "sidecar is stopped once its steps are finished",
+		statuses: []corev1.ContainerStatus{
+			{Name: "step-migrate", State: terminated},
+			{Name: "seed", State: terminated},
+			{Name: "step-test", State: running},
+			{Name: "sidecar-db", State: running},
+			{Name: "sidecar-cache", State: running},
+		},
+		wantImages: []string{"migrate", "seed", "test", nopImage, "redis"},
+	}, {
+		desc: "sidecar keeps running until all its steps are finished",
+		statuses: []corev1.ContainerStatus{
+			{Name: "step-migrate", State: terminated},
+			{Name: "seed", State: running},
+			{Name: "step-test", State: running},
+			{Name: "sidecar-db", State: running},
+			{Name: "sidecar-cache", State: running},
+		},
+		wantImages: []string{"migrate", "seed", "test", "postgres", "redis"},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod", Annotations: annotations},
+				Spec:       corev1.PodSpec{Containers: containers},
+				Status:     corev1.PodStatus{Phase: corev1.PodRunning, ContainerStatuses: c.statuses},
+			}
+			kubeclient := fakek8s.NewSimpleClientset(pod)
+			got, err := StopSidecarsAfterSteps(t.Context(), nopImage, kubeclient, pod)
+			if err != nil {
+				t.Fatalf("StopSidecarsAfterSteps: %v", err)
+			}
+			var gotImages []string
+			for _, c := range got.Spec.Containers {
+				gotImages = append(gotImages, c.Image)
+			}
+			if d := cmp.Diff(c.wantImages, gotImages); d != "" {
+				t.Errorf("Images Diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestCancelPod(t *testing.T) {
 	pod := &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
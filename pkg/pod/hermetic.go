@@ -0,0 +1,139 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/result"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// pingGroupRangeSysctl is the namespaced sysctl of the groups allowed to
+	// open ICMP sockets, which Kubernetes considers safe.
+	pingGroupRangeSysctl = "net.ipv4.ping_group_range"
+	// pingGroupRangeNone is the empty range of groups, which no group belongs to.
+	pingGroupRangeNone = "1 0"
+
+	// reportHermeticViolationsArg makes the entrypoint write the number of
+	// network failures of the step to the termination message.
+	reportHermeticViolationsArg = "-report_hermetic_violations"
+)
+
+// isHermetic returns true if the TaskRun requested the hermetic execution
+// mode, which requires the alpha API.
+func isHermetic(ctx context.Context, taskRun *v1.TaskRun) bool {
+	return taskRun.Annotations[ExecutionModeAnnotation] == ExecutionModeHermetic &&
+		config.FromContextOrDefaults(ctx).FeatureFlags.EnableAPIFields == config.AlphaAPIFields
+}
+
+// isHermeticHardened returns true if the pod of the TaskRun is run
+// hermetically and the enable-hermetic-hardening feature flag is set. Windows
+// pods aren't hardened since they don't support the capabilities nor the
+// sysctls.
+func isHermeticHardened(ctx context.Context, taskRun *v1.TaskRun) bool {
+	return isHermetic(ctx, taskRun) &&
+		config.FromContextOrDefaults(ctx).FeatureFlags.EnableHermeticHardening &&
+		!usesWindows(taskRun)
+}
+
+// hardenHermeticSteps drops the NET_RAW capability of the step containers, so
+// that they can't craft packets with raw sockets even if they end up in a
+// network namespace with interfaces.
+func hardenHermeticSteps(stepContainers []corev1.Container) {
+	for i, s := range stepContainers {
+		sc := &corev1.SecurityContext{}
+		if s.SecurityContext != nil {
+			sc = s.SecurityContext.DeepCopy()
+		}
+		if sc.Capabilities == nil {
+			sc.Capabilities = &corev1.Capabilities{}
+		}
+		if !hasCapability(sc.Capabilities.Drop, "NET_RAW") {
+			sc.Capabilities.Drop = append(sc.Capabilities.Drop, "NET_RAW")
+		}
+		stepContainers[i].SecurityContext = sc
+	}
+}
+
+// hardenHermeticPodSecurityContext denies the ICMP sockets of the pod, unless
+// its security context already sets the sysctl.
+func hardenHermeticPodSecurityContext(securityContext *corev1.PodSecurityContext) *corev1.PodSecurityContext {
+	if securityContext == nil {
+		securityContext = &corev1.PodSecurityContext{}
+	} else {
+		securityContext = securityContext.DeepCopy()
+	}
+	for _, s := range securityContext.Sysctls {
+		if s.Name == pingGroupRangeSysctl {
+			return securityContext
+		}
+	}
+	securityContext.Sysctls = append(securityContext.Sysctls, corev1.Sysctl{Name: pingGroupRangeSysctl, Value: pingGroupRangeNone})
+	return securityContext
+}
+
+// hermeticNetworkSidecar returns the sidecar configured to deny the network of
+// the pods of the TaskRuns run hermetically, if any.
+func hermeticNetworkSidecar(ctx context.Context, taskRun *v1.TaskRun) *config.InjectedSidecar {
+	if !isHermeticHardened(ctx, taskRun) {
+		return nil
+	}
+	return config.FromContextOrDefaults(ctx).Defaults.DefaultHermeticNetworkSidecar
+}
+
+// networkAdminSecurityContext returns the security context of the hermetic
+// network sidecar, which needs the NET_ADMIN capability to configure the
+// network namespace of the pod.
+func networkAdminSecurityContext() *corev1.SecurityContext {
+	return &corev1.SecurityContext{
+		Capabilities: &corev1.Capabilities{
+			Add: []corev1.Capability{"NET_ADMIN"},
+		},
+	}
+}
+
+func hasCapability(capabilities []corev1.Capability, capability corev1.Capability) bool {
+	for _, c := range capabilities {
+		if c == capability || c == "ALL" {
+			return true
+		}
+	}
+	return false
+}
+
+// extractHermeticViolationsFromResults returns the number of network failures
+// of a step written by the entrypoint when the step was run hermetically, if
+// any.
+func extractHermeticViolationsFromResults(results []result.RunResult) (*int32, error) {
+	for _, r := range results {
+		if r.ResultType == result.InternalTektonResultType && r.Key == result.HermeticViolationsKey {
+			i, err := strconv.ParseInt(r.Value, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("could not parse int value %q in %s field: %w", r.Value, result.HermeticViolationsKey, err)
+			}
+			violations := int32(i) // #nosec G115: ParseInt was called with bit size 32, so this is safe
+			return &violations, nil
+		}
+	}
+	return nil, nil //nolint:nilnil // the count is only written for the steps run hermetically
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/logging"
+)
+
+// ErrStepImagePinning is returned when the image of a step can't be pinned
+// to its digest and the pin-step-images feature flag is set to "fail".
+var ErrStepImagePinning = errors.New("failed to pin the image of a step")
+
+// pinStepImages pins the images of the steps referenced by tag to their
+// digests and records the pinned digests in the status of the TaskRun.
+//
+// The digests pinned for a previous attempt of the TaskRun are reused, so
+// that its retries run the same images even if their tags have moved. When
+// an image can't be resolved, an error is returned if failOnError is set,
+// otherwise the step runs the image referenced by tag.
+func pinStepImages(ctx context.Context, cache EntrypointCache, taskRun *v1.TaskRun, imagePullSecrets []corev1.LocalObjectReference, steps []corev1.Container, failOnError bool) ([]corev1.Container, error) {
+	logger := logging.FromContext(ctx)
+	previous := map[string]v1.PinnedStepImage{}
+	for _, p := range taskRun.Status.PinnedStepImages {
+		previous[p.Name] = p
+	}
+
+	// Keep a local cache of the digests resolved for this set of steps, in
+	// case several steps use the same image.
+	localCache := map[name.Reference]string{}
+	var pinned []v1.PinnedStepImage
+	for i, s := range steps {
+		ref, err := name.ParseReference(s.Image, name.WeakValidation)
+		if err != nil {
+			return nil, err
+		}
+		// Images referenced by digest are already pinned.
+		if _, ok := ref.(name.Digest); ok {
+			continue
+		}
+
+		stepName := TrimStepPrefix(s.Name)
+		pin, found := previous[stepName]
+		if !found || pin.Image != s.Image {
+			digest, cached := localCache[ref]
+			if !cached {
+				id, err := cache.get(ctx, ref, taskRun.Namespace, taskRun.Spec.ServiceAccountName, imagePullSecrets, len(s.Args) > 0)
+				if err != nil {
+					if failOnError {
+						return nil, fmt.Errorf("%w: image %q of step %q can't be resolved: %w", ErrStepImagePinning, s.Image, stepName, err)
+					}
+					logger.Warnf("Running the image %q of step %q by tag, as it can't be resolved: %v", s.Image, stepName, err)
+					continue
+				}
+				digest = id.digest.String()
+				localCache[ref] = digest
+			}
+			pin = v1.PinnedStepImage{Name: stepName, Image: s.Image, Digest: digest}
+		}
+
+		steps[i].Image = ref.Context().Digest(pin.Digest).String()
+		pinned = append(pinned, pin)
+	}
+	taskRun.Status.PinnedStepImages = pinned
+	return steps, nil
+}
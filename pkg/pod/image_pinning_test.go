@@ -0,0 +1,115 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestPinStepImages(t *testing.T) {
+	current, err := random.Image(1, 1)
+	if err != nil {
+		t.Fatalf("random.Image: %v", err)
+	}
+	currentDigest, err := current.Digest()
+	if err != nil {
+		t.Fatalf("image.Digest: %v", err)
+	}
+	previousDigest := "sha256:0000000000000000000000000000000000000000000000000000000000000000"
+	cache := fakeCache{
+		"gcr.io/my/image:v1": {id: &imageData{digest: currentDigest}},
+	}
+	byDigest := "gcr.io/my/image@" + previousDigest
+
+	for _, tc := range []struct {
+		name         string
+		previousPins []v1.PinnedStepImage
+		steps        []corev1.Container
+		failOnError  bool
+		wantImages   []string
+		wantPins     []v1.PinnedStepImage
+		wantErr      bool
+	}{{
+		name: "images referenced by tag are pinned",
+		steps: []corev1.Container{
+			{Name: "step-build", Image: "gcr.io/my/image:v1"},
+			{Name: "step-test", Image: "gcr.io/my/image:v1", Command: []string{"test"}},
+			{Name: "step-push", Image: byDigest},
+		},
+		wantImages: []string{"gcr.io/my/image@" + currentDigest.String(), "gcr.io/my/image@" + currentDigest.String(), byDigest},
+		wantPins: []v1.PinnedStepImage{
+			{Name: "build", Image: "gcr.io/my/image:v1", Digest: currentDigest.String()},
+			{Name: "test", Image: "gcr.io/my/image:v1", Digest: currentDigest.String()},
+		},
+	}, {
+		name:         "digests pinned by a previous attempt are reused",
+		previousPins: []v1.PinnedStepImage{{Name: "build", Image: "gcr.io/my/image:v1", Digest: previousDigest}},
+		steps:        []corev1.Container{{Name: "step-build", Image: "gcr.io/my/image:v1"}},
+		wantImages:   []string{byDigest},
+		wantPins:     []v1.PinnedStepImage{{Name: "build", Image: "gcr.io/my/image:v1", Digest: previousDigest}},
+	}, {
+		name:         "digests pinned for another image are not reused",
+		previousPins: []v1.PinnedStepImage{{Name: "build", Image: "gcr.io/my/image:v0", Digest: previousDigest}},
+		steps:        []corev1.Container{{Name: "step-build", Image: "gcr.io/my/image:v1"}},
+		wantImages:   []string{"gcr.io/my/image@" + currentDigest.String()},
+		wantPins:     []v1.PinnedStepImage{{Name: "build", Image: "gcr.io/my/image:v1", Digest: currentDigest.String()}},
+	}, {
+		name:        "image which can't be resolved fails",
+		steps:       []corev1.Container{{Name: "step-build", Image: "gcr.io/my/missing:v1"}},
+		failOnError: true,
+		wantErr:     true,
+	}, {
+		name: "image which can't be resolved runs by tag",
+		steps: []corev1.Container{
+			{Name: "step-build", Image: "gcr.io/my/missing:v1"},
+			{Name: "step-test", Image: "gcr.io/my/image:v1"},
+		},
+		wantImages: []string{"gcr.io/my/missing:v1", "gcr.io/my/image@" + currentDigest.String()},
+		wantPins:   []v1.PinnedStepImage{{Name: "test", Image: "gcr.io/my/image:v1", Digest: currentDigest.String()}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			tr := &v1.TaskRun{Status: v1.TaskRunStatus{TaskRunStatusFields: v1.TaskRunStatusFields{PinnedStepImages: tc.previousPins}}}
+			got, err := pinStepImages(t.Context(), cache, tr, nil, tc.steps, tc.failOnError)
+			if tc.wantErr {
+				if !errors.Is(err, ErrStepImagePinning) {
+					t.Fatalf("expected a step image pinning error but got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var gotImages []string
+			for _, s := range got {
+				gotImages = append(gotImages, s.Image)
+			}
+			if d := cmp.Diff(tc.wantImages, gotImages); d != "" {
+				t.Errorf("unexpected images %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tc.wantPins, tr.Status.PinnedStepImages); d != "" {
+				t.Errorf("unexpected pinned step images %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -0,0 +1,382 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn/k8schain"
+	"github.com/google/go-containerregistry/pkg/name"
+	containerregistryv1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/sigstore/sigstore/pkg/signature"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"knative.dev/pkg/logging"
+)
+
+const (
+	// cosignSignatureAnnotation is the annotation of the layers of a cosign
+	// signature manifest holding the signature.
+	cosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+	// cosignCertificateAnnotation is the annotation of the layers of a
+	// cosign signature manifest holding the PEM encoded certificate of the
+	// keyless signatures.
+	cosignCertificateAnnotation = "dev.sigstore.cosign/certificate"
+	// cosignChainAnnotation is the annotation of the layers of a cosign
+	// signature manifest holding the PEM encoded intermediate certificates
+	// of the keyless signatures.
+	cosignChainAnnotation = "dev.sigstore.cosign/chain"
+
+	// maxSignaturePayloadSize bounds the size of the payloads of the
+	// signatures read to verify them.
+	maxSignaturePayloadSize = 64 * 1024
+
+	// unsignedImageTTL is the time the images found unsigned are cached for,
+	// so that they're not looked up again for every Pod while still picking
+	// up the signatures added later on.
+	unsignedImageTTL = 5 * time.Minute
+
+	// imageSignaturesTimeout bounds the time spent looking up the signatures
+	// of the images of the steps of a TaskRun, so that a slow registry
+	// doesn't hold back the creation of its Pod.
+	imageSignaturesTimeout = 10 * time.Second
+)
+
+// ImageSignatureLookup looks up the cosign signatures of images in their
+// container image registry.
+type ImageSignatureLookup interface {
+	// lookup returns the summary of the cosign signature of the image of
+	// ref, using the credentials of the namespace, service account and pull
+	// secrets.
+	lookup(ctx context.Context, ref name.Reference, namespace, serviceAccountName string, imagePullSecrets []corev1.LocalObjectReference) (*v1.StepImageSignature, error)
+}
+
+type imageSignatureLookup struct {
+	kubeclient kubernetes.Interface
+	lru        *lru.Cache // cache of digest->cachedImageSignature
+	// roots are the root certificates the certificates of the keyless
+	// signatures must chain to for their subject to be reported.
+	roots *x509.CertPool
+	now   func() time.Time
+}
+
+// cachedImageSignature is the signature of an image in the cache, which
+// expires when the image was found unsigned.
+type cachedImageSignature struct {
+	signature *v1.StepImageSignature
+	expiry    time.Time
+}
+
+// simpleSigningPayload is the part of the payload signed by cosign which
+// identifies the signed image.
+type simpleSigningPayload struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+// NewImageSignatureLookup returns a new signature lookup implementation that
+// uses K8s credentials to query the signatures in a container image registry.
+// The signers of the keyless signatures are only reported when their
+// certificate chains to one of the roots, which may be nil.
+func NewImageSignatureLookup(kubeclient kubernetes.Interface, roots *x509.CertPool) (ImageSignatureLookup, error) {
+	lru, err := lru.New(cacheSize)
+	if err != nil {
+		return nil, err
+	}
+	return &imageSignatureLookup{
+		kubeclient: kubeclient,
+		lru:        lru,
+		roots:      roots,
+		now:        time.Now,
+	}, nil
+}
+
+// LoadKeylessSigningRoots returns the root certificates of the PEM encoded
+// file at path, e.g. the ones of the Fulcio instance issuing the certificates
+// of the keyless signatures, or nil when path is empty.
+func LoadKeylessSigningRoots(path string) (*x509.CertPool, error) {
+	if path == "" {
+		return nil, nil //nolint:nilnil // no subject is reported without roots
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading the keyless signing roots: %w", err)
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no PEM encoded certificate found in %s", path)
+	}
+	return roots, nil
+}
+
+func (l *imageSignatureLookup) lookup(ctx context.Context, ref name.Reference, namespace, serviceAccountName string, imagePullSecrets []corev1.LocalObjectReference) (*v1.StepImageSignature, error) {
+	pullSecretsNames := make([]string, 0, len(imagePullSecrets))
+	for _, ps := range imagePullSecrets {
+		pullSecretsNames = append(pullSecretsNames, ps.Name)
+	}
+	kc, err := k8schain.New(ctx, l.kubeclient, k8schain.Options{
+		Namespace:          namespace,
+		ServiceAccountName: serviceAccountName,
+		ImagePullSecrets:   pullSecretsNames,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating k8schain: %w", err)
+	}
+	opts := []remote.Option{remote.WithAuthFromKeychain(kc), remote.WithContext(ctx)}
+
+	digest, ok := ref.(name.Digest)
+	if !ok {
+		desc, err := remote.Head(ref, opts...)
+		if err != nil {
+			return nil, err
+		}
+		digest = ref.Context().Digest(desc.Digest.String())
+	}
+	if cached, ok := l.lru.Get(digest.String()); ok {
+		c := cached.(cachedImageSignature)
+		if c.expiry.IsZero() || l.now().Before(c.expiry) {
+			return c.signature, nil
+		}
+	}
+
+	// cosign stores the signatures of an image in the same repository, under
+	// the tag derived from its digest, e.g. "sha256-<hex>.sig".
+	algorithm, hex, ok := strings.Cut(digest.DigestStr(), ":")
+	if !ok {
+		return nil, fmt.Errorf("invalid digest %q", digest.DigestStr())
+	}
+	sigRef := digest.Context().Tag(fmt.Sprintf("%s-%s.sig", algorithm, hex))
+	sig := &v1.StepImageSignature{Digest: digest.DigestStr()}
+	img, err := remote.Image(sigRef, opts...)
+	if err != nil {
+		var terr *transport.Error
+		if errors.As(err, &terr) && terr.StatusCode == http.StatusNotFound {
+			// The unsigned images may be signed later on, so they're
+			// only cached for a while.
+			l.lru.Add(digest.String(), cachedImageSignature{signature: sig, expiry: l.now().Add(unsignedImageTTL)})
+			return sig, nil
+		}
+		return nil, err
+	}
+	manifest, err := img.Manifest()
+	if err != nil {
+		return nil, err
+	}
+	logger := logging.FromContext(ctx)
+	for _, layer := range manifest.Layers {
+		if _, ok := layer.Annotations[cosignSignatureAnnotation]; !ok {
+			continue
+		}
+		sig.Signed = true
+		if _, ok := layer.Annotations[cosignCertificateAnnotation]; !ok || sig.Subject != "" || l.roots == nil {
+			continue
+		}
+		payload, err := readSignaturePayload(img, layer)
+		if err != nil {
+			return nil, err
+		}
+		subject, err := l.keylessSigner(layer.Annotations, payload, digest.DigestStr())
+		if err != nil {
+			logger.Debugf("Not reporting the signer of the image %s: %v", digest, err)
+			continue
+		}
+		sig.Subject = subject
+	}
+	if sig.Signed {
+		l.lru.Add(digest.String(), cachedImageSignature{signature: sig})
+	}
+	return sig, nil
+}
+
+// readSignaturePayload returns the payload signed by the signature of layer.
+func readSignaturePayload(img containerregistryv1.Image, layer containerregistryv1.Descriptor) ([]byte, error) {
+	l, err := img.LayerByDigest(layer.Digest)
+	if err != nil {
+		return nil, fmt.Errorf("could not read the signature layer %s: %w", layer.Digest, err)
+	}
+	rc, err := l.Uncompressed()
+	if err != nil {
+		return nil, fmt.Errorf("could not read the signature layer %s: %w", layer.Digest, err)
+	}
+	defer rc.Close()
+	payload, err := io.ReadAll(io.LimitReader(rc, maxSignaturePayloadSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("could not read the signature layer %s: %w", layer.Digest, err)
+	}
+	if len(payload) > maxSignaturePayloadSize {
+		return nil, fmt.Errorf("the signature layer %s is larger than %d bytes", layer.Digest, maxSignaturePayloadSize)
+	}
+	return payload, nil
+}
+
+// keylessSigner returns the identity which signed the payload keyless, from
+// the certificate in the annotations of the signature layer. The certificate
+// must chain to one of the roots, through the intermediates in the
+// annotations, and its key must have signed the payload of the digest, so
+// that a certificate can't be copied next to another signature.
+func (l *imageSignatureLookup) keylessSigner(annotations map[string]string, payload []byte, digest string) (string, error) {
+	certs, err := parseCertificates(annotations[cosignCertificateAnnotation])
+	if err != nil {
+		return "", err
+	}
+	cert := certs[0]
+	intermediates := x509.NewCertPool()
+	if chain, ok := annotations[cosignChainAnnotation]; ok {
+		certs, err := parseCertificates(chain)
+		if err != nil {
+			return "", err
+		}
+		for _, c := range certs {
+			intermediates.AddCert(c)
+		}
+	}
+	// The certificates of the keyless signatures are only valid for a few
+	// minutes, so the chain is verified at the time the certificate was
+	// issued.
+	if _, err := cert.Verify(x509.VerifyOptions{
+		Roots:         l.roots,
+		Intermediates: intermediates,
+		CurrentTime:   cert.NotBefore,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	}); err != nil {
+		return "", fmt.Errorf("untrusted signing certificate: %w", err)
+	}
+
+	encoded, err := base64.StdEncoding.DecodeString(annotations[cosignSignatureAnnotation])
+	if err != nil {
+		return "", fmt.Errorf("invalid signature: %w", err)
+	}
+	verifier, err := signature.LoadVerifier(cert.PublicKey, crypto.SHA256)
+	if err != nil {
+		return "", fmt.Errorf("invalid signing certificate: %w", err)
+	}
+	if err := verifier.VerifySignature(bytes.NewReader(encoded), bytes.NewReader(payload)); err != nil {
+		return "", fmt.Errorf("the signature wasn't made with the signing certificate: %w", err)
+	}
+	var p simpleSigningPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return "", fmt.Errorf("invalid signature payload: %w", err)
+	}
+	if signed := p.Critical.Image.DockerManifestDigest; signed != digest {
+		return "", fmt.Errorf("the signature is for the digest %s, not %s", signed, digest)
+	}
+	return certificateSubject(cert), nil
+}
+
+// parseCertificates returns the certificates of the PEM encoded data, which
+// must hold at least one.
+func parseCertificates(data string) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	rest := []byte(data)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signing certificate: %w", err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("invalid signing certificate: no PEM block found")
+	}
+	return certs, nil
+}
+
+// certificateSubject returns the identity of the signer of a keyless
+// signature from its certificate, i.e. the email or the URI of its subject
+// alternative names.
+func certificateSubject(cert *x509.Certificate) string {
+	switch {
+	case len(cert.EmailAddresses) > 0:
+		return cert.EmailAddresses[0]
+	case len(cert.URIs) > 0:
+		return cert.URIs[0].String()
+	}
+	return ""
+}
+
+// recordStepImageSignatures looks up whether the images of the steps have a
+// cosign signature and records it in the status of the steps of the TaskRun.
+//
+// The signatures are recorded for policy engines and Chains to shortcut their
+// own lookups, so failing to look up the signature of an image is logged and
+// never fails the TaskRun.
+func recordStepImageSignatures(ctx context.Context, signatures ImageSignatureLookup, taskRun *v1.TaskRun, imagePullSecrets []corev1.LocalObjectReference, steps []corev1.Container) {
+	logger := logging.FromContext(ctx)
+	ctx, cancel := context.WithTimeout(ctx, imageSignaturesTimeout)
+	defer cancel()
+
+	// Keep a local cache of the signatures looked up for this set of steps,
+	// in case several steps use the same image.
+	localCache := map[string]*v1.StepImageSignature{}
+	for _, s := range steps {
+		stepName := TrimStepPrefix(s.Name)
+		sig, cached := localCache[s.Image]
+		if !cached {
+			ref, err := name.ParseReference(s.Image, name.WeakValidation)
+			if err != nil {
+				logger.Warnf("Failed to look up the signature of the image %q of step %q: %v", s.Image, stepName, err)
+				continue
+			}
+			sig, err = signatures.lookup(ctx, ref, taskRun.Namespace, taskRun.Spec.ServiceAccountName, imagePullSecrets)
+			if err != nil {
+				logger.Warnf("Failed to look up the signature of the image %q of step %q: %v", s.Image, stepName, err)
+				continue
+			}
+			localCache[s.Image] = sig
+		}
+
+		found := false
+		for i, ss := range taskRun.Status.Steps {
+			if ss.Name == stepName {
+				taskRun.Status.Steps[i].ImageSignature = sig.DeepCopy()
+				found = true
+				break
+			}
+		}
+		if !found {
+			taskRun.Status.Steps = append(taskRun.Status.Steps, v1.StepState{
+				Name:           stepName,
+				ImageSignature: sig.DeepCopy(),
+			})
+		}
+	}
+}
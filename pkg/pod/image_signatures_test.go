@@ -0,0 +1,349 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	containerregistryv1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclient "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestRecordStepImageSignatures(t *testing.T) {
+	s := httptest.NewServer(registry.New())
+	defer s.Close()
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatalf("Parsing url with an error: %v", err)
+	}
+	ca, caKey := signingCA(t)
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+
+	unsigned, unsignedDigest := pushRandomImage(t, u.Host+"/unsigned:v1")
+	signed, signedDigest := pushRandomImage(t, u.Host+"/signed:v1")
+	pushSignature(t, u.Host+"/signed", signedDigest, nil, map[string]string{
+		cosignSignatureAnnotation: "c2lnbmF0dXJl",
+	})
+	keyless, keylessDigest := pushRandomImage(t, u.Host+"/keyless:v1")
+	keylessPayload, keylessAnnotations := keylessSignature(t, ca, caKey, "builder@example.com", keylessDigest)
+	pushSignature(t, u.Host+"/keyless", keylessDigest, keylessPayload, keylessAnnotations)
+	untrusted, untrustedDigest := pushRandomImage(t, u.Host+"/untrusted:v1")
+	untrustedPayload, untrustedAnnotations := keylessSignature(t, nil, nil, "mallory@example.com", untrustedDigest)
+	pushSignature(t, u.Host+"/untrusted", untrustedDigest, untrustedPayload, untrustedAnnotations)
+	// The signature of another image, with its certificate, copied next to
+	// the image.
+	copied, copiedDigest := pushRandomImage(t, u.Host+"/copied:v1")
+	pushSignature(t, u.Host+"/copied", copiedDigest, keylessPayload, keylessAnnotations)
+	missing := u.Host + "/missing:v1"
+
+	signatures, err := NewImageSignatureLookup(fakeclient.NewSimpleClientset(&corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: nameSpace},
+	}), roots)
+	if err != nil {
+		t.Fatalf("NewImageSignatureLookup: %v", err)
+	}
+
+	taskRun := &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "taskrun", Namespace: nameSpace},
+		Status: v1.TaskRunStatus{
+			TaskRunStatusFields: v1.TaskRunStatusFields{
+				Steps: []v1.StepState{{
+					Name:       "keyless",
+					Provenance: &v1.Provenance{RefSource: &v1.RefSource{URI: "test-uri"}},
+				}},
+			},
+		},
+	}
+	steps := []corev1.Container{
+		{Name: "step-unsigned", Image: unsigned},
+		{Name: "step-signed", Image: signed},
+		{Name: "step-keyless", Image: keyless},
+		{Name: "step-untrusted", Image: untrusted},
+		{Name: "step-copied", Image: copied},
+		{Name: "step-by-digest", Image: u.Host + "/signed@" + signedDigest},
+		{Name: "step-missing", Image: missing},
+	}
+	recordStepImageSignatures(t.Context(), signatures, taskRun, nil, steps)
+
+	want := []v1.StepState{{
+		Name:       "keyless",
+		Provenance: &v1.Provenance{RefSource: &v1.RefSource{URI: "test-uri"}},
+		ImageSignature: &v1.StepImageSignature{
+			Digest:  keylessDigest,
+			Signed:  true,
+			Subject: "builder@example.com",
+		},
+	}, {
+		Name:           "unsigned",
+		ImageSignature: &v1.StepImageSignature{Digest: unsignedDigest},
+	}, {
+		Name:           "signed",
+		ImageSignature: &v1.StepImageSignature{Digest: signedDigest, Signed: true},
+	}, {
+		Name:           "untrusted",
+		ImageSignature: &v1.StepImageSignature{Digest: untrustedDigest, Signed: true},
+	}, {
+		Name:           "copied",
+		ImageSignature: &v1.StepImageSignature{Digest: copiedDigest, Signed: true},
+	}, {
+		Name:           "by-digest",
+		ImageSignature: &v1.StepImageSignature{Digest: signedDigest, Signed: true},
+	}}
+	if d := cmp.Diff(want, taskRun.Status.Steps); d != "" {
+		t.Errorf("Unexpected step states %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestImageSignatureLookupWithoutRoots(t *testing.T) {
+	s := httptest.NewServer(registry.New())
+	defer s.Close()
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatalf("Parsing url with an error: %v", err)
+	}
+	ca, caKey := signingCA(t)
+	keyless, keylessDigest := pushRandomImage(t, u.Host+"/keyless:v1")
+	payload, annotations := keylessSignature(t, ca, caKey, "builder@example.com", keylessDigest)
+	pushSignature(t, u.Host+"/keyless", keylessDigest, payload, annotations)
+
+	signatures, err := NewImageSignatureLookup(fakeclient.NewSimpleClientset(), nil)
+	if err != nil {
+		t.Fatalf("NewImageSignatureLookup: %v", err)
+	}
+	ref, err := name.ParseReference(keyless)
+	if err != nil {
+		t.Fatalf("name.ParseReference: %v", err)
+	}
+	got, err := signatures.lookup(t.Context(), ref, nameSpace, "", nil)
+	if err != nil {
+		t.Fatalf("lookup: %v", err)
+	}
+	want := &v1.StepImageSignature{Digest: keylessDigest, Signed: true}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Unexpected image signature %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestImageSignatureLookupCachesUnsignedImages(t *testing.T) {
+	s := httptest.NewServer(registry.New())
+	defer s.Close()
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatalf("Parsing url with an error: %v", err)
+	}
+	image, digest := pushRandomImage(t, u.Host+"/image:v1")
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		t.Fatalf("name.ParseReference: %v", err)
+	}
+
+	lookup, err := NewImageSignatureLookup(fakeclient.NewSimpleClientset(), nil)
+	if err != nil {
+		t.Fatalf("NewImageSignatureLookup: %v", err)
+	}
+	now := time.Now()
+	lookup.(*imageSignatureLookup).now = func() time.Time { return now }
+
+	for _, tc := range []struct {
+		desc    string
+		elapsed time.Duration
+		want    *v1.StepImageSignature
+	}{{
+		desc: "unsigned",
+		want: &v1.StepImageSignature{Digest: digest},
+	}, {
+		desc:    "signed since but still cached as unsigned",
+		elapsed: unsignedImageTTL - time.Second,
+		want:    &v1.StepImageSignature{Digest: digest},
+	}, {
+		desc:    "signed once the cache expired",
+		elapsed: unsignedImageTTL,
+		want:    &v1.StepImageSignature{Digest: digest, Signed: true},
+	}} {
+		now = now.Add(tc.elapsed)
+		got, err := lookup.lookup(t.Context(), ref, nameSpace, "", nil)
+		if err != nil {
+			t.Fatalf("%s: lookup: %v", tc.desc, err)
+		}
+		if d := cmp.Diff(tc.want, got); d != "" {
+			t.Errorf("%s: unexpected image signature %s", tc.desc, diff.PrintWantGot(d))
+		}
+		pushSignature(t, u.Host+"/image", digest, nil, map[string]string{
+			cosignSignatureAnnotation: "c2lnbmF0dXJl",
+		})
+	}
+}
+
+func TestRecordStepImageSignaturesUnreachableRegistry(t *testing.T) {
+	s := httptest.NewServer(registry.New())
+	image := strings.TrimPrefix(s.URL, "http://") + "/image:v1"
+	s.Close()
+
+	signatures, err := NewImageSignatureLookup(fakeclient.NewSimpleClientset(), nil)
+	if err != nil {
+		t.Fatalf("NewImageSignatureLookup: %v", err)
+	}
+	taskRun := &v1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "taskrun", Namespace: nameSpace}}
+	recordStepImageSignatures(t.Context(), signatures, taskRun, nil, []corev1.Container{{Name: "step-build", Image: image}})
+
+	if len(taskRun.Status.Steps) != 0 {
+		t.Errorf("Expected no step states when the registry can't be queried, got %v", taskRun.Status.Steps)
+	}
+}
+
+// pushRandomImage pushes a random image to ref and returns the reference of
+// the image with its digest.
+func pushRandomImage(t *testing.T, ref string) (string, string) {
+	t.Helper()
+	img, err := random.Image(1, 1)
+	if err != nil {
+		t.Fatalf("random.Image: %v", err)
+	}
+	tag, err := name.ParseReference(ref)
+	if err != nil {
+		t.Fatalf("name.ParseReference: %v", err)
+	}
+	if err := remote.Write(tag, img); err != nil {
+		t.Fatalf("remote.Write: %v", err)
+	}
+	digest, err := img.Digest()
+	if err != nil {
+		t.Fatalf("image.Digest: %v", err)
+	}
+	return ref, digest.String()
+}
+
+// pushSignature pushes a cosign signature of the image of digest to repo,
+// with the payload, random if nil, and the annotations of its signature layer.
+func pushSignature(t *testing.T, repo, digest string, payload []byte, annotations map[string]string) {
+	t.Helper()
+	var layer containerregistryv1.Layer
+	var err error
+	if payload == nil {
+		layer, err = random.Layer(64, types.OCILayer)
+	} else {
+		layer, err = tarball.LayerFromReader(bytes.NewReader(payload))
+	}
+	if err != nil {
+		t.Fatalf("creating the signature layer: %v", err)
+	}
+	sig, err := mutate.Append(empty.Image, mutate.Addendum{Layer: layer, Annotations: annotations})
+	if err != nil {
+		t.Fatalf("mutate.Append: %v", err)
+	}
+	tag, err := name.ParseReference(fmt.Sprintf("%s:%s.sig", repo, strings.Replace(digest, ":", "-", 1)))
+	if err != nil {
+		t.Fatalf("name.ParseReference: %v", err)
+	}
+	if err := remote.Write(tag, sig); err != nil {
+		t.Fatalf("remote.Write: %v", err)
+	}
+}
+
+// signingCA returns the certificate and the key of a root CA issuing the
+// certificates of keyless signatures.
+func signingCA(t *testing.T) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdsa.GenerateKey: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "sigstore"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("x509.CreateCertificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("x509.ParseCertificate: %v", err)
+	}
+	return cert, key
+}
+
+// keylessSignature returns the payload and the annotations of the signature
+// layer of a keyless signature of the image of digest, made with a short
+// lived certificate issued to email by the CA, or self-signed if nil.
+func keylessSignature(t *testing.T, ca *x509.Certificate, caKey *ecdsa.PrivateKey, email, digest string) ([]byte, map[string]string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdsa.GenerateKey: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:   big.NewInt(2),
+		NotBefore:      time.Now().Add(-20 * time.Minute),
+		NotAfter:       time.Now().Add(-10 * time.Minute),
+		EmailAddresses: []string{email},
+		KeyUsage:       x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:    []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	}
+	parent, parentKey := template, key
+	if ca != nil {
+		parent, parentKey = ca, caKey
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatalf("x509.CreateCertificate: %v", err)
+	}
+	payload := []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"image"},"image":{"docker-manifest-digest":%q},"type":"cosign container image signature"},"optional":null}`, digest))
+	hash := sha256.Sum256(payload)
+	sig, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+	if err != nil {
+		t.Fatalf("ecdsa.SignASN1: %v", err)
+	}
+	return payload, map[string]string{
+		cosignSignatureAnnotation:   base64.StdEncoding.EncodeToString(sig),
+		cosignCertificateAnnotation: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+	}
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/names"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// InjectSidecarsAnnotation can be set to "false" on a TaskRun, or on the
+	// PipelineRun it belongs to, to opt out of the injection of the sidecars
+	// configured in the default-injected-sidecars config.
+	InjectSidecarsAnnotation = "tekton.dev/inject-sidecars"
+
+	// readinessExcludedSidecarsAnnotation lists the containers of a pod
+	// which the steps don't wait for before starting.
+	readinessExcludedSidecarsAnnotation = "tekton.dev/readiness-excluded-sidecars"
+
+	// injectedSidecarSuffix is added to the name of an injected sidecar
+	// when the Task already has a sidecar with the same name.
+	injectedSidecarSuffix = "-injected"
+)
+
+// injectedSidecars returns the sidecars configured to be injected into the
+// pod of the TaskRun, with the volume mounts of the workspaces they request,
+// followed by the hermetic network sidecar if the TaskRun is run hermetically.
+// It also returns the names of the ones the steps don't wait for.
+func injectedSidecars(ctx context.Context, taskRun *v1.TaskRun, taskSpec v1.TaskSpec, sidecars []v1.Sidecar) ([]v1.Sidecar, []string) {
+	var configured []config.InjectedSidecar
+	if taskRun.Annotations[InjectSidecarsAnnotation] != "false" {
+		configured = config.FromContextOrDefaults(ctx).Defaults.InjectedSidecarsForNamespace(taskRun.Namespace)
+	}
+	networkSidecar := hermeticNetworkSidecar(ctx, taskRun)
+	if networkSidecar != nil {
+		configured = append(slices.Clip(configured), *networkSidecar)
+	}
+	if len(configured) == 0 {
+		return nil, nil
+	}
+
+	usedNames := map[string]bool{}
+	for _, s := range sidecars {
+		usedNames[s.Name] = true
+	}
+	var injected []v1.Sidecar
+	var notAwaited []string
+	for i, is := range configured {
+		name := is.Name
+		for usedNames[name] {
+			name += injectedSidecarSuffix
+		}
+		usedNames[name] = true
+
+		sidecar := v1.Sidecar{
+			Name:             name,
+			Image:            is.Image,
+			Command:          is.Command,
+			Args:             is.Args,
+			Env:              is.Env,
+			ComputeResources: is.ComputeResources,
+		}
+		for _, w := range is.Workspaces {
+			if vm, ok := workspaceVolumeMount(taskSpec, w); ok {
+				sidecar.VolumeMounts = append(sidecar.VolumeMounts, vm)
+			}
+		}
+		if networkSidecar != nil && i == len(configured)-1 {
+			sidecar.SecurityContext = networkAdminSecurityContext()
+		}
+		injected = append(injected, *sidecar.DeepCopy())
+		if !is.AwaitReadiness {
+			notAwaited = append(notAwaited, name)
+		}
+	}
+	return injected, notAwaited
+}
+
+// workspaceVolumeMount returns the volume mount of the given workspace of the
+// Task, as mounted in its steps, if the workspace is declared and bound.
+func workspaceVolumeMount(taskSpec v1.TaskSpec, workspace string) (corev1.VolumeMount, bool) {
+	var mountPath string
+	for _, w := range taskSpec.Workspaces {
+		if w.Name == workspace {
+			mountPath = w.GetMountPath()
+			break
+		}
+	}
+	if mountPath == "" {
+		return corev1.VolumeMount{}, false
+	}
+	var mounts []corev1.VolumeMount
+	if taskSpec.StepTemplate != nil {
+		mounts = append(mounts, taskSpec.StepTemplate.VolumeMounts...)
+	}
+	for _, s := range taskSpec.Steps {
+		mounts = append(mounts, s.VolumeMounts...)
+	}
+	for _, vm := range mounts {
+		if vm.MountPath == mountPath {
+			return vm, true
+		}
+	}
+	return corev1.VolumeMount{}, false
+}
+
+// sidecarContainerName returns the name of the container of a sidecar.
+func sidecarContainerName(name string) string {
+	return names.SimpleNameGenerator.RestrictLength(fmt.Sprintf("%v%v", sidecarPrefix, name))
+}
+
+// ReadinessExcludedContainers returns the names of the containers of the pod
+// which SidecarsReady must ignore: the injected sidecars which don't await
+// readiness, which the steps don't wait for before starting, and the steps
+// with a custom container name.
+func ReadinessExcludedContainers(pod *corev1.Pod) []string {
+	var excluded []string
+	if sidecars := pod.Annotations[readinessExcludedSidecarsAnnotation]; sidecars != "" {
+		excluded = strings.Split(sidecars, ",")
+	}
+	for container := range stepContainerNames(pod) {
+		excluded = append(excluded, container)
+	}
+	return excluded
+}
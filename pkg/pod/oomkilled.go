@@ -0,0 +1,102 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"fmt"
+	"strconv"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/result"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// extractMemoryPeakFromResults returns the peak memory usage of the container
+// of a step written by the entrypoint, if any.
+func extractMemoryPeakFromResults(results []result.RunResult) (*resource.Quantity, error) {
+	for _, r := range results {
+		if r.ResultType == result.InternalTektonResultType && r.Key == result.MemoryPeakKey {
+			peak, err := strconv.ParseInt(r.Value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("could not parse int value %q in %s field: %w", r.Value, result.MemoryPeakKey, err)
+			}
+			return resource.NewQuantity(peak, resource.BinarySI), nil
+		}
+	}
+	return nil, nil //nolint:nilnil // the peak is only written for the killed steps
+}
+
+// containerMemoryLimit returns the memory limit of the container with the
+// given name, as reported by its status if the kubelet reports the resources
+// of the containers, or else as declared in the spec of the pod.
+func containerMemoryLimit(pod *corev1.Pod, name string) *resource.Quantity {
+	for _, s := range pod.Status.ContainerStatuses {
+		if s.Name == name && s.Resources != nil {
+			if limit, ok := s.Resources.Limits[corev1.ResourceMemory]; ok {
+				return &limit
+			}
+		}
+	}
+	for _, c := range pod.Spec.Containers {
+		if c.Name == name {
+			if limit, ok := c.Resources.Limits[corev1.ResourceMemory]; ok {
+				return &limit
+			}
+		}
+	}
+	return nil
+}
+
+// setStepsMemoryLimit sets the memory limit which was hit by the OOMKilled
+// steps.
+func setStepsMemoryLimit(trs *v1.TaskRunStatus, pod *corev1.Pod) {
+	for i, ss := range trs.Steps {
+		if ss.Terminated == nil || ss.Terminated.Reason != oomKilled {
+			continue
+		}
+		limit := containerMemoryLimit(pod, ss.Container)
+		if limit == nil {
+			continue
+		}
+		if ss.OOMKilled == nil {
+			trs.Steps[i].OOMKilled = &v1.StepOOMKilled{}
+		}
+		trs.Steps[i].OOMKilled.MemoryLimit = limit
+	}
+}
+
+// getOOMKilledStepMessage returns the failure message naming the first step
+// of the pod whose container was OOMKilled, and false if there is none.
+func getOOMKilledStepMessage(pod *corev1.Pod) (string, bool) {
+	containerNames := stepContainerNames(pod)
+	for _, s := range pod.Status.ContainerStatuses {
+		if !IsPodContainerStep(pod, s.Name) || s.State.Terminated == nil || !isOOMKilled(s) {
+			continue
+		}
+		name := s.Name
+		if n, ok := containerNames[name]; ok {
+			name = n
+		}
+		msg := fmt.Sprintf("step %q was OOMKilled", TrimStepPrefix(name))
+		if limit := containerMemoryLimit(pod, s.Name); limit != nil {
+			msg += " by its memory limit of " + limit.String()
+		}
+		return msg, true
+	}
+	return "", false
+}
@@ -25,6 +25,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/tektoncd/pipeline/internal/artifactref"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
@@ -48,7 +49,7 @@ import (
 
 const (
 	// TektonHermeticEnvVar is the env var we set in containers to indicate they should be run hermetically
-	TektonHermeticEnvVar = "TEKTON_HERMETIC"
+	TektonHermeticEnvVar = pipeline.TektonHermeticEnvVar
 
 	// ExecutionModeAnnotation is an experimental optional annotation to set the execution mode on a TaskRun
 	ExecutionModeAnnotation = "experimental.tekton.dev/execution-mode"
@@ -134,9 +135,10 @@ var (
 
 // Builder exposes options to configure Pod construction from TaskSpecs/Runs.
 type Builder struct {
-	Images          pipeline.Images
-	KubeClient      kubernetes.Interface
-	EntrypointCache EntrypointCache
+	Images               pipeline.Images
+	KubeClient           kubernetes.Interface
+	EntrypointCache      EntrypointCache
+	ImageSignatureLookup ImageSignatureLookup
 }
 
 // Transformer is a function that will transform a Pod. This can be used to mutate
@@ -159,6 +161,7 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1.TaskRun, taskSpec v1.Ta
 	alphaAPIEnabled := featureFlags.EnableAPIFields == config.AlphaAPIFields
 	sidecarLogsResultsEnabled := config.FromContextOrDefaults(ctx).FeatureFlags.ResultExtractionMethod == config.ResultExtractionMethodSidecarLogs
 	enableKeepPodOnCancel := featureFlags.EnableKeepPodOnCancel
+	enableTaskRunPause := featureFlags.EnableTaskRunPause
 	setSecurityContext := config.FromContextOrDefaults(ctx).FeatureFlags.SetSecurityContext
 	setSecurityContextReadOnlyRootFilesystem := config.FromContextOrDefaults(ctx).FeatureFlags.SetSecurityContextReadOnlyRootFilesystem
 	defaultManagedByLabelValue := config.FromContextOrDefaults(ctx).Defaults.DefaultManagedByLabelValue
@@ -175,6 +178,22 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1.TaskRun, taskSpec v1.Ta
 	if config.IsSpireEnabled(ctx) {
 		commonExtraEntrypointArgs = append(commonExtraEntrypointArgs, "-enable_spire")
 	}
+	// Entrypoint arg to set the umask of the steps, so that the files they
+	// write can be shared with steps running as other users
+	if umask := featureFlags.EntrypointUmask; umask != "" {
+		commonExtraEntrypointArgs = append(commonExtraEntrypointArgs, "-umask", umask)
+	}
+	// Entrypoint arg to report the network failures of the steps run
+	// hermetically, when the pod is hardened
+	hermeticHardened := isHermeticHardened(ctx, taskRun)
+	if hermeticHardened {
+		commonExtraEntrypointArgs = append(commonExtraEntrypointArgs, reportHermeticViolationsArg)
+	}
+	// Entrypoint arg to wait for the TaskRun to be resumed before starting
+	// the steps while it is paused
+	if enableTaskRunPause {
+		commonExtraEntrypointArgs = append(commonExtraEntrypointArgs, "-pause_file", DownwardMountPauseFile)
+	}
 	credEntrypointArgs, credVolumes, credVolumeMounts, err := credsInit(ctx, taskRun, taskRun.Spec.ServiceAccountName, taskRun.Namespace, b.KubeClient)
 	if err != nil {
 		return nil, err
@@ -193,6 +212,10 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1.TaskRun, taskSpec v1.Ta
 	if err != nil {
 		return nil, err
 	}
+	steps, err = normalizeWorkingDirs(steps, taskSpec.Workspaces, taskRun.Spec.Workspaces)
+	if err != nil {
+		return nil, err
+	}
 	if taskRun.Spec.ComputeResources != nil {
 		tasklevel.ApplyTaskLevelComputeResources(steps, taskRun.Spec.ComputeResources)
 	}
@@ -219,6 +242,8 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1.TaskRun, taskSpec v1.Ta
 	if err != nil {
 		return nil, err
 	}
+	injected, notAwaitedSidecars := injectedSidecars(ctx, taskRun, taskSpec, sidecars)
+	sidecars = append(sidecars, injected...)
 
 	initContainers = []corev1.Container{
 		entrypointInitContainer(b.Images.EntrypointImage, steps, securityContextConfig, windows),
@@ -226,6 +251,9 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1.TaskRun, taskSpec v1.Ta
 
 	// Convert any steps with Script to command+args.
 	// If any are found, append an init container to initialize scripts.
+	if err := validateConfigMapScripts(steps, sidecars, windows); err != nil {
+		return nil, err
+	}
 	if alphaAPIEnabled {
 		scriptsInit, stepContainers, sidecarContainers = convertScripts(b.Images.ShellImage, b.Images.ShellImageWin, steps, sidecars, taskRun.Spec.Debug, securityContextConfig)
 	} else {
@@ -235,7 +263,9 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1.TaskRun, taskSpec v1.Ta
 	if scriptsInit != nil {
 		initContainers = append(initContainers, *scriptsInit)
 		volumes = append(volumes, scriptsVolume)
+		volumes = append(volumes, scriptFromConfigMapVolumes(steps)...)
 	}
+	placeWorkspaceScriptsInContainers(steps, taskSpec.Workspaces, stepContainers)
 	if alphaAPIEnabled && taskRun.Spec.Debug != nil && taskRun.Spec.Debug.NeedsDebug() {
 		volumes = append(volumes, debugScriptsVolume, debugInfoVolume)
 	}
@@ -251,28 +281,75 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1.TaskRun, taskSpec v1.Ta
 		podTemplate = *taskRun.Spec.PodTemplate
 	}
 
+	// Entrypoint arg to terminate the steps within the grace period of the
+	// pod when the TaskRun is cancelled while its pod is kept, like the
+	// kubelet does when the pod is deleted
+	if enableKeepPodOnCancel && podTemplate.TerminationGracePeriodSeconds != nil {
+		gracePeriod := time.Duration(*podTemplate.TerminationGracePeriodSeconds) * time.Second
+		commonExtraEntrypointArgs = append(commonExtraEntrypointArgs, "-termination_grace_period", gracePeriod.String())
+	}
+
+	// Pin the images of the steps referenced by tag to their digests, reusing
+	// the digests pinned for the previous attempts of the TaskRun.
+	if pinStepImagesPolicy := featureFlags.PinStepImages; pinStepImagesPolicy != "" && pinStepImagesPolicy != config.PinStepImagesDisabled {
+		stepContainers, err = pinStepImages(ctx, b.EntrypointCache, taskRun, podTemplate.ImagePullSecrets, stepContainers, pinStepImagesPolicy == config.PinStepImagesFail)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Resolve entrypoint for any steps that don't specify command.
 	stepContainers, err = resolveEntrypoints(ctx, b.EntrypointCache, taskRun.Namespace, taskRun.Spec.ServiceAccountName, podTemplate.ImagePullSecrets, stepContainers)
 	if err != nil {
 		return nil, err
 	}
 
-	readyImmediately := isPodReadyImmediately(*featureFlags, taskSpec.Sidecars)
+	// Record whether the images of the steps are signed, without ever
+	// failing the TaskRun when a signature can't be looked up.
+	if featureFlags.RecordStepImageSignatures && b.ImageSignatureLookup != nil {
+		recordStepImageSignatures(ctx, b.ImageSignatureLookup, taskRun, podTemplate.ImagePullSecrets, stepContainers)
+	}
+
+	// The steps wait for the sidecars of the Task and for the injected
+	// sidecars which await readiness. When some steps declare the sidecars
+	// they depend on, each step waits for its own sidecars instead of the
+	// first step waiting for the sidecars of the Task.
+	usesDependencies := usesSidecarDependencies(taskSpec.Steps)
+	var awaitedSidecars []v1.Sidecar
+	for _, s := range taskSpec.Sidecars {
+		if usesDependencies && s.Name != pipeline.ReservedResultsSidecarName {
+			notAwaitedSidecars = append(notAwaitedSidecars, s.Name)
+			continue
+		}
+		awaitedSidecars = append(awaitedSidecars, s)
+	}
+	for _, s := range injected {
+		if !slices.Contains(notAwaitedSidecars, s.Name) {
+			awaitedSidecars = append(awaitedSidecars, s)
+		}
+	}
+	readyImmediately := isPodReadyImmediately(*featureFlags, awaitedSidecars)
 
 	if alphaAPIEnabled {
-		stepContainers, err = orderContainers(ctx, commonExtraEntrypointArgs, stepContainers, &taskSpec, taskRun.Spec.Debug, !readyImmediately, enableKeepPodOnCancel)
+		stepContainers, err = orderContainers(ctx, commonExtraEntrypointArgs, stepContainers, &taskSpec, taskRun.Spec.Debug, !readyImmediately, enableKeepPodOnCancel || enableTaskRunPause)
 	} else {
-		stepContainers, err = orderContainers(ctx, commonExtraEntrypointArgs, stepContainers, &taskSpec, nil, !readyImmediately, enableKeepPodOnCancel)
+		stepContainers, err = orderContainers(ctx, commonExtraEntrypointArgs, stepContainers, &taskSpec, nil, !readyImmediately, enableKeepPodOnCancel || enableTaskRunPause)
 	}
 	if err != nil {
 		return nil, err
 	}
 	volumes = append(volumes, binVolume)
-	if !readyImmediately || enableKeepPodOnCancel {
+	if !readyImmediately || enableKeepPodOnCancel || enableTaskRunPause || usesDependencies {
 		downwardVolumeDup := downwardVolume.DeepCopy()
 		if enableKeepPodOnCancel {
 			downwardVolumeDup.VolumeSource.DownwardAPI.Items = append(downwardVolumeDup.VolumeSource.DownwardAPI.Items, downwardCancelVolumeItem)
 		}
+		if enableTaskRunPause {
+			downwardVolumeDup.VolumeSource.DownwardAPI.Items = append(downwardVolumeDup.VolumeSource.DownwardAPI.Items, downwardPauseVolumeItem)
+		}
+		if usesDependencies {
+			downwardVolumeDup.VolumeSource.DownwardAPI.Items = append(downwardVolumeDup.VolumeSource.DownwardAPI.Items, sidecarReadyVolumeItems(dependedSidecarContainers(taskSpec.Steps))...)
+		}
 		volumes = append(volumes, *downwardVolumeDup)
 	}
 
@@ -289,7 +366,9 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1.TaskRun, taskSpec v1.Ta
 	}
 	filteredEnvs := []corev1.EnvVar{}
 	for _, e := range podTemplate.Env {
-		if !slices.Contains(defaultForbiddenEnv, e.Name) {
+		// The env vars reserved for Tekton are dropped, so as not to
+		// override the ones injected for the entrypoint.
+		if !slices.Contains(defaultForbiddenEnv, e.Name) && !pipeline.IsReservedEnvVar(e.Name) {
 			filteredEnvs = append(filteredEnvs, e)
 		}
 	}
@@ -307,6 +386,16 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1.TaskRun, taskSpec v1.Ta
 			stepContainers[i].Env = env
 		}
 	}
+	if hermeticHardened {
+		hardenHermeticSteps(stepContainers)
+	}
+	// Add the build metadata env vars last, so that they never override the
+	// env vars set by the user, the default pod template or the pod template.
+	if featureFlags.EnableBuildMetadataEnv {
+		env := buildMetadataEnv(taskRun, config.FromContextOrDefaults(ctx).Defaults.DefaultBuildMetadataEnv)
+		injectBuildMetadataEnv(stepContainers, env)
+		injectBuildMetadataEnv(sidecarContainers, env)
+	}
 
 	// Add implicit volume mounts to each step, unless the step specifies
 	// its own volume mount at that path.
@@ -372,11 +461,17 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1.TaskRun, taskSpec v1.Ta
 	}
 
 	// This loop:
-	// - sets container name to add "step-" prefix or "step-unnamed-#" if not specified.
+	// - sets container name to add "step-" prefix or "step-unnamed-#" if not specified,
+	//   unless the step sets a custom container name.
 	// TODO(#1605): Remove this loop and make each transformation in
 	// isolation.
+	customContainerNames := map[string]string{}
 	for i, s := range stepContainers {
 		stepContainers[i].Name = names.SimpleNameGenerator.RestrictLength(StepName(s.Name, i))
+		if steps[i].ContainerName != "" {
+			customContainerNames[steps[i].ContainerName] = stepContainers[i].Name
+			stepContainers[i].Name = steps[i].ContainerName
+		}
 	}
 
 	// Add podTemplate Volumes to the explicitly declared use volumes
@@ -439,7 +534,7 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1.TaskRun, taskSpec v1.Ta
 				sc := &sidecarContainers[i]
 				always := corev1.ContainerRestartPolicyAlways
 				sc.RestartPolicy = &always
-				sc.Name = names.SimpleNameGenerator.RestrictLength(fmt.Sprintf("%v%v", sidecarPrefix, sc.Name))
+				sc.Name = sidecarContainerName(sc.Name)
 				mergedPodInitContainers = append(mergedPodInitContainers, *sc)
 			}
 		}
@@ -447,11 +542,17 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1.TaskRun, taskSpec v1.Ta
 	if useTektonSidecar {
 		// Merge sidecar containers with step containers.
 		for _, sc := range sidecarContainers {
-			sc.Name = names.SimpleNameGenerator.RestrictLength(fmt.Sprintf("%v%v", sidecarPrefix, sc.Name))
+			sc.Name = sidecarContainerName(sc.Name)
 			mergedPodContainers = append(mergedPodContainers, sc)
 		}
 	}
 
+	if len(customContainerNames) > 0 {
+		if err := validateContainerNames(customContainerNames, mergedPodInitContainers, mergedPodContainers); err != nil {
+			return nil, err
+		}
+	}
+
 	var dnsPolicy corev1.DNSPolicy
 	if podTemplate.DNSPolicy != nil {
 		dnsPolicy = *podTemplate.DNSPolicy
@@ -464,18 +565,68 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1.TaskRun, taskSpec v1.Ta
 
 	podAnnotations := kmap.ExcludeKeys(kmeta.CopyMap(taskRun.Annotations), tknreconciler.KubernetesManagedByAnnotationKey)
 	podAnnotations[ReleaseAnnotation] = changeset.Get()
+	if len(notAwaitedSidecars) > 0 {
+		containerNames := make([]string, 0, len(notAwaitedSidecars))
+		for _, name := range notAwaitedSidecars {
+			containerNames = append(containerNames, sidecarContainerName(name))
+		}
+		podAnnotations[readinessExcludedSidecarsAnnotation] = strings.Join(containerNames, ",")
+	}
+
+	if len(customContainerNames) > 0 {
+		podAnnotations[stepContainerNamesAnnotation] = formatStepContainerNames(customContainerNames)
+	}
+	if usesDependencies {
+		podAnnotations[sidecarDependenciesAnnotation] = strings.Join(dependedSidecarContainers(taskSpec.Steps), ",")
+	}
+	if stopAfterSteps := formatSidecarStopAfterSteps(taskSpec.Sidecars, taskSpec.Steps, stepContainers); stopAfterSteps != "" {
+		podAnnotations[sidecarStopAfterStepsAnnotation] = stopAfterSteps
+	}
+	if failTaskRunOnTermination := formatSidecarsFailTaskRunOnTermination(taskSpec.Sidecars); failTaskRunOnTermination != "" {
+		podAnnotations[sidecarFailTaskRunOnTerminationAnnotation] = failTaskRunOnTermination
+	}
 
 	if readyImmediately {
 		podAnnotations[readyAnnotation] = readyAnnotationValue
 	}
+	if enableTaskRunPause && taskRun.IsPaused() {
+		podAnnotations[pauseAnnotation] = pauseAnnotationValue
+	}
 
 	// calculate the activeDeadlineSeconds based on the specified timeout (uses default timeout if it's not specified)
 	activeDeadlineSeconds := int64(taskRun.GetTimeout(ctx).Seconds() * deadlineFactor)
 	// set activeDeadlineSeconds to the max. allowed value i.e. max int32 when timeout is explicitly set to 0
-	if taskRun.GetTimeout(ctx) == config.NoTimeoutDuration {
+	// The time a TaskRun which can pause will spend paused isn't known, its
+	// timeout is enforced by the controller instead. A TaskRun with a single
+	// step can only pause before it starts, so it keeps its deadline unless
+	// it's already paused.
+	canPause := enableTaskRunPause && (len(taskSpec.Steps) > 1 || taskRun.IsPaused())
+	if taskRun.GetTimeout(ctx) == config.NoTimeoutDuration || canPause {
 		activeDeadlineSeconds = MaxActiveDeadlineSeconds
 	}
 
+	securityContext := podSecurityContext(podTemplate.SecurityContext, config.FromContextOrDefaults(ctx).Defaults.DefaultFSGroup, windows)
+	if hermeticHardened {
+		securityContext = hardenHermeticPodSecurityContext(securityContext)
+	}
+	securityContext = podTemplateSecurityProfiles(securityContext, podTemplate)
+	if config.FromContextOrDefaults(ctx).Defaults.ForbidLocalhostProfiles {
+		if err := validateLocalhostProfiles(securityContext, mergedPodInitContainers, mergedPodContainers); err != nil {
+			return nil, err
+		}
+	}
+	if usesAppArmorProfiles(securityContext, mergedPodInitContainers, mergedPodContainers) {
+		// Kubernetes versions older than 1.30 only support the AppArmor
+		// annotations.
+		sv, err := b.KubeClient.Discovery().ServerVersion()
+		if err != nil {
+			return nil, err
+		}
+		if !IsAppArmorFieldSupport(sv) {
+			securityContext = appArmorProfilesToAnnotations(securityContext, podAnnotations, mergedPodInitContainers, mergedPodContainers)
+		}
+	}
+
 	podNameSuffix := "-pod"
 	if taskRunRetries := len(taskRun.Status.RetriesStatus); taskRunRetries > 0 {
 		podNameSuffix = fmt.Sprintf("%s-retry%d", podNameSuffix, taskRunRetries)
@@ -497,27 +648,28 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1.TaskRun, taskSpec v1.Ta
 			Labels:      makeLabels(taskRun, defaultManagedByLabelValue),
 		},
 		Spec: corev1.PodSpec{
-			RestartPolicy:                corev1.RestartPolicyNever,
-			InitContainers:               mergedPodInitContainers,
-			Containers:                   mergedPodContainers,
-			ServiceAccountName:           taskRun.Spec.ServiceAccountName,
-			Volumes:                      volumes,
-			NodeSelector:                 podTemplate.NodeSelector,
-			Tolerations:                  podTemplate.Tolerations,
-			Affinity:                     podTemplate.Affinity,
-			SecurityContext:              podTemplate.SecurityContext,
-			RuntimeClassName:             podTemplate.RuntimeClassName,
-			AutomountServiceAccountToken: podTemplate.AutomountServiceAccountToken,
-			SchedulerName:                podTemplate.SchedulerName,
-			HostNetwork:                  podTemplate.HostNetwork,
-			DNSPolicy:                    dnsPolicy,
-			DNSConfig:                    podTemplate.DNSConfig,
-			EnableServiceLinks:           podTemplate.EnableServiceLinks,
-			PriorityClassName:            priorityClassName,
-			ImagePullSecrets:             podTemplate.ImagePullSecrets,
-			HostAliases:                  podTemplate.HostAliases,
-			TopologySpreadConstraints:    podTemplate.TopologySpreadConstraints,
-			ActiveDeadlineSeconds:        &activeDeadlineSeconds, // Set ActiveDeadlineSeconds to mark the pod as "terminating" (like a Job)
+			RestartPolicy:                 corev1.RestartPolicyNever,
+			InitContainers:                mergedPodInitContainers,
+			Containers:                    mergedPodContainers,
+			ServiceAccountName:            taskRun.Spec.ServiceAccountName,
+			Volumes:                       volumes,
+			NodeSelector:                  podTemplate.NodeSelector,
+			Tolerations:                   podTemplate.Tolerations,
+			Affinity:                      podTemplate.Affinity,
+			SecurityContext:               securityContext,
+			RuntimeClassName:              podTemplate.RuntimeClassName,
+			AutomountServiceAccountToken:  podTemplate.AutomountServiceAccountToken,
+			SchedulerName:                 podTemplate.SchedulerName,
+			HostNetwork:                   podTemplate.HostNetwork,
+			DNSPolicy:                     dnsPolicy,
+			DNSConfig:                     podTemplate.DNSConfig,
+			EnableServiceLinks:            podTemplate.EnableServiceLinks,
+			PriorityClassName:             priorityClassName,
+			ImagePullSecrets:              podTemplate.ImagePullSecrets,
+			HostAliases:                   podTemplate.HostAliases,
+			TopologySpreadConstraints:     podTemplate.TopologySpreadConstraints,
+			TerminationGracePeriodSeconds: podTemplate.TerminationGracePeriodSeconds,
+			ActiveDeadlineSeconds:         &activeDeadlineSeconds, // Set ActiveDeadlineSeconds to mark the pod as "terminating" (like a Job)
 		},
 	}
 
@@ -551,6 +703,25 @@ func makeLabels(s *v1.TaskRun, defaultManagedByLabelValue string) map[string]str
 	return labels
 }
 
+// podSecurityContext returns the security context of the pod, with its fsGroup
+// set to the default one when neither the pod template nor the cluster operator
+// specified one. Volumes shared between the steps, such as the results and the
+// workspaces, are then group owned so that steps running as different users can
+// read each other's files. fsGroup isn't supported on Windows.
+func podSecurityContext(securityContext *corev1.PodSecurityContext, defaultFSGroup *int64, windows bool) *corev1.PodSecurityContext {
+	if defaultFSGroup == nil || windows || (securityContext != nil && securityContext.FSGroup != nil) {
+		return securityContext
+	}
+	if securityContext == nil {
+		securityContext = &corev1.PodSecurityContext{}
+	} else {
+		securityContext = securityContext.DeepCopy()
+	}
+	fsGroup := *defaultFSGroup
+	securityContext.FSGroup = &fsGroup
+	return securityContext
+}
+
 // isPodReadyImmediately returns a bool indicating whether the
 // controller should consider the Pod "Ready" as soon as it's deployed.
 // This will add the `Ready` annotation when creating the Pod,
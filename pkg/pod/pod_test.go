@@ -18,9 +18,12 @@ package pod
 
 import (
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -88,6 +91,9 @@ func TestPodBuild(t *testing.T) {
 	enableServiceLinks := false
 	priorityClassName := "system-cluster-critical"
 	taskRunName := "taskrun-name"
+	defaultFSGroup := int64(65532)
+	fsGroup := int64(1000)
+	runAsUser := int64(1000)
 
 	for _, c := range []struct {
 		desc            string
@@ -1352,6 +1358,69 @@ _EOF_
 				ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
 			},
 		},
+		{
+			desc: "setting host aliases and dns search domains",
+			ts: v1.TaskSpec{
+				Steps: []v1.Step{
+					{
+						Name:    "host-aliases-dns",
+						Image:   "image",
+						Command: []string{"cmd"}, // avoid entrypoint lookup.
+					},
+				},
+			},
+			trs: v1.TaskRunSpec{
+				PodTemplate: &pod.Template{
+					HostAliases: []corev1.HostAlias{
+						{IP: "10.0.0.10", Hostnames: []string{"git.internal.example.com", "registry.internal.example.com"}},
+						{IP: "fd00::10", Hostnames: []string{"cache.internal.example.com"}},
+					},
+					DNSConfig: &corev1.PodDNSConfig{
+						Searches: []string{"internal.example.com", "example.com."},
+					},
+				},
+			},
+			want: &corev1.PodSpec{
+				RestartPolicy:  corev1.RestartPolicyNever,
+				InitContainers: []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1.Step{{Name: "host-aliases-dns"}}, SecurityContextConfig{SetSecurityContext: false, SetReadOnlyRootFilesystem: false}, false /* windows */)},
+				Volumes: append(implicitVolumes, binVolume, runVolume(0), downwardVolume, corev1.Volume{
+					Name:         "tekton-creds-init-home-0",
+					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
+				}),
+				Containers: []corev1.Container{{
+					Name:    "step-host-aliases-dns",
+					Image:   "image",
+					Command: []string{"/tekton/bin/entrypoint"},
+					Args: []string{
+						"-wait_file",
+						"/tekton/downward/ready",
+						"-wait_file_content",
+						"-post_file",
+						"/tekton/run/0/out",
+						"-termination_path",
+						"/tekton/termination",
+						"-step_metadata_dir",
+						"/tekton/run/0/status",
+						"-entrypoint",
+						"cmd",
+						"--",
+					},
+					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
+						Name:      "tekton-creds-init-home-0",
+						MountPath: "/tekton/creds",
+					}}, implicitVolumeMounts...),
+					TerminationMessagePath: "/tekton/termination",
+				}},
+				HostAliases: []corev1.HostAlias{
+					{IP: "10.0.0.10", Hostnames: []string{"git.internal.example.com", "registry.internal.example.com"}},
+					{IP: "fd00::10", Hostnames: []string{"cache.internal.example.com"}},
+				},
+				DNSConfig: &corev1.PodDNSConfig{
+					Searches: []string{"internal.example.com", "example.com."},
+				},
+				ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
+			},
+		},
 		{
 			desc: "using hostNetwork",
 			ts: v1.TaskSpec{
@@ -1539,27 +1608,19 @@ _EOF_
 			},
 		},
 		{
-			desc: "default-forbidden-env - disallowed via podTemplate.",
+			desc: "default fsGroup and entrypoint umask",
+			featureFlags: map[string]string{
+				"disable-creds-init": "true",
+				"entrypoint-umask":   "0002",
+			},
+			configDefaults: map[string]string{"default-fs-group": "65532"},
 			ts: v1.TaskSpec{
 				Steps: []v1.Step{{
 					Name:    "name",
 					Image:   "image",
 					Command: []string{"cmd"}, // avoid entrypoint lookup.
-					Env: []corev1.EnvVar{
-						{Name: "SOME_ENV", Value: "some_val"},
-						{Name: "FORBIDDEN_ENV", Value: "some_val"},
-					},
 				}},
 			},
-			configDefaults: map[string]string{"default-forbidden-env": "FORBIDDEN_ENV, TEST_ENV"},
-			trs: v1.TaskRunSpec{
-				PodTemplate: &pod.Template{
-					Env: []corev1.EnvVar{
-						{Name: "FORBIDDEN_ENV", Value: "overridden_val"},
-						{Name: "TEST_ENV", Value: "new_val"},
-					},
-				},
-			},
 			want: &corev1.PodSpec{
 				RestartPolicy:  corev1.RestartPolicyNever,
 				InitContainers: []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1.Step{{Name: "name"}}, SecurityContextConfig{SetSecurityContext: false, SetReadOnlyRootFilesystem: false}, false /* windows */)},
@@ -1577,42 +1638,38 @@ _EOF_
 						"/tekton/termination",
 						"-step_metadata_dir",
 						"/tekton/run/0/status",
+						"-umask",
+						"0002",
 						"-entrypoint",
 						"cmd",
 						"--",
 					},
-					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
-						Name:      "tekton-creds-init-home-0",
-						MountPath: "/tekton/creds",
-					}}, implicitVolumeMounts...),
+					VolumeMounts:           append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount}, implicitVolumeMounts...),
 					TerminationMessagePath: "/tekton/termination",
-					Env: []corev1.EnvVar{
-						{Name: "SOME_ENV", Value: "some_val"},
-						{Name: "FORBIDDEN_ENV", Value: "some_val"},
-					},
 				}},
-				Volumes: append(implicitVolumes, binVolume, runVolume(0), downwardVolume, corev1.Volume{
-					Name:         "tekton-creds-init-home-0",
-					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
-				}),
+				Volumes:               append(implicitVolumes, binVolume, runVolume(0), downwardVolume),
 				ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
+				SecurityContext:       &corev1.PodSecurityContext{FSGroup: &defaultFSGroup},
 			},
 		},
 		{
-			desc: "override env var using podTemplate",
+			desc: "fsGroup from pod template overrides default fsGroup",
+			featureFlags: map[string]string{
+				"disable-creds-init": "true",
+			},
+			configDefaults: map[string]string{"default-fs-group": "65532"},
 			ts: v1.TaskSpec{
 				Steps: []v1.Step{{
 					Name:    "name",
 					Image:   "image",
 					Command: []string{"cmd"}, // avoid entrypoint lookup.
-					Env:     []corev1.EnvVar{{Name: "SOME_ENV", Value: "some_val"}},
 				}},
 			},
 			trs: v1.TaskRunSpec{
 				PodTemplate: &pod.Template{
-					Env: []corev1.EnvVar{
-						{Name: "SOME_ENV", Value: "overridden_val"},
-						{Name: "SOME_ENV2", Value: "new_val"},
+					SecurityContext: &corev1.PodSecurityContext{
+						RunAsUser: &runAsUser,
+						FSGroup:   &fsGroup,
 					},
 				},
 			},
@@ -1637,36 +1694,36 @@ _EOF_
 						"cmd",
 						"--",
 					},
-					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
-						Name:      "tekton-creds-init-home-0",
-						MountPath: "/tekton/creds",
-					}}, implicitVolumeMounts...),
+					VolumeMounts:           append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount}, implicitVolumeMounts...),
 					TerminationMessagePath: "/tekton/termination",
-					Env: []corev1.EnvVar{
-						{Name: "SOME_ENV", Value: "some_val"},
-						{Name: "SOME_ENV", Value: "overridden_val"},
-						{Name: "SOME_ENV2", Value: "new_val"},
-					},
 				}},
-				Volumes: append(implicitVolumes, binVolume, runVolume(0), downwardVolume, corev1.Volume{
-					Name:         "tekton-creds-init-home-0",
-					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
-				}),
+				Volumes:               append(implicitVolumes, binVolume, runVolume(0), downwardVolume),
 				ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
+				SecurityContext:       &corev1.PodSecurityContext{RunAsUser: &runAsUser, FSGroup: &fsGroup},
 			},
 		},
 		{
-			desc:         "hermetic env var",
-			featureFlags: map[string]string{"enable-api-fields": "alpha"},
+			desc: "injected sidecar",
+			featureFlags: map[string]string{
+				"disable-creds-init": "true",
+			},
+			configDefaults: map[string]string{"default-injected-sidecars": `- name: log-forwarder
+  image: fluent-bit
+  args: ["-c", "/fluent-bit/etc/fluent-bit.conf"]
+  workspaces: ["logs", "undeclared"]`},
 			ts: v1.TaskSpec{
 				Steps: []v1.Step{{
 					Name:    "name",
 					Image:   "image",
 					Command: []string{"cmd"}, // avoid entrypoint lookup.
 				}},
+				StepTemplate: &v1.StepTemplate{
+					VolumeMounts: []corev1.VolumeMount{{Name: "ws-logs", MountPath: "/workspace/logs"}},
+				},
+				Workspaces: []v1.WorkspaceDeclaration{{Name: "logs"}},
 			},
-			trAnnotation: map[string]string{
-				"experimental.tekton.dev/execution-mode": "hermetic",
+			wantAnnotations: map[string]string{
+				readinessExcludedSidecarsAnnotation: "sidecar-log-forwarder",
 			},
 			want: &corev1.PodSpec{
 				RestartPolicy:  corev1.RestartPolicyNever,
@@ -1689,36 +1746,38 @@ _EOF_
 						"cmd",
 						"--",
 					},
-					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
-						Name:      "tekton-creds-init-home-0",
-						MountPath: "/tekton/creds",
-					}}, implicitVolumeMounts...),
+					VolumeMounts:           append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount}, append(implicitVolumeMounts, corev1.VolumeMount{Name: "ws-logs", MountPath: "/workspace/logs"})...),
 					TerminationMessagePath: "/tekton/termination",
-					Env: []corev1.EnvVar{
-						{Name: "TEKTON_HERMETIC", Value: "1"},
-					},
+				}, {
+					Name:         "sidecar-log-forwarder",
+					Image:        "fluent-bit",
+					Args:         []string{"-c", "/fluent-bit/etc/fluent-bit.conf"},
+					VolumeMounts: []corev1.VolumeMount{{Name: "ws-logs", MountPath: "/workspace/logs"}},
 				}},
-				Volumes: append(implicitVolumes, binVolume, runVolume(0), downwardVolume, corev1.Volume{
-					Name:         "tekton-creds-init-home-0",
-					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
-				}),
+				Volumes:               append(implicitVolumes, binVolume, runVolume(0), downwardVolume),
 				ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
 			},
 		},
 		{
-			desc:         "override hermetic env var",
-			featureFlags: map[string]string{"enable-api-fields": "alpha"},
+			desc: "injected sidecar awaiting readiness with the name of a sidecar of the Task",
+			featureFlags: map[string]string{
+				"disable-creds-init": "true",
+			},
+			configDefaults: map[string]string{"default-injected-sidecars": `- name: sc-name
+  image: injected-image
+  awaitReadiness: true`},
 			ts: v1.TaskSpec{
 				Steps: []v1.Step{{
 					Name:    "name",
 					Image:   "image",
 					Command: []string{"cmd"}, // avoid entrypoint lookup.
-					Env:     []corev1.EnvVar{{Name: "TEKTON_HERMETIC", Value: "something_else"}},
+				}},
+				Sidecars: []v1.Sidecar{{
+					Name:  "sc-name",
+					Image: "sidecar-image",
 				}},
 			},
-			trAnnotation: map[string]string{
-				"experimental.tekton.dev/execution-mode": "hermetic",
-			},
+			wantAnnotations: map[string]string{},
 			want: &corev1.PodSpec{
 				RestartPolicy:  corev1.RestartPolicyNever,
 				InitContainers: []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1.Step{{Name: "name"}}, SecurityContextConfig{SetSecurityContext: false, SetReadOnlyRootFilesystem: false}, false /* windows */)},
@@ -1740,26 +1799,29 @@ _EOF_
 						"cmd",
 						"--",
 					},
-					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
-						Name:      "tekton-creds-init-home-0",
-						MountPath: "/tekton/creds",
-					}}, implicitVolumeMounts...),
+					VolumeMounts:           append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount}, implicitVolumeMounts...),
 					TerminationMessagePath: "/tekton/termination",
-					Env: []corev1.EnvVar{
-						{Name: "TEKTON_HERMETIC", Value: "something_else"},
-						// this value must be second to override the first
-						{Name: "TEKTON_HERMETIC", Value: "1"},
-					},
+				}, {
+					Name:  "sidecar-sc-name",
+					Image: "sidecar-image",
+				}, {
+					Name:  "sidecar-sc-name-injected",
+					Image: "injected-image",
 				}},
-				Volumes: append(implicitVolumes, binVolume, runVolume(0), downwardVolume, corev1.Volume{
-					Name:         "tekton-creds-init-home-0",
-					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
-				}),
+				Volumes:               append(implicitVolumes, binVolume, runVolume(0), downwardVolume),
 				ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
 			},
 		},
 		{
-			desc: "pod for a taskRun with retries",
+			desc: "injected sidecar opted out of",
+			featureFlags: map[string]string{
+				"disable-creds-init": "true",
+			},
+			configDefaults: map[string]string{"default-injected-sidecars": `- name: log-forwarder
+  image: fluent-bit`},
+			trAnnotation: map[string]string{
+				InjectSidecarsAnnotation: "false",
+			},
 			ts: v1.TaskSpec{
 				Steps: []v1.Step{{
 					Name:    "name",
@@ -1767,24 +1829,8 @@ _EOF_
 					Command: []string{"cmd"}, // avoid entrypoint lookup.
 				}},
 			},
-			trStatus: v1.TaskRunStatus{
-				TaskRunStatusFields: v1.TaskRunStatusFields{
-					RetriesStatus: []v1.TaskRunStatus{{
-						Status: duckv1.Status{
-							Conditions: []apis.Condition{{
-								Type:   apis.ConditionSucceeded,
-								Status: corev1.ConditionFalse,
-							}},
-						},
-					}, {
-						Status: duckv1.Status{
-							Conditions: []apis.Condition{{
-								Type:   apis.ConditionSucceeded,
-								Status: corev1.ConditionFalse,
-							}},
-						},
-					}},
-				},
+			wantAnnotations: map[string]string{
+				InjectSidecarsAnnotation: "false",
 			},
 			want: &corev1.PodSpec{
 				RestartPolicy:  corev1.RestartPolicyNever,
@@ -1807,22 +1853,23 @@ _EOF_
 						"cmd",
 						"--",
 					},
-					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
-						Name:      "tekton-creds-init-home-0",
-						MountPath: "/tekton/creds",
-					}}, implicitVolumeMounts...),
+					VolumeMounts:           append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount}, implicitVolumeMounts...),
 					TerminationMessagePath: "/tekton/termination",
 				}},
-				Volumes: append(implicitVolumes, binVolume, runVolume(0), downwardVolume, corev1.Volume{
-					Name:         "tekton-creds-init-home-0",
-					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
-				}),
+				Volumes:               append(implicitVolumes, binVolume, runVolume(0), downwardVolume),
 				ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
 			},
-			wantPodName: taskRunName + "-pod-retry2",
 		},
 		{
-			desc: "long-taskrun-name",
+			desc: "injected sidecars disabled for the namespace",
+			featureFlags: map[string]string{
+				"disable-creds-init": "true",
+			},
+			configDefaults: map[string]string{
+				"default-injected-sidecars": `- name: log-forwarder
+  image: fluent-bit`,
+				"default-injected-sidecars-by-namespace": `default: []`,
+			},
 			ts: v1.TaskSpec{
 				Steps: []v1.Step{{
 					Name:    "name",
@@ -1830,8 +1877,7 @@ _EOF_
 					Command: []string{"cmd"}, // avoid entrypoint lookup.
 				}},
 			},
-			trName:      "task-run-0123456789-0123456789-0123456789-0123456789-0123456789-0123456789",
-			wantPodName: "task-run-0123456789-01234560d38957287bb0283c59440df14069f59-pod",
+			wantAnnotations: map[string]string{},
 			want: &corev1.PodSpec{
 				RestartPolicy:  corev1.RestartPolicyNever,
 				InitContainers: []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1.Step{{Name: "name"}}, SecurityContextConfig{SetSecurityContext: false, SetReadOnlyRootFilesystem: false}, false /* windows */)},
@@ -1853,67 +1899,34 @@ _EOF_
 						"cmd",
 						"--",
 					},
-					VolumeMounts: append([]corev1.VolumeMount{downwardMount, {
-						Name:      "tekton-creds-init-home-0",
-						MountPath: "/tekton/creds",
-					}, runMount(0, false), binROMount}, implicitVolumeMounts...),
+					VolumeMounts:           append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount}, implicitVolumeMounts...),
 					TerminationMessagePath: "/tekton/termination",
 				}},
-				Volumes: append(implicitVolumes, binVolume, downwardVolume, corev1.Volume{
-					Name:         "tekton-creds-init-home-0",
-					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
-				}, runVolume(0)),
+				Volumes:               append(implicitVolumes, binVolume, runVolume(0), downwardVolume),
 				ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
 			},
 		},
 		{
-			desc: "using TopologySpreadConstraints",
+			desc: "step with custom container name",
+			featureFlags: map[string]string{
+				"disable-creds-init": "true",
+			},
 			ts: v1.TaskSpec{
-				Steps: []v1.Step{
-					{
-						Name:    "use-topologySpreadConstraints",
-						Image:   "image",
-						Command: []string{"cmd"}, // avoid entrypoint lookup.
-					},
-				},
+				Steps: []v1.Step{{
+					Name:          "build",
+					ContainerName: "builder",
+					Image:         "image",
+					Command:       []string{"cmd"}, // avoid entrypoint lookup.
+				}},
 			},
-			trs: v1.TaskRunSpec{
-				PodTemplate: &pod.Template{
-					TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
-						{
-							MaxSkew:           1,
-							TopologyKey:       "zone",
-							WhenUnsatisfiable: corev1.DoNotSchedule,
-							LabelSelector: &metav1.LabelSelector{
-								MatchLabels: map[string]string{
-									"foo": "bar",
-								},
-							},
-						},
-					},
-				},
+			wantAnnotations: map[string]string{
+				stepContainerNamesAnnotation: "builder=step-build",
 			},
 			want: &corev1.PodSpec{
 				RestartPolicy:  corev1.RestartPolicyNever,
-				InitContainers: []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1.Step{{Name: "use-topologySpreadConstraints"}}, SecurityContextConfig{SetSecurityContext: false, SetReadOnlyRootFilesystem: false}, false /* windows */)},
-				TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
-					{
-						MaxSkew:           1,
-						TopologyKey:       "zone",
-						WhenUnsatisfiable: corev1.DoNotSchedule,
-						LabelSelector: &metav1.LabelSelector{
-							MatchLabels: map[string]string{
-								"foo": "bar",
-							},
-						},
-					},
-				},
-				Volumes: append(implicitVolumes, binVolume, runVolume(0), downwardVolume, corev1.Volume{
-					Name:         "tekton-creds-init-home-0",
-					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
-				}),
+				InitContainers: []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1.Step{{Name: "build"}}, SecurityContextConfig{SetSecurityContext: false, SetReadOnlyRootFilesystem: false}, false /* windows */)},
 				Containers: []corev1.Container{{
-					Name:    "step-use-topologySpreadConstraints",
+					Name:    "builder",
 					Image:   "image",
 					Command: []string{"/tekton/bin/entrypoint"},
 					Args: []string{
@@ -1930,34 +1943,38 @@ _EOF_
 						"cmd",
 						"--",
 					},
-					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
-						Name:      "tekton-creds-init-home-0",
-						MountPath: "/tekton/creds",
-					}}, implicitVolumeMounts...),
+					VolumeMounts:           append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount}, implicitVolumeMounts...),
 					TerminationMessagePath: "/tekton/termination",
 				}},
+				Volumes:               append(implicitVolumes, binVolume, runVolume(0), downwardVolume),
 				ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
 			},
 		},
 		{
-			desc:         "sidecar logs enabled, artifacts not enabled",
-			featureFlags: map[string]string{"results-from": "sidecar-logs"},
+			desc: "default-forbidden-env - disallowed via podTemplate.",
 			ts: v1.TaskSpec{
-				Results: []v1.TaskResult{{
-					Name: "foo",
-					Type: v1.ResultsTypeString,
-				}},
 				Steps: []v1.Step{{
 					Name:    "name",
 					Image:   "image",
 					Command: []string{"cmd"}, // avoid entrypoint lookup.
+					Env: []corev1.EnvVar{
+						{Name: "SOME_ENV", Value: "some_val"},
+						{Name: "FORBIDDEN_ENV", Value: "some_val"},
+					},
 				}},
 			},
-			want: &corev1.PodSpec{
-				RestartPolicy: corev1.RestartPolicyNever,
-				InitContainers: []corev1.Container{
-					entrypointInitContainer(images.EntrypointImage, []v1.Step{{Name: "name"}}, SecurityContextConfig{SetSecurityContext: false, SetReadOnlyRootFilesystem: false}, false /* windows */),
+			configDefaults: map[string]string{"default-forbidden-env": "FORBIDDEN_ENV, TEST_ENV"},
+			trs: v1.TaskRunSpec{
+				PodTemplate: &pod.Template{
+					Env: []corev1.EnvVar{
+						{Name: "FORBIDDEN_ENV", Value: "overridden_val"},
+						{Name: "TEST_ENV", Value: "new_val"},
+					},
 				},
+			},
+			want: &corev1.PodSpec{
+				RestartPolicy:  corev1.RestartPolicyNever,
+				InitContainers: []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1.Step{{Name: "name"}}, SecurityContextConfig{SetSecurityContext: false, SetReadOnlyRootFilesystem: false}, false /* windows */)},
 				Containers: []corev1.Container{{
 					Name:    "step-name",
 					Image:   "image",
@@ -1972,10 +1989,6 @@ _EOF_
 						"/tekton/termination",
 						"-step_metadata_dir",
 						"/tekton/run/0/status",
-						"-result_from",
-						"sidecar-logs",
-						"-results",
-						"foo",
 						"-entrypoint",
 						"cmd",
 						"--",
@@ -1985,27 +1998,10 @@ _EOF_
 						MountPath: "/tekton/creds",
 					}}, implicitVolumeMounts...),
 					TerminationMessagePath: "/tekton/termination",
-				}, {
-					Name:  pipeline.ReservedResultsSidecarContainerName,
-					Image: "",
-					Command: []string{
-						"/ko-app/sidecarlogresults",
-						"-results-dir",
-						"/tekton/results",
-						"-result-names",
-						"foo",
-						"-step-names",
-						"",
-						"-step-results",
-						"{}",
-					},
-					Resources: corev1.ResourceRequirements{
-						Requests: nil,
+					Env: []corev1.EnvVar{
+						{Name: "SOME_ENV", Value: "some_val"},
+						{Name: "FORBIDDEN_ENV", Value: "some_val"},
 					},
-					VolumeMounts: append([]corev1.VolumeMount{
-						{Name: "tekton-internal-bin", ReadOnly: true, MountPath: "/tekton/bin"},
-						{Name: "tekton-internal-run-0", ReadOnly: true, MountPath: "/tekton/run/0"},
-					}, implicitVolumeMounts...),
 				}},
 				Volumes: append(implicitVolumes, binVolume, runVolume(0), downwardVolume, corev1.Volume{
 					Name:         "tekton-creds-init-home-0",
@@ -2015,28 +2011,27 @@ _EOF_
 			},
 		},
 		{
-			desc:         "sidecar logs enabled with step results, artifacts not enabled",
-			featureFlags: map[string]string{"results-from": "sidecar-logs"},
+			desc: "env vars reserved for Tekton dropped from podTemplate",
 			ts: v1.TaskSpec{
-				Results: []v1.TaskResult{{
-					Name: "foo",
-					Type: v1.ResultsTypeString,
-				}},
 				Steps: []v1.Step{{
-					Name: "name",
-					Results: []v1.StepResult{{
-						Name: "step-foo",
-						Type: v1.ResultsTypeString,
-					}},
+					Name:    "name",
 					Image:   "image",
 					Command: []string{"cmd"}, // avoid entrypoint lookup.
+					Env:     []corev1.EnvVar{{Name: "SOME_ENV", Value: "some_val"}},
 				}},
 			},
-			want: &corev1.PodSpec{
-				RestartPolicy: corev1.RestartPolicyNever,
-				InitContainers: []corev1.Container{
-					entrypointInitContainer(images.EntrypointImage, []v1.Step{{Name: "name"}}, SecurityContextConfig{SetSecurityContext: false, SetReadOnlyRootFilesystem: false}, false /* windows */),
+			trs: v1.TaskRunSpec{
+				PodTemplate: &pod.Template{
+					Env: []corev1.EnvVar{
+						{Name: "TEKTON_STEP_STDIN_VALUE", Value: "overridden_val"},
+						{Name: "TEKTON_HERMETIC", Value: "1"},
+						{Name: "SOME_ENV2", Value: "new_val"},
+					},
 				},
+			},
+			want: &corev1.PodSpec{
+				RestartPolicy:  corev1.RestartPolicyNever,
+				InitContainers: []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1.Step{{Name: "name"}}, SecurityContextConfig{SetSecurityContext: false, SetReadOnlyRootFilesystem: false}, false /* windows */)},
 				Containers: []corev1.Container{{
 					Name:    "step-name",
 					Image:   "image",
@@ -2051,12 +2046,6 @@ _EOF_
 						"/tekton/termination",
 						"-step_metadata_dir",
 						"/tekton/run/0/status",
-						"-result_from",
-						"sidecar-logs",
-						"-step_results",
-						"step-foo",
-						"-results",
-						"foo",
 						"-entrypoint",
 						"cmd",
 						"--",
@@ -2066,27 +2055,10 @@ _EOF_
 						MountPath: "/tekton/creds",
 					}}, implicitVolumeMounts...),
 					TerminationMessagePath: "/tekton/termination",
-				}, {
-					Name:  pipeline.ReservedResultsSidecarContainerName,
-					Image: "",
-					Command: []string{
-						"/ko-app/sidecarlogresults",
-						"-results-dir",
-						"/tekton/results",
-						"-result-names",
-						"foo",
-						"-step-names",
-						"",
-						"-step-results",
-						"{\"step-name\":[\"step-foo\"]}",
-					},
-					Resources: corev1.ResourceRequirements{
-						Requests: nil,
+					Env: []corev1.EnvVar{
+						{Name: "SOME_ENV", Value: "some_val"},
+						{Name: "SOME_ENV2", Value: "new_val"},
 					},
-					VolumeMounts: append([]corev1.VolumeMount{
-						{Name: "tekton-internal-bin", ReadOnly: true, MountPath: "/tekton/bin"},
-						{Name: "tekton-internal-run-0", ReadOnly: true, MountPath: "/tekton/run/0"},
-					}, implicitVolumeMounts...),
 				}},
 				Volumes: append(implicitVolumes, binVolume, runVolume(0), downwardVolume, corev1.Volume{
 					Name:         "tekton-creds-init-home-0",
@@ -2096,24 +2068,26 @@ _EOF_
 			},
 		},
 		{
-			desc:         "sidecar logs enabled and artifacts not enabled, set security context is true",
-			featureFlags: map[string]string{"results-from": "sidecar-logs", "set-security-context": "true", "set-security-context-read-only-root-filesystem": "true"},
+			desc: "override env var using podTemplate",
 			ts: v1.TaskSpec{
-				Results: []v1.TaskResult{{
-					Name: "foo",
-					Type: v1.ResultsTypeString,
-				}},
 				Steps: []v1.Step{{
 					Name:    "name",
 					Image:   "image",
 					Command: []string{"cmd"}, // avoid entrypoint lookup.
+					Env:     []corev1.EnvVar{{Name: "SOME_ENV", Value: "some_val"}},
 				}},
 			},
-			want: &corev1.PodSpec{
-				RestartPolicy: corev1.RestartPolicyNever,
-				InitContainers: []corev1.Container{
-					entrypointInitContainer(images.EntrypointImage, []v1.Step{{Name: "name"}}, SecurityContextConfig{SetSecurityContext: true, SetReadOnlyRootFilesystem: true}, false /* windows */),
+			trs: v1.TaskRunSpec{
+				PodTemplate: &pod.Template{
+					Env: []corev1.EnvVar{
+						{Name: "SOME_ENV", Value: "overridden_val"},
+						{Name: "SOME_ENV2", Value: "new_val"},
+					},
 				},
+			},
+			want: &corev1.PodSpec{
+				RestartPolicy:  corev1.RestartPolicyNever,
+				InitContainers: []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1.Step{{Name: "name"}}, SecurityContextConfig{SetSecurityContext: false, SetReadOnlyRootFilesystem: false}, false /* windows */)},
 				Containers: []corev1.Container{{
 					Name:    "step-name",
 					Image:   "image",
@@ -2128,10 +2102,6 @@ _EOF_
 						"/tekton/termination",
 						"-step_metadata_dir",
 						"/tekton/run/0/status",
-						"-result_from",
-						"sidecar-logs",
-						"-results",
-						"foo",
 						"-entrypoint",
 						"cmd",
 						"--",
@@ -2141,28 +2111,11 @@ _EOF_
 						MountPath: "/tekton/creds",
 					}}, implicitVolumeMounts...),
 					TerminationMessagePath: "/tekton/termination",
-				}, {
-					Name:  pipeline.ReservedResultsSidecarContainerName,
-					Image: "",
-					Command: []string{
-						"/ko-app/sidecarlogresults",
-						"-results-dir",
-						"/tekton/results",
-						"-result-names",
-						"foo",
-						"-step-names",
-						"",
-						"-step-results",
-						"{}",
-					},
-					Resources: corev1.ResourceRequirements{
-						Requests: nil,
+					Env: []corev1.EnvVar{
+						{Name: "SOME_ENV", Value: "some_val"},
+						{Name: "SOME_ENV", Value: "overridden_val"},
+						{Name: "SOME_ENV2", Value: "new_val"},
 					},
-					VolumeMounts: append([]corev1.VolumeMount{
-						{Name: "tekton-internal-bin", ReadOnly: true, MountPath: "/tekton/bin"},
-						{Name: "tekton-internal-run-0", ReadOnly: true, MountPath: "/tekton/run/0"},
-					}, implicitVolumeMounts...),
-					SecurityContext: SecurityContextConfig{SetSecurityContext: true, SetReadOnlyRootFilesystem: true}.GetSecurityContext(false),
 				}},
 				Volumes: append(implicitVolumes, binVolume, runVolume(0), downwardVolume, corev1.Volume{
 					Name:         "tekton-creds-init-home-0",
@@ -2172,24 +2125,21 @@ _EOF_
 			},
 		},
 		{
-			desc:         "sidecar logs enabled and artifacts referenced",
-			featureFlags: map[string]string{"results-from": "sidecar-logs", "enable-artifacts": "true"},
+			desc:         "hermetic env var",
+			featureFlags: map[string]string{"enable-api-fields": "alpha"},
 			ts: v1.TaskSpec{
-				Results: []v1.TaskResult{{
-					Name: "foo",
-					Type: v1.ResultsTypeString,
-				}},
 				Steps: []v1.Step{{
 					Name:    "name",
 					Image:   "image",
-					Command: []string{"echo", "aaa", ">>>", "/tekton/steps/step-name/artifacts/provenance.json"}, // avoid entrypoint lookup.
+					Command: []string{"cmd"}, // avoid entrypoint lookup.
 				}},
 			},
+			trAnnotation: map[string]string{
+				"experimental.tekton.dev/execution-mode": "hermetic",
+			},
 			want: &corev1.PodSpec{
-				RestartPolicy: corev1.RestartPolicyNever,
-				InitContainers: []corev1.Container{
-					entrypointInitContainer(images.EntrypointImage, []v1.Step{{Name: "name"}}, SecurityContextConfig{SetSecurityContext: false, SetReadOnlyRootFilesystem: false}, false /* windows */),
-				},
+				RestartPolicy:  corev1.RestartPolicyNever,
+				InitContainers: []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1.Step{{Name: "name"}}, SecurityContextConfig{SetSecurityContext: false, SetReadOnlyRootFilesystem: false}, false /* windows */)},
 				Containers: []corev1.Container{{
 					Name:    "step-name",
 					Image:   "image",
@@ -2204,43 +2154,18 @@ _EOF_
 						"/tekton/termination",
 						"-step_metadata_dir",
 						"/tekton/run/0/status",
-						"-result_from",
-						"sidecar-logs",
-						"-results",
-						"foo",
 						"-entrypoint",
-						"echo",
+						"cmd",
 						"--",
-						"aaa",
-						">>>",
-						"/tekton/steps/step-name/artifacts/provenance.json",
 					},
 					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
 						Name:      "tekton-creds-init-home-0",
 						MountPath: "/tekton/creds",
 					}}, implicitVolumeMounts...),
 					TerminationMessagePath: "/tekton/termination",
-				}, {
-					Name:  pipeline.ReservedResultsSidecarContainerName,
-					Image: "",
-					Command: []string{
-						"/ko-app/sidecarlogresults",
-						"-results-dir",
-						"/tekton/results",
-						"-result-names",
-						"foo",
-						"-step-names",
-						"step-name",
-						"-step-results",
-						"{}",
-					},
-					Resources: corev1.ResourceRequirements{
-						Requests: nil,
+					Env: []corev1.EnvVar{
+						{Name: "TEKTON_HERMETIC", Value: "1"},
 					},
-					VolumeMounts: append([]corev1.VolumeMount{
-						{Name: "tekton-internal-bin", ReadOnly: true, MountPath: "/tekton/bin"},
-						{Name: "tekton-internal-run-0", ReadOnly: true, MountPath: "/tekton/run/0"},
-					}, implicitVolumeMounts...),
 				}},
 				Volumes: append(implicitVolumes, binVolume, runVolume(0), downwardVolume, corev1.Volume{
 					Name:         "tekton-creds-init-home-0",
@@ -2250,28 +2175,22 @@ _EOF_
 			},
 		},
 		{
-			desc:         "sidecar logs enabled with step results, artifacts referenced",
-			featureFlags: map[string]string{"results-from": "sidecar-logs", "enable-artifacts": "true"},
+			desc:         "override hermetic env var",
+			featureFlags: map[string]string{"enable-api-fields": "alpha"},
 			ts: v1.TaskSpec{
-				Results: []v1.TaskResult{{
-					Name: "foo",
-					Type: v1.ResultsTypeString,
-				}},
 				Steps: []v1.Step{{
-					Name: "name",
-					Results: []v1.StepResult{{
-						Name: "step-foo",
-						Type: v1.ResultsTypeString,
-					}},
+					Name:    "name",
 					Image:   "image",
-					Command: []string{"echo", "aaa", ">>>", "/tekton/steps/step-name/artifacts/provenance.json"}, //
+					Command: []string{"cmd"}, // avoid entrypoint lookup.
+					Env:     []corev1.EnvVar{{Name: "TEKTON_HERMETIC", Value: "something_else"}},
 				}},
 			},
+			trAnnotation: map[string]string{
+				"experimental.tekton.dev/execution-mode": "hermetic",
+			},
 			want: &corev1.PodSpec{
-				RestartPolicy: corev1.RestartPolicyNever,
-				InitContainers: []corev1.Container{
-					entrypointInitContainer(images.EntrypointImage, []v1.Step{{Name: "name"}}, SecurityContextConfig{SetSecurityContext: false, SetReadOnlyRootFilesystem: false}, false /* windows */),
-				},
+				RestartPolicy:  corev1.RestartPolicyNever,
+				InitContainers: []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1.Step{{Name: "name"}}, SecurityContextConfig{SetSecurityContext: false, SetReadOnlyRootFilesystem: false}, false /* windows */)},
 				Containers: []corev1.Container{{
 					Name:    "step-name",
 					Image:   "image",
@@ -2286,45 +2205,20 @@ _EOF_
 						"/tekton/termination",
 						"-step_metadata_dir",
 						"/tekton/run/0/status",
-						"-result_from",
-						"sidecar-logs",
-						"-step_results",
-						"step-foo",
-						"-results",
-						"foo",
 						"-entrypoint",
-						"echo",
+						"cmd",
 						"--",
-						"aaa",
-						">>>",
-						"/tekton/steps/step-name/artifacts/provenance.json",
 					},
 					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
 						Name:      "tekton-creds-init-home-0",
 						MountPath: "/tekton/creds",
 					}}, implicitVolumeMounts...),
 					TerminationMessagePath: "/tekton/termination",
-				}, {
-					Name:  pipeline.ReservedResultsSidecarContainerName,
-					Image: "",
-					Command: []string{
-						"/ko-app/sidecarlogresults",
-						"-results-dir",
-						"/tekton/results",
-						"-result-names",
-						"foo",
-						"-step-names",
-						"step-name",
-						"-step-results",
-						"{\"step-name\":[\"step-foo\"]}",
-					},
-					Resources: corev1.ResourceRequirements{
-						Requests: nil,
+					Env: []corev1.EnvVar{
+						{Name: "TEKTON_HERMETIC", Value: "something_else"},
+						// this value must be second to override the first
+						{Name: "TEKTON_HERMETIC", Value: "1"},
 					},
-					VolumeMounts: append([]corev1.VolumeMount{
-						{Name: "tekton-internal-bin", ReadOnly: true, MountPath: "/tekton/bin"},
-						{Name: "tekton-internal-run-0", ReadOnly: true, MountPath: "/tekton/run/0"},
-					}, implicitVolumeMounts...),
 				}},
 				Volumes: append(implicitVolumes, binVolume, runVolume(0), downwardVolume, corev1.Volume{
 					Name:         "tekton-creds-init-home-0",
@@ -2334,24 +2228,35 @@ _EOF_
 			},
 		},
 		{
-			desc:         "sidecar logs enabled, artifacts referenced and security context set ",
-			featureFlags: map[string]string{"results-from": "sidecar-logs", "set-security-context": "true", "set-security-context-read-only-root-filesystem": "true", "enable-artifacts": "true"},
+			desc: "hardened hermetic pod",
+			featureFlags: map[string]string{
+				"enable-api-fields":         "alpha",
+				"enable-hermetic-hardening": "true",
+				"disable-creds-init":        "true",
+			},
 			ts: v1.TaskSpec{
-				Results: []v1.TaskResult{{
-					Name: "foo",
-					Type: v1.ResultsTypeString,
-				}},
 				Steps: []v1.Step{{
 					Name:    "name",
 					Image:   "image",
-					Command: []string{"echo", "aaa", ">>>", "/tekton/steps/step-name/artifacts/provenance.json"},
+					Command: []string{"cmd"}, // avoid entrypoint lookup.
+					SecurityContext: &corev1.SecurityContext{
+						RunAsUser: &runAsUser,
+					},
 				}},
 			},
-			want: &corev1.PodSpec{
-				RestartPolicy: corev1.RestartPolicyNever,
-				InitContainers: []corev1.Container{
-					entrypointInitContainer(images.EntrypointImage, []v1.Step{{Name: "name"}}, SecurityContextConfig{SetSecurityContext: true, SetReadOnlyRootFilesystem: true}, false /* windows */),
+			trs: v1.TaskRunSpec{
+				PodTemplate: &pod.Template{
+					SecurityContext: &corev1.PodSecurityContext{
+						Sysctls: []corev1.Sysctl{{Name: "net.ipv4.ip_local_port_range", Value: "1024 65535"}},
+					},
 				},
+			},
+			trAnnotation: map[string]string{
+				"experimental.tekton.dev/execution-mode": "hermetic",
+			},
+			want: &corev1.PodSpec{
+				RestartPolicy:  corev1.RestartPolicyNever,
+				InitContainers: []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1.Step{{Name: "name"}}, SecurityContextConfig{SetSecurityContext: false, SetReadOnlyRootFilesystem: false}, false /* windows */)},
 				Containers: []corev1.Container{{
 					Name:    "step-name",
 					Image:   "image",
@@ -2366,54 +2271,47 @@ _EOF_
 						"/tekton/termination",
 						"-step_metadata_dir",
 						"/tekton/run/0/status",
-						"-result_from",
-						"sidecar-logs",
-						"-results",
-						"foo",
+						"-report_hermetic_violations",
 						"-entrypoint",
-						"echo",
+						"cmd",
 						"--",
-						"aaa",
-						">>>",
-						"/tekton/steps/step-name/artifacts/provenance.json",
 					},
-					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
-						Name:      "tekton-creds-init-home-0",
-						MountPath: "/tekton/creds",
-					}}, implicitVolumeMounts...),
+					VolumeMounts:           append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount}, implicitVolumeMounts...),
 					TerminationMessagePath: "/tekton/termination",
-				}, {
-					Name:  pipeline.ReservedResultsSidecarContainerName,
-					Image: "",
-					Command: []string{
-						"/ko-app/sidecarlogresults",
-						"-results-dir",
-						"/tekton/results",
-						"-result-names",
-						"foo",
-						"-step-names",
-						"step-name",
-						"-step-results",
-						"{}",
+					Env: []corev1.EnvVar{
+						{Name: "TEKTON_HERMETIC", Value: "1"},
 					},
-					Resources: corev1.ResourceRequirements{
-						Requests: nil,
+					SecurityContext: &corev1.SecurityContext{
+						RunAsUser: &runAsUser,
+						Capabilities: &corev1.Capabilities{
+							Drop: []corev1.Capability{"NET_RAW"},
+						},
 					},
-					VolumeMounts: append([]corev1.VolumeMount{
-						{Name: "tekton-internal-bin", ReadOnly: true, MountPath: "/tekton/bin"},
-						{Name: "tekton-internal-run-0", ReadOnly: true, MountPath: "/tekton/run/0"},
-					}, implicitVolumeMounts...),
-					SecurityContext: SecurityContextConfig{SetSecurityContext: true, SetReadOnlyRootFilesystem: true}.GetSecurityContext(false),
 				}},
-				Volumes: append(implicitVolumes, binVolume, runVolume(0), downwardVolume, corev1.Volume{
-					Name:         "tekton-creds-init-home-0",
-					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
-				}),
+				SecurityContext: &corev1.PodSecurityContext{
+					Sysctls: []corev1.Sysctl{
+						{Name: "net.ipv4.ip_local_port_range", Value: "1024 65535"},
+						{Name: "net.ipv4.ping_group_range", Value: "1 0"},
+					},
+				},
+				Volumes:               append(implicitVolumes, binVolume, runVolume(0), downwardVolume),
 				ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
 			},
 		},
 		{
-			desc: "simple with security context",
+			desc: "hardened hermetic pod with the hermetic network sidecar",
+			featureFlags: map[string]string{
+				"enable-api-fields":         "alpha",
+				"enable-hermetic-hardening": "true",
+				"disable-creds-init":        "true",
+			},
+			configDefaults: map[string]string{
+				"default-injected-sidecars": `- name: log-forwarder
+  image: fluent-bit`,
+				"default-hermetic-network-sidecar": `name: network-deny
+image: network-deny-image
+awaitReadiness: true`,
+			},
 			ts: v1.TaskSpec{
 				Steps: []v1.Step{{
 					Name:    "name",
@@ -2421,10 +2319,16 @@ _EOF_
 					Command: []string{"cmd"}, // avoid entrypoint lookup.
 				}},
 			},
-			featureFlags: map[string]string{"set-security-context": "true", "set-security-context-read-only-root-filesystem": "true"},
+			trAnnotation: map[string]string{
+				"experimental.tekton.dev/execution-mode": "hermetic",
+			},
+			wantAnnotations: map[string]string{
+				"experimental.tekton.dev/execution-mode": "hermetic",
+				readinessExcludedSidecarsAnnotation:      "sidecar-log-forwarder",
+			},
 			want: &corev1.PodSpec{
 				RestartPolicy:  corev1.RestartPolicyNever,
-				InitContainers: []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1.Step{{Name: "name"}}, SecurityContextConfig{SetSecurityContext: true, SetReadOnlyRootFilesystem: true}, false /* windows */)},
+				InitContainers: []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1.Step{{Name: "name"}}, SecurityContextConfig{SetSecurityContext: false, SetReadOnlyRootFilesystem: false}, false /* windows */)},
 				Containers: []corev1.Container{{
 					Name:    "step-name",
 					Image:   "image",
@@ -2439,26 +2343,50 @@ _EOF_
 						"/tekton/termination",
 						"-step_metadata_dir",
 						"/tekton/run/0/status",
+						"-report_hermetic_violations",
 						"-entrypoint",
 						"cmd",
 						"--",
 					},
-					VolumeMounts: append([]corev1.VolumeMount{downwardMount, {
-						Name:      "tekton-creds-init-home-0",
-						MountPath: "/tekton/creds",
-					}, runMount(0, false), binROMount}, implicitVolumeMounts...),
+					VolumeMounts:           append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount}, implicitVolumeMounts...),
 					TerminationMessagePath: "/tekton/termination",
+					Env: []corev1.EnvVar{
+						{Name: "TEKTON_HERMETIC", Value: "1"},
+					},
+					SecurityContext: &corev1.SecurityContext{
+						Capabilities: &corev1.Capabilities{
+							Drop: []corev1.Capability{"NET_RAW"},
+						},
+					},
+				}, {
+					Name:  "sidecar-log-forwarder",
+					Image: "fluent-bit",
+				}, {
+					Name:  "sidecar-network-deny",
+					Image: "network-deny-image",
+					SecurityContext: &corev1.SecurityContext{
+						Capabilities: &corev1.Capabilities{
+							Add: []corev1.Capability{"NET_ADMIN"},
+						},
+					},
 				}},
-				Volumes: append(implicitVolumes, binVolume, downwardVolume, corev1.Volume{
-					Name:         "tekton-creds-init-home-0",
-					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
-				}, runVolume(0)),
+				SecurityContext: &corev1.PodSecurityContext{
+					Sysctls: []corev1.Sysctl{{Name: "net.ipv4.ping_group_range", Value: "1 0"}},
+				},
+				Volumes:               append(implicitVolumes, binVolume, runVolume(0), downwardVolume),
 				ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
 			},
 		},
 		{
-			desc:         "keep pod on cancel enabled",
-			featureFlags: map[string]string{"keep-pod-on-cancel": "true", "enable-api-fields": "alpha"},
+			desc: "hermetic pod not hardened without the feature flag",
+			featureFlags: map[string]string{
+				"enable-api-fields":  "alpha",
+				"disable-creds-init": "true",
+			},
+			configDefaults: map[string]string{
+				"default-hermetic-network-sidecar": `name: network-deny
+image: network-deny-image`,
+			},
 			ts: v1.TaskSpec{
 				Steps: []v1.Step{{
 					Name:    "name",
@@ -2466,11 +2394,71 @@ _EOF_
 					Command: []string{"cmd"}, // avoid entrypoint lookup.
 				}},
 			},
+			trAnnotation: map[string]string{
+				"experimental.tekton.dev/execution-mode": "hermetic",
+			},
 			want: &corev1.PodSpec{
-				RestartPolicy: corev1.RestartPolicyNever,
-				InitContainers: []corev1.Container{
-					entrypointInitContainer(images.EntrypointImage, []v1.Step{{Name: "name"}}, SecurityContextConfig{SetSecurityContext: false, SetReadOnlyRootFilesystem: false}, false /* windows */),
+				RestartPolicy:  corev1.RestartPolicyNever,
+				InitContainers: []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1.Step{{Name: "name"}}, SecurityContextConfig{SetSecurityContext: false, SetReadOnlyRootFilesystem: false}, false /* windows */)},
+				Containers: []corev1.Container{{
+					Name:    "step-name",
+					Image:   "image",
+					Command: []string{"/tekton/bin/entrypoint"},
+					Args: []string{
+						"-wait_file",
+						"/tekton/downward/ready",
+						"-wait_file_content",
+						"-post_file",
+						"/tekton/run/0/out",
+						"-termination_path",
+						"/tekton/termination",
+						"-step_metadata_dir",
+						"/tekton/run/0/status",
+						"-entrypoint",
+						"cmd",
+						"--",
+					},
+					VolumeMounts:           append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount}, implicitVolumeMounts...),
+					TerminationMessagePath: "/tekton/termination",
+					Env: []corev1.EnvVar{
+						{Name: "TEKTON_HERMETIC", Value: "1"},
+					},
+				}},
+				Volumes:               append(implicitVolumes, binVolume, runVolume(0), downwardVolume),
+				ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
+			},
+		},
+		{
+			desc: "pod for a taskRun with retries",
+			ts: v1.TaskSpec{
+				Steps: []v1.Step{{
+					Name:    "name",
+					Image:   "image",
+					Command: []string{"cmd"}, // avoid entrypoint lookup.
+				}},
+			},
+			trStatus: v1.TaskRunStatus{
+				TaskRunStatusFields: v1.TaskRunStatusFields{
+					RetriesStatus: []v1.TaskRunStatus{{
+						Status: duckv1.Status{
+							Conditions: []apis.Condition{{
+								Type:   apis.ConditionSucceeded,
+								Status: corev1.ConditionFalse,
+							}},
+						},
+					}, {
+						Status: duckv1.Status{
+							Conditions: []apis.Condition{{
+								Type:   apis.ConditionSucceeded,
+								Status: corev1.ConditionFalse,
+							}},
+						},
+					}},
 				},
+			},
+			want: &corev1.PodSpec{
+				RestartPolicy:  corev1.RestartPolicyNever,
+				InitContainers: []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1.Step{{Name: "name"}}, SecurityContextConfig{SetSecurityContext: false, SetReadOnlyRootFilesystem: false}, false /* windows */)},
 				Containers: []corev1.Container{{
 					Name:    "step-name",
 					Image:   "image",
@@ -2495,28 +2483,16 @@ _EOF_
 					}}, implicitVolumeMounts...),
 					TerminationMessagePath: "/tekton/termination",
 				}},
-				Volumes: append(implicitVolumes, binVolume, runVolume(0), corev1.Volume{
-					Name: downwardVolumeName,
-					VolumeSource: corev1.VolumeSource{
-						DownwardAPI: &corev1.DownwardAPIVolumeSource{
-							Items: []corev1.DownwardAPIVolumeFile{{
-								Path: downwardMountReadyFile,
-								FieldRef: &corev1.ObjectFieldSelector{
-									FieldPath: fmt.Sprintf("metadata.annotations['%s']", readyAnnotation),
-								},
-							}, downwardCancelVolumeItem},
-						},
-					},
-				}, corev1.Volume{
+				Volumes: append(implicitVolumes, binVolume, runVolume(0), downwardVolume, corev1.Volume{
 					Name:         "tekton-creds-init-home-0",
 					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
 				}),
 				ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
 			},
+			wantPodName: taskRunName + "-pod-retry2",
 		},
 		{
-			desc:         "keep pod on cancel enabled but not alpha",
-			featureFlags: map[string]string{"keep-pod-on-cancel": "true"},
+			desc: "long-taskrun-name",
 			ts: v1.TaskSpec{
 				Steps: []v1.Step{{
 					Name:    "name",
@@ -2524,11 +2500,11 @@ _EOF_
 					Command: []string{"cmd"}, // avoid entrypoint lookup.
 				}},
 			},
+			trName:      "task-run-0123456789-0123456789-0123456789-0123456789-0123456789-0123456789",
+			wantPodName: "task-run-0123456789-01234560d38957287bb0283c59440df14069f59-pod",
 			want: &corev1.PodSpec{
-				RestartPolicy: corev1.RestartPolicyNever,
-				InitContainers: []corev1.Container{
-					entrypointInitContainer(images.EntrypointImage, []v1.Step{{Name: "name"}}, SecurityContextConfig{SetSecurityContext: false, SetReadOnlyRootFilesystem: false}, false),
-				},
+				RestartPolicy:  corev1.RestartPolicyNever,
+				InitContainers: []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1.Step{{Name: "name"}}, SecurityContextConfig{SetSecurityContext: false, SetReadOnlyRootFilesystem: false}, false /* windows */)},
 				Containers: []corev1.Container{{
 					Name:    "step-name",
 					Image:   "image",
@@ -2547,33 +2523,851 @@ _EOF_
 						"cmd",
 						"--",
 					},
-					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
+					VolumeMounts: append([]corev1.VolumeMount{downwardMount, {
 						Name:      "tekton-creds-init-home-0",
 						MountPath: "/tekton/creds",
-					}}, implicitVolumeMounts...),
+					}, runMount(0, false), binROMount}, implicitVolumeMounts...),
 					TerminationMessagePath: "/tekton/termination",
 				}},
-				Volumes: append(implicitVolumes, binVolume, runVolume(0), corev1.Volume{
-					Name: downwardVolumeName,
-					VolumeSource: corev1.VolumeSource{
-						DownwardAPI: &corev1.DownwardAPIVolumeSource{
-							Items: []corev1.DownwardAPIVolumeFile{{
-								Path: downwardMountReadyFile,
-								FieldRef: &corev1.ObjectFieldSelector{
-									FieldPath: fmt.Sprintf("metadata.annotations['%s']", readyAnnotation),
-								},
-							}, downwardCancelVolumeItem},
-						},
-					},
-				}, corev1.Volume{
+				Volumes: append(implicitVolumes, binVolume, downwardVolume, corev1.Volume{
 					Name:         "tekton-creds-init-home-0",
 					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
-				}),
+				}, runVolume(0)),
 				ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
 			},
 		},
-	} {
-		t.Run(c.desc, func(t *testing.T) {
+		{
+			desc: "using TopologySpreadConstraints",
+			ts: v1.TaskSpec{
+				Steps: []v1.Step{
+					{
+						Name:    "use-topologySpreadConstraints",
+						Image:   "image",
+						Command: []string{"cmd"}, // avoid entrypoint lookup.
+					},
+				},
+			},
+			trs: v1.TaskRunSpec{
+				PodTemplate: &pod.Template{
+					TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+						{
+							MaxSkew:           1,
+							TopologyKey:       "zone",
+							WhenUnsatisfiable: corev1.DoNotSchedule,
+							LabelSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{
+									"foo": "bar",
+								},
+							},
+						},
+					},
+				},
+			},
+			want: &corev1.PodSpec{
+				RestartPolicy:  corev1.RestartPolicyNever,
+				InitContainers: []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1.Step{{Name: "use-topologySpreadConstraints"}}, SecurityContextConfig{SetSecurityContext: false, SetReadOnlyRootFilesystem: false}, false /* windows */)},
+				TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+					{
+						MaxSkew:           1,
+						TopologyKey:       "zone",
+						WhenUnsatisfiable: corev1.DoNotSchedule,
+						LabelSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								"foo": "bar",
+							},
+						},
+					},
+				},
+				Volumes: append(implicitVolumes, binVolume, runVolume(0), downwardVolume, corev1.Volume{
+					Name:         "tekton-creds-init-home-0",
+					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
+				}),
+				Containers: []corev1.Container{{
+					Name:    "step-use-topologySpreadConstraints",
+					Image:   "image",
+					Command: []string{"/tekton/bin/entrypoint"},
+					Args: []string{
+						"-wait_file",
+						"/tekton/downward/ready",
+						"-wait_file_content",
+						"-post_file",
+						"/tekton/run/0/out",
+						"-termination_path",
+						"/tekton/termination",
+						"-step_metadata_dir",
+						"/tekton/run/0/status",
+						"-entrypoint",
+						"cmd",
+						"--",
+					},
+					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
+						Name:      "tekton-creds-init-home-0",
+						MountPath: "/tekton/creds",
+					}}, implicitVolumeMounts...),
+					TerminationMessagePath: "/tekton/termination",
+				}},
+				ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
+			},
+		},
+		{
+			desc:         "sidecar logs enabled, artifacts not enabled",
+			featureFlags: map[string]string{"results-from": "sidecar-logs"},
+			ts: v1.TaskSpec{
+				Results: []v1.TaskResult{{
+					Name: "foo",
+					Type: v1.ResultsTypeString,
+				}},
+				Steps: []v1.Step{{
+					Name:    "name",
+					Image:   "image",
+					Command: []string{"cmd"}, // avoid entrypoint lookup.
+				}},
+			},
+			want: &corev1.PodSpec{
+				RestartPolicy: corev1.RestartPolicyNever,
+				InitContainers: []corev1.Container{
+					entrypointInitContainer(images.EntrypointImage, []v1.Step{{Name: "name"}}, SecurityContextConfig{SetSecurityContext: false, SetReadOnlyRootFilesystem: false}, false /* windows */),
+				},
+				Containers: []corev1.Container{{
+					Name:    "step-name",
+					Image:   "image",
+					Command: []string{"/tekton/bin/entrypoint"},
+					Args: []string{
+						"-wait_file",
+						"/tekton/downward/ready",
+						"-wait_file_content",
+						"-post_file",
+						"/tekton/run/0/out",
+						"-termination_path",
+						"/tekton/termination",
+						"-step_metadata_dir",
+						"/tekton/run/0/status",
+						"-result_from",
+						"sidecar-logs",
+						"-results",
+						"foo",
+						"-entrypoint",
+						"cmd",
+						"--",
+					},
+					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
+						Name:      "tekton-creds-init-home-0",
+						MountPath: "/tekton/creds",
+					}}, implicitVolumeMounts...),
+					TerminationMessagePath: "/tekton/termination",
+				}, {
+					Name:  pipeline.ReservedResultsSidecarContainerName,
+					Image: "",
+					Command: []string{
+						"/ko-app/sidecarlogresults",
+						"-results-dir",
+						"/tekton/results",
+						"-result-names",
+						"foo",
+						"-step-names",
+						"",
+						"-step-results",
+						"{}",
+					},
+					Resources: corev1.ResourceRequirements{
+						Requests: nil,
+					},
+					VolumeMounts: append([]corev1.VolumeMount{
+						{Name: "tekton-internal-bin", ReadOnly: true, MountPath: "/tekton/bin"},
+						{Name: "tekton-internal-run-0", ReadOnly: true, MountPath: "/tekton/run/0"},
+					}, implicitVolumeMounts...),
+				}},
+				Volumes: append(implicitVolumes, binVolume, runVolume(0), downwardVolume, corev1.Volume{
+					Name:         "tekton-creds-init-home-0",
+					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
+				}),
+				ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
+			},
+		},
+		{
+			desc:         "sidecar logs enabled with step results, artifacts not enabled",
+			featureFlags: map[string]string{"results-from": "sidecar-logs"},
+			ts: v1.TaskSpec{
+				Results: []v1.TaskResult{{
+					Name: "foo",
+					Type: v1.ResultsTypeString,
+				}},
+				Steps: []v1.Step{{
+					Name: "name",
+					Results: []v1.StepResult{{
+						Name: "step-foo",
+						Type: v1.ResultsTypeString,
+					}},
+					Image:   "image",
+					Command: []string{"cmd"}, // avoid entrypoint lookup.
+				}},
+			},
+			want: &corev1.PodSpec{
+				RestartPolicy: corev1.RestartPolicyNever,
+				InitContainers: []corev1.Container{
+					entrypointInitContainer(images.EntrypointImage, []v1.Step{{Name: "name"}}, SecurityContextConfig{SetSecurityContext: false, SetReadOnlyRootFilesystem: false}, false /* windows */),
+				},
+				Containers: []corev1.Container{{
+					Name:    "step-name",
+					Image:   "image",
+					Command: []string{"/tekton/bin/entrypoint"},
+					Args: []string{
+						"-wait_file",
+						"/tekton/downward/ready",
+						"-wait_file_content",
+						"-post_file",
+						"/tekton/run/0/out",
+						"-termination_path",
+						"/tekton/termination",
+						"-step_metadata_dir",
+						"/tekton/run/0/status",
+						"-result_from",
+						"sidecar-logs",
+						"-step_results",
+						"step-foo",
+						"-results",
+						"foo",
+						"-entrypoint",
+						"cmd",
+						"--",
+					},
+					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
+						Name:      "tekton-creds-init-home-0",
+						MountPath: "/tekton/creds",
+					}}, implicitVolumeMounts...),
+					TerminationMessagePath: "/tekton/termination",
+				}, {
+					Name:  pipeline.ReservedResultsSidecarContainerName,
+					Image: "",
+					Command: []string{
+						"/ko-app/sidecarlogresults",
+						"-results-dir",
+						"/tekton/results",
+						"-result-names",
+						"foo",
+						"-step-names",
+						"",
+						"-step-results",
+						"{\"step-name\":[\"step-foo\"]}",
+					},
+					Resources: corev1.ResourceRequirements{
+						Requests: nil,
+					},
+					VolumeMounts: append([]corev1.VolumeMount{
+						{Name: "tekton-internal-bin", ReadOnly: true, MountPath: "/tekton/bin"},
+						{Name: "tekton-internal-run-0", ReadOnly: true, MountPath: "/tekton/run/0"},
+					}, implicitVolumeMounts...),
+				}},
+				Volumes: append(implicitVolumes, binVolume, runVolume(0), downwardVolume, corev1.Volume{
+					Name:         "tekton-creds-init-home-0",
+					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
+				}),
+				ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
+			},
+		},
+		{
+			desc:         "sidecar logs enabled and artifacts not enabled, set security context is true",
+			featureFlags: map[string]string{"results-from": "sidecar-logs", "set-security-context": "true", "set-security-context-read-only-root-filesystem": "true"},
+			ts: v1.TaskSpec{
+				Results: []v1.TaskResult{{
+					Name: "foo",
+					Type: v1.ResultsTypeString,
+				}},
+				Steps: []v1.Step{{
+					Name:    "name",
+					Image:   "image",
+					Command: []string{"cmd"}, // avoid entrypoint lookup.
+				}},
+			},
+			want: &corev1.PodSpec{
+				RestartPolicy: corev1.RestartPolicyNever,
+				InitContainers: []corev1.Container{
+					entrypointInitContainer(images.EntrypointImage, []v1.Step{{Name: "name"}}, SecurityContextConfig{SetSecurityContext: true, SetReadOnlyRootFilesystem: true}, false /* windows */),
+				},
+				Containers: []corev1.Container{{
+					Name:    "step-name",
+					Image:   "image",
+					Command: []string{"/tekton/bin/entrypoint"},
+					Args: []string{
+						"-wait_file",
+						"/tekton/downward/ready",
+						"-wait_file_content",
+						"-post_file",
+						"/tekton/run/0/out",
+						"-termination_path",
+						"/tekton/termination",
+						"-step_metadata_dir",
+						"/tekton/run/0/status",
+						"-result_from",
+						"sidecar-logs",
+						"-results",
+						"foo",
+						"-entrypoint",
+						"cmd",
+						"--",
+					},
+					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
+						Name:      "tekton-creds-init-home-0",
+						MountPath: "/tekton/creds",
+					}}, implicitVolumeMounts...),
+					TerminationMessagePath: "/tekton/termination",
+				}, {
+					Name:  pipeline.ReservedResultsSidecarContainerName,
+					Image: "",
+					Command: []string{
+						"/ko-app/sidecarlogresults",
+						"-results-dir",
+						"/tekton/results",
+						"-result-names",
+						"foo",
+						"-step-names",
+						"",
+						"-step-results",
+						"{}",
+					},
+					Resources: corev1.ResourceRequirements{
+						Requests: nil,
+					},
+					VolumeMounts: append([]corev1.VolumeMount{
+						{Name: "tekton-internal-bin", ReadOnly: true, MountPath: "/tekton/bin"},
+						{Name: "tekton-internal-run-0", ReadOnly: true, MountPath: "/tekton/run/0"},
+					}, implicitVolumeMounts...),
+					SecurityContext: SecurityContextConfig{SetSecurityContext: true, SetReadOnlyRootFilesystem: true}.GetSecurityContext(false),
+				}},
+				Volumes: append(implicitVolumes, binVolume, runVolume(0), downwardVolume, corev1.Volume{
+					Name:         "tekton-creds-init-home-0",
+					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
+				}),
+				ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
+			},
+		},
+		{
+			desc:         "sidecar logs enabled and artifacts referenced",
+			featureFlags: map[string]string{"results-from": "sidecar-logs", "enable-artifacts": "true"},
+			ts: v1.TaskSpec{
+				Results: []v1.TaskResult{{
+					Name: "foo",
+					Type: v1.ResultsTypeString,
+				}},
+				Steps: []v1.Step{{
+					Name:    "name",
+					Image:   "image",
+					Command: []string{"echo", "aaa", ">>>", "/tekton/steps/step-name/artifacts/provenance.json"}, // avoid entrypoint lookup.
+				}},
+			},
+			want: &corev1.PodSpec{
+				RestartPolicy: corev1.RestartPolicyNever,
+				InitContainers: []corev1.Container{
+					entrypointInitContainer(images.EntrypointImage, []v1.Step{{Name: "name"}}, SecurityContextConfig{SetSecurityContext: false, SetReadOnlyRootFilesystem: false}, false /* windows */),
+				},
+				Containers: []corev1.Container{{
+					Name:    "step-name",
+					Image:   "image",
+					Command: []string{"/tekton/bin/entrypoint"},
+					Args: []string{
+						"-wait_file",
+						"/tekton/downward/ready",
+						"-wait_file_content",
+						"-post_file",
+						"/tekton/run/0/out",
+						"-termination_path",
+						"/tekton/termination",
+						"-step_metadata_dir",
+						"/tekton/run/0/status",
+						"-result_from",
+						"sidecar-logs",
+						"-results",
+						"foo",
+						"-entrypoint",
+						"echo",
+						"--",
+						"aaa",
+						">>>",
+						"/tekton/steps/step-name/artifacts/provenance.json",
+					},
+					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
+						Name:      "tekton-creds-init-home-0",
+						MountPath: "/tekton/creds",
+					}}, implicitVolumeMounts...),
+					TerminationMessagePath: "/tekton/termination",
+				}, {
+					Name:  pipeline.ReservedResultsSidecarContainerName,
+					Image: "",
+					Command: []string{
+						"/ko-app/sidecarlogresults",
+						"-results-dir",
+						"/tekton/results",
+						"-result-names",
+						"foo",
+						"-step-names",
+						"step-name",
+						"-step-results",
+						"{}",
+					},
+					Resources: corev1.ResourceRequirements{
+						Requests: nil,
+					},
+					VolumeMounts: append([]corev1.VolumeMount{
+						{Name: "tekton-internal-bin", ReadOnly: true, MountPath: "/tekton/bin"},
+						{Name: "tekton-internal-run-0", ReadOnly: true, MountPath: "/tekton/run/0"},
+					}, implicitVolumeMounts...),
+				}},
+				Volumes: append(implicitVolumes, binVolume, runVolume(0), downwardVolume, corev1.Volume{
+					Name:         "tekton-creds-init-home-0",
+					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
+				}),
+				ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
+			},
+		},
+		{
+			desc:         "sidecar logs enabled with step results, artifacts referenced",
+			featureFlags: map[string]string{"results-from": "sidecar-logs", "enable-artifacts": "true"},
+			ts: v1.TaskSpec{
+				Results: []v1.TaskResult{{
+					Name: "foo",
+					Type: v1.ResultsTypeString,
+				}},
+				Steps: []v1.Step{{
+					Name: "name",
+					Results: []v1.StepResult{{
+						Name: "step-foo",
+						Type: v1.ResultsTypeString,
+					}},
+					Image:   "image",
+					Command: []string{"echo", "aaa", ">>>", "/tekton/steps/step-name/artifacts/provenance.json"}, //
+				}},
+			},
+			want: &corev1.PodSpec{
+				RestartPolicy: corev1.RestartPolicyNever,
+				InitContainers: []corev1.Container{
+					entrypointInitContainer(images.EntrypointImage, []v1.Step{{Name: "name"}}, SecurityContextConfig{SetSecurityContext: false, SetReadOnlyRootFilesystem: false}, false /* windows */),
+				},
+				Containers: []corev1.Container{{
+					Name:    "step-name",
+					Image:   "image",
+					Command: []string{"/tekton/bin/entrypoint"},
+					Args: []string{
+						"-wait_file",
+						"/tekton/downward/ready",
+						"-wait_file_content",
+						"-post_file",
+						"/tekton/run/0/out",
+						"-termination_path",
+						"/tekton/termination",
+						"-step_metadata_dir",
+						"/tekton/run/0/status",
+						"-result_from",
+						"sidecar-logs",
+						"-step_results",
+						"step-foo",
+						"-results",
+						"foo",
+						"-entrypoint",
+						"echo",
+						"--",
+						"aaa",
+						">>>",
+						"/tekton/steps/step-name/artifacts/provenance.json",
+					},
+					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
+						Name:      "tekton-creds-init-home-0",
+						MountPath: "/tekton/creds",
+					}}, implicitVolumeMounts...),
+					TerminationMessagePath: "/tekton/termination",
+				}, {
+					Name:  pipeline.ReservedResultsSidecarContainerName,
+					Image: "",
+					Command: []string{
+						"/ko-app/sidecarlogresults",
+						"-results-dir",
+						"/tekton/results",
+						"-result-names",
+						"foo",
+						"-step-names",
+						"step-name",
+						"-step-results",
+						"{\"step-name\":[\"step-foo\"]}",
+					},
+					Resources: corev1.ResourceRequirements{
+						Requests: nil,
+					},
+					VolumeMounts: append([]corev1.VolumeMount{
+						{Name: "tekton-internal-bin", ReadOnly: true, MountPath: "/tekton/bin"},
+						{Name: "tekton-internal-run-0", ReadOnly: true, MountPath: "/tekton/run/0"},
+					}, implicitVolumeMounts...),
+				}},
+				Volumes: append(implicitVolumes, binVolume, runVolume(0), downwardVolume, corev1.Volume{
+					Name:         "tekton-creds-init-home-0",
+					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
+				}),
+				ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
+			},
+		},
+		{
+			desc:         "sidecar logs enabled, artifacts referenced and security context set ",
+			featureFlags: map[string]string{"results-from": "sidecar-logs", "set-security-context": "true", "set-security-context-read-only-root-filesystem": "true", "enable-artifacts": "true"},
+			ts: v1.TaskSpec{
+				Results: []v1.TaskResult{{
+					Name: "foo",
+					Type: v1.ResultsTypeString,
+				}},
+				Steps: []v1.Step{{
+					Name:    "name",
+					Image:   "image",
+					Command: []string{"echo", "aaa", ">>>", "/tekton/steps/step-name/artifacts/provenance.json"},
+				}},
+			},
+			want: &corev1.PodSpec{
+				RestartPolicy: corev1.RestartPolicyNever,
+				InitContainers: []corev1.Container{
+					entrypointInitContainer(images.EntrypointImage, []v1.Step{{Name: "name"}}, SecurityContextConfig{SetSecurityContext: true, SetReadOnlyRootFilesystem: true}, false /* windows */),
+				},
+				Containers: []corev1.Container{{
+					Name:    "step-name",
+					Image:   "image",
+					Command: []string{"/tekton/bin/entrypoint"},
+					Args: []string{
+						"-wait_file",
+						"/tekton/downward/ready",
+						"-wait_file_content",
+						"-post_file",
+						"/tekton/run/0/out",
+						"-termination_path",
+						"/tekton/termination",
+						"-step_metadata_dir",
+						"/tekton/run/0/status",
+						"-result_from",
+						"sidecar-logs",
+						"-results",
+						"foo",
+						"-entrypoint",
+						"echo",
+						"--",
+						"aaa",
+						">>>",
+						"/tekton/steps/step-name/artifacts/provenance.json",
+					},
+					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
+						Name:      "tekton-creds-init-home-0",
+						MountPath: "/tekton/creds",
+					}}, implicitVolumeMounts...),
+					TerminationMessagePath: "/tekton/termination",
+				}, {
+					Name:  pipeline.ReservedResultsSidecarContainerName,
+					Image: "",
+					Command: []string{
+						"/ko-app/sidecarlogresults",
+						"-results-dir",
+						"/tekton/results",
+						"-result-names",
+						"foo",
+						"-step-names",
+						"step-name",
+						"-step-results",
+						"{}",
+					},
+					Resources: corev1.ResourceRequirements{
+						Requests: nil,
+					},
+					VolumeMounts: append([]corev1.VolumeMount{
+						{Name: "tekton-internal-bin", ReadOnly: true, MountPath: "/tekton/bin"},
+						{Name: "tekton-internal-run-0", ReadOnly: true, MountPath: "/tekton/run/0"},
+					}, implicitVolumeMounts...),
+					SecurityContext: SecurityContextConfig{SetSecurityContext: true, SetReadOnlyRootFilesystem: true}.GetSecurityContext(false),
+				}},
+				Volumes: append(implicitVolumes, binVolume, runVolume(0), downwardVolume, corev1.Volume{
+					Name:         "tekton-creds-init-home-0",
+					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
+				}),
+				ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
+			},
+		},
+		{
+			desc: "simple with security context",
+			ts: v1.TaskSpec{
+				Steps: []v1.Step{{
+					Name:    "name",
+					Image:   "image",
+					Command: []string{"cmd"}, // avoid entrypoint lookup.
+				}},
+			},
+			featureFlags: map[string]string{"set-security-context": "true", "set-security-context-read-only-root-filesystem": "true"},
+			want: &corev1.PodSpec{
+				RestartPolicy:  corev1.RestartPolicyNever,
+				InitContainers: []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1.Step{{Name: "name"}}, SecurityContextConfig{SetSecurityContext: true, SetReadOnlyRootFilesystem: true}, false /* windows */)},
+				Containers: []corev1.Container{{
+					Name:    "step-name",
+					Image:   "image",
+					Command: []string{"/tekton/bin/entrypoint"},
+					Args: []string{
+						"-wait_file",
+						"/tekton/downward/ready",
+						"-wait_file_content",
+						"-post_file",
+						"/tekton/run/0/out",
+						"-termination_path",
+						"/tekton/termination",
+						"-step_metadata_dir",
+						"/tekton/run/0/status",
+						"-entrypoint",
+						"cmd",
+						"--",
+					},
+					VolumeMounts: append([]corev1.VolumeMount{downwardMount, {
+						Name:      "tekton-creds-init-home-0",
+						MountPath: "/tekton/creds",
+					}, runMount(0, false), binROMount}, implicitVolumeMounts...),
+					TerminationMessagePath: "/tekton/termination",
+				}},
+				Volumes: append(implicitVolumes, binVolume, downwardVolume, corev1.Volume{
+					Name:         "tekton-creds-init-home-0",
+					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
+				}, runVolume(0)),
+				ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
+			},
+		},
+		{
+			desc:         "keep pod on cancel enabled",
+			featureFlags: map[string]string{"keep-pod-on-cancel": "true", "enable-api-fields": "alpha"},
+			ts: v1.TaskSpec{
+				Steps: []v1.Step{{
+					Name:    "name",
+					Image:   "image",
+					Command: []string{"cmd"}, // avoid entrypoint lookup.
+				}},
+			},
+			want: &corev1.PodSpec{
+				RestartPolicy: corev1.RestartPolicyNever,
+				InitContainers: []corev1.Container{
+					entrypointInitContainer(images.EntrypointImage, []v1.Step{{Name: "name"}}, SecurityContextConfig{SetSecurityContext: false, SetReadOnlyRootFilesystem: false}, false /* windows */),
+				},
+				Containers: []corev1.Container{{
+					Name:    "step-name",
+					Image:   "image",
+					Command: []string{"/tekton/bin/entrypoint"},
+					Args: []string{
+						"-wait_file",
+						"/tekton/downward/ready",
+						"-wait_file_content",
+						"-post_file",
+						"/tekton/run/0/out",
+						"-termination_path",
+						"/tekton/termination",
+						"-step_metadata_dir",
+						"/tekton/run/0/status",
+						"-entrypoint",
+						"cmd",
+						"--",
+					},
+					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
+						Name:      "tekton-creds-init-home-0",
+						MountPath: "/tekton/creds",
+					}}, implicitVolumeMounts...),
+					TerminationMessagePath: "/tekton/termination",
+				}},
+				Volumes: append(implicitVolumes, binVolume, runVolume(0), corev1.Volume{
+					Name: downwardVolumeName,
+					VolumeSource: corev1.VolumeSource{
+						DownwardAPI: &corev1.DownwardAPIVolumeSource{
+							Items: []corev1.DownwardAPIVolumeFile{{
+								Path: downwardMountReadyFile,
+								FieldRef: &corev1.ObjectFieldSelector{
+									FieldPath: fmt.Sprintf("metadata.annotations['%s']", readyAnnotation),
+								},
+							}, downwardCancelVolumeItem},
+						},
+					},
+				}, corev1.Volume{
+					Name:         "tekton-creds-init-home-0",
+					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
+				}),
+				ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
+			},
+		},
+		{
+			desc:         "taskrun pause enabled",
+			featureFlags: map[string]string{"enable-taskrun-pause": "true"},
+			trs:          v1.TaskRunSpec{Status: v1.TaskRunSpecStatusPause},
+			ts: v1.TaskSpec{
+				Steps: []v1.Step{{
+					Name:    "name",
+					Image:   "image",
+					Command: []string{"cmd"}, // avoid entrypoint lookup.
+				}},
+			},
+			want: &corev1.PodSpec{
+				RestartPolicy: corev1.RestartPolicyNever,
+				InitContainers: []corev1.Container{
+					entrypointInitContainer(images.EntrypointImage, []v1.Step{{Name: "name"}}, SecurityContextConfig{SetSecurityContext: false, SetReadOnlyRootFilesystem: false}, false),
+				},
+				Containers: []corev1.Container{{
+					Name:    "step-name",
+					Image:   "image",
+					Command: []string{"/tekton/bin/entrypoint"},
+					Args: []string{
+						"-wait_file",
+						"/tekton/downward/ready",
+						"-wait_file_content",
+						"-post_file",
+						"/tekton/run/0/out",
+						"-termination_path",
+						"/tekton/termination",
+						"-step_metadata_dir",
+						"/tekton/run/0/status",
+						"-pause_file",
+						"/tekton/downward/pause",
+						"-entrypoint",
+						"cmd",
+						"--",
+					},
+					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
+						Name:      "tekton-creds-init-home-0",
+						MountPath: "/tekton/creds",
+					}}, implicitVolumeMounts...),
+					TerminationMessagePath: "/tekton/termination",
+				}},
+				Volumes: append(implicitVolumes, binVolume, runVolume(0), corev1.Volume{
+					Name: downwardVolumeName,
+					VolumeSource: corev1.VolumeSource{
+						DownwardAPI: &corev1.DownwardAPIVolumeSource{
+							Items: []corev1.DownwardAPIVolumeFile{{
+								Path: downwardMountReadyFile,
+								FieldRef: &corev1.ObjectFieldSelector{
+									FieldPath: fmt.Sprintf("metadata.annotations['%s']", readyAnnotation),
+								},
+							}, downwardPauseVolumeItem},
+						},
+					},
+				}, corev1.Volume{
+					Name:         "tekton-creds-init-home-0",
+					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
+				}),
+				ActiveDeadlineSeconds: &MaxActiveDeadlineSeconds,
+			},
+			wantAnnotations: map[string]string{
+				pauseAnnotation: pauseAnnotationValue,
+			},
+		},
+		{
+			desc:         "taskrun pause enabled with a single step not paused",
+			featureFlags: map[string]string{"enable-taskrun-pause": "true"},
+			ts: v1.TaskSpec{
+				Steps: []v1.Step{{
+					Name:    "name",
+					Image:   "image",
+					Command: []string{"cmd"}, // avoid entrypoint lookup.
+				}},
+			},
+			want: &corev1.PodSpec{
+				RestartPolicy: corev1.RestartPolicyNever,
+				InitContainers: []corev1.Container{
+					entrypointInitContainer(images.EntrypointImage, []v1.Step{{Name: "name"}}, SecurityContextConfig{SetSecurityContext: false, SetReadOnlyRootFilesystem: false}, false),
+				},
+				Containers: []corev1.Container{{
+					Name:    "step-name",
+					Image:   "image",
+					Command: []string{"/tekton/bin/entrypoint"},
+					Args: []string{
+						"-wait_file",
+						"/tekton/downward/ready",
+						"-wait_file_content",
+						"-post_file",
+						"/tekton/run/0/out",
+						"-termination_path",
+						"/tekton/termination",
+						"-step_metadata_dir",
+						"/tekton/run/0/status",
+						"-pause_file",
+						"/tekton/downward/pause",
+						"-entrypoint",
+						"cmd",
+						"--",
+					},
+					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
+						Name:      "tekton-creds-init-home-0",
+						MountPath: "/tekton/creds",
+					}}, implicitVolumeMounts...),
+					TerminationMessagePath: "/tekton/termination",
+				}},
+				Volumes: append(implicitVolumes, binVolume, runVolume(0), corev1.Volume{
+					Name: downwardVolumeName,
+					VolumeSource: corev1.VolumeSource{
+						DownwardAPI: &corev1.DownwardAPIVolumeSource{
+							Items: []corev1.DownwardAPIVolumeFile{{
+								Path: downwardMountReadyFile,
+								FieldRef: &corev1.ObjectFieldSelector{
+									FieldPath: fmt.Sprintf("metadata.annotations['%s']", readyAnnotation),
+								},
+							}, downwardPauseVolumeItem},
+						},
+					},
+				}, corev1.Volume{
+					Name:         "tekton-creds-init-home-0",
+					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
+				}),
+				ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
+			},
+		},
+		{
+			desc:         "keep pod on cancel enabled but not alpha",
+			featureFlags: map[string]string{"keep-pod-on-cancel": "true"},
+			ts: v1.TaskSpec{
+				Steps: []v1.Step{{
+					Name:    "name",
+					Image:   "image",
+					Command: []string{"cmd"}, // avoid entrypoint lookup.
+				}},
+			},
+			want: &corev1.PodSpec{
+				RestartPolicy: corev1.RestartPolicyNever,
+				InitContainers: []corev1.Container{
+					entrypointInitContainer(images.EntrypointImage, []v1.Step{{Name: "name"}}, SecurityContextConfig{SetSecurityContext: false, SetReadOnlyRootFilesystem: false}, false),
+				},
+				Containers: []corev1.Container{{
+					Name:    "step-name",
+					Image:   "image",
+					Command: []string{"/tekton/bin/entrypoint"},
+					Args: []string{
+						"-wait_file",
+						"/tekton/downward/ready",
+						"-wait_file_content",
+						"-post_file",
+						"/tekton/run/0/out",
+						"-termination_path",
+						"/tekton/termination",
+						"-step_metadata_dir",
+						"/tekton/run/0/status",
+						"-entrypoint",
+						"cmd",
+						"--",
+					},
+					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
+						Name:      "tekton-creds-init-home-0",
+						MountPath: "/tekton/creds",
+					}}, implicitVolumeMounts...),
+					TerminationMessagePath: "/tekton/termination",
+				}},
+				Volumes: append(implicitVolumes, binVolume, runVolume(0), corev1.Volume{
+					Name: downwardVolumeName,
+					VolumeSource: corev1.VolumeSource{
+						DownwardAPI: &corev1.DownwardAPIVolumeSource{
+							Items: []corev1.DownwardAPIVolumeFile{{
+								Path: downwardMountReadyFile,
+								FieldRef: &corev1.ObjectFieldSelector{
+									FieldPath: fmt.Sprintf("metadata.annotations['%s']", readyAnnotation),
+								},
+							}, downwardCancelVolumeItem},
+						},
+					},
+				}, corev1.Volume{
+					Name:         "tekton-creds-init-home-0",
+					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
+				}),
+				ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
+			},
+		},
+	} {
+		t.Run(c.desc, func(t *testing.T) {
 			names.TestingSeed()
 			store := config.NewStore(logtesting.TestLogger(t))
 			store.OnConfigChanged(
@@ -2584,8 +3378,221 @@ _EOF_
 			)
 			store.OnConfigChanged(
 				&corev1.ConfigMap{
-					ObjectMeta: metav1.ObjectMeta{Name: config.GetDefaultsConfigName(), Namespace: system.Namespace()},
-					Data:       c.configDefaults,
+					ObjectMeta: metav1.ObjectMeta{Name: config.GetDefaultsConfigName(), Namespace: system.Namespace()},
+					Data:       c.configDefaults,
+				},
+			)
+			kubeclient := fakek8s.NewSimpleClientset(
+				&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}},
+				&corev1.ServiceAccount{
+					ObjectMeta: metav1.ObjectMeta{Name: "service-account", Namespace: "default"},
+					Secrets: []corev1.ObjectReference{{
+						Name: "multi-creds",
+					}},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "multi-creds",
+						Namespace: "default",
+						Annotations: map[string]string{
+							"tekton.dev/docker-0": "https://us.gcr.io",
+							"tekton.dev/docker-1": "https://docker.io",
+							"tekton.dev/git-0":    "github.com",
+							"tekton.dev/git-1":    "gitlab.com",
+						},
+					},
+					Type: "kubernetes.io/basic-auth",
+					Data: map[string][]byte{
+						"username": []byte("foo"),
+						"password": []byte("BestEver"),
+					},
+				},
+			)
+			var trAnnotations map[string]string
+			if c.trAnnotation == nil {
+				trAnnotations = map[string]string{
+					ReleaseAnnotation: fakeVersion,
+				}
+			} else {
+				trAnnotations = c.trAnnotation
+				trAnnotations[ReleaseAnnotation] = fakeVersion
+
+			}
+			testTaskRunName := taskRunName
+			if c.trName != "" {
+				testTaskRunName = c.trName
+			}
+			tr := &v1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        testTaskRunName,
+					Namespace:   "default",
+					Annotations: trAnnotations,
+				},
+				Spec:   c.trs,
+				Status: c.trStatus,
+			}
+
+			// No entrypoints should be looked up.
+			entrypointCache := fakeCache{}
+
+			builder := Builder{
+				Images:          images,
+				KubeClient:      kubeclient,
+				EntrypointCache: entrypointCache,
+			}
+			got, err := builder.Build(store.ToContext(t.Context()), tr, c.ts)
+			if err != nil {
+				t.Fatalf("builder.Build: %v", err)
+			}
+			expectedName := testTaskRunName + "-pod"
+			if c.wantPodName != "" {
+				expectedName = c.wantPodName
+			}
+			if d := cmp.Diff(expectedName, got.Name); d != "" {
+				t.Errorf("Pod name does not match: %s", diff.PrintWantGot(d))
+			}
+
+			if d := cmp.Diff(c.want, &got.Spec, resourceQuantityCmp, volumeSort, volumeMountSort); d != "" {
+				t.Errorf("Diff %s", diff.PrintWantGot(d))
+			}
+
+			if c.wantAnnotations != nil {
+				if d := cmp.Diff(c.wantAnnotations, got.ObjectMeta.Annotations, cmpopts.IgnoreMapEntries(ignoreReleaseAnnotation)); d != "" {
+					t.Errorf("Annotation Diff(-want, +got):\n%s", d)
+				}
+			}
+		})
+	}
+}
+
+func TestPodBuildwithAlphaAPIEnabled(t *testing.T) {
+	placeScriptsContainer := corev1.Container{
+		Name:         "place-scripts",
+		Image:        "busybox",
+		Command:      []string{"sh"},
+		VolumeMounts: []corev1.VolumeMount{writeScriptsVolumeMount, binMount, debugScriptsVolumeMount},
+		Args: []string{"-c", `tmpfile="/tekton/debug/scripts/debug-continue"
+touch ${tmpfile} && chmod +x ${tmpfile}
+cat > ${tmpfile} << 'debug-continue-heredoc-randomly-generated-9l9zj'
+#!/bin/sh
+set -e
+
+numberOfSteps=1
+debugInfo=/tekton/debug/info
+tektonRun=/tekton/run
+
+postFile="$(ls ${debugInfo} | grep -E '[0-9]+' | tail -1)"
+stepNumber="$(echo ${postFile} | sed 's/[^0-9]*//g')"
+
+if [ $stepNumber -lt $numberOfSteps ]; then
+	touch ${tektonRun}/${stepNumber}/out # Mark step as success
+	echo "0" > ${tektonRun}/${stepNumber}/out.breakpointexit
+	echo "Executing step $stepNumber..."
+else
+	echo "Last step (no. $stepNumber) has already been executed, breakpoint exiting !"
+	exit 0
+fi
+debug-continue-heredoc-randomly-generated-9l9zj
+tmpfile="/tekton/debug/scripts/debug-fail-continue"
+touch ${tmpfile} && chmod +x ${tmpfile}
+cat > ${tmpfile} << 'debug-fail-continue-heredoc-randomly-generated-mz4c7'
+#!/bin/sh
+set -e
+
+numberOfSteps=1
+debugInfo=/tekton/debug/info
+tektonRun=/tekton/run
+
+postFile="$(ls ${debugInfo} | grep -E '[0-9]+' | tail -1)"
+stepNumber="$(echo ${postFile} | sed 's/[^0-9]*//g')"
+
+if [ $stepNumber -lt $numberOfSteps ]; then
+	touch ${tektonRun}/${stepNumber}/out.err # Mark step as a failure
+	echo "1" > ${tektonRun}/${stepNumber}/out.breakpointexit
+	echo "Executing step $stepNumber..."
+else
+	echo "Last step (no. $stepNumber) has already been executed, breakpoint exiting !"
+	exit 0
+fi
+debug-fail-continue-heredoc-randomly-generated-mz4c7
+`},
+	}
+
+	containersVolumeMounts := append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
+		Name:      "tekton-creds-init-home-0",
+		MountPath: "/tekton/creds",
+	}}, implicitVolumeMounts...)
+	containersVolumeMounts = append(containersVolumeMounts, debugScriptsVolumeMount)
+	containersVolumeMounts = append(containersVolumeMounts, corev1.VolumeMount{
+		Name:      debugInfoVolumeName,
+		MountPath: filepath.Join(debugInfoDir, strconv.Itoa(0)),
+	})
+
+	for _, c := range []struct {
+		desc            string
+		trs             v1.TaskRunSpec
+		trAnnotation    map[string]string
+		ts              v1.TaskSpec
+		want            *corev1.PodSpec
+		wantAnnotations map[string]string
+	}{{
+		desc: "simple with debug breakpoint onFailure",
+		trs: v1.TaskRunSpec{
+			Debug: &v1.TaskRunDebug{
+				Breakpoints: &v1.TaskBreakpoints{
+					OnFailure: "enabled",
+				},
+			},
+		},
+		ts: v1.TaskSpec{
+			Steps: []v1.Step{{
+				Name:    "name",
+				Image:   "image",
+				Command: []string{"cmd"}, // avoid entrypoint lookup.
+			}},
+		},
+		want: &corev1.PodSpec{
+			RestartPolicy:  corev1.RestartPolicyNever,
+			InitContainers: []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1.Step{{Name: "name"}}, SecurityContextConfig{SetSecurityContext: false, SetReadOnlyRootFilesystem: false}, false /* windows */), placeScriptsContainer},
+			Containers: []corev1.Container{{
+				Name:    "step-name",
+				Image:   "image",
+				Command: []string{"/tekton/bin/entrypoint"},
+				Args: []string{
+					"-wait_file",
+					"/tekton/downward/ready",
+					"-wait_file_content",
+					"-post_file",
+					"/tekton/run/0/out",
+					"-termination_path",
+					"/tekton/termination",
+					"-step_metadata_dir",
+					"/tekton/run/0/status",
+					"-breakpoint_on_failure",
+					"-entrypoint",
+					"cmd",
+					"--",
+				},
+				VolumeMounts:           containersVolumeMounts,
+				TerminationMessagePath: "/tekton/termination",
+			}},
+			Volumes: append(implicitVolumes, debugScriptsVolume, debugInfoVolume, binVolume, scriptsVolume, runVolume(0), downwardVolume, corev1.Volume{
+				Name:         "tekton-creds-init-home-0",
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
+			}),
+			ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
+		},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			featureFlags := map[string]string{
+				"enable-api-fields": "alpha",
+			}
+			names.TestingSeed()
+			store := config.NewStore(logtesting.TestLogger(t))
+			store.OnConfigChanged(
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
+					Data:       featureFlags,
 				},
 			)
 			kubeclient := fakek8s.NewSimpleClientset(
@@ -2622,40 +3629,32 @@ _EOF_
 			} else {
 				trAnnotations = c.trAnnotation
 				trAnnotations[ReleaseAnnotation] = fakeVersion
-
-			}
-			testTaskRunName := taskRunName
-			if c.trName != "" {
-				testTaskRunName = c.trName
 			}
 			tr := &v1.TaskRun{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:        testTaskRunName,
+					Name:        "taskrun-name",
 					Namespace:   "default",
 					Annotations: trAnnotations,
 				},
-				Spec:   c.trs,
-				Status: c.trStatus,
+				Spec: c.trs,
 			}
 
 			// No entrypoints should be looked up.
 			entrypointCache := fakeCache{}
-
 			builder := Builder{
 				Images:          images,
 				KubeClient:      kubeclient,
 				EntrypointCache: entrypointCache,
 			}
+
 			got, err := builder.Build(store.ToContext(t.Context()), tr, c.ts)
 			if err != nil {
 				t.Fatalf("builder.Build: %v", err)
 			}
-			expectedName := testTaskRunName + "-pod"
-			if c.wantPodName != "" {
-				expectedName = c.wantPodName
-			}
+
+			expectedName := kmeta.ChildName(tr.Name, "-pod")
 			if d := cmp.Diff(expectedName, got.Name); d != "" {
-				t.Errorf("Pod name does not match: %s", diff.PrintWantGot(d))
+				t.Errorf("Pod name does not match: %q", d)
 			}
 
 			if d := cmp.Diff(c.want, &got.Spec, resourceQuantityCmp, volumeSort, volumeMountSort); d != "" {
@@ -2671,401 +3670,641 @@ _EOF_
 	}
 }
 
-func TestPodBuildwithAlphaAPIEnabled(t *testing.T) {
-	placeScriptsContainer := corev1.Container{
-		Name:         "place-scripts",
-		Image:        "busybox",
-		Command:      []string{"sh"},
-		VolumeMounts: []corev1.VolumeMount{writeScriptsVolumeMount, binMount, debugScriptsVolumeMount},
-		Args: []string{"-c", `tmpfile="/tekton/debug/scripts/debug-continue"
-touch ${tmpfile} && chmod +x ${tmpfile}
-cat > ${tmpfile} << 'debug-continue-heredoc-randomly-generated-9l9zj'
-#!/bin/sh
-set -e
+type ExpectedComputeResources struct {
+	name                 string
+	ResourceRequirements corev1.ResourceRequirements
+}
 
-numberOfSteps=1
-debugInfo=/tekton/debug/info
-tektonRun=/tekton/run
+func TestPodBuild_TaskLevelResourceRequirements(t *testing.T) {
+	testcases := []struct {
+		desc                     string
+		ts                       v1.TaskSpec
+		trs                      v1.TaskRunSpec
+		expectedComputeResources []ExpectedComputeResources
+	}{{
+		desc: "overwrite stepTemplate resources requirements",
+		ts: v1.TaskSpec{
+			Steps: []v1.Step{{
+				Name:    "1st-step",
+				Image:   "image",
+				Command: []string{"cmd"},
+			}, {
+				Name:    "2nd-step",
+				Image:   "image",
+				Command: []string{"cmd"},
+			}},
+			StepTemplate: &v1.StepTemplate{
+				ComputeResources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("500m"),
+						corev1.ResourceMemory: resource.MustParse("500Mi"),
+					},
+				},
+			},
+		},
+		trs: v1.TaskRunSpec{
+			ComputeResources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("2"),
+					corev1.ResourceMemory: resource.MustParse("2Gi"),
+				},
+			},
+		},
+		expectedComputeResources: []ExpectedComputeResources{{
+			name: "step-1st-step",
+			ResourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("1"),
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+				},
+			},
+		}, {
+			name: "step-2nd-step",
+			ResourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("1"),
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+				},
+			},
+		}},
+	}, {
+		desc: "overwrite step resources requirements",
+		ts: v1.TaskSpec{
+			Steps: []v1.Step{{
+				Name:    "1st-step",
+				Image:   "image",
+				Command: []string{"cmd"},
+				ComputeResources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("250m"),
+						corev1.ResourceMemory: resource.MustParse("500Mi"),
+					},
+				},
+			}, {
+				Name:    "2nd-step",
+				Image:   "image",
+				Command: []string{"cmd"},
+				ComputeResources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("300m"),
+						corev1.ResourceMemory: resource.MustParse("500Mi"),
+					},
+				},
+			}},
+		},
+		trs: v1.TaskRunSpec{
+			ComputeResources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("2"),
+					corev1.ResourceMemory: resource.MustParse("2Gi"),
+				},
+			},
+		},
+		expectedComputeResources: []ExpectedComputeResources{{
+			name: "step-1st-step",
+			ResourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("1"),
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+				},
+			},
+		}, {
+			name: "step-2nd-step",
+			ResourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("1"),
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+				},
+			},
+		}},
+	}, {
+		desc: "with sidecar resource requirements",
+		ts: v1.TaskSpec{
+			Steps: []v1.Step{{
+				Name:    "1st-step",
+				Image:   "image",
+				Command: []string{"cmd"},
+			}},
+			Sidecars: []v1.Sidecar{{
+				Name: "sidecar",
+				ComputeResources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("750m"),
+					},
+					Limits: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("1.5"),
+					},
+				},
+			}},
+		},
+		trs: v1.TaskRunSpec{
+			ComputeResources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("2"),
+					corev1.ResourceMemory: resource.MustParse("2Gi"),
+				},
+			},
+		},
+		expectedComputeResources: []ExpectedComputeResources{{
+			name: "step-1st-step",
+			ResourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("2"),
+					corev1.ResourceMemory: resource.MustParse("2Gi"),
+				},
+			},
+		}, {
+			name: "sidecar-sidecar",
+			ResourceRequirements: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("750m"),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("1.5"),
+				},
+			},
+		}},
+	}}
 
-postFile="$(ls ${debugInfo} | grep -E '[0-9]+' | tail -1)"
-stepNumber="$(echo ${postFile} | sed 's/[^0-9]*//g')"
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			names.TestingSeed()
+			store := config.NewStore(logtesting.TestLogger(t))
+			enableAlphaAPI := map[string]string{"enable-api-fields": "alpha"}
+			store.OnConfigChanged(
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
+					Data:       enableAlphaAPI,
+				},
+			)
 
-if [ $stepNumber -lt $numberOfSteps ]; then
-	touch ${tektonRun}/${stepNumber}/out # Mark step as success
-	echo "0" > ${tektonRun}/${stepNumber}/out.breakpointexit
-	echo "Executing step $stepNumber..."
-else
-	echo "Last step (no. $stepNumber) has already been executed, breakpoint exiting !"
-	exit 0
-fi
-debug-continue-heredoc-randomly-generated-9l9zj
-tmpfile="/tekton/debug/scripts/debug-fail-continue"
-touch ${tmpfile} && chmod +x ${tmpfile}
-cat > ${tmpfile} << 'debug-fail-continue-heredoc-randomly-generated-mz4c7'
-#!/bin/sh
-set -e
+			kubeclient := fakek8s.NewSimpleClientset(
+				&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}},
+			)
+			builder := Builder{
+				Images:     images,
+				KubeClient: kubeclient,
+			}
+			tr := &v1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo-taskrun",
+					Namespace: "default",
+				},
+				Spec: tc.trs,
+			}
 
-numberOfSteps=1
-debugInfo=/tekton/debug/info
-tektonRun=/tekton/run
+			gotPod, err := builder.Build(store.ToContext(t.Context()), tr, tc.ts)
+			if err != nil {
+				t.Fatalf("builder.Build: %v", err)
+			}
+
+			if err := verifyTaskLevelComputeResources(tc.expectedComputeResources, gotPod.Spec.Containers); err != nil {
+				t.Errorf("verifyTaskLevelComputeResources: %v", err)
+			}
+		})
+	}
+}
+
+func TestPodBuild_ExtendedResources(t *testing.T) {
+	const gpu corev1.ResourceName = "nvidia.com/gpu"
+	oneGPU := corev1.ResourceList{gpu: resource.MustParse("1")}
+	for _, taskLevel := range []struct {
+		desc             string
+		computeResources *corev1.ResourceRequirements
+		want             corev1.ResourceRequirements
+	}{{
+		desc: "without task-level compute resources",
+		want: corev1.ResourceRequirements{},
+	}, {
+		desc: "with task-level requests",
+		computeResources: &corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2"), corev1.ResourceMemory: resource.MustParse("2Gi")},
+		},
+		want: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1"), corev1.ResourceMemory: resource.MustParse("1Gi")},
+		},
+	}, {
+		desc: "with task-level limits",
+		computeResources: &corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")},
+		},
+		want: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+			Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")},
+		},
+	}, {
+		desc: "with task-level requests and limits",
+		computeResources: &corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+			Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+		},
+		want: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+			Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+		},
+	}} {
+		for gpuStep := range 2 {
+			t.Run(fmt.Sprintf("GPU on step %d %s", gpuStep, taskLevel.desc), func(t *testing.T) {
+				names.TestingSeed()
+				store := config.NewStore(logtesting.TestLogger(t))
+				store.OnConfigChanged(&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
+					Data:       map[string]string{"enable-api-fields": "alpha"},
+				})
+				steps := []v1.Step{{
+					Name:    "1st-step",
+					Image:   "image",
+					Command: []string{"cmd"},
+				}, {
+					Name:    "2nd-step",
+					Image:   "image",
+					Command: []string{"cmd"},
+				}}
+				steps[gpuStep].ComputeResources = corev1.ResourceRequirements{Requests: oneGPU.DeepCopy(), Limits: oneGPU.DeepCopy()}
 
-postFile="$(ls ${debugInfo} | grep -E '[0-9]+' | tail -1)"
-stepNumber="$(echo ${postFile} | sed 's/[^0-9]*//g')"
+				var expected []ExpectedComputeResources
+				for i, step := range steps {
+					want := *taskLevel.want.DeepCopy()
+					if taskLevel.computeResources == nil {
+						want = *step.ComputeResources.DeepCopy()
+					} else if i == gpuStep {
+						if want.Requests == nil {
+							want.Requests = corev1.ResourceList{}
+						}
+						if want.Limits == nil {
+							want.Limits = corev1.ResourceList{}
+						}
+						want.Requests[gpu] = oneGPU[gpu]
+						want.Limits[gpu] = oneGPU[gpu]
+					}
+					expected = append(expected, ExpectedComputeResources{name: "step-" + step.Name, ResourceRequirements: want})
+				}
 
-if [ $stepNumber -lt $numberOfSteps ]; then
-	touch ${tektonRun}/${stepNumber}/out.err # Mark step as a failure
-	echo "1" > ${tektonRun}/${stepNumber}/out.breakpointexit
-	echo "Executing step $stepNumber..."
-else
-	echo "Last step (no. $stepNumber) has already been executed, breakpoint exiting !"
-	exit 0
-fi
-debug-fail-continue-heredoc-randomly-generated-mz4c7
-`},
+				builder := Builder{
+					Images: images,
+					KubeClient: fakek8s.NewSimpleClientset(
+						&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}},
+					),
+				}
+				tr := &v1.TaskRun{
+					ObjectMeta: metav1.ObjectMeta{Name: "foo-taskrun", Namespace: "default"},
+					Spec:       v1.TaskRunSpec{ComputeResources: taskLevel.computeResources},
+				}
+				gotPod, err := builder.Build(store.ToContext(t.Context()), tr, v1.TaskSpec{Steps: steps})
+				if err != nil {
+					t.Fatalf("builder.Build: %v", err)
+				}
+				if err := verifyTaskLevelComputeResources(expected, gotPod.Spec.Containers); err != nil {
+					t.Errorf("verifyTaskLevelComputeResources: %v", err)
+				}
+			})
+		}
 	}
+}
 
-	containersVolumeMounts := append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
-		Name:      "tekton-creds-init-home-0",
-		MountPath: "/tekton/creds",
-	}}, implicitVolumeMounts...)
-	containersVolumeMounts = append(containersVolumeMounts, debugScriptsVolumeMount)
-	containersVolumeMounts = append(containersVolumeMounts, corev1.VolumeMount{
-		Name:      debugInfoVolumeName,
-		MountPath: filepath.Join(debugInfoDir, strconv.Itoa(0)),
-	})
-
-	for _, c := range []struct {
-		desc            string
-		trs             v1.TaskRunSpec
-		trAnnotation    map[string]string
-		ts              v1.TaskSpec
-		want            *corev1.PodSpec
-		wantAnnotations map[string]string
+func TestPodBuild_ContainerNameCollision(t *testing.T) {
+	for _, tc := range []struct {
+		desc           string
+		configDefaults map[string]string
+		ts             v1.TaskSpec
+		wantErr        string
 	}{{
-		desc: "simple with debug breakpoint onFailure",
-		trs: v1.TaskRunSpec{
-			Debug: &v1.TaskRunDebug{
-				Breakpoints: &v1.TaskBreakpoints{
-					OnFailure: "enabled",
-				},
-			},
+		desc: "collision with an injected sidecar",
+		configDefaults: map[string]string{"default-injected-sidecars": `- name: log-forwarder
+  image: fluent-bit`},
+		ts: v1.TaskSpec{
+			Steps: []v1.Step{{
+				Name:          "build",
+				ContainerName: "sidecar-log-forwarder",
+				Image:         "image",
+				Command:       []string{"cmd"}, // avoid entrypoint lookup.
+			}},
 		},
+		wantErr: `container name "sidecar-log-forwarder" of step "build" is used by another container of the pod`,
+	}, {
+		desc: "collision with an init container",
 		ts: v1.TaskSpec{
 			Steps: []v1.Step{{
-				Name:    "name",
-				Image:   "image",
-				Command: []string{"cmd"}, // avoid entrypoint lookup.
+				Name:          "build",
+				ContainerName: "prepare",
+				Image:         "image",
+				Command:       []string{"cmd"}, // avoid entrypoint lookup.
 			}},
 		},
-		want: &corev1.PodSpec{
-			RestartPolicy:  corev1.RestartPolicyNever,
-			InitContainers: []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1.Step{{Name: "name"}}, SecurityContextConfig{SetSecurityContext: false, SetReadOnlyRootFilesystem: false}, false /* windows */), placeScriptsContainer},
-			Containers: []corev1.Container{{
-				Name:    "step-name",
+		wantErr: `container name "prepare" of step "build" is used by another container of the pod`,
+	}, {
+		desc: "collision with the container of another step",
+		ts: v1.TaskSpec{
+			Steps: []v1.Step{{
+				Name:    "build",
 				Image:   "image",
-				Command: []string{"/tekton/bin/entrypoint"},
-				Args: []string{
-					"-wait_file",
-					"/tekton/downward/ready",
-					"-wait_file_content",
-					"-post_file",
-					"/tekton/run/0/out",
-					"-termination_path",
-					"/tekton/termination",
-					"-step_metadata_dir",
-					"/tekton/run/0/status",
-					"-breakpoint_on_failure",
-					"-entrypoint",
-					"cmd",
-					"--",
-				},
-				VolumeMounts:           containersVolumeMounts,
-				TerminationMessagePath: "/tekton/termination",
+				Command: []string{"cmd"}, // avoid entrypoint lookup.
+			}, {
+				Name:          "test",
+				ContainerName: "step-build",
+				Image:         "image",
+				Command:       []string{"cmd"}, // avoid entrypoint lookup.
 			}},
-			Volumes: append(implicitVolumes, debugScriptsVolume, debugInfoVolume, binVolume, scriptsVolume, runVolume(0), downwardVolume, corev1.Volume{
-				Name:         "tekton-creds-init-home-0",
-				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
-			}),
-			ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
 		},
+		wantErr: `container name "step-build" of step "test" is used by another container of the pod`,
 	}} {
-		t.Run(c.desc, func(t *testing.T) {
-			featureFlags := map[string]string{
-				"enable-api-fields": "alpha",
-			}
-			names.TestingSeed()
+		t.Run(tc.desc, func(t *testing.T) {
 			store := config.NewStore(logtesting.TestLogger(t))
 			store.OnConfigChanged(
 				&corev1.ConfigMap{
 					ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
-					Data:       featureFlags,
+					Data:       map[string]string{"disable-creds-init": "true"},
+				},
+			)
+			store.OnConfigChanged(
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: config.GetDefaultsConfigName(), Namespace: system.Namespace()},
+					Data:       tc.configDefaults,
 				},
 			)
 			kubeclient := fakek8s.NewSimpleClientset(
 				&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}},
-				&corev1.ServiceAccount{
-					ObjectMeta: metav1.ObjectMeta{Name: "service-account", Namespace: "default"},
-					Secrets: []corev1.ObjectReference{{
-						Name: "multi-creds",
-					}},
-				},
-				&corev1.Secret{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      "multi-creds",
-						Namespace: "default",
-						Annotations: map[string]string{
-							"tekton.dev/docker-0": "https://us.gcr.io",
-							"tekton.dev/docker-1": "https://docker.io",
-							"tekton.dev/git-0":    "github.com",
-							"tekton.dev/git-1":    "gitlab.com",
-						},
-					},
-					Type: "kubernetes.io/basic-auth",
-					Data: map[string][]byte{
-						"username": []byte("foo"),
-						"password": []byte("BestEver"),
-					},
-				},
 			)
-			var trAnnotations map[string]string
-			if c.trAnnotation == nil {
-				trAnnotations = map[string]string{
-					ReleaseAnnotation: fakeVersion,
-				}
-			} else {
-				trAnnotations = c.trAnnotation
-				trAnnotations[ReleaseAnnotation] = fakeVersion
+			builder := Builder{
+				Images:          images,
+				KubeClient:      kubeclient,
+				EntrypointCache: fakeCache{},
 			}
 			tr := &v1.TaskRun{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:        "taskrun-name",
-					Namespace:   "default",
-					Annotations: trAnnotations,
+					Name:      "foo-taskrun",
+					Namespace: "default",
 				},
-				Spec: c.trs,
 			}
 
-			// No entrypoints should be looked up.
-			entrypointCache := fakeCache{}
-			builder := Builder{
-				Images:          images,
-				KubeClient:      kubeclient,
-				EntrypointCache: entrypointCache,
+			_, err := builder.Build(store.ToContext(t.Context()), tr, tc.ts)
+			if err == nil || err.Error() != tc.wantErr {
+				t.Errorf("expected error %q but got %v", tc.wantErr, err)
 			}
+		})
+	}
+}
 
-			got, err := builder.Build(store.ToContext(t.Context()), tr, c.ts)
-			if err != nil {
-				t.Fatalf("builder.Build: %v", err)
-			}
+func TestPodBuild_SidecarDependencies(t *testing.T) {
+	store := config.NewStore(logtesting.TestLogger(t))
+	store.OnConfigChanged(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
+			Data: map[string]string{
+				"disable-creds-init":                            "true",
+				"running-in-environment-with-injected-sidecars": "false",
+			},
+		},
+	)
+	kubeclient := fakek8s.NewSimpleClientset(
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}},
+	)
+	builder := Builder{
+		Images:          images,
+		KubeClient:      kubeclient,
+		EntrypointCache: fakeCache{},
+	}
+	tr := &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo-taskrun",
+			Namespace: "default",
+		},
+	}
+	ts := v1.TaskSpec{
+		Steps: []v1.Step{{
+			Name:    "build",
+			Image:   "image",
+			Command: []string{"cmd"}, // avoid entrypoint lookup.
+		}, {
+			Name:              "migrate",
+			ContainerName:     "migrate",
+			Image:             "image",
+			Command:           []string{"cmd"}, // avoid entrypoint lookup.
+			DependsOnSidecars: []string{"db"},
+		}, {
+			Name:              "test",
+			Image:             "image",
+			Command:           []string{"cmd"}, // avoid entrypoint lookup.
+			DependsOnSidecars: []string{"db", "cache"},
+		}},
+		Sidecars: []v1.Sidecar{{
+			Name:           "db",
+			Image:          "postgres",
+			StopAfterSteps: []string{"migrate", "test"},
+		}, {
+			Name:                     "cache",
+			Image:                    "redis",
+			FailTaskRunOnTermination: true,
+		}},
+	}
 
-			expectedName := kmeta.ChildName(tr.Name, "-pod")
-			if d := cmp.Diff(expectedName, got.Name); d != "" {
-				t.Errorf("Pod name does not match: %q", d)
-			}
+	got, err := builder.Build(store.ToContext(t.Context()), tr, ts)
+	if err != nil {
+		t.Fatalf("builder.Build: %v", err)
+	}
 
-			if d := cmp.Diff(c.want, &got.Spec, resourceQuantityCmp, volumeSort, volumeMountSort); d != "" {
-				t.Errorf("Diff %s", diff.PrintWantGot(d))
-			}
+	// The steps wait for the sidecars they depend on rather than the first
+	// step waiting for all the sidecars, so the pod is ready immediately.
+	wantAnnotations := map[string]string{
+		readyAnnotation:                           readyAnnotationValue,
+		readinessExcludedSidecarsAnnotation:       "sidecar-db,sidecar-cache",
+		sidecarDependenciesAnnotation:             "sidecar-cache,sidecar-db",
+		sidecarStopAfterStepsAnnotation:           "sidecar-db=migrate;step-test",
+		sidecarFailTaskRunOnTerminationAnnotation: "sidecar-cache",
+	}
+	for k, v := range wantAnnotations {
+		if got.Annotations[k] != v {
+			t.Errorf("expected the annotation %s to be %q, got %q", k, v, got.Annotations[k])
+		}
+	}
+	wantReadyFiles := map[string]string{
+		"step-build": "",
+		"migrate":    "/tekton/downward/sidecar-ready/sidecar-db",
+		"step-test":  "/tekton/downward/sidecar-ready/sidecar-db,/tekton/downward/sidecar-ready/sidecar-cache",
+	}
+	for _, c := range got.Spec.Containers {
+		want, ok := wantReadyFiles[c.Name]
+		if !ok {
+			continue
+		}
+		gotReadyFiles := ""
+		if i := slices.Index(c.Args, "-ready_file"); i >= 0 {
+			gotReadyFiles = c.Args[i+1]
+		}
+		if gotReadyFiles != want {
+			t.Errorf("expected the step container %s to wait for %q, got %q", c.Name, want, gotReadyFiles)
+		}
+		if mounted := slices.Contains(c.VolumeMounts, downwardMount); mounted != (want != "") {
+			t.Errorf("expected the Downward volume to be mounted in the step container %s: %t, got %t", c.Name, want != "", mounted)
+		}
+		if slices.Contains(c.Args, filepath.Join(downwardMountPoint, downwardMountReadyFile)) {
+			t.Errorf("expected the step container %s not to wait for the pod to be ready, got %v", c.Name, c.Args)
+		}
+	}
 
-			if c.wantAnnotations != nil {
-				if d := cmp.Diff(c.wantAnnotations, got.ObjectMeta.Annotations, cmpopts.IgnoreMapEntries(ignoreReleaseAnnotation)); d != "" {
-					t.Errorf("Annotation Diff(-want, +got):\n%s", d)
-				}
+	var gotItems []string
+	for _, v := range got.Spec.Volumes {
+		if v.Name == downwardVolumeName {
+			for _, item := range v.DownwardAPI.Items {
+				gotItems = append(gotItems, item.Path+"="+item.FieldRef.FieldPath)
 			}
-		})
+		}
+	}
+	wantItems := []string{
+		"ready=metadata.annotations['tekton.dev/ready']",
+		"sidecar-ready/sidecar-cache=metadata.annotations['sidecar-ready.tekton.dev/sidecar-cache']",
+		"sidecar-ready/sidecar-db=metadata.annotations['sidecar-ready.tekton.dev/sidecar-db']",
+	}
+	if d := cmp.Diff(wantItems, gotItems); d != "" {
+		t.Errorf("Downward volume items Diff %s", diff.PrintWantGot(d))
 	}
 }
 
-type ExpectedComputeResources struct {
-	name                 string
-	ResourceRequirements corev1.ResourceRequirements
-}
-
-func TestPodBuild_TaskLevelResourceRequirements(t *testing.T) {
-	testcases := []struct {
-		desc                     string
-		ts                       v1.TaskSpec
-		trs                      v1.TaskRunSpec
-		expectedComputeResources []ExpectedComputeResources
-	}{{
-		desc: "overwrite stepTemplate resources requirements",
-		ts: v1.TaskSpec{
-			Steps: []v1.Step{{
-				Name:    "1st-step",
-				Image:   "image",
-				Command: []string{"cmd"},
-			}, {
-				Name:    "2nd-step",
-				Image:   "image",
-				Command: []string{"cmd"},
-			}},
-			StepTemplate: &v1.StepTemplate{
-				ComputeResources: corev1.ResourceRequirements{
-					Requests: corev1.ResourceList{
-						corev1.ResourceCPU:    resource.MustParse("500m"),
-						corev1.ResourceMemory: resource.MustParse("500Mi"),
-					},
-				},
-			},
-		},
-		trs: v1.TaskRunSpec{
-			ComputeResources: &corev1.ResourceRequirements{
-				Requests: corev1.ResourceList{
-					corev1.ResourceCPU:    resource.MustParse("2"),
-					corev1.ResourceMemory: resource.MustParse("2Gi"),
-				},
+func TestPodBuild_BuildMetadataEnv(t *testing.T) {
+	pipelineTaskRun := &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pr-build-0",
+			Namespace: "default",
+			UID:       "taskrun-uid",
+			Labels: map[string]string{
+				pipeline.TaskLabelKey:           "build",
+				pipeline.PipelineRunLabelKey:    "pr",
+				pipeline.PipelineRunUIDLabelKey: "pipelinerun-uid",
+				pipeline.PipelineLabelKey:       "release",
+				pipeline.PipelineTaskLabelKey:   "build-all",
 			},
+			Annotations: map[string]string{v1.MatrixCombinationIndexAnnotation: "0"},
 		},
-		expectedComputeResources: []ExpectedComputeResources{{
-			name: "step-1st-step",
-			ResourceRequirements: corev1.ResourceRequirements{
-				Requests: corev1.ResourceList{
-					corev1.ResourceCPU:    resource.MustParse("1"),
-					corev1.ResourceMemory: resource.MustParse("1Gi"),
-				},
-			},
-		}, {
-			name: "step-2nd-step",
-			ResourceRequirements: corev1.ResourceRequirements{
-				Requests: corev1.ResourceList{
-					corev1.ResourceCPU:    resource.MustParse("1"),
-					corev1.ResourceMemory: resource.MustParse("1Gi"),
-				},
-			},
+		Spec: v1.TaskRunSpec{TaskRef: &v1.TaskRef{Name: "build"}},
+		Status: v1.TaskRunStatus{TaskRunStatusFields: v1.TaskRunStatusFields{
+			RetriesStatus: []v1.TaskRunStatus{{}},
 		}},
+	}
+	embeddedTaskRun := &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo-taskrun",
+			Namespace: "default",
+			UID:       "taskrun-uid",
+		},
+	}
+	ts := v1.TaskSpec{
+		Steps: []v1.Step{{
+			Name:    "build",
+			Image:   "image",
+			Command: []string{"cmd"}, // avoid entrypoint lookup.
+			Env:     []corev1.EnvVar{{Name: "TEKTON_TASKRUN_ATTEMPT", Value: "user"}},
+		}},
+		Sidecars: []v1.Sidecar{{
+			Name:  "db",
+			Image: "postgres",
+		}},
+	}
+
+	for _, tc := range []struct {
+		desc           string
+		featureFlags   map[string]string
+		configDefaults map[string]string
+		tr             *v1.TaskRun
+		wantStepEnv    []corev1.EnvVar
+		wantSidecarEnv []corev1.EnvVar
+	}{{
+		desc:           "disabled",
+		tr:             pipelineTaskRun,
+		wantStepEnv:    []corev1.EnvVar{{Name: "TEKTON_TASKRUN_ATTEMPT", Value: "user"}},
+		wantSidecarEnv: nil,
 	}, {
-		desc: "overwrite step resources requirements",
-		ts: v1.TaskSpec{
-			Steps: []v1.Step{{
-				Name:    "1st-step",
-				Image:   "image",
-				Command: []string{"cmd"},
-				ComputeResources: corev1.ResourceRequirements{
-					Requests: corev1.ResourceList{
-						corev1.ResourceCPU:    resource.MustParse("250m"),
-						corev1.ResourceMemory: resource.MustParse("500Mi"),
-					},
-				},
-			}, {
-				Name:    "2nd-step",
-				Image:   "image",
-				Command: []string{"cmd"},
-				ComputeResources: corev1.ResourceRequirements{
-					Requests: corev1.ResourceList{
-						corev1.ResourceCPU:    resource.MustParse("300m"),
-						corev1.ResourceMemory: resource.MustParse("500Mi"),
-					},
-				},
-			}},
+		desc:         "TaskRun of a Pipeline",
+		featureFlags: map[string]string{"enable-build-metadata-env": "true"},
+		tr:           pipelineTaskRun,
+		wantStepEnv: []corev1.EnvVar{
+			{Name: "TEKTON_TASKRUN_ATTEMPT", Value: "user"},
+			{Name: "TEKTON_TASKRUN_NAME", Value: "pr-build-0"},
+			{Name: "TEKTON_TASKRUN_NAMESPACE", Value: "default"},
+			{Name: "TEKTON_TASKRUN_UID", Value: "taskrun-uid"},
+			{Name: "TEKTON_TASK_NAME", Value: "build"},
+			{Name: "TEKTON_PIPELINERUN_NAME", Value: "pr"},
+			{Name: "TEKTON_PIPELINERUN_UID", Value: "pipelinerun-uid"},
+			{Name: "TEKTON_PIPELINE_NAME", Value: "release"},
+			{Name: "TEKTON_PIPELINE_TASK_NAME", Value: "build-all"},
+			{Name: "TEKTON_MATRIX_COMBINATION_INDEX", Value: "0"},
 		},
-		trs: v1.TaskRunSpec{
-			ComputeResources: &corev1.ResourceRequirements{
-				Requests: corev1.ResourceList{
-					corev1.ResourceCPU:    resource.MustParse("2"),
-					corev1.ResourceMemory: resource.MustParse("2Gi"),
-				},
-			},
+		wantSidecarEnv: []corev1.EnvVar{
+			{Name: "TEKTON_TASKRUN_NAME", Value: "pr-build-0"},
+			{Name: "TEKTON_TASKRUN_NAMESPACE", Value: "default"},
+			{Name: "TEKTON_TASKRUN_UID", Value: "taskrun-uid"},
+			{Name: "TEKTON_TASK_NAME", Value: "build"},
+			{Name: "TEKTON_TASKRUN_ATTEMPT", Value: "2"},
+			{Name: "TEKTON_PIPELINERUN_NAME", Value: "pr"},
+			{Name: "TEKTON_PIPELINERUN_UID", Value: "pipelinerun-uid"},
+			{Name: "TEKTON_PIPELINE_NAME", Value: "release"},
+			{Name: "TEKTON_PIPELINE_TASK_NAME", Value: "build-all"},
+			{Name: "TEKTON_MATRIX_COMBINATION_INDEX", Value: "0"},
 		},
-		expectedComputeResources: []ExpectedComputeResources{{
-			name: "step-1st-step",
-			ResourceRequirements: corev1.ResourceRequirements{
-				Requests: corev1.ResourceList{
-					corev1.ResourceCPU:    resource.MustParse("1"),
-					corev1.ResourceMemory: resource.MustParse("1Gi"),
-				},
-			},
-		}, {
-			name: "step-2nd-step",
-			ResourceRequirements: corev1.ResourceRequirements{
-				Requests: corev1.ResourceList{
-					corev1.ResourceCPU:    resource.MustParse("1"),
-					corev1.ResourceMemory: resource.MustParse("1Gi"),
-				},
-			},
-		}},
 	}, {
-		desc: "with sidecar resource requirements",
-		ts: v1.TaskSpec{
-			Steps: []v1.Step{{
-				Name:    "1st-step",
-				Image:   "image",
-				Command: []string{"cmd"},
-			}},
-			Sidecars: []v1.Sidecar{{
-				Name: "sidecar",
-				ComputeResources: corev1.ResourceRequirements{
-					Requests: corev1.ResourceList{
-						corev1.ResourceCPU: resource.MustParse("750m"),
-					},
-					Limits: corev1.ResourceList{
-						corev1.ResourceCPU: resource.MustParse("1.5"),
-					},
-				},
-			}},
+		desc:         "standalone TaskRun with configured env vars",
+		featureFlags: map[string]string{"enable-build-metadata-env": "true"},
+		configDefaults: map[string]string{"default-build-metadata-env": `BUILD_ID: "$(context.taskRun.namespace)/$(context.taskRun.name)"
+TEKTON_TASKRUN_NAME: "run-$(context.taskRun.name)"`},
+		tr: embeddedTaskRun,
+		wantStepEnv: []corev1.EnvVar{
+			{Name: "TEKTON_TASKRUN_ATTEMPT", Value: "user"},
+			{Name: "TEKTON_TASKRUN_NAMESPACE", Value: "default"},
+			{Name: "TEKTON_TASKRUN_UID", Value: "taskrun-uid"},
+			{Name: "TEKTON_TASK_NAME", Value: "foo-taskrun"},
+			{Name: "BUILD_ID", Value: "default/foo-taskrun"},
+			{Name: "TEKTON_TASKRUN_NAME", Value: "run-foo-taskrun"},
 		},
-		trs: v1.TaskRunSpec{
-			ComputeResources: &corev1.ResourceRequirements{
-				Requests: corev1.ResourceList{
-					corev1.ResourceCPU:    resource.MustParse("2"),
-					corev1.ResourceMemory: resource.MustParse("2Gi"),
-				},
-			},
+		wantSidecarEnv: []corev1.EnvVar{
+			{Name: "TEKTON_TASKRUN_NAMESPACE", Value: "default"},
+			{Name: "TEKTON_TASKRUN_UID", Value: "taskrun-uid"},
+			{Name: "TEKTON_TASK_NAME", Value: "foo-taskrun"},
+			{Name: "TEKTON_TASKRUN_ATTEMPT", Value: "1"},
+			{Name: "BUILD_ID", Value: "default/foo-taskrun"},
+			{Name: "TEKTON_TASKRUN_NAME", Value: "run-foo-taskrun"},
 		},
-		expectedComputeResources: []ExpectedComputeResources{{
-			name: "step-1st-step",
-			ResourceRequirements: corev1.ResourceRequirements{
-				Requests: corev1.ResourceList{
-					corev1.ResourceCPU:    resource.MustParse("2"),
-					corev1.ResourceMemory: resource.MustParse("2Gi"),
-				},
-			},
-		}, {
-			name: "sidecar-sidecar",
-			ResourceRequirements: corev1.ResourceRequirements{
-				Requests: corev1.ResourceList{
-					corev1.ResourceCPU: resource.MustParse("750m"),
-				},
-				Limits: corev1.ResourceList{
-					corev1.ResourceCPU: resource.MustParse("1.5"),
-				},
-			},
-		}},
-	}}
-
-	for _, tc := range testcases {
+	}} {
 		t.Run(tc.desc, func(t *testing.T) {
-			names.TestingSeed()
+			featureFlags := map[string]string{"disable-creds-init": "true"}
+			maps.Copy(featureFlags, tc.featureFlags)
 			store := config.NewStore(logtesting.TestLogger(t))
-			enableAlphaAPI := map[string]string{"enable-api-fields": "alpha"}
 			store.OnConfigChanged(
 				&corev1.ConfigMap{
 					ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
-					Data:       enableAlphaAPI,
+					Data:       featureFlags,
+				},
+			)
+			store.OnConfigChanged(
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: config.GetDefaultsConfigName(), Namespace: system.Namespace()},
+					Data:       tc.configDefaults,
 				},
 			)
-
 			kubeclient := fakek8s.NewSimpleClientset(
 				&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}},
 			)
 			builder := Builder{
-				Images:     images,
-				KubeClient: kubeclient,
-			}
-			tr := &v1.TaskRun{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "foo-taskrun",
-					Namespace: "default",
-				},
-				Spec: tc.trs,
+				Images:          images,
+				KubeClient:      kubeclient,
+				EntrypointCache: fakeCache{},
 			}
 
-			gotPod, err := builder.Build(store.ToContext(t.Context()), tr, tc.ts)
+			got, err := builder.Build(store.ToContext(t.Context()), tc.tr, ts)
 			if err != nil {
 				t.Fatalf("builder.Build: %v", err)
 			}
-
-			if err := verifyTaskLevelComputeResources(tc.expectedComputeResources, gotPod.Spec.Containers); err != nil {
-				t.Errorf("verifyTaskLevelComputeResources: %v", err)
+			for _, c := range got.Spec.Containers {
+				var want []corev1.EnvVar
+				switch c.Name {
+				case "step-build":
+					want = tc.wantStepEnv
+				case "sidecar-db":
+					want = tc.wantSidecarEnv
+				default:
+					continue
+				}
+				if d := cmp.Diff(want, c.Env); d != "" {
+					t.Errorf("env of the container %s Diff %s", c.Name, diff.PrintWantGot(d))
+				}
 			}
 		})
 	}
@@ -3854,3 +5093,262 @@ func TestIsNativeSidecarSupport(t *testing.T) {
 		})
 	}
 }
+
+func TestPodBuildSecurityProfiles(t *testing.T) {
+	localhostProfile := "custom"
+	runtimeDefaultSeccomp := &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault}
+	unconfinedSeccomp := &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeUnconfined}
+	runtimeDefaultAppArmor := &corev1.AppArmorProfile{Type: corev1.AppArmorProfileTypeRuntimeDefault}
+	unconfinedAppArmor := &corev1.AppArmorProfile{Type: corev1.AppArmorProfileTypeUnconfined}
+	localhostAppArmor := &corev1.AppArmorProfile{Type: corev1.AppArmorProfileTypeLocalhost, LocalhostProfile: &localhostProfile}
+	profilesTemplate := &pod.Template{
+		SeccompProfile:  runtimeDefaultSeccomp,
+		AppArmorProfile: runtimeDefaultAppArmor,
+	}
+
+	for _, c := range []struct {
+		desc                     string
+		minor                    string
+		podTemplate              *pod.Template
+		stepSecurityContext      *corev1.SecurityContext
+		configDefaults           map[string]string
+		wantPodSecurityContext   *corev1.PodSecurityContext
+		wantStepSecurityContexts map[string]*corev1.SecurityContext
+		wantAnnotations          map[string]string
+		wantErr                  bool
+	}{{
+		desc:                   "pod template profiles",
+		minor:                  "30",
+		podTemplate:            profilesTemplate,
+		wantPodSecurityContext: &corev1.PodSecurityContext{SeccompProfile: runtimeDefaultSeccomp, AppArmorProfile: runtimeDefaultAppArmor},
+		wantStepSecurityContexts: map[string]*corev1.SecurityContext{
+			"step-a": nil,
+			"step-b": nil,
+		},
+		wantAnnotations: map[string]string{},
+	}, {
+		desc:                   "pod security context overrides pod template profiles",
+		minor:                  "30",
+		podTemplate:            &pod.Template{SeccompProfile: runtimeDefaultSeccomp, SecurityContext: &corev1.PodSecurityContext{SeccompProfile: unconfinedSeccomp}},
+		wantPodSecurityContext: &corev1.PodSecurityContext{SeccompProfile: unconfinedSeccomp},
+		wantStepSecurityContexts: map[string]*corev1.SecurityContext{
+			"step-a": nil,
+			"step-b": nil,
+		},
+		wantAnnotations: map[string]string{},
+	}, {
+		desc:                   "step overrides pod template profiles",
+		minor:                  "30",
+		podTemplate:            profilesTemplate,
+		stepSecurityContext:    &corev1.SecurityContext{SeccompProfile: unconfinedSeccomp, AppArmorProfile: unconfinedAppArmor},
+		wantPodSecurityContext: &corev1.PodSecurityContext{SeccompProfile: runtimeDefaultSeccomp, AppArmorProfile: runtimeDefaultAppArmor},
+		wantStepSecurityContexts: map[string]*corev1.SecurityContext{
+			"step-a": nil,
+			"step-b": {SeccompProfile: unconfinedSeccomp, AppArmorProfile: unconfinedAppArmor},
+		},
+		wantAnnotations: map[string]string{},
+	}, {
+		desc:                   "AppArmor annotations before Kubernetes 1.30",
+		minor:                  "29",
+		podTemplate:            profilesTemplate,
+		stepSecurityContext:    &corev1.SecurityContext{SeccompProfile: unconfinedSeccomp, AppArmorProfile: localhostAppArmor},
+		wantPodSecurityContext: &corev1.PodSecurityContext{SeccompProfile: runtimeDefaultSeccomp},
+		wantStepSecurityContexts: map[string]*corev1.SecurityContext{
+			"step-a": nil,
+			"step-b": {SeccompProfile: unconfinedSeccomp},
+		},
+		wantAnnotations: map[string]string{
+			"container.apparmor.security.beta.kubernetes.io/prepare": "runtime/default",
+			"container.apparmor.security.beta.kubernetes.io/step-a":  "runtime/default",
+			"container.apparmor.security.beta.kubernetes.io/step-b":  "localhost/custom",
+		},
+	}, {
+		desc:                   "AppArmor annotations of the steps only before Kubernetes 1.30",
+		minor:                  "29",
+		stepSecurityContext:    &corev1.SecurityContext{AppArmorProfile: unconfinedAppArmor},
+		wantPodSecurityContext: nil,
+		wantStepSecurityContexts: map[string]*corev1.SecurityContext{
+			"step-a": nil,
+			"step-b": {},
+		},
+		wantAnnotations: map[string]string{
+			"container.apparmor.security.beta.kubernetes.io/step-b": "unconfined",
+		},
+	}, {
+		desc:           "Localhost profile of the pod template forbidden",
+		minor:          "30",
+		podTemplate:    &pod.Template{SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeLocalhost, LocalhostProfile: &localhostProfile}},
+		configDefaults: map[string]string{"forbid-localhost-profiles": "true"},
+		wantErr:        true,
+	}, {
+		desc:                "Localhost profile of a step forbidden",
+		minor:               "29",
+		stepSecurityContext: &corev1.SecurityContext{AppArmorProfile: localhostAppArmor},
+		configDefaults:      map[string]string{"forbid-localhost-profiles": "true"},
+		wantErr:             true,
+	}, {
+		desc:                   "Localhost profiles allowed by default",
+		minor:                  "30",
+		stepSecurityContext:    &corev1.SecurityContext{AppArmorProfile: localhostAppArmor},
+		wantPodSecurityContext: nil,
+		wantStepSecurityContexts: map[string]*corev1.SecurityContext{
+			"step-a": nil,
+			"step-b": {AppArmorProfile: localhostAppArmor},
+		},
+		wantAnnotations: map[string]string{},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			store := config.NewStore(logtesting.TestLogger(t))
+			store.OnConfigChanged(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: config.GetDefaultsConfigName(), Namespace: system.Namespace()},
+				Data:       c.configDefaults,
+			})
+			kubeclient := fakek8s.NewSimpleClientset(
+				&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}},
+			)
+			fakeDisc, _ := kubeclient.Discovery().(*fakediscovery.FakeDiscovery)
+			fakeDisc.FakedServerVersion = &version.Info{Major: "1", Minor: c.minor}
+
+			ts := v1.TaskSpec{
+				Steps: []v1.Step{{
+					Name:    "a",
+					Image:   "image",
+					Command: []string{"cmd"}, // avoid entrypoint lookup.
+				}, {
+					Name:            "b",
+					Image:           "image",
+					Command:         []string{"cmd"},
+					SecurityContext: c.stepSecurityContext,
+				}},
+			}
+			tr := &v1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "taskrun-name", Namespace: "default"},
+				Spec: v1.TaskRunSpec{
+					TaskSpec:    &ts,
+					PodTemplate: c.podTemplate,
+				},
+			}
+			builder := Builder{
+				Images:          images,
+				KubeClient:      kubeclient,
+				EntrypointCache: fakeCache{},
+			}
+			got, err := builder.Build(store.ToContext(t.Context()), tr, ts)
+			if c.wantErr {
+				if err == nil {
+					t.Fatal("expected the pod build to fail")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("builder.Build: %v", err)
+			}
+
+			if d := cmp.Diff(c.wantPodSecurityContext, got.Spec.SecurityContext); d != "" {
+				t.Errorf("pod security context %s", diff.PrintWantGot(d))
+			}
+			gotStepSecurityContexts := map[string]*corev1.SecurityContext{}
+			for _, container := range got.Spec.Containers {
+				gotStepSecurityContexts[container.Name] = container.SecurityContext
+			}
+			if d := cmp.Diff(c.wantStepSecurityContexts, gotStepSecurityContexts); d != "" {
+				t.Errorf("step security contexts %s", diff.PrintWantGot(d))
+			}
+			gotAnnotations := map[string]string{}
+			for k, v := range got.Annotations {
+				if strings.HasPrefix(k, corev1.DeprecatedAppArmorBetaContainerAnnotationKeyPrefix) {
+					gotAnnotations[k] = v
+				}
+			}
+			if d := cmp.Diff(c.wantAnnotations, gotAnnotations); d != "" {
+				t.Errorf("AppArmor annotations %s", diff.PrintWantGot(d))
+			}
+			// The security contexts of the steps of the TaskRun are unchanged.
+			if d := cmp.Diff(c.stepSecurityContext, ts.Steps[1].SecurityContext); d != "" {
+				t.Errorf("step security context of the TaskRun %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestIsAppArmorFieldSupport(t *testing.T) {
+	for _, tc := range []struct {
+		serverVersion *version.Info
+		want          bool
+	}{
+		{serverVersion: &version.Info{Major: "1", Minor: "29"}, want: false},
+		{serverVersion: &version.Info{Major: "1", Minor: "30"}, want: true},
+		{serverVersion: &version.Info{Major: "1", Minor: "31+"}, want: true},
+		{serverVersion: &version.Info{Major: "2", Minor: "0"}, want: true},
+	} {
+		if got := IsAppArmorFieldSupport(tc.serverVersion); got != tc.want {
+			t.Errorf("IsAppArmorFieldSupport(%s.%s) = %v, want %v", tc.serverVersion.Major, tc.serverVersion.Minor, got, tc.want)
+		}
+	}
+}
+
+func TestPodBuild_TerminationGracePeriod(t *testing.T) {
+	gracePeriod := int64(120)
+	for _, tc := range []struct {
+		desc            string
+		featureFlags    map[string]string
+		podTemplate     *pod.Template
+		wantGracePeriod *int64
+		wantArg         bool
+	}{{
+		desc: "not set",
+	}, {
+		desc:            "pod deleted on cancellation",
+		podTemplate:     &pod.Template{TerminationGracePeriodSeconds: &gracePeriod},
+		wantGracePeriod: &gracePeriod,
+	}, {
+		desc:            "pod kept on cancellation",
+		featureFlags:    map[string]string{"keep-pod-on-cancel": "true"},
+		podTemplate:     &pod.Template{TerminationGracePeriodSeconds: &gracePeriod},
+		wantGracePeriod: &gracePeriod,
+		wantArg:         true,
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			featureFlags := map[string]string{"disable-creds-init": "true"}
+			maps.Copy(featureFlags, tc.featureFlags)
+			store := config.NewStore(logtesting.TestLogger(t))
+			store.OnConfigChanged(
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
+					Data:       featureFlags,
+				},
+			)
+			kubeclient := fakek8s.NewSimpleClientset(
+				&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}},
+			)
+			builder := Builder{
+				Images:          images,
+				KubeClient:      kubeclient,
+				EntrypointCache: fakeCache{},
+			}
+			tr := &v1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "taskrun", Namespace: "default"},
+				Spec:       v1.TaskRunSpec{PodTemplate: tc.podTemplate},
+			}
+			ts := v1.TaskSpec{
+				Steps: []v1.Step{{
+					Name:    "migrate",
+					Image:   "image",
+					Command: []string{"cmd"}, // avoid entrypoint lookup.
+				}},
+			}
+
+			got, err := builder.Build(store.ToContext(t.Context()), tr, ts)
+			if err != nil {
+				t.Fatalf("builder.Build: %v", err)
+			}
+			if d := cmp.Diff(tc.wantGracePeriod, got.Spec.TerminationGracePeriodSeconds); d != "" {
+				t.Errorf("unexpected termination grace period %s", diff.PrintWantGot(d))
+			}
+			args := strings.Join(got.Spec.Containers[0].Args, " ")
+			if hasArg := strings.Contains(args, "-termination_grace_period 2m0s"); hasArg != tc.wantArg {
+				t.Errorf("expected the termination grace period entrypoint arg: %t, got args %q", tc.wantArg, args)
+			}
+		})
+	}
+}
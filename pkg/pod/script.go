@@ -23,6 +23,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/names"
 	corev1 "k8s.io/api/core/v1"
@@ -33,6 +34,9 @@ const (
 	debugScriptsVolumeName = "tekton-internal-debug-scripts"
 	debugInfoVolumeName    = "tekton-internal-debug-info"
 	scriptsDir             = "/tekton/scripts"
+	scriptFromVolumePrefix = "tekton-internal-script-from-"
+	scriptFromDir          = "/tekton/scripts-from"
+	scriptFromKey          = "script"
 	debugScriptsDir        = "/tekton/debug/scripts"
 	defaultScriptPreamble  = "#!/bin/sh\nset -e\n"
 	debugInfoDir           = "/tekton/debug/info"
@@ -144,6 +148,9 @@ func convertListOfSteps(steps []v1.Step, initContainer *corev1.Container, debugC
 		if s.Script != "" {
 			placeScriptInContainer(s.Script, getScriptFile(scriptsDir, fmt.Sprintf("%s-%d", namePrefix, i)), c, initContainer)
 		}
+		if s.ScriptFrom != nil && s.ScriptFrom.ConfigMap != nil {
+			placeConfigMapScriptInContainer(i, getScriptFile(scriptsDir, fmt.Sprintf("%s-%d", namePrefix, i)), c, initContainer)
+		}
 		containers = append(containers, *c)
 	}
 	placeDebugScriptInContainers(containers, initContainer, debugConfig)
@@ -204,6 +211,92 @@ cat > ${scriptfile} << '%s'
 	c.VolumeMounts = append(c.VolumeMounts, scriptsVolumeMount)
 }
 
+// placeConfigMapScriptInContainer modifies initContainer so that it copies the script of the ConfigMap
+// mounted for the step at index i into scriptFile, then it modifies the container so that it can execute
+// the scriptFile in runtime, as it does for inline scripts.
+func placeConfigMapScriptInContainer(i int, scriptFile string, c *corev1.Container, initContainer *corev1.Container) {
+	mountPath := filepath.Join(scriptFromDir, strconv.Itoa(i))
+	initContainer.VolumeMounts = append(initContainer.VolumeMounts, corev1.VolumeMount{
+		Name:      scriptFromVolumePrefix + strconv.Itoa(i),
+		MountPath: mountPath,
+		ReadOnly:  true,
+	})
+	initContainer.Args[1] += fmt.Sprintf(`scriptfile="%s"
+scriptsource="%s"
+touch ${scriptfile} && chmod +x ${scriptfile}
+IFS= read -r firstline < ${scriptsource} || true
+case "${firstline}" in
+'#!'*) : > ${scriptfile} ;;
+*) printf '#!/bin/sh\nset -e\n' > ${scriptfile} ;;
+esac
+cat ${scriptsource} >> ${scriptfile}
+`, scriptFile, filepath.Join(mountPath, scriptFromKey))
+
+	// As for inline scripts, a previous merge with stepTemplate may have
+	// populated Command, so we overwrite it.
+	c.Command = []string{scriptFile}
+	c.VolumeMounts = append(c.VolumeMounts, scriptsVolumeMount)
+}
+
+// validateConfigMapScripts returns an error if a step takes its script from a ConfigMap
+// while the Pod runs on Windows, as the place-scripts init container copies these scripts
+// with a POSIX shell.
+func validateConfigMapScripts(steps []v1.Step, sidecars []v1.Sidecar, windows bool) error {
+	if !windows && !checkWindowsRequirement(steps, sidecars) {
+		return nil
+	}
+	for _, s := range steps {
+		if s.ScriptFrom != nil && s.ScriptFrom.ConfigMap != nil {
+			return fmt.Errorf("step %q: scriptFrom.configMap is not supported on Windows", s.Name)
+		}
+	}
+	return nil
+}
+
+// scriptFromConfigMapVolumes returns the volumes of the ConfigMaps holding the scripts of the steps,
+// which are mounted in the init container placing the scripts.
+func scriptFromConfigMapVolumes(steps []v1.Step) []corev1.Volume {
+	var volumes []corev1.Volume
+	for i, s := range steps {
+		if s.ScriptFrom == nil || s.ScriptFrom.ConfigMap == nil {
+			continue
+		}
+		volumes = append(volumes, corev1.Volume{
+			Name: scriptFromVolumePrefix + strconv.Itoa(i),
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: s.ScriptFrom.ConfigMap.LocalObjectReference,
+					Items:                []corev1.KeyToPath{{Key: s.ScriptFrom.ConfigMap.Key, Path: scriptFromKey}},
+					Optional:             s.ScriptFrom.ConfigMap.Optional,
+				},
+			},
+		})
+	}
+	return volumes
+}
+
+// placeWorkspaceScriptsInContainers sets the command of the step containers whose script is a file of a
+// workspace to the path of that file. The entrypoint reads and executes the file when the step starts,
+// so that the file may be written by the previous steps.
+func placeWorkspaceScriptsInContainers(steps []v1.Step, workspaces []v1.WorkspaceDeclaration, containers []corev1.Container) {
+	for i, s := range steps {
+		if s.ScriptFrom == nil || s.ScriptFrom.Workspace == nil || i >= len(containers) {
+			continue
+		}
+		containers[i].Command = []string{workspaceScriptPath(s.ScriptFrom.Workspace, workspaces)}
+	}
+}
+
+// workspaceScriptPath returns the path of the file of a workspace holding the script of a step.
+func workspaceScriptPath(selector *v1.WorkspaceFileSelector, workspaces []v1.WorkspaceDeclaration) string {
+	for _, w := range workspaces {
+		if w.Name == selector.Name {
+			return filepath.Join(w.GetMountPath(), selector.Path)
+		}
+	}
+	return filepath.Join(pipeline.WorkspaceDir, selector.Name, selector.Path)
+}
+
 // encodeScript encodes a script field into a format that avoids kubernetes' built-in processing of container args,
 // which can mangle dollar signs and unexpectedly replace variable references in the user's script.
 func encodeScript(script string) string {
@@ -271,6 +364,9 @@ func hasScripts(steps []v1.Step, sidecars []v1.Sidecar, debugConfig *v1.TaskRunD
 		if s.Script != "" {
 			return true
 		}
+		if s.ScriptFrom != nil && s.ScriptFrom.ConfigMap != nil {
+			return true
+		}
 	}
 	for _, s := range sidecars {
 		if s.Script != "" {
@@ -194,6 +194,184 @@ _EOF_
 	}
 }
 
+func TestConvertScripts_ScriptFromConfigMap(t *testing.T) {
+	names.TestingSeed()
+	optional := true
+	steps := []v1.Step{{
+		Image: "step-1",
+		ScriptFrom: &v1.StepScriptSource{ConfigMap: &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "scripts"},
+			Key:                  "build.sh",
+		}},
+		Args: []string{"my", "args"},
+	}, {
+		// The script of a workspace is placed by the entrypoint.
+		Image: "step-2",
+		ScriptFrom: &v1.StepScriptSource{Workspace: &v1.WorkspaceFileSelector{
+			Name: "source",
+			Path: "build.sh",
+		}},
+	}, {
+		Image: "step-3",
+		ScriptFrom: &v1.StepScriptSource{ConfigMap: &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "more-scripts"},
+			Key:                  "test.sh",
+			Optional:             &optional,
+		}},
+	}}
+	gotInit, gotSteps, _ := convertScripts(images.ShellImage, images.ShellImageWin, steps, nil, nil, SecurityContextConfig{})
+	wantInit := &corev1.Container{
+		Name:    "place-scripts",
+		Image:   images.ShellImage,
+		Command: []string{"sh"},
+		Args: []string{"-c", `scriptfile="/tekton/scripts/script-0-9l9zj"
+scriptsource="/tekton/scripts-from/0/script"
+touch ${scriptfile} && chmod +x ${scriptfile}
+IFS= read -r firstline < ${scriptsource} || true
+case "${firstline}" in
+'#!'*) : > ${scriptfile} ;;
+*) printf '#!/bin/sh\nset -e\n' > ${scriptfile} ;;
+esac
+cat ${scriptsource} >> ${scriptfile}
+scriptfile="/tekton/scripts/script-2-mz4c7"
+scriptsource="/tekton/scripts-from/2/script"
+touch ${scriptfile} && chmod +x ${scriptfile}
+IFS= read -r firstline < ${scriptsource} || true
+case "${firstline}" in
+'#!'*) : > ${scriptfile} ;;
+*) printf '#!/bin/sh\nset -e\n' > ${scriptfile} ;;
+esac
+cat ${scriptsource} >> ${scriptfile}
+`},
+		VolumeMounts: []corev1.VolumeMount{writeScriptsVolumeMount, binMount, {
+			Name:      "tekton-internal-script-from-0",
+			MountPath: "/tekton/scripts-from/0",
+			ReadOnly:  true,
+		}, {
+			Name:      "tekton-internal-script-from-2",
+			MountPath: "/tekton/scripts-from/2",
+			ReadOnly:  true,
+		}},
+	}
+	want := []corev1.Container{{
+		Image:        "step-1",
+		Command:      []string{"/tekton/scripts/script-0-9l9zj"},
+		Args:         []string{"my", "args"},
+		VolumeMounts: []corev1.VolumeMount{scriptsVolumeMount},
+	}, {
+		Image: "step-2",
+	}, {
+		Image:        "step-3",
+		Command:      []string{"/tekton/scripts/script-2-mz4c7"},
+		VolumeMounts: []corev1.VolumeMount{scriptsVolumeMount},
+	}}
+	if d := cmp.Diff(wantInit, gotInit); d != "" {
+		t.Errorf("Init Container Diff %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(want, gotSteps); d != "" {
+		t.Errorf("Containers Diff %s", diff.PrintWantGot(d))
+	}
+
+	wantVolumes := []corev1.Volume{{
+		Name: "tekton-internal-script-from-0",
+		VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "scripts"},
+			Items:                []corev1.KeyToPath{{Key: "build.sh", Path: "script"}},
+		}},
+	}, {
+		Name: "tekton-internal-script-from-2",
+		VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "more-scripts"},
+			Items:                []corev1.KeyToPath{{Key: "test.sh", Path: "script"}},
+			Optional:             &optional,
+		}},
+	}}
+	if d := cmp.Diff(wantVolumes, scriptFromConfigMapVolumes(steps)); d != "" {
+		t.Errorf("Volumes Diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestValidateConfigMapScripts(t *testing.T) {
+	configMapStep := v1.Step{
+		Name:  "build",
+		Image: "step-1",
+		ScriptFrom: &v1.StepScriptSource{ConfigMap: &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "scripts"},
+			Key:                  "build.sh",
+		}},
+	}
+	windowsStep := v1.Step{Name: "windows", Image: "step-2", Script: "#!win pwsh.exe\nWrite-Host hello"}
+	for _, tc := range []struct {
+		name    string
+		steps   []v1.Step
+		windows bool
+		wantErr bool
+	}{{
+		name:  "configMap script on linux",
+		steps: []v1.Step{configMapStep},
+	}, {
+		name:    "configMap script with a windows nodeSelector",
+		steps:   []v1.Step{configMapStep},
+		windows: true,
+		wantErr: true,
+	}, {
+		name:    "configMap script with a windows script",
+		steps:   []v1.Step{windowsStep, configMapStep},
+		wantErr: true,
+	}, {
+		name:    "windows without configMap script",
+		steps:   []v1.Step{windowsStep},
+		windows: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateConfigMapScripts(tc.steps, nil, tc.windows)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("validateConfigMapScripts() = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestPlaceWorkspaceScriptsInContainers(t *testing.T) {
+	steps := []v1.Step{{
+		Image: "step-1",
+		ScriptFrom: &v1.StepScriptSource{Workspace: &v1.WorkspaceFileSelector{
+			Name: "source",
+			Path: "hack/build.sh",
+		}},
+	}, {
+		Image:   "step-2",
+		Command: []string{"cmd"},
+	}, {
+		Image: "step-3",
+		ScriptFrom: &v1.StepScriptSource{Workspace: &v1.WorkspaceFileSelector{
+			Name: "scripts",
+			Path: "test.sh",
+		}},
+	}}
+	workspaces := []v1.WorkspaceDeclaration{{
+		Name: "source",
+	}, {
+		Name:      "scripts",
+		MountPath: "/custom/scripts",
+	}}
+	containers := []corev1.Container{{Image: "step-1"}, {Image: "step-2", Command: []string{"cmd"}}, {Image: "step-3"}}
+	placeWorkspaceScriptsInContainers(steps, workspaces, containers)
+	want := []corev1.Container{{
+		Image:   "step-1",
+		Command: []string{"/workspace/source/hack/build.sh"},
+	}, {
+		Image:   "step-2",
+		Command: []string{"cmd"},
+	}, {
+		Image:   "step-3",
+		Command: []string{"/custom/scripts/test.sh"},
+	}}
+	if d := cmp.Diff(want, containers); d != "" {
+		t.Errorf("Containers Diff %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestConvertScripts_Sidecars(t *testing.T) {
 	names.TestingSeed()
 
@@ -0,0 +1,150 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/version"
+)
+
+// AppArmorFieldK8sMinorVersionCheck is the minor version of Kubernetes from
+// which the appArmorProfile fields of the security contexts are supported.
+// Older versions only support the AppArmor annotations.
+const AppArmorFieldK8sMinorVersionCheck = 30
+
+// podTemplateSecurityProfiles returns the security context of the pod with
+// the seccomp and AppArmor profiles of the pod template, unless it already
+// sets its own. The containers setting their own profiles override them.
+func podTemplateSecurityProfiles(securityContext *corev1.PodSecurityContext, podTemplate pod.Template) *corev1.PodSecurityContext {
+	if podTemplate.SeccompProfile == nil && podTemplate.AppArmorProfile == nil {
+		return securityContext
+	}
+	if securityContext == nil {
+		securityContext = &corev1.PodSecurityContext{}
+	} else {
+		securityContext = securityContext.DeepCopy()
+	}
+	if securityContext.SeccompProfile == nil {
+		securityContext.SeccompProfile = podTemplate.SeccompProfile
+	}
+	if securityContext.AppArmorProfile == nil {
+		securityContext.AppArmorProfile = podTemplate.AppArmorProfile
+	}
+	return securityContext
+}
+
+// validateLocalhostProfiles returns an error if the pod or one of its
+// containers uses a Localhost seccomp or AppArmor profile, for when the
+// forbid-localhost-profiles config is set.
+func validateLocalhostProfiles(securityContext *corev1.PodSecurityContext, containers ...[]corev1.Container) error {
+	if securityContext != nil && (isLocalhostSeccompProfile(securityContext.SeccompProfile) || isLocalhostAppArmorProfile(securityContext.AppArmorProfile)) {
+		return fmt.Errorf("the pod uses a Localhost security profile, which is forbidden by the forbid-localhost-profiles config")
+	}
+	for _, cs := range containers {
+		for _, c := range cs {
+			if c.SecurityContext != nil && (isLocalhostSeccompProfile(c.SecurityContext.SeccompProfile) || isLocalhostAppArmorProfile(c.SecurityContext.AppArmorProfile)) {
+				return fmt.Errorf("container %q uses a Localhost security profile, which is forbidden by the forbid-localhost-profiles config", c.Name)
+			}
+		}
+	}
+	return nil
+}
+
+func isLocalhostSeccompProfile(profile *corev1.SeccompProfile) bool {
+	return profile != nil && profile.Type == corev1.SeccompProfileTypeLocalhost
+}
+
+func isLocalhostAppArmorProfile(profile *corev1.AppArmorProfile) bool {
+	return profile != nil && profile.Type == corev1.AppArmorProfileTypeLocalhost
+}
+
+// usesAppArmorProfiles returns true if the pod or one of its containers sets
+// an AppArmor profile.
+func usesAppArmorProfiles(securityContext *corev1.PodSecurityContext, containers ...[]corev1.Container) bool {
+	if securityContext != nil && securityContext.AppArmorProfile != nil {
+		return true
+	}
+	for _, cs := range containers {
+		for _, c := range cs {
+			if c.SecurityContext != nil && c.SecurityContext.AppArmorProfile != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsAppArmorFieldSupport returns true if the Kubernetes version supports the
+// appArmorProfile fields of the security contexts (1.30+).
+// See https://kubernetes.io/docs/tutorials/security/apparmor/ for more info.
+func IsAppArmorFieldSupport(serverVersion *version.Info) bool {
+	minor := strings.TrimSuffix(serverVersion.Minor, "+") // Remove '+' if present
+	majorInt, _ := strconv.Atoi(serverVersion.Major)
+	minorInt, _ := strconv.Atoi(minor)
+	return (majorInt == 1 && minorInt >= AppArmorFieldK8sMinorVersionCheck) || majorInt > 1
+}
+
+// appArmorProfilesToAnnotations moves the AppArmor profiles of the pod and of
+// its containers to the AppArmor annotations of the containers, for the
+// Kubernetes versions which don't support the appArmorProfile fields. The
+// profile of a container overrides the one of the pod. It returns the
+// security context of the pod without its AppArmor profile.
+func appArmorProfilesToAnnotations(securityContext *corev1.PodSecurityContext, annotations map[string]string, containers ...[]corev1.Container) *corev1.PodSecurityContext {
+	var podProfile *corev1.AppArmorProfile
+	if securityContext != nil && securityContext.AppArmorProfile != nil {
+		podProfile = securityContext.AppArmorProfile
+		securityContext = securityContext.DeepCopy()
+		securityContext.AppArmorProfile = nil
+	}
+	for _, cs := range containers {
+		for i := range cs {
+			profile := podProfile
+			if sc := cs[i].SecurityContext; sc != nil && sc.AppArmorProfile != nil {
+				profile = sc.AppArmorProfile
+				// The security context may be shared with the spec of the TaskRun.
+				cs[i].SecurityContext = sc.DeepCopy()
+				cs[i].SecurityContext.AppArmorProfile = nil
+			}
+			if profile != nil {
+				annotations[corev1.DeprecatedAppArmorBetaContainerAnnotationKeyPrefix+cs[i].Name] = appArmorAnnotationValue(profile)
+			}
+		}
+	}
+	return securityContext
+}
+
+// appArmorAnnotationValue returns the value of the AppArmor annotation of a
+// container equivalent to the given profile.
+func appArmorAnnotationValue(profile *corev1.AppArmorProfile) string {
+	switch profile.Type {
+	case corev1.AppArmorProfileTypeUnconfined:
+		return corev1.DeprecatedAppArmorBetaProfileNameUnconfined
+	case corev1.AppArmorProfileTypeLocalhost:
+		var name string
+		if profile.LocalhostProfile != nil {
+			name = *profile.LocalhostProfile
+		}
+		return corev1.DeprecatedAppArmorBetaProfileNamePrefix + name
+	default:
+		return corev1.DeprecatedAppArmorBetaProfileRuntimeDefault
+	}
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// sidecarDependenciesAnnotation lists the containers of the sidecars of a
+	// pod which some steps depend on, as a comma separated list.
+	sidecarDependenciesAnnotation = "tekton.dev/sidecar-dependencies"
+
+	// sidecarReadyAnnotationPrefix prefixes the container name of a sidecar
+	// some steps depend on in the annotation set to "READY" once the
+	// container is ready, which is projected into the steps via the Downward
+	// API.
+	sidecarReadyAnnotationPrefix = "sidecar-ready.tekton.dev/"
+
+	// sidecarStopAfterStepsAnnotation records the sidecars of a pod which are
+	// stopped once some steps are finished, as a comma separated list of
+	// <sidecar container>=<step container>[;<step container>...] pairs.
+	sidecarStopAfterStepsAnnotation = "tekton.dev/sidecar-stop-after-steps"
+
+	downwardMountSidecarReadyDir = "sidecar-ready"
+)
+
+// usesSidecarDependencies returns true if a step declares the sidecars it
+// depends on, in which case the steps only wait for the sidecars they depend
+// on rather than the first step waiting for all the sidecars of the Task.
+func usesSidecarDependencies(steps []v1.Step) bool {
+	for _, s := range steps {
+		if len(s.DependsOnSidecars) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// dependedSidecarContainers returns the sorted names of the containers of the
+// sidecars which the steps depend on.
+func dependedSidecarContainers(steps []v1.Step) []string {
+	var containers []string
+	for _, s := range steps {
+		for _, name := range s.DependsOnSidecars {
+			if container := sidecarContainerName(name); !slices.Contains(containers, container) {
+				containers = append(containers, container)
+			}
+		}
+	}
+	sort.Strings(containers)
+	return containers
+}
+
+// sidecarReadyFiles returns the comma separated paths of the files a step
+// waits for to have content before starting, one per sidecar it depends on.
+func sidecarReadyFiles(sidecars []string) string {
+	files := make([]string, 0, len(sidecars))
+	for _, name := range sidecars {
+		files = append(files, filepath.Join(downwardMountPoint, downwardMountSidecarReadyDir, sidecarContainerName(name)))
+	}
+	return strings.Join(files, ",")
+}
+
+// sidecarReadyVolumeItems returns the items of the Downward volume projecting
+// the ready annotations of the containers of the sidecars.
+func sidecarReadyVolumeItems(containers []string) []corev1.DownwardAPIVolumeFile {
+	items := make([]corev1.DownwardAPIVolumeFile, 0, len(containers))
+	for _, container := range containers {
+		items = append(items, corev1.DownwardAPIVolumeFile{
+			Path: filepath.Join(downwardMountSidecarReadyDir, container),
+			FieldRef: &corev1.ObjectFieldSelector{
+				FieldPath: fmt.Sprintf("metadata.annotations['%s%s']", sidecarReadyAnnotationPrefix, container),
+			},
+		})
+	}
+	return items
+}
+
+// formatSidecarStopAfterSteps returns the value of the sidecar stop after
+// steps annotation for the sidecars of the Task, or "" if none of them is
+// stopped after steps. The steps are given by the containers they run in.
+func formatSidecarStopAfterSteps(sidecars []v1.Sidecar, steps []v1.Step, stepContainers []corev1.Container) string {
+	stepContainerNames := map[string]string{}
+	for i, s := range steps {
+		if i < len(stepContainers) {
+			stepContainerNames[s.Name] = stepContainers[i].Name
+		}
+	}
+	var pairs []string
+	for _, s := range sidecars {
+		if len(s.StopAfterSteps) == 0 {
+			continue
+		}
+		containers := make([]string, 0, len(s.StopAfterSteps))
+		for _, step := range s.StopAfterSteps {
+			containers = append(containers, stepContainerNames[step])
+		}
+		pairs = append(pairs, sidecarContainerName(s.Name)+"="+strings.Join(containers, ";"))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// sidecarStopAfterSteps returns the containers of the sidecars of the pod
+// which are stopped after some steps, mapped to the containers of these
+// steps.
+func sidecarStopAfterSteps(pod *corev1.Pod) map[string][]string {
+	stopAfterSteps := map[string][]string{}
+	for _, pair := range strings.Split(pod.Annotations[sidecarStopAfterStepsAnnotation], ",") {
+		if sidecar, steps, ok := strings.Cut(pair, "="); ok {
+			stopAfterSteps[sidecar] = strings.Split(steps, ";")
+		}
+	}
+	return stopAfterSteps
+}
+
+// UpdateSidecarsReady updates the Pod's annotations to signal the steps
+// depending on sidecars that these sidecars are ready, by projecting the
+// ready annotation of each sidecar via the Downward API. As with the ready
+// annotation of the pod, a sidecar which terminated is considered ready.
+func UpdateSidecarsReady(ctx context.Context, kubeclient kubernetes.Interface, pod *corev1.Pod) error {
+	dependencies := pod.Annotations[sidecarDependenciesAnnotation]
+	if dependencies == "" || pod.Status.Phase != corev1.PodRunning {
+		return nil
+	}
+	containers := strings.Split(dependencies, ",")
+	annotations := map[string]interface{}{}
+	for _, s := range append(slices.Clip(pod.Status.InitContainerStatuses), pod.Status.ContainerStatuses...) {
+		key := sidecarReadyAnnotationPrefix + s.Name
+		if !slices.Contains(containers, s.Name) || pod.Annotations[key] == readyAnnotationValue {
+			continue
+		}
+		if (s.State.Running != nil && s.Ready) || s.State.Terminated != nil {
+			annotations[key] = readyAnnotationValue
+		}
+	}
+	// Don't PATCH if no sidecar became ready.
+	if len(annotations) == 0 {
+		return nil
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{"annotations": annotations},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = kubeclient.CoreV1().Pods(pod.Namespace).Patch(ctx, pod.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// StopSidecarsAfterSteps updates the containers of the sidecars which are
+// stopped after some steps to a nop image, which exits successfully
+// immediately, once all these steps are finished. It returns the Pod as is if
+// no sidecar is stopped.
+func StopSidecarsAfterSteps(ctx context.Context, nopImage string, kubeclient kubernetes.Interface, pod *corev1.Pod) (*corev1.Pod, error) {
+	stopAfterSteps := sidecarStopAfterSteps(pod)
+	if len(stopAfterSteps) == 0 || pod.Status.Phase != corev1.PodRunning {
+		return pod, nil
+	}
+	terminated := map[string]bool{}
+	for _, s := range pod.Status.ContainerStatuses {
+		terminated[s.Name] = s.State.Terminated != nil
+	}
+
+	newPod := pod.DeepCopy()
+	updated := false
+	for i, c := range newPod.Spec.Containers {
+		steps, ok := stopAfterSteps[c.Name]
+		if !ok || c.Image == nopImage {
+			continue
+		}
+		if !slices.ContainsFunc(steps, func(step string) bool { return !terminated[step] }) {
+			newPod.Spec.Containers[i].Image = nopImage
+			updated = true
+		}
+	}
+	if !updated {
+		return pod, nil
+	}
+	newPod, err := kubeclient.CoreV1().Pods(newPod.Namespace).Update(ctx, newPod, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error stopping sidecars of Pod %q after their steps: %w", pod.Name, err)
+	}
+	return newPod, nil
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"slices"
+	"strings"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// sidecarFailTaskRunOnTerminationAnnotation lists the containers of the
+// sidecars of a pod whose termination while the steps are running fails the
+// TaskRun, as a comma separated list.
+const sidecarFailTaskRunOnTerminationAnnotation = "tekton.dev/sidecar-fail-taskrun-on-termination"
+
+// formatSidecarsFailTaskRunOnTermination returns the value of the sidecar
+// fail TaskRun on termination annotation for the sidecars of the Task, or ""
+// if none of them fails the TaskRun on termination.
+func formatSidecarsFailTaskRunOnTermination(sidecars []v1.Sidecar) string {
+	var containers []string
+	for _, s := range sidecars {
+		if s.FailTaskRunOnTermination {
+			containers = append(containers, sidecarContainerName(s.Name))
+		}
+	}
+	return strings.Join(containers, ",")
+}
+
+// SidecarFailure is the unexpected termination of a sidecar failing the
+// TaskRun.
+type SidecarFailure struct {
+	// Name is the name of the sidecar.
+	Name string
+	// ExitCode is the exit code of the container of the sidecar.
+	ExitCode int32
+}
+
+// FailedSidecar returns the sidecar with failTaskRunOnTermination of the pod
+// which terminated while some steps aren't finished, or nil if there's none.
+// The sidecars stopped by Tekton, whose image is replaced by the nop image,
+// terminate normally.
+func FailedSidecar(pod *corev1.Pod, nopImage string) *SidecarFailure {
+	failing := pod.Annotations[sidecarFailTaskRunOnTerminationAnnotation]
+	if failing == "" || pod.Status.Phase != corev1.PodRunning {
+		return nil
+	}
+	containers := strings.Split(failing, ",")
+
+	stopped := map[string]bool{}
+	for _, c := range append(slices.Clip(pod.Spec.InitContainers), pod.Spec.Containers...) {
+		stopped[c.Name] = c.Image == nopImage
+	}
+	stepsRunning := false
+	for _, s := range pod.Status.ContainerStatuses {
+		if IsPodContainerStep(pod, s.Name) && s.State.Terminated == nil {
+			stepsRunning = true
+		}
+	}
+	if !stepsRunning {
+		return nil
+	}
+
+	for _, s := range append(slices.Clip(pod.Status.InitContainerStatuses), pod.Status.ContainerStatuses...) {
+		if !slices.Contains(containers, s.Name) || stopped[s.Name] {
+			continue
+		}
+		// A sidecar running as a restartable init container is restarted
+		// after it terminates.
+		terminated := s.State.Terminated
+		if terminated == nil {
+			terminated = s.LastTerminationState.Terminated
+		}
+		if terminated != nil {
+			return &SidecarFailure{Name: TrimSidecarPrefix(s.Name), ExitCode: terminated.ExitCode}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFailedSidecar(t *testing.T) {
+	running := corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+	crashed := corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 137}}
+	completed := corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}}
+
+	for _, tc := range []struct {
+		name        string
+		annotations map[string]string
+		phase       corev1.PodPhase
+		containers  []corev1.Container
+		statuses    []corev1.ContainerStatus
+		initStatus  *corev1.ContainerStatus
+		want        *SidecarFailure
+	}{{
+		name:        "sidecar crashed while a step is running",
+		annotations: map[string]string{sidecarFailTaskRunOnTerminationAnnotation: "sidecar-dind"},
+		statuses: []corev1.ContainerStatus{
+			{Name: "step-build", State: running},
+			{Name: "sidecar-dind", State: crashed},
+		},
+		want: &SidecarFailure{Name: "dind", ExitCode: 137},
+	}, {
+		name:        "sidecar crashed while a step with a custom container name is running",
+		annotations: map[string]string{sidecarFailTaskRunOnTerminationAnnotation: "sidecar-dind", stepContainerNamesAnnotation: "build=step-build"},
+		statuses: []corev1.ContainerStatus{
+			{Name: "build", State: running},
+			{Name: "sidecar-dind", State: crashed},
+		},
+		want: &SidecarFailure{Name: "dind", ExitCode: 137},
+	}, {
+		name:        "restartable init container sidecar crashed",
+		annotations: map[string]string{sidecarFailTaskRunOnTerminationAnnotation: "sidecar-dind"},
+		statuses: []corev1.ContainerStatus{
+			{Name: "step-build", State: running},
+		},
+		initStatus: &corev1.ContainerStatus{Name: "sidecar-dind", State: running, LastTerminationState: crashed, RestartCount: 1},
+		want:       &SidecarFailure{Name: "dind", ExitCode: 137},
+	}, {
+		name: "sidecar without failTaskRunOnTermination crashed",
+		statuses: []corev1.ContainerStatus{
+			{Name: "step-build", State: running},
+			{Name: "sidecar-dind", State: crashed},
+		},
+	}, {
+		name:        "other sidecar crashed",
+		annotations: map[string]string{sidecarFailTaskRunOnTerminationAnnotation: "sidecar-dind"},
+		statuses: []corev1.ContainerStatus{
+			{Name: "step-build", State: running},
+			{Name: "sidecar-dind", State: running},
+			{Name: "sidecar-cache", State: crashed},
+		},
+	}, {
+		name:        "sidecar stopped with the nop image",
+		annotations: map[string]string{sidecarFailTaskRunOnTerminationAnnotation: "sidecar-dind"},
+		containers:  []corev1.Container{{Name: "sidecar-dind", Image: images.NopImage}},
+		statuses: []corev1.ContainerStatus{
+			{Name: "step-build", State: running},
+			{Name: "sidecar-dind", State: completed},
+		},
+	}, {
+		name:        "sidecar terminated after the steps",
+		annotations: map[string]string{sidecarFailTaskRunOnTerminationAnnotation: "sidecar-dind"},
+		statuses: []corev1.ContainerStatus{
+			{Name: "step-build", State: completed},
+			{Name: "sidecar-dind", State: crashed},
+		},
+	}, {
+		name:        "pod not running",
+		annotations: map[string]string{sidecarFailTaskRunOnTerminationAnnotation: "sidecar-dind"},
+		phase:       corev1.PodFailed,
+		statuses: []corev1.ContainerStatus{
+			{Name: "step-build", State: running},
+			{Name: "sidecar-dind", State: crashed},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			phase := tc.phase
+			if phase == "" {
+				phase = corev1.PodRunning
+			}
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations},
+				Spec:       corev1.PodSpec{Containers: tc.containers},
+				Status:     corev1.PodStatus{Phase: phase, ContainerStatuses: tc.statuses},
+			}
+			if tc.initStatus != nil {
+				pod.Status.InitContainerStatuses = []corev1.ContainerStatus{*tc.initStatus}
+			}
+			if d := cmp.Diff(tc.want, FailedSidecar(pod, images.NopImage)); d != "" {
+				t.Errorf("FailedSidecar() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
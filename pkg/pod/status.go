@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -77,6 +78,14 @@ const (
 	// ReasonPodAdmissionFailed indicates that the TaskRun's pod failed to pass admission validation
 	ReasonPodAdmissionFailed = "PodAdmissionFailed"
 
+	// ReasonStepImagePinningFailed indicates that the TaskRun failed to create a pod because
+	// the image of a step couldn't be pinned to its digest
+	ReasonStepImagePinningFailed = "StepImagePinningFailed"
+
+	// ReasonInvalidWorkingDir indicates that the TaskRun failed to create a pod because
+	// the workingDir of a step can't be used once its variables are substituted
+	ReasonInvalidWorkingDir = "InvalidWorkingDir"
+
 	// ReasonPending indicates that the pod is in corev1.Pending, and the reason is not
 	// ReasonExceededNodeResources or isPodHitConfigError
 	ReasonPodPending = "Pending"
@@ -92,7 +101,7 @@ const (
 
 // SidecarsReady returns true if all of the Pod's sidecars are Ready or
 // Terminated.
-func SidecarsReady(podStatus corev1.PodStatus) bool {
+func SidecarsReady(podStatus corev1.PodStatus, excluded ...string) bool {
 	if podStatus.Phase != corev1.PodRunning {
 		return false
 	}
@@ -101,7 +110,7 @@ func SidecarsReady(podStatus corev1.PodStatus) bool {
 		// An injected sidecar might not have the "sidecar-" prefix, so
 		// we can't just look for that prefix, we need to look at any
 		// non-step container.
-		if IsContainerStep(s.Name) {
+		if IsContainerStep(s.Name) || slices.Contains(excluded, s.Name) {
 			continue
 		}
 		if s.State.Running != nil && s.Ready {
@@ -139,12 +148,27 @@ func MakeTaskRunStatus(ctx context.Context, logger *zap.SugaredLogger, tr v1.Tas
 	}
 
 	trs.PodName = pod.Name
+	if !pod.CreationTimestamp.IsZero() {
+		trs.PodCreationTime = pod.CreationTimestamp.DeepCopy()
+	}
+	if pod.Status.StartTime != nil {
+		trs.PodStartTime = pod.Status.StartTime.DeepCopy()
+	}
 	trs.Sidecars = []v1.SidecarState{}
 
+	// The statuses of the steps with a custom container name are handled
+	// under the default name of their container, and their container is
+	// restored once their state is set.
+	customContainerNames := stepContainerNames(pod)
+	defaultContainerNames := make(map[string]string, len(customContainerNames))
 	var stepStatuses []corev1.ContainerStatus
 	var sidecarStatuses []corev1.ContainerStatus
 	for _, s := range pod.Status.ContainerStatuses {
-		if IsContainerStep(s.Name) {
+		if name, ok := customContainerNames[s.Name]; ok {
+			defaultContainerNames[name] = s.Name
+			s.Name = name
+			stepStatuses = append(stepStatuses, s)
+		} else if IsContainerStep(s.Name) {
 			stepStatuses = append(stepStatuses, s)
 		} else if IsContainerSidecar(s.Name) {
 			sidecarStatuses = append(sidecarStatuses, s)
@@ -157,6 +181,12 @@ func MakeTaskRunStatus(ctx context.Context, logger *zap.SugaredLogger, tr v1.Tas
 	}
 
 	err := setTaskRunStatusBasedOnStepStatus(ctx, logger, stepStatuses, &tr, pod.Status.Phase, kubeclient, ts)
+	for i, ss := range trs.Steps {
+		if container, ok := defaultContainerNames[ss.Container]; ok {
+			trs.Steps[i].Container = container
+		}
+	}
+	setStepsMemoryLimit(trs, pod)
 	setTaskRunStatusBasedOnSidecarStatus(sidecarStatuses, trs)
 
 	trs.Results = removeDuplicateResults(trs.Results)
@@ -302,6 +332,8 @@ func setTaskRunStatusBasedOnStepStatus(ctx context.Context, logger *zap.SugaredL
 
 		// Parse termination messages
 		terminationReason := ""
+		var oomKilledState *v1.StepOOMKilled
+		var hermeticViolations *int32
 		if state.Terminated != nil && len(state.Terminated.Message) != 0 {
 			msg := state.Terminated.Message
 
@@ -356,24 +388,42 @@ func setTaskRunStatusBasedOnStepStatus(ctx context.Context, logger *zap.SugaredL
 					state.Terminated.ExitCode = *exitCode
 				}
 
+				memoryPeak, err := extractMemoryPeakFromResults(results)
+				if err != nil {
+					logger.Errorf("error extracting the memory peak of step %q in taskrun %q: %v", s.Name, tr.Name, err)
+					errs = append(errs, err)
+				}
+
+				hermeticViolations, err = extractHermeticViolationsFromResults(results)
+				if err != nil {
+					logger.Errorf("error extracting the hermetic violations of step %q in taskrun %q: %v", s.Name, tr.Name, err)
+					errs = append(errs, err)
+				}
+
 				terminationFromResults := extractTerminationReasonFromResults(results)
 				terminationReason = getTerminationReason(state.Terminated.Reason, terminationFromResults, exitCode)
+				if memoryPeak != nil && state.Terminated.Reason == oomKilled {
+					oomKilledState = &v1.StepOOMKilled{MemoryPeak: memoryPeak}
+				}
 			}
 		}
 		stepState := v1.StepState{
-			ContainerState:    *state.DeepCopy(),
-			Name:              TrimStepPrefix(s.Name),
-			Container:         s.Name,
-			ImageID:           s.ImageID,
-			Results:           taskRunStepResults,
-			TerminationReason: terminationReason,
-			Inputs:            sas.Inputs,
-			Outputs:           sas.Outputs,
+			ContainerState:     *state.DeepCopy(),
+			Name:               TrimStepPrefix(s.Name),
+			Container:          s.Name,
+			ImageID:            s.ImageID,
+			Results:            taskRunStepResults,
+			TerminationReason:  terminationReason,
+			Inputs:             sas.Inputs,
+			Outputs:            sas.Outputs,
+			OOMKilled:          oomKilledState,
+			HermeticViolations: hermeticViolations,
 		}
 		foundStep := false
 		for i, ss := range trs.Steps {
 			if ss.Name == stepState.Name {
 				stepState.Provenance = ss.Provenance
+				stepState.ImageSignature = ss.ImageSignature
 				trs.Steps[i] = stepState
 				foundStep = true
 				break
@@ -600,6 +650,8 @@ func updateCompletedTaskRunStatus(logger *zap.SugaredLogger, trs *v1.TaskRunStat
 		msg := getFailureMessage(logger, pod)
 		if onError == v1.PipelineTaskContinue {
 			markStatusFailure(trs, v1.TaskRunReasonFailureIgnored.String(), msg)
+		} else if oomMsg, ok := getOOMKilledStepMessage(pod); ok {
+			markStatusFailure(trs, v1.TaskRunReasonStepOOMKilled.String(), oomMsg)
 		} else {
 			markStatusFailure(trs, v1.TaskRunReasonFailed.String(), msg)
 		}
@@ -647,7 +699,7 @@ func isPodCompleted(pod *corev1.Pod) bool {
 		return true
 	}
 	for _, s := range pod.Status.ContainerStatuses {
-		if IsContainerStep(s.Name) {
+		if IsPodContainerStep(pod, s.Name) {
 			if s.State.Terminated != nil {
 				if isOOMKilled(s) {
 					return true
@@ -665,7 +717,7 @@ func DidTaskRunFail(pod *corev1.Pod) bool {
 	}
 
 	for _, s := range pod.Status.ContainerStatuses {
-		if IsContainerStep(s.Name) {
+		if IsPodContainerStep(pod, s.Name) {
 			if s.State.Terminated != nil {
 				if s.State.Terminated.ExitCode != 0 || isOOMKilled(s) {
 					return true
@@ -701,7 +753,9 @@ func isMatchingAnyFilter(name string, filters []containerNameFilter) bool {
 
 // areContainersCompleted returns true if all related containers in the pod are completed.
 func areContainersCompleted(ctx context.Context, pod *corev1.Pod) bool {
-	nameFilters := []containerNameFilter{IsContainerStep}
+	nameFilters := []containerNameFilter{func(name string) bool {
+		return IsPodContainerStep(pod, name)
+	}}
 	if config.FromContextOrDefaults(ctx).FeatureFlags.ResultExtractionMethod == config.ResultExtractionMethodSidecarLogs {
 		// If we are using sidecar logs to extract results, we need to wait for the sidecar to complete.
 		// Avoid failing to obtain the final result from the sidecar because the sidecar is not yet complete.
@@ -755,7 +809,7 @@ func getFailureMessage(logger *zap.SugaredLogger, pod *corev1.Pod) string {
 	}
 
 	for _, s := range pod.Status.ContainerStatuses {
-		if IsContainerStep(s.Name) {
+		if IsPodContainerStep(pod, s.Name) {
 			if s.State.Terminated != nil {
 				if isOOMKilled(s) {
 					return oomKilled
@@ -30,8 +30,10 @@ import (
 	"github.com/tektoncd/pipeline/pkg/result"
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	fakek8s "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/logging"
@@ -482,6 +484,9 @@ func TestMakeTaskRunStatus_StepResults(t *testing.T) {
 
 			// Common traits, set for test case brevity.
 			c.want.PodName = "pod"
+			if !c.pod.CreationTimestamp.IsZero() {
+				c.want.PodCreationTime = &c.pod.CreationTimestamp
+			}
 
 			ensureTimeNotNil := cmp.Comparer(func(x, y *metav1.Time) bool {
 				if x == nil {
@@ -655,6 +660,9 @@ func TestMakeTaskRunStatus_StepProvenance(t *testing.T) {
 
 			// Common traits, set for test case brevity.
 			c.want.PodName = "pod"
+			if !c.pod.CreationTimestamp.IsZero() {
+				c.want.PodCreationTime = &c.pod.CreationTimestamp
+			}
 
 			ensureTimeNotNil := cmp.Comparer(func(x, y *metav1.Time) bool {
 				if x == nil {
@@ -797,6 +805,9 @@ func TestMakeTaskRunStatus_StepArtifacts(t *testing.T) {
 
 			// Common traits, set for test case brevity.
 			c.want.PodName = "pod"
+			if !c.pod.CreationTimestamp.IsZero() {
+				c.want.PodCreationTime = &c.pod.CreationTimestamp
+			}
 
 			ensureTimeNotNil := cmp.Comparer(func(x, y *metav1.Time) bool {
 				if x == nil {
@@ -992,6 +1003,39 @@ func TestMakeTaskRunStatus(t *testing.T) {
 				CompletionTime: &metav1.Time{Time: time.Now()},
 			},
 		},
+	}, {
+		desc: "hermetic step reports its violations",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodFailed,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "step-fetch",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						ExitCode: 6,
+						Message:  `[{"key":"hermeticViolations","value":"2","type":3}]`,
+					},
+				},
+			}},
+		},
+		want: v1.TaskRunStatus{
+			Status: statusFailure(v1.TaskRunReasonFailed.String(), "\"step-fetch\" exited with code 6"),
+			TaskRunStatusFields: v1.TaskRunStatusFields{
+				Steps: []v1.StepState{{
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ExitCode: 6,
+						},
+					},
+					Name:               "fetch",
+					Container:          "step-fetch",
+					HermeticViolations: ptr.To(int32(2)),
+				}},
+				Sidecars:  []v1.SidecarState{},
+				Artifacts: &v1.Artifacts{},
+				// We don't actually care about the time, just that it's not nil
+				CompletionTime: &metav1.Time{Time: time.Now()},
+			},
+		},
 	}, {
 		desc: "failure-message",
 		podStatus: corev1.PodStatus{
@@ -1023,7 +1067,7 @@ func TestMakeTaskRunStatus(t *testing.T) {
 			}},
 		},
 		want: v1.TaskRunStatus{
-			Status: statusFailure(v1.TaskRunReasonFailed.String(), "OOMKilled"),
+			Status: statusFailure(v1.TaskRunReasonStepOOMKilled.String(), "step \"step-push\" was OOMKilled"),
 			TaskRunStatusFields: v1.TaskRunStatusFields{
 				Steps: []v1.StepState{{
 					ContainerState: corev1.ContainerState{
@@ -1466,7 +1510,7 @@ func TestMakeTaskRunStatus(t *testing.T) {
 			}},
 		},
 		want: v1.TaskRunStatus{
-			Status: statusFailure(v1.TaskRunReasonFailed.String(), "\"step-one\" exited with code 137: OOMKilled"),
+			Status: statusFailure(v1.TaskRunReasonStepOOMKilled.String(), "step \"one\" was OOMKilled"),
 			TaskRunStatusFields: v1.TaskRunStatusFields{
 				Steps: []v1.StepState{{
 					ContainerState: corev1.ContainerState{
@@ -1488,6 +1532,110 @@ func TestMakeTaskRunStatus(t *testing.T) {
 				CompletionTime: &metav1.Time{Time: time.Now()},
 			},
 		},
+	}, {
+		desc: "oomkilled step reports its memory limit and peak",
+		pod: corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pod",
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Name: "step-build",
+					Resources: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+					},
+				}},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodFailed,
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name: "step-build",
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							Reason:   oomKilled,
+							ExitCode: 137,
+							Message:  `[{"key":"MemoryPeak","value":"268435456","type":3}]`,
+						},
+					},
+				}},
+			},
+		},
+		want: v1.TaskRunStatus{
+			Status: statusFailure(v1.TaskRunReasonStepOOMKilled.String(), "step \"build\" was OOMKilled by its memory limit of 256Mi"),
+			TaskRunStatusFields: v1.TaskRunStatusFields{
+				Steps: []v1.StepState{{
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							Reason:   oomKilled,
+							ExitCode: 137,
+						},
+					},
+					Name:              "build",
+					Container:         "step-build",
+					TerminationReason: oomKilled,
+					OOMKilled: &v1.StepOOMKilled{
+						MemoryLimit: resource.NewQuantity(256*1024*1024, resource.BinarySI),
+						MemoryPeak:  resource.NewQuantity(256*1024*1024, resource.BinarySI),
+					},
+				}},
+				Sidecars:  []v1.SidecarState{},
+				Artifacts: &v1.Artifacts{},
+				// We don't actually care about the time, just that it's not nil
+				CompletionTime: &metav1.Time{Time: time.Now()},
+			},
+		},
+	}, {
+		desc: "oomkilled step reports the memory limit of its container status",
+		pod: corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pod",
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Name: "step-build",
+					Resources: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+					},
+				}},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodFailed,
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name: "step-build",
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							Reason:   oomKilled,
+							ExitCode: 137,
+						},
+					},
+					Resources: &corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("128Mi")},
+					},
+				}},
+			},
+		},
+		want: v1.TaskRunStatus{
+			Status: statusFailure(v1.TaskRunReasonStepOOMKilled.String(), "step \"build\" was OOMKilled by its memory limit of 128Mi"),
+			TaskRunStatusFields: v1.TaskRunStatusFields{
+				Steps: []v1.StepState{{
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							Reason:   oomKilled,
+							ExitCode: 137,
+						},
+					},
+					Name:      "build",
+					Container: "step-build",
+					OOMKilled: &v1.StepOOMKilled{
+						MemoryLimit: resource.NewQuantity(128*1024*1024, resource.BinarySI),
+					},
+				}},
+				Sidecars:  []v1.SidecarState{},
+				Artifacts: &v1.Artifacts{},
+				// We don't actually care about the time, just that it's not nil
+				CompletionTime: &metav1.Time{Time: time.Now()},
+			},
+		},
 	}, {
 		desc: "the failed task show task results",
 		podStatus: corev1.PodStatus{
@@ -1940,6 +2088,59 @@ func TestMakeTaskRunStatus(t *testing.T) {
 				CompletionTime: &metav1.Time{Time: time.Now()},
 			},
 		},
+	}, {
+		desc: "step with custom container name",
+		pod: corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "pod",
+				Namespace:   "foo",
+				Annotations: map[string]string{stepContainerNamesAnnotation: "builder=step-build"},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Name: "builder",
+				}, {
+					Name: "step-test",
+				}},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name: "builder",
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ExitCode: 0,
+						},
+					},
+				}, {
+					Name: "step-test",
+					State: corev1.ContainerState{
+						Running: &corev1.ContainerStateRunning{},
+					},
+				}},
+			},
+		},
+		want: v1.TaskRunStatus{
+			Status: statusRunning(),
+			TaskRunStatusFields: v1.TaskRunStatusFields{
+				Steps: []v1.StepState{{
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ExitCode: 0,
+						},
+					},
+					Name:      "build",
+					Container: "builder",
+				}, {
+					ContainerState: corev1.ContainerState{
+						Running: &corev1.ContainerStateRunning{},
+					},
+					Name:      "test",
+					Container: "step-test",
+				}},
+				Sidecars: []v1.SidecarState{},
+			},
+		},
 	}} {
 		t.Run(c.desc, func(t *testing.T) {
 			now := metav1.Now()
@@ -1975,6 +2176,9 @@ func TestMakeTaskRunStatus(t *testing.T) {
 
 			// Common traits, set for test case brevity.
 			c.want.PodName = "pod"
+			if !c.pod.CreationTimestamp.IsZero() {
+				c.want.PodCreationTime = &c.pod.CreationTimestamp
+			}
 			c.want.StartTime = &metav1.Time{Time: startTime}
 			for i := range c.want.TaskRunStatusFields.Steps {
 				if c.want.TaskRunStatusFields.Steps[i].Results == nil {
@@ -2193,6 +2397,46 @@ func TestMakeTaskRunStatus_SidecarNotCompleted(t *testing.T) {
 	}
 }
 
+func TestMakeTaskRunStatus_PodTimes(t *testing.T) {
+	created := metav1.NewTime(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC))
+	started := metav1.NewTime(created.Add(30 * time.Second))
+	for _, c := range []struct {
+		desc          string
+		podStatus     corev1.PodStatus
+		wantStartTime *metav1.Time
+	}{{
+		desc:      "pod not started",
+		podStatus: corev1.PodStatus{Phase: corev1.PodPending},
+	}, {
+		desc:          "pod started",
+		podStatus:     corev1.PodStatus{Phase: corev1.PodRunning, StartTime: &started},
+		wantStartTime: &started,
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "pod",
+					Namespace:         "foo",
+					CreationTimestamp: created,
+				},
+				Status: c.podStatus,
+			}
+			tr := v1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "task-run", Namespace: "foo"}}
+			logger, _ := logging.NewLogger("", "status")
+			got, err := MakeTaskRunStatus(t.Context(), logger, tr, pod, fakek8s.NewSimpleClientset(), &v1.TaskSpec{})
+			if err != nil {
+				t.Fatalf("MakeTaskRunStatus: %v", err)
+			}
+			if d := cmp.Diff(&created, got.PodCreationTime); d != "" {
+				t.Errorf("Unexpected pod creation time %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(c.wantStartTime, got.PodStartTime); d != "" {
+				t.Errorf("Unexpected pod start time %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestMakeTaskRunStatusAlpha(t *testing.T) {
 	for _, c := range []struct {
 		desc      string
@@ -2463,6 +2707,9 @@ func TestMakeTaskRunStatusAlpha(t *testing.T) {
 
 			// Common traits, set for test case brevity.
 			c.want.PodName = "pod"
+			if !c.pod.CreationTimestamp.IsZero() {
+				c.want.PodCreationTime = &c.pod.CreationTimestamp
+			}
 			c.want.StartTime = &metav1.Time{Time: startTime}
 			for i := range c.want.TaskRunStatusFields.Steps {
 				if c.want.TaskRunStatusFields.Steps[i].Results == nil {
@@ -2610,6 +2857,7 @@ func TestSidecarsReady(t *testing.T) {
 	for _, c := range []struct {
 		desc     string
 		statuses []corev1.ContainerStatus
+		excluded []string
 		want     bool
 	}{{
 		desc: "no sidecars",
@@ -2683,12 +2931,34 @@ func TestSidecarsReady(t *testing.T) {
 			{Name: "step-ignore-me"},
 		},
 		want: false,
+	}, {
+		desc: "excluded sidecar not running",
+		statuses: []corev1.ContainerStatus{
+			{Name: "step-ignore-me"},
+			{
+				Name:  "sidecar-ready",
+				Ready: true,
+				State: corev1.ContainerState{
+					Running: &corev1.ContainerStateRunning{
+						StartedAt: metav1.NewTime(time.Now()),
+					},
+				},
+			},
+			{
+				Name: "sidecar-log-forwarder",
+				State: corev1.ContainerState{
+					Waiting: &corev1.ContainerStateWaiting{},
+				},
+			},
+		},
+		excluded: []string{"sidecar-log-forwarder"},
+		want:     true,
 	}} {
 		t.Run(c.desc, func(t *testing.T) {
 			got := SidecarsReady(corev1.PodStatus{
 				Phase:             corev1.PodRunning,
 				ContainerStatuses: c.statuses,
-			})
+			}, c.excluded...)
 			if got != c.want {
 				t.Errorf("SidecarsReady got %t, want %t", got, c.want)
 			}
@@ -0,0 +1,79 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// stepContainerNamesAnnotation records the steps of a pod which set a custom
+// container name, as a comma separated list of <container name>=<default
+// container name> pairs, the default container name being the "step-"
+// prefixed name the container would have had otherwise.
+const stepContainerNamesAnnotation = "tekton.dev/step-container-names"
+
+// formatStepContainerNames returns the value of the step container names
+// annotation for the given custom container names, by default container name.
+func formatStepContainerNames(containerNames map[string]string) string {
+	pairs := make([]string, 0, len(containerNames))
+	for custom, name := range containerNames {
+		pairs = append(pairs, custom+"="+name)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// stepContainerNames returns the custom container names of the steps of the
+// pod, mapped to their default container name.
+func stepContainerNames(pod *corev1.Pod) map[string]string {
+	containerNames := map[string]string{}
+	for _, pair := range strings.Split(pod.Annotations[stepContainerNamesAnnotation], ",") {
+		if custom, name, ok := strings.Cut(pair, "="); ok {
+			containerNames[custom] = name
+		}
+	}
+	return containerNames
+}
+
+// IsPodContainerStep returns true if the container with the given name is
+// the container of a step of the pod, be it named after the step or not.
+func IsPodContainerStep(pod *corev1.Pod, name string) bool {
+	if _, ok := stepContainerNames(pod)[name]; ok {
+		return true
+	}
+	return IsContainerStep(name)
+}
+
+// validateContainerNames returns an error if several containers of the pod
+// have the same name, which can happen when steps set a custom container name.
+func validateContainerNames(containerNames map[string]string, initContainers, containers []corev1.Container) error {
+	seen := map[string]bool{}
+	for _, c := range append(append([]corev1.Container{}, initContainers...), containers...) {
+		if seen[c.Name] {
+			if name, ok := containerNames[c.Name]; ok {
+				return fmt.Errorf("container name %q of step %q is used by another container of the pod", c.Name, TrimStepPrefix(name))
+			}
+			return fmt.Errorf("container name %q is used by several containers of the pod", c.Name)
+		}
+		seen[c.Name] = true
+	}
+	return nil
+}
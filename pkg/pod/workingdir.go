@@ -0,0 +1,80 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// ErrInvalidWorkingDir is returned when the workingDir of a step can't be
+// used once its variables are substituted.
+var ErrInvalidWorkingDir = errors.New("invalid workingDir of a step")
+
+var (
+	// workspacePathVarRegex matches the path variables of the workspaces,
+	// capturing the name of the workspace.
+	workspacePathVarRegex = regexp.MustCompile(`\$\(workspaces\.([^.)]+)\.path\)`)
+	// redundantSlashesRegex matches the consecutive slashes of a path.
+	redundantSlashesRegex = regexp.MustCompile(`/{2,}`)
+)
+
+// normalizeWorkingDirs validates the substituted workingDirs of the steps and
+// normalizes their redundant slashes. The path of an optional workspace which
+// isn't bound by the TaskRun is left unsubstituted in the workingDirs, which
+// would otherwise be relative to the root of the container, so a workingDir
+// using it is rejected.
+func normalizeWorkingDirs(steps []v1.Step, workspaces []v1.WorkspaceDeclaration, bindings []v1.WorkspaceBinding) ([]v1.Step, error) {
+	bound := sets.New[string]()
+	for _, binding := range bindings {
+		bound.Insert(binding.Name)
+	}
+	unbound := sets.New[string]()
+	for _, declaration := range workspaces {
+		if declaration.Optional && !bound.Has(declaration.Name) {
+			unbound.Insert(declaration.Name)
+		}
+	}
+
+	for i, s := range steps {
+		if s.WorkingDir == "" {
+			continue
+		}
+		for _, match := range workspacePathVarRegex.FindAllStringSubmatch(s.WorkingDir, -1) {
+			if unbound.Has(match[1]) {
+				return nil, fmt.Errorf("%w: the workingDir %q of step %q uses the path of the optional workspace %q which is not bound by the TaskRun", ErrInvalidWorkingDir, s.WorkingDir, TrimStepPrefix(StepName(s.Name, i)), match[1])
+			}
+		}
+		steps[i].WorkingDir = normalizeWorkingDir(s.WorkingDir)
+	}
+	return steps, nil
+}
+
+// normalizeWorkingDir collapses the consecutive slashes of the workingDir and
+// removes its trailing slash.
+func normalizeWorkingDir(workingDir string) string {
+	workingDir = redundantSlashesRegex.ReplaceAllString(workingDir, "/")
+	if len(workingDir) > 1 {
+		workingDir = strings.TrimSuffix(workingDir, "/")
+	}
+	return workingDir
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+func TestPodBuild_WorkingDirs(t *testing.T) {
+	workspaces := []v1.WorkspaceDeclaration{{
+		Name: "source",
+	}, {
+		Name:     "cache",
+		Optional: true,
+	}}
+	sourceBinding := v1.WorkspaceBinding{Name: "source", EmptyDir: &corev1.EmptyDirVolumeSource{}}
+
+	for _, tc := range []struct {
+		desc            string
+		steps           []v1.Step
+		bindings        []v1.WorkspaceBinding
+		wantWorkingDirs []string
+		wantInitArgs    []string
+	}{{
+		desc: "bound workspace",
+		steps: []v1.Step{{
+			Name:       "build",
+			Image:      "image",
+			Command:    []string{"make"},
+			WorkingDir: "/workspace/source//app/",
+		}},
+		bindings:        []v1.WorkspaceBinding{sourceBinding},
+		wantWorkingDirs: []string{"/workspace/source/app"},
+		wantInitArgs:    []string{"/workspace/source/app"},
+	}, {
+		desc: "bound optional workspace",
+		steps: []v1.Step{{
+			Name:       "build",
+			Image:      "image",
+			Command:    []string{"make"},
+			WorkingDir: "/workspace/cache/",
+		}, {
+			Name:       "test",
+			Image:      "image",
+			Command:    []string{"make"},
+			WorkingDir: "/workspace/source/app",
+		}},
+		bindings:        []v1.WorkspaceBinding{sourceBinding, {Name: "cache", EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+		wantWorkingDirs: []string{"/workspace/cache", "/workspace/source/app"},
+		wantInitArgs:    []string{"/workspace/cache", "/workspace/source/app"},
+	}, {
+		desc: "nested path",
+		steps: []v1.Step{{
+			Name:       "build",
+			Image:      "image",
+			Command:    []string{"make"},
+			WorkingDir: "/workspace/source///a//b/c/",
+		}, {
+			Name:    "no-working-dir",
+			Image:   "image",
+			Command: []string{"make"},
+		}},
+		bindings:        []v1.WorkspaceBinding{sourceBinding},
+		wantWorkingDirs: []string{"/workspace/source/a/b/c", ""},
+		wantInitArgs:    []string{"/workspace/source/a/b/c"},
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			gotPod, err := buildWorkingDirsPod(t, v1.TaskSpec{Steps: tc.steps, Workspaces: workspaces}, tc.bindings)
+			if err != nil {
+				t.Fatalf("builder.Build: %v", err)
+			}
+
+			var gotWorkingDirs []string
+			for _, c := range gotPod.Spec.Containers {
+				gotWorkingDirs = append(gotWorkingDirs, c.WorkingDir)
+			}
+			if d := cmp.Diff(tc.wantWorkingDirs, gotWorkingDirs); d != "" {
+				t.Errorf("unexpected workingDirs of the steps: %s", diff.PrintWantGot(d))
+			}
+
+			var gotInitArgs []string
+			for _, c := range gotPod.Spec.InitContainers {
+				if c.Name == "working-dir-initializer" {
+					gotInitArgs = c.Args
+				}
+			}
+			if d := cmp.Diff(tc.wantInitArgs, gotInitArgs); d != "" {
+				t.Errorf("unexpected args of the working-dir-initializer: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPodBuild_WorkingDirUnboundOptionalWorkspace(t *testing.T) {
+	ts := v1.TaskSpec{
+		Workspaces: []v1.WorkspaceDeclaration{{
+			Name:     "cache",
+			Optional: true,
+		}},
+		Steps: []v1.Step{{
+			Name:    "prepare",
+			Image:   "image",
+			Command: []string{"make"},
+		}, {
+			Name:       "build",
+			Image:      "image",
+			Command:    []string{"make"},
+			WorkingDir: "$(workspaces.cache.path)/app",
+		}},
+	}
+
+	_, err := buildWorkingDirsPod(t, ts, nil)
+	if !errors.Is(err, ErrInvalidWorkingDir) {
+		t.Fatalf("expected error %v, got %v", ErrInvalidWorkingDir, err)
+	}
+	want := `invalid workingDir of a step: the workingDir "$(workspaces.cache.path)/app" of step "build" uses the path of the optional workspace "cache" which is not bound by the TaskRun`
+	if err.Error() != want {
+		t.Errorf("expected error %q, got %q", want, err.Error())
+	}
+}
+
+func buildWorkingDirsPod(t *testing.T, ts v1.TaskSpec, bindings []v1.WorkspaceBinding) (*corev1.Pod, error) {
+	t.Helper()
+	store := config.NewStore(logtesting.TestLogger(t))
+	kubeclient := fakek8s.NewSimpleClientset(
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}},
+	)
+	builder := Builder{
+		Images:     images,
+		KubeClient: kubeclient,
+	}
+	tr := &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo-taskrun",
+			Namespace: "default",
+		},
+		Spec: v1.TaskRunSpec{Workspaces: bindings},
+	}
+	return builder.Build(store.ToContext(t.Context()), tr, ts)
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package debuglog records the decisions the reconcilers make about a single
+// TaskRun or PipelineRun, for the runs which opt in with the debug annotation.
+package debuglog
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/trustedresources"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+const (
+	// AnnotationKey is the annotation enabling the decision trail of a run
+	// when set to "true". The trail is written to the status annotation with
+	// the same key.
+	AnnotationKey = pipeline.GroupName + "/debug-log"
+
+	// MaxSize is the maximum size in bytes of the decision trail status
+	// annotation, the oldest decisions are dropped to fit in it.
+	MaxSize = 8 * 1024
+)
+
+// Stages of the reconciliation the decisions are made at.
+const (
+	StageResolution   = "resolution"
+	StageVerification = "verification"
+	StageScheduling   = "scheduling"
+	StagePod          = "pod"
+)
+
+// Decision is a decision made by a reconciler about a run.
+type Decision struct {
+	// Stage is the stage of the reconciliation the decision was made at.
+	Stage string `json:"stage"`
+	// Subject is what the decision is about, e.g. a PipelineTask.
+	Subject string `json:"subject,omitempty"`
+	// Decision is what was decided.
+	Decision string `json:"decision"`
+	// Reason is why it was decided.
+	Reason string `json:"reason,omitempty"`
+}
+
+// Trail is the list of the decisions made during a reconcile of a run. A nil
+// Trail, returned for the runs without the debug annotation, records nothing.
+type Trail struct {
+	decisions []Decision
+}
+
+// trailJSON is the content of the decision trail status annotation.
+type trailJSON struct {
+	Decisions []Decision `json:"decisions"`
+	// Dropped is the number of the oldest decisions dropped to fit in MaxSize.
+	Dropped int `json:"dropped,omitempty"`
+}
+
+// New returns a Trail for the run if it has the debug annotation, nil
+// otherwise.
+func New(obj metav1.Object) *Trail {
+	if obj.GetAnnotations()[AnnotationKey] != "true" {
+		return nil
+	}
+	return &Trail{}
+}
+
+// Enabled returns true if the decisions are recorded, so that the callers can
+// skip computing the decisions which aren't cheap.
+func (t *Trail) Enabled() bool {
+	return t != nil
+}
+
+// Record records a decision.
+func (t *Trail) Record(stage, subject, decision, reason string) {
+	if t == nil {
+		return
+	}
+	t.decisions = append(t.decisions, Decision{Stage: stage, Subject: subject, Decision: decision, Reason: reason})
+}
+
+// RecordVerification records the result of the verification of the resource.
+func (t *Trail) RecordVerification(subject string, result *trustedresources.VerificationResult) {
+	if t == nil || result == nil {
+		return
+	}
+	reason := ""
+	if result.Err != nil {
+		reason = result.Err.Error()
+	}
+	switch result.VerificationResultType {
+	case trustedresources.VerificationError:
+		t.Record(StageVerification, subject, "failed", reason)
+	case trustedresources.VerificationWarn:
+		t.Record(StageVerification, subject, "warned", reason)
+	case trustedresources.VerificationPass:
+		t.Record(StageVerification, subject, "passed", reason)
+	case trustedresources.VerificationSkip:
+		t.Record(StageVerification, subject, "skipped", "no matching VerificationPolicy")
+	}
+}
+
+// Decisions returns the recorded decisions.
+func (t *Trail) Decisions() []Decision {
+	if t == nil {
+		return nil
+	}
+	return t.decisions
+}
+
+// Write writes the trail to the status annotation. The trail of the previous
+// reconcile is kept when no decision was recorded, e.g. once the run is done,
+// and removed when the debug annotation was removed.
+func (t *Trail) Write(status *duckv1.Status) {
+	if t == nil {
+		delete(status.Annotations, AnnotationKey)
+		return
+	}
+	if len(t.decisions) == 0 {
+		return
+	}
+	trail := trailJSON{Decisions: t.decisions}
+	b, err := json.Marshal(trail)
+	for err == nil && len(b) > MaxSize && len(trail.Decisions) > 0 {
+		// Drop the oldest decisions making up for the excess size.
+		excess, n := len(b)-MaxSize, 0
+		for ; n < len(trail.Decisions) && excess > 0; n++ {
+			d, _ := json.Marshal(trail.Decisions[n])
+			excess -= len(d) + 1
+		}
+		trail.Decisions = trail.Decisions[n:]
+		trail.Dropped += n
+		b, err = json.Marshal(trail)
+	}
+	if err != nil {
+		return
+	}
+	if status.Annotations == nil {
+		status.Annotations = map[string]string{}
+	}
+	status.Annotations[AnnotationKey] = string(b)
+}
+
+// trailKey is used to associate the Trail inside the context.Context.
+type trailKey struct{}
+
+// ToContext adds the trail to the context.
+func ToContext(ctx context.Context, t *Trail) context.Context {
+	if t == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, trailKey{}, t)
+}
+
+// FromContext returns the trail of the context, nil if there is none.
+func FromContext(ctx context.Context) *Trail {
+	t, _ := ctx.Value(trailKey{}).(*Trail)
+	return t
+}
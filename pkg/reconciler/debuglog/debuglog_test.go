@@ -0,0 +1,117 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debuglog_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/reconciler/debuglog"
+	"github.com/tektoncd/pipeline/pkg/trustedresources"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+type trail struct {
+	Decisions []debuglog.Decision `json:"decisions"`
+	Dropped   int                 `json:"dropped"`
+}
+
+func parseTrail(t *testing.T, status duckv1.Status) trail {
+	t.Helper()
+	var tr trail
+	if err := json.Unmarshal([]byte(status.Annotations[debuglog.AnnotationKey]), &tr); err != nil {
+		t.Fatalf("couldn't parse the decision trail: %v", err)
+	}
+	return tr
+}
+
+func TestTrailDisabled(t *testing.T) {
+	tr := debuglog.New(&metav1.ObjectMeta{Annotations: map[string]string{debuglog.AnnotationKey: "false"}})
+	if tr.Enabled() {
+		t.Fatal("expected the trail to be disabled without the debug annotation")
+	}
+	tr.Record(debuglog.StageResolution, "", "resolved", "")
+	tr.RecordVerification("task", &trustedresources.VerificationResult{VerificationResultType: trustedresources.VerificationPass})
+	if d := tr.Decisions(); len(d) != 0 {
+		t.Errorf("expected no decision recorded, got %v", d)
+	}
+	if debuglog.FromContext(debuglog.ToContext(context.Background(), tr)) != nil {
+		t.Error("expected no trail in the context")
+	}
+
+	status := duckv1.Status{Annotations: map[string]string{debuglog.AnnotationKey: "{}", "other": "value"}}
+	tr.Write(&status)
+	if d := cmp.Diff(map[string]string{"other": "value"}, status.Annotations); d != "" {
+		t.Errorf("expected the decision trail to be removed %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestTrailWrite(t *testing.T) {
+	tr := debuglog.New(&metav1.ObjectMeta{Annotations: map[string]string{debuglog.AnnotationKey: "true"}})
+	if debuglog.FromContext(debuglog.ToContext(context.Background(), tr)) != tr {
+		t.Fatal("expected the trail in the context")
+	}
+
+	status := duckv1.Status{Annotations: map[string]string{debuglog.AnnotationKey: `{"decisions":[]}`}}
+	tr.Write(&status)
+	if got := status.Annotations[debuglog.AnnotationKey]; got != `{"decisions":[]}` {
+		t.Errorf("expected the previous decision trail to be kept when no decision was recorded, got %q", got)
+	}
+
+	tr.Record(debuglog.StageResolution, "task", "resolved", "")
+	tr.RecordVerification("task", &trustedresources.VerificationResult{VerificationResultType: trustedresources.VerificationWarn, Err: errors.New("no matching policies")})
+	tr.Write(&status)
+	want := trail{Decisions: []debuglog.Decision{{
+		Stage:    debuglog.StageResolution,
+		Subject:  "task",
+		Decision: "resolved",
+	}, {
+		Stage:    debuglog.StageVerification,
+		Subject:  "task",
+		Decision: "warned",
+		Reason:   "no matching policies",
+	}}}
+	if d := cmp.Diff(want, parseTrail(t, status)); d != "" {
+		t.Errorf("unexpected decision trail %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestTrailWriteMaxSize(t *testing.T) {
+	tr := debuglog.New(&metav1.ObjectMeta{Annotations: map[string]string{debuglog.AnnotationKey: "true"}})
+	for i := range 500 {
+		tr.Record(debuglog.StageScheduling, fmt.Sprintf("task-%d", i), "not scheduled", "waiting for tasks.producer")
+	}
+	status := duckv1.Status{}
+	tr.Write(&status)
+
+	if size := len(status.Annotations[debuglog.AnnotationKey]); size > debuglog.MaxSize {
+		t.Errorf("expected the decision trail to fit in %d bytes, got %d", debuglog.MaxSize, size)
+	}
+	got := parseTrail(t, status)
+	if got.Dropped == 0 || got.Dropped+len(got.Decisions) != 500 {
+		t.Fatalf("expected the oldest decisions to be dropped, got %d dropped and %d kept", got.Dropped, len(got.Decisions))
+	}
+	if last := got.Decisions[len(got.Decisions)-1].Subject; last != "task-499" {
+		t.Errorf("expected the latest decisions to be kept, got %s last", last)
+	}
+}
@@ -0,0 +1,38 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FormatDuration returns the time elapsed between start and end rounded to
+// seconds, as reported in the status of the runs, or "" if either time isn't
+// set yet, e.g. until a run has completed, so that the reported duration
+// doesn't change on every reconcile.
+func FormatDuration(start, end *metav1.Time) string {
+	if start.IsZero() || end.IsZero() {
+		return ""
+	}
+	d := end.Sub(start.Time).Round(time.Second)
+	if d < 0 {
+		d = 0
+	}
+	return d.String()
+}
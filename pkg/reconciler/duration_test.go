@@ -0,0 +1,62 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler_test
+
+import (
+	"testing"
+	"time"
+
+	reconciler "github.com/tektoncd/pipeline/pkg/reconciler"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFormatDuration(t *testing.T) {
+	start := metav1.NewTime(time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC))
+	tests := []struct {
+		name  string
+		start *metav1.Time
+		end   *metav1.Time
+		want  string
+	}{{
+		name:  "no start",
+		start: nil,
+		end:   &start,
+		want:  "",
+	}, {
+		name:  "no end",
+		start: &start,
+		end:   nil,
+		want:  "",
+	}, {
+		name:  "rounded to seconds",
+		start: &start,
+		end:   &metav1.Time{Time: start.Add(90*time.Second + 600*time.Millisecond)},
+		want:  "1m31s",
+	}, {
+		name:  "end before start",
+		start: &start,
+		end:   &metav1.Time{Time: start.Add(-time.Second)},
+		want:  "0s",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := reconciler.FormatDuration(tc.start, tc.end); got != tc.want {
+				t.Errorf("FormatDuration() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -271,6 +271,66 @@ func TestEmitCloudEventsWhenConditionChange(t *testing.T) {
 	fakeClient.CheckCloudEventsUnordered(t, "with sink", wantCloudEvents)
 }
 
+func TestEmitCloudEventsSuperseded(t *testing.T) {
+	failed := func(reason string) duckv1.Status {
+		return duckv1.Status{Conditions: []apis.Condition{{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionFalse,
+			Reason: reason,
+		}}}
+	}
+	for _, tc := range []struct {
+		name           string
+		object         runtime.Object
+		wantCloudEvent string
+	}{{
+		name: "superseded taskrun",
+		object: &v1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{SelfLink: "/taskruns/test1"},
+			Status:     v1.TaskRunStatus{Status: failed(v1.TaskRunReasonSuperseded.String())},
+		},
+		wantCloudEvent: `(?s)dev.tekton.event.taskrun.superseded.v1.*test1`,
+	}, {
+		name: "cancelled taskrun",
+		object: &v1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{SelfLink: "/taskruns/test1"},
+			Status:     v1.TaskRunStatus{Status: failed(v1.TaskRunReasonCancelled.String())},
+		},
+		wantCloudEvent: `(?s)dev.tekton.event.taskrun.failed.v1.*test1`,
+	}, {
+		name: "superseded pipelinerun",
+		object: &v1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{SelfLink: "/pipelineruns/test1"},
+			Status:     v1.PipelineRunStatus{Status: failed(v1.PipelineRunReasonSuperseded.String())},
+		},
+		wantCloudEvent: `(?s)dev.tekton.event.pipelinerun.superseded.v1.*test1`,
+	}, {
+		name: "cancelled pipelinerun",
+		object: &v1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{SelfLink: "/pipelineruns/test1"},
+			Status:     v1.PipelineRunStatus{Status: failed(v1.PipelineRunReasonCancelled.String())},
+		},
+		wantCloudEvent: `(?s)dev.tekton.event.pipelinerun.failed.v1.*test1`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			ctx = cloudevent.WithFakeClient(ctx, &cloudevent.FakeClientBehaviour{SendSuccessfully: true}, 1)
+			fakeClient := cloudevent.Get(ctx).(cloudevent.FakeClient)
+
+			defaultsConfig, _ := config.NewDefaultsFromMap(map[string]string{})
+			eventsConfig, _ := config.NewEventsFromMap(map[string]string{"sink": "http://mysink"})
+			ctx = config.ToContext(ctx, &config.Config{
+				Events:       eventsConfig,
+				Defaults:     defaultsConfig,
+				FeatureFlags: config.DefaultFeatureFlags.DeepCopy(),
+			})
+
+			cloudevent.EmitCloudEvents(ctx, tc.object)
+			fakeClient.CheckCloudEventsUnordered(t, tc.name, []string{tc.wantCloudEvent})
+		})
+	}
+}
+
 func setupFakeContext(t *testing.T, behaviour cloudevent.FakeClientBehaviour, withClient bool, expectedEventCount int) context.Context {
 	t.Helper()
 	ctx, _ := rtesting.SetupFakeContext(t)
@@ -279,3 +339,96 @@ func setupFakeContext(t *testing.T, behaviour cloudevent.FakeClientBehaviour, wi
 	}
 	return ctx
 }
+
+func TestEventForObjectWithConditionSourceEventExtensions(t *testing.T) {
+	tr := &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:     "test1",
+			SelfLink: "/taskruns/test1",
+			Annotations: map[string]string{
+				v1.SourceEventIDAnnotationKey:   "72d3162e-cc78-11e3-81ab-4c9367dc0958",
+				v1.SourceEventTypeAnnotationKey: "push",
+				v1.SourceURLAnnotationKey:       "https://github.com/tektoncd/pipeline",
+			},
+		},
+		Status: v1.TaskRunStatus{Status: duckv1.Status{Conditions: []apis.Condition{{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionTrue,
+		}}}},
+	}
+	event, err := cloudevent.EventForObjectWithCondition(t.Context(), tr)
+	if err != nil {
+		t.Fatalf("unexpected error creating the cloud event: %v", err)
+	}
+	want := map[string]interface{}{
+		cloudevent.SourceEventIDExtension:   "72d3162e-cc78-11e3-81ab-4c9367dc0958",
+		cloudevent.SourceEventTypeExtension: "push",
+		cloudevent.SourceURLExtension:       "https://github.com/tektoncd/pipeline",
+	}
+	if d := cmp.Diff(want, event.Extensions()); d != "" {
+		t.Errorf("unexpected extensions %s", diff.PrintWantGot(d))
+	}
+
+	tr.Annotations = nil
+	event, err = cloudevent.EventForObjectWithCondition(t.Context(), tr)
+	if err != nil {
+		t.Fatalf("unexpected error creating the cloud event: %v", err)
+	}
+	if len(event.Extensions()) != 0 {
+		t.Errorf("expected no extensions for a run without source event but got %v", event.Extensions())
+	}
+}
+
+func TestEventForObjectWithConditionFailedTasks(t *testing.T) {
+	failures := []v1.PipelineTaskFailure{{
+		Name:    "build",
+		Reason:  "Failed",
+		Message: `"step-build" exited with code 1`,
+	}, {
+		Name:   "test",
+		Reason: "TaskRunTimeout",
+	}}
+	pr := &v1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:     "test1",
+			SelfLink: "/pipelineruns/test1",
+		},
+		Status: v1.PipelineRunStatus{
+			Status: duckv1.Status{Conditions: []apis.Condition{{
+				Type:    apis.ConditionSucceeded,
+				Status:  corev1.ConditionFalse,
+				Reason:  v1.PipelineRunReasonFailed.String(),
+				Message: "Tasks Completed: 2 (Failed: 2, Cancelled 0), Skipped: 0\n" + v1.PipelineTaskFailuresMessage(failures, 2),
+			}}},
+			PipelineRunStatusFields: v1.PipelineRunStatusFields{
+				Summary: &v1.PipelineRunSummary{FailedTask: "build", FailedTasks: failures},
+			},
+		},
+	}
+	event, err := cloudevent.EventForObjectWithCondition(t.Context(), pr)
+	if err != nil {
+		t.Fatalf("unexpected error creating the cloud event: %v", err)
+	}
+	if event.Type() != cloudevent.PipelineRunFailedEventV1.String() {
+		t.Errorf("expected a %s event but got %s", cloudevent.PipelineRunFailedEventV1, event.Type())
+	}
+	data := cloudevent.TektonCloudEventData{}
+	if err := event.DataAs(&data); err != nil {
+		t.Fatalf("unexpected error reading the data of the cloud event: %v", err)
+	}
+	wantFailures := []v1beta1.PipelineTaskFailure{{
+		Name:    "build",
+		Reason:  "Failed",
+		Message: `"step-build" exited with code 1`,
+	}, {
+		Name:   "test",
+		Reason: "TaskRunTimeout",
+	}}
+	if d := cmp.Diff(wantFailures, data.PipelineRun.Status.Summary.FailedTasks); d != "" {
+		t.Errorf("unexpected failed tasks in the cloud event %s", diff.PrintWantGot(d))
+	}
+	wantMessage := "Tasks Completed: 2 (Failed: 2, Cancelled 0), Skipped: 0\nFailed tasks:\n- build (Failed): \"step-build\" exited with code 1\n- test (TaskRunTimeout)"
+	if d := cmp.Diff(wantMessage, data.PipelineRun.Status.GetCondition(apis.ConditionSucceeded).Message); d != "" {
+		t.Errorf("unexpected failure message in the cloud event %s", diff.PrintWantGot(d))
+	}
+}
@@ -31,6 +31,18 @@ import (
 	"knative.dev/pkg/apis"
 )
 
+const (
+	// SourceEventIDExtension is the extension of the cloud events of a run
+	// holding the value of its tekton.dev/source-event-id annotation.
+	SourceEventIDExtension = "sourceeventid"
+	// SourceEventTypeExtension is the extension of the cloud events of a run
+	// holding the value of its tekton.dev/source-event-type annotation.
+	SourceEventTypeExtension = "sourceeventtype"
+	// SourceURLExtension is the extension of the cloud events of a run
+	// holding the value of its tekton.dev/source-url annotation.
+	SourceURLExtension = "sourceurl"
+)
+
 // TektonEventType holds the types of cloud events sent by Tekton
 type TektonEventType string
 
@@ -48,6 +60,9 @@ const (
 	TaskRunSuccessfulEventV1 TektonEventType = "dev.tekton.event.taskrun.successful.v1"
 	// TaskRunFailedEventV1 is sent for TaskRuns with "ConditionSucceeded" "False"
 	TaskRunFailedEventV1 TektonEventType = "dev.tekton.event.taskrun.failed.v1"
+	// TaskRunSupersededEventV1 is sent for TaskRuns with "ConditionSucceeded" "False"
+	// and the "Superseded" reason
+	TaskRunSupersededEventV1 TektonEventType = "dev.tekton.event.taskrun.superseded.v1"
 	// PipelineRunStartedEventV1 is sent for PipelineRuns with "ConditionSucceeded" "Unknown"
 	// the first time they are picked up by the reconciler
 	PipelineRunStartedEventV1 TektonEventType = "dev.tekton.event.pipelinerun.started.v1"
@@ -61,12 +76,18 @@ const (
 	PipelineRunSuccessfulEventV1 TektonEventType = "dev.tekton.event.pipelinerun.successful.v1"
 	// PipelineRunFailedEventV1 is sent for PipelineRuns with "ConditionSucceeded" "False"
 	PipelineRunFailedEventV1 TektonEventType = "dev.tekton.event.pipelinerun.failed.v1"
+	// PipelineRunSupersededEventV1 is sent for PipelineRuns with "ConditionSucceeded" "False"
+	// and the "Superseded" reason
+	PipelineRunSupersededEventV1 TektonEventType = "dev.tekton.event.pipelinerun.superseded.v1"
 	// CustomRunStartedEventV1 is sent for CustomRuns with "ConditionSucceeded" "Unknown"
 	// the first time they are picked up by the reconciler
 	CustomRunStartedEventV1 TektonEventType = "dev.tekton.event.customrun.started.v1"
 	// CustomRunRunningEventV1 is sent for CustomRuns with "ConditionSucceeded" "Unknown"
 	// once the CustomRun is validated and Pod created
 	CustomRunRunningEventV1 TektonEventType = "dev.tekton.event.customrun.running.v1"
+	// CustomRunWaitingForApprovalEventV1 is sent for the CustomRuns of approval gates with
+	// "ConditionSucceeded" "Unknown" once they wait for the approval of their PipelineRun
+	CustomRunWaitingForApprovalEventV1 TektonEventType = "dev.tekton.event.customrun.waitingforapproval.v1"
 	// CustomRunSuccessfulEventV1 is sent for CustomRuns with "ConditionSucceeded" "True"
 	CustomRunSuccessfulEventV1 TektonEventType = "dev.tekton.event.customrun.successful.v1"
 	// CustomRunFailedEventV1 is sent for CustomRuns with "ConditionSucceeded" "False"
@@ -156,9 +177,24 @@ func EventForObjectWithCondition(ctx context.Context, runObject objectWithCondit
 	if err := event.SetData(cloudevents.ApplicationJSON, tektonCloudEventData); err != nil {
 		return nil, err
 	}
+	setSourceEventExtensions(&event, runObject.GetObjectMeta().GetAnnotations())
 	return &event, nil
 }
 
+// setSourceEventExtensions sets the extensions identifying the event which
+// caused the run, from its source event annotations.
+func setSourceEventExtensions(event *cloudevents.Event, annotations map[string]string) {
+	for extension, key := range map[string]string{
+		SourceEventIDExtension:   v1.SourceEventIDAnnotationKey,
+		SourceEventTypeExtension: v1.SourceEventTypeAnnotationKey,
+		SourceURLExtension:       v1.SourceURLAnnotationKey,
+	} {
+		if value := annotations[key]; value != "" {
+			event.SetExtension(extension, value)
+		}
+	}
+}
+
 func getEventType(runObject objectWithCondition) (*TektonEventType, error) {
 	var eventType TektonEventType
 	c := runObject.GetStatusCondition().GetCondition(apis.ConditionSucceeded)
@@ -217,19 +253,25 @@ func getEventType(runObject objectWithCondition) (*TektonEventType, error) {
 		case *v1beta1.CustomRun:
 			// CustomRun controller have the freedom of setting reasons as they wish
 			// so we cannot make many assumptions here. If a condition is set
-			// to unknown (not finished), we sent the running event
+			// to unknown (not finished), we sent the running event, except for
+			// the approval gates implemented by the PipelineRun controller
 			eventType = CustomRunRunningEventV1
+			if c.Reason == v1beta1.CustomRunReasonWaitingForApproval.String() {
+				eventType = CustomRunWaitingForApprovalEventV1
+			}
 		}
 	case c.IsFalse():
 		switch runObject.(type) {
-		case *v1.TaskRun:
-			eventType = TaskRunFailedEventV1
-		case *v1.PipelineRun:
-			eventType = PipelineRunFailedEventV1
-		case *v1beta1.TaskRun:
+		case *v1.TaskRun, *v1beta1.TaskRun:
 			eventType = TaskRunFailedEventV1
-		case *v1beta1.PipelineRun:
+			if c.Reason == v1.TaskRunReasonSuperseded.String() {
+				eventType = TaskRunSupersededEventV1
+			}
+		case *v1.PipelineRun, *v1beta1.PipelineRun:
 			eventType = PipelineRunFailedEventV1
+			if c.Reason == v1.PipelineRunReasonSuperseded.String() {
+				eventType = PipelineRunSupersededEventV1
+			}
 		case *v1beta1.CustomRun:
 			eventType = CustomRunFailedEventV1
 		}
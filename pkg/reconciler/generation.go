@@ -0,0 +1,47 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"context"
+
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/logging"
+)
+
+// ObserveGeneration records in the status of a run that it reflects the spec
+// of the generation which was reconciled. The generated reconcilers of the
+// TaskRuns and PipelineRuns don't record it, as they are generated without
+// the logic of knative's PostProcessReconcile.
+func ObserveGeneration(run kmeta.Accessor, status *duckv1.Status) {
+	status.ObservedGeneration = run.GetGeneration()
+}
+
+// RequeueIfSpecUpdated returns an error requeueing the run right away if its
+// latest copy has a newer generation than the reconciled one, e.g. because it
+// was cancelled during the reconcile, so that the new spec is acted on
+// without waiting for the next resync. It returns nil otherwise.
+func RequeueIfSpecUpdated(ctx context.Context, reconciled, latest kmeta.Accessor) error {
+	if latest.GetGeneration() == reconciled.GetGeneration() {
+		return nil
+	}
+	logging.FromContext(ctx).Infof("%s/%s was updated to generation %d while reconciling generation %d, requeuing",
+		reconciled.GetNamespace(), reconciled.GetName(), latest.GetGeneration(), reconciled.GetGeneration())
+	return controller.NewRequeueImmediately()
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler_test
+
+import (
+	"testing"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	reconciler "github.com/tektoncd/pipeline/pkg/reconciler"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/controller"
+)
+
+func TestObserveGeneration(t *testing.T) {
+	pr := &v1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Generation: 3}}
+	reconciler.ObserveGeneration(pr, &pr.Status.Status)
+	if pr.Status.ObservedGeneration != 3 {
+		t.Errorf("expected the observed generation 3 but got %d", pr.Status.ObservedGeneration)
+	}
+}
+
+func TestRequeueIfSpecUpdated(t *testing.T) {
+	reconciled := &v1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "tr", Namespace: "foo", Generation: 1}}
+	if err := reconciler.RequeueIfSpecUpdated(t.Context(), reconciled, reconciled.DeepCopy()); err != nil {
+		t.Errorf("expected no requeue for the same generation but got %v", err)
+	}
+	latest := reconciled.DeepCopy()
+	latest.Generation = 2
+	err := reconciler.RequeueIfSpecUpdated(t.Context(), reconciled, latest)
+	if ok, delay := controller.IsRequeueKey(err); !ok || delay != 0 {
+		t.Errorf("expected an immediate requeue for a new generation but got %v", err)
+	}
+}
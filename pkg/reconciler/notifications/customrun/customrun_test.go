@@ -117,6 +117,14 @@ func TestReconcile_CloudEvents(t *testing.T) {
 			Reason: v1beta1.CustomRunReasonRunning.String(),
 		},
 		wantCloudEvents: []string{`(?s)dev.tekton.event.customrun.running.v1.*test-customRun`},
+	}, {
+		name: "CustomRun waiting for approval",
+		condition: &apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionUnknown,
+			Reason: v1beta1.CustomRunReasonWaitingForApproval.String(),
+		},
+		wantCloudEvents: []string{`(?s)dev.tekton.event.customrun.waitingforapproval.v1.*test-customRun`},
 	}, {
 		name: "CustomRun with finished true condition",
 		condition: &apis.Condition{
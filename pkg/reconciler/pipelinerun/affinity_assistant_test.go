@@ -134,6 +134,22 @@ var testPRWithEmptyDir = &v1.PipelineRun{
 	},
 }
 
+var readOnlyCSI = true
+
+var testPRWithCSI = &v1.PipelineRun{
+	ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun-with-csi"},
+	Spec: v1.PipelineRunSpec{
+		Workspaces: []v1.WorkspaceBinding{{
+			Name: "CSI Workspace",
+			CSI: &corev1.CSIVolumeSource{
+				Driver:           "secrets-store.csi.k8s.io",
+				ReadOnly:         &readOnlyCSI,
+				VolumeAttributes: map[string]string{"secretProviderClass": "vault-database"},
+			},
+		}},
+	},
+}
+
 var testPRWithWindowsOs = &v1.PipelineRun{
 	ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun-with-windows"},
 	Spec: v1.PipelineRunSpec{
@@ -506,6 +522,11 @@ func TestCreateOrUpdateAffinityAssistantsAndPVCsPerWorkspaceOrDisabled(t *testin
 		aaBehavior:            aa.AffinityAssistantPerWorkspace,
 		pr:                    testPRWithEmptyDir,
 		expectStatefulSetSpec: nil,
+	}, {
+		name:                  "CSI Workspace type",
+		aaBehavior:            aa.AffinityAssistantPerWorkspace,
+		pr:                    testPRWithCSI,
+		expectStatefulSetSpec: nil,
 	}}
 
 	for _, tc := range tests {
@@ -529,6 +550,15 @@ func TestCreateOrUpdateAffinityAssistantsAndPVCsPerWorkspaceOrDisabled(t *testin
 					validateStatefulSetSpec(t, ctx, c, expectAAName, tc.expectStatefulSetSpec[i])
 				}
 			}
+			if tc.expectStatefulSetSpec == nil {
+				statefulSets, err := c.KubeClientSet.AppsV1().StatefulSets(tc.pr.Namespace).List(ctx, metav1.ListOptions{})
+				if err != nil {
+					t.Fatalf("unexpected error when listing StatefulSets: %v", err)
+				}
+				if len(statefulSets.Items) != 0 {
+					t.Errorf("expected no Affinity Assistant, got %d", len(statefulSets.Items))
+				}
+			}
 
 			// validate PVCs from VolumeClaimTemplate
 			if tc.expectedPVCName != "" {
@@ -0,0 +1,203 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
+	"github.com/tektoncd/pipeline/pkg/reconciler/events/k8sevent"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/logging"
+)
+
+const (
+	// approvalGateDecisionResult is the result of the CustomRun of an approval
+	// gate holding the decision on its approval.
+	approvalGateDecisionResult = "decision"
+	// approvalGateApproverResult is the result of the CustomRun of an approval
+	// gate holding the user who decided on its approval.
+	approvalGateApproverResult = "approver"
+)
+
+// approvalGateAPIVersion is the apiVersion of the CustomRuns of the approval
+// gates, which are reconciled by the PipelineRun controller itself rather than
+// by the controller of a custom task.
+var approvalGateAPIVersion = v1beta1.SchemeGroupVersion.String()
+
+// isApprovalGate returns whether the CustomRun is the CustomRun of an approval
+// gate.
+func isApprovalGate(customRun *v1beta1.CustomRun) bool {
+	ref := customRun.Spec.CustomRef
+	return ref != nil && ref.APIVersion == approvalGateAPIVersion && ref.Kind == v1beta1.TaskKind(v1.ApprovalGateKind)
+}
+
+// reconcileApprovalGates updates the status of the CustomRuns of the approval
+// gates of the PipelineRun from the decisions on its approval, and the state
+// of the PipelineRun with the updated CustomRuns.
+func (c *Reconciler) reconcileApprovalGates(ctx context.Context, pr *v1.PipelineRun, facts *resources.PipelineRunFacts) error {
+	for _, rpt := range facts.State {
+		if !rpt.PipelineTask.TaskRef.IsApprovalGate() {
+			continue
+		}
+		for i, customRun := range rpt.CustomRuns {
+			updated, err := c.reconcileApprovalGate(ctx, pr, customRun)
+			if err != nil {
+				return err
+			}
+			rpt.CustomRuns[i] = updated
+		}
+	}
+	return nil
+}
+
+// finishApprovalGates fails the CustomRuns of the approval gates of a done
+// PipelineRun which were cancelled while waiting for approval, since no other
+// controller marks them as done.
+func (c *Reconciler) finishApprovalGates(ctx context.Context, pr *v1.PipelineRun) error {
+	var errs []error
+	for _, gate := range c.approvalGates(pr) {
+		if !gate.IsCancelled() {
+			continue
+		}
+		if _, err := c.reconcileApprovalGate(ctx, pr, gate); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// reconcileApprovalGate updates the status of the CustomRun of an approval gate
+// from the decision on the approval of its PipelineRun, and returns the
+// updated CustomRun.
+func (c *Reconciler) reconcileApprovalGate(ctx context.Context, pr *v1.PipelineRun, customRun *v1beta1.CustomRun) (*v1beta1.CustomRun, error) {
+	if customRun.IsDone() {
+		return customRun, nil
+	}
+	logger := logging.FromContext(ctx)
+	pipelineTaskName := customRun.Labels[pipeline.PipelineTaskLabelKey]
+	before := customRun.Status.GetCondition(apis.ConditionSucceeded)
+
+	gate := customRun.DeepCopy()
+	if !gate.HasStarted() {
+		gate.Status.StartTime = &metav1.Time{Time: c.Clock.Now()}
+	}
+	decision, approver := v1.ApprovalDecision(pr.Annotations, pipelineTaskName)
+	switch {
+	case gate.IsCancelled():
+		message := gate.Spec.StatusMessage
+		if message == "" {
+			message = "CustomRun was cancelled"
+		}
+		gate.Status.MarkCustomRunFailed(v1beta1.CustomRunReasonCancelled.String(), "%s", message)
+	case decision == v1.ApprovalDecisionApproved:
+		gate.Status.Results = approvalGateResults(decision, approver)
+		gate.Status.MarkCustomRunSucceeded(v1beta1.CustomRunReasonApproved.String(), "%s", decisionMessage("Approved", approver))
+	case decision == v1.ApprovalDecisionRejected:
+		gate.Status.Results = approvalGateResults(decision, approver)
+		gate.Status.MarkCustomRunFailed(v1beta1.CustomRunReasonRejected.String(), "%s", decisionMessage("Rejected", approver))
+	case gate.HasTimedOut(c.Clock):
+		gate.Status.MarkCustomRunFailed(v1beta1.CustomRunReasonTimedOut.String(),
+			"Approval gate %q timed out after %s without a decision", pipelineTaskName, gate.GetTimeout())
+	default:
+		gate.Status.MarkCustomRunRunning(v1beta1.CustomRunReasonWaitingForApproval.String(),
+			"Waiting for the annotation %s%s of PipelineRun %s to be set to %q or %q",
+			v1.ApprovalAnnotationKeyPrefix, pipelineTaskName, pr.Name, v1.ApprovalDecisionApproved, v1.ApprovalDecisionRejected)
+	}
+	if equality.Semantic.DeepEqual(customRun.Status, gate.Status) {
+		return customRun, nil
+	}
+
+	updated, err := c.PipelineClientSet.TektonV1beta1().CustomRuns(gate.Namespace).UpdateStatus(ctx, gate, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error updating the status of the approval gate %s of PipelineRun %s: %w", gate.Name, pr.Name, err)
+	}
+	after := updated.Status.GetCondition(apis.ConditionSucceeded)
+	logger.Infof("Approval gate %s of PipelineRun %s is %s: %s", updated.Name, pr.Name, after.Reason, after.Message)
+	k8sevent.EmitK8sEvents(ctx, before, after, updated)
+	// The CustomRun controller sends the cloud events of all the CustomRuns
+	// when it's enabled.
+	if !config.FromContextOrDefaults(ctx).FeatureFlags.SendCloudEventsForRuns {
+		cloudevent.EmitCloudEventsWhenConditionChange(ctx, before, after, updated)
+	}
+	return updated, nil
+}
+
+// approvalGatesWaitTime returns the time until the first approval gate of the
+// PipelineRun which is waiting for approval times out, and false if none will.
+func (c *Reconciler) approvalGatesWaitTime(pr *v1.PipelineRun) (time.Duration, bool) {
+	var waitTime time.Duration
+	found := false
+	for _, gate := range c.approvalGates(pr) {
+		timeout := gate.GetTimeout()
+		if gate.IsDone() || !gate.HasStarted() || timeout == config.NoTimeoutDuration {
+			continue
+		}
+		if remaining := timeout - c.Clock.Since(gate.Status.StartTime.Time); !found || remaining < waitTime {
+			waitTime, found = remaining, true
+		}
+	}
+	return waitTime, found
+}
+
+// approvalGates returns the CustomRuns of the approval gates among the child
+// references of the PipelineRun.
+func (c *Reconciler) approvalGates(pr *v1.PipelineRun) []*v1beta1.CustomRun {
+	var gates []*v1beta1.CustomRun
+	for _, childRef := range pr.Status.ChildReferences {
+		if childRef.Kind != pipeline.CustomRunControllerName {
+			continue
+		}
+		customRun, err := c.customRunLister.CustomRuns(pr.Namespace).Get(childRef.Name)
+		if err != nil || !isApprovalGate(customRun) {
+			continue
+		}
+		gates = append(gates, customRun)
+	}
+	return gates
+}
+
+// approvalGateResults returns the results of the CustomRun of an approval gate
+// with the given decision.
+func approvalGateResults(decision, approver string) []v1beta1.CustomRunResult {
+	return []v1beta1.CustomRunResult{{
+		Name:  approvalGateDecisionResult,
+		Value: decision,
+	}, {
+		Name:  approvalGateApproverResult,
+		Value: approver,
+	}}
+}
+
+// decisionMessage returns the message of the condition of the CustomRun of an
+// approval gate once decided.
+func decisionMessage(decision, approver string) string {
+	if approver == "" {
+		return decision
+	}
+	return decision + " by " + approver
+}
@@ -0,0 +1,376 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
+	"github.com/tektoncd/pipeline/test"
+	"github.com/tektoncd/pipeline/test/diff"
+	"github.com/tektoncd/pipeline/test/names"
+	"github.com/tektoncd/pipeline/test/parse"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktesting "k8s.io/client-go/testing"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/system"
+)
+
+const approvalGatePipelineSpec = `
+  pipelineSpec:
+    tasks:
+    - name: approve
+      taskRef:
+        kind: ApprovalGate
+      timeout: 1h
+    - name: deploy
+      runAfter: ["approve"]
+      taskSpec:
+        steps:
+        - name: deploy
+          image: busybox
+          script: echo deploy
+`
+
+func approvalGateConfigMaps() []*corev1.ConfigMap {
+	return []*corev1.ConfigMap{withEnabledAlphaAPIFields(newFeatureFlagsConfigMap()), {
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetEventsConfigName(), Namespace: system.Namespace()},
+		Data:       map[string]string{"sink": "http://synk:8080"},
+	}}
+}
+
+func approvalGatePipelineRun(t *testing.T, annotations string, onError string) *v1.PipelineRun {
+	t.Helper()
+	spec := approvalGatePipelineSpec
+	if onError != "" {
+		spec = strings.Replace(spec, "      timeout: 1h\n", "      timeout: 1h\n      onError: "+onError+"\n", 1)
+	}
+	return parse.MustParseV1PipelineRun(t, fmt.Sprintf(`
+metadata:
+  name: test-pipelinerun
+  namespace: foo
+  annotations:
+%s
+spec:
+%s
+status:
+  startTime: "2022-01-01T00:00:00Z"
+  childReferences:
+  - apiVersion: tekton.dev/v1beta1
+    kind: CustomRun
+    name: test-pipelinerun-approve
+    pipelineTaskName: approve
+`, annotations, spec))
+}
+
+func approvalGateCustomRun(t *testing.T, status string) *v1beta1.CustomRun {
+	t.Helper()
+	objectMeta := taskRunObjectMeta("test-pipelinerun-approve", "foo", "test-pipelinerun", "test-pipelinerun", "approve", false)
+	// The CustomRun is created when the PipelineRun starts, so that it doesn't
+	// move its start time.
+	objectMeta.CreationTimestamp = metav1.Time{Time: now}
+	return mustParseCustomRunWithObjectMeta(t, objectMeta, `
+spec:
+  customRef:
+    apiVersion: tekton.dev/v1beta1
+    kind: ApprovalGate
+  serviceAccountName: default
+  timeout: 1h
+`+status)
+}
+
+func TestReconcile_ApprovalGateCreated(t *testing.T) {
+	names.TestingSeed()
+	pr := parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipelinerun
+  namespace: foo
+spec:
+`+approvalGatePipelineSpec)
+	d := test.Data{
+		PipelineRuns: []*v1.PipelineRun{pr},
+		ConfigMaps:   approvalGateConfigMaps(),
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
+
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipelinerun", []string{}, false)
+
+	var created *v1beta1.CustomRun
+	for _, action := range clients.Pipeline.Actions() {
+		if action.GetVerb() == "create" && action.GetResource().Resource == "customruns" {
+			created = action.(ktesting.CreateAction).GetObject().(*v1beta1.CustomRun)
+		}
+	}
+	if created == nil {
+		t.Fatalf("expected the CustomRun of the approval gate to be created")
+	}
+	wantRef := &v1beta1.TaskRef{APIVersion: "tekton.dev/v1beta1", Kind: "ApprovalGate"}
+	if d := cmp.Diff(wantRef, created.Spec.CustomRef); d != "" {
+		t.Errorf("unexpected customRef of the approval gate: %s", diff.PrintWantGot(d))
+	}
+	checkPipelineRunConditionStatusAndReason(t, reconciledRun, corev1.ConditionUnknown, v1.PipelineRunReasonRunning.String())
+	verifyCustomRunOrRunStatusesNames(t, customRun, reconciledRun.Status, "test-pipelinerun-approve")
+}
+
+func TestReconcile_ApprovalGateWaiting(t *testing.T) {
+	names.TestingSeed()
+	d := test.Data{
+		PipelineRuns:            []*v1.PipelineRun{approvalGatePipelineRun(t, "    foo: bar", "")},
+		CustomRuns:              []*v1beta1.CustomRun{approvalGateCustomRun(t, "")},
+		ConfigMaps:              approvalGateConfigMaps(),
+		ExpectedCloudEventCount: 2,
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
+
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipelinerun", []string{}, false)
+	checkPipelineRunConditionStatusAndReason(t, reconciledRun, corev1.ConditionUnknown, v1.PipelineRunReasonRunning.String())
+
+	gate, err := clients.Pipeline.TektonV1beta1().CustomRuns("foo").Get(prt.TestAssets.Ctx, "test-pipelinerun-approve", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get the approval gate: %v", err)
+	}
+	condition := gate.Status.GetCondition(apis.ConditionSucceeded)
+	if !condition.IsUnknown() || condition.Reason != v1beta1.CustomRunReasonWaitingForApproval.String() {
+		t.Errorf("expected the approval gate to be waiting for approval, got %v", condition)
+	}
+	if gate.Status.StartTime == nil || !gate.Status.StartTime.Time.Equal(now) {
+		t.Errorf("expected the approval gate to start at %s, got %v", now, gate.Status.StartTime)
+	}
+	for _, action := range clients.Pipeline.Actions() {
+		if action.GetVerb() == "create" && action.GetResource().Resource == "taskruns" {
+			t.Errorf("expected no TaskRun to be created before the approval, got %v", action)
+		}
+	}
+
+	wantCloudEvents := []string{
+		`(?s)dev.tekton.event.customrun.waitingforapproval.v1.*test-pipelinerun-approve`,
+		`(?s)dev.tekton.event.pipelinerun.running.v1.*test-pipelinerun`,
+	}
+	ceClient := clients.CloudEvents.(cloudevent.FakeClient)
+	ceClient.CheckCloudEventsUnordered(t, "approval-gate-waiting", wantCloudEvents)
+}
+
+func TestReconcile_ApprovalGateDecided(t *testing.T) {
+	waiting := fmt.Sprintf(`
+status:
+  startTime: "2022-01-01T00:00:00Z"
+  conditions:
+  - type: Succeeded
+    status: Unknown
+    reason: %s
+`, v1beta1.CustomRunReasonWaitingForApproval)
+	startedTwoHoursAgo := fmt.Sprintf(`
+status:
+  startTime: "2021-12-31T22:00:00Z"
+  conditions:
+  - type: Succeeded
+    status: Unknown
+    reason: %s
+`, v1beta1.CustomRunReasonWaitingForApproval)
+
+	for _, tc := range []struct {
+		name           string
+		annotations    string
+		onError        string
+		gateStatus     string
+		wantGateStatus corev1.ConditionStatus
+		wantGateReason string
+		wantMessage    string
+		wantResults    []v1beta1.CustomRunResult
+		wantPRStatus   corev1.ConditionStatus
+		wantPRReason   string
+		wantDeploy     bool
+	}{{
+		name:           "approved",
+		annotations:    "    approvals.tekton.dev/approve: approved\n    approvers.tekton.dev/approve: alice",
+		gateStatus:     waiting,
+		wantGateStatus: corev1.ConditionTrue,
+		wantGateReason: v1beta1.CustomRunReasonApproved.String(),
+		wantMessage:    "Approved by alice",
+		wantResults:    []v1beta1.CustomRunResult{{Name: "decision", Value: "approved"}, {Name: "approver", Value: "alice"}},
+		wantPRStatus:   corev1.ConditionUnknown,
+		wantPRReason:   v1.PipelineRunReasonRunning.String(),
+		wantDeploy:     true,
+	}, {
+		name:           "rejected",
+		annotations:    "    approvals.tekton.dev/approve: rejected\n    approvers.tekton.dev/approve: bob",
+		gateStatus:     waiting,
+		wantGateStatus: corev1.ConditionFalse,
+		wantGateReason: v1beta1.CustomRunReasonRejected.String(),
+		wantMessage:    "Rejected by bob",
+		wantResults:    []v1beta1.CustomRunResult{{Name: "decision", Value: "rejected"}, {Name: "approver", Value: "bob"}},
+		wantPRStatus:   corev1.ConditionFalse,
+		wantPRReason:   v1.PipelineRunReasonFailed.String(),
+	}, {
+		name:           "rejected with onError continue",
+		annotations:    "    approvals.tekton.dev/approve: rejected\n    approvers.tekton.dev/approve: bob",
+		onError:        "continue",
+		gateStatus:     waiting,
+		wantGateStatus: corev1.ConditionFalse,
+		wantGateReason: v1beta1.CustomRunReasonRejected.String(),
+		wantMessage:    "Rejected by bob",
+		wantResults:    []v1beta1.CustomRunResult{{Name: "decision", Value: "rejected"}, {Name: "approver", Value: "bob"}},
+		wantPRStatus:   corev1.ConditionUnknown,
+		wantPRReason:   v1.PipelineRunReasonRunning.String(),
+		wantDeploy:     true,
+	}, {
+		name:           "timed out",
+		annotations:    "    foo: bar",
+		gateStatus:     startedTwoHoursAgo,
+		wantGateStatus: corev1.ConditionFalse,
+		wantGateReason: v1beta1.CustomRunReasonTimedOut.String(),
+		wantMessage:    `Approval gate "approve" timed out after 1h0m0s without a decision`,
+		wantPRStatus:   corev1.ConditionFalse,
+		wantPRReason:   v1.PipelineRunReasonFailed.String(),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			names.TestingSeed()
+			d := test.Data{
+				PipelineRuns: []*v1.PipelineRun{approvalGatePipelineRun(t, tc.annotations, tc.onError)},
+				CustomRuns:   []*v1beta1.CustomRun{approvalGateCustomRun(t, tc.gateStatus)},
+				ConfigMaps:   approvalGateConfigMaps(),
+				// The cloud events of the approval gate and of the PipelineRun.
+				ExpectedCloudEventCount: 2,
+			}
+			prt := newPipelineRunTest(t, d)
+			defer prt.Cancel()
+
+			reconciledRun, clients := prt.reconcileRun("foo", "test-pipelinerun", []string{}, false)
+			checkPipelineRunConditionStatusAndReason(t, reconciledRun, tc.wantPRStatus, tc.wantPRReason)
+
+			gate, err := clients.Pipeline.TektonV1beta1().CustomRuns("foo").Get(prt.TestAssets.Ctx, "test-pipelinerun-approve", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("failed to get the approval gate: %v", err)
+			}
+			condition := gate.Status.GetCondition(apis.ConditionSucceeded)
+			if condition.Status != tc.wantGateStatus || condition.Reason != tc.wantGateReason || condition.Message != tc.wantMessage {
+				t.Errorf("expected the approval gate to be %s with reason %s and message %q, got %v", tc.wantGateStatus, tc.wantGateReason, tc.wantMessage, condition)
+			}
+			if gate.Status.CompletionTime == nil {
+				t.Errorf("expected the approval gate to be completed")
+			}
+			if d := cmp.Diff(tc.wantResults, gate.Status.Results, cmpopts.EquateEmpty()); d != "" {
+				t.Errorf("unexpected results of the approval gate: %s", diff.PrintWantGot(d))
+			}
+
+			deployed := false
+			for _, action := range clients.Pipeline.Actions() {
+				if action.GetVerb() == "create" && action.GetResource().Resource == "taskruns" {
+					deployed = true
+				}
+			}
+			if deployed != tc.wantDeploy {
+				t.Errorf("expected the TaskRun of the downstream task to be created: %t, got %t", tc.wantDeploy, deployed)
+			}
+		})
+	}
+}
+
+func TestReconcile_ApprovalGateCancelled(t *testing.T) {
+	names.TestingSeed()
+	pr := parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipelinerun
+  namespace: foo
+spec:
+  status: Cancelled
+`+approvalGatePipelineSpec+`
+status:
+  startTime: "2022-01-01T00:00:00Z"
+  conditions:
+  - type: Succeeded
+    status: "False"
+    reason: Cancelled
+  childReferences:
+  - apiVersion: tekton.dev/v1beta1
+    kind: CustomRun
+    name: test-pipelinerun-approve
+    pipelineTaskName: approve
+`)
+	gate := approvalGateCustomRun(t, fmt.Sprintf(`
+  status: RunCancelled
+  statusMessage: %s
+status:
+  startTime: "2022-01-01T00:00:00Z"
+  conditions:
+  - type: Succeeded
+    status: Unknown
+    reason: %s
+`, v1beta1.CustomRunCancelledByPipelineMsg, v1beta1.CustomRunReasonWaitingForApproval))
+	d := test.Data{
+		PipelineRuns: []*v1.PipelineRun{pr},
+		CustomRuns:   []*v1beta1.CustomRun{gate},
+		ConfigMaps:   approvalGateConfigMaps(),
+		// The cloud event of the approval gate.
+		ExpectedCloudEventCount: 1,
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
+
+	_, clients := prt.reconcileRun("foo", "test-pipelinerun", []string{}, false)
+
+	reconciledGate, err := clients.Pipeline.TektonV1beta1().CustomRuns("foo").Get(prt.TestAssets.Ctx, "test-pipelinerun-approve", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get the approval gate: %v", err)
+	}
+	condition := reconciledGate.Status.GetCondition(apis.ConditionSucceeded)
+	if !condition.IsFalse() || condition.Reason != v1beta1.CustomRunReasonCancelled.String() || condition.Message != string(v1beta1.CustomRunCancelledByPipelineMsg) {
+		t.Errorf("expected the approval gate to be cancelled, got %v", condition)
+	}
+}
+
+func TestReconcile_ApprovalGateRequeuedUntilTimeout(t *testing.T) {
+	names.TestingSeed()
+	// The approval gate started 20 minutes ago and times out after an hour,
+	// before the PipelineRun.
+	gate := approvalGateCustomRun(t, fmt.Sprintf(`
+status:
+  startTime: "2021-12-31T23:40:00Z"
+  conditions:
+  - type: Succeeded
+    status: Unknown
+    reason: %s
+`, v1beta1.CustomRunReasonWaitingForApproval))
+	d := test.Data{
+		PipelineRuns: []*v1.PipelineRun{approvalGatePipelineRun(t, "    foo: bar", "")},
+		CustomRuns:   []*v1beta1.CustomRun{gate},
+		ConfigMaps:   approvalGateConfigMaps(),
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
+
+	err := prt.TestAssets.Controller.Reconciler.Reconcile(prt.TestAssets.Ctx, "foo/test-pipelinerun")
+	ok, waitTime := controller.IsRequeueKey(err)
+	if !ok {
+		t.Fatalf("expected the PipelineRun to be requeued, got %v", err)
+	}
+	if want := 40 * time.Minute; waitTime != want {
+		t.Errorf("expected the PipelineRun to be requeued after %s, got %s", want, waitTime)
+	}
+}
@@ -38,7 +38,7 @@ import (
 	"knative.dev/pkg/apis"
 )
 
-var cancelTaskRunPatchBytes, cancelCustomRunPatchBytes []byte
+var cancelTaskRunPatchBytes, supersedeTaskRunPatchBytes, cancelCustomRunPatchBytes []byte
 
 func init() {
 	var err error
@@ -56,6 +56,20 @@ func init() {
 	if err != nil {
 		log.Fatalf("failed to marshal TaskRun cancel patch bytes: %v", err)
 	}
+	supersedeTaskRunPatchBytes, err = json.Marshal([]jsonpatch.JsonPatchOperation{
+		{
+			Operation: "add",
+			Path:      "/spec/status",
+			Value:     v1.TaskRunSpecStatusSuperseded,
+		},
+		{
+			Operation: "add",
+			Path:      "/spec/statusMessage",
+			Value:     v1.TaskRunSupersededByPipelineMsg,
+		}})
+	if err != nil {
+		log.Fatalf("failed to marshal TaskRun supersede patch bytes: %v", err)
+	}
 	cancelCustomRunPatchBytes, err = json.Marshal([]jsonpatch.JsonPatchOperation{
 		{
 			Operation: "add",
@@ -82,8 +96,8 @@ func cancelCustomRun(ctx context.Context, runName string, namespace string, clie
 	return err
 }
 
-func cancelTaskRun(ctx context.Context, taskRunName string, namespace string, clientSet clientset.Interface) error {
-	_, err := clientSet.TektonV1().TaskRuns(namespace).Patch(ctx, taskRunName, types.JSONPatchType, cancelTaskRunPatchBytes, metav1.PatchOptions{}, "")
+func cancelTaskRun(ctx context.Context, taskRunName string, namespace string, clientSet clientset.Interface, patchBytes []byte) error {
+	_, err := clientSet.TektonV1().TaskRuns(namespace).Patch(ctx, taskRunName, types.JSONPatchType, patchBytes, metav1.PatchOptions{}, "")
 	if errors.IsNotFound(err) {
 		// The resource may have been deleted in the meanwhile, but we should
 		// still be able to cancel the PipelineRun
@@ -96,19 +110,24 @@ func cancelTaskRun(ctx context.Context, taskRunName string, namespace string, cl
 	return err
 }
 
-// cancelPipelineRun marks the PipelineRun as cancelled and any resolved TaskRun(s) too.
+// cancelPipelineRun marks the PipelineRun as cancelled, or superseded, and any resolved TaskRun(s) too.
 func cancelPipelineRun(ctx context.Context, logger *zap.SugaredLogger, pr *v1.PipelineRun, clientSet clientset.Interface) error {
 	errs := cancelPipelineTaskRuns(ctx, logger, pr, clientSet)
 
 	// If we successfully cancelled all the TaskRuns and Runs, we can consider the PipelineRun cancelled.
 	if len(errs) == 0 {
 		reason := v1.PipelineRunReasonCancelled
+		message := fmt.Sprintf("PipelineRun %q was cancelled", pr.Name)
+		if pr.IsSuperseded() {
+			reason = v1.PipelineRunReasonSuperseded
+			message = fmt.Sprintf("PipelineRun %q was superseded", pr.Name)
+		}
 
 		pr.Status.SetCondition(&apis.Condition{
 			Type:    apis.ConditionSucceeded,
 			Status:  corev1.ConditionFalse,
 			Reason:  reason.String(),
-			Message: fmt.Sprintf("PipelineRun %q was cancelled", pr.Name),
+			Message: message,
 		})
 		// update pr completed time
 		pr.Status.CompletionTime = &metav1.Time{Time: time.Now()}
@@ -131,9 +150,14 @@ func cancelPipelineTaskRuns(ctx context.Context, logger *zap.SugaredLogger, pr *
 	return cancelPipelineTaskRunsForTaskNames(ctx, logger, pr, clientSet, sets.NewString())
 }
 
-// cancelPipelineTaskRunsForTaskNames patches `TaskRun`s and `Run`s for the given task names, or all if no task names are given, with canceled status
+// cancelPipelineTaskRunsForTaskNames patches `TaskRun`s and `Run`s for the given task names, or all if no task names are given, with canceled status.
+// The `TaskRun`s of a superseded PipelineRun are superseded instead, while its `Run`s are cancelled.
 func cancelPipelineTaskRunsForTaskNames(ctx context.Context, logger *zap.SugaredLogger, pr *v1.PipelineRun, clientSet clientset.Interface, taskNames sets.String) []string {
 	errs := []string{}
+	taskRunPatchBytes := cancelTaskRunPatchBytes
+	if pr.IsSuperseded() {
+		taskRunPatchBytes = supersedeTaskRunPatchBytes
+	}
 
 	trNames, customRunNames, err := getChildObjectsFromPRStatusForTaskNames(ctx, pr.Status, taskNames)
 	if err != nil {
@@ -143,7 +167,7 @@ func cancelPipelineTaskRunsForTaskNames(ctx context.Context, logger *zap.Sugared
 	for _, taskRunName := range trNames {
 		logger.Infof("cancelling TaskRun %s", taskRunName)
 
-		if err := cancelTaskRun(ctx, taskRunName, pr.Namespace, clientSet); err != nil {
+		if err := cancelTaskRun(ctx, taskRunName, pr.Namespace, clientSet, taskRunPatchBytes); err != nil {
 			errs = append(errs, fmt.Errorf("failed to patch TaskRun `%s` with cancellation: %w", taskRunName, err).Error())
 			continue
 		}
@@ -189,8 +213,9 @@ func getChildObjectsFromPRStatusForTaskNames(ctx context.Context, prs v1.Pipelin
 }
 
 // gracefullyCancelPipelineRun marks any non-final resolved TaskRun(s) as cancelled and runs finally.
-func gracefullyCancelPipelineRun(ctx context.Context, logger *zap.SugaredLogger, pr *v1.PipelineRun, clientSet clientset.Interface) error {
-	errs := cancelPipelineTaskRuns(ctx, logger, pr, clientSet)
+// The TaskRuns of the final tasks, which may start before the others stopped, are left running.
+func gracefullyCancelPipelineRun(ctx context.Context, logger *zap.SugaredLogger, pr *v1.PipelineRun, clientSet clientset.Interface, taskNames sets.String) error {
+	errs := cancelPipelineTaskRunsForTaskNames(ctx, logger, pr, clientSet, taskNames)
 
 	// If we successfully cancelled all the TaskRuns and Runs, we can proceed with the PipelineRun reconciliation to trigger finally.
 	if len(errs) > 0 {
@@ -277,6 +277,31 @@ func TestCancelPipelineRun(t *testing.T) {
 			{ObjectMeta: metav1.ObjectMeta{Name: "cr1"}},
 			{ObjectMeta: metav1.ObjectMeta{Name: "cr2"}},
 		},
+	}, {
+		name: "superseded-taskruns-and-runs",
+		pipelineRun: &v1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-pipeline-run-superseded"},
+			Spec: v1.PipelineRunSpec{
+				Status: v1.PipelineRunSpecStatusSuperseded,
+			},
+			Status: v1.PipelineRunStatus{PipelineRunStatusFields: v1.PipelineRunStatusFields{
+				ChildReferences: []v1.ChildStatusReference{{
+					TypeMeta:         runtime.TypeMeta{Kind: taskRun},
+					Name:             "t1",
+					PipelineTaskName: "task-1",
+				}, {
+					TypeMeta:         runtime.TypeMeta{Kind: customRun},
+					Name:             "r1",
+					PipelineTaskName: "run-1",
+				}},
+			}},
+		},
+		taskRuns: []*v1.TaskRun{
+			{ObjectMeta: metav1.ObjectMeta{Name: "t1"}},
+		},
+		customRuns: []*v1beta1.CustomRun{
+			{ObjectMeta: metav1.ObjectMeta{Name: "r1"}},
+		},
 	}, {
 		name: "unknown-kind-on-child-references",
 		pipelineRun: &v1.PipelineRun{
@@ -322,18 +347,29 @@ func TestCancelPipelineRun(t *testing.T) {
 				if cond.IsTrue() {
 					t.Errorf("Expected PipelineRun status to be complete and false, but was %v", cond)
 				}
+				// The TaskRuns of a superseded PipelineRun are superseded too, with a neutral reason
+				expectedReason := v1.PipelineRunReasonCancelled.String()
+				expectedTaskRunStatus := v1.TaskRunSpecStatus(v1.TaskRunSpecStatusCancelled)
+				expectedTaskRunStatusMessage := v1.TaskRunCancelledByPipelineMsg
+				if tc.pipelineRun.IsSuperseded() {
+					expectedReason = v1.PipelineRunReasonSuperseded.String()
+					expectedTaskRunStatus = v1.TaskRunSpecStatusSuperseded
+					expectedTaskRunStatusMessage = v1.TaskRunSupersededByPipelineMsg
+				}
+				if cond.Reason != expectedReason {
+					t.Errorf("Expected PipelineRun to have reason %q, but was %q", expectedReason, cond.Reason)
+				}
 				if tc.taskRuns != nil {
 					for _, expectedTR := range tc.taskRuns {
 						tr, err := c.Pipeline.TektonV1().TaskRuns("").Get(ctx, expectedTR.Name, metav1.GetOptions{})
 						if err != nil {
 							t.Fatalf("couldn't get expected TaskRun %s, got error %s", expectedTR.Name, err)
 						}
-						if tr.Spec.Status != v1.TaskRunSpecStatusCancelled {
-							t.Errorf("expected task %q to be marked as cancelled, was %q", tr.Name, tr.Spec.Status)
+						if tr.Spec.Status != expectedTaskRunStatus {
+							t.Errorf("expected task %q to be marked as %s, was %q", tr.Name, expectedTaskRunStatus, tr.Spec.Status)
 						}
-						expectedStatusMessage := v1.TaskRunCancelledByPipelineMsg
-						if tr.Spec.StatusMessage != expectedStatusMessage {
-							t.Errorf("expected task %q to have status message %s but was %s", tr.Name, expectedStatusMessage, tr.Spec.StatusMessage)
+						if tr.Spec.StatusMessage != expectedTaskRunStatusMessage {
+							t.Errorf("expected task %q to have status message %s but was %s", tr.Name, expectedTaskRunStatusMessage, tr.Spec.StatusMessage)
 						}
 					}
 				}
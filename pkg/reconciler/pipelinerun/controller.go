@@ -18,6 +18,7 @@ package pipelinerun
 
 import (
 	"context"
+	"sync"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
@@ -32,12 +33,14 @@ import (
 	resolutioninformer "github.com/tektoncd/pipeline/pkg/client/resolution/injection/informers/resolution/v1beta1/resolutionrequest"
 	"github.com/tektoncd/pipeline/pkg/pipelinerunmetrics"
 	cloudeventclient "github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
+	"github.com/tektoncd/pipeline/pkg/reconciler/ratelimit"
 	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
 	resolution "github.com/tektoncd/pipeline/pkg/remoteresolution/resource"
 	"github.com/tektoncd/pipeline/pkg/tracing"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/utils/clock"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	configmapinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/configmap"
 	secretinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/secret"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
@@ -53,6 +56,7 @@ const (
 func NewController(opts *pipeline.Options, clock clock.PassiveClock) func(context.Context, configmap.Watcher) *controller.Impl {
 	return func(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
 		logger := logging.FromContext(ctx)
+		ctx, clientRateLimiter := ratelimit.WithControllerClients(ctx)
 		kubeclientset := kubeclient.Get(ctx)
 		pipelineclientset := pipelineclient.Get(ctx)
 		taskRunInformer := taskruninformer.Get(ctx)
@@ -63,8 +67,10 @@ func NewController(opts *pipeline.Options, clock clock.PassiveClock) func(contex
 		secretinformer := secretinformer.Get(ctx)
 		tracerProvider := tracing.New(TracerProviderName, logger.Named("tracing"))
 		pipelinerunmetricsRecorder := pipelinerunmetrics.Get(ctx)
+		rateLimiter := ratelimit.NewLimiter(config.DefaultConfig.ControllerRateLimitsFor(config.ControllerRateLimitsPipelineRunKey))
 		//nolint:contextcheck // OnStore methods does not support context as a parameter
 		configStore := config.NewStore(logger.Named("config-store"),
+			ratelimit.OnStore(logger, config.ControllerRateLimitsPipelineRunKey, rateLimiter, clientRateLimiter),
 			pipelinerunmetrics.OnStore(logger, pipelinerunmetricsRecorder),
 			tracerProvider.OnStore(secretinformer.Lister()),
 		)
@@ -79,11 +85,14 @@ func NewController(opts *pipeline.Options, clock clock.PassiveClock) func(contex
 			taskRunLister:            taskRunInformer.Lister(),
 			customRunLister:          customRunInformer.Lister(),
 			verificationPolicyLister: verificationpolicyInformer.Lister(),
+			configMapLister:          configmapinformer.Get(ctx).Lister(),
 			cloudEventClient:         cloudeventclient.Get(ctx),
 			metrics:                  pipelinerunmetricsRecorder,
 			pvcHandler:               volumeclaim.NewPVCHandler(kubeclientset, logger),
 			resolutionRequester:      resolution.NewCRDRequester(resolutionclient.Get(ctx), resolutionInformer.Lister()),
 			tracerProvider:           tracerProvider,
+			creationBreaker:          ratelimit.GetCreationBreaker(ctx),
+			warnedUnsupportedFields:  &sync.Map{},
 		}
 		impl := pipelinerunreconciler.NewImpl(ctx, c, func(impl *controller.Impl) controller.Options {
 			return controller.Options{
@@ -91,12 +100,13 @@ func NewController(opts *pipeline.Options, clock clock.PassiveClock) func(contex
 				ConfigStore: configStore,
 			}
 		})
+		impl.Reconciler = ratelimit.NewReconciler(impl.Reconciler, rateLimiter)
 
 		if _, err := secretinformer.Informer().AddEventHandler(controller.HandleAll(tracerProvider.Handler)); err != nil {
 			logging.FromContext(ctx).Panicf("Couldn't register Secret informer event handler: %w", err)
 		}
 
-		if _, err := pipelineRunInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue)); err != nil {
+		if _, err := pipelineRunInformer.Informer().AddEventHandler(ratelimit.SkipTerminalResyncs(impl.Enqueue)); err != nil {
 			logging.FromContext(ctx).Panicf("Couldn't register PipelineRun informer event handler: %w", err)
 		}
 
@@ -113,6 +123,11 @@ func NewController(opts *pipeline.Options, clock clock.PassiveClock) func(contex
 		}); err != nil {
 			logging.FromContext(ctx).Panicf("Couldn't register CustomRun informer event handler: %w", err)
 		}
+		if _, err := customRunInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			DeleteFunc: c.forgetUnsupportedFields,
+		}); err != nil {
+			logging.FromContext(ctx).Panicf("Couldn't register CustomRun informer delete handler: %w", err)
+		}
 
 		if _, err := resolutionInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 			FilterFunc: controller.FilterController(&v1.PipelineRun{}),
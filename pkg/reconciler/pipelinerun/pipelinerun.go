@@ -24,13 +24,16 @@ import (
 	"path/filepath"
 	"reflect"
 	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	pipelineErrors "github.com/tektoncd/pipeline/pkg/apis/pipeline/errors"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
 	pipelinerunreconciler "github.com/tektoncd/pipeline/pkg/client/injection/reconciler/pipeline/v1/pipelinerun"
@@ -42,11 +45,13 @@ import (
 	"github.com/tektoncd/pipeline/pkg/pipelinerunmetrics"
 	tknreconciler "github.com/tektoncd/pipeline/pkg/reconciler"
 	"github.com/tektoncd/pipeline/pkg/reconciler/apiserver"
+	"github.com/tektoncd/pipeline/pkg/reconciler/debuglog"
 	"github.com/tektoncd/pipeline/pkg/reconciler/events"
 	"github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipeline/dag"
 	rprp "github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/pipelinespec"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
+	"github.com/tektoncd/pipeline/pkg/reconciler/ratelimit"
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun"
 	tresources "github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
 	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
@@ -68,6 +73,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes"
+	corev1Listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/utils/clock"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/controller"
@@ -161,11 +168,19 @@ type Reconciler struct {
 	taskRunLister            listers.TaskRunLister
 	customRunLister          beta1listers.CustomRunLister
 	verificationPolicyLister alpha1listers.VerificationPolicyLister
+	configMapLister          corev1Listers.ConfigMapLister
 	cloudEventClient         cloudevent.CEClient
 	metrics                  *pipelinerunmetrics.Recorder
 	pvcHandler               volumeclaim.PvcHandler
 	resolutionRequester      resolution.Requester
 	tracerProvider           trace.TracerProvider
+	// creationBreaker pauses the creations while the API server throttles them.
+	creationBreaker *ratelimit.CreationBreaker
+	// warnedUnsupportedFields are the unsupported fields last warned about,
+	// by the namespace/name of their CustomRun, so that the warning is only
+	// sent again when they change. The entries are dropped once the CustomRun
+	// is done or deleted.
+	warnedUnsupportedFields *sync.Map
 }
 
 var (
@@ -174,6 +189,37 @@ var (
 	filterReservedAnnotationRegexp                                 = regexp.MustCompile(pipeline.TektonReservedAnnotationExpr)
 )
 
+// warnUnsupportedFields sends a warning event about the fields of the
+// CustomRun which the controller of its custom task declares unsupported,
+// unless it was already sent for the same fields.
+func (c *Reconciler) warnUnsupportedFields(ctx context.Context, pr *v1.PipelineRun, cr *v1beta1.CustomRun) {
+	key := cr.Namespace + "/" + cr.Name
+	unsupported := strings.Join(cr.UnsupportedFields(), ", ")
+	if cr.IsDone() || unsupported == "" {
+		if c.warnedUnsupportedFields != nil {
+			c.warnedUnsupportedFields.Delete(key)
+		}
+		return
+	}
+	if c.warnedUnsupportedFields != nil {
+		if warned, ok := c.warnedUnsupportedFields.Swap(key, unsupported); ok && warned == unsupported {
+			return
+		}
+	}
+	controller.GetEventRecorder(ctx).Eventf(pr, corev1.EventTypeWarning, "CustomRunUnsupportedFields",
+		"The controller of CustomRun %s doesn't support its fields: %s", cr.Name, unsupported)
+}
+
+// forgetUnsupportedFields drops the unsupported fields warned about for a
+// deleted CustomRun, which wouldn't be reconciled as done otherwise.
+func (c *Reconciler) forgetUnsupportedFields(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil || c.warnedUnsupportedFields == nil {
+		return
+	}
+	c.warnedUnsupportedFields.Delete(key)
+}
+
 // ReconcileKind compares the actual state with the desired, and attempts to
 // converge the two. It then updates the Status block of the Pipeline Run
 // resource with the current status of the resource.
@@ -181,6 +227,7 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, pr *v1.PipelineRun) pkgr
 	logger := logging.FromContext(ctx)
 	ctx = cloudevent.ToContext(ctx, c.cloudEventClient)
 	ctx = initTracing(ctx, c.tracerProvider, pr)
+	ctx = debuglog.ToContext(ctx, debuglog.New(pr))
 	ctx, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "PipelineRun:ReconcileKind")
 	defer span.End()
 
@@ -238,6 +285,10 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, pr *v1.PipelineRun) pkgr
 		if err != nil {
 			logger.Errorf("Failed to delete StatefulSet or PVC for PipelineRun %s: %v", pr.Name, err)
 		}
+		if gatesErr := c.finishApprovalGates(ctx, pr); gatesErr != nil {
+			logger.Errorf("Failed to finish the approval gates of PipelineRun %s: %v", pr.Name, gatesErr)
+			err = errors.Join(err, gatesErr)
+		}
 		return c.finishReconcileUpdateEmitEvents(ctx, pr, before, err)
 	}
 
@@ -246,8 +297,8 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, pr *v1.PipelineRun) pkgr
 		return c.finishReconcileUpdateEmitEvents(ctx, pr, before, err)
 	}
 
-	// If the pipelinerun is cancelled, cancel tasks and update status
-	if pr.IsCancelled() {
+	// If the pipelinerun is cancelled or superseded, cancel tasks and update status
+	if pr.IsCancelled() || pr.IsSuperseded() {
 		err := cancelPipelineRun(ctx, logger, pr, c.PipelineClientSet)
 		return c.finishReconcileUpdateEmitEvents(ctx, pr, before, err)
 	}
@@ -295,6 +346,10 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, pr *v1.PipelineRun) pkgr
 				waitTime = finallyWaitTime
 			}
 		}
+		// Snooze until the first approval gate times out if it's sooner.
+		if gatesWaitTime, ok := c.approvalGatesWaitTime(pr); ok && gatesWaitTime < waitTime {
+			waitTime = gatesWaitTime
+		}
 		return controller.NewRequeueAfter(waitTime)
 	}
 	return nil
@@ -309,6 +364,61 @@ func (c *Reconciler) durationAndCountMetrics(ctx context.Context, pr *v1.Pipelin
 		if err != nil {
 			logger.Warnf("Failed to log the metrics : %v", err)
 		}
+		if err := c.metrics.PhaseDurationsAndCount(pr, beforeCondition, c.finallyChildRuns(pr)); err != nil {
+			logger.Warnf("Failed to log the metrics : %v", err)
+		}
+	}
+}
+
+// finallyChildRuns returns the timestamps and outcomes of the child runs of the finally
+// tasks of the PipelineRun, as found in the informer caches.
+func (c *Reconciler) finallyChildRuns(pr *v1.PipelineRun) []pipelinerunmetrics.ChildRun {
+	if pr.Status.PipelineSpec == nil || len(pr.Status.PipelineSpec.Finally) == 0 {
+		return nil
+	}
+	finallyTaskNames := sets.New[string]()
+	for _, t := range pr.Status.PipelineSpec.Finally {
+		finallyTaskNames.Insert(t.Name)
+	}
+	var runs []pipelinerunmetrics.ChildRun
+	for _, cr := range pr.Status.ChildReferences {
+		if !finallyTaskNames.Has(cr.PipelineTaskName) {
+			continue
+		}
+		switch cr.Kind {
+		case taskRun:
+			tr, err := c.taskRunLister.TaskRuns(pr.Namespace).Get(cr.Name)
+			if err != nil {
+				continue
+			}
+			runs = append(runs, pipelinerunmetrics.ChildRun{
+				PipelineTaskName: cr.PipelineTaskName,
+				StartTime:        tr.Status.StartTime,
+				CompletionTime:   tr.Status.CompletionTime,
+				Condition:        tr.Status.GetCondition(apis.ConditionSucceeded),
+			})
+		case customRun:
+			r, err := c.customRunLister.CustomRuns(pr.Namespace).Get(cr.Name)
+			if err != nil {
+				continue
+			}
+			runs = append(runs, pipelinerunmetrics.ChildRun{
+				PipelineTaskName: cr.PipelineTaskName,
+				StartTime:        r.Status.StartTime,
+				CompletionTime:   r.Status.CompletionTime,
+				Condition:        r.Status.GetCondition(apis.ConditionSucceeded),
+			})
+		}
+	}
+	return runs
+}
+
+func (c *Reconciler) queuedDurationMetrics(ctx context.Context, pr *v1.PipelineRun, beforeSummary *v1.PipelineRunSummary) {
+	ctx, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "queuedDurationMetrics")
+	defer span.End()
+	logger := logging.FromContext(ctx)
+	if err := c.metrics.TaskRunsQueuedDuration(pr, beforeSummary); err != nil {
+		logger.Warnf("Failed to log the metrics : %v", err)
 	}
 }
 
@@ -317,9 +427,12 @@ func (c *Reconciler) finishReconcileUpdateEmitEvents(ctx context.Context, pr *v1
 	defer span.End()
 	logger := logging.FromContext(ctx)
 
+	updateSummary(pr)
+	debuglog.FromContext(ctx).Write(&pr.Status.Status)
+	tknreconciler.ObserveGeneration(pr, &pr.Status.Status)
 	afterCondition := pr.Status.GetCondition(apis.ConditionSucceeded)
 	events.Emit(ctx, beforeCondition, afterCondition, pr)
-	_, err := c.updateLabelsAndAnnotations(ctx, pr)
+	newPr, err := c.updateLabelsAndAnnotations(ctx, pr)
 	if err != nil {
 		logger.Warn("Failed to update PipelineRun labels/annotations", zap.Error(err))
 		events.EmitError(controller.GetEventRecorder(ctx), err, pr)
@@ -329,6 +442,9 @@ func (c *Reconciler) finishReconcileUpdateEmitEvents(ctx context.Context, pr *v1
 	if controller.IsPermanentError(previousError) {
 		return controller.NewPermanentError(errs)
 	}
+	if errs == nil {
+		return tknreconciler.RequeueIfSpecUpdated(ctx, pr, newPr)
+	}
 	return errs
 }
 
@@ -340,15 +456,17 @@ func (c *Reconciler) resolvePipelineState(
 	pipelineMeta *metav1.ObjectMeta,
 	pr *v1.PipelineRun,
 	pst resources.PipelineRunState,
+	walk *resources.ResolutionWalk,
 ) (resources.PipelineRunState, error) {
 	ctx, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "resolvePipelineState")
 	defer span.End()
+	childRefsByPipelineTask := resources.IndexChildReferences(pr.Status.ChildReferences)
 	// Resolve each pipeline task individually because they each could have a different reference context (remote or local).
 	for _, pipelineTask := range pipelineTasks {
 		// We need the TaskRun name to ensure that we don't perform an additional remote resolution request for a PipelineTask
 		// in the TaskRun reconciler.
 		trName := resources.GetTaskRunName(
-			pr.Status.ChildReferences,
+			childRefsByPipelineTask[pipelineTask.Name],
 			pipelineTask.Name,
 			pr.Name,
 		)
@@ -371,6 +489,7 @@ func (c *Reconciler) resolvePipelineState(
 			pr.Spec.TaskRunTemplate.ServiceAccountName,
 			vp,
 		)
+		getTaskFunc = walk.GetTask(pipelineTask.TaskRef, getTaskFunc)
 
 		getTaskRunFunc := func(name string) (*v1.TaskRun, error) {
 			return c.taskRunLister.TaskRuns(pr.Namespace).Get(name)
@@ -384,21 +503,35 @@ func (c *Reconciler) resolvePipelineState(
 			return r, nil
 		}
 
-		resolvedTask, err := resources.ResolvePipelineTask(ctx,
-			*pr,
-			getTaskFunc,
-			getTaskRunFunc,
-			getCustomRunFunc,
-			pipelineTask,
-			pst,
-		)
+		// Only pass the children of this PipelineTask, so that resolving each PipelineTask
+		// doesn't scan the child references of the whole PipelineRun.
+		prForTask := *pr
+		prForTask.Status.ChildReferences = childRefsByPipelineTask[pipelineTask.Name]
+		var resolvedTask *resources.ResolvedPipelineTask
+		// The Pipelines in Pipelines aren't run yet, but their references are
+		// walked so that their cycles and depth are reported.
+		if pipelineTask.PipelineRef != nil {
+			err = c.walkPipelineRef(ctx, pr, pipelineTask.PipelineRef, walk, vp)
+		}
+		if err == nil {
+			resolvedTask, err = resources.ResolvePipelineTask(ctx,
+				prForTask,
+				getTaskFunc,
+				getTaskRunFunc,
+				getCustomRunFunc,
+				pipelineTask,
+				pst,
+			)
+		}
 		if err != nil {
 			if resolutioncommon.IsErrTransient(err) {
 				return nil, err
 			}
 			if errors.Is(err, remote.ErrRequestInProgress) {
+				debuglog.FromContext(ctx).Record(debuglog.StageResolution, pipelineTask.Name, "in progress", "awaiting remote Task")
 				return nil, err
 			}
+			debuglog.FromContext(ctx).Record(debuglog.StageResolution, pipelineTask.Name, "failed", err.Error())
 			var nfErr *resources.TaskNotFoundError
 			if errors.As(err, &nfErr) {
 				pr.Status.MarkFailed(v1.PipelineRunReasonCouldntGetTask.String(),
@@ -413,6 +546,7 @@ func (c *Reconciler) resolvePipelineState(
 		}
 
 		if resolvedTask.ResolvedTask != nil && resolvedTask.ResolvedTask.VerificationResult != nil {
+			debuglog.FromContext(ctx).RecordVerification(pipelineTask.Name, resolvedTask.ResolvedTask.VerificationResult)
 			cond, err := conditionFromVerificationResult(resolvedTask.ResolvedTask.VerificationResult, pr, pipelineTask.Name)
 			pr.Status.SetCondition(cond)
 			if err != nil {
@@ -426,24 +560,63 @@ func (c *Reconciler) resolvePipelineState(
 	return pst, nil
 }
 
+// walkPipelineRef walks the references of the Pipeline referenced by the
+// PipelineRef of a PipelineTask of the PipelineRun, and of its own
+// PipelineTasks, with the ResolutionWalk of the PipelineRun.
+func (c *Reconciler) walkPipelineRef(ctx context.Context, pr *v1.PipelineRun, pipelineRef *v1.PipelineRef, walk *resources.ResolutionWalk, vp []*v1alpha1.VerificationPolicy) error {
+	prForPipeline := *pr
+	prForPipeline.Spec.PipelineRef = pipelineRef
+	prForPipeline.Status.PipelineSpec = nil
+	getPipelineFunc := resources.GetPipelineFunc(ctx, c.KubeClientSet, c.PipelineClientSet, c.resolutionRequester, &prForPipeline, vp)
+	return walk.WalkPipelineRef(ctx, pipelineRef, getPipelineFunc, func(ctx context.Context, pipelineTask v1.PipelineTask) error {
+		switch {
+		case pipelineTask.TaskRef != nil:
+			getTaskFunc := tresources.GetTaskFunc(
+				ctx,
+				c.KubeClientSet,
+				c.PipelineClientSet,
+				c.resolutionRequester,
+				pr,
+				pipelineTask.TaskRef,
+				resources.GetTaskRunName(nil, pipelineTask.Name, pr.Name),
+				pr.Namespace,
+				pr.Spec.TaskRunTemplate.ServiceAccountName,
+				vp,
+			)
+			_, _, _, err := walk.GetTask(pipelineTask.TaskRef, getTaskFunc)(ctx, pipelineTask.TaskRef.Name)
+			return err
+		case pipelineTask.PipelineRef != nil:
+			return c.walkPipelineRef(ctx, pr, pipelineTask.PipelineRef, walk, vp)
+		default:
+			return nil
+		}
+	})
+}
+
 func (c *Reconciler) reconcile(ctx context.Context, pr *v1.PipelineRun, getPipelineFunc rprp.GetPipeline, beforeCondition *apis.Condition) error {
 	ctx, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "reconcile")
 	defer span.End()
 	defer c.durationAndCountMetrics(ctx, pr, beforeCondition)
 	logger := logging.FromContext(ctx)
+	trail := debuglog.FromContext(ctx)
 	pr.SetDefaults(ctx)
 
 	// When pipeline run is pending, return to avoid creating the task
 	if pr.IsPending() {
 		pr.Status.MarkRunning(v1.PipelineRunReasonPending.String(), fmt.Sprintf("PipelineRun %q is pending", pr.Name))
+		trail.Record(debuglog.StageScheduling, "", "not started", "the PipelineRun is pending")
 		return nil
 	}
 
+	// The PipelineSpec is only stored in the status on the first reconcile
+	// which resolves it.
+	pipelineSpecStored := pr.Status.PipelineSpec != nil
 	pipelineMeta, pipelineSpec, err := rprp.GetPipelineData(ctx, pr, getPipelineFunc)
 	switch {
 	case errors.Is(err, remote.ErrRequestInProgress):
 		message := fmt.Sprintf("PipelineRun %s/%s awaiting remote resource", pr.Namespace, pr.Name)
 		pr.Status.MarkRunning(v1.PipelineRunReasonResolvingPipelineRef.String(), message)
+		trail.Record(debuglog.StageResolution, "", "in progress", "awaiting remote Pipeline")
 		return nil
 	case errors.Is(err, apiserver.ErrReferencedObjectValidationFailed), errors.Is(err, apiserver.ErrCouldntValidateObjectPermanent):
 		logger.Errorf("Failed dryRunValidation for PipelineRun %s: %w", pr.Name, err)
@@ -455,18 +628,43 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1.PipelineRun, getPipel
 		return err
 	case err != nil:
 		logger.Errorf("Failed to determine Pipeline spec to use for pipelinerun %s: %v", pr.Name, err)
+		trail.Record(debuglog.StageResolution, "", "failed", err.Error())
 		pr.Status.MarkFailed(v1.PipelineRunReasonCouldntGetPipeline.String(),
 			"Error retrieving pipeline for pipelinerun %s/%s: %s",
 			pr.Namespace, pr.Name, err)
 		return controller.NewPermanentError(err)
 	default:
+		trail.Record(debuglog.StageResolution, pipelineMeta.Name, "resolved", "")
 		// Store the fetched PipelineSpec on the PipelineRun for auditing
 		if err := storePipelineSpecAndMergeMeta(ctx, pr, pipelineSpec, pipelineMeta); err != nil {
 			logger.Errorf("Failed to store PipelineSpec on PipelineRun.Status for pipelinerun %s: %v", pr.Name, err)
 		}
 	}
 
+	expandedParamsFrom := len(pipelineSpec.ParamsFrom) > 0
+	if expandedParamsFrom {
+		params, expanded, err := tresources.ExpandPipelineParamsFrom(ctx, pipelineSpec.Params, pipelineSpec.ParamsFrom, tresources.GetParameterSetFunc(c.PipelineClientSet, pr.Namespace))
+		switch {
+		case errors.Is(err, tresources.ErrParameterSetNotFound), errors.Is(err, tresources.ErrCircularParameterSetReference):
+			pr.Status.MarkFailed(v1.PipelineRunReasonInvalidParameterSet.String(),
+				"PipelineRun %s/%s can't import the params of Pipeline %s/%s: %s",
+				pr.Namespace, pr.Name, pr.Namespace, pipelineMeta.Name, pipelineErrors.WrapUserError(err))
+			return controller.NewPermanentError(err)
+		case err != nil:
+			logger.Errorf("Failed to expand the paramsFrom of pipelinerun %s: %v", pr.Name, err)
+			return err
+		}
+		// The expanded params are stored with the PipelineSpec, and the expanded
+		// ParameterSets are recorded for the PipelineRun to be reproducible.
+		pipelineSpec.Params = params
+		pipelineSpec.ParamsFrom = nil
+		if pr.Status.ExpandedParameterSets == nil {
+			pr.Status.ExpandedParameterSets = expanded
+		}
+	}
+
 	if pipelineMeta.VerificationResult != nil {
+		trail.RecordVerification(pipelineMeta.Name, pipelineMeta.VerificationResult)
 		cond, err := conditionFromVerificationResult(pipelineMeta.VerificationResult, pr, pipelineMeta.Name)
 		pr.Status.SetCondition(cond)
 		if err != nil {
@@ -497,7 +695,7 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1.PipelineRun, getPipel
 		return controller.NewPermanentError(err)
 	}
 
-	if err := pipelineSpec.Validate(ctx); err != nil {
+	if err := pipelineSpec.Validate(ctx).Filter(apis.ErrorLevel); err != nil {
 		// This Run has failed, so we need to mark it as failed and stop reconciling it
 		pr.Status.MarkFailed(v1.PipelineRunReasonFailedValidation.String(),
 			"Pipeline %s/%s can't be Run; it has an invalid spec: %s",
@@ -505,6 +703,17 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1.PipelineRun, getPipel
 		return controller.NewPermanentError(err)
 	}
 
+	// The usage of the params imported by a referenced Pipeline couldn't be
+	// validated when it was created.
+	if expandedParamsFrom && pr.Spec.PipelineRef != nil {
+		if err := pipelineSpec.ValidateParameterUsage(ctx); err != nil {
+			pr.Status.MarkFailed(v1.PipelineRunReasonFailedValidation.String(),
+				"Pipeline %s/%s can't be Run; it has an invalid spec: %s",
+				pipelineMeta.Namespace, pipelineMeta.Name, pipelineErrors.WrapUserError(err))
+			return controller.NewPermanentError(err)
+		}
+	}
+
 	// Ensure that the PipelineRun provides all the parameters required by the Pipeline
 	if err := resources.ValidateRequiredParametersProvided(&pipelineSpec.Params, &pr.Spec.Params); err != nil {
 		// This Run has failed, so we need to mark it as failed and stop reconciling it
@@ -568,6 +777,28 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1.PipelineRun, getPipel
 		return controller.NewPermanentError(err)
 	}
 
+	// Prune the Pipeline to the PipelineTasks selected by the tasksFilter, once the
+	// whole Pipeline has been validated.
+	var filteredOutTasks, filteredOutFinallyTasks []string
+	if pr.Spec.TasksFilter != nil {
+		pipelineSpec, filteredOutTasks, filteredOutFinallyTasks, err = resources.FilterPipelineTasks(pipelineSpec, pr.Spec.TasksFilter)
+		if err != nil {
+			pr.Status.MarkFailed(v1.PipelineRunReasonInvalidTasksFilter.String(),
+				"PipelineRun %s/%s can't filter the PipelineTasks of Pipeline %s/%s: %s",
+				pr.Namespace, pr.Name, pr.Namespace, pipelineMeta.Name, err)
+			return controller.NewPermanentError(err)
+		}
+		if d, err = dag.Build(v1.PipelineTaskList(pipelineSpec.Tasks), v1.PipelineTaskList(pipelineSpec.Tasks).Deps()); err == nil {
+			dfinally, err = dag.Build(v1.PipelineTaskList(pipelineSpec.Finally), map[string][]string{})
+		}
+		if err != nil {
+			pr.Status.MarkFailed(v1.PipelineRunReasonInvalidGraph.String(),
+				"PipelineRun %s/%s's filtered Pipeline DAG is invalid: %s",
+				pr.Namespace, pr.Name, pipelineErrors.WrapUserError(err))
+			return controller.NewPermanentError(err)
+		}
+	}
+
 	resources.ApplyParametersToWorkspaceBindings(ctx, pr)
 	// Make a deep copy of the Pipeline and its Tasks before value substitution.
 	// This is used to find referenced pipeline-level params at each PipelineTask when validate param enum subset requirement
@@ -581,6 +812,14 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1.PipelineRun, getPipel
 	pipelineSpec = resources.ApplyWorkspaces(pipelineSpec, pr)
 	// Update pipelinespec of pipelinerun's status field
 	pr.Status.PipelineSpec = pipelineSpec
+	// Unresolvable references in display names and descriptions don't fail the
+	// PipelineRun, they are left verbatim. Warn about them once, on the first
+	// reconcile which resolves the PipelineSpec.
+	if unresolved := resources.UnresolvedDisplayReferences(pipelineSpec); len(unresolved) > 0 && !pipelineSpecStored {
+		controller.GetEventRecorder(ctx).Eventf(pr, corev1.EventTypeWarning, "UnresolvedReferences",
+			"Display names and descriptions of PipelineRun %s/%s contain references which can't be resolved: %s",
+			pr.Namespace, pr.Name, strings.Join(unresolved, ", "))
+	}
 
 	// validate pipelineSpec after apply parameters
 	if err := validatePipelineSpecAfterApplyParameters(ctx, pipelineSpec); err != nil {
@@ -625,8 +864,12 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1.PipelineRun, getPipel
 		}
 	}
 
+	// The identical references of the PipelineTasks are resolved once for
+	// both iterations.
+	walk := resources.NewResolutionWalk(config.FromContextOrDefaults(ctx).Defaults.DefaultMaxResolutionDepth, pipelineMeta.RefSource)
+
 	// First iteration
-	pipelineRunState, err := c.resolvePipelineState(ctx, ranOrRunningTasks, pipelineMeta.ObjectMeta, pr, resources.PipelineRunState{})
+	pipelineRunState, err := c.resolvePipelineState(ctx, ranOrRunningTasks, pipelineMeta.ObjectMeta, pr, resources.PipelineRunState{}, walk)
 	switch {
 	case errors.Is(err, remote.ErrRequestInProgress):
 		message := fmt.Sprintf("PipelineRun %s/%s awaiting remote resource", pr.Namespace, pr.Name)
@@ -638,7 +881,7 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1.PipelineRun, getPipel
 	}
 
 	// Second iteration
-	pipelineRunState, err = c.resolvePipelineState(ctx, notStartedTasks, pipelineMeta.ObjectMeta, pr, pipelineRunState)
+	pipelineRunState, err = c.resolvePipelineState(ctx, notStartedTasks, pipelineMeta.ObjectMeta, pr, pipelineRunState, walk)
 	switch {
 	case errors.Is(err, remote.ErrRequestInProgress):
 		message := fmt.Sprintf("PipelineRun %s/%s awaiting remote resource", pr.Namespace, pr.Name)
@@ -659,6 +902,9 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1.PipelineRun, getPipel
 		TimeoutsState: resources.PipelineRunTimeoutsState{
 			Clock: c.Clock,
 		},
+		StartFinallyOnCancel:    config.FromContextOrDefaults(ctx).FeatureFlags.StartFinallyOnCancel,
+		FilteredOutTasks:        filteredOutTasks,
+		FilteredOutFinallyTasks: filteredOutFinallyTasks,
 	}
 	if pr.Status.StartTime != nil {
 		pipelineRunFacts.TimeoutsState.StartTime = &pr.Status.StartTime.Time
@@ -689,6 +935,15 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1.PipelineRun, getPipel
 				return controller.NewPermanentError(err)
 			}
 
+			// Ensure that the stepSpecs and sidecarSpecs of the TaskRunSpecs
+			// match the steps and sidecars of the resolved Task.
+			if err := resources.ValidateTaskRunSpecOverrides(pr, rpt); err != nil {
+				pr.Status.MarkFailed(v1.PipelineRunReasonInvalidTaskRunSpec.String(),
+					"PipelineRun %s/%s doesn't define taskRunSpecs correctly: %s",
+					pr.Namespace, pr.Name, err)
+				return controller.NewPermanentError(err)
+			}
+
 			if config.FromContextOrDefaults(ctx).FeatureFlags.EnableParamEnum {
 				if err := resources.ValidateParamEnumSubset(originalTasks[i].Params, pipelineSpec.Params, rpt.ResolvedTask); err != nil {
 					logger.Errorf("Failed to validate pipelinerun %q with error %w", pr.Name, err)
@@ -712,10 +967,24 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1.PipelineRun, getPipel
 		}
 	}
 
+	// Warn about the fields of the CustomRuns which the controllers of their
+	// custom tasks declare unsupported, once per set of fields, until the
+	// CustomRuns are done.
+	for _, rpt := range pipelineRunFacts.State {
+		for _, cr := range rpt.CustomRuns {
+			c.warnUnsupportedFields(ctx, pr, cr)
+		}
+	}
+
+	if err := c.reconcileApprovalGates(ctx, pr, pipelineRunFacts); err != nil {
+		logger.Errorf("Failed to reconcile the approval gates of PipelineRun %s: %v", pr.Name, err)
+		return err
+	}
+
 	// check if pipeline run is gracefully cancelled and there are active pipeline task runs, which require cancelling
 	if pr.IsGracefullyCancelled() && pipelineRunFacts.IsRunning() {
 		// If the pipelinerun is cancelled, cancel tasks, but run finally
-		err := gracefullyCancelPipelineRun(ctx, logger, pr, c.PipelineClientSet)
+		err := gracefullyCancelPipelineRun(ctx, logger, pr, c.PipelineClientSet, pipelineRunFacts.GetTaskNames())
 		if err != nil {
 			// failed to cancel tasks, maybe retry would help (don't return permanent error)
 			return err
@@ -744,6 +1013,10 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1.PipelineRun, getPipel
 			return controller.NewPermanentError(err)
 		}
 
+		if err := c.checkResourceBudget(ctx, pr, pipelineRunFacts); err != nil {
+			return err
+		}
+
 		aaBehavior, err := affinityassistant.GetAffinityAssistantBehavior(ctx)
 		if err != nil {
 			return controller.NewPermanentError(err)
@@ -829,9 +1102,16 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1.PipelineRun, getPipel
 	after = pr.Status.GetCondition(apis.ConditionSucceeded)
 	pr.Status.StartTime = pipelineRunFacts.State.AdjustStartTime(pr.Status.StartTime)
 
-	pr.Status.ChildReferences = pipelineRunFacts.GetChildReferences()
+	childRefs, changed := resources.UpdateChildReferences(pr.Status.ChildReferences, pipelineRunFacts.GetChildReferences())
+	if changed > 0 {
+		logger.Debugf("Updating %d of the %d child references of PipelineRun %s", changed, len(childRefs), pr.Name)
+	}
+	pr.Status.ChildReferences = childRefs
 
 	pr.Status.SkippedTasks = pipelineRunFacts.GetSkippedTasks()
+	beforeSummary := pr.Status.Summary
+	pr.Status.Summary = pipelineRunFacts.GetPipelineRunSummary()
+	c.queuedDurationMetrics(ctx, pr, beforeSummary)
 	pipelineTaskStatus := pipelineRunFacts.GetPipelineTaskStatus()
 	finalPipelineTaskStatus := pipelineRunFacts.GetPipelineFinalTaskStatus()
 	pipelineTaskStatus = kmap.Union(pipelineTaskStatus, finalPipelineTaskStatus)
@@ -843,6 +1123,7 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1.PipelineRun, getPipel
 			pipelineRunFacts.State.GetTaskRunsResults(),
 			pipelineRunFacts.State.GetRunsResults(),
 			pipelineTaskStatus,
+			pr.Status.SkippedTasks,
 		)
 		if err != nil {
 			pr.Status.MarkFailed(v1.PipelineRunReasonCouldntGetPipelineResult.String(),
@@ -850,6 +1131,14 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1.PipelineRun, getPipel
 				pr.Name, err)
 			return err
 		}
+		if config.FromContextOrDefaults(ctx).FeatureFlags.EnableResultSchemaValidation {
+			if err := resources.ValidateObjectPipelineResults(pipelineSpec.Results, pr.Status.Results, pipelineRunFacts.State); err != nil {
+				pr.Status.MarkFailed(v1.PipelineRunReasonResultSchemaMismatch.String(),
+					"PipelineResults of PipelineRun %s don't match the properties declared by their Tasks: %s",
+					pr.Name, err)
+				return controller.NewPermanentError(err)
+			}
+		}
 	}
 
 	logger.Infof("PipelineRun %s status is being set to %s", pr.Name, after)
@@ -865,6 +1154,7 @@ func (c *Reconciler) runNextSchedulableTask(ctx context.Context, pr *v1.Pipeline
 
 	logger := logging.FromContext(ctx)
 	recorder := controller.GetEventRecorder(ctx)
+	trail := debuglog.FromContext(ctx)
 
 	// nextRpts holds a list of pipeline tasks which should be executed next
 	nextRpts, err := pipelineRunFacts.DAGExecutionQueue()
@@ -872,6 +1162,9 @@ func (c *Reconciler) runNextSchedulableTask(ctx context.Context, pr *v1.Pipeline
 		logger.Errorf("Error getting potential next tasks for valid pipelinerun %s: %v", pr.Name, err)
 		return controller.NewPermanentError(err)
 	}
+	if trail.Enabled() {
+		recordSchedulingDecisions(trail, pipelineRunFacts, nextRpts)
+	}
 
 	for _, rpt := range nextRpts {
 		// Check for Missing Result References
@@ -880,6 +1173,7 @@ func (c *Reconciler) runNextSchedulableTask(ctx context.Context, pr *v1.Pipeline
 		err := resources.CheckMissingResultReferences(pipelineRunFacts.State, rpt)
 		if err != nil {
 			logger.Infof("Failed to resolve task result reference for %q with error %v", pr.Name, err)
+			trail.Record(debuglog.StageScheduling, rpt.PipelineTask.Name, "not scheduled", err.Error())
 			// If there is an error encountered, no new task
 			// will be scheduled, hence nextRpts should be empty
 			// If finally tasks are found, then those tasks will
@@ -901,6 +1195,7 @@ func (c *Reconciler) runNextSchedulableTask(ctx context.Context, pr *v1.Pipeline
 			resolvedResultRefs, _, err := resources.ResolveResultRef(pipelineRunFacts.State, rpt)
 			if err != nil {
 				logger.Infof("Final task %q is not executed as it could not resolve task params for %q: %v", rpt.PipelineTask.Name, pr.Name, err)
+				trail.Record(debuglog.StageScheduling, rpt.PipelineTask.Name, "not scheduled", err.Error())
 				continue
 			}
 			resources.ApplyTaskResults(resources.PipelineRunState{rpt}, resolvedResultRefs)
@@ -929,7 +1224,15 @@ func (c *Reconciler) runNextSchedulableTask(ctx context.Context, pr *v1.Pipeline
 			c.setFinallyStartedTimeIfNeeded(pr, pipelineRunFacts)
 		}
 
-		if rpt == nil || rpt.Skip(pipelineRunFacts).IsSkipped || rpt.IsFinallySkipped(pipelineRunFacts).IsSkipped {
+		if rpt == nil {
+			continue
+		}
+		if skip := rpt.Skip(pipelineRunFacts); skip.IsSkipped {
+			trail.Record(debuglog.StageScheduling, rpt.PipelineTask.Name, "skipped", string(skip.SkippingReason))
+			continue
+		}
+		if skip := rpt.IsFinallySkipped(pipelineRunFacts); skip.IsSkipped {
+			trail.Record(debuglog.StageScheduling, rpt.PipelineTask.Name, "skipped", string(skip.SkippingReason))
 			continue
 		}
 
@@ -943,6 +1246,14 @@ func (c *Reconciler) runNextSchedulableTask(ctx context.Context, pr *v1.Pipeline
 			return controller.NewPermanentError(err)
 		}
 
+		// Validate the workspace subPaths after apply substitutions from Task Results
+		if err := resources.ValidateWorkspaceSubPaths(rpt); err != nil {
+			logger.Errorf("Failed to validate the workspaces of %q with error %v", pr.Name, err)
+			pr.Status.MarkFailed(v1.PipelineRunReasonInvalidWorkspaceBinding.String(),
+				"Failed to validate the workspaces of pipeline task %q: %s", rpt.PipelineTask.Name, err)
+			return controller.NewPermanentError(err)
+		}
+
 		// Validate parameter types in matrix after apply substitutions from Task Results
 		if rpt.PipelineTask.IsMatrixed() {
 			if err := resources.ValidateParameterTypesInMatrix(pipelineRunFacts.State); err != nil {
@@ -960,6 +1271,7 @@ func (c *Reconciler) runNextSchedulableTask(ctx context.Context, pr *v1.Pipeline
 				err = fmt.Errorf("error creating CustomRuns called %s for PipelineTask %s from PipelineRun %s: %w", rpt.CustomRunNames, rpt.PipelineTask.Name, pr.Name, err)
 				return err
 			}
+			trail.Record(debuglog.StageScheduling, rpt.PipelineTask.Name, "scheduled", "created CustomRuns "+strings.Join(rpt.CustomRunNames, ", "))
 		} else {
 			rpt.TaskRuns, err = c.createTaskRuns(ctx, rpt, pr, pipelineRunFacts)
 			if err != nil {
@@ -967,12 +1279,30 @@ func (c *Reconciler) runNextSchedulableTask(ctx context.Context, pr *v1.Pipeline
 				err = fmt.Errorf("error creating TaskRuns called %s for PipelineTask %s from PipelineRun %s: %w", rpt.TaskRunNames, rpt.PipelineTask.Name, pr.Name, err)
 				return err
 			}
+			trail.Record(debuglog.StageScheduling, rpt.PipelineTask.Name, "scheduled", "created TaskRuns "+strings.Join(rpt.TaskRunNames, ", "))
 		}
 	}
 
 	return nil
 }
 
+// recordSchedulingDecisions records the DAG tasks which are candidates to be
+// scheduled and why the others can't be scheduled yet.
+func recordSchedulingDecisions(trail *debuglog.Trail, facts *resources.PipelineRunFacts, candidates resources.PipelineRunState) {
+	switch {
+	case facts.IsCancelled() || facts.IsGracefullyCancelled():
+		trail.Record(debuglog.StageScheduling, "", "not scheduling new tasks", "the PipelineRun is cancelled")
+	case facts.IsStopping() || facts.IsGracefullyStopped():
+		trail.Record(debuglog.StageScheduling, "", "not scheduling new tasks", "the PipelineRun is stopping")
+	}
+	for _, rpt := range candidates {
+		trail.Record(debuglog.StageScheduling, rpt.PipelineTask.Name, "candidate", "the tasks it depends on are done")
+	}
+	for _, blocked := range facts.BlockedDAGTasks() {
+		trail.Record(debuglog.StageScheduling, blocked.Name, "not scheduled", "waiting for "+strings.Join(blocked.WaitingFor, ", "))
+	}
+}
+
 // setFinallyStartedTimeIfNeeded sets the PipelineRun.Status.FinallyStartedTime to the current time if it's nil.
 func (c *Reconciler) setFinallyStartedTimeIfNeeded(pr *v1.PipelineRun, facts *resources.PipelineRunFacts) {
 	if pr.Status.FinallyStartTime == nil {
@@ -1012,10 +1342,12 @@ func (c *Reconciler) createTaskRuns(ctx context.Context, rpt *resources.Resolved
 	var taskRuns []*v1.TaskRun
 	for i, taskRunName := range rpt.TaskRunNames {
 		var params v1.Params
+		combinationIndex := -1
 		if len(matrixCombinations) > i {
 			params = matrixCombinations[i]
+			combinationIndex = i
 		}
-		taskRun, err := c.createTaskRun(ctx, taskRunName, params, rpt, pr, facts)
+		taskRun, err := c.createTaskRun(ctx, taskRunName, params, combinationIndex, rpt, pr, facts)
 		if err != nil {
 			err := c.handleRunCreationError(ctx, pr, err)
 			return nil, err
@@ -1026,7 +1358,10 @@ func (c *Reconciler) createTaskRuns(ctx context.Context, rpt *resources.Resolved
 	return taskRuns, nil
 }
 
-func (c *Reconciler) createTaskRun(ctx context.Context, taskRunName string, params v1.Params, rpt *resources.ResolvedPipelineTask, pr *v1.PipelineRun, facts *resources.PipelineRunFacts) (*v1.TaskRun, error) {
+// createTaskRun creates the TaskRun of the PipelineTask with the params, and
+// the index of its matrix combination if the PipelineTask is matrixed or else
+// -1.
+func (c *Reconciler) createTaskRun(ctx context.Context, taskRunName string, params v1.Params, combinationIndex int, rpt *resources.ResolvedPipelineTask, pr *v1.PipelineRun, facts *resources.PipelineRunFacts) (*v1.TaskRun, error) {
 	ctx, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "createTaskRun")
 	defer span.End()
 	logger := logging.FromContext(ctx)
@@ -1060,6 +1395,16 @@ func (c *Reconciler) createTaskRun(ctx context.Context, taskRunName string, para
 	if rpt.PipelineTask.OnError == v1.PipelineTaskContinue {
 		tr.Annotations[v1.PipelineTaskOnErrorAnnotation] = string(v1.PipelineTaskContinue)
 	}
+	// The pod builder injects the index of the matrix combination into the
+	// steps with the build metadata environment variables.
+	if combinationIndex >= 0 && config.FromContextOrDefaults(ctx).FeatureFlags.EnableBuildMetadataEnv {
+		tr.Annotations[v1.MatrixCombinationIndexAnnotation] = strconv.Itoa(combinationIndex)
+	}
+	if config.FromContextOrDefaults(ctx).FeatureFlags.RecordResolvedParams {
+		if err := recordResolvedParams(tr.Annotations, params, rpt, pr, facts); err != nil {
+			return nil, err
+		}
+	}
 
 	if rpt.PipelineTask.Timeout != nil {
 		tr.Spec.Timeout = rpt.PipelineTask.Timeout
@@ -1087,8 +1432,13 @@ func (c *Reconciler) createTaskRun(ctx context.Context, taskRunName string, para
 		tr.Annotations[workspace.AnnotationAffinityAssistantName] = aaAnnotationVal
 	}
 
+	if err := c.creationBreaker.Allow(ctx); err != nil {
+		return nil, err
+	}
 	logger.Infof("Creating a new TaskRun object %s for pipeline task %s", taskRunName, rpt.PipelineTask.Name)
-	return c.PipelineClientSet.TektonV1().TaskRuns(pr.Namespace).Create(ctx, tr, metav1.CreateOptions{})
+	tr, err = c.PipelineClientSet.TektonV1().TaskRuns(pr.Namespace).Create(ctx, tr, metav1.CreateOptions{})
+	c.creationBreaker.Record(ctx, err)
+	return tr, err
 }
 
 // handleRunCreationError marks the PipelineRun as failed and returns a permanent error if the run creation error is not retryable
@@ -1102,6 +1452,12 @@ func (c *Reconciler) handleRunCreationError(ctx context.Context, pr *v1.Pipeline
 		pr.Status.MarkFailed(v1.PipelineRunReasonCreateRunFailed.String(), err.Error())
 		return controller.NewPermanentError(err)
 	}
+	if retryAfter, ok := ratelimit.CreationRetryAfter(err); ok {
+		// The API server is overloaded: the creation of the runs is retried
+		// once it recovers rather than failing the PipelineRun.
+		pr.Status.MarkRunning(v1.PipelineRunReasonWaiting.String(), "Waiting for the API server to accept the creation of the runs: %v", err)
+		return controller.NewRequeueAfter(retryAfter)
+	}
 	return err
 }
 
@@ -1153,6 +1509,11 @@ func (c *Reconciler) createCustomRun(ctx context.Context, runName string, params
 		Labels:          getTaskrunLabels(pr, rpt.PipelineTask.Name, true),
 		Annotations:     getTaskrunAnnotations(pr),
 	}
+	if config.FromContextOrDefaults(ctx).FeatureFlags.RecordResolvedParams {
+		if err := recordResolvedParams(objectMeta.Annotations, params, rpt, pr, facts); err != nil {
+			return nil, err
+		}
+	}
 
 	// TaskRef, Params and Workspaces are converted to v1beta1 since CustomRuns
 	// is still in v1beta1 apiVersion
@@ -1160,6 +1521,9 @@ func (c *Reconciler) createCustomRun(ctx context.Context, runName string, params
 	if rpt.PipelineTask.TaskRef != nil {
 		customRef = &v1beta1.TaskRef{}
 		customRef.ConvertFrom(ctx, *rpt.PipelineTask.TaskRef)
+		if rpt.PipelineTask.TaskRef.IsApprovalGate() {
+			customRef.APIVersion = approvalGateAPIVersion
+		}
 	}
 
 	customRunParams := v1beta1.Params{}
@@ -1185,8 +1549,17 @@ func (c *Reconciler) createCustomRun(ctx context.Context, runName string, params
 			ServiceAccountName: taskRunSpec.ServiceAccountName,
 			Timeout:            taskTimeout,
 			Workspaces:         customRunWorkspaces,
+			PodTemplate:        taskRunSpec.PodTemplate,
+			ComputeResources:   taskRunSpec.ComputeResources,
 		},
 	}
+	if pr.Spec.Timeouts != nil {
+		r.Spec.Timeouts = &v1beta1.TimeoutFields{
+			Pipeline: pr.Spec.Timeouts.Pipeline,
+			Tasks:    pr.Spec.Timeouts.Tasks,
+			Finally:  pr.Spec.Timeouts.Finally,
+		}
+	}
 
 	if rpt.PipelineTask.TaskSpec != nil {
 		j, err := json.Marshal(rpt.PipelineTask.TaskSpec.Spec)
@@ -1446,7 +1819,8 @@ func combineTaskRunAndTaskSpecLabels(pr *v1.PipelineRun, pipelineTask *v1.Pipeli
 }
 
 func combineTaskRunAndTaskSpecAnnotations(pr *v1.PipelineRun, pipelineTask *v1.PipelineTask) map[string]string {
-	annotations := make(map[string]string)
+	// The source event annotations of the PipelineRun can't be overridden for its TaskRuns.
+	annotations := v1.SourceEventAnnotations(pr.Annotations)
 
 	taskRunSpec := pr.GetTaskRunSpec(pipelineTask.Name)
 	if taskRunSpec.Metadata != nil {
@@ -1479,17 +1853,31 @@ func (c *Reconciler) updateLabelsAndAnnotations(ctx context.Context, pr *v1.Pipe
 	if err != nil {
 		return nil, fmt.Errorf("error getting PipelineRun %s when updating labels/annotations: %w", pr.Name, err)
 	}
-	if !reflect.DeepEqual(pr.ObjectMeta.Labels, newPr.ObjectMeta.Labels) || !reflect.DeepEqual(pr.ObjectMeta.Annotations, newPr.ObjectMeta.Annotations) {
-		// Note that this uses Update vs. Patch because the former is significantly easier to test.
-		// If we want to switch this to Patch, then we will need to teach the utilities in test/controller.go
-		// to deal with Patch (setting resourceVersion, and optimistic concurrency checks).
+	if reflect.DeepEqual(pr.ObjectMeta.Labels, newPr.ObjectMeta.Labels) && reflect.DeepEqual(pr.ObjectMeta.Annotations, newPr.ObjectMeta.Annotations) {
+		return newPr, nil
+	}
+	// Note that this uses Update vs. Patch because the former is significantly easier to test.
+	// If we want to switch this to Patch, then we will need to teach the utilities in test/controller.go
+	// to deal with Patch (setting resourceVersion, and optimistic concurrency checks).
+	var updated *v1.PipelineRun
+	err = pkgreconciler.RetryUpdateConflicts(func(attempts int) error {
+		// The first attempt uses the lister's copy, the next ones the latest PipelineRun, so that
+		// the labels and annotations are merged into concurrent edits of the spec, like a
+		// cancellation, rather than overwriting them with a stale spec.
+		if attempts > 0 {
+			newPr, err = c.PipelineClientSet.TektonV1().PipelineRuns(pr.Namespace).Get(ctx, pr.Name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+		}
 		newPr = newPr.DeepCopy()
 		// Properly merge labels and annotations, as the labels *might* have changed during the reconciliation
 		newPr.Labels = kmap.Union(newPr.Labels, pr.Labels)
 		newPr.Annotations = kmap.Union(newPr.Annotations, pr.Annotations)
-		return c.PipelineClientSet.TektonV1().PipelineRuns(pr.Namespace).Update(ctx, newPr, metav1.UpdateOptions{})
-	}
-	return newPr, nil
+		updated, err = c.PipelineClientSet.TektonV1().PipelineRuns(pr.Namespace).Update(ctx, newPr, metav1.UpdateOptions{})
+		return err
+	})
+	return updated, err
 }
 
 func storePipelineSpecAndMergeMeta(ctx context.Context, pr *v1.PipelineRun, ps *v1.PipelineSpec, meta *resolutionutil.ResolvedObjectMeta) error {
@@ -1507,7 +1895,9 @@ func storePipelineSpecAndMergeMeta(ctx context.Context, pr *v1.PipelineRun, ps *
 		}
 
 		// Propagate annotations from Pipeline to PipelineRun. PipelineRun annotations take precedences over Pipeline.
-		pr.ObjectMeta.Annotations = kmap.Union(kmap.ExcludeKeys(meta.Annotations, tknreconciler.KubectlLastAppliedAnnotationKey), pr.ObjectMeta.Annotations)
+		// The source event annotations describe the event which caused the run, not the Pipeline.
+		pr.ObjectMeta.Annotations = kmap.Union(kmap.ExcludeKeys(meta.Annotations, tknreconciler.KubectlLastAppliedAnnotationKey,
+			v1.SourceEventIDAnnotationKey, v1.SourceEventTypeAnnotationKey, v1.SourceURLAnnotationKey), pr.ObjectMeta.Annotations)
 	}
 
 	// Propagate refSource from remote resolution to PipelineRun Status
@@ -1523,6 +1913,9 @@ func storePipelineSpecAndMergeMeta(ctx context.Context, pr *v1.PipelineRun, ps *
 		if meta != nil && meta.RefSource != nil && pr.Status.Provenance.RefSource == nil {
 			pr.Status.Provenance.RefSource = meta.RefSource
 		}
+		if pr.Status.Provenance.SourceEvent == nil {
+			pr.Status.Provenance.SourceEvent = v1.SourceEventFromAnnotations(pr.Annotations)
+		}
 	}
 
 	return nil
@@ -1686,6 +2079,7 @@ func updatePipelineRunStatusFromChildRefs(logger *zap.SugaredLogger, pr *v1.Pipe
 	for k := range childRefByName {
 		newChildRefs = append(newChildRefs, *childRefByName[k])
 	}
+	resources.SortChildReferences(newChildRefs)
 	pr.Status.ChildReferences = newChildRefs
 }
 
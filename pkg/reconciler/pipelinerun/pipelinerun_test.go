@@ -25,6 +25,8 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -41,6 +43,7 @@ import (
 	resolutionv1beta1 "github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/internal/affinityassistant"
 	resolutionutil "github.com/tektoncd/pipeline/pkg/internal/resolution"
+	"github.com/tektoncd/pipeline/pkg/reconciler/debuglog"
 	"github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
 	"github.com/tektoncd/pipeline/pkg/reconciler/events/k8sevent"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipeline/dag"
@@ -71,6 +74,7 @@ import (
 	"k8s.io/client-go/kubernetes/typed/core/v1/fake"
 	ktesting "k8s.io/client-go/testing"
 	testing2 "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	clock "k8s.io/utils/clock/testing"
 	"knative.dev/pkg/apis"
@@ -100,6 +104,7 @@ var (
 	ignoreCompletionTime     = cmpopts.IgnoreFields(v1.PipelineRunStatusFields{}, "CompletionTime")
 	ignoreFinallyStartTime   = cmpopts.IgnoreFields(v1.PipelineRunStatusFields{}, "FinallyStartTime")
 	ignoreProvenance         = cmpopts.IgnoreFields(v1.PipelineRunStatusFields{}, "Provenance")
+	ignoreSummary            = cmpopts.IgnoreFields(v1.PipelineRunStatusFields{}, "Summary")
 	trueb                    = true
 	simpleHelloWorldTask     = &v1.Task{ObjectMeta: baseObjectMeta("hello-world", "foo")}
 	simpleSomeTask           = &v1.Task{ObjectMeta: baseObjectMeta("some-task", "foo")}
@@ -413,6 +418,12 @@ spec:
 
 	verifyTaskRunStatusesCount(t, reconciledRun.Status, 2)
 	verifyTaskRunStatusesNames(t, reconciledRun.Status, tr1Name, tr2Name)
+
+	// The summary reflects the progress of the PipelineRun
+	expectedSummary := &v1.PipelineRunSummary{TotalTasks: 3}
+	if d := cmp.Diff(expectedSummary, reconciledRun.Status.Summary); d != "" {
+		t.Errorf("expected to see summary %v. Diff %s", expectedSummary, diff.PrintWantGot(d))
+	}
 }
 
 // TestReconcile_V1Beta1CustomTask runs "Reconcile" on a PipelineRun with one Custom
@@ -465,6 +476,8 @@ spec:
     kind: Example
   retries: 3
   serviceAccountName: default
+  timeouts:
+    pipeline: 1h0m0s
 `
 
 	tcs := []struct {
@@ -510,6 +523,8 @@ spec:
   - name: contextPipelineParam
     value: test-pipelinerun
   serviceAccountName: default
+  timeouts:
+    pipeline: 1h0m0s
   customSpec:
     apiVersion: example.dev/v0
     kind: Example
@@ -556,11 +571,61 @@ spec:
     apiVersion: example.dev/v0
     kind: Example
   serviceAccountName: default
+  timeouts:
+    pipeline: 1h0m0s
   workspaces:
   - name: taskws
     persistentVolumeClaim:
       claimName: myclaim
     subPath: foo/bar
+`),
+	}, {
+		name: "custom task with taskRunSpecs and timeouts",
+		pr: parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipelinerun
+  namespace: namespace
+spec:
+  pipelineSpec:
+    tasks:
+    - name: custom-task
+      taskRef:
+        apiVersion: example.dev/v0
+        kind: Example
+  taskRunTemplate:
+    podTemplate:
+      nodeSelector:
+        disktype: ssd
+  taskRunSpecs:
+  - pipelineTaskName: custom-task
+    serviceAccountName: custom-sa
+    podTemplate:
+      hostNetwork: true
+    computeResources:
+      requests:
+        memory: 1Gi
+  timeouts:
+    pipeline: 2h
+    tasks: 90m
+`),
+		wantRun: mustParseCustomRunWithObjectMeta(t,
+			taskRunObjectMeta("test-pipelinerun-custom-task", "namespace", "test-pipelinerun", "test-pipelinerun", "custom-task", false),
+			`
+spec:
+  customRef:
+    apiVersion: example.dev/v0
+    kind: Example
+  serviceAccountName: custom-sa
+  podTemplate:
+    nodeSelector:
+      disktype: ssd
+    hostNetwork: true
+  computeResources:
+    requests:
+      memory: 1Gi
+  timeouts:
+    pipeline: 2h0m0s
+    tasks: 1h30m0s
 `),
 	}}
 
@@ -599,6 +664,106 @@ spec:
 	}
 }
 
+func TestReconcile_V1Beta1CustomTaskUnsupportedFields(t *testing.T) {
+	names.TestingSeed()
+	prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipelinerun
+  namespace: namespace
+spec:
+  pipelineSpec:
+    tasks:
+    - name: custom-task
+      taskRef:
+        apiVersion: example.dev/v0
+        kind: Example
+  taskRunSpecs:
+  - pipelineTaskName: custom-task
+    podTemplate:
+      hostNetwork: true
+status:
+  childReferences:
+  - apiVersion: tekton.dev/v1beta1
+    kind: CustomRun
+    name: test-pipelinerun-custom-task
+    pipelineTaskName: custom-task
+`)}
+	crs := []*v1beta1.CustomRun{mustParseCustomRunWithObjectMeta(t,
+		taskRunObjectMetaWithAnnotations("test-pipelinerun-custom-task", "namespace", "test-pipelinerun",
+			"test-pipelinerun", "custom-task", false, map[string]string{
+				v1beta1.CustomRunUnsupportedFieldsAnnotation: "podTemplate, computeResources",
+			}),
+		`
+spec:
+  customRef:
+    apiVersion: example.dev/v0
+    kind: Example
+  serviceAccountName: default
+  podTemplate:
+    hostNetwork: true
+status:
+  conditions:
+  - type: Succeeded
+    status: Unknown
+    reason: Running
+`)}
+	d := test.Data{
+		PipelineRuns: prs,
+		CustomRuns:   crs,
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
+
+	wantEvents := []string{
+		"Normal Started",
+		"Warning CustomRunUnsupportedFields The controller of CustomRun test-pipelinerun-custom-task doesn't support its fields: podTemplate",
+		"Normal Running Tasks Completed: 0",
+	}
+	prt.reconcileRun("namespace", "test-pipelinerun", wantEvents, false)
+
+	// The warning isn't sent again while the unsupported fields don't change.
+	prt.reconcileRun("namespace", "test-pipelinerun", nil, false)
+	for {
+		select {
+		case event := <-prt.TestAssets.Recorder.Events:
+			if strings.Contains(event, "CustomRunUnsupportedFields") {
+				t.Errorf("expected no new warning on the second reconcile, got %q", event)
+			}
+		default:
+			return
+		}
+	}
+}
+
+func TestForgetUnsupportedFields(t *testing.T) {
+	cr := &v1beta1.CustomRun{ObjectMeta: metav1.ObjectMeta{Name: "deleted", Namespace: "namespace"}}
+	for _, tc := range []struct {
+		name string
+		obj  interface{}
+	}{{
+		name: "deleted CustomRun",
+		obj:  cr,
+	}, {
+		name: "tombstone of a deleted CustomRun",
+		obj:  cache.DeletedFinalStateUnknown{Key: "namespace/deleted", Obj: cr},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Reconciler{warnedUnsupportedFields: &sync.Map{}}
+			c.warnedUnsupportedFields.Store("namespace/deleted", "podTemplate")
+			c.warnedUnsupportedFields.Store("namespace/running", "podTemplate")
+
+			c.forgetUnsupportedFields(tc.obj)
+
+			if _, ok := c.warnedUnsupportedFields.Load("namespace/deleted"); ok {
+				t.Error("expected the unsupported fields of the deleted CustomRun to be forgotten")
+			}
+			if _, ok := c.warnedUnsupportedFields.Load("namespace/running"); !ok {
+				t.Error("expected the unsupported fields of the other CustomRun to be kept")
+			}
+		})
+	}
+}
+
 func TestReconcile_PipelineSpecTaskSpec(t *testing.T) {
 	// TestReconcile_PipelineSpecTaskSpec runs "Reconcile" on a PipelineRun that has an embedded PipelineSpec that has an embedded TaskSpec.
 	// It verifies that a TaskRun is created, it checks the resulting API actions, status and events.
@@ -1286,7 +1451,7 @@ status:
           image: busybox
           script: 'exit 0'
   conditions:
-  - message: "Tasks Completed: 1 (Failed: 0, Cancelled 0), Skipped: 0, Failed Validation: 1"
+  - message: "Tasks Completed: 1 (Failed: 0, Cancelled 0), Skipped: 0, Failed Validation: 1\nFailed tasks:\n- task2 (TaskRunValidationFailed): the results it references are missing"
     reason: PipelineValidationFailed
     status: "False"
     type: Succeeded
@@ -1310,7 +1475,7 @@ status:
 
 	// The PipelineRun should be marked as failed
 	if d := cmp.Diff(expectedPipelineRun, reconciledRun, ignoreResourceVersion, ignoreLastTransitionTime, ignoreTypeMeta,
-		ignoreStartTime, ignoreCompletionTime, ignoreProvenance); d != "" {
+		ignoreStartTime, ignoreSummary, ignoreCompletionTime, ignoreProvenance); d != "" {
 		t.Errorf("Expected to see PipelineRun run marked as failed. Diff %s", diff.PrintWantGot(d))
 	}
 
@@ -1392,14 +1557,14 @@ status:
     status: "True"
     type: Succeeded
   childReferences:
-    - name: test-pipeline-run-completed-hello-world-task-run
-      pipelineTaskName: hello-world-1
-      kind: TaskRun
-      apiVersion: tekton.dev/v1
     - name: test-pipeline-run-completed-hello-world-custom-run
       pipelineTaskName: hello-world-1
       kind: CustomRun
       apiVersion: tekton.dev/v1
+    - name: test-pipeline-run-completed-hello-world-task-run
+      pipelineTaskName: hello-world-1
+      kind: TaskRun
+      apiVersion: tekton.dev/v1
 `, pipelineRunName))}
 	ps := []*v1.Pipeline{simpleHelloWorldPipeline}
 	ts := []*v1.Task{simpleHelloWorldTask}
@@ -1412,16 +1577,16 @@ status:
 	expectedChildReferences := []v1.ChildStatusReference{{
 		TypeMeta: runtime.TypeMeta{
 			APIVersion: v1.SchemeGroupVersion.String(),
-			Kind:       "TaskRun",
+			Kind:       customRun,
 		},
-		Name:             taskRunName,
+		Name:             customRunName,
 		PipelineTaskName: "hello-world-1",
 	}, {
 		TypeMeta: runtime.TypeMeta{
 			APIVersion: v1.SchemeGroupVersion.String(),
-			Kind:       customRun,
+			Kind:       "TaskRun",
 		},
-		Name:             customRunName,
+		Name:             taskRunName,
 		PipelineTaskName: "hello-world-1",
 	}}
 
@@ -1499,11 +1664,18 @@ func TestReconcileOnCancelledPipelineRun(t *testing.T) {
 		name       string
 		specStatus v1.PipelineRunSpecStatus
 		reason     string
+		wantEvent  string
 	}{
 		{
 			name:       "cancelled",
 			specStatus: v1.PipelineRunSpecStatusCancelled,
 			reason:     v1.PipelineRunReasonCancelled.String(),
+			wantEvent:  "Warning Failed PipelineRun \"test-pipeline-run-cancelled\" was cancelled",
+		}, {
+			name:       "superseded",
+			specStatus: v1.PipelineRunSpecStatusSuperseded,
+			reason:     v1.PipelineRunReasonSuperseded.String(),
+			wantEvent:  "Warning Failed PipelineRun \"test-pipeline-run-cancelled\" was superseded",
 		},
 	}
 
@@ -1526,9 +1698,7 @@ func TestReconcileOnCancelledPipelineRun(t *testing.T) {
 			prt := newPipelineRunTest(t, d)
 			defer prt.Cancel()
 
-			wantEvents := []string{
-				"Warning Failed PipelineRun \"test-pipeline-run-cancelled\" was cancelled",
-			}
+			wantEvents := []string{tc.wantEvent}
 			reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-cancelled", wantEvents, false)
 			actions := clients.Pipeline.Actions()
 
@@ -1550,6 +1720,96 @@ func TestReconcileOnCancelledPipelineRun(t *testing.T) {
 	}
 }
 
+func TestReconcile_ConcurrentSpecUpdate(t *testing.T) {
+	prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run-concurrent-update
+  namespace: foo
+  generation: 1
+spec:
+  pipelineRef:
+    name: test-pipeline
+`)}
+	ts := []*v1.Task{parse.MustParseV1Task(t, `
+metadata:
+  name: hello-world
+  namespace: foo
+spec:
+  steps:
+  - name: hello
+    image: foo
+    command: ["/mycmd"]
+`)}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    []*v1.Pipeline{simpleHelloWorldPipeline},
+		Tasks:        ts,
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
+
+	// Cancel the PipelineRun right before the reconciler updates its labels
+	// and annotations, as if the user edited the spec during the reconcile.
+	store := prt.TestAssets.Informers.PipelineRun.Informer().GetIndexer()
+	edited := false
+	prt.TestAssets.Clients.Pipeline.PrependReactor("update", "pipelineruns", func(action ktesting.Action) (bool, runtime.Object, error) {
+		if edited || action.GetSubresource() != "" {
+			return false, nil, nil
+		}
+		edited = true
+		obj, _, err := store.GetByKey("foo/test-pipeline-run-concurrent-update")
+		if err != nil {
+			return true, nil, err
+		}
+		cancelled := obj.(*v1.PipelineRun).DeepCopy()
+		cancelled.Spec.Status = v1.PipelineRunSpecStatusCancelled
+		cancelled.Generation = 2
+		cancelled.ResourceVersion = "concurrent-edit"
+		if err := store.Update(cancelled); err != nil {
+			return true, nil, err
+		}
+		if err := prt.TestAssets.Clients.Pipeline.Tracker().Update(v1.SchemeGroupVersion.WithResource("pipelineruns"), cancelled, cancelled.Namespace); err != nil {
+			return true, nil, err
+		}
+		return false, nil, nil
+	})
+
+	// The reconcile of the first generation is requeued right away.
+	err := prt.TestAssets.Controller.Reconciler.Reconcile(prt.TestAssets.Ctx, "foo/test-pipeline-run-concurrent-update")
+	if ok, delay := controller.IsRequeueKey(err); !ok || delay != 0 {
+		t.Fatalf("Expected the PipelineRun to be requeued immediately but got %v", err)
+	}
+	if !edited {
+		t.Fatal("Expected the PipelineRun labels and annotations to be updated")
+	}
+	pr, err := prt.TestAssets.Clients.Pipeline.TektonV1().PipelineRuns("foo").Get(prt.TestAssets.Ctx, "test-pipeline-run-concurrent-update", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Error getting updated PipelineRun: %v", err)
+	}
+	if pr.Spec.Status != v1.PipelineRunSpecStatusCancelled {
+		t.Errorf("Expected the concurrent cancellation to be kept but the spec status is %q", pr.Spec.Status)
+	}
+	if pr.Labels[pipeline.PipelineLabelKey] != "test-pipeline" {
+		t.Errorf("Expected the labels to be merged into the cancelled PipelineRun but got %v", pr.Labels)
+	}
+	if pr.Status.ObservedGeneration != 1 {
+		t.Errorf("Expected the status to be for the observed generation 1 but got %d", pr.Status.ObservedGeneration)
+	}
+
+	// The next reconcile acts on the cancellation.
+	if err := prt.TestAssets.Controller.Reconciler.Reconcile(prt.TestAssets.Ctx, "foo/test-pipeline-run-concurrent-update"); err != nil {
+		t.Fatalf("Unexpected error reconciling the cancelled PipelineRun: %v", err)
+	}
+	pr, err = prt.TestAssets.Clients.Pipeline.TektonV1().PipelineRuns("foo").Get(prt.TestAssets.Ctx, "test-pipeline-run-concurrent-update", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Error getting updated PipelineRun: %v", err)
+	}
+	checkPipelineRunConditionStatusAndReason(t, pr, corev1.ConditionFalse, v1.PipelineRunReasonCancelled.String())
+	if pr.Status.ObservedGeneration != 2 {
+		t.Errorf("Expected the status to be for the observed generation 2 but got %d", pr.Status.ObservedGeneration)
+	}
+}
+
 func TestReconcileForCustomTaskWithPipelineTaskTimedOut(t *testing.T) {
 	names.TestingSeed()
 	// TestReconcileForCustomTaskWithPipelineTaskTimedOut runs "Reconcile" on a PipelineRun.
@@ -1968,6 +2228,132 @@ spec:
 	}
 }
 
+func TestReconcileOnCancelledRunFinallyPipelineRunStartFinallyOnCancel(t *testing.T) {
+	// TestReconcileOnCancelledRunFinallyPipelineRunStartFinallyOnCancel runs "Reconcile" on a PipelineRun that has been gracefully
+	// cancelled while a task ignoring the cancellation is still running. With the start-finally-on-cancel feature flag, it verifies
+	// that the final tasks which don't depend on the running task are started without waiting for it to stop.
+	prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run-cancelled-run-finally
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+  taskRunTemplate:
+    serviceAccountName: test-sa
+  status: CancelledRunFinally
+status:
+  startTime: "2022-01-01T00:00:00Z"
+  childReferences:
+  - apiVersion: tekton.dev/v1
+    kind: TaskRun
+    name: test-pipeline-run-cancelled-run-finally-hello-world
+    pipelineTaskName: hello-world-1
+`)}
+	ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  finally:
+  - name: final-task-1
+    taskRef:
+      name: some-task
+  - name: final-task-2
+    params:
+    - name: foo
+      value: $(tasks.hello-world-1.results.foo)
+    taskRef:
+      name: some-task
+  tasks:
+  - name: hello-world-1
+    taskRef:
+      name: hello-world
+`)}
+	ts := []*v1.Task{
+		simpleHelloWorldTask,
+		simpleSomeTask,
+	}
+
+	for _, tc := range []struct {
+		name                 string
+		startFinallyOnCancel string
+		wantFinalTaskRuns    []string
+	}{{
+		name:                 "start-finally-on-cancel enabled",
+		startFinallyOnCancel: "true",
+		wantFinalTaskRuns:    []string{"test-pipeline-run-cancelled-run-finally-final-task-1"},
+	}, {
+		name:                 "start-finally-on-cancel disabled",
+		startFinallyOnCancel: "false",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			// The TaskRun of hello-world-1 is still running although it has been cancelled.
+			trs := []*v1.TaskRun{
+				createHelloWorldTaskRunWithStatus(t, "test-pipeline-run-cancelled-run-finally-hello-world", "foo",
+					"test-pipeline-run-cancelled-run-finally", "test-pipeline", "my-pod-name",
+					apis.Condition{
+						Type:   apis.ConditionSucceeded,
+						Status: corev1.ConditionUnknown,
+						Reason: v1.TaskRunReasonRunning.String(),
+					}),
+			}
+			cms := []*corev1.ConfigMap{newFeatureFlagsConfigMap()}
+			cms[0].Data["start-finally-on-cancel"] = tc.startFinallyOnCancel
+			d := test.Data{
+				PipelineRuns: prs,
+				Pipelines:    ps,
+				Tasks:        ts,
+				TaskRuns:     trs,
+				ConfigMaps:   cms,
+			}
+			prt := newPipelineRunTest(t, d)
+			defer prt.Cancel()
+
+			wantEvents := []string{
+				"Normal Started",
+			}
+			reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-cancelled-run-finally", wantEvents, false)
+
+			if reconciledRun.Status.CompletionTime != nil {
+				t.Errorf("Expected a CompletionTime to be nil on incomplete PipelineRun but was %v", reconciledRun.Status.CompletionTime)
+			}
+			// The PipelineRun waits for the running task to stop
+			if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() {
+				t.Errorf("Expected PipelineRun status to be unknown, but was %v", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+			}
+
+			// Only the running TaskRun of the DAG task is cancelled
+			var patched []string
+			for _, action := range clients.Pipeline.Actions() {
+				if patchAction, ok := action.(ktesting.PatchAction); ok {
+					patched = append(patched, patchAction.GetName())
+				}
+			}
+			if d := cmp.Diff([]string{"test-pipeline-run-cancelled-run-finally-hello-world"}, patched); d != "" {
+				t.Errorf("Unexpected patched TaskRuns %s", diff.PrintWantGot(d))
+			}
+
+			// final-task-2 consumes the results of the running task so it isn't started yet
+			var created []string
+			for _, action := range clients.Pipeline.Actions() {
+				if action.GetVerb() == "create" && action.GetResource().Resource == "taskruns" {
+					created = append(created, action.(ktesting.CreateAction).GetObject().(*v1.TaskRun).Name)
+				}
+			}
+			if d := cmp.Diff(tc.wantFinalTaskRuns, created); d != "" {
+				t.Errorf("Unexpected created TaskRuns %s", diff.PrintWantGot(d))
+			}
+			if tc.wantFinalTaskRuns != nil && reconciledRun.Status.FinallyStartTime == nil {
+				t.Errorf("Expected the FinallyStartTime to be set when the final tasks are started")
+			}
+			if tc.wantFinalTaskRuns == nil && reconciledRun.Status.FinallyStartTime != nil {
+				t.Errorf("Expected the FinallyStartTime to be nil but was %v", reconciledRun.Status.FinallyStartTime)
+			}
+		})
+	}
+}
+
 func TestReconcileOnCancelledRunFinallyPipelineRunWithFinalTaskAndRetries(t *testing.T) {
 	// TestReconcileOnCancelledRunFinallyPipelineRunWithFinalTaskAndRetries runs "Reconcile" on a PipelineRun that has
 	// been gracefully cancelled. It verifies that reconcile is successful, the pipeline status updated and events generated.
@@ -3000,23 +3386,23 @@ status:
   finallyStartTime: "2021-12-31T23:44:59Z"
   startTime: "2021-12-31T23:40:00Z"
   childReferences:
-  - name: test-pipeline-run-with-timeout-hello-world
+  - name: test-pipeline-run-with-timeout-finaltask-1
     apiVersion: tekton.dev/v1
     kind: TaskRun
-    pipelineTaskName: task1
+    pipelineTaskName: finaltask-1
     status:
       conditions:
       - lastTransitionTime: null
-        status: "True"
+        status: "Unknown"
         type: Succeeded
-  - name: test-pipeline-run-with-timeout-finaltask-1
+  - name: test-pipeline-run-with-timeout-hello-world
     apiVersion: tekton.dev/v1
     kind: TaskRun
-    pipelineTaskName: finaltask-1
+    pipelineTaskName: task1
     status:
       conditions:
       - lastTransitionTime: null
-        status: "Unknown"
+        status: "True"
         type: Succeeded
 `, prName)),
 		wantFinallyTimeout:      true,
@@ -3038,23 +3424,23 @@ status:
   finallyStartTime: "2021-12-31T23:44:59Z"
   startTime: "2021-12-31T23:40:00Z"
   childReferences:
-  - name: test-pipeline-run-with-timeout-hello-world
+  - name: test-pipeline-run-with-timeout-finaltask-1
     apiVersion: tekton.dev/v1
     kind: TaskRun
-    pipelineTaskName: task1
+    pipelineTaskName: finaltask-1
     status:
       conditions:
       - lastTransitionTime: null
-        status: "True"
+        status: "Unknown"
         type: Succeeded
-  - name: test-pipeline-run-with-timeout-finaltask-1
+  - name: test-pipeline-run-with-timeout-hello-world
     apiVersion: tekton.dev/v1
     kind: TaskRun
-    pipelineTaskName: finaltask-1
+    pipelineTaskName: task1
     status:
       conditions:
       - lastTransitionTime: null
-        status: "Unknown"
+        status: "True"
         type: Succeeded
 `, prName)),
 		wantFinallyTimeout:      true,
@@ -3265,23 +3651,23 @@ spec:
 status:
   startTime: "2021-12-31T23:40:00Z"
   childReferences:
-  - name: test-pipeline-run-with-set-finally-start-time-hello-world
+  - name: test-pipeline-run-with-set-finally-start-time-finaltask-1
     apiVersion: tekton.dev/v1
     kind: TaskRun
-    pipelineTaskName: task1
+    pipelineTaskName: finaltask-1
     status:
       conditions:
       - lastTransitionTime: null
-        status: "True"
+        status: "Unknown"
         type: Succeeded
-  - name: test-pipeline-run-with-set-finally-start-time-finaltask-1
+  - name: test-pipeline-run-with-set-finally-start-time-hello-world
     apiVersion: tekton.dev/v1
     kind: TaskRun
-    pipelineTaskName: finaltask-1
+    pipelineTaskName: task1
     status:
       conditions:
       - lastTransitionTime: null
-        status: "Unknown"
+        status: "True"
         type: Succeeded
 `, prName)),
 		wantEvents: []string{
@@ -3442,6 +3828,386 @@ spec:
 	}
 }
 
+func TestReconcileWithParamsFrom(t *testing.T) {
+	common := &v1alpha1.ParameterSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "common", Namespace: "foo"},
+		Spec: v1alpha1.ParameterSetSpec{
+			Params: v1.ParamSpecs{{
+				Name:    "registry",
+				Type:    v1.ParamTypeString,
+				Default: v1.NewStructuredValues("registry.example.com"),
+			}},
+		},
+	}
+	prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+`)}
+	ts := []*v1.Task{simpleHelloWorldTask}
+
+	for _, tc := range []struct {
+		name          string
+		paramsFrom    string
+		paramValue    string
+		wantReason    string
+		wantTaskRun   bool
+		wantExpanded  []v1.ExpandedParameterSet
+		wantParamsLen int
+	}{{
+		name:          "params imported from a ParameterSet",
+		paramsFrom:    "common",
+		paramValue:    "$(params.registry)",
+		wantReason:    v1.PipelineRunReasonRunning.String(),
+		wantTaskRun:   true,
+		wantExpanded:  []v1.ExpandedParameterSet{{Name: "common", Params: []string{"registry"}}},
+		wantParamsLen: 1,
+	}, {
+		name:       "missing ParameterSet",
+		paramsFrom: "missing",
+		paramValue: "$(params.registry)",
+		wantReason: v1.PipelineRunReasonInvalidParameterSet.String(),
+	}, {
+		name:       "param declared neither by the Pipeline nor by the ParameterSet",
+		paramsFrom: "common",
+		paramValue: "$(params.undeclared)",
+		wantReason: v1.PipelineRunReasonFailedValidation.String(),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, fmt.Sprintf(`
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  paramsFrom:
+  - name: %s
+  tasks:
+  - name: hello-world-1
+    taskRef:
+      name: hello-world
+    params:
+    - name: registry
+      value: "%s"
+`, tc.paramsFrom, tc.paramValue))}
+			d := test.Data{
+				PipelineRuns:  prs,
+				Pipelines:     ps,
+				Tasks:         ts,
+				ParameterSets: []*v1alpha1.ParameterSet{common},
+			}
+			prt := newPipelineRunTest(t, d)
+			defer prt.Cancel()
+
+			reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run", []string{}, !tc.wantTaskRun)
+			if reason := reconciledRun.Status.GetCondition(apis.ConditionSucceeded).Reason; reason != tc.wantReason {
+				t.Errorf("Expected the PipelineRun to have the reason %q but got %q", tc.wantReason, reason)
+			}
+			if !tc.wantTaskRun {
+				return
+			}
+			if d := cmp.Diff(tc.wantExpanded, reconciledRun.Status.ExpandedParameterSets, cmpopts.IgnoreFields(v1.ExpandedParameterSet{}, "ResourceVersion")); d != "" {
+				t.Errorf("Unexpected expanded ParameterSets %s", diff.PrintWantGot(d))
+			}
+			if n := len(reconciledRun.Status.PipelineSpec.Params); n != tc.wantParamsLen || reconciledRun.Status.PipelineSpec.ParamsFrom != nil {
+				t.Errorf("Expected the paramsFrom of the PipelineSpec of the status to be expanded but got %v", reconciledRun.Status.PipelineSpec)
+			}
+			taskRuns, err := clients.Pipeline.TektonV1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("Failure to list TaskRuns: %v", err)
+			}
+			if len(taskRuns.Items) != 1 {
+				t.Fatalf("Expected 1 TaskRun to be created but got %d", len(taskRuns.Items))
+			}
+			wantParams := v1.Params{{Name: "registry", Value: *v1.NewStructuredValues("registry.example.com")}}
+			if d := cmp.Diff(wantParams, taskRuns.Items[0].Spec.Params); d != "" {
+				t.Errorf("Unexpected params of the TaskRun %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestReconcileWithInjectedFinallyTasks(t *testing.T) {
+	cms := []*corev1.ConfigMap{{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetDefaultsConfigName(), Namespace: system.Namespace()},
+		Data: map[string]string{
+			"default-injected-finally-tasks": `
+tasks:
+- name: audit-report
+  taskRef:
+    name: audit-report
+  params:
+    pipelinerun: $(context.pipelineRun.name)
+exemptNamespaces:
+- exempt
+`,
+		},
+	}}
+	auditReport := v1.PipelineTask{
+		Name:    "audit-report",
+		TaskRef: &v1.TaskRef{Name: "audit-report", Kind: v1.NamespacedTaskKind},
+		// The context variables are replaced in the PipelineSpec of the status.
+		Params: v1.Params{{Name: "pipelinerun", Value: *v1.NewStructuredValues("test-pipeline-run")}},
+	}
+
+	for _, tc := range []struct {
+		name        string
+		namespace   string
+		wantFinally []v1.PipelineTask
+	}{{
+		name:        "injected",
+		namespace:   "foo",
+		wantFinally: []v1.PipelineTask{auditReport},
+	}, {
+		name:      "exempted namespace",
+		namespace: "exempt",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, fmt.Sprintf(`
+metadata:
+  name: test-pipeline-run
+  namespace: %s
+spec:
+  pipelineRef:
+    name: test-pipeline
+`, tc.namespace))}
+			ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, fmt.Sprintf(`
+metadata:
+  name: test-pipeline
+  namespace: %s
+spec:
+  tasks:
+  - name: hello-world-1
+    taskRef:
+      name: hello-world
+`, tc.namespace))}
+			ts := []*v1.Task{
+				{ObjectMeta: baseObjectMeta("hello-world", tc.namespace)},
+				parse.MustParseV1Task(t, fmt.Sprintf(`
+metadata:
+  name: audit-report
+  namespace: %s
+spec:
+  params:
+  - name: pipelinerun
+  steps:
+  - name: report
+    image: foo
+`, tc.namespace)),
+			}
+			d := test.Data{
+				PipelineRuns: prs,
+				Pipelines:    ps,
+				Tasks:        ts,
+				ConfigMaps:   cms,
+			}
+			prt := newPipelineRunTest(t, d)
+			defer prt.Cancel()
+
+			reconciledRun, clients := prt.reconcileRun(tc.namespace, "test-pipeline-run", []string{}, false)
+			checkPipelineRunConditionStatusAndReason(t, reconciledRun, corev1.ConditionUnknown, v1.PipelineRunReasonRunning.String())
+			if d := cmp.Diff(tc.wantFinally, reconciledRun.Status.PipelineSpec.Finally); d != "" {
+				t.Errorf("Unexpected finally tasks of the PipelineSpec of the status %s", diff.PrintWantGot(d))
+			}
+			taskRuns, err := clients.Pipeline.TektonV1().TaskRuns(tc.namespace).List(prt.TestAssets.Ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("Failure to list TaskRuns: %v", err)
+			}
+			if len(taskRuns.Items) != 1 {
+				t.Errorf("Expected only the TaskRun of the task to be created before the finally tasks but got %d", len(taskRuns.Items))
+			}
+		})
+	}
+}
+
+func TestReconcileWithInjectedFinallyTaskNameClash(t *testing.T) {
+	cms := []*corev1.ConfigMap{{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetDefaultsConfigName(), Namespace: system.Namespace()},
+		Data: map[string]string{
+			"default-injected-finally-tasks": `
+tasks:
+- name: audit-report
+  taskRef:
+    name: audit-report
+`,
+		},
+	}}
+	prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+`)}
+	ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: hello-world-1
+    taskRef:
+      name: hello-world
+  finally:
+  - name: audit-report
+    taskRef:
+      name: hello-world
+`)}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        []*v1.Task{{ObjectMeta: baseObjectMeta("hello-world", "foo")}},
+		ConfigMaps:   cms,
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
+
+	// The injected finally task is skipped rather than failing the PipelineRun
+	// of the Pipeline which already declares a task with its name.
+	wantEvents := []string{
+		"Normal Started",
+		`Warning InjectedFinallyTaskSkipped The Pipeline has a task named "audit-report", so the finally task with that name injected into the PipelineRuns of namespace foo is skipped`,
+		"Normal Running Tasks Completed: 0",
+	}
+	reconciledRun, _ := prt.reconcileRun("foo", "test-pipeline-run", wantEvents, false)
+	checkPipelineRunConditionStatusAndReason(t, reconciledRun, corev1.ConditionUnknown, v1.PipelineRunReasonRunning.String())
+	wantFinally := []v1.PipelineTask{{
+		Name:    "audit-report",
+		TaskRef: &v1.TaskRef{Name: "hello-world", Kind: v1.NamespacedTaskKind},
+	}}
+	if d := cmp.Diff(wantFinally, reconciledRun.Status.PipelineSpec.Finally); d != "" {
+		t.Errorf("Expected the finally tasks of the Pipeline to be kept %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestReconcileWithTasksFilter(t *testing.T) {
+	ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: hello-world-1
+    taskRef:
+      name: greeter
+  - name: hello-world-2
+    taskRef:
+      name: greeter
+    params:
+    - name: greeting
+      value: $(tasks.hello-world-1.results.greeting)
+  - name: hello-world-3
+    taskRef:
+      name: hello-world
+    runAfter:
+    - hello-world-2
+  finally:
+  - name: final-task
+    taskRef:
+      name: hello-world
+`)}
+	ts := []*v1.Task{simpleHelloWorldTask, parse.MustParseV1Task(t, `
+metadata:
+  name: greeter
+  namespace: foo
+spec:
+  params:
+  - name: greeting
+    default: hi
+  results:
+  - name: greeting
+  steps:
+  - name: greet
+    image: busybox
+    script: echo -n $(params.greeting) > $(results.greeting.path)
+`)}
+
+	for _, tc := range []struct {
+		name             string
+		tasksFilter      string
+		wantReason       string
+		wantPipelineTask string
+		wantParams       v1.Params
+		wantSkipped      []v1.SkippedTask
+	}{{
+		name: "task with its dependencies",
+		tasksFilter: `
+    include: [hello-world-2]
+    withDependencies: true`,
+		wantReason:       v1.PipelineRunReasonRunning.String(),
+		wantPipelineTask: "hello-world-1",
+		wantSkipped:      []v1.SkippedTask{{Name: "hello-world-3", Reason: v1.FilteredOutSkip}},
+	}, {
+		name: "task with the results of its dependencies overridden",
+		tasksFilter: `
+    include: [hello-world-2]
+    excludeFinally: [final-task]
+    resultOverrides:
+    - pipelineTask: hello-world-1
+      name: greeting
+      value: hello`,
+		wantReason:       v1.PipelineRunReasonRunning.String(),
+		wantPipelineTask: "hello-world-2",
+		wantParams:       v1.Params{{Name: "greeting", Value: *v1.NewStructuredValues("hello")}},
+		wantSkipped: []v1.SkippedTask{
+			{Name: "hello-world-1", Reason: v1.FilteredOutSkip},
+			{Name: "hello-world-3", Reason: v1.FilteredOutSkip},
+			{Name: "final-task", Reason: v1.FilteredOutSkip},
+		},
+	}, {
+		name: "task whose results of its dependencies are missing",
+		tasksFilter: `
+    include: [hello-world-2]`,
+		wantReason: v1.PipelineRunReasonInvalidTasksFilter.String(),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+  tasksFilter:`+tc.tasksFilter)}
+			d := test.Data{
+				PipelineRuns: prs,
+				Pipelines:    ps,
+				Tasks:        ts,
+			}
+			prt := newPipelineRunTest(t, d)
+			defer prt.Cancel()
+
+			wantTaskRun := tc.wantPipelineTask != ""
+			reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run", []string{}, !wantTaskRun)
+			if reason := reconciledRun.Status.GetCondition(apis.ConditionSucceeded).Reason; reason != tc.wantReason {
+				t.Errorf("Expected the PipelineRun to have the reason %q but got %q", tc.wantReason, reason)
+			}
+			if !wantTaskRun {
+				return
+			}
+			if d := cmp.Diff(tc.wantSkipped, reconciledRun.Status.SkippedTasks); d != "" {
+				t.Errorf("Unexpected skipped tasks %s", diff.PrintWantGot(d))
+			}
+			taskRuns, err := clients.Pipeline.TektonV1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("Failure to list TaskRuns: %v", err)
+			}
+			if len(taskRuns.Items) != 1 {
+				t.Fatalf("Expected 1 TaskRun to be created but got %d", len(taskRuns.Items))
+			}
+			if pt := taskRuns.Items[0].Labels[pipeline.PipelineTaskLabelKey]; pt != tc.wantPipelineTask {
+				t.Errorf("Expected a TaskRun for the PipelineTask %q but got one for %q", tc.wantPipelineTask, pt)
+			}
+			if d := cmp.Diff(tc.wantParams, taskRuns.Items[0].Spec.Params, cmpopts.EquateEmpty()); d != "" {
+				t.Errorf("Unexpected params of the TaskRun %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestReconcileCancelledFailsTaskRunCancellation(t *testing.T) {
 	prName := "test-pipeline-fails-to-cancel"
 
@@ -4231,6 +4997,17 @@ spec:
 			Operator: "notin",
 			Values:   []string{"yes"},
 		}},
+		EvaluatedWhenExpressions: []v1.EvaluatedWhenExpression{{
+			Input:    "aResultValue",
+			Operator: "in",
+			Values:   []string{"missing"},
+			Outcome:  false,
+		}, {
+			Input:    "yes",
+			Operator: "notin",
+			Values:   []string{"yes"},
+			Outcome:  false,
+		}},
 	}}
 	if d := cmp.Diff(expectedSkippedTasks, actualSkippedTasks); d != "" {
 		t.Errorf("expected to find Skipped Tasks %v. Diff %s", expectedSkippedTasks, diff.PrintWantGot(d))
@@ -4407,6 +5184,12 @@ spec:
 			Operator: "in",
 			Values:   []string{"bar"},
 		}},
+		EvaluatedWhenExpressions: []v1.EvaluatedWhenExpression{{
+			Input:    "foo",
+			Operator: "in",
+			Values:   []string{"bar"},
+			Outcome:  false,
+		}},
 	}, {
 		// its when expressions evaluate to false
 		Name:   "c-task",
@@ -4416,6 +5199,12 @@ spec:
 			Operator: "in",
 			Values:   []string{"bar"},
 		}},
+		EvaluatedWhenExpressions: []v1.EvaluatedWhenExpression{{
+			Input:    "foo",
+			Operator: "in",
+			Values:   []string{"bar"},
+			Outcome:  false,
+		}},
 	}, {
 		// its when expressions evaluate to false
 		Name:   "e-task",
@@ -4425,6 +5214,12 @@ spec:
 			Operator: "in",
 			Values:   []string{"aResultValue"},
 		}},
+		EvaluatedWhenExpressions: []v1.EvaluatedWhenExpression{{
+			Input:    "$(tasks.a-task.results.aResult)",
+			Operator: "in",
+			Values:   []string{"aResultValue"},
+			Outcome:  false,
+		}},
 	}}
 	if d := cmp.Diff(expectedSkippedTasks, actualSkippedTasks); d != "" {
 		t.Errorf("expected to find Skipped Tasks %v. Diff %s", expectedSkippedTasks, diff.PrintWantGot(d))
@@ -4447,7 +5242,246 @@ spec:
 	}
 }
 
-func TestReconcileWithWhenExpressionsWithResultRefs(t *testing.T) {
+func TestReconcileWithWhenExpressionsWithWorkspacesBound(t *testing.T) {
+	ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  workspaces:
+  - name: cache
+    optional: true
+  tasks:
+# restore-cache is skipped when the optional cache workspace isn't bound
+  - name: restore-cache
+    taskRef:
+      name: restore-cache
+    when:
+    - input: $(workspaces.cache.bound)
+      operator: in
+      values:
+      - "true"
+  - name: build
+    taskRef:
+      name: build
+`)}
+	ts := []*v1.Task{
+		{ObjectMeta: baseObjectMeta("restore-cache", "foo")},
+		{ObjectMeta: baseObjectMeta("build", "foo")},
+	}
+
+	for _, tc := range []struct {
+		name             string
+		workspaces       string
+		wantEvents       []string
+		wantTaskRuns     []string
+		wantSkippedTasks []v1.SkippedTask
+	}{{
+		name: "cache workspace bound",
+		workspaces: `
+  workspaces:
+  - name: cache
+    emptyDir: {}
+`,
+		wantEvents: []string{
+			"Normal Started",
+			"Normal Running Tasks Completed: 0 \\(Failed: 0, Cancelled 0\\), Incomplete: 2, Skipped: 0",
+		},
+		wantTaskRuns: []string{"restore-cache", "build"},
+	}, {
+		name: "cache workspace not bound",
+		wantEvents: []string{
+			"Normal Started",
+			"Normal Running Tasks Completed: 0 \\(Failed: 0, Cancelled 0\\), Incomplete: 1, Skipped: 1",
+		},
+		wantTaskRuns: []string{"build"},
+		wantSkippedTasks: []v1.SkippedTask{{
+			Name:   "restore-cache",
+			Reason: v1.WhenExpressionsSkip,
+			WhenExpressions: v1.WhenExpressions{{
+				Input:    "false",
+				Operator: "in",
+				Values:   []string{"true"},
+			}},
+			EvaluatedWhenExpressions: []v1.EvaluatedWhenExpression{{
+				Input:    "false",
+				Operator: "in",
+				Values:   []string{"true"},
+				Outcome:  false,
+			}},
+		}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+`+tc.workspaces)}
+			d := test.Data{
+				PipelineRuns: prs,
+				Pipelines:    ps,
+				Tasks:        ts,
+			}
+			prt := newPipelineRunTest(t, d)
+			defer prt.Cancel()
+
+			pipelineRun, clients := prt.reconcileRun("foo", "test-pipeline-run", tc.wantEvents, false)
+
+			taskRuns, err := clients.Pipeline.TektonV1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{
+				LabelSelector: "tekton.dev/pipelineRun=test-pipeline-run",
+			})
+			if err != nil {
+				t.Fatalf("Failure to list TaskRuns %s", err)
+			}
+			var gotTaskRuns []string
+			for _, tr := range taskRuns.Items {
+				gotTaskRuns = append(gotTaskRuns, tr.Labels[pipeline.PipelineTaskLabelKey])
+			}
+			if d := cmp.Diff(tc.wantTaskRuns, gotTaskRuns, cmpopts.SortSlices(func(i, j string) bool { return i < j })); d != "" {
+				t.Errorf("expected to see TaskRuns created for %v. Diff %s", tc.wantTaskRuns, diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tc.wantSkippedTasks, pipelineRun.Status.SkippedTasks); d != "" {
+				t.Errorf("expected to find Skipped Tasks %v. Diff %s", tc.wantSkippedTasks, diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestReconcileWithDisplayNamesAndDescriptionsSubstitution(t *testing.T) {
+	ts := []*v1.Task{
+		parse.MustParseV1Task(t, `
+metadata:
+  name: deploy
+  namespace: foo
+spec:
+  results:
+  - name: url
+  steps:
+  - name: deploy
+    image: busybox
+`),
+		parse.MustParseV1Task(t, `
+metadata:
+  name: build
+  namespace: foo
+spec:
+  params:
+  - name: platform
+  steps:
+  - name: build
+    image: busybox
+`),
+	}
+
+	for _, tc := range []struct {
+		name                string
+		description         string
+		wantDescription     string
+		wantEvents          []string
+		wantChildReferences []string
+	}{{
+		name:            "references resolved",
+		description:     "Deploys $(context.pipelineRun.name) to $(params.environment)",
+		wantDescription: "Deploys test-pipeline-run to staging",
+		wantEvents: []string{
+			"Normal Started",
+			"Normal Running Tasks Completed: 0 \\(Failed: 0, Cancelled 0\\), Incomplete: 2, Skipped: 0",
+		},
+	}, {
+		name:            "unresolvable references",
+		description:     "Deploys to $(params.environment) in $(params.region)",
+		wantDescription: "Deploys to staging in $(params.region)",
+		wantEvents: []string{
+			"Normal Started",
+			"Warning UnresolvedReferences Display names and descriptions of PipelineRun foo/test-pipeline-run contain references which can't be resolved: \\$\\(params.region\\)",
+			"Normal Running Tasks Completed: 0 \\(Failed: 0, Cancelled 0\\), Incomplete: 2, Skipped: 0",
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, fmt.Sprintf(`
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  displayName: Deploy to $(params.environment)
+  description: %s
+  params:
+  - name: environment
+  tasks:
+  - name: deploy
+    displayName: Deploy to $(params.environment)
+    taskRef:
+      name: deploy
+  - name: build
+    displayName: Build $(params.platform) for $(params.environment)
+    taskRef:
+      name: build
+    matrix:
+      params:
+      - name: platform
+        value:
+        - linux
+        - mac
+  results:
+  - name: url
+    description: The URL of the $(params.environment) deployment
+    value: $(tasks.deploy.results.url)
+`, tc.description))}
+			prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+  params:
+  - name: environment
+    value: staging
+`)}
+			d := test.Data{
+				PipelineRuns: prs,
+				Pipelines:    ps,
+				Tasks:        ts,
+			}
+			prt := newPipelineRunTest(t, d)
+			defer prt.Cancel()
+
+			pipelineRun, _ := prt.reconcileRun("foo", "test-pipeline-run", tc.wantEvents, false)
+
+			spec := pipelineRun.Status.PipelineSpec
+			if spec.DisplayName != "Deploy to staging" {
+				t.Errorf("expected the display name of the Pipeline to be substituted but got %q", spec.DisplayName)
+			}
+			if spec.Description != tc.wantDescription {
+				t.Errorf("expected the description of the Pipeline to be %q but got %q", tc.wantDescription, spec.Description)
+			}
+			if spec.Results[0].Description != "The URL of the staging deployment" {
+				t.Errorf("expected the description of the result to be substituted but got %q", spec.Results[0].Description)
+			}
+			var gotDisplayNames []string
+			for _, cr := range pipelineRun.Status.ChildReferences {
+				gotDisplayNames = append(gotDisplayNames, cr.DisplayName)
+			}
+			wantDisplayNames := []string{"Deploy to staging", "Build linux for staging", "Build mac for staging"}
+			if d := cmp.Diff(wantDisplayNames, gotDisplayNames, cmpopts.SortSlices(func(i, j string) bool { return i < j })); d != "" {
+				t.Errorf("expected the display names of the children to be substituted. Diff %s", diff.PrintWantGot(d))
+			}
+
+			// The unresolvable references are only warned about once.
+			prt.reconcileRun("foo", "test-pipeline-run", nil, false)
+			for len(prt.TestAssets.Recorder.Events) > 0 {
+				if event := <-prt.TestAssets.Recorder.Events; strings.Contains(event, "UnresolvedReferences") {
+					t.Errorf("expected no UnresolvedReferences event on the next reconcile but got %q", event)
+				}
+			}
+		})
+	}
+}
+
+func TestReconcileWithWhenExpressionsWithResultRefs(t *testing.T) {
 	names.TestingSeed()
 	ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, `
 metadata:
@@ -4553,6 +5587,12 @@ status:
 			Operator: "in",
 			Values:   []string{"notResultValue"},
 		}},
+		EvaluatedWhenExpressions: []v1.EvaluatedWhenExpression{{
+			Input:    "aResultValue",
+			Operator: "in",
+			Values:   []string{"notResultValue"},
+			Outcome:  false,
+		}},
 	}}
 	if d := cmp.Diff(expectedSkippedTasks, actualSkippedTasks); d != "" {
 		t.Errorf("expected to find Skipped Tasks %v. Diff %s", expectedSkippedTasks, diff.PrintWantGot(d))
@@ -4705,6 +5745,13 @@ spec:
 		}, {
 			CEL: "'yes'!='yes'",
 		}},
+		EvaluatedWhenExpressions: []v1.EvaluatedWhenExpression{{
+			CEL:     "'aResultValue' == 'missing'",
+			Outcome: false,
+		}, {
+			CEL:     "'yes'!='yes'",
+			Outcome: false,
+		}},
 	}}
 	if d := cmp.Diff(expectedSkippedTasks, actualSkippedTasks); d != "" {
 		t.Errorf("expected to find Skipped Tasks %v. Diff %s", expectedSkippedTasks, diff.PrintWantGot(d))
@@ -4872,6 +5919,10 @@ spec:
 		WhenExpressions: v1.WhenExpressions{{
 			CEL: "'Failed' == 'Succeeded'",
 		}},
+		EvaluatedWhenExpressions: []v1.EvaluatedWhenExpression{{
+			CEL:     "'Failed' == 'Succeeded'",
+			Outcome: false,
+		}},
 	}}
 	if d := cmp.Diff(expectedSkippedTasks, actualSkippedTasks); d != "" {
 		t.Errorf("expected to find Skipped Tasks %v. Diff %s", expectedSkippedTasks, diff.PrintWantGot(d))
@@ -5914,6 +6965,133 @@ spec:
 	}
 }
 
+// TestReconcileWithTaskResultsInWorkspaceSubPath checks that the results of a
+// PipelineTask are substituted in the workspace subPaths of the PipelineTasks
+// consuming them, and that the PipelineRun fails if the substituted subPath
+// escapes the workspace.
+func TestReconcileWithTaskResultsInWorkspaceSubPath(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		rootdir     string
+		wantSubPath string
+		wantReason  string
+	}{{
+		name:        "result substituted",
+		rootdir:     "extracted",
+		wantSubPath: "extracted/src",
+	}, {
+		name:       "path traversal rejected",
+		rootdir:    "../secrets",
+		wantReason: v1.PipelineRunReasonInvalidWorkspaceBinding.String(),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			names.TestingSeed()
+			ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  workspaces:
+  - name: source
+  tasks:
+  - name: extract
+    taskRef:
+      name: extract
+  - name: build
+    taskRef:
+      name: build
+    workspaces:
+    - name: src
+      workspace: source
+      subPath: $(tasks.extract.results.rootdir)/src
+`)}
+			prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run-subpath
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+  taskRunTemplate:
+    serviceAccountName: test-sa-0
+  workspaces:
+  - name: source
+    emptyDir: {}
+`)}
+			ts := []*v1.Task{
+				parse.MustParseV1Task(t, `
+metadata:
+  name: extract
+  namespace: foo
+spec:
+  results:
+  - name: rootdir
+`),
+				parse.MustParseV1Task(t, `
+metadata:
+  name: build
+  namespace: foo
+spec:
+  workspaces:
+  - name: src
+`),
+			}
+			trs := []*v1.TaskRun{mustParseTaskRunWithObjectMeta(t,
+				taskRunObjectMeta("test-pipeline-run-subpath-extract", "foo",
+					"test-pipeline-run-subpath", "test-pipeline", "extract", false),
+				fmt.Sprintf(`
+spec:
+  serviceAccountName: test-sa-0
+  taskRef:
+    name: extract
+    kind: Task
+status:
+  conditions:
+  - lastTransitionTime: null
+    status: "True"
+    type: Succeeded
+  results:
+  - name: rootdir
+    value: %s
+    type: string
+`, tc.rootdir))}
+
+			d := test.Data{
+				PipelineRuns: prs,
+				Pipelines:    ps,
+				Tasks:        ts,
+				TaskRuns:     trs,
+			}
+			prt := newPipelineRunTest(t, d)
+			defer prt.Cancel()
+
+			pr, clients := prt.reconcileRun("foo", "test-pipeline-run-subpath", nil, tc.wantReason != "")
+
+			actual, err := clients.Pipeline.TektonV1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{
+				LabelSelector: "tekton.dev/pipelineTask=build,tekton.dev/pipelineRun=test-pipeline-run-subpath",
+			})
+			if err != nil {
+				t.Fatalf("Failure to list TaskRun's %s", err)
+			}
+			if tc.wantReason != "" {
+				if c := pr.Status.GetCondition(apis.ConditionSucceeded); c.Status != corev1.ConditionFalse || c.Reason != tc.wantReason {
+					t.Errorf("expected the PipelineRun to fail with the reason %s, got %v", tc.wantReason, c)
+				}
+				if len(actual.Items) != 0 {
+					t.Errorf("expected no TaskRun for the build task, got %d", len(actual.Items))
+				}
+				return
+			}
+			if len(actual.Items) != 1 {
+				t.Fatalf("Expected 1 TaskRun for the build task, got %d", len(actual.Items))
+			}
+			if got := actual.Items[0].Spec.Workspaces[0].SubPath; got != tc.wantSubPath {
+				t.Errorf("expected the subPath %q, got %q", tc.wantSubPath, got)
+			}
+		})
+	}
+}
+
 func TestReconcileAndPopulateTaskResultsToWorkspaceBindings(t *testing.T) {
 	names.TestingSeed()
 	ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, `
@@ -6516,10 +7694,6 @@ status:
   - name: custom-result
     value: bResultValue
   childReferences:
-  - apiVersion: tekton.dev/v1
-    kind: TaskRun
-    name: test-pipeline-run-finally-results-task-run-c
-    pipelineTaskName: c-task
   - apiVersion: tekton.dev/v1
     kind: TaskRun
     name: test-pipeline-run-finally-results-task-run-a
@@ -6528,11 +7702,15 @@ status:
     kind: CustomRun
     name: test-pipeline-run-finally-results-task-run-b
     pipelineTaskName: b-task
+  - apiVersion: tekton.dev/v1
+    kind: TaskRun
+    name: test-pipeline-run-finally-results-task-run-c
+    pipelineTaskName: c-task
 `)
 
 	expectedPr := expectedPrStatus
 
-	if d := cmp.Diff(expectedPr, reconciledRun, ignoreResourceVersion, ignoreLastTransitionTime, ignoreCompletionTime, ignoreStartTime,
+	if d := cmp.Diff(expectedPr, reconciledRun, ignoreResourceVersion, ignoreLastTransitionTime, ignoreCompletionTime, ignoreStartTime, ignoreSummary,
 		ignoreProvenance, ignoreFinallyStartTime, cmpopts.EquateEmpty()); d != "" {
 		t.Errorf("expected to see pipeline run results created. Diff %s", diff.PrintWantGot(d))
 	}
@@ -6546,54 +7724,314 @@ metadata:
   namespace: foo
 spec:
   results:
-    - description: pipeline result
-      name: result
-      value: $(tasks.a-task.results.a-Result)
-    - description: custom task pipeline result
-      name: custom-result
-      value: $(tasks.b-task.results.b-Result)
+    - description: pipeline result
+      name: result
+      value: $(tasks.a-task.results.a-Result)
+    - description: custom task pipeline result
+      name: custom-result
+      value: $(tasks.b-task.results.b-Result)
+  tasks:
+  - name: b-task
+    taskRef:
+      apiVersion: example.dev/v0
+      kind: Example
+      name: b-task
+  - name: a-task
+    taskRef:
+      name: a-task
+`)}
+	trs := []*v1.TaskRun{mustParseTaskRunWithObjectMeta(t,
+		taskRunObjectMeta("test-pipeline-run-results-task-run-a", "foo",
+			"test-pipeline-run-results", "test-pipeline", "a-task", true),
+		`
+spec:
+  taskRef:
+    name: hello-world
+status:
+  conditions:
+  - status: "True"
+    type: Succeeded
+  results:
+  - name: a-Result
+    value: aResultValue
+`)}
+	rs := []*v1beta1.CustomRun{mustParseCustomRunWithObjectMeta(t,
+		taskRunObjectMeta("test-pipeline-run-results-task-run-b", "foo",
+			"test-pipeline-run-results", "test-pipeline", "b-task", true),
+		`
+spec:
+  customRef:
+    apiVersion: example.dev/v0
+    kind: Example
+status:
+  conditions:
+  - status: "True"
+    type: Succeeded
+  results:
+  - name: b-Result
+    value: bResultValue
+`)}
+	prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run-results
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+status:
+  conditions:
+  - status: "Unknown"
+    type: Succeeded
+`)}
+	ts := []*v1.Task{
+		parse.MustParseV1Task(t, `
+metadata:
+  name: a-task
+  namespace: foo
+spec:
+  results:
+  - name: a-Result
+`),
+	}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
+		CustomRuns:   rs,
+		ConfigMaps:   []*corev1.ConfigMap{newFeatureFlagsConfigMap()},
+	}
+
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
+	reconciledRun, _ := prt.reconcileRun("foo", "test-pipeline-run-results", []string{}, false)
+
+	expectedPrStatus := parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run-results
+  namespace: foo
+  labels:
+    tekton.dev/pipeline: test-pipeline
+  annotations: {}
+spec:
+  pipelineRef:
+    name: test-pipeline
+status:
+  pipelineSpec:
+    results:
+    - description: pipeline result
+      name: result
+      value: $(tasks.a-task.results.a-Result)
+    - description: custom task pipeline result
+      name: custom-result
+      value: $(tasks.b-task.results.b-Result)
+    tasks:
+    - name: b-task
+      taskRef:
+        name: b-task
+        apiVersion: example.dev/v0
+        kind: Example
+    - name: a-task
+      taskRef:
+        name: a-task
+        kind: Task
+  conditions:
+  - status: "True"
+    type: Succeeded
+    reason: Succeeded
+    message: "Tasks Completed: 2 (Failed: 0, Cancelled 0), Skipped: 0"
+  results:
+  - name: result
+    value: aResultValue
+  - name: custom-result
+    value: bResultValue
+  childReferences:
+  - apiVersion: tekton.dev/v1
+    kind: TaskRun
+    name: test-pipeline-run-results-task-run-a
+    pipelineTaskName: a-task
+  - apiVersion: tekton.dev/v1beta1
+    kind: CustomRun
+    name: test-pipeline-run-results-task-run-b
+    pipelineTaskName: b-task
+`)
+
+	expectedPr := expectedPrStatus
+
+	if d := cmp.Diff(expectedPr, reconciledRun, ignoreResourceVersion, ignoreLastTransitionTime, ignoreCompletionTime,
+		ignoreStartTime, ignoreSummary, ignoreProvenance, cmpopts.EquateEmpty()); d != "" {
+		t.Errorf("expected to see pipeline run results created. Diff %s", diff.PrintWantGot(d))
+	}
+	expectedSummary := &v1.PipelineRunSummary{CompletedTasks: 2, TotalTasks: 2}
+	if d := cmp.Diff(expectedSummary, reconciledRun.Status.Summary, cmpopts.IgnoreFields(v1.PipelineRunSummary{}, "Duration")); d != "" {
+		t.Errorf("expected to see summary %v. Diff %s", expectedSummary, diff.PrintWantGot(d))
+	}
+}
+
+func TestReconcilePipelineRunQueuedTasks(t *testing.T) {
+	ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: a-task
+    taskRef:
+      name: hello-world
+  - name: b-task
+    taskRef:
+      name: hello-world
+`)}
+	trs := []*v1.TaskRun{mustParseTaskRunWithObjectMeta(t,
+		taskRunObjectMeta("test-pipeline-run-queued-a-task", "foo",
+			"test-pipeline-run-queued", "test-pipeline", "a-task", false),
+		`
+spec:
+  taskRef:
+    name: hello-world
+status:
+  conditions:
+  - status: "Unknown"
+    type: Succeeded
+  podName: test-pipeline-run-queued-a-task-pod
+  podCreationTime: "2022-01-01T00:00:02Z"
+  podStartTime: "2022-01-01T00:00:45Z"
+`), mustParseTaskRunWithObjectMeta(t,
+		taskRunObjectMeta("test-pipeline-run-queued-b-task", "foo",
+			"test-pipeline-run-queued", "test-pipeline", "b-task", false),
+		`
+spec:
+  taskRef:
+    name: hello-world
+status:
+  conditions:
+  - status: "Unknown"
+    type: Succeeded
+  podName: test-pipeline-run-queued-b-task-pod
+  podCreationTime: "2022-01-01T00:00:03Z"
+`)}
+	created := metav1.NewTime(now)
+	for _, tr := range trs {
+		tr.CreationTimestamp = created
+	}
+	prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run-queued
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+status:
+  conditions:
+  - status: "Unknown"
+    type: Succeeded
+  childReferences:
+  - apiVersion: tekton.dev/v1
+    kind: TaskRun
+    name: test-pipeline-run-queued-a-task
+    pipelineTaskName: a-task
+  - apiVersion: tekton.dev/v1
+    kind: TaskRun
+    name: test-pipeline-run-queued-b-task
+    pipelineTaskName: b-task
+`)}
+	ts := []*v1.Task{simpleHelloWorldTask}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
+		ConfigMaps:   []*corev1.ConfigMap{newFeatureFlagsConfigMap()},
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
+	reconciledRun, _ := prt.reconcileRun("foo", "test-pipeline-run-queued", []string{}, false)
+
+	// The queue times are part of the summary, which is kept in the minimal status
+	podCreatedA := metav1.NewTime(now.Add(2 * time.Second))
+	podStartedA := metav1.NewTime(now.Add(45 * time.Second))
+	podCreatedB := metav1.NewTime(now.Add(3 * time.Second))
+	wantQueuedTasks := []v1.PipelineTaskQueueTime{{
+		Name:             "test-pipeline-run-queued-a-task",
+		PipelineTaskName: "a-task",
+		CreationTime:     &created,
+		PodCreationTime:  &podCreatedA,
+		PodStartTime:     &podStartedA,
+		QueuedDuration:   "45s",
+	}, {
+		Name:             "test-pipeline-run-queued-b-task",
+		PipelineTaskName: "b-task",
+		CreationTime:     &created,
+		PodCreationTime:  &podCreatedB,
+	}}
+	if d := cmp.Diff(wantQueuedTasks, reconciledRun.Status.Summary.QueuedTasks); d != "" {
+		t.Errorf("expected to see the queue times of the TaskRuns in the summary. Diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestReconcileWithPipelineResults_ObjectResultSchema(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		objectResult   string
+		permanentError bool
+		wantReason     string
+		wantEvents     []string
+	}{{
+		name: "object result conforming to its properties",
+		objectResult: `
+    value:
+      url: abc
+      commit: xyz
+`,
+		wantReason: v1.PipelineRunReasonSuccessful.String(),
+		wantEvents: []string{"Normal Started", "Normal Succeeded Tasks Completed: 1"},
+	}, {
+		name: "object result missing a property",
+		objectResult: `
+    value:
+      url: abc
+`,
+		permanentError: true,
+		wantReason:     v1.PipelineRunReasonResultSchemaMismatch.String(),
+		wantEvents: []string{
+			"Normal Started",
+			`Warning Failed PipelineResults of PipelineRun test-pipeline-run-results don't match the properties declared by their Tasks: result "object" is missing property "commit"`,
+			"Warning InternalError",
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			names.TestingSeed()
+			ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  results:
+    - name: object
+      type: object
+      value: $(tasks.a-task.results.object[*])
   tasks:
-  - name: b-task
-    taskRef:
-      apiVersion: example.dev/v0
-      kind: Example
-      name: b-task
   - name: a-task
     taskRef:
       name: a-task
 `)}
-	trs := []*v1.TaskRun{mustParseTaskRunWithObjectMeta(t,
-		taskRunObjectMeta("test-pipeline-run-results-task-run-a", "foo",
-			"test-pipeline-run-results", "test-pipeline", "a-task", true),
-		`
+			trs := []*v1.TaskRun{mustParseTaskRunWithObjectMeta(t,
+				taskRunObjectMeta("test-pipeline-run-results-task-run-a", "foo",
+					"test-pipeline-run-results", "test-pipeline", "a-task", true),
+				`
 spec:
   taskRef:
-    name: hello-world
-status:
-  conditions:
-  - status: "True"
-    type: Succeeded
-  results:
-  - name: a-Result
-    value: aResultValue
-`)}
-	rs := []*v1beta1.CustomRun{mustParseCustomRunWithObjectMeta(t,
-		taskRunObjectMeta("test-pipeline-run-results-task-run-b", "foo",
-			"test-pipeline-run-results", "test-pipeline", "b-task", true),
-		`
-spec:
-  customRef:
-    apiVersion: example.dev/v0
-    kind: Example
+    name: a-task
 status:
   conditions:
   - status: "True"
     type: Succeeded
   results:
-  - name: b-Result
-    value: bResultValue
-`)}
-	prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
+  - name: object
+    type: object`+tc.objectResult)}
+			prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
 metadata:
   name: test-pipeline-run-results
   namespace: foo
@@ -6605,85 +8043,119 @@ status:
   - status: "Unknown"
     type: Succeeded
 `)}
-	ts := []*v1.Task{
-		parse.MustParseV1Task(t, `
+			ts := []*v1.Task{parse.MustParseV1Task(t, `
 metadata:
   name: a-task
   namespace: foo
 spec:
   results:
-  - name: a-Result
-`),
-	}
+  - name: object
+    type: object
+    properties:
+      url:
+        type: string
+      commit:
+        type: string
+`)}
+			cm := newFeatureFlagsConfigMap()
+			cm.Data[config.EnableResultSchemaValidation] = "true"
+			d := test.Data{
+				PipelineRuns: prs,
+				Pipelines:    ps,
+				Tasks:        ts,
+				TaskRuns:     trs,
+				ConfigMaps:   []*corev1.ConfigMap{withEnabledAlphaAPIFields(cm)},
+			}
 
-	d := test.Data{
-		PipelineRuns: prs,
-		Pipelines:    ps,
-		Tasks:        ts,
-		TaskRuns:     trs,
-		CustomRuns:   rs,
-		ConfigMaps:   []*corev1.ConfigMap{newFeatureFlagsConfigMap()},
-	}
+			prt := newPipelineRunTest(t, d)
+			defer prt.Cancel()
+			reconciledRun, _ := prt.reconcileRun("foo", "test-pipeline-run-results", tc.wantEvents, tc.permanentError)
 
-	prt := newPipelineRunTest(t, d)
-	defer prt.Cancel()
-	reconciledRun, _ := prt.reconcileRun("foo", "test-pipeline-run-results", []string{}, false)
+			if reason := reconciledRun.Status.GetCondition(apis.ConditionSucceeded).Reason; reason != tc.wantReason {
+				t.Errorf("expected the PipelineRun to have reason %q but got %q", tc.wantReason, reason)
+			}
+		})
+	}
+}
 
-	expectedPrStatus := parse.MustParseV1PipelineRun(t, `
+func TestReconcile_FailedTasksMessage(t *testing.T) {
+	names.TestingSeed()
+	// Twelve concurrent tasks fail, listed in reverse order to check the failures are sorted
+	taskCount := v1.MaxPipelineTaskFailures + 2
+	var pipelineTasks []string
+	var trs []*v1.TaskRun
+	for i := taskCount - 1; i >= 0; i-- {
+		name := fmt.Sprintf("task-%02d", i)
+		pipelineTasks = append(pipelineTasks, fmt.Sprintf("  - name: %s\n    taskRef:\n      name: hello-world\n", name))
+		trs = append(trs, mustParseTaskRunWithObjectMeta(t,
+			taskRunObjectMeta("test-pipeline-run-failures-"+name, "foo", "test-pipeline-run-failures", "test-pipeline", name, true),
+			fmt.Sprintf(`
+spec:
+  taskRef:
+    name: hello-world
+status:
+  conditions:
+  - status: "False"
+    type: Succeeded
+    reason: Failed
+    message: |-
+      "step-%s" exited with code 1
+      see the logs of the step
+`, name)))
+	}
+	ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, `
 metadata:
-  name: test-pipeline-run-results
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+`+strings.Join(pipelineTasks, ""))}
+	prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run-failures
   namespace: foo
-  labels:
-    tekton.dev/pipeline: test-pipeline
-  annotations: {}
 spec:
   pipelineRef:
     name: test-pipeline
+  timeouts:
+    pipeline: "0"
 status:
-  pipelineSpec:
-    results:
-    - description: pipeline result
-      name: result
-      value: $(tasks.a-task.results.a-Result)
-    - description: custom task pipeline result
-      name: custom-result
-      value: $(tasks.b-task.results.b-Result)
-    tasks:
-    - name: b-task
-      taskRef:
-        name: b-task
-        apiVersion: example.dev/v0
-        kind: Example
-    - name: a-task
-      taskRef:
-        name: a-task
-        kind: Task
   conditions:
-  - status: "True"
+  - message: Message
+    reason: Running
+    status: "Unknown"
     type: Succeeded
-    reason: Succeeded
-    message: "Tasks Completed: 2 (Failed: 0, Cancelled 0), Skipped: 0"
-  results:
-  - name: result
-    value: aResultValue
-  - name: custom-result
-    value: bResultValue
-  childReferences:
-  - apiVersion: tekton.dev/v1beta1
-    kind: CustomRun
-    name: test-pipeline-run-results-task-run-b
-    pipelineTaskName: b-task
-  - apiVersion: tekton.dev/v1
-    kind: TaskRun
-    name: test-pipeline-run-results-task-run-a
-    pipelineTaskName: a-task
-`)
+  startTime: "2021-12-31T00:00:00Z"
+`)}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        []*v1.Task{simpleHelloWorldTask},
+		TaskRuns:     trs,
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
 
-	expectedPr := expectedPrStatus
+	reconciledRun, _ := prt.reconcileRun("foo", "test-pipeline-run-failures", []string{}, false)
 
-	if d := cmp.Diff(expectedPr, reconciledRun, ignoreResourceVersion, ignoreLastTransitionTime, ignoreCompletionTime,
-		ignoreStartTime, ignoreProvenance, cmpopts.EquateEmpty()); d != "" {
-		t.Errorf("expected to see pipeline run results created. Diff %s", diff.PrintWantGot(d))
+	wantMessage := "Tasks Completed: 12 (Failed: 12, Cancelled 0), Skipped: 0\nFailed tasks:"
+	var wantFailures []v1.PipelineTaskFailure
+	for i := range v1.MaxPipelineTaskFailures {
+		name := fmt.Sprintf("task-%02d", i)
+		failure := v1.PipelineTaskFailure{Name: name, Reason: "Failed", Message: fmt.Sprintf(`"step-%s" exited with code 1`, name)}
+		wantMessage += fmt.Sprintf("\n- %s (%s): %s", failure.Name, failure.Reason, failure.Message)
+		wantFailures = append(wantFailures, failure)
+	}
+	wantMessage += "\n- ... and 2 more"
+	condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+	if condition.Reason != v1.PipelineRunReasonFailed.String() {
+		t.Errorf("Expected the PipelineRun to fail but got reason %q", condition.Reason)
+	}
+	if d := cmp.Diff(wantMessage, condition.Message); d != "" {
+		t.Errorf("Unexpected failure message %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(wantFailures, reconciledRun.Status.Summary.FailedTasks); d != "" {
+		t.Errorf("Unexpected failed tasks in the summary %s", diff.PrintWantGot(d))
 	}
 }
 
@@ -6786,7 +8258,7 @@ spec:
     pipeline: "0"
 status:
   conditions:
-  - message: "Tasks Completed: 2 (Failed: 1, Cancelled 0), Skipped: 0"
+  - message: "Tasks Completed: 2 (Failed: 1, Cancelled 0), Skipped: 0\nFailed tasks:\n- b-task"
     reason: Failed
     status: "False"
     type: Succeeded
@@ -8299,8 +9771,9 @@ spec:
 		t.Errorf("expected to see TaskRun %v created. Diff %s", expectedTaskRunName, diff.PrintWantGot(d))
 	}
 	expectedSkippedTasks := []v1.SkippedTask{{
-		Name:   "final-task-2",
-		Reason: v1.MissingResultsSkip,
+		Name:           "final-task-2",
+		Reason:         v1.MissingResultsSkip,
+		FailedAncestor: "dag-task-2",
 	}, {
 		Name:   "final-task-3",
 		Reason: v1.WhenExpressionsSkip,
@@ -8309,14 +9782,102 @@ spec:
 			Operator: "notin",
 			Values:   []string{"aResultValue"},
 		}},
+		EvaluatedWhenExpressions: []v1.EvaluatedWhenExpression{{
+			Input:    "aResultValue",
+			Operator: "notin",
+			Values:   []string{"aResultValue"},
+			Outcome:  false,
+		}},
 	}, {
-		Name:   "final-task-5",
-		Reason: v1.MissingResultsSkip,
+		Name:           "final-task-5",
+		Reason:         v1.MissingResultsSkip,
+		FailedAncestor: "dag-task-2",
 	}, {
-		Name:   "final-task-6",
-		Reason: v1.MissingResultsSkip,
+		Name:           "final-task-6",
+		Reason:         v1.MissingResultsSkip,
+		FailedAncestor: "dag-task-2",
+	}}
+
+	if d := cmp.Diff(expectedSkippedTasks, reconciledRun.Status.SkippedTasks); d != "" {
+		t.Fatalf("Didn't get the expected list of skipped tasks. Diff: %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestReconcileWithFailedTaskReportsFailedAncestorOfSkippedTasks(t *testing.T) {
+	names.TestingSeed()
+
+	ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: build
+    taskRef:
+      name: hello-world
+  - name: test
+    runAfter:
+    - build
+    taskRef:
+      name: hello-world
+  - name: deploy
+    runAfter:
+    - test
+    taskRef:
+      name: hello-world
+`)}
+	prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run-failed-ancestor
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+`)}
+	ts := []*v1.Task{simpleHelloWorldTask}
+	trs := []*v1.TaskRun{mustParseTaskRunWithObjectMeta(t,
+		taskRunObjectMeta("test-pipeline-run-failed-ancestor-build", "foo",
+			"test-pipeline-run-failed-ancestor", "test-pipeline", "build", false),
+		`
+spec:
+  taskRef:
+    name: hello-world
+status:
+  conditions:
+  - reason: Failed
+    status: "False"
+    type: Succeeded
+`)}
+	prs[0].Status.ChildReferences = []v1.ChildStatusReference{{
+		TypeMeta:         runtime.TypeMeta{APIVersion: "tekton.dev/v1", Kind: "TaskRun"},
+		Name:             "test-pipeline-run-failed-ancestor-build",
+		PipelineTaskName: "build",
 	}}
 
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
+
+	wantEvents := []string{
+		"Normal Started",
+		"Warning Failed Tasks Completed: 1 \\(Failed: 1, Cancelled 0\\), Skipped: 2",
+	}
+	reconciledRun, _ := prt.reconcileRun("foo", "test-pipeline-run-failed-ancestor", wantEvents, false)
+
+	expectedSkippedTasks := []v1.SkippedTask{{
+		Name:           "test",
+		Reason:         v1.StoppingSkip,
+		FailedAncestor: "build",
+	}, {
+		Name:           "deploy",
+		Reason:         v1.StoppingSkip,
+		FailedAncestor: "build",
+	}}
 	if d := cmp.Diff(expectedSkippedTasks, reconciledRun.Status.SkippedTasks); d != "" {
 		t.Fatalf("Didn't get the expected list of skipped tasks. Diff: %s", diff.PrintWantGot(d))
 	}
@@ -8996,13 +10557,102 @@ spec:
 			if run == nil {
 				t.Fatalf("Could not reconcile run %s", tc.name)
 			}
-			c := run.Status.GetCondition(apis.ConditionSucceeded)
-			if c.Status != corev1.ConditionFalse {
-				t.Errorf("expected Succeeded/False condition but saw: %v", c)
+			c := run.Status.GetCondition(apis.ConditionSucceeded)
+			if c.Status != corev1.ConditionFalse {
+				t.Errorf("expected Succeeded/False condition but saw: %v", c)
+			}
+			if c.Reason != tc.reason {
+				t.Errorf("want reason %s but got %s", tc.reason, c.Reason)
+			}
+		})
+	}
+}
+
+// TestReconcileResourceBudget checks that the peak resource requests of a
+// PipelineRun are recorded in its status and that a PipelineRun which exceeds
+// the resource budget of its namespace fails before creating any TaskRun.
+func TestReconcileResourceBudget(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		budget       map[string]string
+		wantStatus   corev1.ConditionStatus
+		wantReason   string
+		wantTaskRuns int
+	}{{
+		name:         "no budget",
+		wantStatus:   corev1.ConditionUnknown,
+		wantReason:   v1.PipelineRunReasonRunning.String(),
+		wantTaskRuns: 2,
+	}, {
+		name:         "within budget",
+		budget:       map[string]string{"cpu": "2", "memory": "1Gi"},
+		wantStatus:   corev1.ConditionUnknown,
+		wantReason:   v1.PipelineRunReasonRunning.String(),
+		wantTaskRuns: 2,
+	}, {
+		name:         "exceeds budget",
+		budget:       map[string]string{"cpu": "1500m"},
+		wantStatus:   corev1.ConditionFalse,
+		wantReason:   v1.PipelineRunReasonExceedsResourceBudget.String(),
+		wantTaskRuns: 0,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run-resource-budget
+  namespace: foo
+spec:
+  pipelineSpec:
+    tasks:
+    - name: build-a
+      taskSpec:
+        steps:
+        - name: build
+          image: busybox
+          computeResources:
+            requests:
+              cpu: "1"
+    - name: build-b
+      taskSpec:
+        steps:
+        - name: build
+          image: busybox
+          computeResources:
+            requests:
+              cpu: "1"
+    - name: package
+      runAfter: [build-a, build-b]
+      taskSpec:
+        steps:
+        - name: package
+          image: busybox
+          computeResources:
+            requests:
+              cpu: 1500m
+`)}
+			cms := []*corev1.ConfigMap{newFeatureFlagsConfigMap()}
+			if tc.budget != nil {
+				cms = append(cms, &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: resources.ResourceBudgetConfigMapName, Namespace: "foo"},
+					Data:       tc.budget,
+				})
+			}
+			d := test.Data{
+				PipelineRuns: prs,
+				ConfigMaps:   cms,
 			}
-			if c.Reason != tc.reason {
-				t.Errorf("want reason %s but got %s", tc.reason, c.Reason)
+			prt := newPipelineRunTest(t, d)
+			defer prt.Cancel()
+
+			reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-resource-budget", nil, tc.wantStatus == corev1.ConditionFalse)
+			checkPipelineRunConditionStatusAndReason(t, reconciledRun, tc.wantStatus, tc.wantReason)
+
+			wantPeak := resource.MustParse("2")
+			if got := reconciledRun.Status.PeakResourceRequests[corev1.ResourceCPU]; got.Cmp(wantPeak) != 0 {
+				t.Errorf("expected peak cpu requests %s but got %s", wantPeak.String(), got.String())
 			}
+			taskRuns := getTaskRunsForPipelineRun(prt.TestAssets.Ctx, t, clients, "foo", "test-pipeline-run-resource-budget")
+			validateTaskRunsCount(t, taskRuns, tc.wantTaskRuns)
 		})
 	}
 }
@@ -9869,6 +11519,293 @@ spec:
 	}
 }
 
+func TestReconcile_PropagateSourceEventAnnotations(t *testing.T) {
+	names.TestingSeed()
+
+	namespace := "foo"
+	prName := "test-pipeline-run"
+	trName := "test-pipeline-run-hello-world-1"
+
+	ps := []*v1.Pipeline{simpleHelloWorldPipeline}
+	prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run
+  namespace: foo
+  annotations:
+    tekton.dev/source-event-id: 72d3162e-cc78-11e3-81ab-4c9367dc0958
+    tekton.dev/source-event-type: push
+    tekton.dev/source-url: https://github.com/tektoncd/pipeline
+spec:
+  pipelineRef:
+    name: test-pipeline
+  taskRunSpecs:
+  - pipelineTaskName: hello-world-1
+    metadata:
+      annotations:
+        tekton.dev/source-event-id: overridden
+`)}
+	ts := []*v1.Task{simpleHelloWorldTask}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
+
+	reconciledRun, clients := prt.reconcileRun(namespace, prName, []string{}, false)
+
+	wantSourceEvent := &v1.SourceEvent{
+		ID:   "72d3162e-cc78-11e3-81ab-4c9367dc0958",
+		Type: "push",
+		URL:  "https://github.com/tektoncd/pipeline",
+	}
+	if reconciledRun.Status.Provenance == nil {
+		t.Fatal("expected the provenance of the PipelineRun to be set")
+	}
+	if d := cmp.Diff(wantSourceEvent, reconciledRun.Status.Provenance.SourceEvent); d != "" {
+		t.Errorf("expected the source event in the provenance of the PipelineRun %s", diff.PrintWantGot(d))
+	}
+
+	taskRuns := getTaskRunsForPipelineRun(prt.TestAssets.Ctx, t, clients, namespace, prName)
+	validateTaskRunsCount(t, taskRuns, 1)
+
+	actual := getTaskRunByName(t, taskRuns, trName)
+	want := map[string]string{
+		v1.SourceEventIDAnnotationKey:   "72d3162e-cc78-11e3-81ab-4c9367dc0958",
+		v1.SourceEventTypeAnnotationKey: "push",
+		v1.SourceURLAnnotationKey:       "https://github.com/tektoncd/pipeline",
+	}
+	if d := cmp.Diff(want, v1.SourceEventAnnotations(actual.Annotations)); d != "" {
+		t.Errorf("expected the source event annotations of the PipelineRun to be propagated to its TaskRun %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestReconcile_SourceEventAnnotationsOfChildrenNotPropagatedUp(t *testing.T) {
+	names.TestingSeed()
+
+	namespace := "foo"
+	prName := "test-pipeline-run"
+	trName := "test-pipeline-run-hello-world-1"
+
+	ps := []*v1.Pipeline{simpleHelloWorldPipeline}
+	prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run
+  namespace: foo
+  annotations:
+    tekton.dev/source-event-id: 72d3162e-cc78-11e3-81ab-4c9367dc0958
+    tekton.dev/source-event-type: push
+spec:
+  pipelineRef:
+    name: test-pipeline
+status:
+  childReferences:
+  - apiVersion: tekton.dev/v1
+    kind: TaskRun
+    name: test-pipeline-run-hello-world-1
+    pipelineTaskName: hello-world-1
+`)}
+	// The TaskRun was edited after its creation.
+	trs := []*v1.TaskRun{mustParseTaskRunWithObjectMeta(t,
+		taskRunObjectMetaWithAnnotations(trName, namespace, prName, "test-pipeline", "hello-world-1", false, map[string]string{
+			v1.SourceEventIDAnnotationKey:   "edited",
+			v1.SourceEventTypeAnnotationKey: "pull_request",
+			v1.SourceURLAnnotationKey:       "https://github.com/tektoncd/triggers",
+		}), `
+spec:
+  taskRef:
+    name: hello-world
+`)}
+	ts := []*v1.Task{simpleHelloWorldTask}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
+
+	reconciledRun, _ := prt.reconcileRun(namespace, prName, []string{}, false)
+
+	want := map[string]string{
+		v1.SourceEventIDAnnotationKey:   "72d3162e-cc78-11e3-81ab-4c9367dc0958",
+		v1.SourceEventTypeAnnotationKey: "push",
+	}
+	if d := cmp.Diff(want, v1.SourceEventAnnotations(reconciledRun.Annotations)); d != "" {
+		t.Errorf("expected the source event annotations of the PipelineRun to be unchanged %s", diff.PrintWantGot(d))
+	}
+	wantSourceEvent := &v1.SourceEvent{ID: "72d3162e-cc78-11e3-81ab-4c9367dc0958", Type: "push"}
+	if d := cmp.Diff(wantSourceEvent, reconciledRun.Status.Provenance.SourceEvent); d != "" {
+		t.Errorf("expected the source event in the provenance of the PipelineRun %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestReconcile_PropagatePipelineTaskRunSpecStepAndSidecarSpecs(t *testing.T) {
+	names.TestingSeed()
+
+	namespace := "foo"
+	prName := "test-pipeline-run"
+
+	ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: build
+    taskRef:
+      name: build
+    matrix:
+      params:
+      - name: platform
+        value:
+        - linux
+        - mac
+`)}
+	ts := []*v1.Task{parse.MustParseV1Task(t, `
+metadata:
+  name: build
+  namespace: foo
+spec:
+  params:
+  - name: platform
+  steps:
+  - name: compile
+    image: compiler
+  sidecars:
+  - name: cache
+    image: cache
+`)}
+	prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+  taskRunSpecs:
+  - pipelineTaskName: build
+    stepSpecs:
+    - name: compile
+      computeResources:
+        requests:
+          memory: 2Gi
+    sidecarSpecs:
+    - name: cache
+      computeResources:
+        requests:
+          memory: 1Gi
+`)}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
+
+	_, clients := prt.reconcileRun(namespace, prName, []string{}, false)
+
+	// Each TaskRun of the matrix gets the stepSpecs and sidecarSpecs
+	taskRuns := getTaskRunsForPipelineRun(prt.TestAssets.Ctx, t, clients, namespace, prName)
+	validateTaskRunsCount(t, taskRuns, 2)
+	wantStepSpecs := []v1.TaskRunStepSpec{{
+		Name:             "compile",
+		ComputeResources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")}},
+	}}
+	wantSidecarSpecs := []v1.TaskRunSidecarSpec{{
+		Name:             "cache",
+		ComputeResources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")}},
+	}}
+	for _, tr := range taskRuns {
+		if d := cmp.Diff(wantStepSpecs, tr.Spec.StepSpecs); d != "" {
+			t.Errorf("expected stepSpecs in TaskRun %s %s", tr.Name, diff.PrintWantGot(d))
+		}
+		if d := cmp.Diff(wantSidecarSpecs, tr.Spec.SidecarSpecs); d != "" {
+			t.Errorf("expected sidecarSpecs in TaskRun %s %s", tr.Name, diff.PrintWantGot(d))
+		}
+	}
+}
+
+func TestReconcile_InvalidPipelineTaskRunSpecStepAndSidecarSpecs(t *testing.T) {
+	ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: build
+    taskSpec:
+      steps:
+      - name: compile
+        image: compiler
+      sidecars:
+      - name: cache
+        image: cache
+`)}
+
+	for _, tc := range []struct {
+		name        string
+		taskRunSpec string
+		wantMessage string
+	}{{
+		name: "unknown step",
+		taskRunSpec: `
+    stepSpecs:
+    - name: link
+`,
+		wantMessage: `stepSpecs of pipelineTask "build" refer to step "link" which doesn't exist in its Task`,
+	}, {
+		name: "unknown sidecar",
+		taskRunSpec: `
+    sidecarSpecs:
+    - name: database
+`,
+		wantMessage: `sidecarSpecs of pipelineTask "build" refer to sidecar "database" which doesn't exist in its Task`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+  taskRunSpecs:
+  - pipelineTaskName: build`+tc.taskRunSpec)}
+
+			d := test.Data{
+				PipelineRuns: prs,
+				Pipelines:    ps,
+			}
+			prt := newPipelineRunTest(t, d)
+			defer prt.Cancel()
+
+			wantEvents := []string{
+				"Normal Started",
+				"Warning Failed .*PipelineRun foo/test-pipeline-run doesn't define taskRunSpecs correctly",
+				"Warning InternalError",
+			}
+			reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run", wantEvents, true)
+
+			condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+			if !condition.IsFalse() || condition.Reason != v1.PipelineRunReasonInvalidTaskRunSpec.String() {
+				t.Errorf("Expected PipelineRun to fail with reason %s but got condition %v", v1.PipelineRunReasonInvalidTaskRunSpec, condition)
+			}
+			if !strings.Contains(condition.Message, tc.wantMessage) {
+				t.Errorf("Expected the message of the condition to contain %q but got %q", tc.wantMessage, condition.Message)
+			}
+			taskRuns := getTaskRunsForPipelineRun(prt.TestAssets.Ctx, t, clients, "foo", "test-pipeline-run")
+			validateTaskRunsCount(t, taskRuns, 0)
+		})
+	}
+}
+
 func TestReconcile_AddMetadataByPrecedence(t *testing.T) {
 	names.TestingSeed()
 
@@ -10242,6 +12179,10 @@ status:
     reason: "Running"
     message: "Tasks Completed: 0 (Failed: 0, Cancelled 0), Incomplete: 2, Skipped: 0"
   childReferences:
+  - apiVersion: tekton.dev/v1
+    kind: TaskRun
+    name: pr-matrix-using-platforms
+    pipelineTaskName: matrix-using-platforms
   - apiVersion: tekton.dev/v1
     kind: TaskRun
     name: pr-platforms-and-browsers-0
@@ -10278,10 +12219,6 @@ status:
     kind: TaskRun
     name: pr-platforms-and-browsers-8
     pipelineTaskName: platforms-and-browsers
-  - apiVersion: tekton.dev/v1
-    kind: TaskRun
-    name: pr-matrix-using-platforms
-    pipelineTaskName: matrix-using-platforms
 `),
 	}, {
 		name:     "p-finally",
@@ -10397,10 +12334,6 @@ status:
     reason: "Running"
     message: "Tasks Completed: 1 (Failed: 0, Cancelled 0), Incomplete: 1, Skipped: 0"
   childReferences:
-  - apiVersion: tekton.dev/v1
-    kind: TaskRun
-    name: pr-unmatrixed-pt
-    pipelineTaskName: unmatrixed-pt
   - apiVersion: tekton.dev/v1
     kind: TaskRun
     name: pr-platforms-and-browsers-0
@@ -10437,6 +12370,10 @@ status:
     kind: TaskRun
     name: pr-platforms-and-browsers-8
     pipelineTaskName: platforms-and-browsers
+  - apiVersion: tekton.dev/v1
+    kind: TaskRun
+    name: pr-unmatrixed-pt
+    pipelineTaskName: unmatrixed-pt
 `),
 	}}
 	for _, tt := range tests {
@@ -10490,7 +12427,7 @@ spec:
 				t.Fatalf("Got an error getting reconciled run out of fake client: %s", err)
 			}
 			if d := cmp.Diff(tt.expectedPipelineRun, pipelineRun, ignoreResourceVersion, ignoreTypeMeta, ignoreLastTransitionTime,
-				ignoreStartTime, ignoreFinallyStartTime, ignoreProvenance, cmpopts.EquateEmpty()); d != "" {
+				ignoreStartTime, ignoreSummary, ignoreFinallyStartTime, ignoreProvenance, cmpopts.EquateEmpty()); d != "" {
 				t.Errorf("expected PipelineRun was not created. Diff %s", diff.PrintWantGot(d))
 			}
 		})
@@ -10741,7 +12678,7 @@ labels:
 			}
 
 			if d := cmp.Diff(tt.expectedPipelineRun, pipelineRun, ignoreResourceVersion, ignoreTypeMeta, ignoreLastTransitionTime,
-				ignoreStartTime, ignoreFinallyStartTime, ignoreProvenance, cmpopts.EquateEmpty()); d != "" {
+				ignoreStartTime, ignoreSummary, ignoreFinallyStartTime, ignoreProvenance, cmpopts.EquateEmpty()); d != "" {
 				t.Errorf("found PipelineRun does not match expected PipelineRun. Diff %s", diff.PrintWantGot(d))
 			}
 		})
@@ -11206,10 +13143,6 @@ status:
     reason: "Running"
     message: "Tasks Completed: 1 (Failed: 0, Cancelled 0), Incomplete: 1, Skipped: 0"
   childReferences:
-  - apiVersion: tekton.dev/v1
-    kind: TaskRun
-    name: pr-unmatrixed-pt
-    pipelineTaskName: unmatrixed-pt
   - apiVersion: tekton.dev/v1
     kind: TaskRun
     name: pr-matrix-include-0
@@ -11245,6 +13178,10 @@ status:
     name: pr-matrix-include-6
     displayName: non-existent-arch
     pipelineTaskName: matrix-include
+  - apiVersion: tekton.dev/v1
+    kind: TaskRun
+    name: pr-unmatrixed-pt
+    pipelineTaskName: unmatrixed-pt
 `),
 	}}
 	for _, tt := range tests {
@@ -11299,7 +13236,7 @@ spec:
 			}
 
 			if d := cmp.Diff(tt.expectedPipelineRun, pipelineRun, ignoreResourceVersion, ignoreTypeMeta, ignoreLastTransitionTime,
-				ignoreStartTime, ignoreFinallyStartTime, ignoreProvenance, cmpopts.EquateEmpty()); d != "" {
+				ignoreStartTime, ignoreSummary, ignoreFinallyStartTime, ignoreProvenance, cmpopts.EquateEmpty()); d != "" {
 				t.Errorf("expected PipelineRun was not created. Diff %s", diff.PrintWantGot(d))
 			}
 		})
@@ -11529,7 +13466,7 @@ spec:
 			}
 
 			if d := cmp.Diff(tt.expectedPipelineRun, pipelineRun, ignoreResourceVersion, ignoreTypeMeta, ignoreLastTransitionTime,
-				ignoreStartTime, ignoreFinallyStartTime, ignoreProvenance, cmpopts.EquateEmpty()); d != "" {
+				ignoreStartTime, ignoreSummary, ignoreFinallyStartTime, ignoreProvenance, cmpopts.EquateEmpty()); d != "" {
 				t.Errorf("expected PipelineRun was not created. Diff %s", diff.PrintWantGot(d))
 			}
 		})
@@ -11867,10 +13804,6 @@ status:
     reason: "Running"
     message: "Tasks Completed: 1 (Failed: 0, Cancelled 0), Incomplete: 1, Skipped: 0"
   childReferences:
-  - apiVersion: tekton.dev/v1
-    kind: TaskRun
-    name: pr-pt-with-result
-    pipelineTaskName: pt-with-result
   - apiVersion: tekton.dev/v1
     kind: TaskRun
     name: pr-platforms-and-browsers-0
@@ -11907,6 +13840,10 @@ status:
     kind: TaskRun
     name: pr-platforms-and-browsers-8
     pipelineTaskName: platforms-and-browsers
+  - apiVersion: tekton.dev/v1
+    kind: TaskRun
+    name: pr-pt-with-result
+    pipelineTaskName: pt-with-result
 `),
 	}, {
 		name:     "p-finally",
@@ -12033,10 +13970,6 @@ status:
     reason: "Running"
     message: "Tasks Completed: 1 (Failed: 0, Cancelled 0), Incomplete: 1, Skipped: 0"
   childReferences:
-  - apiVersion: tekton.dev/v1
-    kind: TaskRun
-    name: pr-pt-with-result
-    pipelineTaskName: pt-with-result
   - apiVersion: tekton.dev/v1
     kind: TaskRun
     name: pr-platforms-and-browsers-0
@@ -12073,6 +14006,10 @@ status:
     kind: TaskRun
     name: pr-platforms-and-browsers-8
     pipelineTaskName: platforms-and-browsers
+  - apiVersion: tekton.dev/v1
+    kind: TaskRun
+    name: pr-pt-with-result
+    pipelineTaskName: pt-with-result
 `),
 	}}
 	for _, tt := range tests {
@@ -12127,7 +14064,7 @@ spec:
 			}
 
 			if d := cmp.Diff(tt.expectedPipelineRun, pipelineRun, ignoreResourceVersion, ignoreTypeMeta, ignoreLastTransitionTime,
-				ignoreStartTime, ignoreFinallyStartTime, ignoreProvenance, cmpopts.EquateEmpty()); d != "" {
+				ignoreStartTime, ignoreSummary, ignoreFinallyStartTime, ignoreProvenance, cmpopts.EquateEmpty()); d != "" {
 				t.Errorf("expected PipelineRun was not created. Diff %s", diff.PrintWantGot(d))
 			}
 		})
@@ -12280,14 +14217,14 @@ status:
     reason: "Running"
     message: "Tasks Completed: 1 (Failed: 0, Cancelled 0), Incomplete: 1, Skipped: 0"
   childReferences:
-  - apiVersion: tekton.dev/v1
-    kind: TaskRun
-    name: pr-pt-with-result
-    pipelineTaskName: pt-with-result
   - apiVersion: tekton.dev/v1
     kind: TaskRun
     name: pr-echo-platforms
     pipelineTaskName: echo-platforms
+  - apiVersion: tekton.dev/v1
+    kind: TaskRun
+    name: pr-pt-with-result
+    pipelineTaskName: pt-with-result
 `),
 	}, {
 		name:  "indexing results in matrix.params",
@@ -12425,10 +14362,6 @@ status:
     reason: "Running"
     message: "Tasks Completed: 1 (Failed: 0, Cancelled 0), Incomplete: 1, Skipped: 0"
   childReferences:
-  - apiVersion: tekton.dev/v1
-    kind: TaskRun
-    name: pr-pt-with-result
-    pipelineTaskName: pt-with-result
   - apiVersion: tekton.dev/v1
     kind: TaskRun
     name: pr-echo-platforms-0
@@ -12441,6 +14374,10 @@ status:
     kind: TaskRun
     name: pr-echo-platforms-2
     pipelineTaskName: echo-platforms
+  - apiVersion: tekton.dev/v1
+    kind: TaskRun
+    name: pr-pt-with-result
+    pipelineTaskName: pt-with-result
 `),
 	}, {
 		name:  "whole array result replacements in matrix.params",
@@ -12572,10 +14509,6 @@ status:
     reason: "Running"
     message: "Tasks Completed: 1 (Failed: 0, Cancelled 0), Incomplete: 1, Skipped: 0"
   childReferences:
-  - apiVersion: tekton.dev/v1
-    kind: TaskRun
-    name: pr-pt-with-result
-    pipelineTaskName: pt-with-result
   - apiVersion: tekton.dev/v1
     kind: TaskRun
     name: pr-echo-platforms-0
@@ -12588,6 +14521,10 @@ status:
     kind: TaskRun
     name: pr-echo-platforms-2
     pipelineTaskName: echo-platforms
+  - apiVersion: tekton.dev/v1
+    kind: TaskRun
+    name: pr-pt-with-result
+    pipelineTaskName: pt-with-result
 `),
 	}}
 	for _, tt := range tests {
@@ -12626,7 +14563,7 @@ spec:
 			}
 
 			if d := cmp.Diff(tt.expectedPipelineRun, pipelineRun, ignoreResourceVersion, ignoreTypeMeta, ignoreLastTransitionTime,
-				ignoreStartTime, ignoreFinallyStartTime, ignoreProvenance, cmpopts.EquateEmpty()); d != "" {
+				ignoreStartTime, ignoreSummary, ignoreFinallyStartTime, ignoreProvenance, cmpopts.EquateEmpty()); d != "" {
 				t.Errorf("expected PipelineRun was not created. Diff %s", diff.PrintWantGot(d))
 			}
 		})
@@ -12663,6 +14600,8 @@ spec:
   - name: platform
     value: linux
   serviceAccountName: test-sa
+  timeouts:
+    pipeline: 1h0m0s
   taskRef:
     name: mytask
     kind: Task
@@ -12682,6 +14621,8 @@ spec:
   - name: platform
     value: mac
   serviceAccountName: test-sa
+  timeouts:
+    pipeline: 1h0m0s
   taskRef:
     name: mytask
     kind: Task
@@ -12701,6 +14642,8 @@ spec:
   - name: platform
     value: windows
   serviceAccountName: test-sa
+  timeouts:
+    pipeline: 1h0m0s
   taskRef:
     name: mytask
     kind: Task
@@ -12800,10 +14743,6 @@ status:
     reason: "Running"
     message: "Tasks Completed: 1 (Failed: 0, Cancelled 0), Incomplete: 1, Skipped: 0"
   childReferences:
-  - apiVersion: tekton.dev/v1
-    kind: TaskRun
-    name: pr-pt-with-result
-    pipelineTaskName: pt-with-result
   - apiVersion: tekton.dev/v1beta1
     kind: CustomRun
     name:  pr-pt-matrix-custom-task-0
@@ -12816,6 +14755,10 @@ status:
     kind: CustomRun
     name:  pr-pt-matrix-custom-task-2
     pipelineTaskName: pt-matrix-custom-task
+  - apiVersion: tekton.dev/v1
+    kind: TaskRun
+    name: pr-pt-with-result
+    pipelineTaskName: pt-with-result
 `),
 	}}
 	for _, tt := range tests {
@@ -12861,7 +14804,7 @@ spec:
 			}
 
 			if d := cmp.Diff(tt.expectedPipelineRun, pipelineRun, ignoreResourceVersion, ignoreTypeMeta, ignoreLastTransitionTime,
-				ignoreStartTime, ignoreFinallyStartTime, ignoreProvenance, cmpopts.EquateEmpty()); d != "" {
+				ignoreStartTime, ignoreSummary, ignoreFinallyStartTime, ignoreProvenance, cmpopts.EquateEmpty()); d != "" {
 				t.Errorf("expected PipelineRun was not created. Diff %s", diff.PrintWantGot(d))
 			}
 		})
@@ -13296,7 +15239,7 @@ status:
 
 			for i := range taskRuns.Items {
 				expectedTaskRun := tt.expectedTaskRuns[i]
-				if d := cmp.Diff(expectedTaskRun, &taskRuns.Items[i], ignoreResourceVersion, ignoreTypeMeta, ignoreLastTransitionTime, ignoreStartTime); d != "" {
+				if d := cmp.Diff(expectedTaskRun, &taskRuns.Items[i], ignoreResourceVersion, ignoreTypeMeta, ignoreLastTransitionTime, ignoreStartTime, ignoreSummary); d != "" {
 					t.Errorf("expected to see TaskRun %v created. Diff %s", tt.expectedTaskRuns[i].Name, diff.PrintWantGot(d))
 				}
 			}
@@ -13307,7 +15250,7 @@ status:
 			}
 
 			if d := cmp.Diff(tt.expectedPipelineRun, pipelineRun, ignoreResourceVersion, ignoreTypeMeta, ignoreLastTransitionTime,
-				ignoreStartTime, ignoreProvenance, cmpopts.SortSlices(lessChildReferences), cmpopts.EquateEmpty()); d != "" {
+				ignoreStartTime, ignoreSummary, ignoreProvenance, cmpopts.SortSlices(lessChildReferences), cmpopts.EquateEmpty()); d != "" {
 				t.Errorf("expected PipelineRun was not created. Diff %s", diff.PrintWantGot(d))
 			}
 		})
@@ -13353,6 +15296,8 @@ spec:
   - name: version
     value: v0.1
   serviceAccountName: test-sa
+  timeouts:
+    pipeline: 1h0m0s
   taskRef:
     name: mytask
 `),
@@ -13372,6 +15317,8 @@ spec:
   - name: version
     value: v0.1
   serviceAccountName: test-sa
+  timeouts:
+    pipeline: 1h0m0s
   taskRef:
     name: mytask
 `),
@@ -13391,6 +15338,8 @@ spec:
   - name: version
     value: v0.1
   serviceAccountName: test-sa
+  timeouts:
+    pipeline: 1h0m0s
   taskRef:
     name: mytask
 `),
@@ -13410,6 +15359,8 @@ spec:
   - name: version
     value: v0.1
   serviceAccountName: test-sa
+  timeouts:
+    pipeline: 1h0m0s
   taskRef:
     name: mytask
 `),
@@ -13429,6 +15380,8 @@ spec:
   - name: version
     value: v0.1
   serviceAccountName: test-sa
+  timeouts:
+    pipeline: 1h0m0s
   taskRef:
     name: mytask
 `),
@@ -13448,6 +15401,8 @@ spec:
   - name: version
     value: v0.1
   serviceAccountName: test-sa
+  timeouts:
+    pipeline: 1h0m0s
   taskRef:
     name: mytask
 `),
@@ -13467,6 +15422,8 @@ spec:
   - name: version
     value: v0.1
   serviceAccountName: test-sa
+  timeouts:
+    pipeline: 1h0m0s
   taskRef:
     name: mytask
 `),
@@ -13486,6 +15443,8 @@ spec:
   - name: version
     value: v0.1
   serviceAccountName: test-sa
+  timeouts:
+    pipeline: 1h0m0s
   taskRef:
     name: mytask
 `),
@@ -13505,6 +15464,8 @@ spec:
   - name: version
     value: v0.1
   serviceAccountName: test-sa
+  timeouts:
+    pipeline: 1h0m0s
   taskRef:
     name: mytask
 `),
@@ -13738,10 +15699,6 @@ status:
     reason: "Running"
     message: "Tasks Completed: 1 (Failed: 0, Cancelled 0), Incomplete: 1, Skipped: 0"
   childReferences:
-  - apiVersion: tekton.dev/v1
-    kind: TaskRun
-    name: pr-unmatrixed-pt
-    pipelineTaskName: unmatrixed-pt
   - apiVersion: tekton.dev/v1beta1
     kind: CustomRun
     name: pr-platforms-and-browsers-0
@@ -13778,6 +15735,10 @@ status:
     kind: CustomRun
     name: pr-platforms-and-browsers-8
     pipelineTaskName: platforms-and-browsers
+  - apiVersion: tekton.dev/v1
+    kind: TaskRun
+    name: pr-unmatrixed-pt
+    pipelineTaskName: unmatrixed-pt
 `),
 	}}
 	for _, tt := range tests {
@@ -13832,7 +15793,7 @@ spec:
 			}
 
 			if d := cmp.Diff(tt.expectedPipelineRun, pipelineRun, ignoreResourceVersion, ignoreTypeMeta, ignoreLastTransitionTime,
-				ignoreStartTime, ignoreFinallyStartTime, ignoreProvenance, cmpopts.EquateEmpty()); d != "" {
+				ignoreStartTime, ignoreSummary, ignoreFinallyStartTime, ignoreProvenance, cmpopts.EquateEmpty()); d != "" {
 				t.Errorf("expected PipelineRun was not created. Diff %s", diff.PrintWantGot(d))
 			}
 		})
@@ -14202,10 +16163,6 @@ status:
     reason: "Running"
     message: "Tasks Completed: 1 (Failed: 0, Cancelled 0), Incomplete: 1, Skipped: 0"
   childReferences:
-  - apiVersion: tekton.dev/v1
-    kind: TaskRun
-    name: pr-unmatrixed-pt
-    pipelineTaskName: unmatrixed-pt
   - apiVersion: tekton.dev/v1
     kind: TaskRun
     name: pr-matrix-with-onerror-0
@@ -14222,6 +16179,10 @@ status:
     kind: TaskRun
     name: pr-matrix-with-onerror-3
     pipelineTaskName: matrix-with-onerror
+  - apiVersion: tekton.dev/v1
+    kind: TaskRun
+    name: pr-unmatrixed-pt
+    pipelineTaskName: unmatrixed-pt
 `),
 	}}
 	for _, tt := range tests {
@@ -14275,13 +16236,95 @@ spec:
 				t.Fatalf("Got an error getting reconciled run out of fake client: %s", err)
 			}
 			if d := cmp.Diff(tt.expectedPipelineRun, pipelineRun, ignoreResourceVersion, ignoreTypeMeta, ignoreLastTransitionTime,
-				ignoreStartTime, ignoreFinallyStartTime, ignoreProvenance, cmpopts.EquateEmpty()); d != "" {
+				ignoreStartTime, ignoreSummary, ignoreFinallyStartTime, ignoreProvenance, cmpopts.EquateEmpty()); d != "" {
 				t.Errorf("expected PipelineRun was not created. Diff %s", diff.PrintWantGot(d))
 			}
 		})
 	}
 }
 
+func TestReconciler_PipelineTaskMatrixCombinationIndex(t *testing.T) {
+	names.TestingSeed()
+	task := parse.MustParseV1Task(t, `
+metadata:
+  name: mytask
+  namespace: foo
+spec:
+  params:
+    - name: platform
+  steps:
+    - name: echo
+      image: alpine
+      script: echo $(params.platform)
+`)
+	p := parse.MustParseV1Pipeline(t, `
+metadata:
+  name: p
+  namespace: foo
+spec:
+  tasks:
+    - name: build
+      taskRef:
+        name: mytask
+      matrix:
+        params:
+          - name: platform
+            value:
+              - linux
+              - mac
+    - name: unmatrixed
+      taskRef:
+        name: mytask
+      params:
+        - name: platform
+          value: windows
+`)
+	pr := parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: pr
+  namespace: foo
+spec:
+  pipelineRef:
+    name: p
+`)
+
+	for _, tc := range []struct {
+		name    string
+		enabled bool
+		want    map[string]string
+	}{{
+		name:    "enabled",
+		enabled: true,
+		want:    map[string]string{"pr-build-0": "0", "pr-build-1": "1", "pr-unmatrixed": ""},
+	}, {
+		name: "disabled",
+		want: map[string]string{"pr-build-0": "", "pr-build-1": "", "pr-unmatrixed": ""},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			featureFlags := newFeatureFlagsConfigMap()
+			featureFlags.Data["enable-build-metadata-env"] = strconv.FormatBool(tc.enabled)
+			d := test.Data{
+				PipelineRuns: []*v1.PipelineRun{pr},
+				Pipelines:    []*v1.Pipeline{p},
+				Tasks:        []*v1.Task{task},
+				ConfigMaps:   []*corev1.ConfigMap{featureFlags},
+			}
+			prt := newPipelineRunTest(t, d)
+			defer prt.Cancel()
+
+			_, clients := prt.reconcileRun("foo", "pr", []string{}, false)
+			taskRuns := getTaskRunsForPipelineRun(prt.TestAssets.Ctx, t, clients, "foo", "pr")
+			got := map[string]string{}
+			for name, tr := range taskRuns {
+				got[name] = tr.Annotations[v1.MatrixCombinationIndexAnnotation]
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("unexpected matrix combination indexes: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestReconciler_PipelineTaskMatrixExplicitCombosResultsAndMatrixContextVars(t *testing.T) {
 	names.TestingSeed()
 	task1 := parse.MustParseV1Task(t, `
@@ -15022,7 +17065,7 @@ spec:
 			if err != nil {
 				t.Fatalf("Got an error getting reconciled run out of fake client: %s", err)
 			}
-			if d := cmp.Diff(tt.expectedPipelineRun, pipelineRun, ignoreResourceVersion, ignoreTypeMeta, ignoreLastTransitionTime, ignoreStartTime, ignoreFinallyStartTime, ignoreProvenance, cmpopts.EquateEmpty(), cmpopts.SortSlices(lessChildReferences)); d != "" {
+			if d := cmp.Diff(tt.expectedPipelineRun, pipelineRun, ignoreResourceVersion, ignoreTypeMeta, ignoreLastTransitionTime, ignoreStartTime, ignoreSummary, ignoreFinallyStartTime, ignoreProvenance, cmpopts.EquateEmpty(), cmpopts.SortSlices(lessChildReferences)); d != "" {
 				t.Errorf("expected PipelineRun was not created. Diff %s", diff.PrintWantGot(d))
 			}
 		})
@@ -15421,22 +17464,6 @@ status:
     reason: "Running"
     message: "Tasks Completed: 1 (Failed: 0, Cancelled 0), Incomplete: 1, Skipped: 0"
   childReferences:
-  - apiVersion: tekton.dev/v1
-    kind: TaskRun
-    name: pr-matrix-emitting-results-0
-    pipelineTaskName: matrix-emitting-results
-  - apiVersion: tekton.dev/v1
-    kind: TaskRun
-    name: pr-matrix-emitting-results-1
-    pipelineTaskName: matrix-emitting-results
-  - apiVersion: tekton.dev/v1
-    kind: TaskRun
-    name: pr-matrix-emitting-results-2
-    pipelineTaskName: matrix-emitting-results
-  - apiVersion: tekton.dev/v1
-    kind: TaskRun
-    name: pr-matrix-emitting-results-3
-    pipelineTaskName: matrix-emitting-results
   - apiVersion: tekton.dev/v1
     kind: TaskRun
     name: pr-matrix-consuming-results-0
@@ -15453,6 +17480,22 @@ status:
     kind: TaskRun
     name: pr-matrix-consuming-results-3
     pipelineTaskName: matrix-consuming-results
+  - apiVersion: tekton.dev/v1
+    kind: TaskRun
+    name: pr-matrix-emitting-results-0
+    pipelineTaskName: matrix-emitting-results
+  - apiVersion: tekton.dev/v1
+    kind: TaskRun
+    name: pr-matrix-emitting-results-1
+    pipelineTaskName: matrix-emitting-results
+  - apiVersion: tekton.dev/v1
+    kind: TaskRun
+    name: pr-matrix-emitting-results-2
+    pipelineTaskName: matrix-emitting-results
+  - apiVersion: tekton.dev/v1
+    kind: TaskRun
+    name: pr-matrix-emitting-results-3
+    pipelineTaskName: matrix-emitting-results
 `),
 	}}
 	for _, tt := range tests {
@@ -15499,7 +17542,7 @@ spec:
 			if err != nil {
 				t.Fatalf("Got an error getting reconciled run out of fake client: %s", err)
 			}
-			if d := cmp.Diff(tt.expectedPipelineRun, pipelineRun, ignoreResourceVersion, ignoreTypeMeta, ignoreLastTransitionTime, ignoreStartTime, ignoreFinallyStartTime, ignoreProvenance, cmpopts.EquateEmpty()); d != "" {
+			if d := cmp.Diff(tt.expectedPipelineRun, pipelineRun, ignoreResourceVersion, ignoreTypeMeta, ignoreLastTransitionTime, ignoreStartTime, ignoreSummary, ignoreFinallyStartTime, ignoreProvenance, cmpopts.EquateEmpty()); d != "" {
 				t.Errorf("expected PipelineRun was not created. Diff %s", diff.PrintWantGot(d))
 			}
 		})
@@ -15561,6 +17604,8 @@ spec:
       - https://api.example/get-report/linux-safari
       - https://api.example/get-report/mac-safari
   serviceAccountName: test-sa
+  timeouts:
+    pipeline: 1h0m0s
   taskRef:
     name: arraytask
     kind: Task
@@ -15798,7 +17843,7 @@ spec:
 			}
 
 			if d := cmp.Diff(tt.expectedPipelineRun, pipelineRun, ignoreResourceVersion, ignoreTypeMeta, ignoreLastTransitionTime,
-				ignoreStartTime, ignoreFinallyStartTime, ignoreProvenance, cmpopts.EquateEmpty()); d != "" {
+				ignoreStartTime, ignoreSummary, ignoreFinallyStartTime, ignoreProvenance, cmpopts.EquateEmpty()); d != "" {
 				t.Errorf("expected PipelineRun was not created. Diff %s", diff.PrintWantGot(d))
 			}
 		})
@@ -17096,6 +19141,68 @@ spec:
 	}
 }
 
+func TestHandleTaskRunCreationThrottled(t *testing.T) {
+	prName := "taskrun-creation-throttled"
+	namespace := "default"
+	pr := parse.MustParseV1PipelineRun(t, fmt.Sprintf(`
+metadata:
+  name: %s
+  namespace: %s
+spec:
+  pipelineSpec:
+    tasks:
+    - name: hello-world
+      taskSpec:
+        steps:
+        - image: busybox
+          script: echo hello
+`, prName, namespace))
+
+	tcs := []struct {
+		name        string
+		creationErr error
+		wantDelay   time.Duration
+	}{{
+		name:        "too many requests",
+		creationErr: apierrors.NewTooManyRequests("the server is overloaded", 5),
+		wantDelay:   5 * time.Second,
+	}, {
+		name:        "service unavailable",
+		creationErr: apierrors.NewServiceUnavailable("etcd is slow"),
+		wantDelay:   time.Second,
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			d := test.Data{
+				PipelineRuns: []*v1.PipelineRun{pr.DeepCopy()},
+			}
+			testAssets, cancel := getPipelineRunController(t, d)
+			defer cancel()
+			c := testAssets.Controller
+			clients := testAssets.Clients
+
+			clients.Pipeline.PrependReactor("create", "taskruns", func(action ktesting.Action) (bool, runtime.Object, error) {
+				return true, nil, tc.creationErr
+			})
+			err := c.Reconciler.Reconcile(testAssets.Ctx, fmt.Sprintf("%s/%s", namespace, prName))
+			if ok, delay := controller.IsRequeueKey(err); !ok || delay != tc.wantDelay {
+				t.Errorf("expected the PipelineRun to be requeued after %s, got %v", tc.wantDelay, err)
+			}
+			reconciledRun, err := clients.Pipeline.TektonV1().PipelineRuns(namespace).Get(testAssets.Ctx, prName, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+			}
+			if reconciledRun.Status.CompletionTime != nil {
+				t.Errorf("Expected no CompletionTime on the waiting PipelineRun, got %v", reconciledRun.Status.CompletionTime)
+			}
+			condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+			if condition.Status != corev1.ConditionUnknown || condition.Reason != v1.PipelineRunReasonWaiting.String() {
+				t.Errorf("Expected the PipelineRun to wait for its TaskRuns to be created, got %v", condition)
+			}
+		})
+	}
+}
+
 func TestHandleCustomRunCreationError(t *testing.T) {
 	prName := "customrun-creation-fails"
 	namespace := "default"
@@ -17410,7 +19517,7 @@ status:
 	expectedPipelineRun.Status.PipelineSpec = &ps[0].Spec
 
 	// The PipelineRun should include a task3 child
-	if d := cmp.Diff(expectedPipelineRun, reconciledRun, ignoreResourceVersion, ignoreLastTransitionTime, ignoreTypeMeta, ignoreProvenance, ignoreStartTime); d != "" {
+	if d := cmp.Diff(expectedPipelineRun, reconciledRun, ignoreResourceVersion, ignoreLastTransitionTime, ignoreTypeMeta, ignoreProvenance, ignoreStartTime, ignoreSummary); d != "" {
 		t.Errorf("Expected to see PipelineRun run with a task3 child reference %s", diff.PrintWantGot(d))
 	}
 
@@ -17438,7 +19545,7 @@ spec:
 	// Check that the expected TaskRun was created
 	actual := getTaskRunByName(t, taskRuns, "7103-reproducer-run-7jp4w-task3")
 	// The TaskRun for task3 should include resolved results
-	if d := cmp.Diff(expectedTaskRun, actual, ignoreResourceVersion, ignoreLastTransitionTime, ignoreTypeMeta, ignoreProvenance, ignoreStartTime); d != "" {
+	if d := cmp.Diff(expectedTaskRun, actual, ignoreResourceVersion, ignoreLastTransitionTime, ignoreTypeMeta, ignoreProvenance, ignoreStartTime, ignoreSummary); d != "" {
 		t.Errorf("Expected to see PipelineRun run with a task3 child reference %s", diff.PrintWantGot(d))
 	}
 }
@@ -18077,3 +20184,131 @@ func signInterface(signer signature.Signer, i interface{}) ([]byte, error) {
 
 	return sig, nil
 }
+
+func TestReconcile_DebugLogBlockedOnUnresolvedResult(t *testing.T) {
+	// TestReconcile_DebugLogBlockedOnUnresolvedResult runs "Reconcile" on a PipelineRun with the debug-log
+	// annotation whose tasks are waiting for the result of a running task, and checks the decision trail.
+	names.TestingSeed()
+	prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run-debug
+  namespace: foo
+  annotations:
+    tekton.dev/debug-log: "true"
+spec:
+  pipelineRef:
+    name: test-pipeline
+status:
+  childReferences:
+  - apiVersion: tekton.dev/v1
+    kind: TaskRun
+    name: test-pipeline-run-debug-produce
+    pipelineTaskName: produce
+`)}
+	ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: produce
+    taskSpec:
+      results:
+      - name: url
+      steps:
+      - image: myimage
+  - name: consume
+    params:
+    - name: url
+      value: $(tasks.produce.results.url)
+    taskSpec:
+      params:
+      - name: url
+      steps:
+      - image: myimage
+  - name: after
+    runAfter:
+    - produce
+    taskSpec:
+      steps:
+      - image: myimage
+`)}
+	trs := []*v1.TaskRun{mustParseTaskRunWithObjectMeta(t,
+		taskRunObjectMeta("test-pipeline-run-debug-produce", "foo", "test-pipeline-run-debug", "test-pipeline", "produce", false), `
+spec:
+  taskSpec:
+    results:
+    - name: url
+    steps:
+    - image: myimage
+status:
+  conditions:
+  - type: Succeeded
+    status: Unknown
+    reason: Running
+`)}
+
+	prt := newPipelineRunTest(t, test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		TaskRuns:     trs,
+		ConfigMaps:   []*corev1.ConfigMap{newFeatureFlagsConfigMap()},
+	})
+	defer prt.Cancel()
+
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-debug", []string{}, false)
+	validateTaskRunsCount(t, getTaskRunsForPipelineRun(prt.TestAssets.Ctx, t, clients, "foo", "test-pipeline-run-debug"), 1)
+
+	var trail struct {
+		Decisions []debuglog.Decision `json:"decisions"`
+	}
+	if err := json.Unmarshal([]byte(reconciledRun.Status.Annotations[debuglog.AnnotationKey]), &trail); err != nil {
+		t.Fatalf("couldn't parse the decision trail %q: %v", reconciledRun.Status.Annotations[debuglog.AnnotationKey], err)
+	}
+	want := []debuglog.Decision{{
+		Stage:    debuglog.StageResolution,
+		Subject:  "test-pipeline",
+		Decision: "resolved",
+	}, {
+		Stage:    debuglog.StageScheduling,
+		Subject:  "consume",
+		Decision: "not scheduled",
+		Reason:   "waiting for tasks.produce.results.url",
+	}, {
+		Stage:    debuglog.StageScheduling,
+		Subject:  "after",
+		Decision: "not scheduled",
+		Reason:   "waiting for tasks.produce",
+	}}
+	if d := cmp.Diff(want, trail.Decisions); d != "" {
+		t.Errorf("unexpected decision trail %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestReconcile_DebugLogDisabled(t *testing.T) {
+	prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run-debug
+  namespace: foo
+spec:
+  pipelineSpec:
+    tasks:
+    - name: hello
+      taskSpec:
+        steps:
+        - image: myimage
+status:
+  annotations:
+    tekton.dev/debug-log: '{"decisions":[]}'
+`)}
+	prt := newPipelineRunTest(t, test.Data{
+		PipelineRuns: prs,
+		ConfigMaps:   []*corev1.ConfigMap{newFeatureFlagsConfigMap()},
+	})
+	defer prt.Cancel()
+
+	reconciledRun, _ := prt.reconcileRun("foo", "test-pipeline-run-debug", []string{}, false)
+	if trail, ok := reconciledRun.Status.Annotations[debuglog.AnnotationKey]; ok {
+		t.Errorf("expected the decision trail to be removed without the debug-log annotation, got %q", trail)
+	}
+}
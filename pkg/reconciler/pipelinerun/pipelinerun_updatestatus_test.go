@@ -17,6 +17,7 @@ limitations under the License.
 package pipelinerun
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
 	"testing"
@@ -24,6 +25,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/test/diff"
@@ -599,6 +601,39 @@ metadata:
 	}
 }
 
+func TestUpdatePipelineRunStatusFromChildRefs_StableOrder(t *testing.T) {
+	prUID := types.UID("11111111-1111-1111-1111-111111111111")
+	var trs []*v1.TaskRun
+	for i := range 1000 {
+		trs = append(trs, &v1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            fmt.Sprintf("pr-matrixed-%d", i),
+				Labels:          map[string]string{pipeline.PipelineTaskLabelKey: "matrixed"},
+				OwnerReferences: []metav1.OwnerReference{{UID: prUID}},
+			},
+		})
+	}
+	logger := logtesting.TestLogger(t)
+
+	var first []v1.ChildStatusReference
+	for range 5 {
+		pr := &v1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "pr", UID: prUID}}
+		updatePipelineRunStatusFromChildRefs(logger, pr, trs, nil)
+		if first == nil {
+			first = pr.Status.ChildReferences
+			continue
+		}
+		if d := cmp.Diff(first, pr.Status.ChildReferences); d != "" {
+			t.Fatalf("child references changed between reconciles: %s", diff.PrintWantGot(d))
+		}
+	}
+	for i, cr := range first {
+		if want := fmt.Sprintf("pr-matrixed-%d", i); cr.Name != want {
+			t.Fatalf("expected child reference %d to be %s, got %s", i, want, cr.Name)
+		}
+	}
+}
+
 func TestValidateChildObjectsInPipelineRunStatus(t *testing.T) {
 	testCases := []struct {
 		name            string
@@ -20,11 +20,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	resolutionutil "github.com/tektoncd/pipeline/pkg/internal/resolution"
 	"github.com/tektoncd/pipeline/pkg/trustedresources"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/controller"
 )
 
 // GetPipeline is a function used to retrieve Pipelines.
@@ -73,9 +77,88 @@ func GetPipelineData(ctx context.Context, pipelineRun *v1.PipelineRun, getPipeli
 	}
 
 	pipelineSpec.SetDefaults(ctx)
+	pipelineSpec.ApplyTaskDefaults()
+	injectFinallyTasks(ctx, &pipelineSpec, pipelineRun)
 	return &resolutionutil.ResolvedObjectMeta{
 		ObjectMeta:         &pipelineMeta,
 		RefSource:          refSource,
 		VerificationResult: verificationResult,
 	}, &pipelineSpec, nil
 }
+
+// injectFinallyTasks appends the injected finally tasks to the PipelineSpec of
+// the PipelineRun. They are only injected when the PipelineSpec is first
+// resolved: once it's stored in the status of the PipelineRun, the finally
+// tasks stored with it are kept, so that changing the injected finally tasks
+// doesn't change the running PipelineRuns. An injected finally task with the
+// name of a task of the Pipeline is skipped with a warning event rather than
+// failing the PipelineRun, so that the injection doesn't break the Pipelines
+// which already declare it.
+func injectFinallyTasks(ctx context.Context, ps *v1.PipelineSpec, pipelineRun *v1.PipelineRun) {
+	if pipelineRun.Status.PipelineSpec != nil {
+		ps.Finally = pipelineRun.Status.PipelineSpec.DeepCopy().Finally
+		return
+	}
+	injected := config.FromContextOrDefaults(ctx).Defaults.InjectedFinallyTasksForNamespace(pipelineRun.Namespace)
+	if len(injected) == 0 {
+		return
+	}
+	declared := map[string]bool{}
+	for _, pt := range ps.Tasks {
+		declared[pt.Name] = true
+	}
+	for _, pt := range ps.Finally {
+		declared[pt.Name] = true
+	}
+	finally := make([]v1.PipelineTask, 0, len(ps.Finally)+len(injected))
+	finally = append(finally, ps.Finally...)
+	for _, t := range injected {
+		if declared[t.Name] {
+			if recorder := controller.GetEventRecorder(ctx); recorder != nil {
+				recorder.Eventf(pipelineRun, corev1.EventTypeWarning, "InjectedFinallyTaskSkipped",
+					"The Pipeline has a task named %q, so the finally task with that name injected into the PipelineRuns of namespace %s is skipped", t.Name, pipelineRun.Namespace)
+			}
+			continue
+		}
+		pt := injectedPipelineTask(t)
+		pt.SetDefaults(ctx)
+		finally = append(finally, pt)
+	}
+	if len(finally) == len(ps.Finally) {
+		return
+	}
+	// The finally tasks may be shared with the PipelineSpec embedded in the
+	// PipelineRun, which mustn't be changed.
+	ps.Finally = finally
+}
+
+// injectedPipelineTask returns the PipelineTask of an injected finally task.
+func injectedPipelineTask(t config.InjectedFinallyTask) v1.PipelineTask {
+	ref := &v1.TaskRef{Name: t.TaskRef.Name, Kind: v1.TaskKind(t.TaskRef.Kind)}
+	if t.TaskRef.Resolver != "" {
+		ref.Resolver = v1.ResolverName(t.TaskRef.Resolver)
+		ref.Params = stringParams(t.TaskRef.Params)
+	}
+	return v1.PipelineTask{
+		Name:    t.Name,
+		TaskRef: ref,
+		Params:  stringParams(t.Params),
+	}
+}
+
+// stringParams returns the params of the given string values, sorted by name.
+func stringParams(values map[string]string) v1.Params {
+	if len(values) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	params := make(v1.Params, 0, len(names))
+	for _, name := range names {
+		params = append(params, v1.Param{Name: name, Value: *v1.NewStructuredValues(values[name])})
+	}
+	return params
+}
@@ -24,10 +24,13 @@ import (
 	"github.com/google/go-cmp/cmp"
 	cfgtesting "github.com/tektoncd/pipeline/pkg/apis/config/testing"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/events/k8sevent"
 	pipelinespec "github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/pipelinespec"
 	"github.com/tektoncd/pipeline/pkg/trustedresources"
 	"github.com/tektoncd/pipeline/test/diff"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"knative.dev/pkg/controller"
 )
 
 func TestGetPipelineSpec_Ref(t *testing.T) {
@@ -114,6 +117,54 @@ func TestGetPipelineSpec_Embedded(t *testing.T) {
 	}
 }
 
+func TestGetPipelineData_TaskDefaults(t *testing.T) {
+	pr := &v1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "mypipelinerun",
+		},
+		Spec: v1.PipelineRunSpec{
+			PipelineRef: &v1.PipelineRef{
+				Name: "mypipeline",
+			},
+		},
+	}
+	pipeline := &v1.Pipeline{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "mypipeline",
+		},
+		Spec: v1.PipelineSpec{
+			Tasks: []v1.PipelineTask{{
+				Name:    "mytask",
+				TaskRef: &v1.TaskRef{Name: "mytask"},
+			}, {
+				Name:    "mytask-with-retries",
+				TaskRef: &v1.TaskRef{Name: "mytask"},
+				Retries: 1,
+			}},
+			Finally: []v1.PipelineTask{{
+				Name:    "myfinaltask",
+				TaskRef: &v1.TaskRef{Name: "myfinaltask"},
+			}},
+			TaskDefaults:    &v1.PipelineTaskDefaults{Retries: 2},
+			FinallyDefaults: &v1.PipelineTaskDefaults{OnError: v1.PipelineTaskContinue},
+		},
+	}
+	gt := func(ctx context.Context, n string) (*v1.Pipeline, *v1.RefSource, *trustedresources.VerificationResult, error) {
+		return pipeline, nil, nil, nil
+	}
+	_, pipelineSpec, err := pipelinespec.GetPipelineData(t.Context(), pr, gt)
+	if err != nil {
+		t.Fatalf("Did not expect error getting pipeline spec but got: %s", err)
+	}
+
+	if pipelineSpec.Tasks[0].Retries != 2 || pipelineSpec.Tasks[1].Retries != 1 {
+		t.Errorf("Expected the task defaults to be applied to the tasks without retries but got: %v", pipelineSpec.Tasks)
+	}
+	if pipelineSpec.Finally[0].OnError != v1.PipelineTaskContinue {
+		t.Errorf("Expected the finally defaults to be applied to the finally tasks but got: %v", pipelineSpec.Finally)
+	}
+}
+
 func TestGetPipelineSpec_Invalid(t *testing.T) {
 	tr := &v1.PipelineRun{
 		ObjectMeta: metav1.ObjectMeta{
@@ -307,3 +358,102 @@ func TestGetPipelineData_ResolvedNilPipeline(t *testing.T) {
 		t.Fatalf("Expected error when unable to find referenced Pipeline but got none")
 	}
 }
+
+func TestGetPipelineData_InjectedFinallyTasks(t *testing.T) {
+	injected := `
+tasks:
+- name: audit-report
+  taskRef:
+    resolver: cluster
+    params:
+      name: audit-report
+      namespace: compliance
+  params:
+    pipelinerun: $(context.pipelineRun.name)
+    namespace: $(context.pipelineRun.namespace)
+exemptNamespaces:
+- kube-system
+`
+	auditReport := v1.PipelineTask{
+		Name: "audit-report",
+		TaskRef: &v1.TaskRef{ResolverRef: v1.ResolverRef{
+			Resolver: "cluster",
+			Params: v1.Params{
+				{Name: "name", Value: *v1.NewStructuredValues("audit-report")},
+				{Name: "namespace", Value: *v1.NewStructuredValues("compliance")},
+			},
+		}},
+		Params: v1.Params{
+			{Name: "namespace", Value: *v1.NewStructuredValues("$(context.pipelineRun.namespace)")},
+			{Name: "pipelinerun", Value: *v1.NewStructuredValues("$(context.pipelineRun.name)")},
+		},
+	}
+	build := v1.PipelineTask{Name: "build", TaskRef: &v1.TaskRef{Name: "build", Kind: v1.NamespacedTaskKind}}
+	cleanup := v1.PipelineTask{Name: "cleanup", TaskRef: &v1.TaskRef{Name: "cleanup", Kind: v1.NamespacedTaskKind}}
+	ownAuditReport := v1.PipelineTask{Name: "audit-report", TaskRef: &v1.TaskRef{Name: "own-audit-report", Kind: v1.NamespacedTaskKind}}
+
+	for _, tc := range []struct {
+		name        string
+		namespace   string
+		spec        v1.PipelineSpec
+		status      *v1.PipelineSpec
+		wantFinally []v1.PipelineTask
+		wantEvents  []string
+	}{{
+		name:        "appended to the finally tasks",
+		namespace:   "default",
+		spec:        v1.PipelineSpec{Tasks: []v1.PipelineTask{build}, Finally: []v1.PipelineTask{cleanup}},
+		wantFinally: []v1.PipelineTask{cleanup, auditReport},
+	}, {
+		name:        "Pipeline without finally tasks",
+		namespace:   "default",
+		spec:        v1.PipelineSpec{Tasks: []v1.PipelineTask{build}},
+		wantFinally: []v1.PipelineTask{auditReport},
+	}, {
+		name:        "exempted namespace",
+		namespace:   "kube-system",
+		spec:        v1.PipelineSpec{Tasks: []v1.PipelineTask{build}, Finally: []v1.PipelineTask{cleanup}},
+		wantFinally: []v1.PipelineTask{cleanup},
+	}, {
+		name:        "finally task with the same name",
+		namespace:   "default",
+		spec:        v1.PipelineSpec{Tasks: []v1.PipelineTask{build}, Finally: []v1.PipelineTask{ownAuditReport}},
+		wantFinally: []v1.PipelineTask{ownAuditReport},
+		wantEvents:  []string{`Warning InjectedFinallyTaskSkipped The Pipeline has a task named "audit-report", so the finally task with that name injected into the PipelineRuns of namespace default is skipped`},
+	}, {
+		name:       "task with the same name",
+		namespace:  "default",
+		spec:       v1.PipelineSpec{Tasks: []v1.PipelineTask{build, ownAuditReport}},
+		wantEvents: []string{`Warning InjectedFinallyTaskSkipped The Pipeline has a task named "audit-report", so the finally task with that name injected into the PipelineRuns of namespace default is skipped`},
+	}, {
+		name:        "already stored in the status",
+		namespace:   "default",
+		spec:        v1.PipelineSpec{Tasks: []v1.PipelineTask{build}, Finally: []v1.PipelineTask{cleanup}},
+		status:      &v1.PipelineSpec{Tasks: []v1.PipelineTask{build}, Finally: []v1.PipelineTask{cleanup, ownAuditReport}},
+		wantFinally: []v1.PipelineTask{cleanup, ownAuditReport},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := &v1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "mypipelinerun", Namespace: tc.namespace},
+				Spec:       v1.PipelineRunSpec{PipelineSpec: tc.spec.DeepCopy()},
+				Status:     v1.PipelineRunStatus{PipelineRunStatusFields: v1.PipelineRunStatusFields{PipelineSpec: tc.status}},
+			}
+			ctx := cfgtesting.SetDefaults(t.Context(), t, map[string]string{"default-injected-finally-tasks": injected})
+			recorder := record.NewFakeRecorder(1)
+			ctx = controller.WithEventRecorder(ctx, recorder)
+			_, pipelineSpec, err := pipelinespec.GetPipelineData(ctx, pr, nil)
+			if err != nil {
+				t.Fatalf("Did not expect error getting pipeline spec but got: %s", err)
+			}
+			if d := cmp.Diff(tc.wantFinally, pipelineSpec.Finally); d != "" {
+				t.Errorf("Unexpected finally tasks %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tc.spec.Finally, pr.Spec.PipelineSpec.Finally); d != "" {
+				t.Errorf("Expected the PipelineSpec of the PipelineRun to be unchanged %s", diff.PrintWantGot(d))
+			}
+			if err := k8sevent.CheckEventsOrdered(t, recorder.Events, tc.name, tc.wantEvents); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
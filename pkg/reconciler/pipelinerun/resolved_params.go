@@ -0,0 +1,189 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	// resolvedParamsMaxValueLength is the maximum length of the values
+	// recorded in the resolved params annotation. The longer values are
+	// truncated with the hash of the whole value.
+	resolvedParamsMaxValueLength = 256
+	// resolvedParamsMaxSize is the maximum size of the values recorded in the
+	// resolved params annotation. Once it's exceeded, the remaining values
+	// are only recorded by their hash.
+	resolvedParamsMaxSize = 8 * 1024
+	// redactedParamValue replaces the values of the params fed by the results
+	// redacted by the result sanitization.
+	redactedParamValue = "[redacted]"
+)
+
+// resolvedParams returns the resolved params annotation of a run: the values
+// of its params, then the defaults of the params of its Task which it
+// doesn't set, keyed by their names. The arrays and objects are recorded as
+// JSON, and the values of the redacted params as redactedParamValue.
+func resolvedParams(params v1.Params, specs v1.ParamSpecs, redacted sets.Set[string]) (string, error) {
+	values := map[string]string{}
+	for _, p := range params {
+		values[p.Name] = paramValueString(p.Value)
+	}
+	for _, s := range specs {
+		if _, ok := values[s.Name]; !ok && s.Default != nil {
+			values[s.Name] = paramValueString(*s.Default)
+		}
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	size := 0
+	for _, name := range names {
+		value := truncateParamValue(values[name])
+		if size+len(name)+len(value) > resolvedParamsMaxSize {
+			value = hashParamValue(values[name])
+		}
+		if redacted.Has(name) {
+			value = redactedParamValue
+		}
+		values[name] = value
+		size += len(name) + len(value)
+	}
+
+	b, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// paramValueString returns the string of a param value, or the JSON of an
+// array or an object.
+func paramValueString(value v1.ParamValue) string {
+	var v any
+	switch value.Type {
+	case v1.ParamTypeArray:
+		v = value.ArrayVal
+	case v1.ParamTypeObject:
+		v = value.ObjectVal
+	default:
+		return value.StringVal
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// truncateParamValue truncates a value longer than
+// resolvedParamsMaxValueLength, suffixed with its hash.
+func truncateParamValue(value string) string {
+	if len(value) <= resolvedParamsMaxValueLength {
+		return value
+	}
+	suffix := "..." + hashParamValue(value)
+	prefix := value[:resolvedParamsMaxValueLength-len(suffix)]
+	// Don't split a multi-byte character.
+	for !utf8.ValidString(prefix) {
+		prefix = prefix[:len(prefix)-1]
+	}
+	return prefix + suffix
+}
+
+// hashParamValue returns the sha256 of a value.
+func hashParamValue(value string) string {
+	h := sha256.Sum256([]byte(value))
+	return "sha256:" + hex.EncodeToString(h[:])
+}
+
+// sanitizedResultParams returns the names of the params of the PipelineTask
+// fed by the results which the result sanitization redacted from the
+// TaskRuns producing them.
+func sanitizedResultParams(pipelineSpec *v1.PipelineSpec, pipelineTaskName string, state resources.PipelineRunState) sets.Set[string] {
+	redacted := sets.New[string]()
+	if pipelineSpec == nil {
+		return redacted
+	}
+	// The PipelineTasks of the spec of the status reference the results,
+	// which the ones being run were substituted with.
+	var pt *v1.PipelineTask
+	for _, t := range slices.Concat(pipelineSpec.Tasks, pipelineSpec.Finally) {
+		if t.Name == pipelineTaskName {
+			pt = &t
+			break
+		}
+	}
+	if pt == nil {
+		return redacted
+	}
+
+	params := pt.Params
+	if pt.Matrix != nil {
+		params = slices.Concat(params, pt.Matrix.GetAllParams())
+	}
+	stateMap := state.ToMap()
+	for _, p := range params {
+		expressions, ok := p.GetVarSubstitutionExpressions()
+		if !ok {
+			continue
+		}
+		for _, ref := range v1.NewResultRefs(expressions) {
+			producer, ok := stateMap[ref.PipelineTask]
+			if !ok {
+				continue
+			}
+			for _, tr := range producer.TaskRuns {
+				if tr != nil && slices.Contains(strings.Split(tr.Annotations[v1.SanitizedResultsAnnotationKey], ","), ref.Result) {
+					redacted.Insert(p.Name)
+				}
+			}
+		}
+	}
+	return redacted
+}
+
+// recordResolvedParams records the resolved params of a run of the
+// PipelineTask with the params in its annotations.
+func recordResolvedParams(annotations map[string]string, params v1.Params, rpt *resources.ResolvedPipelineTask, pr *v1.PipelineRun, facts *resources.PipelineRunFacts) error {
+	var specs v1.ParamSpecs
+	if rpt.ResolvedTask != nil && rpt.ResolvedTask.TaskSpec != nil {
+		specs = rpt.ResolvedTask.TaskSpec.Params
+	}
+	var state resources.PipelineRunState
+	if facts != nil {
+		state = facts.State
+	}
+	resolved, err := resolvedParams(params, specs, sanitizedResultParams(pr.Status.PipelineSpec, rpt.PipelineTask.Name, state))
+	if err != nil {
+		return err
+	}
+	annotations[v1.ResolvedParamsAnnotation] = resolved
+	return nil
+}
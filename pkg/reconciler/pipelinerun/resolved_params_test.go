@@ -0,0 +1,254 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test"
+	"github.com/tektoncd/pipeline/test/diff"
+	"github.com/tektoncd/pipeline/test/names"
+	"github.com/tektoncd/pipeline/test/parse"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func TestResolvedParams(t *testing.T) {
+	long := strings.Repeat("a", resolvedParamsMaxValueLength+1)
+	for _, tc := range []struct {
+		name     string
+		params   v1.Params
+		specs    v1.ParamSpecs
+		redacted sets.Set[string]
+		want     map[string]string
+	}{{
+		name: "params and defaults",
+		params: v1.Params{
+			{Name: "platform", Value: *v1.NewStructuredValues("linux")},
+			{Name: "version", Value: *v1.NewStructuredValues("2.0")},
+		},
+		specs: v1.ParamSpecs{
+			{Name: "platform"},
+			{Name: "version", Default: v1.NewStructuredValues("1.0")},
+			{Name: "channel", Default: v1.NewStructuredValues("stable")},
+			{Name: "optional"},
+		},
+		want: map[string]string{"platform": "linux", "version": "2.0", "channel": "stable"},
+	}, {
+		name: "arrays and objects",
+		params: v1.Params{
+			{Name: "flags", Value: *v1.NewStructuredValues("-v", "-x")},
+			{Name: "image", Value: *v1.NewObject(map[string]string{"url": "registry/image"})},
+		},
+		want: map[string]string{"flags": `["-v","-x"]`, "image": `{"url":"registry/image"}`},
+	}, {
+		name: "redacted",
+		params: v1.Params{
+			{Name: "token", Value: *v1.NewStructuredValues("abc")},
+			{Name: "user", Value: *v1.NewStructuredValues("me")},
+		},
+		redacted: sets.New("token"),
+		want:     map[string]string{"token": redactedParamValue, "user": "me"},
+	}, {
+		name:   "long value",
+		params: v1.Params{{Name: "script", Value: *v1.NewStructuredValues(long)}},
+		want: map[string]string{
+			"script": long[:resolvedParamsMaxValueLength-len("...sha256:")-64] + "..." + hashParamValue(long),
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			annotation, err := resolvedParams(tc.params, tc.specs, tc.redacted)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := map[string]string{}
+			if err := json.Unmarshal([]byte(annotation), &got); err != nil {
+				t.Fatalf("invalid annotation %q: %v", annotation, err)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("unexpected resolved params %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestResolvedParams_MaxSize(t *testing.T) {
+	var params v1.Params
+	value := strings.Repeat("a", resolvedParamsMaxValueLength)
+	for i := range 2 * resolvedParamsMaxSize / resolvedParamsMaxValueLength {
+		params = append(params, v1.Param{Name: "p" + strconv.Itoa(i), Value: *v1.NewStructuredValues(value)})
+	}
+
+	annotation, err := resolvedParams(params, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := map[string]string{}
+	if err := json.Unmarshal([]byte(annotation), &got); err != nil {
+		t.Fatalf("invalid annotation %q: %v", annotation, err)
+	}
+	if len(got) != len(params) {
+		t.Errorf("expected all the %d params to be recorded, got %d", len(params), len(got))
+	}
+	hashed := 0
+	for _, v := range got {
+		if v == hashParamValue(value) {
+			hashed++
+		}
+	}
+	if hashed == 0 {
+		t.Errorf("expected the values beyond the maximum size to be recorded by their hash")
+	}
+	if size := len(annotation); size > 2*resolvedParamsMaxSize {
+		t.Errorf("expected the annotation to be capped, got %d bytes", size)
+	}
+}
+
+func TestReconciler_ResolvedParams(t *testing.T) {
+	names.TestingSeed()
+	task := parse.MustParseV1Task(t, `
+metadata:
+  name: mytask
+  namespace: foo
+spec:
+  params:
+    - name: platform
+    - name: channel
+    - name: owner
+    - name: token
+    - name: version
+      default: "1.0"
+  steps:
+    - name: echo
+      image: alpine
+      script: echo $(params.platform) $(params.channel) $(params.owner) $(params.version)
+`)
+	p := parse.MustParseV1Pipeline(t, `
+metadata:
+  name: p
+  namespace: foo
+spec:
+  params:
+    - name: release
+      default: rc
+    - name: owner
+  tasks:
+    - name: producer
+      taskSpec:
+        results:
+          - name: token
+        steps:
+          - name: produce
+            image: alpine
+            script: echo -n abc > $(results.token.path)
+    - name: build
+      taskRef:
+        name: mytask
+      params:
+        - name: channel
+          value: $(params.release)
+        - name: owner
+          value: $(params.owner)
+        - name: token
+          value: $(tasks.producer.results.token)
+      matrix:
+        params:
+          - name: platform
+            value:
+              - linux
+              - mac
+`)
+	pr := parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: pr
+  namespace: foo
+spec:
+  pipelineRef:
+    name: p
+  params:
+    - name: owner
+      value: team-a
+`)
+	producer := mustParseTaskRunWithObjectMeta(t,
+		taskRunObjectMetaWithAnnotations("pr-producer", "foo", "pr", "p", "producer", false,
+			map[string]string{v1.SanitizedResultsAnnotationKey: "token"}),
+		`
+spec:
+  serviceAccountName: default
+status:
+  conditions:
+  - status: "True"
+    type: Succeeded
+  results:
+  - name: token
+    value: "[redacted]"
+`)
+
+	for _, tc := range []struct {
+		name    string
+		enabled bool
+		want    map[string]map[string]string
+	}{{
+		name:    "enabled",
+		enabled: true,
+		want: map[string]map[string]string{
+			"pr-build-0": {"platform": "linux", "channel": "rc", "owner": "team-a", "token": redactedParamValue, "version": "1.0"},
+			"pr-build-1": {"platform": "mac", "channel": "rc", "owner": "team-a", "token": redactedParamValue, "version": "1.0"},
+		},
+	}, {
+		name: "disabled",
+		want: map[string]map[string]string{"pr-build-0": nil, "pr-build-1": nil},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			featureFlags := newFeatureFlagsConfigMap()
+			featureFlags.Data["record-resolved-params"] = strconv.FormatBool(tc.enabled)
+			d := test.Data{
+				PipelineRuns: []*v1.PipelineRun{pr},
+				Pipelines:    []*v1.Pipeline{p},
+				Tasks:        []*v1.Task{task},
+				TaskRuns:     []*v1.TaskRun{producer},
+				ConfigMaps:   []*corev1.ConfigMap{featureFlags},
+			}
+			prt := newPipelineRunTest(t, d)
+			defer prt.Cancel()
+
+			_, clients := prt.reconcileRun("foo", "pr", []string{}, false)
+			taskRuns := getTaskRunsForPipelineRun(prt.TestAssets.Ctx, t, clients, "foo", "pr")
+			got := map[string]map[string]string{}
+			for name, tr := range taskRuns {
+				if name == "pr-producer" {
+					continue
+				}
+				var params map[string]string
+				if annotation, ok := tr.Annotations[v1.ResolvedParamsAnnotation]; ok {
+					if err := json.Unmarshal([]byte(annotation), &params); err != nil {
+						t.Fatalf("invalid annotation %q of %s: %v", annotation, name, err)
+					}
+				}
+				got[name] = params
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("unexpected resolved params: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
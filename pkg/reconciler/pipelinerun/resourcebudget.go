@@ -0,0 +1,69 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"fmt"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+)
+
+// checkResourceBudget records the peak resource requests of the PipelineRun in
+// its status and, if its namespace has a resource budget ConfigMap, fails the
+// PipelineRun when the peak resource requests exceed the budget. It is called
+// once all PipelineTasks are resolved, before the first of them starts.
+func (c *Reconciler) checkResourceBudget(ctx context.Context, pr *v1.PipelineRun, facts *resources.PipelineRunFacts) error {
+	logger := logging.FromContext(ctx)
+	peak, err := facts.PeakResourceRequests(pr)
+	if err != nil {
+		// The same error is surfaced when creating the TaskRun's Pod,
+		// so the PipelineRun is only left without a budget preview.
+		logger.Warnf("Failed to compute the peak resource requests of PipelineRun %s/%s: %v", pr.Namespace, pr.Name, err)
+		return nil
+	}
+	pr.Status.PeakResourceRequests = peak
+	if len(peak) == 0 {
+		return nil
+	}
+
+	cm, err := c.configMapLister.ConfigMaps(pr.Namespace).Get(resources.ResourceBudgetConfigMapName)
+	switch {
+	case apierrors.IsNotFound(err):
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to get the resource budget of namespace %s: %w", pr.Namespace, err)
+	}
+	budget, err := resources.ParseResourceBudget(cm.Data)
+	if err != nil {
+		logger.Errorf("Invalid resource budget in namespace %s: %v", pr.Namespace, err)
+		pr.Status.MarkFailed(v1.PipelineRunReasonExceedsResourceBudget.String(),
+			"Invalid resource budget in ConfigMap %s/%s: %s", pr.Namespace, resources.ResourceBudgetConfigMapName, err)
+		return controller.NewPermanentError(err)
+	}
+	if exceeded := resources.ExceededResourceBudget(peak, budget); exceeded != "" {
+		logger.Infof("PipelineRun %s/%s exceeds the resource budget of its namespace: %s", pr.Namespace, pr.Name, exceeded)
+		pr.Status.MarkFailed(v1.PipelineRunReasonExceedsResourceBudget.String(),
+			"PipelineRun %s/%s requests more resources than the budget of its namespace allows (%s)", pr.Namespace, pr.Name, exceeded)
+		return controller.NewPermanentError(fmt.Errorf("pipelinerun %s/%s exceeds the resource budget: %s", pr.Namespace, pr.Name, exceeded))
+	}
+	return nil
+}
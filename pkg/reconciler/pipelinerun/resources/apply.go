@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -28,6 +29,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
 	"github.com/tektoncd/pipeline/pkg/substitution"
 	"github.com/tektoncd/pipeline/pkg/workspace"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 const (
@@ -308,9 +310,13 @@ func replaceVariablesInPipelineTasks(tasks []v1.PipelineTask, replacements map[s
 			for j := range tasks[i].Matrix.Include {
 				tasks[i].Matrix.Include[j].Params = tasks[i].Matrix.Include[j].Params.ReplaceVariables(replacements, nil, nil)
 			}
+			// The references to the matrix params are replaced by the values of
+			// each combination when building the status of its child.
+			tasks[i].DisplayName = substitution.ApplyReplacements(tasks[i].DisplayName, withoutMatrixParams(replacements, tasks[i].Matrix))
 		} else {
 			tasks[i].DisplayName = substitution.ApplyReplacements(tasks[i].DisplayName, replacements)
 		}
+		tasks[i].Description = substitution.ApplyReplacements(tasks[i].Description, replacements)
 		for j := range tasks[i].Workspaces {
 			tasks[i].Workspaces[j].SubPath = substitution.ApplyReplacements(tasks[i].Workspaces[j].SubPath, replacements)
 		}
@@ -330,6 +336,12 @@ func replaceVariablesInPipelineTasks(tasks []v1.PipelineTask, replacements map[s
 func ApplyReplacements(p *v1.PipelineSpec, replacements map[string]string, arrayReplacements map[string][]string, objectReplacements map[string]map[string]string) *v1.PipelineSpec {
 	p = p.DeepCopy()
 
+	p.DisplayName = substitution.ApplyReplacements(p.DisplayName, replacements)
+	p.Description = substitution.ApplyReplacements(p.Description, replacements)
+	for i := range p.Results {
+		p.Results[i].Description = substitution.ApplyReplacements(p.Results[i].Description, replacements)
+	}
+
 	// Replace variables in Tasks and Finally tasks
 	replaceVariablesInPipelineTasks(p.Tasks, replacements, arrayReplacements, objectReplacements)
 	replaceVariablesInPipelineTasks(p.Finally, replacements, arrayReplacements, objectReplacements)
@@ -337,6 +349,66 @@ func ApplyReplacements(p *v1.PipelineSpec, replacements map[string]string, array
 	return p
 }
 
+// withoutMatrixParams returns the replacements which don't replace the
+// references to the params of the given matrix.
+func withoutMatrixParams(replacements map[string]string, matrix *v1.Matrix) map[string]string {
+	filtered := make(map[string]string, len(replacements))
+	for k, v := range replacements {
+		filtered[k] = v
+	}
+	for _, param := range matrix.GetAllParams() {
+		for _, pattern := range paramPatterns {
+			delete(filtered, fmt.Sprintf(pattern, param.Name))
+		}
+	}
+	return filtered
+}
+
+// UnresolvedDisplayReferences returns the references to params, context and
+// workspaces variables left in the display names and descriptions of the
+// Pipeline, of its results and of its tasks after the values of the
+// PipelineRun have been substituted, e.g. references to params the Pipeline
+// doesn't declare. They are left verbatim in the status of the PipelineRun.
+// The references of matrixed tasks to their matrix params are resolved for
+// each combination and aren't returned.
+func UnresolvedDisplayReferences(p *v1.PipelineSpec) []string {
+	var unresolved []string
+	add := func(s string, matrix *v1.Matrix) {
+		for _, prefix := range []string{"params", "context", "workspaces"} {
+			expressions, _ := substitution.ExtractVariableExpressions(s, prefix)
+			for _, expression := range expressions {
+				if prefix == "params" && matrix != nil && referencesMatrixParam(expression, matrix) {
+					continue
+				}
+				unresolved = append(unresolved, expression)
+			}
+		}
+	}
+	add(p.DisplayName, nil)
+	add(p.Description, nil)
+	for _, r := range p.Results {
+		add(r.Description, nil)
+	}
+	for _, pt := range append(append([]v1.PipelineTask{}, p.Tasks...), p.Finally...) {
+		add(pt.DisplayName, pt.Matrix)
+		add(pt.Description, nil)
+	}
+	return unresolved
+}
+
+// referencesMatrixParam returns true if the given $(params.<name>) expression
+// references one of the params of the given matrix.
+func referencesMatrixParam(expression string, matrix *v1.Matrix) bool {
+	for _, param := range matrix.GetAllParams() {
+		for _, pattern := range paramPatterns {
+			if expression == "$("+fmt.Sprintf(pattern, param.Name)+")" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // propagateParams returns a Pipeline Task spec that is the same as the input Pipeline Task spec, but with
 // all parameter replacements from `stringReplacements`, `arrayReplacements`, and `objectReplacements` substituted.
 // It does not modify `stringReplacements`, `arrayReplacements`, or `objectReplacements`.
@@ -466,18 +538,25 @@ func PropagateArtifacts(rpt *ResolvedPipelineTask, runStates PipelineRunState) e
 // ApplyTaskResultsToPipelineResults applies the results of completed TasksRuns and Runs to a Pipeline's
 // list of PipelineResults, returning the computed set of PipelineRunResults. References to
 // non-existent TaskResults or failed TaskRuns or Runs result in a PipelineResult being considered invalid
-// and omitted from the returned slice. A nil slice is returned if no results are passed in or all
-// results are invalid.
+// and omitted from the returned slice. A PipelineResult referencing a task skipped for any reason, as listed
+// in skippedTasks, gets its default if it has one. A nil slice is returned if no results are
+// passed in or all results are invalid.
 func ApplyTaskResultsToPipelineResults(
 	_ context.Context,
 	results []v1.PipelineResult,
 	taskRunResults map[string][]v1.TaskRunResult,
 	customTaskResults map[string][]v1beta1.CustomRunResult,
 	taskstatus map[string]string,
+	skippedTasks []v1.SkippedTask,
 ) ([]v1.PipelineRunResult, error) {
 	var runResults []v1.PipelineRunResult
 	var invalidPipelineResults []string
 
+	skippedTaskNames := sets.New[string]()
+	for _, st := range skippedTasks {
+		skippedTaskNames.Insert(st.Name)
+	}
+
 	stringReplacements := map[string]string{}
 	arrayReplacements := map[string][]string{}
 	objectReplacements := map[string]map[string]string{}
@@ -487,6 +566,10 @@ func ApplyTaskResultsToPipelineResults(
 			continue
 		}
 		validPipelineResult := true
+		// referencesSkippedTask is true if one of the tasks the result
+		// references was skipped, for any reason, so that it gets its
+		// default.
+		referencesSkippedTask := false
 		for _, variable := range variablesInPipelineResult {
 			if _, isMemoized := stringReplacements[variable]; isMemoized {
 				continue
@@ -538,6 +621,7 @@ func ApplyTaskResultsToPipelineResults(
 					if status, ok := taskstatus[PipelineTaskStatusPrefix+taskName+PipelineTaskStatusSuffix]; ok {
 						if status != v1.TaskRunReasonSuccessful.String() {
 							validPipelineResult = false
+							referencesSkippedTask = referencesSkippedTask || status == PipelineTaskStateNone && skippedTaskNames.Has(taskName)
 							continue
 						}
 					}
@@ -562,6 +646,7 @@ func ApplyTaskResultsToPipelineResults(
 					if status, ok := taskstatus[PipelineTaskStatusPrefix+taskName+PipelineTaskStatusSuffix]; ok {
 						if status != v1.TaskRunReasonSuccessful.String() {
 							validPipelineResult = false
+							referencesSkippedTask = referencesSkippedTask || status == PipelineTaskStateNone && skippedTaskNames.Has(taskName)
 							continue
 						}
 					}
@@ -581,6 +666,12 @@ func ApplyTaskResultsToPipelineResults(
 				Name:  pipelineResult.Name,
 				Value: finalValue,
 			})
+		} else if referencesSkippedTask && pipelineResult.Default != nil && !slices.Contains(invalidPipelineResults, pipelineResult.Name) {
+			runResults = append(runResults, v1.PipelineRunResult{
+				Name:      pipelineResult.Name,
+				Value:     *pipelineResult.Default.DeepCopy(),
+				Defaulted: true,
+			})
 		}
 	}
 
@@ -1791,6 +1791,56 @@ func TestApplyParameters(t *testing.T) {
 				}},
 			},
 		},
+		{
+			name: "parameters in display names and descriptions",
+			original: v1.PipelineSpec{
+				DisplayName: "Deploy to $(params.environment)",
+				Description: "Deploys to $(params.environment) $(params.unknown)",
+				Params: []v1.ParamSpec{
+					{Name: "environment", Type: v1.ParamTypeString},
+				},
+				Tasks: []v1.PipelineTask{{
+					Name:        "deploy",
+					DisplayName: "Deploy to $(params.environment)",
+					Description: "Deploys to $(params.environment)",
+				}, {
+					Name:        "build",
+					DisplayName: "Build $(params.platform) for $(params.environment)",
+					Matrix: &v1.Matrix{
+						Params: v1.Params{{Name: "platform", Value: *v1.NewStructuredValues("linux", "mac")}},
+					},
+				}},
+				Results: []v1.PipelineResult{{
+					Name:        "url",
+					Description: "The URL of the $(params.environment) deployment",
+					Value:       *v1.NewStructuredValues("$(tasks.deploy.results.url)"),
+				}},
+			},
+			params: v1.Params{{Name: "environment", Value: *v1.NewStructuredValues("staging")}},
+			expected: v1.PipelineSpec{
+				DisplayName: "Deploy to staging",
+				Description: "Deploys to staging $(params.unknown)",
+				Params: []v1.ParamSpec{
+					{Name: "environment", Type: v1.ParamTypeString},
+				},
+				Tasks: []v1.PipelineTask{{
+					Name:        "deploy",
+					DisplayName: "Deploy to staging",
+					Description: "Deploys to staging",
+				}, {
+					Name:        "build",
+					DisplayName: "Build $(params.platform) for staging",
+					Matrix: &v1.Matrix{
+						Params: v1.Params{{Name: "platform", Value: *v1.NewStructuredValues("linux", "mac")}},
+					},
+				}},
+				Results: []v1.PipelineResult{{
+					Name:        "url",
+					Description: "The URL of the staging deployment",
+					Value:       *v1.NewStructuredValues("$(tasks.deploy.results.url)"),
+				}},
+			},
+		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
@@ -1811,6 +1861,58 @@ func TestApplyParameters(t *testing.T) {
 	}
 }
 
+func TestUnresolvedDisplayReferences(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		spec v1.PipelineSpec
+		want []string
+	}{{
+		name: "all references resolved",
+		spec: v1.PipelineSpec{
+			DisplayName: "Deploy to staging",
+			Tasks: []v1.PipelineTask{{
+				Name:        "deploy",
+				DisplayName: "Deploy $(tasks.build.results.image)",
+			}},
+		},
+	}, {
+		name: "unresolved references",
+		spec: v1.PipelineSpec{
+			DisplayName: "Deploy to $(params.environment)",
+			Description: "Run by $(context.pipelineRun.owner)",
+			Results: []v1.PipelineResult{{
+				Name:        "url",
+				Description: "Deployed to $(params['region'])",
+			}},
+			Tasks: []v1.PipelineTask{{
+				Name:        "build",
+				DisplayName: "Build $(params.platform) with $(params.compiler)",
+				Matrix: &v1.Matrix{
+					Params: v1.Params{{Name: "platform", Value: *v1.NewStructuredValues("linux", "mac")}},
+				},
+			}},
+			Finally: []v1.PipelineTask{{
+				Name:        "notify",
+				Description: "Notifies $(workspaces.contacts.path)",
+			}},
+		},
+		want: []string{
+			"$(params.environment)",
+			"$(context.pipelineRun.owner)",
+			"$(params['region'])",
+			"$(params.compiler)",
+			"$(workspaces.contacts.path)",
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := resources.UnresolvedDisplayReferences(&tc.spec)
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("UnresolvedDisplayReferences() got diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestApplyParameters_ArrayIndexing(t *testing.T) {
 	for _, tt := range []struct {
 		name     string
@@ -3839,7 +3941,7 @@ func TestApplyFinallyResultsToPipelineResults(t *testing.T) {
 		},
 	} {
 		t.Run(tc.description, func(t *testing.T) {
-			received, _ := resources.ApplyTaskResultsToPipelineResults(t.Context(), tc.results, tc.taskResults, tc.runResults, nil /* skippedTasks */)
+			received, _ := resources.ApplyTaskResultsToPipelineResults(t.Context(), tc.results, tc.taskResults, tc.runResults, nil /* taskstatus */, tc.skippedTasks)
 			if d := cmp.Diff(tc.expected, received); d != "" {
 				t.Error(diff.PrintWantGot(d))
 			}
@@ -4170,9 +4272,93 @@ func TestApplyTaskResultsToPipelineResults_Success(t *testing.T) {
 			Name:  "foo",
 			Value: *v1.NewStructuredValues("do", "rae", "mi"),
 		}},
+	}, {
+		description: "skipped-task-results-with-defaults",
+		results: []v1.PipelineResult{{
+			Name:    "pipeline-result-1",
+			Value:   *v1.NewStructuredValues("$(tasks.skippedTask.results.foo)"),
+			Default: v1.NewStructuredValues("none"),
+		}, {
+			Name:    "pipeline-result-2",
+			Value:   *v1.NewStructuredValues("$(tasks.skippedTask.results.foo), $(tasks.normaltask.results.baz)"),
+			Default: v1.NewStructuredValues("partial"),
+		}, {
+			Name:    "pipeline-result-3",
+			Type:    v1.ResultsTypeArray,
+			Value:   *v1.NewStructuredValues("$(tasks.skippedTask.results.images[*])"),
+			Default: v1.NewStructuredValues("busybox", "alpine"),
+		}, {
+			Name:    "pipeline-result-4",
+			Value:   *v1.NewStructuredValues("$(tasks.normaltask.results.baz)"),
+			Default: v1.NewStructuredValues("unused"),
+		}},
+		taskResults: map[string][]v1.TaskRunResult{
+			"normaltask": {{
+				Name:  "baz",
+				Value: *v1.NewStructuredValues("rae"),
+			}},
+		},
+		taskstatus:   map[string]string{resources.PipelineTaskStatusPrefix + "skippedTask" + resources.PipelineTaskStatusSuffix: resources.PipelineTaskStateNone},
+		skippedTasks: []v1.SkippedTask{{Name: "skippedTask", Reason: v1.WhenExpressionsSkip}},
+		expectedResults: []v1.PipelineRunResult{{
+			Name:      "pipeline-result-1",
+			Value:     *v1.NewStructuredValues("none"),
+			Defaulted: true,
+		}, {
+			Name:      "pipeline-result-2",
+			Value:     *v1.NewStructuredValues("partial"),
+			Defaulted: true,
+		}, {
+			Name:      "pipeline-result-3",
+			Value:     *v1.NewStructuredValues("busybox", "alpine"),
+			Defaulted: true,
+		}, {
+			Name:  "pipeline-result-4",
+			Value: *v1.NewStructuredValues("rae"),
+		}},
+	}, {
+		description: "results-of-task-skipped-for-its-parents-with-defaults",
+		results: []v1.PipelineResult{{
+			Name:    "foo",
+			Value:   *v1.NewStructuredValues("$(tasks.pt1.results.foo)"),
+			Default: v1.NewStructuredValues("none"),
+		}},
+		taskResults:  map[string][]v1.TaskRunResult{},
+		taskstatus:   map[string]string{resources.PipelineTaskStatusPrefix + "pt1" + resources.PipelineTaskStatusSuffix: resources.PipelineTaskStateNone},
+		skippedTasks: []v1.SkippedTask{{Name: "pt1", Reason: v1.ParentTasksSkip}},
+		expectedResults: []v1.PipelineRunResult{{
+			Name:      "foo",
+			Value:     *v1.NewStructuredValues("none"),
+			Defaulted: true,
+		}},
+	}, {
+		description: "results-of-task-skipped-for-the-pipelinerun-timeout-with-defaults",
+		results: []v1.PipelineResult{{
+			Name:    "foo",
+			Value:   *v1.NewStructuredValues("$(tasks.pt1.results.foo)"),
+			Default: v1.NewStructuredValues("none"),
+		}},
+		taskResults:  map[string][]v1.TaskRunResult{},
+		taskstatus:   map[string]string{resources.PipelineTaskStatusPrefix + "pt1" + resources.PipelineTaskStatusSuffix: resources.PipelineTaskStateNone},
+		skippedTasks: []v1.SkippedTask{{Name: "pt1", Reason: v1.PipelineTimedOutSkip}},
+		expectedResults: []v1.PipelineRunResult{{
+			Name:      "foo",
+			Value:     *v1.NewStructuredValues("none"),
+			Defaulted: true,
+		}},
+	}, {
+		description: "failed-task-results-with-defaults",
+		results: []v1.PipelineResult{{
+			Name:    "foo",
+			Value:   *v1.NewStructuredValues("$(tasks.pt1.results.foo)"),
+			Default: v1.NewStructuredValues("none"),
+		}},
+		taskResults:     map[string][]v1.TaskRunResult{},
+		taskstatus:      map[string]string{resources.PipelineTaskStatusPrefix + "pt1" + resources.PipelineTaskStatusSuffix: v1beta1.TaskRunReasonFailed.String()},
+		expectedResults: nil,
 	}} {
 		t.Run(tc.description, func(t *testing.T) {
-			received, err := resources.ApplyTaskResultsToPipelineResults(t.Context(), tc.results, tc.taskResults, tc.runResults, tc.taskstatus)
+			received, err := resources.ApplyTaskResultsToPipelineResults(t.Context(), tc.results, tc.taskResults, tc.runResults, tc.taskstatus, tc.skippedTasks)
 			if err != nil {
 				t.Errorf("Got unecpected error:%v", err)
 			}
@@ -4388,7 +4574,7 @@ func TestApplyTaskResultsToPipelineResults_Error(t *testing.T) {
 		expectedError:   errors.New("invalid pipelineresults [foo], the referenced results don't exist"),
 	}} {
 		t.Run(tc.description, func(t *testing.T) {
-			received, err := resources.ApplyTaskResultsToPipelineResults(t.Context(), tc.results, tc.taskResults, tc.runResults, nil /*skipped tasks*/)
+			received, err := resources.ApplyTaskResultsToPipelineResults(t.Context(), tc.results, tc.taskResults, tc.runResults, nil /* taskstatus */, nil /* skippedTasks */)
 			if err == nil {
 				t.Errorf("Expect error but got nil")
 				return
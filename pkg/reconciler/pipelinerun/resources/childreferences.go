@@ -0,0 +1,94 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+// SortChildReferences sorts the child references by PipelineTask name, then by matrix
+// index, so that their order doesn't churn between reconciles. The retries of a run are
+// recorded in its own status rather than as new children, so the child name is the last
+// key and only breaks the ties of the runs which are not part of a matrix.
+func SortChildReferences(childRefs []v1.ChildStatusReference) {
+	slices.SortStableFunc(childRefs, func(a, b v1.ChildStatusReference) int {
+		return cmp.Or(
+			cmp.Compare(a.PipelineTaskName, b.PipelineTaskName),
+			cmp.Compare(matrixIndex(a.Name), matrixIndex(b.Name)),
+			cmp.Compare(a.Name, b.Name),
+		)
+	})
+}
+
+// matrixIndex returns the matrix index suffixed to the name of a fanned out run, e.g.
+// 2 for "pr-task-2", or -1 if the name has no such suffix.
+func matrixIndex(name string) int {
+	i := strings.LastIndexByte(name, '-')
+	if i < 0 {
+		return -1
+	}
+	index, err := strconv.Atoi(name[i+1:])
+	if err != nil || index < 0 {
+		return -1
+	}
+	return index
+}
+
+// ChildReferencesByPipelineTask indexes the child references by PipelineTask name, so that
+// the children of each PipelineTask are found without scanning all the child references.
+type ChildReferencesByPipelineTask map[string][]v1.ChildStatusReference
+
+// IndexChildReferences returns the child references indexed by PipelineTask name. The
+// child references of each PipelineTask keep their relative order.
+func IndexChildReferences(childRefs []v1.ChildStatusReference) ChildReferencesByPipelineTask {
+	index := make(ChildReferencesByPipelineTask)
+	for _, cr := range childRefs {
+		index[cr.PipelineTaskName] = append(index[cr.PipelineTaskName], cr)
+	}
+	return index
+}
+
+// UpdateChildReferences returns the child references to record in the status, and how many
+// of them differ from the current ones. The current child references are returned as they
+// are when none of them changed, so that the status is not rewritten.
+func UpdateChildReferences(current, desired []v1.ChildStatusReference) ([]v1.ChildStatusReference, int) {
+	currentByName := make(map[string]*v1.ChildStatusReference, len(current))
+	for i := range current {
+		currentByName[current[i].Name] = &current[i]
+	}
+	changed := 0
+	reordered := len(current) != len(desired)
+	for i := range desired {
+		cr, ok := currentByName[desired[i].Name]
+		if !ok || !equality.Semantic.DeepEqual(*cr, desired[i]) {
+			changed++
+		}
+		if !reordered && current[i].Name != desired[i].Name {
+			reordered = true
+		}
+	}
+	if changed == 0 && !reordered {
+		return current, 0
+	}
+	return desired, changed
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipeline/dag"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func childRef(name, pipelineTaskName string) v1.ChildStatusReference {
+	return v1.ChildStatusReference{
+		TypeMeta: runtime.TypeMeta{
+			APIVersion: v1.SchemeGroupVersion.String(),
+			Kind:       pipeline.TaskRunControllerName,
+		},
+		Name:             name,
+		PipelineTaskName: pipelineTaskName,
+	}
+}
+
+func TestSortChildReferences(t *testing.T) {
+	childRefs := []v1.ChildStatusReference{
+		childRef("pr-matrixed-10", "matrixed"),
+		childRef("pr-unmatrixed", "unmatrixed"),
+		childRef("pr-matrixed-2", "matrixed"),
+		childRef("pr-b-task", "a-task"),
+		childRef("pr-matrixed-0", "matrixed"),
+		childRef("pr-a-task", "a-task"),
+	}
+	want := []v1.ChildStatusReference{
+		childRef("pr-a-task", "a-task"),
+		childRef("pr-b-task", "a-task"),
+		childRef("pr-matrixed-0", "matrixed"),
+		childRef("pr-matrixed-2", "matrixed"),
+		childRef("pr-matrixed-10", "matrixed"),
+		childRef("pr-unmatrixed", "unmatrixed"),
+	}
+	SortChildReferences(childRefs)
+	if d := cmp.Diff(want, childRefs); d != "" {
+		t.Errorf("unexpected order of the child references: %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestIndexChildReferences(t *testing.T) {
+	index := IndexChildReferences([]v1.ChildStatusReference{
+		childRef("pr-matrixed-0", "matrixed"),
+		childRef("pr-unmatrixed", "unmatrixed"),
+		childRef("pr-matrixed-1", "matrixed"),
+	})
+	want := ChildReferencesByPipelineTask{
+		"matrixed":   {childRef("pr-matrixed-0", "matrixed"), childRef("pr-matrixed-1", "matrixed")},
+		"unmatrixed": {childRef("pr-unmatrixed", "unmatrixed")},
+	}
+	if d := cmp.Diff(want, index); d != "" {
+		t.Errorf("unexpected index of the child references: %s", diff.PrintWantGot(d))
+	}
+	if got := index["missing"]; got != nil {
+		t.Errorf("expected no child references for an unknown PipelineTask, got %v", got)
+	}
+}
+
+func TestUpdateChildReferences(t *testing.T) {
+	current := []v1.ChildStatusReference{
+		childRef("pr-matrixed-0", "matrixed"),
+		childRef("pr-matrixed-1", "matrixed"),
+	}
+
+	got, changed := UpdateChildReferences(current, []v1.ChildStatusReference{
+		childRef("pr-matrixed-0", "matrixed"),
+		childRef("pr-matrixed-1", "matrixed"),
+	})
+	if changed != 0 {
+		t.Errorf("expected no changed child reference, got %d", changed)
+	}
+	if &got[0] != &current[0] {
+		t.Errorf("expected the current child references to be kept when nothing changed")
+	}
+
+	withDisplayName := childRef("pr-matrixed-1", "matrixed")
+	withDisplayName.DisplayName = "matrixed 1"
+	desired := []v1.ChildStatusReference{
+		childRef("pr-matrixed-0", "matrixed"),
+		withDisplayName,
+		childRef("pr-unmatrixed", "unmatrixed"),
+	}
+	got, changed = UpdateChildReferences(current, desired)
+	if changed != 2 {
+		t.Errorf("expected 2 changed child references, got %d", changed)
+	}
+	if d := cmp.Diff(desired, got); d != "" {
+		t.Errorf("unexpected child references: %s", diff.PrintWantGot(d))
+	}
+}
+
+// largeMatrixFacts returns the facts of a PipelineRun whose matrixed PipelineTask fanned
+// out numChildren TaskRuns, listed in a random order.
+func largeMatrixFacts(tb testing.TB, numChildren int, rnd *rand.Rand) *PipelineRunFacts {
+	tb.Helper()
+	rpt := &ResolvedPipelineTask{
+		PipelineTask: &v1.PipelineTask{Name: "matrixed"},
+	}
+	for i := range numChildren {
+		name := fmt.Sprintf("pr-matrixed-%d", i)
+		rpt.TaskRunNames = append(rpt.TaskRunNames, name)
+		rpt.TaskRuns = append(rpt.TaskRuns, &v1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: name}})
+	}
+	rnd.Shuffle(len(rpt.TaskRuns), func(i, j int) {
+		rpt.TaskRuns[i], rpt.TaskRuns[j] = rpt.TaskRuns[j], rpt.TaskRuns[i]
+	})
+	state := PipelineRunState{rpt, {
+		PipelineTask: &v1.PipelineTask{Name: "a-task"},
+		TaskRunNames: []string{"pr-a-task"},
+		TaskRuns:     []*v1.TaskRun{{ObjectMeta: metav1.ObjectMeta{Name: "pr-a-task"}}},
+	}}
+	d, err := dagFromState(state)
+	if err != nil {
+		tb.Fatalf("Unexpected error while building DAG for state %v: %v", state, err)
+	}
+	return &PipelineRunFacts{
+		State:           state,
+		TasksGraph:      d,
+		FinalTasksGraph: &dag.Graph{},
+		TimeoutsState: PipelineRunTimeoutsState{
+			Clock: testClock,
+		},
+	}
+}
+
+func TestGetChildReferences_StableAcrossReconciles(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	first := largeMatrixFacts(t, 1000, rnd).GetChildReferences()
+	if len(first) != 1001 {
+		t.Fatalf("expected 1001 child references, got %d", len(first))
+	}
+	if first[0].Name != "pr-a-task" || first[1].Name != "pr-matrixed-0" || first[1000].Name != "pr-matrixed-999" {
+		t.Errorf("unexpected order of the child references: %s, %s, ..., %s", first[0].Name, first[1].Name, first[1000].Name)
+	}
+
+	for range 5 {
+		next := largeMatrixFacts(t, 1000, rnd).GetChildReferences()
+		if d := cmp.Diff(first, next); d != "" {
+			t.Fatalf("child references changed between reconciles: %s", diff.PrintWantGot(d))
+		}
+		if _, changed := UpdateChildReferences(first, next); changed != 0 {
+			t.Errorf("expected no child reference to be updated, got %d", changed)
+		}
+	}
+}
+
+func BenchmarkGetChildReferences(b *testing.B) {
+	facts := largeMatrixFacts(b, 1000, rand.New(rand.NewSource(1)))
+	b.ResetTimer()
+	for range b.N {
+		facts.GetChildReferences()
+	}
+}
+
+func BenchmarkUpdateChildReferences(b *testing.B) {
+	facts := largeMatrixFacts(b, 1000, rand.New(rand.NewSource(1)))
+	current := facts.GetChildReferences()
+	desired := facts.GetChildReferences()
+	b.ResetTimer()
+	for range b.N {
+		UpdateChildReferences(current, desired)
+	}
+}
+
+func BenchmarkIndexChildReferences(b *testing.B) {
+	childRefs := largeMatrixFacts(b, 1000, rand.New(rand.NewSource(1))).GetChildReferences()
+	b.ResetTimer()
+	for range b.N {
+		index := IndexChildReferences(childRefs)
+		GetNamesOfTaskRuns(index["matrixed"], "matrixed", "pr", 1000)
+	}
+}
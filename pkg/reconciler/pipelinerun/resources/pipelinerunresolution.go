@@ -35,6 +35,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/resolution/resource"
 	"github.com/tektoncd/pipeline/pkg/substitution"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/kmeta"
 )
@@ -175,6 +176,24 @@ func (t ResolvedPipelineTask) getReason() string {
 	return ""
 }
 
+// failedCondition returns the condition of the first run of the PipelineTask which failed, if any
+func (t ResolvedPipelineTask) failedCondition() *apis.Condition {
+	if t.IsCustomTask() {
+		for _, run := range t.CustomRuns {
+			if c := run.GetStatusCondition().GetCondition(apis.ConditionSucceeded); c.IsFalse() {
+				return c
+			}
+		}
+		return nil
+	}
+	for _, taskRun := range t.TaskRuns {
+		if c := taskRun.Status.GetCondition(apis.ConditionSucceeded); c.IsFalse() {
+			return c
+		}
+	}
+	return nil
+}
+
 // isSuccessful returns true only if the run has completed successfully
 // If the PipelineTask has a Matrix, isSuccessful returns true if all runs have completed successfully
 func (t ResolvedPipelineTask) isSuccessful() bool {
@@ -270,7 +289,7 @@ func (t ResolvedPipelineTask) isCancelledForTimeOut() bool {
 	return atLeastOneCancelled && isDone
 }
 
-// isCancelled returns true only if the run is cancelled
+// isCancelled returns true only if the run is cancelled or superseded
 // If the PipelineTask has a Matrix, isCancelled returns true if any run is cancelled and all other runs are done.
 func (t ResolvedPipelineTask) isCancelled() bool {
 	if t.IsCustomTask() {
@@ -295,7 +314,7 @@ func (t ResolvedPipelineTask) isCancelled() bool {
 	for _, taskRun := range t.TaskRuns {
 		isDone = isDone && taskRun.IsDone()
 		c := taskRun.Status.GetCondition(apis.ConditionSucceeded)
-		taskRunCancelled := c.IsFalse() && c.Reason == v1beta1.TaskRunReasonCancelled.String()
+		taskRunCancelled := c.IsFalse() && (c.Reason == v1beta1.TaskRunReasonCancelled.String() || c.Reason == v1beta1.TaskRunReasonSuperseded.String())
 		atLeastOneCancelled = atLeastOneCancelled || taskRunCancelled
 	}
 	return atLeastOneCancelled && isDone
@@ -520,7 +539,7 @@ func (t *ResolvedPipelineTask) IsFinallySkipped(facts *PipelineRunFacts) TaskSki
 	switch {
 	case t.isScheduled():
 		skippingReason = v1.None
-	case facts.checkDAGTasksDone() && facts.isFinalTask(t.PipelineTask.Name):
+	case (facts.checkDAGTasksDone() || facts.isStartingFinallyOnCancel()) && facts.isFinalTask(t.PipelineTask.Name) && facts.checkFinalTaskDepsDone(t):
 		switch {
 		case t.skipBecauseResultReferencesAreMissing(facts):
 			skippingReason = v1.MissingResultsSkip
@@ -585,6 +604,37 @@ func ValidateTaskRunSpecs(p *v1.PipelineSpec, pr *v1.PipelineRun) error {
 	return nil
 }
 
+// ValidateTaskRunSpecOverrides validates that the stepSpecs and sidecarSpecs of
+// the taskRunSpec of a PipelineTask refer to the steps and sidecars of its
+// resolved Task.
+func ValidateTaskRunSpecOverrides(pr *v1.PipelineRun, rpt *ResolvedPipelineTask) error {
+	if rpt.ResolvedTask == nil || rpt.ResolvedTask.TaskSpec == nil {
+		return nil
+	}
+	taskRunSpec := pr.GetTaskRunSpec(rpt.PipelineTask.Name)
+	ts := rpt.ResolvedTask.TaskSpec
+
+	stepNames := sets.NewString()
+	for _, step := range ts.Steps {
+		stepNames.Insert(step.Name)
+	}
+	for _, stepSpec := range taskRunSpec.StepSpecs {
+		if !stepNames.Has(stepSpec.Name) {
+			return pipelineErrors.WrapUserError(fmt.Errorf("stepSpecs of pipelineTask %q refer to step %q which doesn't exist in its Task", rpt.PipelineTask.Name, stepSpec.Name))
+		}
+	}
+	sidecarNames := sets.NewString()
+	for _, sidecar := range ts.Sidecars {
+		sidecarNames.Insert(sidecar.Name)
+	}
+	for _, sidecarSpec := range taskRunSpec.SidecarSpecs {
+		if !sidecarNames.Has(sidecarSpec.Name) {
+			return pipelineErrors.WrapUserError(fmt.Errorf("sidecarSpecs of pipelineTask %q refer to sidecar %q which doesn't exist in its Task", rpt.PipelineTask.Name, sidecarSpec.Name))
+		}
+	}
+	return nil
+}
+
 // ResolvePipelineTask returns a new ResolvedPipelineTask representing any TaskRuns or CustomRuns
 // associated with this Pipeline Task, if they exist.
 //
@@ -606,7 +656,9 @@ func ResolvePipelineTask(
 	rpt := ResolvedPipelineTask{
 		PipelineTask: &pipelineTask,
 	}
-	rpt.CustomTask = rpt.PipelineTask.TaskRef.IsCustomTask() || rpt.PipelineTask.TaskSpec.IsCustomTask()
+	// The approval gates are run as CustomRuns which are reconciled by the
+	// PipelineRun controller itself.
+	rpt.CustomTask = rpt.PipelineTask.TaskRef.IsCustomTask() || rpt.PipelineTask.TaskSpec.IsCustomTask() || rpt.PipelineTask.TaskRef.IsApprovalGate()
 	numCombinations := 1
 	// We want to resolve all of the result references and ignore any errors at this point since there could be
 	// instances where result references are missing here, but will be later skipped and resolved in
@@ -695,6 +747,8 @@ func resolveTask(
 			switch {
 			case errors.Is(err, remote.ErrRequestInProgress) || (err != nil && resolutioncommon.IsErrTransient(err)):
 				return rt, err
+			case errors.As(err, new(*ResolutionChainError)):
+				return rt, err
 			case err != nil:
 				// some of the resolvers obtain the name from the parameters instead of from the TaskRef.Name field,
 				// so we account for both locations when constructing the error
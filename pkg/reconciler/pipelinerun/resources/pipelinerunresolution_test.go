@@ -321,6 +321,11 @@ func withCancelled(tr *v1.TaskRun) *v1.TaskRun {
 	return tr
 }
 
+func withSuperseded(tr *v1.TaskRun) *v1.TaskRun {
+	tr.Status.Conditions[0].Reason = v1.TaskRunReasonSuperseded.String()
+	return tr
+}
+
 func withCancelledForTimeout(tr *v1.TaskRun) *v1.TaskRun {
 	tr.Spec.StatusMessage = v1.TaskRunCancelledByPipelineTimeoutMsg
 	tr.Status.Conditions[0].Reason = v1.TaskRunSpecStatusCancelled
@@ -1709,6 +1714,12 @@ func TestIsCancelled(t *testing.T) {
 			TaskRuns: []*v1.TaskRun{withCancelled(makeFailed(trs[0]))},
 		},
 		want: true,
+	}, {
+		name: "taskrun superseded and failed",
+		rpt: ResolvedPipelineTask{
+			TaskRuns: []*v1.TaskRun{withSuperseded(makeFailed(trs[0]))},
+		},
+		want: true,
 	}, {
 		name: "taskrun cancelled but still running",
 		rpt: ResolvedPipelineTask{
@@ -2789,6 +2800,67 @@ func TestValidateTaskRunSpecs(t *testing.T) {
 	}
 }
 
+func TestValidateTaskRunSpecOverrides(t *testing.T) {
+	rpt := &ResolvedPipelineTask{
+		PipelineTask: &v1.PipelineTask{Name: "build"},
+		ResolvedTask: &resources.ResolvedTask{
+			TaskSpec: &v1.TaskSpec{
+				Steps:    []v1.Step{{Name: "compile"}},
+				Sidecars: []v1.Sidecar{{Name: "database"}},
+			},
+		},
+	}
+	for _, tc := range []struct {
+		name         string
+		taskRunSpecs []v1.PipelineTaskRunSpec
+		wantErr      string
+	}{{
+		name: "valid stepSpecs and sidecarSpecs",
+		taskRunSpecs: []v1.PipelineTaskRunSpec{{
+			PipelineTaskName: "build",
+			StepSpecs:        []v1.TaskRunStepSpec{{Name: "compile"}},
+			SidecarSpecs:     []v1.TaskRunSidecarSpec{{Name: "database"}},
+		}},
+	}, {
+		name: "stepSpecs of another pipelineTask",
+		taskRunSpecs: []v1.PipelineTaskRunSpec{{
+			PipelineTaskName: "test",
+			StepSpecs:        []v1.TaskRunStepSpec{{Name: "unit-tests"}},
+		}},
+	}, {
+		name: "unknown step",
+		taskRunSpecs: []v1.PipelineTaskRunSpec{{
+			PipelineTaskName: "build",
+			StepSpecs:        []v1.TaskRunStepSpec{{Name: "compile"}, {Name: "link"}},
+		}},
+		wantErr: `stepSpecs of pipelineTask "build" refer to step "link" which doesn't exist in its Task`,
+	}, {
+		name: "unknown sidecar",
+		taskRunSpecs: []v1.PipelineTaskRunSpec{{
+			PipelineTaskName: "build",
+			SidecarSpecs:     []v1.TaskRunSidecarSpec{{Name: "cache"}},
+		}},
+		wantErr: `sidecarSpecs of pipelineTask "build" refer to sidecar "cache" which doesn't exist in its Task`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := &v1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun"},
+				Spec:       v1.PipelineRunSpec{TaskRunSpecs: tc.taskRunSpecs},
+			}
+			err := ValidateTaskRunSpecOverrides(pr, rpt)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Unexpected error when no error expected: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.wantErr {
+				t.Fatalf("Expected error %q but got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
 func TestResolvePipeline_WhenExpressions(t *testing.T) {
 	names.TestingSeed()
 	tName1 := "pipelinerun-mytask1-always-true"
@@ -19,12 +19,15 @@ package resources
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	tknreconciler "github.com/tektoncd/pipeline/pkg/reconciler"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipeline/dag"
 	"github.com/tektoncd/pipeline/pkg/substitution"
 	"go.uber.org/zap"
@@ -44,6 +47,15 @@ const (
 	// PipelineTaskStatusSuffix is a suffix of the param representing execution state of pipelineTask
 	PipelineTaskStatusSuffix = ".status"
 	PipelineTaskReasonSuffix = ".reason"
+	// maxSkippedTaskValueLength is the maximum length of the inputs, values and CEL expressions of
+	// the evaluated when expressions reported for a skipped task, longer ones being truncated.
+	maxSkippedTaskValueLength = 256
+	// maxSkippedTaskValues is the maximum number of values of an evaluated when expression reported
+	// for a skipped task.
+	maxSkippedTaskValues = 16
+	// maxPipelineTaskFailureMessageLength is the maximum length of the messages of the failures of
+	// the PipelineTasks reported for a failed PipelineRun, longer ones being truncated.
+	maxPipelineTaskFailureMessageLength = 256
 )
 
 // PipelineRunState is a slice of ResolvedPipelineRunTasks the represents the current execution
@@ -76,6 +88,17 @@ type PipelineRunFacts struct {
 	// the case of failing at the validation is during CheckMissingResultReferences method
 	// Tasks in ValidationFailedTask is added in method runNextSchedulableTask
 	ValidationFailedTask []*ResolvedPipelineTask
+
+	// StartFinallyOnCancel starts the final tasks of a gracefully cancelled
+	// PipelineRun without waiting for its running DAG tasks to stop, following
+	// the start-finally-on-cancel feature flag.
+	StartFinallyOnCancel bool
+
+	// FilteredOutTasks and FilteredOutFinallyTasks are the names of the PipelineTasks
+	// and of the finally tasks which the tasksFilter of the PipelineRun filtered out.
+	// They are not part of the State and the graphs, and are reported as skipped.
+	FilteredOutTasks        []string
+	FilteredOutFinallyTasks []string
 }
 
 // PipelineRunTimeoutsState records information about start times and timeouts for the PipelineRun, so that the PipelineRunFacts
@@ -248,7 +271,7 @@ func (state PipelineRunState) GetRunsResults() map[string][]v1beta1.CustomRunRes
 }
 
 // GetChildReferences returns a slice of references, including version, kind, name, and pipeline task name, for all
-// TaskRuns and Runs in the state.
+// TaskRuns and Runs in the state, sorted with SortChildReferences.
 func (facts *PipelineRunFacts) GetChildReferences() []v1.ChildStatusReference {
 	var childRefs []v1.ChildStatusReference
 
@@ -274,6 +297,7 @@ func (facts *PipelineRunFacts) GetChildReferences() []v1.ChildStatusReference {
 			}
 		}
 	}
+	SortChildReferences(childRefs)
 	return childRefs
 }
 
@@ -424,6 +448,51 @@ func (facts *PipelineRunFacts) DAGExecutionQueue() (PipelineRunState, error) {
 	return tasks, nil
 }
 
+// BlockedDAGTask is a DAG task which can't be scheduled until the tasks it
+// depends on are done.
+type BlockedDAGTask struct {
+	// Name is the name of the PipelineTask.
+	Name string
+	// WaitingFor are the references to the results, or else to the tasks,
+	// the PipelineTask is waiting for.
+	WaitingFor []string
+}
+
+// BlockedDAGTasks returns the DAG tasks which haven't been scheduled yet
+// because some of the tasks they depend on aren't done.
+func (facts *PipelineRunFacts) BlockedDAGTasks() []BlockedDAGTask {
+	done := sets.NewString(facts.completedOrSkippedDAGTasks()...)
+	var blocked []BlockedDAGTask
+	for _, t := range facts.State {
+		node, ok := facts.TasksGraph.Nodes[t.PipelineTask.Name]
+		if !ok || t.isScheduled() || done.Has(t.PipelineTask.Name) {
+			continue
+		}
+		resultRefs := map[string]sets.String{}
+		for _, ref := range v1.PipelineTaskResultRefs(t.PipelineTask) {
+			if resultRefs[ref.PipelineTask] == nil {
+				resultRefs[ref.PipelineTask] = sets.NewString()
+			}
+			resultRefs[ref.PipelineTask].Insert(fmt.Sprintf("tasks.%s.results.%s", ref.PipelineTask, ref.Result))
+		}
+		waitingFor := sets.NewString()
+		for _, prev := range node.Prev {
+			if done.Has(prev.Key) {
+				continue
+			}
+			if refs, ok := resultRefs[prev.Key]; ok {
+				waitingFor.Insert(refs.List()...)
+			} else {
+				waitingFor.Insert("tasks." + prev.Key)
+			}
+		}
+		if waitingFor.Len() > 0 {
+			blocked = append(blocked, BlockedDAGTask{Name: t.PipelineTask.Name, WaitingFor: waitingFor.List()})
+		}
+	}
+	return blocked
+}
+
 // GetFinalTaskNames returns a list of all final task names
 func (facts *PipelineRunFacts) GetFinalTaskNames() sets.String {
 	names := sets.NewString()
@@ -449,16 +518,18 @@ func (facts *PipelineRunFacts) GetTaskNames() sets.String {
 }
 
 // GetFinalTasks returns a list of final tasks which needs to be executed next
-// GetFinalTasks returns final tasks only when all DAG tasks have finished executing or have been skipped
+// GetFinalTasks returns final tasks only when all DAG tasks have finished executing or have been skipped,
+// or when the PipelineRun starts its final tasks on its graceful cancellation
 func (facts *PipelineRunFacts) GetFinalTasks() PipelineRunState {
 	tasks := PipelineRunState{}
 	finalCandidates := sets.NewString()
 	// check either pipeline has finished executing all DAG pipelineTasks,
-	// where "finished executing" means succeeded, failed, or skipped.
-	if facts.checkDAGTasksDone() {
+	// where "finished executing" means succeeded, failed, or skipped,
+	// or is starting the final tasks on its graceful cancellation.
+	if facts.checkDAGTasksDone() || facts.isStartingFinallyOnCancel() {
 		// return list of tasks with all final tasks
 		for _, t := range facts.State {
-			if facts.isFinalTask(t.PipelineTask.Name) {
+			if facts.isFinalTask(t.PipelineTask.Name) && facts.checkFinalTaskDepsDone(t) {
 				finalCandidates.Insert(t.PipelineTask.Name)
 			}
 		}
@@ -467,11 +538,32 @@ func (facts *PipelineRunFacts) GetFinalTasks() PipelineRunState {
 	return tasks
 }
 
+// isStartingFinallyOnCancel returns true if the PipelineRun was gracefully
+// cancelled and starts its final tasks without waiting for its running DAG
+// tasks to stop. They have been signalled by the time the final tasks are
+// scheduled, and the PipelineRun still finishes once they are done.
+func (facts *PipelineRunFacts) isStartingFinallyOnCancel() bool {
+	return facts.StartFinallyOnCancel && facts.IsGracefullyCancelled()
+}
+
+// checkFinalTaskDepsDone returns true if the DAG tasks the final task consumes
+// the results of, or runs after, are done. They always are unless the final
+// tasks start on the graceful cancellation of the PipelineRun.
+func (facts *PipelineRunFacts) checkFinalTaskDepsDone(t *ResolvedPipelineTask) bool {
+	stateMap := facts.State.ToMap()
+	for _, dep := range t.PipelineTask.Deps() {
+		if rpt, ok := stateMap[dep]; ok && facts.isDAGTask(dep) && !rpt.isDone(facts) {
+			return false
+		}
+	}
+	return true
+}
+
 // IsFinalTaskStarted returns true if all DAG pipelineTasks is finished and one or more final tasks have been created.
 func (facts *PipelineRunFacts) IsFinalTaskStarted() bool {
 	// check either pipeline has finished executing all DAG pipelineTasks,
 	// where "finished executing" means succeeded, failed, or skipped.
-	if facts.checkDAGTasksDone() {
+	if facts.checkDAGTasksDone() || facts.isStartingFinallyOnCancel() {
 		// return list of tasks with all final tasks
 		for _, t := range facts.State {
 			if facts.isFinalTask(t.PipelineTask.Name) && t.isScheduled() {
@@ -560,6 +652,10 @@ func (facts *PipelineRunFacts) GetPipelineConditionStatus(ctx context.Context, p
 			reason = v1.PipelineRunReasonCancelled.String()
 			status = corev1.ConditionFalse
 		}
+		// List the failed tasks in the message of a failed PipelineRun
+		if failures, failed := facts.getPipelineTaskFailures(); status == corev1.ConditionFalse && failed > 0 {
+			message += "\n" + v1.PipelineTaskFailuresMessage(failures, failed)
+		}
 		logger.Infof("All TaskRuns have finished for PipelineRun %s so it has finished", pr.Name)
 		return &apis.Condition{
 			Type:    apis.ConditionSucceeded,
@@ -598,30 +694,201 @@ func (facts *PipelineRunFacts) GetPipelineConditionStatus(ctx context.Context, p
 func (facts *PipelineRunFacts) GetSkippedTasks() []v1.SkippedTask {
 	var skipped []v1.SkippedTask
 	for _, rpt := range facts.State {
-		if rpt.Skip(facts).IsSkipped {
-			skippedTask := v1.SkippedTask{
-				Name:            rpt.PipelineTask.Name,
-				Reason:          rpt.Skip(facts).SkippingReason,
-				WhenExpressions: rpt.PipelineTask.When,
-			}
+		if skipStatus := rpt.Skip(facts); skipStatus.IsSkipped {
+			skippedTask := rpt.skippedTask(facts, skipStatus.SkippingReason)
+			skippedTask.WhenExpressions = rpt.PipelineTask.When
 			skipped = append(skipped, skippedTask)
 		}
-		if rpt.IsFinallySkipped(facts).IsSkipped {
-			skippedTask := v1.SkippedTask{
-				Name:   rpt.PipelineTask.Name,
-				Reason: rpt.IsFinallySkipped(facts).SkippingReason,
-			}
+		if skipStatus := rpt.IsFinallySkipped(facts); skipStatus.IsSkipped {
+			skippedTask := rpt.skippedTask(facts, skipStatus.SkippingReason)
 			// include the when expressions only when the finally task was skipped because
 			// its when expressions evaluated to false (not because results variables were missing)
-			if rpt.IsFinallySkipped(facts).SkippingReason == v1.WhenExpressionsSkip {
+			if skipStatus.SkippingReason == v1.WhenExpressionsSkip {
 				skippedTask.WhenExpressions = rpt.PipelineTask.When
 			}
 			skipped = append(skipped, skippedTask)
 		}
 	}
+	for _, name := range facts.FilteredOutTasks {
+		skipped = append(skipped, v1.SkippedTask{Name: name, Reason: v1.FilteredOutSkip})
+	}
+	for _, name := range facts.FilteredOutFinallyTasks {
+		skipped = append(skipped, v1.SkippedTask{Name: name, Reason: v1.FilteredOutSkip})
+	}
 	return skipped
 }
 
+// skippedTask returns the SkippedTask of a PipelineTask skipped for the given reason, with the
+// outcome of its when expressions or the ancestor whose failure caused it to be skipped.
+func (t *ResolvedPipelineTask) skippedTask(facts *PipelineRunFacts, reason v1.SkippingReason) v1.SkippedTask {
+	skippedTask := v1.SkippedTask{
+		Name:   t.PipelineTask.Name,
+		Reason: reason,
+	}
+	switch reason {
+	case v1.WhenExpressionsSkip:
+		skippedTask.EvaluatedWhenExpressions = capEvaluatedWhenExpressions(t.PipelineTask.When.Evaluate(t.EvaluatedCEL))
+	case v1.ParentTasksSkip, v1.MissingResultsSkip, v1.StoppingSkip:
+		skippedTask.FailedAncestor = t.failedAncestor(facts)
+	}
+	return skippedTask
+}
+
+// failedAncestor returns the name of the nearest ancestor of the PipelineTask which failed,
+// looking at the PipelineTasks it runs after or whose results it references, or "" if none failed.
+func (t *ResolvedPipelineTask) failedAncestor(facts *PipelineRunFacts) string {
+	stateMap := facts.State.ToMap()
+	visited := sets.NewString()
+	queue := t.parentNames(facts)
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		if visited.Has(name) {
+			continue
+		}
+		visited.Insert(name)
+		parent, ok := stateMap[name]
+		if !ok {
+			continue
+		}
+		if parent.isFailure() {
+			return name
+		}
+		queue = append(queue, parent.parentNames(facts)...)
+	}
+	return ""
+}
+
+// parentNames returns the names of the parents of the PipelineTask in the graph of the Pipeline tasks,
+// or of the tasks a finally task references the results of.
+func (t *ResolvedPipelineTask) parentNames(facts *PipelineRunFacts) []string {
+	if node, ok := facts.TasksGraph.Nodes[t.PipelineTask.Name]; ok {
+		var names []string
+		for _, p := range node.Prev {
+			names = append(names, p.Key)
+		}
+		return names
+	}
+	return t.PipelineTask.Deps()
+}
+
+// capEvaluatedWhenExpressions truncates the long inputs, values and CEL expressions of the
+// evaluated when expressions and drops the values past maxSkippedTaskValues, so that huge
+// values, such as results, don't bloat the status of the PipelineRun.
+func capEvaluatedWhenExpressions(wes []v1.EvaluatedWhenExpression) []v1.EvaluatedWhenExpression {
+	for i := range wes {
+		wes[i].Input = truncateSkippedTaskValue(wes[i].Input)
+		wes[i].CEL = truncateSkippedTaskValue(wes[i].CEL)
+		values := wes[i].Values
+		var capped []string
+		for j, v := range values {
+			if j == maxSkippedTaskValues {
+				capped = append(capped, fmt.Sprintf("... %d more", len(values)-maxSkippedTaskValues))
+				break
+			}
+			capped = append(capped, truncateSkippedTaskValue(v))
+		}
+		wes[i].Values = capped
+	}
+	return wes
+}
+
+func truncateSkippedTaskValue(s string) string {
+	return truncate(s, maxSkippedTaskValueLength)
+}
+
+func truncate(s string, maxLength int) string {
+	if len(s) <= maxLength {
+		return s
+	}
+	n := maxLength
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n] + "..."
+}
+
+// GetPipelineRunSummary constructs the PipelineTasks related fields of the summary included in
+// the PipelineRun Status: the number of completed and total PipelineTasks and the first failed one
+func (facts *PipelineRunFacts) GetPipelineRunSummary() *v1.PipelineRunSummary {
+	s := facts.getPipelineTasksCount()
+	total := len(facts.State) + len(facts.FilteredOutTasks) + len(facts.FilteredOutFinallyTasks)
+	summary := &v1.PipelineRunSummary{
+		CompletedTasks: total - s.Incomplete,
+		TotalTasks:     total,
+	}
+	for _, t := range facts.State {
+		if facts.hasFailed(t) {
+			summary.FailedTask = t.PipelineTask.Name
+			break
+		}
+	}
+	summary.FailedTasks, _ = facts.getPipelineTaskFailures()
+	summary.QueuedTasks = facts.getPipelineTaskQueueTimes()
+	return summary
+}
+
+// getPipelineTaskQueueTimes returns the queue times of the child TaskRuns which were created,
+// ordered by name. The time the TaskRuns were queued is only known once their Pod has started.
+func (facts *PipelineRunFacts) getPipelineTaskQueueTimes() []v1.PipelineTaskQueueTime {
+	var queueTimes []v1.PipelineTaskQueueTime
+	for _, t := range facts.State {
+		for _, tr := range t.TaskRuns {
+			if tr == nil || tr.CreationTimestamp.IsZero() {
+				continue
+			}
+			q := v1.PipelineTaskQueueTime{
+				Name:             tr.Name,
+				PipelineTaskName: t.PipelineTask.Name,
+				CreationTime:     tr.CreationTimestamp.DeepCopy(),
+				PodCreationTime:  tr.Status.PodCreationTime.DeepCopy(),
+				PodStartTime:     tr.Status.PodStartTime.DeepCopy(),
+			}
+			q.QueuedDuration = tknreconciler.FormatDuration(q.CreationTime, q.PodStartTime)
+			queueTimes = append(queueTimes, q)
+		}
+	}
+	sort.Slice(queueTimes, func(i, j int) bool {
+		return queueTimes[i].Name < queueTimes[j].Name
+	})
+	return queueTimes
+}
+
+// hasFailed returns whether the PipelineTask failed, and its failure isn't ignored
+func (facts *PipelineRunFacts) hasFailed(t *ResolvedPipelineTask) bool {
+	return t.isCancelledForTimeOut() || t.isValidationFailed(facts.ValidationFailedTask) ||
+		(t.isFailure() && !t.isCancelled() && t.PipelineTask.OnError != v1.PipelineTaskContinue)
+}
+
+// getPipelineTaskFailures returns the failures of the PipelineTasks which failed, ordered by name
+// and capped at v1.MaxPipelineTaskFailures, and the number of PipelineTasks which failed
+func (facts *PipelineRunFacts) getPipelineTaskFailures() ([]v1.PipelineTaskFailure, int) {
+	var failures []v1.PipelineTaskFailure
+	for _, t := range facts.State {
+		if !facts.hasFailed(t) {
+			continue
+		}
+		failure := v1.PipelineTaskFailure{Name: t.PipelineTask.Name}
+		if t.isValidationFailed(facts.ValidationFailedTask) {
+			failure.Reason = v1.TaskRunReasonFailedValidation.String()
+			failure.Message = "the results it references are missing"
+		} else if c := t.failedCondition(); c != nil {
+			failure.Reason = c.Reason
+			message, _, _ := strings.Cut(strings.TrimSpace(c.Message), "\n")
+			failure.Message = truncate(message, maxPipelineTaskFailureMessageLength)
+		}
+		failures = append(failures, failure)
+	}
+	sort.Slice(failures, func(i, j int) bool {
+		return failures[i].Name < failures[j].Name
+	})
+	failed := len(failures)
+	if failed > v1.MaxPipelineTaskFailures {
+		failures = failures[:v1.MaxPipelineTaskFailures]
+	}
+	return failures, failed
+}
+
 // GetPipelineTaskStatus returns the status of a PipelineTask depending on its taskRun
 // the checks are implemented such that the finally tasks are requesting status of the dag tasks
 func (facts *PipelineRunFacts) GetPipelineTaskStatus() map[string]string {
@@ -645,6 +912,10 @@ func (facts *PipelineRunFacts) GetPipelineTaskStatus() map[string]string {
 			tStatus[PipelineTaskStatusPrefix+t.PipelineTask.Name+PipelineTaskReasonSuffix] = t.getReason()
 		}
 	}
+	for _, name := range facts.FilteredOutTasks {
+		tStatus[PipelineTaskStatusPrefix+name+PipelineTaskStatusSuffix] = PipelineTaskStateNone
+		tStatus[PipelineTaskStatusPrefix+name+PipelineTaskReasonSuffix] = ""
+	}
 
 	// initialize aggregate status of all dag tasks to None
 	aggregateStatus := PipelineTaskStateNone
@@ -652,6 +923,9 @@ func (facts *PipelineRunFacts) GetPipelineTaskStatus() map[string]string {
 		// all dag tasks are done, change the aggregate status to succeeded
 		// will reset it to failed/skipped if needed
 		aggregateStatus = v1.PipelineRunReasonSuccessful.String()
+		if len(facts.FilteredOutTasks) > 0 {
+			aggregateStatus = v1.PipelineRunReasonCompleted.String()
+		}
 		for _, t := range facts.State {
 			if facts.isDAGTask(t.PipelineTask.Name) {
 				// if any of the dag task failed, change the aggregate status to failed and return
@@ -693,6 +967,9 @@ func (facts *PipelineRunFacts) GetPipelineFinalTaskStatus() map[string]string {
 			tStatus[PipelineTaskStatusPrefix+t.PipelineTask.Name+PipelineTaskStatusSuffix] = s
 		}
 	}
+	for _, name := range facts.FilteredOutFinallyTasks {
+		tStatus[PipelineTaskStatusPrefix+name+PipelineTaskStatusSuffix] = PipelineTaskStateNone
+	}
 	return tStatus
 }
 
@@ -782,6 +1059,8 @@ func (facts *PipelineRunFacts) getPipelineTasksCount() pipelineRunStatusCount {
 			s.Incomplete++
 		}
 	}
+	// increment skip counter for the tasks filtered out by the tasksFilter of the PipelineRun
+	s.Skipped += len(facts.FilteredOutTasks) + len(facts.FilteredOutFinallyTasks)
 	return s
 }
 
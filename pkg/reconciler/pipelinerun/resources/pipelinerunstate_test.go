@@ -18,6 +18,7 @@ package resources
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -1746,6 +1747,7 @@ func TestGetPipelineConditionStatus(t *testing.T) {
 		expectedSkipped    int
 		expectedFailed     int
 		expectedCancelled  int
+		expectedFailures   string
 	}{{
 		name:               "no-tasks-started",
 		state:              noneStartedState,
@@ -1797,12 +1799,13 @@ func TestGetPipelineConditionStatus(t *testing.T) {
 		expectedSucceeded: 1,
 		expectedSkipped:   1,
 	}, {
-		name:            "one-task-failed",
-		state:           oneFailedState,
-		expectedStatus:  corev1.ConditionFalse,
-		expectedReason:  v1.PipelineRunReasonFailed.String(),
-		expectedFailed:  1,
-		expectedSkipped: 1,
+		name:             "one-task-failed",
+		state:            oneFailedState,
+		expectedStatus:   corev1.ConditionFalse,
+		expectedReason:   v1.PipelineRunReasonFailed.String(),
+		expectedFailed:   1,
+		expectedSkipped:  1,
+		expectedFailures: "\nFailed tasks:\n- mytask1 (Failed)",
 	}, {
 		name:              "all-finished",
 		state:             allFinishedState,
@@ -1822,11 +1825,12 @@ func TestGetPipelineConditionStatus(t *testing.T) {
 		expectedStatus:    corev1.ConditionFalse,
 		expectedCancelled: 1,
 	}, {
-		name:           "task that was cancelled for timeout",
-		state:          taskCancelledFailedTimedOut,
-		expectedReason: v1.PipelineRunReasonFailed.String(),
-		expectedStatus: corev1.ConditionFalse,
-		expectedFailed: 1,
+		name:             "task that was cancelled for timeout",
+		state:            taskCancelledFailedTimedOut,
+		expectedReason:   v1.PipelineRunReasonFailed.String(),
+		expectedStatus:   corev1.ConditionFalse,
+		expectedFailed:   1,
+		expectedFailures: "\nFailed tasks:\n- mytask5 (TaskRunCancelled)",
 	}, {
 		name:               "task with multiple failures",
 		state:              taskMultipleFailuresSkipRunning,
@@ -1855,6 +1859,7 @@ func TestGetPipelineConditionStatus(t *testing.T) {
 		expectedSucceeded: 1,
 		expectedFailed:    1,
 		expectedSkipped:   1,
+		expectedFailures:  "\nFailed tasks:\n- mytask1 (Failed)",
 	}, {
 		name:              "cancelled task should result in cancelled pipeline",
 		state:             cancelledTask,
@@ -1868,11 +1873,12 @@ func TestGetPipelineConditionStatus(t *testing.T) {
 		expectedReason:    v1.PipelineRunReasonCancelled.String(),
 		expectedCancelled: 1,
 	}, {
-		name:           "cancelled for timeout run should result in failed pipeline",
-		state:          timedOutRun,
-		expectedStatus: corev1.ConditionFalse,
-		expectedReason: v1.PipelineRunReasonFailed.String(),
-		expectedFailed: 1,
+		name:             "cancelled for timeout run should result in failed pipeline",
+		state:            timedOutRun,
+		expectedStatus:   corev1.ConditionFalse,
+		expectedReason:   v1.PipelineRunReasonFailed.String(),
+		expectedFailed:   1,
+		expectedFailures: "\nFailed tasks:\n- mytask13 (CustomRunCancelled)",
 	}, {
 		name:  "skipped for timeout run should result in failed pipeline",
 		state: notRunningRun,
@@ -1921,7 +1927,7 @@ func TestGetPipelineConditionStatus(t *testing.T) {
 				Status: tc.expectedStatus,
 				Reason: tc.expectedReason,
 				Message: getExpectedMessage(pr.Name, tc.specStatus, tc.expectedStatus, tc.expectedSucceeded,
-					tc.expectedIncomplete, tc.expectedSkipped, tc.expectedFailed, tc.expectedCancelled),
+					tc.expectedIncomplete, tc.expectedSkipped, tc.expectedFailed, tc.expectedCancelled) + tc.expectedFailures,
 			}
 			if d := cmp.Diff(wantCondition, c); d != "" {
 				t.Fatalf("Mismatch in condition %s", diff.PrintWantGot(d))
@@ -1985,6 +1991,7 @@ func TestGetPipelineConditionStatus_WithFinalTasks(t *testing.T) {
 		expectedSkipped    int
 		expectedFailed     int
 		expectedCancelled  int
+		expectedFailures   string
 	}{{
 		name:               "pipeline with one successful DAG task and failed final task",
 		state:              dagSucceededFinalFailed,
@@ -1997,6 +2004,7 @@ func TestGetPipelineConditionStatus_WithFinalTasks(t *testing.T) {
 		expectedSkipped:    0,
 		expectedFailed:     1,
 		expectedCancelled:  0,
+		expectedFailures:   "\nFailed tasks:\n- mytask2 (Failed)",
 	}, {
 		name:               "pipeline with one failed DAG task and not started final task",
 		state:              dagFailedFinalNotStarted,
@@ -2021,6 +2029,7 @@ func TestGetPipelineConditionStatus_WithFinalTasks(t *testing.T) {
 		expectedSkipped:    0,
 		expectedFailed:     2,
 		expectedCancelled:  0,
+		expectedFailures:   "\nFailed tasks:\n- mytask1 (Failed)\n- mytask2 (Failed)",
 	}, {
 		name:               "pipeline with one failed DAG task and skipped final task",
 		state:              dagFailedFinalSkipped,
@@ -2033,6 +2042,7 @@ func TestGetPipelineConditionStatus_WithFinalTasks(t *testing.T) {
 		expectedSkipped:    1,
 		expectedFailed:     1,
 		expectedCancelled:  0,
+		expectedFailures:   "\nFailed tasks:\n- mytask1 (Failed)",
 	}}
 
 	for _, tc := range tcs {
@@ -2065,7 +2075,7 @@ func TestGetPipelineConditionStatus_WithFinalTasks(t *testing.T) {
 				Status: tc.expectedStatus,
 				Reason: tc.expectedReason,
 				Message: getExpectedMessage(pr.Name, "", tc.expectedStatus, tc.expectedSucceeded,
-					tc.expectedIncomplete, tc.expectedSkipped, tc.expectedFailed, tc.expectedCancelled),
+					tc.expectedIncomplete, tc.expectedSkipped, tc.expectedFailed, tc.expectedCancelled) + tc.expectedFailures,
 			}
 			if d := cmp.Diff(wantCondition, c); d != "" {
 				t.Fatalf("Mismatch in condition %s", diff.PrintWantGot(d))
@@ -2074,6 +2084,76 @@ func TestGetPipelineConditionStatus_WithFinalTasks(t *testing.T) {
 	}
 }
 
+func TestGetPipelineConditionStatus_FailedTasks(t *testing.T) {
+	var state PipelineRunState
+	var tasks []v1.PipelineTask
+	failedTask := func(name, reason, message string) {
+		pt := v1.PipelineTask{Name: name, TaskRef: &v1.TaskRef{Name: "task"}}
+		tasks = append(tasks, pt)
+		state = append(state, &ResolvedPipelineTask{
+			TaskRunNames: []string{"pr-" + name},
+			PipelineTask: &pt,
+			TaskRuns: []*v1.TaskRun{{
+				ObjectMeta: metav1.ObjectMeta{Name: "pr-" + name},
+				Status: v1.TaskRunStatus{Status: duckv1.Status{Conditions: duckv1.Conditions{{
+					Type:    apis.ConditionSucceeded,
+					Status:  corev1.ConditionFalse,
+					Reason:  reason,
+					Message: message,
+				}}}},
+			}},
+		})
+	}
+	// The failures of concurrent tasks are listed ordered by name, with the first line of their message
+	failedTask("unit-tests", "Failed", "\"step-test\" exited with code 1\nsee the logs of the step")
+	failedTask("build", v1.TaskRunReasonTimedOut.String(), "TaskRun \"pr-build\" failed to finish within \"1h0m0s\"")
+	failedTask("lint", "Failed", strings.Repeat("x", 300))
+
+	d, err := dag.Build(v1.PipelineTaskList(tasks), v1.PipelineTaskList(tasks).Deps())
+	if err != nil {
+		t.Fatalf("Unexpected error while building graph for DAG tasks %v: %v", tasks, err)
+	}
+	facts := PipelineRunFacts{
+		State:           state,
+		TasksGraph:      d,
+		FinalTasksGraph: &dag.Graph{},
+		TimeoutsState:   PipelineRunTimeoutsState{Clock: testClock},
+	}
+	pr := &v1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "pr"}}
+
+	c := facts.GetPipelineConditionStatus(t.Context(), pr, zap.NewNop().Sugar(), testClock)
+	wantMessage := "Tasks Completed: 3 (Failed: 3, Cancelled 0), Skipped: 0\nFailed tasks:" +
+		"\n- build (TaskRunTimeout): TaskRun \"pr-build\" failed to finish within \"1h0m0s\"" +
+		"\n- lint (Failed): " + strings.Repeat("x", 256) + "..." +
+		"\n- unit-tests (Failed): \"step-test\" exited with code 1"
+	if d := cmp.Diff(wantMessage, c.Message); d != "" {
+		t.Errorf("Mismatch in condition message %s", diff.PrintWantGot(d))
+	}
+
+	// The failed tasks are capped in the summary and the message
+	for i := range v1.MaxPipelineTaskFailures {
+		failedTask(fmt.Sprintf("z-task-%02d", i), "Failed", "")
+	}
+	d, err = dag.Build(v1.PipelineTaskList(tasks), v1.PipelineTaskList(tasks).Deps())
+	if err != nil {
+		t.Fatalf("Unexpected error while building graph for DAG tasks %v: %v", tasks, err)
+	}
+	facts.State = state
+	facts.TasksGraph = d
+
+	summary := facts.GetPipelineRunSummary()
+	if len(summary.FailedTasks) != v1.MaxPipelineTaskFailures {
+		t.Fatalf("Expected %d failed tasks in the summary but got %d", v1.MaxPipelineTaskFailures, len(summary.FailedTasks))
+	}
+	if first, last := summary.FailedTasks[0].Name, summary.FailedTasks[v1.MaxPipelineTaskFailures-1].Name; first != "build" || last != "z-task-06" {
+		t.Errorf("Expected the failed tasks from %q to %q but got from %q to %q", "build", "z-task-06", first, last)
+	}
+	c = facts.GetPipelineConditionStatus(t.Context(), pr, zap.NewNop().Sugar(), testClock)
+	if !strings.HasSuffix(c.Message, "\n- z-task-06 (Failed)\n- ... and 3 more") {
+		t.Errorf("Expected the message to list the first %d failed tasks but got %q", v1.MaxPipelineTaskFailures, c.Message)
+	}
+}
+
 // pipeline should result in timeout if its runtime exceeds its spec.Timeout based on its status.Timeout
 func TestGetPipelineConditionStatus_PipelineTimeoutDeprecated(t *testing.T) {
 	d, err := dagFromState(oneFinishedState)
@@ -2686,6 +2766,23 @@ func TestPipelineRunFacts_GetPipelineFinalTaskStatus(t *testing.T) {
 }
 
 func TestPipelineRunFacts_GetSkippedTasks(t *testing.T) {
+	var hugeWhenValues []string
+	for i := range 20 {
+		hugeWhenValues = append(hugeWhenValues, fmt.Sprintf("value-%d", i))
+	}
+	hugeWhenTask := v1.PipelineTask{
+		Name:    "mytask-huge-when",
+		TaskRef: &v1.TaskRef{Name: "taskWithWhenExpressions"},
+		When: v1.WhenExpressions{{
+			Input:    strings.Repeat("a", 1000),
+			Operator: selection.In,
+			Values:   hugeWhenValues,
+		}, {
+			Input:    "foo",
+			Operator: selection.In,
+			Values:   []string{"foo"},
+		}},
+	}
 	for _, tc := range []struct {
 		name                 string
 		state                PipelineRunState
@@ -2702,8 +2799,32 @@ func TestPipelineRunFacts_GetSkippedTasks(t *testing.T) {
 		}},
 		dagTasks: []v1.PipelineTask{pts[0], pts[14]},
 		expectedSkippedTasks: []v1.SkippedTask{{
-			Name:   pts[14].Name,
-			Reason: v1.StoppingSkip,
+			Name:           pts[14].Name,
+			Reason:         v1.StoppingSkip,
+			FailedAncestor: pts[0].Name,
+		}},
+	}, {
+		name: "stopping-skip-taskruns-with-failed-grandparent",
+		state: PipelineRunState{{
+			PipelineTask: &pts[0],
+			TaskRuns:     []*v1.TaskRun{makeFailed(trs[0])},
+		}, {
+			PipelineTask: &pts[5],
+			TaskRuns:     []*v1.TaskRun{makeSucceeded(trs[1])},
+		}, {
+			PipelineTask: &pts[7],
+		}, {
+			PipelineTask: &pts[8],
+		}},
+		dagTasks: []v1.PipelineTask{pts[0], pts[5], pts[7], pts[8]},
+		expectedSkippedTasks: []v1.SkippedTask{{
+			Name:           pts[7].Name,
+			Reason:         v1.StoppingSkip,
+			FailedAncestor: pts[0].Name,
+		}, {
+			Name:           pts[8].Name,
+			Reason:         v1.StoppingSkip,
+			FailedAncestor: pts[0].Name,
 		}},
 	}, {
 		name: "missing-results-skip-finally",
@@ -2717,8 +2838,9 @@ func TestPipelineRunFacts_GetSkippedTasks(t *testing.T) {
 		dagTasks:     []v1.PipelineTask{pts[0]},
 		finallyTasks: []v1.PipelineTask{pts[14]},
 		expectedSkippedTasks: []v1.SkippedTask{{
-			Name:   pts[14].Name,
-			Reason: v1.MissingResultsSkip,
+			Name:           pts[14].Name,
+			Reason:         v1.MissingResultsSkip,
+			FailedAncestor: pts[0].Name,
 		}},
 	}, {
 		name: "when-expressions-skip-finally",
@@ -2734,6 +2856,34 @@ func TestPipelineRunFacts_GetSkippedTasks(t *testing.T) {
 				Operator: "notin",
 				Values:   []string{"foo", "bar"},
 			}},
+			EvaluatedWhenExpressions: []v1.EvaluatedWhenExpression{{
+				Input:    "foo",
+				Operator: "notin",
+				Values:   []string{"foo", "bar"},
+				Outcome:  false,
+			}},
+		}},
+	}, {
+		name: "when-expressions-skip-with-huge-values",
+		state: PipelineRunState{{
+			PipelineTask: &hugeWhenTask,
+		}},
+		dagTasks: []v1.PipelineTask{hugeWhenTask},
+		expectedSkippedTasks: []v1.SkippedTask{{
+			Name:            hugeWhenTask.Name,
+			Reason:          v1.WhenExpressionsSkip,
+			WhenExpressions: hugeWhenTask.When,
+			EvaluatedWhenExpressions: []v1.EvaluatedWhenExpression{{
+				Input:    strings.Repeat("a", 256) + "...",
+				Operator: "in",
+				Values:   append(hugeWhenValues[:16:16], "... 4 more"),
+				Outcome:  false,
+			}, {
+				Input:    "foo",
+				Operator: "in",
+				Values:   []string{"foo"},
+				Outcome:  true,
+			}},
 		}},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
@@ -2761,6 +2911,156 @@ func TestPipelineRunFacts_GetSkippedTasks(t *testing.T) {
 	}
 }
 
+var (
+	queueTimeCreated    = metav1.NewTime(now)
+	queueTimePodCreated = metav1.NewTime(now.Add(5 * time.Second))
+	queueTimePodStarted = metav1.NewTime(now.Add(90 * time.Second))
+)
+
+func withPodTimes(tr *v1.TaskRun, created metav1.Time, podCreated, podStarted *metav1.Time) *v1.TaskRun {
+	tr.CreationTimestamp = created
+	tr.Status.PodCreationTime = podCreated
+	tr.Status.PodStartTime = podStarted
+	return tr
+}
+
+func TestPipelineRunFacts_GetPipelineRunSummary(t *testing.T) {
+	ignoredFailureTask := pts[1]
+	ignoredFailureTask.OnError = v1.PipelineTaskContinue
+	for _, tc := range []struct {
+		name            string
+		state           PipelineRunState
+		dagTasks        []v1.PipelineTask
+		expectedSummary *v1.PipelineRunSummary
+	}{{
+		name: "none-started",
+		state: PipelineRunState{{
+			PipelineTask: &pts[0],
+		}, {
+			PipelineTask: &pts[1],
+		}},
+		dagTasks:        []v1.PipelineTask{pts[0], pts[1]},
+		expectedSummary: &v1.PipelineRunSummary{TotalTasks: 2},
+	}, {
+		name: "one-running",
+		state: PipelineRunState{{
+			TaskRunNames: []string{"pipelinerun-mytask1"},
+			PipelineTask: &pts[0],
+			TaskRuns:     []*v1.TaskRun{makeStarted(trs[0])},
+		}, {
+			PipelineTask: &pts[1],
+		}},
+		dagTasks:        []v1.PipelineTask{pts[0], pts[1]},
+		expectedSummary: &v1.PipelineRunSummary{TotalTasks: 2},
+	}, {
+		name: "one-succeeded-one-running",
+		state: PipelineRunState{{
+			TaskRunNames: []string{"pipelinerun-mytask1"},
+			PipelineTask: &pts[0],
+			TaskRuns:     []*v1.TaskRun{makeSucceeded(trs[0])},
+		}, {
+			TaskRunNames: []string{"pipelinerun-mytask2"},
+			PipelineTask: &pts[1],
+			TaskRuns:     []*v1.TaskRun{makeStarted(trs[1])},
+		}},
+		dagTasks:        []v1.PipelineTask{pts[0], pts[1]},
+		expectedSummary: &v1.PipelineRunSummary{CompletedTasks: 1, TotalTasks: 2},
+	}, {
+		name: "one-succeeded-one-failed",
+		state: PipelineRunState{{
+			TaskRunNames: []string{"pipelinerun-mytask1"},
+			PipelineTask: &pts[0],
+			TaskRuns:     []*v1.TaskRun{makeSucceeded(trs[0])},
+		}, {
+			TaskRunNames: []string{"pipelinerun-mytask2"},
+			PipelineTask: &pts[1],
+			TaskRuns:     []*v1.TaskRun{makeFailed(trs[1])},
+		}},
+		dagTasks: []v1.PipelineTask{pts[0], pts[1]},
+		expectedSummary: &v1.PipelineRunSummary{
+			CompletedTasks: 2,
+			TotalTasks:     2,
+			FailedTask:     "mytask2",
+			FailedTasks:    []v1.PipelineTaskFailure{{Name: "mytask2", Reason: "Failed"}},
+		},
+	}, {
+		name: "one-failed-one-skipped",
+		state: PipelineRunState{{
+			TaskRunNames: []string{"pipelinerun-mytask1"},
+			PipelineTask: &pts[0],
+			TaskRuns:     []*v1.TaskRun{makeFailed(trs[0])},
+		}, {
+			PipelineTask: &pts[14],
+		}},
+		dagTasks: []v1.PipelineTask{pts[0], pts[14]},
+		expectedSummary: &v1.PipelineRunSummary{
+			CompletedTasks: 2,
+			TotalTasks:     2,
+			FailedTask:     "mytask1",
+			FailedTasks:    []v1.PipelineTaskFailure{{Name: "mytask1", Reason: "Failed"}},
+		},
+	}, {
+		name: "ignored-failure",
+		state: PipelineRunState{{
+			TaskRunNames: []string{"pipelinerun-mytask1"},
+			PipelineTask: &pts[0],
+			TaskRuns:     []*v1.TaskRun{makeSucceeded(trs[0])},
+		}, {
+			TaskRunNames: []string{"pipelinerun-mytask2"},
+			PipelineTask: &ignoredFailureTask,
+			TaskRuns:     []*v1.TaskRun{makeFailed(trs[1])},
+		}},
+		dagTasks:        []v1.PipelineTask{pts[0], ignoredFailureTask},
+		expectedSummary: &v1.PipelineRunSummary{CompletedTasks: 2, TotalTasks: 2},
+	}, {
+		name: "queued-and-started",
+		state: PipelineRunState{{
+			TaskRunNames: []string{"pipelinerun-mytask1"},
+			PipelineTask: &pts[0],
+			TaskRuns:     []*v1.TaskRun{withPodTimes(makeStarted(trs[0]), queueTimeCreated, &queueTimePodCreated, &queueTimePodStarted)},
+		}, {
+			TaskRunNames: []string{"pipelinerun-mytask2"},
+			PipelineTask: &pts[1],
+			TaskRuns:     []*v1.TaskRun{withPodTimes(makeStarted(trs[1]), queueTimeCreated, &queueTimePodCreated, nil)},
+		}},
+		dagTasks: []v1.PipelineTask{pts[0], pts[1]},
+		expectedSummary: &v1.PipelineRunSummary{
+			TotalTasks: 2,
+			QueuedTasks: []v1.PipelineTaskQueueTime{{
+				Name:             "pipelinerun-mytask1",
+				PipelineTaskName: "mytask1",
+				CreationTime:     &queueTimeCreated,
+				PodCreationTime:  &queueTimePodCreated,
+				PodStartTime:     &queueTimePodStarted,
+				QueuedDuration:   "1m30s",
+			}, {
+				Name:             "pipelinerun-mytask2",
+				PipelineTaskName: "mytask2",
+				CreationTime:     &queueTimeCreated,
+				PodCreationTime:  &queueTimePodCreated,
+			}},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := dag.Build(v1.PipelineTaskList(tc.dagTasks), v1.PipelineTaskList(tc.dagTasks).Deps())
+			if err != nil {
+				t.Fatalf("Unexpected error while building graph for DAG tasks %v: %v", tc.dagTasks, err)
+			}
+			facts := PipelineRunFacts{
+				State:           tc.state,
+				TasksGraph:      d,
+				FinalTasksGraph: &dag.Graph{},
+				TimeoutsState: PipelineRunTimeoutsState{
+					Clock: testClock,
+				},
+			}
+			if d := cmp.Diff(tc.expectedSummary, facts.GetPipelineRunSummary()); d != "" {
+				t.Fatalf("Mismatch pipelinerun summary %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestPipelineRunFacts_IsRunning(t *testing.T) {
 	for _, tc := range []struct {
 		name     string
@@ -3646,14 +3946,6 @@ func TestPipelineRunState_GetChildReferences(t *testing.T) {
 					}},
 			}},
 			childRefs: []v1.ChildStatusReference{{
-				TypeMeta: runtime.TypeMeta{
-					APIVersion: "tekton.dev/v1",
-					Kind:       "TaskRun",
-				},
-				Name:             "single-task-run",
-				PipelineTaskName: "single-task-1",
-				DisplayName:      "Single Task 1",
-			}, {
 				TypeMeta: runtime.TypeMeta{
 					APIVersion: "tekton.dev/v1beta1",
 					Kind:       "CustomRun",
@@ -3661,6 +3953,14 @@ func TestPipelineRunState_GetChildReferences(t *testing.T) {
 				Name:             "single-custom-task-run",
 				PipelineTaskName: "single-custom-task-1",
 				DisplayName:      "Single Custom Task 1",
+			}, {
+				TypeMeta: runtime.TypeMeta{
+					APIVersion: "tekton.dev/v1",
+					Kind:       "TaskRun",
+				},
+				Name:             "single-task-run",
+				PipelineTaskName: "single-task-1",
+				DisplayName:      "Single Task 1",
 			}},
 		},
 		{
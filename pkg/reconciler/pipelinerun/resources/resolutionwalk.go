@@ -0,0 +1,254 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	rprp "github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/pipelinespec"
+	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
+	"github.com/tektoncd/pipeline/pkg/trustedresources"
+)
+
+// ResolutionChainError is returned when resolving a reference would exceed
+// the maximum resolution depth or close a cycle of references.
+type ResolutionChainError struct {
+	// Chain is the chain of the references which led to the error, from the
+	// referenced Pipeline to the offending reference.
+	Chain []string
+	// MaxDepth is the maximum resolution depth when it was exceeded, or 0
+	// for a cycle.
+	MaxDepth int
+}
+
+var _ error = &ResolutionChainError{}
+
+func (e *ResolutionChainError) Error() string {
+	chain := strings.Join(e.Chain, " -> ")
+	if e.MaxDepth > 0 {
+		return fmt.Sprintf("resolution depth limit %d exceeded: %s", e.MaxDepth, chain)
+	}
+	return "resolution cycle detected: " + chain
+}
+
+// ResolutionWalk tracks the remote references resolved to run a PipelineRun,
+// so that cycles of references and chains deeper than the maximum resolution
+// depth are rejected, and so that the identical references of its
+// PipelineTasks are only resolved once per reconcile.
+//
+// The walk keeps the chain of the references being resolved: each resolved
+// reference is pushed on the chain while the references nested in it, like
+// the PipelineRefs of the PipelineTasks of a Pipeline or the remote StepAction
+// refs of the Steps of a Task, are walked, and popped afterwards.
+//
+// The references are identified by the URI and the digest of their
+// RefSource, so the references resolved without a RefSource, like the
+// Tasks of the cluster, don't count towards the depth.
+type ResolutionWalk struct {
+	maxDepth int
+	// chain is the chain of the references being resolved, from the
+	// referenced Pipeline.
+	chain []string
+	// tasks are the results of resolving the TaskRefs of the walk, keyed by
+	// the chain they were resolved in and their serialized TaskRef.
+	tasks map[string]*resolvedTaskRef
+	// resolutions counts the references actually resolved by the walk.
+	resolutions int
+}
+
+type resolvedTaskRef struct {
+	task               *v1.Task
+	refSource          *v1.RefSource
+	verificationResult *trustedresources.VerificationResult
+	err                error
+}
+
+// NewResolutionWalk returns a ResolutionWalk of the references of the
+// Pipeline resolved from the RefSource, which is nil for a Pipeline of the
+// cluster or embedded in its PipelineRun.
+func NewResolutionWalk(maxDepth int, pipelineSource *v1.RefSource) *ResolutionWalk {
+	if maxDepth < 1 {
+		maxDepth = config.DefaultMaxResolutionDepth
+	}
+	w := &ResolutionWalk{
+		maxDepth: maxDepth,
+		tasks:    map[string]*resolvedTaskRef{},
+	}
+	if key := refSourceKey(pipelineSource); key != "" {
+		w.chain = []string{key}
+	}
+	return w
+}
+
+// Resolutions returns the number of references resolved with the walk, the
+// identical references being only resolved once.
+func (w *ResolutionWalk) Resolutions() int {
+	return w.resolutions
+}
+
+// GetTask wraps the GetTask of the TaskRef of a PipelineTask, so that the
+// TaskRef is resolved once for all the PipelineTasks and matrix combinations
+// referencing it, and is rejected when it's a remote reference deeper than
+// the maximum resolution depth, when it resolves to one of its parents, or
+// when the remote StepActions of the Task would be deeper than the maximum
+// resolution depth.
+func (w *ResolutionWalk) GetTask(taskRef *v1.TaskRef, getTask resources.GetTask) resources.GetTask {
+	return func(ctx context.Context, name string) (*v1.Task, *v1.RefSource, *trustedresources.VerificationResult, error) {
+		if taskRef == nil {
+			return getTask(ctx, name)
+		}
+		// Check the depth before resolving the reference, so that a chain
+		// too deep doesn't create any resolution request.
+		if taskRef.Resolver != "" {
+			if err := w.checkDepth(describeResolverRef(taskRef.ResolverRef)); err != nil {
+				return nil, nil, nil, err
+			}
+		}
+
+		ref, err := json.Marshal(taskRef)
+		if err != nil {
+			return getTask(ctx, name)
+		}
+		// The same reference can be a cycle in one chain and not in another.
+		key := strings.Join(w.chain, " -> ") + " -> " + string(ref)
+		r, ok := w.tasks[key]
+		if !ok {
+			r = &resolvedTaskRef{}
+			r.task, r.refSource, r.verificationResult, r.err = getTask(ctx, name)
+			w.resolutions++
+			if r.err == nil {
+				r.err = w.walk(r.refSource, func() error {
+					return w.checkStepActions(r.task)
+				})
+				if r.err != nil {
+					r.task = nil
+				}
+			}
+			w.tasks[key] = r
+		}
+		if r.err != nil {
+			return nil, nil, nil, r.err
+		}
+		// The callers set the defaults of the spec of the Task in place.
+		return r.task.DeepCopy(), r.refSource.DeepCopy(), r.verificationResult, nil
+	}
+}
+
+// WalkPipelineRef resolves the PipelineRef of a PipelineTask with getPipeline
+// and walks the PipelineTasks and finally tasks of the resolved Pipeline with
+// walkPipelineTask, while the Pipeline is on the chain. The PipelineRef is
+// rejected when it's a remote reference deeper than the maximum resolution
+// depth or when it resolves to one of its parents.
+func (w *ResolutionWalk) WalkPipelineRef(ctx context.Context, pipelineRef *v1.PipelineRef, getPipeline rprp.GetPipeline, walkPipelineTask func(context.Context, v1.PipelineTask) error) error {
+	if pipelineRef.Resolver != "" {
+		if err := w.checkDepth(describeResolverRef(pipelineRef.ResolverRef)); err != nil {
+			return err
+		}
+	}
+	p, source, _, err := getPipeline(ctx, pipelineRef.Name)
+	if err != nil {
+		return err
+	}
+	w.resolutions++
+	return w.walk(source, func() error {
+		for _, pt := range slices.Concat(p.Spec.Tasks, p.Spec.Finally) {
+			if err := walkPipelineTask(ctx, pt); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// walk pushes the reference resolved from the RefSource on the chain while
+// walking the references nested in it with walkNested, and pops it
+// afterwards. It returns a ResolutionChainError without walking the nested
+// references if the reference is already on the chain.
+func (w *ResolutionWalk) walk(source *v1.RefSource, walkNested func() error) error {
+	key := refSourceKey(source)
+	if key == "" {
+		return walkNested()
+	}
+	if slices.Contains(w.chain, key) {
+		return &ResolutionChainError{Chain: append(slices.Clone(w.chain), key)}
+	}
+	w.chain = append(w.chain, key)
+	defer func() { w.chain = w.chain[:len(w.chain)-1] }()
+	return walkNested()
+}
+
+// checkDepth returns a ResolutionChainError if resolving the described remote
+// reference from the chain would exceed the maximum resolution depth.
+func (w *ResolutionWalk) checkDepth(ref string) error {
+	if len(w.chain) < w.maxDepth {
+		return nil
+	}
+	return &ResolutionChainError{
+		Chain:    append(slices.Clone(w.chain), ref),
+		MaxDepth: w.maxDepth,
+	}
+}
+
+// checkStepActions checks the depth of the remote StepActions referenced by
+// the Steps of the Task, which are resolved later by the TaskRun.
+func (w *ResolutionWalk) checkStepActions(task *v1.Task) error {
+	for _, step := range task.Spec.Steps {
+		if step.Ref != nil && step.Ref.Resolver != "" {
+			if err := w.checkDepth(describeResolverRef(step.Ref.ResolverRef)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// refSourceKey returns the key identifying a reference resolved from the
+// RefSource by its URI and digest, or "" when it's nil.
+func refSourceKey(source *v1.RefSource) string {
+	if source == nil || source.URI == "" {
+		return ""
+	}
+	digests := make([]string, 0, len(source.Digest))
+	for algorithm, digest := range source.Digest {
+		digests = append(digests, algorithm+":"+digest)
+	}
+	slices.Sort(digests)
+	key := source.URI
+	if len(digests) > 0 {
+		key += "@" + strings.Join(digests, ",")
+	}
+	if source.EntryPoint != "" {
+		key += "#" + source.EntryPoint
+	}
+	return key
+}
+
+// describeResolverRef describes a remote reference which wasn't resolved in
+// the chain of references.
+func describeResolverRef(ref v1.ResolverRef) string {
+	params := make([]string, 0, len(ref.Params))
+	for _, p := range ref.Params {
+		params = append(params, p.Name+"="+p.Value.StringVal)
+	}
+	return fmt.Sprintf("%s(%s)", ref.Resolver, strings.Join(params, ", "))
+}
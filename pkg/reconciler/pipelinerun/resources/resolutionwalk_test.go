@@ -0,0 +1,385 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	rprp "github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/pipelinespec"
+	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
+	"github.com/tektoncd/pipeline/pkg/trustedresources"
+	"github.com/tektoncd/pipeline/test/diff"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// fakeGitResolver resolves the git TaskRefs and PipelineRefs to the Task or
+// Pipeline of their path in the repository, counting the resolutions.
+type fakeGitResolver struct {
+	// steps are the Steps of the Tasks by path, one plain Step by default.
+	steps map[string][]v1.Step
+	// pipelines are the specs of the Pipelines by path.
+	pipelines   map[string]v1.PipelineSpec
+	resolutions map[string]int
+}
+
+func (r *fakeGitResolver) resolve(path string) *v1.RefSource {
+	if r.resolutions == nil {
+		r.resolutions = map[string]int{}
+	}
+	r.resolutions[path]++
+	return &v1.RefSource{
+		URI:        "git+https://github.com/tektoncd/catalog.git",
+		Digest:     map[string]string{"sha1": "abc"},
+		EntryPoint: path,
+	}
+}
+
+func (r *fakeGitResolver) getTask(taskRef *v1.TaskRef) resources.GetTask {
+	return func(_ context.Context, _ string) (*v1.Task, *v1.RefSource, *trustedresources.VerificationResult, error) {
+		path := pathInRepo(taskRef.Params)
+		steps, ok := r.steps[path]
+		if !ok {
+			steps = []v1.Step{{Name: "step", Image: "alpine"}}
+		}
+		task := &v1.Task{
+			ObjectMeta: metav1.ObjectMeta{Name: path},
+			Spec:       v1.TaskSpec{Steps: steps},
+		}
+		return task, r.resolve(path), nil, nil
+	}
+}
+
+func (r *fakeGitResolver) getPipeline(pipelineRef *v1.PipelineRef) rprp.GetPipeline {
+	return func(_ context.Context, _ string) (*v1.Pipeline, *v1.RefSource, *trustedresources.VerificationResult, error) {
+		path := pathInRepo(pipelineRef.Params)
+		pipeline := &v1.Pipeline{
+			ObjectMeta: metav1.ObjectMeta{Name: path},
+			Spec:       r.pipelines[path],
+		}
+		return pipeline, r.resolve(path), nil, nil
+	}
+}
+
+// walkPipelineTask walks the references of a PipelineTask of a nested
+// Pipeline, as the reconciler does.
+func (r *fakeGitResolver) walkPipelineTask(walk *ResolutionWalk) func(context.Context, v1.PipelineTask) error {
+	return func(ctx context.Context, pt v1.PipelineTask) error {
+		switch {
+		case pt.TaskRef != nil:
+			_, _, _, err := walk.GetTask(pt.TaskRef, r.getTask(pt.TaskRef))(ctx, "")
+			return err
+		case pt.PipelineRef != nil:
+			return walk.WalkPipelineRef(ctx, pt.PipelineRef, r.getPipeline(pt.PipelineRef), r.walkPipelineTask(walk))
+		default:
+			return nil
+		}
+	}
+}
+
+func pathInRepo(params v1.Params) string {
+	for _, p := range params {
+		if p.Name == "pathInRepo" {
+			return p.Value.StringVal
+		}
+	}
+	return ""
+}
+
+func gitResolverRef(path string) v1.ResolverRef {
+	return v1.ResolverRef{
+		Resolver: "git",
+		Params: v1.Params{
+			{Name: "url", Value: *v1.NewStructuredValues("https://github.com/tektoncd/catalog.git")},
+			{Name: "pathInRepo", Value: *v1.NewStructuredValues(path)},
+		},
+	}
+}
+
+func gitTaskRef(path string) *v1.TaskRef {
+	return &v1.TaskRef{ResolverRef: gitResolverRef(path)}
+}
+
+func gitPipelineRef(path string) *v1.PipelineRef {
+	return &v1.PipelineRef{ResolverRef: gitResolverRef(path)}
+}
+
+var catalogPipelineSource = &v1.RefSource{
+	URI:        "git+https://github.com/tektoncd/catalog.git",
+	Digest:     map[string]string{"sha1": "abc"},
+	EntryPoint: "pipeline.yaml",
+}
+
+func TestResolutionWalk_Dedup(t *testing.T) {
+	resolver := &fakeGitResolver{}
+	walk := NewResolutionWalk(5, catalogPipelineSource)
+	pts := []v1.PipelineTask{{
+		Name:    "build",
+		TaskRef: gitTaskRef("build.yaml"),
+		Matrix: &v1.Matrix{Params: v1.Params{{
+			Name:  "platform",
+			Value: *v1.NewStructuredValues("linux", "mac", "windows"),
+		}}},
+	}, {
+		Name:    "build-docs",
+		TaskRef: gitTaskRef("build.yaml"),
+	}, {
+		Name:    "test",
+		TaskRef: gitTaskRef("test.yaml"),
+	}}
+	getTaskRun := func(name string) (*v1.TaskRun, error) {
+		return nil, kerrors.NewNotFound(v1.Resource("taskrun"), name)
+	}
+	pr := v1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun"}}
+
+	for _, pt := range pts {
+		rpt, err := ResolvePipelineTask(t.Context(), pr, walk.GetTask(pt.TaskRef, resolver.getTask(pt.TaskRef)), getTaskRun, nopGetCustomRun, pt, nil)
+		if err != nil {
+			t.Fatalf("unexpected error resolving %s: %v", pt.Name, err)
+		}
+		if rpt.ResolvedTask.TaskName != pathInRepo(pt.TaskRef.Params) {
+			t.Errorf("expected %s to resolve its own Task, got %s", pt.Name, rpt.ResolvedTask.TaskName)
+		}
+	}
+
+	want := map[string]int{"build.yaml": 1, "test.yaml": 1}
+	if d := cmp.Diff(want, resolver.resolutions); d != "" {
+		t.Errorf("unexpected resolutions: %s", diff.PrintWantGot(d))
+	}
+	if walk.Resolutions() != 2 {
+		t.Errorf("expected 2 resolutions, got %d", walk.Resolutions())
+	}
+}
+
+func TestResolutionWalk_Cycle(t *testing.T) {
+	resolver := &fakeGitResolver{}
+	walk := NewResolutionWalk(5, catalogPipelineSource)
+	// The Task resolves to the file of the Pipeline referencing it.
+	taskRef := gitTaskRef("pipeline.yaml")
+
+	_, _, _, err := walk.GetTask(taskRef, resolver.getTask(taskRef))(t.Context(), "")
+
+	var chainErr *ResolutionChainError
+	if !errors.As(err, &chainErr) {
+		t.Fatalf("expected a ResolutionChainError, got %v", err)
+	}
+	want := "resolution cycle detected: git+https://github.com/tektoncd/catalog.git@sha1:abc#pipeline.yaml -> git+https://github.com/tektoncd/catalog.git@sha1:abc#pipeline.yaml"
+	if d := cmp.Diff(want, err.Error()); d != "" {
+		t.Errorf("unexpected error: %s", diff.PrintWantGot(d))
+	}
+
+	// The cycle is reported for the other PipelineTasks with the same
+	// reference without resolving it again.
+	if _, _, _, err := walk.GetTask(taskRef, resolver.getTask(taskRef))(t.Context(), ""); !errors.As(err, &chainErr) {
+		t.Errorf("expected a ResolutionChainError, got %v", err)
+	}
+	if resolver.resolutions["pipeline.yaml"] != 1 {
+		t.Errorf("expected the reference to be resolved once, got %d", resolver.resolutions["pipeline.yaml"])
+	}
+}
+
+func TestResolutionWalk_DepthLimit(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		maxDepth       int
+		pipelineSource *v1.RefSource
+		taskRef        *v1.TaskRef
+		wantErr        string
+	}{{
+		name:           "remote Task of a remote Pipeline",
+		maxDepth:       1,
+		pipelineSource: catalogPipelineSource,
+		taskRef:        gitTaskRef("build.yaml"),
+		wantErr:        "resolution depth limit 1 exceeded: git+https://github.com/tektoncd/catalog.git@sha1:abc#pipeline.yaml -> git(url=https://github.com/tektoncd/catalog.git, pathInRepo=build.yaml)",
+	}, {
+		name:     "remote Task of a local Pipeline",
+		maxDepth: 1,
+		taskRef:  gitTaskRef("build.yaml"),
+	}, {
+		name:           "local Task of a remote Pipeline",
+		maxDepth:       1,
+		pipelineSource: catalogPipelineSource,
+		taskRef:        &v1.TaskRef{Name: "build"},
+	}, {
+		name:           "within the limit",
+		maxDepth:       2,
+		pipelineSource: catalogPipelineSource,
+		taskRef:        gitTaskRef("build.yaml"),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			resolver := &fakeGitResolver{}
+			walk := NewResolutionWalk(tc.maxDepth, tc.pipelineSource)
+
+			_, _, _, err := walk.GetTask(tc.taskRef, resolver.getTask(tc.taskRef))(t.Context(), "")
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.wantErr {
+				t.Fatalf("expected error %q, got %v", tc.wantErr, err)
+			}
+			if len(resolver.resolutions) != 0 {
+				t.Errorf("expected no resolution beyond the limit, got %v", resolver.resolutions)
+			}
+		})
+	}
+}
+
+func TestResolutionWalk_NestedCycle(t *testing.T) {
+	// pipeline.yaml -> child.yaml -> pipeline.yaml
+	resolver := &fakeGitResolver{pipelines: map[string]v1.PipelineSpec{
+		"child.yaml": {Tasks: []v1.PipelineTask{{
+			Name:    "build",
+			TaskRef: gitTaskRef("build.yaml"),
+		}, {
+			Name:        "parent",
+			PipelineRef: gitPipelineRef("pipeline.yaml"),
+		}}},
+	}}
+	walk := NewResolutionWalk(5, catalogPipelineSource)
+	pipelineRef := gitPipelineRef("child.yaml")
+
+	err := walk.WalkPipelineRef(t.Context(), pipelineRef, resolver.getPipeline(pipelineRef), resolver.walkPipelineTask(walk))
+
+	var chainErr *ResolutionChainError
+	if !errors.As(err, &chainErr) {
+		t.Fatalf("expected a ResolutionChainError, got %v", err)
+	}
+	wantChain := []string{
+		"git+https://github.com/tektoncd/catalog.git@sha1:abc#pipeline.yaml",
+		"git+https://github.com/tektoncd/catalog.git@sha1:abc#child.yaml",
+		"git+https://github.com/tektoncd/catalog.git@sha1:abc#pipeline.yaml",
+	}
+	if d := cmp.Diff(wantChain, chainErr.Chain); d != "" {
+		t.Errorf("unexpected chain: %s", diff.PrintWantGot(d))
+	}
+	if chainErr.MaxDepth != 0 {
+		t.Errorf("expected a cycle, got the depth limit %d", chainErr.MaxDepth)
+	}
+
+	// The nested Pipeline was popped from the chain, so the Tasks of the
+	// referenced Pipeline are walked from it again.
+	if _, _, _, err := walk.GetTask(gitTaskRef("child.yaml"), resolver.getTask(gitTaskRef("child.yaml")))(t.Context(), ""); err != nil {
+		t.Errorf("expected the Task to resolve once the nested Pipeline was popped, got %v", err)
+	}
+}
+
+func TestResolutionWalk_NestedDepthLimit(t *testing.T) {
+	// pipeline.yaml -> child.yaml -> grandchild.yaml -> build.yaml
+	resolver := &fakeGitResolver{pipelines: map[string]v1.PipelineSpec{
+		"child.yaml": {Tasks: []v1.PipelineTask{{
+			Name:        "grandchild",
+			PipelineRef: gitPipelineRef("grandchild.yaml"),
+		}}},
+		"grandchild.yaml": {Finally: []v1.PipelineTask{{
+			Name:    "build",
+			TaskRef: gitTaskRef("build.yaml"),
+		}}},
+	}}
+	pipelineRef := gitPipelineRef("child.yaml")
+
+	for _, tc := range []struct {
+		name            string
+		maxDepth        int
+		wantErr         string
+		wantResolutions map[string]int
+	}{{
+		name:     "within the limit",
+		maxDepth: 4,
+		wantResolutions: map[string]int{
+			"child.yaml":      1,
+			"grandchild.yaml": 1,
+			"build.yaml":      1,
+		},
+	}, {
+		name:     "Task beyond the limit",
+		maxDepth: 3,
+		wantErr:  "resolution depth limit 3 exceeded: git+https://github.com/tektoncd/catalog.git@sha1:abc#pipeline.yaml -> git+https://github.com/tektoncd/catalog.git@sha1:abc#child.yaml -> git+https://github.com/tektoncd/catalog.git@sha1:abc#grandchild.yaml -> git(url=https://github.com/tektoncd/catalog.git, pathInRepo=build.yaml)",
+		wantResolutions: map[string]int{
+			"child.yaml":      1,
+			"grandchild.yaml": 1,
+		},
+	}, {
+		name:     "Pipeline beyond the limit",
+		maxDepth: 2,
+		wantErr:  "resolution depth limit 2 exceeded: git+https://github.com/tektoncd/catalog.git@sha1:abc#pipeline.yaml -> git+https://github.com/tektoncd/catalog.git@sha1:abc#child.yaml -> git(url=https://github.com/tektoncd/catalog.git, pathInRepo=grandchild.yaml)",
+		wantResolutions: map[string]int{
+			"child.yaml": 1,
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			resolver.resolutions = nil
+			walk := NewResolutionWalk(tc.maxDepth, catalogPipelineSource)
+
+			err := walk.WalkPipelineRef(t.Context(), pipelineRef, resolver.getPipeline(pipelineRef), resolver.walkPipelineTask(walk))
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			} else if err == nil || err.Error() != tc.wantErr {
+				t.Fatalf("expected error %q, got %v", tc.wantErr, err)
+			}
+			if d := cmp.Diff(tc.wantResolutions, resolver.resolutions); d != "" {
+				t.Errorf("unexpected resolutions: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestResolutionWalk_StepActionDepthLimit(t *testing.T) {
+	resolver := &fakeGitResolver{steps: map[string][]v1.Step{
+		"build.yaml": {{
+			Name: "clone",
+			Ref:  &v1.Ref{ResolverRef: gitResolverRef("git-clone.yaml")},
+		}},
+	}}
+	walk := NewResolutionWalk(2, catalogPipelineSource)
+	taskRef := gitTaskRef("build.yaml")
+
+	_, _, _, err := walk.GetTask(taskRef, resolver.getTask(taskRef))(t.Context(), "")
+
+	want := "resolution depth limit 2 exceeded: git+https://github.com/tektoncd/catalog.git@sha1:abc#pipeline.yaml -> git+https://github.com/tektoncd/catalog.git@sha1:abc#build.yaml -> git(url=https://github.com/tektoncd/catalog.git, pathInRepo=git-clone.yaml)"
+	if err == nil || err.Error() != want {
+		t.Fatalf("expected error %q, got %v", want, err)
+	}
+}
+
+func TestResolvePipelineTask_ResolutionChainError(t *testing.T) {
+	walk := NewResolutionWalk(1, catalogPipelineSource)
+	pt := v1.PipelineTask{Name: "build", TaskRef: gitTaskRef("build.yaml")}
+	getTaskRun := func(name string) (*v1.TaskRun, error) {
+		return nil, kerrors.NewNotFound(v1.Resource("taskrun"), name)
+	}
+	pr := v1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun"}}
+
+	_, err := ResolvePipelineTask(t.Context(), pr, walk.GetTask(pt.TaskRef, (&fakeGitResolver{}).getTask(pt.TaskRef)), getTaskRun, nopGetCustomRun, pt, nil)
+
+	// The error isn't reported as a missing Task.
+	var nfErr *TaskNotFoundError
+	if errors.As(err, &nfErr) {
+		t.Errorf("expected the error not to be a TaskNotFoundError, got %v", err)
+	}
+	if !errors.As(err, new(*ResolutionChainError)) {
+		t.Errorf("expected a ResolutionChainError, got %v", err)
+	}
+}
@@ -0,0 +1,321 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/internal/computeresources/tasklevel"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipeline/dag"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// ResourceBudgetConfigMapName is the name of the optional ConfigMap holding
+// the resource budget of a namespace. Each key of the ConfigMap is the name
+// of a resource, e.g. "cpu" or "memory", and its value is the maximum amount
+// of that resource a single PipelineRun of the namespace may request.
+const ResourceBudgetConfigMapName = "tekton-resource-budget"
+
+// PipelineTaskResourceRequests returns the resource requests of the Pods
+// created for the given PipelineTask, i.e. the sum of the requests of its
+// Steps and Sidecars once the Step template, the step and sidecar specs and
+// the task-level compute resources of the PipelineRun have been applied.
+// Each combination of a matrixed PipelineTask runs in its own Pod, so the
+// requests are multiplied by the number of combinations. Custom Tasks don't
+// create Pods and don't request any resources.
+func PipelineTaskResourceRequests(rpt *ResolvedPipelineTask, pr *v1.PipelineRun) (corev1.ResourceList, error) {
+	if rpt.IsCustomTask() || rpt.ResolvedTask == nil || rpt.ResolvedTask.TaskSpec == nil {
+		return nil, nil
+	}
+	taskSpec := rpt.ResolvedTask.TaskSpec
+	taskRunSpec := pr.GetTaskRunSpec(rpt.PipelineTask.Name)
+
+	steps, err := v1.MergeStepsWithStepTemplate(taskSpec.StepTemplate, taskSpec.Steps)
+	if err != nil {
+		return nil, err
+	}
+	steps, err = v1.MergeStepsWithSpecs(steps, taskRunSpec.StepSpecs)
+	if err != nil {
+		return nil, err
+	}
+	if taskRunSpec.ComputeResources != nil {
+		tasklevel.ApplyTaskLevelComputeResources(steps, taskRunSpec.ComputeResources)
+	}
+	sidecars, err := v1.MergeSidecarsWithSpecs(taskSpec.Sidecars, taskRunSpec.SidecarSpecs)
+	if err != nil {
+		return nil, err
+	}
+
+	requests := corev1.ResourceList{}
+	for _, s := range steps {
+		addResourceList(requests, s.ComputeResources.Requests)
+	}
+	for _, s := range sidecars {
+		addResourceList(requests, s.ComputeResources.Requests)
+	}
+	if rpt.PipelineTask.IsMatrixed() {
+		combinations := int64(rpt.PipelineTask.Matrix.CountCombinations())
+		for name, q := range requests {
+			requests[name] = scaleQuantity(name, q, combinations)
+		}
+	}
+	return requests, nil
+}
+
+// PeakResourceRequests returns the highest amount of each resource the
+// PipelineRun may request at any point in time. The PipelineTasks which
+// can run concurrently are the ones which don't depend on each other, so the
+// peak of the tasks is the heaviest set of PipelineTasks of which none is an
+// ancestor of another in the DAG. Finally tasks all run concurrently once
+// the tasks are done, so the peak of the PipelineRun is the highest of the
+// peak of the tasks and the sum of the finally tasks.
+func (facts *PipelineRunFacts) PeakResourceRequests(pr *v1.PipelineRun) (corev1.ResourceList, error) {
+	requests := map[string]corev1.ResourceList{}
+	for _, rpt := range facts.State {
+		r, err := PipelineTaskResourceRequests(rpt, pr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute the resource requests of pipeline task %q: %w", rpt.PipelineTask.Name, err)
+		}
+		requests[rpt.PipelineTask.Name] = r
+	}
+
+	peak := corev1.ResourceList{}
+	if facts.TasksGraph != nil {
+		for name, q := range graphPeakResourceRequests(facts.TasksGraph, requests) {
+			peak[name] = q
+		}
+	}
+	if facts.FinalTasksGraph != nil {
+		finally := corev1.ResourceList{}
+		for task := range facts.FinalTasksGraph.Nodes {
+			addResourceList(finally, requests[task])
+		}
+		for name, q := range finally {
+			if current, ok := peak[name]; !ok || q.Cmp(current) > 0 {
+				peak[name] = q
+			}
+		}
+	}
+	if len(peak) == 0 {
+		return nil, nil
+	}
+	return peak, nil
+}
+
+// ExceededResourceBudget returns a description of the resources of which the
+// requests exceed the budget, or an empty string if all of them fit in it.
+// Resources which aren't part of the budget aren't limited.
+func ExceededResourceBudget(requests, budget corev1.ResourceList) string {
+	var exceeded []string
+	for name, limit := range budget {
+		if q, ok := requests[name]; ok && q.Cmp(limit) > 0 {
+			exceeded = append(exceeded, fmt.Sprintf("%s: requested %s, budget %s", name, q.String(), limit.String()))
+		}
+	}
+	sort.Strings(exceeded)
+	return strings.Join(exceeded, ", ")
+}
+
+// ParseResourceBudget parses the data of the resource budget ConfigMap.
+func ParseResourceBudget(data map[string]string) (corev1.ResourceList, error) {
+	budget := corev1.ResourceList{}
+	for name, value := range data {
+		q, err := resource.ParseQuantity(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid budget %q for resource %q: %w", value, name, err)
+		}
+		budget[corev1.ResourceName(name)] = q
+	}
+	return budget, nil
+}
+
+// graphPeakResourceRequests returns, for each resource, the highest sum of the
+// requests of a set of tasks of the graph which can all run at the same time.
+func graphPeakResourceRequests(g *dag.Graph, requests map[string]corev1.ResourceList) corev1.ResourceList {
+	tasks := make([]string, 0, len(g.Nodes))
+	for name := range g.Nodes {
+		tasks = append(tasks, name)
+	}
+	sort.Strings(tasks)
+	descendants := graphDescendants(g, tasks)
+
+	names := map[corev1.ResourceName]bool{}
+	for _, task := range tasks {
+		for name := range requests[task] {
+			names[name] = true
+		}
+	}
+	peak := corev1.ResourceList{}
+	for name := range names {
+		weights := make([]int64, len(tasks))
+		for i, task := range tasks {
+			if q, ok := requests[task][name]; ok {
+				weights[i] = quantityValue(name, q)
+			}
+		}
+		peak[name] = quantityFromValue(name, maxWeightAntichain(weights, descendants))
+	}
+	return peak
+}
+
+// graphDescendants returns, for each task, the indexes of the tasks which
+// transitively depend on it.
+func graphDescendants(g *dag.Graph, tasks []string) [][]int {
+	index := make(map[string]int, len(tasks))
+	for i, task := range tasks {
+		index[task] = i
+	}
+	descendants := make([][]int, len(tasks))
+	for i, task := range tasks {
+		visited := map[string]bool{}
+		queue := append([]*dag.Node{}, g.Nodes[task].Next...)
+		for len(queue) > 0 {
+			n := queue[0]
+			queue = queue[1:]
+			name := n.Key
+			if visited[name] {
+				continue
+			}
+			visited[name] = true
+			descendants[i] = append(descendants[i], index[name])
+			queue = append(queue, n.Next...)
+		}
+	}
+	return descendants
+}
+
+// maxWeightAntichain returns the highest total weight of a set of tasks of
+// which none is a descendant of another. By Dilworth's theorem, it is the
+// total weight minus the maximum flow of the network in which the source
+// feeds the "out" side of each task with its weight, the "in" side of each
+// task drains its weight into the sink, and the "out" side of each task is
+// connected to the "in" side of all of its descendants.
+func maxWeightAntichain(weights []int64, descendants [][]int) int64 {
+	n := len(weights)
+	source, sink := 2*n, 2*n+1
+	f := newFlowNetwork(2*n + 2)
+	var total int64
+	for i, w := range weights {
+		if w <= 0 {
+			continue
+		}
+		total += w
+		f.addEdge(source, i, w)
+		f.addEdge(n+i, sink, w)
+	}
+	for i, ds := range descendants {
+		for _, j := range ds {
+			f.addEdge(i, n+j, math.MaxInt64)
+		}
+	}
+	return total - f.maxFlow(source, sink)
+}
+
+type flowEdge struct {
+	to       int
+	capacity int64
+}
+
+// flowNetwork computes maximum flows with the Edmonds-Karp algorithm. The
+// networks built from Pipelines are small enough for it to be instant.
+type flowNetwork struct {
+	edges []flowEdge
+	// adjacent holds the indexes in edges of the edges leaving each vertex.
+	// The reverse of edge e is e^1.
+	adjacent [][]int
+}
+
+func newFlowNetwork(vertices int) *flowNetwork {
+	return &flowNetwork{adjacent: make([][]int, vertices)}
+}
+
+func (f *flowNetwork) addEdge(from, to int, capacity int64) {
+	f.adjacent[from] = append(f.adjacent[from], len(f.edges))
+	f.edges = append(f.edges, flowEdge{to: to, capacity: capacity})
+	f.adjacent[to] = append(f.adjacent[to], len(f.edges))
+	f.edges = append(f.edges, flowEdge{to: from})
+}
+
+func (f *flowNetwork) maxFlow(source, sink int) int64 {
+	var flow int64
+	for {
+		parent := make([]int, len(f.adjacent))
+		for i := range parent {
+			parent[i] = -1
+		}
+		queue := []int{source}
+		for len(queue) > 0 && parent[sink] == -1 {
+			v := queue[0]
+			queue = queue[1:]
+			for _, e := range f.adjacent[v] {
+				to := f.edges[e].to
+				if f.edges[e].capacity > 0 && parent[to] == -1 && to != source {
+					parent[to] = e
+					queue = append(queue, to)
+				}
+			}
+		}
+		if parent[sink] == -1 {
+			return flow
+		}
+		bottleneck := int64(math.MaxInt64)
+		for v := sink; v != source; v = f.edges[parent[v]^1].to {
+			bottleneck = min(bottleneck, f.edges[parent[v]].capacity)
+		}
+		for v := sink; v != source; v = f.edges[parent[v]^1].to {
+			f.edges[parent[v]].capacity -= bottleneck
+			f.edges[parent[v]^1].capacity += bottleneck
+		}
+		flow += bottleneck
+	}
+}
+
+func addResourceList(sum, requests corev1.ResourceList) {
+	for name, q := range requests {
+		if current, ok := sum[name]; ok {
+			current.Add(q)
+			sum[name] = current
+		} else {
+			sum[name] = q.DeepCopy()
+		}
+	}
+}
+
+func scaleQuantity(name corev1.ResourceName, q resource.Quantity, factor int64) resource.Quantity {
+	return quantityFromValue(name, quantityValue(name, q)*factor)
+}
+
+// quantityValue returns the value of the quantity as an integer, in
+// millicores for CPU so that fractions of cores aren't rounded up.
+func quantityValue(name corev1.ResourceName, q resource.Quantity) int64 {
+	if name == corev1.ResourceCPU {
+		return q.MilliValue()
+	}
+	return q.Value()
+}
+
+func quantityFromValue(name corev1.ResourceName, value int64) resource.Quantity {
+	if name == corev1.ResourceCPU {
+		return *resource.NewMilliQuantity(value, resource.DecimalSI)
+	}
+	return *resource.NewQuantity(value, resource.BinarySI)
+}
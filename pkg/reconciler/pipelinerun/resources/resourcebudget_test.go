@@ -0,0 +1,214 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipeline/dag"
+	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func requestingTask(name string, requests corev1.ResourceList, runAfter ...string) *ResolvedPipelineTask {
+	return &ResolvedPipelineTask{
+		PipelineTask: &v1.PipelineTask{Name: name, RunAfter: runAfter},
+		ResolvedTask: &resources.ResolvedTask{TaskSpec: &v1.TaskSpec{
+			Steps: []v1.Step{{Name: "step", ComputeResources: corev1.ResourceRequirements{Requests: requests}}},
+		}},
+	}
+}
+
+func cpu(q string) corev1.ResourceList {
+	return corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(q)}
+}
+
+func TestPeakResourceRequests(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		tasks      []*ResolvedPipelineTask
+		finally    []*ResolvedPipelineTask
+		pr         *v1.PipelineRun
+		wantTotals corev1.ResourceList
+	}{{
+		name: "parallel branches determine the peak",
+		tasks: []*ResolvedPipelineTask{
+			requestingTask("a", cpu("1")),
+			requestingTask("b", cpu("2"), "a"),
+			requestingTask("c", cpu("3"), "a"),
+			requestingTask("d", cpu("4"), "b", "c"),
+		},
+		wantTotals: cpu("5"),
+	}, {
+		name: "a single heavy task outweighs light parallel tasks",
+		tasks: []*ResolvedPipelineTask{
+			requestingTask("a", cpu("100m")),
+			requestingTask("b", cpu("100m")),
+			requestingTask("c", cpu("1"), "a", "b"),
+		},
+		wantTotals: cpu("1"),
+	}, {
+		name: "tasks of different levels of independent branches run concurrently",
+		tasks: []*ResolvedPipelineTask{
+			requestingTask("a1", cpu("2")),
+			requestingTask("a2", cpu("1"), "a1"),
+			requestingTask("b1", cpu("1")),
+			requestingTask("b2", cpu("2"), "b1"),
+		},
+		wantTotals: cpu("4"),
+	}, {
+		name: "finally tasks run concurrently",
+		tasks: []*ResolvedPipelineTask{
+			requestingTask("a", cpu("1")),
+		},
+		finally: []*ResolvedPipelineTask{
+			requestingTask("f1", cpu("1")),
+			requestingTask("f2", cpu("1500m")),
+		},
+		wantTotals: cpu("2500m"),
+	}, {
+		name: "step template, sidecars and step specs are merged",
+		tasks: []*ResolvedPipelineTask{{
+			PipelineTask: &v1.PipelineTask{Name: "a"},
+			ResolvedTask: &resources.ResolvedTask{TaskSpec: &v1.TaskSpec{
+				StepTemplate: &v1.StepTemplate{ComputeResources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+				}},
+				Steps: []v1.Step{{Name: "one"}, {Name: "two"}},
+				Sidecars: []v1.Sidecar{{Name: "sidecar", ComputeResources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+				}}},
+			}},
+		}},
+		pr: &v1.PipelineRun{Spec: v1.PipelineRunSpec{TaskRunSpecs: []v1.PipelineTaskRunSpec{{
+			PipelineTaskName: "a",
+			StepSpecs: []v1.TaskRunStepSpec{{Name: "two", ComputeResources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")},
+			}}},
+		}}}},
+		wantTotals: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1792Mi")},
+	}, {
+		name: "task-level compute resources are spread over the steps",
+		tasks: []*ResolvedPipelineTask{{
+			PipelineTask: &v1.PipelineTask{Name: "a"},
+			ResolvedTask: &resources.ResolvedTask{TaskSpec: &v1.TaskSpec{
+				Steps: []v1.Step{{Name: "one"}, {Name: "two"}, {Name: "three"}, {Name: "four"}},
+			}},
+		}},
+		pr: &v1.PipelineRun{Spec: v1.PipelineRunSpec{TaskRunSpecs: []v1.PipelineTaskRunSpec{{
+			PipelineTaskName: "a",
+			ComputeResources: &corev1.ResourceRequirements{Requests: cpu("2")},
+		}}}},
+		wantTotals: cpu("2"),
+	}, {
+		name: "each combination of a matrixed task runs in its own pod",
+		tasks: []*ResolvedPipelineTask{{
+			PipelineTask: &v1.PipelineTask{Name: "a", Matrix: &v1.Matrix{Params: v1.Params{{
+				Name: "platform", Value: *v1.NewStructuredValues("linux", "mac", "windows"),
+			}}}},
+			ResolvedTask: requestingTask("a", cpu("500m")).ResolvedTask,
+		}},
+		wantTotals: cpu("1500m"),
+	}, {
+		name: "custom tasks don't request resources",
+		tasks: []*ResolvedPipelineTask{
+			requestingTask("a", cpu("1")),
+			{PipelineTask: &v1.PipelineTask{Name: "b"}, CustomTask: true},
+		},
+		wantTotals: cpu("1"),
+	}, {
+		name: "no requests",
+		tasks: []*ResolvedPipelineTask{
+			requestingTask("a", nil),
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			var dagTasks, finalTasks []v1.PipelineTask
+			state := PipelineRunState{}
+			for _, rpt := range tc.tasks {
+				dagTasks = append(dagTasks, *rpt.PipelineTask)
+				state = append(state, rpt)
+			}
+			for _, rpt := range tc.finally {
+				finalTasks = append(finalTasks, *rpt.PipelineTask)
+				state = append(state, rpt)
+			}
+			d, err := dag.Build(v1.PipelineTaskList(dagTasks), v1.PipelineTaskList(dagTasks).Deps())
+			if err != nil {
+				t.Fatalf("Unexpected error while building DAG for pipelineTasks %v: %v", dagTasks, err)
+			}
+			f, err := dag.Build(v1.PipelineTaskList(finalTasks), map[string][]string{})
+			if err != nil {
+				t.Fatalf("Unexpected error while building DAG for finally tasks %v: %v", finalTasks, err)
+			}
+			facts := PipelineRunFacts{State: state, TasksGraph: d, FinalTasksGraph: f}
+			pr := tc.pr
+			if pr == nil {
+				pr = &v1.PipelineRun{}
+			}
+
+			got, err := facts.PeakResourceRequests(pr)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(got) != len(tc.wantTotals) {
+				t.Fatalf("Expected peak resource requests %v but got %v", tc.wantTotals, got)
+			}
+			for name, want := range tc.wantTotals {
+				if q, ok := got[name]; !ok || q.Cmp(want) != 0 {
+					t.Errorf("Expected peak %s requests %s but got %v", name, want.String(), got)
+				}
+			}
+		})
+	}
+}
+
+func TestExceededResourceBudget(t *testing.T) {
+	budget, err := ParseResourceBudget(map[string]string{"cpu": "4", "memory": " 8Gi "})
+	if err != nil {
+		t.Fatalf("Unexpected error parsing budget: %v", err)
+	}
+	for _, tc := range []struct {
+		name     string
+		requests corev1.ResourceList
+		want     string
+	}{{
+		name:     "within budget",
+		requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4"), corev1.ResourceMemory: resource.MustParse("1Gi")},
+	}, {
+		name:     "resources without budget aren't limited",
+		requests: corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("8")},
+	}, {
+		name:     "exceeds budget",
+		requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4500m"), corev1.ResourceMemory: resource.MustParse("16Gi")},
+		want:     "cpu: requested 4500m, budget 4, memory: requested 16Gi, budget 8Gi",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ExceededResourceBudget(tc.requests, budget); got != tc.want {
+				t.Errorf("Expected %q but got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestParseResourceBudget_Invalid(t *testing.T) {
+	if _, err := ParseResourceBudget(map[string]string{"cpu": "lots"}); err == nil {
+		t.Error("Expected an error parsing an invalid budget")
+	}
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"errors"
+	"fmt"
+
+	pipelineErrors "github.com/tektoncd/pipeline/pkg/apis/pipeline/errors"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// FilterPipelineTasks prunes the PipelineSpec to the PipelineTasks selected by the tasksFilter
+// of a PipelineRun, and to the ones they depend on if the filter includes the dependencies.
+// The runAfter of the selected PipelineTasks to the ones which are filtered out are dropped,
+// and the references to the results of the PipelineTasks which are filtered out are replaced
+// with the values of the result overrides. The results of the Pipeline which still reference
+// the PipelineTasks which are filtered out can't be produced and are dropped. It returns the
+// pruned PipelineSpec, with the names of the PipelineTasks and of the finally tasks which were
+// filtered out.
+//
+// An error is returned if an entry of the filter doesn't select any PipelineTask, if a result
+// is overridden for a PipelineTask which runs or which doesn't exist, or if a PipelineTask
+// which runs consumes a result of a PipelineTask which is filtered out and isn't overridden.
+func FilterPipelineTasks(p *v1.PipelineSpec, filter *v1.PipelineRunTasksFilter) (*v1.PipelineSpec, []string, []string, error) {
+	taskNames := sets.New[string]()
+	for _, pt := range p.Tasks {
+		taskNames.Insert(pt.Name)
+	}
+	finallyNames := sets.New[string]()
+	for _, pt := range p.Finally {
+		finallyNames.Insert(pt.Name)
+	}
+
+	selected, err := selectPipelineTasks(p.Tasks, filter.Include, taskNames, finallyNames)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if filter.WithDependencies {
+		deps := v1.PipelineTaskList(p.Tasks).Deps()
+		queue := sets.List(selected)
+		for len(queue) > 0 {
+			name := queue[0]
+			queue = queue[1:]
+			for _, dep := range deps[name] {
+				if !selected.Has(dep) {
+					selected.Insert(dep)
+					queue = append(queue, dep)
+				}
+			}
+		}
+	}
+
+	excluded := sets.New[string]()
+	for _, name := range filter.ExcludeFinally {
+		if !finallyNames.Has(name) {
+			return nil, nil, nil, pipelineErrors.WrapUserError(fmt.Errorf("excluded finally task %q is not a finally task of the Pipeline", name))
+		}
+		excluded.Insert(name)
+	}
+
+	var overrides ResolvedResultRefs
+	for _, o := range filter.ResultOverrides {
+		switch {
+		case !taskNames.Has(o.PipelineTask):
+			return nil, nil, nil, pipelineErrors.WrapUserError(fmt.Errorf("result %q is overridden for PipelineTask %q which is not a PipelineTask of the Pipeline", o.Name, o.PipelineTask))
+		case selected.Has(o.PipelineTask):
+			return nil, nil, nil, pipelineErrors.WrapUserError(fmt.Errorf("result %q is overridden for PipelineTask %q which is not filtered out", o.Name, o.PipelineTask))
+		}
+		overrides = append(overrides, &ResolvedResultRef{
+			Value:           o.Value,
+			ResultReference: v1.ResultRef{PipelineTask: o.PipelineTask, Result: o.Name},
+		})
+	}
+
+	// Replace the references to the overridden results in the whole spec, including the
+	// results of the Pipeline, before pruning it.
+	stringReplacements := overrides.getStringReplacements()
+	arrayReplacements := overrides.getArrayReplacements()
+	objectReplacements := overrides.getObjectReplacements()
+	filtered := ApplyReplacements(p, stringReplacements, arrayReplacements, objectReplacements)
+	results := []v1.PipelineResult{}
+	for _, result := range filtered.Results {
+		result.Value.ApplyReplacements(stringReplacements, arrayReplacements, objectReplacements)
+		if !referencesPipelineTasks(result, taskNames.Difference(selected)) {
+			results = append(results, result)
+		}
+	}
+
+	var filteredOutTasks []string
+	tasks := []v1.PipelineTask{}
+	for _, pt := range filtered.Tasks {
+		if !selected.Has(pt.Name) {
+			filteredOutTasks = append(filteredOutTasks, pt.Name)
+			continue
+		}
+		var runAfter []string
+		for _, name := range pt.RunAfter {
+			if selected.Has(name) {
+				runAfter = append(runAfter, name)
+			}
+		}
+		pt.RunAfter = runAfter
+		tasks = append(tasks, pt)
+	}
+	var filteredOutFinally []string
+	finally := []v1.PipelineTask{}
+	for _, pt := range filtered.Finally {
+		if excluded.Has(pt.Name) {
+			filteredOutFinally = append(filteredOutFinally, pt.Name)
+			continue
+		}
+		finally = append(finally, pt)
+	}
+
+	var errs []error
+	for _, pt := range append(tasks, finally...) {
+		for _, ref := range v1.PipelineTaskResultRefs(&pt) {
+			if taskNames.Has(ref.PipelineTask) && !selected.Has(ref.PipelineTask) {
+				errs = append(errs, fmt.Errorf("PipelineTask %q consumes the result %q of PipelineTask %q which is filtered out and whose result isn't overridden", pt.Name, ref.Result, ref.PipelineTask))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return nil, nil, nil, pipelineErrors.WrapUserError(errors.Join(errs...))
+	}
+
+	filtered.Tasks = tasks
+	filtered.Finally = finally
+	filtered.Results = results
+	return filtered, filteredOutTasks, filteredOutFinally, nil
+}
+
+// selectPipelineTasks returns the names of the PipelineTasks selected by the entries of the
+// include list of a tasksFilter, which are either names of PipelineTasks or label selectors
+// matching the labels of the metadata of their taskSpec.
+func selectPipelineTasks(tasks []v1.PipelineTask, include []string, taskNames, finallyNames sets.Set[string]) (sets.Set[string], error) {
+	selected := sets.New[string]()
+	for _, entry := range include {
+		if v1.IsPipelineTaskName(entry) {
+			switch {
+			case taskNames.Has(entry):
+				selected.Insert(entry)
+			case finallyNames.Has(entry):
+				return nil, pipelineErrors.WrapUserError(fmt.Errorf("finally task %q can't be included, the finally tasks run unless they are excluded", entry))
+			default:
+				return nil, pipelineErrors.WrapUserError(fmt.Errorf("included PipelineTask %q is not a PipelineTask of the Pipeline", entry))
+			}
+			continue
+		}
+
+		selector, err := labels.Parse(entry)
+		if err != nil {
+			return nil, pipelineErrors.WrapUserError(fmt.Errorf("invalid label selector %q: %w", entry, err))
+		}
+		matched := false
+		for _, pt := range tasks {
+			if pt.TaskSpec != nil && selector.Matches(labels.Set(pt.TaskSpec.Metadata.Labels)) {
+				selected.Insert(pt.Name)
+				matched = true
+			}
+		}
+		if !matched {
+			return nil, pipelineErrors.WrapUserError(fmt.Errorf("label selector %q doesn't match the labels of any PipelineTask of the Pipeline", entry))
+		}
+	}
+	return selected, nil
+}
+
+// referencesPipelineTasks returns true if the value of the result of a Pipeline references
+// the results of one of the PipelineTasks.
+func referencesPipelineTasks(result v1.PipelineResult, names sets.Set[string]) bool {
+	expressions, _ := result.GetVarSubstitutionExpressions()
+	for _, ref := range v1.NewResultRefs(expressions) {
+		if names.Has(ref.PipelineTask) {
+			return true
+		}
+	}
+	return false
+}
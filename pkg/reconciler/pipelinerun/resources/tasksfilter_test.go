@@ -0,0 +1,371 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipeline/dag"
+	"github.com/tektoncd/pipeline/test/diff"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+)
+
+// filterTestPipelineSpec is a Pipeline whose build and unit-tests tasks
+// consume the results of the tasks before them, and whose report finally
+// task consumes the image built by the build task:
+//
+//	fetch -> build -> unit-tests -> deploy
+//	     \-> lint -----------------/
+func filterTestPipelineSpec() *v1.PipelineSpec {
+	embedded := func(labels map[string]string) *v1.EmbeddedTask {
+		return &v1.EmbeddedTask{
+			Metadata: v1.PipelineTaskMetadata{Labels: labels},
+			TaskSpec: v1.TaskSpec{Steps: []v1.Step{{Name: "step", Image: "busybox"}}},
+		}
+	}
+	return &v1.PipelineSpec{
+		Tasks: []v1.PipelineTask{{
+			Name:     "fetch",
+			TaskSpec: embedded(map[string]string{"stage": "source"}),
+		}, {
+			Name:     "build",
+			TaskSpec: embedded(map[string]string{"stage": "build"}),
+			Params:   v1.Params{{Name: "commit", Value: *v1.NewStructuredValues("$(tasks.fetch.results.commit)")}},
+		}, {
+			Name:     "lint",
+			TaskSpec: embedded(map[string]string{"stage": "test", "tier": "lint"}),
+			RunAfter: []string{"fetch"},
+		}, {
+			Name:     "unit-tests",
+			TaskSpec: embedded(map[string]string{"stage": "test"}),
+			Params: v1.Params{
+				{Name: "image", Value: *v1.NewStructuredValues("$(tasks.build.results.image)")},
+				{Name: "tags", Value: *v1.NewStructuredValues("$(tasks.build.results.tags[*])")},
+			},
+		}, {
+			Name:     "deploy",
+			TaskRef:  &v1.TaskRef{Name: "deploy"},
+			RunAfter: []string{"unit-tests", "lint"},
+		}},
+		Finally: []v1.PipelineTask{{
+			Name:    "notify",
+			TaskRef: &v1.TaskRef{Name: "notify"},
+		}, {
+			Name:    "report",
+			TaskRef: &v1.TaskRef{Name: "report"},
+			Params:  v1.Params{{Name: "image", Value: *v1.NewStructuredValues("$(tasks.build.results.image)")}},
+		}},
+		Results: []v1.PipelineResult{{
+			Name:  "image",
+			Value: *v1.NewStructuredValues("$(tasks.build.results.image)"),
+		}, {
+			Name:  "commit",
+			Value: *v1.NewStructuredValues("$(tasks.fetch.results.commit)"),
+		}},
+	}
+}
+
+// filteredGraph returns the names of the tasks of a filtered Pipeline with
+// their runAfter, the names of its finally tasks and of its results.
+type filteredGraph struct {
+	Tasks   map[string][]string
+	Finally []string
+	Results []string
+}
+
+func graphOf(p *v1.PipelineSpec) filteredGraph {
+	g := filteredGraph{Tasks: map[string][]string{}}
+	for _, pt := range p.Tasks {
+		g.Tasks[pt.Name] = pt.RunAfter
+	}
+	for _, pt := range p.Finally {
+		g.Finally = append(g.Finally, pt.Name)
+	}
+	for _, r := range p.Results {
+		g.Results = append(g.Results, r.Name)
+	}
+	return g
+}
+
+func TestFilterPipelineTasks(t *testing.T) {
+	for _, tc := range []struct {
+		name               string
+		filter             v1.PipelineRunTasksFilter
+		want               filteredGraph
+		wantFilteredOut    []string
+		wantFilteredOutFin []string
+	}{{
+		name: "task with its dependencies",
+		filter: v1.PipelineRunTasksFilter{
+			Include:          []string{"unit-tests"},
+			WithDependencies: true,
+		},
+		want: filteredGraph{
+			Tasks:   map[string][]string{"fetch": nil, "build": nil, "unit-tests": nil},
+			Finally: []string{"notify", "report"},
+			Results: []string{"image", "commit"},
+		},
+		wantFilteredOut: []string{"lint", "deploy"},
+	}, {
+		name: "dependencies through runAfter and results",
+		filter: v1.PipelineRunTasksFilter{
+			Include:          []string{"deploy"},
+			WithDependencies: true,
+		},
+		want: filteredGraph{
+			Tasks:   map[string][]string{"fetch": nil, "build": nil, "lint": {"fetch"}, "unit-tests": nil, "deploy": {"unit-tests", "lint"}},
+			Finally: []string{"notify", "report"},
+			Results: []string{"image", "commit"},
+		},
+	}, {
+		name: "label selector without the dependencies",
+		filter: v1.PipelineRunTasksFilter{
+			Include:        []string{"tier=lint"},
+			ExcludeFinally: []string{"report"},
+		},
+		want: filteredGraph{
+			Tasks:   map[string][]string{"lint": nil},
+			Finally: []string{"notify"},
+		},
+		wantFilteredOut:    []string{"fetch", "build", "unit-tests", "deploy"},
+		wantFilteredOutFin: []string{"report"},
+	}, {
+		name: "names and label selectors",
+		filter: v1.PipelineRunTasksFilter{
+			Include: []string{"fetch", "stage in (test)"},
+			ResultOverrides: []v1.PipelineTaskResultOverride{{
+				PipelineTask: "build",
+				Name:         "image",
+				Value:        *v1.NewStructuredValues("registry.example.com/app:dev"),
+			}, {
+				PipelineTask: "build",
+				Name:         "tags",
+				Value:        *v1.NewStructuredValues("dev", "latest"),
+			}},
+		},
+		want: filteredGraph{
+			Tasks:   map[string][]string{"fetch": nil, "lint": {"fetch"}, "unit-tests": nil},
+			Finally: []string{"notify", "report"},
+			Results: []string{"image", "commit"},
+		},
+		wantFilteredOut: []string{"build", "deploy"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, filteredOut, filteredOutFinally, err := FilterPipelineTasks(filterTestPipelineSpec(), &tc.filter)
+			if err != nil {
+				t.Fatalf("FilterPipelineTasks() = %v", err)
+			}
+			if d := cmp.Diff(tc.want, graphOf(got), cmpopts.EquateEmpty()); d != "" {
+				t.Errorf("Unexpected filtered Pipeline %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tc.wantFilteredOut, filteredOut); d != "" {
+				t.Errorf("Unexpected filtered out tasks %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tc.wantFilteredOutFin, filteredOutFinally); d != "" {
+				t.Errorf("Unexpected filtered out finally tasks %s", diff.PrintWantGot(d))
+			}
+			// The filtered Pipeline must be a valid graph.
+			if _, err := dag.Build(v1.PipelineTaskList(got.Tasks), v1.PipelineTaskList(got.Tasks).Deps()); err != nil {
+				t.Errorf("Invalid graph for the filtered Pipeline: %v", err)
+			}
+		})
+	}
+}
+
+func TestFilterPipelineTasks_ResultOverrides(t *testing.T) {
+	filter := &v1.PipelineRunTasksFilter{
+		Include: []string{"unit-tests"},
+		ResultOverrides: []v1.PipelineTaskResultOverride{{
+			PipelineTask: "build",
+			Name:         "image",
+			Value:        *v1.NewStructuredValues("registry.example.com/app:dev"),
+		}, {
+			PipelineTask: "build",
+			Name:         "tags",
+			Value:        *v1.NewStructuredValues("dev", "latest"),
+		}},
+	}
+	got, filteredOut, _, err := FilterPipelineTasks(filterTestPipelineSpec(), filter)
+	if err != nil {
+		t.Fatalf("FilterPipelineTasks() = %v", err)
+	}
+	if d := cmp.Diff([]string{"fetch", "build", "lint", "deploy"}, filteredOut); d != "" {
+		t.Errorf("Unexpected filtered out tasks %s", diff.PrintWantGot(d))
+	}
+
+	wantParams := v1.Params{
+		{Name: "image", Value: *v1.NewStructuredValues("registry.example.com/app:dev")},
+		{Name: "tags", Value: *v1.NewStructuredValues("dev", "latest")},
+	}
+	if d := cmp.Diff(wantParams, got.Tasks[0].Params); d != "" {
+		t.Errorf("Unexpected params of unit-tests %s", diff.PrintWantGot(d))
+	}
+	wantFinallyParams := v1.Params{{Name: "image", Value: *v1.NewStructuredValues("registry.example.com/app:dev")}}
+	if d := cmp.Diff(wantFinallyParams, got.Finally[1].Params); d != "" {
+		t.Errorf("Unexpected params of report %s", diff.PrintWantGot(d))
+	}
+	// The result of the Pipeline which references fetch can't be produced.
+	wantResults := []v1.PipelineResult{{
+		Name:  "image",
+		Value: *v1.NewStructuredValues("registry.example.com/app:dev"),
+	}}
+	if d := cmp.Diff(wantResults, got.Results); d != "" {
+		t.Errorf("Unexpected results of the Pipeline %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestFilterPipelineTasks_Invalid(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		filter  v1.PipelineRunTasksFilter
+		wantErr string
+	}{{
+		name:    "unknown task",
+		filter:  v1.PipelineRunTasksFilter{Include: []string{"integration-tests"}},
+		wantErr: `included PipelineTask "integration-tests" is not a PipelineTask of the Pipeline`,
+	}, {
+		name:    "finally task",
+		filter:  v1.PipelineRunTasksFilter{Include: []string{"notify"}},
+		wantErr: `finally task "notify" can't be included`,
+	}, {
+		name:    "label selector matching no task",
+		filter:  v1.PipelineRunTasksFilter{Include: []string{"stage=release"}},
+		wantErr: `label selector "stage=release" doesn't match the labels of any PipelineTask of the Pipeline`,
+	}, {
+		name:    "unknown finally task excluded",
+		filter:  v1.PipelineRunTasksFilter{Include: []string{"fetch"}, ExcludeFinally: []string{"cleanup"}},
+		wantErr: `excluded finally task "cleanup" is not a finally task of the Pipeline`,
+	}, {
+		name:    "result consumed from a filtered out task",
+		filter:  v1.PipelineRunTasksFilter{Include: []string{"build"}},
+		wantErr: `PipelineTask "build" consumes the result "commit" of PipelineTask "fetch" which is filtered out`,
+	}, {
+		name:    "result consumed by a finally task",
+		filter:  v1.PipelineRunTasksFilter{Include: []string{"lint"}, WithDependencies: true},
+		wantErr: `PipelineTask "report" consumes the result "image" of PipelineTask "build" which is filtered out`,
+	}, {
+		name: "result overridden for a task which runs",
+		filter: v1.PipelineRunTasksFilter{
+			Include:          []string{"unit-tests"},
+			WithDependencies: true,
+			ResultOverrides: []v1.PipelineTaskResultOverride{{
+				PipelineTask: "build",
+				Name:         "image",
+				Value:        *v1.NewStructuredValues("registry.example.com/app:dev"),
+			}},
+		},
+		wantErr: `result "image" is overridden for PipelineTask "build" which is not filtered out`,
+	}, {
+		name: "result overridden for an unknown task",
+		filter: v1.PipelineRunTasksFilter{
+			Include: []string{"fetch"},
+			ResultOverrides: []v1.PipelineTaskResultOverride{{
+				PipelineTask: "package",
+				Name:         "image",
+				Value:        *v1.NewStructuredValues("registry.example.com/app:dev"),
+			}},
+		},
+		wantErr: `result "image" is overridden for PipelineTask "package" which is not a PipelineTask of the Pipeline`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, _, err := FilterPipelineTasks(filterTestPipelineSpec(), &tc.filter)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("Expected error containing %q but got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestPipelineRunFacts_FilteredOutTasks(t *testing.T) {
+	tasks := []v1.PipelineTask{{Name: "build", TaskRef: &v1.TaskRef{Name: "build"}}}
+	finally := []v1.PipelineTask{{Name: "notify", TaskRef: &v1.TaskRef{Name: "notify"}}}
+	d, err := dag.Build(v1.PipelineTaskList(tasks), v1.PipelineTaskList(tasks).Deps())
+	if err != nil {
+		t.Fatalf("Unexpected error while building graph for DAG tasks %v: %v", tasks, err)
+	}
+	df, err := dag.Build(v1.PipelineTaskList(finally), map[string][]string{})
+	if err != nil {
+		t.Fatalf("Unexpected error while building graph for final tasks %v: %v", finally, err)
+	}
+	facts := PipelineRunFacts{
+		State: PipelineRunState{{
+			PipelineTask: &tasks[0],
+			TaskRunNames: []string{"pr-build"},
+			TaskRuns:     []*v1.TaskRun{makeSucceeded(trs[0])},
+		}, {
+			PipelineTask: &finally[0],
+			TaskRunNames: []string{"pr-notify"},
+			TaskRuns:     []*v1.TaskRun{makeSucceeded(trs[1])},
+		}},
+		TasksGraph:              d,
+		FinalTasksGraph:         df,
+		TimeoutsState:           PipelineRunTimeoutsState{Clock: testClock},
+		FilteredOutTasks:        []string{"lint", "deploy"},
+		FilteredOutFinallyTasks: []string{"cleanup"},
+	}
+
+	wantSkipped := []v1.SkippedTask{
+		{Name: "lint", Reason: v1.FilteredOutSkip},
+		{Name: "deploy", Reason: v1.FilteredOutSkip},
+		{Name: "cleanup", Reason: v1.FilteredOutSkip},
+	}
+	if d := cmp.Diff(wantSkipped, facts.GetSkippedTasks()); d != "" {
+		t.Errorf("Mismatch skipped tasks %s", diff.PrintWantGot(d))
+	}
+
+	wantStatus := map[string]string{
+		PipelineTaskStatusPrefix + "build" + PipelineTaskStatusSuffix:  v1.TaskRunReasonSuccessful.String(),
+		PipelineTaskStatusPrefix + "build" + PipelineTaskReasonSuffix:  v1.TaskRunReasonSuccessful.String(),
+		PipelineTaskStatusPrefix + "lint" + PipelineTaskStatusSuffix:   PipelineTaskStateNone,
+		PipelineTaskStatusPrefix + "lint" + PipelineTaskReasonSuffix:   "",
+		PipelineTaskStatusPrefix + "deploy" + PipelineTaskStatusSuffix: PipelineTaskStateNone,
+		PipelineTaskStatusPrefix + "deploy" + PipelineTaskReasonSuffix: "",
+		v1.PipelineTasksAggregateStatus:                                v1.PipelineRunReasonCompleted.String(),
+	}
+	if d := cmp.Diff(wantStatus, facts.GetPipelineTaskStatus()); d != "" {
+		t.Errorf("Unexpected status of the tasks %s", diff.PrintWantGot(d))
+	}
+	wantFinalStatus := map[string]string{
+		PipelineTaskStatusPrefix + "notify" + PipelineTaskStatusSuffix:  v1.TaskRunReasonSuccessful.String(),
+		PipelineTaskStatusPrefix + "cleanup" + PipelineTaskStatusSuffix: PipelineTaskStateNone,
+	}
+	if d := cmp.Diff(wantFinalStatus, facts.GetPipelineFinalTaskStatus()); d != "" {
+		t.Errorf("Unexpected status of the finally tasks %s", diff.PrintWantGot(d))
+	}
+
+	summary := facts.GetPipelineRunSummary()
+	if summary.TotalTasks != 5 || summary.CompletedTasks != 5 {
+		t.Errorf("Expected 5 completed tasks out of 5 but got %d out of %d", summary.CompletedTasks, summary.TotalTasks)
+	}
+
+	c := facts.GetPipelineConditionStatus(t.Context(), &v1.PipelineRun{}, zap.NewNop().Sugar(), testClock)
+	wantCondition := &apis.Condition{
+		Type:    apis.ConditionSucceeded,
+		Status:  corev1.ConditionTrue,
+		Reason:  v1.PipelineRunReasonCompleted.String(),
+		Message: "Tasks Completed: 2 (Failed: 0, Cancelled 0), Skipped: 3",
+	}
+	if d := cmp.Diff(wantCondition, c); d != "" {
+		t.Errorf("Unexpected condition %s", diff.PrintWantGot(d))
+	}
+}
@@ -112,3 +112,15 @@ func ValidateOptionalWorkspaces(pipelineWorkspaces []v1.PipelineWorkspaceDeclara
 	}
 	return nil
 }
+
+// ValidateWorkspaceSubPaths validates that the subPaths of the workspaces bound to the
+// PipelineTask don't escape the workspaces of the Pipeline once the results of the other
+// PipelineTasks they reference are substituted.
+func ValidateWorkspaceSubPaths(rpt *ResolvedPipelineTask) error {
+	for _, ws := range rpt.PipelineTask.Workspaces {
+		if err := ws.ValidateSubPath(); err != nil {
+			return pipelineErrors.WrapUserError(fmt.Errorf("invalid subPath of the workspace %q in pipeline task %q: %w", ws.Name, rpt.PipelineTask.Name, err))
+		}
+	}
+	return nil
+}
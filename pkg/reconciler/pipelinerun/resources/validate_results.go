@@ -0,0 +1,75 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"errors"
+	"strings"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	trresources "github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
+)
+
+// ValidateObjectPipelineResults validates the object results of the PipelineRun which propagate a whole object
+// result of a PipelineTask, e.g. $(tasks.task.results.object[*]), against the properties declared by its Task.
+func ValidateObjectPipelineResults(results []v1.PipelineResult, runResults []v1.PipelineRunResult, state PipelineRunState) error {
+	values := make(map[string]v1.ResultValue, len(runResults))
+	for _, r := range runResults {
+		values[r.Name] = r.Value
+	}
+	tasks := state.ToMap()
+
+	var mismatches []string
+	for _, result := range results {
+		value, ok := values[result.Name]
+		if result.Type != v1.ResultsTypeObject || !ok {
+			continue
+		}
+		properties := propagatedObjectResultProperties(result, tasks)
+		if err := trresources.ValidateObjectResultSchema(result.Name, properties, value); err != nil {
+			mismatches = append(mismatches, err.Error())
+		}
+	}
+	if len(mismatches) > 0 {
+		return errors.New(strings.Join(mismatches, ", "))
+	}
+	return nil
+}
+
+// propagatedObjectResultProperties returns the properties of the Task result propagated by the object pipeline
+// result, if its value is a reference to a whole object result of a PipelineTask.
+func propagatedObjectResultProperties(result v1.PipelineResult, tasks map[string]*ResolvedPipelineTask) map[string]v1.PropertySpec {
+	expressions, _ := result.GetVarSubstitutionExpressions()
+	if len(expressions) != 1 || result.Value.StringVal != "$("+expressions[0]+")" {
+		return nil
+	}
+	parts := strings.Split(expressions[0], ".")
+	if len(parts) != resultsParseNumber || (parts[0] != v1.ResultTaskPart && parts[0] != v1.ResultFinallyPart) || parts[2] != v1.ResultResultPart {
+		return nil
+	}
+	resultName, idx := v1.ParseResultName(parts[3])
+	rpt, ok := tasks[parts[1]]
+	if idx != "*" || !ok || rpt.ResolvedTask == nil || rpt.ResolvedTask.TaskSpec == nil {
+		return nil
+	}
+	for _, r := range rpt.ResolvedTask.TaskSpec.Results {
+		if r.Name == resultName && r.Type == v1.ResultsTypeObject {
+			return r.Properties
+		}
+	}
+	return nil
+}
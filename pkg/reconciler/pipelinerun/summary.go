@@ -0,0 +1,41 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	tknreconciler "github.com/tektoncd/pipeline/pkg/reconciler"
+)
+
+// updateSummary refreshes the fields of the PipelineRun summary which do not
+// depend on the state of the PipelineTasks. The task counts are set by reconcile
+// from the PipelineRunFacts, and are kept as they are here.
+func updateSummary(pr *v1.PipelineRun) {
+	if pr.Status.StartTime == nil {
+		pr.Status.Summary = nil
+		return
+	}
+	if pr.Status.Summary == nil {
+		pr.Status.Summary = &v1.PipelineRunSummary{}
+	}
+
+	pr.Status.Summary.Duration = tknreconciler.FormatDuration(pr.Status.StartTime, pr.Status.CompletionTime)
+	pr.Status.Summary.Resolver = ""
+	if pr.Spec.PipelineRef != nil {
+		pr.Status.Summary.Resolver = string(pr.Spec.PipelineRef.Resolver)
+	}
+}
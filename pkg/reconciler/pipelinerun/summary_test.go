@@ -0,0 +1,78 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestUpdateSummary(t *testing.T) {
+	startTime := &metav1.Time{Time: now.Add(-5 * time.Minute)}
+	completionTime := &metav1.Time{Time: now.Add(-time.Minute)}
+
+	for _, tc := range []struct {
+		name string
+		pr   *v1.PipelineRun
+		want *v1.PipelineRunSummary
+	}{{
+		name: "not started",
+		pr:   &v1.PipelineRun{},
+		want: nil,
+	}, {
+		name: "running, task counts are kept",
+		pr: &v1.PipelineRun{
+			Spec: v1.PipelineRunSpec{PipelineRef: &v1.PipelineRef{ResolverRef: v1.ResolverRef{Resolver: "bundles"}}},
+			Status: v1.PipelineRunStatus{PipelineRunStatusFields: v1.PipelineRunStatusFields{
+				StartTime: startTime,
+				Summary:   &v1.PipelineRunSummary{CompletedTasks: 1, TotalTasks: 3},
+			}},
+		},
+		want: &v1.PipelineRunSummary{CompletedTasks: 1, TotalTasks: 3, Resolver: "bundles"},
+	}, {
+		name: "completed with a failed task",
+		pr: &v1.PipelineRun{
+			Spec: v1.PipelineRunSpec{PipelineRef: &v1.PipelineRef{Name: "test-pipeline"}},
+			Status: v1.PipelineRunStatus{PipelineRunStatusFields: v1.PipelineRunStatusFields{
+				StartTime:      startTime,
+				CompletionTime: completionTime,
+				Summary:        &v1.PipelineRunSummary{CompletedTasks: 3, TotalTasks: 3, FailedTask: "build"},
+			}},
+		},
+		want: &v1.PipelineRunSummary{Duration: "4m0s", CompletedTasks: 3, TotalTasks: 3, FailedTask: "build"},
+	}, {
+		name: "started before the first reconcile of the tasks",
+		pr: &v1.PipelineRun{
+			Status: v1.PipelineRunStatus{PipelineRunStatusFields: v1.PipelineRunStatusFields{
+				StartTime: startTime,
+			}},
+		},
+		want: &v1.PipelineRunSummary{},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			updateSummary(tc.pr)
+			if d := cmp.Diff(tc.want, tc.pr.Status.Summary); d != "" {
+				t.Errorf("Unexpected summary %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -0,0 +1,270 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/utils/clock"
+	"knative.dev/pkg/metrics"
+)
+
+const (
+	// breakerFailureThreshold is the number of consecutive creations
+	// throttled by the API server which open the CreationBreaker.
+	breakerFailureThreshold = 5
+	// breakerBaseCoolDown is the time the creations are paused for the first
+	// time the CreationBreaker opens. It doubles every time the creations are
+	// throttled again while resuming, up to breakerMaxCoolDown.
+	breakerBaseCoolDown = 10 * time.Second
+	breakerMaxCoolDown  = 5 * time.Minute
+	// breakerMaxAllowance is the number of creations admitted at once while
+	// resuming above which the CreationBreaker closes. The allowance starts at
+	// one creation and doubles every time all the admitted creations succeed.
+	breakerMaxAllowance = 16
+	// breakerHalfOpenRetry is the delay before retrying a creation which
+	// isn't admitted while resuming.
+	breakerHalfOpenRetry = time.Second
+	// throttledRetry is the delay before retrying a creation throttled by
+	// the API server if it doesn't suggest one.
+	throttledRetry = time.Second
+)
+
+// BreakerState is the state of a CreationBreaker.
+type BreakerState int
+
+const (
+	// BreakerClosed lets all the creations through.
+	BreakerClosed BreakerState = iota
+	// BreakerHalfOpen resumes the creations gradually after a cool down.
+	BreakerHalfOpen
+	// BreakerOpen pauses the creations until the end of the cool down.
+	BreakerOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerHalfOpen:
+		return "half-open"
+	case BreakerOpen:
+		return "open"
+	}
+	return fmt.Sprintf("BreakerState(%d)", int(s))
+}
+
+var (
+	breakerStateMeasure = stats.Float64("creation_breaker_state",
+		"The state of the circuit breaker of the pod and TaskRun creations: 0 closed, 1 half-open, 2 open",
+		stats.UnitDimensionless)
+	breakerOpenedMeasure = stats.Float64("creation_breaker_opened_total",
+		"Number of times the circuit breaker of the pod and TaskRun creations paused them",
+		stats.UnitDimensionless)
+
+	registerBreakerViews sync.Once
+)
+
+// BreakerOpenError is returned instead of creating a resource while the
+// CreationBreaker pauses the creations.
+type BreakerOpenError struct {
+	// RetryAfter is the delay before the creation can be retried.
+	RetryAfter time.Duration
+}
+
+func (e *BreakerOpenError) Error() string {
+	return fmt.Sprintf("creations are paused for %s after being throttled by the API server", e.RetryAfter)
+}
+
+// IsThrottlingError returns true if the API server rejected a request because
+// it is overloaded, with a 429 or a 5xx status.
+func IsThrottlingError(err error) bool {
+	return k8serrors.IsTooManyRequests(err) ||
+		k8serrors.IsServerTimeout(err) ||
+		k8serrors.IsTimeout(err) ||
+		k8serrors.IsInternalError(err) ||
+		k8serrors.IsServiceUnavailable(err) ||
+		k8serrors.IsUnexpectedServerError(err)
+}
+
+// CreationRetryAfter returns the delay before retrying a creation which
+// failed because the CreationBreaker paused it or the API server throttled
+// it, and false if it failed for another reason. Such a creation must be
+// retried rather than failing the run.
+func CreationRetryAfter(err error) (time.Duration, bool) {
+	var openErr *BreakerOpenError
+	if errors.As(err, &openErr) {
+		return openErr.RetryAfter, true
+	}
+	if !IsThrottlingError(err) {
+		return 0, false
+	}
+	if seconds, ok := k8serrors.SuggestsClientDelay(err); ok && seconds > 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	return throttledRetry, true
+}
+
+// CreationBreaker is a circuit breaker pausing the creations of the pods and
+// TaskRuns by the controllers while the API server throttles them, so that
+// their retries don't make its overload worse. It opens after a number of
+// consecutive throttled creations and pauses them for a cool down, then
+// resumes them gradually: it admits one creation, then twice as many every
+// time they all succeed, until it closes. A throttled creation while resuming
+// opens it again for twice the cool down.
+//
+// A nil CreationBreaker lets all the creations through.
+type CreationBreaker struct {
+	mu    sync.Mutex
+	clock clock.PassiveClock
+
+	state    BreakerState
+	failures int
+	coolDown time.Duration
+	openedAt time.Time
+
+	allowance int
+	admitted  int
+	succeeded int
+}
+
+// NewCreationBreaker returns a closed CreationBreaker.
+func NewCreationBreaker(c clock.PassiveClock) *CreationBreaker {
+	registerBreakerViews.Do(func() {
+		_ = view.Register(&view.View{
+			Description: breakerStateMeasure.Description(),
+			Measure:     breakerStateMeasure,
+			Aggregation: view.LastValue(),
+		}, &view.View{
+			Description: breakerOpenedMeasure.Description(),
+			Measure:     breakerOpenedMeasure,
+			Aggregation: view.Count(),
+		})
+	})
+	return &CreationBreaker{clock: c}
+}
+
+// State returns the state of the CreationBreaker.
+func (b *CreationBreaker) State() BreakerState {
+	if b == nil {
+		return BreakerClosed
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// Allow returns nil if a resource can be created, in which case the result
+// of the creation must be passed to Record, or a *BreakerOpenError with the
+// delay before retrying otherwise.
+func (b *CreationBreaker) Allow(ctx context.Context) error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case BreakerOpen:
+		if remaining := b.openedAt.Add(b.coolDown).Sub(b.clock.Now()); remaining > 0 {
+			return &BreakerOpenError{RetryAfter: remaining}
+		}
+		b.setState(ctx, BreakerHalfOpen)
+		b.allowance, b.admitted, b.succeeded = 1, 0, 0
+		fallthrough
+	case BreakerHalfOpen:
+		if b.admitted >= b.allowance {
+			return &BreakerOpenError{RetryAfter: breakerHalfOpenRetry}
+		}
+		b.admitted++
+	}
+	return nil
+}
+
+// Record records the result of a creation admitted by Allow. Only the
+// errors of an overloaded API server count as failures.
+func (b *CreationBreaker) Record(ctx context.Context, err error) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	throttled := IsThrottlingError(err)
+	switch b.state {
+	case BreakerClosed:
+		if !throttled {
+			b.failures = 0
+			return
+		}
+		b.failures++
+		if b.failures >= breakerFailureThreshold {
+			b.open(ctx, breakerBaseCoolDown)
+		}
+	case BreakerHalfOpen:
+		if throttled {
+			b.open(ctx, min(2*b.coolDown, breakerMaxCoolDown))
+			return
+		}
+		b.succeeded++
+		if b.succeeded < b.allowance {
+			return
+		}
+		b.allowance *= 2
+		b.admitted, b.succeeded = 0, 0
+		if b.allowance > breakerMaxAllowance {
+			b.failures = 0
+			b.coolDown = 0
+			b.setState(ctx, BreakerClosed)
+		}
+	case BreakerOpen:
+		// The creations admitted before the CreationBreaker opened don't
+		// change the cool down.
+	}
+}
+
+func (b *CreationBreaker) open(ctx context.Context, coolDown time.Duration) {
+	b.coolDown = coolDown
+	b.openedAt = b.clock.Now()
+	b.setState(ctx, BreakerOpen)
+	metrics.Record(ctx, breakerOpenedMeasure.M(1))
+}
+
+func (b *CreationBreaker) setState(ctx context.Context, state BreakerState) {
+	b.state = state
+	metrics.Record(ctx, breakerStateMeasure.M(float64(state)))
+}
+
+type creationBreakerKey struct{}
+
+// WithCreationBreaker attaches the CreationBreaker shared by the controllers
+// to the context.
+func WithCreationBreaker(ctx context.Context, b *CreationBreaker) context.Context {
+	return context.WithValue(ctx, creationBreakerKey{}, b)
+}
+
+// GetCreationBreaker returns the CreationBreaker attached to the context, or
+// nil if there isn't any.
+func GetCreationBreaker(ctx context.Context) *CreationBreaker {
+	b, _ := ctx.Value(creationBreakerKey{}).(*CreationBreaker)
+	return b
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/reconciler/ratelimit"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+var throttled = k8serrors.NewTooManyRequests("the server is overloaded", 0)
+
+// openBreaker returns a CreationBreaker opened by consecutive throttled
+// creations.
+func openBreaker(t *testing.T, clock *clocktesting.FakePassiveClock) *ratelimit.CreationBreaker {
+	t.Helper()
+	b := ratelimit.NewCreationBreaker(clock)
+	for range 5 {
+		if err := b.Allow(t.Context()); err != nil {
+			t.Fatalf("expected the closed breaker to allow the creation, got %v", err)
+		}
+		b.Record(t.Context(), throttled)
+	}
+	if b.State() != ratelimit.BreakerOpen {
+		t.Fatalf("expected the breaker to be open, got %s", b.State())
+	}
+	return b
+}
+
+func wantRetryAfter(t *testing.T, err error, want time.Duration) {
+	t.Helper()
+	var openErr *ratelimit.BreakerOpenError
+	if !errors.As(err, &openErr) {
+		t.Fatalf("expected the creation to be paused, got %v", err)
+	}
+	if openErr.RetryAfter != want {
+		t.Errorf("expected to retry the creation after %s, got %s", want, openErr.RetryAfter)
+	}
+}
+
+func TestCreationBreakerOpensAfterConsecutiveThrottling(t *testing.T) {
+	clock := clocktesting.NewFakePassiveClock(time.Now())
+	b := ratelimit.NewCreationBreaker(clock)
+	for _, err := range []error{throttled, throttled, throttled, throttled, nil, throttled, throttled, throttled, throttled} {
+		if err := b.Allow(t.Context()); err != nil {
+			t.Fatalf("expected the closed breaker to allow the creation, got %v", err)
+		}
+		b.Record(t.Context(), err)
+	}
+	if b.State() != ratelimit.BreakerClosed {
+		t.Fatalf("expected a success to reset the throttled creations, got a %s breaker", b.State())
+	}
+
+	b.Record(t.Context(), k8serrors.NewServiceUnavailable("etcd is slow"))
+	if b.State() != ratelimit.BreakerOpen {
+		t.Fatalf("expected the breaker to open, got %s", b.State())
+	}
+	wantRetryAfter(t, b.Allow(t.Context()), 10*time.Second)
+	clock.SetTime(clock.Now().Add(4 * time.Second))
+	wantRetryAfter(t, b.Allow(t.Context()), 6*time.Second)
+}
+
+func TestCreationBreakerIgnoresOtherErrors(t *testing.T) {
+	b := ratelimit.NewCreationBreaker(clocktesting.NewFakePassiveClock(time.Now()))
+	for range 10 {
+		b.Record(t.Context(), k8serrors.NewBadRequest("invalid pod"))
+		b.Record(t.Context(), k8serrors.NewAlreadyExists(schema.GroupResource{Resource: "pods"}, "pod"))
+	}
+	if b.State() != ratelimit.BreakerClosed {
+		t.Errorf("expected the errors of the requests to keep the breaker closed, got %s", b.State())
+	}
+}
+
+func TestCreationBreakerResumesGradually(t *testing.T) {
+	clock := clocktesting.NewFakePassiveClock(time.Now())
+	b := openBreaker(t, clock)
+	clock.SetTime(clock.Now().Add(10 * time.Second))
+
+	for _, allowance := range []int{1, 2, 4, 8, 16} {
+		for range allowance {
+			if err := b.Allow(t.Context()); err != nil {
+				t.Fatalf("expected the breaker to admit %d creations, got %v", allowance, err)
+			}
+		}
+		if b.State() != ratelimit.BreakerHalfOpen {
+			t.Fatalf("expected the breaker to be half-open, got %s", b.State())
+		}
+		wantRetryAfter(t, b.Allow(t.Context()), time.Second)
+		for range allowance {
+			b.Record(t.Context(), nil)
+		}
+	}
+	if b.State() != ratelimit.BreakerClosed {
+		t.Errorf("expected the breaker to close after resuming, got %s", b.State())
+	}
+}
+
+func TestCreationBreakerReopensWithLongerCoolDown(t *testing.T) {
+	clock := clocktesting.NewFakePassiveClock(time.Now())
+	b := openBreaker(t, clock)
+	coolDown := 10 * time.Second
+	for _, want := range []time.Duration{20 * time.Second, 40 * time.Second, 80 * time.Second, 160 * time.Second, 5 * time.Minute, 5 * time.Minute} {
+		clock.SetTime(clock.Now().Add(coolDown))
+		if err := b.Allow(t.Context()); err != nil {
+			t.Fatalf("expected the breaker to admit a creation after the cool down, got %v", err)
+		}
+		b.Record(t.Context(), throttled)
+		if b.State() != ratelimit.BreakerOpen {
+			t.Fatalf("expected the breaker to open again, got %s", b.State())
+		}
+		wantRetryAfter(t, b.Allow(t.Context()), want)
+		coolDown = want
+	}
+}
+
+func TestNilCreationBreaker(t *testing.T) {
+	var b *ratelimit.CreationBreaker
+	for range 10 {
+		if err := b.Allow(t.Context()); err != nil {
+			t.Fatalf("expected a nil breaker to allow the creations, got %v", err)
+		}
+		b.Record(t.Context(), throttled)
+	}
+	if b.State() != ratelimit.BreakerClosed {
+		t.Errorf("expected a nil breaker to be closed, got %s", b.State())
+	}
+}
+
+func TestCreationBreakerContext(t *testing.T) {
+	if b := ratelimit.GetCreationBreaker(t.Context()); b != nil {
+		t.Errorf("expected no breaker, got %v", b)
+	}
+	b := ratelimit.NewCreationBreaker(clocktesting.NewFakePassiveClock(time.Now()))
+	if got := ratelimit.GetCreationBreaker(ratelimit.WithCreationBreaker(t.Context(), b)); got != b {
+		t.Errorf("expected the breaker attached to the context, got %v", got)
+	}
+}
+
+func TestCreationRetryAfter(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		err       error
+		want      time.Duration
+		wantRetry bool
+	}{{
+		name:      "paused by the breaker",
+		err:       fmt.Errorf("creating the pod: %w", &ratelimit.BreakerOpenError{RetryAfter: 3 * time.Second}),
+		want:      3 * time.Second,
+		wantRetry: true,
+	}, {
+		name:      "too many requests with a suggested delay",
+		err:       k8serrors.NewTooManyRequests("slow down", 7),
+		want:      7 * time.Second,
+		wantRetry: true,
+	}, {
+		name:      "service unavailable",
+		err:       k8serrors.NewServiceUnavailable("etcd is slow"),
+		want:      time.Second,
+		wantRetry: true,
+	}, {
+		name:      "internal error",
+		err:       k8serrors.NewInternalError(errors.New("etcdserver: request timed out")),
+		want:      time.Second,
+		wantRetry: true,
+	}, {
+		name: "bad request",
+		err:  k8serrors.NewBadRequest("invalid pod"),
+	}, {
+		name: "other error",
+		err:  errors.New("invalid TaskSpec"),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, retry := ratelimit.CreationRetryAfter(tc.err)
+			if retry != tc.wantRetry || got != tc.want {
+				t.Errorf("expected to retry: %t after %s, got %t after %s", tc.wantRetry, tc.want, retry, got)
+			}
+		})
+	}
+}
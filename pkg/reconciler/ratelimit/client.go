@@ -0,0 +1,138 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+
+	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	pipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client"
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	"knative.dev/pkg/injection"
+)
+
+type clientLimits struct {
+	qps   float64
+	burst int
+}
+
+// ClientRateLimiter throttles the requests of the API clients of a controller
+// to its client rate limits. It doesn't throttle them until they are
+// configured, leaving the requests to the throttling of the clients
+// themselves.
+type ClientRateLimiter struct {
+	mu       sync.Mutex
+	defaults clientLimits
+	limits   clientLimits
+	limiter  atomic.Pointer[rate.Limiter]
+}
+
+// NewClientRateLimiter returns a ClientRateLimiter using the given QPS and
+// burst when only one of the client rate limits is configured.
+func NewClientRateLimiter(qps float64, burst int) *ClientRateLimiter {
+	c := &ClientRateLimiter{defaults: clientLimits{qps: qps, burst: burst}}
+	c.limiter.Store(rate.NewLimiter(rate.Inf, 0))
+	return c
+}
+
+// Set sets the client rate limits of the controller. Zero values stop the
+// throttling.
+func (c *ClientRateLimiter) Set(qps float64, burst int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	limits := clientLimits{qps: qps, burst: burst}
+	if limits == c.limits {
+		return
+	}
+	c.limits = limits
+	if qps == 0 && burst == 0 {
+		c.limiter.Store(rate.NewLimiter(rate.Inf, 0))
+		return
+	}
+	if qps == 0 {
+		qps = c.defaults.qps
+	}
+	if burst == 0 {
+		burst = c.defaults.burst
+	}
+	// A new limiter starts with a full burst, which the bucket of the
+	// previous limits may not hold.
+	c.limiter.Store(rate.NewLimiter(rate.Limit(qps), burst))
+}
+
+// Limit returns the QPS the requests are throttled to, which is rate.Inf if
+// they aren't throttled.
+func (c *ClientRateLimiter) Limit() rate.Limit {
+	return c.limiter.Load().Limit()
+}
+
+// Burst returns the number of requests which can be sent at once.
+func (c *ClientRateLimiter) Burst() int {
+	return c.limiter.Load().Burst()
+}
+
+// Wrap wraps the transport of an API client to throttle its requests, e.g.
+// restConfig.Wrap(clientRateLimiter.Wrap).
+func (c *ClientRateLimiter) Wrap(rt http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if err := c.limiter.Load().Wait(req.Context()); err != nil {
+			return nil, err
+		}
+		return rt.RoundTrip(req)
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+type clientDefaultsKey struct{}
+
+// WithClientDefaults attaches the QPS and burst of the API clients of a single
+// controller to the context, for the controllers to create API clients of
+// their own with WithControllerClients.
+func WithClientDefaults(ctx context.Context, qps float64, burst int) context.Context {
+	return context.WithValue(ctx, clientDefaultsKey{}, clientLimits{qps: qps, burst: burst})
+}
+
+// WithControllerClients returns the context with Kubernetes and Tekton API
+// clients of their own for a controller, throttled by the returned
+// ClientRateLimiter on top of the throttling of the clients of the context, so
+// that the requests of a controller don't use up the client rate limits of the
+// others. It returns the context unchanged and a nil ClientRateLimiter if
+// WithClientDefaults wasn't called or the context has no REST config.
+func WithControllerClients(ctx context.Context) (context.Context, *ClientRateLimiter) {
+	defaults, ok := ctx.Value(clientDefaultsKey{}).(clientLimits)
+	cfg := injection.GetConfig(ctx)
+	if !ok || cfg == nil {
+		return ctx, nil
+	}
+	c := NewClientRateLimiter(defaults.qps, defaults.burst)
+	cfg = rest.CopyConfig(cfg)
+	cfg.Wrap(c.Wrap)
+	ctx = context.WithValue(ctx, kubeclient.Key{}, kubernetes.NewForConfigOrDie(cfg))
+	ctx = context.WithValue(ctx, pipelineclient.Key{}, versioned.NewForConfigOrDie(cfg))
+	return ctx, c
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	pipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client"
+	"github.com/tektoncd/pipeline/pkg/reconciler/ratelimit"
+	"golang.org/x/time/rate"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	"knative.dev/pkg/injection"
+)
+
+func TestWithControllerClients(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := w.Write([]byte(`{"kind":"NamespaceList","apiVersion":"v1","items":[]}`)); err != nil {
+			t.Errorf("failed to write the response: %v", err)
+		}
+	}))
+	defer server.Close()
+	cfg := &rest.Config{Host: server.URL, QPS: -1}
+	baseClient := kubernetes.NewForConfigOrDie(cfg)
+
+	ctx := injection.WithConfig(t.Context(), cfg)
+	ctx = context.WithValue(ctx, kubeclient.Key{}, baseClient)
+	if _, c := ratelimit.WithControllerClients(ctx); c != nil {
+		t.Fatal("expected no ClientRateLimiter without client defaults")
+	}
+
+	ctx = ratelimit.WithClientDefaults(ctx, 10, 20)
+	taskRunCtx, taskRunLimiter := ratelimit.WithControllerClients(ctx)
+	pipelineRunCtx, pipelineRunLimiter := ratelimit.WithControllerClients(ctx)
+	if taskRunLimiter == nil || pipelineRunLimiter == nil {
+		t.Fatal("expected a ClientRateLimiter for each controller")
+	}
+	if kubeclient.Get(taskRunCtx) == baseClient || kubeclient.Get(taskRunCtx) == kubeclient.Get(pipelineRunCtx) {
+		t.Error("expected each controller to have a Kubernetes client of its own")
+	}
+	if pipelineclient.Get(taskRunCtx) == pipelineclient.Get(pipelineRunCtx) {
+		t.Error("expected each controller to have a Tekton client of its own")
+	}
+	if taskRunLimiter.Limit() != rate.Inf {
+		t.Errorf("expected the requests not to be throttled before the client rate limits are set but got %v", taskRunLimiter.Limit())
+	}
+
+	taskRunLimiter.Set(0.001, 1)
+	list := func(ctx context.Context) error {
+		ctx, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+		_, err := kubeclient.Get(ctx).CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+		return err
+	}
+	if err := list(taskRunCtx); err != nil {
+		t.Fatalf("expected the first request of the TaskRun controller to be sent but got %v", err)
+	}
+	if err := list(taskRunCtx); err == nil {
+		t.Error("expected the second request of the TaskRun controller to be throttled")
+	}
+	// The requests of the TaskRun controller don't use up the client rate
+	// limits of the other controllers.
+	for range 3 {
+		if err := list(pipelineRunCtx); err != nil {
+			t.Errorf("expected the requests of the PipelineRun controller not to be throttled but got %v", err)
+		}
+		if err := list(ctx); err != nil {
+			t.Errorf("expected the requests of the shared client not to be throttled but got %v", err)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ratelimit throttles the reconciliation of the runs by the
+// controllers, following the default-controller-rate-limits config.
+package ratelimit
+
+import (
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// OnStore returns a function updating the Limiter and the ClientRateLimiter
+// of the controller of the given kind when the config-defaults ConfigMap
+// changes. The ClientRateLimiter can be nil.
+func OnStore(logger *zap.SugaredLogger, kind string, limiter *Limiter, clientRateLimiter *ClientRateLimiter) func(name string, value interface{}) {
+	return func(name string, value interface{}) {
+		if name != config.GetDefaultsConfigName() {
+			return
+		}
+		defaults, ok := value.(*config.Defaults)
+		if !ok {
+			logger.Error("Failed to do type insertion for extracting defaults config")
+			return
+		}
+		limits := defaults.ControllerRateLimitsFor(kind)
+		limiter.Update(limits)
+		if clientRateLimiter != nil {
+			clientRateLimiter.Set(limits.ClientQPS, limits.ClientBurst)
+		}
+	}
+}
+
+// run is implemented by the TaskRuns and PipelineRuns.
+type run interface {
+	metav1.Object
+	IsDone() bool
+}
+
+// SkipTerminalResyncs returns the event handler calling the given function
+// for all the events of the runs, except for the periodic resyncs of the runs
+// which are done: they are delivered as updates of an unchanged object and
+// reconciling them again would only load the API server.
+func SkipTerminalResyncs(handler func(obj interface{})) cache.ResourceEventHandler {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: handler,
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if IsTerminalResync(oldObj, newObj) {
+				return
+			}
+			handler(newObj)
+		},
+		DeleteFunc: handler,
+	}
+}
+
+// IsTerminalResync returns whether the update of a run is a resync of a run
+// which is done.
+func IsTerminalResync(oldObj, newObj interface{}) bool {
+	oldRun, ok := oldObj.(run)
+	if !ok {
+		return false
+	}
+	newRun, ok := newObj.(run)
+	if !ok {
+		return false
+	}
+	return oldRun.GetResourceVersion() == newRun.GetResourceVersion() && newRun.IsDone()
+}
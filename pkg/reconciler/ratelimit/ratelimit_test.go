@@ -0,0 +1,154 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/ratelimit"
+	"golang.org/x/time/rate"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+func TestSkipTerminalResyncs(t *testing.T) {
+	running := &v1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "tr", ResourceVersion: "1"}}
+	done := &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "tr", ResourceVersion: "2"},
+		Status: v1.TaskRunStatus{Status: duckv1.Status{Conditions: duckv1.Conditions{{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionTrue,
+		}}}},
+	}
+	doneUpdated := done.DeepCopy()
+	doneUpdated.ResourceVersion = "3"
+	donePipelineRun := &v1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pr", ResourceVersion: "1"},
+		Status: v1.PipelineRunStatus{Status: duckv1.Status{Conditions: duckv1.Conditions{{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionFalse,
+		}}}},
+	}
+
+	for _, tc := range []struct {
+		name        string
+		oldObj      interface{}
+		newObj      interface{}
+		wantHandled bool
+	}{{
+		name:        "resync of a running run",
+		oldObj:      running,
+		newObj:      running.DeepCopy(),
+		wantHandled: true,
+	}, {
+		name:        "run which became done",
+		oldObj:      running,
+		newObj:      done,
+		wantHandled: true,
+	}, {
+		name:        "update of a done run",
+		oldObj:      done,
+		newObj:      doneUpdated,
+		wantHandled: true,
+	}, {
+		name:   "resync of a done TaskRun",
+		oldObj: done,
+		newObj: done.DeepCopy(),
+	}, {
+		name:   "resync of a done PipelineRun",
+		oldObj: donePipelineRun,
+		newObj: donePipelineRun.DeepCopy(),
+	}, {
+		name:        "resync of an object which isn't a run",
+		oldObj:      &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", ResourceVersion: "1"}},
+		newObj:      &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", ResourceVersion: "1"}},
+		wantHandled: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			handled := false
+			handler := ratelimit.SkipTerminalResyncs(func(interface{}) { handled = true })
+			handler.OnUpdate(tc.oldObj, tc.newObj)
+			if handled != tc.wantHandled {
+				t.Errorf("expected the update to be handled: %t but was: %t", tc.wantHandled, handled)
+			}
+		})
+	}
+
+	handled := 0
+	handler := ratelimit.SkipTerminalResyncs(func(interface{}) { handled++ })
+	handler.OnAdd(done, true)
+	handler.OnDelete(done)
+	if handled != 2 {
+		t.Errorf("expected the addition and deletion of a done run to be handled but %d events were", handled)
+	}
+}
+
+func TestOnStore(t *testing.T) {
+	limiter := ratelimit.NewLimiter(config.DefaultConfig.ControllerRateLimitsFor(config.ControllerRateLimitsTaskRunKey))
+	clientRateLimiter := ratelimit.NewClientRateLimiter(10, 20)
+	onStore := ratelimit.OnStore(logtesting.TestLogger(t), config.ControllerRateLimitsTaskRunKey, limiter, clientRateLimiter)
+
+	onStore(config.GetDefaultsConfigName(), &config.Defaults{
+		DefaultControllerRateLimits: map[string]config.ControllerRateLimits{
+			config.ControllerRateLimitsDefaultKey: {WorkQueueBaseDelay: metav1.Duration{Duration: time.Second}},
+			config.ControllerRateLimitsTaskRunKey: {WorkQueueMaxDelay: metav1.Duration{Duration: 3 * time.Second}, ClientQPS: 5, ClientBurst: 7},
+		},
+	})
+	for i, want := range []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second} {
+		if got := limiter.When("ns/tr"); got != want {
+			t.Errorf("expected delay %d to be %s but got %s", i, want, got)
+		}
+	}
+	if clientRateLimiter.Limit() != 5 || clientRateLimiter.Burst() != 7 {
+		t.Errorf("expected the client requests to be throttled to 5 QPS with a burst of 7 but got %v and %d", clientRateLimiter.Limit(), clientRateLimiter.Burst())
+	}
+
+	// The client rate limits of the PipelineRun controller don't apply to
+	// the TaskRun controller, and the burst defaults to the one the
+	// ClientRateLimiter was created with.
+	onStore(config.GetDefaultsConfigName(), &config.Defaults{
+		DefaultControllerRateLimits: map[string]config.ControllerRateLimits{
+			config.ControllerRateLimitsPipelineRunKey: {ClientQPS: 100, ClientBurst: 200},
+			config.ControllerRateLimitsTaskRunKey:     {ClientQPS: 5},
+		},
+	})
+	if clientRateLimiter.Limit() != 5 || clientRateLimiter.Burst() != 20 {
+		t.Errorf("expected the client requests to be throttled to 5 QPS with a burst of 20 but got %v and %d", clientRateLimiter.Limit(), clientRateLimiter.Burst())
+	}
+
+	// Other ConfigMaps are ignored.
+	onStore(config.GetFeatureFlagsConfigName(), &config.FeatureFlags{})
+	if clientRateLimiter.Limit() != 5 {
+		t.Errorf("expected the client rate limits to be unchanged but got %v", clientRateLimiter.Limit())
+	}
+
+	// Removing the client rate limits stops the throttling.
+	onStore(config.GetDefaultsConfigName(), &config.Defaults{})
+	if clientRateLimiter.Limit() != rate.Inf {
+		t.Errorf("expected the client requests not to be throttled but got %v", clientRateLimiter.Limit())
+	}
+	limiter.Forget("ns/tr")
+	if got := limiter.When("ns/tr"); got != config.DefaultWorkQueueBaseDelay {
+		t.Errorf("expected the delay to be reset to %s but got %s", config.DefaultWorkQueueBaseDelay, got)
+	}
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/reconciler"
+)
+
+// Limiter computes the delay before retrying the keys which failed to be
+// reconciled, like the default rate limiter of the work queues: the maximum
+// of a per-key exponential backoff and of an overall token bucket. Unlike it,
+// its settings can be updated while the controller runs.
+type Limiter struct {
+	mu        sync.Mutex
+	baseDelay time.Duration
+	maxDelay  time.Duration
+	failures  map[string]int
+	bucket    *rate.Limiter
+}
+
+// NewLimiter returns a Limiter with the work queue settings of the given
+// rate limits.
+func NewLimiter(limits config.ControllerRateLimits) *Limiter {
+	l := &Limiter{
+		failures: map[string]int{},
+		bucket:   rate.NewLimiter(rate.Limit(limits.WorkQueueQPS), limits.WorkQueueBurst),
+	}
+	l.Update(limits)
+	return l
+}
+
+// Update replaces the work queue settings of the Limiter. The failures of the
+// keys are kept.
+func (l *Limiter) Update(limits config.ControllerRateLimits) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.baseDelay = limits.WorkQueueBaseDelay.Duration
+	l.maxDelay = limits.WorkQueueMaxDelay.Duration
+	l.bucket.SetLimit(rate.Limit(limits.WorkQueueQPS))
+	l.bucket.SetBurst(limits.WorkQueueBurst)
+}
+
+// When records a failure of the key and returns the delay before retrying it.
+func (l *Limiter) When(key string) time.Duration {
+	l.mu.Lock()
+	exp := l.failures[key]
+	l.failures[key]++
+	delay := l.maxDelay
+	if backoff := float64(l.baseDelay.Nanoseconds()) * math.Pow(2, float64(exp)); backoff < float64(l.maxDelay.Nanoseconds()) {
+		delay = time.Duration(backoff)
+	}
+	l.mu.Unlock()
+
+	if d := l.bucket.Reserve().Delay(); d > delay {
+		delay = d
+	}
+	return delay
+}
+
+// Forget clears the failures of the key.
+func (l *Limiter) Forget(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.failures, key)
+}
+
+// NumRequeues returns the number of failures of the key since it was last
+// forgotten.
+func (l *Limiter) NumRequeues(key string) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.failures[key]
+}
+
+// leaderAwareReconciler is implemented by the generated reconcilers.
+type leaderAwareReconciler interface {
+	controller.Reconciler
+	reconciler.LeaderAware
+}
+
+// Reconciler retries the keys which failed to be reconciled with a transient
+// error after the delay computed by its Limiter, instead of the one computed
+// by the rate limiter of the work queue, which can't be configured.
+type Reconciler struct {
+	leaderAwareReconciler
+	limiter *Limiter
+}
+
+var _ leaderAwareReconciler = (*Reconciler)(nil)
+
+// NewReconciler wraps the reconciler of a controller.Impl, e.g.
+// impl.Reconciler = ratelimit.NewReconciler(impl.Reconciler, limiter).
+// Reconcilers which aren't leader aware are returned unchanged.
+func NewReconciler(r controller.Reconciler, limiter *Limiter) controller.Reconciler {
+	la, ok := r.(leaderAwareReconciler)
+	if !ok {
+		return r
+	}
+	return &Reconciler{leaderAwareReconciler: la, limiter: limiter}
+}
+
+// Reconcile implements controller.Reconciler. The failures of the key are
+// forgotten unless the reconcile fails with a transient error, including
+// when the reconciler requests a requeue or the object of the key was
+// deleted, so that the Limiter doesn't keep the keys of the objects which are
+// done or gone.
+func (r *Reconciler) Reconcile(ctx context.Context, key string) error {
+	err := r.leaderAwareReconciler.Reconcile(ctx, key)
+	if err == nil || controller.IsPermanentError(err) || controller.IsSkipKey(err) || apierrors.IsNotFound(err) {
+		r.limiter.Forget(key)
+		return err
+	}
+	if ok, _ := controller.IsRequeueKey(err); ok {
+		r.limiter.Forget(key)
+		return err
+	}
+	delay := r.limiter.When(key)
+	// The work queue doesn't log the requeued keys, so log the error here.
+	logging.FromContext(ctx).Errorw("Reconcile error", zap.Duration("retryAfter", delay), zap.Error(err))
+	return &retryError{err: err, requeue: controller.NewRequeueAfter(delay)}
+}
+
+// retryError is a transient reconcile error which the work queue requeues
+// after a delay. It keeps the message of the error.
+type retryError struct {
+	err     error
+	requeue error
+}
+
+func (e *retryError) Error() string {
+	return e.err.Error()
+}
+
+func (e *retryError) Unwrap() []error {
+	return []error{e.err, e.requeue}
+}
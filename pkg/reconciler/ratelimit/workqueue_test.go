@@ -0,0 +1,134 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/reconciler/ratelimit"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/reconciler"
+)
+
+type fakeReconciler struct {
+	reconciler.LeaderAwareFuncs
+	err error
+}
+
+func (r *fakeReconciler) Reconcile(context.Context, string) error {
+	return r.err
+}
+
+func TestReconcilerRetriesTransientErrors(t *testing.T) {
+	limiter := ratelimit.NewLimiter(config.ControllerRateLimits{
+		WorkQueueBaseDelay: metav1.Duration{Duration: time.Second},
+		WorkQueueMaxDelay:  metav1.Duration{Duration: time.Minute},
+		WorkQueueQPS:       10,
+		WorkQueueBurst:     100,
+	})
+	transient := errors.New("etcd is unavailable")
+	fake := &fakeReconciler{err: transient}
+	r := ratelimit.NewReconciler(fake, limiter)
+	if _, ok := r.(reconciler.LeaderAware); !ok {
+		t.Fatal("expected the reconciler to stay leader aware")
+	}
+
+	for _, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second} {
+		err := r.Reconcile(t.Context(), "ns/tr")
+		if !errors.Is(err, transient) || err.Error() != transient.Error() {
+			t.Errorf("expected the transient error to be returned but got %v", err)
+		}
+		if ok, delay := controller.IsRequeueKey(err); !ok || delay != want {
+			t.Errorf("expected the key to be requeued after %s but got %t, %s", want, ok, delay)
+		}
+	}
+
+	fake.err = controller.NewPermanentError(transient)
+	if err := r.Reconcile(t.Context(), "ns/tr"); !controller.IsPermanentError(err) {
+		t.Errorf("expected the permanent error to be returned unchanged but got %v", err)
+	}
+	if n := limiter.NumRequeues("ns/tr"); n != 0 {
+		t.Errorf("expected the failures of the key to be forgotten but got %d", n)
+	}
+
+	fake.err = transient
+	r.Reconcile(t.Context(), "ns/tr")
+	fake.err = controller.NewRequeueAfter(time.Hour)
+	if ok, delay := controller.IsRequeueKey(r.Reconcile(t.Context(), "ns/tr")); !ok || delay != time.Hour {
+		t.Errorf("expected the requested requeue to be returned unchanged but got %t, %s", ok, delay)
+	}
+	if n := limiter.NumRequeues("ns/tr"); n != 0 {
+		t.Errorf("expected requested requeues to forget the failures of the key but got %d", n)
+	}
+}
+
+func TestReconcilerForgetsDeletedKeys(t *testing.T) {
+	limiter := ratelimit.NewLimiter(config.ControllerRateLimits{
+		WorkQueueBaseDelay: metav1.Duration{Duration: time.Second},
+		WorkQueueMaxDelay:  metav1.Duration{Duration: time.Minute},
+		WorkQueueQPS:       10,
+		WorkQueueBurst:     100,
+	})
+	for _, tc := range []struct {
+		name    string
+		deleted error
+	}{{
+		name: "not found by the generated reconciler",
+	}, {
+		name:    "not found error",
+		deleted: apierrors.NewNotFound(schema.GroupResource{Group: "tekton.dev", Resource: "taskruns"}, "tr"),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			fake := &fakeReconciler{err: errors.New("etcd is unavailable")}
+			r := ratelimit.NewReconciler(fake, limiter)
+			r.Reconcile(t.Context(), "ns/deleted")
+			if n := limiter.NumRequeues("ns/deleted"); n != 1 {
+				t.Fatalf("expected the transient error to count as a failure but got %d", n)
+			}
+
+			fake.err = tc.deleted
+			if err := r.Reconcile(t.Context(), "ns/deleted"); !errors.Is(err, tc.deleted) {
+				t.Errorf("expected the error to be returned unchanged but got %v", err)
+			}
+			if n := limiter.NumRequeues("ns/deleted"); n != 0 {
+				t.Errorf("expected the failures of the deleted key to be forgotten but got %d", n)
+			}
+		})
+	}
+}
+
+func TestLimiterThrottlesRetries(t *testing.T) {
+	limiter := ratelimit.NewLimiter(config.ControllerRateLimits{
+		WorkQueueBaseDelay: metav1.Duration{Duration: time.Millisecond},
+		WorkQueueMaxDelay:  metav1.Duration{Duration: time.Second},
+		WorkQueueQPS:       1,
+		WorkQueueBurst:     1,
+	})
+	if d := limiter.When("ns/a"); d != time.Millisecond {
+		t.Errorf("expected the first retry to be delayed by the base delay but got %s", d)
+	}
+	if d := limiter.When("ns/b"); d < 500*time.Millisecond {
+		t.Errorf("expected the retry of another key to be throttled by the bucket but got %s", d)
+	}
+}
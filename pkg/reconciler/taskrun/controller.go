@@ -30,6 +30,7 @@ import (
 	resolutioninformer "github.com/tektoncd/pipeline/pkg/client/resolution/injection/informers/resolution/v1beta1/resolutionrequest"
 	"github.com/tektoncd/pipeline/pkg/pod"
 	cloudeventclient "github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
+	"github.com/tektoncd/pipeline/pkg/reconciler/ratelimit"
 	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
 	resolution "github.com/tektoncd/pipeline/pkg/remoteresolution/resource"
 	"github.com/tektoncd/pipeline/pkg/spire"
@@ -55,6 +56,7 @@ const (
 func NewController(opts *pipeline.Options, clock clock.PassiveClock) func(context.Context, configmap.Watcher) *controller.Impl {
 	return func(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
 		logger := logging.FromContext(ctx)
+		ctx, clientRateLimiter := ratelimit.WithControllerClients(ctx)
 		kubeclientset := kubeclient.Get(ctx)
 		pipelineclientset := pipelineclient.Get(ctx)
 		taskRunInformer := taskruninformer.Get(ctx)
@@ -66,8 +68,10 @@ func NewController(opts *pipeline.Options, clock clock.PassiveClock) func(contex
 		spireClient := spire.GetControllerAPIClient(ctx)
 		tracerProvider := tracing.New(TracerProviderName, logger.Named("tracing"))
 		taskrunmetricsRecorder := taskrunmetrics.Get(ctx)
+		rateLimiter := ratelimit.NewLimiter(config.DefaultConfig.ControllerRateLimitsFor(config.ControllerRateLimitsTaskRunKey))
 		//nolint:contextcheck // OnStore methods does not support context as a parameter
 		configStore := config.NewStore(logger.Named("config-store"),
+			ratelimit.OnStore(logger, config.ControllerRateLimitsTaskRunKey, rateLimiter, clientRateLimiter),
 			taskrunmetrics.OnStore(logger, taskrunmetricsRecorder),
 			spire.OnStore(ctx, logger),
 			tracerProvider.OnStore(secretinformer.Lister()),
@@ -79,6 +83,15 @@ func NewController(opts *pipeline.Options, clock clock.PassiveClock) func(contex
 			logger.Fatalf("Error creating entrypoint cache: %v", err)
 		}
 
+		keylessSigningRoots, err := pod.LoadKeylessSigningRoots(opts.KeylessSigningRoots)
+		if err != nil {
+			logger.Fatalf("Error loading the keyless signing roots: %v", err)
+		}
+		imageSignatureLookup, err := pod.NewImageSignatureLookup(kubeclientset, keylessSigningRoots)
+		if err != nil {
+			logger.Fatalf("Error creating image signature lookup: %v", err)
+		}
+
 		c := &Reconciler{
 			KubeClientSet:            kubeclientset,
 			PipelineClientSet:        pipelineclientset,
@@ -91,10 +104,12 @@ func NewController(opts *pipeline.Options, clock clock.PassiveClock) func(contex
 			cloudEventClient:         cloudeventclient.Get(ctx),
 			metrics:                  taskrunmetricsRecorder,
 			entrypointCache:          entrypointCache,
+			imageSignatureLookup:     imageSignatureLookup,
 			podLister:                podInformer.Lister(),
 			pvcHandler:               volumeclaim.NewPVCHandler(kubeclientset, logger),
 			resolutionRequester:      resolution.NewCRDRequester(resolutionclient.Get(ctx), resolutionInformer.Lister()),
 			tracerProvider:           tracerProvider,
+			creationBreaker:          ratelimit.GetCreationBreaker(ctx),
 		}
 		impl := taskrunreconciler.NewImpl(ctx, c, func(impl *controller.Impl) controller.Options {
 			return controller.Options{
@@ -102,12 +117,13 @@ func NewController(opts *pipeline.Options, clock clock.PassiveClock) func(contex
 				ConfigStore: configStore,
 			}
 		})
+		impl.Reconciler = ratelimit.NewReconciler(impl.Reconciler, rateLimiter)
 
 		if _, err := secretinformer.Informer().AddEventHandler(controller.HandleAll(tracerProvider.Handler)); err != nil {
 			logging.FromContext(ctx).Panicf("Couldn't register Secret informer event handler: %w", err)
 		}
 
-		if _, err := taskRunInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue)); err != nil {
+		if _, err := taskRunInformer.Informer().AddEventHandler(ratelimit.SkipTerminalResyncs(impl.Enqueue)); err != nil {
 			logging.FromContext(ctx).Panicf("Couldn't register TaskRun informer event handler: %w", err)
 		}
 
@@ -517,11 +517,16 @@ func ApplyWorkspaces(ctx context.Context, spec *v1.TaskSpec, declarations []v1.W
 		bindNames.Insert(binding.Name)
 	}
 
+	// The path of an unbound workspace is kept in the workingDir of the steps,
+	// rather than replaced with an empty string, so that the pod builder can
+	// reject it with an error naming the step and the workspace.
+	unboundPaths := sets.NewString()
 	for _, declaration := range declarations {
 		prefix := fmt.Sprintf("workspaces.%s.", declaration.Name)
 		if declaration.Optional && !bindNames.Has(declaration.Name) {
 			stringReplacements[prefix+"bound"] = "false"
 			stringReplacements[prefix+"path"] = ""
+			unboundPaths.Insert(prefix + "path")
 		} else {
 			stringReplacements[prefix+"bound"] = "true"
 			spec = applyWorkspaceMountPath(prefix+"path", spec, declaration)
@@ -538,7 +543,32 @@ func ApplyWorkspaces(ctx context.Context, spec *v1.TaskSpec, declarations []v1.W
 			stringReplacements[fmt.Sprintf("workspaces.%s.claim", binding.Name)] = ""
 		}
 	}
-	return ApplyReplacements(spec, stringReplacements, map[string][]string{}, map[string]map[string]string{})
+	if unboundPaths.Len() == 0 {
+		return ApplyReplacements(spec, stringReplacements, map[string][]string{}, map[string]map[string]string{})
+	}
+
+	workingDirReplacements := map[string]string{}
+	for k, v := range stringReplacements {
+		if !unboundPaths.Has(k) {
+			workingDirReplacements[k] = v
+		}
+	}
+	workingDirs := make([]string, len(spec.Steps))
+	for i, step := range spec.Steps {
+		workingDirs[i] = substitution.ApplyReplacements(step.WorkingDir, workingDirReplacements)
+	}
+	var templateWorkingDir string
+	if spec.StepTemplate != nil {
+		templateWorkingDir = substitution.ApplyReplacements(spec.StepTemplate.WorkingDir, workingDirReplacements)
+	}
+	spec = ApplyReplacements(spec, stringReplacements, map[string][]string{}, map[string]map[string]string{})
+	for i := range spec.Steps {
+		spec.Steps[i].WorkingDir = workingDirs[i]
+	}
+	if spec.StepTemplate != nil {
+		spec.StepTemplate.WorkingDir = templateWorkingDir
+	}
+	return spec
 }
 
 // ApplyParametersToWorkspaceBindings applies parameters to the WorkspaceBindings of a TaskRun. It takes a TaskSpec and a TaskRun as input and returns the modified TaskRun.
@@ -1197,6 +1197,32 @@ func TestApplyWorkspaces(t *testing.T) {
 		want: &v1.TaskSpec{Steps: []v1.Step{{
 			Script: `test "false" = "true" && echo ""`,
 		}}},
+	}, {
+		name: "optional-workspace-omitted-path-kept-in-working-dir",
+		spec: &v1.TaskSpec{
+			StepTemplate: &v1.StepTemplate{WorkingDir: "$(workspaces.ows.path)"},
+			Steps: []v1.Step{{
+				WorkingDir: "$(workspaces.ows.path)/$(workspaces.ws.path)",
+				Script:     `echo "$(workspaces.ows.path)"`,
+			}},
+		},
+		decls: []v1.WorkspaceDeclaration{{
+			Name:     "ows",
+			Optional: true,
+		}, {
+			Name: "ws",
+		}},
+		binds: []v1.WorkspaceBinding{{
+			Name:     "ws",
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		}},
+		want: &v1.TaskSpec{
+			StepTemplate: &v1.StepTemplate{WorkingDir: "$(workspaces.ows.path)"},
+			Steps: []v1.Step{{
+				WorkingDir: "$(workspaces.ows.path)//workspace/ws",
+				Script:     `echo ""`,
+			}},
+		},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			vols := workspace.CreateVolumes(tc.binds)
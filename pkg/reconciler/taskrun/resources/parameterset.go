@@ -0,0 +1,169 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+var (
+	// ErrParameterSetNotFound is returned when a ParameterSet referenced by a
+	// paramsFrom doesn't exist.
+	ErrParameterSetNotFound = errors.New("ParameterSet not found")
+	// ErrCircularParameterSetReference is returned when ParameterSets import
+	// each other's params in a cycle.
+	ErrCircularParameterSetReference = errors.New("circular ParameterSet reference")
+)
+
+// GetParameterSet is a function used to retrieve ParameterSets.
+type GetParameterSet func(context.Context, string) (*v1alpha1.ParameterSet, error)
+
+// GetParameterSetFunc returns a GetParameterSet retrieving the ParameterSets
+// of the given namespace from the local cluster.
+func GetParameterSetFunc(tekton clientset.Interface, namespace string) GetParameterSet {
+	return func(ctx context.Context, name string) (*v1alpha1.ParameterSet, error) {
+		ps, err := tekton.TektonV1alpha1().ParameterSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			return nil, fmt.Errorf("%w: %s/%s", ErrParameterSetNotFound, namespace, name)
+		}
+		return ps, err
+	}
+}
+
+// ExpandParamsFrom returns the given params and results declared by a Task
+// with the params and results imported from the ParameterSets referenced by
+// its paramsFrom, and the ParameterSets which were expanded. The declared
+// params and results take precedence over the imported ones, and the ones of
+// a ParameterSet over the ones of the next ParameterSets. The ParameterSets
+// imported by a ParameterSet are expanded right after it.
+func ExpandParamsFrom(ctx context.Context, params v1.ParamSpecs, results []v1.TaskResult, paramsFrom []v1.ParameterSetRef, getParameterSet GetParameterSet) (v1.ParamSpecs, []v1.TaskResult, []v1.ExpandedParameterSet, error) {
+	e := newParamsFromExpander(params, getParameterSet)
+	e.importResults = true
+	e.results = append([]v1.TaskResult(nil), results...)
+	for _, r := range results {
+		e.declaredResults.Insert(r.Name)
+	}
+	if err := e.expandAll(ctx, paramsFrom); err != nil {
+		return nil, nil, nil, err
+	}
+	return e.params, e.results, e.expandedParameterSets, nil
+}
+
+// ExpandPipelineParamsFrom returns the given params declared by a Pipeline
+// with the params imported from the ParameterSets referenced by its
+// paramsFrom, and the ParameterSets which were expanded, with the same
+// precedence as ExpandParamsFrom. The results of the ParameterSets aren't
+// imported, as the results of a Pipeline need a value.
+func ExpandPipelineParamsFrom(ctx context.Context, params v1.ParamSpecs, paramsFrom []v1.ParameterSetRef, getParameterSet GetParameterSet) (v1.ParamSpecs, []v1.ExpandedParameterSet, error) {
+	e := newParamsFromExpander(params, getParameterSet)
+	if err := e.expandAll(ctx, paramsFrom); err != nil {
+		return nil, nil, err
+	}
+	return e.params, e.expandedParameterSets, nil
+}
+
+type paramsFromExpander struct {
+	getParameterSet       GetParameterSet
+	importResults         bool
+	params                v1.ParamSpecs
+	results               []v1.TaskResult
+	declared              sets.Set[string]
+	declaredResults       sets.Set[string]
+	expanded              sets.Set[string]
+	expandedParameterSets []v1.ExpandedParameterSet
+}
+
+func newParamsFromExpander(params v1.ParamSpecs, getParameterSet GetParameterSet) *paramsFromExpander {
+	return &paramsFromExpander{
+		getParameterSet: getParameterSet,
+		params:          append(v1.ParamSpecs{}, params...),
+		declared:        sets.New(params.GetNames()...),
+		declaredResults: sets.New[string](),
+		expanded:        sets.New[string](),
+	}
+}
+
+// expandAll imports the params, and the results if they are imported, of the
+// ParameterSets referenced by the given paramsFrom.
+func (e *paramsFromExpander) expandAll(ctx context.Context, paramsFrom []v1.ParameterSetRef) error {
+	for _, ref := range paramsFrom {
+		if err := e.expand(ctx, ref.Name, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// expand imports the params, and the results if they are imported, of the
+// ParameterSet with the given name, which is imported by the ParameterSets
+// of the path.
+func (e *paramsFromExpander) expand(ctx context.Context, name string, path []string) error {
+	if slices.Contains(path, name) {
+		return fmt.Errorf("%w: %s", ErrCircularParameterSetReference, strings.Join(append(path, name), " -> "))
+	}
+	// A ParameterSet imported several times is only expanded once.
+	if e.expanded.Has(name) {
+		return nil
+	}
+	e.expanded.Insert(name)
+
+	ps, err := e.getParameterSet(ctx, name)
+	if err != nil {
+		return err
+	}
+	expanded := v1.ExpandedParameterSet{Name: name, ResourceVersion: ps.ResourceVersion}
+	for _, p := range ps.Spec.Params {
+		if e.declared.Has(p.Name) {
+			continue
+		}
+		p.SetDefaults(ctx)
+		e.params = append(e.params, p)
+		e.declared.Insert(p.Name)
+		expanded.Params = append(expanded.Params, p.Name)
+	}
+	if e.importResults {
+		for _, r := range ps.Spec.Results {
+			if e.declaredResults.Has(r.Name) {
+				continue
+			}
+			r.SetDefaults(ctx)
+			e.results = append(e.results, r)
+			e.declaredResults.Insert(r.Name)
+			expanded.Results = append(expanded.Results, r.Name)
+		}
+	}
+	e.expandedParameterSets = append(e.expandedParameterSets, expanded)
+
+	path = append(path, name)
+	for _, ref := range ps.Spec.ParamsFrom {
+		if err := e.expand(ctx, ref.Name, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
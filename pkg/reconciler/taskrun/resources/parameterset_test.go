@@ -0,0 +1,278 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
+	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func parameterSet(name string, params v1.ParamSpecs, paramsFrom ...string) *v1alpha1.ParameterSet {
+	ps := &v1alpha1.ParameterSet{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", ResourceVersion: "1"},
+		Spec:       v1alpha1.ParameterSetSpec{Params: params},
+	}
+	for _, ref := range paramsFrom {
+		ps.Spec.ParamsFrom = append(ps.Spec.ParamsFrom, v1.ParameterSetRef{Name: ref})
+	}
+	return ps
+}
+
+func parameterSetWithResults(name string, results []v1.TaskResult, paramsFrom ...string) *v1alpha1.ParameterSet {
+	ps := parameterSet(name, nil, paramsFrom...)
+	ps.Spec.Results = results
+	return ps
+}
+
+func stringParamSpec(name, description string) v1.ParamSpec {
+	return v1.ParamSpec{Name: name, Type: v1.ParamTypeString, Description: description}
+}
+
+func stringResult(name, description string) v1.TaskResult {
+	return v1.TaskResult{Name: name, Type: v1.ResultsTypeString, Description: description}
+}
+
+func TestExpandParamsFrom(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		params        v1.ParamSpecs
+		results       []v1.TaskResult
+		paramsFrom    []string
+		parameterSets []runtime.Object
+		wantParams    v1.ParamSpecs
+		wantResults   []v1.TaskResult
+		wantExpanded  []v1.ExpandedParameterSet
+	}{{
+		name:       "params imported from a ParameterSet",
+		params:     v1.ParamSpecs{stringParamSpec("local", "")},
+		paramsFrom: []string{"common"},
+		parameterSets: []runtime.Object{
+			parameterSet("common", v1.ParamSpecs{{Name: "registry"}, {Name: "tags", Type: v1.ParamTypeArray}}),
+		},
+		wantParams: v1.ParamSpecs{
+			stringParamSpec("local", ""),
+			stringParamSpec("registry", ""),
+			{Name: "tags", Type: v1.ParamTypeArray},
+		},
+		wantExpanded: []v1.ExpandedParameterSet{{Name: "common", ResourceVersion: "1", Params: []string{"registry", "tags"}}},
+	}, {
+		name:       "local declarations win",
+		params:     v1.ParamSpecs{stringParamSpec("registry", "local")},
+		paramsFrom: []string{"common"},
+		parameterSets: []runtime.Object{
+			parameterSet("common", v1.ParamSpecs{stringParamSpec("registry", "common"), stringParamSpec("tag", "common")}),
+		},
+		wantParams:   v1.ParamSpecs{stringParamSpec("registry", "local"), stringParamSpec("tag", "common")},
+		wantExpanded: []v1.ExpandedParameterSet{{Name: "common", ResourceVersion: "1", Params: []string{"tag"}}},
+	}, {
+		name:       "earlier ParameterSets win",
+		paramsFrom: []string{"first", "second"},
+		parameterSets: []runtime.Object{
+			parameterSet("first", v1.ParamSpecs{stringParamSpec("registry", "first")}),
+			parameterSet("second", v1.ParamSpecs{stringParamSpec("registry", "second"), stringParamSpec("tag", "second")}),
+		},
+		wantParams: v1.ParamSpecs{stringParamSpec("registry", "first"), stringParamSpec("tag", "second")},
+		wantExpanded: []v1.ExpandedParameterSet{
+			{Name: "first", ResourceVersion: "1", Params: []string{"registry"}},
+			{Name: "second", ResourceVersion: "1", Params: []string{"tag"}},
+		},
+	}, {
+		name:       "nested ParameterSets",
+		paramsFrom: []string{"build", "deploy"},
+		parameterSets: []runtime.Object{
+			parameterSet("build", v1.ParamSpecs{stringParamSpec("image", "build")}, "common"),
+			parameterSet("deploy", v1.ParamSpecs{stringParamSpec("cluster", "deploy"), stringParamSpec("registry", "deploy")}, "common"),
+			parameterSet("common", v1.ParamSpecs{stringParamSpec("image", "common"), stringParamSpec("registry", "common")}),
+		},
+		wantParams: v1.ParamSpecs{
+			stringParamSpec("image", "build"),
+			stringParamSpec("registry", "common"),
+			stringParamSpec("cluster", "deploy"),
+		},
+		wantExpanded: []v1.ExpandedParameterSet{
+			{Name: "build", ResourceVersion: "1", Params: []string{"image"}},
+			{Name: "common", ResourceVersion: "1", Params: []string{"registry"}},
+			{Name: "deploy", ResourceVersion: "1", Params: []string{"cluster"}},
+		},
+	}, {
+		name:       "ParameterSet without new params",
+		params:     v1.ParamSpecs{stringParamSpec("registry", "local")},
+		paramsFrom: []string{"common"},
+		parameterSets: []runtime.Object{
+			parameterSet("common", v1.ParamSpecs{stringParamSpec("registry", "common")}),
+		},
+		wantParams:   v1.ParamSpecs{stringParamSpec("registry", "local")},
+		wantExpanded: []v1.ExpandedParameterSet{{Name: "common", ResourceVersion: "1"}},
+	}, {
+		name:       "results imported from a ParameterSet",
+		results:    []v1.TaskResult{stringResult("local", "")},
+		paramsFrom: []string{"image"},
+		parameterSets: []runtime.Object{
+			parameterSetWithResults("image", []v1.TaskResult{{Name: "IMAGE_DIGEST"}, {Name: "IMAGE_URL"}}),
+		},
+		wantParams:   v1.ParamSpecs{},
+		wantResults:  []v1.TaskResult{stringResult("local", ""), stringResult("IMAGE_DIGEST", ""), stringResult("IMAGE_URL", "")},
+		wantExpanded: []v1.ExpandedParameterSet{{Name: "image", ResourceVersion: "1", Results: []string{"IMAGE_DIGEST", "IMAGE_URL"}}},
+	}, {
+		name:       "local result declarations win",
+		results:    []v1.TaskResult{stringResult("IMAGE_DIGEST", "local")},
+		paramsFrom: []string{"image"},
+		parameterSets: []runtime.Object{
+			parameterSetWithResults("image", []v1.TaskResult{stringResult("IMAGE_DIGEST", "image"), stringResult("IMAGE_URL", "image")}),
+		},
+		wantParams:   v1.ParamSpecs{},
+		wantResults:  []v1.TaskResult{stringResult("IMAGE_DIGEST", "local"), stringResult("IMAGE_URL", "image")},
+		wantExpanded: []v1.ExpandedParameterSet{{Name: "image", ResourceVersion: "1", Results: []string{"IMAGE_URL"}}},
+	}, {
+		name:       "results of earlier and nested ParameterSets win",
+		paramsFrom: []string{"build", "deploy"},
+		parameterSets: []runtime.Object{
+			parameterSetWithResults("build", []v1.TaskResult{stringResult("IMAGE_DIGEST", "build")}, "image"),
+			parameterSetWithResults("deploy", []v1.TaskResult{stringResult("IMAGE_URL", "deploy"), stringResult("URL", "deploy")}),
+			parameterSetWithResults("image", []v1.TaskResult{stringResult("IMAGE_DIGEST", "image"), stringResult("IMAGE_URL", "image")}),
+		},
+		wantParams: v1.ParamSpecs{},
+		wantResults: []v1.TaskResult{
+			stringResult("IMAGE_DIGEST", "build"),
+			stringResult("IMAGE_URL", "image"),
+			stringResult("URL", "deploy"),
+		},
+		wantExpanded: []v1.ExpandedParameterSet{
+			{Name: "build", ResourceVersion: "1", Results: []string{"IMAGE_DIGEST"}},
+			{Name: "image", ResourceVersion: "1", Results: []string{"IMAGE_URL"}},
+			{Name: "deploy", ResourceVersion: "1", Results: []string{"URL"}},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			var paramsFrom []v1.ParameterSetRef
+			for _, name := range tc.paramsFrom {
+				paramsFrom = append(paramsFrom, v1.ParameterSetRef{Name: name})
+			}
+			tektonclient := fake.NewSimpleClientset(tc.parameterSets...)
+			params, results, expanded, err := resources.ExpandParamsFrom(t.Context(), tc.params, tc.results, paramsFrom, resources.GetParameterSetFunc(tektonclient, "default"))
+			if err != nil {
+				t.Fatalf("Unexpected error expanding paramsFrom: %v", err)
+			}
+			if d := cmp.Diff(tc.wantParams, params); d != "" {
+				t.Errorf("Unexpected params %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tc.wantResults, results); d != "" {
+				t.Errorf("Unexpected results %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tc.wantExpanded, expanded); d != "" {
+				t.Errorf("Unexpected expanded ParameterSets %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestExpandPipelineParamsFrom(t *testing.T) {
+	common := parameterSet("common", v1.ParamSpecs{stringParamSpec("registry", "common")})
+	common.Spec.Results = []v1.TaskResult{stringResult("IMAGE_DIGEST", "common")}
+	tektonclient := fake.NewSimpleClientset(common)
+	params, expanded, err := resources.ExpandPipelineParamsFrom(t.Context(), nil, []v1.ParameterSetRef{{Name: "common"}}, resources.GetParameterSetFunc(tektonclient, "default"))
+	if err != nil {
+		t.Fatalf("Unexpected error expanding paramsFrom: %v", err)
+	}
+	if d := cmp.Diff(v1.ParamSpecs{stringParamSpec("registry", "common")}, params); d != "" {
+		t.Errorf("Unexpected params %s", diff.PrintWantGot(d))
+	}
+	// The results of the ParameterSets aren't imported by the Pipelines.
+	wantExpanded := []v1.ExpandedParameterSet{{Name: "common", ResourceVersion: "1", Params: []string{"registry"}}}
+	if d := cmp.Diff(wantExpanded, expanded); d != "" {
+		t.Errorf("Unexpected expanded ParameterSets %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestExpandParamsFrom_Error(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		paramsFrom    []string
+		parameterSets []runtime.Object
+		wantErr       error
+		wantMessage   string
+	}{{
+		name:        "missing ParameterSet",
+		paramsFrom:  []string{"missing"},
+		wantErr:     resources.ErrParameterSetNotFound,
+		wantMessage: "ParameterSet not found: default/missing",
+	}, {
+		name:       "missing nested ParameterSet",
+		paramsFrom: []string{"common"},
+		parameterSets: []runtime.Object{
+			parameterSet("common", v1.ParamSpecs{stringParamSpec("registry", "")}, "missing"),
+		},
+		wantErr:     resources.ErrParameterSetNotFound,
+		wantMessage: "ParameterSet not found: default/missing",
+	}, {
+		name:       "circular reference",
+		paramsFrom: []string{"build"},
+		parameterSets: []runtime.Object{
+			parameterSet("build", v1.ParamSpecs{stringParamSpec("image", "")}, "common"),
+			parameterSet("common", v1.ParamSpecs{stringParamSpec("registry", "")}, "build"),
+		},
+		wantErr:     resources.ErrCircularParameterSetReference,
+		wantMessage: "circular ParameterSet reference: build -> common -> build",
+	}, {
+		name:       "self reference",
+		paramsFrom: []string{"common"},
+		parameterSets: []runtime.Object{
+			parameterSet("common", v1.ParamSpecs{stringParamSpec("registry", "")}, "common"),
+		},
+		wantErr:     resources.ErrCircularParameterSetReference,
+		wantMessage: "circular ParameterSet reference: common -> common",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			var paramsFrom []v1.ParameterSetRef
+			for _, name := range tc.paramsFrom {
+				paramsFrom = append(paramsFrom, v1.ParameterSetRef{Name: name})
+			}
+			tektonclient := fake.NewSimpleClientset(tc.parameterSets...)
+			_, _, _, err := resources.ExpandParamsFrom(t.Context(), nil, nil, paramsFrom, resources.GetParameterSetFunc(tektonclient, "default"))
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("Expected error %v but got %v", tc.wantErr, err)
+			}
+			if err.Error() != tc.wantMessage {
+				t.Errorf("Expected error message %q but got %q", tc.wantMessage, err.Error())
+			}
+		})
+	}
+}
+
+func TestExpandParamsFrom_RetryableError(t *testing.T) {
+	want := errors.New("etcd is unavailable")
+	getParameterSet := func(context.Context, string) (*v1alpha1.ParameterSet, error) {
+		return nil, want
+	}
+	_, _, _, err := resources.ExpandParamsFrom(t.Context(), nil, nil, []v1.ParameterSetRef{{Name: "common"}}, getParameterSet)
+	if !errors.Is(err, want) {
+		t.Fatalf("Expected error %v but got %v", want, err)
+	}
+	if errors.Is(err, resources.ErrParameterSetNotFound) || errors.Is(err, resources.ErrCircularParameterSetReference) {
+		t.Errorf("Expected the error not to be permanent but got %v", err)
+	}
+}
@@ -20,8 +20,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
@@ -141,6 +143,24 @@ func GetTaskFunc(ctx context.Context, k8s kubernetes.Interface, tekton clientset
 func GetStepActionFunc(tekton clientset.Interface, k8s kubernetes.Interface, requester remoteresource.Requester, tr *v1.TaskRun, taskSpec v1.TaskSpec, step *v1.Step) GetStepAction {
 	trName := tr.Name
 	namespace := tr.Namespace
+	if step.Ref != nil && step.Ref.Version != "" && requester != nil {
+		// Return an inline function that resolves the version of the StepAction with the default StepAction resolver.
+		return func(ctx context.Context, name string) (*v1beta1.StepAction, *v1.RefSource, error) {
+			stepActionResolver := config.FromContextOrDefaults(ctx).Defaults.DefaultStepActionResolver
+			if stepActionResolver == nil {
+				return nil, nil, fmt.Errorf("StepAction %s references the version %s but default-step-action-resolver isn't configured in %s", name, step.Ref.Version, config.GetDefaultsConfigName())
+			}
+			resolverPayload := remoteresource.ResolverPayload{
+				Name:      trName,
+				Namespace: namespace,
+				ResolutionSpec: &resolutionV1beta1.ResolutionRequestSpec{
+					Params: stepActionResolverParams(stepActionResolver, name, step.Ref.Version),
+				},
+			}
+			resolver := resolution.NewResolver(requester, tr, stepActionResolver.Resolver, resolverPayload)
+			return resolveStepAction(ctx, resolver, name, namespace, k8s, tekton)
+		}
+	}
 	if step.Ref != nil && step.Ref.Resolver != "" && requester != nil {
 		// Return an inline function that implements GetStepAction by calling Resolver.Get with the specified StepAction type and
 		// casting it to a StepAction.
@@ -166,6 +186,17 @@ func GetStepActionFunc(tekton clientset.Interface, k8s kubernetes.Interface, req
 	return local.GetStepAction
 }
 
+// stepActionResolverParams returns the params of the default StepAction
+// resolver for the version of the StepAction, sorted by name.
+func stepActionResolverParams(stepActionResolver *config.StepActionResolver, name, version string) v1.Params {
+	params := v1.Params{}
+	for k, v := range stepActionResolver.ParamsFor(name, version) {
+		params = append(params, v1.Param{Name: k, Value: *v1.NewStructuredValues(v)})
+	}
+	sort.Slice(params, func(i, j int) bool { return params[i].Name < params[j].Name })
+	return params
+}
+
 // ApplyParameterSubstitutionInResolverParams applies parameter substitutions in resolver params for Step Ref.
 func ApplyParameterSubstitutionInResolverParams(tr *v1.TaskRun, taskSpec v1.TaskSpec, step *v1.Step) {
 	stringReplacements := make(map[string]string)
@@ -935,6 +935,122 @@ func TestGetStepActionFunc_RemoteResolution_Error(t *testing.T) {
 	}
 }
 
+// capturingRequester records the resolver and the payload of the request it
+// resolves.
+type capturingRequester struct {
+	resolved     resource.ResolvedResource
+	resolverName resource.ResolverName
+	payload      resource.ResolverPayload
+}
+
+func (r *capturingRequester) Submit(_ context.Context, name resource.ResolverName, req resource.Request) (resource.ResolvedResource, error) {
+	r.resolverName = name
+	r.payload = req.ResolverPayload()
+	return r.resolved, nil
+}
+
+func TestGetStepActionFunc_VersionedRef(t *testing.T) {
+	stepActionYAML := strings.Join([]string{
+		"kind: StepAction",
+		"apiVersion: tekton.dev/v1beta1",
+		stepActionYAMLString,
+	}, "\n")
+	for _, tc := range []struct {
+		name       string
+		resolver   *config.StepActionResolver
+		wantParams []v1.Param
+	}{{
+		name: "hub",
+		resolver: &config.StepActionResolver{
+			Resolver: "hub",
+			Params: map[string]string{
+				"kind":    "stepaction",
+				"name":    "$(ref.name)",
+				"version": "$(ref.version)",
+			},
+		},
+		wantParams: []v1.Param{
+			{Name: "kind", Value: *v1.NewStructuredValues("stepaction")},
+			{Name: "name", Value: *v1.NewStructuredValues("build-push")},
+			{Name: "version", Value: *v1.NewStructuredValues("0.3")},
+		},
+	}, {
+		name: "git",
+		resolver: &config.StepActionResolver{
+			Resolver: "git",
+			Params: map[string]string{
+				"url":        "https://github.com/tektoncd/catalog.git",
+				"revision":   "main",
+				"pathInRepo": "stepaction/$(ref.name)/$(ref.version)/$(ref.name).yaml",
+			},
+		},
+		wantParams: []v1.Param{
+			{Name: "pathInRepo", Value: *v1.NewStructuredValues("stepaction/build-push/0.3/build-push.yaml")},
+			{Name: "revision", Value: *v1.NewStructuredValues("main")},
+			{Name: "url", Value: *v1.NewStructuredValues("https://github.com/tektoncd/catalog.git")},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := config.ToContext(t.Context(), &config.Config{
+				Defaults: &config.Defaults{DefaultStepActionResolver: tc.resolver},
+			})
+			requester := &capturingRequester{
+				resolved: resolution.NewResolvedResource([]byte(stepActionYAML), nil /* annotations */, sampleRefSource.DeepCopy(), nil /* data error */),
+			}
+			tr := &v1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "tr", Namespace: "default"},
+				Spec: v1.TaskRunSpec{
+					TaskSpec: &v1.TaskSpec{
+						Steps: []v1.Step{{
+							Ref: &v1.Ref{Name: "build-push", Version: "0.3"},
+						}},
+					},
+				},
+			}
+			fn := resources.GetStepActionFunc(fake.NewSimpleClientset(), nil, requester, tr, *tr.Spec.TaskSpec, &tr.Spec.TaskSpec.Steps[0])
+			resolvedStepAction, resolvedRefSource, err := fn(ctx, tr.Spec.TaskSpec.Steps[0].Ref.Name)
+			if err != nil {
+				t.Fatalf("failed to call fn: %s", err.Error())
+			}
+			if string(requester.resolverName) != tc.resolver.Resolver {
+				t.Errorf("expected the StepAction to be resolved by %s, got %s", tc.resolver.Resolver, requester.resolverName)
+			}
+			if d := cmp.Diff(tc.wantParams, requester.payload.ResolutionSpec.Params); d != "" {
+				t.Errorf("resolver params did not match: %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(sampleRefSource, resolvedRefSource); d != "" {
+				t.Errorf("refSources did not match: %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(parse.MustParseV1beta1StepAction(t, stepActionYAMLString), resolvedStepAction); d != "" {
+				t.Errorf("resolvedStepActions did not match: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestGetStepActionFunc_VersionedRef_NoDefaultResolver(t *testing.T) {
+	requester := &capturingRequester{}
+	tr := &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "tr", Namespace: "default"},
+		Spec: v1.TaskRunSpec{
+			TaskSpec: &v1.TaskSpec{
+				Steps: []v1.Step{{
+					Ref: &v1.Ref{Name: "build-push", Version: "0.3"},
+				}},
+			},
+		},
+	}
+	fn := resources.GetStepActionFunc(fake.NewSimpleClientset(), nil, requester, tr, *tr.Spec.TaskSpec, &tr.Spec.TaskSpec.Steps[0])
+	_, _, err := fn(t.Context(), tr.Spec.TaskSpec.Steps[0].Ref.Name)
+	wantErr := "StepAction build-push references the version 0.3 but default-step-action-resolver isn't configured in config-defaults"
+	if err == nil || err.Error() != wantErr {
+		t.Fatalf("expected error %q, got %v", wantErr, err)
+	}
+	if requester.resolverName != "" {
+		t.Errorf("expected no resolution request, got one for %s", requester.resolverName)
+	}
+}
+
 func TestGetTaskFuncFromTaskRunSpecAlreadyFetched(t *testing.T) {
 	ctx := t.Context()
 	ctx, cancel := context.WithCancel(ctx)
@@ -0,0 +1,92 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+// ValidateObjectResultSchema returns an error naming the result and its
+// properties if the value of an object result misses some of the declared
+// properties or has properties whose value isn't a string. An object with
+// values which aren't strings is parsed as a string result holding its JSON,
+// which is decoded to report the properties of the wrong type. Values which
+// aren't objects are left to the validation of the result types.
+func ValidateObjectResultSchema(name string, properties map[string]v1.PropertySpec, value v1.ResultValue) error {
+	if len(properties) == 0 {
+		return nil
+	}
+	var object map[string]any
+	switch value.Type {
+	case v1.ParamTypeObject:
+		object = make(map[string]any, len(value.ObjectVal))
+		for k, v := range value.ObjectVal {
+			object[k] = v
+		}
+	case v1.ParamTypeString:
+		if err := json.Unmarshal([]byte(value.StringVal), &object); err != nil || object == nil {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	keys := make([]string, 0, len(properties))
+	for k := range properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var mismatches []string
+	for _, k := range keys {
+		v, ok := object[k]
+		if !ok {
+			mismatches = append(mismatches, fmt.Sprintf("result %q is missing property %q", name, k))
+			continue
+		}
+		if _, ok := v.(string); !ok {
+			mismatches = append(mismatches, fmt.Sprintf("property %q of result %q must be a string but is %s", k, name, jsonType(v)))
+		}
+	}
+	if len(mismatches) > 0 {
+		return errors.New(strings.Join(mismatches, ", "))
+	}
+	return nil
+}
+
+// jsonType returns the JSON type of a decoded value.
+func jsonType(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "a boolean"
+	case float64:
+		return "a number"
+	case []any:
+		return "an array"
+	case map[string]any:
+		return "an object"
+	default:
+		return "a string"
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources_test
+
+import (
+	"testing"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
+)
+
+func TestValidateObjectResultSchema(t *testing.T) {
+	properties := map[string]v1.PropertySpec{"url": {Type: v1.ParamTypeString}, "commit": {Type: v1.ParamTypeString}}
+	for _, tc := range []struct {
+		name       string
+		properties map[string]v1.PropertySpec
+		value      v1.ResultValue
+		wantErr    string
+	}{{
+		name:       "conforming object",
+		properties: properties,
+		value:      *v1.NewObject(map[string]string{"url": "abc", "commit": "xyz", "extra": "value"}),
+	}, {
+		name:  "no declared properties",
+		value: *v1.NewStructuredValues(`{"url":1}`),
+	}, {
+		name:       "string which isn't an object",
+		properties: properties,
+		value:      *v1.NewStructuredValues("abc"),
+	}, {
+		name:       "missing properties",
+		properties: properties,
+		value:      *v1.NewObject(map[string]string{"url": "abc"}),
+		wantErr:    `result "result" is missing property "commit"`,
+	}, {
+		name:       "properties of the wrong type",
+		properties: properties,
+		value:      *v1.NewStructuredValues(`{"url":["abc"],"commit":true}`),
+		wantErr:    `property "commit" of result "result" must be a string but is a boolean, property "url" of result "result" must be a string but is an array`,
+	}, {
+		name:       "missing property and property of the wrong type",
+		properties: properties,
+		value:      *v1.NewStructuredValues(`{"commit":null}`),
+		wantErr:    `property "commit" of result "result" must be a string but is null, result "result" is missing property "url"`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := resources.ValidateObjectResultSchema("result", tc.properties, tc.value)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Errorf("expected no error but got %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.wantErr {
+				t.Errorf("expected error %q but got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
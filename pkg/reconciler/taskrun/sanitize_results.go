@@ -0,0 +1,169 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// redactedValue replaces the secrets found in the results.
+const redactedValue = "[redacted]"
+
+var (
+	// wordPattern splits the results into the words checked for their
+	// entropy, keeping the base64 and URL-safe alphabets and padding together.
+	wordPattern = regexp.MustCompile(`[A-Za-z0-9+/_-]+=*`)
+	// hexPattern matches the digests, commit SHAs and UUIDs, which are random
+	// but are not secrets and are commonly emitted as results.
+	hexPattern = regexp.MustCompile(`^[0-9a-fA-F-]+$`)
+)
+
+// resultSanitizer redacts the matches of the configured patterns and the
+// high-entropy words from the values of the results.
+type resultSanitizer struct {
+	patterns         []*regexp.Regexp
+	entropyThreshold float64
+	minEntropyLength int
+}
+
+// sanitizeResults redacts the secrets leaked into the results and the step
+// results of the status of the TaskRun, and records the names of the
+// sanitized results, but not their values, in an annotation. It is a noop
+// when no sanitization is configured.
+func sanitizeResults(tr *v1.TaskRun, cfg *config.ResultSanitization) error {
+	if cfg == nil {
+		return nil
+	}
+	patterns, err := cfg.CompilePatterns()
+	if err != nil {
+		return err
+	}
+	s := &resultSanitizer{
+		patterns:         patterns,
+		entropyThreshold: cfg.EntropyThreshold,
+		minEntropyLength: cfg.MinEntropyLength,
+	}
+	if s.minEntropyLength == 0 {
+		s.minEntropyLength = config.DefaultMinEntropyLength
+	}
+
+	sanitized := sets.New[string]()
+	for i := range tr.Status.Results {
+		if s.sanitize(&tr.Status.Results[i].Value) {
+			sanitized.Insert(tr.Status.Results[i].Name)
+		}
+	}
+	for i := range tr.Status.Steps {
+		step := &tr.Status.Steps[i]
+		for j := range step.Results {
+			if s.sanitize(&step.Results[j].Value) {
+				sanitized.Insert(step.Name + "." + step.Results[j].Name)
+			}
+		}
+	}
+	if sanitized.Len() == 0 {
+		return nil
+	}
+
+	if tr.Annotations == nil {
+		tr.Annotations = map[string]string{}
+	}
+	if previous := tr.Annotations[v1.SanitizedResultsAnnotationKey]; previous != "" {
+		sanitized.Insert(strings.Split(previous, ",")...)
+	}
+	tr.Annotations[v1.SanitizedResultsAnnotationKey] = strings.Join(sets.List(sanitized), ",")
+	return nil
+}
+
+// sanitize redacts the secrets from the string, the elements of the array or
+// the values of the properties of the object, and returns true if any was
+// redacted.
+func (s *resultSanitizer) sanitize(value *v1.ResultValue) bool {
+	redacted := false
+	switch value.Type {
+	case v1.ParamTypeString:
+		value.StringVal, redacted = s.sanitizeString(value.StringVal)
+	case v1.ParamTypeArray:
+		for i, v := range value.ArrayVal {
+			var r bool
+			value.ArrayVal[i], r = s.sanitizeString(v)
+			redacted = redacted || r
+		}
+	case v1.ParamTypeObject:
+		for k, v := range value.ObjectVal {
+			var r bool
+			value.ObjectVal[k], r = s.sanitizeString(v)
+			redacted = redacted || r
+		}
+	}
+	return redacted
+}
+
+func (s *resultSanitizer) sanitizeString(value string) (string, bool) {
+	sanitized := value
+	for _, p := range s.patterns {
+		sanitized = p.ReplaceAllLiteralString(sanitized, redactedValue)
+	}
+	if s.entropyThreshold > 0 {
+		sanitized = wordPattern.ReplaceAllStringFunc(sanitized, func(word string) string {
+			if s.isRandomToken(word) {
+				return redactedValue
+			}
+			return word
+		})
+	}
+	return sanitized, sanitized != value
+}
+
+// isRandomToken returns true if the word looks like a generated secret: it is
+// long enough, mixes letters and digits, is not a hexadecimal digest and its
+// entropy reaches the threshold.
+func (s *resultSanitizer) isRandomToken(word string) bool {
+	if len(word) < s.minEntropyLength || hexPattern.MatchString(word) {
+		return false
+	}
+	if !strings.ContainsAny(word, "0123456789") || strings.IndexFunc(word, isLetter) < 0 {
+		return false
+	}
+	return shannonEntropy(word) >= s.entropyThreshold
+}
+
+func isLetter(r rune) bool {
+	return ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z')
+}
+
+// shannonEntropy returns the entropy of the characters of the word, in bits
+// per character.
+func shannonEntropy(word string) float64 {
+	counts := map[rune]int{}
+	for _, r := range word {
+		counts[r]++
+	}
+	entropy := 0.0
+	n := float64(len(word))
+	for _, c := range counts {
+		p := float64(c) / n
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/internal/computeresources/compare"
+	"github.com/tektoncd/pipeline/pkg/internal/computeresources/tasklevel"
+	tknreconciler "github.com/tektoncd/pipeline/pkg/reconciler"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// updateSummary refreshes the summary of the TaskRun status from the current
+// state of its steps. It is called at the end of every reconcile so that the
+// summary tracks the progress of the TaskRun and not only its final state.
+func updateSummary(tr *v1.TaskRun) {
+	if tr.Status.StartTime == nil {
+		tr.Status.Summary = nil
+		return
+	}
+
+	summary := &v1.TaskRunSummary{
+		Duration:   tknreconciler.FormatDuration(tr.Status.StartTime, tr.Status.CompletionTime),
+		TotalSteps: len(tr.Status.Steps),
+	}
+	if tr.Status.TaskSpec != nil && len(tr.Status.TaskSpec.Steps) > summary.TotalSteps {
+		summary.TotalSteps = len(tr.Status.TaskSpec.Steps)
+	}
+	for _, step := range tr.Status.Steps {
+		if step.Terminated == nil {
+			continue
+		}
+		summary.CompletedSteps++
+		if summary.FailedStep == "" && step.Terminated.ExitCode != 0 {
+			summary.FailedStep = step.Name
+		}
+	}
+	if tr.Spec.TaskRef != nil {
+		summary.Resolver = string(tr.Spec.TaskRef.Resolver)
+	}
+	summary.Devices = requestedDevices(tr)
+	tr.Status.Summary = summary
+}
+
+// requestedDevices sums the extended resources, like nvidia.com/gpu, which
+// the Steps of the TaskRun request once their compute resources are merged
+// like for the containers of its pod. A Step requesting an extended resource
+// only in its limits requests as much as its limit.
+func requestedDevices(tr *v1.TaskRun) corev1.ResourceList {
+	if tr.Status.TaskSpec == nil {
+		return nil
+	}
+	ts := tr.Status.TaskSpec.DeepCopy()
+	steps, err := v1.MergeStepsWithStepTemplate(ts.StepTemplate, ts.Steps)
+	if err != nil {
+		return nil
+	}
+	steps, err = v1.MergeStepsWithSpecs(steps, tr.Spec.StepSpecs)
+	if err != nil {
+		return nil
+	}
+	tasklevel.ApplyTaskLevelComputeResources(steps, tr.Spec.ComputeResources.DeepCopy())
+
+	var devices corev1.ResourceList
+	for _, step := range steps {
+		requested := corev1.ResourceList{}
+		for name, q := range step.ComputeResources.Limits {
+			requested[name] = q
+		}
+		for name, q := range step.ComputeResources.Requests {
+			requested[name] = q
+		}
+		for name, q := range requested {
+			if !compare.IsExtendedResource(name) {
+				continue
+			}
+			if devices == nil {
+				devices = corev1.ResourceList{}
+			}
+			total := devices[name]
+			total.Add(q)
+			devices[name] = total
+		}
+	}
+	return devices
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestUpdateSummary(t *testing.T) {
+	const gpu corev1.ResourceName = "nvidia.com/gpu"
+	startTime := &metav1.Time{Time: now.Add(-90 * time.Second)}
+	completionTime := &metav1.Time{Time: now.Add(-30 * time.Second)}
+	taskSpec := &v1.TaskSpec{Steps: []v1.Step{{Name: "clone"}, {Name: "build"}}}
+
+	for _, tc := range []struct {
+		name string
+		tr   *v1.TaskRun
+		want *v1.TaskRunSummary
+	}{{
+		name: "not started",
+		tr:   &v1.TaskRun{},
+		want: nil,
+	}, {
+		name: "started, pod not running yet",
+		tr: &v1.TaskRun{
+			Spec: v1.TaskRunSpec{TaskRef: &v1.TaskRef{ResolverRef: v1.ResolverRef{Resolver: "git"}}},
+			Status: v1.TaskRunStatus{TaskRunStatusFields: v1.TaskRunStatusFields{
+				StartTime: startTime,
+				TaskSpec:  taskSpec,
+			}},
+		},
+		want: &v1.TaskRunSummary{TotalSteps: 2, Resolver: "git"},
+	}, {
+		name: "running, first step completed",
+		tr: &v1.TaskRun{
+			Status: v1.TaskRunStatus{TaskRunStatusFields: v1.TaskRunStatusFields{
+				StartTime: startTime,
+				TaskSpec:  taskSpec,
+				Steps: []v1.StepState{{
+					Name:           "clone",
+					ContainerState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}},
+				}, {
+					Name:           "build",
+					ContainerState: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+				}},
+			}},
+		},
+		want: &v1.TaskRunSummary{CompletedSteps: 1, TotalSteps: 2},
+	}, {
+		name: "completed with a failed step",
+		tr: &v1.TaskRun{
+			Status: v1.TaskRunStatus{TaskRunStatusFields: v1.TaskRunStatusFields{
+				StartTime:      startTime,
+				CompletionTime: completionTime,
+				TaskSpec:       taskSpec,
+				Steps: []v1.StepState{{
+					Name:           "clone",
+					ContainerState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1}},
+				}, {
+					Name:           "build",
+					ContainerState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1}},
+				}},
+			}},
+		},
+		want: &v1.TaskRunSummary{Duration: "1m0s", CompletedSteps: 2, TotalSteps: 2, FailedStep: "clone"},
+	}, {
+		name: "completion time before the start time",
+		tr: &v1.TaskRun{
+			Status: v1.TaskRunStatus{TaskRunStatusFields: v1.TaskRunStatusFields{
+				StartTime:      &metav1.Time{Time: now.Add(time.Minute)},
+				CompletionTime: completionTime,
+			}},
+		},
+		want: &v1.TaskRunSummary{Duration: "0s"},
+	}, {
+		name: "steps requesting GPUs",
+		tr: &v1.TaskRun{
+			Spec: v1.TaskRunSpec{
+				StepSpecs: []v1.TaskRunStepSpec{{
+					Name:             "train",
+					ComputeResources: corev1.ResourceRequirements{Limits: corev1.ResourceList{gpu: resource.MustParse("2")}},
+				}},
+			},
+			Status: v1.TaskRunStatus{TaskRunStatusFields: v1.TaskRunStatusFields{
+				StartTime: startTime,
+				TaskSpec: &v1.TaskSpec{Steps: []v1.Step{{
+					Name: "prepare",
+					ComputeResources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1"), gpu: resource.MustParse("1")},
+					},
+				}, {
+					Name: "train",
+				}}},
+			}},
+		},
+		want: &v1.TaskRunSummary{TotalSteps: 2, Devices: corev1.ResourceList{gpu: resource.MustParse("3")}},
+	}, {
+		name: "task-level GPUs",
+		tr: &v1.TaskRun{
+			Spec: v1.TaskRunSpec{
+				ComputeResources: &corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi"), gpu: resource.MustParse("1")},
+				},
+			},
+			Status: v1.TaskRunStatus{TaskRunStatusFields: v1.TaskRunStatusFields{
+				StartTime: startTime,
+				TaskSpec:  taskSpec,
+			}},
+		},
+		want: &v1.TaskRunSummary{TotalSteps: 2, Devices: corev1.ResourceList{gpu: resource.MustParse("1")}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			updateSummary(tc.tr)
+			if d := cmp.Diff(tc.want, tc.tr.Status.Summary); d != "" {
+				t.Errorf("Unexpected summary %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
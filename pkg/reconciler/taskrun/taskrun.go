@@ -42,8 +42,10 @@ import (
 	podconvert "github.com/tektoncd/pipeline/pkg/pod"
 	tknreconciler "github.com/tektoncd/pipeline/pkg/reconciler"
 	"github.com/tektoncd/pipeline/pkg/reconciler/apiserver"
+	"github.com/tektoncd/pipeline/pkg/reconciler/debuglog"
 	"github.com/tektoncd/pipeline/pkg/reconciler/events"
 	"github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
+	"github.com/tektoncd/pipeline/pkg/reconciler/ratelimit"
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
 	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
 	"github.com/tektoncd/pipeline/pkg/remote"
@@ -89,10 +91,13 @@ type Reconciler struct {
 	verificationPolicyLister alphalisters.VerificationPolicyLister
 	cloudEventClient         cloudevent.CEClient
 	entrypointCache          podconvert.EntrypointCache
+	imageSignatureLookup     podconvert.ImageSignatureLookup
 	metrics                  *taskrunmetrics.Recorder
 	pvcHandler               volumeclaim.PvcHandler
 	resolutionRequester      resolution.Requester
 	tracerProvider           trace.TracerProvider
+	// creationBreaker pauses the creations while the API server throttles them.
+	creationBreaker *ratelimit.CreationBreaker
 }
 
 const ImagePullBackOff = "ImagePullBackOff"
@@ -115,6 +120,7 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, tr *v1.TaskRun) pkgrecon
 	logger := logging.FromContext(ctx)
 	ctx = cloudevent.ToContext(ctx, c.cloudEventClient)
 	ctx = initTracing(ctx, c.tracerProvider, tr)
+	ctx = debuglog.ToContext(ctx, debuglog.New(tr))
 	ctx, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "TaskRun:ReconcileKind")
 	defer span.End()
 
@@ -179,6 +185,17 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, tr *v1.TaskRun) pkgrecon
 		return c.finishReconcileUpdateEmitEvents(ctx, tr, before, err)
 	}
 
+	// If the TaskRun is superseded, kill resources like for a cancellation but with a neutral reason
+	if tr.IsSuperseded() {
+		message := fmt.Sprintf("TaskRun %q was superseded. %s", tr.Name, tr.Spec.StatusMessage)
+		err := c.failTaskRun(ctx, tr, v1.TaskRunReasonSuperseded, message)
+		return c.finishReconcileUpdateEmitEvents(ctx, tr, before, err)
+	}
+
+	// Record when the TaskRun is paused and resumed, so that the time it is
+	// paused doesn't count towards its timeout.
+	updatePauseWindows(tr, c.Clock.Now())
+
 	// Check if the TaskRun has timed out; if it is, this will set its status
 	// accordingly.
 	if tr.HasTimedOut(ctx, c.Clock) {
@@ -226,8 +243,8 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, tr *v1.TaskRun) pkgrecon
 	}
 
 	if tr.Status.StartTime != nil {
-		// Compute the time since the task started.
-		elapsed := c.Clock.Since(tr.Status.StartTime.Time)
+		// Compute the time since the task started, excluding the time it was paused.
+		elapsed := c.Clock.Since(tr.Status.StartTime.Time) - tr.PausedDuration(c.Clock)
 		// Snooze this resource until the timeout has elapsed.
 		timeout := tr.GetTimeout(ctx)
 		waitTime := timeout - elapsed
@@ -322,11 +339,11 @@ func (c *Reconciler) stopSidecars(ctx context.Context, tr *v1.TaskRun) error {
 		return nil
 	}
 
-	// do not continue if the TaskRun was canceled or timed out as this caused the pod to be deleted in failTaskRun
+	// do not continue if the TaskRun was canceled, superseded or timed out as this caused the pod to be deleted in failTaskRun
 	condition := tr.Status.GetCondition(apis.ConditionSucceeded)
 	if condition != nil {
 		reason := v1.TaskRunReason(condition.Reason)
-		if reason == v1.TaskRunReasonCancelled || reason == v1.TaskRunReasonTimedOut {
+		if reason == v1.TaskRunReasonCancelled || reason == v1.TaskRunReasonSuperseded || reason == v1.TaskRunReasonTimedOut {
 			return nil
 		}
 	}
@@ -362,10 +379,14 @@ func (c *Reconciler) finishReconcileUpdateEmitEvents(ctx context.Context, tr *v1
 	logger := logging.FromContext(ctx)
 
 	afterCondition := tr.Status.GetCondition(apis.ConditionSucceeded)
-	if afterCondition.IsFalse() && !tr.IsCancelled() && tr.IsRetriable() {
+	if afterCondition.IsFalse() && !tr.IsCancelled() && !tr.IsSuperseded() && tr.IsRetriable() {
+		c.cleanupFailedAttemptPVCs(ctx, tr)
 		retryTaskRun(tr, afterCondition.Message)
 		afterCondition = tr.Status.GetCondition(apis.ConditionSucceeded)
 	}
+	updateSummary(tr)
+	debuglog.FromContext(ctx).Write(&tr.Status.Status)
+	tknreconciler.ObserveGeneration(tr, &tr.Status.Status)
 	// Send k8s events and cloud events (when configured)
 	events.Emit(ctx, beforeCondition, afterCondition, tr)
 
@@ -374,8 +395,10 @@ func (c *Reconciler) finishReconcileUpdateEmitEvents(ctx context.Context, tr *v1
 	// If the Run has been completed before and remains so at present,
 	// no need to update the labels and annotations
 	skipUpdateLabelsAndAnnotations := !afterCondition.IsUnknown() && !beforeCondition.IsUnknown()
+	var newTr *v1.TaskRun
 	if !skipUpdateLabelsAndAnnotations {
-		_, err := c.updateLabelsAndAnnotations(ctx, tr)
+		var err error
+		newTr, err = c.updateLabelsAndAnnotations(ctx, tr)
 		if err != nil {
 			logger.Warn("Failed to update TaskRun labels/annotations", zap.Error(err))
 			events.EmitError(controller.GetEventRecorder(ctx), err, tr)
@@ -386,6 +409,9 @@ func (c *Reconciler) finishReconcileUpdateEmitEvents(ctx context.Context, tr *v1
 	if controller.IsPermanentError(previousError) {
 		return controller.NewPermanentError(joinedErr)
 	}
+	if joinedErr == nil && newTr != nil {
+		return tknreconciler.RequeueIfSpecUpdated(ctx, tr, newTr)
+	}
 	return joinedErr
 }
 
@@ -401,6 +427,7 @@ func (c *Reconciler) prepare(ctx context.Context, tr *v1.TaskRun) (*v1.TaskSpec,
 	ctx, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "prepare")
 	defer span.End()
 	logger := logging.FromContext(ctx)
+	trail := debuglog.FromContext(ctx)
 	tr.SetDefaults(ctx)
 
 	// list VerificationPolicies for trusted resources
@@ -415,6 +442,7 @@ func (c *Reconciler) prepare(ctx context.Context, tr *v1.TaskRun) (*v1.TaskSpec,
 	case errors.Is(err, remote.ErrRequestInProgress):
 		message := fmt.Sprintf("TaskRun %s/%s awaiting remote resource", tr.Namespace, tr.Name)
 		tr.Status.MarkResourceOngoing(v1.TaskRunReasonResolvingTaskRef, message)
+		trail.Record(debuglog.StageResolution, "", "in progress", "awaiting remote Task")
 		return nil, nil, err
 	case errors.Is(err, apiserver.ErrReferencedObjectValidationFailed), errors.Is(err, apiserver.ErrCouldntValidateObjectPermanent):
 		tr.Status.MarkResourceFailed(v1.TaskRunReasonTaskFailedValidation, err)
@@ -423,23 +451,46 @@ func (c *Reconciler) prepare(ctx context.Context, tr *v1.TaskRun) (*v1.TaskSpec,
 		return nil, nil, err
 	case err != nil:
 		logger.Errorf("Failed to determine Task spec to use for taskrun %s: %v", tr.Name, err)
+		trail.Record(debuglog.StageResolution, "", "failed", err.Error())
 		if resolutioncommon.IsErrTransient(err) {
 			return nil, nil, err
 		}
 		tr.Status.MarkResourceFailed(v1.TaskRunReasonFailedResolution, err)
 		return nil, nil, controller.NewPermanentError(err)
 	default:
+		trail.Record(debuglog.StageResolution, taskMeta.Name, "resolved", "")
 		// Store the fetched TaskSpec on the TaskRun for auditing
 		if err := storeTaskSpecAndMergeMeta(ctx, tr, taskSpec, taskMeta); err != nil {
 			logger.Errorf("Failed to store TaskSpec on TaskRun.Status for taskrun %s: %v", tr.Name, err)
 		}
 	}
 
+	if len(taskSpec.ParamsFrom) > 0 {
+		params, results, expanded, err := resources.ExpandParamsFrom(ctx, taskSpec.Params, taskSpec.Results, taskSpec.ParamsFrom, resources.GetParameterSetFunc(c.PipelineClientSet, tr.Namespace))
+		switch {
+		case errors.Is(err, resources.ErrParameterSetNotFound), errors.Is(err, resources.ErrCircularParameterSetReference):
+			tr.Status.MarkResourceFailed(v1.TaskRunReasonInvalidParameterSet, err)
+			return nil, nil, controller.NewPermanentError(err)
+		case err != nil:
+			logger.Errorf("Failed to expand the paramsFrom of taskrun %s: %v", tr.Name, err)
+			return nil, nil, err
+		}
+		// The expanded params and results are stored with the TaskSpec, and the
+		// expanded ParameterSets are recorded for the TaskRun to be reproducible.
+		taskSpec.Params = params
+		taskSpec.Results = results
+		taskSpec.ParamsFrom = nil
+		if tr.Status.ExpandedParameterSets == nil {
+			tr.Status.ExpandedParameterSets = expanded
+		}
+	}
+
 	steps, err := resources.GetStepActionsData(ctx, *taskSpec, tr, c.PipelineClientSet, c.KubeClientSet, c.resolutionRequester)
 	switch {
 	case errors.Is(err, remote.ErrRequestInProgress):
 		message := fmt.Sprintf("TaskRun %s/%s awaiting remote StepAction", tr.Namespace, tr.Name)
 		tr.Status.MarkResourceOngoing(v1.TaskRunReasonResolvingStepActionRef, message)
+		trail.Record(debuglog.StageResolution, "", "in progress", "awaiting remote StepAction")
 		return nil, nil, err
 	case errors.Is(err, apiserver.ErrReferencedObjectValidationFailed), errors.Is(err, apiserver.ErrCouldntValidateObjectPermanent):
 		tr.Status.MarkResourceFailed(v1.TaskRunReasonTaskFailedValidation, err)
@@ -448,6 +499,7 @@ func (c *Reconciler) prepare(ctx context.Context, tr *v1.TaskRun) (*v1.TaskSpec,
 		return nil, nil, err
 	case err != nil:
 		logger.Errorf("Failed to determine StepAction to use for TaskRun %s: %v", tr.Name, err)
+		trail.Record(debuglog.StageResolution, "", "failed", err.Error())
 		if resolutioncommon.IsErrTransient(err) {
 			return nil, nil, err
 		}
@@ -462,6 +514,7 @@ func (c *Reconciler) prepare(ctx context.Context, tr *v1.TaskRun) (*v1.TaskSpec,
 	}
 
 	if taskMeta.VerificationResult != nil {
+		trail.RecordVerification(taskMeta.Name, taskMeta.VerificationResult)
 		switch taskMeta.VerificationResult.VerificationResultType {
 		case trustedresources.VerificationError:
 			logger.Errorf("TaskRun %s/%s referred task failed signature verification", tr.Namespace, tr.Name)
@@ -614,8 +667,9 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1.TaskRun, rtr *resourc
 	// Please note that this block is required to run before `applyParamsContextsResultsAndWorkspaces` is called the first time,
 	// and that `applyParamsContextsResultsAndWorkspaces` _must_ be called on every reconcile.
 	if pod == nil && tr.HasVolumeClaimTemplate() {
+		attempt := pvcAttempt(ctx, len(tr.Status.RetriesStatus))
 		for _, ws := range tr.Spec.Workspaces {
-			if err := c.pvcHandler.CreatePVCFromVolumeClaimTemplate(ctx, ws, *kmeta.NewControllerRef(tr), tr.Namespace); err != nil {
+			if err := c.pvcHandler.CreatePVCFromVolumeClaimTemplateForAttempt(ctx, ws, *kmeta.NewControllerRef(tr), tr.Namespace, attempt); err != nil {
 				logger.Errorf("Failed to create PVC for TaskRun %s: %v", tr.Name, err)
 				tr.Status.MarkResourceFailed(volumeclaim.ReasonCouldntCreateWorkspacePVC,
 					fmt.Errorf("failed to create PVC for TaskRun %s workspaces correctly: %w",
@@ -624,7 +678,7 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1.TaskRun, rtr *resourc
 			}
 		}
 
-		taskRunWorkspaces := applyVolumeClaimTemplates(tr.Spec.Workspaces, *kmeta.NewControllerRef(tr))
+		taskRunWorkspaces := applyVolumeClaimTemplates(tr.Spec.Workspaces, *kmeta.NewControllerRef(tr), attempt)
 		// This is used by createPod below. Changes to the Spec are not updated.
 		tr.Spec.Workspaces = taskRunWorkspaces
 	}
@@ -649,15 +703,17 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1.TaskRun, rtr *resourc
 		if err != nil {
 			newErr := c.handlePodCreationError(tr, err)
 			logger.Errorf("Failed to create task run pod for taskrun %q: %v", tr.Name, newErr)
+			debuglog.FromContext(ctx).Record(debuglog.StagePod, "", "not created", newErr.Error())
 			return newErr
 		}
+		debuglog.FromContext(ctx).Record(debuglog.StagePod, pod.Name, "created", "")
 	}
 
 	if podconvert.IsPodExceedingNodeResources(pod) {
 		recorder.Eventf(tr, corev1.EventTypeWarning, podconvert.ReasonExceededNodeResources, "Insufficient resources to schedule pod %q", pod.Name)
 	}
 
-	if podconvert.SidecarsReady(pod.Status) {
+	if podconvert.SidecarsReady(pod.Status, podconvert.ReadinessExcludedContainers(pod)...) {
 		if err := podconvert.UpdateReady(ctx, c.KubeClientSet, *pod); err != nil {
 			return err
 		}
@@ -665,6 +721,25 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1.TaskRun, rtr *resourc
 			logger.Warnf("Failed to log the metrics : %v", err)
 		}
 	}
+	// Signal the steps depending on sidecars which of them are ready, and
+	// stop the sidecars whose steps are finished.
+	if err := podconvert.UpdateSidecarsReady(ctx, c.KubeClientSet, pod); err != nil {
+		return err
+	}
+	stoppedPod, err := podconvert.StopSidecarsAfterSteps(ctx, c.Images.NopImage, c.KubeClientSet, pod)
+	if k8serrors.IsConflict(err) {
+		// The pod changed since it was listed, retry with the new pod.
+		return controller.NewRequeueAfter(time.Second)
+	} else if err != nil {
+		return err
+	}
+	pod = stoppedPod
+
+	if config.FromContextOrDefaults(ctx).FeatureFlags.EnableTaskRunPause {
+		if err := podconvert.UpdatePause(ctx, c.KubeClientSet, pod, tr.IsPaused()); err != nil {
+			return err
+		}
+	}
 
 	// Convert the Pod's status to the equivalent TaskRun Status.
 	tr.Status, err = podconvert.MakeTaskRunStatus(ctx, logger, *tr, pod, c.KubeClientSet, rtr.TaskSpec)
@@ -672,6 +747,36 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1.TaskRun, rtr *resourc
 		return err
 	}
 
+	// The results are validated against their schema before they are
+	// redacted, which may not match it anymore.
+	var schemaErr error
+	if config.FromContextOrDefaults(ctx).FeatureFlags.EnableResultSchemaValidation {
+		schemaErr = validateTaskRunResultsSchema(tr, rtr.TaskSpec)
+	}
+
+	// Redact the secrets leaked into the results before they are recorded
+	// in the status and emitted in events, including when the TaskRun fails
+	// below.
+	if err := sanitizeResults(tr, config.FromContextOrDefaults(ctx).Defaults.DefaultResultSanitization); err != nil {
+		return err
+	}
+
+	// Fail the TaskRun when a sidecar crashed while its steps are running,
+	// rather than letting the steps run until the TaskRun times out.
+	if failure := podconvert.FailedSidecar(pod, c.Images.NopImage); failure != nil && !tr.IsDone() {
+		message := fmt.Sprintf("sidecar %q in TaskRun %q terminated with exit code %d while its steps were running", failure.Name, tr.Name, failure.ExitCode)
+		return c.failTaskRun(ctx, tr, v1.TaskRunReasonSidecarFailed, message)
+	}
+
+	if tr.IsPaused() && !tr.IsDone() {
+		tr.Status.MarkResourceOngoing(v1.TaskRunReasonPaused, fmt.Sprintf("TaskRun %q is paused, its next steps start once it is resumed", tr.Name))
+	}
+
+	if schemaErr != nil {
+		tr.Status.MarkResourceFailed(v1.TaskRunReasonResultSchemaMismatch, schemaErr)
+		return schemaErr
+	}
+
 	if err := validateTaskRunResults(tr, rtr.TaskSpec); err != nil {
 		tr.Status.MarkResourceFailed(v1.TaskRunReasonFailedValidation, err)
 		return err
@@ -681,6 +786,19 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1.TaskRun, rtr *resourc
 	return nil
 }
 
+// updatePauseWindows opens a PauseWindow when the TaskRun is paused and closes
+// it when the TaskRun is resumed.
+func updatePauseWindows(tr *v1.TaskRun, now time.Time) {
+	windows := tr.Status.PauseWindows
+	open := len(windows) > 0 && windows[len(windows)-1].EndTime == nil
+	switch {
+	case tr.IsPaused() && !open:
+		tr.Status.PauseWindows = append(windows, v1.PauseWindow{StartTime: metav1.NewTime(now)})
+	case !tr.IsPaused() && open:
+		windows[len(windows)-1].EndTime = &metav1.Time{Time: now}
+	}
+}
+
 func (c *Reconciler) updateTaskRunWithDefaultWorkspaces(ctx context.Context, tr *v1.TaskRun, taskSpec *v1.TaskSpec) error {
 	ctx, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "updateTaskRunWithDefaultWorkspaces")
 	defer span.End()
@@ -731,19 +849,40 @@ func (c *Reconciler) updateLabelsAndAnnotations(ctx context.Context, tr *v1.Task
 	if err != nil {
 		return nil, fmt.Errorf("error getting TaskRun %s when updating labels/annotations: %w", tr.Name, err)
 	}
-	if !reflect.DeepEqual(tr.ObjectMeta.Labels, newTr.ObjectMeta.Labels) || !reflect.DeepEqual(tr.ObjectMeta.Annotations, newTr.ObjectMeta.Annotations) {
-		// Note that this uses Update vs. Patch because the former is significantly easier to test.
-		// If we want to switch this to Patch, then we will need to teach the utilities in test/controller.go
-		// to deal with Patch (setting resourceVersion, and optimistic concurrency checks).
+	if reflect.DeepEqual(tr.ObjectMeta.Labels, newTr.ObjectMeta.Labels) && reflect.DeepEqual(tr.ObjectMeta.Annotations, newTr.ObjectMeta.Annotations) {
+		return newTr, nil
+	}
+	// Note that this uses Update vs. Patch because the former is significantly easier to test.
+	// If we want to switch this to Patch, then we will need to teach the utilities in test/controller.go
+	// to deal with Patch (setting resourceVersion, and optimistic concurrency checks).
+	var updated *v1.TaskRun
+	err = pkgreconciler.RetryUpdateConflicts(func(attempts int) error {
+		// The first attempt uses the lister's copy, the next ones the latest TaskRun, so that
+		// the labels and annotations are merged into concurrent edits of the spec, like a
+		// cancellation, rather than overwriting them with a stale spec.
+		if attempts > 0 {
+			newTr, err = c.PipelineClientSet.TektonV1().TaskRuns(tr.Namespace).Get(ctx, tr.Name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+		}
 		newTr = newTr.DeepCopy()
 		newTr.Labels = kmap.Union(newTr.Labels, tr.Labels)
 		newTr.Annotations = kmap.Union(kmap.ExcludeKeys(newTr.Annotations, tknreconciler.KubectlLastAppliedAnnotationKey), tr.Annotations)
-		return c.PipelineClientSet.TektonV1().TaskRuns(tr.Namespace).Update(ctx, newTr, metav1.UpdateOptions{})
-	}
-	return newTr, nil
+		updated, err = c.PipelineClientSet.TektonV1().TaskRuns(tr.Namespace).Update(ctx, newTr, metav1.UpdateOptions{})
+		return err
+	})
+	return updated, err
 }
 
 func (c *Reconciler) handlePodCreationError(tr *v1.TaskRun, err error) error {
+	if retryAfter, ok := ratelimit.CreationRetryAfter(err); ok {
+		// The API server is overloaded: the creation of the pod is retried
+		// once it recovers rather than failing the TaskRun.
+		tr.Status.StartTime = nil
+		tr.Status.MarkResourceOngoing(v1.TaskRunReasonWaiting, fmt.Sprint("Waiting for the API server to accept the creation of the pod: ", err))
+		return controller.NewRequeueAfter(retryAfter)
+	}
 	switch {
 	case isResourceQuotaConflictError(err):
 		// Requeue if it runs into ResourceQuotaConflictError Error i.e https://github.com/kubernetes/kubernetes/issues/67761
@@ -761,6 +900,12 @@ func (c *Reconciler) handlePodCreationError(tr *v1.TaskRun, err error) error {
 		tr.Status.MarkResourceOngoing(podconvert.ReasonPodPending, "tried to create pod, but it already exists")
 	case isPodAdmissionFailed(err):
 		tr.Status.MarkResourceFailed(podconvert.ReasonPodAdmissionFailed, err)
+	case errors.Is(err, podconvert.ErrStepImagePinning):
+		err = controller.NewPermanentError(err)
+		tr.Status.MarkResourceFailed(podconvert.ReasonStepImagePinningFailed, err)
+	case errors.Is(err, podconvert.ErrInvalidWorkingDir):
+		err = controller.NewPermanentError(err)
+		tr.Status.MarkResourceFailed(podconvert.ReasonInvalidWorkingDir, err)
 	default:
 		// The pod creation failed with unknown reason. The most likely
 		// reason is that something is wrong with the spec of the Task, that we could
@@ -805,7 +950,7 @@ func (c *Reconciler) failTaskRun(ctx context.Context, tr *v1.TaskRun, reason v1.
 	terminateStepsInPod(tr, reason)
 
 	var err error
-	if reason == v1.TaskRunReasonCancelled && (config.FromContextOrDefaults(ctx).FeatureFlags.EnableKeepPodOnCancel) {
+	if (reason == v1.TaskRunReasonCancelled || reason == v1.TaskRunReasonSuperseded) && (config.FromContextOrDefaults(ctx).FeatureFlags.EnableKeepPodOnCancel) {
 		logger.Infof("Canceling task run %q by entrypoint", tr.Name)
 		err = podconvert.CancelPod(ctx, c.KubeClientSet, tr.Namespace, tr.Status.PodName)
 	} else {
@@ -890,12 +1035,19 @@ func (c *Reconciler) createPod(ctx context.Context, ts *v1.TaskSpec, tr *v1.Task
 	ts = resources.ApplyCredentialsPath(ts, pipeline.CredsDir)
 
 	podbuilder := podconvert.Builder{
-		Images:          c.Images,
-		KubeClient:      c.KubeClientSet,
-		EntrypointCache: c.entrypointCache,
+		Images:               c.Images,
+		KubeClient:           c.KubeClientSet,
+		EntrypointCache:      c.entrypointCache,
+		ImageSignatureLookup: c.imageSignatureLookup,
+	}
+	// The default container resource requirements can be overridden by the
+	// namespace of the TaskRun when it was created.
+	namespaceCtx, err := config.ToContextForRecordedDefaults(ctx, tr.Annotations)
+	if err != nil {
+		logging.FromContext(ctx).Warnf("Ignoring the defaults of the namespace recorded on TaskRun %s/%s: %v", tr.Namespace, tr.Name, err)
 	}
 	pod, err := podbuilder.Build(ctx, tr, *ts,
-		defaultresourcerequirements.NewTransformer(ctx),
+		defaultresourcerequirements.NewTransformer(namespaceCtx),
 		computeresources.NewTransformer(ctx, tr.Namespace, c.limitrangeLister),
 		affinityassistant.NewTransformer(ctx, tr.Annotations),
 	)
@@ -906,7 +1058,11 @@ func (c *Reconciler) createPod(ctx context.Context, ts *v1.TaskSpec, tr *v1.Task
 	// Stash the podname in case there's create conflict so that we can try
 	// to fetch it.
 	podName := pod.Name
+	if err := c.creationBreaker.Allow(ctx); err != nil {
+		return nil, err
+	}
 	pod, err = c.KubeClientSet.CoreV1().Pods(tr.Namespace).Create(ctx, pod, metav1.CreateOptions{})
+	c.creationBreaker.Record(ctx, err)
 
 	if err == nil && willOverwritePodSetAffinity(tr) {
 		if recorder := controller.GetEventRecorder(ctx); recorder != nil {
@@ -1018,8 +1174,8 @@ func updateStoppedSidecarStatus(pod *corev1.Pod, tr *v1.TaskRun) error {
 	return nil
 }
 
-// applyVolumeClaimTemplates and return WorkspaceBindings were templates is translated to PersistentVolumeClaims
-func applyVolumeClaimTemplates(workspaceBindings []v1.WorkspaceBinding, owner metav1.OwnerReference) []v1.WorkspaceBinding {
+// applyVolumeClaimTemplates and return WorkspaceBindings were templates is translated to the PersistentVolumeClaims of the attempt
+func applyVolumeClaimTemplates(workspaceBindings []v1.WorkspaceBinding, owner metav1.OwnerReference, attempt int) []v1.WorkspaceBinding {
 	taskRunWorkspaceBindings := make([]v1.WorkspaceBinding, 0, len(workspaceBindings))
 	for _, wb := range workspaceBindings {
 		if wb.VolumeClaimTemplate == nil {
@@ -1032,7 +1188,7 @@ func applyVolumeClaimTemplates(workspaceBindings []v1.WorkspaceBinding, owner me
 			Name:    wb.Name,
 			SubPath: wb.SubPath,
 			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-				ClaimName: volumeclaim.GeneratePVCNameFromWorkspaceBinding(wb.VolumeClaimTemplate.Name, wb, owner),
+				ClaimName: volumeclaim.GeneratePVCNameForAttempt(wb.VolumeClaimTemplate.Name, wb, owner, attempt),
 			},
 		}
 		taskRunWorkspaceBindings = append(taskRunWorkspaceBindings, b)
@@ -1040,7 +1196,46 @@ func applyVolumeClaimTemplates(workspaceBindings []v1.WorkspaceBinding, owner me
 	return taskRunWorkspaceBindings
 }
 
+// pvcAttempt returns the attempt whose PersistentVolumeClaims are mounted by the attempt of a TaskRun after the
+// given number of retries: the first one when the PersistentVolumeClaims are reused across the retries, the given
+// one otherwise.
+func pvcAttempt(ctx context.Context, retries int) int {
+	if config.FromContextOrDefaults(ctx).FeatureFlags.ReuseWorkspacePVCOnRetry {
+		return 0
+	}
+	return retries
+}
+
+// cleanupFailedAttemptPVCs deletes the PersistentVolumeClaims created from the volumeClaimTemplates for the failed
+// attempt of a TaskRun about to be retried. The PersistentVolumeClaims which are also mounted by the next attempt,
+// which is always the case when they are reused across the retries, are kept. Failing to delete a
+// PersistentVolumeClaim doesn't prevent the retry, as it is still deleted with the TaskRun.
+func (c *Reconciler) cleanupFailedAttemptPVCs(ctx context.Context, tr *v1.TaskRun) {
+	logger := logging.FromContext(ctx)
+	failed := pvcAttempt(ctx, len(tr.Status.RetriesStatus))
+	next := pvcAttempt(ctx, len(tr.Status.RetriesStatus)+1)
+	owner := *kmeta.NewControllerRef(tr)
+	for _, wb := range tr.Spec.Workspaces {
+		if wb.VolumeClaimTemplate == nil {
+			continue
+		}
+		pvcName := volumeclaim.GeneratePVCNameForAttempt(wb.VolumeClaimTemplate.Name, wb, owner, failed)
+		if pvcName == volumeclaim.GeneratePVCNameForAttempt(wb.VolumeClaimTemplate.Name, wb, owner, next) {
+			continue
+		}
+		if err := c.pvcHandler.PurgeFinalizerAndDeletePVCForWorkspace(ctx, pvcName, tr.Namespace); err != nil {
+			logger.Warnf("Failed to delete the PVC %s of the failed attempt of the TaskRun %s: %v", pvcName, tr.Name, err)
+		}
+	}
+}
+
 func storeTaskSpecAndMergeMeta(ctx context.Context, tr *v1.TaskRun, ts *v1.TaskSpec, meta *resolutionutil.ResolvedObjectMeta) error {
+	// Only store the config once so that it reflects the config the TaskRun
+	// started with, even if the config changes while it runs.
+	if tr.Status.ObservedConfig == nil {
+		tr.Status.ObservedConfig = observedConfig(config.FromContextOrDefaults(ctx))
+	}
+
 	// Only store the TaskSpec once, if it has never been set before.
 	if tr.Status.TaskSpec == nil {
 		tr.Status.TaskSpec = ts
@@ -1049,7 +1244,9 @@ func storeTaskSpecAndMergeMeta(ctx context.Context, tr *v1.TaskRun, ts *v1.TaskS
 		}
 
 		// Propagate annotations from Task to TaskRun. TaskRun annotations take precedences over Task.
-		tr.ObjectMeta.Annotations = kmap.Union(kmap.ExcludeKeys(meta.Annotations, tknreconciler.KubectlLastAppliedAnnotationKey), tr.ObjectMeta.Annotations)
+		// The source event annotations describe the event which caused the run, not the Task.
+		tr.ObjectMeta.Annotations = kmap.Union(kmap.ExcludeKeys(meta.Annotations, tknreconciler.KubectlLastAppliedAnnotationKey,
+			v1.SourceEventIDAnnotationKey, v1.SourceEventTypeAnnotationKey, v1.SourceURLAnnotationKey), tr.ObjectMeta.Annotations)
 		// Propagate labels from Task to TaskRun. TaskRun labels take precedences over Task.
 		tr.ObjectMeta.Labels = kmap.Union(meta.Labels, tr.ObjectMeta.Labels)
 		if tr.Spec.TaskRef != nil {
@@ -1069,11 +1266,30 @@ func storeTaskSpecAndMergeMeta(ctx context.Context, tr *v1.TaskRun, ts *v1.TaskS
 		if meta != nil && meta.RefSource != nil && tr.Status.Provenance.RefSource == nil {
 			tr.Status.Provenance.RefSource = meta.RefSource
 		}
+		if tr.Status.Provenance.SourceEvent == nil {
+			tr.Status.Provenance.SourceEvent = v1.SourceEventFromAnnotations(tr.Annotations)
+		}
 	}
 
 	return nil
 }
 
+// observedConfig returns the subset of the feature flags and defaults of cfg
+// which affect the execution of the TaskRuns.
+func observedConfig(cfg *config.Config) *v1.ObservedConfig {
+	oc := &v1.ObservedConfig{}
+	if cfg.FeatureFlags != nil {
+		oc.Coschedule = cfg.FeatureFlags.Coschedule
+		oc.EnforceNonfalsifiability = cfg.FeatureFlags.EnforceNonfalsifiability
+		oc.ResultExtractionMethod = cfg.FeatureFlags.ResultExtractionMethod
+		oc.MaxResultSize = cfg.FeatureFlags.MaxResultSize
+	}
+	if cfg.Defaults != nil {
+		oc.DefaultTimeout = &metav1.Duration{Duration: time.Duration(cfg.Defaults.DefaultTimeoutMinutes) * time.Minute}
+	}
+	return oc
+}
+
 // willOverwritePodSetAffinity returns a bool indicating whether the
 // affinity for pods will be overwritten with affinity assistant.
 func willOverwritePodSetAffinity(taskRun *v1.TaskRun) bool {
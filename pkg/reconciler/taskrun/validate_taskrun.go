@@ -267,14 +267,7 @@ func validateSidecarOverrides(ts *v1.TaskSpec, trs *v1.TaskRunSpec) error {
 
 // validateResults checks the emitted results type and object properties against the ones defined in spec.
 func validateTaskRunResults(tr *v1.TaskRun, resolvedTaskSpec *v1.TaskSpec) error {
-	specResults := []v1.TaskResult{}
-	if tr.Spec.TaskSpec != nil {
-		specResults = append(specResults, tr.Spec.TaskSpec.Results...)
-	}
-
-	if resolvedTaskSpec != nil {
-		specResults = append(specResults, resolvedTaskSpec.Results...)
-	}
+	specResults := declaredResults(tr, resolvedTaskSpec)
 
 	// When get the results, check if the type of result is the expected one
 	if missmatchedTypes := mismatchedTypesResults(tr, specResults); len(missmatchedTypes) != 0 {
@@ -293,6 +286,45 @@ func validateTaskRunResults(tr *v1.TaskRun, resolvedTaskSpec *v1.TaskSpec) error
 	return nil
 }
 
+// validateTaskRunResultsSchema validates the object results of the TaskRun against the properties they declare,
+// and removes the ones which don't match them.
+func validateTaskRunResultsSchema(tr *v1.TaskRun, resolvedTaskSpec *v1.TaskSpec) error {
+	properties := make(map[string]map[string]v1.PropertySpec)
+	for _, r := range declaredResults(tr, resolvedTaskSpec) {
+		if r.Type == v1.ResultsTypeObject {
+			properties[r.Name] = r.Properties
+		}
+	}
+
+	var mismatches []string
+	var filteredResults []v1.TaskRunResult
+	for _, trr := range tr.Status.Results {
+		if err := resources.ValidateObjectResultSchema(trr.Name, properties[trr.Name], trr.Value); err != nil {
+			mismatches = append(mismatches, err.Error())
+			continue
+		}
+		filteredResults = append(filteredResults, trr)
+	}
+	if len(mismatches) == 0 {
+		return nil
+	}
+	tr.Status.Results = filteredResults
+	return pipelineErrors.WrapUserError(fmt.Errorf("results don't match their declared properties: %s", strings.Join(mismatches, ", ")))
+}
+
+// declaredResults returns the results declared by the TaskRun's embedded and resolved TaskSpecs.
+func declaredResults(tr *v1.TaskRun, resolvedTaskSpec *v1.TaskSpec) []v1.TaskResult {
+	specResults := []v1.TaskResult{}
+	if tr.Spec.TaskSpec != nil {
+		specResults = append(specResults, tr.Spec.TaskSpec.Results...)
+	}
+
+	if resolvedTaskSpec != nil {
+		specResults = append(specResults, resolvedTaskSpec.Results...)
+	}
+	return specResults
+}
+
 // mismatchedTypesResults checks and returns all the mismatched types of emitted results against specified results.
 func mismatchedTypesResults(tr *v1.TaskRun, specResults []v1.TaskResult) map[string]string {
 	neededTypes := make(map[string]string)
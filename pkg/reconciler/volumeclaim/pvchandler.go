@@ -42,6 +42,7 @@ const (
 // PvcHandler is used to create PVCs for workspaces
 type PvcHandler interface {
 	CreatePVCFromVolumeClaimTemplate(ctx context.Context, wb v1.WorkspaceBinding, ownerReference metav1.OwnerReference, namespace string) error
+	CreatePVCFromVolumeClaimTemplateForAttempt(ctx context.Context, wb v1.WorkspaceBinding, ownerReference metav1.OwnerReference, namespace string, attempt int) error
 	PurgeFinalizerAndDeletePVCForWorkspace(ctx context.Context, pvcName, namespace string) error
 }
 
@@ -60,7 +61,13 @@ func NewPVCHandler(clientset clientset.Interface, logger *zap.SugaredLogger) Pvc
 // resource with the volumeClaimTemplate declared, a PipelineRun or TaskRun. If the PVC did not exist, a new PVC
 // with that name is created with the provided OwnerReference.
 func (c *defaultPVCHandler) CreatePVCFromVolumeClaimTemplate(ctx context.Context, wb v1.WorkspaceBinding, ownerReference metav1.OwnerReference, namespace string) error {
-	claim := c.getPVCFromVolumeClaimTemplate(wb, ownerReference, namespace)
+	return c.CreatePVCFromVolumeClaimTemplateForAttempt(ctx, wb, ownerReference, namespace, 0)
+}
+
+// CreatePVCFromVolumeClaimTemplateForAttempt is CreatePVCFromVolumeClaimTemplate for the given attempt of a TaskRun,
+// whose PVC is named with GeneratePVCNameForAttempt. The attempt 0 uses the same PVC as CreatePVCFromVolumeClaimTemplate.
+func (c *defaultPVCHandler) CreatePVCFromVolumeClaimTemplateForAttempt(ctx context.Context, wb v1.WorkspaceBinding, ownerReference metav1.OwnerReference, namespace string, attempt int) error {
+	claim := c.getPVCFromVolumeClaimTemplate(wb, ownerReference, namespace, attempt)
 	if claim == nil {
 		return nil
 	}
@@ -135,14 +142,14 @@ func (c *defaultPVCHandler) PurgeFinalizerAndDeletePVCForWorkspace(ctx context.C
 }
 
 // getPVCFromVolumeClaimTemplate returns a PersistentVolumeClaim based on given workspaceBinding (using VolumeClaimTemplate), ownerReference and namespace
-func (c *defaultPVCHandler) getPVCFromVolumeClaimTemplate(workspaceBinding v1.WorkspaceBinding, ownerReference metav1.OwnerReference, namespace string) *corev1.PersistentVolumeClaim {
+func (c *defaultPVCHandler) getPVCFromVolumeClaimTemplate(workspaceBinding v1.WorkspaceBinding, ownerReference metav1.OwnerReference, namespace string, attempt int) *corev1.PersistentVolumeClaim {
 	if workspaceBinding.VolumeClaimTemplate == nil {
 		c.logger.Infof("workspace binding %v does not contain VolumeClaimTemplate, skipping creating PVC", workspaceBinding.Name)
 		return nil
 	}
 
 	claim := workspaceBinding.VolumeClaimTemplate.DeepCopy()
-	claim.Name = GeneratePVCNameFromWorkspaceBinding(workspaceBinding.VolumeClaimTemplate.Name, workspaceBinding, ownerReference)
+	claim.Name = GeneratePVCNameForAttempt(workspaceBinding.VolumeClaimTemplate.Name, workspaceBinding, ownerReference, attempt)
 	claim.Namespace = namespace
 	claim.OwnerReferences = []metav1.OwnerReference{ownerReference}
 
@@ -163,6 +170,20 @@ func GeneratePVCNameFromWorkspaceBinding(claimName string, wb v1.WorkspaceBindin
 	return fmt.Sprintf("%s-%s", claimName, getPersistentVolumeClaimIdentity(wb.Name, string(owner.UID)))
 }
 
+// GeneratePVCNameForAttempt gets the name of the PersistentVolumeClaim of a Workspace for the given attempt of a
+// TaskRun, i.e. the number of its retries. The attempt 0 has the name returned by GeneratePVCNameFromWorkspaceBinding
+// so that the first attempt of a TaskRun always mounts the same PersistentVolumeClaim, whether or not its retries
+// reuse it; the next attempts have a name including the attempt.
+func GeneratePVCNameForAttempt(claimName string, wb v1.WorkspaceBinding, owner metav1.OwnerReference, attempt int) string {
+	if attempt == 0 {
+		return GeneratePVCNameFromWorkspaceBinding(claimName, wb, owner)
+	}
+	if claimName == "" {
+		claimName = "pvc"
+	}
+	return fmt.Sprintf("%s-%s", claimName, getPersistentVolumeClaimIdentity(wb.Name, fmt.Sprintf("%s-%d", owner.UID, attempt)))
+}
+
 func getPersistentVolumeClaimIdentity(workspaceName, ownerName string) string {
 	hashBytes := sha256.Sum256([]byte(workspaceName + ownerName))
 	hashString := hex.EncodeToString(hashBytes[:])
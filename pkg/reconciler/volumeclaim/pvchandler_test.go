@@ -192,7 +192,7 @@ func TestCreateExistPersistentVolumeClaims(t *testing.T) {
 	pvcHandler := defaultPVCHandler{fakekubeclient, zap.NewExample().Sugar()}
 
 	for _, ws := range workspaces {
-		claim := pvcHandler.getPVCFromVolumeClaimTemplate(ws, ownerRef, namespace)
+		claim := pvcHandler.getPVCFromVolumeClaimTemplate(ws, ownerRef, namespace, 0)
 		if claim == nil {
 			t.Fatalf("expect PVC but got nil from workspace: %v", ws.Name)
 		}
@@ -295,3 +295,23 @@ func TestPurgeFinalizerAndDeletePVCForWorkspace(t *testing.T) {
 		t.Errorf("pvc %s kubernetes.io/pvc-protection finalizer is not removed properly", pvcName)
 	}
 }
+
+func TestGeneratePVCNameForAttempt(t *testing.T) {
+	wb := v1.WorkspaceBinding{Name: "source"}
+	owner := metav1.OwnerReference{UID: types.UID("taskrun-uid")}
+
+	if got, want := GeneratePVCNameForAttempt("claim", wb, owner, 0), GeneratePVCNameFromWorkspaceBinding("claim", wb, owner); got != want {
+		t.Errorf("expected the PVC of the first attempt to be named %q, got %q", want, got)
+	}
+	names := map[string]int{}
+	for attempt := range 3 {
+		name := GeneratePVCNameForAttempt("", wb, owner, attempt)
+		if name != GeneratePVCNameForAttempt("", wb, owner, attempt) {
+			t.Errorf("expected the name of the PVC of the attempt %d to be deterministic", attempt)
+		}
+		if other, ok := names[name]; ok {
+			t.Errorf("expected the attempts %d and %d to have different PVCs, got %q", other, attempt, name)
+		}
+		names[name] = attempt
+	}
+}
@@ -43,11 +43,13 @@ var _ framework.Resolver = &Resolver{}
 // Resolver implements a framework.Resolver that can fetch files from OCI bundles.
 type Resolver struct {
 	kubeClientSet kubernetes.Interface
+	layerCache    *bundle.LayerCache
 }
 
-// Initialize sets up any dependencies needed by the Resolver. None atm.
+// Initialize sets up any dependencies needed by the Resolver.
 func (r *Resolver) Initialize(ctx context.Context) error {
 	r.kubeClientSet = client.Get(ctx)
+	r.layerCache = bundle.NewLayerCache()
 	return nil
 }
 
@@ -80,7 +82,7 @@ func (r *Resolver) Validate(ctx context.Context, req *v1beta1.ResolutionRequestS
 // Resolve uses the given request spec resolve the requested file or resource.
 func (r *Resolver) Resolve(ctx context.Context, req *v1beta1.ResolutionRequestSpec) (resolutionframework.ResolvedResource, error) {
 	if len(req.Params) > 0 {
-		return bundle.ResolveRequest(ctx, r.kubeClientSet, req)
+		return bundle.ResolveRequest(ctx, r.kubeClientSet, r.layerCache, req)
 	}
 	// Remove this error once resolution of url has been implemented.
 	return nil, errors.New("the Resolve method has not been implemented.")
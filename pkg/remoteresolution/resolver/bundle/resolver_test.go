@@ -214,10 +214,13 @@ func TestValidateMissing(t *testing.T) {
 		Name:  bundleresolution.ParamImagePullSecret,
 		Value: *pipelinev1.NewStructuredValues("baz"),
 	}}
+	// The name can be omitted when the bundle contains a single object of the kind.
+	ctx := framework.InjectResolverConfigToContext(t.Context(), map[string]string{
+		bundleresolution.ConfigServiceAccount: "default",
+	})
 	req = v1beta1.ResolutionRequestSpec{Params: paramsMissingName}
-	err = resolver.Validate(t.Context(), &req)
-	if err == nil {
-		t.Fatalf("expected missing name err")
+	if err := resolver.Validate(ctx, &req); err != nil {
+		t.Fatalf("unexpected error validating params without name: %v", err)
 	}
 }
 
@@ -354,6 +357,9 @@ func TestResolve(t *testing.T) {
 		t.Fatalf("couldn't marshal pipeline: %v", err)
 	}
 
+	otherTask := exampleTask.DeepCopy()
+	otherTask.Name = "other-task"
+
 	// too many objects in bundle resolver test
 	var tooManyObjs []runtime.Object
 	for i := 0; i <= bundleresolution.MaximumBundleObjects; i++ {
@@ -382,6 +388,7 @@ func TestResolve(t *testing.T) {
 		"single-task":                     pushToRegistry(t, r, "single-task", []runtime.Object{exampleTask}, test.DefaultObjectAnnotationMapper),
 		"single-pipeline":                 pushToRegistry(t, r, "single-pipeline", []runtime.Object{examplePipeline}, test.DefaultObjectAnnotationMapper),
 		"multiple-resources":              pushToRegistry(t, r, "multiple-resources", []runtime.Object{exampleTask, examplePipeline}, test.DefaultObjectAnnotationMapper),
+		"multiple-tasks":                  pushToRegistry(t, r, "multiple-tasks", []runtime.Object{exampleTask, otherTask}, test.DefaultObjectAnnotationMapper),
 		"too-many-objs":                   pushToRegistry(t, r, "too-many-objs", tooManyObjs, asIsMapper),
 		"single-task-no-version":          pushToRegistry(t, r, "single-task-no-version", []runtime.Object{&pipelinev1beta1.Task{TypeMeta: metav1.TypeMeta{Kind: "task"}, ObjectMeta: metav1.ObjectMeta{Name: "foo"}}}, asIsMapper),
 		"single-task-no-kind":             pushToRegistry(t, r, "single-task-no-kind", []runtime.Object{&pipelinev1beta1.Task{TypeMeta: metav1.TypeMeta{APIVersion: "tekton.dev/v1beta1"}, ObjectMeta: metav1.ObjectMeta{Name: "foo"}}}, asIsMapper),
@@ -394,8 +401,10 @@ func TestResolve(t *testing.T) {
 		args               *params
 		imageName          string
 		kindInBundle       string
+		resolvedName       string
 		expectedStatus     *v1beta1.ResolutionRequestStatus
 		expectedErrMessage string
+		entryErrMessage    string
 	}{
 		{
 			name: "single task: digest is included in the bundle parameter",
@@ -451,6 +460,40 @@ func TestResolve(t *testing.T) {
 			},
 			imageName:      "multiple-resources",
 			expectedStatus: resolution.CreateResolutionRequestStatusWithData(pipelineAsYAML),
+		}, {
+			name: "single task: name omitted",
+			args: &params{
+				bundle: testImages["single-task"].uri + ":latest",
+				kind:   "task",
+			},
+			imageName:      "single-task",
+			resolvedName:   "example-task",
+			expectedStatus: resolution.CreateResolutionRequestStatusWithData(taskAsYAML),
+		}, {
+			name: "multiple resources: name omitted with a single object of the kind",
+			args: &params{
+				bundle: testImages["multiple-resources"].uri + ":latest",
+				kind:   "pipeline",
+			},
+			imageName:      "multiple-resources",
+			resolvedName:   "example-pipeline",
+			expectedStatus: resolution.CreateResolutionRequestStatusWithData(pipelineAsYAML),
+		}, {
+			name: "multiple tasks: name omitted",
+			args: &params{
+				bundle: testImages["multiple-tasks"].uri + ":latest",
+				kind:   "task",
+			},
+			expectedStatus:  resolution.CreateResolutionRequestFailureStatus(),
+			entryErrMessage: `parameter "name" required, the image contains several objects with kind: task: example-task, other-task`,
+		}, {
+			name: "single pipeline: name omitted without an object of the kind",
+			args: &params{
+				bundle: testImages["single-pipeline"].uri + ":latest",
+				kind:   "task",
+			},
+			expectedStatus:  resolution.CreateResolutionRequestFailureStatus(),
+			entryErrMessage: "could not find object in image with kind: task",
 		}, {
 			name: "too many objects in an image",
 			args: &params{
@@ -533,7 +576,15 @@ func TestResolve(t *testing.T) {
 			var expectedError error
 			if tc.expectedStatus != nil {
 				expectedStatus = tc.expectedStatus.DeepCopy()
-				if tc.expectedErrMessage == "" {
+				switch {
+				case tc.entryErrMessage != "":
+					expectedError = createEntryError(tc.entryErrMessage)
+					expectedStatus.Status.Conditions[0].Message = expectedError.Error()
+				case tc.expectedErrMessage == "":
+					name := tc.args.name
+					if tc.resolvedName != "" {
+						name = tc.resolvedName
+					}
 					if expectedStatus.Annotations == nil {
 						expectedStatus.Annotations = make(map[string]string)
 					}
@@ -547,18 +598,20 @@ func TestResolve(t *testing.T) {
 						expectedStatus.Annotations[bundleresolution.ResolverAnnotationKind] = "task"
 					}
 
-					expectedStatus.Annotations[bundleresolution.ResolverAnnotationName] = tc.args.name
+					expectedStatus.Annotations[bundleresolution.ResolverAnnotationName] = name
 					expectedStatus.Annotations[bundleresolution.ResolverAnnotationAPIVersion] = "v1beta1"
+					expectedStatus.Annotations[bundleresolution.ResolverAnnotationDigest] = testImages[tc.imageName].algo + ":" + testImages[tc.imageName].hex
+					expectedStatus.Annotations[bundleresolution.ResolverAnnotationCache] = bundleresolution.CacheMiss
 
 					expectedStatus.RefSource = &pipelinev1.RefSource{
 						URI: testImages[tc.imageName].uri,
 						Digest: map[string]string{
 							testImages[tc.imageName].algo: testImages[tc.imageName].hex,
 						},
-						EntryPoint: tc.args.name,
+						EntryPoint: name,
 					}
 					expectedStatus.Source = expectedStatus.RefSource
-				} else {
+				default:
 					expectedError = createError(tc.args.bundle, tc.expectedErrMessage)
 					expectedStatus.Status.Conditions[0].Message = expectedError.Error()
 				}
@@ -613,6 +666,14 @@ func createError(image, msg string) error {
 	}
 }
 
+func createEntryError(msg string) error {
+	return &resolutioncommon.GetResourceError{
+		ResolverName: bundle.BundleResolverName,
+		Key:          "foo/rr",
+		Original:     errors.New(msg),
+	}
+}
+
 func asIsMapper(obj runtime.Object) map[string]string {
 	annotations := map[string]string{}
 	if test.GetObjectName(obj) != "" {
@@ -0,0 +1,203 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/tektoncd/pipeline/pkg/apis/resolution"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+)
+
+const (
+	// AnnotationKeyArchiveRef is the annotation of the status of a
+	// ResolutionRequest recording the digest reference of the OCI artifact
+	// its resolved data was archived to.
+	AnnotationKeyArchiveRef = resolution.GroupName + "/archive-ref"
+	// AnnotationKeyArchiveError is the annotation of the status of a
+	// ResolutionRequest recording why its resolved data couldn't be
+	// archived. The resolution succeeds anyway.
+	AnnotationKeyArchiveError = resolution.GroupName + "/archive-error"
+
+	// ArchiveLayerMediaType is the media type of the layer of the OCI
+	// artifacts holding the resolved data.
+	ArchiveLayerMediaType types.MediaType = "application/vnd.tekton.resolved-resource.v1"
+	// ArchiveConfigMediaType is the media type of the config of the OCI
+	// artifacts holding the resolved data.
+	ArchiveConfigMediaType types.MediaType = "application/vnd.tekton.resolved-resource.config.v1+json"
+
+	// archiveSourceAnnotation is the annotation of the OCI artifacts
+	// recording the URI of the source of the resolved data.
+	archiveSourceAnnotation = "org.opencontainers.image.source"
+
+	// archiveTimeout is the maximum duration of the archiving of the
+	// resolved data of a ResolutionRequest.
+	archiveTimeout = 30 * time.Second
+)
+
+// Archiver archives the resources resolved by the resolvers, e.g. so that
+// they can be resolved again once their source is gone.
+type Archiver interface {
+	// Archive archives the resolved resource and returns a reference to
+	// its archive.
+	Archive(ctx context.Context, resource framework.ResolvedResource) (string, error)
+}
+
+// WithArchiver returns a ReconcilerModifier archiving the resources resolved
+// by the reconciler with the archiver.
+func WithArchiver(archiver Archiver) ReconcilerModifier {
+	return func(r *Reconciler) {
+		r.archiver = archiver
+	}
+}
+
+// OCIArchiver archives the resolved resources to an OCI repository, as OCI
+// artifacts holding the resolved data in their only layer and the annotations
+// of the resource in their manifest, tagged by the digest of the data.
+type OCIArchiver struct {
+	repository name.Repository
+	keychain   authn.Keychain
+	options    []remote.Option
+}
+
+// NewOCIArchiver returns an OCIArchiver pushing to the repository, e.g.
+// "registry.example.com/tekton/resolved", with the credentials of the
+// keychain.
+func NewOCIArchiver(repository string, keychain authn.Keychain, options ...remote.Option) (*OCIArchiver, error) {
+	repo, err := name.NewRepository(repository)
+	if err != nil {
+		return nil, fmt.Errorf("invalid archive repository %q: %w", repository, err)
+	}
+	return &OCIArchiver{repository: repo, keychain: keychain, options: options}, nil
+}
+
+// Archive pushes the resolved resource to the repository of the archiver and
+// returns the digest reference of the pushed artifact.
+func (a *OCIArchiver) Archive(ctx context.Context, resource framework.ResolvedResource) (string, error) {
+	layer := newRawLayer(resource.Data())
+	dataDigest, err := layer.Digest()
+	if err != nil {
+		return "", err
+	}
+	img, err := mutate.Append(empty.Image, mutate.Addendum{Layer: layer})
+	if err != nil {
+		return "", err
+	}
+	img = mutate.MediaType(img, types.OCIManifestSchema1)
+	img = mutate.ConfigMediaType(img, ArchiveConfigMediaType)
+	annotations := maps.Clone(resource.Annotations())
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	if refSource := resource.RefSource(); refSource != nil && refSource.URI != "" {
+		annotations[archiveSourceAnnotation] = refSource.URI
+	}
+	img, ok := mutate.Annotations(img, annotations).(v1.Image)
+	if !ok {
+		return "", errors.New("couldn't annotate the archive of the resolved data")
+	}
+
+	tag := a.repository.Tag(dataDigest.Algorithm + "-" + dataDigest.Hex)
+	options := append([]remote.Option{remote.WithContext(ctx), remote.WithAuthFromKeychain(a.keychain)}, a.options...)
+	if err := remote.Write(tag, img, options...); err != nil {
+		return "", fmt.Errorf("error pushing the archive of the resolved data to %s: %w", tag, err)
+	}
+	digest, err := img.Digest()
+	if err != nil {
+		return "", err
+	}
+	return a.repository.Digest(digest.String()).String(), nil
+}
+
+// rawLayer is an uncompressed layer holding the resolved data as is.
+type rawLayer struct {
+	data   []byte
+	digest v1.Hash
+}
+
+var _ v1.Layer = &rawLayer{}
+
+func newRawLayer(data []byte) *rawLayer {
+	digest, _, _ := v1.SHA256(bytes.NewReader(data))
+	return &rawLayer{data: data, digest: digest}
+}
+
+// Digest returns the digest of the data.
+func (l *rawLayer) Digest() (v1.Hash, error) {
+	return l.digest, nil
+}
+
+// DiffID returns the digest of the data, which isn't compressed.
+func (l *rawLayer) DiffID() (v1.Hash, error) {
+	return l.digest, nil
+}
+
+// Compressed returns the data, which isn't compressed.
+func (l *rawLayer) Compressed() (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(l.data)), nil
+}
+
+// Uncompressed returns the data.
+func (l *rawLayer) Uncompressed() (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(l.data)), nil
+}
+
+// Size returns the size of the data.
+func (l *rawLayer) Size() (int64, error) {
+	return int64(len(l.data)), nil
+}
+
+// MediaType returns ArchiveLayerMediaType.
+func (l *rawLayer) MediaType() (types.MediaType, error) {
+	return ArchiveLayerMediaType, nil
+}
+
+// archive archives the resolved resource with the archiver of the reconciler,
+// if any, and returns the annotations of the resource with the reference to
+// its archive, or with the error which prevented it to be archived.
+func (r *Reconciler) archive(ctx context.Context, resource framework.ResolvedResource) map[string]string {
+	annotations := resource.Annotations()
+	if r.archiver == nil {
+		return annotations
+	}
+	annotations = maps.Clone(annotations)
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	ctx, cancel := context.WithTimeout(ctx, archiveTimeout)
+	defer cancel()
+	ref, err := r.archiver.Archive(ctx, resource)
+	if err != nil {
+		annotations[AnnotationKeyArchiveError] = err.Error()
+		return annotations
+	}
+	annotations[AnnotationKeyArchiveRef] = ref
+	return annotations
+}
@@ -0,0 +1,227 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework_test
+
+import (
+	"encoding/base64"
+	"io"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
+	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"github.com/tektoncd/pipeline/pkg/remoteresolution/resolver/framework"
+	resolutioncommon "github.com/tektoncd/pipeline/pkg/resolution/common"
+	resolutionframework "github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"github.com/tektoncd/pipeline/test"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/controller"
+)
+
+// newTestRegistry returns the host of a registry served for the test.
+func newTestRegistry(t *testing.T) string {
+	t.Helper()
+	s := httptest.NewServer(registry.New())
+	t.Cleanup(s.Close)
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u.Host
+}
+
+func TestOCIArchiver(t *testing.T) {
+	repository := newTestRegistry(t) + "/tekton/resolved"
+	archiver, err := framework.NewOCIArchiver(repository, authn.DefaultKeychain)
+	if err != nil {
+		t.Fatalf("unexpected error creating the archiver: %v", err)
+	}
+	resource := &resolutionframework.FakeResolvedResource{
+		Content:       "some content",
+		AnnotationMap: map[string]string{"foo": "bar"},
+		ContentSource: &pipelinev1.RefSource{
+			URI:    "git+https://github.com/tektoncd/catalog",
+			Digest: map[string]string{"sha1": "xyz"},
+		},
+	}
+
+	ref, err := archiver.Archive(t.Context(), resource)
+	if err != nil {
+		t.Fatalf("unexpected error archiving the resource: %v", err)
+	}
+	if !strings.HasPrefix(ref, repository+"@sha256:") {
+		t.Fatalf("expected a digest reference of %s, got %s", repository, ref)
+	}
+
+	// The artifact is tagged by the digest of the data.
+	const dataDigest = "sha256-290f493c44f5d63d06b374d0a5abd292fae38b92cab2fae5efefe1b0e9347f56"
+	tagged, err := name.ParseReference(repository + ":" + dataDigest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := remote.Image(tagged)
+	if err != nil {
+		t.Fatalf("expected the archive to be tagged by the digest of the data: %v", err)
+	}
+	digest, err := img.Digest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := repository + "@" + digest.String(); ref != want {
+		t.Errorf("expected the reference %s of the tagged archive, got %s", want, ref)
+	}
+
+	manifest, err := img.Manifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantAnnotations := map[string]string{
+		"foo":                             "bar",
+		"org.opencontainers.image.source": "git+https://github.com/tektoncd/catalog",
+	}
+	if d := cmp.Diff(wantAnnotations, manifest.Annotations); d != "" {
+		t.Errorf("unexpected annotations of the archive %s", diff.PrintWantGot(d))
+	}
+	if manifest.Config.MediaType != framework.ArchiveConfigMediaType {
+		t.Errorf("expected the config media type %s, got %s", framework.ArchiveConfigMediaType, manifest.Config.MediaType)
+	}
+	layers, err := img.Layers()
+	if err != nil || len(layers) != 1 {
+		t.Fatalf("expected the archive to have one layer, got %d: %v", len(layers), err)
+	}
+	if mt, _ := layers[0].MediaType(); mt != framework.ArchiveLayerMediaType {
+		t.Errorf("expected the layer media type %s, got %s", framework.ArchiveLayerMediaType, mt)
+	}
+	rc, err := layers[0].Uncompressed()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "some content" {
+		t.Errorf("expected the archived data to be the resolved data, got %q", data)
+	}
+}
+
+func TestNewOCIArchiver_InvalidRepository(t *testing.T) {
+	if _, err := framework.NewOCIArchiver("Not A Repository", authn.DefaultKeychain); err == nil {
+		t.Error("expected an error creating an archiver with an invalid repository")
+	}
+}
+
+func TestReconcileWithArchiver(t *testing.T) {
+	resource := &resolutionframework.FakeResolvedResource{
+		Content:       "some content",
+		AnnotationMap: map[string]string{"foo": "bar"},
+	}
+
+	for _, tc := range []struct {
+		name            string
+		repository      string
+		wantAnnotations func(repository string) map[string]string
+	}{{
+		name:       "archived",
+		repository: newTestRegistry(t) + "/tekton/resolved",
+	}, {
+		name: "archiving failure isn't fatal",
+		// Nothing listens on the port of the closed registry.
+		repository: func() string {
+			s := httptest.NewServer(registry.New())
+			s.Close()
+			u, _ := url.Parse(s.URL)
+			return u.Host + "/tekton/resolved"
+		}(),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := &v1beta1.ResolutionRequest{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "resolution.tekton.dev/v1beta1",
+					Kind:       "ResolutionRequest",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "rr",
+					Namespace:         "foo",
+					CreationTimestamp: metav1.Time{Time: time.Now()},
+					Labels: map[string]string{
+						resolutioncommon.LabelKeyResolverType: resolutionframework.LabelValueFakeResolverType,
+					},
+				},
+				Spec: v1beta1.ResolutionRequestSpec{
+					Params: []pipelinev1.Param{{
+						Name:  resolutionframework.FakeParamName,
+						Value: *pipelinev1.NewStructuredValues("bar"),
+					}},
+				},
+			}
+			archiver, err := framework.NewOCIArchiver(tc.repository, authn.DefaultKeychain)
+			if err != nil {
+				t.Fatalf("unexpected error creating the archiver: %v", err)
+			}
+			fakeResolver := &framework.FakeResolver{ForParam: map[string]*resolutionframework.FakeResolvedResource{"bar": resource}}
+
+			ctx, _ := ttesting.SetupFakeContext(t)
+			testAssets, cancel := getResolverFrameworkController(ctx, t, test.Data{ResolutionRequests: []*v1beta1.ResolutionRequest{rr}}, fakeResolver, setClockOnReconciler, framework.WithArchiver(archiver))
+			defer cancel()
+
+			if err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRequestName(rr)); err != nil {
+				if ok, _ := controller.IsRequeueKey(err); !ok {
+					t.Fatalf("did not expect an error, but got %v", err)
+				}
+			}
+			reconciledRR, err := testAssets.Clients.ResolutionRequests.ResolutionV1beta1().ResolutionRequests(rr.Namespace).Get(testAssets.Ctx, rr.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("getting updated ResolutionRequest: %v", err)
+			}
+			if got := reconciledRR.Status.Data; got != base64.StdEncoding.EncodeToString([]byte("some content")) {
+				t.Errorf("expected the resolved data in the status, got %q", got)
+			}
+			if c := reconciledRR.Status.GetCondition(apis.ConditionSucceeded); c != nil && c.IsFalse() {
+				t.Errorf("expected the resolution not to fail, got %v", c)
+			}
+			annotations := reconciledRR.Status.Annotations
+			if annotations["foo"] != "bar" {
+				t.Errorf("expected the annotations of the resource in the status, got %v", annotations)
+			}
+			ref, archived := annotations[framework.AnnotationKeyArchiveRef]
+			archiveErr, failed := annotations[framework.AnnotationKeyArchiveError]
+			if tc.name == "archived" {
+				if !archived || !strings.HasPrefix(ref, tc.repository+"@sha256:") || failed {
+					t.Errorf("expected the archive reference in the status, got %v", annotations)
+				}
+			} else if archived || !strings.Contains(archiveErr, "error pushing the archive of the resolved data") {
+				t.Errorf("expected the archiving error in the status, got %v", annotations)
+			}
+			if resource.AnnotationMap[framework.AnnotationKeyArchiveRef] != "" || resource.AnnotationMap[framework.AnnotationKeyArchiveError] != "" {
+				t.Errorf("expected the annotations of the resource not to be modified, got %v", resource.AnnotationMap)
+			}
+		})
+	}
+}
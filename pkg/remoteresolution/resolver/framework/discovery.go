@@ -0,0 +1,90 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+
+	resolutioncommon "github.com/tektoncd/pipeline/pkg/resolution/common"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"knative.dev/pkg/logging"
+)
+
+// DiscoveryPath is the path under which the discovery handler serves
+// the params of the resolvers. The params of a single resolver are
+// served under DiscoveryPath followed by its type, e.g. "/resolvers/git".
+const DiscoveryPath = "/resolvers"
+
+// ResolverParams is the description of the params accepted by a
+// resolver, as served by the discovery handler.
+type ResolverParams struct {
+	// Name is the name of the resolver, e.g. "Git".
+	Name string `json:"name"`
+	// Type is the value of the resolver type label, e.g. "git".
+	Type string `json:"type"`
+	// Params is the description of the params accepted by the resolver.
+	Params []framework.ParamDescription `json:"params"`
+}
+
+// NewDiscoveryHandler returns an http.Handler serving, as JSON, the
+// description of the params of the given resolvers which implement the
+// framework.ParamDescriber interface.
+func NewDiscoveryHandler(ctx context.Context, resolvers ...Resolver) http.Handler {
+	described := map[string]ResolverParams{}
+	for _, r := range resolvers {
+		describer, ok := r.(framework.ParamDescriber)
+		if !ok {
+			continue
+		}
+		resolverType := r.GetSelector(ctx)[resolutioncommon.LabelKeyResolverType]
+		described[resolverType] = ResolverParams{
+			Name:   r.GetName(ctx),
+			Type:   resolverType,
+			Params: describer.DescribeParams(ctx),
+		}
+	}
+	all := make([]ResolverParams, 0, len(described))
+	for _, rp := range described {
+		all = append(all, rp)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Type < all[j].Type })
+
+	logger := logging.FromContext(ctx)
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		var body interface{} = all
+		if resolverType := strings.Trim(strings.TrimPrefix(req.URL.Path, DiscoveryPath), "/"); resolverType != "" {
+			rp, ok := described[resolverType]
+			if !ok {
+				http.NotFound(w, req)
+				return
+			}
+			body = rp
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(body); err != nil {
+			logger.Warnf("error writing resolver params discovery response: %v", err)
+		}
+	})
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/remoteresolution/resolver/framework"
+	resolutioncommon "github.com/tektoncd/pipeline/pkg/resolution/common"
+	resolutionframework "github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+// undescribedResolver is a resolver which doesn't implement the
+// ParamDescriber interface.
+type undescribedResolver struct {
+	framework.Resolver
+}
+
+func (r *undescribedResolver) GetSelector(context.Context) map[string]string {
+	return map[string]string{resolutioncommon.LabelKeyResolverType: "undescribed"}
+}
+
+func TestDiscoveryHandler(t *testing.T) {
+	ctx := t.Context()
+	handler := framework.NewDiscoveryHandler(ctx, &framework.FakeResolver{}, &undescribedResolver{Resolver: &framework.FakeResolver{}})
+	fakeParams := framework.ResolverParams{
+		Name:   resolutionframework.FakeResolverName,
+		Type:   resolutionframework.LabelValueFakeResolverType,
+		Params: resolutionframework.DescribeFakeParams(),
+	}
+
+	for _, tc := range []struct {
+		name         string
+		method       string
+		path         string
+		expectedCode int
+		expectedBody interface{}
+	}{{
+		name:         "all resolvers",
+		method:       http.MethodGet,
+		path:         framework.DiscoveryPath,
+		expectedCode: http.StatusOK,
+		expectedBody: []framework.ResolverParams{fakeParams},
+	}, {
+		name:         "single resolver",
+		method:       http.MethodGet,
+		path:         framework.DiscoveryPath + "/" + resolutionframework.LabelValueFakeResolverType,
+		expectedCode: http.StatusOK,
+		expectedBody: fakeParams,
+	}, {
+		name:         "resolver without param descriptions",
+		method:       http.MethodGet,
+		path:         framework.DiscoveryPath + "/undescribed",
+		expectedCode: http.StatusNotFound,
+	}, {
+		name:         "method not allowed",
+		method:       http.MethodPost,
+		path:         framework.DiscoveryPath,
+		expectedCode: http.StatusMethodNotAllowed,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(tc.method, tc.path, nil))
+			if rec.Code != tc.expectedCode {
+				t.Fatalf("expected status code %d but got %d", tc.expectedCode, rec.Code)
+			}
+			if tc.expectedBody == nil {
+				return
+			}
+			expected, err := json.Marshal(tc.expectedBody)
+			if err != nil {
+				t.Fatalf("unexpected error marshalling expected body: %v", err)
+			}
+			if d := cmp.Diff(string(expected)+"\n", rec.Body.String()); d != "" {
+				t.Errorf("unexpected discovery response %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -81,6 +81,13 @@ func (r *FakeResolver) Resolve(_ context.Context, req *v1beta1.ResolutionRequest
 	return frr, nil
 }
 
+var _ framework.ParamDescriber = &FakeResolver{}
+
+// DescribeParams returns the description of the fake resolver's single parameter.
+func (r *FakeResolver) DescribeParams(_ context.Context) []framework.ParamDescription {
+	return framework.DescribeFakeParams()
+}
+
 var _ framework.TimedResolution = &FakeResolver{}
 
 // GetResolutionTimeout returns the configured timeout for the reconciler, or the default time.Duration if not configured.
@@ -76,6 +76,9 @@ type Reconciler struct {
 	resolutionRequestClientSet rrclient.Interface
 
 	configStore *framework.ConfigStore
+
+	// archiver archives the resolved resources, if set.
+	archiver Archiver
 }
 
 var _ reconciler.LeaderAware = &Reconciler{}
@@ -140,6 +143,9 @@ func (r *Reconciler) resolve(ctx context.Context, key string, rr *v1beta1.Resolu
 
 	go func() {
 		validationError := r.resolver.Validate(resolutionCtx, &rr.Spec)
+		if describer, ok := r.resolver.(framework.ParamDescriber); ok {
+			validationError = framework.WithParamSuggestions(validationError, rr.Spec.Params, describer.DescribeParams(resolutionCtx))
+		}
 		if validationError != nil {
 			errChan <- &resolutioncommon.InvalidRequestError{
 				ResolutionRequestKey: key,
@@ -148,6 +154,13 @@ func (r *Reconciler) resolve(ctx context.Context, key string, rr *v1beta1.Resolu
 			return
 		}
 		resource, resolveErr := r.resolver.Resolve(resolutionCtx, &rr.Spec)
+		// A GetResourceError returned by the resolver already names
+		// what it failed to get, it isn't wrapped again.
+		var getResourceErr *resolutioncommon.GetResourceError
+		if errors.As(resolveErr, &getResourceErr) {
+			errChan <- resolveErr
+			return
+		}
 		if resolveErr != nil {
 			errChan <- &resolutioncommon.GetResourceError{
 				ResolverName: r.resolver.GetName(resolutionCtx),
@@ -216,10 +229,15 @@ func (r *Reconciler) MarkFailed(ctx context.Context, rr *v1beta1.ResolutionReque
 
 func (r *Reconciler) writeResolvedData(ctx context.Context, rr *v1beta1.ResolutionRequest, resource framework.ResolvedResource) error {
 	encodedData := base64.StdEncoding.Strict().EncodeToString(resource.Data())
+	// Failing to archive the resource doesn't fail its resolution.
+	annotations := r.archive(ctx, resource)
+	if archiveErr, ok := annotations[AnnotationKeyArchiveError]; ok {
+		logging.FromContext(ctx).Warnf("writeResolvedData error archiving the resolved data of resolution request %s:%s: %s", rr.Namespace, rr.Name, archiveErr)
+	}
 	patchBytes, err := json.Marshal(map[string]statusDataPatch{
 		"status": {
 			Data:        encodedData,
-			Annotations: resource.Annotations(),
+			Annotations: annotations,
 			RefSource:   resource.RefSource(),
 			Source:      (*pipelinev1beta1.ConfigSource)(resource.RefSource()),
 		},
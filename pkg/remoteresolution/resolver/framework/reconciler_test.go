@@ -285,6 +285,30 @@ func TestReconcile(t *testing.T) {
 				Status: v1beta1.ResolutionRequestStatus{},
 			},
 			expectedErr: errors.New(`invalid resource request "foo/rr": missing fake-key`),
+		}, {
+			name: "misspelled param",
+			inputRequest: &v1beta1.ResolutionRequest{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "resolution.tekton.dev/v1beta1",
+					Kind:       "ResolutionRequest",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "rr",
+					Namespace:         "foo",
+					CreationTimestamp: metav1.Time{Time: time.Now()},
+					Labels: map[string]string{
+						resolutioncommon.LabelKeyResolverType: resolutionframework.LabelValueFakeResolverType,
+					},
+				},
+				Spec: v1beta1.ResolutionRequestSpec{
+					Params: []pipelinev1.Param{{
+						Name:  "fake-kye",
+						Value: *pipelinev1.NewStructuredValues("bar"),
+					}},
+				},
+				Status: v1beta1.ResolutionRequestStatus{},
+			},
+			expectedErr: errors.New(`invalid resource request "foo/rr": missing fake-key (unknown param "fake-kye", did you mean "fake-key"?)`),
 		}, {
 			name: "error resolving",
 			inputRequest: &v1beta1.ResolutionRequest{
@@ -19,6 +19,7 @@ package git
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/jenkins-x/go-scm/scm"
@@ -63,9 +64,13 @@ type Resolver struct {
 	logger     *zap.SugaredLogger
 	cache      *cache.LRUExpireCache
 	ttl        time.Duration
+	cloneCache *git.CloneCache
+	// resolutions deduplicates the concurrent resolutions of the same file.
+	resolutions *git.ResolutionGroup
 
 	// Used in testing
-	clientFunc func(string, string, string, ...factory.ClientOptionFunc) (*scm.Client, error)
+	clientFunc            func(string, string, string, ...factory.ClientOptionFunc) (*scm.Client, error)
+	installationTokenFunc git.InstallationTokenFunc
 }
 
 // Initialize performs any setup required by the gitresolver.
@@ -74,12 +79,35 @@ func (r *Resolver) Initialize(ctx context.Context) error {
 	r.logger = logging.FromContext(ctx)
 	r.cache = cache.NewLRUExpireCache(cacheSize)
 	r.ttl = ttl
+	r.cloneCache = git.NewCloneCache()
+	r.resolutions = git.NewResolutionGroup()
+	if err := git.RegisterMetrics(); err != nil {
+		return fmt.Errorf("failed to register the metrics of the git resolver: %w", err)
+	}
 	if r.clientFunc == nil {
 		r.clientFunc = factory.NewClient
 	}
+	if r.installationTokenFunc == nil {
+		r.installationTokenFunc = git.MintInstallationToken
+	}
 	return nil
 }
 
+// InvalidateRepo removes the files resolved from the repo from the clone
+// cache and returns their number.
+func (r *Resolver) InvalidateRepo(repoURL string) int {
+	if r.cloneCache == nil {
+		return 0
+	}
+	return r.cloneCache.InvalidateRepo(repoURL)
+}
+
+// CloneCacheStats returns the number of resolutions from a cloned repo
+// served from the clone cache, and the number of them which cloned the repo.
+func (r *Resolver) CloneCacheStats() (hits, misses int64) {
+	return r.cloneCache.Stats()
+}
+
 // GetName returns the string name that the gitresolver should be
 // associated with.
 func (r *Resolver) GetName(_ context.Context) string {
@@ -125,13 +153,24 @@ func (r *Resolver) Resolve(ctx context.Context, req *v1beta1.ResolutionRequestSp
 			Cache:      r.cache,
 			TTL:        r.ttl,
 			Params:     params,
-		}
 
-		if params[git.UrlParam] != "" {
-			return g.ResolveGitClone(ctx)
+			InstallationTokenFunc: r.installationTokenFunc,
+			CloneCache:            r.cloneCache,
+			ClientFunc:            r.clientFunc,
 		}
 
-		return g.ResolveAPIGit(ctx, r.clientFunc)
+		timeout, err := r.GetResolutionTimeout(ctx, git.DefaultSharedResolutionTimeout, params)
+		if err != nil {
+			return nil, err
+		}
+		return git.ObserveResolution(ctx, params, func() (resolutionframework.ResolvedResource, error) {
+			return r.resolutions.Do(ctx, params, timeout, func(ctx context.Context) (resolutionframework.ResolvedResource, error) {
+				if params[git.UrlParam] != "" {
+					return g.ResolveGitClone(ctx)
+				}
+				return g.ResolveAPIGit(ctx, r.clientFunc)
+			})
+		})
 	}
 	// Remove this error once resolution of url has been implemented.
 	return nil, errors.New("the Resolve method has not been implemented.")
@@ -144,6 +183,14 @@ func (r *Resolver) GetConfigName(context.Context) string {
 	return ConfigMapName
 }
 
+var _ resolutionframework.ParamDescriber = &Resolver{}
+
+// DescribeParams returns the description of the params accepted by the
+// gitresolver.
+func (r *Resolver) DescribeParams(context.Context) []resolutionframework.ParamDescription {
+	return git.DescribeParams()
+}
+
 var _ resolutionframework.TimedResolution = &Resolver{}
 
 // GetResolutionTimeout returns a time.Duration for the amount of time a
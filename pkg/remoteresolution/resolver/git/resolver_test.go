@@ -336,8 +336,12 @@ func TestResolve(t *testing.T) {
 	if err != nil {
 		t.Fatalf("couldn't read main task: %v", err)
 	}
+	pullRequestTaskYAML, err := os.ReadFile(filepath.Join(refsDir, "def", "tasks", "example-task.yaml"))
+	if err != nil {
+		t.Fatalf("couldn't read pull request task: %v", err)
+	}
 
-	commitSHAsInSCMRepo := []string{"abc", "xyz"}
+	commitSHAsInSCMRepo := []string{"abc", "xyz", "def"}
 
 	scmFakeRepoURL := fmt.Sprintf("https://fake/%s/%s.git", testOrg, testRepo)
 	resolver := &Resolver{
@@ -352,8 +356,14 @@ func TestResolve(t *testing.T) {
 
 			// git service
 			scmData.Commits = map[string]*scm.Commit{
-				"main":  {Sha: commitSHAsInSCMRepo[0]},
-				"other": {Sha: commitSHAsInSCMRepo[1]},
+				"main":                 {Sha: commitSHAsInSCMRepo[0]},
+				"other":                {Sha: commitSHAsInSCMRepo[1]},
+				commitSHAsInSCMRepo[2]: {Sha: commitSHAsInSCMRepo[2]},
+			}
+
+			// pull request service
+			scmData.PullRequests = map[int]*scm.PullRequest{
+				42: {Number: 42, Head: scm.PullRequestBranch{Ref: "feature", Sha: commitSHAsInSCMRepo[2]}},
 			}
 			return scmClient, nil
 		},
@@ -403,6 +413,20 @@ func TestResolve(t *testing.T) {
 		},
 		expectedCommitSHA: commitSHAsInAnonRepo[1],
 		expectedStatus:    resolution.CreateResolutionRequestStatusWithData([]byte("new content in test branch")),
+	}, {
+		name: "clone: default revision of the configKey",
+		args: &params{
+			pathInRepo: "foo/new",
+			url:        anonFakeRepoURL,
+			configKey:  "test",
+		},
+		config: map[string]string{
+			gitresolution.DefaultRevisionKey:           "main",
+			"test." + gitresolution.DefaultRevisionKey: "test-branch",
+		},
+		configIdentifer:   "test.",
+		expectedCommitSHA: commitSHAsInAnonRepo[1],
+		expectedStatus:    resolution.CreateResolutionRequestStatusWithData([]byte("new content in test branch")),
 	}, {
 		name: "clone: revision is a specific commit sha",
 		args: &params{
@@ -427,6 +451,14 @@ func TestResolve(t *testing.T) {
 			url:        anonFakeRepoURL,
 		},
 		expectedErr: createError("git fetch error: fatal: couldn't find remote ref non-existent-revision: exit status 128"),
+	}, {
+		name: "clone: directory without yaml files",
+		args: &params{
+			revision:   "test-branch",
+			pathInRepo: "foo/",
+			url:        anonFakeRepoURL,
+		},
+		expectedErr: createError(`directory "foo/" doesn't contain any .yaml or .yml file`),
 	}, {
 		name: "api: successful task from params api information",
 		args: &params{
@@ -463,6 +495,24 @@ func TestResolve(t *testing.T) {
 		apiToken:          "some-token",
 		expectedCommitSHA: commitSHAsInSCMRepo[0],
 		expectedStatus:    resolution.CreateResolutionRequestStatusWithData(mainTaskYAML),
+	}, {
+		name: "api: directory",
+		args: &params{
+			revision:   "main",
+			pathInRepo: "tasks/",
+			org:        testOrg,
+			repo:       testRepo,
+		},
+		config: map[string]string{
+			gitresolution.ServerURLKey:          "fake",
+			gitresolution.SCMTypeKey:            "fake",
+			gitresolution.APISecretNameKey:      "token-secret",
+			gitresolution.APISecretKeyKey:       "token",
+			gitresolution.APISecretNamespaceKey: system.Namespace(),
+		},
+		apiToken:          "some-token",
+		expectedCommitSHA: commitSHAsInSCMRepo[0],
+		expectedStatus:    resolution.CreateResolutionRequestStatusWithData(mainTaskYAML),
 	}, {
 		name: "api: successful task from params api information with identifier",
 		args: &params{
@@ -539,6 +589,60 @@ func TestResolve(t *testing.T) {
 		apiToken:          "some-token",
 		expectedCommitSHA: commitSHAsInSCMRepo[1],
 		expectedStatus:    resolution.CreateResolutionRequestStatusWithData(otherPipelineYAML),
+	}, {
+		name: "api: revision is the head of a pull request",
+		args: &params{
+			revision:   "refs/pull/42/head",
+			pathInRepo: "tasks/example-task.yaml",
+			org:        testOrg,
+			repo:       testRepo,
+		},
+		config: map[string]string{
+			gitresolution.ServerURLKey:          "fake",
+			gitresolution.SCMTypeKey:            "fake",
+			gitresolution.APISecretNameKey:      "token-secret",
+			gitresolution.APISecretKeyKey:       "token",
+			gitresolution.APISecretNamespaceKey: system.Namespace(),
+		},
+		apiToken:          "some-token",
+		expectedCommitSHA: commitSHAsInSCMRepo[2],
+		expectedStatus:    resolution.CreateResolutionRequestStatusWithData(pullRequestTaskYAML),
+	}, {
+		name: "api: revision is the head of a merge request",
+		args: &params{
+			revision:   "refs/merge-requests/42/head",
+			pathInRepo: "tasks/example-task.yaml",
+			org:        testOrg,
+			repo:       testRepo,
+		},
+		config: map[string]string{
+			gitresolution.ServerURLKey:          "fake",
+			gitresolution.SCMTypeKey:            "fake",
+			gitresolution.APISecretNameKey:      "token-secret",
+			gitresolution.APISecretKeyKey:       "token",
+			gitresolution.APISecretNamespaceKey: system.Namespace(),
+		},
+		apiToken:          "some-token",
+		expectedCommitSHA: commitSHAsInSCMRepo[2],
+		expectedStatus:    resolution.CreateResolutionRequestStatusWithData(pullRequestTaskYAML),
+	}, {
+		name: "api: pull request does not exist",
+		args: &params{
+			revision:   "refs/pull/7/head",
+			pathInRepo: "tasks/example-task.yaml",
+			org:        testOrg,
+			repo:       testRepo,
+		},
+		config: map[string]string{
+			gitresolution.ServerURLKey:          "fake",
+			gitresolution.SCMTypeKey:            "fake",
+			gitresolution.APISecretNameKey:      "token-secret",
+			gitresolution.APISecretKeyKey:       "token",
+			gitresolution.APISecretNamespaceKey: system.Namespace(),
+		},
+		apiToken:       "some-token",
+		expectedStatus: resolution.CreateResolutionRequestFailureStatus(),
+		expectedErr:    createError("couldn't fetch the pull request 7 of the revision refs/pull/7/head in the repo: pull request number 7 does not exit"),
 	}, {
 		name: "api: successful override scm type and server URL from user params",
 
@@ -693,18 +797,28 @@ func TestResolve(t *testing.T) {
 					expectedStatus.Annotations[common.AnnotationKeyContentType] = "application/x-yaml"
 					expectedStatus.Annotations[gitresolution.AnnotationKeyRevision] = tc.expectedCommitSHA
 					expectedStatus.Annotations[gitresolution.AnnotationKeyPath] = tc.args.pathInRepo
+					effectiveRevision := tc.args.revision
+					if effectiveRevision == "" {
+						effectiveRevision = cfg[tc.configIdentifer+gitresolution.DefaultRevisionKey]
+					}
+					expectedStatus.Annotations[gitresolution.AnnotationKeyEffectiveRevision] = effectiveRevision
 
 					if tc.args.url != "" {
 						expectedStatus.Annotations[gitresolution.AnnotationKeyURL] = anonFakeRepoURL
+						expectedStatus.Annotations[gitresolution.AnnotationKeyResolutionMode] = gitresolution.ResolutionModeClone
+						expectedStatus.Annotations[gitresolution.AnnotationKeyCommitAuthor] = "PipelinesTests <test@test.com>"
+						expectedStatus.Annotations[gitresolution.AnnotationKeyCommitTimestamp] = "2025-01-02T03:04:05Z"
+						expectedStatus.Annotations[gitresolution.AnnotationKeyCommitMessageSubject] = "adding file for test"
 					} else {
 						expectedStatus.Annotations[gitresolution.AnnotationKeyOrg] = testOrg
 						expectedStatus.Annotations[gitresolution.AnnotationKeyRepo] = testRepo
 						expectedStatus.Annotations[gitresolution.AnnotationKeyURL] = scmFakeRepoURL
+						expectedStatus.Annotations[gitresolution.AnnotationKeyResolutionMode] = gitresolution.ResolutionModeAPI
 					}
 
 					// status.refSource
 					expectedStatus.RefSource = &pipelinev1.RefSource{
-						URI: "git+" + expectedStatus.Annotations[gitresolution.AnnotationKeyURL],
+						URI: "git+" + expectedStatus.Annotations[gitresolution.AnnotationKeyURL] + "@" + effectiveRevision,
 						Digest: map[string]string{
 							"sha1": tc.expectedCommitSHA,
 						},
@@ -856,7 +970,9 @@ func writeAndCommitToTestRepo(t *testing.T, repoDir string, subPath string, file
 		t.Fatalf("couldn't add file %s to git: %v", outfile, err)
 	}
 
-	err = gitCmd("commit", "-m", "adding file for test").Run()
+	commitCmd := gitCmd("commit", "-m", "adding file for test")
+	commitCmd.Env = append(os.Environ(), "GIT_AUTHOR_DATE=2025-01-02T03:04:05Z", "GIT_COMMITTER_DATE=2025-01-02T03:04:05Z")
+	err = commitCmd.Run()
 	if err != nil {
 		t.Fatalf("couldn't perform commit for test: %v", err)
 	}
@@ -979,3 +1095,35 @@ func toParams(m map[string]string) []pipelinev1.Param {
 
 	return params
 }
+
+func TestResolveCloneCache(t *testing.T) {
+	repoURL, _ := createTestRepo(t, []commitForRepo{{
+		Filename: "task.yaml",
+		Content:  "task",
+	}})
+	resolver := &Resolver{cloneCache: gitresolution.NewCloneCache()}
+	ctx := resolutionframework.InjectResolverConfigToContext(t.Context(), map[string]string{})
+	req := &v1beta1.ResolutionRequestSpec{Params: []pipelinev1.Param{{
+		Name:  gitresolution.UrlParam,
+		Value: *pipelinev1.NewStructuredValues(repoURL),
+	}, {
+		Name:  gitresolution.RevisionParam,
+		Value: *pipelinev1.NewStructuredValues("main"),
+	}, {
+		Name:  gitresolution.PathParam,
+		Value: *pipelinev1.NewStructuredValues("task.yaml"),
+	}}}
+
+	for range 2 {
+		res, err := resolver.Resolve(ctx, req)
+		if err != nil {
+			t.Fatalf("unexpected error resolving: %v", err)
+		}
+		if string(res.Data()) != "task" {
+			t.Errorf("unexpected resolved content %q", res.Data())
+		}
+	}
+	if hits, misses := resolver.CloneCacheStats(); hits != 1 || misses != 1 {
+		t.Errorf("expected the second resolution of the same commit to be served from the clone cache, got %d hits and %d misses", hits, misses)
+	}
+}
@@ -22,6 +22,8 @@ import (
 	"github.com/tektoncd/pipeline/pkg/resolution/common"
 	resolutionframework "github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
 	"github.com/tektoncd/pipeline/pkg/resolution/resolver/hub"
+	"k8s.io/client-go/kubernetes"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
 )
 
 const (
@@ -44,10 +46,13 @@ type Resolver struct {
 	TektonHubURL string
 	// ArtifactHubURL is the URL for hub resolver with type artifact
 	ArtifactHubURL string
+
+	kubeClient kubernetes.Interface
 }
 
-// Initialize sets up any dependencies needed by the resolver. None atm.
-func (r *Resolver) Initialize(context.Context) error {
+// Initialize sets up the kubernetes client reading the API tokens.
+func (r *Resolver) Initialize(ctx context.Context) error {
+	r.kubeClient = kubeclient.Get(ctx)
 	return nil
 }
 
@@ -80,7 +85,7 @@ func (r *Resolver) Validate(ctx context.Context, req *v1beta1.ResolutionRequestS
 // Resolve uses the given params to resolve the requested file or resource.
 func (r *Resolver) Resolve(ctx context.Context, req *v1beta1.ResolutionRequestSpec) (resolutionframework.ResolvedResource, error) {
 	if len(req.Params) > 0 {
-		return hub.Resolve(ctx, req.Params, r.TektonHubURL, r.ArtifactHubURL)
+		return hub.Resolve(ctx, req.Params, r.TektonHubURL, r.ArtifactHubURL, r.kubeClient)
 	}
 	// Remove this error once resolution of url has been implemented.
 	return nil, errors.New("the Resolve method has not been implemented.")
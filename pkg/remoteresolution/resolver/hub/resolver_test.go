@@ -18,6 +18,8 @@ package hub
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"net/http"
@@ -31,6 +33,9 @@ import (
 	resolutionframework "github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
 	hubresolver "github.com/tektoncd/pipeline/pkg/resolution/resolver/hub"
 	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
 )
 
 func TestGetSelector(t *testing.T) {
@@ -69,6 +74,15 @@ func TestValidate(t *testing.T) {
 			catalog:      "baz",
 			hubType:      ArtifactHubType,
 		},
+		{
+			testName:     "stepaction with tekton type",
+			kind:         "stepaction",
+			resourceName: "foo",
+			version:      "bar",
+			catalog:      "baz",
+			hubType:      TektonHubType,
+			expectedErr:  errors.New("failed to validate params: kind stepaction is not supported by the tekton hub type, use the artifact type to resolve it"),
+		},
 		{
 			testName:     "tekton type validation",
 			kind:         "task",
@@ -78,6 +92,15 @@ func TestValidate(t *testing.T) {
 			hubType:      TektonHubType,
 			expectedErr:  errors.New("failed to validate params: please configure TEKTON_HUB_API env variable to use tekton type"),
 		},
+		{
+			testName:     "invalid version constraint",
+			kind:         "task",
+			resourceName: "foo",
+			version:      "~",
+			catalog:      "baz",
+			hubType:      ArtifactHubType,
+			expectedErr:  errors.New(`failed to validate params: invalid version constraint "~": Malformed constraint: ~; use comparisons such as ">=0.5 <0.7" or "~0.6"`),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -287,6 +310,141 @@ func TestResolve(t *testing.T) {
 	}
 }
 
+func TestResolveStepAction(t *testing.T) {
+	manifest := `apiVersion: tekton.dev/v1beta1
+kind: StepAction
+metadata:
+  name: git-clone
+spec:
+  image: alpine/git
+  script: git clone "$(params.url)"
+`
+	var requestedPath string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPath = r.URL.Path
+		fmt.Fprintf(w, `{"data":{"manifestRaw":%q}}`, manifest)
+	}))
+	defer svr.Close()
+
+	resolver := &Resolver{ArtifactHubURL: svr.URL}
+	params := map[string]string{
+		hubresolver.ParamKind:    "stepaction",
+		hubresolver.ParamName:    "git-clone",
+		hubresolver.ParamVersion: "0.1",
+		hubresolver.ParamType:    ArtifactHubType,
+	}
+	req := v1beta1.ResolutionRequestSpec{
+		Params: toParams(params),
+	}
+	output, err := resolver.Resolve(contextWithConfig(), &req)
+	if err != nil {
+		t.Fatalf("unexpected error resolving: %v", err)
+	}
+	if d := cmp.Diff("/api/v1/packages/tekton-stepaction/tekton-catalog-stepactions/git-clone/0.1.0", requestedPath); d != "" {
+		t.Errorf("unexpected path requested from the Artifact Hub: %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff([]byte(manifest), output.Data()); d != "" {
+		t.Errorf("unexpected resource from Resolve: %s", diff.PrintWantGot(d))
+	}
+	sum := sha256.Sum256([]byte(manifest))
+	wantAnnotations := map[string]string{
+		resolutioncommon.AnnotationKeyContentType: "application/x-yaml",
+		hubresolver.AnnotationKeyVersion:          "0.1.0",
+		hubresolver.AnnotationKeySHA256:           hex.EncodeToString(sum[:]),
+		hubresolver.AnnotationKeyEndpoint:         svr.URL,
+	}
+	if d := cmp.Diff(wantAnnotations, output.Annotations()); d != "" {
+		t.Errorf("unexpected annotations: %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestResolveFailsOverToMirror(t *testing.T) {
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer primary.Close()
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":{"manifestRaw":"task from the mirror"}}`)
+	}))
+	defer mirror.Close()
+
+	ctx := contextWithConfig()
+	config := resolutionframework.GetResolverConfigFromContext(ctx)
+	config[hubresolver.ConfigArtifactHubMirrors] = mirror.URL
+	ctx = resolutionframework.InjectResolverConfigToContext(ctx, config)
+
+	resolver := &Resolver{ArtifactHubURL: primary.URL}
+	req := v1beta1.ResolutionRequestSpec{
+		Params: toParams(map[string]string{
+			hubresolver.ParamKind:    "task",
+			hubresolver.ParamName:    "foo",
+			hubresolver.ParamVersion: "0.1",
+			hubresolver.ParamType:    ArtifactHubType,
+		}),
+	}
+	output, err := resolver.Resolve(ctx, &req)
+	if err != nil {
+		t.Fatalf("unexpected error resolving: %v", err)
+	}
+	if d := cmp.Diff([]byte("task from the mirror"), output.Data()); d != "" {
+		t.Errorf("unexpected resource from Resolve: %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(mirror.URL, output.Annotations()[hubresolver.AnnotationKeyEndpoint]); d != "" {
+		t.Errorf("unexpected endpoint annotation: %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestResolveAuthenticated(t *testing.T) {
+	var gotHeader http.Header
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeader = r.Header.Clone()
+		fmt.Fprint(w, `{"data":{"manifestRaw":"private task"}}`)
+	}))
+	defer svr.Close()
+
+	ctx, _ := fakekubeclient.With(contextWithConfig(), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "hub-token", Namespace: "foo"},
+		Data:       map[string][]byte{"token": []byte("key-id:key-secret")},
+	})
+	ctx = resolutioncommon.InjectRequestNamespace(ctx, "foo")
+	resolver := &Resolver{ArtifactHubURL: svr.URL}
+	if err := resolver.Initialize(ctx); err != nil {
+		t.Fatalf("unexpected error initializing the resolver: %v", err)
+	}
+	req := v1beta1.ResolutionRequestSpec{
+		Params: toParams(map[string]string{
+			hubresolver.ParamKind:    "task",
+			hubresolver.ParamName:    "foo",
+			hubresolver.ParamVersion: "0.1",
+			hubresolver.ParamType:    ArtifactHubType,
+			hubresolver.ParamToken:   "hub-token",
+		}),
+	}
+	output, err := resolver.Resolve(ctx, &req)
+	if err != nil {
+		t.Fatalf("unexpected error resolving: %v", err)
+	}
+	if d := cmp.Diff([]byte("private task"), output.Data()); d != "" {
+		t.Errorf("unexpected resource from Resolve: %s", diff.PrintWantGot(d))
+	}
+	if got := gotHeader.Get("X-API-KEY-ID"); got != "key-id" {
+		t.Errorf("expected the X-API-KEY-ID header to be key-id, got %q", got)
+	}
+	if got := gotHeader.Get("X-API-KEY-SECRET"); got != "key-secret" {
+		t.Errorf("expected the X-API-KEY-SECRET header to be key-secret, got %q", got)
+	}
+
+	req.Params = toParams(map[string]string{
+		hubresolver.ParamKind:    "task",
+		hubresolver.ParamName:    "foo",
+		hubresolver.ParamVersion: "0.1",
+		hubresolver.ParamType:    ArtifactHubType,
+		hubresolver.ParamToken:   "missing",
+	})
+	_, err = resolver.Resolve(ctx, &req)
+	checkExpectedErr(t, errors.New("cannot get API token, secret missing not found in namespace foo"), err)
+}
+
 func toParams(m map[string]string) []pipelinev1.Param {
 	var params []pipelinev1.Param
 
@@ -302,10 +460,11 @@ func toParams(m map[string]string) []pipelinev1.Param {
 
 func contextWithConfig() context.Context {
 	config := map[string]string{
-		"default-tekton-hub-catalog":            "Tekton",
-		"default-artifact-hub-task-catalog":     "tekton-catalog-tasks",
-		"default-artifact-hub-pipeline-catalog": "tekton-catalog-pipelines",
-		"default-type":                          "artifact",
+		"default-tekton-hub-catalog":              "Tekton",
+		"default-artifact-hub-task-catalog":       "tekton-catalog-tasks",
+		"default-artifact-hub-pipeline-catalog":   "tekton-catalog-pipelines",
+		"default-artifact-hub-stepaction-catalog": "tekton-catalog-stepactions",
+		"default-type":                            "artifact",
 	}
 
 	return resolutionframework.InjectResolverConfigToContext(context.Background(), config)
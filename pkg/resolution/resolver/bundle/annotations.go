@@ -27,6 +27,16 @@ const (
 	// BundleAnnotationAPIVersion is the image layer annotation used to
 	// indicate the "apiVersion" of resource stored in a given layer.
 	BundleAnnotationAPIVersion = "dev.tekton.image.apiVersion"
+
+	// BundleAnnotationTitle is the image layer annotation used by some
+	// bundle tooling instead of BundleAnnotationName to indicate the "name"
+	// of resource stored in a given layer.
+	BundleAnnotationTitle = "dev.tekton.image.title"
+
+	// OCIAnnotationTitle is the pre-defined OCI annotation of the title of
+	// a layer, used as the "name" of resource stored in a given layer when
+	// neither BundleAnnotationName nor BundleAnnotationTitle is set.
+	OCIAnnotationTitle = "org.opencontainers.image.title"
 )
 
 var (
@@ -41,4 +51,24 @@ var (
 	// ResolverAnnotationAPIVersion is the resolver annotation used to
 	// indicate the "apiVersion" of resource.
 	ResolverAnnotationAPIVersion = resolution.GroupName + "/" + BundleAnnotationAPIVersion
+
+	// ResolverAnnotationCache is the resolver annotation used to indicate
+	// whether the content of the resource was served from the layer cache,
+	// either "hit" or "miss". It's omitted when the layer cache is disabled.
+	ResolverAnnotationCache = resolution.GroupName + "/cache"
+
+	// ResolverAnnotationDigest is the resolver annotation used to record
+	// the digest of the manifest of the bundle the resource was read from,
+	// which the reference of the bundle may only identify by a tag.
+	ResolverAnnotationDigest = resolution.GroupName + "/bundle-digest"
+
+	// ResolverAnnotationRegistryRetries is the resolver annotation used to
+	// record the number of times the requests to the registry were retried
+	// to resolve the resource. It's omitted when none was retried.
+	ResolverAnnotationRegistryRetries = resolution.GroupName + "/registry-retries"
+
+	// ResolverAnnotationSignatureKey is the resolver annotation used to
+	// record the fingerprint of the public key whose cosign signature of the
+	// bundle was verified. It's omitted when the signatures aren't verified.
+	ResolverAnnotationSignatureKey = resolution.GroupName + "/signature-key-fingerprint"
 )
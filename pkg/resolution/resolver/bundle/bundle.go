@@ -16,9 +16,13 @@ package bundle
 import (
 	"archive/tar"
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/google/go-containerregistry/pkg/authn"
@@ -73,15 +77,59 @@ func (br *ResolvedResource) RefSource() *pipelinev1.RefSource {
 // GetEntry accepts a keychain and options for the request and returns
 // either a successfully resolved bundle entry or an error.
 func GetEntry(ctx context.Context, keychain authn.Keychain, opts RequestOptions) (*ResolvedResource, error) {
-	uri, img, err := retrieveImage(ctx, keychain, opts.Bundle)
+	return GetCachedEntry(ctx, keychain, opts, nil, nil)
+}
+
+// GetCachedEntry is GetEntry serving the content of the layers of the bundle
+// from the layer cache once the digest of the bundle is known. The reference
+// of the bundle is still resolved on each request, so that a tag which moved
+// isn't resolved from the cache and the credentials of the request are
+// checked by the registry. Nothing is cached if the cache is nil or disabled
+// in the config.
+//
+// When keys are given, the bundle must have a cosign signature made with one
+// of them, which is verified before its content is read.
+func GetCachedEntry(ctx context.Context, keychain authn.Keychain, opts RequestOptions, cache *LayerCache, keys []PublicKey) (*ResolvedResource, error) {
+	cacheTTL, cacheMaxSize, err := GetBundleResolverCacheConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if cacheTTL == 0 || cacheMaxSize == 0 {
+		cache = nil
+	}
+
+	retries, backoff, err := GetBundleResolverRetryConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	policy := &registryRetries{retries: retries, backoff: backoff}
+
+	ref, err := parseReference(ctx, opts.Bundle)
+	if err != nil {
+		return nil, fmt.Errorf("cannot retrieve the oci image: %s is an unparseable image reference: %w", opts.Bundle, err)
+	}
+	transport := policy.transport(registryTransport(ctx, ref.Context().Registry))
+	img, err := retrieveImage(ctx, keychain, ref, transport)
 	if err != nil {
 		return nil, fmt.Errorf("cannot retrieve the oci image: %w", err)
 	}
+	uri := ref.Context().Name()
 
 	h, err := img.Digest()
 	if err != nil {
 		return nil, fmt.Errorf("cannot get the oci digest: %w", err)
 	}
+	if d, ok := ref.(name.Digest); ok && d.DigestStr() != h.String() {
+		return nil, fmt.Errorf("the digest %s of the manifest of bundle %s does not match its pinned digest", h, opts.Bundle)
+	}
+
+	var fingerprint string
+	if len(keys) > 0 {
+		fingerprint, err = verifySignature(ctx, keychain, ref.Context(), h, keys, transport)
+		if err != nil {
+			return nil, fmt.Errorf("bundle %s signature verification failed: %w", opts.Bundle, err)
+		}
+	}
 
 	manifest, err := img.Manifest()
 	if err != nil {
@@ -106,53 +154,217 @@ func GetEntry(ctx context.Context, keychain authn.Keychain, opts RequestOptions)
 		layerMap[digest.String()] = l
 	}
 
-	for idx, l := range manifest.Layers {
-		lKind := l.Annotations[BundleAnnotationKind]
-		lName := l.Annotations[BundleAnnotationName]
-
-		if strings.ToLower(opts.Kind) == strings.ToLower(lKind) && opts.EntryName == lName {
-			obj, err := readTarLayer(layerMap[l.Digest.String()])
-			if err != nil {
-				// This could still be a raw layer so try to read it as that instead.
-				obj, _ = readRawLayer(layers[idx])
-			}
-			return &ResolvedResource{
-				data: obj,
-				annotations: map[string]string{
-					ResolverAnnotationKind:       lKind,
-					ResolverAnnotationName:       lName,
-					ResolverAnnotationAPIVersion: l.Annotations[BundleAnnotationAPIVersion],
-				},
-				source: &pipelinev1.RefSource{
-					URI: uri,
-					Digest: map[string]string{
-						h.Algorithm: h.Hex,
-					},
-					EntryPoint: opts.EntryName,
-				},
-			}, nil
+	idx, err := findLayer(manifest, opts)
+	if err != nil {
+		return nil, err
+	}
+	l := manifest.Layers[idx]
+	lKind := l.Annotations[BundleAnnotationKind]
+	lName := layerName(l)
+	annotations := map[string]string{
+		ResolverAnnotationKind: lKind,
+		// The name is recorded even when it was discovered rather than requested.
+		ResolverAnnotationName:       lName,
+		ResolverAnnotationAPIVersion: l.Annotations[BundleAnnotationAPIVersion],
+		ResolverAnnotationDigest:     h.String(),
+	}
+
+	if fingerprint != "" {
+		annotations[ResolverAnnotationSignatureKey] = fingerprint
+	}
+
+	var obj []byte
+	key := layerCacheKey{image: h.String(), layer: l.Digest.String()}
+	cached := false
+	if cache != nil {
+		obj, cached = cache.get(key)
+	}
+	if !cached {
+		obj = readLayer(layerMap[l.Digest.String()], layers[idx])
+	}
+	if cache != nil {
+		if cached {
+			annotations[ResolverAnnotationCache] = CacheHit
+		} else {
+			annotations[ResolverAnnotationCache] = CacheMiss
+			cache.add(key, obj, cacheTTL, cacheMaxSize)
 		}
 	}
-	return nil, fmt.Errorf("could not find object in image with kind: %s and name: %s", opts.Kind, opts.EntryName)
+	if attempts := policy.attempts.Load(); attempts > 0 {
+		annotations[ResolverAnnotationRegistryRetries] = strconv.FormatInt(attempts, 10)
+	}
+	return &ResolvedResource{
+		data:        obj,
+		annotations: annotations,
+		source: &pipelinev1.RefSource{
+			URI: uri,
+			Digest: map[string]string{
+				h.Algorithm: h.Hex,
+			},
+			EntryPoint: lName,
+		},
+	}, nil
 }
 
-// retrieveImage will fetch the image's url, contents and manifest.
-func retrieveImage(ctx context.Context, keychain authn.Keychain, ref string) (string, v1.Image, error) {
-	imgRef, err := name.ParseReference(ref)
-	if err != nil {
-		return "", nil, fmt.Errorf("%s is an unparseable image reference: %w", ref, err)
+// findLayer returns the index of the layer of the manifest with the kind and
+// the name of the options. When the name is omitted, the bundle must contain
+// exactly one layer of the kind.
+//
+// The kind and the name are matched case-insensitively, and the name against
+// any of the titles of the layers. An exact match of the name wins over the
+// layers matching it case-insensitively, which must otherwise be unique.
+func findLayer(manifest *v1.Manifest, opts RequestOptions) (int, error) {
+	var candidates []string
+	var matches []int
+	idx := -1
+	for i, l := range manifest.Layers {
+		if !kindMatches(opts.Kind, l.Annotations[BundleAnnotationKind]) {
+			continue
+		}
+		if opts.EntryName == "" {
+			candidates = append(candidates, layerName(l))
+			idx = i
+			continue
+		}
+		titles := layerTitles(l)
+		if slices.Contains(titles, opts.EntryName) {
+			return i, nil
+		}
+		if slices.ContainsFunc(titles, func(title string) bool { return strings.EqualFold(title, opts.EntryName) }) {
+			candidates = append(candidates, layerName(l))
+			matches = append(matches, i)
+		}
 	}
+	switch {
+	case opts.EntryName != "" && len(matches) == 1:
+		return matches[0], nil
+	case opts.EntryName != "" && len(matches) > 1:
+		return 0, fmt.Errorf("several objects in image with kind: %s match the name: %s: %s", opts.Kind, opts.EntryName, strings.Join(candidates, ", "))
+	case opts.EntryName != "":
+		return 0, fmt.Errorf("could not find object in image with kind: %s and name: %s, available titles: %s", opts.Kind, opts.EntryName, strings.Join(availableTitles(manifest), ", "))
+	case len(candidates) == 0:
+		return 0, fmt.Errorf("could not find object in image with kind: %s", opts.Kind)
+	case len(candidates) > 1:
+		return 0, fmt.Errorf("parameter %q required, the image contains several objects with kind: %s: %s", ParamName, opts.Kind, strings.Join(candidates, ", "))
+	}
+	return idx, nil
+}
+
+// kindMatches returns whether the kind requested matches the kind of a
+// layer, regardless of their case and of whether they are plural.
+func kindMatches(kind, layerKind string) bool {
+	return strings.EqualFold(singularKind(kind), singularKind(layerKind))
+}
+
+// singularKind returns the kind without its plural suffix, in any case.
+func singularKind(kind string) string {
+	if strings.HasSuffix(strings.ToLower(kind), "s") {
+		return kind[:len(kind)-1]
+	}
+	return kind
+}
+
+// titleAnnotations are the annotations of the titles of a layer, by
+// precedence.
+var titleAnnotations = []string{BundleAnnotationName, BundleAnnotationTitle, OCIAnnotationTitle}
+
+// layerTitles returns the titles a layer can be looked up by: its name
+// annotation, then its Tekton and OCI title annotations, without duplicates.
+func layerTitles(l v1.Descriptor) []string {
+	var titles []string
+	for _, annotation := range titleAnnotations {
+		if title := l.Annotations[annotation]; title != "" && !slices.Contains(titles, title) {
+			titles = append(titles, title)
+		}
+	}
+	return titles
+}
+
+// layerName returns the name of the resource stored in a layer, which is the
+// first of its titles.
+func layerName(l v1.Descriptor) string {
+	if titles := layerTitles(l); len(titles) > 0 {
+		return titles[0]
+	}
+	return ""
+}
+
+// hasTitle returns whether a layer has any of the annotations of its titles.
+func hasTitle(l v1.Descriptor) bool {
+	for _, annotation := range titleAnnotations {
+		if _, ok := l.Annotations[annotation]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// availableTitles lists the titles of the layers of the manifest, prefixed
+// with their kind, so that the users can fix the name they requested.
+func availableTitles(manifest *v1.Manifest) []string {
+	var available []string
+	for _, l := range manifest.Layers {
+		for _, title := range layerTitles(l) {
+			available = append(available, l.Annotations[BundleAnnotationKind]+"/"+title)
+		}
+	}
+	return available
+}
+
+// retrieveImage will fetch the image's contents and manifest with the
+// transport. The throttled and failed requests are retried by the transport
+// rather than by remote.
+func retrieveImage(ctx context.Context, keychain authn.Keychain, imgRef name.Reference, transport http.RoundTripper) (v1.Image, error) {
 	customRetryBackoff, err := GetBundleResolverBackoff(ctx)
 	if err == nil {
-		img, err := remote.Image(imgRef, remote.WithAuthFromKeychain(keychain), remote.WithContext(ctx),
-			remote.WithRetryBackoff(customRetryBackoff))
+		return remote.Image(imgRef, remote.WithAuthFromKeychain(keychain), remote.WithContext(ctx),
+			remote.WithRetryBackoff(customRetryBackoff), remote.WithTransport(transport), remote.WithRetryStatusCodes())
+	}
+	return remote.Image(imgRef, remote.WithAuthFromKeychain(keychain), remote.WithContext(ctx),
+		remote.WithTransport(transport), remote.WithRetryStatusCodes())
+}
 
-		return imgRef.Context().Name(), img, err
-	} else {
-		img, err := remote.Image(imgRef, remote.WithAuthFromKeychain(keychain), remote.WithContext(ctx))
+var (
+	// defaultTransport is the transport of the requests to the registries.
+	defaultTransport http.RoundTripper = remote.DefaultTransport
+	// skipTLSVerifyTransport is the transport of the requests to the
+	// registries whose TLS certificate isn't verified.
+	skipTLSVerifyTransport http.RoundTripper = newSkipTLSVerifyTransport(remote.DefaultTransport.(*http.Transport))
+)
 
-		return imgRef.Context().Name(), img, err
+// newSkipTLSVerifyTransport returns a copy of the transport which doesn't
+// verify the TLS certificates of the servers.
+func newSkipTLSVerifyTransport(t *http.Transport) *http.Transport {
+	t = t.Clone()
+	if t.TLSClientConfig == nil {
+		t.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
 	}
+	t.TLSClientConfig.InsecureSkipVerify = true //nolint:gosec // Only for the registries configured to skip the TLS verification.
+	return t
+}
+
+// parseReference parses the reference of a bundle, whose registry is reached
+// over plain HTTP when it's listed in the insecure-registries field of the
+// bundle-resolver-config ConfigMap.
+func parseReference(ctx context.Context, ref string) (name.Reference, error) {
+	imgRef, err := name.ParseReference(ref)
+	if err != nil {
+		return nil, err
+	}
+	if getRegistryConfig(ctx, imgRef.Context().RegistryStr()).insecure {
+		return name.ParseReference(ref, name.Insecure)
+	}
+	return imgRef, nil
+}
+
+// registryTransport returns the transport of the requests to the registry,
+// which doesn't verify its TLS certificate when it's listed in the
+// skip-tls-verify-registries field of the bundle-resolver-config ConfigMap.
+func registryTransport(ctx context.Context, reg name.Registry) http.RoundTripper {
+	if getRegistryConfig(ctx, reg.RegistryStr()).skipTLSVerify {
+		return skipTLSVerifyTransport
+	}
+	return defaultTransport
 }
 
 // checkImageCompliance will perform common checks to ensure the Tekton Bundle is compliant to our spec.
@@ -168,7 +380,7 @@ func checkImageCompliance(manifest *v1.Manifest) error {
 			return fmt.Errorf("the layer %v does not contain a %s annotation", i, BundleAnnotationAPIVersion)
 		}
 
-		if _, ok := l.Annotations[BundleAnnotationName]; !ok {
+		if !hasTitle(l) {
 			return fmt.Errorf("the layer %v does not contain a %s annotation", i, BundleAnnotationName)
 		}
 
@@ -184,6 +396,17 @@ func checkImageCompliance(manifest *v1.Manifest) error {
 	return nil
 }
 
+// readLayer reads out the contents of an image layer, either a tarball or
+// else raw bytes.
+func readLayer(layer, rawLayer v1.Layer) []byte {
+	obj, err := readTarLayer(layer)
+	if err != nil {
+		// This could still be a raw layer so try to read it as that instead.
+		obj, _ = readRawLayer(rawLayer)
+	}
+	return obj
+}
+
 // Utility function to read out the contents of an image layer, assumed to be a tarball, as bytes.
 func readTarLayer(layer v1.Layer) ([]byte, error) {
 	rc, err := layer.Uncompressed()
@@ -0,0 +1,233 @@
+/*
+Copyright 2025 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"github.com/tektoncd/pipeline/test"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// pushMixedBundle pushes a bundle whose layers record the names of their
+// objects under different annotations, and returns its reference.
+func pushMixedBundle(t *testing.T) string {
+	t.Helper()
+	r := newLayerRegistry(t)
+	task := func(name string) *pipelinev1.Task {
+		return &pipelinev1.Task{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1", Kind: "Task"},
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       pipelinev1.TaskSpec{Steps: []pipelinev1.Step{{Name: "step", Image: "image"}}},
+		}
+	}
+	pipeline := &pipelinev1.Pipeline{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1", Kind: "Pipeline"},
+		ObjectMeta: metav1.ObjectMeta{Name: "deploy"},
+		Spec:       pipelinev1.PipelineSpec{Tasks: []pipelinev1.PipelineTask{{Name: "build", TaskRef: &pipelinev1.TaskRef{Name: "build"}}}},
+	}
+	titles := map[string]map[string]string{
+		"build":  {BundleAnnotationName: "build"},
+		"lint":   {BundleAnnotationTitle: "Lint"},
+		"test":   {OCIAnnotationTitle: "test.yaml"},
+		"deploy": {BundleAnnotationName: "deploy", BundleAnnotationTitle: "Deploy-Pipeline"},
+	}
+	mapper := func(obj runtime.Object) map[string]string {
+		annotations := map[string]string{
+			BundleAnnotationKind:       strings.ToLower(obj.GetObjectKind().GroupVersionKind().Kind),
+			BundleAnnotationAPIVersion: obj.GetObjectKind().GroupVersionKind().Version,
+		}
+		for k, v := range titles[test.GetObjectName(obj)] {
+			annotations[k] = v
+		}
+		return annotations
+	}
+	ref, err := test.CreateImageWithAnnotations(r.host+"/bundle:latest", mapper, task("build"), task("lint"), task("test"), pipeline)
+	if err != nil {
+		t.Fatalf("couldn't push the image: %v", err)
+	}
+	return ref
+}
+
+func TestGetCachedEntry_Titles(t *testing.T) {
+	ref := pushMixedBundle(t)
+	ctx := framework.InjectResolverConfigToContext(t.Context(), map[string]string{})
+
+	for _, tc := range []struct {
+		name     string
+		kind     string
+		entry    string
+		wantName string
+	}{{
+		name:     "name annotation",
+		kind:     "task",
+		entry:    "build",
+		wantName: "build",
+	}, {
+		name:     "name annotation with another casing",
+		kind:     "task",
+		entry:    "BUILD",
+		wantName: "build",
+	}, {
+		name:     "tekton title annotation",
+		kind:     "task",
+		entry:    "lint",
+		wantName: "Lint",
+	}, {
+		name:     "oci title annotation",
+		kind:     "task",
+		entry:    "Test.yaml",
+		wantName: "test.yaml",
+	}, {
+		name:     "title annotation of a layer with a name annotation",
+		kind:     "pipeline",
+		entry:    "deploy-pipeline",
+		wantName: "deploy",
+	}, {
+		name:     "plural kind with another casing",
+		kind:     "Tasks",
+		entry:    "lint",
+		wantName: "Lint",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := GetCachedEntry(ctx, authn.DefaultKeychain, RequestOptions{Bundle: ref, EntryName: tc.entry, Kind: tc.kind}, nil, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := res.Annotations()[ResolverAnnotationName]; got != tc.wantName {
+				t.Errorf("expected the name %q, got %q", tc.wantName, got)
+			}
+			if got := res.RefSource().EntryPoint; got != tc.wantName {
+				t.Errorf("expected the entry point %q, got %q", tc.wantName, got)
+			}
+		})
+	}
+}
+
+func TestGetCachedEntry_TitleNotFound(t *testing.T) {
+	ref := pushMixedBundle(t)
+	ctx := framework.InjectResolverConfigToContext(t.Context(), map[string]string{})
+
+	_, err := GetCachedEntry(ctx, authn.DefaultKeychain, RequestOptions{Bundle: ref, EntryName: "release", Kind: "task"}, nil, nil)
+
+	want := "could not find object in image with kind: task and name: release, available titles: task/build, task/Lint, task/test.yaml, pipeline/deploy, pipeline/Deploy-Pipeline"
+	if err == nil || err.Error() != want {
+		t.Errorf("expected error %q, got %v", want, err)
+	}
+}
+
+func TestFindLayer_CaseInsensitiveMatches(t *testing.T) {
+	manifest := &v1.Manifest{Layers: []v1.Descriptor{{
+		Annotations: map[string]string{BundleAnnotationKind: "task", BundleAnnotationName: "build"},
+	}, {
+		Annotations: map[string]string{BundleAnnotationKind: "task", BundleAnnotationTitle: "Build"},
+	}}}
+
+	idx, err := findLayer(manifest, RequestOptions{Kind: "task", EntryName: "Build"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if idx != 1 {
+		t.Errorf("expected the exact match to win, got the layer %d", idx)
+	}
+
+	_, err = findLayer(manifest, RequestOptions{Kind: "task", EntryName: "BUILD"})
+	want := "several objects in image with kind: task match the name: BUILD: build, Build"
+	if err == nil || err.Error() != want {
+		t.Errorf("expected error %q, got %v", want, err)
+	}
+}
+
+func TestKindMatches(t *testing.T) {
+	for _, tc := range []struct {
+		kind, layerKind string
+		want            bool
+	}{
+		{kind: "task", layerKind: "task", want: true},
+		{kind: "Task", layerKind: "task", want: true},
+		{kind: "tasks", layerKind: "task", want: true},
+		{kind: "TASKS", layerKind: "task", want: true},
+		{kind: "task", layerKind: "Task", want: true},
+		{kind: "Tasks", layerKind: "TASK", want: true},
+		{kind: "task", layerKind: "pipeline", want: false},
+		{kind: "stepactions", layerKind: "stepaction", want: true},
+	} {
+		if got := kindMatches(tc.kind, tc.layerKind); got != tc.want {
+			t.Errorf("kindMatches(%q, %q) = %t, want %t", tc.kind, tc.layerKind, got, tc.want)
+		}
+	}
+}
+
+func TestGetCachedEntry_PinnedDigestMismatch(t *testing.T) {
+	handler := registry.New()
+	// The registry serves the manifest of another bundle for the digest of
+	// the pinned bundle.
+	var tamper atomic.Pointer[strings.Replacer]
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if replacer := tamper.Load(); replacer != nil {
+			req.URL.Path = replacer.Replace(req.URL.Path)
+		}
+		handler.ServeHTTP(w, req)
+	}))
+	t.Cleanup(s.Close)
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := &layerRegistry{host: u.Host}
+	ref := r.push(t, "pinned", "image")
+	otherRef := r.push(t, "other", "other-image")
+	tamper.Store(strings.NewReplacer(ref[strings.Index(ref, "@")+1:], otherRef[strings.Index(otherRef, "@")+1:]))
+	ctx := framework.InjectResolverConfigToContext(t.Context(), map[string]string{})
+
+	_, err = GetCachedEntry(ctx, authn.DefaultKeychain, RequestOptions{Bundle: ref, EntryName: "example-task", Kind: "task"}, nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "does not match") {
+		t.Errorf("expected the digest mismatch to fail the resolution, got %v", err)
+	}
+}
+
+func TestGetCachedEntry_BundleDigest(t *testing.T) {
+	r := newLayerRegistry(t)
+	ref := r.push(t, "latest", "image")
+	digest := ref[strings.Index(ref, "@")+1:]
+	ctx := framework.InjectResolverConfigToContext(t.Context(), map[string]string{})
+
+	for _, bundle := range []string{ref, r.host + "/bundle:latest"} {
+		res := resolveCachedEntry(ctx, t, nil, bundle)
+		if got := res.Annotations()[ResolverAnnotationDigest]; got != digest {
+			t.Errorf("expected the digest %s of %s to be recorded, got %q", digest, bundle, got)
+		}
+		want := &pipelinev1.RefSource{
+			URI:        r.host + "/bundle",
+			Digest:     map[string]string{"sha256": strings.TrimPrefix(digest, "sha256:")},
+			EntryPoint: "example-task",
+		}
+		if d := cmp.Diff(want, res.RefSource()); d != "" {
+			t.Errorf("unexpected RefSource of %s %s", bundle, diff.PrintWantGot(d))
+		}
+	}
+}
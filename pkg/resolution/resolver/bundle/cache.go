@@ -0,0 +1,112 @@
+/*
+Copyright 2025 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+const (
+	// CacheHit is the value of the cache annotation of a resource whose
+	// content was served from the layer cache.
+	CacheHit = "hit"
+	// CacheMiss is the value of the cache annotation of a resource whose
+	// content was downloaded from the registry.
+	CacheMiss = "miss"
+)
+
+// LayerCache caches the content extracted from the layers of the bundles,
+// keyed by the digests of the bundle and of the layer, so that the
+// resolutions of the same bundle don't download its layers again. The least
+// recently used entries are evicted once the total size of the cached
+// content exceeds the maximum size.
+type LayerCache struct {
+	mu      sync.Mutex
+	entries map[layerCacheKey]*list.Element
+	lru     *list.List
+	size    int64
+
+	now func() time.Time
+}
+
+// layerCacheKey is the key of the content of a layer in the layer cache.
+type layerCacheKey struct {
+	image string
+	layer string
+}
+
+type layerCacheEntry struct {
+	key     layerCacheKey
+	content []byte
+	expires time.Time
+}
+
+// NewLayerCache returns an empty LayerCache.
+func NewLayerCache() *LayerCache {
+	return &LayerCache{
+		entries: map[layerCacheKey]*list.Element{},
+		lru:     list.New(),
+		now:     time.Now,
+	}
+}
+
+// get returns a copy of the content cached with the key, if it hasn't
+// expired.
+func (c *LayerCache) get(key layerCacheKey) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*layerCacheEntry)
+	if !c.now().Before(entry.expires) {
+		c.remove(elem)
+		return nil, false
+	}
+	c.lru.MoveToFront(elem)
+	return append([]byte(nil), entry.content...), true
+}
+
+// add caches the content with the key for the ttl, evicting the least
+// recently used entries to keep the total size of the cached content under
+// maxSize. Content larger than maxSize isn't cached.
+func (c *LayerCache) add(key layerCacheKey, content []byte, ttl time.Duration, maxSize int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+	size := int64(len(content))
+	if size > maxSize {
+		return
+	}
+	for c.size+size > maxSize {
+		c.remove(c.lru.Back())
+	}
+	c.entries[key] = c.lru.PushFront(&layerCacheEntry{
+		key:     key,
+		content: append([]byte(nil), content...),
+		expires: c.now().Add(ttl),
+	})
+	c.size += size
+}
+
+func (c *LayerCache) remove(elem *list.Element) {
+	entry := c.lru.Remove(elem).(*layerCacheEntry)
+	delete(c.entries, entry.key)
+	c.size -= int64(len(entry.content))
+}
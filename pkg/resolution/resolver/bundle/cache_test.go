@@ -0,0 +1,244 @@
+/*
+Copyright 2025 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/registry"
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"github.com/tektoncd/pipeline/test"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// layerRegistry is a fake registry counting the downloads of the layers.
+type layerRegistry struct {
+	host      string
+	downloads atomic.Int64
+}
+
+func newLayerRegistry(t *testing.T) *layerRegistry {
+	t.Helper()
+	r := &layerRegistry{}
+	handler := registry.New()
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method == http.MethodGet && strings.Contains(req.URL.Path, "/blobs/") {
+			r.downloads.Add(1)
+		}
+		handler.ServeHTTP(w, req)
+	}))
+	t.Cleanup(s.Close)
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.host = u.Host
+	return r
+}
+
+// push pushes a bundle of a task with the step image to the tag, and returns
+// the reference of the bundle with its digest.
+func (r *layerRegistry) push(t *testing.T, tag, stepImage string) string {
+	t.Helper()
+	task := &pipelinev1.Task{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1", Kind: "Task"},
+		ObjectMeta: metav1.ObjectMeta{Name: "example-task"},
+		Spec: pipelinev1.TaskSpec{
+			Steps: []pipelinev1.Step{{Name: "step", Image: stepImage}},
+		},
+	}
+	ref, err := test.CreateImage(fmt.Sprintf("%s/bundle:%s", r.host, tag), task)
+	if err != nil {
+		t.Fatalf("couldn't push the image: %v", err)
+	}
+	r.downloads.Store(0)
+	return ref
+}
+
+func resolveCachedEntry(ctx context.Context, t *testing.T, cache *LayerCache, bundle string) *ResolvedResource {
+	t.Helper()
+	res, err := GetCachedEntry(ctx, authn.DefaultKeychain, RequestOptions{
+		Bundle:    bundle,
+		EntryName: "example-task",
+		Kind:      "task",
+	}, cache, nil)
+	if err != nil {
+		t.Fatalf("unexpected error resolving %s: %v", bundle, err)
+	}
+	return res
+}
+
+func TestGetCachedEntry_Digest(t *testing.T) {
+	r := newLayerRegistry(t)
+	ref := r.push(t, "latest", "image")
+	ctx := framework.InjectResolverConfigToContext(t.Context(), map[string]string{})
+	cache := NewLayerCache()
+
+	first := resolveCachedEntry(ctx, t, cache, ref)
+	second := resolveCachedEntry(ctx, t, cache, ref)
+
+	if got := first.Annotations()[ResolverAnnotationCache]; got != CacheMiss {
+		t.Errorf("expected the first resolution to be a cache %s, got %q", CacheMiss, got)
+	}
+	if got := second.Annotations()[ResolverAnnotationCache]; got != CacheHit {
+		t.Errorf("expected the second resolution to be a cache %s, got %q", CacheHit, got)
+	}
+	if string(first.Data()) != string(second.Data()) {
+		t.Errorf("expected the same content, got %q and %q", first.Data(), second.Data())
+	}
+	if got := r.downloads.Load(); got != 1 {
+		t.Errorf("expected the layer to be downloaded once, got %d downloads", got)
+	}
+}
+
+func TestGetCachedEntry_Tag(t *testing.T) {
+	r := newLayerRegistry(t)
+	r.push(t, "v1", "old-image")
+	ctx := framework.InjectResolverConfigToContext(t.Context(), map[string]string{})
+	cache := NewLayerCache()
+	ref := r.host + "/bundle:v1"
+
+	resolveCachedEntry(ctx, t, cache, ref)
+	if res := resolveCachedEntry(ctx, t, cache, ref); res.Annotations()[ResolverAnnotationCache] != CacheHit {
+		t.Errorf("expected the unchanged tag to be resolved from the cache, got %q", res.Annotations()[ResolverAnnotationCache])
+	}
+
+	// The tag is resolved again on each request, so the content of the bundle
+	// it moved to is resolved.
+	r.push(t, "v1", "new-image")
+	res := resolveCachedEntry(ctx, t, cache, ref)
+	if got := res.Annotations()[ResolverAnnotationCache]; got != CacheMiss {
+		t.Errorf("expected the moved tag to be a cache %s, got %q", CacheMiss, got)
+	}
+	if !strings.Contains(string(res.Data()), "new-image") {
+		t.Errorf("expected the content of the new bundle, got %q", res.Data())
+	}
+	if got := r.downloads.Load(); got != 1 {
+		t.Errorf("expected the layer of the new bundle to be downloaded once, got %d downloads", got)
+	}
+}
+
+func TestGetCachedEntry_Disabled(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		config map[string]string
+		cache  *LayerCache
+	}{{
+		name:  "no cache",
+		cache: nil,
+	}, {
+		name:   "cache ttl 0",
+		config: map[string]string{ConfigCacheTTL: "0"},
+		cache:  NewLayerCache(),
+	}, {
+		name:   "cache max size 0",
+		config: map[string]string{ConfigCacheMaxSizeBytes: "0"},
+		cache:  NewLayerCache(),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newLayerRegistry(t)
+			ref := r.push(t, "latest", "image")
+			ctx := framework.InjectResolverConfigToContext(t.Context(), tc.config)
+
+			for range 2 {
+				res := resolveCachedEntry(ctx, t, tc.cache, ref)
+				if got, ok := res.Annotations()[ResolverAnnotationCache]; ok {
+					t.Errorf("expected no cache annotation, got %q", got)
+				}
+			}
+			if got := r.downloads.Load(); got != 2 {
+				t.Errorf("expected the layer to be downloaded twice, got %d downloads", got)
+			}
+		})
+	}
+}
+
+func TestGetCachedEntry_InvalidConfig(t *testing.T) {
+	for _, tc := range []struct {
+		config  map[string]string
+		wantErr string
+	}{{
+		config:  map[string]string{ConfigCacheTTL: "forever"},
+		wantErr: `error parsing cache ttl value forever: must be a non-negative duration like "5m"`,
+	}, {
+		config:  map[string]string{ConfigCacheMaxSizeBytes: "-1"},
+		wantErr: "error parsing cache max size value -1: must be a non-negative number of bytes",
+	}} {
+		ctx := framework.InjectResolverConfigToContext(t.Context(), tc.config)
+		_, err := GetCachedEntry(ctx, authn.DefaultKeychain, RequestOptions{Bundle: "example.com/bundle:latest", Kind: "task"}, NewLayerCache(), nil)
+		if err == nil || err.Error() != tc.wantErr {
+			t.Errorf("expected error %q, got %v", tc.wantErr, err)
+		}
+	}
+}
+
+func TestLayerCache_Expires(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := NewLayerCache()
+	cache.now = func() time.Time { return now }
+	key := layerCacheKey{image: "sha256:image", layer: "sha256:layer"}
+
+	cache.add(key, []byte("content"), time.Minute, 100)
+	if _, ok := cache.get(key); !ok {
+		t.Fatal("expected the content to be cached")
+	}
+	now = now.Add(time.Minute)
+	if _, ok := cache.get(key); ok {
+		t.Error("expected the content to have expired")
+	}
+	if cache.size != 0 {
+		t.Errorf("expected the size of the expired content to be released, got %d", cache.size)
+	}
+}
+
+func TestLayerCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewLayerCache()
+	a := layerCacheKey{image: "sha256:image", layer: "sha256:a"}
+	b := layerCacheKey{image: "sha256:image", layer: "sha256:b"}
+	c := layerCacheKey{image: "sha256:image", layer: "sha256:c"}
+
+	cache.add(a, []byte("aaaa"), time.Minute, 10)
+	cache.add(b, []byte("bbbb"), time.Minute, 10)
+	// a is used more recently than b.
+	cache.get(a)
+	cache.add(c, []byte("cccc"), time.Minute, 10)
+
+	if _, ok := cache.get(b); ok {
+		t.Error("expected the least recently used content to be evicted")
+	}
+	for _, key := range []layerCacheKey{a, c} {
+		if _, ok := cache.get(key); !ok {
+			t.Errorf("expected the content of %s to be cached", key.layer)
+		}
+	}
+	if cache.size != 8 {
+		t.Errorf("expected the size of the cache to be 8, got %d", cache.size)
+	}
+
+	// Content larger than the cache isn't cached.
+	cache.add(b, []byte("bbbbbbbbbbb"), time.Minute, 10)
+	if _, ok := cache.get(b); ok {
+		t.Error("expected the content larger than the cache not to be cached")
+	}
+}
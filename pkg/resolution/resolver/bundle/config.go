@@ -15,8 +15,12 @@ package bundle
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
+	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/go-containerregistry/pkg/v1/remote"
@@ -54,6 +58,50 @@ const (
 	// the maximum duration to try when backing off
 	ConfigBackoffCap  = "backoff-cap"
 	DefaultBackoffCap = 10 * time.Second
+	// ConfigCacheTTL is the configuration field name for controlling how long
+	// the content of the layers of a bundle is cached once the digest of the
+	// bundle is known. "0" disables the layer cache.
+	ConfigCacheTTL  = "cache-ttl"
+	DefaultCacheTTL = 5 * time.Minute
+	// ConfigCacheMaxSizeBytes is the configuration field name for controlling
+	// the maximum total size of the content of the layers kept in the layer
+	// cache. "0" disables the layer cache.
+	ConfigCacheMaxSizeBytes  = "cache-max-size-bytes"
+	DefaultCacheMaxSizeBytes = 50 * 1024 * 1024
+	// ConfigVerifySignatures is the configuration field name for controlling
+	// whether the cosign signatures of the bundles are verified against the
+	// configured public keys before their content is returned.
+	ConfigVerifySignatures = "verify-signatures"
+	// ConfigSignaturePublicKeysSecretName is the configuration field name for
+	// controlling the name of the secret holding the PEM encoded public keys
+	// the signatures of the bundles are verified against.
+	ConfigSignaturePublicKeysSecretName = "signature-public-keys-secret-name"
+	// ConfigSignaturePublicKeysSecretKey is the configuration field name for
+	// controlling the key of the public keys in their secret.
+	ConfigSignaturePublicKeysSecretKey  = "signature-public-keys-secret-key"
+	DefaultSignaturePublicKeysSecretKey = "cosign.pub"
+	// ConfigSignaturePublicKeysSecretNamespace is the configuration field
+	// name for controlling the namespace of the secret of the public keys. It
+	// defaults to the namespace of the resolvers.
+	ConfigSignaturePublicKeysSecretNamespace = "signature-public-keys-secret-namespace"
+	// ConfigInsecureRegistries is the configuration field name for
+	// controlling the comma-separated host[:port] of the registries the
+	// bundles are pulled from over plain HTTP.
+	ConfigInsecureRegistries = "insecure-registries"
+	// ConfigSkipTLSVerifyRegistries is the configuration field name for
+	// controlling the comma-separated host[:port] of the registries whose TLS
+	// certificate isn't verified, e.g. because it's self-signed.
+	ConfigSkipTLSVerifyRegistries = "skip-tls-verify-registries"
+	// ConfigRegistryRetries is the configuration field name for controlling
+	// the maximum number of retries of the requests to the registries which
+	// were throttled or failed with a server error. 0 disables the retries.
+	ConfigRegistryRetries  = "registry-retries"
+	DefaultRegistryRetries = 3
+	// ConfigRegistryRetryBackoff is the configuration field name for
+	// controlling the wait before the first retry of a request to a
+	// registry, doubled before each of the next ones.
+	ConfigRegistryRetryBackoff  = "registry-retry-backoff"
+	DefaultRegistryRetryBackoff = 1 * time.Second
 )
 
 // GetBundleResolverBackoff returns a remote.Backoff to
@@ -113,3 +161,143 @@ func GetBundleResolverBackoff(ctx context.Context) (remote.Backoff, error) {
 
 	return customRetryBackoff, nil
 }
+
+// GetBundleResolverCacheConfig returns the time to live of the entries of the
+// layer cache and its maximum size in bytes. This can be configured with the
+// cache-ttl and cache-max-size-bytes fields in the bundle-resolver-config
+// ConfigMap. Either being 0 disables the layer cache.
+func GetBundleResolverCacheConfig(ctx context.Context) (time.Duration, int64, error) {
+	conf := framework.GetResolverConfigFromContext(ctx)
+
+	ttl := DefaultCacheTTL
+	if v, ok := conf[ConfigCacheTTL]; ok {
+		var err error
+		ttl, err = time.ParseDuration(v)
+		if err != nil || ttl < 0 {
+			return 0, 0, fmt.Errorf("error parsing cache ttl value %s: must be a non-negative duration like \"5m\"", v)
+		}
+	}
+	var maxSize int64 = DefaultCacheMaxSizeBytes
+	if v, ok := conf[ConfigCacheMaxSizeBytes]; ok {
+		var err error
+		maxSize, err = strconv.ParseInt(v, 10, 64)
+		if err != nil || maxSize < 0 {
+			return 0, 0, fmt.Errorf("error parsing cache max size value %s: must be a non-negative number of bytes", v)
+		}
+	}
+
+	return ttl, maxSize, nil
+}
+
+// GetBundleResolverRetryConfig returns the maximum number of retries of the
+// requests to the registries and the wait before their first retry. This can
+// be configured with the registry-retries and registry-retry-backoff fields in
+// the bundle-resolver-config ConfigMap.
+func GetBundleResolverRetryConfig(ctx context.Context) (int, time.Duration, error) {
+	conf := framework.GetResolverConfigFromContext(ctx)
+
+	retries := DefaultRegistryRetries
+	if v, ok := conf[ConfigRegistryRetries]; ok {
+		var err error
+		retries, err = strconv.Atoi(v)
+		if err != nil || retries < 0 {
+			return 0, 0, fmt.Errorf("error parsing registry retries value %s: must be a non-negative number", v)
+		}
+	}
+	backoff := DefaultRegistryRetryBackoff
+	if v, ok := conf[ConfigRegistryRetryBackoff]; ok {
+		var err error
+		backoff, err = time.ParseDuration(v)
+		if err != nil || backoff < 0 {
+			return 0, 0, fmt.Errorf("error parsing registry retry backoff value %s: must be a non-negative duration like \"1s\"", v)
+		}
+	}
+
+	return retries, backoff, nil
+}
+
+// signaturePublicKeysSecret is the secret holding the public keys the
+// signatures of the bundles are verified against.
+type signaturePublicKeysSecret struct {
+	name string
+	key  string
+	ns   string
+}
+
+// getSignaturePublicKeysSecret returns the secret of the public keys the
+// signatures of the bundles are verified against, or nil when the
+// verify-signatures field of the bundle-resolver-config ConfigMap isn't set.
+func getSignaturePublicKeysSecret(ctx context.Context) (*signaturePublicKeysSecret, error) {
+	conf := framework.GetResolverConfigFromContext(ctx)
+
+	v, ok := conf[ConfigVerifySignatures]
+	if !ok {
+		return nil, nil
+	}
+	verify, err := strconv.ParseBool(v)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing verify signatures value %s: %w", v, err)
+	}
+	if !verify {
+		return nil, nil
+	}
+	secret := &signaturePublicKeysSecret{
+		name: conf[ConfigSignaturePublicKeysSecretName],
+		key:  conf[ConfigSignaturePublicKeysSecretKey],
+		ns:   conf[ConfigSignaturePublicKeysSecretNamespace],
+	}
+	if secret.name == "" {
+		return nil, errors.New(ConfigSignaturePublicKeysSecretName + " is required to verify the signatures of the bundles")
+	}
+	if secret.key == "" {
+		secret.key = DefaultSignaturePublicKeysSecretKey
+	}
+	if secret.ns == "" {
+		secret.ns = os.Getenv("SYSTEM_NAMESPACE")
+	}
+	return secret, nil
+}
+
+// registryConfig is how the resolver connects to the registry of a bundle.
+type registryConfig struct {
+	// insecure is whether the registry is reached over plain HTTP.
+	insecure bool
+	// skipTLSVerify is whether the TLS certificate of the registry isn't
+	// verified.
+	skipTLSVerify bool
+}
+
+// getRegistryConfig returns how the resolver connects to the registry of the
+// host[:port], as listed in the insecure-registries and
+// skip-tls-verify-registries fields in the bundle-resolver-config ConfigMap.
+// An entry without a port matches the host on any port.
+func getRegistryConfig(ctx context.Context, host string) registryConfig {
+	conf := framework.GetResolverConfigFromContext(ctx)
+
+	return registryConfig{
+		insecure:      registryListed(conf[ConfigInsecureRegistries], host),
+		skipTLSVerify: registryListed(conf[ConfigSkipTLSVerifyRegistries], host),
+	}
+}
+
+// registryListed returns whether the comma-separated list of host[:port]
+// has an entry matching the host[:port].
+func registryListed(list, host string) bool {
+	hostname := host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		hostname = h
+	}
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if strings.EqualFold(entry, host) {
+			return true
+		}
+		if _, _, err := net.SplitHostPort(entry); err != nil && strings.EqualFold(entry, hostname) {
+			return true
+		}
+	}
+	return false
+}
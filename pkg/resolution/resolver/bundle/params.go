@@ -36,7 +36,8 @@ const ParamImagePullSecret = "secret"
 const ParamBundle = "bundle"
 
 // ParamName is the parameter defining what the layer name in the bundle
-// image is.
+// image is. It can be omitted when the bundle contains a single object of
+// the requested kind.
 const ParamName = resource.ParamName
 
 // ParamKind is the parameter defining what the layer kind in the bundle
@@ -74,11 +75,6 @@ func OptionsFromParams(ctx context.Context, params []pipelinev1.Param) (RequestO
 		return opts, fmt.Errorf("invalid bundle reference: %w", err)
 	}
 
-	nameVal, ok := paramsMap[ParamName]
-	if !ok || nameVal.StringVal == "" {
-		return opts, fmt.Errorf("parameter %q required", ParamName)
-	}
-
 	kindVal, ok := paramsMap[ParamKind]
 	kind := ""
 	if !ok || kindVal.StringVal == "" {
@@ -94,7 +90,7 @@ func OptionsFromParams(ctx context.Context, params []pipelinev1.Param) (RequestO
 	opts.ServiceAccount = sa
 	opts.ImagePullSecret = paramsMap[ParamImagePullSecret].StringVal
 	opts.Bundle = bundleVal.StringVal
-	opts.EntryName = nameVal.StringVal
+	opts.EntryName = paramsMap[ParamName].StringVal
 	opts.Kind = kind
 
 	return opts, nil
@@ -0,0 +1,163 @@
+/*
+Copyright 2025 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"github.com/tektoncd/pipeline/test"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// registryHost is the host of the bundles of the tests of the registry
+// config. Unlike the loopback address of the test servers, it's reached over
+// HTTPS unless it's listed in the insecure registries.
+const registryHost = "bundles.registry.test"
+
+// serveRegistry serves a registry holding a bundle with the server, routes
+// the requests to registryHost to the server, and returns the reference of
+// the bundle on registryHost.
+func serveRegistry(t *testing.T, start func(*httptest.Server)) string {
+	t.Helper()
+	handler := registry.New()
+
+	// The bundle is pushed through a plain HTTP loopback server sharing the
+	// storage of the registry.
+	push := httptest.NewServer(handler)
+	t.Cleanup(push.Close)
+	u, err := url.Parse(push.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	task := &pipelinev1.Task{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1", Kind: "Task"},
+		ObjectMeta: metav1.ObjectMeta{Name: "example-task"},
+		Spec:       pipelinev1.TaskSpec{Steps: []pipelinev1.Step{{Name: "step", Image: "image"}}},
+	}
+	ref, err := test.CreateImage(u.Host+"/bundle:latest", task)
+	if err != nil {
+		t.Fatalf("couldn't push the image: %v", err)
+	}
+
+	s := httptest.NewUnstartedServer(handler)
+	start(s)
+	t.Cleanup(s.Close)
+
+	route := func(rt *http.Transport) *http.Transport {
+		rt = rt.Clone()
+		rt.DialContext = func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, s.Listener.Addr().String())
+		}
+		return rt
+	}
+	prevDefault, prevSkipTLSVerify := defaultTransport, skipTLSVerifyTransport
+	t.Cleanup(func() {
+		defaultTransport, skipTLSVerifyTransport = prevDefault, prevSkipTLSVerify
+	})
+	defaultTransport = route(remote.DefaultTransport.(*http.Transport))
+	skipTLSVerifyTransport = route(newSkipTLSVerifyTransport(remote.DefaultTransport.(*http.Transport)))
+
+	return registryHost + strings.TrimPrefix(ref, u.Host)
+}
+
+func TestGetCachedEntry_InsecureRegistries(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		config  map[string]string
+		wantErr string
+	}{{
+		name:    "not listed",
+		config:  map[string]string{},
+		wantErr: "server gave HTTP response to HTTPS client",
+	}, {
+		name:   "listed",
+		config: map[string]string{ConfigInsecureRegistries: "registry.example.com, " + registryHost},
+	}, {
+		name:    "listed on another port",
+		config:  map[string]string{ConfigInsecureRegistries: registryHost + ":5000"},
+		wantErr: "server gave HTTP response to HTTPS client",
+	}, {
+		name:    "listed to skip the TLS verification",
+		config:  map[string]string{ConfigSkipTLSVerifyRegistries: registryHost},
+		wantErr: "server gave HTTP response to HTTPS client",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ref := serveRegistry(t, (*httptest.Server).Start)
+			ctx := framework.InjectResolverConfigToContext(t.Context(), tc.config)
+
+			res, err := GetCachedEntry(ctx, authn.DefaultKeychain, RequestOptions{Bundle: ref, EntryName: "example-task", Kind: "task"}, nil, nil)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected an error containing %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !strings.Contains(string(res.Data()), "example-task") {
+				t.Errorf("expected the content of the bundle, got %q", res.Data())
+			}
+		})
+	}
+}
+
+func TestGetCachedEntry_SkipTLSVerifyRegistries(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		config  map[string]string
+		wantErr string
+	}{{
+		name:    "not listed",
+		config:  map[string]string{},
+		wantErr: "failed to verify certificate",
+	}, {
+		name:   "listed",
+		config: map[string]string{ConfigSkipTLSVerifyRegistries: registryHost},
+	}, {
+		name:    "listed as insecure",
+		config:  map[string]string{ConfigInsecureRegistries: registryHost},
+		wantErr: "failed to verify certificate",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ref := serveRegistry(t, (*httptest.Server).StartTLS)
+			ctx := framework.InjectResolverConfigToContext(t.Context(), tc.config)
+
+			res, err := GetCachedEntry(ctx, authn.DefaultKeychain, RequestOptions{Bundle: ref, EntryName: "example-task", Kind: "task"}, nil, nil)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected an error containing %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !strings.Contains(string(res.Data()), "example-task") {
+				t.Errorf("expected the content of the bundle, got %q", res.Data())
+			}
+		})
+	}
+}
@@ -60,11 +60,13 @@ var _ framework.TimedResolution = &Resolver{}
 // Deprecated: Use [github.com/tektoncd/pipeline/pkg/remoteresolution/resolver/bundle.Resolver] instead.
 type Resolver struct {
 	kubeClientSet kubernetes.Interface
+	layerCache    *LayerCache
 }
 
-// Initialize sets up any dependencies needed by the Resolver. None atm.
+// Initialize sets up any dependencies needed by the Resolver.
 func (r *Resolver) Initialize(ctx context.Context) error {
 	r.kubeClientSet = client.Get(ctx)
+	r.layerCache = NewLayerCache()
 	return nil
 }
 
@@ -87,11 +89,13 @@ func (r *Resolver) ValidateParams(ctx context.Context, params []v1.Param) error
 
 // Resolve uses the given params to resolve the requested file or resource.
 func (r *Resolver) Resolve(ctx context.Context, params []v1.Param) (framework.ResolvedResource, error) {
-	return ResolveRequest(ctx, r.kubeClientSet, &v1beta1.ResolutionRequestSpec{Params: params})
+	return ResolveRequest(ctx, r.kubeClientSet, r.layerCache, &v1beta1.ResolutionRequestSpec{Params: params})
 }
 
-// Resolve uses the given params to resolve the requested file or resource.
-func ResolveRequest(ctx context.Context, kubeClientSet kubernetes.Interface, req *v1beta1.ResolutionRequestSpec) (framework.ResolvedResource, error) {
+// ResolveRequest uses the given request spec to resolve the requested file or
+// resource, serving the content of the layers of the bundle from the layer
+// cache when it's not nil and verifying its signature when configured.
+func ResolveRequest(ctx context.Context, kubeClientSet kubernetes.Interface, layerCache *LayerCache, req *v1beta1.ResolutionRequestSpec) (framework.ResolvedResource, error) {
 	if isDisabled(ctx) {
 		return nil, errors.New(disabledError)
 	}
@@ -112,7 +116,11 @@ func ResolveRequest(ctx context.Context, kubeClientSet kubernetes.Interface, req
 	if err != nil {
 		return nil, err
 	}
-	return GetEntry(ctx, kc, opts)
+	keys, err := LoadSignaturePublicKeys(ctx, kubeClientSet)
+	if err != nil {
+		return nil, fmt.Errorf("bundle %s signature verification failed: %w", opts.Bundle, err)
+	}
+	return GetCachedEntry(ctx, kc, opts, layerCache, keys)
 }
 
 func ValidateParams(ctx context.Context, params []v1.Param) error {
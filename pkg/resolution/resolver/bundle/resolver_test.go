@@ -227,9 +227,12 @@ func TestValidateParamsMissing(t *testing.T) {
 		Name:  bundle.ParamImagePullSecret,
 		Value: *pipelinev1.NewStructuredValues("baz"),
 	}}
-	err = resolver.ValidateParams(t.Context(), paramsMissingName)
-	if err == nil {
-		t.Fatalf("expected missing name err")
+	// The name can be omitted when the bundle contains a single object of the kind.
+	ctx := framework.InjectResolverConfigToContext(t.Context(), map[string]string{
+		bundle.ConfigServiceAccount: "default",
+	})
+	if err := resolver.ValidateParams(ctx, paramsMissingName); err != nil {
+		t.Fatalf("unexpected error validating params without name: %v", err)
 	}
 }
 
@@ -365,6 +368,9 @@ func TestResolve(t *testing.T) {
 		t.Fatalf("couldn't marshal pipeline: %v", err)
 	}
 
+	otherTask := exampleTask.DeepCopy()
+	otherTask.Name = "other-task"
+
 	// too many objects in bundle resolver test
 	var tooManyObjs []runtime.Object
 	for i := 0; i <= bundle.MaximumBundleObjects; i++ {
@@ -393,6 +399,7 @@ func TestResolve(t *testing.T) {
 		"single-task":                     pushToRegistry(t, r, "single-task", []runtime.Object{exampleTask}, test.DefaultObjectAnnotationMapper),
 		"single-pipeline":                 pushToRegistry(t, r, "single-pipeline", []runtime.Object{examplePipeline}, test.DefaultObjectAnnotationMapper),
 		"multiple-resources":              pushToRegistry(t, r, "multiple-resources", []runtime.Object{exampleTask, examplePipeline}, test.DefaultObjectAnnotationMapper),
+		"multiple-tasks":                  pushToRegistry(t, r, "multiple-tasks", []runtime.Object{exampleTask, otherTask}, test.DefaultObjectAnnotationMapper),
 		"too-many-objs":                   pushToRegistry(t, r, "too-many-objs", tooManyObjs, asIsMapper),
 		"single-task-no-version":          pushToRegistry(t, r, "single-task-no-version", []runtime.Object{&pipelinev1.Task{TypeMeta: metav1.TypeMeta{Kind: "task"}, ObjectMeta: metav1.ObjectMeta{Name: "foo"}}}, asIsMapper),
 		"single-task-no-kind":             pushToRegistry(t, r, "single-task-no-kind", []runtime.Object{&pipelinev1.Task{TypeMeta: metav1.TypeMeta{APIVersion: "tekton.dev/v1"}, ObjectMeta: metav1.ObjectMeta{Name: "foo"}}}, asIsMapper),
@@ -405,8 +412,10 @@ func TestResolve(t *testing.T) {
 		args               *params
 		imageName          string
 		kindInBundle       string
+		resolvedName       string
 		expectedStatus     *v1beta1.ResolutionRequestStatus
 		expectedErrMessage string
+		entryErrMessage    string
 	}{
 		{
 			name: "single task: digest is included in the bundle parameter",
@@ -500,6 +509,40 @@ func TestResolve(t *testing.T) {
 			},
 			imageName:      "multiple-resources",
 			expectedStatus: resolution.CreateResolutionRequestStatusWithData(pipelineAsYAML),
+		}, {
+			name: "single task: name omitted",
+			args: &params{
+				bundle: testImages["single-task"].uri + ":latest",
+				kind:   "task",
+			},
+			imageName:      "single-task",
+			resolvedName:   "example-task",
+			expectedStatus: resolution.CreateResolutionRequestStatusWithData(taskAsYAML),
+		}, {
+			name: "multiple resources: name omitted with a single object of the kind",
+			args: &params{
+				bundle: testImages["multiple-resources"].uri + ":latest",
+				kind:   "pipeline",
+			},
+			imageName:      "multiple-resources",
+			resolvedName:   "example-pipeline",
+			expectedStatus: resolution.CreateResolutionRequestStatusWithData(pipelineAsYAML),
+		}, {
+			name: "multiple tasks: name omitted",
+			args: &params{
+				bundle: testImages["multiple-tasks"].uri + ":latest",
+				kind:   "task",
+			},
+			expectedStatus:  resolution.CreateResolutionRequestFailureStatus(),
+			entryErrMessage: `parameter "name" required, the image contains several objects with kind: task: example-task, other-task`,
+		}, {
+			name: "single pipeline: name omitted without an object of the kind",
+			args: &params{
+				bundle: testImages["single-pipeline"].uri + ":latest",
+				kind:   "task",
+			},
+			expectedStatus:  resolution.CreateResolutionRequestFailureStatus(),
+			entryErrMessage: "could not find object in image with kind: task",
 		}, {
 			name: "too many objects in an image",
 			args: &params{
@@ -582,7 +625,15 @@ func TestResolve(t *testing.T) {
 			var expectedError error
 			if tc.expectedStatus != nil {
 				expectedStatus = tc.expectedStatus.DeepCopy()
-				if tc.expectedErrMessage == "" {
+				switch {
+				case tc.entryErrMessage != "":
+					expectedError = createEntryError(tc.entryErrMessage)
+					expectedStatus.Status.Conditions[0].Message = expectedError.Error()
+				case tc.expectedErrMessage == "":
+					name := tc.args.name
+					if tc.resolvedName != "" {
+						name = tc.resolvedName
+					}
 					if expectedStatus.Annotations == nil {
 						expectedStatus.Annotations = make(map[string]string)
 					}
@@ -596,18 +647,20 @@ func TestResolve(t *testing.T) {
 						expectedStatus.Annotations[bundle.ResolverAnnotationKind] = "task"
 					}
 
-					expectedStatus.Annotations[bundle.ResolverAnnotationName] = tc.args.name
+					expectedStatus.Annotations[bundle.ResolverAnnotationName] = name
 					expectedStatus.Annotations[bundle.ResolverAnnotationAPIVersion] = "v1"
+					expectedStatus.Annotations[bundle.ResolverAnnotationCache] = bundle.CacheMiss
+					expectedStatus.Annotations[bundle.ResolverAnnotationDigest] = testImages[tc.imageName].algo + ":" + testImages[tc.imageName].hex
 
 					expectedStatus.RefSource = &pipelinev1.RefSource{
 						URI: testImages[tc.imageName].uri,
 						Digest: map[string]string{
 							testImages[tc.imageName].algo: testImages[tc.imageName].hex,
 						},
-						EntryPoint: tc.args.name,
+						EntryPoint: name,
 					}
 					expectedStatus.Source = expectedStatus.RefSource
-				} else {
+				default:
 					expectedError = createError(tc.args.bundle, tc.expectedErrMessage)
 					expectedStatus.Status.Conditions[0].Message = expectedError.Error()
 				}
@@ -662,6 +715,14 @@ func createError(image, msg string) error {
 	}
 }
 
+func createEntryError(msg string) error {
+	return &common.GetResourceError{
+		ResolverName: bundle.BundleResolverName,
+		Key:          "foo/rr",
+		Original:     errors.New(msg),
+	}
+}
+
 func asIsMapper(obj runtime.Object) map[string]string {
 	annotations := map[string]string{}
 	if test.GetObjectName(obj) != "" {
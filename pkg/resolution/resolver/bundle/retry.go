@@ -0,0 +1,134 @@
+/*
+Copyright 2025 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// maxRegistryRetryBackoff is the longest wait before a retry of a request to
+// a registry, whatever the backoff or the Retry-After header of the response.
+const maxRegistryRetryBackoff = time.Minute
+
+// registryRetries is the policy retrying the requests to the registries of
+// a resolution which were throttled, failed with a server error or whose
+// connection was reset, with an exponential backoff honoring the Retry-After
+// headers of the responses. The other failures, like the authentication
+// errors or the missing bundles, fail right away.
+//
+// The retries stop once the deadline of the request would be exceeded, so
+// the resolution timeout bounds the time spent retrying. Each wait is capped
+// to maxRegistryRetryBackoff for the requests without a deadline.
+type registryRetries struct {
+	// retries is the maximum number of retries of a request.
+	retries int
+	// backoff is the wait before the first retry of a request, doubled
+	// before each of the next ones.
+	backoff time.Duration
+	// attempts counts the retries of all the requests.
+	attempts atomic.Int64
+}
+
+// transport returns the transport retrying the requests sent with inner.
+func (r *registryRetries) transport(inner http.RoundTripper) http.RoundTripper {
+	return &retryTransport{inner: inner, policy: r}
+}
+
+type retryTransport struct {
+	inner  http.RoundTripper
+	policy *registryRetries
+}
+
+var _ http.RoundTripper = (*retryTransport)(nil)
+
+// RoundTrip implements http.RoundTripper. Only the requests without a body,
+// which can be sent again, are retried.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return t.inner.RoundTrip(req)
+	}
+	for attempt := 0; ; attempt++ {
+		resp, err := t.inner.RoundTrip(req)
+		if attempt >= t.policy.retries || !retryable(resp, err) {
+			return resp, err
+		}
+		wait := t.policy.wait(attempt, resp)
+		if deadline, ok := req.Context().Deadline(); ok && time.Until(deadline) < wait {
+			return resp, err
+		}
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		t.policy.attempts.Add(1)
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// wait returns the wait before the retry following the attempt which got
+// the response, capped to maxRegistryRetryBackoff.
+func (r *registryRetries) wait(attempt int, resp *http.Response) time.Duration {
+	if after, ok := retryAfter(resp); ok {
+		return min(after, maxRegistryRetryBackoff)
+	}
+	wait := r.backoff
+	for range attempt {
+		if wait >= maxRegistryRetryBackoff {
+			break
+		}
+		wait *= 2
+	}
+	return min(wait, maxRegistryRetryBackoff)
+}
+
+// retryable returns whether a request which got the response or the error
+// can be retried.
+func retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return errors.Is(err, syscall.ECONNRESET)
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+}
+
+// retryAfter returns the wait requested by the Retry-After header of the
+// response, either a number of seconds or a date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(v); err == nil && seconds >= 0 {
+		return time.Duration(min(seconds, int(maxRegistryRetryBackoff/time.Second))) * time.Second, true
+	}
+	if date, err := http.ParseTime(v); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
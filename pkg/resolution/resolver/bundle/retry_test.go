@@ -0,0 +1,298 @@
+/*
+Copyright 2025 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+)
+
+// failingRegistry is a fake registry failing the first requests of the
+// manifests with a status code.
+type failingRegistry struct {
+	*layerRegistry
+	// failures is the number of requests of the manifests to fail.
+	failures   atomic.Int64
+	status     int
+	retryAfter string
+	// manifestRequests counts the requests of the manifests.
+	manifestRequests atomic.Int64
+}
+
+func newFailingRegistry(t *testing.T, status int, retryAfter string) *failingRegistry {
+	t.Helper()
+	r := &failingRegistry{layerRegistry: &layerRegistry{}, status: status, retryAfter: retryAfter}
+	handler := registry.New()
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPut && strings.Contains(req.URL.Path, "/manifests/") {
+			if r.manifestRequests.Add(1) <= r.failures.Load() {
+				if r.retryAfter != "" {
+					w.Header().Set("Retry-After", r.retryAfter)
+				}
+				w.WriteHeader(r.status)
+				return
+			}
+		}
+		handler.ServeHTTP(w, req)
+	}))
+	t.Cleanup(s.Close)
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.host = u.Host
+	return r
+}
+
+// push pushes a bundle like layerRegistry.push, and then fails the next
+// requests of its manifest.
+func (r *failingRegistry) push(t *testing.T, failures int64) string {
+	t.Helper()
+	ref := r.layerRegistry.push(t, "latest", "image")
+	r.manifestRequests.Store(0)
+	r.failures.Store(failures)
+	return ref
+}
+
+func TestGetCachedEntry_RegistryRetries(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		failures     int64
+		status       int
+		retryAfter   string
+		config       map[string]string
+		wantRetries  string
+		wantRequests int64
+		wantErr      bool
+	}{{
+		name:         "throttled",
+		failures:     2,
+		status:       http.StatusTooManyRequests,
+		wantRetries:  "2",
+		wantRequests: 3,
+	}, {
+		name:         "server error",
+		failures:     1,
+		status:       http.StatusServiceUnavailable,
+		wantRetries:  "1",
+		wantRequests: 2,
+	}, {
+		name:         "throttled with retry after",
+		failures:     1,
+		status:       http.StatusTooManyRequests,
+		retryAfter:   "0",
+		config:       map[string]string{ConfigRegistryRetryBackoff: "1h"},
+		wantRetries:  "1",
+		wantRequests: 2,
+	}, {
+		name:         "retries exhausted",
+		failures:     3,
+		status:       http.StatusTooManyRequests,
+		config:       map[string]string{ConfigRegistryRetries: "2"},
+		wantRequests: 3,
+		wantErr:      true,
+	}, {
+		name:         "retries disabled",
+		failures:     1,
+		status:       http.StatusTooManyRequests,
+		config:       map[string]string{ConfigRegistryRetries: "0"},
+		wantRequests: 1,
+		wantErr:      true,
+	}, {
+		name:         "unauthorized",
+		failures:     1,
+		status:       http.StatusUnauthorized,
+		wantRequests: 1,
+		wantErr:      true,
+	}, {
+		name:         "forbidden",
+		failures:     1,
+		status:       http.StatusForbidden,
+		wantRequests: 1,
+		wantErr:      true,
+	}, {
+		name:         "not found",
+		failures:     1,
+		status:       http.StatusNotFound,
+		wantRequests: 1,
+		wantErr:      true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newFailingRegistry(t, tc.status, tc.retryAfter)
+			ref := r.push(t, tc.failures)
+			config := map[string]string{ConfigRegistryRetryBackoff: "1ms"}
+			for k, v := range tc.config {
+				config[k] = v
+			}
+			ctx := framework.InjectResolverConfigToContext(t.Context(), config)
+
+			res, err := GetCachedEntry(ctx, authn.DefaultKeychain, RequestOptions{Bundle: ref, EntryName: "example-task", Kind: "task"}, nil, nil)
+			if got := r.manifestRequests.Load(); got != tc.wantRequests {
+				t.Errorf("expected %d requests of the manifest, got %d", tc.wantRequests, got)
+			}
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := res.Annotations()[ResolverAnnotationRegistryRetries]; got != tc.wantRetries {
+				t.Errorf("expected %q retries to be recorded, got %q", tc.wantRetries, got)
+			}
+		})
+	}
+}
+
+func TestGetCachedEntry_RegistryRetriesNoRetry(t *testing.T) {
+	r := newLayerRegistry(t)
+	ref := r.push(t, "latest", "image")
+	ctx := framework.InjectResolverConfigToContext(t.Context(), map[string]string{})
+
+	res := resolveCachedEntry(ctx, t, nil, ref)
+	if got, ok := res.Annotations()[ResolverAnnotationRegistryRetries]; ok {
+		t.Errorf("expected no retries to be recorded, got %q", got)
+	}
+}
+
+func TestGetCachedEntry_RetryAfterBeyondDeadline(t *testing.T) {
+	r := newFailingRegistry(t, http.StatusTooManyRequests, "3600")
+	ref := r.push(t, 1)
+	ctx := framework.InjectResolverConfigToContext(t.Context(), map[string]string{})
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	start := time.Now()
+	_, err := GetCachedEntry(ctx, authn.DefaultKeychain, RequestOptions{Bundle: ref, EntryName: "example-task", Kind: "task"}, nil, nil)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("expected the request not to be retried beyond the deadline, took %v", elapsed)
+	}
+	if got := r.manifestRequests.Load(); got != 1 {
+		t.Errorf("expected 1 request of the manifest, got %d", got)
+	}
+}
+
+func TestGetBundleResolverRetryConfig(t *testing.T) {
+	for _, tc := range []struct {
+		config      map[string]string
+		wantRetries int
+		wantBackoff time.Duration
+		wantErr     bool
+	}{{
+		config:      map[string]string{},
+		wantRetries: DefaultRegistryRetries,
+		wantBackoff: DefaultRegistryRetryBackoff,
+	}, {
+		config:      map[string]string{ConfigRegistryRetries: "5", ConfigRegistryRetryBackoff: "250ms"},
+		wantRetries: 5,
+		wantBackoff: 250 * time.Millisecond,
+	}, {
+		config:  map[string]string{ConfigRegistryRetries: "-1"},
+		wantErr: true,
+	}, {
+		config:  map[string]string{ConfigRegistryRetries: "many"},
+		wantErr: true,
+	}, {
+		config:  map[string]string{ConfigRegistryRetryBackoff: "-1s"},
+		wantErr: true,
+	}} {
+		t.Run(fmt.Sprint(tc.config), func(t *testing.T) {
+			ctx := framework.InjectResolverConfigToContext(t.Context(), tc.config)
+			retries, backoff, err := GetBundleResolverRetryConfig(ctx)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if retries != tc.wantRetries || backoff != tc.wantBackoff {
+				t.Errorf("expected %d retries after %v, got %d after %v", tc.wantRetries, tc.wantBackoff, retries, backoff)
+			}
+		})
+	}
+}
+
+func TestRegistryRetries_Wait(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		backoff    time.Duration
+		attempt    int
+		retryAfter string
+		want       time.Duration
+	}{{
+		name:    "first retry",
+		backoff: time.Second,
+		want:    time.Second,
+	}, {
+		name:    "doubled",
+		backoff: time.Second,
+		attempt: 3,
+		want:    8 * time.Second,
+	}, {
+		name:    "capped",
+		backoff: time.Second,
+		attempt: 10,
+		want:    maxRegistryRetryBackoff,
+	}, {
+		name:    "capped instead of overflowing",
+		backoff: time.Second,
+		attempt: 100,
+		want:    maxRegistryRetryBackoff,
+	}, {
+		name:       "retry after",
+		backoff:    time.Hour,
+		retryAfter: "5",
+		want:       5 * time.Second,
+	}, {
+		name:       "retry after capped",
+		backoff:    time.Second,
+		retryAfter: "3600",
+		want:       maxRegistryRetryBackoff,
+	}, {
+		name:       "retry after capped instead of overflowing",
+		backoff:    time.Second,
+		retryAfter: "9223372036854775807",
+		want:       maxRegistryRetryBackoff,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+			if tc.retryAfter != "" {
+				resp.Header.Set("Retry-After", tc.retryAfter)
+			}
+			r := &registryRetries{backoff: tc.backoff}
+			if got := r.wait(tc.attempt, resp); got != tc.want {
+				t.Errorf("expected to wait %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2025 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// cosignSignatureAnnotation is the annotation of the layers of a cosign
+	// signature manifest holding the base64 encoded signature of the layer.
+	cosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+)
+
+// PublicKey is a public key the cosign signatures of the bundles are
+// verified against.
+type PublicKey struct {
+	verifier signature.Verifier
+	// fingerprint is the SHA-256 digest of the DER encoding of the key.
+	fingerprint string
+}
+
+// simpleSigningPayload is the part of the payload signed by cosign which
+// identifies the signed image.
+type simpleSigningPayload struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+// ParsePublicKeys returns the public keys of the PEM encoded data, which may
+// hold several keys.
+func ParsePublicKeys(data []byte) ([]PublicKey, error) {
+	var keys []PublicKey
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		pub, err := cryptoutils.UnmarshalPEMToPublicKey(pem.EncodeToMemory(block))
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key: %w", err)
+		}
+		verifier, err := signature.LoadVerifier(pub, crypto.SHA256)
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key: %w", err)
+		}
+		der, err := cryptoutils.MarshalPublicKeyToDER(pub)
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key: %w", err)
+		}
+		digest := sha256.Sum256(der)
+		keys = append(keys, PublicKey{
+			verifier:    verifier,
+			fingerprint: "sha256:" + hex.EncodeToString(digest[:]),
+		})
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no PEM encoded public key found")
+	}
+	return keys, nil
+}
+
+// LoadSignaturePublicKeys returns the public keys of the secret configured
+// in the bundle-resolver-config ConfigMap, or nil when the signatures of the
+// bundles aren't verified.
+func LoadSignaturePublicKeys(ctx context.Context, kubeClient kubernetes.Interface) ([]PublicKey, error) {
+	keysSecret, err := getSignaturePublicKeysSecret(ctx)
+	if err != nil || keysSecret == nil {
+		return nil, err
+	}
+	secret, err := kubeClient.CoreV1().Secrets(keysSecret.ns).Get(ctx, keysSecret.name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("cannot get the public keys, secret %s not found in namespace %s", keysSecret.name, keysSecret.ns)
+		}
+		return nil, fmt.Errorf("error reading the public keys from secret %s in namespace %s: %w", keysSecret.name, keysSecret.ns, err)
+	}
+	data, ok := secret.Data[keysSecret.key]
+	if !ok {
+		return nil, fmt.Errorf("cannot get the public keys, key %s not found in secret %s in namespace %s", keysSecret.key, keysSecret.name, keysSecret.ns)
+	}
+	keys, err := ParsePublicKeys(data)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing the public keys of secret %s in namespace %s: %w", keysSecret.name, keysSecret.ns, err)
+	}
+	return keys, nil
+}
+
+// verifySignature verifies that the bundle image of the repository with the
+// digest has a cosign signature made with one of the keys, and returns the
+// fingerprint of the key. The signatures are fetched with the transport rt of
+// the bundle.
+func verifySignature(ctx context.Context, keychain authn.Keychain, repo name.Repository, digest v1.Hash, keys []PublicKey, rt http.RoundTripper) (string, error) {
+	// cosign stores the signatures of an image in the same repository, under
+	// the tag derived from its digest, e.g. "sha256-<hex>.sig".
+	sigRef := repo.Tag(fmt.Sprintf("%s-%s.sig", digest.Algorithm, digest.Hex))
+	sigImg, err := remote.Image(sigRef, remote.WithAuthFromKeychain(keychain), remote.WithContext(ctx),
+		remote.WithTransport(rt), remote.WithRetryStatusCodes())
+	if err != nil {
+		var terr *transport.Error
+		if errors.As(err, &terr) && terr.StatusCode == http.StatusNotFound {
+			return "", fmt.Errorf("no signature found at %s", sigRef)
+		}
+		return "", fmt.Errorf("cannot retrieve the signatures %s: %w", sigRef, err)
+	}
+	manifest, err := sigImg.Manifest()
+	if err != nil {
+		return "", fmt.Errorf("could not parse the manifest of the signatures %s: %w", sigRef, err)
+	}
+
+	signatures := 0
+	// mismatch records why the last signature made with one of the keys
+	// didn't sign the image, in case no other signature does.
+	var mismatch error
+	for _, l := range manifest.Layers {
+		encoded, ok := l.Annotations[cosignSignatureAnnotation]
+		if !ok {
+			continue
+		}
+		signatures++
+		sig, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			continue
+		}
+		layer, err := sigImg.LayerByDigest(l.Digest)
+		if err != nil {
+			return "", fmt.Errorf("could not read the signature layer %s: %w", l.Digest, err)
+		}
+		payload, err := readRawLayer(layer)
+		if err != nil {
+			return "", err
+		}
+		for _, key := range keys {
+			if err := key.verifier.VerifySignature(bytes.NewReader(sig), bytes.NewReader(payload)); err != nil {
+				continue
+			}
+			// The signature is only valid for the image whose digest it
+			// signed, not for any image it's copied next to, so keep looking
+			// at the other signatures.
+			var p simpleSigningPayload
+			if err := json.Unmarshal(payload, &p); err != nil {
+				mismatch = fmt.Errorf("invalid signature payload: %w", err)
+				continue
+			}
+			if signed := p.Critical.Image.DockerManifestDigest; signed != digest.String() {
+				mismatch = fmt.Errorf("the signature of key %s is for the digest %s, not %s", key.fingerprint, signed, digest)
+				continue
+			}
+			return key.fingerprint, nil
+		}
+	}
+	if signatures == 0 {
+		return "", fmt.Errorf("no signature found at %s", sigRef)
+	}
+	if mismatch != nil {
+		return "", mismatch
+	}
+	fingerprints := make([]string, 0, len(keys))
+	for _, key := range keys {
+		fingerprints = append(fingerprints, key.fingerprint)
+	}
+	return "", fmt.Errorf("none of the %d signatures at %s was made with the public keys %s", signatures, sigRef, strings.Join(fingerprints, ", "))
+}
@@ -0,0 +1,277 @@
+/*
+Copyright 2025 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+)
+
+// signingKey is an in-process cosign signing key.
+type signingKey struct {
+	signer signature.SignerVerifier
+	pem    []byte
+}
+
+func newSigningKey(t *testing.T) *signingKey {
+	t.Helper()
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := signature.LoadECDSASignerVerifier(priv, crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, err := cryptoutils.MarshalPublicKeyToPEM(priv.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &signingKey{signer: signer, pem: pub}
+}
+
+func (k *signingKey) publicKeys(t *testing.T) []PublicKey {
+	t.Helper()
+	keys, err := ParsePublicKeys(k.pem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keys
+}
+
+// sign pushes the cosign signatures of the given digests with the key next to
+// the bundle ref, like cosign sign does, one signature layer per digest.
+func (k *signingKey) sign(t *testing.T, bundle string, signedDigests ...string) {
+	t.Helper()
+	ref, err := name.NewDigest(bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addenda := make([]mutate.Addendum, 0, len(signedDigests))
+	for _, signedDigest := range signedDigests {
+		payload := fmt.Appendf(nil, `{"critical":{"identity":{"docker-reference":%q},"image":{"docker-manifest-digest":%q},"type":"cosign container image signature"},"optional":null}`,
+			ref.Context().Name(), signedDigest)
+		sig, err := k.signer.SignMessage(bytes.NewReader(payload))
+		if err != nil {
+			t.Fatal(err)
+		}
+		layer, err := tarball.LayerFromReader(bytes.NewReader(payload))
+		if err != nil {
+			t.Fatal(err)
+		}
+		addenda = append(addenda, mutate.Addendum{
+			Layer:       layer,
+			Annotations: map[string]string{cosignSignatureAnnotation: base64.StdEncoding.EncodeToString(sig)},
+		})
+	}
+	img, err := mutate.Append(empty.Image, addenda...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	algorithm, hex, _ := strings.Cut(ref.DigestStr(), ":")
+	if err := remote.Write(ref.Context().Tag(algorithm+"-"+hex+".sig"), img); err != nil {
+		t.Fatalf("couldn't push the signature: %v", err)
+	}
+}
+
+func digestOf(t *testing.T, bundle string) string {
+	t.Helper()
+	ref, err := name.NewDigest(bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ref.DigestStr()
+}
+
+func TestGetCachedEntry_VerifiedSignature(t *testing.T) {
+	r := newLayerRegistry(t)
+	ref := r.push(t, "latest", "image")
+	other := newSigningKey(t)
+	key := newSigningKey(t)
+	key.sign(t, ref, digestOf(t, ref))
+	ctx := framework.InjectResolverConfigToContext(t.Context(), map[string]string{})
+
+	keys := append(other.publicKeys(t), key.publicKeys(t)...)
+	res, err := GetCachedEntry(ctx, authn.DefaultKeychain, RequestOptions{Bundle: ref, EntryName: "example-task", Kind: "task"}, nil, keys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := res.Annotations()[ResolverAnnotationSignatureKey], keys[1].fingerprint; got != want {
+		t.Errorf("expected the fingerprint of the signing key %q, got %q", want, got)
+	}
+	if !strings.Contains(string(res.Data()), "example-task") {
+		t.Errorf("expected the content of the bundle, got %q", res.Data())
+	}
+}
+
+func TestGetCachedEntry_VerifiedSignatureAfterOtherDigest(t *testing.T) {
+	r := newLayerRegistry(t)
+	ref := r.push(t, "latest", "image")
+	key := newSigningKey(t)
+	key.sign(t, ref, "sha256:0000000000000000000000000000000000000000000000000000000000000000", digestOf(t, ref))
+	ctx := framework.InjectResolverConfigToContext(t.Context(), map[string]string{})
+
+	keys := key.publicKeys(t)
+	res, err := GetCachedEntry(ctx, authn.DefaultKeychain, RequestOptions{Bundle: ref, EntryName: "example-task", Kind: "task"}, nil, keys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := res.Annotations()[ResolverAnnotationSignatureKey], keys[0].fingerprint; got != want {
+		t.Errorf("expected the fingerprint of the signing key %q, got %q", want, got)
+	}
+}
+
+func TestGetCachedEntry_SignatureVerificationFailed(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		sign    func(t *testing.T, ref string, key, other *signingKey)
+		wantErr string
+	}{{
+		name:    "unsigned",
+		sign:    func(*testing.T, string, *signingKey, *signingKey) {},
+		wantErr: "no signature found at",
+	}, {
+		name: "signed with another key",
+		sign: func(t *testing.T, ref string, key, other *signingKey) {
+			t.Helper()
+			other.sign(t, ref, digestOf(t, ref))
+		},
+		wantErr: "none of the 1 signatures at",
+	}, {
+		name: "signature of another digest",
+		sign: func(t *testing.T, ref string, key, other *signingKey) {
+			t.Helper()
+			key.sign(t, ref, "sha256:0000000000000000000000000000000000000000000000000000000000000000")
+		},
+		wantErr: "is for the digest sha256:0000000000000000000000000000000000000000000000000000000000000000",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newLayerRegistry(t)
+			ref := r.push(t, "latest", "image")
+			key, other := newSigningKey(t), newSigningKey(t)
+			tc.sign(t, ref, key, other)
+			ctx := framework.InjectResolverConfigToContext(t.Context(), map[string]string{})
+
+			_, err := GetCachedEntry(ctx, authn.DefaultKeychain, RequestOptions{Bundle: ref, EntryName: "example-task", Kind: "task"}, nil, key.publicKeys(t))
+			if err == nil {
+				t.Fatal("expected an error, got nil")
+			}
+			wantPrefix := fmt.Sprintf("bundle %s signature verification failed: ", ref)
+			if !strings.HasPrefix(err.Error(), wantPrefix) || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("expected an error starting with %q and containing %q, got %v", wantPrefix, tc.wantErr, err)
+			}
+			if got := r.downloads.Load(); got > 1 {
+				t.Errorf("expected the layer of the bundle not to be downloaded, got %d downloads", got)
+			}
+		})
+	}
+}
+
+func TestLoadSignaturePublicKeys(t *testing.T) {
+	key := newSigningKey(t)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "bundle-keys", Namespace: "tekton-pipelines-resolvers"},
+		Data:       map[string][]byte{"cosign.pub": key.pem, "keys.pem": append(append([]byte{}, key.pem...), newSigningKey(t).pem...)},
+	}
+	for _, tc := range []struct {
+		name     string
+		config   map[string]string
+		wantKeys int
+		wantErr  string
+	}{{
+		name: "not configured",
+	}, {
+		name:   "disabled",
+		config: map[string]string{ConfigVerifySignatures: "false", ConfigSignaturePublicKeysSecretName: "bundle-keys"},
+	}, {
+		name: "default key",
+		config: map[string]string{
+			ConfigVerifySignatures:                   "true",
+			ConfigSignaturePublicKeysSecretName:      "bundle-keys",
+			ConfigSignaturePublicKeysSecretNamespace: "tekton-pipelines-resolvers",
+		},
+		wantKeys: 1,
+	}, {
+		name: "several keys",
+		config: map[string]string{
+			ConfigVerifySignatures:                   "true",
+			ConfigSignaturePublicKeysSecretName:      "bundle-keys",
+			ConfigSignaturePublicKeysSecretKey:       "keys.pem",
+			ConfigSignaturePublicKeysSecretNamespace: "tekton-pipelines-resolvers",
+		},
+		wantKeys: 2,
+	}, {
+		name:    "invalid verify signatures",
+		config:  map[string]string{ConfigVerifySignatures: "always"},
+		wantErr: `error parsing verify signatures value always: strconv.ParseBool: parsing "always": invalid syntax`,
+	}, {
+		name:    "no secret",
+		config:  map[string]string{ConfigVerifySignatures: "true"},
+		wantErr: "signature-public-keys-secret-name is required to verify the signatures of the bundles",
+	}, {
+		name: "secret not found",
+		config: map[string]string{
+			ConfigVerifySignatures:                   "true",
+			ConfigSignaturePublicKeysSecretName:      "missing",
+			ConfigSignaturePublicKeysSecretNamespace: "tekton-pipelines-resolvers",
+		},
+		wantErr: "cannot get the public keys, secret missing not found in namespace tekton-pipelines-resolvers",
+	}, {
+		name: "key not found",
+		config: map[string]string{
+			ConfigVerifySignatures:                   "true",
+			ConfigSignaturePublicKeysSecretName:      "bundle-keys",
+			ConfigSignaturePublicKeysSecretKey:       "missing",
+			ConfigSignaturePublicKeysSecretNamespace: "tekton-pipelines-resolvers",
+		},
+		wantErr: "cannot get the public keys, key missing not found in secret bundle-keys in namespace tekton-pipelines-resolvers",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := framework.InjectResolverConfigToContext(t.Context(), tc.config)
+			keys, err := LoadSignaturePublicKeys(ctx, fakek8s.NewSimpleClientset(secret))
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("expected error %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(keys) != tc.wantKeys {
+				t.Errorf("expected %d keys, got %d", tc.wantKeys, len(keys))
+			}
+		})
+	}
+}
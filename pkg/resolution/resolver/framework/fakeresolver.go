@@ -163,6 +163,22 @@ func Resolve(params []pipelinev1.Param, forParam map[string]*FakeResolvedResourc
 	return frr, nil
 }
 
+var _ ParamDescriber = &FakeResolver{}
+
+// DescribeParams returns the description of the fake resolver's single parameter.
+func (r *FakeResolver) DescribeParams(_ context.Context) []ParamDescription {
+	return DescribeFakeParams()
+}
+
+// DescribeFakeParams returns the description of the fake resolver's single parameter.
+func DescribeFakeParams() []ParamDescription {
+	return []ParamDescription{{
+		Name:        FakeParamName,
+		Description: "The value used to look up the fake resource to return.",
+		Required:    true,
+	}}
+}
+
 var _ TimedResolution = &FakeResolver{}
 
 // GetResolutionTimeout returns the configured timeout for the reconciler, or the default time.Duration if not configured.
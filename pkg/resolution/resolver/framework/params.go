@@ -0,0 +1,128 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+// ParamDescriber is an optional interface that a resolver can
+// implement to describe the params it accepts. The descriptions are
+// served by the resolvers' discovery endpoint, so that clients don't
+// need to hardcode the params of each resolver, and are used by the
+// framework to suggest the closest known param when a request fails
+// validation because of a misspelled param name.
+type ParamDescriber interface {
+	// DescribeParams returns the description of every param
+	// accepted by the resolver.
+	DescribeParams(ctx context.Context) []ParamDescription
+}
+
+// ParamDescription describes a single param accepted by a resolver.
+type ParamDescription struct {
+	// Name is the name of the param.
+	Name string `json:"name"`
+	// Description is a human readable description of the param.
+	Description string `json:"description"`
+	// Required is true when the param must always be provided.
+	Required bool `json:"required,omitempty"`
+	// Enum is the list of allowed values, if the param only accepts
+	// a fixed set of values.
+	Enum []string `json:"enum,omitempty"`
+	// Default is the value used when the param isn't provided.
+	Default string `json:"default,omitempty"`
+}
+
+// maxSuggestionDistance is the maximum edit distance between an
+// unknown param and a described param for the latter to be suggested.
+// Shorter params only allow a smaller distance, see allowedDistance.
+const maxSuggestionDistance = 3
+
+// SuggestParam returns the name of the described param which is the
+// closest to the given name, or an empty string if none of them is
+// close enough for the given name to be a likely typo.
+func SuggestParam(name string, descriptions []ParamDescription) string {
+	suggestion := ""
+	best := maxSuggestionDistance + 1
+	for _, d := range descriptions {
+		if d.Name == name {
+			return ""
+		}
+		distance := levenshtein(strings.ToLower(name), strings.ToLower(d.Name))
+		if distance > allowedDistance(d.Name) {
+			continue
+		}
+		if distance < best {
+			best = distance
+			suggestion = d.Name
+		}
+	}
+	return suggestion
+}
+
+// allowedDistance returns the maximum edit distance for a name to be
+// considered a typo of the given param name, so that short params such
+// as "org" aren't suggested for unrelated names such as "foo".
+func allowedDistance(name string) int {
+	return min(maxSuggestionDistance, max(2, len(name)/3))
+}
+
+// WithParamSuggestions adds a suggestion to the given validation error
+// for each param of the request which isn't described by the resolver
+// but is close to one which is. The error is returned unchanged when
+// there is nothing to suggest.
+func WithParamSuggestions(err error, params []pipelinev1.Param, descriptions []ParamDescription) error {
+	if err == nil {
+		return nil
+	}
+	var suggestions []string
+	for _, p := range params {
+		if suggestion := SuggestParam(p.Name, descriptions); suggestion != "" {
+			suggestions = append(suggestions, fmt.Sprintf("unknown param %q, did you mean %q?", p.Name, suggestion))
+		}
+	}
+	if len(suggestions) == 0 {
+		return err
+	}
+	return fmt.Errorf("%w (%s)", err, strings.Join(suggestions, "; "))
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,126 @@
+/*
+ Copyright 2025 The Tekton Authors
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package framework_test
+
+import (
+	"errors"
+	"testing"
+
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	framework "github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+)
+
+var testParamDescriptions = []framework.ParamDescription{
+	{Name: "url"},
+	{Name: "org"},
+	{Name: "repo"},
+	{Name: "pathInRepo", Required: true},
+	{Name: "revision"},
+	{Name: "token"},
+	{Name: "tokenKey"},
+}
+
+func TestSuggestParam(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		param    string
+		expected string
+	}{{
+		name:     "known param",
+		param:    "pathInRepo",
+		expected: "",
+	}, {
+		name:     "wrong case",
+		param:    "pathinrepo",
+		expected: "pathInRepo",
+	}, {
+		name:     "typo",
+		param:    "pathInRepp",
+		expected: "pathInRepo",
+	}, {
+		name:     "missing letters",
+		param:    "revison",
+		expected: "revision",
+	}, {
+		name:     "closest param is suggested",
+		param:    "tokenKy",
+		expected: "tokenKey",
+	}, {
+		name:     "short params need a close match",
+		param:    "foo",
+		expected: "",
+	}, {
+		name:     "nothing close",
+		param:    "completely-different",
+		expected: "",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := framework.SuggestParam(tc.param, testParamDescriptions); got != tc.expected {
+				t.Errorf("expected suggestion %q for %q but got %q", tc.expected, tc.param, got)
+			}
+		})
+	}
+}
+
+func TestWithParamSuggestions(t *testing.T) {
+	validationErr := errors.New("missing required params: pathInRepo")
+	for _, tc := range []struct {
+		name     string
+		err      error
+		params   []pipelinev1.Param
+		expected string
+	}{{
+		name:     "no error",
+		err:      nil,
+		params:   []pipelinev1.Param{{Name: "pathinrepo"}},
+		expected: "",
+	}, {
+		name:     "no suggestion",
+		err:      validationErr,
+		params:   []pipelinev1.Param{{Name: "url"}, {Name: "something-else"}},
+		expected: "missing required params: pathInRepo",
+	}, {
+		name:     "one suggestion",
+		err:      validationErr,
+		params:   []pipelinev1.Param{{Name: "url"}, {Name: "pathinrepo"}},
+		expected: `missing required params: pathInRepo (unknown param "pathinrepo", did you mean "pathInRepo"?)`,
+	}, {
+		name:     "several suggestions",
+		err:      validationErr,
+		params:   []pipelinev1.Param{{Name: "ulr"}, {Name: "pathinrepo"}},
+		expected: `missing required params: pathInRepo (unknown param "ulr", did you mean "url"?; unknown param "pathinrepo", did you mean "pathInRepo"?)`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := framework.WithParamSuggestions(tc.err, tc.params, testParamDescriptions)
+			if tc.expected == "" {
+				if err != nil {
+					t.Fatalf("expected no error but got %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error %q but got nil", tc.expected)
+			}
+			if err.Error() != tc.expected {
+				t.Errorf("expected error %q but got %q", tc.expected, err.Error())
+			}
+			if !errors.Is(err, tc.err) {
+				t.Errorf("expected error to wrap %v", tc.err)
+			}
+		})
+	}
+}
@@ -131,6 +131,9 @@ func (r *Reconciler) resolve(ctx context.Context, key string, rr *v1beta1.Resolu
 
 	go func() {
 		validationError := r.resolver.ValidateParams(resolutionCtx, rr.Spec.Params)
+		if describer, ok := r.resolver.(ParamDescriber); ok {
+			validationError = WithParamSuggestions(validationError, rr.Spec.Params, describer.DescribeParams(resolutionCtx))
+		}
 		if validationError != nil {
 			errChan <- &resolutioncommon.InvalidRequestError{
 				ResolutionRequestKey: key,
@@ -139,6 +142,13 @@ func (r *Reconciler) resolve(ctx context.Context, key string, rr *v1beta1.Resolu
 			return
 		}
 		resource, resolveErr := r.resolver.Resolve(resolutionCtx, rr.Spec.Params)
+		// A GetResourceError returned by the resolver already names
+		// what it failed to get, it isn't wrapped again.
+		var getResourceErr *resolutioncommon.GetResourceError
+		if errors.As(resolveErr, &getResourceErr) {
+			errChan <- resolveErr
+			return
+		}
 		if resolveErr != nil {
 			errChan <- &resolutioncommon.GetResourceError{
 				ResolverName: r.resolver.GetName(resolutionCtx),
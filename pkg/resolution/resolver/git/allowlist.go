@@ -0,0 +1,121 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// urlPattern is a pattern of the allowed-url-patterns of the config, either
+// a glob or a regular expression.
+type urlPattern struct {
+	glob string
+	re   *regexp.Regexp
+}
+
+func (p urlPattern) match(u string) bool {
+	if p.re != nil {
+		return p.re.MatchString(u)
+	}
+	matched, _ := path.Match(p.glob, u)
+	return matched
+}
+
+// allowedURLPatterns returns the patterns of the URLs of the repos which
+// can be resolved with the config, nil if all of them can.
+func (c ScmConfig) allowedURLPatterns() ([]urlPattern, error) {
+	var patterns []urlPattern
+	for _, p := range strings.Split(c.AllowedURLPatterns, ",") {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		if strings.HasPrefix(p, "^") {
+			re, err := regexp.Compile(p)
+			if err != nil {
+				return nil, fmt.Errorf("invalid regular expression %q in the %s of the git resolver config: %w", p, AllowedURLPatternsKey, err)
+			}
+			patterns = append(patterns, urlPattern{re: re})
+			continue
+		}
+		if _, err := path.Match(p, ""); err != nil {
+			return nil, fmt.Errorf("invalid glob %q in the %s of the git resolver config: %w", p, AllowedURLPatternsKey, err)
+		}
+		patterns = append(patterns, urlPattern{glob: p})
+	}
+	return patterns, nil
+}
+
+// checkAllowedURL returns an error if the URL doesn't match any of the
+// allowed-url-patterns of the config. The credentials of the URL are ignored,
+// so that they can't be used to match a pattern.
+func (c ScmConfig) checkAllowedURL(repoURL string) error {
+	patterns, err := c.allowedURLPatterns()
+	if err != nil || len(patterns) == 0 {
+		return err
+	}
+	matchedURL := repoURL
+	if u, err := url.Parse(repoURL); err == nil && u.Scheme != "" && u.Host != "" {
+		u.User = nil
+		matchedURL = u.String()
+	}
+	for _, p := range patterns {
+		if p.match(matchedURL) {
+			return nil
+		}
+	}
+	return fmt.Errorf("url %q not allowed by resolver configuration", matchedURL)
+}
+
+// submoduleURL returns the URL the submodule with the given URL is fetched
+// from, rewritten by the url-rewrite of the config, or an error if it isn't
+// allowed by the allowed-url-patterns of the config.
+func (c ScmConfig) submoduleURL(submoduleURL string) (string, error) {
+	fetchURL, err := c.rewriteURL(submoduleURL)
+	if err != nil {
+		return "", err
+	}
+	if err := c.checkAllowedURL(fetchURL); err != nil {
+		return "", err
+	}
+	return fetchURL, nil
+}
+
+// checkAllowedRepo returns an error if the repo of the params isn't allowed
+// by the config: the url param for a clone, or the server URL of the SCM API.
+func checkAllowedRepo(ctx context.Context, conf ScmConfig, params map[string]string) error {
+	if params[RepoParam] == "" {
+		return conf.checkAllowedURL(params[UrlParam])
+	}
+	scmType, serverURL, err := getSCMTypeAndServerURL(ctx, params)
+	if err != nil {
+		return err
+	}
+	serverURL = effectiveServerURL(scmType, serverURL)
+	if serverURL == "" {
+		if patterns, err := conf.allowedURLPatterns(); err != nil || len(patterns) == 0 {
+			return err
+		}
+		return fmt.Errorf("'%s' is required to check the repos of the scm type %q against the %s of the git resolver config", ServerURLKey, scmType, AllowedURLPatternsKey)
+	}
+	return conf.checkAllowedURL(serverURL)
+}
@@ -31,4 +31,37 @@ var (
 	AnnotationKeyPath = resolution.GroupName + "/path"
 	// AnnotationKeyURL is the repo URL used
 	AnnotationKeyURL = resolution.GroupName + "/url"
+	// AnnotationKeyFetchURL is the URL the repo was fetched from, when
+	// the repo URL was rewritten by the url-rewrite of the config or has
+	// a repo-mirror
+	AnnotationKeyFetchURL = resolution.GroupName + "/fetch-url"
+	// AnnotationKeyTag is the tag the revision was resolved from, when
+	// it is a semver constraint
+	AnnotationKeyTag = resolution.GroupName + "/tag"
+	// AnnotationKeyEffectiveRevision is the symbolic revision the file was
+	// resolved at, the revision param or else the default revision of the
+	// config
+	AnnotationKeyEffectiveRevision = resolution.GroupName + "/effective-revision"
+
+	// AnnotationKeyCommitAuthor is the author of the commit that was fetched
+	AnnotationKeyCommitAuthor = resolution.GroupName + "/commit-author"
+	// AnnotationKeyCommitTimestamp is the RFC 3339 timestamp of the commit
+	// that was fetched
+	AnnotationKeyCommitTimestamp = resolution.GroupName + "/commit-timestamp"
+	// AnnotationKeyCommitMessageSubject is the subject of the message of
+	// the commit that was fetched, truncated to 120 characters
+	AnnotationKeyCommitMessageSubject = resolution.GroupName + "/commit-message-subject"
+
+	// AnnotationKeyResolutionMode is the mode which fetched the file,
+	// ResolutionModeAPI or ResolutionModeClone
+	AnnotationKeyResolutionMode = resolution.GroupName + "/resolution-mode"
+)
+
+const (
+	// ResolutionModeAPI is the resolution mode of the files fetched with
+	// the SCM API
+	ResolutionModeAPI = "api"
+	// ResolutionModeClone is the resolution mode of the files fetched from
+	// a clone of the repo
+	ResolutionModeClone = "clone"
 )
@@ -0,0 +1,133 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+// defaultServerURLs are the URLs of the public servers of the SCM types,
+// used to clone their repos when server-url isn't set.
+var defaultServerURLs = map[string]string{
+	"github": "https://github.com",
+	"gitlab": "https://gitlab.com",
+	"azure":  azureServerURL,
+}
+
+// scmStatusError is an error of a request to the SCM API, with the HTTP
+// status of its response.
+type scmStatusError struct {
+	status int
+	err    error
+}
+
+func (e *scmStatusError) Error() string {
+	return e.err.Error()
+}
+
+func (e *scmStatusError) Unwrap() error {
+	return e.err
+}
+
+// newSCMStatusError returns the error of a request to the SCM API with the
+// status of its response, or the error as is without a response.
+func newSCMStatusError(res *scm.Response, err error) error {
+	if err == nil || res == nil {
+		return err
+	}
+	return &scmStatusError{status: res.Status, err: err}
+}
+
+// isAPIFallbackError returns true if the SCM API rejected a request because
+// of its credentials or of a rate limit, rather than because of the file
+// requested, so that the file may be fetched from a clone of the repo.
+func isAPIFallbackError(err error) bool {
+	var statusErr *scmStatusError
+	if !errors.As(err, &statusErr) {
+		return false
+	}
+	switch statusErr.status {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests:
+		return true
+	default:
+		return false
+	}
+}
+
+// effectiveServerURL returns the URL of the server of the SCM type without
+// trailing slash, the public server if serverURL is empty, or "" if the SCM
+// type has no public server. The SCM type defaults to "github".
+func effectiveServerURL(scmType, serverURL string) string {
+	if serverURL != "" {
+		return strings.TrimSuffix(serverURL, "/")
+	}
+	if scmType == "" {
+		scmType = "github"
+	}
+	return defaultServerURLs[scmType]
+}
+
+// apiFallbackCloneURL returns the URL of the repo of the org on the server
+// of the SCM type, the public server if serverURL is empty.
+func apiFallbackCloneURL(scmType, serverURL, org, repo string) (string, error) {
+	serverURL = effectiveServerURL(scmType, serverURL)
+	if serverURL == "" {
+		return "", fmt.Errorf("'%s' is required to clone the repos of the scm type %q", ServerURLKey, scmType)
+	}
+	if scmType == scmTypeAzure {
+		return azureRepoURL(serverURL, org, repo), nil
+	}
+	return fmt.Sprintf("%s/%s/%s.git", serverURL, org, repo), nil
+}
+
+// resolveAPIFallbackClone fetches the file, or the directory, which the SCM
+// API failed to fetch with apiErr from an anonymous clone of the repo.
+func (g *GitResolver) resolveAPIFallbackClone(ctx context.Context, conf ScmConfig, apiErr error) (*resolvedGitResource, error) {
+	scmType, serverURL, err := getSCMTypeAndServerURL(ctx, g.Params)
+	if err != nil {
+		return nil, err
+	}
+	cloneURL, err := apiFallbackCloneURL(scmType, serverURL, g.Params[OrgParam], g.Params[RepoParam])
+	if err != nil {
+		return nil, fmt.Errorf("%w, and couldn't fall back to an anonymous clone: %w", apiErr, err)
+	}
+	maxFileSize, err := conf.GetMaxFileSize()
+	if err != nil {
+		return nil, err
+	}
+	cloneTimeout, err := conf.GetCloneTimeout()
+	if err != nil {
+		return nil, err
+	}
+
+	g.Logger.Infof("falling back to an anonymous clone of %s after the SCM API failed: %v", cloneURL, apiErr)
+	res, err := g.resolveClone(ctx, conf, remote{url: cloneURL, cloneTimeout: cloneTimeout}, g.Params[RevisionParam], g.Params[PathParam], maxFileSize)
+	if err != nil {
+		return nil, fmt.Errorf("%w, and the fallback to an anonymous clone of %s failed: %w", apiErr, cloneURL, err)
+	}
+	res.Org = g.Params[OrgParam]
+	res.Repo = g.Params[RepoParam]
+	res.EffectiveRevision = g.effectiveRevision(conf)
+	res.Mode = ResolutionModeClone
+	return res, nil
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/github"
+	"github.com/jenkins-x/go-scm/scm/factory"
+	"github.com/tektoncd/pipeline/pkg/resolution/common"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"github.com/tektoncd/pipeline/test/diff"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/cache"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestResolveAPIGitFallbackToClone(t *testing.T) {
+	testOrg, testRepo := "test-org", "test-repo"
+	repoDir, commits := createTestRepo(t, []commitForRepo{{
+		Dir:      "tasks",
+		Filename: "task.yaml",
+		Content:  "task from the clone",
+	}})
+	// The repo is served as <server-url>/<org>/<repo>.git
+	serverURL := t.TempDir()
+	if err := os.Mkdir(filepath.Join(serverURL, testOrg), 0o700); err != nil {
+		t.Fatalf("couldn't create the org directory: %v", err)
+	}
+	cloneURL := filepath.Join(serverURL, testOrg, testRepo+".git")
+	if err := os.Symlink(repoDir, cloneURL); err != nil {
+		t.Fatalf("couldn't link the repo: %v", err)
+	}
+	tokenSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "token-secret", Namespace: "tekton-pipelines"},
+		Data:       map[string][]byte{"token": []byte("expired-token")},
+	}
+
+	for _, tc := range []struct {
+		name      string
+		apiStatus int
+		fallback  string
+		wantErr   string
+	}{{
+		name:      "forbidden",
+		apiStatus: http.StatusForbidden,
+		fallback:  "true",
+	}, {
+		name:      "unauthorized",
+		apiStatus: http.StatusUnauthorized,
+		fallback:  "true",
+	}, {
+		name:      "rate limited",
+		apiStatus: http.StatusTooManyRequests,
+		fallback:  "true",
+	}, {
+		name:      "forbidden without fallback",
+		apiStatus: http.StatusForbidden,
+		wantErr:   "couldn't fetch resource content: Forbidden",
+	}, {
+		name:      "forbidden with fallback disabled",
+		apiStatus: http.StatusForbidden,
+		fallback:  "false",
+		wantErr:   "couldn't fetch resource content: Forbidden",
+	}, {
+		name:      "file does not exist",
+		apiStatus: http.StatusNotFound,
+		fallback:  "true",
+		wantErr:   "couldn't fetch resource content: Not Found",
+	}, {
+		name:      "invalid fallback config",
+		apiStatus: http.StatusForbidden,
+		fallback:  "yes",
+		wantErr:   `invalid api-fallback-to-clone "yes" in git resolver config, must be "true" or "false"`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("X-RateLimit-Remaining", "0")
+				w.WriteHeader(tc.apiStatus)
+			}))
+			defer server.Close()
+			clientFunc := func(_, _, _ string, _ ...factory.ClientOptionFunc) (*scm.Client, error) {
+				return github.New(server.URL)
+			}
+
+			config := map[string]string{
+				SCMTypeKey:            "github",
+				ServerURLKey:          serverURL,
+				APISecretNameKey:      "token-secret",
+				APISecretKeyKey:       "token",
+				APISecretNamespaceKey: "tekton-pipelines",
+			}
+			if tc.fallback != "" {
+				config[APIFallbackToCloneKey] = tc.fallback
+			}
+			g := &GitResolver{
+				Params: map[string]string{
+					OrgParam:      testOrg,
+					RepoParam:     testRepo,
+					PathParam:     "tasks/task.yaml",
+					RevisionParam: "main",
+				},
+				Logger:     zap.NewNop().Sugar(),
+				Cache:      cache.NewLRUExpireCache(cacheSize),
+				TTL:        ttl,
+				KubeClient: kubefake.NewSimpleClientset(tokenSecret),
+			}
+
+			ctx := framework.InjectResolverConfigToContext(t.Context(), config)
+			res, err := g.ResolveAPIGit(ctx, clientFunc)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("expected the error %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error resolving with the fallback to a clone: %v", err)
+			}
+			if d := cmp.Diff("task from the clone", string(res.Data())); d != "" {
+				t.Errorf("unexpected content %s", diff.PrintWantGot(d))
+			}
+			wantAnnotations := map[string]string{
+				common.AnnotationKeyContentType:   yamlContentType,
+				AnnotationKeyRevision:             commits[0],
+				AnnotationKeyPath:                 "tasks/task.yaml",
+				AnnotationKeyURL:                  cloneURL,
+				AnnotationKeyOrg:                  testOrg,
+				AnnotationKeyRepo:                 testRepo,
+				AnnotationKeyResolutionMode:       ResolutionModeClone,
+				AnnotationKeyEffectiveRevision:    "main",
+				AnnotationKeyCommitAuthor:         "PipelinesTests <test@test.com>",
+				AnnotationKeyCommitTimestamp:      testCommitDate,
+				AnnotationKeyCommitMessageSubject: "adding file for test",
+			}
+			if d := cmp.Diff(wantAnnotations, res.Annotations()); d != "" {
+				t.Errorf("unexpected annotations %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestAPIFallbackCloneURL(t *testing.T) {
+	for _, tc := range []struct {
+		scmType   string
+		serverURL string
+		want      string
+		wantErr   string
+	}{{
+		scmType: "github",
+		want:    "https://github.com/tektoncd/catalog.git",
+	}, {
+		scmType: "gitlab",
+		want:    "https://gitlab.com/tektoncd/catalog.git",
+	}, {
+		scmType: "azure",
+		want:    "https://dev.azure.com/tektoncd/_git/catalog",
+	}, {
+		scmType:   "gitea",
+		serverURL: "https://gitea.example.com/",
+		want:      "https://gitea.example.com/tektoncd/catalog.git",
+	}, {
+		scmType: "gitea",
+		wantErr: `'server-url' is required to clone the repos of the scm type "gitea"`,
+	}} {
+		t.Run(tc.scmType+" "+tc.serverURL, func(t *testing.T) {
+			got, err := apiFallbackCloneURL(tc.scmType, tc.serverURL, "tektoncd", "catalog")
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("expected the error %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("expected the clone URL %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+const (
+	// apiFetchStrategyContents fetches each file resolved in API mode with a
+	// request to the contents API of the SCM.
+	apiFetchStrategyContents = "contents"
+	// apiFetchStrategyArchive fetches the archive of the resolved commit
+	// once and reads the files resolved in API mode from it.
+	apiFetchStrategyArchive = "archive"
+	// apiEntryTypeSymlink is the type of the symlinks listed by the contents
+	// API of the SCM.
+	apiEntryTypeSymlink = "symlink"
+	// maxArchiveExtractedFactor bounds the total size of the files extracted
+	// from an archive, as a multiple of the max file size. The files dropped
+	// afterwards by export-ignore count towards it.
+	maxArchiveExtractedFactor = 4
+)
+
+// errArchiveUnsupported is returned when the SCM doesn't serve the archives
+// of the repos, so that the files are fetched with the contents API instead.
+var errArchiveUnsupported = errors.New("the SCM doesn't serve repository archives")
+
+// archiveEndpoint returns the endpoint of the API of the SCM of the client
+// serving the gzipped tarball of the repo at the given ref.
+func archiveEndpoint(driver scm.Driver, orgRepo, ref string) (string, bool) {
+	switch driver {
+	case scm.DriverGithub:
+		return fmt.Sprintf("repos/%s/tarball/%s", orgRepo, url.PathEscape(ref)), true
+	case scm.DriverGitlab:
+		return fmt.Sprintf("api/v4/projects/%s/repository/archive.tar.gz?sha=%s", strings.ReplaceAll(orgRepo, "/", "%2F"), url.QueryEscape(ref)), true
+	case scm.DriverGitea:
+		return fmt.Sprintf("api/v1/repos/%s/archive/%s.tar.gz", orgRepo, url.PathEscape(ref)), true
+	default:
+		return "", false
+	}
+}
+
+// repoArchive holds the files extracted from the archive of a repo, by their
+// path in the repo, the directories containing them and the symlinks which
+// would have been extracted if they were files.
+type repoArchive struct {
+	files    map[string][]byte
+	dirs     map[string]bool
+	symlinks map[string]bool
+}
+
+// readFile returns the content of the extracted file at the given path, or
+// nil if it wasn't extracted.
+func (a *repoArchive) readFile(p string) ([]byte, error) {
+	return a.files[cleanRepoPath(p)], nil
+}
+
+// listManifests returns the extracted YAML files of the given directory.
+func (a *repoArchive) listManifests(dir string, maxSize int64) ([]manifestFile, error) {
+	dir = cleanRepoPath(dir)
+	var files []manifestFile
+	for p := range a.symlinks {
+		if archiveDir(p) == dir && isManifestFile(p) {
+			return nil, apiSymlinkError(p)
+		}
+	}
+	for p, content := range a.files {
+		if archiveDir(p) != dir || !isManifestFile(p) {
+			continue
+		}
+		if size := int64(len(content)); size > maxSize {
+			return nil, fileTooLargeError(p, size, maxSize)
+		}
+		files = append(files, manifestFile{path: p, content: content})
+	}
+	return files, nil
+}
+
+// fetchAPIArchive downloads the archive of the repo at the given ref with
+// the API of the SCM and extracts the file or the YAML files of the directory
+// at pathInRepo, along with the .gitattributes files which apply to them. It
+// fails if one of them is larger than maxSize, and returns
+// errArchiveUnsupported if the SCM doesn't serve archives.
+func fetchAPIArchive(ctx context.Context, scmClient *scm.Client, orgRepo, ref, pathInRepo string, maxSize int64) (*repoArchive, error) {
+	endpoint, ok := archiveEndpoint(scmClient.Driver, orgRepo, ref)
+	if !ok {
+		return nil, errArchiveUnsupported
+	}
+	res, err := scmClient.Do(ctx, &scm.Request{Method: http.MethodGet, Path: endpoint})
+	if err != nil {
+		return nil, fmt.Errorf("couldn't fetch the archive of the repo: %w", err)
+	}
+	defer res.Body.Close()
+	switch res.Status {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotAcceptable, http.StatusNotImplemented:
+		return nil, errArchiveUnsupported
+	default:
+		return nil, newSCMStatusError(res, fmt.Errorf("couldn't fetch the archive of the repo: %s", http.StatusText(res.Status)))
+	}
+
+	gz, err := gzip.NewReader(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read the archive of the repo: %w", err)
+	}
+	defer gz.Close()
+	return extractArchive(tar.NewReader(gz), pathInRepo, maxSize)
+}
+
+// extractArchive extracts the file or the YAML files of the directory at
+// pathInRepo from the tarball of a repo, whose entries are nested in a top
+// level directory named after the repo and the commit. It fails if the
+// extracted files total more than maxArchiveExtractedFactor times maxSize.
+func extractArchive(tr *tar.Reader, pathInRepo string, maxSize int64) (*repoArchive, error) {
+	target := cleanRepoPath(pathInRepo)
+	// The .gitattributes files of the target itself apply to the files of
+	// the directory it designates.
+	ancestors := map[string]bool{target: true}
+	for _, dir := range parentDirs(target) {
+		ancestors[dir] = true
+	}
+	archive := &repoArchive{files: map[string][]byte{}, dirs: map[string]bool{}, symlinks: map[string]bool{}}
+	maxExtracted := maxArchiveExtractedFactor * maxSize
+	var extracted int64
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return archive, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("couldn't read the archive of the repo: %w", err)
+		}
+		_, p, found := strings.Cut(strings.TrimPrefix(hdr.Name, "./"), "/")
+		if !found {
+			continue
+		}
+		p = cleanRepoPath(p)
+		if p == "" {
+			continue
+		}
+		if hdr.Typeflag == tar.TypeDir {
+			archive.dirs[p] = true
+			continue
+		}
+		if hdr.Typeflag != tar.TypeReg && hdr.Typeflag != tar.TypeSymlink {
+			continue
+		}
+		for _, dir := range parentDirs(p) {
+			archive.dirs[dir] = true
+		}
+		dir := archiveDir(p)
+		wanted := p == target ||
+			(dir == target && isManifestFile(p)) ||
+			(path.Base(p) == gitAttributesFile && ancestors[dir])
+		if !wanted {
+			continue
+		}
+		if hdr.Typeflag == tar.TypeSymlink {
+			archive.symlinks[p] = true
+			continue
+		}
+		if hdr.Size > maxSize {
+			return nil, fileTooLargeError(p, hdr.Size, maxSize)
+		}
+		if extracted+hdr.Size > maxExtracted {
+			return nil, archiveTooLargeError(pathInRepo, maxExtracted)
+		}
+		content, err := io.ReadAll(io.LimitReader(tr, maxSize+1))
+		if err != nil {
+			return nil, fmt.Errorf("couldn't read %s from the archive of the repo: %w", p, err)
+		}
+		if size := int64(len(content)); size > maxSize {
+			return nil, fileTooLargeError(p, size, maxSize)
+		}
+		if extracted += int64(len(content)); extracted > maxExtracted {
+			return nil, archiveTooLargeError(pathInRepo, maxExtracted)
+		}
+		archive.files[p] = content
+	}
+}
+
+// archiveTooLargeError is returned when the files extracted from the archive
+// of a repo for the given path total more than maxExtracted bytes.
+func archiveTooLargeError(pathInRepo string, maxExtracted int64) error {
+	return fmt.Errorf("the files extracted from the archive of the repo for %s exceed %d bytes: %s", pathInRepo, maxExtracted, splitResourceSuggestion)
+}
+
+// archiveDir returns the directory of the given path of the repo, "" for the
+// root of the repo.
+func archiveDir(p string) string {
+	dir := path.Dir(p)
+	if dir == "." {
+		return ""
+	}
+	return dir
+}
@@ -0,0 +1,333 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/fake"
+	"github.com/jenkins-x/go-scm/scm/factory"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/cache"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+const archiveTestSHA = "0123456789abcdef0123456789abcdef01234567"
+
+// makeTarball returns the gzipped tarball of the files and of the symlinks to
+// their targets, nested in a top level directory like the archives served by
+// GitHub.
+func makeTarball(t *testing.T, files, symlinks map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	if err := tw.WriteHeader(&tar.Header{Typeflag: tar.TypeXGlobalHeader, Name: "pax_global_header", PAXRecords: map[string]string{"comment": archiveTestSHA}}); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		hdr := &tar.Header{Typeflag: tar.TypeReg, Name: "org-repo-0123456/" + name, Mode: 0o644, Size: int64(len(content))}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for name, target := range symlinks {
+		if err := tw.WriteHeader(&tar.Header{Typeflag: tar.TypeSymlink, Name: "org-repo-0123456/" + name, Linkname: target, Mode: 0o777}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// countingContents counts the requests to the contents API.
+type countingContents struct {
+	scm.ContentService
+	calls *atomic.Int32
+}
+
+func (c countingContents) Find(ctx context.Context, repo, p, ref string) (*scm.Content, *scm.Response, error) {
+	c.calls.Add(1)
+	return c.ContentService.Find(ctx, repo, p, ref)
+}
+
+func (c countingContents) List(ctx context.Context, repo, p, ref string, opts *scm.ListOptions) ([]*scm.FileEntry, *scm.Response, error) {
+	c.calls.Add(1)
+	return c.ContentService.List(ctx, repo, p, ref, opts)
+}
+
+func TestResolveAPIGitWithArchive(t *testing.T) {
+	archive := makeTarball(t, map[string]string{
+		"task/task.yaml":            "kind: Task\n",
+		"pipelines/a.yaml":          "kind: Pipeline\nname: a\n",
+		"pipelines/b.yml":           "kind: Pipeline\nname: b\n",
+		"pipelines/README.md":       "# Pipelines\n",
+		"pipelines/nested/c.yaml":   "kind: Pipeline\nname: c\n",
+		"pipelines/big/large.yaml":  strings.Repeat("#", 200),
+		"internal/.gitattributes":   "* export-ignore\n",
+		"internal/secret-task.yaml": "kind: Task\n",
+	}, map[string]string{
+		"task/link.yaml":  "task.yaml",
+		"links/link.yaml": "../task/task.yaml",
+	})
+	for _, tc := range []struct {
+		name              string
+		path              string
+		serveArchive      bool
+		maxFileSizeBytes  string
+		wantData          string
+		wantErr           string
+		wantContentsCalls bool
+	}{{
+		name:         "file",
+		path:         "task/task.yaml",
+		serveArchive: true,
+		wantData:     "kind: Task\n",
+	}, {
+		name:         "directory",
+		path:         "pipelines/",
+		serveArchive: true,
+		wantData:     "kind: Pipeline\nname: a\n---\nkind: Pipeline\nname: b\n",
+	}, {
+		name:         "directory without trailing slash",
+		path:         "pipelines",
+		serveArchive: true,
+		wantData:     "kind: Pipeline\nname: a\n---\nkind: Pipeline\nname: b\n",
+	}, {
+		name:             "file of a directory larger than the limit",
+		path:             "pipelines/big/",
+		serveArchive:     true,
+		maxFileSizeBytes: "100",
+		wantErr:          "resolved file pipelines/big/large.yaml is 200 bytes",
+	}, {
+		name:             "file larger than the limit",
+		path:             "pipelines/big/large.yaml",
+		serveArchive:     true,
+		maxFileSizeBytes: "100",
+		wantErr:          "resolved file pipelines/big/large.yaml is 200 bytes",
+	}, {
+		name:         "export-ignore",
+		path:         "internal/secret-task.yaml",
+		serveArchive: true,
+		wantErr:      `path "internal/secret-task.yaml" is marked export-ignore`,
+	}, {
+		name:         "missing file",
+		path:         "task/other.yaml",
+		serveArchive: true,
+		wantErr:      "couldn't fetch resource content: task/other.yaml not found in the archive of the repo",
+	}, {
+		name:         "symlink",
+		path:         "task/link.yaml",
+		serveArchive: true,
+		wantErr:      `path "task/link.yaml" is a symlink`,
+	}, {
+		name:         "directory with a symlink",
+		path:         "links/",
+		serveArchive: true,
+		wantErr:      `path "links/link.yaml" is a symlink`,
+	}, {
+		name:              "symlink with the contents API",
+		path:              "task/link.yaml",
+		wantErr:           `path "task/link.yaml" is a symlink`,
+		wantContentsCalls: true,
+	}, {
+		name:              "falls back to the contents API without archives",
+		path:              "task/task.yaml",
+		wantData:          "kind: Task from the contents API\n",
+		wantContentsCalls: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			var archiveRequests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if !tc.serveArchive || r.URL.Path != "/repos/org/repo/tarball/"+archiveTestSHA {
+					http.NotFound(w, r)
+					return
+				}
+				archiveRequests.Add(1)
+				w.Header().Set("Content-Type", "application/x-gzip")
+				_, _ = w.Write(archive)
+			}))
+			defer server.Close()
+
+			var contentsCalls atomic.Int32
+			clientFunc := func(driver, serverURL, token string, opts ...factory.ClientOptionFunc) (*scm.Client, error) {
+				scmClient, scmData := fake.NewDefault()
+				scmClient.Driver = scm.DriverGithub
+				scmClient.BaseURL, _ = url.Parse(server.URL + "/")
+				scmData.Repositories = []*scm.Repository{{FullName: "org/repo", Clone: "https://github.com/org/repo.git"}}
+				scmData.Commits = map[string]*scm.Commit{"main": {Sha: archiveTestSHA}}
+				scmClient.Contents = countingContents{ContentService: fakeArchiveContents{}, calls: &contentsCalls}
+				return scmClient, nil
+			}
+
+			g := &GitResolver{
+				Params: map[string]string{
+					OrgParam:      "org",
+					RepoParam:     "repo",
+					PathParam:     tc.path,
+					RevisionParam: "main",
+				},
+				Logger: zap.NewNop().Sugar(),
+				Cache:  cache.NewLRUExpireCache(cacheSize),
+				TTL:    ttl,
+				KubeClient: kubefake.NewSimpleClientset(&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "token-secret", Namespace: "tekton-pipelines"},
+					Data:       map[string][]byte{"token": []byte("github-token")},
+				}),
+			}
+			config := map[string]string{
+				SCMTypeKey:            "github",
+				ServerURLKey:          server.URL,
+				APIFetchStrategyKey:   apiFetchStrategyArchive,
+				APISecretNameKey:      "token-secret",
+				APISecretKeyKey:       "token",
+				APISecretNamespaceKey: "tekton-pipelines",
+			}
+			if tc.maxFileSizeBytes != "" {
+				config[MaxFileSizeBytesKey] = tc.maxFileSizeBytes
+			}
+			ctx := framework.InjectResolverConfigToContext(t.Context(), config)
+			res, err := g.ResolveAPIGit(ctx, clientFunc)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("unexpected error resolving with the archive strategy: %v", err)
+				}
+				if string(res.Data()) != tc.wantData {
+					t.Errorf("expected the content %q, got %q", tc.wantData, res.Data())
+				}
+				if src := res.RefSource(); src.Digest["sha1"] != archiveTestSHA {
+					t.Errorf("expected the file to be resolved at the commit %s, got %+v", archiveTestSHA, src)
+				}
+			}
+			if got := contentsCalls.Load(); tc.wantContentsCalls != (got > 0) {
+				t.Errorf("expected the contents API to be called: %t, got %d calls", tc.wantContentsCalls, got)
+			}
+			if got, want := archiveRequests.Load(), int32(0); tc.serveArchive && got != 1 {
+				t.Errorf("expected the archive to be fetched once, got %d requests", got)
+			} else if !tc.serveArchive && got != want {
+				t.Errorf("expected no archive to be served, got %d requests", got)
+			}
+		})
+	}
+}
+
+// fakeArchiveContents serves the task of the repo with the contents API.
+type fakeArchiveContents struct {
+	scm.ContentService
+}
+
+func (fakeArchiveContents) Find(_ context.Context, _, p, _ string) (*scm.Content, *scm.Response, error) {
+	if p != "task/task.yaml" {
+		return nil, &scm.Response{Status: http.StatusNotFound}, scm.ErrNotFound
+	}
+	return &scm.Content{Path: p, Data: []byte("kind: Task from the contents API\n")}, nil, nil
+}
+
+func (fakeArchiveContents) List(context.Context, string, string, string, *scm.ListOptions) ([]*scm.FileEntry, *scm.Response, error) {
+	return []*scm.FileEntry{
+		{Name: "task.yaml", Path: "task/task.yaml", Type: "file", Size: 33},
+		{Name: "link.yaml", Path: "task/link.yaml", Type: "symlink", Size: 9},
+	}, nil, nil
+}
+
+func TestExtractArchiveTotalSize(t *testing.T) {
+	files := map[string]string{}
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		files["pipelines/"+name+".yaml"] = strings.Repeat("#", 90)
+	}
+	archive := makeTarball(t, files, nil)
+
+	for _, tc := range []struct {
+		name    string
+		maxSize int64
+		wantErr string
+	}{{
+		name:    "within the limit",
+		maxSize: 120,
+	}, {
+		name:    "files under the max file size but exceeding the total limit",
+		maxSize: 100,
+		wantErr: "the files extracted from the archive of the repo for pipelines/ exceed 400 bytes",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			gz, err := gzip.NewReader(bytes.NewReader(archive))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer gz.Close()
+			got, err := extractArchive(tar.NewReader(gz), "pipelines/", tc.maxSize)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error extracting the archive: %v", err)
+			}
+			if len(got.files) != len(files) {
+				t.Errorf("expected %d files to be extracted, got %d", len(files), len(got.files))
+			}
+		})
+	}
+}
+
+func TestGetAPIFetchStrategy(t *testing.T) {
+	for _, tc := range []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{value: "", want: apiFetchStrategyContents},
+		{value: "contents", want: apiFetchStrategyContents},
+		{value: "archive", want: apiFetchStrategyArchive},
+		{value: "zip", wantErr: true},
+	} {
+		got, err := ScmConfig{APIFetchStrategy: tc.value}.GetAPIFetchStrategy()
+		if (err != nil) != tc.wantErr {
+			t.Errorf("GetAPIFetchStrategy(%q) returned error %v, wanted error: %t", tc.value, err, tc.wantErr)
+		}
+		if got != tc.want {
+			t.Errorf("GetAPIFetchStrategy(%q) = %q, want %q", tc.value, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+const (
+	// scmTypeAzure is the SCM type of Azure DevOps, whose repos belong to a
+	// project of an org: the org param is "<org>/<project>" with this type.
+	scmTypeAzure = "azure"
+
+	azureServerURL = "https://dev.azure.com"
+)
+
+// azureRepoURLRegexes match the URLs of the Azure DevOps repos, capturing
+// their org, project and repo.
+var azureRepoURLRegexes = []*regexp.Regexp{
+	// https://[<user>@]dev.azure.com/<org>/<project>/_git/<repo>
+	regexp.MustCompile(`^https?://(?:[^@/]+@)?(?i:dev\.azure\.com)/([^/]+)/([^/]+)/_git/([^/]+?)/?$`),
+	// https://<org>.visualstudio.com/[DefaultCollection/]<project>/_git/<repo>
+	regexp.MustCompile(`^https?://(?:[^@/]+@)?([^./@]+)(?i:\.visualstudio\.com)/(?:DefaultCollection/)?([^/]+)/_git/([^/]+?)/?$`),
+	// [ssh://]git@ssh.dev.azure.com[:/]v3/<org>/<project>/<repo>
+	regexp.MustCompile(`^(?:ssh://)?git@(?i:ssh\.dev\.azure\.com)[:/]v3/([^/]+)/([^/]+)/([^/]+?)/?$`),
+}
+
+// parseAzureRepoURL returns the org of the URL of an Azure DevOps repo, with
+// its project as "<org>/<project>", and the repo, or false if the URL isn't
+// the one of an Azure DevOps repo.
+func parseAzureRepoURL(repoURL string) (org, repo string, ok bool) {
+	for _, re := range azureRepoURLRegexes {
+		if m := re.FindStringSubmatch(repoURL); m != nil {
+			return m[1] + "/" + m[2], m[3], true
+		}
+	}
+	return "", "", false
+}
+
+// azureRepoURL returns the URL of the repo of the "<org>/<project>" org on
+// the Azure DevOps server.
+func azureRepoURL(serverURL, org, repo string) string {
+	return fmt.Sprintf("%s/%s/_git/%s", serverURL, org, repo)
+}
+
+// validateAzureOrg returns an error if the org param isn't "<org>/<project>",
+// as the repos of Azure DevOps belong to a project of an org.
+func validateAzureOrg(org string) error {
+	parts := strings.Split(org, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("'%s' must be <organization>/<project> with the %s scm type, got %q", OrgParam, scmTypeAzure, org)
+	}
+	return nil
+}
+
+// resolveAzureRef returns the SHA of the commit of the revision in an Azure
+// DevOps repo. Its API looks the files up by branch name or by commit SHA
+// rather than by ref, and only looks the commits up by SHA, so the branch is
+// resolved to its head commit once and the files and the commit are fetched
+// at that commit.
+func resolveAzureRef(ctx context.Context, scmClient *scm.Client, orgRepo, ref string) (string, error) {
+	if commitSHARegex.MatchString(ref) {
+		return ref, nil
+	}
+	if strings.HasPrefix(ref, "refs/") && !strings.HasPrefix(ref, "refs/heads/") {
+		return "", fmt.Errorf("the revision %s isn't supported with the %s scm type, which only supports branches and commit SHAs", ref, scmTypeAzure)
+	}
+	branch := strings.TrimPrefix(ref, "refs/heads/")
+	commits, res, err := scmClient.Git.ListCommits(ctx, orgRepo, scm.CommitListOptions{Ref: branch, Size: 1})
+	if err != nil {
+		return "", fmt.Errorf("couldn't fetch the head commit of the branch %s in the repo: %w", branch, newSCMStatusError(res, err))
+	}
+	if len(commits) == 0 || commits[0].Sha == "" {
+		return "", fmt.Errorf("couldn't find the head commit of the branch %s in the repo", branch)
+	}
+	return commits[0].Sha, nil
+}
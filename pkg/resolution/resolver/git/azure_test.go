@@ -0,0 +1,220 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/fake"
+	"github.com/jenkins-x/go-scm/scm/factory"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/cache"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+const (
+	azureTestOrgRepo = "org/project/repo"
+	azureTestSHA     = "0123456789abcdef0123456789abcdef01234567"
+)
+
+// fakeAzureGit behaves like the Azure DevOps driver, which only looks the
+// commits up by SHA.
+type fakeAzureGit struct {
+	scm.GitService
+}
+
+func (g fakeAzureGit) ListCommits(_ context.Context, repo string, opts scm.CommitListOptions) ([]*scm.Commit, *scm.Response, error) {
+	if repo != azureTestOrgRepo || opts.Ref != "main" {
+		return nil, nil, scm.ErrNotFound
+	}
+	return []*scm.Commit{{Sha: azureTestSHA}}, nil, nil
+}
+
+func (g fakeAzureGit) FindCommit(_ context.Context, repo, ref string) (*scm.Commit, *scm.Response, error) {
+	if repo != azureTestOrgRepo || ref != azureTestSHA {
+		return nil, nil, fmt.Errorf("unexpected commit %s in %s", ref, repo)
+	}
+	return &scm.Commit{Sha: azureTestSHA}, nil, nil
+}
+
+// fakeAzureContents serves the files of the repo at the head commit of its
+// main branch.
+type fakeAzureContents struct {
+	scm.ContentService
+}
+
+func (c fakeAzureContents) Find(_ context.Context, repo, p, ref string) (*scm.Content, *scm.Response, error) {
+	if repo != azureTestOrgRepo || ref != azureTestSHA {
+		return nil, nil, fmt.Errorf("unexpected file %s at %s in %s", p, ref, repo)
+	}
+	if p != "task/task.yaml" {
+		return nil, nil, scm.ErrNotFound
+	}
+	return &scm.Content{Path: p, Data: []byte("task")}, nil, nil
+}
+
+func (c fakeAzureContents) List(context.Context, string, string, string, *scm.ListOptions) ([]*scm.FileEntry, *scm.Response, error) {
+	return nil, nil, scm.ErrNotFound
+}
+
+func TestResolveAPIGitWithAzure(t *testing.T) {
+	tokenSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "token-secret", Namespace: "tekton-pipelines"},
+		Data:       map[string][]byte{"token": []byte("azure-token")},
+	}
+	g := &GitResolver{
+		Params: map[string]string{
+			OrgParam:      "org/project",
+			RepoParam:     "repo",
+			PathParam:     "task/task.yaml",
+			RevisionParam: "refs/heads/main",
+		},
+		Logger:     zap.NewNop().Sugar(),
+		Cache:      cache.NewLRUExpireCache(cacheSize),
+		TTL:        ttl,
+		KubeClient: kubefake.NewSimpleClientset(tokenSecret),
+	}
+	clientFunc := func(driver, _, token string, _ ...factory.ClientOptionFunc) (*scm.Client, error) {
+		if driver != scmTypeAzure || token != "azure-token" {
+			t.Errorf("unexpected SCM client for %s with token %q", driver, token)
+		}
+		scmClient, scmData := fake.NewDefault()
+		scmData.Repositories = []*scm.Repository{{FullName: azureTestOrgRepo, Clone: "https://dev.azure.com/org/project/_git/repo"}}
+		scmClient.Git = fakeAzureGit{GitService: scmClient.Git}
+		scmClient.Contents = fakeAzureContents{ContentService: scmClient.Contents}
+		return scmClient, nil
+	}
+
+	ctx := framework.InjectResolverConfigToContext(t.Context(), map[string]string{
+		SCMTypeKey:            scmTypeAzure,
+		APISecretNameKey:      "token-secret",
+		APISecretKeyKey:       "token",
+		APISecretNamespaceKey: "tekton-pipelines",
+	})
+	res, err := g.ResolveAPIGit(ctx, clientFunc)
+	if err != nil {
+		t.Fatalf("unexpected error resolving from Azure DevOps: %v", err)
+	}
+	if string(res.Data()) != "task" {
+		t.Errorf("expected the content of the file, got %q", res.Data())
+	}
+	if src := res.RefSource(); src.Digest["sha1"] != azureTestSHA || src.URI != "git+https://dev.azure.com/org/project/_git/repo@refs/heads/main" {
+		t.Errorf("expected the file to be resolved at the head commit of the branch, got %+v", src)
+	}
+}
+
+func TestResolveAzureRef(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		ref     string
+		want    string
+		wantErr string
+	}{{
+		name: "branch",
+		ref:  "main",
+		want: azureTestSHA,
+	}, {
+		name: "full branch ref",
+		ref:  "refs/heads/main",
+		want: azureTestSHA,
+	}, {
+		name: "commit SHA",
+		ref:  "fedcba9876543210fedcba9876543210fedcba98",
+		want: "fedcba9876543210fedcba9876543210fedcba98",
+	}, {
+		name:    "tag",
+		ref:     "refs/tags/v1",
+		wantErr: "the revision refs/tags/v1 isn't supported with the azure scm type, which only supports branches and commit SHAs",
+	}, {
+		name:    "unknown branch",
+		ref:     "other",
+		wantErr: "couldn't fetch the head commit of the branch other in the repo: Not Found",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			scmClient, _ := fake.NewDefault()
+			scmClient.Git = fakeAzureGit{GitService: scmClient.Git}
+			got, err := resolveAzureRef(t.Context(), scmClient, azureTestOrgRepo, tc.ref)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("expected error %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("expected the ref %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestParseAzureRepoURL(t *testing.T) {
+	for _, tc := range []struct {
+		url      string
+		wantOrg  string
+		wantRepo string
+		wantOK   bool
+	}{{
+		url:      "https://dev.azure.com/org/project/_git/repo",
+		wantOrg:  "org/project",
+		wantRepo: "repo",
+		wantOK:   true,
+	}, {
+		url:      "https://user@dev.azure.com/org/project/_git/repo/",
+		wantOrg:  "org/project",
+		wantRepo: "repo",
+		wantOK:   true,
+	}, {
+		url:      "https://org.visualstudio.com/DefaultCollection/project/_git/repo",
+		wantOrg:  "org/project",
+		wantRepo: "repo",
+		wantOK:   true,
+	}, {
+		url:      "git@ssh.dev.azure.com:v3/org/project/repo",
+		wantOrg:  "org/project",
+		wantRepo: "repo",
+		wantOK:   true,
+	}, {
+		url: "https://github.com/tektoncd/catalog",
+	}} {
+		t.Run(tc.url, func(t *testing.T) {
+			org, repo, ok := parseAzureRepoURL(tc.url)
+			if org != tc.wantOrg || repo != tc.wantRepo || ok != tc.wantOK {
+				t.Errorf("expected %q %q %t, got %q %q %t", tc.wantOrg, tc.wantRepo, tc.wantOK, org, repo, ok)
+			}
+		})
+	}
+}
+
+func TestValidateAzureOrg(t *testing.T) {
+	if err := validateAzureOrg("org/project"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	for _, org := range []string{"org", "org/", "/project", "org/project/repo"} {
+		if err := validateAzureOrg(org); err == nil {
+			t.Errorf("expected an error for the org %q", org)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+	"k8s.io/apimachinery/pkg/util/cache"
+)
+
+// CloneCache caches the files resolved from the commits of the cloned repos,
+// so that the ResolutionRequests of the same file at the same commit, like
+// the ones of the fan-out of a matrix, clone the repo once. The concurrent
+// resolutions of the same file share the same clone.
+type CloneCache struct {
+	mu         sync.Mutex
+	entries    *cache.LRUExpireCache
+	maxEntries int
+
+	group  singleflight.Group
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+// cloneCacheKey is the key of a resolved file in the clone cache. The
+// resolution of the file doesn't depend on the params which aren't part of it.
+type cloneCacheKey struct {
+	url                string
+	sha                string
+	path               string
+	maxFileSize        int64
+	ignoreExportIgnore bool
+	submodules         string
+	// configKey is the key of the config the URLs of the submodules are
+	// rewritten and checked with, if they are initialized.
+	configKey string
+}
+
+// NewCloneCache returns an empty CloneCache.
+func NewCloneCache() *CloneCache {
+	return &CloneCache{}
+}
+
+// Stats returns the number of resolutions served from the cache and the
+// number of resolutions which cloned the repo.
+func (c *CloneCache) Stats() (hits, misses int64) {
+	return c.hits.Load(), c.misses.Load()
+}
+
+// getOrLoad returns the resource cached with the key, or else the resource
+// loaded with load, which is cached if it is the one of the commit of the key.
+// The lookups in the cache are recorded in the metrics of the git resolver.
+func (c *CloneCache) getOrLoad(ctx context.Context, key cloneCacheKey, ttl time.Duration, maxEntries int, load func() (*resolvedGitResource, error)) (*resolvedGitResource, error) {
+	entries := c.getEntries(maxEntries)
+	if val, ok := entries.Get(key); ok {
+		c.hits.Add(1)
+		recordCacheLookup(ctx, true)
+		return copyResource(val.(*resolvedGitResource)), nil
+	}
+
+	loaded := false
+	val, err, _ := c.group.Do(fmt.Sprintf("%#v", key), func() (interface{}, error) {
+		if val, ok := entries.Get(key); ok {
+			return val, nil
+		}
+		loaded = true
+		c.misses.Add(1)
+		recordCacheLookup(ctx, false)
+		res, err := load()
+		if err != nil {
+			return nil, err
+		}
+		// The revision may have moved between its resolution and the clone.
+		if res.Revision == key.sha {
+			entries.Add(key, res, ttl)
+		}
+		return res, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !loaded {
+		c.hits.Add(1)
+		recordCacheLookup(ctx, true)
+	}
+	return copyResource(val.(*resolvedGitResource)), nil
+}
+
+// InvalidateRepo removes the entries of the repo from the cache, whichever
+// URL of the repo they were resolved with, and returns their number.
+func (c *CloneCache) InvalidateRepo(repoURL string) int {
+	c.mu.Lock()
+	entries := c.entries
+	c.mu.Unlock()
+	if entries == nil {
+		return 0
+	}
+	repoURL = normalizeRepoURL(repoURL)
+	removed := 0
+	entries.RemoveAll(func(key any) bool {
+		if key.(cloneCacheKey).url == repoURL {
+			removed++
+			return true
+		}
+		return false
+	})
+	return removed
+}
+
+// getEntries returns the cached entries, dropping them if the maximum number
+// of entries changed.
+func (c *CloneCache) getEntries(maxEntries int) *cache.LRUExpireCache {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil || c.maxEntries != maxEntries {
+		c.entries = cache.NewLRUExpireCache(maxEntries)
+		c.maxEntries = maxEntries
+	}
+	return c.entries
+}
+
+func copyResource(r *resolvedGitResource) *resolvedGitResource {
+	c := *r
+	return &c
+}
+
+// normalizeRepoURL returns the URL of the repo without the credentials, the
+// trailing slash and the .git suffix, with a lowercase scheme and host, so
+// that the different URLs of a repo share the clone cache entries. The HTTPS,
+// SSH and legacy visualstudio.com URLs of an Azure DevOps repo are all
+// normalized to its dev.azure.com URL.
+func normalizeRepoURL(repoURL string) string {
+	if org, repo, ok := parseAzureRepoURL(repoURL); ok {
+		return azureRepoURL(azureServerURL, org, repo)
+	}
+	u, err := url.Parse(repoURL)
+	if err != nil || u.Host == "" {
+		return strings.TrimSuffix(strings.TrimSuffix(repoURL, "/"), ".git")
+	}
+	u.User = nil
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	u.Path = strings.TrimSuffix(strings.TrimSuffix(u.Path, "/"), ".git")
+	return u.String()
+}
@@ -20,9 +20,13 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
+	"time"
 
+	common "github.com/tektoncd/pipeline/pkg/resolution/common"
 	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 const (
@@ -41,6 +45,10 @@ const (
 	// DefaultOrgKey is the configuration field name for setting a default organization when using the SCM API.
 	DefaultOrgKey = "default-org"
 
+	// DefaultRepoKey is the configuration field name for setting a default repository when using the SCM API,
+	// used when neither the url nor the repo param is set.
+	DefaultRepoKey = "default-repo"
+
 	// ServerURLKey is the config map key for the SCM provider URL
 	ServerURLKey = "server-url"
 	// SCMTypeKey is the config map key for the SCM provider type
@@ -51,21 +59,161 @@ const (
 	APISecretKeyKey = "api-token-secret-key"
 	// APISecretNamespaceKey is the config map key for the token secret's namespace
 	APISecretNamespaceKey = "api-token-secret-namespace"
+
+	// APIAppIDKey is the config map key for the ID of the GitHub App used to
+	// authenticate to the SCM API instead of an API token
+	APIAppIDKey = "api-app-id"
+	// APIAppInstallationIDKey is the config map key for the ID of the installation of the GitHub App
+	APIAppInstallationIDKey = "api-app-installation-id"
+	// APIAppPrivateKeySecretNameKey is the config map key for the GitHub App private key secret's name
+	APIAppPrivateKeySecretNameKey = "api-app-private-key-secret-name"
+	// APIAppPrivateKeySecretKeyKey is the config map key for the key containing the private key within the private key secret
+	APIAppPrivateKeySecretKeyKey = "api-app-private-key-secret-key"
+	// APIAppPrivateKeySecretNamespaceKey is the config map key for the GitHub App private key secret's namespace
+	APIAppPrivateKeySecretNamespaceKey = "api-app-private-key-secret-namespace"
+
+	// DefaultSparseCheckoutDirectoriesKey is the configuration field name for
+	// the comma separated list of the only directories to fetch when cloning
+	// a repo, if the request doesn't set it.
+	DefaultSparseCheckoutDirectoriesKey = "default-sparse-checkout-directories"
+
+	// MaxFileSizeKey is the configuration field name for controlling the
+	// maximum size of the files which can be resolved, as a quantity like "1Mi".
+	// It's ignored if max-file-size-bytes is set.
+	//
+	// Deprecated: use MaxFileSizeBytesKey instead.
+	MaxFileSizeKey = "max-file-size"
+
+	// MaxFileSizeBytesKey is the configuration field name for controlling the
+	// maximum size of the files which can be resolved, as a number of bytes.
+	// It takes precedence over the deprecated max-file-size.
+	MaxFileSizeBytesKey = "max-file-size-bytes"
+
+	// DefaultMaxFileSize is the maximum size of the files which can be
+	// resolved when neither max-file-size-bytes nor max-file-size is set. The data of a ResolutionRequest
+	// is stored base64 encoded, taking 4/3 of the size of the file, so that
+	// 1MiB files fit in the 1.5MiB requests accepted by etcd.
+	DefaultMaxFileSize int64 = 1024 * 1024
+
+	// CacheTTLKey is the configuration field name for controlling how long
+	// the files resolved from a commit of a cloned repo are cached, as a
+	// duration like "5m". "0" disables the clone cache.
+	CacheTTLKey = "cache-ttl"
+
+	// CacheMaxEntriesKey is the configuration field name for controlling the
+	// maximum number of resolved files kept in the clone cache. "0" disables
+	// the clone cache.
+	CacheMaxEntriesKey = "cache-max-entries"
+
+	// DefaultCacheTTL is the time to live of the clone cache entries when
+	// cache-ttl isn't set.
+	DefaultCacheTTL = 5 * time.Minute
+
+	// DefaultCacheMaxEntries is the maximum number of entries of the clone
+	// cache when cache-max-entries isn't set.
+	DefaultCacheMaxEntries = 100
+
+	// APIFallbackToCloneKey is the configuration field name for falling back
+	// to an anonymous clone of the repo when the SCM API rejects the requests
+	// because of their credentials or of a rate limit, "true" or "false".
+	APIFallbackToCloneKey = "api-fallback-to-clone"
+
+	// APIFetchStrategyKey is the configuration field name for how the files
+	// are fetched in API mode, "contents" to fetch each file with the contents
+	// API or "archive" to download the archive of the resolved commit once.
+	APIFetchStrategyKey = "api-fetch-strategy"
+
+	// GitTokenSchemeKey is the configuration field name for how the gitToken
+	// is sent when cloning a repo, "basic" or "bearer", if the request doesn't
+	// set it.
+	GitTokenSchemeKey = "git-token-scheme"
+
+	// CloneTimeoutKey is the configuration field name for the maximum
+	// duration of each operation fetching from the remote of a cloned repo,
+	// like the clone itself, as a duration like "2m". The operations are only
+	// bounded by the fetch-timeout of the whole resolution if it isn't set.
+	CloneTimeoutKey = "clone-timeout"
+
+	// AllowedURLPatternsKey is the configuration field name for the comma
+	// separated list of the patterns of the URLs of the repos which can be
+	// resolved, all of them if empty. A pattern starting with "^" is a regular
+	// expression, any other pattern is a glob like "https://github.com/tektoncd/*".
+	AllowedURLPatternsKey = "allowed-url-patterns"
+
+	// URLRewriteKey is the prefix of the configuration field names of the
+	// URL rewrites, "url-rewrite.<n>.from" and "url-rewrite.<n>.to", which
+	// rewrite the url param starting with "from" to start with "to" instead
+	// before cloning the repo. The rewrite with the longest matching "from"
+	// is applied, like the insteadOf of the git config.
+	URLRewriteKey = "url-rewrite"
+
+	// RepoMirrorKey is the prefix of the configuration field names of the
+	// repo mirrors, "repo-mirror.<n>.upstream" and "repo-mirror.<n>.mirror",
+	// which clone the repos whose url param starts with "upstream" from the
+	// mirror whose URL starts with "mirror" instead. The mirror with the
+	// longest matching "upstream" is used.
+	RepoMirrorKey = "repo-mirror"
+
+	// MirrorFallbackKey is the configuration field name for what the
+	// resolution does when the repo can't be cloned from its mirror,
+	// "upstream" to clone it from its url param or "fail".
+	MirrorFallbackKey = "mirror-fallback"
+
+	// CredentialPluginKey is the configuration field name for the name of the
+	// credential plugin, registered with RegisterTokenProvider, providing the
+	// tokens authenticating to the repos and to the SCM API. Its tokens take
+	// precedence over the token secrets.
+	CredentialPluginKey = "credential-plugin"
+
+	// CredentialPluginHostsKey is the configuration field name for the
+	// comma-separated hosts, besides the ones of server-url and default-url,
+	// which the tokens of the credential plugin are sent to.
+	CredentialPluginHostsKey = "credential-plugin-hosts"
+
+	// CloneAPIPrecheckKey is the configuration field name for checking with
+	// the SCM API of the config that the path exists at the revision before
+	// cloning a repo of the same server, "true" or "false". The file is
+	// fetched with the SCM API instead of cloning the repo when it can be.
+	CloneAPIPrecheckKey = "clone-api-precheck"
 )
 
 type GitResolverConfig map[string]ScmConfig
 
 type ScmConfig struct {
-	Timeout            string `json:"fetch-timeout"`
-	URL                string `json:"default-url"`
-	Revision           string `json:"default-revision"`
-	Org                string `json:"default-org"`
-	ServerURL          string `json:"server-url"`
-	SCMType            string `json:"scm-type"`
-	GitToken           string `json:"git-token"`
-	APISecretName      string `json:"api-token-secret-name"`
-	APISecretKey       string `json:"api-token-secret-key"`
-	APISecretNamespace string `json:"api-token-secret-namespace"`
+	Timeout                         string `json:"fetch-timeout"`
+	URL                             string `json:"default-url"`
+	Revision                        string `json:"default-revision"`
+	Org                             string `json:"default-org"`
+	Repo                            string `json:"default-repo"`
+	ServerURL                       string `json:"server-url"`
+	SCMType                         string `json:"scm-type"`
+	GitToken                        string `json:"git-token"`
+	APISecretName                   string `json:"api-token-secret-name"`
+	APISecretKey                    string `json:"api-token-secret-key"`
+	APISecretNamespace              string `json:"api-token-secret-namespace"`
+	APIAppID                        string `json:"api-app-id"`
+	APIAppInstallationID            string `json:"api-app-installation-id"`
+	APIAppPrivateKeySecretName      string `json:"api-app-private-key-secret-name"`
+	APIAppPrivateKeySecretKey       string `json:"api-app-private-key-secret-key"`
+	APIAppPrivateKeySecretNamespace string `json:"api-app-private-key-secret-namespace"`
+	MaxFileSize                     string `json:"max-file-size"`
+	MaxFileSizeBytes                string `json:"max-file-size-bytes"`
+	SparseCheckoutDirectories       string `json:"default-sparse-checkout-directories"`
+	CacheTTL                        string `json:"cache-ttl"`
+	CacheMaxEntries                 string `json:"cache-max-entries"`
+	APIFallbackToClone              string `json:"api-fallback-to-clone"`
+	APIFetchStrategy                string `json:"api-fetch-strategy"`
+	AllowedURLPatterns              string `json:"allowed-url-patterns"`
+	GitTokenScheme                  string `json:"git-token-scheme"`
+	CloneTimeout                    string `json:"clone-timeout"`
+	CredentialPlugin                string `json:"credential-plugin"`
+	CredentialPluginHosts           string `json:"credential-plugin-hosts"`
+	MirrorFallback                  string `json:"mirror-fallback"`
+	CloneAPIPrecheck                string `json:"clone-api-precheck"`
+	// URLRewrites are the URL rewrites of the config, by their name.
+	URLRewrites map[string]URLRewrite `json:"-"`
+	// RepoMirrors are the repo mirrors of the config, by their name.
+	RepoMirrors map[string]RepoMirror `json:"-"`
 }
 
 func GetGitResolverConfig(ctx context.Context) (GitResolverConfig, error) {
@@ -74,6 +222,22 @@ func GetGitResolverConfig(ctx context.Context) (GitResolverConfig, error) {
 	gitResolverConfig := map[string]ScmConfig{}
 	conf := framework.GetResolverConfigFromContext(ctx)
 	for key, value := range conf {
+		if configIdentifier, name, field, ok := splitURLRewriteKey(key); ok {
+			c := gitResolverConfig[configIdentifier]
+			if err := c.setURLRewriteField(key, name, field, value); err != nil {
+				return nil, err
+			}
+			gitResolverConfig[configIdentifier] = c
+			continue
+		}
+		if configIdentifier, name, field, ok := splitRepoMirrorKey(key); ok {
+			c := gitResolverConfig[configIdentifier]
+			if err := c.setRepoMirrorField(key, name, field, value); err != nil {
+				return nil, err
+			}
+			gitResolverConfig[configIdentifier] = c
+			continue
+		}
 		var configIdentifier, configKey string
 		splittedKeyName := strings.Split(key, ".")
 		switch len(splittedKeyName) {
@@ -94,7 +258,7 @@ func GetGitResolverConfig(ctx context.Context) (GitResolverConfig, error) {
 			field := structType.Field(i)
 			fieldName := field.Name
 			jsonTag := field.Tag.Get("json")
-			if configKey == jsonTag {
+			if configKey == jsonTag && field.Type.Kind() == reflect.String {
 				tokenDetails := gitResolverConfig[configIdentifier]
 				var scm interface{} = &tokenDetails
 				structValue := reflect.ValueOf(scm).Elem()
@@ -105,3 +269,138 @@ func GetGitResolverConfig(ctx context.Context) (GitResolverConfig, error) {
 	}
 	return gitResolverConfig, nil
 }
+
+// GetMaxFileSize returns the maximum size in bytes of the files which can be
+// resolved with the config: max-file-size-bytes if it's set, else the
+// deprecated max-file-size.
+func (c ScmConfig) GetMaxFileSize() (int64, error) {
+	if c.MaxFileSizeBytes != "" {
+		n, err := strconv.ParseInt(c.MaxFileSizeBytes, 10, 64)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid %s %q in git resolver config, must be a positive number of bytes", MaxFileSizeBytesKey, c.MaxFileSizeBytes)
+		}
+		return n, nil
+	}
+	if c.MaxFileSize == "" {
+		return DefaultMaxFileSize, nil
+	}
+	q, err := resource.ParseQuantity(c.MaxFileSize)
+	if err != nil || q.Sign() <= 0 {
+		return 0, fmt.Errorf("invalid %s %q in git resolver config, must be a positive quantity like \"1Mi\"", MaxFileSizeKey, c.MaxFileSize)
+	}
+	return q.Value(), nil
+}
+
+// GetCacheTTL returns the time to live of the clone cache entries with the
+// config, 0 if the clone cache is disabled.
+func (c ScmConfig) GetCacheTTL() (time.Duration, error) {
+	if c.CacheTTL == "" {
+		return DefaultCacheTTL, nil
+	}
+	d, err := time.ParseDuration(c.CacheTTL)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid %s %q in git resolver config, must be a duration like \"5m\"", CacheTTLKey, c.CacheTTL)
+	}
+	return d, nil
+}
+
+// GetCacheMaxEntries returns the maximum number of entries of the clone cache
+// with the config, 0 if the clone cache is disabled.
+func (c ScmConfig) GetCacheMaxEntries() (int, error) {
+	if c.CacheMaxEntries == "" {
+		return DefaultCacheMaxEntries, nil
+	}
+	n, err := strconv.Atoi(c.CacheMaxEntries)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid %s %q in git resolver config, must be a non-negative integer", CacheMaxEntriesKey, c.CacheMaxEntries)
+	}
+	return n, nil
+}
+
+// GetCloneTimeout returns the maximum duration of each operation fetching
+// from the remote of a cloned repo with the config, 0 if it's unbounded.
+func (c ScmConfig) GetCloneTimeout() (time.Duration, error) {
+	if c.CloneTimeout == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(c.CloneTimeout)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid %s %q in git resolver config, must be a positive duration like \"2m\"", CloneTimeoutKey, c.CloneTimeout)
+	}
+	return d, nil
+}
+
+// GetAPIFallbackToClone returns whether the files are fetched from an
+// anonymous clone of the repo when the SCM API rejects the requests because
+// of their credentials or of a rate limit.
+func (c ScmConfig) GetAPIFallbackToClone() (bool, error) {
+	if c.APIFallbackToClone == "" {
+		return false, nil
+	}
+	fallback, err := strconv.ParseBool(c.APIFallbackToClone)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s %q in git resolver config, must be \"true\" or \"false\"", APIFallbackToCloneKey, c.APIFallbackToClone)
+	}
+	return fallback, nil
+}
+
+// GetCloneAPIPrecheck returns whether the path is checked with the SCM API
+// before cloning a repo of the server of the config.
+func (c ScmConfig) GetCloneAPIPrecheck() (bool, error) {
+	if c.CloneAPIPrecheck == "" {
+		return false, nil
+	}
+	precheck, err := strconv.ParseBool(c.CloneAPIPrecheck)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s %q in git resolver config, must be \"true\" or \"false\"", CloneAPIPrecheckKey, c.CloneAPIPrecheck)
+	}
+	return precheck, nil
+}
+
+// GetAPIFetchStrategy returns how the files are fetched in API mode with the
+// config, with the contents API by default.
+func (c ScmConfig) GetAPIFetchStrategy() (string, error) {
+	switch c.APIFetchStrategy {
+	case "":
+		return apiFetchStrategyContents, nil
+	case apiFetchStrategyContents, apiFetchStrategyArchive:
+		return c.APIFetchStrategy, nil
+	default:
+		return "", fmt.Errorf("invalid %s %q in git resolver config, must be \"%s\" or \"%s\"", APIFetchStrategyKey, c.APIFetchStrategy, apiFetchStrategyContents, apiFetchStrategyArchive)
+	}
+}
+
+// GetGitTokenScheme returns how the gitToken is sent when cloning a repo with
+// the config, "basic" by default.
+func (c ScmConfig) GetGitTokenScheme() (string, error) {
+	if c.GitTokenScheme == "" {
+		return gitTokenSchemeBasic, nil
+	}
+	if err := validateGitTokenScheme(c.GitTokenScheme); err != nil {
+		return "", fmt.Errorf("invalid %s %q in git resolver config: %w", GitTokenSchemeKey, c.GitTokenScheme, err)
+	}
+	return c.GitTokenScheme, nil
+}
+
+// validateGitTokenScheme returns an error if the scheme isn't a known scheme
+// of the gitToken.
+func validateGitTokenScheme(scheme string) error {
+	if scheme != gitTokenSchemeBasic && scheme != gitTokenSchemeBearer {
+		return fmt.Errorf("must be \"%s\" or \"%s\"", gitTokenSchemeBasic, gitTokenSchemeBearer)
+	}
+	return nil
+}
+
+// splitResourceSuggestion is appended to the errors of the files larger than
+// the max file size.
+const splitResourceSuggestion = "split the resource into smaller files, e.g. move the Tasks of a Pipeline to their own files and reference them"
+
+// fileTooLargeError returns the error of a file whose size exceeds the max
+// file size.
+func fileTooLargeError(path string, size, maxSize int64) error {
+	return &common.GetResourceError{
+		ResolverName: gitResolverName,
+		Key:          path,
+		Original:     fmt.Errorf("resolved file %s is %d bytes which exceeds the configured limit of %d bytes: %s", path, size, maxSize, splitResourceSuggestion),
+	}
+}
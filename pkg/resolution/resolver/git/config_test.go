@@ -18,6 +18,7 @@ package git
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	resolutionframework "github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
@@ -116,6 +117,36 @@ func TestGetGitResolverConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "config with url rewrites",
+			config: map[string]string{
+				URLRewriteKey + ".github.from":           "https://github.com/",
+				URLRewriteKey + ".github.to":             "https://mirror.example.com/github/",
+				"test." + URLRewriteKey + ".gitlab.from": "https://gitlab.com/",
+				"test." + URLRewriteKey + ".gitlab.to":   "https://mirror.example.com/gitlab/",
+			},
+			expectedConfig: GitResolverConfig{
+				"default": ScmConfig{
+					URLRewrites: map[string]URLRewrite{
+						"github": {From: "https://github.com/", To: "https://mirror.example.com/github/"},
+					},
+				},
+				"test": ScmConfig{
+					URLRewrites: map[string]URLRewrite{
+						"gitlab": {From: "https://gitlab.com/", To: "https://mirror.example.com/gitlab/"},
+					},
+				},
+			},
+		},
+		{
+			name: "config with invalid url rewrite field",
+			config: map[string]string{
+				URLRewriteKey + ".github.prefix": "https://github.com/",
+			},
+			wantErr:        true,
+			expectedErr:    `key url-rewrite.github.prefix passed in git resolver configmap is invalid, the field of a url-rewrite must be "from" or "to"`,
+			expectedConfig: nil,
+		},
 		{
 			name: "config with invalid format",
 			config: map[string]string{
@@ -144,3 +175,25 @@ func TestGetGitResolverConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestGetCloneTimeout(t *testing.T) {
+	for _, tc := range []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{value: "", want: 0},
+		{value: "30s", want: 30 * time.Second},
+		{value: "0s", wantErr: true},
+		{value: "-1m", wantErr: true},
+		{value: "soon", wantErr: true},
+	} {
+		got, err := ScmConfig{CloneTimeout: tc.value}.GetCloneTimeout()
+		if (err != nil) != tc.wantErr {
+			t.Errorf("GetCloneTimeout(%q) returned error %v, wanted error: %t", tc.value, err, tc.wantErr)
+		}
+		if got != tc.want {
+			t.Errorf("GetCloneTimeout(%q) = %s, want %s", tc.value, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+// contentResponseOverhead is the room left in the responses of the contents
+// API for the metadata of the file served along with its content.
+const contentResponseOverhead = 64 * 1024
+
+// contentLimitKey is the key of the context value bounding the size of the
+// response of a request to the contents API.
+type contentLimitKey struct{}
+
+// contentLimit bounds the size of the response serving the content of the
+// file at path.
+type contentLimit struct {
+	path        string
+	maxFileSize int64
+}
+
+// contentTooLargeError is the error of a file served by the contents API in a
+// response too large for the file to be under the max file size, whose exact
+// size isn't known since the response isn't read further.
+type contentTooLargeError struct {
+	path    string
+	maxSize int64
+}
+
+func (e *contentTooLargeError) Error() string {
+	return fmt.Sprintf("resolved file %s exceeds the configured limit of %d bytes: %s", e.path, e.maxSize, splitResourceSuggestion)
+}
+
+// withContentLimit returns a context for fetching the content of the file at
+// path with the contents API, which fails reading the response once it's too
+// large for the file to be under maxFileSize. This bounds the memory used
+// when the size of the file can't be checked from the metadata of its
+// directory beforehand.
+func withContentLimit(ctx context.Context, path string, maxFileSize int64) context.Context {
+	return context.WithValue(ctx, contentLimitKey{}, contentLimit{path: path, maxFileSize: maxFileSize})
+}
+
+// limitContentResponses makes the SCM client read the responses of the
+// requests made with withContentLimit through a size limit.
+func limitContentResponses(scmClient *scm.Client) {
+	client := http.DefaultClient
+	if scmClient.Client != nil {
+		client = scmClient.Client
+	}
+	limited := *client
+	limited.Transport = &contentLimitTransport{base: client.Transport}
+	scmClient.Client = &limited
+}
+
+// contentLimitTransport bounds the size of the responses of the requests
+// made with withContentLimit.
+type contentLimitTransport struct {
+	base http.RoundTripper
+}
+
+func (t *contentLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	res, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if limit, ok := req.Context().Value(contentLimitKey{}).(contentLimit); ok {
+		// The content is base64 encoded in the JSON responses of most SCMs,
+		// with a line break every 60 characters for GitHub.
+		res.Body = &limitedBody{
+			ReadCloser: res.Body,
+			remaining:  limit.maxFileSize*3/2 + contentResponseOverhead,
+			limit:      limit,
+		}
+	}
+	return res, nil
+}
+
+// limitedBody fails reading a response body larger than the remaining bytes.
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+	limit     contentLimit
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining < 0 {
+		return 0, &contentTooLargeError{path: b.limit.path, maxSize: b.limit.maxFileSize}
+	}
+	// One more byte than the remaining ones is read to know whether the
+	// body is over the limit.
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+	n, err := b.ReadCloser.Read(p)
+	b.remaining -= int64(n)
+	if b.remaining < 0 {
+		return 0, &contentTooLargeError{path: b.limit.path, maxSize: b.limit.maxFileSize}
+	}
+	return n, err
+}
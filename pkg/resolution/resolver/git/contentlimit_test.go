@@ -0,0 +1,164 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/github"
+	"github.com/jenkins-x/go-scm/scm/factory"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/cache"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+// servingContents returns a server of the GitHub contents API serving the
+// files, which fails to list the directories so that the size of the files
+// can't be checked beforehand.
+func servingContents(t *testing.T, files map[string]string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p, ok := strings.CutPrefix(r.URL.Path, "/repos/org/repo/contents/")
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		content, ok := files[p]
+		if !ok {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"name":    p,
+			"path":    p,
+			"content": base64.StdEncoding.EncodeToString([]byte(content)),
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestResolveAPIGitLimitsTheContentRead(t *testing.T) {
+	server := servingContents(t, map[string]string{"large.yaml": strings.Repeat("a", 512*1024)})
+	clientFunc := func(_, _, _ string, _ ...factory.ClientOptionFunc) (*scm.Client, error) {
+		return github.New(server.URL)
+	}
+	g := &GitResolver{
+		Params: map[string]string{
+			OrgParam:      "org",
+			RepoParam:     "repo",
+			PathParam:     "large.yaml",
+			RevisionParam: "main",
+		},
+		Logger: zap.NewNop().Sugar(),
+		Cache:  cache.NewLRUExpireCache(cacheSize),
+		TTL:    ttl,
+		KubeClient: kubefake.NewSimpleClientset(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "token-secret", Namespace: "tekton-pipelines"},
+			Data:       map[string][]byte{"token": []byte("github-token")},
+		}),
+	}
+	ctx := framework.InjectResolverConfigToContext(t.Context(), map[string]string{
+		SCMTypeKey:            "github",
+		ServerURLKey:          server.URL,
+		MaxFileSizeBytesKey:   "1024",
+		APISecretNameKey:      "token-secret",
+		APISecretKeyKey:       "token",
+		APISecretNamespaceKey: "tekton-pipelines",
+	})
+	_, err := g.ResolveAPIGit(ctx, clientFunc)
+	want := "resolved file large.yaml exceeds the configured limit of 1024 bytes: " + splitResourceSuggestion
+	if err == nil || err.Error() != want {
+		t.Fatalf("expected the error %q, got %v", want, err)
+	}
+}
+
+func TestLimitContentResponses(t *testing.T) {
+	server := servingContents(t, map[string]string{
+		"small.yaml": "kind: Task\n",
+		"large.yaml": strings.Repeat("a", 512*1024),
+	})
+	scmClient, err := github.New(server.URL)
+	if err != nil {
+		t.Fatalf("github.New: %v", err)
+	}
+	limitContentResponses(scmClient)
+
+	if _, _, err := scmClient.Contents.Find(withContentLimit(t.Context(), "small.yaml", 1024), "org/repo", "small.yaml", "main"); err != nil {
+		t.Errorf("unexpected error fetching a file under the limit: %v", err)
+	}
+	if _, _, err := scmClient.Contents.Find(t.Context(), "org/repo", "large.yaml", "main"); err != nil {
+		t.Errorf("unexpected error fetching a file without limit: %v", err)
+	}
+	_, _, err = scmClient.Contents.Find(withContentLimit(t.Context(), "large.yaml", 1024), "org/repo", "large.yaml", "main")
+	var tooLarge *contentTooLargeError
+	if !errors.As(err, &tooLarge) {
+		t.Errorf("expected a contentTooLargeError fetching a file over the limit, got %v", err)
+	}
+}
+
+// countingReader counts the bytes read from it.
+type countingReader struct {
+	io.Reader
+	read int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.read += int64(n)
+	return n, err
+}
+
+func TestLimitedBody(t *testing.T) {
+	limit := contentLimit{path: "large.yaml", maxFileSize: 1024}
+	remaining := limit.maxFileSize*3/2 + contentResponseOverhead
+	for _, tc := range []struct {
+		name    string
+		size    int64
+		wantErr bool
+	}{{
+		name: "at the limit",
+		size: remaining,
+	}, {
+		name:    "over the limit",
+		size:    10 * remaining,
+		wantErr: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &countingReader{Reader: strings.NewReader(strings.Repeat("a", int(tc.size)))}
+			body := &limitedBody{ReadCloser: io.NopCloser(r), remaining: remaining, limit: limit}
+			_, err := io.ReadAll(body)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected an error: %t, got %v", tc.wantErr, err)
+			}
+			if r.read > remaining+1 {
+				t.Errorf("expected no more than %d bytes to be read, got %d", remaining+1, r.read)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+// yamlDocumentSeparator separates the manifests of the files of a resolved
+// directory in the multi-document YAML stream returned for it.
+const yamlDocumentSeparator = "---\n"
+
+// isDirectoryPath returns whether the pathInRepo param designates a
+// directory, by ending with a slash.
+func isDirectoryPath(p string) bool {
+	return strings.HasSuffix(p, "/")
+}
+
+// isManifestFile returns whether the file with the given name is one of the
+// YAML manifests of a resolved directory.
+func isManifestFile(name string) bool {
+	ext := path.Ext(name)
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// manifestFile is a YAML file of a resolved directory.
+type manifestFile struct {
+	// path is the path of the file in the repo.
+	path    string
+	content []byte
+}
+
+// joinManifests returns the multi-document YAML stream of the given files of
+// the directory dir, sorted by filename. It fails if there are no files or if
+// the stream is larger than maxSize.
+func joinManifests(dir string, files []manifestFile, maxSize int64) ([]byte, error) {
+	if len(files) == 0 {
+		return nil, fmt.Errorf("directory %q doesn't contain any .yaml or .yml file", dir)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return path.Base(files[i].path) < path.Base(files[j].path)
+	})
+	var buf bytes.Buffer
+	for i, f := range files {
+		if i > 0 {
+			buf.WriteString(yamlDocumentSeparator)
+		}
+		buf.Write(f.content)
+		if len(f.content) > 0 && f.content[len(f.content)-1] != '\n' {
+			buf.WriteByte('\n')
+		}
+	}
+	if size := int64(buf.Len()); size > maxSize {
+		return nil, fileTooLargeError(dir, size, maxSize)
+	}
+	return buf.Bytes(), nil
+}
+
+// exportedManifests returns the given files of a resolved directory without
+// the ones marked export-ignore in the .gitattributes files of the resolved
+// tree, unless the ignoreExportIgnore param is set.
+func (g *GitResolver) exportedManifests(files []manifestFile, readFile readFileFunc) ([]manifestFile, error) {
+	if g.Params[IgnoreExportIgnoreParam] == "true" || len(files) == 0 {
+		return files, nil
+	}
+	// The files of the directory share the same .gitattributes files.
+	attrs, err := loadGitAttributes(files[0].path, readFile)
+	if err != nil {
+		return nil, err
+	}
+	var exported []manifestFile
+	for _, f := range files {
+		if !attrs.exportIgnored(f.path) {
+			exported = append(exported, f)
+		}
+	}
+	return exported, nil
+}
+
+// listManifestsFunc returns the YAML files of the directory at the given path
+// of the resolved tree. It fails if one of them is larger than maxSize.
+type listManifestsFunc func(dir string, maxSize int64) ([]manifestFile, error)
+
+// resolveDirectory returns the multi-document YAML stream of the YAML files
+// of the directory at the given path of the resolved tree.
+func (g *GitResolver) resolveDirectory(dir string, maxSize int64, listManifests listManifestsFunc, readFile readFileFunc) ([]byte, error) {
+	if err := g.checkExportIgnore(dir, readFile); err != nil {
+		return nil, err
+	}
+	files, err := listManifests(dir, maxSize)
+	if err != nil {
+		return nil, fmt.Errorf("error reading directory %q: %w", dir, err)
+	}
+	files, err = g.exportedManifests(files, readFile)
+	if err != nil {
+		return nil, err
+	}
+	return joinManifests(dir, files, maxSize)
+}
+
+// listAPIManifests returns the YAML files of the directory at the given path
+// of a repo with the SCM API.
+func listAPIManifests(ctx context.Context, scmClient *scm.Client, orgRepo, dir, ref string, maxSize int64) ([]manifestFile, error) {
+	entries, res, err := scmClient.Contents.List(ctx, orgRepo, dir, ref, &scm.ListOptions{})
+	if err != nil {
+		return nil, newSCMStatusError(res, err)
+	}
+	var files []manifestFile
+	for _, e := range entries {
+		if (e.Type != "file" && e.Type != apiEntryTypeSymlink) || !isManifestFile(e.Name) {
+			continue
+		}
+		p := path.Join(dir, e.Name)
+		if e.Type == apiEntryTypeSymlink {
+			return nil, apiSymlinkError(p)
+		}
+		if size := int64(e.Size); size > maxSize {
+			return nil, fileTooLargeError(p, size, maxSize)
+		}
+		content, res, err := scmClient.Contents.Find(ctx, orgRepo, p, ref)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't fetch the content of %q: %w", p, newSCMStatusError(res, err))
+		}
+		if size := int64(len(content.Data)); size > maxSize {
+			return nil, fileTooLargeError(p, size, maxSize)
+		}
+		files = append(files, manifestFile{path: p, content: content.Data})
+	}
+	return files, nil
+}
+
+// apiFileEntry returns the entry of the file at the given path of a repo with
+// the SCM API, by listing its directory, so that its size and its type can be
+// checked before fetching the file. It returns nil if the entry is unknown.
+func apiFileEntry(ctx context.Context, scmClient *scm.Client, orgRepo, p, ref string) *scm.FileEntry {
+	dir := path.Dir(p)
+	if dir == "." {
+		dir = ""
+	}
+	entries, _, err := scmClient.Contents.List(ctx, orgRepo, dir, ref, &scm.ListOptions{})
+	if err != nil {
+		return nil
+	}
+	for _, e := range entries {
+		if (e.Type == "file" || e.Type == apiEntryTypeSymlink) && e.Name == path.Base(p) {
+			return e
+		}
+	}
+	return nil
+}
+
+// apiSymlinkError returns the error of a symlink resolved with the SCM API,
+// which serves the path of its target rather than the content of the file.
+func apiSymlinkError(p string) error {
+	return fmt.Errorf("path %q is a symlink, which can't be resolved with the SCM API: resolve its target instead, or clone the repo with the url param", p)
+}
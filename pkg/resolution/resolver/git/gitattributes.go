@@ -0,0 +1,185 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+const (
+	// gitAttributesFile is the name of the files defining the attributes
+	// of the paths of a directory and of its subdirectories.
+	gitAttributesFile = ".gitattributes"
+	// exportIgnoreAttribute is the attribute marking the paths which
+	// `git archive` leaves out of the archives of a tree.
+	exportIgnoreAttribute = "export-ignore"
+)
+
+// attributeState is the state of an attribute for a path, see
+// https://git-scm.com/docs/gitattributes#_description
+type attributeState int
+
+const (
+	attributeUnspecified attributeState = iota
+	attributeSet
+	attributeUnset
+)
+
+// attributeRule is a line of a .gitattributes file which sets the state of
+// the export-ignore attribute for the paths matching its pattern.
+type attributeRule struct {
+	pattern string
+	state   attributeState
+}
+
+// gitAttributes holds the export-ignore rules of the .gitattributes files of
+// a tree, by the directory they are in, "" being the root of the tree.
+type gitAttributes map[string][]attributeRule
+
+// readFileFunc returns the content of the file at the given path of the
+// resolved tree, or nil if there is no such file.
+type readFileFunc func(path string) ([]byte, error)
+
+// loadGitAttributes reads the .gitattributes files which apply to the given
+// path, i.e. the ones in the root of the tree and in each of its parent
+// directories.
+func loadGitAttributes(p string, readFile readFileFunc) (gitAttributes, error) {
+	attrs := gitAttributes{}
+	for _, dir := range parentDirs(p) {
+		content, err := readFile(path.Join(dir, gitAttributesFile))
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %w", path.Join(dir, gitAttributesFile), err)
+		}
+		if content != nil {
+			attrs[dir] = parseGitAttributes(content)
+		}
+	}
+	return attrs, nil
+}
+
+// parseGitAttributes returns the rules of a .gitattributes file which
+// concern the export-ignore attribute.
+func parseGitAttributes(content []byte) []attributeRule {
+	var rules []attributeRule
+	for _, line := range strings.Split(string(content), "\n") {
+		fields := strings.Fields(line)
+		// Negative patterns are forbidden in .gitattributes files and
+		// ignored by git.
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") || strings.HasPrefix(fields[0], "!") {
+			continue
+		}
+		for _, attr := range fields[1:] {
+			switch {
+			case attr == exportIgnoreAttribute || strings.HasPrefix(attr, exportIgnoreAttribute+"="):
+				rules = append(rules, attributeRule{pattern: fields[0], state: attributeSet})
+			case attr == "-"+exportIgnoreAttribute:
+				rules = append(rules, attributeRule{pattern: fields[0], state: attributeUnset})
+			case attr == "!"+exportIgnoreAttribute:
+				rules = append(rules, attributeRule{pattern: fields[0], state: attributeUnspecified})
+			}
+		}
+	}
+	return rules
+}
+
+// exportIgnored returns true if the given path, or one of its parent
+// directories, is marked export-ignore, i.e. it would be left out of the
+// archive of the tree created by `git archive`.
+func (attrs gitAttributes) exportIgnored(p string) bool {
+	p = cleanRepoPath(p)
+	for _, dir := range parentDirs(p)[1:] {
+		if attrs.state(dir) == attributeSet {
+			return true
+		}
+	}
+	return p != "" && attrs.state(p) == attributeSet
+}
+
+// state returns the state of the export-ignore attribute for the given path.
+// Rules of deeper .gitattributes files take precedence over the ones of
+// their parent directories, and later rules over earlier ones.
+func (attrs gitAttributes) state(p string) attributeState {
+	state := attributeUnspecified
+	for _, dir := range parentDirs(p) {
+		rel := strings.TrimPrefix(strings.TrimPrefix(p, dir), "/")
+		for _, rule := range attrs[dir] {
+			if matchAttributePattern(rule.pattern, rel) {
+				state = rule.state
+			}
+		}
+	}
+	return state
+}
+
+// matchAttributePattern returns true if the pattern of a .gitattributes file
+// matches the given path, relative to the directory of the file. Patterns
+// without a slash match the name of a path at any depth, the other ones
+// match the whole relative path. Patterns with a trailing slash never match,
+// like in git.
+func matchAttributePattern(pattern, rel string) bool {
+	if strings.HasSuffix(pattern, "/") {
+		return false
+	}
+	if !strings.Contains(pattern, "/") {
+		ok, _ := path.Match(pattern, path.Base(rel))
+		return ok
+	}
+	return matchSegments(strings.Split(strings.TrimPrefix(pattern, "/"), "/"), strings.Split(rel, "/"))
+}
+
+// matchSegments matches the segments of a path against the segments of a
+// pattern, where a "**" segment matches any number of segments.
+func matchSegments(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchSegments(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segments) == 0 {
+		return false
+	}
+	if ok, _ := path.Match(pattern[0], segments[0]); !ok {
+		return false
+	}
+	return matchSegments(pattern[1:], segments[1:])
+}
+
+// parentDirs returns the directories containing the given path, from the
+// root of the tree, "", to its direct parent.
+func parentDirs(p string) []string {
+	dirs := []string{""}
+	p = cleanRepoPath(p)
+	for i, c := range p {
+		if c == '/' {
+			dirs = append(dirs, p[:i])
+		}
+	}
+	return dirs
+}
+
+// cleanRepoPath returns the given path relative to the root of the tree.
+func cleanRepoPath(p string) string {
+	return strings.TrimPrefix(path.Clean("/"+p), "/")
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestExportIgnored(t *testing.T) {
+	files := map[string]string{
+		".gitattributes": `# generated files aren't part of the catalog
+/generated export-ignore
+*.tmpl.yaml export-ignore
+docs/** export-ignore
+fixtures/ export-ignore
+!negated.yaml export-ignore
+`,
+		"tasks/.gitattributes": `internal-* export-ignore
+kept.tmpl.yaml -export-ignore
+`,
+		"tasks/build/.gitattributes": `internal-* !export-ignore
+/local.yaml export-ignore
+`,
+	}
+	readFile := func(p string) ([]byte, error) {
+		if content, ok := files[p]; ok {
+			return []byte(content), nil
+		}
+		return nil, nil
+	}
+
+	for _, tc := range []struct {
+		path string
+		want bool
+	}{
+		{path: "tasks/task.yaml", want: false},
+		{path: "generated/task.yaml", want: true},
+		{path: "./generated/nested/task.yaml", want: true},
+		{path: "tasks/generated/task.yaml", want: false},
+		{path: "task.tmpl.yaml", want: true},
+		{path: "tasks/nested/task.tmpl.yaml", want: true},
+		{path: "docs/task.yaml", want: true},
+		{path: "fixtures/task.yaml", want: false},
+		{path: "negated.yaml", want: false},
+		{path: "tasks/internal-task.yaml", want: true},
+		{path: "tasks/nested/internal-task.yaml", want: true},
+		{path: "tasks/kept.tmpl.yaml", want: false},
+		{path: "tasks/build/internal-task.yaml", want: false},
+		{path: "tasks/build/local.yaml", want: true},
+		{path: "tasks/build/nested/local.yaml", want: false},
+	} {
+		t.Run(tc.path, func(t *testing.T) {
+			attrs, err := loadGitAttributes(tc.path, readFile)
+			if err != nil {
+				t.Fatalf("unexpected error loading .gitattributes: %v", err)
+			}
+			if got := attrs.exportIgnored(tc.path); got != tc.want {
+				t.Errorf("expected exportIgnored(%q) to be %t but got %t", tc.path, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestLoadGitAttributes_Error(t *testing.T) {
+	_, err := loadGitAttributes("tasks/task.yaml", func(string) ([]byte, error) {
+		return nil, errors.New("boom")
+	})
+	if err == nil || err.Error() != "error reading .gitattributes: boom" {
+		t.Errorf("expected error reading .gitattributes but got %v", err)
+	}
+}
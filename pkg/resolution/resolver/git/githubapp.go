@@ -0,0 +1,226 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jenkins-x/go-scm/scm"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// githubAPIURL is the URL of the API of github.com.
+	githubAPIURL = "https://api.github.com"
+
+	// appJWTLifetime is the lifetime of the JWTs authenticating as the
+	// GitHub App, GitHub rejects JWTs expiring in more than 10 minutes.
+	appJWTLifetime = 9 * time.Minute
+
+	// installationTokenExpiryMargin is how long before its expiry an
+	// installation token stops being reused, so that it doesn't expire while
+	// a resolution is in progress.
+	installationTokenExpiryMargin = 5 * time.Minute
+)
+
+// ErrGitHubAppNotConfigured is returned when the GitHub App authentication is
+// enabled with api-app-id but the rest of the app credentials are missing.
+var ErrGitHubAppNotConfigured = errors.New("GitHub App credentials not configured")
+
+// InstallationTokenFunc mints an installation access token for the GitHub
+// App with the given ID and private key, returning the token and its expiry.
+type InstallationTokenFunc func(ctx context.Context, apiURL, appID, installationID string, privateKey []byte) (string, time.Time, error)
+
+// installationTokenCacheKey is the key of the installation tokens in the
+// resolver cache. The tokens of the same app minted with the credentials of
+// different configs aren't shared.
+type installationTokenCacheKey struct {
+	configKey      string
+	namespace      string
+	apiURL         string
+	appID          string
+	installationID string
+}
+
+// usesGitHubApp returns true if the SCM API is authenticated as a GitHub App
+// with the config.
+func (c ScmConfig) usesGitHubApp() bool {
+	return c.APIAppID != ""
+}
+
+// getInstallationToken returns an installation access token of the GitHub
+// App of the config, minting one with mintToken if there's none cached
+// which isn't about to expire. The token is only ever requested from, and
+// used with, the server-url of the config: the serverURL param can't
+// override it, so that the app credentials aren't sent to another server.
+func (g *GitResolver) getInstallationToken(ctx context.Context, conf ScmConfig, scmType string, mintToken InstallationTokenFunc) (string, error) {
+	if scmType != "" && scmType != "github" {
+		return "", fmt.Errorf("cannot get GitHub App installation token, '%s' is only supported with the github '%s', not %q", APIAppIDKey, SCMTypeKey, scmType)
+	}
+	if serverURL := g.Params[ServerURLParam]; serverURL != "" && serverURL != conf.ServerURL {
+		err := fmt.Errorf("cannot get GitHub App installation token, the %s param can't override the '%s' of the config with '%s'", ServerURLParam, ServerURLKey, APIAppIDKey)
+		g.Logger.Info(err)
+		return "", err
+	}
+	if conf.APIAppInstallationID == "" {
+		return "", g.appNotConfiguredError(APIAppInstallationIDKey)
+	}
+	if conf.APIAppPrivateKeySecretName == "" {
+		return "", g.appNotConfiguredError(APIAppPrivateKeySecretNameKey)
+	}
+	if conf.APIAppPrivateKeySecretKey == "" {
+		return "", g.appNotConfiguredError(APIAppPrivateKeySecretKeyKey)
+	}
+
+	configKey := g.Params[ConfigKeyParam]
+	if configKey == "" {
+		configKey = "default"
+	}
+	cacheKey := installationTokenCacheKey{
+		configKey:      configKey,
+		namespace:      conf.appPrivateKeySecretNamespace(),
+		apiURL:         githubAPIURLFor(conf.ServerURL),
+		appID:          conf.APIAppID,
+		installationID: conf.APIAppInstallationID,
+	}
+	if val, ok := g.Cache.Get(cacheKey); ok {
+		return val.(string), nil
+	}
+
+	privateKey, err := g.getAppPrivateKey(ctx, conf)
+	if err != nil {
+		return "", err
+	}
+	token, expiresAt, err := mintToken(ctx, cacheKey.apiURL, cacheKey.appID, cacheKey.installationID, privateKey)
+	if err != nil {
+		wrappedErr := fmt.Errorf("GitHub App installation token request failed for app %s installation %s: %w", cacheKey.appID, cacheKey.installationID, err)
+		g.Logger.Info(wrappedErr)
+		return "", wrappedErr
+	}
+	if ttl := time.Until(expiresAt) - installationTokenExpiryMargin; ttl > 0 {
+		g.Cache.Add(cacheKey, token, ttl)
+	}
+	return token, nil
+}
+
+func (g *GitResolver) appNotConfiguredError(key string) error {
+	err := fmt.Errorf("cannot get GitHub App installation token, %w: '%s' is set but '%s' not specified in config", ErrGitHubAppNotConfigured, APIAppIDKey, key)
+	g.Logger.Info(err)
+	return err
+}
+
+// appPrivateKeySecretNamespace returns the namespace of the secret of the
+// private key of the GitHub App, the one of the resolvers by default.
+func (c ScmConfig) appPrivateKeySecretNamespace() string {
+	if c.APIAppPrivateKeySecretNamespace != "" {
+		return c.APIAppPrivateKeySecretNamespace
+	}
+	return os.Getenv("SYSTEM_NAMESPACE")
+}
+
+// getAppPrivateKey reads the private key of the GitHub App from its secret.
+func (g *GitResolver) getAppPrivateKey(ctx context.Context, conf ScmConfig) ([]byte, error) {
+	ns := conf.appPrivateKeySecretNamespace()
+	secret, err := g.KubeClient.CoreV1().Secrets(ns).Get(ctx, conf.APIAppPrivateKeySecretName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			notFoundErr := fmt.Errorf("cannot get GitHub App private key, secret %s not found in namespace %s", conf.APIAppPrivateKeySecretName, ns)
+			g.Logger.Info(notFoundErr)
+			return nil, notFoundErr
+		}
+		wrappedErr := fmt.Errorf("error reading GitHub App private key from secret %s in namespace %s: %w", conf.APIAppPrivateKeySecretName, ns, err)
+		g.Logger.Info(wrappedErr)
+		return nil, wrappedErr
+	}
+	privateKey, ok := secret.Data[conf.APIAppPrivateKeySecretKey]
+	if !ok {
+		err := fmt.Errorf("cannot get GitHub App private key, key %s not found in secret %s in namespace %s", conf.APIAppPrivateKeySecretKey, conf.APIAppPrivateKeySecretName, ns)
+		g.Logger.Info(err)
+		return nil, err
+	}
+	return privateKey, nil
+}
+
+// githubAPIURLFor returns the URL of the GitHub API of the server URL, the
+// same way the go-scm github driver does.
+func githubAPIURLFor(serverURL string) string {
+	if serverURL == "" || strings.HasPrefix(serverURL, "https://github.com") || strings.HasPrefix(serverURL, "http://github.com") {
+		return githubAPIURL
+	}
+	if !strings.Contains(serverURL, "/api/") {
+		return scm.URLJoin(serverURL, "/api/v3")
+	}
+	return serverURL
+}
+
+// MintInstallationToken is the InstallationTokenFunc requesting an
+// installation access token from the GitHub API, authenticated with a JWT
+// signed with the private key of the GitHub App.
+func MintInstallationToken(ctx context.Context, apiURL, appID, installationID string, privateKey []byte) (string, time.Time, error) {
+	key, err := jwt.ParseRSAPrivateKeyFromPEM(privateKey)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("invalid private key: %w", err)
+	}
+	now := time.Now()
+	appJWT, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.RegisteredClaims{
+		Issuer: appID,
+		// Allow for the clock drift between the resolver and GitHub.
+		IssuedAt:  jwt.NewNumericDate(now.Add(-time.Minute)),
+		ExpiresAt: jwt.NewNumericDate(now.Add(appJWTLifetime)),
+	}).SignedString(key)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("couldn't sign the GitHub App JWT: %w", err)
+	}
+
+	url := scm.URLJoin(apiURL, "app", "installations", installationID, "access_tokens")
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+appJWT)
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	if res.StatusCode != http.StatusCreated {
+		return "", time.Time{}, fmt.Errorf("%s: %s", res.Status, strings.TrimSpace(string(body)))
+	}
+	var installationToken struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	if err := json.Unmarshal(body, &installationToken); err != nil {
+		return "", time.Time{}, fmt.Errorf("couldn't parse the installation token: %w", err)
+	}
+	return installationToken.Token, installationToken.ExpiresAt, nil
+}
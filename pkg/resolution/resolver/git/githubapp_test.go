@@ -0,0 +1,326 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/go-cmp/cmp"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/fake"
+	"github.com/jenkins-x/go-scm/scm/factory"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"github.com/tektoncd/pipeline/test/diff"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/cache"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func githubAppConfig() map[string]string {
+	return map[string]string{
+		SCMTypeKey:                         "github",
+		APIAppIDKey:                        "1234",
+		APIAppInstallationIDKey:            "5678",
+		APIAppPrivateKeySecretNameKey:      "github-app",
+		APIAppPrivateKeySecretKeyKey:       "private-key",
+		APIAppPrivateKeySecretNamespaceKey: "tekton-pipelines",
+	}
+}
+
+func TestResolveAPIGitWithGitHubApp(t *testing.T) {
+	privateKeySecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "github-app", Namespace: "tekton-pipelines"},
+		Data:       map[string][]byte{"private-key": []byte("app-private-key")},
+	}
+	g := &GitResolver{
+		Params: map[string]string{
+			OrgParam:      "test-org",
+			RepoParam:     "test-repo",
+			PathParam:     "pipelines/example-pipeline.yaml",
+			RevisionParam: "main",
+		},
+		Logger:     zap.NewNop().Sugar(),
+		Cache:      cache.NewLRUExpireCache(cacheSize),
+		TTL:        ttl,
+		KubeClient: kubefake.NewSimpleClientset(privateKeySecret),
+	}
+	mintCount := 0
+	g.InstallationTokenFunc = func(_ context.Context, apiURL, appID, installationID string, privateKey []byte) (string, time.Time, error) {
+		mintCount++
+		if apiURL != githubAPIURL || appID != "1234" || installationID != "5678" || string(privateKey) != "app-private-key" {
+			t.Errorf("unexpected installation token request for %s app %s installation %s with private key %q", apiURL, appID, installationID, privateKey)
+		}
+		return "installation-token", time.Now().Add(time.Hour), nil
+	}
+	var tokens []string
+	clientFunc := func(_, _, token string, _ ...factory.ClientOptionFunc) (*scm.Client, error) {
+		tokens = append(tokens, token)
+		scmClient, scmData := fake.NewDefault()
+		scmData.Repositories = []*scm.Repository{{FullName: "test-org/test-repo", Clone: "https://fake/test-org/test-repo.git"}}
+		scmData.Commits = map[string]*scm.Commit{"main": {Sha: "abc"}}
+		return scmClient, nil
+	}
+
+	ctx := framework.InjectResolverConfigToContext(t.Context(), githubAppConfig())
+	for range 2 {
+		if _, err := g.ResolveAPIGit(ctx, clientFunc); err != nil {
+			t.Fatalf("unexpected error resolving with the GitHub App: %v", err)
+		}
+	}
+	if mintCount != 1 {
+		t.Errorf("expected the installation token to be minted once and then reused, but it was minted %d times", mintCount)
+	}
+	if d := strings.Join(tokens, ","); d != "installation-token,installation-token" {
+		t.Errorf("expected the SCM clients to be created with the installation token, got %s", d)
+	}
+}
+
+func TestGetInstallationToken(t *testing.T) {
+	privateKeySecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "github-app", Namespace: "tekton-pipelines"},
+		Data:       map[string][]byte{"private-key": []byte("app-private-key")},
+	}
+	for _, tc := range []struct {
+		name          string
+		config        map[string]string
+		scmType       string
+		serverURL     string
+		mintErr       error
+		wantAPIURL    string
+		wantErr       string
+		notConfigured bool
+	}{{
+		name:       "github.com",
+		config:     githubAppConfig(),
+		wantAPIURL: "https://api.github.com",
+	}, {
+		name: "GitHub Enterprise",
+		config: func() map[string]string {
+			c := githubAppConfig()
+			c[ServerURLKey] = "https://github.example.com"
+			return c
+		}(),
+		wantAPIURL: "https://github.example.com/api/v3",
+	}, {
+		name: "serverURL param of the config",
+		config: func() map[string]string {
+			c := githubAppConfig()
+			c[ServerURLKey] = "https://github.example.com"
+			return c
+		}(),
+		serverURL:  "https://github.example.com",
+		wantAPIURL: "https://github.example.com/api/v3",
+	}, {
+		name:      "serverURL param overriding the config",
+		config:    githubAppConfig(),
+		serverURL: "https://attacker.example.com",
+		wantErr:   "cannot get GitHub App installation token, the serverURL param can't override the 'server-url' of the config with 'api-app-id'",
+	}, {
+		name: "installation ID not specified",
+		config: func() map[string]string {
+			c := githubAppConfig()
+			delete(c, APIAppInstallationIDKey)
+			return c
+		}(),
+		wantErr:       "cannot get GitHub App installation token, GitHub App credentials not configured: 'api-app-id' is set but 'api-app-installation-id' not specified in config",
+		notConfigured: true,
+	}, {
+		name: "private key secret not specified",
+		config: func() map[string]string {
+			c := githubAppConfig()
+			delete(c, APIAppPrivateKeySecretNameKey)
+			return c
+		}(),
+		wantErr:       "cannot get GitHub App installation token, GitHub App credentials not configured: 'api-app-id' is set but 'api-app-private-key-secret-name' not specified in config",
+		notConfigured: true,
+	}, {
+		name: "private key secret not found",
+		config: func() map[string]string {
+			c := githubAppConfig()
+			c[APIAppPrivateKeySecretNameKey] = "missing"
+			return c
+		}(),
+		wantErr: "cannot get GitHub App private key, secret missing not found in namespace tekton-pipelines",
+	}, {
+		name:    "not github",
+		config:  githubAppConfig(),
+		scmType: "gitlab",
+		wantErr: `cannot get GitHub App installation token, 'api-app-id' is only supported with the github 'scm-type', not "gitlab"`,
+	}, {
+		name:       "installation token request failed",
+		config:     githubAppConfig(),
+		mintErr:    errors.New("401 Unauthorized"),
+		wantAPIURL: "https://api.github.com",
+		wantErr:    "GitHub App installation token request failed for app 1234 installation 5678: 401 Unauthorized",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := &GitResolver{
+				Params:     map[string]string{ServerURLParam: tc.serverURL},
+				Logger:     zap.NewNop().Sugar(),
+				Cache:      cache.NewLRUExpireCache(cacheSize),
+				KubeClient: kubefake.NewSimpleClientset(privateKeySecret),
+			}
+			mintToken := func(_ context.Context, apiURL, _, _ string, _ []byte) (string, time.Time, error) {
+				if apiURL != tc.wantAPIURL {
+					t.Errorf("expected the installation token to be requested from %s, got %s", tc.wantAPIURL, apiURL)
+				}
+				return "installation-token", time.Now().Add(time.Hour), tc.mintErr
+			}
+			ctx := framework.InjectResolverConfigToContext(t.Context(), tc.config)
+			conf, err := GetScmConfigForParamConfigKey(ctx, nil)
+			if err != nil {
+				t.Fatalf("unexpected error getting the config: %v", err)
+			}
+			token, err := g.getInstallationToken(ctx, conf, tc.scmType, mintToken)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("expected error %q, got %v", tc.wantErr, err)
+				}
+				if errors.Is(err, ErrGitHubAppNotConfigured) != tc.notConfigured {
+					t.Errorf("expected the error to be ErrGitHubAppNotConfigured: %t, got %v", tc.notConfigured, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if token != "installation-token" {
+				t.Errorf("expected the installation token, got %q", token)
+			}
+		})
+	}
+}
+
+func TestGetInstallationTokenNearExpiry(t *testing.T) {
+	g := &GitResolver{
+		Logger: zap.NewNop().Sugar(),
+		Cache:  cache.NewLRUExpireCache(cacheSize),
+		KubeClient: kubefake.NewSimpleClientset(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "github-app", Namespace: "tekton-pipelines"},
+			Data:       map[string][]byte{"private-key": []byte("app-private-key")},
+		}),
+	}
+	mintCount := 0
+	mintToken := func(context.Context, string, string, string, []byte) (string, time.Time, error) {
+		mintCount++
+		return "installation-token", time.Now().Add(installationTokenExpiryMargin), nil
+	}
+	ctx := framework.InjectResolverConfigToContext(t.Context(), githubAppConfig())
+	conf, err := GetScmConfigForParamConfigKey(ctx, nil)
+	if err != nil {
+		t.Fatalf("unexpected error getting the config: %v", err)
+	}
+	for range 2 {
+		if _, err := g.getInstallationToken(ctx, conf, "github", mintToken); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if mintCount != 2 {
+		t.Errorf("expected a token about to expire not to be reused, but it was minted %d times", mintCount)
+	}
+}
+
+func TestMintInstallationToken(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("couldn't generate the private key: %v", err)
+	}
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	expiresAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/app/installations/5678/access_tokens" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		claims := &jwt.RegisteredClaims{}
+		if _, err := jwt.ParseWithClaims(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "), claims, func(*jwt.Token) (any, error) {
+			return &key.PublicKey, nil
+		}, jwt.WithValidMethods([]string{"RS256"})); err != nil {
+			t.Errorf("invalid GitHub App JWT: %v", err)
+		}
+		if claims.Issuer != "1234" {
+			t.Errorf("expected the JWT to be issued by the app 1234, got %q", claims.Issuer)
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"token": "installation-token", "expires_at": "2030-01-01T00:00:00Z"}`))
+	}))
+	defer server.Close()
+
+	token, gotExpiresAt, err := MintInstallationToken(t.Context(), server.URL, "1234", "5678", privateKey)
+	if err != nil {
+		t.Fatalf("unexpected error minting the installation token: %v", err)
+	}
+	if token != "installation-token" || !gotExpiresAt.Equal(expiresAt) {
+		t.Errorf("expected the installation token expiring at %s, got %q expiring at %s", expiresAt, token, gotExpiresAt)
+	}
+
+	if _, _, err := MintInstallationToken(t.Context(), server.URL, "1234", "5678", []byte("not a key")); err == nil {
+		t.Error("expected an error minting the installation token with an invalid private key")
+	}
+}
+
+func TestGetInstallationTokenPerConfig(t *testing.T) {
+	g := &GitResolver{
+		Logger: zap.NewNop().Sugar(),
+		Cache:  cache.NewLRUExpireCache(cacheSize),
+		KubeClient: kubefake.NewSimpleClientset(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "github-app", Namespace: "tekton-pipelines"},
+			Data:       map[string][]byte{"private-key": []byte("app-private-key")},
+		}),
+	}
+	mintCount := 0
+	mintToken := func(context.Context, string, string, string, []byte) (string, time.Time, error) {
+		mintCount++
+		return fmt.Sprintf("installation-token-%d", mintCount), time.Now().Add(time.Hour), nil
+	}
+	config := map[string]string{}
+	for k, v := range githubAppConfig() {
+		config[k] = v
+		config["other."+k] = v
+	}
+	ctx := framework.InjectResolverConfigToContext(t.Context(), config)
+	var tokens []string
+	for _, configKey := range []string{"default", "other", "default"} {
+		g.Params = map[string]string{ConfigKeyParam: configKey}
+		conf, err := GetScmConfigForParamConfigKey(ctx, g.Params)
+		if err != nil {
+			t.Fatalf("unexpected error getting the config: %v", err)
+		}
+		token, err := g.getInstallationToken(ctx, conf, "github", mintToken)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		tokens = append(tokens, token)
+	}
+	if d := cmp.Diff([]string{"installation-token-1", "installation-token-2", "installation-token-1"}, tokens); d != "" {
+		t.Errorf("expected the tokens not to be shared between the configs %s", diff.PrintWantGot(d))
+	}
+}
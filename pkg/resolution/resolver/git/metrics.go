@@ -0,0 +1,168 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"knative.dev/pkg/metrics"
+)
+
+const (
+	// resolutionOutcomeSuccess is the outcome of the resolutions which
+	// fetched the file.
+	resolutionOutcomeSuccess = "success"
+	// resolutionOutcomeNotFound is the outcome of the resolutions of a file
+	// which doesn't exist in the repo.
+	resolutionOutcomeNotFound = "not-found"
+	// resolutionOutcomeAuthFailure is the outcome of the resolutions rejected
+	// because of their credentials.
+	resolutionOutcomeAuthFailure = "auth-failure"
+	// resolutionOutcomeError is the outcome of the resolutions which failed
+	// for any other reason.
+	resolutionOutcomeError = "error"
+)
+
+var (
+	modeTag    = tag.MustNewKey("mode")
+	outcomeTag = tag.MustNewKey("outcome")
+
+	resolutionDuration = stats.Float64("git_resolver_resolution_duration_seconds",
+		"The time the git resolver took to resolve a file in seconds",
+		stats.UnitSeconds)
+
+	cacheHits = stats.Int64("git_resolver_cache_hits_total",
+		"Number of resolutions from a cloned repo served from the clone cache",
+		stats.UnitDimensionless)
+
+	cacheMisses = stats.Int64("git_resolver_cache_misses_total",
+		"Number of resolutions from a cloned repo which cloned the repo",
+		stats.UnitDimensionless)
+
+	authFailures = stats.Int64("git_resolver_auth_failures_total",
+		"Number of resolutions rejected because of their credentials",
+		stats.UnitDimensionless)
+
+	resolutionDurationView = &view.View{
+		Description: resolutionDuration.Description(),
+		Measure:     resolutionDuration,
+		Aggregation: view.Distribution(0.1, 0.5, 1, 2, 5, 10, 30, 60, 120, 300),
+		TagKeys:     []tag.Key{modeTag, outcomeTag},
+	}
+	cacheHitsView = &view.View{
+		Description: cacheHits.Description(),
+		Measure:     cacheHits,
+		Aggregation: view.Count(),
+	}
+	cacheMissesView = &view.View{
+		Description: cacheMisses.Description(),
+		Measure:     cacheMisses,
+		Aggregation: view.Count(),
+	}
+	authFailuresView = &view.View{
+		Description: authFailures.Description(),
+		Measure:     authFailures,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{modeTag},
+	}
+
+	// We cannot register the views multiple times, so RegisterMetrics
+	// registers them once for all the git resolvers.
+	registerOnce   sync.Once
+	errRegistering error
+)
+
+// RegisterMetrics registers the views of the metrics of the git resolver. It
+// can be called by every git resolver, the views are only registered once.
+func RegisterMetrics() error {
+	registerOnce.Do(func() {
+		errRegistering = view.Register(
+			resolutionDurationView,
+			cacheHitsView,
+			cacheMissesView,
+			authFailuresView,
+		)
+	})
+	return errRegistering
+}
+
+// ObserveResolution returns the resource resolved by resolve for the params,
+// recording the duration of the resolution by mode and outcome, and the
+// resolutions rejected because of their credentials.
+func ObserveResolution(ctx context.Context, params map[string]string, resolve func() (framework.ResolvedResource, error)) (framework.ResolvedResource, error) {
+	mode := ResolutionModeAPI
+	if params[UrlParam] != "" {
+		mode = ResolutionModeClone
+	}
+	start := time.Now()
+	res, err := resolve()
+	outcome := resolutionOutcome(err)
+
+	tagCtx, tagErr := tag.New(ctx, tag.Insert(modeTag, mode), tag.Insert(outcomeTag, outcome))
+	if tagErr != nil {
+		return res, err
+	}
+	metrics.Record(tagCtx, resolutionDuration.M(time.Since(start).Seconds()))
+	if outcome == resolutionOutcomeAuthFailure {
+		metrics.Record(tagCtx, authFailures.M(1))
+	}
+	return res, err
+}
+
+// recordCacheLookup records whether a resolution from a cloned repo was
+// served from the clone cache or cloned the repo.
+func recordCacheLookup(ctx context.Context, hit bool) {
+	if hit {
+		metrics.Record(ctx, cacheHits.M(1))
+	} else {
+		metrics.Record(ctx, cacheMisses.M(1))
+	}
+}
+
+// resolutionOutcome returns the outcome of a resolution which failed with
+// err, or succeeded if err is nil.
+func resolutionOutcome(err error) string {
+	if err == nil {
+		return resolutionOutcomeSuccess
+	}
+	if errors.Is(err, errFileNotExist) || errors.Is(err, errDirectoryNotExist) || errors.Is(err, scm.ErrNotFound) {
+		return resolutionOutcomeNotFound
+	}
+	if errors.Is(err, errCloneAuthRequired) || strings.Contains(err.Error(), "Authentication failed") {
+		return resolutionOutcomeAuthFailure
+	}
+	var statusErr *scmStatusError
+	if errors.As(err, &statusErr) {
+		switch statusErr.status {
+		case http.StatusNotFound:
+			return resolutionOutcomeNotFound
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return resolutionOutcomeAuthFailure
+		}
+	}
+	return resolutionOutcomeError
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"go.uber.org/zap"
+	"knative.dev/pkg/metrics/metricstest"
+	_ "knative.dev/pkg/metrics/testing"
+)
+
+func TestResolveRecordsResolutionDuration(t *testing.T) {
+	unregisterMetrics()
+	if err := RegisterMetrics(); err != nil {
+		t.Fatalf("RegisterMetrics: %v", err)
+	}
+	repoURL, _ := createTestRepo(t, []commitForRepo{{
+		Dir:      "tasks/",
+		Filename: "task.yaml",
+		Content:  "some task",
+	}})
+	ctx := framework.InjectResolverConfigToContext(t.Context(), map[string]string{})
+	r := &Resolver{logger: zap.NewNop().Sugar()}
+
+	if _, err := r.Resolve(ctx, toParams(map[string]string{
+		UrlParam:      repoURL,
+		RevisionParam: "main",
+		PathParam:     "tasks/task.yaml",
+	})); err != nil {
+		t.Fatalf("unexpected error resolving the file: %v", err)
+	}
+	metricstest.CheckDistributionCount(t, "git_resolver_resolution_duration_seconds",
+		map[string]string{"mode": ResolutionModeClone, "outcome": resolutionOutcomeSuccess}, 1)
+
+	unregisterMetrics()
+	if err := RegisterMetrics(); err != nil {
+		t.Fatalf("RegisterMetrics: %v", err)
+	}
+	if _, err := r.Resolve(ctx, toParams(map[string]string{
+		UrlParam:      repoURL,
+		RevisionParam: "main",
+		PathParam:     "tasks/missing.yaml",
+	})); err == nil {
+		t.Fatal("expected an error resolving a file which doesn't exist")
+	}
+	metricstest.CheckDistributionCount(t, "git_resolver_resolution_duration_seconds",
+		map[string]string{"mode": ResolutionModeClone, "outcome": resolutionOutcomeNotFound}, 1)
+	metricstest.CheckStatsNotReported(t, "git_resolver_auth_failures_total")
+}
+
+func TestResolutionOutcome(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		err  error
+		want string
+	}{{
+		name: "success",
+		want: resolutionOutcomeSuccess,
+	}, {
+		name: "missing file",
+		err:  fmt.Errorf("error opening file %q: %w", "task.yaml", errFileNotExist),
+		want: resolutionOutcomeNotFound,
+	}, {
+		name: "missing directory",
+		err:  errDirectoryNotExist,
+		want: resolutionOutcomeNotFound,
+	}, {
+		name: "missing file from the api",
+		err:  fmt.Errorf("couldn't fetch resource content: %w", &scmStatusError{status: http.StatusNotFound, err: scm.ErrNotFound}),
+		want: resolutionOutcomeNotFound,
+	}, {
+		name: "clone without credentials",
+		err:  fmt.Errorf("error resolving repository: %w", errCloneAuthRequired),
+		want: resolutionOutcomeAuthFailure,
+	}, {
+		name: "clone with invalid credentials",
+		err:  errors.New("git clone error: remote: Invalid username or password. fatal: Authentication failed for 'https://github.com/tektoncd/catalog.git/'"),
+		want: resolutionOutcomeAuthFailure,
+	}, {
+		name: "api token rejected",
+		err:  &scmStatusError{status: http.StatusUnauthorized, err: errors.New("bad credentials")},
+		want: resolutionOutcomeAuthFailure,
+	}, {
+		name: "api rate limit",
+		err:  &scmStatusError{status: http.StatusTooManyRequests, err: errors.New("rate limit exceeded")},
+		want: resolutionOutcomeError,
+	}, {
+		name: "other error",
+		err:  errors.New("default Git Revision was not set during installation of the git resolver"),
+		want: resolutionOutcomeError,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := resolutionOutcome(tc.err); got != tc.want {
+				t.Errorf("expected the outcome %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func unregisterMetrics() {
+	metricstest.Unregister("git_resolver_resolution_duration_seconds", "git_resolver_cache_hits_total", "git_resolver_cache_misses_total", "git_resolver_auth_failures_total")
+
+	// Allow the views to be registered again.
+	registerOnce = sync.Once{}
+	errRegistering = nil
+}
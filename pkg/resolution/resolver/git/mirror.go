@@ -0,0 +1,128 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	repoMirrorUpstreamField = "upstream"
+	repoMirrorMirrorField   = "mirror"
+
+	// mirrorFallbackUpstream clones the repo from its upstream URL when it
+	// can't be cloned from its mirror.
+	mirrorFallbackUpstream = "upstream"
+	// mirrorFallbackFail fails the resolution when the repo can't be cloned
+	// from its mirror.
+	mirrorFallbackFail = "fail"
+)
+
+// RepoMirror clones the repos whose URL starts with Upstream from the mirror
+// whose URL starts with Mirror instead, falling back to the upstream URL
+// according to the mirror-fallback of the config.
+type RepoMirror struct {
+	Upstream string
+	Mirror   string
+}
+
+// unreachableRemoteError is the error of the clones of a remote which can't be
+// reached or doesn't have the repo, e.g. a mirror which didn't mirror it.
+type unreachableRemoteError struct {
+	err error
+}
+
+func (e *unreachableRemoteError) Error() string {
+	return e.err.Error()
+}
+
+func (e *unreachableRemoteError) Unwrap() error {
+	return e.err
+}
+
+// isUnreachableRemoteError returns whether the error is the error of a clone
+// of a remote which can't be reached or doesn't have the repo.
+func isUnreachableRemoteError(err error) bool {
+	var unreachable *unreachableRemoteError
+	return errors.As(err, &unreachable)
+}
+
+// splitRepoMirrorKey splits a key of the config of the form
+// "[<configKey>.]repo-mirror.<n>.<field>", and returns false if the key isn't
+// the key of a repo mirror.
+func splitRepoMirrorKey(key string) (configIdentifier, name, field string, ok bool) {
+	return splitNamedKey(key, RepoMirrorKey)
+}
+
+// setRepoMirrorField sets the field of the repo mirror with the given name
+// from the value of its key in the config.
+func (c *ScmConfig) setRepoMirrorField(key, name, field, value string) error {
+	if c.RepoMirrors == nil {
+		c.RepoMirrors = map[string]RepoMirror{}
+	}
+	mirror := c.RepoMirrors[name]
+	switch field {
+	case repoMirrorUpstreamField:
+		mirror.Upstream = value
+	case repoMirrorMirrorField:
+		mirror.Mirror = value
+	default:
+		return fmt.Errorf("key %s passed in git resolver configmap is invalid, the field of a %s must be %q or %q", key, RepoMirrorKey, repoMirrorUpstreamField, repoMirrorMirrorField)
+	}
+	c.RepoMirrors[name] = mirror
+	return nil
+}
+
+// mirrorURL returns the URL of the mirror the repo with the given URL is
+// cloned from, with the repo mirror of the config with the longest matching
+// upstream prefix, and false if none matches.
+func (c ScmConfig) mirrorURL(repoURL string) (string, bool, error) {
+	var longest *RepoMirror
+	upstreams := map[string]string{}
+	for name, mirror := range c.RepoMirrors {
+		if mirror.Upstream == "" || mirror.Mirror == "" {
+			return "", false, fmt.Errorf("%s %q of the git resolver config must set both %q and %q", RepoMirrorKey, name, repoMirrorUpstreamField, repoMirrorMirrorField)
+		}
+		if other, ok := upstreams[mirror.Upstream]; ok {
+			return "", false, fmt.Errorf("%s %q and %q of the git resolver config mirror the same prefix %q", RepoMirrorKey, min(name, other), max(name, other), mirror.Upstream)
+		}
+		upstreams[mirror.Upstream] = name
+		if strings.HasPrefix(repoURL, mirror.Upstream) && (longest == nil || len(mirror.Upstream) > len(longest.Upstream)) {
+			longest = &mirror
+		}
+	}
+	if longest == nil {
+		return "", false, nil
+	}
+	return longest.Mirror + strings.TrimPrefix(repoURL, longest.Upstream), true, nil
+}
+
+// GetMirrorFallback returns what the resolution does when the repo can't be
+// cloned from its mirror with the config, clone it from its upstream URL by
+// default.
+func (c ScmConfig) GetMirrorFallback() (string, error) {
+	switch c.MirrorFallback {
+	case "":
+		return mirrorFallbackUpstream, nil
+	case mirrorFallbackUpstream, mirrorFallbackFail:
+		return c.MirrorFallback, nil
+	default:
+		return "", fmt.Errorf("invalid %s %q in git resolver config, must be \"%s\" or \"%s\"", MirrorFallbackKey, c.MirrorFallback, mirrorFallbackUpstream, mirrorFallbackFail)
+	}
+}
@@ -0,0 +1,305 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/resolution/common"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"github.com/tektoncd/pipeline/test/diff"
+	"go.uber.org/zap"
+)
+
+func TestGetGitResolverConfig_RepoMirrors(t *testing.T) {
+	ctx := framework.InjectResolverConfigToContext(t.Context(), map[string]string{
+		RepoMirrorKey + ".github.upstream":           "https://github.com/",
+		RepoMirrorKey + ".github.mirror":             "https://gitea.example.com/github/",
+		"internal." + RepoMirrorKey + ".gh.upstream": "https://github.com/tektoncd/",
+		"internal." + RepoMirrorKey + ".gh.mirror":   "https://gitea.internal/tektoncd/",
+		"internal." + MirrorFallbackKey:              "fail",
+	})
+	conf, err := GetGitResolverConfig(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error getting the config: %v", err)
+	}
+	want := map[string]map[string]RepoMirror{
+		"default":  {"github": {Upstream: "https://github.com/", Mirror: "https://gitea.example.com/github/"}},
+		"internal": {"gh": {Upstream: "https://github.com/tektoncd/", Mirror: "https://gitea.internal/tektoncd/"}},
+	}
+	got := map[string]map[string]RepoMirror{}
+	for key, c := range conf {
+		got[key] = c.RepoMirrors
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("unexpected repo mirrors: %s", diff.PrintWantGot(d))
+	}
+	if got := conf["internal"].MirrorFallback; got != "fail" {
+		t.Errorf("expected the %s of the internal config to be fail, got %q", MirrorFallbackKey, got)
+	}
+
+	ctx = framework.InjectResolverConfigToContext(t.Context(), map[string]string{
+		RepoMirrorKey + ".github.url": "https://gitea.example.com/github/",
+	})
+	wantErr := `key repo-mirror.github.url passed in git resolver configmap is invalid, the field of a repo-mirror must be "upstream" or "mirror"`
+	if _, err := GetGitResolverConfig(ctx); err == nil || err.Error() != wantErr {
+		t.Errorf("expected error %q, got %v", wantErr, err)
+	}
+}
+
+func TestMirrorURL(t *testing.T) {
+	conf := ScmConfig{RepoMirrors: map[string]RepoMirror{
+		"github": {Upstream: "https://github.com/", Mirror: "https://gitea.example.com/github/"},
+		"tekton": {Upstream: "https://github.com/tektoncd/", Mirror: "https://gitea.example.com/tekton/"},
+	}}
+	for _, tc := range []struct {
+		name         string
+		url          string
+		want         string
+		wantMirrored bool
+	}{{
+		name:         "prefix",
+		url:          "https://github.com/org/repo.git",
+		want:         "https://gitea.example.com/github/org/repo.git",
+		wantMirrored: true,
+	}, {
+		name:         "longest prefix wins",
+		url:          "https://github.com/tektoncd/catalog",
+		want:         "https://gitea.example.com/tekton/catalog",
+		wantMirrored: true,
+	}, {
+		name: "no match",
+		url:  "https://gitlab.com/org/repo.git",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, mirrored, err := conf.mirrorURL(tc.url)
+			if err != nil {
+				t.Fatalf("unexpected error mirroring the url: %v", err)
+			}
+			if got != tc.want || mirrored != tc.wantMirrored {
+				t.Errorf("expected %s to be mirrored to %q (%t), got %q (%t)", tc.url, tc.want, tc.wantMirrored, got, mirrored)
+			}
+		})
+	}
+}
+
+func TestMirrorURL_Invalid(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		mirrors map[string]RepoMirror
+		wantErr string
+	}{{
+		name:    "missing mirror",
+		mirrors: map[string]RepoMirror{"github": {Upstream: "https://github.com/"}},
+		wantErr: `repo-mirror "github" of the git resolver config must set both "upstream" and "mirror"`,
+	}, {
+		name: "same prefix",
+		mirrors: map[string]RepoMirror{
+			"a": {Upstream: "https://github.com/", Mirror: "https://a.example.com/"},
+			"b": {Upstream: "https://github.com/", Mirror: "https://b.example.com/"},
+		},
+		wantErr: `repo-mirror "a" and "b" of the git resolver config mirror the same prefix "https://github.com/"`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := ScmConfig{RepoMirrors: tc.mirrors}.mirrorURL("https://gitlab.com/org/repo.git")
+			if err == nil || err.Error() != tc.wantErr {
+				t.Errorf("expected error %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestGetMirrorFallback(t *testing.T) {
+	for _, tc := range []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{value: "", want: mirrorFallbackUpstream},
+		{value: "upstream", want: mirrorFallbackUpstream},
+		{value: "fail", want: mirrorFallbackFail},
+		{value: "retry", wantErr: true},
+	} {
+		got, err := ScmConfig{MirrorFallback: tc.value}.GetMirrorFallback()
+		if (err != nil) != tc.wantErr {
+			t.Errorf("unexpected error for %q: %v", tc.value, err)
+		}
+		if got != tc.want {
+			t.Errorf("expected %q for %q, got %q", tc.want, tc.value, got)
+		}
+	}
+}
+
+func TestResolveGitCloneRepoMirror(t *testing.T) {
+	repoPath, commits := createTestRepo(t, []commitForRepo{{
+		Dir:      "tasks/",
+		Filename: "task.yaml",
+		Content:  "mirrored task",
+	}})
+	repoDir := filepath.Dir(repoPath) + "/"
+	upstreamURL := "https://github.com/tektoncd/" + filepath.Base(repoPath)
+	// The mirror of the local repo doesn't have it.
+	emptyMirrorDir := t.TempDir() + "/"
+
+	for _, tc := range []struct {
+		name         string
+		url          string
+		config       map[string]string
+		wantFetched  []string
+		wantFetchURL string
+		wantErr      string
+	}{{
+		name: "mirror hit",
+		url:  upstreamURL,
+		config: map[string]string{
+			RepoMirrorKey + ".github.upstream": "https://github.com/tektoncd/",
+			RepoMirrorKey + ".github.mirror":   repoDir,
+		},
+		wantFetched:  []string{repoPath},
+		wantFetchURL: repoPath,
+	}, {
+		name: "mirror miss falls back to the upstream",
+		url:  repoPath,
+		config: map[string]string{
+			RepoMirrorKey + ".local.upstream": repoDir,
+			RepoMirrorKey + ".local.mirror":   emptyMirrorDir,
+		},
+		wantFetched:  []string{emptyMirrorDir + filepath.Base(repoPath), repoPath},
+		wantFetchURL: repoPath,
+	}, {
+		name: "mirror miss falls back to the rewritten upstream",
+		url:  upstreamURL,
+		config: map[string]string{
+			RepoMirrorKey + ".github.upstream": "https://github.com/tektoncd/",
+			RepoMirrorKey + ".github.mirror":   emptyMirrorDir,
+			URLRewriteKey + ".github.from":     "https://github.com/tektoncd/",
+			URLRewriteKey + ".github.to":       repoDir,
+			MirrorFallbackKey:                  "upstream",
+		},
+		wantFetched:  []string{emptyMirrorDir + filepath.Base(repoPath), repoPath},
+		wantFetchURL: repoPath,
+	}, {
+		name: "mirror miss fails",
+		url:  repoPath,
+		config: map[string]string{
+			RepoMirrorKey + ".local.upstream": repoDir,
+			RepoMirrorKey + ".local.mirror":   emptyMirrorDir,
+			MirrorFallbackKey:                 "fail",
+		},
+		wantFetched: []string{emptyMirrorDir + filepath.Base(repoPath)},
+		wantErr:     "couldn't clone the repository from its mirror " + emptyMirrorDir + filepath.Base(repoPath) + ` and the mirror-fallback of the git resolver config is "fail"`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := framework.InjectResolverConfigToContext(t.Context(), tc.config)
+			var fetched []string
+			g := &GitResolver{
+				Params: map[string]string{
+					UrlParam:      tc.url,
+					RevisionParam: "main",
+					PathParam:     "tasks/task.yaml",
+				},
+				Logger: zap.NewNop().Sugar(),
+				cloneFunc: func(ctx context.Context, rem remote) (*repository, func(), error) {
+					fetched = append(fetched, rem.url)
+					if strings.HasPrefix(rem.url, "https://") {
+						// Don't reach out to GitHub.
+						return nil, func() {}, errors.New("not mirrored")
+					}
+					return rem.clone(ctx)
+				},
+			}
+			res, err := g.ResolveGitClone(ctx)
+			if d := cmp.Diff(tc.wantFetched, fetched); d != "" {
+				t.Errorf("unexpected URLs fetched: %s", diff.PrintWantGot(d))
+			}
+			if tc.wantErr != "" {
+				if err == nil || !strings.HasPrefix(err.Error(), tc.wantErr) {
+					t.Fatalf("expected error starting with %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error resolving the file: %v", err)
+			}
+			if got := string(res.Data()); got != "mirrored task" {
+				t.Errorf("expected the content of the file, got %q", got)
+			}
+			annotations := res.Annotations()
+			if got := annotations[AnnotationKeyURL]; got != tc.url {
+				t.Errorf("expected the %s annotation to be the url param %s, got %s", AnnotationKeyURL, tc.url, got)
+			}
+			if got := annotations[AnnotationKeyFetchURL]; got != tc.wantFetchURL {
+				t.Errorf("expected the %s annotation to be %s, got %s", AnnotationKeyFetchURL, tc.wantFetchURL, got)
+			}
+			if got := res.RefSource().URI; got != "git+"+tc.url+"@main" {
+				t.Errorf("expected the source to be the url param, got %s", got)
+			}
+			if got := res.RefSource().Digest["sha1"]; got != commits[0] {
+				t.Errorf("expected the source digest %s, got %s", commits[0], got)
+			}
+		})
+	}
+}
+
+func TestResolveGitCloneRepoMirrorCredentials(t *testing.T) {
+	repoPath, _ := createTestRepo(t, []commitForRepo{{
+		Dir:      "tasks/",
+		Filename: "task.yaml",
+		Content:  "mirrored task",
+	}})
+	// The mirror of the local repo doesn't have it.
+	emptyMirrorDir := t.TempDir() + "/"
+	config := map[string]string{
+		RepoMirrorKey + ".local.upstream": filepath.Dir(repoPath) + "/",
+		RepoMirrorKey + ".local.mirror":   emptyMirrorDir,
+	}
+	var cloned []remote
+	g := &GitResolver{
+		Params: map[string]string{
+			UrlParam:            repoPath,
+			RevisionParam:       "main",
+			PathParam:           "tasks/task.yaml",
+			GitTokenParam:       "token-secret",
+			GitTokenKeyParam:    "token",
+			GitTokenSchemeParam: gitTokenSchemeBearer,
+		},
+		Logger:     zap.NewNop().Sugar(),
+		KubeClient: tokenSecrets(),
+		cloneFunc: func(ctx context.Context, rem remote) (*repository, func(), error) {
+			cloned = append(cloned, rem)
+			return rem.clone(ctx)
+		},
+	}
+	ctx := common.InjectRequestNamespace(framework.InjectResolverConfigToContext(t.Context(), config), "foo")
+	if _, err := g.ResolveGitClone(ctx); err != nil {
+		t.Fatalf("unexpected error resolving the file: %v", err)
+	}
+	if len(cloned) != 2 {
+		t.Fatalf("expected the repo to be cloned from the mirror, then from the upstream, got %d clones", len(cloned))
+	}
+	if mirror := cloned[0]; mirror.username != "" || mirror.password != "" || mirror.tokenScheme != "" {
+		t.Errorf("expected the repo to be cloned anonymously from the mirror, got %q with %q and scheme %q", mirror.username, mirror.password, mirror.tokenScheme)
+	}
+	if upstream := cloned[1]; upstream.password != "param-secret-token" || upstream.tokenScheme != gitTokenSchemeBearer {
+		t.Errorf("expected the repo to be cloned from the upstream with the gitToken secret, got %q with scheme %q", upstream.password, upstream.tokenScheme)
+	}
+}
@@ -16,7 +16,10 @@ limitations under the License.
 
 package git
 
-import "github.com/tektoncd/pipeline/pkg/resolution/resource"
+import (
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"github.com/tektoncd/pipeline/pkg/resolution/resource"
+)
 
 const (
 	// UrlParam is the git repo Url when using the anonymous/full clone approach
@@ -25,7 +28,7 @@ const (
 	OrgParam = "org"
 	// RepoParam is the repository to use when using the SCM API approach
 	RepoParam = "repo"
-	// PathParam is the pathInRepo into the git repo where a file is located. This is used with both approaches.
+	// PathParam is the pathInRepo into the git repo where a file, or a directory of YAML files, is located. This is used with both approaches.
 	PathParam string = "pathInRepo"
 	// RevisionParam is the git revision that a file should be fetched from. This is used with both approaches.
 	RevisionParam string = "revision"
@@ -37,6 +40,8 @@ const (
 	GitTokenParam string = "gitToken"
 	// GitTokenParam is an optional reference to a secret name when using native-git for git authentication
 	GitTokenKeyParam string = "gitTokenKey"
+	// GitTokenSchemeParam is an optional "basic" or "bearer" scheme of the Authorization header sending the gitToken when cloning
+	GitTokenSchemeParam string = "gitTokenScheme"
 	// DefaultTokenKeyParam is the default key in the TokenParam secret for SCM API authentication
 	DefaultTokenKeyParam string = "token"
 	// scmTypeParam is an optional string overriding the scm-type configuration (ie: github, gitea, gitlab etc..)
@@ -45,4 +50,92 @@ const (
 	ServerURLParam string = "serverURL"
 	// ConfigKeyParam is an optional string to provid which scm configuration to use from git resolver configmap
 	ConfigKeyParam string = "configKey"
+	// IgnoreExportIgnoreParam is an optional boolean allowing to resolve paths marked export-ignore in .gitattributes
+	IgnoreExportIgnoreParam string = "ignoreExportIgnore"
+	// SparseCheckoutDirectoriesParam is an optional comma separated list of the only directories to fetch when cloning the repo
+	SparseCheckoutDirectoriesParam string = "sparseCheckoutDirectories"
+	// SubmodulesParam is an optional "true", "false" or "recursive" value initializing the submodules of the repo after cloning it
+	SubmodulesParam string = "submodules"
+)
+
+const (
+	// gitTokenSchemeBasic sends the gitToken as the password of the basic
+	// authentication of the clones.
+	gitTokenSchemeBasic = "basic"
+	// gitTokenSchemeBearer sends the gitToken as a bearer token, for the
+	// servers like Gitea which don't accept it with basic authentication.
+	gitTokenSchemeBearer = "bearer"
 )
+
+const (
+	// submodulesDirect initializes the submodules of the repo, but not
+	// their own submodules.
+	submodulesDirect = "true"
+	// submodulesRecursive initializes the submodules of the repo and all
+	// their nested submodules.
+	submodulesRecursive = "recursive"
+)
+
+// DescribeParams returns the description of every param accepted by the
+// git resolver, for use by resolvers implementing framework.ParamDescriber.
+func DescribeParams() []framework.ParamDescription {
+	return []framework.ParamDescription{{
+		Name:        UrlParam,
+		Description: "URL of the repo to fetch and clone anonymously. Either url, or repo (with org) must be specified, but not both.",
+	}, {
+		Name:        OrgParam,
+		Description: "The organization to find the repository in when using the SCM API. Defaults to the default-org configuration.",
+	}, {
+		Name:        RepoParam,
+		Description: "The repository to find the resource in when using the SCM API. Either url, or repo (with org) must be specified, but not both.",
+	}, {
+		Name:        PathParam,
+		Description: "Where to find the file, or the directory of YAML files, in the repo.",
+		Required:    true,
+	}, {
+		Name:        RevisionParam,
+		Description: "Git revision to checkout a file from. This can be commit SHA, branch or tag. Defaults to the default-revision configuration.",
+	}, {
+		Name:        TokenParam,
+		Description: "An optional secret name in the namespace of the request to fetch the SCM API token from.",
+	}, {
+		Name:        TokenKeyParam,
+		Description: "An optional key in the token secret to fetch the SCM API token from.",
+		Default:     DefaultTokenKeyParam,
+	}, {
+		Name:        GitTokenParam,
+		Description: "An optional secret name in the namespace of the request to fetch the token used to clone the repo from.",
+	}, {
+		Name:        GitTokenKeyParam,
+		Description: "An optional key in the gitToken secret to fetch the token used to clone the repo from.",
+		Default:     DefaultTokenKeyParam,
+	}, {
+		Name:        GitTokenSchemeParam,
+		Description: "How the gitToken is sent when cloning the repo, \"basic\" sends it as the password of the basic authentication and \"bearer\" as a bearer token. Defaults to the git-token-scheme configuration.",
+		Enum:        []string{gitTokenSchemeBasic, gitTokenSchemeBearer},
+		Default:     gitTokenSchemeBasic,
+	}, {
+		Name:        ScmTypeParam,
+		Description: "An optional SCM type to use for API operations, overriding the scm-type configuration.",
+		Enum:        []string{"github", "gitlab", "gitea", "bitbucketcloud", scmTypeBitbucketServer, scmTypeStash, scmTypeAzure},
+	}, {
+		Name:        ServerURLParam,
+		Description: "An optional server URL (that includes the https:// prefix) to connect to for API operations, overriding the server-url configuration.",
+	}, {
+		Name:        ConfigKeyParam,
+		Description: "An optional key of the configuration to use from the git resolver configmap.",
+	}, {
+		Name:        IgnoreExportIgnoreParam,
+		Description: "Whether to resolve the file even if it is marked export-ignore in the .gitattributes files of the repo.",
+		Enum:        []string{"true", "false"},
+		Default:     "false",
+	}, {
+		Name:        SparseCheckoutDirectoriesParam,
+		Description: "An optional comma separated list of the only directories of the repo to fetch when cloning it, which must contain the directory of pathInRepo. Defaults to the default-sparse-checkout-directories configuration.",
+	}, {
+		Name:        SubmodulesParam,
+		Description: "Whether to initialize the submodules of the repo after cloning it, so that pathInRepo can be in a submodule. \"true\" only initializes the submodules of the repo and \"recursive\" also initializes their nested submodules.",
+		Enum:        []string{"true", "false", "recursive"},
+		Default:     "false",
+	}}
+}
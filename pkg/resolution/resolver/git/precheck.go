@@ -0,0 +1,181 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/factory"
+)
+
+// precheckOrgRepo returns the org/repo of the repo of the URL on the server
+// of the SCM type, false if the URL isn't the one of a repo of the server.
+func precheckOrgRepo(scmType, serverURL, repoURL string) (string, bool) {
+	server, err := url.Parse(effectiveServerURL(scmType, serverURL))
+	if err != nil || server.Host == "" {
+		return "", false
+	}
+	repo, err := url.Parse(repoURL)
+	if err != nil || !strings.EqualFold(repo.Host, server.Host) {
+		return "", false
+	}
+	orgRepo, ok := strings.CutPrefix(strings.TrimSuffix(repo.Path, "/"), strings.TrimSuffix(server.Path, "/")+"/")
+	orgRepo = strings.TrimSuffix(orgRepo, ".git")
+	if !ok || !strings.Contains(orgRepo, "/") {
+		return "", false
+	}
+	return orgRepo, true
+}
+
+// precheckAPIToken returns the token authenticating to the SCM API of the
+// config, "" if the config has no API credentials.
+func (g *GitResolver) precheckAPIToken(ctx context.Context, conf ScmConfig) (string, error) {
+	switch {
+	case conf.CredentialPlugin != "":
+		return g.getProviderToken(ctx, conf)
+	case conf.usesGitHubApp():
+		mintToken := g.InstallationTokenFunc
+		if mintToken == nil {
+			mintToken = MintInstallationToken
+		}
+		return g.getInstallationToken(ctx, conf, conf.SCMType, mintToken)
+	case conf.APISecretName != "" && conf.APISecretKey != "":
+		token, err := g.getAPIToken(ctx, nil, APISecretNameKey)
+		if err != nil {
+			return "", err
+		}
+		return string(token), nil
+	default:
+		return "", nil
+	}
+}
+
+// precheckClonePath checks with the SCM API of the config that the file at
+// path exists at the revision of the repo of repoURL before cloning it. It
+// returns the file when the SCM API can fetch it, so that the repo doesn't
+// have to be cloned, and an error when the path doesn't exist at the
+// revision. It returns neither when the clone is needed to resolve the path,
+// or when the SCM API can't tell whether it exists: the pre-check is skipped
+// without the clone-api-precheck config, without API credentials, for the
+// repos of other servers and for the paths the SCM API can't resolve.
+//
+// Only the server-url and scm-type of the config are used, never the
+// serverURL and scmType params, so that the API credentials of the config
+// are only sent to its server.
+func (g *GitResolver) precheckClonePath(ctx context.Context, conf ScmConfig, repoURL, revision, path string, maxFileSize int64) (*resolvedGitResource, error) {
+	precheck, err := conf.GetCloneAPIPrecheck()
+	if err != nil || !precheck {
+		return nil, err
+	}
+	// The clone resolves the directories, the semver constraints and the
+	// files of the submodules, which the SCM API can't.
+	if isDirectoryPath(path) || isSemverRevision(revision) || g.Params[SubmodulesParam] == submodulesDirect || g.Params[SubmodulesParam] == submodulesRecursive {
+		return nil, nil
+	}
+	scmType, serverURL := conf.SCMType, conf.ServerURL
+	if scmType == scmTypeAzure || isStashSCMType(scmType) {
+		return nil, nil
+	}
+	orgRepo, ok := precheckOrgRepo(scmType, serverURL, repoURL)
+	if !ok {
+		return nil, nil
+	}
+	apiToken, err := g.precheckAPIToken(ctx, conf)
+	if err != nil || apiToken == "" {
+		if err != nil {
+			g.Logger.Debugf("skipping the SCM API pre-check of %s: %v", repoURL, err)
+		}
+		return nil, nil
+	}
+	clientFunc := g.ClientFunc
+	if clientFunc == nil {
+		clientFunc = factory.NewClient
+	}
+	scmClient, err := clientFunc(scmType, serverURL, apiToken)
+	if err != nil {
+		g.Logger.Debugf("skipping the SCM API pre-check of %s: %v", repoURL, err)
+		return nil, nil
+	}
+
+	// The path is only missing if the repo and the revision exist.
+	ref, err := resolvePullRequestRef(ctx, scmClient, orgRepo, revision)
+	if err != nil {
+		g.Logger.Debugf("skipping the SCM API pre-check of %s: %v", repoURL, err)
+		return nil, nil
+	}
+	commit, _, err := scmClient.Git.FindCommit(ctx, orgRepo, ref)
+	if err != nil || commit == nil {
+		g.Logger.Debugf("skipping the SCM API pre-check of %s, couldn't find the revision %s: %v", repoURL, revision, err)
+		return nil, nil
+	}
+	if entry := apiFileEntry(ctx, scmClient, orgRepo, path, ref); entry != nil {
+		// The clone resolves the target of the symlinks.
+		if entry.Type == apiEntryTypeSymlink {
+			return nil, nil
+		}
+		if size := int64(entry.Size); size > maxFileSize {
+			return nil, fileTooLargeError(path, size, maxFileSize)
+		}
+	}
+	content, res, err := scmClient.Contents.Find(ctx, orgRepo, path, ref)
+	if err != nil {
+		if !errors.Is(err, scm.ErrNotFound) && (res == nil || res.Status != http.StatusNotFound) {
+			g.Logger.Debugf("skipping the SCM API pre-check of %s: %v", repoURL, err)
+			return nil, nil
+		}
+		// A directory can't be fetched as a file but can be listed.
+		if _, _, listErr := scmClient.Contents.List(ctx, orgRepo, path, ref, &scm.ListOptions{}); listErr == nil {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("path %s not found at revision %s of %s", path, revision, repoURL)
+	}
+	if content == nil || len(content.Data) == 0 {
+		return nil, nil
+	}
+	if size := int64(len(content.Data)); size > maxFileSize {
+		return nil, fileTooLargeError(path, size, maxFileSize)
+	}
+	readFile := func(p string) ([]byte, error) {
+		c, res, err := scmClient.Contents.Find(ctx, orgRepo, p, ref)
+		if err != nil {
+			if errors.Is(err, scm.ErrNotFound) || (res != nil && res.Status == http.StatusNotFound) {
+				return nil, nil
+			}
+			return nil, newSCMStatusError(res, err)
+		}
+		return c.Data, nil
+	}
+	if err := g.checkExportIgnore(path, readFile); err != nil {
+		return nil, err
+	}
+
+	return &resolvedGitResource{
+		Content:           content.Data,
+		Revision:          commit.Sha,
+		Commit:            newSCMCommitMetadata(commit),
+		Path:              path,
+		URL:               repoURL,
+		Mode:              ResolutionModeAPI,
+		EffectiveRevision: revision,
+	}, nil
+}
@@ -0,0 +1,264 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/fake"
+	"github.com/jenkins-x/go-scm/scm/factory"
+	"github.com/tektoncd/pipeline/pkg/resolution/common"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"github.com/tektoncd/pipeline/test/diff"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/cache"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestResolveGitClonePrecheck(t *testing.T) {
+	repoURL := "https://github.com/test-org/test-repo.git"
+	contentDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(contentDir, "test-org", "test-repo", "tasks"), 0o700); err != nil {
+		t.Fatalf("couldn't create the content directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(contentDir, "test-org", "test-repo", "tasks", "task.yaml"), []byte("task from the api"), 0o600); err != nil {
+		t.Fatalf("couldn't write the file: %v", err)
+	}
+	sha := "8bb1de9d4a9ccc4d1e44e8d1f3b3b0c7b4fa7e1d"
+	clientFunc := func(_, _, _ string, _ ...factory.ClientOptionFunc) (*scm.Client, error) {
+		scmClient, scmData := fake.NewDefault()
+		scmData.ContentDir = contentDir
+		scmData.Commits = map[string]*scm.Commit{
+			"main": {
+				Sha:     sha,
+				Message: "Add the task",
+				Author:  scm.Signature{Name: "Ranni", Email: "ranni@example.com", Date: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)},
+			},
+		}
+		return scmClient, nil
+	}
+	credentials := map[string]string{
+		SCMTypeKey:            "github",
+		APISecretNameKey:      "token-secret",
+		APISecretKeyKey:       "token",
+		APISecretNamespaceKey: "tekton-pipelines",
+	}
+	errCloned := errors.New("cloned")
+
+	for _, tc := range []struct {
+		name       string
+		url        string
+		path       string
+		revision   string
+		params     map[string]string
+		config     map[string]string
+		wantCloned bool
+		wantErr    string
+	}{{
+		name:     "served by the api",
+		path:     "tasks/task.yaml",
+		revision: "main",
+		config:   map[string]string{CloneAPIPrecheckKey: "true"},
+	}, {
+		name:     "path not found",
+		path:     "tasks/missing.yaml",
+		revision: "main",
+		config:   map[string]string{CloneAPIPrecheckKey: "true"},
+		wantErr:  "path tasks/missing.yaml not found at revision main of " + repoURL,
+	}, {
+		name:       "precheck disabled",
+		path:       "tasks/missing.yaml",
+		revision:   "main",
+		config:     map[string]string{},
+		wantCloned: true,
+	}, {
+		name:       "no api credentials",
+		path:       "tasks/missing.yaml",
+		revision:   "main",
+		config:     map[string]string{CloneAPIPrecheckKey: "true", APISecretNameKey: ""},
+		wantCloned: true,
+	}, {
+		name:       "repo of another server",
+		url:        "https://gitlab.com/test-org/test-repo.git",
+		path:       "tasks/missing.yaml",
+		revision:   "main",
+		config:     map[string]string{CloneAPIPrecheckKey: "true"},
+		wantCloned: true,
+	}, {
+		name:       "server of the params",
+		url:        "https://git.example.com/test-org/test-repo.git",
+		path:       "tasks/missing.yaml",
+		revision:   "main",
+		params:     map[string]string{ServerURLParam: "https://git.example.com", ScmTypeParam: "gitlab"},
+		config:     map[string]string{CloneAPIPrecheckKey: "true"},
+		wantCloned: true,
+	}, {
+		name:       "revision unknown to the api",
+		path:       "tasks/missing.yaml",
+		revision:   "other",
+		config:     map[string]string{CloneAPIPrecheckKey: "true"},
+		wantCloned: true,
+	}, {
+		name:       "directory",
+		path:       "tasks",
+		revision:   "main",
+		config:     map[string]string{CloneAPIPrecheckKey: "true"},
+		wantCloned: true,
+	}, {
+		name:     "invalid precheck config",
+		path:     "tasks/task.yaml",
+		revision: "main",
+		config:   map[string]string{CloneAPIPrecheckKey: "yes"},
+		wantErr:  `invalid clone-api-precheck "yes" in git resolver config, must be "true" or "false"`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			config := map[string]string{}
+			for k, v := range credentials {
+				config[k] = v
+			}
+			for k, v := range tc.config {
+				config[k] = v
+			}
+			url := tc.url
+			if url == "" {
+				url = repoURL
+			}
+			params := map[string]string{
+				UrlParam:      url,
+				RevisionParam: tc.revision,
+				PathParam:     tc.path,
+			}
+			for k, v := range tc.params {
+				params[k] = v
+			}
+			cloned := false
+			var clientServerURLs []string
+			g := &GitResolver{
+				Params: params,
+				Logger: zap.NewNop().Sugar(),
+				Cache:  cache.NewLRUExpireCache(cacheSize),
+				TTL:    ttl,
+				KubeClient: kubefake.NewSimpleClientset(&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "token-secret", Namespace: "tekton-pipelines"},
+					Data:       map[string][]byte{"token": []byte("token")},
+				}),
+				ClientFunc: func(scmType, serverURL, token string, opts ...factory.ClientOptionFunc) (*scm.Client, error) {
+					clientServerURLs = append(clientServerURLs, serverURL)
+					return clientFunc(scmType, serverURL, token, opts...)
+				},
+				cloneFunc: func(context.Context, remote) (*repository, func(), error) {
+					cloned = true
+					return nil, func() {}, errCloned
+				},
+			}
+
+			ctx := framework.InjectResolverConfigToContext(t.Context(), config)
+			res, err := g.ResolveGitClone(ctx)
+			for _, serverURL := range clientServerURLs {
+				if serverURL != "" {
+					t.Errorf("expected the API of the server of the config to be called, got %s", serverURL)
+				}
+			}
+			if cloned != tc.wantCloned {
+				t.Errorf("expected the repo to be cloned: %t, got %t", tc.wantCloned, cloned)
+			}
+			if tc.wantCloned {
+				if !errors.Is(err, errCloned) {
+					t.Errorf("expected the clone to resolve the file, got %v", err)
+				}
+				return
+			}
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("expected the error %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if d := cmp.Diff("task from the api", string(res.Data())); d != "" {
+				t.Errorf("unexpected content %s", diff.PrintWantGot(d))
+			}
+			wantAnnotations := map[string]string{
+				common.AnnotationKeyContentType:   yamlContentType,
+				AnnotationKeyRevision:             sha,
+				AnnotationKeyPath:                 tc.path,
+				AnnotationKeyURL:                  repoURL,
+				AnnotationKeyResolutionMode:       ResolutionModeAPI,
+				AnnotationKeyEffectiveRevision:    "main",
+				AnnotationKeyCommitAuthor:         "Ranni <ranni@example.com>",
+				AnnotationKeyCommitTimestamp:      "2025-01-01T12:00:00Z",
+				AnnotationKeyCommitMessageSubject: "Add the task",
+			}
+			if d := cmp.Diff(wantAnnotations, res.Annotations()); d != "" {
+				t.Errorf("unexpected annotations %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPrecheckOrgRepo(t *testing.T) {
+	for _, tc := range []struct {
+		scmType   string
+		serverURL string
+		repoURL   string
+		want      string
+	}{{
+		scmType: "github",
+		repoURL: "https://github.com/tektoncd/catalog.git",
+		want:    "tektoncd/catalog",
+	}, {
+		scmType: "github",
+		repoURL: "https://GitHub.com/tektoncd/catalog",
+		want:    "tektoncd/catalog",
+	}, {
+		scmType:   "gitlab",
+		serverURL: "https://git.example.com/gitlab/",
+		repoURL:   "https://git.example.com/gitlab/group/subgroup/catalog.git",
+		want:      "group/subgroup/catalog",
+	}, {
+		scmType: "github",
+		repoURL: "https://gitlab.com/tektoncd/catalog.git",
+	}, {
+		scmType:   "gitlab",
+		serverURL: "https://git.example.com/gitlab",
+		repoURL:   "https://git.example.com/other/catalog.git",
+	}, {
+		scmType: "github",
+		repoURL: "https://github.com/catalog.git",
+	}, {
+		scmType: "gitea",
+		repoURL: "https://gitea.example.com/tektoncd/catalog.git",
+	}} {
+		t.Run(tc.repoURL, func(t *testing.T) {
+			got, ok := precheckOrgRepo(tc.scmType, tc.serverURL, tc.repoURL)
+			if ok != (tc.want != "") || got != tc.want {
+				t.Errorf("expected the org/repo %q, got %q (%t)", tc.want, got, ok)
+			}
+		})
+	}
+}
@@ -21,19 +21,67 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"io"
+	"net/url"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 )
 
+// commitSHARegex matches the full SHA-1 and SHA-256 commit SHAs.
+var commitSHARegex = regexp.MustCompile(`^([0-9a-f]{40}|[0-9a-f]{64})$`)
+
+var (
+	// errCloneAuthRequired is the error of the clones of the repos which
+	// require credentials.
+	errCloneAuthRequired = errors.New("clone error: authentication required")
+	// errFileNotExist is the error of the resolutions of a file which doesn't
+	// exist in the checked out tree.
+	errFileNotExist = errors.New("file does not exist")
+	// errDirectoryNotExist is the error of the resolutions of a directory
+	// which doesn't exist in the checked out tree.
+	errDirectoryNotExist = errors.New("directory does not exist")
+	// errSymlinkEscapesRepo is the error of the resolutions of a path
+	// through a symlink pointing outside of the checked out tree.
+	errSymlinkEscapesRepo = errors.New("symlink points outside of the repository")
+	// errBrokenSymlink is the error of the resolutions of a path through a
+	// symlink whose target doesn't exist.
+	errBrokenSymlink = errors.New("symlink target does not exist")
+)
+
+// maxSymlinkHops is the maximum number of symlinks followed to resolve a
+// path of the checked out tree, like the limit of the kernel.
+const maxSymlinkHops = 40
+
 type cmdExecutor = func(context.Context, string, ...string) *exec.Cmd
 
 type remote struct {
-	url         string
-	username    string
-	password    string
-	cmdExecutor cmdExecutor
+	url      string
+	username string
+	password string
+	// tokenScheme is "bearer" to send the password as a bearer token rather
+	// than with basic authentication.
+	tokenScheme string
+	// sparseCheckoutDirectories are the only directories of the repository
+	// to fetch and check out, or all of them if empty.
+	sparseCheckoutDirectories []string
+	// submodules is "true" to initialize the submodules of the repository
+	// once checked out, "recursive" to also initialize their submodules, or
+	// empty or "false" to leave them uninitialized.
+	submodules string
+	// submoduleURL returns the URL the submodule with the given URL is
+	// fetched from, or an error if it can't be fetched. The submodules are
+	// fetched from their URL if it is nil.
+	submoduleURL func(string) (string, error)
+	// cloneTimeout bounds each git operation fetching from the remote, if
+	// positive.
+	cloneTimeout time.Duration
+	cmdExecutor  cmdExecutor
 }
 
 func (r remote) clone(ctx context.Context) (*repository, func(), error) {
@@ -48,29 +96,113 @@ func (r remote) clone(ctx context.Context) (*repository, func(), error) {
 	}
 
 	repo := repository{
-		url:       r.url,
-		username:  r.username,
-		password:  r.password,
-		directory: tmpDir,
-		executor:  r.cmdExecutor,
+		url:          r.url,
+		username:     r.username,
+		password:     r.password,
+		tokenScheme:  r.tokenScheme,
+		directory:    tmpDir,
+		cloneTimeout: r.cloneTimeout,
+		executor:     r.cmdExecutor,
 	}
 
-	_, err = repo.execGit(ctx, "clone", repo.url, tmpDir, "--depth=1", "--no-checkout")
+	cloneArgs := []string{repo.url, tmpDir, "--depth=1", "--no-checkout"}
+	if len(r.sparseCheckoutDirectories) > 0 {
+		// Only fetch the blobs of the files which are checked out, when they
+		// are checked out.
+		cloneArgs = append(cloneArgs, "--filter=blob:none", "--sparse")
+	}
+	_, err = repo.execGit(ctx, "clone", cloneArgs...)
 	if err != nil {
 		if strings.Contains(err.Error(), "could not read Username") {
-			err = errors.New("clone error: authentication required")
+			return nil, cleanupFunc, errCloneAuthRequired
+		}
+		if strings.Contains(err.Error(), "Authentication failed") {
+			return nil, cleanupFunc, err
+		}
+		return nil, cleanupFunc, &unreachableRemoteError{err: err}
+	}
+	if len(r.sparseCheckoutDirectories) > 0 {
+		_, err = repo.execGit(ctx, "sparse-checkout", append([]string{"set", "--cone", "--end-of-options"}, r.sparseCheckoutDirectories...)...)
+		if err != nil {
+			return nil, cleanupFunc, err
 		}
-		return nil, cleanupFunc, err
 	}
 	return &repo, cleanupFunc, nil
 }
 
+// resolveRevision returns the SHA of the commit the revision points to in the
+// remote, without cloning it, or "" if the revision isn't a ref of the remote.
+// A commit SHA is returned as is once the remote is checked to be readable.
+func (r remote) resolveRevision(ctx context.Context, revision string) (string, error) {
+	repo := repository{
+		url:          r.url,
+		username:     r.username,
+		password:     r.password,
+		tokenScheme:  r.tokenScheme,
+		directory:    os.TempDir(),
+		cloneTimeout: r.cloneTimeout,
+		executor:     r.cmdExecutor,
+	}
+	if commitSHARegex.MatchString(revision) {
+		_, err := repo.execGit(ctx, "ls-remote", repo.url, "HEAD")
+		return revision, err
+	}
+	out, err := repo.execGit(ctx, "ls-remote", repo.url, revision, revision+"^{}")
+	if err != nil {
+		return "", err
+	}
+	refs := map[string]string{}
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		sha, ref, ok := strings.Cut(line, "\t")
+		if ok {
+			refs[ref] = sha
+		}
+	}
+	// The refs are looked up in the order git fetch looks them up, with the
+	// commit of annotated tags.
+	for _, ref := range []string{revision, "refs/" + revision, "refs/tags/" + revision, "refs/heads/" + revision} {
+		if sha, ok := refs[ref+"^{}"]; ok {
+			return sha, nil
+		}
+		if sha, ok := refs[ref]; ok {
+			return sha, nil
+		}
+	}
+	return "", nil
+}
+
+// listTags returns the names of the tags of the remote, without cloning it.
+func (r remote) listTags(ctx context.Context) ([]string, error) {
+	repo := repository{
+		url:          r.url,
+		username:     r.username,
+		password:     r.password,
+		tokenScheme:  r.tokenScheme,
+		directory:    os.TempDir(),
+		cloneTimeout: r.cloneTimeout,
+		executor:     r.cmdExecutor,
+	}
+	out, err := repo.execGit(ctx, "ls-remote", "--tags", "--refs", repo.url)
+	if err != nil {
+		return nil, &unreachableRemoteError{err: err}
+	}
+	var tags []string
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if _, ref, ok := strings.Cut(line, "\t"); ok {
+			tags = append(tags, strings.TrimPrefix(ref, "refs/tags/"))
+		}
+	}
+	return tags, nil
+}
+
 type repository struct {
-	url       string
-	username  string
-	password  string
-	directory string
-	executor  cmdExecutor
+	url          string
+	username     string
+	password     string
+	tokenScheme  string
+	directory    string
+	cloneTimeout time.Duration
+	executor     cmdExecutor
 }
 
 func (repo *repository) currentRevision(ctx context.Context) (string, error) {
@@ -81,7 +213,27 @@ func (repo *repository) currentRevision(ctx context.Context) (string, error) {
 	return strings.TrimSpace(string(revisionSha)), nil
 }
 
+// currentCommitMetadata returns the metadata of the checked out commit.
+func (repo *repository) currentCommitMetadata(ctx context.Context) (commitMetadata, error) {
+	out, err := repo.execGit(ctx, "log", "-1", "--format=%an%x00%ae%x00%ct%x00%s", "HEAD")
+	if err != nil {
+		return commitMetadata{}, err
+	}
+	fields := strings.SplitN(strings.TrimSuffix(string(out), "\n"), "\x00", 4)
+	if len(fields) != 4 {
+		return commitMetadata{}, fmt.Errorf("unexpected output of git log: %q", out)
+	}
+	var date time.Time
+	if seconds, err := strconv.ParseInt(fields[2], 10, 64); err == nil {
+		date = time.Unix(seconds, 0)
+	}
+	return newCommitMetadata(fields[0], fields[1], date, fields[3]), nil
+}
+
 func (repo *repository) checkout(ctx context.Context, revision string) error {
+	if commitSHARegex.MatchString(revision) {
+		return repo.checkoutCommit(ctx, revision)
+	}
 	_, err := repo.execGit(ctx, "fetch", "origin", revision, "--depth=1")
 	if err != nil {
 		return err
@@ -95,6 +247,138 @@ func (repo *repository) checkout(ctx context.Context, revision string) error {
 	return nil
 }
 
+// checkoutCommit fetches and checks out the commit with the SHA. Only the
+// commit is fetched if the server lets the commits be fetched by SHA, like
+// with uploadpack.allowReachableSHA1InWant. Otherwise all the branches and
+// tags are fetched, which fails to find the commits which aren't reachable
+// from them.
+func (repo *repository) checkoutCommit(ctx context.Context, sha string) error {
+	if _, err := repo.execGit(ctx, "fetch", "origin", sha, "--depth=1"); err == nil {
+		_, err = repo.execGit(ctx, "checkout", "FETCH_HEAD")
+		return err
+	}
+
+	fetchArgs := []string{"origin", "+refs/heads/*:refs/remotes/origin/*", "+refs/tags/*:refs/tags/*"}
+	if _, err := os.Stat(filepath.Join(repo.directory, ".git", "shallow")); err == nil {
+		fetchArgs = append(fetchArgs, "--unshallow")
+	}
+	if _, err := repo.execGit(ctx, "fetch", fetchArgs...); err != nil {
+		return err
+	}
+	if _, err := repo.execGit(ctx, "cat-file", "-e", sha+"^{commit}"); err != nil {
+		return fmt.Errorf("commit %s not found in repository", sha)
+	}
+	_, err := repo.execGit(ctx, "checkout", sha)
+	return err
+}
+
+// updateSubmodules initializes and checks out the submodules of the checked
+// out commit, and their nested submodules if recursive. The submodules are
+// fetched from the URL submoduleURL returns for their URL, if it isn't nil,
+// so that they can be rewritten or rejected before anything is fetched.
+func (repo *repository) updateSubmodules(ctx context.Context, recursive bool, submoduleURL func(string) (string, error)) error {
+	if _, err := repo.execGit(ctx, "submodule", "init"); err != nil {
+		return err
+	}
+	if submoduleURL != nil {
+		// The URLs relative to the URL of the repository are absolute once
+		// the submodules are initialized.
+		urls, err := repo.configEntries(ctx, "", `^submodule\..*\.url$`)
+		if err != nil {
+			return err
+		}
+		for _, u := range urls {
+			fetchURL, err := submoduleURL(u.value)
+			if err != nil {
+				return fmt.Errorf("submodule %s: %w", strings.TrimSuffix(strings.TrimPrefix(u.key, "submodule."), ".url"), err)
+			}
+			if fetchURL != u.value {
+				if _, err := repo.execGit(ctx, "config", u.key, fetchURL); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if _, err := repo.execGit(ctx, "submodule", "update"); err != nil {
+		return err
+	}
+	if !recursive {
+		return nil
+	}
+	// The nested submodules are updated one submodule at a time, so that
+	// their URLs go through submoduleURL too.
+	paths, err := repo.configEntries(ctx, ".gitmodules", `^submodule\..*\.path$`)
+	if err != nil {
+		return err
+	}
+	for _, p := range paths {
+		submodule := *repo
+		submodule.directory = filepath.Join(repo.directory, filepath.FromSlash(p.value))
+		if err := submodule.updateSubmodules(ctx, true, submoduleURL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// configEntry is an entry of a git config.
+type configEntry struct {
+	key   string
+	value string
+}
+
+// configEntries returns the entries of the git config of the repository, or
+// of the config file if it isn't empty, whose keys match the regexp.
+func (repo *repository) configEntries(ctx context.Context, file, keyRegexp string) ([]configEntry, error) {
+	args := []string{"--null"}
+	if file != "" {
+		args = append(args, "--file", file)
+	}
+	out, err := repo.execGit(ctx, "config", append(args, "--get-regexp", keyRegexp)...)
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			// No key matches the regexp.
+			return nil, nil
+		}
+		return nil, err
+	}
+	var entries []configEntry
+	for _, entry := range strings.Split(string(out), "\x00") {
+		key, value, ok := strings.Cut(entry, "\n")
+		if ok {
+			entries = append(entries, configEntry{key: key, value: value})
+		}
+	}
+	return entries, nil
+}
+
+// urlPrefix returns the scheme and host of the HTTP URL, as the prefix of
+// the URLs of the repositories hosted on the same server, or "" if it isn't
+// an HTTP URL.
+func urlPrefix(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ""
+	}
+	return u.Scheme + "://" + u.Host + "/"
+}
+
+// authHeader returns the value of the Authorization header sending the
+// credentials of the repository, or "" if it has none.
+func (repo *repository) authHeader() string {
+	if repo.password == "" {
+		return ""
+	}
+	if repo.tokenScheme == gitTokenSchemeBearer {
+		return "Bearer " + repo.password
+	}
+	if repo.username == "" {
+		return ""
+	}
+	return "Basic " + base64.URLEncoding.EncodeToString([]byte(repo.username+":"+repo.password))
+}
+
 func (repo *repository) execGit(ctx context.Context, subCmd string, args ...string) ([]byte, error) {
 	if repo.executor == nil {
 		repo.executor = exec.CommandContext
@@ -106,23 +390,43 @@ func (repo *repository) execGit(ctx context.Context, subCmd string, args ...stri
 	// into the repository directory is not concurrency-safe
 	configArgs := []string{"-C", repo.directory}
 	env := []string{"GIT_TERMINAL_PROMPT=false"}
-	if subCmd == "clone" {
-		// NOTE: Since this is only HTTP basic auth, authentication only supports http
+	// The checkout fetches the blobs missing from a partial clone, like the
+	// ones of a sparse checkout.
+	fetchesRemote := subCmd == "clone" || subCmd == "fetch" || subCmd == "checkout" || subCmd == "ls-remote" || subCmd == "submodule"
+	if fetchesRemote {
+		// NOTE: Since this is only HTTP basic or bearer auth, authentication only supports http
 		// cloning, while unauthenticated cloning works for any other protocol supported
 		// by the git binary which doesn't require authentication.
-		if repo.username != "" && repo.password != "" {
-			token := base64.URLEncoding.EncodeToString([]byte(repo.username + ":" + repo.password))
-			env = append(
-				env,
-				"GIT_AUTH_HEADER=Authorization=Basic "+token,
-			)
-			configArgs = append(configArgs, "--config-env", "http.extraHeader=GIT_AUTH_HEADER")
+		headerConfig := "http.extraHeader"
+		if subCmd == "submodule" {
+			// The submodules may be hosted on other servers, which must
+			// not get the credentials of the repository.
+			headerConfig = ""
+			if prefix := urlPrefix(repo.url); prefix != "" {
+				headerConfig = "http." + prefix + ".extraHeader"
+			}
 		}
+		if header := repo.authHeader(); header != "" && headerConfig != "" {
+			env = append(env, "GIT_AUTH_HEADER=Authorization="+header)
+			configArgs = append(configArgs, "--config-env", headerConfig+"=GIT_AUTH_HEADER")
+		}
+	}
+	cmdCtx := ctx
+	if fetchesRemote && repo.cloneTimeout > 0 {
+		var cancel context.CancelFunc
+		cmdCtx, cancel = context.WithTimeout(ctx, repo.cloneTimeout)
+		defer cancel()
 	}
-	cmd := repo.executor(ctx, "git", append(configArgs, args...)...)
+	cmd := repo.executor(cmdCtx, "git", append(configArgs, args...)...)
 	cmd.Env = append(cmd.Env, env...)
+	// The helpers git runs to reach the remote, like git-remote-https, may
+	// hold its output open once it's killed.
+	cmd.WaitDelay = time.Second
 
 	out, err := cmd.Output()
+	if err != nil && errors.Is(cmdCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+		return nil, fmt.Errorf("git clone timed out after %s", repo.cloneTimeout)
+	}
 	if err != nil {
 		msg := string(out)
 		var exitErr *exec.ExitError
@@ -134,16 +438,175 @@ func (repo *repository) execGit(ctx context.Context, subCmd string, args ...stri
 	return out, err
 }
 
-func (repo *repository) getFileContent(path string) ([]byte, error) {
+// getFileContent returns the content of the file at the given path of the
+// checked out tree. It fails without reading the file if it is larger than
+// maxSize, and stops reading the file if it grows past maxSize.
+func (repo *repository) getFileContent(path string, maxSize int64) ([]byte, error) {
 	if _, err := os.Stat(repo.directory); errors.Is(err, os.ErrNotExist) {
 		return nil, fmt.Errorf("repository clone no longer exists, used after cleaned? %w", err)
 	}
-	fileContents, err := os.ReadFile(filepath.Join(repo.directory, path))
+	resolved, err := repo.resolveSymlinks(path)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(resolved)
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
-			return nil, errors.New("file does not exist")
+			return nil, errFileNotExist
 		}
 		return nil, err
 	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() > maxSize {
+		return nil, fileTooLargeError(path, info.Size(), maxSize)
+	}
+	fileContents, err := io.ReadAll(io.LimitReader(f, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(fileContents)) > maxSize {
+		return nil, fileTooLargeError(path, int64(len(fileContents)), maxSize)
+	}
 	return fileContents, nil
 }
+
+// readFileIfExists returns the content of the file at the given path of the
+// checked out tree, or nil if there is no such file.
+func (repo *repository) readFileIfExists(path string) ([]byte, error) {
+	resolved, err := repo.resolveSymlinks(path)
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(resolved)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return content, err
+}
+
+// isDirectory returns whether the given path of the checked out tree is a
+// directory.
+func (repo *repository) isDirectory(path string) (bool, error) {
+	resolved, err := repo.resolveSymlinks(path)
+	if err != nil {
+		return false, err
+	}
+	info, err := os.Stat(resolved)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return info.IsDir(), nil
+}
+
+// getDirectoryManifests returns the YAML files of the directory at the given
+// path of the checked out tree, skipping the other files and the
+// subdirectories. It fails if one of the files is larger than maxSize.
+func (repo *repository) getDirectoryManifests(dir string, maxSize int64) ([]manifestFile, error) {
+	resolved, err := repo.resolveSymlinks(dir)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(resolved)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, errDirectoryNotExist
+		}
+		return nil, err
+	}
+	var files []manifestFile
+	for _, e := range entries {
+		// The symlinks are resolved like the path of a file, so that the ones
+		// pointing outside of the repository are rejected.
+		if !(e.Type().IsRegular() || e.Type()&os.ModeSymlink != 0) || !isManifestFile(e.Name()) {
+			continue
+		}
+		p := path.Join(dir, e.Name())
+		content, err := repo.getFileContent(p, maxSize)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, manifestFile{path: p, content: content})
+	}
+	return files, nil
+}
+
+// resolveSymlinks returns the path on disk of the given path of the checked
+// out tree, following the symlinks of its components within the tree. It fails
+// if one of them points outside of the tree or to a missing file, so that the
+// files of the host are never read. The path of the first component which
+// doesn't exist is returned, for the caller to report the missing path.
+func (repo *repository) resolveSymlinks(p string) (string, error) {
+	type component struct {
+		name string
+		// link is the symlink whose target the component is part of.
+		link string
+	}
+	var pending []component
+	for _, name := range strings.Split(filepath.ToSlash(p), "/") {
+		pending = append(pending, component{name: name})
+	}
+	resolved := ""
+	hops := 0
+	for len(pending) > 0 {
+		c := pending[0]
+		pending = pending[1:]
+		switch c.name {
+		case "", ".":
+			continue
+		case "..":
+			if resolved == "" {
+				if c.link != "" {
+					return "", fmt.Errorf("%w: %q", errSymlinkEscapesRepo, c.link)
+				}
+				return "", fmt.Errorf("path %q is outside of the repository", p)
+			}
+			resolved = path.Dir(resolved)
+			if resolved == "." {
+				resolved = ""
+			}
+			continue
+		}
+		candidate := path.Join(resolved, c.name)
+		info, err := os.Lstat(filepath.Join(repo.directory, candidate))
+		if errors.Is(err, os.ErrNotExist) {
+			if c.link != "" {
+				return "", fmt.Errorf("%w: %q", errBrokenSymlink, c.link)
+			}
+			// No path under a missing component exists: its remaining
+			// components, which could climb out of the tree with "..", are
+			// dropped rather than joined lexically.
+			return filepath.Join(repo.directory, candidate), nil
+		}
+		if err != nil {
+			return "", err
+		}
+		if info.Mode()&os.ModeSymlink == 0 {
+			resolved = candidate
+			continue
+		}
+		hops++
+		if hops > maxSymlinkHops {
+			return "", fmt.Errorf("too many levels of symlinks resolving %q", p)
+		}
+		target, err := os.Readlink(filepath.Join(repo.directory, candidate))
+		if err != nil {
+			return "", err
+		}
+		if filepath.IsAbs(target) {
+			return "", fmt.Errorf("%w: %q", errSymlinkEscapesRepo, candidate)
+		}
+		var expanded []component
+		for _, name := range strings.Split(filepath.ToSlash(target), "/") {
+			expanded = append(expanded, component{name: name, link: candidate})
+		}
+		pending = append(expanded, pending...)
+	}
+	return filepath.Join(repo.directory, resolved), nil
+}
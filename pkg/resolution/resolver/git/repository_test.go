@@ -20,17 +20,24 @@ package git
 import (
 	"context"
 	"encoding/base64"
+	"errors"
+	"net"
 	"os/exec"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestClone(t *testing.T) {
 	type testCase struct {
-		url       string
-		username  string
-		password  string
-		expectErr string
+		url         string
+		username    string
+		password    string
+		tokenScheme string
+		sparse      []string
+		expectErr   string
 	}
 
 	testCases := map[string]testCase{
@@ -38,6 +45,8 @@ func TestClone(t *testing.T) {
 		"normal usage with .git": {url: "https://github.com/tektoncd/pipeline.git"},
 		"private repository":     {url: "https://github.com/tektoncd/not-a-repository.git"},
 		"with crendentials":      {url: "https://github.com/tektoncd/not-a-repository.git", username: "fake", password: "fake"},
+		"with bearer token":      {url: "https://gitea.com/tektoncd/not-a-repository.git", username: "fake", password: "fake", tokenScheme: gitTokenSchemeBearer},
+		"sparse checkout":        {url: "https://github.com/tektoncd/pipeline", sparse: []string{"tasks", "pipelines"}},
 	}
 
 	for name, test := range testCases {
@@ -52,7 +61,7 @@ func TestClone(t *testing.T) {
 				return cmd
 			}
 
-			mockCmdRemote := remote{url: test.url, username: test.username, password: test.password, cmdExecutor: executor}
+			mockCmdRemote := remote{url: test.url, username: test.username, password: test.password, tokenScheme: test.tokenScheme, sparseCheckoutDirectories: test.sparse, cmdExecutor: executor}
 			repo, cleanup, err := mockCmdRemote.clone(t.Context())
 			defer cleanup()
 			if test.expectErr != "" {
@@ -67,15 +76,23 @@ func TestClone(t *testing.T) {
 
 			expectedEnv := []string{"GIT_TERMINAL_PROMPT=false"}
 			expectedCmd := []string{"git", "-C", repo.directory}
-			if test.username != "" {
+			if test.tokenScheme == gitTokenSchemeBearer {
+				expectedCmd = append(expectedCmd, "--config-env", "http.extraHeader=GIT_AUTH_HEADER")
+				expectedEnv = append(expectedEnv, "GIT_AUTH_HEADER=Authorization=Bearer "+test.password)
+			} else if test.username != "" {
 				token := base64.URLEncoding.EncodeToString([]byte(test.username + ":" + test.password))
 				expectedCmd = append(expectedCmd, "--config-env", "http.extraHeader=GIT_AUTH_HEADER")
 				expectedEnv = append(expectedEnv, "GIT_AUTH_HEADER=Authorization=Basic "+token)
 			}
 			expectedCmd = append(expectedCmd, "clone", test.url, repo.directory, "--depth=1", "--no-checkout")
+			expectedExecutions := 1
+			if len(test.sparse) > 0 {
+				expectedCmd = append(expectedCmd, "--filter=blob:none", "--sparse")
+				expectedExecutions = 2
+			}
 
-			if len(executions) != 1 {
-				t.Fatalf("Expected 1 command execution during cloning, got %d: %v", len(executions), executions)
+			if len(executions) != expectedExecutions {
+				t.Fatalf("Expected %d command executions during cloning, got %d: %v", expectedExecutions, len(executions), executions)
 			}
 
 			cmd := executions[0]
@@ -87,6 +104,12 @@ func TestClone(t *testing.T) {
 			if !reflect.DeepEqual(cmd.Env, expectedEnv) {
 				t.Fatalf("Expected clone command env vars to be %v but got %v", expectedEnv, cmd.Env)
 			}
+			if len(test.sparse) > 0 {
+				expectedSparseCmd := append([]string{"git", "-C", repo.directory, "sparse-checkout", "set", "--cone", "--end-of-options"}, test.sparse...)
+				if sparseCmdParts := executions[1].Args[1:]; !reflect.DeepEqual(sparseCmdParts, expectedSparseCmd) {
+					t.Fatalf("Expected sparse-checkout command to be %v but got %v", expectedSparseCmd, sparseCmdParts)
+				}
+			}
 		})
 	}
 }
@@ -129,6 +152,7 @@ func TestCheckout(t *testing.T) {
 		"revision is sha":             {revision: revisions[0], expectedRevision: revisions[0]},
 		"revision is unreachable sha": {revision: revisions[1], expectedRevision: revisions[1]},
 		"non-existent revision":       {revision: "fake-revision", expectErr: "git fetch error: fatal: couldn't find remote ref fake-revision: exit status 128"},
+		"non-existent sha":            {revision: "0123456789abcdef0123456789abcdef01234567", expectErr: "commit 0123456789abcdef0123456789abcdef01234567 not found in repository"},
 	}
 
 	for name, test := range testCases {
@@ -162,3 +186,422 @@ func TestCheckout(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckoutCommit(t *testing.T) {
+	repoPath, revisions := createTestRepo(t, []commitForRepo{{
+		Filename: "README.md",
+		Content:  "branch",
+		Branch:   "feature",
+	}, {
+		Filename: "README.md",
+		Content:  "main",
+	}})
+
+	for _, tc := range []struct {
+		name          string
+		rejectSHAWant bool
+		wantFetches   [][]string
+	}{{
+		name:        "the commit is fetched by SHA",
+		wantFetches: [][]string{{"fetch", "origin", revisions[0], "--depth=1"}},
+	}, {
+		name:          "all the branches are fetched when the server rejects fetching by SHA",
+		rejectSHAWant: true,
+		wantFetches: [][]string{
+			{"fetch", "origin", revisions[0], "--depth=1"},
+			{"fetch", "origin", "+refs/heads/*:refs/remotes/origin/*", "+refs/tags/*:refs/tags/*"},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			var fetches [][]string
+			executor := func(ctx context.Context, name string, args ...string) *exec.Cmd {
+				// Remove the "-C <dir>" prefix
+				if args[2] == "fetch" {
+					fetches = append(fetches, args[2:])
+					if tc.rejectSHAWant && args[len(args)-1] == "--depth=1" {
+						return exec.CommandContext(ctx, "false")
+					}
+				}
+				return exec.CommandContext(ctx, name, args...)
+			}
+
+			ctx := t.Context()
+			repo, cleanup, err := remote{url: repoPath, cmdExecutor: executor}.clone(ctx)
+			defer cleanup()
+			if err != nil {
+				t.Fatalf("Error cloning repository %v", err)
+			}
+			if err := repo.checkout(ctx, revisions[0]); err != nil {
+				t.Fatalf("Error checking out revision: %v", err)
+			}
+			revision, err := repo.currentRevision(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if revision != revisions[0] {
+				t.Errorf("Expected revision to be %q but got %q", revisions[0], revision)
+			}
+			if !reflect.DeepEqual(fetches, tc.wantFetches) {
+				t.Errorf("Expected the fetches %v but got %v", tc.wantFetches, fetches)
+			}
+		})
+	}
+}
+
+func TestUpdateSubmodules(t *testing.T) {
+	repoPath := createTestRepoWithSubmodules(t)
+
+	for _, tc := range []struct {
+		name       string
+		submodules string
+		wantFiles  []string
+		wantMissed []string
+	}{{
+		name:       "submodules not initialized",
+		wantFiles:  []string{"pipeline.yaml"},
+		wantMissed: []string{"common/tasks/build.yaml", "common/nested/task.yaml"},
+	}, {
+		name:       "direct submodules",
+		submodules: submodulesDirect,
+		wantFiles:  []string{"pipeline.yaml", "common/tasks/build.yaml"},
+		wantMissed: []string{"common/nested/task.yaml"},
+	}, {
+		name:       "recursive submodules",
+		submodules: submodulesRecursive,
+		wantFiles:  []string{"pipeline.yaml", "common/tasks/build.yaml", "common/nested/task.yaml"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := t.Context()
+			repo, cleanup, err := remote{url: repoPath, cmdExecutor: allowFileSubmodules}.clone(ctx)
+			defer cleanup()
+			if err != nil {
+				t.Fatalf("Error cloning repository %v", err)
+			}
+			if err := repo.checkout(ctx, "main"); err != nil {
+				t.Fatalf("Error checking out revision: %v", err)
+			}
+			if tc.submodules != "" {
+				if err := repo.updateSubmodules(ctx, tc.submodules == submodulesRecursive, nil); err != nil {
+					t.Fatalf("Error initializing the submodules: %v", err)
+				}
+			}
+			for _, path := range tc.wantFiles {
+				if _, err := repo.getFileContent(path, 1024); err != nil {
+					t.Errorf("Expected %q to be checked out but got %v", path, err)
+				}
+			}
+			for _, path := range tc.wantMissed {
+				if _, err := repo.getFileContent(path, 1024); err == nil || err.Error() != "file does not exist" {
+					t.Errorf("Expected %q not to be checked out but got %v", path, err)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateSubmodulesURLs(t *testing.T) {
+	nestedPath, _ := createTestRepo(t, []commitForRepo{{Filename: "task.yaml", Content: "nested task"}})
+	commonPath, _ := createTestRepo(t, []commitForRepo{{Dir: "tasks/", Filename: "build.yaml", Content: "build task"}})
+	addSubmoduleToTestRepo(t, commonPath, nestedPath, "nested")
+	mirrorPath := filepath.Join(t.TempDir(), "mirror.git")
+	if out, err := exec.Command("git", "clone", "--bare", commonPath, mirrorPath).CombinedOutput(); err != nil {
+		t.Fatalf("couldn't mirror %s: %q: %v", commonPath, out, err)
+	}
+	repoPath, _ := createTestRepo(t, []commitForRepo{{Filename: "pipeline.yaml", Content: "pipeline"}})
+	addSubmoduleToTestRepo(t, repoPath, commonPath, "common")
+
+	errNotAllowed := errors.New("not allowed")
+	for _, tc := range []struct {
+		name         string
+		recursive    bool
+		submoduleURL func(string) (string, error)
+		wantFiles    []string
+		wantURLs     map[string]string
+		wantErr      string
+	}{{
+		name: "rewritten submodule",
+		submoduleURL: func(u string) (string, error) {
+			if u == commonPath {
+				return mirrorPath, nil
+			}
+			return u, nil
+		},
+		wantFiles: []string{"common/tasks/build.yaml"},
+		wantURLs:  map[string]string{"submodule.common.url": mirrorPath},
+	}, {
+		name: "rejected submodule",
+		submoduleURL: func(u string) (string, error) {
+			if u == commonPath {
+				return "", errNotAllowed
+			}
+			return u, nil
+		},
+		wantErr: "submodule common: not allowed",
+	}, {
+		name:      "rejected nested submodule",
+		recursive: true,
+		submoduleURL: func(u string) (string, error) {
+			if u == nestedPath {
+				return "", errNotAllowed
+			}
+			return u, nil
+		},
+		wantErr: "submodule nested: not allowed",
+	}, {
+		name:      "allowed nested submodule",
+		recursive: true,
+		submoduleURL: func(u string) (string, error) {
+			return u, nil
+		},
+		wantFiles: []string{"common/tasks/build.yaml", "common/nested/task.yaml"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := t.Context()
+			repo, cleanup, err := remote{url: repoPath, cmdExecutor: allowFileSubmodules}.clone(ctx)
+			defer cleanup()
+			if err != nil {
+				t.Fatalf("Error cloning repository %v", err)
+			}
+			if err := repo.checkout(ctx, "main"); err != nil {
+				t.Fatalf("Error checking out revision: %v", err)
+			}
+			err = repo.updateSubmodules(ctx, tc.recursive, tc.submoduleURL)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("Expected error %q but got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Error initializing the submodules: %v", err)
+			}
+			for _, path := range tc.wantFiles {
+				if _, err := repo.getFileContent(path, 1024); err != nil {
+					t.Errorf("Expected %q to be checked out but got %v", path, err)
+				}
+			}
+			for key, want := range tc.wantURLs {
+				out, err := repo.execGit(ctx, "config", key)
+				if err != nil {
+					t.Fatalf("Error getting %s: %v", key, err)
+				}
+				if got := strings.TrimSpace(string(out)); got != want {
+					t.Errorf("Expected %s to be %q but got %q", key, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestSubmoduleCredentials(t *testing.T) {
+	for _, tc := range []struct {
+		url        string
+		wantConfig []string
+	}{{
+		url:        "https://github.com/tektoncd/catalog.git",
+		wantConfig: []string{"--config-env", "http.https://github.com/.extraHeader=GIT_AUTH_HEADER"},
+	}, {
+		url: "git@github.com:tektoncd/catalog.git",
+	}} {
+		t.Run(tc.url, func(t *testing.T) {
+			var cmd *exec.Cmd
+			executor := func(ctx context.Context, name string, args ...string) *exec.Cmd {
+				// Run the command as `echo` args to avoid side effects
+				cmd = exec.CommandContext(ctx, "echo", append([]string{name}, args...)...)
+				return cmd
+			}
+			repo := repository{url: tc.url, username: "git", password: "token", directory: t.TempDir(), executor: executor}
+			if err := repo.updateSubmodules(t.Context(), false, nil); err != nil {
+				t.Fatalf("Error initializing the submodules: %v", err)
+			}
+			want := append(append([]string{"git", "-C", repo.directory}, tc.wantConfig...), "submodule", "update")
+			if got := cmd.Args[1:]; !reflect.DeepEqual(got, want) {
+				t.Errorf("Expected the submodule command %v but got %v", want, got)
+			}
+		})
+	}
+}
+
+func TestSparseCheckout(t *testing.T) {
+	repoPath, _ := createTestRepo(
+		t,
+		[]commitForRepo{
+			{Dir: "tasks/", Filename: "task.yaml", Content: "task"},
+			{Dir: "tasks/nested/", Filename: "nested-task.yaml", Content: "nested task"},
+			{Dir: "pipelines/", Filename: "pipeline.yaml", Content: "pipeline"},
+			{Dir: "tasks-other/", Filename: "task.yaml", Content: "other task"},
+		},
+	)
+
+	ctx := t.Context()
+	repo, cleanup, err := remote{url: repoPath, sparseCheckoutDirectories: []string{"tasks"}}.clone(ctx)
+	defer cleanup()
+	if err != nil {
+		t.Fatalf("Error cloning repository %v", err)
+	}
+	if err := repo.checkout(ctx, "main"); err != nil {
+		t.Fatalf("Error checking out revision: %v", err)
+	}
+
+	for path, expectedContent := range map[string]string{
+		"tasks/task.yaml":               "task",
+		"tasks/nested/nested-task.yaml": "nested task",
+		"README":                        "This is a test",
+	} {
+		content, err := repo.getFileContent(path, 1024)
+		if err != nil {
+			t.Fatalf("Error reading %q: %v", path, err)
+		}
+		if string(content) != expectedContent {
+			t.Errorf("Expected the content of %q to be %q but got %q", path, expectedContent, content)
+		}
+	}
+	for _, path := range []string{"pipelines/pipeline.yaml", "tasks-other/task.yaml"} {
+		if _, err := repo.getFileContent(path, 1024); err == nil || err.Error() != "file does not exist" {
+			t.Errorf("Expected %q not to be checked out but got %v", path, err)
+		}
+	}
+}
+
+func TestResolveRevision(t *testing.T) {
+	repoPath, revisions := createTestRepo(t, []commitForRepo{{
+		Filename: "README.md",
+		Content:  "tagged",
+		Tag:      "v1",
+	}, {
+		Filename: "README.md",
+		Content:  "branch",
+		Branch:   "feature",
+	}, {
+		Filename: "README.md",
+		Content:  "main",
+	}})
+	gitCmd := getGitCmd(t, repoPath)
+	if out, err := gitCmd("tag", "-a", "v2", "-m", "annotated tag", revisions[0]).CombinedOutput(); err != nil {
+		t.Fatalf("couldn't add annotated tag: %q: %v", out, err)
+	}
+
+	for _, tc := range []struct {
+		revision string
+		want     string
+	}{
+		{revision: "main", want: revisions[2]},
+		{revision: "feature", want: revisions[1]},
+		{revision: "refs/heads/feature", want: revisions[1]},
+		{revision: "v1", want: revisions[0]},
+		{revision: "v2", want: revisions[0]},
+		{revision: revisions[1], want: revisions[1]},
+		{revision: "missing", want: ""},
+	} {
+		t.Run(tc.revision, func(t *testing.T) {
+			got, err := remote{url: repoPath}.resolveRevision(t.Context(), tc.revision)
+			if err != nil {
+				t.Fatalf("unexpected error resolving %s: %v", tc.revision, err)
+			}
+			if got != tc.want {
+				t.Errorf("expected %s to be resolved to %q, got %q", tc.revision, tc.want, got)
+			}
+		})
+	}
+
+	if _, err := (remote{url: repoPath + "-missing"}).resolveRevision(t.Context(), "main"); err == nil {
+		t.Error("expected an error resolving a revision of a missing repo")
+	}
+}
+
+func TestCloneTimeout(t *testing.T) {
+	// The listener accepts the connections of git but never responds.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			t.Cleanup(func() { conn.Close() })
+		}
+	}()
+
+	start := time.Now()
+	_, cleanup, err := remote{url: "http://" + listener.Addr().String() + "/repo.git", cloneTimeout: 200 * time.Millisecond}.clone(t.Context())
+	if err == nil {
+		cleanup()
+		t.Fatal("expected the clone of an unresponsive remote to fail")
+	}
+	if want := "git clone timed out after 200ms"; err.Error() != want {
+		t.Errorf("expected the error %q, got %q", want, err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the clone to fail once the timeout expired, it took %s", elapsed)
+	}
+}
+
+func TestSymlinks(t *testing.T) {
+	repoPath, _ := createTestRepo(t, []commitForRepo{{
+		Dir:      "tasks",
+		Filename: "task.yaml",
+		Content:  "kind: Task\n",
+	}})
+	addSymlinkToTestRepo(t, repoPath, "tasks/link.yaml", "task.yaml")
+	addSymlinkToTestRepo(t, repoPath, "linked-tasks", "tasks")
+	addSymlinkToTestRepo(t, repoPath, "nested/link.yaml", "../tasks/link.yaml")
+	addSymlinkToTestRepo(t, repoPath, "broken.yaml", "missing.yaml")
+	addSymlinkToTestRepo(t, repoPath, "escaping.yaml", "../../etc/passwd")
+	addSymlinkToTestRepo(t, repoPath, "absolute.yaml", "/etc/passwd")
+	addSymlinkToTestRepo(t, repoPath, "escaping/link.yaml", "../escaping.yaml")
+
+	repo, cleanup, err := remote{url: repoPath}.clone(t.Context())
+	defer cleanup()
+	if err != nil {
+		t.Fatalf("unexpected error cloning the test repo: %v", err)
+	}
+	if err := repo.checkout(t.Context(), "main"); err != nil {
+		t.Fatalf("unexpected error checking out main: %v", err)
+	}
+
+	for _, tc := range []struct {
+		path    string
+		want    string
+		wantErr error
+	}{
+		{path: "tasks/link.yaml", want: "kind: Task\n"},
+		{path: "linked-tasks/task.yaml", want: "kind: Task\n"},
+		{path: "nested/link.yaml", want: "kind: Task\n"},
+		{path: "broken.yaml", wantErr: errBrokenSymlink},
+		{path: "escaping.yaml", wantErr: errSymlinkEscapesRepo},
+		{path: "absolute.yaml", wantErr: errSymlinkEscapesRepo},
+		{path: "escaping/link.yaml", wantErr: errSymlinkEscapesRepo},
+		{path: "linked-tasks/missing.yaml", wantErr: errFileNotExist},
+		{path: "missing/../../../../etc/passwd", wantErr: errFileNotExist},
+		{path: "missing/../escaping.yaml", wantErr: errFileNotExist},
+		{path: "missing/../tasks/task.yaml", wantErr: errFileNotExist},
+	} {
+		t.Run(tc.path, func(t *testing.T) {
+			got, err := repo.getFileContent(tc.path, 1024)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected the error %v reading %s, got %v", tc.wantErr, tc.path, err)
+			}
+			if string(got) != tc.want {
+				t.Errorf("expected the content %q reading %s, got %q", tc.want, tc.path, got)
+			}
+		})
+	}
+
+	manifests, err := repo.getDirectoryManifests("linked-tasks", 1024)
+	if err != nil {
+		t.Fatalf("unexpected error listing the manifests of a symlinked directory: %v", err)
+	}
+	if len(manifests) != 2 || string(manifests[0].content) != "kind: Task\n" || string(manifests[1].content) != "kind: Task\n" {
+		t.Errorf("expected the file and the symlink to it to be listed, got %+v", manifests)
+	}
+	if _, err := repo.getDirectoryManifests("escaping", 1024); !errors.Is(err, errSymlinkEscapesRepo) {
+		t.Errorf("expected listing a directory with an escaping symlink to fail, got %v", err)
+	}
+	if _, err := repo.getFileContent("../README", 1024); err == nil {
+		t.Error("expected reading a path outside of the repository to fail")
+	}
+}
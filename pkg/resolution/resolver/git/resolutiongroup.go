@@ -0,0 +1,106 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/resolution/common"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"golang.org/x/sync/singleflight"
+)
+
+// DefaultSharedResolutionTimeout is the timeout of the resolutions shared by
+// the ResolutionGroup when fetch-timeout isn't set, which is the default
+// timeout of the resolutions.
+const DefaultSharedResolutionTimeout = time.Minute
+
+// ResolutionGroup deduplicates the concurrent resolutions of the same file,
+// like the ones of the tasks of a PipelineRun referencing the same remote
+// Task, so that they share one clone or one set of API requests rather than
+// each fetching the file. Unlike the CloneCache, it doesn't keep the resolved
+// files once they are returned.
+//
+// A nil ResolutionGroup doesn't deduplicate the resolutions.
+type ResolutionGroup struct {
+	group singleflight.Group
+}
+
+// NewResolutionGroup returns an empty ResolutionGroup.
+func NewResolutionGroup() *ResolutionGroup {
+	return &ResolutionGroup{}
+}
+
+// Do returns the resource resolved by resolve for the params, which is shared
+// with the concurrent resolutions of the same params in the namespace of the
+// request. Each resolution gets its own copy of the resource.
+//
+// The shared resolution isn't cancelled with the context of the resolution
+// which started it, as the others wait for it: it runs with the values of
+// the context but its own timeout. Each resolution stops waiting for it when
+// its own context is done.
+func (rg *ResolutionGroup) Do(ctx context.Context, params map[string]string, timeout time.Duration, resolve func(context.Context) (framework.ResolvedResource, error)) (framework.ResolvedResource, error) {
+	if rg == nil {
+		return resolve(ctx)
+	}
+	shared := rg.group.DoChan(resolutionKey(ctx, params), func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+		defer cancel()
+		return resolve(ctx)
+	})
+	var val interface{}
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result := <-shared:
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		val = result.Val
+	}
+	res, ok := val.(*resolvedGitResource)
+	if !ok {
+		return val.(framework.ResolvedResource), nil
+	}
+	res = copyResource(res)
+	// The resource may have been resolved with another URL of the repo.
+	if repoURL := params[UrlParam]; repoURL != "" {
+		res.URL = repoURL
+	}
+	return res, nil
+}
+
+// resolutionKey returns the key of the resolutions of the params which share
+// the same fetch: the URL of the repo is normalized, and the namespace of the
+// request is part of the key as the secrets of the params are read from it.
+func resolutionKey(ctx context.Context, params map[string]string) string {
+	var b strings.Builder
+	b.WriteString(common.RequestNamespace(ctx))
+	for _, name := range slices.Sorted(maps.Keys(params)) {
+		value := params[name]
+		if name == UrlParam {
+			value = normalizeRepoURL(value)
+		}
+		fmt.Fprintf(&b, "\x00%s=%s", name, value)
+	}
+	return b.String()
+}
@@ -20,8 +20,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
+	"path"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -73,9 +76,14 @@ type Resolver struct {
 	logger     *zap.SugaredLogger
 	cache      *cache.LRUExpireCache
 	ttl        time.Duration
+	cloneCache *CloneCache
+	// resolutions deduplicates the concurrent resolutions of the same file.
+	resolutions *ResolutionGroup
 
 	// Used in testing
-	clientFunc func(string, string, string, ...factory.ClientOptionFunc) (*scm.Client, error)
+	clientFunc            func(string, string, string, ...factory.ClientOptionFunc) (*scm.Client, error)
+	installationTokenFunc InstallationTokenFunc
+	cloneFunc             func(context.Context, remote) (*repository, func(), error)
 }
 
 // Initialize performs any setup required by the gitresolver.
@@ -84,12 +92,35 @@ func (r *Resolver) Initialize(ctx context.Context) error {
 	r.logger = logging.FromContext(ctx)
 	r.cache = cache.NewLRUExpireCache(cacheSize)
 	r.ttl = ttl
+	r.cloneCache = NewCloneCache()
+	r.resolutions = NewResolutionGroup()
+	if err := RegisterMetrics(); err != nil {
+		return fmt.Errorf("failed to register the metrics of the git resolver: %w", err)
+	}
 	if r.clientFunc == nil {
 		r.clientFunc = factory.NewClient
 	}
+	if r.installationTokenFunc == nil {
+		r.installationTokenFunc = MintInstallationToken
+	}
 	return nil
 }
 
+// InvalidateRepo removes the files resolved from the repo from the clone
+// cache and returns their number.
+func (r *Resolver) InvalidateRepo(repoURL string) int {
+	if r.cloneCache == nil {
+		return 0
+	}
+	return r.cloneCache.InvalidateRepo(repoURL)
+}
+
+// CloneCacheStats returns the number of resolutions from a cloned repo
+// served from the clone cache, and the number of them which cloned the repo.
+func (r *Resolver) CloneCacheStats() (hits, misses int64) {
+	return r.cloneCache.Stats()
+}
+
 // GetName returns the string name that the gitresolver should be
 // associated with.
 func (r *Resolver) GetName(_ context.Context) string {
@@ -128,13 +159,25 @@ func (r *Resolver) Resolve(ctx context.Context, origParams []pipelinev1.Param) (
 		Cache:      r.cache,
 		TTL:        r.ttl,
 		KubeClient: r.kubeClient,
-	}
 
-	if params[UrlParam] != "" {
-		return g.ResolveGitClone(ctx)
+		InstallationTokenFunc: r.installationTokenFunc,
+		CloneCache:            r.cloneCache,
+		ClientFunc:            r.clientFunc,
+		cloneFunc:             r.cloneFunc,
 	}
 
-	return g.ResolveAPIGit(ctx, r.clientFunc)
+	timeout, err := r.GetResolutionTimeout(ctx, DefaultSharedResolutionTimeout, params)
+	if err != nil {
+		return nil, err
+	}
+	return ObserveResolution(ctx, params, func() (framework.ResolvedResource, error) {
+		return r.resolutions.Do(ctx, params, timeout, func(ctx context.Context) (framework.ResolvedResource, error) {
+			if params[UrlParam] != "" {
+				return g.ResolveGitClone(ctx)
+			}
+			return g.ResolveAPIGit(ctx, r.clientFunc)
+		})
+	})
 }
 
 func ValidateParams(ctx context.Context, params []pipelinev1.Param) error {
@@ -149,6 +192,24 @@ func ValidateParams(ctx context.Context, params []pipelinev1.Param) error {
 	return nil
 }
 
+// repoShorthandRegex matches the org/repo shorthand of the URL of a repo,
+// without a scheme.
+var repoShorthandRegex = regexp.MustCompile(`^[\w.-]+/[\w.-]+$`)
+
+// expandRepoShorthand returns the clone URL of the repo of the org/repo
+// shorthand, on the server of the default-url configuration.
+func expandRepoShorthand(conf ScmConfig, shorthand string) (string, error) {
+	org, repo, _ := strings.Cut(shorthand, "/")
+	if org == "." || org == ".." || repo == "." || repo == ".." {
+		return "", fmt.Errorf("invalid git repository url: %s", shorthand)
+	}
+	prefix := urlPrefix(conf.URL)
+	if prefix == "" {
+		return "", fmt.Errorf("invalid git repository url: %s, the org/repo shorthand requires an http(s) '%s' in the git resolver config", shorthand, DefaultURLKey)
+	}
+	return prefix + org + "/" + strings.TrimSuffix(repo, ".git") + ".git", nil
+}
+
 // validateRepoURL validates if the given URL is a valid git, http, https URL or
 // starting with a / (a local repository).
 func validateRepoURL(url string) bool {
@@ -164,6 +225,18 @@ type GitResolver struct {
 	Cache      *cache.LRUExpireCache
 	TTL        time.Duration
 	KubeClient kubernetes.Interface
+	// InstallationTokenFunc mints the installation tokens of the GitHub App
+	// authenticating to the SCM API, MintInstallationToken if nil.
+	InstallationTokenFunc InstallationTokenFunc
+	// CloneCache caches the files resolved from the cloned repos, nothing is
+	// cached if nil.
+	CloneCache *CloneCache
+	// ClientFunc creates the SCM API clients of the pre-check of the paths
+	// of the cloned repos, factory.NewClient if nil.
+	ClientFunc func(string, string, string, ...factory.ClientOptionFunc) (*scm.Client, error)
+
+	// Used in testing
+	cloneFunc func(context.Context, remote) (*repository, func(), error)
 }
 
 func (g *GitResolver) ResolveGitClone(ctx context.Context) (framework.ResolvedResource, error) {
@@ -178,17 +251,11 @@ func (g *GitResolver) ResolveGitClone(ctx context.Context) (framework.ResolvedRe
 			return nil, errors.New("default Git Repo Url was not set during installation of the git resolver")
 		}
 	}
-	revision := g.Params[RevisionParam]
+	revision := g.effectiveRevision(conf)
 	if revision == "" {
-		revisionString := conf.Revision
-		if revisionString == "" {
-			return nil, errors.New("default Git Revision was not set during installation of the git resolver")
-		}
+		return nil, errors.New("default Git Revision was not set during installation of the git resolver")
 	}
 
-	var username string
-	var password string
-
 	secretRef := &secretCacheKey{
 		name: g.Params[GitTokenParam],
 		key:  g.Params[GitTokenKeyParam],
@@ -202,18 +269,213 @@ func (g *GitResolver) ResolveGitClone(ctx context.Context) (framework.ResolvedRe
 		secretRef = nil
 	}
 
+	path := g.Params[PathParam]
+	maxFileSize, err := conf.GetMaxFileSize()
+	if err != nil {
+		return nil, err
+	}
+
+	sparseDirectories, err := sparseCheckoutDirectories(g.Params[SparseCheckoutDirectoriesParam], path)
+	if err != nil {
+		return nil, err
+	}
+
+	cloneTimeout, err := conf.GetCloneTimeout()
+	if err != nil {
+		return nil, err
+	}
+	fetchURL, err := conf.rewriteURL(repoURL)
+	if err != nil {
+		return nil, err
+	}
+	mirrorURL, mirrored, err := conf.mirrorURL(repoURL)
+	if err != nil {
+		return nil, err
+	}
+	mirrorFallback, err := conf.GetMirrorFallback()
+	if err != nil {
+		return nil, err
+	}
+	username, password, err := g.cloneCredentials(ctx, conf, fetchURL, secretRef)
+	if err != nil {
+		return nil, err
+	}
+	precheckRes, err := g.precheckClonePath(ctx, conf, repoURL, revision, path, maxFileSize)
+	if err != nil {
+		return nil, err
+	}
+	if precheckRes != nil {
+		return precheckRes, nil
+	}
+	rem := remote{url: fetchURL, username: username, password: password, tokenScheme: g.Params[GitTokenSchemeParam], sparseCheckoutDirectories: sparseDirectories, submodules: g.Params[SubmodulesParam], submoduleURL: conf.submoduleURL, cloneTimeout: cloneTimeout}
+	var res *resolvedGitResource
+	if mirrored {
+		mirrorRem := rem
+		mirrorRem.url = mirrorURL
+		// The gitToken secret authenticates to the upstream host, so the
+		// mirror, possibly a less trusted server, is only sent the token of
+		// the credential plugin if it is one of its hosts.
+		mirrorRem.username, mirrorRem.password, err = g.cloneCredentials(ctx, conf, mirrorURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		if mirrorRem.password == "" {
+			mirrorRem.tokenScheme = ""
+		}
+		res, err = g.resolveCloneFromRemote(ctx, conf, mirrorRem, revision, path, maxFileSize)
+		if err != nil && isUnreachableRemoteError(err) {
+			if mirrorFallback == mirrorFallbackFail {
+				return nil, fmt.Errorf("couldn't clone the repository from its mirror %s and the %s of the git resolver config is %q: %w", mirrorURL, MirrorFallbackKey, mirrorFallbackFail, err)
+			}
+			g.Logger.Infof("couldn't clone the repository from its mirror %s, falling back to %s: %v", mirrorURL, fetchURL, err)
+			res, err = g.resolveCloneFromRemote(ctx, conf, rem, revision, path, maxFileSize)
+		}
+	} else {
+		res, err = g.resolveCloneFromRemote(ctx, conf, rem, revision, path, maxFileSize)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// The provenance records the url param, and not the URL it was
+	// rewritten to or mirrored from.
+	if mirrored || res.URL != repoURL {
+		res.FetchURL = res.URL
+	}
+	res.URL = repoURL
+	res.Mode = ResolutionModeClone
+	return res, nil
+}
+
+// cloneCredentials returns the username and the password cloning the repo
+// from the URL, the one it is actually fetched from once rewritten or
+// mirrored. The token of the credential plugin takes precedence over the
+// gitToken secret, for the repos of the hosts of the config.
+func (g *GitResolver) cloneCredentials(ctx context.Context, conf ScmConfig, cloneURL string, secretRef *secretCacheKey) (string, string, error) {
+	if conf.usesCredentialPluginFor(cloneURL) {
+		token, err := g.getProviderToken(ctx, conf)
+		if err != nil {
+			return "", "", err
+		}
+		return "git", token, nil
+	}
 	if secretRef != nil {
 		gitToken, err := g.getAPIToken(ctx, secretRef, GitTokenKeyParam)
+		if err != nil {
+			return "", "", err
+		}
+		return "git", string(gitToken), nil
+	}
+	return "", "", nil
+}
+
+// resolveCloneFromRemote resolves the file, or the directory, at path from a
+// clone of the remote at the revision, resolving the revision to the highest
+// matching tag first if it is a semver constraint.
+func (g *GitResolver) resolveCloneFromRemote(ctx context.Context, conf ScmConfig, rem remote, revision, path string, maxFileSize int64) (*resolvedGitResource, error) {
+	tag := ""
+	if isSemverRevision(revision) {
+		var err error
+		tag, err = resolveSemverTag(ctx, rem, revision)
 		if err != nil {
 			return nil, err
 		}
-		username = "git"
-		password = string(gitToken)
+		revision = "refs/tags/" + tag
 	}
+	res, err := g.resolveClone(ctx, conf, rem, revision, path, maxFileSize)
+	if err != nil {
+		return nil, err
+	}
+	res.URL = rem.url
+	res.Tag = tag
+	res.EffectiveRevision = revision
+	return res, nil
+}
 
-	path := g.Params[PathParam]
+// effectiveRevision returns the revision param, or else the default revision
+// of the config.
+func (g *GitResolver) effectiveRevision(conf ScmConfig) string {
+	if revision := g.Params[RevisionParam]; revision != "" {
+		return revision
+	}
+	return conf.Revision
+}
+
+// resolveSemverTag returns the highest tag of the remote matching the semver
+// constraint of the revision.
+func resolveSemverTag(ctx context.Context, rem remote, revision string) (string, error) {
+	constraint, err := parseSemverRevision(revision)
+	if err != nil {
+		return "", err
+	}
+	tags, err := rem.listTags(ctx)
+	if err != nil {
+		return "", fmt.Errorf("couldn't list the tags of the repository: %w", err)
+	}
+	return constraint.highestMatchingTag(tags)
+}
 
-	repo, cleanupFunc, err := remote{url: repoURL, username: username, password: password}.clone(ctx)
+// resolveClone resolves the file, or the directory, at path from the clone
+// cache if the revision is a commit already resolved, or else from a clone
+// of the repo at the revision.
+func (g *GitResolver) resolveClone(ctx context.Context, conf ScmConfig, rem remote, revision, path string, maxFileSize int64) (*resolvedGitResource, error) {
+	if g.CloneCache == nil {
+		return g.cloneAndResolve(ctx, rem, revision, path, maxFileSize)
+	}
+	cacheTTL, err := conf.GetCacheTTL()
+	if err != nil {
+		return nil, err
+	}
+	cacheMaxEntries, err := conf.GetCacheMaxEntries()
+	if err != nil {
+		return nil, err
+	}
+	if cacheTTL == 0 || cacheMaxEntries == 0 {
+		return g.cloneAndResolve(ctx, rem, revision, path, maxFileSize)
+	}
+	// The revision is resolved with the credentials of the request on each
+	// resolution, so that a branch which moved isn't resolved from the cache
+	// and the files of a private repo aren't resolved without access to it.
+	sha, err := rem.resolveRevision(ctx, revision)
+	if err != nil || sha == "" {
+		if err != nil {
+			g.Logger.Debugf("couldn't resolve revision %s of %s without cloning it: %v", revision, rem.url, err)
+		}
+		return g.cloneAndResolve(ctx, rem, revision, path, maxFileSize)
+	}
+	key := cloneCacheKey{
+		url:                normalizeRepoURL(rem.url),
+		sha:                sha,
+		path:               path,
+		maxFileSize:        maxFileSize,
+		ignoreExportIgnore: g.Params[IgnoreExportIgnoreParam] == "true",
+		submodules:         rem.submodules,
+	}
+	if rem.submodules == submodulesDirect || rem.submodules == submodulesRecursive {
+		// The URLs of the submodules are rewritten and checked with the
+		// config.
+		key.configKey = g.Params[ConfigKeyParam]
+	}
+	res, err := g.CloneCache.getOrLoad(ctx, key, cacheTTL, cacheMaxEntries, func() (*resolvedGitResource, error) {
+		return g.cloneAndResolve(ctx, rem, revision, path, maxFileSize)
+	})
+	if err != nil {
+		return nil, err
+	}
+	// The resource may have been resolved with another URL of the repo.
+	res.URL = rem.url
+	return res, nil
+}
+
+// cloneAndResolve clones the repo at the revision to resolve the file, or the
+// directory, at path.
+func (g *GitResolver) cloneAndResolve(ctx context.Context, rem remote, revision, path string, maxFileSize int64) (*resolvedGitResource, error) {
+	clone := g.cloneFunc
+	if clone == nil {
+		clone = func(ctx context.Context, r remote) (*repository, func(), error) {
+			return r.clone(ctx)
+		}
+	}
+	repo, cleanupFunc, err := clone(ctx, rem)
 	defer cleanupFunc()
 	if err != nil {
 		return nil, fmt.Errorf("error resolving repository: %w", err)
@@ -223,25 +485,69 @@ func (g *GitResolver) ResolveGitClone(ctx context.Context) (framework.ResolvedRe
 	if err != nil {
 		return nil, err
 	}
+	if rem.submodules == submodulesDirect || rem.submodules == submodulesRecursive {
+		if err := repo.updateSubmodules(ctx, rem.submodules == submodulesRecursive, rem.submoduleURL); err != nil {
+			return nil, fmt.Errorf("error initializing the submodules: %w", err)
+		}
+	}
 
 	fullRevision, err := repo.currentRevision(ctx)
 	if err != nil {
 		return nil, err
 	}
+	// The metadata of the commit is only informative.
+	commit, err := repo.currentCommitMetadata(ctx)
+	if err != nil {
+		g.Logger.Debugf("couldn't read the metadata of the commit %s of %s: %v", fullRevision, repo.url, err)
+	}
 
-	fileContents, err := repo.getFileContent(path)
+	isDir, err := repo.isDirectory(path)
 	if err != nil {
-		return nil, fmt.Errorf("error opening file %q: %w", path, err)
+		return nil, err
+	}
+	var fileContents []byte
+	if isDir || isDirectoryPath(path) {
+		fileContents, err = g.resolveDirectory(path, maxFileSize, repo.getDirectoryManifests, repo.readFileIfExists)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		fileContents, err = repo.getFileContent(path, maxFileSize)
+		if err != nil {
+			return nil, fmt.Errorf("error opening file %q: %w", path, err)
+		}
+		if err := g.checkExportIgnore(path, repo.readFileIfExists); err != nil {
+			return nil, err
+		}
 	}
 
 	return &resolvedGitResource{
 		Revision: fullRevision,
+		Commit:   commit,
 		Content:  fileContents,
 		URL:      repo.url,
 		Path:     path,
 	}, nil
 }
 
+// checkExportIgnore returns an error if the given path is marked export-ignore
+// in the .gitattributes files of the resolved tree, unless the
+// ignoreExportIgnore param is set, so that only the files which are part
+// of the archives of the repo can be resolved.
+func (g *GitResolver) checkExportIgnore(path string, readFile readFileFunc) error {
+	if g.Params[IgnoreExportIgnoreParam] == "true" {
+		return nil
+	}
+	attrs, err := loadGitAttributes(path, readFile)
+	if err != nil {
+		return err
+	}
+	if attrs.exportIgnored(path) {
+		return fmt.Errorf("path %q is marked %s in .gitattributes, set the '%s' param to \"true\" to resolve it anyway", path, exportIgnoreAttribute, IgnoreExportIgnoreParam)
+	}
+	return nil
+}
+
 var _ framework.ConfigWatcher = &Resolver{}
 
 // GetConfigName returns the name of the git resolver's configmap.
@@ -249,6 +555,14 @@ func (r *Resolver) GetConfigName(context.Context) string {
 	return ConfigMapName
 }
 
+var _ framework.ParamDescriber = &Resolver{}
+
+// DescribeParams returns the description of the params accepted by the
+// gitresolver.
+func (r *Resolver) DescribeParams(context.Context) []framework.ParamDescription {
+	return DescribeParams()
+}
+
 var _ framework.TimedResolution = &Resolver{}
 
 // GetResolutionTimeout returns a time.Duration for the amount of time a
@@ -299,14 +613,26 @@ func PopulateDefaultParams(ctx context.Context, params []pipelinev1.Param) (map[
 	}
 
 	if paramsMap[UrlParam] == "" && paramsMap[RepoParam] == "" {
-		urlString := conf.URL
-		if urlString != "" {
-			paramsMap[UrlParam] = urlString
-		} else {
+		// The default repo of the SCM API takes precedence over the default
+		// URL, which is set in the default configuration.
+		switch {
+		case conf.Repo != "":
+			paramsMap[RepoParam] = conf.Repo
+		case conf.URL != "":
+			paramsMap[UrlParam] = conf.URL
+		default:
 			return nil, fmt.Errorf("must specify one of '%s' or '%s'", UrlParam, RepoParam)
 		}
 	}
 
+	if repoShorthandRegex.MatchString(paramsMap[UrlParam]) {
+		expandedURL, err := expandRepoShorthand(conf, paramsMap[UrlParam])
+		if err != nil {
+			return nil, err
+		}
+		paramsMap[UrlParam] = expandedURL
+	}
+
 	if paramsMap[RepoParam] != "" {
 		if _, ok := paramsMap[OrgParam]; !ok {
 			defaultOrg := conf.Org
@@ -316,20 +642,117 @@ func PopulateDefaultParams(ctx context.Context, params []pipelinev1.Param) (map[
 				return nil, fmt.Errorf("'%s' is required when '%s' is specified", OrgParam, RepoParam)
 			}
 		}
+		scmType := paramsMap[ScmTypeParam]
+		if scmType == "" {
+			scmType = conf.SCMType
+		}
+		if scmType == scmTypeAzure {
+			if err := validateAzureOrg(paramsMap[OrgParam]); err != nil {
+				return nil, err
+			}
+		}
 	}
 	if len(missingParams) > 0 {
 		return nil, fmt.Errorf("missing required git resolver params: %s", strings.Join(missingParams, ", "))
 	}
 
+	if isSemverRevision(paramsMap[RevisionParam]) {
+		if paramsMap[RepoParam] != "" {
+			return nil, fmt.Errorf("'%s' revisions can only be used with '%s'", semverRevisionPrefix, UrlParam)
+		}
+		if _, err := parseSemverRevision(paramsMap[RevisionParam]); err != nil {
+			return nil, err
+		}
+	}
+
+	if v, ok := paramsMap[IgnoreExportIgnoreParam]; ok && v != "true" && v != "false" {
+		return nil, fmt.Errorf("invalid value for '%s' param: %q, must be \"true\" or \"false\"", IgnoreExportIgnoreParam, v)
+	}
+
+	if v, ok := paramsMap[SubmodulesParam]; ok {
+		if v != submodulesDirect && v != submodulesRecursive && v != "false" {
+			return nil, fmt.Errorf("invalid value for '%s' param: %q, must be \"true\", \"false\" or \"%s\"", SubmodulesParam, v, submodulesRecursive)
+		}
+		if paramsMap[RepoParam] != "" && v != "false" {
+			return nil, fmt.Errorf("'%s' can only be used with '%s'", SubmodulesParam, UrlParam)
+		}
+	}
+
+	if v, ok := paramsMap[GitTokenSchemeParam]; ok {
+		if err := validateGitTokenScheme(v); err != nil {
+			return nil, fmt.Errorf("invalid value for '%s' param: %q, %w", GitTokenSchemeParam, v, err)
+		}
+		if paramsMap[RepoParam] != "" {
+			return nil, fmt.Errorf("'%s' can only be used with '%s'", GitTokenSchemeParam, UrlParam)
+		}
+	} else if paramsMap[RepoParam] == "" && conf.GitTokenScheme != "" {
+		scheme, err := conf.GetGitTokenScheme()
+		if err != nil {
+			return nil, err
+		}
+		paramsMap[GitTokenSchemeParam] = scheme
+	}
+
+	if _, ok := paramsMap[SparseCheckoutDirectoriesParam]; ok {
+		if paramsMap[RepoParam] != "" {
+			return nil, fmt.Errorf("'%s' can only be used with '%s'", SparseCheckoutDirectoriesParam, UrlParam)
+		}
+	} else if paramsMap[RepoParam] == "" && conf.SparseCheckoutDirectories != "" {
+		paramsMap[SparseCheckoutDirectoriesParam] = conf.SparseCheckoutDirectories
+	}
+	if _, err := sparseCheckoutDirectories(paramsMap[SparseCheckoutDirectoriesParam], paramsMap[PathParam]); err != nil {
+		return nil, err
+	}
+
 	// validate the url params if we are not using the SCM API
 	if paramsMap[RepoParam] == "" && paramsMap[OrgParam] == "" && !validateRepoURL(paramsMap[UrlParam]) {
 		return nil, fmt.Errorf("invalid git repository url: %s", paramsMap[UrlParam])
 	}
 
+	if err := checkAllowedRepo(ctx, conf, paramsMap); err != nil {
+		return nil, err
+	}
+
 	// TODO(sbwsg): validate pathInRepo is valid relative pathInRepo
 	return paramsMap, nil
 }
 
+// sparseCheckoutDirectories returns the directories of the comma separated
+// list of the sparseCheckoutDirectories param, which must be relative paths
+// in the repo and contain the directory of the file at pathInRepo, or the
+// directory at pathInRepo if it ends with a slash.
+func sparseCheckoutDirectories(value, pathInRepo string) ([]string, error) {
+	var directories []string
+	for _, d := range strings.Split(value, ",") {
+		d = strings.TrimSpace(d)
+		if d == "" {
+			continue
+		}
+		cleaned := path.Clean(d)
+		if path.IsAbs(cleaned) || cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+			return nil, fmt.Errorf("invalid directory %q in '%s' param, must be a directory of the repo", d, SparseCheckoutDirectoriesParam)
+		}
+		directories = append(directories, cleaned)
+	}
+	if len(directories) == 0 {
+		return nil, nil
+	}
+	// The files at the root of the repo are always checked out.
+	dir := path.Dir(strings.TrimPrefix(path.Clean("/"+pathInRepo), "/"))
+	if isDirectoryPath(pathInRepo) {
+		dir = strings.TrimPrefix(path.Clean("/"+pathInRepo), "/")
+	}
+	if dir == "." || dir == "" {
+		return directories, nil
+	}
+	for _, d := range directories {
+		if dir == d || strings.HasPrefix(dir, d+"/") {
+			return directories, nil
+		}
+	}
+	return nil, fmt.Errorf("'%s' param %q doesn't contain the directory %q of '%s'", SparseCheckoutDirectoriesParam, value, dir, PathParam)
+}
+
 // supports the SPDX format which is recommended by in-toto
 // ref: https://spdx.dev/spdx-specification-21-web-version/#h.49x2ik5
 // ref: https://github.com/in-toto/attestation/blob/main/spec/field_types.md
@@ -341,15 +764,76 @@ func spdxGit(url string) string {
 // the resolved file []byte data and an annotation map for any metadata.
 type resolvedGitResource struct {
 	Revision string
-	Content  []byte
-	Org      string
-	Repo     string
-	Path     string
-	URL      string
+	// Tag is the tag the revision was resolved from, when it is a semver
+	// constraint.
+	Tag     string
+	Commit  commitMetadata
+	Content []byte
+	Org     string
+	Repo    string
+	Path    string
+	URL     string
+	// FetchURL is the URL the repo was fetched from when the URL was
+	// rewritten by the url-rewrite of the config, or has a repo-mirror.
+	FetchURL string
+	// Mode is the resolution mode which fetched the file,
+	// ResolutionModeAPI or ResolutionModeClone.
+	Mode string
+	// EffectiveRevision is the symbolic revision the file was resolved at,
+	// the revision param or else the default revision of the config.
+	EffectiveRevision string
 }
 
 var _ framework.ResolvedResource = &resolvedGitResource{}
 
+// maxCommitSubjectLength is the maximum number of characters of the subject
+// of the commit annotation.
+const maxCommitSubjectLength = 120
+
+// commitMetadata is the metadata of the resolved commit, a field is empty
+// when the backend doesn't return it.
+type commitMetadata struct {
+	// Author is the name and email of the author, as "name <email>".
+	Author string
+	// Timestamp is the RFC 3339 date the commit was committed.
+	Timestamp string
+	// Subject is the first line of the message, truncated to
+	// maxCommitSubjectLength characters.
+	Subject string
+}
+
+func newCommitMetadata(name, email string, date time.Time, message string) commitMetadata {
+	var m commitMetadata
+	switch {
+	case name != "" && email != "":
+		m.Author = fmt.Sprintf("%s <%s>", name, email)
+	case name != "":
+		m.Author = name
+	default:
+		m.Author = email
+	}
+	if !date.IsZero() {
+		m.Timestamp = date.UTC().Format(time.RFC3339)
+	}
+	subject, _, _ := strings.Cut(strings.TrimSpace(message), "\n")
+	subject = strings.TrimSpace(subject)
+	if runes := []rune(subject); len(runes) > maxCommitSubjectLength {
+		subject = string(runes[:maxCommitSubjectLength])
+	}
+	m.Subject = subject
+	return m
+}
+
+// newSCMCommitMetadata returns the metadata of a commit returned by the SCM
+// API, with the date it was committed, or else authored, as timestamp.
+func newSCMCommitMetadata(commit *scm.Commit) commitMetadata {
+	date := commit.Committer.Date
+	if date.IsZero() {
+		date = commit.Author.Date
+	}
+	return newCommitMetadata(commit.Author.Name, commit.Author.Email, date, commit.Message)
+}
+
 // Data returns the bytes of the file resolved from git.
 func (r *resolvedGitResource) Data() []byte {
 	return r.Content
@@ -365,21 +849,47 @@ func (r *resolvedGitResource) Annotations() map[string]string {
 		common.AnnotationKeyContentType: yamlContentType,
 	}
 
+	if r.Mode != "" {
+		m[AnnotationKeyResolutionMode] = r.Mode
+	}
+	if r.FetchURL != "" {
+		m[AnnotationKeyFetchURL] = r.FetchURL
+	}
+
 	if r.Org != "" {
 		m[AnnotationKeyOrg] = r.Org
 	}
 	if r.Repo != "" {
 		m[AnnotationKeyRepo] = r.Repo
 	}
+	if r.Tag != "" {
+		m[AnnotationKeyTag] = r.Tag
+	}
+	if r.EffectiveRevision != "" {
+		m[AnnotationKeyEffectiveRevision] = r.EffectiveRevision
+	}
+	if r.Commit.Author != "" {
+		m[AnnotationKeyCommitAuthor] = r.Commit.Author
+	}
+	if r.Commit.Timestamp != "" {
+		m[AnnotationKeyCommitTimestamp] = r.Commit.Timestamp
+	}
+	if r.Commit.Subject != "" {
+		m[AnnotationKeyCommitMessageSubject] = r.Commit.Subject
+	}
 
 	return m
 }
 
 // RefSource is the source reference of the remote data that records where the remote
-// file came from including the url, digest and the entrypoint.
+// file came from including the url, the effective revision, digest and the entrypoint.
 func (r *resolvedGitResource) RefSource() *pipelinev1.RefSource {
+	uri := spdxGit(r.URL)
+	if r.EffectiveRevision != "" {
+		uri += "@" + r.EffectiveRevision
+	}
 	return &pipelinev1.RefSource{
-		URI: spdxGit(r.URL),
+		URI: uri,
 		Digest: map[string]string{
 			"sha1": r.Revision,
 		},
@@ -393,7 +903,37 @@ type secretCacheKey struct {
 	key  string
 }
 
+// ResolveAPIGit fetches the file, or the directory, from the repo with the
+// SCM API. If the SCM API rejects the requests because of their credentials
+// or of a rate limit and the api-fallback-to-clone config is set, the file is
+// fetched from an anonymous clone of the repo instead.
 func (g *GitResolver) ResolveAPIGit(ctx context.Context, clientFunc func(string, string, string, ...factory.ClientOptionFunc) (*scm.Client, error)) (framework.ResolvedResource, error) {
+	res, err := g.resolveAPIGit(ctx, clientFunc)
+	if err == nil {
+		return res, nil
+	}
+	if !isAPIFallbackError(err) {
+		return nil, err
+	}
+	conf, confErr := GetScmConfigForParamConfigKey(ctx, g.Params)
+	if confErr != nil {
+		return nil, err
+	}
+	fallback, confErr := conf.GetAPIFallbackToClone()
+	if confErr != nil {
+		return nil, confErr
+	}
+	if !fallback {
+		return nil, err
+	}
+	res, err = g.resolveAPIFallbackClone(ctx, conf, err)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (g *GitResolver) resolveAPIGit(ctx context.Context, clientFunc func(string, string, string, ...factory.ClientOptionFunc) (*scm.Client, error)) (*resolvedGitResource, error) {
 	// If we got here, the "repo" param was specified, so use the API approach
 	scmType, serverURL, err := getSCMTypeAndServerURL(ctx, g.Params)
 	if err != nil {
@@ -411,50 +951,243 @@ func (g *GitResolver) ResolveAPIGit(ctx context.Context, clientFunc func(string,
 	} else {
 		secretRef = nil
 	}
-	apiToken, err := g.getAPIToken(ctx, secretRef, APISecretNameKey)
+	conf, err := GetScmConfigForParamConfigKey(ctx, g.Params)
 	if err != nil {
 		return nil, err
 	}
-	scmClient, err := clientFunc(scmType, serverURL, string(apiToken))
+	var apiToken string
+	// The token of the credential plugin takes precedence over the token
+	// secrets for the server of the config, and the token secret passed in
+	// the params over the GitHub App of the config.
+	switch {
+	case conf.usesCredentialPluginFor(effectiveServerURL(scmType, serverURL)):
+		apiToken, err = g.getProviderToken(ctx, conf)
+		if err != nil {
+			return nil, err
+		}
+	case secretRef == nil && conf.usesGitHubApp():
+		mintToken := g.InstallationTokenFunc
+		if mintToken == nil {
+			mintToken = MintInstallationToken
+		}
+		apiToken, err = g.getInstallationToken(ctx, conf, scmType, mintToken)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		secretVal, err := g.getAPIToken(ctx, secretRef, APISecretNameKey)
+		if err != nil {
+			return nil, err
+		}
+		apiToken = string(secretVal)
+	}
+	var clientOpts []factory.ClientOptionFunc
+	if isStashSCMType(scmType) {
+		clientOpts = append(clientOpts, stashBaseURL)
+	}
+	scmClient, err := clientFunc(scmType, serverURL, apiToken, clientOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create SCM client: %w", err)
 	}
+	limitContentResponses(scmClient)
+
+	maxFileSize, err := conf.GetMaxFileSize()
+	if err != nil {
+		return nil, err
+	}
 
 	orgRepo := fmt.Sprintf("%s/%s", g.Params[OrgParam], g.Params[RepoParam])
 	path := g.Params[PathParam]
-	ref := g.Params[RevisionParam]
+	// The commit is looked up by ref, unless the SCM resolved the ref to the
+	// SHA of its commit.
+	var ref, commitRef string
+	switch {
+	case scmType == scmTypeAzure:
+		ref, err = resolveAzureRef(ctx, scmClient, orgRepo, g.Params[RevisionParam])
+	case isStashSCMType(scmType):
+		orgRepo = stashOrgRepo(g.Params[OrgParam], g.Params[RepoParam])
+		ref, commitRef, err = resolveStashRef(ctx, scmClient, orgRepo, g.Params[RevisionParam])
+	default:
+		ref, err = resolvePullRequestRef(ctx, scmClient, orgRepo, g.Params[RevisionParam])
+	}
+	if err != nil {
+		return nil, err
+	}
+	if commitRef == "" {
+		commitRef = ref
+	}
 
-	// fetch the actual content from a file in the repo
-	content, _, err := scmClient.Contents.Find(ctx, orgRepo, path, ref)
+	strategy, err := conf.GetAPIFetchStrategy()
 	if err != nil {
-		return nil, fmt.Errorf("couldn't fetch resource content: %w", err)
+		return nil, err
 	}
-	if content == nil || len(content.Data) == 0 {
-		return nil, fmt.Errorf("no content for resource in %s %s", orgRepo, path)
+	// The commit is looked up first with the archive strategy, for the
+	// archive to be the one of its SHA.
+	var commit *scm.Commit
+	var archive *repoArchive
+	if strategy == apiFetchStrategyArchive {
+		var res *scm.Response
+		commit, res, err = scmClient.Git.FindCommit(ctx, orgRepo, commitRef)
+		if err != nil || commit == nil {
+			return nil, fmt.Errorf("couldn't fetch the commit sha for the ref %s in the repo: %w", ref, newSCMStatusError(res, err))
+		}
+		archive, err = fetchAPIArchive(ctx, scmClient, orgRepo, commit.Sha, path, maxFileSize)
+		if errors.Is(err, errArchiveUnsupported) {
+			archive = nil
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	readFile := func(p string) ([]byte, error) {
+		c, res, err := scmClient.Contents.Find(withContentLimit(ctx, p, maxFileSize), orgRepo, p, ref)
+		if err != nil {
+			var tooLarge *contentTooLargeError
+			if errors.As(err, &tooLarge) {
+				return nil, err
+			}
+			if errors.Is(err, scm.ErrNotFound) || (res != nil && res.Status == http.StatusNotFound) {
+				return nil, nil
+			}
+			return nil, newSCMStatusError(res, err)
+		}
+		return c.Data, nil
+	}
+	if archive != nil {
+		readFile = archive.readFile
+	}
+	var data []byte
+	isDir := isDirectoryPath(path)
+	var content *scm.Content
+	switch {
+	case archive != nil && !isDir:
+		// The files marked export-ignore are left out of the archive.
+		if err := g.checkExportIgnore(path, readFile); err != nil {
+			return nil, err
+		}
+		if archive.symlinks[cleanRepoPath(path)] {
+			return nil, apiSymlinkError(path)
+		}
+		if c, ok := archive.files[cleanRepoPath(path)]; ok {
+			content = &scm.Content{Path: path, Data: c}
+		} else if archive.dirs[cleanRepoPath(path)] {
+			isDir = true
+		} else {
+			return nil, fmt.Errorf("couldn't fetch resource content: %s not found in the archive of the repo", path)
+		}
+	case !isDir:
+		// check the size of the file before fetching it
+		if entry := apiFileEntry(ctx, scmClient, orgRepo, path, ref); entry != nil {
+			if entry.Type == apiEntryTypeSymlink {
+				return nil, apiSymlinkError(path)
+			}
+			if size := int64(entry.Size); size > maxFileSize {
+				return nil, fileTooLargeError(path, size, maxFileSize)
+			}
+		}
+		// fetch the actual content from a file in the repo, reading no more
+		// than the max file size when it couldn't be checked beforehand
+		var res *scm.Response
+		content, res, err = scmClient.Contents.Find(withContentLimit(ctx, path, maxFileSize), orgRepo, path, ref)
+		var tooLarge *contentTooLargeError
+		if errors.As(err, &tooLarge) {
+			return nil, err
+		}
+		if err != nil {
+			// A directory can't be fetched as a file but can be listed.
+			if _, _, listErr := scmClient.Contents.List(ctx, orgRepo, path, ref, &scm.ListOptions{}); listErr != nil {
+				return nil, fmt.Errorf("couldn't fetch resource content: %w", newSCMStatusError(res, err))
+			}
+			isDir = true
+		}
+	}
+	if isDir {
+		listManifests := func(dir string, maxSize int64) ([]manifestFile, error) {
+			return listAPIManifests(ctx, scmClient, orgRepo, dir, ref, maxSize)
+		}
+		if archive != nil {
+			listManifests = archive.listManifests
+		}
+		data, err = g.resolveDirectory(path, maxFileSize, listManifests, readFile)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		if content == nil || len(content.Data) == 0 {
+			return nil, fmt.Errorf("no content for resource in %s %s", orgRepo, path)
+		}
+		if size := int64(len(content.Data)); size > maxFileSize {
+			return nil, fileTooLargeError(path, size, maxFileSize)
+		}
+		if err := g.checkExportIgnore(path, readFile); err != nil {
+			return nil, err
+		}
+		data = content.Data
+		path = content.Path
 	}
 
 	// find the actual git commit sha by the ref
-	commit, _, err := scmClient.Git.FindCommit(ctx, orgRepo, ref)
-	if err != nil || commit == nil {
-		return nil, fmt.Errorf("couldn't fetch the commit sha for the ref %s in the repo: %w", ref, err)
+	if commit == nil {
+		var res *scm.Response
+		commit, res, err = scmClient.Git.FindCommit(ctx, orgRepo, commitRef)
+		if err != nil || commit == nil {
+			return nil, fmt.Errorf("couldn't fetch the commit sha for the ref %s in the repo: %w", ref, newSCMStatusError(res, err))
+		}
 	}
 
 	// fetch the repository URL
-	repo, _, err := scmClient.Repositories.Find(ctx, orgRepo)
+	repo, res, err := scmClient.Repositories.Find(ctx, orgRepo)
 	if err != nil {
-		return nil, fmt.Errorf("couldn't fetch repository: %w", err)
+		return nil, fmt.Errorf("couldn't fetch repository: %w", newSCMStatusError(res, err))
 	}
 
 	return &resolvedGitResource{
-		Content:  content.Data,
+		Content:  data,
 		Revision: commit.Sha,
+		Commit:   newSCMCommitMetadata(commit),
 		Org:      g.Params[OrgParam],
 		Repo:     g.Params[RepoParam],
-		Path:     content.Path,
+		Path:     path,
 		URL:      repo.Clone,
+		Mode:     ResolutionModeAPI,
+
+		EffectiveRevision: g.effectiveRevision(conf),
 	}, nil
 }
 
+// pullRequestRefRegex matches the refs of the heads of the GitHub pull
+// requests and of the GitLab merge requests.
+var pullRequestRefRegex = regexp.MustCompile(`^refs/(?:pull|merge-requests)/([0-9]+)/head$`)
+
+// resolvePullRequestRef returns the SHA of the head of the pull request if
+// the ref is the one of its head, since the SCM API can only look the commits
+// up by branch, tag or SHA, and returns the ref as is otherwise.
+func resolvePullRequestRef(ctx context.Context, scmClient *scm.Client, orgRepo, ref string) (string, error) {
+	m := pullRequestRefRegex.FindStringSubmatch(ref)
+	if m == nil {
+		return ref, nil
+	}
+	number, err := strconv.Atoi(m[1])
+	if err != nil {
+		return "", fmt.Errorf("invalid pull request number in the revision %s: %w", ref, err)
+	}
+	pr, res, err := scmClient.PullRequests.Find(ctx, orgRepo, number)
+	if err != nil {
+		return "", fmt.Errorf("couldn't fetch the pull request %d of the revision %s in the repo: %w", number, ref, newSCMStatusError(res, err))
+	}
+	sha := ""
+	if pr != nil {
+		sha = pr.Head.Sha
+		if sha == "" {
+			sha = pr.Sha
+		}
+	}
+	if sha == "" {
+		return "", fmt.Errorf("couldn't find the head commit of the pull request %d of the revision %s in the repo", number, ref)
+	}
+	return sha, nil
+}
+
 func (g *GitResolver) getAPIToken(ctx context.Context, apiSecret *secretCacheKey, key string) ([]byte, error) {
 	conf, err := GetScmConfigForParamConfigKey(ctx, g.Params)
 	if err != nil {
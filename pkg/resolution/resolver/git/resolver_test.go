@@ -21,8 +21,15 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -40,8 +47,10 @@ import (
 	frtesting "github.com/tektoncd/pipeline/pkg/resolution/resolver/framework/testing"
 	"github.com/tektoncd/pipeline/test"
 	"github.com/tektoncd/pipeline/test/diff"
+	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
 	"knative.dev/pkg/system"
 	_ "knative.dev/pkg/system/testing"
 )
@@ -56,6 +65,45 @@ func TestGetSelector(t *testing.T) {
 	}
 }
 
+func TestDescribeParams(t *testing.T) {
+	resolver := Resolver{}
+	descriptions := resolver.DescribeParams(t.Context())
+
+	described := map[string]framework.ParamDescription{}
+	for _, d := range descriptions {
+		if _, ok := described[d.Name]; ok {
+			t.Errorf("param %q is described more than once", d.Name)
+		}
+		if d.Description == "" {
+			t.Errorf("param %q has no description", d.Name)
+		}
+		described[d.Name] = d
+	}
+	for _, p := range []string{
+		UrlParam, OrgParam, RepoParam, PathParam, RevisionParam, TokenParam, TokenKeyParam,
+		GitTokenParam, GitTokenKeyParam, ScmTypeParam, ServerURLParam, ConfigKeyParam,
+		IgnoreExportIgnoreParam, SparseCheckoutDirectoriesParam, SubmodulesParam, GitTokenSchemeParam,
+	} {
+		if _, ok := described[p]; !ok {
+			t.Errorf("param %q is not described", p)
+		}
+	}
+	if !described[PathParam].Required {
+		t.Errorf("expected param %q to be required", PathParam)
+	}
+	for _, scmType := range []string{scmTypeBitbucketServer, scmTypeStash, scmTypeAzure} {
+		if !slices.Contains(described[ScmTypeParam].Enum, scmType) {
+			t.Errorf("expected param %q to accept %q but got %v", ScmTypeParam, scmType, described[ScmTypeParam].Enum)
+		}
+	}
+	if described[TokenKeyParam].Default != DefaultTokenKeyParam {
+		t.Errorf("expected param %q to default to %q but got %q", TokenKeyParam, DefaultTokenKeyParam, described[TokenKeyParam].Default)
+	}
+	if suggestion := framework.SuggestParam("pathinrepo", descriptions); suggestion != PathParam {
+		t.Errorf("expected %q to be suggested for %q but got %q", PathParam, "pathinrepo", suggestion)
+	}
+}
+
 func TestValidateParams(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -193,6 +241,106 @@ func TestValidateParams_Failure(t *testing.T) {
 				RepoParam:     "foo",
 			},
 			expectedErr: "'org' is required when 'repo' is specified",
+		}, {
+			name: "invalid ignoreExportIgnore",
+			params: map[string]string{
+				RevisionParam:           "abcd1234",
+				PathParam:               "/foo/bar",
+				UrlParam:                "http://foo",
+				IgnoreExportIgnoreParam: "yes",
+			},
+			expectedErr: `invalid value for 'ignoreExportIgnore' param: "yes", must be "true" or "false"`,
+		}, {
+			name: "invalid submodules",
+			params: map[string]string{
+				RevisionParam:   "abcd1234",
+				PathParam:       "/foo/bar",
+				UrlParam:        "http://foo",
+				SubmodulesParam: "all",
+			},
+			expectedErr: `invalid value for 'submodules' param: "all", must be "true", "false" or "recursive"`,
+		}, {
+			name: "submodules with repo",
+			params: map[string]string{
+				RevisionParam:   "abcd1234",
+				PathParam:       "tasks/task.yaml",
+				OrgParam:        "abcd1234",
+				RepoParam:       "foo",
+				SubmodulesParam: "true",
+			},
+			expectedErr: "'submodules' can only be used with 'url'",
+		}, {
+			name: "org/repo shorthand without default-url",
+			params: map[string]string{
+				RevisionParam: "main",
+				PathParam:     "tasks/task.yaml",
+				UrlParam:      "tektoncd/catalog",
+			},
+			expectedErr: "invalid git repository url: tektoncd/catalog, the org/repo shorthand requires an http(s) 'default-url' in the git resolver config",
+		}, {
+			name: "invalid gitTokenScheme",
+			params: map[string]string{
+				RevisionParam:       "abcd1234",
+				PathParam:           "/foo/bar",
+				UrlParam:            "http://foo",
+				GitTokenSchemeParam: "digest",
+			},
+			expectedErr: `invalid value for 'gitTokenScheme' param: "digest", must be "basic" or "bearer"`,
+		}, {
+			name: "gitTokenScheme with repo",
+			params: map[string]string{
+				RevisionParam:       "abcd1234",
+				PathParam:           "tasks/task.yaml",
+				OrgParam:            "abcd1234",
+				RepoParam:           "foo",
+				GitTokenSchemeParam: "bearer",
+			},
+			expectedErr: "'gitTokenScheme' can only be used with 'url'",
+		}, {
+			name: "sparse checkout directories not containing the path",
+			params: map[string]string{
+				RevisionParam:                  "abcd1234",
+				PathParam:                      "tasks/foo/task.yaml",
+				UrlParam:                       "http://foo",
+				SparseCheckoutDirectoriesParam: "pipelines, tasks/bar",
+			},
+			expectedErr: `'sparseCheckoutDirectories' param "pipelines, tasks/bar" doesn't contain the directory "tasks/foo" of 'pathInRepo'`,
+		}, {
+			name: "sparse checkout directory out of the repo",
+			params: map[string]string{
+				RevisionParam:                  "abcd1234",
+				PathParam:                      "tasks/task.yaml",
+				UrlParam:                       "http://foo",
+				SparseCheckoutDirectoriesParam: "tasks,../other",
+			},
+			expectedErr: `invalid directory "../other" in 'sparseCheckoutDirectories' param, must be a directory of the repo`,
+		}, {
+			name: "sparse checkout directories with repo",
+			params: map[string]string{
+				RevisionParam:                  "abcd1234",
+				PathParam:                      "tasks/task.yaml",
+				OrgParam:                       "abcd1234",
+				RepoParam:                      "foo",
+				SparseCheckoutDirectoriesParam: "tasks",
+			},
+			expectedErr: "'sparseCheckoutDirectories' can only be used with 'url'",
+		}, {
+			name: "semver revision with repo",
+			params: map[string]string{
+				RevisionParam: "semver:^1.2",
+				PathParam:     "tasks/task.yaml",
+				OrgParam:      "abcd1234",
+				RepoParam:     "foo",
+			},
+			expectedErr: "'semver:' revisions can only be used with 'url'",
+		}, {
+			name: "invalid semver revision",
+			params: map[string]string{
+				RevisionParam: "semver:latest",
+				PathParam:     "tasks/task.yaml",
+				UrlParam:      "http://foo",
+			},
+			expectedErr: `invalid semver constraint "latest" in revision "semver:latest": Could not get version from string: "latest"`,
 		},
 	}
 
@@ -290,6 +438,9 @@ type params struct {
 	configKey   string
 	gitToken    string
 	gitTokenKey string
+
+	ignoreExportIgnore        string
+	sparseCheckoutDirectories string
 }
 
 func TestResolve(t *testing.T) {
@@ -310,6 +461,80 @@ func TestResolve(t *testing.T) {
 		Filename: "released",
 		Content:  "released content in main branch and in tag v1",
 		Tag:      "v1",
+	}, {
+		Dir:      "./",
+		Filename: ".gitattributes",
+		Content:  "/generated export-ignore\n*.tmpl.yaml export-ignore\n",
+		Branch:   "export-ignore",
+	}, {
+		Dir:      "generated/",
+		Filename: "task.yaml",
+		Content:  "generated task",
+		Branch:   "export-ignore",
+	}, {
+		Dir:      "tasks/",
+		Filename: ".gitattributes",
+		Content:  "internal-* export-ignore\nkept.tmpl.yaml -export-ignore\n",
+		Branch:   "export-ignore",
+	}, {
+		Dir:      "tasks/",
+		Filename: "internal-task.yaml",
+		Content:  "internal task",
+		Branch:   "export-ignore",
+	}, {
+		Dir:      "tasks/",
+		Filename: "kept.tmpl.yaml",
+		Content:  "kept task",
+		Branch:   "export-ignore",
+	}, {
+		Dir:      "./",
+		Filename: "large.yaml",
+		Content:  strings.Repeat("a", 65),
+		Branch:   "large-file",
+	}, {
+		Dir:      "manifests/",
+		Filename: "b-task.yaml",
+		Content:  "b task\n",
+		Branch:   "directory",
+	}, {
+		Dir:      "manifests/",
+		Filename: "a-task.yml",
+		Content:  "a task",
+		Branch:   "directory",
+	}, {
+		Dir:      "manifests/",
+		Filename: "README.md",
+		Content:  "readme",
+		Branch:   "directory",
+	}, {
+		Dir:      "empty/",
+		Filename: "notes.txt",
+		Content:  "notes",
+		Branch:   "directory",
+	}, {
+		Dir:      "./",
+		Filename: "versioned",
+		Content:  "versioned content in tag v1.2.0",
+		Branch:   "releases",
+		Tag:      "v1.2.0",
+	}, {
+		Dir:      "./",
+		Filename: "versioned",
+		Content:  "versioned content in tag v1.2.5",
+		Branch:   "releases",
+		Tag:      "v1.2.5",
+	}, {
+		Dir:      "./",
+		Filename: "versioned",
+		Content:  "versioned content in tag v1.3.0-rc.1",
+		Branch:   "releases",
+		Tag:      "v1.3.0-rc.1",
+	}, {
+		Dir:      "./",
+		Filename: "versioned",
+		Content:  "versioned content in tag v2.0.0",
+		Branch:   "releases",
+		Tag:      "v2.0.0",
 	}}
 
 	anonFakeRepoURL, commitSHAsInAnonRepo := createTestRepo(t, commits)
@@ -333,8 +558,30 @@ func TestResolve(t *testing.T) {
 	if err != nil {
 		t.Fatalf("couldn't read main task: %v", err)
 	}
+	pullRequestTaskYAML, err := os.ReadFile(filepath.Join(refsDir, "def", "tasks", "example-task.yaml"))
+	if err != nil {
+		t.Fatalf("couldn't read pull request task: %v", err)
+	}
+	internalTaskYAML, err := os.ReadFile(filepath.Join(refsDir, "main", "tasks", "internal-task.yaml"))
+	if err != nil {
+		t.Fatalf("couldn't read internal task: %v", err)
+	}
 
-	commitSHAsInSCMRepo := []string{"abc", "xyz"}
+	commitSHAsInSCMRepo := []string{"abc", "xyz", "def"}
+
+	// The commit metadata annotations expected for the commits of the fake
+	// SCM, which are omitted for the commits without metadata.
+	scmCommitAnnotations := map[string]map[string]string{
+		commitSHAsInSCMRepo[0]: {
+			AnnotationKeyCommitAuthor:         "Ranni <ranni@example.com>",
+			AnnotationKeyCommitTimestamp:      "2025-01-02T11:00:00Z",
+			AnnotationKeyCommitMessageSubject: ("Add the example " + strings.Repeat("task ", 30))[:maxCommitSubjectLength],
+		},
+		commitSHAsInSCMRepo[2]: {
+			AnnotationKeyCommitAuthor:    "Iji",
+			AnnotationKeyCommitTimestamp: "2025-02-03T04:05:06Z",
+		},
+	}
 
 	scmFakeRepoURL := fmt.Sprintf("https://fake/%s/%s.git", testOrg, testRepo)
 	resolver := &Resolver{
@@ -349,8 +596,22 @@ func TestResolve(t *testing.T) {
 
 			// git service
 			scmData.Commits = map[string]*scm.Commit{
-				"main":  {Sha: commitSHAsInSCMRepo[0]},
+				"main": {
+					Sha:       commitSHAsInSCMRepo[0],
+					Message:   "Add the example " + strings.Repeat("task ", 30) + "\n\nThe example task and pipeline.",
+					Author:    scm.Signature{Name: "Ranni", Email: "ranni@example.com", Date: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)},
+					Committer: scm.Signature{Name: "Blaidd", Email: "blaidd@example.com", Date: time.Date(2025, 1, 2, 12, 0, 0, 0, time.FixedZone("CET", 3600))},
+				},
 				"other": {Sha: commitSHAsInSCMRepo[1]},
+				commitSHAsInSCMRepo[2]: {
+					Sha:    commitSHAsInSCMRepo[2],
+					Author: scm.Signature{Name: "Iji", Date: time.Date(2025, 2, 3, 4, 5, 6, 0, time.UTC)},
+				},
+			}
+
+			// pull request service
+			scmData.PullRequests = map[int]*scm.PullRequest{
+				42: {Number: 42, Head: scm.PullRequestBranch{Ref: "feature", Sha: commitSHAsInSCMRepo[2]}},
 			}
 			return scmClient, nil
 		},
@@ -362,6 +623,7 @@ func TestResolve(t *testing.T) {
 		config            map[string]string
 		apiToken          string
 		expectedCommitSHA string
+		expectedTag       string
 		expectedStatus    *v1beta1.ResolutionRequestStatus
 		expectedErr       error
 		configIdentifer   string
@@ -400,6 +662,20 @@ func TestResolve(t *testing.T) {
 		},
 		expectedCommitSHA: commitSHAsInAnonRepo[1],
 		expectedStatus:    resolution.CreateResolutionRequestStatusWithData([]byte("new content in test branch")),
+	}, {
+		name: "clone: default revision of the configKey",
+		args: &params{
+			pathInRepo: "foo/new",
+			url:        anonFakeRepoURL,
+			configKey:  "test",
+		},
+		config: map[string]string{
+			DefaultRevisionKey:           "main",
+			"test." + DefaultRevisionKey: "test-branch",
+		},
+		configIdentifer:   "test.",
+		expectedCommitSHA: commitSHAsInAnonRepo[1],
+		expectedStatus:    resolution.CreateResolutionRequestStatusWithData([]byte("new content in test branch")),
 	}, {
 		name: "clone: revision is a specific commit sha",
 		args: &params{
@@ -409,6 +685,34 @@ func TestResolve(t *testing.T) {
 		},
 		expectedCommitSHA: commitSHAsInAnonRepo[0],
 		expectedStatus:    resolution.CreateResolutionRequestStatusWithData([]byte("old content in test branch")),
+	}, {
+		name: "clone: revision is a semver constraint",
+		args: &params{
+			revision:   "semver:^1.2",
+			pathInRepo: "versioned",
+			url:        anonFakeRepoURL,
+		},
+		expectedCommitSHA: commitSHAsInAnonRepo[14],
+		expectedTag:       "v1.2.5",
+		expectedStatus:    resolution.CreateResolutionRequestStatusWithData([]byte("versioned content in tag v1.2.5")),
+	}, {
+		name: "clone: revision is a semver constraint opting in pre-releases",
+		args: &params{
+			revision:   "semver:>=1.3.0-rc.0 <2.0.0",
+			pathInRepo: "versioned",
+			url:        anonFakeRepoURL,
+		},
+		expectedCommitSHA: commitSHAsInAnonRepo[15],
+		expectedTag:       "v1.3.0-rc.1",
+		expectedStatus:    resolution.CreateResolutionRequestStatusWithData([]byte("versioned content in tag v1.3.0-rc.1")),
+	}, {
+		name: "clone: no tag matches the semver constraint",
+		args: &params{
+			revision:   "semver:~1.3",
+			pathInRepo: "versioned",
+			url:        anonFakeRepoURL,
+		},
+		expectedErr: createError(`no tag of the repository matches the semver constraint "~1.3"`),
 	}, {
 		name: "clone: file does not exist",
 		args: &params{
@@ -444,6 +748,183 @@ func TestResolve(t *testing.T) {
 			url:        anonFakeRepoURL,
 		},
 		expectedErr: createError("git fetch error: fatal: couldn't find remote ref non-existent-revision: exit status 128"),
+	}, {
+		name: "clone: path is export-ignored by a nested .gitattributes",
+		args: &params{
+			revision:   "export-ignore",
+			pathInRepo: "tasks/internal-task.yaml",
+			url:        anonFakeRepoURL,
+		},
+		expectedErr: createError(`path "tasks/internal-task.yaml" is marked export-ignore in .gitattributes, set the 'ignoreExportIgnore' param to "true" to resolve it anyway`),
+	}, {
+		name: "clone: path is in an export-ignored directory",
+		args: &params{
+			revision:   "export-ignore",
+			pathInRepo: "./generated/task.yaml",
+			url:        anonFakeRepoURL,
+		},
+		expectedErr: createError(`path "./generated/task.yaml" is marked export-ignore in .gitattributes, set the 'ignoreExportIgnore' param to "true" to resolve it anyway`),
+	}, {
+		name: "clone: export-ignore is unset by a nested .gitattributes",
+		args: &params{
+			revision:   "export-ignore",
+			pathInRepo: "tasks/kept.tmpl.yaml",
+			url:        anonFakeRepoURL,
+		},
+		expectedCommitSHA: commitSHAsInAnonRepo[7],
+		expectedStatus:    resolution.CreateResolutionRequestStatusWithData([]byte("kept task")),
+	}, {
+		name: "clone: export-ignored path with ignoreExportIgnore",
+		args: &params{
+			revision:           "export-ignore",
+			pathInRepo:         "tasks/internal-task.yaml",
+			url:                anonFakeRepoURL,
+			ignoreExportIgnore: "true",
+		},
+		expectedCommitSHA: commitSHAsInAnonRepo[7],
+		expectedStatus:    resolution.CreateResolutionRequestStatusWithData([]byte("internal task")),
+	}, {
+		name: "clone: sparse checkout of the directory of the path",
+		args: &params{
+			revision:                  "export-ignore",
+			pathInRepo:                "./tasks/kept.tmpl.yaml",
+			url:                       anonFakeRepoURL,
+			sparseCheckoutDirectories: "generated,tasks",
+		},
+		expectedCommitSHA: commitSHAsInAnonRepo[7],
+		expectedStatus:    resolution.CreateResolutionRequestStatusWithData([]byte("kept task")),
+	}, {
+		name: "clone: default sparse checkout directories",
+		args: &params{
+			revision:   "test-branch",
+			pathInRepo: "foo/new",
+			url:        anonFakeRepoURL,
+		},
+		config: map[string]string{
+			DefaultSparseCheckoutDirectoriesKey: "foo",
+		},
+		expectedCommitSHA: commitSHAsInAnonRepo[1],
+		expectedStatus:    resolution.CreateResolutionRequestStatusWithData([]byte("new content in test branch")),
+	}, {
+		name: "clone: file just over the max file size",
+		args: &params{
+			revision:   "large-file",
+			pathInRepo: "large.yaml",
+			url:        anonFakeRepoURL,
+		},
+		config: map[string]string{
+			MaxFileSizeKey: "64",
+		},
+		expectedErr: fmt.Errorf(`error opening file "large.yaml": %w`, fileTooLargeError("large.yaml", 65, 64)),
+	}, {
+		name: "clone: file of the max file size",
+		args: &params{
+			revision:   "large-file",
+			pathInRepo: "large.yaml",
+			url:        anonFakeRepoURL,
+		},
+		config: map[string]string{
+			MaxFileSizeKey: "65",
+		},
+		expectedCommitSHA: commitSHAsInAnonRepo[8],
+		expectedStatus:    resolution.CreateResolutionRequestStatusWithData([]byte(strings.Repeat("a", 65))),
+	}, {
+		name: "clone: directory",
+		args: &params{
+			revision:   "directory",
+			pathInRepo: "manifests/",
+			url:        anonFakeRepoURL,
+		},
+		expectedCommitSHA: commitSHAsInAnonRepo[12],
+		expectedStatus:    resolution.CreateResolutionRequestStatusWithData([]byte("a task\n---\nb task\n")),
+	}, {
+		name: "clone: directory without a trailing slash",
+		args: &params{
+			revision:   "directory",
+			pathInRepo: "manifests",
+			url:        anonFakeRepoURL,
+		},
+		expectedCommitSHA: commitSHAsInAnonRepo[12],
+		expectedStatus:    resolution.CreateResolutionRequestStatusWithData([]byte("a task\n---\nb task\n")),
+	}, {
+		name: "clone: directory without yaml files",
+		args: &params{
+			revision:   "directory",
+			pathInRepo: "empty/",
+			url:        anonFakeRepoURL,
+		},
+		expectedErr: createError(`directory "empty/" doesn't contain any .yaml or .yml file`),
+	}, {
+		name: "clone: directory does not exist",
+		args: &params{
+			revision:   "directory",
+			pathInRepo: "non-exist/",
+			url:        anonFakeRepoURL,
+		},
+		expectedErr: createError(`error reading directory "non-exist/": directory does not exist`),
+	}, {
+		name: "clone: directory without its export-ignored files",
+		args: &params{
+			revision:   "export-ignore",
+			pathInRepo: "tasks/",
+			url:        anonFakeRepoURL,
+		},
+		expectedCommitSHA: commitSHAsInAnonRepo[7],
+		expectedStatus:    resolution.CreateResolutionRequestStatusWithData([]byte("kept task\n")),
+	}, {
+		name: "clone: sparse checkout of the directory",
+		args: &params{
+			revision:                  "directory",
+			pathInRepo:                "manifests/",
+			url:                       anonFakeRepoURL,
+			sparseCheckoutDirectories: "manifests",
+		},
+		expectedCommitSHA: commitSHAsInAnonRepo[12],
+		expectedStatus:    resolution.CreateResolutionRequestStatusWithData([]byte("a task\n---\nb task\n")),
+	}, {
+		name: "clone: invalid max file size",
+		args: &params{
+			pathInRepo: "./released",
+			url:        anonFakeRepoURL,
+		},
+		config: map[string]string{
+			MaxFileSizeKey: "large",
+		},
+		expectedErr: createError(`invalid max-file-size "large" in git resolver config, must be a positive quantity like "1Mi"`),
+	}, {
+		name: "clone: file just over the max file size in bytes",
+		args: &params{
+			revision:   "large-file",
+			pathInRepo: "large.yaml",
+			url:        anonFakeRepoURL,
+		},
+		config: map[string]string{
+			MaxFileSizeKey:      "1Mi",
+			MaxFileSizeBytesKey: "64",
+		},
+		expectedErr: fmt.Errorf(`error opening file "large.yaml": %w`, fileTooLargeError("large.yaml", 65, 64)),
+	}, {
+		name: "clone: directory over the max file size",
+		args: &params{
+			revision:   "directory",
+			pathInRepo: "manifests/",
+			url:        anonFakeRepoURL,
+		},
+		config: map[string]string{
+			// Each of the files fits but not the resolved stream.
+			MaxFileSizeBytesKey: "17",
+		},
+		expectedErr: fileTooLargeError("manifests/", 18, 17),
+	}, {
+		name: "clone: invalid max file size in bytes",
+		args: &params{
+			pathInRepo: "./released",
+			url:        anonFakeRepoURL,
+		},
+		config: map[string]string{
+			MaxFileSizeBytesKey: "1Mi",
+		},
+		expectedErr: createError(`invalid max-file-size-bytes "1Mi" in git resolver config, must be a positive number of bytes`),
 	}, {
 		name: "api: successful task from params api information",
 		args: &params{
@@ -521,12 +1002,10 @@ func TestResolve(t *testing.T) {
 		expectedCommitSHA: commitSHAsInSCMRepo[0],
 		expectedStatus:    resolution.CreateResolutionRequestStatusWithData(mainTaskYAML),
 	}, {
-		name: "api: successful pipeline",
+		name: "api: successful task from the default org and repo",
 		args: &params{
 			revision:   "main",
-			pathInRepo: "pipelines/example-pipeline.yaml",
-			org:        testOrg,
-			repo:       testRepo,
+			pathInRepo: "tasks/example-task.yaml",
 		},
 		config: map[string]string{
 			ServerURLKey:          "fake",
@@ -534,56 +1013,38 @@ func TestResolve(t *testing.T) {
 			APISecretNameKey:      "token-secret",
 			APISecretKeyKey:       "token",
 			APISecretNamespaceKey: system.Namespace(),
+			DefaultOrgKey:         testOrg,
+			DefaultRepoKey:        testRepo,
 		},
 		apiToken:          "some-token",
 		expectedCommitSHA: commitSHAsInSCMRepo[0],
-		expectedStatus:    resolution.CreateResolutionRequestStatusWithData(mainPipelineYAML),
-	}, {
-		name: "api: successful pipeline with default revision",
-		args: &params{
-			pathInRepo: "pipelines/example-pipeline.yaml",
-			org:        testOrg,
-			repo:       testRepo,
-		},
-		config: map[string]string{
-			ServerURLKey:          "fake",
-			SCMTypeKey:            "fake",
-			APISecretNameKey:      "token-secret",
-			APISecretKeyKey:       "token",
-			APISecretNamespaceKey: system.Namespace(),
-			DefaultRevisionKey:    "other",
-		},
-		apiToken:          "some-token",
-		expectedCommitSHA: commitSHAsInSCMRepo[1],
-		expectedStatus:    resolution.CreateResolutionRequestStatusWithData(otherPipelineYAML),
+		expectedStatus:    resolution.CreateResolutionRequestStatusWithData(mainTaskYAML),
 	}, {
-		name: "api: successful override scm type and server URL from user params",
-
+		name: "api: successful task from the default org and repo with identifier",
 		args: &params{
 			revision:   "main",
 			pathInRepo: "tasks/example-task.yaml",
-			org:        testOrg,
-			repo:       testRepo,
-			token:      "token-secret",
-			tokenKey:   "token",
-			namespace:  "foo",
-			scmType:    "fake",
-			serverURL:  "fake",
+			configKey:  "test",
 		},
 		config: map[string]string{
-			ServerURLKey: "notsofake",
-			SCMTypeKey:   "definitivelynotafake",
+			DefaultRepoKey:                  "other-repo",
+			"test." + ServerURLKey:          "fake",
+			"test." + SCMTypeKey:            "fake",
+			"test." + APISecretNameKey:      "token-secret",
+			"test." + APISecretKeyKey:       "token",
+			"test." + APISecretNamespaceKey: system.Namespace(),
+			"test." + DefaultOrgKey:         testOrg,
+			"test." + DefaultRepoKey:        testRepo,
 		},
+		configIdentifer:   "test.",
 		apiToken:          "some-token",
 		expectedCommitSHA: commitSHAsInSCMRepo[0],
 		expectedStatus:    resolution.CreateResolutionRequestStatusWithData(mainTaskYAML),
 	}, {
-		name: "api: file does not exist",
+		name: "api: default org without a default repo",
 		args: &params{
 			revision:   "main",
-			pathInRepo: "pipelines/other-pipeline.yaml",
-			org:        testOrg,
-			repo:       testRepo,
+			pathInRepo: "tasks/example-task.yaml",
 		},
 		config: map[string]string{
 			ServerURLKey:          "fake",
@@ -591,12 +1052,16 @@ func TestResolve(t *testing.T) {
 			APISecretNameKey:      "token-secret",
 			APISecretKeyKey:       "token",
 			APISecretNamespaceKey: system.Namespace(),
+			DefaultOrgKey:         testOrg,
 		},
 		apiToken:       "some-token",
 		expectedStatus: resolution.CreateResolutionRequestFailureStatus(),
-		expectedErr:    createError("couldn't fetch resource content: file testdata/test-org/test-repo/refs/main/pipelines/other-pipeline.yaml does not exist: stat testdata/test-org/test-repo/refs/main/pipelines/other-pipeline.yaml: no such file or directory"),
+		expectedErr: &common.InvalidRequestError{
+			ResolutionRequestKey: "foo/rr",
+			Message:              "must specify one of 'url' or 'repo'",
+		},
 	}, {
-		name: "api: token not found",
+		name: "api: successful pipeline",
 		args: &params{
 			revision:   "main",
 			pathInRepo: "pipelines/example-pipeline.yaml",
@@ -610,12 +1075,12 @@ func TestResolve(t *testing.T) {
 			APISecretKeyKey:       "token",
 			APISecretNamespaceKey: system.Namespace(),
 		},
-		expectedStatus: resolution.CreateResolutionRequestFailureStatus(),
-		expectedErr:    createError("cannot get API token, secret token-secret not found in namespace " + system.Namespace()),
+		apiToken:          "some-token",
+		expectedCommitSHA: commitSHAsInSCMRepo[0],
+		expectedStatus:    resolution.CreateResolutionRequestStatusWithData(mainPipelineYAML),
 	}, {
-		name: "api: token secret name not specified",
+		name: "api: successful pipeline with default revision",
 		args: &params{
-			revision:   "main",
 			pathInRepo: "pipelines/example-pipeline.yaml",
 			org:        testOrg,
 			repo:       testRepo,
@@ -623,11 +1088,288 @@ func TestResolve(t *testing.T) {
 		config: map[string]string{
 			ServerURLKey:          "fake",
 			SCMTypeKey:            "fake",
+			APISecretNameKey:      "token-secret",
 			APISecretKeyKey:       "token",
 			APISecretNamespaceKey: system.Namespace(),
+			DefaultRevisionKey:    "other",
 		},
-		apiToken:       "some-token",
-		expectedStatus: resolution.CreateResolutionRequestFailureStatus(),
+		apiToken:          "some-token",
+		expectedCommitSHA: commitSHAsInSCMRepo[1],
+		expectedStatus:    resolution.CreateResolutionRequestStatusWithData(otherPipelineYAML),
+	}, {
+		name: "api: revision is the head of a pull request",
+		args: &params{
+			revision:   "refs/pull/42/head",
+			pathInRepo: "tasks/example-task.yaml",
+			org:        testOrg,
+			repo:       testRepo,
+		},
+		config: map[string]string{
+			ServerURLKey:          "fake",
+			SCMTypeKey:            "fake",
+			APISecretNameKey:      "token-secret",
+			APISecretKeyKey:       "token",
+			APISecretNamespaceKey: system.Namespace(),
+		},
+		apiToken:          "some-token",
+		expectedCommitSHA: commitSHAsInSCMRepo[2],
+		expectedStatus:    resolution.CreateResolutionRequestStatusWithData(pullRequestTaskYAML),
+	}, {
+		name: "api: revision is the head of a merge request",
+		args: &params{
+			revision:   "refs/merge-requests/42/head",
+			pathInRepo: "tasks/example-task.yaml",
+			org:        testOrg,
+			repo:       testRepo,
+		},
+		config: map[string]string{
+			ServerURLKey:          "fake",
+			SCMTypeKey:            "fake",
+			APISecretNameKey:      "token-secret",
+			APISecretKeyKey:       "token",
+			APISecretNamespaceKey: system.Namespace(),
+		},
+		apiToken:          "some-token",
+		expectedCommitSHA: commitSHAsInSCMRepo[2],
+		expectedStatus:    resolution.CreateResolutionRequestStatusWithData(pullRequestTaskYAML),
+	}, {
+		name: "api: pull request does not exist",
+		args: &params{
+			revision:   "refs/pull/7/head",
+			pathInRepo: "tasks/example-task.yaml",
+			org:        testOrg,
+			repo:       testRepo,
+		},
+		config: map[string]string{
+			ServerURLKey:          "fake",
+			SCMTypeKey:            "fake",
+			APISecretNameKey:      "token-secret",
+			APISecretKeyKey:       "token",
+			APISecretNamespaceKey: system.Namespace(),
+		},
+		apiToken:       "some-token",
+		expectedStatus: resolution.CreateResolutionRequestFailureStatus(),
+		expectedErr:    createError("couldn't fetch the pull request 7 of the revision refs/pull/7/head in the repo: pull request number 7 does not exit"),
+	}, {
+		name: "api: successful override scm type and server URL from user params",
+
+		args: &params{
+			revision:   "main",
+			pathInRepo: "tasks/example-task.yaml",
+			org:        testOrg,
+			repo:       testRepo,
+			token:      "token-secret",
+			tokenKey:   "token",
+			namespace:  "foo",
+			scmType:    "fake",
+			serverURL:  "fake",
+		},
+		config: map[string]string{
+			ServerURLKey: "notsofake",
+			SCMTypeKey:   "definitivelynotafake",
+		},
+		apiToken:          "some-token",
+		expectedCommitSHA: commitSHAsInSCMRepo[0],
+		expectedStatus:    resolution.CreateResolutionRequestStatusWithData(mainTaskYAML),
+	}, {
+		name: "api: file does not exist",
+		args: &params{
+			revision:   "main",
+			pathInRepo: "pipelines/other-pipeline.yaml",
+			org:        testOrg,
+			repo:       testRepo,
+		},
+		config: map[string]string{
+			ServerURLKey:          "fake",
+			SCMTypeKey:            "fake",
+			APISecretNameKey:      "token-secret",
+			APISecretKeyKey:       "token",
+			APISecretNamespaceKey: system.Namespace(),
+		},
+		apiToken:       "some-token",
+		expectedStatus: resolution.CreateResolutionRequestFailureStatus(),
+		expectedErr:    createError("couldn't fetch resource content: file testdata/test-org/test-repo/refs/main/pipelines/other-pipeline.yaml does not exist: stat testdata/test-org/test-repo/refs/main/pipelines/other-pipeline.yaml: no such file or directory"),
+	}, {
+		name: "api: path is export-ignored by a nested .gitattributes",
+		args: &params{
+			revision:   "main",
+			pathInRepo: "tasks/internal-task.yaml",
+			org:        testOrg,
+			repo:       testRepo,
+		},
+		config: map[string]string{
+			ServerURLKey:          "fake",
+			SCMTypeKey:            "fake",
+			APISecretNameKey:      "token-secret",
+			APISecretKeyKey:       "token",
+			APISecretNamespaceKey: system.Namespace(),
+		},
+		apiToken:       "some-token",
+		expectedStatus: resolution.CreateResolutionRequestFailureStatus(),
+		expectedErr:    createError(`path "tasks/internal-task.yaml" is marked export-ignore in .gitattributes, set the 'ignoreExportIgnore' param to "true" to resolve it anyway`),
+	}, {
+		name: "api: path is in an export-ignored directory",
+		args: &params{
+			revision:   "main",
+			pathInRepo: "generated/task.yaml",
+			org:        testOrg,
+			repo:       testRepo,
+		},
+		config: map[string]string{
+			ServerURLKey:          "fake",
+			SCMTypeKey:            "fake",
+			APISecretNameKey:      "token-secret",
+			APISecretKeyKey:       "token",
+			APISecretNamespaceKey: system.Namespace(),
+		},
+		apiToken:       "some-token",
+		expectedStatus: resolution.CreateResolutionRequestFailureStatus(),
+		expectedErr:    createError(`path "generated/task.yaml" is marked export-ignore in .gitattributes, set the 'ignoreExportIgnore' param to "true" to resolve it anyway`),
+	}, {
+		name: "api: export-ignored path with ignoreExportIgnore",
+		args: &params{
+			revision:           "main",
+			pathInRepo:         "tasks/internal-task.yaml",
+			org:                testOrg,
+			repo:               testRepo,
+			ignoreExportIgnore: "true",
+		},
+		config: map[string]string{
+			ServerURLKey:          "fake",
+			SCMTypeKey:            "fake",
+			APISecretNameKey:      "token-secret",
+			APISecretKeyKey:       "token",
+			APISecretNamespaceKey: system.Namespace(),
+		},
+		apiToken:          "some-token",
+		expectedCommitSHA: commitSHAsInSCMRepo[0],
+		expectedStatus:    resolution.CreateResolutionRequestStatusWithData(internalTaskYAML),
+	}, {
+		name: "api: directory",
+		args: &params{
+			revision:   "main",
+			pathInRepo: "tasks/",
+			org:        testOrg,
+			repo:       testRepo,
+		},
+		config: map[string]string{
+			ServerURLKey:          "fake",
+			SCMTypeKey:            "fake",
+			APISecretNameKey:      "token-secret",
+			APISecretKeyKey:       "token",
+			APISecretNamespaceKey: system.Namespace(),
+		},
+		apiToken:          "some-token",
+		expectedCommitSHA: commitSHAsInSCMRepo[0],
+		expectedStatus:    resolution.CreateResolutionRequestStatusWithData(mainTaskYAML),
+	}, {
+		name: "api: directory without a trailing slash",
+		args: &params{
+			revision:           "main",
+			pathInRepo:         "tasks",
+			org:                testOrg,
+			repo:               testRepo,
+			ignoreExportIgnore: "true",
+		},
+		config: map[string]string{
+			ServerURLKey:          "fake",
+			SCMTypeKey:            "fake",
+			APISecretNameKey:      "token-secret",
+			APISecretKeyKey:       "token",
+			APISecretNamespaceKey: system.Namespace(),
+		},
+		apiToken:          "some-token",
+		expectedCommitSHA: commitSHAsInSCMRepo[0],
+		expectedStatus:    resolution.CreateResolutionRequestStatusWithData(append(append(append([]byte{}, mainTaskYAML...), "---\n"...), internalTaskYAML...)),
+	}, {
+		name: "api: directory without yaml files",
+		args: &params{
+			revision:   "main",
+			pathInRepo: "docs/",
+			org:        testOrg,
+			repo:       testRepo,
+		},
+		config: map[string]string{
+			ServerURLKey:          "fake",
+			SCMTypeKey:            "fake",
+			APISecretNameKey:      "token-secret",
+			APISecretKeyKey:       "token",
+			APISecretNamespaceKey: system.Namespace(),
+		},
+		apiToken:       "some-token",
+		expectedStatus: resolution.CreateResolutionRequestFailureStatus(),
+		expectedErr:    createError(`directory "docs/" doesn't contain any .yaml or .yml file`),
+	}, {
+		name: "api: file just over the max file size",
+		args: &params{
+			revision:   "main",
+			pathInRepo: "tasks/example-task.yaml",
+			org:        testOrg,
+			repo:       testRepo,
+		},
+		config: map[string]string{
+			ServerURLKey:          "fake",
+			SCMTypeKey:            "fake",
+			APISecretNameKey:      "token-secret",
+			APISecretKeyKey:       "token",
+			APISecretNamespaceKey: system.Namespace(),
+			MaxFileSizeKey:        strconv.Itoa(len(mainTaskYAML) - 1),
+		},
+		apiToken:       "some-token",
+		expectedStatus: resolution.CreateResolutionRequestFailureStatus(),
+		expectedErr:    fileTooLargeError("tasks/example-task.yaml", int64(len(mainTaskYAML)), int64(len(mainTaskYAML)-1)),
+	}, {
+		name: "api: directory with a file over the max file size in bytes",
+		args: &params{
+			revision:   "main",
+			pathInRepo: "tasks/",
+			org:        testOrg,
+			repo:       testRepo,
+		},
+		config: map[string]string{
+			ServerURLKey:          "fake",
+			SCMTypeKey:            "fake",
+			APISecretNameKey:      "token-secret",
+			APISecretKeyKey:       "token",
+			APISecretNamespaceKey: system.Namespace(),
+			MaxFileSizeBytesKey:   strconv.Itoa(len(mainTaskYAML) - 1),
+		},
+		apiToken:       "some-token",
+		expectedStatus: resolution.CreateResolutionRequestFailureStatus(),
+		expectedErr:    fmt.Errorf(`error reading directory "tasks/": %w`, fileTooLargeError("tasks/example-task.yaml", int64(len(mainTaskYAML)), int64(len(mainTaskYAML)-1))),
+	}, {
+		name: "api: token not found",
+		args: &params{
+			revision:   "main",
+			pathInRepo: "pipelines/example-pipeline.yaml",
+			org:        testOrg,
+			repo:       testRepo,
+		},
+		config: map[string]string{
+			ServerURLKey:          "fake",
+			SCMTypeKey:            "fake",
+			APISecretNameKey:      "token-secret",
+			APISecretKeyKey:       "token",
+			APISecretNamespaceKey: system.Namespace(),
+		},
+		expectedStatus: resolution.CreateResolutionRequestFailureStatus(),
+		expectedErr:    createError("cannot get API token, secret token-secret not found in namespace " + system.Namespace()),
+	}, {
+		name: "api: token secret name not specified",
+		args: &params{
+			revision:   "main",
+			pathInRepo: "pipelines/example-pipeline.yaml",
+			org:        testOrg,
+			repo:       testRepo,
+		},
+		config: map[string]string{
+			ServerURLKey:          "fake",
+			SCMTypeKey:            "fake",
+			APISecretKeyKey:       "token",
+			APISecretNamespaceKey: system.Namespace(),
+		},
+		apiToken:       "some-token",
+		expectedStatus: resolution.CreateResolutionRequestFailureStatus(),
 		expectedErr:    createError("cannot get API token, required when specifying 'repo' param, 'api-token-secret-name' not specified in config"),
 	}, {
 		name: "api: token secret key not specified",
@@ -710,18 +1452,37 @@ func TestResolve(t *testing.T) {
 					expectedStatus.Annotations[common.AnnotationKeyContentType] = "application/x-yaml"
 					expectedStatus.Annotations[AnnotationKeyRevision] = tc.expectedCommitSHA
 					expectedStatus.Annotations[AnnotationKeyPath] = tc.args.pathInRepo
+					effectiveRevision := tc.args.revision
+					if effectiveRevision == "" {
+						effectiveRevision = cfg[tc.configIdentifer+DefaultRevisionKey]
+					}
+					if tc.expectedTag != "" {
+						effectiveRevision = "refs/tags/" + tc.expectedTag
+					}
+					expectedStatus.Annotations[AnnotationKeyEffectiveRevision] = effectiveRevision
+					if tc.expectedTag != "" {
+						expectedStatus.Annotations[AnnotationKeyTag] = tc.expectedTag
+					}
 
 					if tc.args.url != "" {
 						expectedStatus.Annotations[AnnotationKeyURL] = anonFakeRepoURL
+						expectedStatus.Annotations[AnnotationKeyResolutionMode] = ResolutionModeClone
+						expectedStatus.Annotations[AnnotationKeyCommitAuthor] = "PipelinesTests <test@test.com>"
+						expectedStatus.Annotations[AnnotationKeyCommitTimestamp] = testCommitDate
+						expectedStatus.Annotations[AnnotationKeyCommitMessageSubject] = "adding file for test"
 					} else {
 						expectedStatus.Annotations[AnnotationKeyOrg] = testOrg
 						expectedStatus.Annotations[AnnotationKeyRepo] = testRepo
 						expectedStatus.Annotations[AnnotationKeyURL] = scmFakeRepoURL
+						expectedStatus.Annotations[AnnotationKeyResolutionMode] = ResolutionModeAPI
+						for k, v := range scmCommitAnnotations[tc.expectedCommitSHA] {
+							expectedStatus.Annotations[k] = v
+						}
 					}
 
 					// status.refSource
 					expectedStatus.RefSource = &pipelinev1.RefSource{
-						URI: "git+" + expectedStatus.Annotations[AnnotationKeyURL],
+						URI: "git+" + expectedStatus.Annotations[AnnotationKeyURL] + "@" + effectiveRevision,
 						Digest: map[string]string{
 							"sha1": tc.expectedCommitSHA,
 						},
@@ -825,14 +1586,18 @@ func createRequest(args *params) *v1beta1.ResolutionRequest {
 			})
 		}
 	} else {
-		rr.Spec.Params = append(rr.Spec.Params, pipelinev1.Param{
-			Name:  RepoParam,
-			Value: *pipelinev1.NewStructuredValues(args.repo),
-		})
-		rr.Spec.Params = append(rr.Spec.Params, pipelinev1.Param{
-			Name:  OrgParam,
-			Value: *pipelinev1.NewStructuredValues(args.org),
-		})
+		if args.repo != "" {
+			rr.Spec.Params = append(rr.Spec.Params, pipelinev1.Param{
+				Name:  RepoParam,
+				Value: *pipelinev1.NewStructuredValues(args.repo),
+			})
+		}
+		if args.org != "" {
+			rr.Spec.Params = append(rr.Spec.Params, pipelinev1.Param{
+				Name:  OrgParam,
+				Value: *pipelinev1.NewStructuredValues(args.org),
+			})
+		}
 		if args.token != "" {
 			rr.Spec.Params = append(rr.Spec.Params, pipelinev1.Param{
 				Name:  TokenParam,
@@ -852,6 +1617,20 @@ func createRequest(args *params) *v1beta1.ResolutionRequest {
 		})
 	}
 
+	if args.ignoreExportIgnore != "" {
+		rr.Spec.Params = append(rr.Spec.Params, pipelinev1.Param{
+			Name:  IgnoreExportIgnoreParam,
+			Value: *pipelinev1.NewStructuredValues(args.ignoreExportIgnore),
+		})
+	}
+
+	if args.sparseCheckoutDirectories != "" {
+		rr.Spec.Params = append(rr.Spec.Params, pipelinev1.Param{
+			Name:  SparseCheckoutDirectoriesParam,
+			Value: *pipelinev1.NewStructuredValues(args.sparseCheckoutDirectories),
+		})
+	}
+
 	return rr
 }
 
@@ -880,6 +1659,422 @@ func toParams(m map[string]string) []pipelinev1.Param {
 	return params
 }
 
+func TestResolveGitCloneSubmodules(t *testing.T) {
+	repoURL := createTestRepoWithSubmodules(t)
+	ctx := framework.InjectResolverConfigToContext(t.Context(), map[string]string{})
+
+	for _, tc := range []struct {
+		name        string
+		submodules  string
+		path        string
+		wantContent string
+		wantErr     string
+	}{{
+		name:    "submodules not initialized",
+		path:    "common/tasks/build.yaml",
+		wantErr: `error opening file "common/tasks/build.yaml": file does not exist`,
+	}, {
+		name:       "submodules disabled",
+		submodules: "false",
+		path:       "common/tasks/build.yaml",
+		wantErr:    `error opening file "common/tasks/build.yaml": file does not exist`,
+	}, {
+		name:        "file of a submodule",
+		submodules:  "true",
+		path:        "common/tasks/build.yaml",
+		wantContent: "build task",
+	}, {
+		name:       "file of a nested submodule without recursive",
+		submodules: "true",
+		path:       "common/nested/task.yaml",
+		wantErr:    `error opening file "common/nested/task.yaml": file does not exist`,
+	}, {
+		name:        "file of a nested submodule",
+		submodules:  "recursive",
+		path:        "common/nested/task.yaml",
+		wantContent: "nested task",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := &GitResolver{
+				Params: map[string]string{
+					UrlParam:      repoURL,
+					RevisionParam: "main",
+					PathParam:     tc.path,
+				},
+				Logger: zap.NewNop().Sugar(),
+				cloneFunc: func(ctx context.Context, r remote) (*repository, func(), error) {
+					r.cmdExecutor = allowFileSubmodules
+					return r.clone(ctx)
+				},
+			}
+			if tc.submodules != "" {
+				g.Params[SubmodulesParam] = tc.submodules
+			}
+			res, err := g.ResolveGitClone(ctx)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("expected the error %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error resolving %s: %v", tc.path, err)
+			}
+			if got := string(res.Data()); got != tc.wantContent {
+				t.Errorf("expected the content %q, got %q", tc.wantContent, got)
+			}
+		})
+	}
+}
+
+func TestResolveGitCloneSubmodulesNotAllowed(t *testing.T) {
+	repoURL := createTestRepoWithSubmodules(t)
+	ctx := framework.InjectResolverConfigToContext(t.Context(), map[string]string{AllowedURLPatternsKey: repoURL})
+
+	g := &GitResolver{
+		Params: map[string]string{
+			UrlParam:        repoURL,
+			RevisionParam:   "main",
+			PathParam:       "common/tasks/build.yaml",
+			SubmodulesParam: "true",
+		},
+		Logger: zap.NewNop().Sugar(),
+		cloneFunc: func(ctx context.Context, r remote) (*repository, func(), error) {
+			r.cmdExecutor = allowFileSubmodules
+			return r.clone(ctx)
+		},
+	}
+	_, err := g.ResolveGitClone(ctx)
+	if err == nil || !strings.Contains(err.Error(), "submodule common: url") || !strings.Contains(err.Error(), "not allowed by resolver configuration") {
+		t.Fatalf("expected the submodule not to be allowed, got %v", err)
+	}
+}
+
+func TestResolveRepoShorthand(t *testing.T) {
+	repoURL, _ := createTestRepo(t, []commitForRepo{{
+		Dir:      "tasks/",
+		Filename: "task.yaml",
+		Content:  "some task",
+	}})
+
+	for _, tc := range []struct {
+		name    string
+		config  map[string]string
+		url     string
+		wantURL string
+		wantErr string
+	}{{
+		name:    "shorthand on the server of default-url",
+		config:  map[string]string{DefaultURLKey: "https://github.com/tektoncd/catalog.git"},
+		url:     "tektoncd/pipeline",
+		wantURL: "https://github.com/tektoncd/pipeline.git",
+	}, {
+		name:    "shorthand with .git suffix",
+		config:  map[string]string{DefaultURLKey: "https://gitea.example.com/infra/tasks"},
+		url:     "tektoncd/pipeline.git",
+		wantURL: "https://gitea.example.com/tektoncd/pipeline.git",
+	}, {
+		name:    "shorthand with the config of another key",
+		config:  map[string]string{"other." + DefaultURLKey: "https://gitlab.com/tektoncd/catalog.git"},
+		url:     "tektoncd/pipeline",
+		wantErr: "invalid git repository url: tektoncd/pipeline, the org/repo shorthand requires an http(s) 'default-url' in the git resolver config",
+	}, {
+		name:    "shorthand without default-url",
+		url:     "tektoncd/pipeline",
+		wantErr: "invalid git repository url: tektoncd/pipeline, the org/repo shorthand requires an http(s) 'default-url' in the git resolver config",
+	}, {
+		name:    "shorthand with an ssh default-url",
+		config:  map[string]string{DefaultURLKey: "git@github.com:tektoncd/catalog.git"},
+		url:     "tektoncd/pipeline",
+		wantErr: "invalid git repository url: tektoncd/pipeline, the org/repo shorthand requires an http(s) 'default-url' in the git resolver config",
+	}, {
+		name:    "shorthand out of the server",
+		config:  map[string]string{DefaultURLKey: "https://github.com/tektoncd/catalog.git"},
+		url:     "../pipeline",
+		wantErr: "invalid git repository url: ../pipeline",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := framework.InjectResolverConfigToContext(t.Context(), tc.config)
+			var clonedURL string
+			r := &Resolver{
+				logger:      zap.NewNop().Sugar(),
+				resolutions: NewResolutionGroup(),
+				cloneFunc: func(ctx context.Context, rem remote) (*repository, func(), error) {
+					clonedURL = rem.url
+					rem.url = repoURL
+					return rem.clone(ctx)
+				},
+			}
+			params := toParams(map[string]string{
+				UrlParam:      tc.url,
+				RevisionParam: "main",
+				PathParam:     "tasks/task.yaml",
+			})
+
+			err := r.ValidateParams(ctx, params)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("expected the validation error %q, got %v", tc.wantErr, err)
+				}
+				if _, err := r.Resolve(ctx, params); err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("expected the resolution error %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error validating the params: %v", err)
+			}
+			res, err := r.Resolve(ctx, params)
+			if err != nil {
+				t.Fatalf("unexpected error resolving the file: %v", err)
+			}
+			if clonedURL != tc.wantURL {
+				t.Errorf("expected %s to be cloned, got %s", tc.wantURL, clonedURL)
+			}
+			if got := res.Annotations()[AnnotationKeyURL]; got != tc.wantURL {
+				t.Errorf("expected the url annotation %s, got %s", tc.wantURL, got)
+			}
+		})
+	}
+}
+
+func TestResolveGitCloneTokenScheme(t *testing.T) {
+	basicAuth := "Authorization=Basic " + base64.URLEncoding.EncodeToString([]byte("git:a-token"))
+	bearerAuth := "Authorization=Bearer a-token"
+	tokenSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "git-token", Namespace: "foo"},
+		Data:       map[string][]byte{DefaultTokenKeyParam: []byte("a-token")},
+	}
+	errCloneInspected := errors.New("clone inspected")
+
+	for _, tc := range []struct {
+		name     string
+		config   map[string]string
+		scheme   string
+		wantAuth string
+	}{{
+		name:     "basic by default",
+		wantAuth: basicAuth,
+	}, {
+		name:     "bearer param",
+		scheme:   gitTokenSchemeBearer,
+		wantAuth: bearerAuth,
+	}, {
+		name:     "bearer config",
+		config:   map[string]string{GitTokenSchemeKey: gitTokenSchemeBearer},
+		wantAuth: bearerAuth,
+	}, {
+		name:     "basic param overriding the config",
+		config:   map[string]string{GitTokenSchemeKey: gitTokenSchemeBearer},
+		scheme:   gitTokenSchemeBasic,
+		wantAuth: basicAuth,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := framework.InjectResolverConfigToContext(t.Context(), tc.config)
+			ctx = common.InjectRequestNamespace(ctx, "foo")
+			params := map[string]string{
+				UrlParam:      "https://gitea.example.com/org/repo.git",
+				RevisionParam: "main",
+				PathParam:     "tasks/task.yaml",
+				GitTokenParam: tokenSecret.Name,
+			}
+			if tc.scheme != "" {
+				params[GitTokenSchemeParam] = tc.scheme
+			}
+			populated, err := PopulateDefaultParams(ctx, toParams(params))
+			if err != nil {
+				t.Fatalf("unexpected error populating the params: %v", err)
+			}
+
+			var cloneCmd *exec.Cmd
+			g := &GitResolver{
+				Params:     populated,
+				Logger:     zap.NewNop().Sugar(),
+				KubeClient: kubefake.NewSimpleClientset(tokenSecret),
+				cloneFunc: func(ctx context.Context, r remote) (*repository, func(), error) {
+					// Inspect the options of the clone without running git.
+					r.cmdExecutor = func(ctx context.Context, name string, args ...string) *exec.Cmd {
+						cloneCmd = exec.CommandContext(ctx, "echo", append([]string{name}, args...)...)
+						return cloneCmd
+					}
+					_, cleanup, err := r.clone(ctx)
+					cleanup()
+					if err != nil {
+						return nil, func() {}, err
+					}
+					return nil, func() {}, errCloneInspected
+				},
+			}
+			if _, err := g.ResolveGitClone(ctx); !errors.Is(err, errCloneInspected) {
+				t.Fatalf("expected the clone to be inspected, got %v", err)
+			}
+			if want := "GIT_AUTH_HEADER=" + tc.wantAuth; !slices.Contains(cloneCmd.Env, want) {
+				t.Errorf("expected the clone env to contain %q, got %v", want, cloneCmd.Env)
+			}
+		})
+	}
+}
+
+func TestResolveConcurrentResolutionsShareOneClone(t *testing.T) {
+	repoURL, _ := createTestRepo(t, []commitForRepo{{
+		Dir:      "tasks/",
+		Filename: "task.yaml",
+		Content:  "shared task",
+	}})
+	ctx := framework.InjectResolverConfigToContext(t.Context(), map[string]string{})
+
+	var clones atomic.Int32
+	cloning := make(chan struct{})
+	release := make(chan struct{})
+	r := &Resolver{
+		logger:      zap.NewNop().Sugar(),
+		resolutions: NewResolutionGroup(),
+		cloneFunc: func(ctx context.Context, rem remote) (*repository, func(), error) {
+			if clones.Add(1) == 1 {
+				close(cloning)
+			}
+			<-release
+			return rem.clone(ctx)
+		},
+	}
+
+	const resolutions = 10
+	urls := make([]string, resolutions)
+	results := make([]framework.ResolvedResource, resolutions)
+	errs := make([]error, resolutions)
+	var wg sync.WaitGroup
+	for i := range resolutions {
+		// The resolutions of the different URLs of the repo share the clone.
+		urls[i] = repoURL
+		if i%2 == 1 {
+			urls[i] += "/"
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = r.Resolve(ctx, toParams(map[string]string{
+				UrlParam:      urls[i],
+				RevisionParam: "main",
+				PathParam:     "tasks/task.yaml",
+			}))
+		}()
+	}
+	<-cloning
+	// Let the other resolutions wait for the clone before it completes.
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := clones.Load(); n != 1 {
+		t.Errorf("expected the concurrent resolutions to clone the repo once, got %d clones", n)
+	}
+	for i := range resolutions {
+		if errs[i] != nil {
+			t.Fatalf("unexpected error resolving the file: %v", errs[i])
+		}
+		if got := string(results[i].Data()); got != "shared task" {
+			t.Errorf("expected the content of the file, got %q", got)
+		}
+		if got := results[i].RefSource().URI; got != "git+"+urls[i]+"@main" {
+			t.Errorf("expected the source of the resolution of %s, got %s", urls[i], got)
+		}
+	}
+}
+
+func TestResolveSharedCloneOutlivesTheFirstResolution(t *testing.T) {
+	repoURL, _ := createTestRepo(t, []commitForRepo{{
+		Dir:      "tasks/",
+		Filename: "task.yaml",
+		Content:  "shared task",
+	}})
+	ctx := framework.InjectResolverConfigToContext(t.Context(), map[string]string{})
+
+	cloning := make(chan struct{})
+	release := make(chan struct{})
+	var cloneErr error
+	r := &Resolver{
+		logger:      zap.NewNop().Sugar(),
+		resolutions: NewResolutionGroup(),
+		cloneFunc: func(ctx context.Context, rem remote) (*repository, func(), error) {
+			close(cloning)
+			<-release
+			// The clone isn't cancelled with the first resolution.
+			if cloneErr = ctx.Err(); cloneErr != nil {
+				return nil, func() {}, cloneErr
+			}
+			return rem.clone(ctx)
+		},
+	}
+	params := toParams(map[string]string{
+		UrlParam:      repoURL,
+		RevisionParam: "main",
+		PathParam:     "tasks/task.yaml",
+	})
+
+	firstCtx, cancelFirst := context.WithCancel(ctx)
+	firstErr := make(chan error)
+	go func() {
+		_, err := r.Resolve(firstCtx, params)
+		firstErr <- err
+	}()
+	<-cloning
+
+	var second framework.ResolvedResource
+	var secondErr error
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		second, secondErr = r.Resolve(ctx, params)
+	}()
+	// Let the second resolution wait for the clone before the first one is
+	// cancelled.
+	time.Sleep(100 * time.Millisecond)
+	cancelFirst()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the first resolution to stop waiting when cancelled, got %v", err)
+	}
+	close(release)
+	<-done
+
+	if cloneErr != nil {
+		t.Errorf("expected the shared clone not to be cancelled, got %v", cloneErr)
+	}
+	if secondErr != nil {
+		t.Fatalf("unexpected error resolving the file: %v", secondErr)
+	}
+	if got := string(second.Data()); got != "shared task" {
+		t.Errorf("expected the content of the file, got %q", got)
+	}
+}
+
+func TestResolutionKey(t *testing.T) {
+	params := map[string]string{
+		UrlParam:      "https://github.com/tektoncd/catalog.git",
+		RevisionParam: "main",
+		PathParam:     "task/git-clone/0.9/git-clone.yaml",
+	}
+	withParam := func(name, value string) map[string]string {
+		p := maps.Clone(params)
+		p[name] = value
+		return p
+	}
+	ctx := common.InjectRequestNamespace(t.Context(), "foo")
+	key := resolutionKey(ctx, params)
+	if other := resolutionKey(ctx, withParam(UrlParam, "https://github.com/tektoncd/catalog/")); other != key {
+		t.Errorf("expected the URLs of the repo to share the key, got %q and %q", key, other)
+	}
+	for name, other := range map[string]string{
+		"another revision":  resolutionKey(ctx, withParam(RevisionParam, "v1")),
+		"another path":      resolutionKey(ctx, withParam(PathParam, "task/git-clone/0.8/git-clone.yaml")),
+		"another token":     resolutionKey(ctx, withParam(GitTokenParam, "token-secret")),
+		"another namespace": resolutionKey(common.InjectRequestNamespace(t.Context(), "bar"), params),
+	} {
+		if other == key {
+			t.Errorf("expected %s not to share the key %q", name, key)
+		}
+	}
+}
+
 func TestGetScmConfigForParamConfigKey(t *testing.T) {
 	tests := []struct {
 		name           string
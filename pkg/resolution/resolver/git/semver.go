@@ -0,0 +1,117 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/blang/semver/v4"
+)
+
+// semverRevisionPrefix is the prefix of the revisions which are semver
+// constraints, resolved to the highest tag of the repo matching them.
+const semverRevisionPrefix = "semver:"
+
+// semverConstraint is a semver constraint on the tags of a repo.
+type semverConstraint struct {
+	constraint string
+	matches    semver.Range
+	// allowPrerelease is true when a version of the constraint is a
+	// pre-release, the pre-release tags are excluded otherwise.
+	allowPrerelease bool
+}
+
+// isSemverRevision returns true if the revision is a semver constraint.
+func isSemverRevision(revision string) bool {
+	return strings.HasPrefix(revision, semverRevisionPrefix)
+}
+
+// parseSemverRevision parses the semver constraint of the revision. Besides
+// the ranges of github.com/blang/semver, e.g. ">=1.2.0 <2.0.0", the caret and
+// tilde constraints are supported: "^1.2" matches the versions compatible with
+// 1.2.0, i.e. ">=1.2.0 <2.0.0", and "~1.2" matches its patch versions, i.e.
+// ">=1.2.0 <1.3.0".
+func parseSemverRevision(revision string) (*semverConstraint, error) {
+	constraint := strings.TrimSpace(strings.TrimPrefix(revision, semverRevisionPrefix))
+	rangeString := constraint
+	var err error
+	if strings.HasPrefix(constraint, "^") || strings.HasPrefix(constraint, "~") {
+		rangeString, err = expandSemverConstraint(constraint)
+	}
+	var matches semver.Range
+	if err == nil {
+		matches, err = semver.ParseRange(rangeString)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid semver constraint %q in revision %q: %w", constraint, revision, err)
+	}
+	c := &semverConstraint{constraint: constraint, matches: matches}
+	for _, field := range strings.FieldsFunc(rangeString, func(r rune) bool { return r == ' ' || r == '|' }) {
+		if v, err := semver.ParseTolerant(strings.TrimLeft(field, "<>=!")); err == nil && len(v.Pre) > 0 {
+			c.allowPrerelease = true
+		}
+	}
+	return c, nil
+}
+
+// expandSemverConstraint returns the range of a caret or tilde constraint.
+func expandSemverConstraint(constraint string) (string, error) {
+	operator, version := constraint[:1], strings.TrimSpace(constraint[1:])
+	lower, err := semver.ParseTolerant(version)
+	if err != nil {
+		return "", err
+	}
+	// The number of the components of the version, without its pre-release
+	// and build metadata, e.g. 2 for "1.2".
+	core := strings.TrimPrefix(version, "v")
+	if i := strings.IndexAny(core, "-+"); i >= 0 {
+		core = core[:i]
+	}
+	components := strings.Count(core, ".") + 1
+	upper := semver.Version{Major: lower.Major + 1}
+	switch {
+	case operator == "~" && components > 1:
+		upper = semver.Version{Major: lower.Major, Minor: lower.Minor + 1}
+	case operator == "^" && lower.Major == 0 && lower.Minor > 0:
+		upper = semver.Version{Minor: lower.Minor + 1}
+	case operator == "^" && lower.Major == 0 && components > 2:
+		upper = semver.Version{Minor: lower.Minor, Patch: lower.Patch + 1}
+	}
+	return fmt.Sprintf(">=%s <%s", lower, upper), nil
+}
+
+// highestMatchingTag returns the highest of the tags matching the constraint.
+// The tags which aren't semver versions, with or without a "v" prefix, are
+// ignored.
+func (c *semverConstraint) highestMatchingTag(tags []string) (string, error) {
+	var highestTag string
+	var highest semver.Version
+	for _, tag := range tags {
+		v, err := semver.Parse(strings.TrimPrefix(tag, "v"))
+		if err != nil || (len(v.Pre) > 0 && !c.allowPrerelease) || !c.matches(v) {
+			continue
+		}
+		if highestTag == "" || v.GT(highest) {
+			highestTag, highest = tag, v
+		}
+	}
+	if highestTag == "" {
+		return "", fmt.Errorf("no tag of the repository matches the semver constraint %q", c.constraint)
+	}
+	return highestTag, nil
+}
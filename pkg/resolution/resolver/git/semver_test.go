@@ -0,0 +1,76 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"testing"
+)
+
+func TestHighestMatchingTag(t *testing.T) {
+	tags := []string{"v0.1.0", "v0.1.3", "v0.2.0", "1.2.0", "v1.2.5", "v1.3.0-rc.1", "v1.3.0", "v1.4.0-beta.1", "v2.0.0", "latest", "v3"}
+	for _, tc := range []struct {
+		revision string
+		want     string
+	}{
+		{revision: "semver:^1.2", want: "v1.3.0"},
+		{revision: "semver:^1.2.5", want: "v1.3.0"},
+		{revision: "semver:~1.2", want: "v1.2.5"},
+		{revision: "semver:~1", want: "v1.3.0"},
+		{revision: "semver:^0.1", want: "v0.1.3"},
+		{revision: "semver:^0.1.0", want: "v0.1.3"},
+		{revision: "semver:>=1.0.0 <1.3.0", want: "v1.2.5"},
+		{revision: "semver:>=2.0.0 || <0.2.0", want: "v2.0.0"},
+		{revision: "semver: ^1.4.0-alpha", want: "v1.4.0-beta.1"},
+		{revision: "semver:=1.2.0", want: "1.2.0"},
+	} {
+		t.Run(tc.revision, func(t *testing.T) {
+			constraint, err := parseSemverRevision(tc.revision)
+			if err != nil {
+				t.Fatalf("unexpected error parsing the revision: %v", err)
+			}
+			got, err := constraint.highestMatchingTag(tags)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("expected the tag %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestHighestMatchingTag_NoMatch(t *testing.T) {
+	constraint, err := parseSemverRevision("semver:~1.3")
+	if err != nil {
+		t.Fatalf("unexpected error parsing the revision: %v", err)
+	}
+	// The pre-releases are excluded as the constraint doesn't opt in.
+	_, err = constraint.highestMatchingTag([]string{"v1.2.0", "v1.3.0-rc.1", "v1.4.0"})
+	if err == nil || err.Error() != `no tag of the repository matches the semver constraint "~1.3"` {
+		t.Errorf("expected no tag to match, got %v", err)
+	}
+}
+
+func TestParseSemverRevision_Invalid(t *testing.T) {
+	for _, revision := range []string{"semver:", "semver:^one", "semver:>=1.x.0 <<2"} {
+		t.Run(revision, func(t *testing.T) {
+			if _, err := parseSemverRevision(revision); err == nil {
+				t.Errorf("expected an error parsing the revision %q", revision)
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package git
 
 import (
+	"context"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -28,6 +29,10 @@ import (
 
 const defaultBranch string = "main"
 
+// testCommitDate is the date of the commits of the test repositories, so that
+// the timestamp of their resolved commits is known.
+const testCommitDate = "2025-01-02T03:04:05Z"
+
 // withTemporaryGitConfig resets the .gitconfig for the duration of the test.
 func withTemporaryGitConfig(t *testing.T) {
 	t.Helper()
@@ -144,7 +149,9 @@ func writeAndCommitToTestRepo(t *testing.T, repoDir string, subPath string, file
 		t.Fatalf("couldn't add file %s to git: %q: %v", outfile, out, err)
 	}
 
-	if out, err := gitCmd("commit", "-m", "adding file for test").Output(); err != nil {
+	commitCmd := gitCmd("commit", "-m", "adding file for test")
+	commitCmd.Env = append(os.Environ(), "GIT_AUTHOR_DATE="+testCommitDate, "GIT_COMMITTER_DATE="+testCommitDate)
+	if out, err := commitCmd.Output(); err != nil {
 		t.Fatalf("couldn't perform commit for test: %q: %v", out, err)
 	}
 
@@ -155,3 +162,69 @@ func writeAndCommitToTestRepo(t *testing.T, repoDir string, subPath string, file
 
 	return strings.TrimSpace(string(out))
 }
+
+// addSubmoduleToTestRepo adds the local repository at submodulePath as a
+// submodule of the test repository at path, and returns the SHA of the commit.
+func addSubmoduleToTestRepo(t *testing.T, repoDir, submodulePath, path string) string {
+	t.Helper()
+	gitCmd := getGitCmd(t, repoDir)
+	if out, err := gitCmd("-c", "protocol.file.allow=always", "submodule", "add", submodulePath, path).CombinedOutput(); err != nil {
+		t.Fatalf("couldn't add submodule %s: %q: %v", submodulePath, out, err)
+	}
+	commitCmd := gitCmd("commit", "-m", "adding submodule for test")
+	commitCmd.Env = append(os.Environ(), "GIT_AUTHOR_DATE="+testCommitDate, "GIT_COMMITTER_DATE="+testCommitDate)
+	if out, err := commitCmd.CombinedOutput(); err != nil {
+		t.Fatalf("couldn't commit submodule %s: %q: %v", submodulePath, out, err)
+	}
+	out, err := gitCmd("rev-parse", "HEAD").Output()
+	if err != nil {
+		t.Fatalf("couldn't parse HEAD revision: %v", err)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// addSymlinkToTestRepo commits a symlink to target at the given path of the
+// test repository, and returns the SHA of the commit.
+func addSymlinkToTestRepo(t *testing.T, repoDir, path, target string) string {
+	t.Helper()
+	gitCmd := getGitCmd(t, repoDir)
+	link := filepath.Join(repoDir, path)
+	if err := os.MkdirAll(filepath.Dir(link), 0o700); err != nil {
+		t.Fatalf("couldn't create the directory of symlink %s: %v", path, err)
+	}
+	if err := os.Symlink(target, link); err != nil {
+		t.Fatalf("couldn't create symlink %s: %v", path, err)
+	}
+	if out, err := gitCmd("add", link).CombinedOutput(); err != nil {
+		t.Fatalf("couldn't add symlink %s to git: %q: %v", path, out, err)
+	}
+	commitCmd := gitCmd("commit", "-m", "adding symlink for test")
+	commitCmd.Env = append(os.Environ(), "GIT_AUTHOR_DATE="+testCommitDate, "GIT_COMMITTER_DATE="+testCommitDate)
+	if out, err := commitCmd.CombinedOutput(); err != nil {
+		t.Fatalf("couldn't commit symlink %s: %q: %v", path, out, err)
+	}
+	out, err := gitCmd("rev-parse", "HEAD").Output()
+	if err != nil {
+		t.Fatalf("couldn't parse HEAD revision: %v", err)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// createTestRepoWithSubmodules returns a local test repository with the
+// submodule "common", which has a tasks/build.yaml file and the nested
+// submodule "nested", which has a task.yaml file.
+func createTestRepoWithSubmodules(t *testing.T) string {
+	t.Helper()
+	nestedPath, _ := createTestRepo(t, []commitForRepo{{Filename: "task.yaml", Content: "nested task"}})
+	commonPath, _ := createTestRepo(t, []commitForRepo{{Dir: "tasks/", Filename: "build.yaml", Content: "build task"}})
+	addSubmoduleToTestRepo(t, commonPath, nestedPath, "nested")
+	repoPath, _ := createTestRepo(t, []commitForRepo{{Filename: "pipeline.yaml", Content: "pipeline"}})
+	addSubmoduleToTestRepo(t, repoPath, commonPath, "common")
+	return repoPath
+}
+
+// allowFileSubmodules is a cmdExecutor allowing the local test repositories
+// to be cloned as submodules.
+func allowFileSubmodules(ctx context.Context, name string, args ...string) *exec.Cmd {
+	return exec.CommandContext(ctx, name, append([]string{"-c", "protocol.file.allow=always"}, args...)...)
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+const (
+	// scmTypeStash and scmTypeBitbucketServer are the SCM types of Bitbucket
+	// Data Center, formerly Bitbucket Server and Stash, whose repos belong to
+	// a project: the org param is the key of the project with these types.
+	scmTypeStash           = "stash"
+	scmTypeBitbucketServer = "bitbucketserver"
+
+	// stashAPIPath is the path of the REST API of Bitbucket Data Center,
+	// which the stash driver adds to the server URL.
+	stashAPIPath = "/rest/api/1.0"
+)
+
+// isStashSCMType returns true if the SCM type is the one of Bitbucket Data
+// Center.
+func isStashSCMType(scmType string) bool {
+	return scmType == scmTypeStash || scmType == scmTypeBitbucketServer
+}
+
+// stashBaseURL is the client option dropping the path of the REST API from
+// the base URL of a Bitbucket Data Center client, so that the server URL can
+// be configured as the one of the server or of its API.
+func stashBaseURL(client *scm.Client) {
+	if client.BaseURL == nil {
+		return
+	}
+	path := strings.TrimSuffix(client.BaseURL.Path, "/")
+	path = strings.TrimSuffix(path, stashAPIPath)
+	client.BaseURL.Path = strings.TrimSuffix(path, "/") + "/"
+}
+
+// stashOrgRepo returns the "<project>/<repo>" of a repo of Bitbucket Data
+// Center. The keys of the projects are upper case, while the keys of the
+// personal projects are the "~"-prefixed slugs of their users and are kept
+// as is.
+func stashOrgRepo(org, repo string) string {
+	if !strings.HasPrefix(org, "~") {
+		org = strings.ToUpper(org)
+	}
+	return fmt.Sprintf("%s/%s", org, repo)
+}
+
+// resolveStashRef returns the full ref of the revision in a Bitbucket Data
+// Center repo, which its API needs to look the files up at a branch or a tag,
+// and the SHA of its commit. The revision is looked up as a branch first and
+// as a tag otherwise. Commit SHAs and full refs are returned as is.
+func resolveStashRef(ctx context.Context, scmClient *scm.Client, orgRepo, revision string) (ref, sha string, err error) {
+	if commitSHARegex.MatchString(revision) || strings.HasPrefix(revision, "refs/") {
+		return revision, revision, nil
+	}
+	branch, res, err := scmClient.Git.FindBranch(ctx, orgRepo, revision)
+	if err == nil && branch != nil {
+		return "refs/heads/" + revision, branch.Sha, nil
+	}
+	if err != nil && !errors.Is(err, scm.ErrNotFound) {
+		return "", "", fmt.Errorf("couldn't fetch the branch %s in the repo: %w", revision, newSCMStatusError(res, err))
+	}
+	tag, res, err := scmClient.Git.FindTag(ctx, orgRepo, revision)
+	if err == nil && tag != nil {
+		return "refs/tags/" + revision, tag.Sha, nil
+	}
+	if err != nil && !errors.Is(err, scm.ErrNotFound) {
+		return "", "", fmt.Errorf("couldn't fetch the tag %s in the repo: %w", revision, newSCMStatusError(res, err))
+	}
+	return "", "", fmt.Errorf("couldn't find a branch or a tag %s in the repo: %w", revision, scm.ErrNotFound)
+}
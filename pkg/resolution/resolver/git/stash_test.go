@@ -0,0 +1,224 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/fake"
+	"github.com/jenkins-x/go-scm/scm/factory"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/cache"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+const (
+	stashTestOrgRepo   = "PROJ/repo"
+	stashTestBranchSHA = "0123456789abcdef0123456789abcdef01234567"
+	stashTestTagSHA    = "fedcba9876543210fedcba9876543210fedcba98"
+)
+
+// fakeStashGit behaves like the Bitbucket Data Center driver, which looks the
+// branches and the tags up by name.
+type fakeStashGit struct {
+	scm.GitService
+}
+
+func (g fakeStashGit) FindBranch(_ context.Context, repo, name string) (*scm.Reference, *scm.Response, error) {
+	if repo != stashTestOrgRepo || name != "main" {
+		return nil, nil, scm.ErrNotFound
+	}
+	return &scm.Reference{Name: name, Path: "refs/heads/main", Sha: stashTestBranchSHA}, nil, nil
+}
+
+func (g fakeStashGit) FindTag(_ context.Context, repo, name string) (*scm.Reference, *scm.Response, error) {
+	if repo != stashTestOrgRepo || name != "v1" {
+		return nil, nil, scm.ErrNotFound
+	}
+	return &scm.Reference{Name: name, Path: "refs/tags/v1", Sha: stashTestTagSHA}, nil, nil
+}
+
+func (g fakeStashGit) FindCommit(_ context.Context, repo, ref string) (*scm.Commit, *scm.Response, error) {
+	if repo != stashTestOrgRepo || (ref != stashTestBranchSHA && ref != stashTestTagSHA) {
+		return nil, nil, fmt.Errorf("unexpected commit %s in %s", ref, repo)
+	}
+	return &scm.Commit{Sha: ref}, nil, nil
+}
+
+// fakeStashContents serves the files of the repo at the full refs of its
+// main branch and of its v1 tag.
+type fakeStashContents struct {
+	scm.ContentService
+}
+
+func (c fakeStashContents) Find(_ context.Context, repo, p, ref string) (*scm.Content, *scm.Response, error) {
+	if repo != stashTestOrgRepo || (ref != "refs/heads/main" && ref != "refs/tags/v1") {
+		return nil, nil, fmt.Errorf("unexpected file %s at %s in %s", p, ref, repo)
+	}
+	if p != "task/task.yaml" {
+		return nil, nil, scm.ErrNotFound
+	}
+	return &scm.Content{Path: p, Data: []byte("task at " + ref)}, nil, nil
+}
+
+func (c fakeStashContents) List(context.Context, string, string, string, *scm.ListOptions) ([]*scm.FileEntry, *scm.Response, error) {
+	return nil, nil, scm.ErrNotFound
+}
+
+func TestResolveAPIGitWithStash(t *testing.T) {
+	tokenSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "token-secret", Namespace: "tekton-pipelines"},
+		Data:       map[string][]byte{"token": []byte("stash-token")},
+	}
+	for _, tc := range []struct {
+		name     string
+		org      string
+		revision string
+		wantData string
+		wantSHA  string
+		wantErr  string
+	}{{
+		name:     "branch",
+		org:      "PROJ",
+		revision: "main",
+		wantData: "task at refs/heads/main",
+		wantSHA:  stashTestBranchSHA,
+	}, {
+		name:     "tag",
+		org:      "PROJ",
+		revision: "v1",
+		wantData: "task at refs/tags/v1",
+		wantSHA:  stashTestTagSHA,
+	}, {
+		name:     "lower case project key",
+		org:      "proj",
+		revision: "main",
+		wantData: "task at refs/heads/main",
+		wantSHA:  stashTestBranchSHA,
+	}, {
+		name:     "unknown revision",
+		org:      "PROJ",
+		revision: "other",
+		wantErr:  "couldn't find a branch or a tag other in the repo: Not Found",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := &GitResolver{
+				Params: map[string]string{
+					OrgParam:      tc.org,
+					RepoParam:     "repo",
+					PathParam:     "task/task.yaml",
+					RevisionParam: tc.revision,
+				},
+				Logger:     zap.NewNop().Sugar(),
+				Cache:      cache.NewLRUExpireCache(cacheSize),
+				TTL:        ttl,
+				KubeClient: kubefake.NewSimpleClientset(tokenSecret),
+			}
+			clientFunc := func(driver, serverURL, token string, opts ...factory.ClientOptionFunc) (*scm.Client, error) {
+				if driver != scmTypeStash || token != "stash-token" {
+					t.Errorf("unexpected SCM client for %s with token %q", driver, token)
+				}
+				scmClient, scmData := fake.NewDefault()
+				scmClient.BaseURL, _ = url.Parse(serverURL)
+				for _, opt := range opts {
+					opt(scmClient)
+				}
+				if got := scmClient.BaseURL.String(); got != "https://bitbucket.example.com/" {
+					t.Errorf("expected the base URL of the server, got %s", got)
+				}
+				scmData.Repositories = []*scm.Repository{{FullName: stashTestOrgRepo, Clone: "https://bitbucket.example.com/scm/proj/repo.git"}}
+				scmClient.Git = fakeStashGit{GitService: scmClient.Git}
+				scmClient.Contents = fakeStashContents{ContentService: scmClient.Contents}
+				return scmClient, nil
+			}
+
+			ctx := framework.InjectResolverConfigToContext(t.Context(), map[string]string{
+				SCMTypeKey:            scmTypeStash,
+				ServerURLKey:          "https://bitbucket.example.com/rest/api/1.0",
+				APISecretNameKey:      "token-secret",
+				APISecretKeyKey:       "token",
+				APISecretNamespaceKey: "tekton-pipelines",
+			})
+			res, err := g.ResolveAPIGit(ctx, clientFunc)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("expected error %q, got %v", tc.wantErr, err)
+				}
+				if !errors.Is(err, scm.ErrNotFound) {
+					t.Errorf("expected a not found error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error resolving from Bitbucket Data Center: %v", err)
+			}
+			if string(res.Data()) != tc.wantData {
+				t.Errorf("expected the content %q, got %q", tc.wantData, res.Data())
+			}
+			if src := res.RefSource(); src.Digest["sha1"] != tc.wantSHA {
+				t.Errorf("expected the file to be resolved at the commit %s, got %+v", tc.wantSHA, src)
+			}
+		})
+	}
+}
+
+func TestStashBaseURL(t *testing.T) {
+	for _, serverURL := range []string{
+		"https://bitbucket.example.com",
+		"https://bitbucket.example.com/",
+		"https://bitbucket.example.com/rest/api/1.0",
+		"https://bitbucket.example.com/rest/api/1.0/",
+	} {
+		t.Run(serverURL, func(t *testing.T) {
+			scmClient, err := factory.NewClient(scmTypeStash, serverURL, "token", stashBaseURL)
+			if err != nil {
+				t.Fatalf("unexpected error creating the client: %v", err)
+			}
+			if got := scmClient.BaseURL.String(); got != "https://bitbucket.example.com/" {
+				t.Errorf("expected the base URL of the server, got %s", got)
+			}
+		})
+	}
+}
+
+func TestStashOrgRepo(t *testing.T) {
+	for _, tc := range []struct {
+		org  string
+		want string
+	}{{
+		org:  "PROJ",
+		want: "PROJ/repo",
+	}, {
+		org:  "proj",
+		want: "PROJ/repo",
+	}, {
+		org:  "~jdoe",
+		want: "~jdoe/repo",
+	}} {
+		if got := stashOrgRepo(tc.org, "repo"); got != tc.want {
+			t.Errorf("expected %s for the org %s, got %s", tc.want, tc.org, got)
+		}
+	}
+}
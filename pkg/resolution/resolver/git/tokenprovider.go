@@ -0,0 +1,148 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// providerTokenExpiryMargin is how long before its advertised expiry a token
+// of a credential plugin stops being reused.
+const providerTokenExpiryMargin = time.Minute
+
+// TokenProvider returns a token authenticating to the repos and to the SCM
+// API of the config, e.g. a short-lived token minted with the workload
+// identity of the resolver. It can advertise the expiry of the token with
+// SetTokenExpiry, the token is not reused otherwise.
+type TokenProvider func(ctx context.Context, conf ScmConfig) (string, error)
+
+var (
+	tokenProvidersMu sync.RWMutex
+	tokenProviders   = map[string]TokenProvider{}
+)
+
+// RegisterTokenProvider registers the provider of the tokens of the
+// credential plugin with the given name, which the configs select with
+// credential-plugin. It's meant to be called from the init functions of the
+// downstream builds of the resolvers, and panics if the name is already
+// registered.
+func RegisterTokenProvider(name string, provider TokenProvider) {
+	tokenProvidersMu.Lock()
+	defer tokenProvidersMu.Unlock()
+	if provider == nil {
+		panic("git: RegisterTokenProvider provider is nil")
+	}
+	if _, dup := tokenProviders[name]; dup {
+		panic("git: RegisterTokenProvider called twice for provider " + name)
+	}
+	tokenProviders[name] = provider
+}
+
+// StaticTokenProvider returns a TokenProvider always returning the token,
+// advertising the given expiry unless it's zero.
+func StaticTokenProvider(token string, expiresAt time.Time) TokenProvider {
+	return func(ctx context.Context, _ ScmConfig) (string, error) {
+		if !expiresAt.IsZero() {
+			SetTokenExpiry(ctx, expiresAt)
+		}
+		return token, nil
+	}
+}
+
+type tokenExpiryKey struct{}
+
+// SetTokenExpiry advertises the expiry of the token returned by the
+// TokenProvider called with the context, so that the token is reused until
+// shortly before then.
+func SetTokenExpiry(ctx context.Context, expiresAt time.Time) {
+	if expiry, ok := ctx.Value(tokenExpiryKey{}).(*time.Time); ok {
+		*expiry = expiresAt
+	}
+}
+
+// tokenProviderCacheKey is the key of the tokens of the credential plugins in
+// the resolver cache.
+type tokenProviderCacheKey struct {
+	plugin    string
+	configKey string
+}
+
+// getProviderToken returns a token of the credential plugin of the config,
+// calling its TokenProvider if there's none cached which isn't about to
+// expire.
+func (g *GitResolver) getProviderToken(ctx context.Context, conf ScmConfig) (string, error) {
+	tokenProvidersMu.RLock()
+	provider, ok := tokenProviders[conf.CredentialPlugin]
+	tokenProvidersMu.RUnlock()
+	if !ok {
+		err := fmt.Errorf("cannot get token, '%s' %q is not registered", CredentialPluginKey, conf.CredentialPlugin)
+		g.Logger.Info(err)
+		return "", err
+	}
+
+	cacheKey := tokenProviderCacheKey{plugin: conf.CredentialPlugin, configKey: g.Params[ConfigKeyParam]}
+	if cacheKey.configKey == "" {
+		cacheKey.configKey = "default"
+	}
+	if val, ok := g.Cache.Get(cacheKey); ok {
+		return val.(string), nil
+	}
+
+	var expiresAt time.Time
+	token, err := provider(context.WithValue(ctx, tokenExpiryKey{}, &expiresAt), conf)
+	if err != nil {
+		wrappedErr := fmt.Errorf("credential plugin %s failed to provide a token: %w", conf.CredentialPlugin, err)
+		g.Logger.Info(wrappedErr)
+		return "", wrappedErr
+	}
+	if ttl := time.Until(expiresAt) - providerTokenExpiryMargin; !expiresAt.IsZero() && ttl > 0 {
+		g.Cache.Add(cacheKey, token, ttl)
+	}
+	return token, nil
+}
+
+// usesCredentialPluginFor returns whether the requests to the URL are
+// authenticated with the token of the credential plugin of the config. The
+// token is only sent to the host of the server-url of the config, or of the
+// public server of its scm-type, to the one of its default-url and to its
+// credential-plugin-hosts, and never to the hosts of the params.
+func (c ScmConfig) usesCredentialPluginFor(rawURL string) bool {
+	if c.CredentialPlugin == "" {
+		return false
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	hosts := strings.Split(c.CredentialPluginHosts, ",")
+	for _, configURL := range []string{effectiveServerURL(c.SCMType, c.ServerURL), c.URL} {
+		if configURL, err := url.Parse(configURL); err == nil {
+			hosts = append(hosts, configURL.Host)
+		}
+	}
+	for _, host := range hosts {
+		if host = strings.TrimSpace(host); host != "" && strings.EqualFold(u.Host, host) {
+			return true
+		}
+	}
+	return false
+}
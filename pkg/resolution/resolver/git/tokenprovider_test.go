@@ -0,0 +1,355 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/fake"
+	"github.com/jenkins-x/go-scm/scm/factory"
+	"github.com/tektoncd/pipeline/pkg/resolution/common"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/cache"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+// registerTestTokenProvider registers the provider for the duration of the
+// test, which RegisterTokenProvider doesn't allow.
+func registerTestTokenProvider(t *testing.T, name string, provider TokenProvider) {
+	t.Helper()
+	tokenProvidersMu.Lock()
+	defer tokenProvidersMu.Unlock()
+	tokenProviders[name] = provider
+	t.Cleanup(func() {
+		tokenProvidersMu.Lock()
+		defer tokenProvidersMu.Unlock()
+		delete(tokenProviders, name)
+	})
+}
+
+// tokenSecrets returns the secrets of the tokens of the params and of the
+// config of the tests.
+func tokenSecrets() *kubefake.Clientset {
+	return kubefake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "token-secret", Namespace: "foo"},
+		Data:       map[string][]byte{"token": []byte("param-secret-token")},
+	}, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "api-token", Namespace: "tekton-pipelines"},
+		Data:       map[string][]byte{"token": []byte("config-secret-token")},
+	})
+}
+
+func TestResolveAPIGitWithCredentialPlugin(t *testing.T) {
+	registerTestTokenProvider(t, "static", StaticTokenProvider("provider-token", time.Now().Add(time.Hour)))
+	config := map[string]string{
+		SCMTypeKey:            "github",
+		APISecretNameKey:      "api-token",
+		APISecretKeyKey:       "token",
+		APISecretNamespaceKey: "tekton-pipelines",
+	}
+	for _, tc := range []struct {
+		name      string
+		plugin    string
+		params    map[string]string
+		wantToken string
+	}{{
+		name:      "plugin token wins over the config secret",
+		plugin:    "static",
+		wantToken: "provider-token",
+	}, {
+		name:      "plugin token wins over the token param secret",
+		plugin:    "static",
+		params:    map[string]string{TokenParam: "token-secret", TokenKeyParam: "token"},
+		wantToken: "provider-token",
+	}, {
+		name:      "plugin token not sent to the server of the params",
+		plugin:    "static",
+		params:    map[string]string{ServerURLParam: "https://github.example.com", TokenParam: "token-secret", TokenKeyParam: "token"},
+		wantToken: "param-secret-token",
+	}, {
+		name:      "config secret without plugin",
+		wantToken: "config-secret-token",
+	}, {
+		name:      "token param secret without plugin",
+		params:    map[string]string{TokenParam: "token-secret", TokenKeyParam: "token"},
+		wantToken: "param-secret-token",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			params := map[string]string{
+				OrgParam:      "test-org",
+				RepoParam:     "test-repo",
+				PathParam:     "pipelines/example-pipeline.yaml",
+				RevisionParam: "main",
+			}
+			for k, v := range tc.params {
+				params[k] = v
+			}
+			g := &GitResolver{
+				Params:     params,
+				Logger:     zap.NewNop().Sugar(),
+				Cache:      cache.NewLRUExpireCache(cacheSize),
+				TTL:        ttl,
+				KubeClient: tokenSecrets(),
+			}
+			var gotToken string
+			clientFunc := func(_, _, token string, _ ...factory.ClientOptionFunc) (*scm.Client, error) {
+				gotToken = token
+				scmClient, scmData := fake.NewDefault()
+				scmData.Repositories = []*scm.Repository{{FullName: "test-org/test-repo", Clone: "https://fake/test-org/test-repo.git"}}
+				scmData.Commits = map[string]*scm.Commit{"main": {Sha: "abc"}}
+				return scmClient, nil
+			}
+
+			conf := map[string]string{CredentialPluginKey: tc.plugin}
+			for k, v := range config {
+				conf[k] = v
+			}
+			ctx := common.InjectRequestNamespace(framework.InjectResolverConfigToContext(t.Context(), conf), "foo")
+			if _, err := g.ResolveAPIGit(ctx, clientFunc); err != nil {
+				t.Fatalf("unexpected error resolving: %v", err)
+			}
+			if gotToken != tc.wantToken {
+				t.Errorf("expected the SCM client to be created with %q, got %q", tc.wantToken, gotToken)
+			}
+		})
+	}
+}
+
+func TestResolveGitCloneWithCredentialPlugin(t *testing.T) {
+	registerTestTokenProvider(t, "static", StaticTokenProvider("provider-token", time.Time{}))
+	for _, tc := range []struct {
+		name         string
+		plugin       string
+		url          string
+		config       map[string]string
+		wantURL      string
+		wantPassword string
+	}{{
+		name:         "plugin token wins over the gitToken secret",
+		plugin:       "static",
+		wantPassword: "provider-token",
+	}, {
+		name:         "gitToken secret without plugin",
+		wantPassword: "param-secret-token",
+	}, {
+		name:         "plugin token not sent to the hosts of the params",
+		plugin:       "static",
+		url:          "https://git.example.com/tektoncd/catalog.git",
+		wantPassword: "param-secret-token",
+	}, {
+		name:         "plugin token sent to the default-url host",
+		plugin:       "static",
+		url:          "https://git.example.com/tektoncd/catalog.git",
+		config:       map[string]string{DefaultURLKey: "https://git.example.com/tektoncd/other.git"},
+		wantPassword: "provider-token",
+	}, {
+		name:         "plugin token sent to the allowed hosts",
+		plugin:       "static",
+		url:          "https://git.example.com/tektoncd/catalog.git",
+		config:       map[string]string{CredentialPluginHostsKey: "gitlab.com, git.example.com"},
+		wantPassword: "provider-token",
+	}, {
+		name:   "plugin token not sent to the host the url is rewritten to",
+		plugin: "static",
+		config: map[string]string{
+			URLRewriteKey + ".github.from": "https://github.com/",
+			URLRewriteKey + ".github.to":   "https://git.example.com/",
+		},
+		wantURL:      "https://git.example.com/tektoncd/catalog.git",
+		wantPassword: "param-secret-token",
+	}, {
+		name:   "plugin token sent to the allowed host the url is rewritten to",
+		plugin: "static",
+		url:    "https://git.example.com/tektoncd/catalog.git",
+		config: map[string]string{
+			URLRewriteKey + ".example.from": "https://git.example.com/",
+			URLRewriteKey + ".example.to":   "https://github.com/",
+		},
+		wantURL:      "https://github.com/tektoncd/catalog.git",
+		wantPassword: "provider-token",
+	}, {
+		name:   "neither the plugin token nor the gitToken secret sent to the mirror host",
+		plugin: "static",
+		config: map[string]string{
+			RepoMirrorKey + ".github.upstream": "https://github.com/",
+			RepoMirrorKey + ".github.mirror":   "https://mirror.example.com/",
+		},
+		wantURL: "https://mirror.example.com/tektoncd/catalog.git",
+	}, {
+		name:   "plugin token sent to the allowed mirror host",
+		plugin: "static",
+		config: map[string]string{
+			RepoMirrorKey + ".github.upstream": "https://github.com/",
+			RepoMirrorKey + ".github.mirror":   "https://mirror.example.com/",
+			CredentialPluginHostsKey:           "mirror.example.com",
+		},
+		wantURL:      "https://mirror.example.com/tektoncd/catalog.git",
+		wantPassword: "provider-token",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			url := tc.url
+			if url == "" {
+				url = "https://github.com/tektoncd/catalog.git"
+			}
+			config := map[string]string{CredentialPluginKey: tc.plugin}
+			for k, v := range tc.config {
+				config[k] = v
+			}
+			var got remote
+			g := &GitResolver{
+				Params: map[string]string{
+					UrlParam:         url,
+					RevisionParam:    "main",
+					PathParam:        "task/git-clone/0.9/git-clone.yaml",
+					GitTokenParam:    "token-secret",
+					GitTokenKeyParam: "token",
+				},
+				Logger:     zap.NewNop().Sugar(),
+				Cache:      cache.NewLRUExpireCache(cacheSize),
+				TTL:        ttl,
+				KubeClient: tokenSecrets(),
+				cloneFunc: func(_ context.Context, rem remote) (*repository, func(), error) {
+					got = rem
+					// Don't reach out to GitHub.
+					return nil, func() {}, errors.New("not cloned")
+				},
+			}
+			ctx := common.InjectRequestNamespace(framework.InjectResolverConfigToContext(t.Context(), config), "foo")
+			if _, err := g.ResolveGitClone(ctx); err == nil {
+				t.Fatal("expected the clone to fail")
+			}
+			wantURL := tc.wantURL
+			if wantURL == "" {
+				wantURL = url
+			}
+			if got.url != wantURL {
+				t.Errorf("expected the repo to be cloned from %s, got %s", wantURL, got.url)
+			}
+			wantUsername := ""
+			if tc.wantPassword != "" {
+				wantUsername = "git"
+			}
+			if got.username != wantUsername || got.password != tc.wantPassword {
+				t.Errorf("expected the repo to be cloned as %q with %q, got %q with %q", wantUsername, tc.wantPassword, got.username, got.password)
+			}
+		})
+	}
+}
+
+func TestGetProviderToken(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		expiresIn time.Duration
+		wantCalls int
+	}{{
+		name:      "reused until the expiry",
+		expiresIn: time.Hour,
+		wantCalls: 1,
+	}, {
+		name:      "about to expire",
+		expiresIn: providerTokenExpiryMargin / 2,
+		wantCalls: 2,
+	}, {
+		name:      "no advertised expiry",
+		wantCalls: 2,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			calls := 0
+			registerTestTokenProvider(t, "counting", func(ctx context.Context, conf ScmConfig) (string, error) {
+				calls++
+				if conf.ServerURL != "https://github.example.com" {
+					t.Errorf("expected the provider to be called with the config, got %v", conf)
+				}
+				if tc.expiresIn != 0 {
+					SetTokenExpiry(ctx, time.Now().Add(tc.expiresIn))
+				}
+				return "provider-token", nil
+			})
+			g := &GitResolver{
+				Params: map[string]string{},
+				Logger: zap.NewNop().Sugar(),
+				Cache:  cache.NewLRUExpireCache(cacheSize),
+			}
+			conf := ScmConfig{CredentialPlugin: "counting", ServerURL: "https://github.example.com"}
+			for range 2 {
+				token, err := g.getProviderToken(t.Context(), conf)
+				if err != nil {
+					t.Fatalf("unexpected error getting the token: %v", err)
+				}
+				if token != "provider-token" {
+					t.Errorf("expected the token of the provider, got %q", token)
+				}
+			}
+			if calls != tc.wantCalls {
+				t.Errorf("expected the provider to be called %d times, got %d", tc.wantCalls, calls)
+			}
+		})
+	}
+}
+
+func TestGetProviderToken_Error(t *testing.T) {
+	registerTestTokenProvider(t, "failing", func(context.Context, ScmConfig) (string, error) {
+		return "", errors.New("no workload identity")
+	})
+	for _, tc := range []struct {
+		name    string
+		plugin  string
+		wantErr string
+	}{{
+		name:    "not registered",
+		plugin:  "missing",
+		wantErr: `cannot get token, 'credential-plugin' "missing" is not registered`,
+	}, {
+		name:    "provider failed",
+		plugin:  "failing",
+		wantErr: "credential plugin failing failed to provide a token: no workload identity",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := &GitResolver{
+				Params: map[string]string{},
+				Logger: zap.NewNop().Sugar(),
+				Cache:  cache.NewLRUExpireCache(cacheSize),
+			}
+			_, err := g.getProviderToken(t.Context(), ScmConfig{CredentialPlugin: tc.plugin})
+			if err == nil || err.Error() != tc.wantErr {
+				t.Errorf("expected error %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestRegisterTokenProvider(t *testing.T) {
+	t.Cleanup(func() {
+		tokenProvidersMu.Lock()
+		defer tokenProvidersMu.Unlock()
+		delete(tokenProviders, "registered")
+	})
+	RegisterTokenProvider("registered", StaticTokenProvider("token", time.Time{}))
+	defer func() {
+		if recover() == nil {
+			t.Error("expected registering a provider twice to panic")
+		}
+	}()
+	RegisterTokenProvider("registered", StaticTokenProvider("token", time.Time{}))
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	urlRewriteFromField = "from"
+	urlRewriteToField   = "to"
+)
+
+// URLRewrite rewrites the URLs of the repos starting with From to start with
+// To instead before cloning them, like the insteadOf of the git config.
+type URLRewrite struct {
+	From string
+	To   string
+}
+
+// splitURLRewriteKey splits a key of the config of the form
+// "[<configKey>.]url-rewrite.<n>.<field>", and returns false if the key isn't
+// the key of a URL rewrite.
+func splitURLRewriteKey(key string) (configIdentifier, name, field string, ok bool) {
+	return splitNamedKey(key, URLRewriteKey)
+}
+
+// splitNamedKey splits a key of the config of the form
+// "[<configKey>.]<prefix>.<n>.<field>", and returns false if the key doesn't
+// have this form.
+func splitNamedKey(key, prefix string) (configIdentifier, name, field string, ok bool) {
+	parts := strings.Split(key, ".")
+	switch {
+	case len(parts) == 3 && parts[0] == prefix:
+		return "default", parts[1], parts[2], true
+	case len(parts) == 4 && parts[1] == prefix:
+		return parts[0], parts[2], parts[3], true
+	default:
+		return "", "", "", false
+	}
+}
+
+// setURLRewriteField sets the field of the URL rewrite with the given name
+// from the value of its key in the config.
+func (c *ScmConfig) setURLRewriteField(key, name, field, value string) error {
+	if c.URLRewrites == nil {
+		c.URLRewrites = map[string]URLRewrite{}
+	}
+	rewrite := c.URLRewrites[name]
+	switch field {
+	case urlRewriteFromField:
+		rewrite.From = value
+	case urlRewriteToField:
+		rewrite.To = value
+	default:
+		return fmt.Errorf("key %s passed in git resolver configmap is invalid, the field of a %s must be %q or %q", key, URLRewriteKey, urlRewriteFromField, urlRewriteToField)
+	}
+	c.URLRewrites[name] = rewrite
+	return nil
+}
+
+// rewriteURL returns the URL the repo with the given URL is fetched from,
+// rewritten by the URL rewrite of the config with the longest matching
+// prefix, or the URL itself if none matches.
+func (c ScmConfig) rewriteURL(repoURL string) (string, error) {
+	var longest *URLRewrite
+	froms := map[string]string{}
+	for name, rewrite := range c.URLRewrites {
+		if rewrite.From == "" || rewrite.To == "" {
+			return "", fmt.Errorf("%s %q of the git resolver config must set both %q and %q", URLRewriteKey, name, urlRewriteFromField, urlRewriteToField)
+		}
+		if other, ok := froms[rewrite.From]; ok {
+			return "", fmt.Errorf("%s %q and %q of the git resolver config rewrite the same prefix %q", URLRewriteKey, min(name, other), max(name, other), rewrite.From)
+		}
+		froms[rewrite.From] = name
+		if strings.HasPrefix(repoURL, rewrite.From) && (longest == nil || len(rewrite.From) > len(longest.From)) {
+			longest = &rewrite
+		}
+	}
+	if longest == nil {
+		return repoURL, nil
+	}
+	return longest.To + strings.TrimPrefix(repoURL, longest.From), nil
+}
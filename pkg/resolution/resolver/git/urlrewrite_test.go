@@ -0,0 +1,194 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"go.uber.org/zap"
+)
+
+func TestRewriteURL(t *testing.T) {
+	conf := ScmConfig{URLRewrites: map[string]URLRewrite{
+		"github": {From: "https://github.com/", To: "https://mirror.example.com/github/"},
+		"tekton": {From: "https://github.com/tektoncd/", To: "https://tekton-mirror.example.com/"},
+		"ssh":    {From: "git@github.com:", To: "https://mirror.example.com/github/"},
+	}}
+	for _, tc := range []struct {
+		name string
+		url  string
+		want string
+	}{{
+		name: "prefix",
+		url:  "https://github.com/org/repo.git",
+		want: "https://mirror.example.com/github/org/repo.git",
+	}, {
+		name: "longest prefix wins",
+		url:  "https://github.com/tektoncd/catalog",
+		want: "https://tekton-mirror.example.com/catalog",
+	}, {
+		name: "scp-like url",
+		url:  "git@github.com:org/repo.git",
+		want: "https://mirror.example.com/github/org/repo.git",
+	}, {
+		name: "no match",
+		url:  "https://gitlab.com/org/repo.git",
+		want: "https://gitlab.com/org/repo.git",
+	}, {
+		name: "prefix in the middle of the url",
+		url:  "https://example.com/https://github.com/org/repo.git",
+		want: "https://example.com/https://github.com/org/repo.git",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := conf.rewriteURL(tc.url)
+			if err != nil {
+				t.Fatalf("unexpected error rewriting the url: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("expected %s to be rewritten to %s, got %s", tc.url, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestRewriteURL_Invalid(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		rewrites map[string]URLRewrite
+		wantErr  string
+	}{{
+		name:     "missing to",
+		rewrites: map[string]URLRewrite{"github": {From: "https://github.com/"}},
+		wantErr:  `url-rewrite "github" of the git resolver config must set both "from" and "to"`,
+	}, {
+		name:     "missing from",
+		rewrites: map[string]URLRewrite{"github": {To: "https://mirror.example.com/"}},
+		wantErr:  `url-rewrite "github" of the git resolver config must set both "from" and "to"`,
+	}, {
+		name: "same prefix",
+		rewrites: map[string]URLRewrite{
+			"a": {From: "https://github.com/", To: "https://a.example.com/"},
+			"b": {From: "https://github.com/", To: "https://b.example.com/"},
+		},
+		wantErr: `url-rewrite "a" and "b" of the git resolver config rewrite the same prefix "https://github.com/"`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ScmConfig{URLRewrites: tc.rewrites}.rewriteURL("https://gitlab.com/org/repo.git")
+			if err == nil || err.Error() != tc.wantErr {
+				t.Errorf("expected error %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestResolveGitCloneURLRewrite(t *testing.T) {
+	repoPath, commits := createTestRepo(t, []commitForRepo{{
+		Dir:      "tasks/",
+		Filename: "task.yaml",
+		Content:  "mirrored task",
+	}})
+	mirrorDir := filepath.Dir(repoPath) + "/"
+	repoURL := "https://github.com/tektoncd/" + filepath.Base(repoPath)
+
+	for _, tc := range []struct {
+		name         string
+		config       map[string]string
+		configKey    string
+		wantFetchURL string
+	}{{
+		name: "rewritten",
+		config: map[string]string{
+			URLRewriteKey + ".github.from": "https://github.com/tektoncd/",
+			URLRewriteKey + ".github.to":   mirrorDir,
+		},
+		wantFetchURL: repoPath,
+	}, {
+		name: "rewritten with the config of the configKey",
+		config: map[string]string{
+			URLRewriteKey + ".github.from":         "https://github.com/",
+			URLRewriteKey + ".github.to":           "https://unreachable.invalid/",
+			"mirror." + URLRewriteKey + ".gh.from": "https://github.com/tektoncd/",
+			"mirror." + URLRewriteKey + ".gh.to":   mirrorDir,
+		},
+		configKey:    "mirror",
+		wantFetchURL: repoPath,
+	}, {
+		name: "not rewritten",
+		config: map[string]string{
+			URLRewriteKey + ".gitlab.from": "https://gitlab.com/",
+			URLRewriteKey + ".gitlab.to":   "https://unreachable.invalid/",
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := framework.InjectResolverConfigToContext(t.Context(), tc.config)
+			params := map[string]string{
+				UrlParam:      repoURL,
+				RevisionParam: "main",
+				PathParam:     "tasks/task.yaml",
+			}
+			if tc.configKey != "" {
+				params[ConfigKeyParam] = tc.configKey
+			}
+			var fetched string
+			g := &GitResolver{
+				Params: params,
+				Logger: zap.NewNop().Sugar(),
+				cloneFunc: func(ctx context.Context, rem remote) (*repository, func(), error) {
+					fetched = rem.url
+					if rem.url == repoURL {
+						// Don't reach out to GitHub when the url isn't rewritten.
+						return nil, func() {}, errors.New("not rewritten")
+					}
+					return rem.clone(ctx)
+				},
+			}
+			res, err := g.ResolveGitClone(ctx)
+			if tc.wantFetchURL == "" {
+				if fetched != repoURL {
+					t.Errorf("expected the repo to be fetched from %s, got %s", repoURL, fetched)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error resolving the file: %v", err)
+			}
+			if fetched != tc.wantFetchURL {
+				t.Errorf("expected the repo to be fetched from %s, got %s", tc.wantFetchURL, fetched)
+			}
+			if got := string(res.Data()); got != "mirrored task" {
+				t.Errorf("expected the content of the file, got %q", got)
+			}
+			annotations := res.Annotations()
+			if got := annotations[AnnotationKeyURL]; got != repoURL {
+				t.Errorf("expected the %s annotation to be the url param %s, got %s", AnnotationKeyURL, repoURL, got)
+			}
+			if got := annotations[AnnotationKeyFetchURL]; got != tc.wantFetchURL {
+				t.Errorf("expected the %s annotation to be %s, got %s", AnnotationKeyFetchURL, tc.wantFetchURL, got)
+			}
+			if got := res.RefSource().URI; got != "git+"+repoURL+"@main" {
+				t.Errorf("expected the source to be the url param, got %s", got)
+			}
+			if got := res.RefSource().Digest["sha1"]; got != commits[0] {
+				t.Errorf("expected the source digest %s, got %s", commits[0], got)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"knative.dev/pkg/logging"
+)
+
+// InvalidatePath is the path under which the invalidation handler receives
+// the push webhooks of the git servers.
+const InvalidatePath = "/invalidate/git"
+
+const (
+	// gitHubSignatureHeader is the header of the HMAC SHA-256 signature of
+	// the payloads of the GitHub webhooks, as "sha256=<hex>".
+	gitHubSignatureHeader = "X-Hub-Signature-256"
+	// gitLabTokenHeader is the header of the secret token of the GitLab
+	// webhooks.
+	gitLabTokenHeader = "X-Gitlab-Token"
+
+	// maxWebhookPayloadSize is the maximum size of the webhook payloads.
+	maxWebhookPayloadSize = 25 << 20
+)
+
+// RepoInvalidator removes the files resolved from a repo from a cache.
+type RepoInvalidator interface {
+	// InvalidateRepo removes the files resolved from the repo and returns
+	// their number.
+	InvalidateRepo(repoURL string) int
+}
+
+// pushPayload holds the URLs of the repo of the GitHub and GitLab push
+// webhook payloads.
+type pushPayload struct {
+	Ref        string `json:"ref"`
+	Repository struct {
+		// GitHub
+		CloneURL string `json:"clone_url"`
+		HTMLURL  string `json:"html_url"`
+		SSHURL   string `json:"ssh_url"`
+		// GitLab
+		GitHTTPURL string `json:"git_http_url"`
+		GitSSHURL  string `json:"git_ssh_url"`
+		Homepage   string `json:"homepage"`
+	} `json:"repository"`
+	// GitLab
+	Project struct {
+		GitHTTPURL string `json:"git_http_url"`
+		GitSSHURL  string `json:"git_ssh_url"`
+		WebURL     string `json:"web_url"`
+	} `json:"project"`
+}
+
+func (p pushPayload) repoURLs() []string {
+	var urls []string
+	for _, u := range []string{
+		p.Repository.CloneURL, p.Repository.HTMLURL, p.Repository.SSHURL,
+		p.Repository.GitHTTPURL, p.Repository.GitSSHURL, p.Repository.Homepage,
+		p.Project.GitHTTPURL, p.Project.GitSSHURL, p.Project.WebURL,
+	} {
+		if u != "" {
+			urls = append(urls, u)
+		}
+	}
+	return urls
+}
+
+// NewInvalidationHandler returns an http.Handler receiving the push webhooks
+// of GitHub and GitLab, which removes the files resolved from the pushed repo
+// from the cache of the invalidator. The webhooks must be signed with the
+// secret, as the X-Hub-Signature-256 header of GitHub or the X-Gitlab-Token
+// header of GitLab; all the webhooks are rejected if the secret is empty.
+// A push to a repo without cached files is a no-op.
+func NewInvalidationHandler(ctx context.Context, invalidator RepoInvalidator, secret []byte) http.Handler {
+	logger := logging.FromContext(ctx)
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		body, err := io.ReadAll(io.LimitReader(req.Body, maxWebhookPayloadSize))
+		if err != nil {
+			http.Error(w, "couldn't read the payload", http.StatusBadRequest)
+			return
+		}
+		if !validWebhookSignature(req.Header, body, secret) {
+			http.Error(w, "invalid or missing webhook signature", http.StatusUnauthorized)
+			return
+		}
+		var payload pushPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			http.Error(w, "invalid push payload", http.StatusBadRequest)
+			return
+		}
+		removed := 0
+		for _, u := range payload.repoURLs() {
+			removed += invalidator.InvalidateRepo(u)
+		}
+		logger.Infof("invalidated %d cached files of the repo %v after a push to %s", removed, payload.repoURLs(), payload.Ref)
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+// validWebhookSignature returns true if the payload is signed with the
+// secret, as a GitHub or a GitLab webhook.
+func validWebhookSignature(header http.Header, body, secret []byte) bool {
+	if len(secret) == 0 {
+		return false
+	}
+	if signature := header.Get(gitHubSignatureHeader); signature != "" {
+		got, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+		if err != nil || !strings.HasPrefix(signature, "sha256=") {
+			return false
+		}
+		mac := hmac.New(sha256.New, secret)
+		mac.Write(body)
+		return hmac.Equal(got, mac.Sum(nil))
+	}
+	if token := header.Get(gitLabTokenHeader); token != "" {
+		return subtle.ConstantTimeCompare([]byte(token), secret) == 1
+	}
+	return false
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testWebhookSecret = "webhook-secret"
+
+	gitHubPushPayload = `{
+  "ref": "refs/heads/main",
+  "before": "abc",
+  "after": "def",
+  "repository": {
+    "full_name": "tektoncd/catalog",
+    "html_url": "https://github.com/tektoncd/catalog",
+    "clone_url": "https://github.com/tektoncd/catalog.git",
+    "ssh_url": "git@github.com:tektoncd/catalog.git"
+  }
+}`
+	gitLabPushPayload = `{
+  "object_kind": "push",
+  "ref": "refs/heads/main",
+  "project": {
+    "path_with_namespace": "tektoncd/catalog",
+    "web_url": "https://gitlab.com/tektoncd/catalog",
+    "git_http_url": "https://gitlab.com/tektoncd/catalog.git",
+    "git_ssh_url": "git@gitlab.com:tektoncd/catalog.git"
+  }
+}`
+	unknownRepoPushPayload = `{
+  "ref": "refs/heads/main",
+  "repository": {
+    "clone_url": "https://github.com/tektoncd/other.git"
+  }
+}`
+)
+
+func gitHubSignature(secret, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestInvalidationHandler(t *testing.T) {
+	cachedRepos := []string{"https://github.com/tektoncd/catalog", "https://gitlab.com/tektoncd/catalog"}
+	for _, tc := range []struct {
+		name        string
+		method      string
+		payload     string
+		headers     map[string]string
+		noSecret    bool
+		wantStatus  int
+		wantEvicted []string
+	}{{
+		name:        "signed GitHub push",
+		payload:     gitHubPushPayload,
+		headers:     map[string]string{gitHubSignatureHeader: gitHubSignature(testWebhookSecret, gitHubPushPayload)},
+		wantStatus:  http.StatusOK,
+		wantEvicted: []string{"https://github.com/tektoncd/catalog"},
+	}, {
+		name:        "GitLab push with the secret token",
+		payload:     gitLabPushPayload,
+		headers:     map[string]string{gitLabTokenHeader: testWebhookSecret},
+		wantStatus:  http.StatusOK,
+		wantEvicted: []string{"https://gitlab.com/tektoncd/catalog"},
+	}, {
+		name:       "push to a repo without cached files",
+		payload:    unknownRepoPushPayload,
+		headers:    map[string]string{gitHubSignatureHeader: gitHubSignature(testWebhookSecret, unknownRepoPushPayload)},
+		wantStatus: http.StatusOK,
+	}, {
+		name:       "unsigned push",
+		payload:    gitHubPushPayload,
+		wantStatus: http.StatusUnauthorized,
+	}, {
+		name:       "push signed with another secret",
+		payload:    gitHubPushPayload,
+		headers:    map[string]string{gitHubSignatureHeader: gitHubSignature("other-secret", gitHubPushPayload)},
+		wantStatus: http.StatusUnauthorized,
+	}, {
+		name:       "GitLab push with another token",
+		payload:    gitLabPushPayload,
+		headers:    map[string]string{gitLabTokenHeader: "other-secret"},
+		wantStatus: http.StatusUnauthorized,
+	}, {
+		name:       "signed push without a configured secret",
+		payload:    gitHubPushPayload,
+		headers:    map[string]string{gitHubSignatureHeader: gitHubSignature("", gitHubPushPayload)},
+		noSecret:   true,
+		wantStatus: http.StatusUnauthorized,
+	}, {
+		name:       "signed invalid payload",
+		payload:    "not json",
+		headers:    map[string]string{gitHubSignatureHeader: gitHubSignature(testWebhookSecret, "not json")},
+		wantStatus: http.StatusBadRequest,
+	}, {
+		name:       "GET",
+		method:     http.MethodGet,
+		wantStatus: http.StatusMethodNotAllowed,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			cache := NewCloneCache()
+			keys := map[string]cloneCacheKey{}
+			for _, repo := range cachedRepos {
+				key := cloneCacheKey{url: normalizeRepoURL(repo), sha: "abc", path: "task/task.yaml"}
+				keys[repo] = key
+				if _, err := cache.getOrLoad(t.Context(), key, time.Minute, 10, func() (*resolvedGitResource, error) {
+					return &resolvedGitResource{Revision: "abc", Content: []byte("task")}, nil
+				}); err != nil {
+					t.Fatalf("couldn't cache the file of %s: %v", repo, err)
+				}
+			}
+
+			secret := testWebhookSecret
+			if tc.noSecret {
+				secret = ""
+			}
+			method := tc.method
+			if method == "" {
+				method = http.MethodPost
+			}
+			req := httptest.NewRequest(method, InvalidatePath, strings.NewReader(tc.payload))
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			NewInvalidationHandler(t.Context(), cache, []byte(secret)).ServeHTTP(rec, req)
+
+			if rec.Code != tc.wantStatus {
+				t.Errorf("expected the status %d, got %d: %s", tc.wantStatus, rec.Code, rec.Body.String())
+			}
+			evicted := map[string]bool{}
+			for _, repo := range tc.wantEvicted {
+				evicted[repo] = true
+			}
+			for repo, key := range keys {
+				_, cached := cache.getEntries(10).Get(key)
+				if cached == evicted[repo] {
+					t.Errorf("expected the file of %s to be evicted: %t, got cached: %t", repo, evicted[repo], cached)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+/*
+Copyright 2025 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hub
+
+import "github.com/tektoncd/pipeline/pkg/apis/resolution"
+
+var (
+	// AnnotationKeyVersion is the concrete version of the resource that was
+	// fetched from the hub, after any version constraint has been resolved.
+	AnnotationKeyVersion = resolution.GroupName + "/version"
+
+	// AnnotationKeySHA256 is the hex encoded sha256 digest of the resource
+	// that was fetched from the hub.
+	AnnotationKeySHA256 = resolution.GroupName + "/sha256"
+
+	// AnnotationKeyEndpoint is the base URL of the hub, or of the mirror of
+	// the hub, which served the resource.
+	AnnotationKeyEndpoint = resolution.GroupName + "/hub-endpoint"
+)
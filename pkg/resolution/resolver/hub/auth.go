@@ -0,0 +1,113 @@
+/*
+Copyright 2025 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	common "github.com/tektoncd/pipeline/pkg/resolution/common"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// DefaultTokenKey is the key of the API token in its secret when neither
+	// the tokenKey param nor the api-token-secret-key config is set.
+	DefaultTokenKey = "token"
+
+	// artifactHubAPIKeyIDHeader and artifactHubAPIKeySecretHeader are the
+	// headers of the API key authenticating to the Artifact Hub.
+	artifactHubAPIKeyIDHeader     = "X-API-KEY-ID"
+	artifactHubAPIKeySecretHeader = "X-API-KEY-SECRET"
+)
+
+// apiTokenSecret is the secret holding the API token authenticating to the hub.
+type apiTokenSecret struct {
+	name string
+	key  string
+	ns   string
+}
+
+// getAPITokenSecret returns the secret of the API token from the token params,
+// or else from the config, or nil if the requests to the hub are anonymous.
+// The secret of the token params is always read from the namespace of the
+// request, so that a request can't read the secrets of other namespaces.
+func getAPITokenSecret(ctx context.Context, paramsMap map[string]string) *apiTokenSecret {
+	if name := paramsMap[ParamToken]; name != "" {
+		secret := &apiTokenSecret{name: name, key: paramsMap[ParamTokenKey], ns: common.RequestNamespace(ctx)}
+		if secret.key == "" {
+			secret.key = DefaultTokenKey
+		}
+		return secret
+	}
+	conf := framework.GetResolverConfigFromContext(ctx)
+	name := conf[ConfigAPISecretName]
+	if name == "" {
+		return nil
+	}
+	secret := &apiTokenSecret{name: name, key: conf[ConfigAPISecretKey], ns: conf[ConfigAPISecretNamespace]}
+	if secret.key == "" {
+		secret.key = DefaultTokenKey
+	}
+	if secret.ns == "" {
+		secret.ns = os.Getenv("SYSTEM_NAMESPACE")
+	}
+	return secret
+}
+
+// authHeader returns the headers authenticating the requests to the hub of the
+// given type with the API token of the request, or nil if the requests are
+// anonymous.
+func authHeader(ctx context.Context, paramsMap map[string]string, kubeClient kubernetes.Interface) (http.Header, error) {
+	apiSecret := getAPITokenSecret(ctx, paramsMap)
+	if apiSecret == nil {
+		return nil, nil
+	}
+	if kubeClient == nil {
+		return nil, errors.New("cannot get API token, the hub resolver is not initialized")
+	}
+	secret, err := kubeClient.CoreV1().Secrets(apiSecret.ns).Get(ctx, apiSecret.name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("cannot get API token, secret %s not found in namespace %s", apiSecret.name, apiSecret.ns)
+		}
+		return nil, fmt.Errorf("error reading API token from secret %s in namespace %s: %w", apiSecret.name, apiSecret.ns, err)
+	}
+	token, ok := secret.Data[apiSecret.key]
+	if !ok {
+		return nil, fmt.Errorf("cannot get API token, key %s not found in secret %s in namespace %s", apiSecret.key, apiSecret.name, apiSecret.ns)
+	}
+
+	header := http.Header{}
+	switch paramsMap[ParamType] {
+	case ArtifactHubType:
+		// The API keys of the Artifact Hub are made of an ID and a secret.
+		id, keySecret, ok := strings.Cut(strings.TrimSpace(string(token)), ":")
+		if !ok || id == "" || keySecret == "" {
+			return nil, fmt.Errorf("cannot get API token, key %s of secret %s in namespace %s must be an Artifact Hub API key of the form <key id>:<key secret>", apiSecret.key, apiSecret.name, apiSecret.ns)
+		}
+		header.Set(artifactHubAPIKeyIDHeader, id)
+		header.Set(artifactHubAPIKeySecretHeader, keySecret)
+	default:
+		header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+	return header, nil
+}
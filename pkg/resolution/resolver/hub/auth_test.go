@@ -0,0 +1,148 @@
+/*
+Copyright 2025 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hub
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	common "github.com/tektoncd/pipeline/pkg/resolution/common"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestResolveAuthenticated(t *testing.T) {
+	secrets := []*corev1.Secret{{
+		ObjectMeta: metav1.ObjectMeta{Name: "hub-token", Namespace: "tekton-pipelines-resolvers"},
+		Data:       map[string][]byte{"token": []byte("key-id:key-secret\n")},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{Name: "my-hub-token", Namespace: "foo"},
+		Data:       map[string][]byte{"api-key": []byte("my-id:my-secret"), "token": []byte("my-token")},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{Name: "shared-hub-token", Namespace: "shared"},
+		Data:       map[string][]byte{"token": []byte("shared-id:shared-secret")},
+	}}
+	configSecret := map[string]string{
+		ConfigAPISecretName:      "hub-token",
+		ConfigAPISecretNamespace: "tekton-pipelines-resolvers",
+	}
+
+	for _, tc := range []struct {
+		name       string
+		config     map[string]string
+		params     map[string]string
+		wantHeader map[string]string
+		wantErr    error
+	}{{
+		name:       "anonymous",
+		params:     map[string]string{ParamType: ArtifactHubType},
+		wantHeader: map[string]string{},
+	}, {
+		name:   "secret of the config for the artifact hub",
+		config: configSecret,
+		params: map[string]string{ParamType: ArtifactHubType},
+		wantHeader: map[string]string{
+			artifactHubAPIKeyIDHeader:     "key-id",
+			artifactHubAPIKeySecretHeader: "key-secret",
+		},
+	}, {
+		name:   "secret of the params takes precedence over the config",
+		config: configSecret,
+		params: map[string]string{ParamType: ArtifactHubType, ParamToken: "my-hub-token", ParamTokenKey: "api-key"},
+		wantHeader: map[string]string{
+			artifactHubAPIKeyIDHeader:     "my-id",
+			artifactHubAPIKeySecretHeader: "my-secret",
+		},
+	}, {
+		name:    "secret of the params only read from the namespace of the request",
+		params:  map[string]string{ParamType: ArtifactHubType, ParamToken: "shared-hub-token", "namespace": "shared"},
+		wantErr: errors.New("cannot get API token, secret shared-hub-token not found in namespace foo"),
+	}, {
+		name:       "bearer token for the tekton hub",
+		params:     map[string]string{ParamType: TektonHubType, ParamToken: "my-hub-token"},
+		wantHeader: map[string]string{"Authorization": "Bearer my-token"},
+	}, {
+		name:    "secret not found",
+		params:  map[string]string{ParamType: ArtifactHubType, ParamToken: "missing"},
+		wantErr: errors.New("cannot get API token, secret missing not found in namespace foo"),
+	}, {
+		name:    "key not found",
+		params:  map[string]string{ParamType: ArtifactHubType, ParamToken: "my-hub-token", ParamTokenKey: "missing"},
+		wantErr: errors.New("cannot get API token, key missing not found in secret my-hub-token in namespace foo"),
+	}, {
+		name:    "invalid artifact hub api key",
+		params:  map[string]string{ParamType: ArtifactHubType, ParamToken: "my-hub-token"},
+		wantErr: errors.New("cannot get API token, key token of secret my-hub-token in namespace foo must be an Artifact Hub API key of the form <key id>:<key secret>"),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			var gotHeader http.Header
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotHeader = r.Header.Clone()
+				fmt.Fprint(w, `{"data":{"yaml":"some content","manifestRaw":"some content"}}`)
+			}))
+			defer svr.Close()
+
+			kubeClient := fake.NewSimpleClientset()
+			for _, s := range secrets {
+				if _, err := kubeClient.CoreV1().Secrets(s.Namespace).Create(t.Context(), s, metav1.CreateOptions{}); err != nil {
+					t.Fatalf("failed to create secret: %v", err)
+				}
+			}
+			ctx := contextWithConfig()
+			config := framework.GetResolverConfigFromContext(ctx)
+			for k, v := range tc.config {
+				config[k] = v
+			}
+			ctx = framework.InjectResolverConfigToContext(ctx, config)
+			ctx = common.InjectRequestNamespace(ctx, "foo")
+
+			params := map[string]string{
+				ParamKind:    "task",
+				ParamName:    "foo",
+				ParamVersion: "0.1",
+			}
+			for k, v := range tc.params {
+				params[k] = v
+			}
+			resolver := &Resolver{TektonHubURL: svr.URL, ArtifactHubURL: svr.URL, kubeClient: kubeClient}
+			_, err := resolver.Resolve(ctx, toParams(params))
+			if tc.wantErr != nil {
+				checkExpectedErr(t, tc.wantErr, err)
+				if gotHeader != nil {
+					t.Errorf("expected no request to the hub, got one")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error resolving: %v", err)
+			}
+			got := map[string]string{}
+			for _, key := range []string{artifactHubAPIKeyIDHeader, artifactHubAPIKeySecretHeader, "Authorization"} {
+				if v := gotHeader.Get(key); v != "" {
+					got[key] = v
+				}
+			}
+			if d := cmp.Diff(tc.wantHeader, got); d != "" {
+				t.Errorf("unexpected auth headers of the request: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -28,6 +28,10 @@ const ConfigArtifactHubTaskCatalog = "default-artifact-hub-task-catalog"
 // the Artifact Hub Pipeline catalog to fetch the remote resource from.
 const ConfigArtifactHubPipelineCatalog = "default-artifact-hub-pipeline-catalog"
 
+// ConfigArtifactHubStepActionCatalog is the configuration field name for controlling
+// the Artifact Hub StepAction catalog to fetch the remote resource from.
+const ConfigArtifactHubStepActionCatalog = "default-artifact-hub-stepaction-catalog"
+
 // ConfigKind is the configuration field name for controlling
 // what the layer name in the hub image is.
 const ConfigKind = "default-kind"
@@ -35,3 +39,25 @@ const ConfigKind = "default-kind"
 // ConfigType is the configuration field name for controlling
 // the hub type to pull the resource from.
 const ConfigType = "default-type"
+
+// ConfigArtifactHubMirrors is the configuration field name for the comma-separated
+// base URLs of the mirrors of the Artifact Hub, tried in order when the hub can't be
+// reached or has a server error.
+const ConfigArtifactHubMirrors = "artifact-hub-mirrors"
+
+// ConfigTektonHubMirrors is the configuration field name for the comma-separated
+// base URLs of the mirrors of the Tekton Hub, tried in order when the hub can't be
+// reached or has a server error.
+const ConfigTektonHubMirrors = "tekton-hub-mirrors"
+
+// ConfigAPISecretName is the configuration field name for the name of the secret
+// holding the API token authenticating to the hub.
+const ConfigAPISecretName = "api-token-secret-name"
+
+// ConfigAPISecretKey is the configuration field name for the key of the API token
+// within its secret.
+const ConfigAPISecretKey = "api-token-secret-key"
+
+// ConfigAPISecretNamespace is the configuration field name for the namespace of the
+// secret holding the API token.
+const ConfigAPISecretNamespace = "api-token-secret-namespace"
@@ -0,0 +1,109 @@
+/*
+Copyright 2025 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+)
+
+// hubStatusError is returned when the hub answers a request with a status
+// other than 200 OK.
+type hubStatusError struct {
+	url        string
+	statusCode int
+}
+
+func (e *hubStatusError) Error() string {
+	if e.statusCode >= http.StatusInternalServerError {
+		return fmt.Sprintf("requesting resource '%s' from hub failed with status %d", e.url, e.statusCode)
+	}
+	return fmt.Sprintf("requested resource '%s' not found on hub", e.url)
+}
+
+// hubRequestError is returned when the request to the hub fails, e.g. because
+// the hub can't be reached.
+type hubRequestError struct {
+	err error
+}
+
+func (e *hubRequestError) Error() string {
+	return fmt.Sprintf("requesting resource from Hub: %v", e.err)
+}
+
+func (e *hubRequestError) Unwrap() error {
+	return e.err
+}
+
+// shouldFailOver reports whether the request should be retried against the
+// next mirror: the hub can't be reached or has a server error. A client error,
+// e.g. a 404, means that the resource doesn't exist so it is returned as is.
+func shouldFailOver(err error) bool {
+	var statusErr *hubStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.statusCode >= http.StatusInternalServerError
+	}
+	var requestErr *hubRequestError
+	return errors.As(err, &requestErr)
+}
+
+// hubEndpoints returns the base URL of the hub of the type followed by the
+// base URLs of its mirrors, configured in the artifact-hub-mirrors or the
+// tekton-hub-mirrors key of the resolver config since the two hubs have
+// different APIs.
+func hubEndpoints(ctx context.Context, hubType, hubURL string) []string {
+	key := ConfigArtifactHubMirrors
+	if hubType == TektonHubType {
+		key = ConfigTektonHubMirrors
+	}
+	endpoints := []string{hubURL}
+	conf := framework.GetResolverConfigFromContext(ctx)
+	for _, mirror := range strings.Split(conf[key], ",") {
+		if mirror = strings.TrimSuffix(strings.TrimSpace(mirror), "/"); mirror != "" {
+			endpoints = append(endpoints, mirror)
+		}
+	}
+	return endpoints
+}
+
+// fetchHubResourceFromEndpoints fetches the resource at the path of the API of
+// the hub from the endpoints, in order, until one serves it. It returns the
+// endpoint which served the resource. The header authenticating to the hub
+// is only sent to the first endpoint, the hub itself: the mirrors are
+// requested anonymously, so that the API token isn't sent to them.
+func fetchHubResourceFromEndpoints(ctx context.Context, endpoints []string, path string, header http.Header, v interface{}) (string, error) {
+	var errs []error
+	for i, endpoint := range endpoints {
+		if i > 0 {
+			header = nil
+		}
+		err := fetchHubResource(ctx, fmt.Sprintf("%s/%s", endpoint, path), header, v)
+		if err == nil {
+			return endpoint, nil
+		}
+		errs = append(errs, err)
+		if !shouldFailOver(err) {
+			break
+		}
+	}
+	if len(errs) == 1 {
+		return "", errs[0]
+	}
+	return "", errors.Join(errs...)
+}
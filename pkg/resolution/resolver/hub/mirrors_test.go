@@ -0,0 +1,208 @@
+/*
+Copyright 2025 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hub
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	common "github.com/tektoncd/pipeline/pkg/resolution/common"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const mirroredTask = `apiVersion: tekton.dev/v1
+kind: Task
+metadata:
+  name: foo
+`
+
+func contextWithMirrors(mirrors ...string) context.Context {
+	ctx := contextWithConfig()
+	config := framework.GetResolverConfigFromContext(ctx)
+	config[ConfigArtifactHubMirrors] = strings.Join(mirrors, ", ")
+	return framework.InjectResolverConfigToContext(ctx, config)
+}
+
+func servingHub(t *testing.T, requests *int) *httptest.Server {
+	t.Helper()
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		fmt.Fprintf(w, `{"data":{"manifestRaw":%q}}`, mirroredTask)
+	}))
+	t.Cleanup(svr.Close)
+	return svr
+}
+
+func failingHub(t *testing.T, status int, requests *int) *httptest.Server {
+	t.Helper()
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(svr.Close)
+	return svr
+}
+
+func mirroredTaskParams() map[string]string {
+	return map[string]string{
+		ParamKind:    "task",
+		ParamName:    "foo",
+		ParamVersion: "0.1",
+		ParamType:    ArtifactHubType,
+	}
+}
+
+func TestResolveFailsOverToMirror(t *testing.T) {
+	var primaryRequests, mirrorRequests int
+	primary := failingHub(t, http.StatusServiceUnavailable, &primaryRequests)
+	mirror := servingHub(t, &mirrorRequests)
+
+	resolver := &Resolver{ArtifactHubURL: primary.URL}
+	output, err := resolver.Resolve(contextWithMirrors(mirror.URL+"/"), toParams(mirroredTaskParams()))
+	if err != nil {
+		t.Fatalf("unexpected error resolving: %v", err)
+	}
+	if primaryRequests != 1 || mirrorRequests != 1 {
+		t.Errorf("expected one request to the hub and one to the mirror, got %d and %d", primaryRequests, mirrorRequests)
+	}
+	if d := cmp.Diff([]byte(mirroredTask), output.Data()); d != "" {
+		t.Errorf("unexpected resource from Resolve: %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(mirror.URL, output.Annotations()[AnnotationKeyEndpoint]); d != "" {
+		t.Errorf("unexpected endpoint annotation: %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestResolveFailsOverWhenHubUnreachable(t *testing.T) {
+	var primaryRequests, mirrorRequests int
+	primary := servingHub(t, &primaryRequests)
+	primaryURL := primary.URL
+	primary.Close()
+	mirror := servingHub(t, &mirrorRequests)
+
+	resolver := &Resolver{ArtifactHubURL: primaryURL}
+	output, err := resolver.Resolve(contextWithMirrors(mirror.URL), toParams(mirroredTaskParams()))
+	if err != nil {
+		t.Fatalf("unexpected error resolving: %v", err)
+	}
+	if d := cmp.Diff(mirror.URL, output.Annotations()[AnnotationKeyEndpoint]); d != "" {
+		t.Errorf("unexpected endpoint annotation: %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestResolveDoesNotFailOverOnClientError(t *testing.T) {
+	var primaryRequests, mirrorRequests int
+	primary := failingHub(t, http.StatusNotFound, &primaryRequests)
+	mirror := servingHub(t, &mirrorRequests)
+
+	resolver := &Resolver{ArtifactHubURL: primary.URL}
+	_, err := resolver.Resolve(contextWithMirrors(mirror.URL), toParams(mirroredTaskParams()))
+	expectedErr := fmt.Errorf("fail to fetch Artifact Hub resource: requested resource '%s/api/v1/packages/tekton-task/tekton-catalog-tasks/foo/0.1.0' not found on hub", primary.URL)
+	checkExpectedErr(t, expectedErr, err)
+	if mirrorRequests != 0 {
+		t.Errorf("expected no request to the mirror, got %d", mirrorRequests)
+	}
+}
+
+func TestResolveAllEndpointsFail(t *testing.T) {
+	var primaryRequests, mirrorRequests int
+	primary := failingHub(t, http.StatusServiceUnavailable, &primaryRequests)
+	mirror := failingHub(t, http.StatusBadGateway, &mirrorRequests)
+
+	resolver := &Resolver{ArtifactHubURL: primary.URL}
+	_, err := resolver.Resolve(contextWithMirrors(mirror.URL), toParams(mirroredTaskParams()))
+	path := "api/v1/packages/tekton-task/tekton-catalog-tasks/foo/0.1.0"
+	expectedErr := fmt.Errorf("fail to fetch Artifact Hub resource: "+
+		"requesting resource '%s/%s' from hub failed with status 503\n"+
+		"requesting resource '%s/%s' from hub failed with status 502", primary.URL, path, mirror.URL, path)
+	checkExpectedErr(t, expectedErr, err)
+}
+
+func TestHubEndpoints(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		hubType string
+		config  map[string]string
+		want    []string
+	}{{
+		name:    "no mirrors",
+		hubType: ArtifactHubType,
+		want:    []string{"https://hub"},
+	}, {
+		name:    "artifact hub mirrors in order",
+		hubType: ArtifactHubType,
+		config: map[string]string{
+			ConfigArtifactHubMirrors: "https://mirror-1/, ,https://mirror-2 ",
+			ConfigTektonHubMirrors:   "https://tekton-mirror",
+		},
+		want: []string{"https://hub", "https://mirror-1", "https://mirror-2"},
+	}, {
+		name:    "tekton hub mirrors",
+		hubType: TektonHubType,
+		config: map[string]string{
+			ConfigArtifactHubMirrors: "https://mirror-1",
+			ConfigTektonHubMirrors:   "https://tekton-mirror/",
+		},
+		want: []string{"https://hub", "https://tekton-mirror"},
+	}, {
+		name:    "no tekton hub mirrors",
+		hubType: TektonHubType,
+		config:  map[string]string{ConfigArtifactHubMirrors: "https://mirror-1"},
+		want:    []string{"https://hub"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := framework.InjectResolverConfigToContext(context.Background(), tc.config)
+			if d := cmp.Diff(tc.want, hubEndpoints(ctx, tc.hubType, "https://hub")); d != "" {
+				t.Errorf("unexpected endpoints: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestResolveDoesNotSendTheTokenToMirrors(t *testing.T) {
+	var primaryRequests int
+	primary := failingHub(t, http.StatusServiceUnavailable, &primaryRequests)
+	var mirrorHeader http.Header
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mirrorHeader = r.Header.Clone()
+		fmt.Fprintf(w, `{"data":{"manifestRaw":%q}}`, mirroredTask)
+	}))
+	t.Cleanup(mirror.Close)
+
+	kubeClient := fake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "hub-token", Namespace: "foo"},
+		Data:       map[string][]byte{"token": []byte("key-id:key-secret")},
+	})
+	params := mirroredTaskParams()
+	params[ParamToken] = "hub-token"
+	resolver := &Resolver{ArtifactHubURL: primary.URL, kubeClient: kubeClient}
+	ctx := common.InjectRequestNamespace(contextWithMirrors(mirror.URL), "foo")
+	if _, err := resolver.Resolve(ctx, toParams(params)); err != nil {
+		t.Fatalf("unexpected error resolving: %v", err)
+	}
+	for _, key := range []string{artifactHubAPIKeyIDHeader, artifactHubAPIKeySecretHeader} {
+		if v := mirrorHeader.Get(key); v != "" {
+			t.Errorf("expected the API token not to be sent to the mirror, got %s: %s", key, v)
+		}
+	}
+}
@@ -48,3 +48,15 @@ const ParamCatalog = "catalog"
 
 // ParamType is the parameter defining what the hub type to pull the resource from.
 const ParamType = "type"
+
+// ParamSHA256 is the optional parameter defining the hex encoded sha256 digest
+// the fetched resource must have.
+const ParamSHA256 = "sha256"
+
+// ParamToken is the optional parameter defining the name of the secret holding
+// the API token authenticating to the hub.
+const ParamToken = "token"
+
+// ParamTokenKey is the optional parameter defining the key of the API token
+// within its secret.
+const ParamTokenKey = "tokenKey"
@@ -30,6 +30,8 @@ import (
 	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	common "github.com/tektoncd/pipeline/pkg/resolution/common"
 	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"k8s.io/client-go/kubernetes"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
 )
 
 const (
@@ -44,10 +46,17 @@ const (
 	TektonHubType string = "tekton"
 
 	disabledError = "cannot handle resolution request, enable-hub-resolver feature flag not true"
+
+	// yamlContentType is the content type of the resources returned by the hubs
+	yamlContentType = "application/x-yaml"
 )
 
 var supportedKinds = []string{"task", "pipeline", "stepaction"}
 
+// tektonHubKinds are the kinds of resources listed by the Tekton Hub, which
+// doesn't list the StepActions.
+var tektonHubKinds = []string{"task", "pipeline"}
+
 // Resolver implements a framework.Resolver that can fetch files from OCI bundles.
 //
 // Deprecated: Use [github.com/tektoncd/pipeline/pkg/remoteresolution/resolver/hub.Resolver] instead.
@@ -56,10 +65,13 @@ type Resolver struct {
 	TektonHubURL string
 	// ArtifactHubURL is the URL for hub resolver with type artifact
 	ArtifactHubURL string
+
+	kubeClient kubernetes.Interface
 }
 
-// Initialize sets up any dependencies needed by the resolver. None atm.
-func (r *Resolver) Initialize(context.Context) error {
+// Initialize sets up the kubernetes client reading the API tokens.
+func (r *Resolver) Initialize(ctx context.Context) error {
+	r.kubeClient = kubeclient.Get(ctx)
 	return nil
 }
 
@@ -119,10 +131,12 @@ type artifactHubResponse struct {
 
 // Resolve uses the given params to resolve the requested file or resource.
 func (r *Resolver) Resolve(ctx context.Context, params []pipelinev1.Param) (framework.ResolvedResource, error) {
-	return Resolve(ctx, params, r.TektonHubURL, r.ArtifactHubURL)
+	return Resolve(ctx, params, r.TektonHubURL, r.ArtifactHubURL, r.kubeClient)
 }
 
-func Resolve(ctx context.Context, params []pipelinev1.Param, tektonHubURL, artifactHubURL string) (framework.ResolvedResource, error) {
+// Resolve fetches the resource of the params from the hub, authenticating with the
+// API token read with the kubernetes client if the params or the config set one.
+func Resolve(ctx context.Context, params []pipelinev1.Param, tektonHubURL, artifactHubURL string, kubeClient kubernetes.Interface) (framework.ResolvedResource, error) {
 	if isDisabled(ctx) {
 		return nil, errors.New(disabledError)
 	}
@@ -135,12 +149,21 @@ func Resolve(ctx context.Context, params []pipelinev1.Param, tektonHubURL, artif
 		return nil, fmt.Errorf("failed to validate params: %w", err)
 	}
 
-	if constraint, err := goversion.NewConstraint(paramsMap[ParamVersion]); err == nil {
-		chosen, err := resolveVersionConstraint(ctx, paramsMap, constraint, artifactHubURL, tektonHubURL)
+	header, err := authHeader(ctx, paramsMap, kubeClient)
+	if err != nil {
+		return nil, err
+	}
+
+	if isVersionConstraint(paramsMap[ParamVersion]) {
+		constraint, err := parseVersionConstraint(paramsMap[ParamVersion])
+		if err != nil {
+			return nil, err
+		}
+		chosen, err := resolveVersionConstraint(ctx, paramsMap, constraint, artifactHubURL, tektonHubURL, header)
 		if err != nil {
 			return nil, err
 		}
-		paramsMap[ParamVersion] = chosen.String()
+		paramsMap[ParamVersion] = chosen.Original()
 	}
 
 	resVer, err := resolveVersion(paramsMap[ParamVersion], paramsMap[ParamType])
@@ -150,38 +173,56 @@ func Resolve(ctx context.Context, params []pipelinev1.Param, tektonHubURL, artif
 	paramsMap[ParamVersion] = resVer
 
 	// call hub API
+	var rr *ResolvedHubResource
 	switch paramsMap[ParamType] {
 	case ArtifactHubType:
-		url := fmt.Sprintf(fmt.Sprintf("%s/%s", artifactHubURL, ArtifactHubYamlEndpoint),
+		path := fmt.Sprintf(ArtifactHubYamlEndpoint,
 			paramsMap[ParamKind], paramsMap[ParamCatalog], paramsMap[ParamName], paramsMap[ParamVersion])
 		resp := artifactHubResponse{}
-		if err := fetchHubResource(ctx, url, &resp); err != nil {
+		endpoint, err := fetchHubResourceFromEndpoints(ctx, hubEndpoints(ctx, ArtifactHubType, artifactHubURL), path, header, &resp)
+		if err != nil {
 			return nil, fmt.Errorf("fail to fetch Artifact Hub resource: %w", err)
 		}
-		return &ResolvedHubResource{
-			URL:     url,
-			Content: []byte(resp.Data.YAML),
-		}, nil
+		rr = &ResolvedHubResource{
+			URL:      fmt.Sprintf("%s/%s", endpoint, path),
+			Content:  []byte(resp.Data.YAML),
+			Version:  paramsMap[ParamVersion],
+			Endpoint: endpoint,
+		}
 	case TektonHubType:
-		url := fmt.Sprintf(fmt.Sprintf("%s/%s", tektonHubURL, TektonHubYamlEndpoint),
+		path := fmt.Sprintf(TektonHubYamlEndpoint,
 			paramsMap[ParamCatalog], paramsMap[ParamKind], paramsMap[ParamName], paramsMap[ParamVersion])
 		resp := tektonHubResponse{}
-		if err := fetchHubResource(ctx, url, &resp); err != nil {
+		endpoint, err := fetchHubResourceFromEndpoints(ctx, hubEndpoints(ctx, TektonHubType, tektonHubURL), path, header, &resp)
+		if err != nil {
 			return nil, fmt.Errorf("fail to fetch Tekton Hub resource: %w", err)
 		}
-		return &ResolvedHubResource{
-			URL:     url,
-			Content: []byte(resp.Data.YAML),
-		}, nil
+		rr = &ResolvedHubResource{
+			URL:      fmt.Sprintf("%s/%s", endpoint, path),
+			Content:  []byte(resp.Data.YAML),
+			Version:  paramsMap[ParamVersion],
+			Endpoint: endpoint,
+		}
+	default:
+		return nil, fmt.Errorf("hub resolver type: %s is not supported", paramsMap[ParamType])
 	}
 
-	return nil, fmt.Errorf("hub resolver type: %s is not supported", paramsMap[ParamType])
+	if expected, ok := paramsMap[ParamSHA256]; ok {
+		if actual := rr.sha256(); !strings.EqualFold(expected, actual) {
+			return nil, fmt.Errorf("sha256 digest mismatch for resource %s: expected %s, got %s", rr.URL, expected, actual)
+		}
+	}
+	return rr, nil
 }
 
 // ResolvedHubResource wraps the data we want to return to Pipelines
 type ResolvedHubResource struct {
 	URL     string
 	Content []byte
+	// Version is the concrete version fetched from the hub.
+	Version string
+	// Endpoint is the base URL of the hub, or of its mirror, which served the content.
+	Endpoint string
 }
 
 var _ framework.ResolvedResource = &ResolvedHubResource{}
@@ -191,48 +232,64 @@ func (rr *ResolvedHubResource) Data() []byte {
 	return rr.Content
 }
 
-// Annotations returns any metadata needed alongside the data. None atm.
-func (*ResolvedHubResource) Annotations() map[string]string {
-	return nil
+// Annotations returns the content type, the concrete version, the sha256 digest and the
+// endpoint which served the fetched resource.
+func (rr *ResolvedHubResource) Annotations() map[string]string {
+	m := map[string]string{
+		common.AnnotationKeyContentType: yamlContentType,
+		AnnotationKeySHA256:             rr.sha256(),
+	}
+	if rr.Version != "" {
+		m[AnnotationKeyVersion] = rr.Version
+	}
+	if rr.Endpoint != "" {
+		m[AnnotationKeyEndpoint] = rr.Endpoint
+	}
+	return m
 }
 
 // RefSource is the source reference of the remote data that records where the remote
 // file came from including the url, digest and the entrypoint.
 func (rr *ResolvedHubResource) RefSource() *pipelinev1.RefSource {
-	h := sha256.New()
-	h.Write(rr.Content)
-	sha256CheckSum := hex.EncodeToString(h.Sum(nil))
-
 	return &pipelinev1.RefSource{
 		URI: rr.URL,
 		Digest: map[string]string{
-			"sha256": sha256CheckSum,
+			"sha256": rr.sha256(),
 		},
 	}
 }
 
+// sha256 returns the hex encoded sha256 digest of the content.
+func (rr *ResolvedHubResource) sha256() string {
+	sum := sha256.Sum256(rr.Content)
+	return hex.EncodeToString(sum[:])
+}
+
 func isDisabled(ctx context.Context) bool {
 	cfg := resolverconfig.FromContextOrDefaults(ctx)
 	return !cfg.FeatureFlags.EnableHubResolver
 }
 
-func fetchHubResource(ctx context.Context, apiEndpoint string, v interface{}) error {
+func fetchHubResource(ctx context.Context, apiEndpoint string, header http.Header, v interface{}) error {
 	// #nosec G107 -- URL cannot be constant in this case.
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiEndpoint, nil)
 	if err != nil {
 		return fmt.Errorf("constructing request: %w", err)
 	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("requesting resource from Hub: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("requested resource '%s' not found on hub", apiEndpoint)
+		return &hubRequestError{err: err}
 	}
 	defer func() {
 		_ = resp.Body.Close()
 	}()
+	if resp.StatusCode != http.StatusOK {
+		return &hubStatusError{url: apiEndpoint, statusCode: resp.StatusCode}
+	}
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return fmt.Errorf("error reading response body: %w", err)
@@ -266,6 +323,11 @@ func resolveCatalogName(paramsMap, conf map[string]string) (string, error) {
 				return configAHTaskCatalog, nil
 			case "pipeline":
 				return configAHPipelineCatalog, nil
+			case "stepaction":
+				if configAHStepActionCatalog, ok := conf[ConfigArtifactHubStepActionCatalog]; ok {
+					return configAHStepActionCatalog, nil
+				}
+				return "", errors.New("default Artifact Hub stepaction catalog was not set during installation of the hub resolver")
 			default:
 				return "", fmt.Errorf("failed to resolve catalog name with kind: %s", paramsMap[ParamKind])
 			}
@@ -371,10 +433,24 @@ func validateParams(ctx context.Context, paramsMap map[string]string, tektonHubU
 			return fmt.Errorf("type param must be %s or %s", ArtifactHubType, TektonHubType)
 		}
 
+		if kind := paramsMap[ParamKind]; hubType == TektonHubType && !slices.Contains(tektonHubKinds, kind) {
+			return fmt.Errorf("kind %s is not supported by the %s hub type, use the %s type to resolve it", kind, TektonHubType, ArtifactHubType)
+		}
+
 		if hubType == TektonHubType && tektonHubURL == "" {
 			return errors.New("please configure TEKTON_HUB_API env variable to use tekton type")
 		}
 	}
+	if digest, ok := paramsMap[ParamSHA256]; ok {
+		if _, err := hex.DecodeString(digest); err != nil || len(digest) != sha256.Size*2 {
+			return fmt.Errorf("%s param must be a hex encoded sha256 digest of %d characters, got %q", ParamSHA256, sha256.Size*2, digest)
+		}
+	}
+	if version, ok := paramsMap[ParamVersion]; ok && isVersionConstraint(version) {
+		if _, err := parseVersionConstraint(version); err != nil {
+			return err
+		}
+	}
 
 	if len(missingParams) > 0 {
 		return fmt.Errorf("missing required hub resolver params: %s", strings.Join(missingParams, ", "))
@@ -383,14 +459,13 @@ func validateParams(ctx context.Context, paramsMap map[string]string, tektonHubU
 	return nil
 }
 
-func resolveVersionConstraint(ctx context.Context, paramsMap map[string]string, constraint goversion.Constraints, artifactHubURL, tektonHubURL string) (*goversion.Version, error) {
+func resolveVersionConstraint(ctx context.Context, paramsMap map[string]string, constraint goversion.Constraints, artifactHubURL, tektonHubURL string, header http.Header) (*goversion.Version, error) {
 	var ret *goversion.Version
 	if paramsMap[ParamType] == ArtifactHubType {
-		allVersionsURL := fmt.Sprintf("%s/%s", artifactHubURL, fmt.Sprintf(
-			ArtifactHubListTasksEndpoint,
-			paramsMap[ParamKind], paramsMap[ParamCatalog], paramsMap[ParamName]))
+		allVersionsPath := fmt.Sprintf(ArtifactHubListTasksEndpoint,
+			paramsMap[ParamKind], paramsMap[ParamCatalog], paramsMap[ParamName])
 		resp := artifactHubListResult{}
-		if err := fetchHubResource(ctx, allVersionsURL, &resp); err != nil {
+		if _, err := fetchHubResourceFromEndpoints(ctx, hubEndpoints(ctx, ArtifactHubType, artifactHubURL), allVersionsPath, header, &resp); err != nil {
 			return nil, fmt.Errorf("fail to fetch Artifact Hub resource: %w", err)
 		}
 		for _, vers := range resp.AvailableVersions {
@@ -401,7 +476,7 @@ func resolveVersionConstraint(ctx context.Context, paramsMap map[string]string,
 			if err != nil {
 				return nil, fmt.Errorf("fail to parse version %s from %s: %w", ArtifactHubType, vers.Version, err)
 			}
-			if checkV == nil {
+			if checkV == nil || checkV.Prerelease() != "" {
 				continue
 			}
 			if constraint.Check(checkV) {
@@ -413,11 +488,10 @@ func resolveVersionConstraint(ctx context.Context, paramsMap map[string]string,
 			}
 		}
 	} else if paramsMap[ParamType] == TektonHubType {
-		allVersionsURL := fmt.Sprintf("%s/%s", tektonHubURL,
-			fmt.Sprintf(TektonHubListTasksEndpoint,
-				paramsMap[ParamCatalog], paramsMap[ParamKind], paramsMap[ParamName]))
+		allVersionsPath := fmt.Sprintf(TektonHubListTasksEndpoint,
+			paramsMap[ParamCatalog], paramsMap[ParamKind], paramsMap[ParamName])
 		resp := tektonHubListResult{}
-		if err := fetchHubResource(ctx, allVersionsURL, &resp); err != nil {
+		if _, err := fetchHubResourceFromEndpoints(ctx, hubEndpoints(ctx, TektonHubType, tektonHubURL), allVersionsPath, header, &resp); err != nil {
 			return nil, fmt.Errorf("fail to fetch Tekton Hub resource: %w", err)
 		}
 		for _, vers := range resp.Data.Versions {
@@ -425,7 +499,7 @@ func resolveVersionConstraint(ctx context.Context, paramsMap map[string]string,
 			if err != nil {
 				return nil, fmt.Errorf("fail to parse version %s from %s: %w", TektonHubType, vers, err)
 			}
-			if checkV == nil {
+			if checkV == nil || checkV.Prerelease() != "" {
 				continue
 			}
 			if constraint.Check(checkV) {
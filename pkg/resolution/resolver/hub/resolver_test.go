@@ -18,6 +18,8 @@ package hub
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -53,6 +55,7 @@ func TestValidateParams(t *testing.T) {
 		catalog      string
 		resourceName string
 		hubType      string
+		sha256       string
 		expectedErr  error
 	}{
 		{
@@ -71,6 +74,15 @@ func TestValidateParams(t *testing.T) {
 			catalog:      "baz",
 			hubType:      ArtifactHubType,
 		},
+		{
+			testName:     "stepaction with tekton type",
+			kind:         "stepaction",
+			resourceName: "foo",
+			version:      "bar",
+			catalog:      "baz",
+			hubType:      TektonHubType,
+			expectedErr:  errors.New("failed to validate params: kind stepaction is not supported by the tekton hub type, use the artifact type to resolve it"),
+		},
 		{
 			testName:     "tekton type validation",
 			kind:         "task",
@@ -80,6 +92,33 @@ func TestValidateParams(t *testing.T) {
 			hubType:      TektonHubType,
 			expectedErr:  errors.New("failed to validate params: please configure TEKTON_HUB_API env variable to use tekton type"),
 		},
+		{
+			testName:     "version constraint validation",
+			kind:         "task",
+			resourceName: "foo",
+			version:      ">=0.5 <0.7",
+			catalog:      "baz",
+			hubType:      ArtifactHubType,
+		},
+		{
+			testName:     "invalid version constraint",
+			kind:         "task",
+			resourceName: "foo",
+			version:      ">=0.5 <<0.7",
+			catalog:      "baz",
+			hubType:      ArtifactHubType,
+			expectedErr:  errors.New(`failed to validate params: invalid version constraint ">=0.5 <<0.7": Malformed constraint: <<0.7; use comparisons such as ">=0.5 <0.7" or "~0.6"`),
+		},
+		{
+			testName:     "invalid sha256 digest",
+			kind:         "task",
+			resourceName: "foo",
+			version:      "bar",
+			catalog:      "baz",
+			hubType:      ArtifactHubType,
+			sha256:       "deadbeef",
+			expectedErr:  errors.New(`failed to validate params: sha256 param must be a hex encoded sha256 digest of 64 characters, got "deadbeef"`),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -92,6 +131,9 @@ func TestValidateParams(t *testing.T) {
 				ParamCatalog: tc.catalog,
 				ParamType:    tc.hubType,
 			}
+			if tc.sha256 != "" {
+				params[ParamSHA256] = tc.sha256
+			}
 
 			err := resolver.ValidateParams(contextWithConfig(), toParams(params))
 			if tc.expectedErr != nil {
@@ -329,6 +371,89 @@ func TestResolveConstraint(t *testing.T) {
 				},
 			},
 			expectedErr: errors.New("no version found for constraint >= 0.2.0"),
+		}, {
+			name:        "good/tekton hub/space separated constraints",
+			kind:        "task",
+			version:     ">=0.5 <0.7",
+			catalog:     "Tekton",
+			taskName:    "something",
+			hubType:     TektonHubType,
+			expectedRes: "some content",
+			resultTask: &tektonHubResponse{
+				Data: tektonHubDataResponse{
+					YAML: "some content",
+				},
+			},
+			resultList: &tektonHubListResult{
+				Data: tektonHubListDataResult{
+					Versions: []tektonHubListResultVersion{
+						{Version: "0.5"},
+						{Version: "0.6"},
+						{Version: "0.7"},
+					},
+				},
+			},
+			expectedTaskVersion: "0.6",
+		}, {
+			name:        "good/tekton hub/pre-releases are excluded",
+			kind:        "task",
+			version:     ">=0.5",
+			catalog:     "Tekton",
+			taskName:    "something",
+			hubType:     TektonHubType,
+			expectedRes: "some content",
+			resultTask: &tektonHubResponse{
+				Data: tektonHubDataResponse{
+					YAML: "some content",
+				},
+			},
+			resultList: &tektonHubListResult{
+				Data: tektonHubListDataResult{
+					Versions: []tektonHubListResultVersion{
+						{Version: "0.5"},
+						{Version: "0.6-rc1"},
+					},
+				},
+			},
+			expectedTaskVersion: "0.5",
+		}, {
+			name:        "good/artifact hub/tilde constraint",
+			kind:        "task",
+			version:     "~0.6",
+			catalog:     "Tekton",
+			taskName:    "something",
+			hubType:     ArtifactHubType,
+			expectedRes: "some content",
+			resultTask: &artifactHubResponse{
+				Data: artifactHubDataResponse{
+					YAML: "some content",
+				},
+			},
+			resultList: &artifactHubListResult{
+				AvailableVersions: []artifactHubavailableVersionsResults{
+					{Version: "0.5.3"},
+					{Version: "0.6.0"},
+					{Version: "0.6.2"},
+					{Version: "0.6.3", Prerelease: true},
+					{Version: "0.7.0-beta.1"},
+					{Version: "0.7.0"},
+				},
+			},
+			expectedTaskVersion: "0.6.2",
+		}, {
+			name:     "bad/artifact hub/only pre-releases satisfy the constraint",
+			kind:     "task",
+			version:  "~0.7",
+			catalog:  "Tekton",
+			taskName: "something",
+			hubType:  ArtifactHubType,
+			resultList: &artifactHubListResult{
+				AvailableVersions: []artifactHubavailableVersionsResults{
+					{Version: "0.6.2"},
+					{Version: "0.7.0-beta.1"},
+				},
+			},
+			expectedErr: errors.New("no version found for constraint ~0.7"),
 		},
 	}
 	for _, tt := range tests {
@@ -379,6 +504,14 @@ func TestResolveConstraint(t *testing.T) {
 				if d := cmp.Diff(tt.expectedRes, string(output.Data())); d != "" {
 					t.Errorf("unexpected resource from Resolve: %s", diff.PrintWantGot(d))
 				}
+				if tt.expectedTaskVersion != "" {
+					if d := cmp.Diff(tt.expectedTaskVersion, output.Annotations()[AnnotationKeyVersion]); d != "" {
+						t.Errorf("unexpected version annotation: %s", diff.PrintWantGot(d))
+					}
+					if !strings.Contains(output.RefSource().URI, "/"+tt.expectedTaskVersion) {
+						t.Errorf("expected the RefSource URI %q to contain the version %q", output.RefSource().URI, tt.expectedTaskVersion)
+					}
+				}
 			}
 		})
 	}
@@ -462,6 +595,12 @@ func TestResolveCatalogName(t *testing.T) {
 			hubType:     "artifact",
 			expectedCat: "tekton-catalog-pipelines",
 		},
+		{
+			name:        "artifact type default stepaction catalog",
+			kind:        "stepaction",
+			hubType:     "artifact",
+			expectedCat: "tekton-catalog-stepactions",
+		},
 		{
 			name:        "custom catalog",
 			inputCat:    "custom-catalog",
@@ -622,10 +761,116 @@ func TestResolve(t *testing.T) {
 	}
 }
 
+func TestResolveSHA256(t *testing.T) {
+	content := "some content"
+	sum := sha256.Sum256([]byte(content))
+	digest := hex.EncodeToString(sum[:])
+	otherDigest := strings.Repeat("0", 64)
+
+	testCases := []struct {
+		name        string
+		sha256      string
+		expectedErr error
+	}{{
+		name: "no expected digest",
+	}, {
+		name:   "matching digest",
+		sha256: digest,
+	}, {
+		name:   "matching upper case digest",
+		sha256: strings.ToUpper(digest),
+	}, {
+		name:        "mismatching digest",
+		sha256:      otherDigest,
+		expectedErr: fmt.Errorf("sha256 digest mismatch for resource %%SERVER%%/api/v1/packages/tekton-task/Tekton/foo/0.1.0: expected %s, got %s", otherDigest, digest),
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprintf(w, `{"data":{"manifestRaw":%q}}`, content)
+			}))
+			defer svr.Close()
+
+			resolver := &Resolver{ArtifactHubURL: svr.URL}
+			params := map[string]string{
+				ParamKind:    "task",
+				ParamName:    "foo",
+				ParamVersion: "0.1",
+				ParamCatalog: "Tekton",
+				ParamType:    ArtifactHubType,
+			}
+			if tc.sha256 != "" {
+				params[ParamSHA256] = tc.sha256
+			}
+
+			output, err := resolver.Resolve(contextWithConfig(), toParams(params))
+			if tc.expectedErr != nil {
+				checkExpectedErr(t, errors.New(strings.ReplaceAll(tc.expectedErr.Error(), "%SERVER%", svr.URL)), err)
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error resolving: %v", err)
+			}
+			if d := cmp.Diff(digest, output.Annotations()[AnnotationKeySHA256]); d != "" {
+				t.Errorf("unexpected sha256 annotation: %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(digest, output.RefSource().Digest["sha256"]); d != "" {
+				t.Errorf("unexpected sha256 digest in the RefSource: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func resolverDisabledContext() context.Context {
 	return frtesting.ContextWithHubResolverDisabled(context.Background())
 }
 
+func TestResolveStepAction(t *testing.T) {
+	manifest := `apiVersion: tekton.dev/v1beta1
+kind: StepAction
+metadata:
+  name: git-clone
+spec:
+  image: alpine/git
+  script: git clone "$(params.url)"
+`
+	var requestedPath string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPath = r.URL.Path
+		fmt.Fprintf(w, `{"data":{"manifestRaw":%q}}`, manifest)
+	}))
+	defer svr.Close()
+
+	resolver := &Resolver{ArtifactHubURL: svr.URL}
+	params := map[string]string{
+		ParamKind:    "stepaction",
+		ParamName:    "git-clone",
+		ParamVersion: "0.1",
+		ParamType:    ArtifactHubType,
+	}
+	output, err := resolver.Resolve(contextWithConfig(), toParams(params))
+	if err != nil {
+		t.Fatalf("unexpected error resolving: %v", err)
+	}
+	if d := cmp.Diff("/api/v1/packages/tekton-stepaction/tekton-catalog-stepactions/git-clone/0.1.0", requestedPath); d != "" {
+		t.Errorf("unexpected path requested from the Artifact Hub: %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff([]byte(manifest), output.Data()); d != "" {
+		t.Errorf("unexpected resource from Resolve: %s", diff.PrintWantGot(d))
+	}
+	sum := sha256.Sum256([]byte(manifest))
+	wantAnnotations := map[string]string{
+		common.AnnotationKeyContentType: "application/x-yaml",
+		AnnotationKeyVersion:            "0.1.0",
+		AnnotationKeySHA256:             hex.EncodeToString(sum[:]),
+		AnnotationKeyEndpoint:           svr.URL,
+	}
+	if d := cmp.Diff(wantAnnotations, output.Annotations()); d != "" {
+		t.Errorf("unexpected annotations: %s", diff.PrintWantGot(d))
+	}
+}
+
 func toParams(m map[string]string) []pipelinev1.Param {
 	var params []pipelinev1.Param
 
@@ -641,10 +886,11 @@ func toParams(m map[string]string) []pipelinev1.Param {
 
 func contextWithConfig() context.Context {
 	config := map[string]string{
-		"default-tekton-hub-catalog":            "Tekton",
-		"default-artifact-hub-task-catalog":     "tekton-catalog-tasks",
-		"default-artifact-hub-pipeline-catalog": "tekton-catalog-pipelines",
-		"default-type":                          "artifact",
+		"default-tekton-hub-catalog":              "Tekton",
+		"default-artifact-hub-task-catalog":       "tekton-catalog-tasks",
+		"default-artifact-hub-pipeline-catalog":   "tekton-catalog-pipelines",
+		"default-artifact-hub-stepaction-catalog": "tekton-catalog-stepactions",
+		"default-type":                            "artifact",
 	}
 
 	return framework.InjectResolverConfigToContext(context.Background(), config)
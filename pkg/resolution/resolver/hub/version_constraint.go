@@ -0,0 +1,66 @@
+/*
+Copyright 2025 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hub
+
+import (
+	"fmt"
+	"strings"
+
+	goversion "github.com/hashicorp/go-version"
+)
+
+// constraintOperatorChars are the characters marking a version param as a
+// constraint expression rather than a concrete version.
+const constraintOperatorChars = "<>=!~^,"
+
+// isVersionConstraint reports whether the version param is a constraint
+// expression, e.g. ">=0.5 <0.7" or "~0.6", rather than a concrete version.
+func isVersionConstraint(version string) bool {
+	return strings.ContainsAny(version, constraintOperatorChars)
+}
+
+// parseVersionConstraint parses a version constraint expression. Besides the
+// go-version syntax (">= 0.5, < 0.7", "~> 0.6.0"), the constraints may be
+// separated by spaces (">=0.5 <0.7") and "~X.Y" selects the X.Y patch
+// releases as "~> X.Y.0" does.
+func parseVersionConstraint(version string) (goversion.Constraints, error) {
+	fields := strings.FieldsFunc(version, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+	var constraints []string
+	for i := 0; i < len(fields); i++ {
+		c := fields[i]
+		// An operator separated from its version by spaces, e.g. ">= 0.5".
+		if strings.Trim(c, constraintOperatorChars) == "" && i+1 < len(fields) {
+			i++
+			c += fields[i]
+		}
+		if len(c) > 1 && strings.HasPrefix(c, "~") && !strings.HasPrefix(c, "~>") {
+			v := strings.TrimPrefix(c, "~")
+			if strings.Count(v, ".") < 2 {
+				v += ".0"
+			}
+			c = "~>" + v
+		}
+		constraints = append(constraints, c)
+	}
+	if len(constraints) == 0 {
+		return nil, fmt.Errorf("invalid version constraint %q: the constraint is empty", version)
+	}
+	constraint, err := goversion.NewConstraint(strings.Join(constraints, ","))
+	if err != nil {
+		return nil, fmt.Errorf("invalid version constraint %q: %w; use comparisons such as \">=0.5 <0.7\" or \"~0.6\"", version, err)
+	}
+	return constraint, nil
+}
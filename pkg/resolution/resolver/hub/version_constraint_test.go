@@ -0,0 +1,101 @@
+/*
+Copyright 2025 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hub
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	goversion "github.com/hashicorp/go-version"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func TestIsVersionConstraint(t *testing.T) {
+	for version, want := range map[string]bool{
+		"0.6":         false,
+		"0.6.0":       false,
+		"latest":      false,
+		">=0.5":       true,
+		">=0.5 <0.7":  true,
+		"~0.6":        true,
+		">= 0.5, < 1": true,
+	} {
+		if got := isVersionConstraint(version); got != want {
+			t.Errorf("isVersionConstraint(%q) = %t, want %t", version, got, want)
+		}
+	}
+}
+
+func TestParseVersionConstraint(t *testing.T) {
+	tests := []struct {
+		constraint string
+		want       string
+		matching   []string
+		others     []string
+	}{{
+		constraint: ">=0.5 <0.7",
+		want:       ">=0.5,<0.7",
+		matching:   []string{"0.5", "0.6.3"},
+		others:     []string{"0.4.9", "0.7"},
+	}, {
+		constraint: ">= 0.5, < 0.7",
+		want:       ">=0.5,<0.7",
+		matching:   []string{"0.5", "0.6.3"},
+		others:     []string{"0.4.9", "0.7"},
+	}, {
+		constraint: "~0.6",
+		want:       "~>0.6.0",
+		matching:   []string{"0.6", "0.6.9"},
+		others:     []string{"0.5.9", "0.7.0"},
+	}, {
+		constraint: "~1",
+		want:       "~>1.0",
+		matching:   []string{"1.0.0", "1.9.0"},
+		others:     []string{"0.9.0", "2.0.0"},
+	}, {
+		constraint: "~> 0.6",
+		want:       "~>0.6",
+		matching:   []string{"0.6", "0.9"},
+		others:     []string{"1.0"},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.constraint, func(t *testing.T) {
+			got, err := parseVersionConstraint(tc.constraint)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if d := cmp.Diff(tc.want, got.String()); d != "" {
+				t.Errorf("unexpected constraint: %s", diff.PrintWantGot(d))
+			}
+			for _, v := range tc.matching {
+				if !got.Check(goversion.Must(goversion.NewVersion(v))) {
+					t.Errorf("expected %s to satisfy %q", v, tc.constraint)
+				}
+			}
+			for _, v := range tc.others {
+				if got.Check(goversion.Must(goversion.NewVersion(v))) {
+					t.Errorf("expected %s not to satisfy %q", v, tc.constraint)
+				}
+			}
+		})
+	}
+}
+
+func TestParseVersionConstraint_Invalid(t *testing.T) {
+	for _, constraint := range []string{">=", ",", ">=0.5 <<0.7", "~foo"} {
+		if _, err := parseVersionConstraint(constraint); err == nil {
+			t.Errorf("expected an error parsing %q", constraint)
+		}
+	}
+}
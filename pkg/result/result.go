@@ -42,6 +42,15 @@ const (
 	TaskRunArtifactsResultType ResultType = 6
 )
 
+// MemoryPeakKey is the key of the internal result holding the peak memory
+// usage, in bytes, of the container of a step, written by the entrypoint and
+// reported when the container was OOMKilled.
+const MemoryPeakKey = "MemoryPeak"
+
+// HermeticViolationsKey is the key of the internal result holding the number
+// of network failures of a step run hermetically, written by the entrypoint.
+const HermeticViolationsKey = "hermeticViolations"
+
 // RunResult is used to write key/value pairs to TaskRun pod termination messages.
 // The key/value pairs may come from the entrypoint binary, or represent a TaskRunResult.
 // If they represent a TaskRunResult, the key is the name of the result and the value is the
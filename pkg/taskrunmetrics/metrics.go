@@ -429,6 +429,9 @@ func (r *Recorder) DurationAndCount(ctx context.Context, tr *v1.TaskRun, beforeC
 	status := "success"
 	if cond.Status == corev1.ConditionFalse {
 		status = "failed"
+		if cond.Reason == v1.TaskRunReasonSuperseded.String() {
+			status = "superseded"
+		}
 	}
 	reason := cond.Reason
 
@@ -0,0 +1,315 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package upgradecheck finds the fields of the v1beta1 Tasks, Pipelines,
+// TaskRuns and PipelineRuns which don't convert as they are to v1, by running
+// them through the conversion of the API in dry-run, and optionally rewrites
+// the objects of a cluster in the storage version.
+package upgradecheck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"knative.dev/pkg/apis"
+)
+
+// Change is how a field of a v1beta1 object changes when it's converted to v1.
+type Change string
+
+const (
+	// ChangeMoved means that the value of the field moves to another field.
+	ChangeMoved Change = "Moved"
+	// ChangeMovedToAnnotation means that the field has no v1 equivalent and
+	// its value is serialized into an annotation of the object.
+	ChangeMovedToAnnotation Change = "MovedToAnnotation"
+	// ChangeDropped means that the value of the field is lost.
+	ChangeDropped Change = "Dropped"
+)
+
+// Finding is a field of a v1beta1 object which doesn't convert as it is to v1.
+type Finding struct {
+	// Field is the path of the field in the v1beta1 object, e.g. "spec.steps[0].tty".
+	Field string `json:"field"`
+	// Change is how the field changes.
+	Change Change `json:"change"`
+	// Target is the field the value moves to, or the annotation it's
+	// serialized into.
+	Target string `json:"target,omitempty"`
+}
+
+// Report lists the findings of the conversion of an object.
+type Report struct {
+	Kind      string    `json:"kind"`
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+	Findings  []Finding `json:"findings,omitempty"`
+	// Error is set if the object can't be converted or rewritten.
+	Error string `json:"error,omitempty"`
+	// Rewritten is set once the object was rewritten in the storage version.
+	Rewritten bool `json:"rewritten,omitempty"`
+}
+
+// HasDrops returns true if a field of the object would be lost by the conversion.
+func (r Report) HasDrops() bool {
+	for _, f := range r.Findings {
+		if f.Change == ChangeDropped {
+			return true
+		}
+	}
+	return false
+}
+
+// object is a versioned object of the API.
+type object interface {
+	apis.Convertible
+	metav1.Object
+	runtime.Object
+}
+
+// CheckTask returns the report of the conversion of the Task to v1.
+func CheckTask(ctx context.Context, t *v1beta1.Task) Report {
+	return checkObject(ctx, "Task", t,
+		func() object { return &v1.Task{} }, func() object { return &v1beta1.Task{} })
+}
+
+// CheckPipeline returns the report of the conversion of the Pipeline to v1.
+func CheckPipeline(ctx context.Context, p *v1beta1.Pipeline) Report {
+	return checkObject(ctx, "Pipeline", p,
+		func() object { return &v1.Pipeline{} }, func() object { return &v1beta1.Pipeline{} })
+}
+
+// CheckTaskRun returns the report of the conversion of the TaskRun to v1.
+func CheckTaskRun(ctx context.Context, tr *v1beta1.TaskRun) Report {
+	return checkObject(ctx, "TaskRun", tr,
+		func() object { return &v1.TaskRun{} }, func() object { return &v1beta1.TaskRun{} })
+}
+
+// CheckPipelineRun returns the report of the conversion of the PipelineRun to v1.
+func CheckPipelineRun(ctx context.Context, pr *v1beta1.PipelineRun) Report {
+	return checkObject(ctx, "PipelineRun", pr,
+		func() object { return &v1.PipelineRun{} }, func() object { return &v1beta1.PipelineRun{} })
+}
+
+// checkObject converts the object to v1 with ConvertTo, like the conversion
+// webhook does, and back to v1beta1 with ConvertFrom. The fields missing from
+// the round trip were dropped, or moved if their value shows up in another
+// field. The fields missing from the round trip without one of the
+// annotations added by ConvertTo were moved to that annotation.
+func checkObject(ctx context.Context, kind string, obj object, newV1, newV1beta1 func() object) Report {
+	report := Report{Kind: kind, Namespace: obj.GetNamespace(), Name: obj.GetName()}
+	// The conversion shares the annotations of the source and the sink, so
+	// each conversion gets its own copy of its source.
+	sink := newV1()
+	if err := obj.DeepCopyObject().(object).ConvertTo(ctx, sink); err != nil {
+		report.Error = fmt.Sprintf("converting to v1: %v", err)
+		return report
+	}
+	original, err := leafFields(obj)
+	if err != nil {
+		report.Error = err.Error()
+		return report
+	}
+	roundTrip, err := convertBack(ctx, sink, newV1beta1)
+	if err != nil {
+		report.Error = fmt.Sprintf("converting back to v1beta1: %v", err)
+		return report
+	}
+
+	// Fields whose value shows up in a field which the original object
+	// doesn't set were moved there.
+	added := map[string][]string{}
+	for field, value := range roundTrip {
+		if v, ok := original[field]; !ok || v != value {
+			added[value] = append(added[value], field)
+		}
+	}
+	for _, fields := range added {
+		sort.Strings(fields)
+	}
+	for _, field := range sortedKeys(original) {
+		value := original[field]
+		if v, ok := roundTrip[field]; ok && v == value {
+			continue
+		}
+		if targets := added[value]; len(targets) > 0 {
+			report.Findings = append(report.Findings, Finding{Field: field, Change: ChangeMoved, Target: targets[0]})
+			added[value] = targets[1:]
+			continue
+		}
+		report.Findings = append(report.Findings, Finding{Field: field, Change: ChangeDropped})
+	}
+
+	// Fields which don't survive the round trip without an annotation added
+	// by ConvertTo were moved to it.
+	for _, key := range addedAnnotations(obj.GetAnnotations(), sink.GetAnnotations()) {
+		stripped := sink.DeepCopyObject().(object)
+		annotations := stripped.GetAnnotations()
+		delete(annotations, key)
+		stripped.SetAnnotations(annotations)
+		withoutKey, err := convertBack(ctx, stripped, newV1beta1)
+		if err != nil {
+			report.Error = fmt.Sprintf("converting back to v1beta1 without the annotation %s: %v", key, err)
+			return report
+		}
+		for _, field := range sortedKeys(roundTrip) {
+			if v, ok := withoutKey[field]; !ok || v != roundTrip[field] {
+				report.Findings = append(report.Findings, Finding{Field: field, Change: ChangeMovedToAnnotation, Target: key})
+			}
+		}
+	}
+	sort.SliceStable(report.Findings, func(i, j int) bool { return report.Findings[i].Field < report.Findings[j].Field })
+	report.Findings = collapse(report.Findings, original)
+	return report
+}
+
+// collapse replaces the findings of the leaf fields by a single finding for
+// the outermost field whose leaves all change the same way, e.g. a finding
+// for "spec.resources" rather than one per field of each resource.
+func collapse(findings []Finding, original map[string]string) []Finding {
+	byField := map[string]Finding{}
+	for _, f := range findings {
+		byField[f.Field] = f
+	}
+	uniform := func(field string, f Finding) bool {
+		for leaf := range original {
+			if !strings.HasPrefix(leaf, field+".") && !strings.HasPrefix(leaf, field+"[") && leaf != field {
+				continue
+			}
+			if g, ok := byField[leaf]; !ok || g.Change != f.Change || g.Target != f.Target {
+				return false
+			}
+		}
+		return true
+	}
+	var collapsed []Finding
+	seen := map[string]bool{}
+	for _, f := range findings {
+		field := f.Field
+		if f.Change != ChangeMoved {
+			for _, parent := range parentFields(f.Field) {
+				if uniform(parent, f) {
+					field = parent
+					break
+				}
+			}
+		}
+		if !seen[field] {
+			seen[field] = true
+			collapsed = append(collapsed, Finding{Field: field, Change: f.Change, Target: f.Target})
+		}
+	}
+	return collapsed
+}
+
+// parentFields returns the fields containing the given field, outermost
+// first, below the spec or the status.
+func parentFields(field string) []string {
+	var parents []string
+	for i := strings.IndexAny(field, ".["); i >= 0; {
+		next := strings.IndexAny(field[i+1:], ".[")
+		if next < 0 {
+			break
+		}
+		i += next + 1
+		parents = append(parents, field[:i])
+	}
+	return parents
+}
+
+// convertBack converts a copy of the v1 object to v1beta1 and returns its leaf fields.
+func convertBack(ctx context.Context, sink object, newV1beta1 func() object) (map[string]string, error) {
+	back := newV1beta1()
+	if err := back.ConvertFrom(ctx, sink.DeepCopyObject().(object)); err != nil {
+		return nil, err
+	}
+	return leafFields(back)
+}
+
+// addedAnnotations returns the sorted keys of the annotations which were
+// added or changed by the conversion.
+func addedAnnotations(before, after map[string]string) []string {
+	var keys []string
+	for k, v := range after {
+		if old, ok := before[k]; !ok || old != v {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// leafFields returns the JSON encoded values of the leaf fields of the spec
+// and the status of the object by their path.
+func leafFields(obj object) (map[string]string, error) {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling %s: %w", obj.GetName(), err)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, fmt.Errorf("unmarshalling %s: %w", obj.GetName(), err)
+	}
+	leaves := map[string]string{}
+	for _, key := range []string{"spec", "status"} {
+		if err := flatten(key, fields[key], leaves); err != nil {
+			return nil, err
+		}
+	}
+	return leaves, nil
+}
+
+func flatten(path string, value any, leaves map[string]string) error {
+	switch v := value.(type) {
+	case nil:
+	case map[string]any:
+		for k, child := range v {
+			if err := flatten(path+"."+k, child, leaves); err != nil {
+				return err
+			}
+		}
+	case []any:
+		for i, child := range v {
+			if err := flatten(path+"["+strconv.Itoa(i)+"]", child, leaves); err != nil {
+				return err
+			}
+		}
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("marshalling %s: %w", path, err)
+		}
+		leaves[path] = string(b)
+	}
+	return nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
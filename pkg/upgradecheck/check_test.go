@@ -0,0 +1,149 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upgradecheck
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	deprecationsAnnotation    = v1beta1.TaskDeprecationsAnnotationKey
+	resourcesAnnotation       = "tekton.dev/v1beta1Resources"
+	cloudEventsAnnotation     = "tekton.dev/v1beta1CloudEvents"
+	resourcesResultAnnotation = "tekton.dev/v1beta1ResourcesResult"
+)
+
+func mustParseFixture(t *testing.T, name string, obj any) {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("reading the fixture %s: %v", name, err)
+	}
+	if err := yaml.Unmarshal(b, obj); err != nil {
+		t.Fatalf("parsing the fixture %s: %v", name, err)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		fixture string
+		check   func(t *testing.T, fixture string) Report
+		want    Report
+	}{{
+		name:    "task with resources and deprecated step fields",
+		fixture: "task.yaml",
+		check: func(t *testing.T, fixture string) Report {
+			t.Helper()
+			task := &v1beta1.Task{}
+			mustParseFixture(t, fixture, task)
+			return CheckTask(t.Context(), task)
+		},
+		want: Report{Kind: "Task", Namespace: "default", Name: "build", Findings: []Finding{
+			{Field: "spec.resources", Change: ChangeMovedToAnnotation, Target: resourcesAnnotation},
+			{Field: "spec.stepTemplate.name", Change: ChangeMovedToAnnotation, Target: deprecationsAnnotation},
+			{Field: "spec.stepTemplate.ports", Change: ChangeMovedToAnnotation, Target: deprecationsAnnotation},
+			{Field: "spec.stepTemplate.stdin", Change: ChangeMovedToAnnotation, Target: deprecationsAnnotation},
+			{Field: "spec.steps[0].lifecycle", Change: ChangeMovedToAnnotation, Target: deprecationsAnnotation},
+			{Field: "spec.steps[0].livenessProbe", Change: ChangeMovedToAnnotation, Target: deprecationsAnnotation},
+			{Field: "spec.steps[0].ports", Change: ChangeMovedToAnnotation, Target: deprecationsAnnotation},
+			{Field: "spec.steps[0].readinessProbe", Change: ChangeMovedToAnnotation, Target: deprecationsAnnotation},
+			{Field: "spec.steps[0].startupProbe", Change: ChangeMovedToAnnotation, Target: deprecationsAnnotation},
+			{Field: "spec.steps[0].stdin", Change: ChangeMovedToAnnotation, Target: deprecationsAnnotation},
+			{Field: "spec.steps[0].stdinOnce", Change: ChangeMovedToAnnotation, Target: deprecationsAnnotation},
+			{Field: "spec.steps[0].terminationMessagePath", Change: ChangeMovedToAnnotation, Target: deprecationsAnnotation},
+			{Field: "spec.steps[0].terminationMessagePolicy", Change: ChangeMovedToAnnotation, Target: deprecationsAnnotation},
+			{Field: "spec.steps[0].tty", Change: ChangeMovedToAnnotation, Target: deprecationsAnnotation},
+		}},
+	}, {
+		name:    "pipeline with resources and an embedded task with deprecated step fields",
+		fixture: "pipeline.yaml",
+		check: func(t *testing.T, fixture string) Report {
+			t.Helper()
+			pipeline := &v1beta1.Pipeline{}
+			mustParseFixture(t, fixture, pipeline)
+			return CheckPipeline(t.Context(), pipeline)
+		},
+		want: Report{Kind: "Pipeline", Namespace: "default", Name: "release", Findings: []Finding{
+			{Field: "spec.resources", Change: ChangeMovedToAnnotation, Target: resourcesAnnotation},
+			{Field: "spec.tasks[0].resources", Change: ChangeDropped},
+			{Field: "spec.tasks[1].taskSpec.steps[0].tty", Change: ChangeMovedToAnnotation, Target: deprecationsAnnotation},
+		}},
+	}, {
+		name:    "taskrun with resources, cloud events and resources results",
+		fixture: "taskrun.yaml",
+		check: func(t *testing.T, fixture string) Report {
+			t.Helper()
+			tr := &v1beta1.TaskRun{}
+			mustParseFixture(t, fixture, tr)
+			return CheckTaskRun(t.Context(), tr)
+		},
+		want: Report{Kind: "TaskRun", Namespace: "default", Name: "build-run", Findings: []Finding{
+			{Field: "spec.resources", Change: ChangeMovedToAnnotation, Target: resourcesAnnotation},
+			{Field: "status.cloudEvents", Change: ChangeMovedToAnnotation, Target: cloudEventsAnnotation},
+			{Field: "status.resourcesResult", Change: ChangeMovedToAnnotation, Target: resourcesResultAnnotation},
+		}},
+	}, {
+		name:    "pipelinerun with resources and timeout",
+		fixture: "pipelinerun.yaml",
+		check: func(t *testing.T, fixture string) Report {
+			t.Helper()
+			pr := &v1beta1.PipelineRun{}
+			mustParseFixture(t, fixture, pr)
+			return CheckPipelineRun(t.Context(), pr)
+		},
+		want: Report{Kind: "PipelineRun", Namespace: "default", Name: "release-run", Findings: []Finding{
+			{Field: "spec.resources", Change: ChangeMovedToAnnotation, Target: resourcesAnnotation},
+			{Field: "spec.timeout", Change: ChangeMoved, Target: "spec.timeouts.pipeline"},
+		}},
+	}, {
+		name:    "task without deprecated fields",
+		fixture: "clean-task.yaml",
+		check: func(t *testing.T, fixture string) Report {
+			t.Helper()
+			task := &v1beta1.Task{}
+			mustParseFixture(t, fixture, task)
+			return CheckTask(t.Context(), task)
+		},
+		want: Report{Kind: "Task", Namespace: "default", Name: "clean"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.check(t, tc.fixture)
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("report diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestCheckDoesNotMutate(t *testing.T) {
+	task := &v1beta1.Task{}
+	mustParseFixture(t, "task.yaml", task)
+	task.Annotations = map[string]string{"foo": "bar"}
+	want := task.DeepCopy()
+	CheckTask(t.Context(), task)
+	if d := cmp.Diff(want, task); d != "" {
+		t.Errorf("CheckTask mutated the Task %s", diff.PrintWantGot(d))
+	}
+}
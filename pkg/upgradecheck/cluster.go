@@ -0,0 +1,209 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upgradecheck
+
+import (
+	"context"
+	"fmt"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	apiextensionsv1client "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/typed/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// listPageSize is the number of objects listed per request.
+const listPageSize = 500
+
+// Checker checks the conversion of the Tasks, Pipelines, TaskRuns and
+// PipelineRuns of a cluster.
+type Checker struct {
+	Client versioned.Interface
+	// Namespace restricts the check to a namespace. All the namespaces are
+	// checked if empty.
+	Namespace string
+	// RewriteStorageVersions rewrites the objects which convert without
+	// dropping any field, so that they're stored in the storage version.
+	RewriteStorageVersions bool
+	// CRDClient updates the stored versions of the CRDs once all their
+	// objects were rewritten, if set. It's ignored unless all the namespaces
+	// are checked.
+	CRDClient apiextensionsv1client.CustomResourceDefinitionsGetter
+}
+
+// getUpdater gets and updates the objects of a kind in a namespace.
+type getUpdater[T any] interface {
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (T, error)
+	Update(ctx context.Context, obj T, opts metav1.UpdateOptions) (T, error)
+}
+
+// Run checks the objects of the cluster and returns their reports. The
+// errors of the objects are recorded in their report, only the errors of the
+// lists and of the updates of the CRDs are returned.
+func (c *Checker) Run(ctx context.Context) ([]Report, error) {
+	var reports []Report
+	for _, check := range []func(context.Context) ([]Report, error){c.checkTasks, c.checkPipelines, c.checkTaskRuns, c.checkPipelineRuns} {
+		r, err := check(ctx)
+		if err != nil {
+			return reports, err
+		}
+		reports = append(reports, r...)
+	}
+	return reports, nil
+}
+
+func (c *Checker) checkTasks(ctx context.Context) ([]Report, error) {
+	var reports []Report
+	opts := metav1.ListOptions{Limit: listPageSize}
+	for {
+		list, err := c.Client.TektonV1beta1().Tasks(c.Namespace).List(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("listing the Tasks: %w", err)
+		}
+		for i := range list.Items {
+			r := CheckTask(ctx, &list.Items[i])
+			c.rewrite(&r, func() error {
+				return rewrite[*v1.Task](ctx, c.Client.TektonV1().Tasks(r.Namespace), r.Name)
+			})
+			reports = append(reports, r)
+		}
+		if list.Continue == "" {
+			break
+		}
+		opts.Continue = list.Continue
+	}
+	return reports, c.updateStoredVersions(ctx, "tasks", reports)
+}
+
+func (c *Checker) checkPipelines(ctx context.Context) ([]Report, error) {
+	var reports []Report
+	opts := metav1.ListOptions{Limit: listPageSize}
+	for {
+		list, err := c.Client.TektonV1beta1().Pipelines(c.Namespace).List(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("listing the Pipelines: %w", err)
+		}
+		for i := range list.Items {
+			r := CheckPipeline(ctx, &list.Items[i])
+			c.rewrite(&r, func() error {
+				return rewrite[*v1.Pipeline](ctx, c.Client.TektonV1().Pipelines(r.Namespace), r.Name)
+			})
+			reports = append(reports, r)
+		}
+		if list.Continue == "" {
+			break
+		}
+		opts.Continue = list.Continue
+	}
+	return reports, c.updateStoredVersions(ctx, "pipelines", reports)
+}
+
+func (c *Checker) checkTaskRuns(ctx context.Context) ([]Report, error) {
+	var reports []Report
+	opts := metav1.ListOptions{Limit: listPageSize}
+	for {
+		list, err := c.Client.TektonV1beta1().TaskRuns(c.Namespace).List(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("listing the TaskRuns: %w", err)
+		}
+		for i := range list.Items {
+			r := CheckTaskRun(ctx, &list.Items[i])
+			c.rewrite(&r, func() error {
+				return rewrite[*v1.TaskRun](ctx, c.Client.TektonV1().TaskRuns(r.Namespace), r.Name)
+			})
+			reports = append(reports, r)
+		}
+		if list.Continue == "" {
+			break
+		}
+		opts.Continue = list.Continue
+	}
+	return reports, c.updateStoredVersions(ctx, "taskruns", reports)
+}
+
+func (c *Checker) checkPipelineRuns(ctx context.Context) ([]Report, error) {
+	var reports []Report
+	opts := metav1.ListOptions{Limit: listPageSize}
+	for {
+		list, err := c.Client.TektonV1beta1().PipelineRuns(c.Namespace).List(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("listing the PipelineRuns: %w", err)
+		}
+		for i := range list.Items {
+			r := CheckPipelineRun(ctx, &list.Items[i])
+			c.rewrite(&r, func() error {
+				return rewrite[*v1.PipelineRun](ctx, c.Client.TektonV1().PipelineRuns(r.Namespace), r.Name)
+			})
+			reports = append(reports, r)
+		}
+		if list.Continue == "" {
+			break
+		}
+		opts.Continue = list.Continue
+	}
+	return reports, c.updateStoredVersions(ctx, "pipelineruns", reports)
+}
+
+// rewrite rewrites the object of the report with update if the storage
+// versions are rewritten and the object converts without dropping any field.
+func (c *Checker) rewrite(r *Report, update func() error) {
+	if !c.RewriteStorageVersions || r.Error != "" || r.HasDrops() {
+		return
+	}
+	if err := update(); err != nil {
+		r.Error = fmt.Sprintf("rewriting in the storage version: %v", err)
+		return
+	}
+	r.Rewritten = true
+}
+
+// rewrite gets the object in v1 and updates it as it is, which makes the API
+// server store it again in the storage version.
+func rewrite[T any](ctx context.Context, client getUpdater[T], name string) error {
+	obj, err := client.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	_, err = client.Update(ctx, obj, metav1.UpdateOptions{})
+	return err
+}
+
+// updateStoredVersions records that the objects of the CRD of the given
+// plural are only stored in v1 once all of them were rewritten.
+func (c *Checker) updateStoredVersions(ctx context.Context, plural string, reports []Report) error {
+	if !c.RewriteStorageVersions || c.CRDClient == nil || c.Namespace != "" {
+		return nil
+	}
+	for _, r := range reports {
+		if !r.Rewritten {
+			return nil
+		}
+	}
+	name := plural + ".tekton.dev"
+	crd, err := c.CRDClient.CustomResourceDefinitions().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("getting the CRD %s: %w", name, err)
+	}
+	if len(crd.Status.StoredVersions) == 1 && crd.Status.StoredVersions[0] == v1.SchemeGroupVersion.Version {
+		return nil
+	}
+	crd.Status.StoredVersions = []string{v1.SchemeGroupVersion.Version}
+	if _, err := c.CRDClient.CustomResourceDefinitions().UpdateStatus(ctx, crd, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("updating the stored versions of the CRD %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2025 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upgradecheck
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
+	"github.com/tektoncd/pipeline/test/diff"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsv1client "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/typed/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// fakeCRDs serves the CRDs of the map by their name.
+type fakeCRDs struct {
+	apiextensionsv1client.CustomResourceDefinitionInterface
+	crds map[string]*apiextensionsv1.CustomResourceDefinition
+}
+
+func (f *fakeCRDs) CustomResourceDefinitions() apiextensionsv1client.CustomResourceDefinitionInterface {
+	return f
+}
+
+func (f *fakeCRDs) Get(_ context.Context, name string, _ metav1.GetOptions) (*apiextensionsv1.CustomResourceDefinition, error) {
+	return f.crds[name].DeepCopy(), nil
+}
+
+func (f *fakeCRDs) UpdateStatus(_ context.Context, crd *apiextensionsv1.CustomResourceDefinition, _ metav1.UpdateOptions) (*apiextensionsv1.CustomResourceDefinition, error) {
+	f.crds[crd.Name] = crd.DeepCopy()
+	return crd, nil
+}
+
+func TestCheckerRun(t *testing.T) {
+	task := &v1beta1.Task{}
+	mustParseFixture(t, "task.yaml", task)
+	pipeline := &v1beta1.Pipeline{}
+	mustParseFixture(t, "pipeline.yaml", pipeline)
+	// The fake clientset doesn't convert the objects, so they're also served in v1.
+	v1Task := &v1.Task{}
+	if err := task.DeepCopy().ConvertTo(t.Context(), v1Task); err != nil {
+		t.Fatal(err)
+	}
+	v1Pipeline := &v1.Pipeline{}
+	if err := pipeline.DeepCopy().ConvertTo(t.Context(), v1Pipeline); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name              string
+		namespace         string
+		rewrite           bool
+		wantRewritten     map[string]bool
+		wantStoredVersion map[string][]string
+	}{{
+		name:          "dry run",
+		wantRewritten: map[string]bool{},
+		wantStoredVersion: map[string][]string{
+			"tasks.tekton.dev":        {"v1beta1", "v1"},
+			"pipelines.tekton.dev":    {"v1beta1", "v1"},
+			"taskruns.tekton.dev":     {"v1beta1", "v1"},
+			"pipelineruns.tekton.dev": {"v1beta1", "v1"},
+		},
+	}, {
+		name:          "rewrite storage versions",
+		rewrite:       true,
+		wantRewritten: map[string]bool{"Task": true},
+		wantStoredVersion: map[string][]string{
+			"tasks.tekton.dev":        {"v1"},
+			"pipelines.tekton.dev":    {"v1beta1", "v1"},
+			"taskruns.tekton.dev":     {"v1"},
+			"pipelineruns.tekton.dev": {"v1"},
+		},
+	}, {
+		name:          "rewrite storage versions of a namespace",
+		namespace:     "default",
+		rewrite:       true,
+		wantRewritten: map[string]bool{"Task": true},
+		wantStoredVersion: map[string][]string{
+			"tasks.tekton.dev":        {"v1beta1", "v1"},
+			"pipelines.tekton.dev":    {"v1beta1", "v1"},
+			"taskruns.tekton.dev":     {"v1beta1", "v1"},
+			"pipelineruns.tekton.dev": {"v1beta1", "v1"},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset([]runtime.Object{task, pipeline, v1Task, v1Pipeline}...)
+			crds := &fakeCRDs{crds: map[string]*apiextensionsv1.CustomResourceDefinition{}}
+			for name := range tc.wantStoredVersion {
+				crds.crds[name] = &apiextensionsv1.CustomResourceDefinition{
+					ObjectMeta: metav1.ObjectMeta{Name: name},
+					Status:     apiextensionsv1.CustomResourceDefinitionStatus{StoredVersions: []string{"v1beta1", "v1"}},
+				}
+			}
+			c := &Checker{Client: client, Namespace: tc.namespace, RewriteStorageVersions: tc.rewrite, CRDClient: crds}
+			reports, err := c.Run(t.Context())
+			if err != nil {
+				t.Fatalf("Run() = %v", err)
+			}
+			if len(reports) != 2 {
+				t.Fatalf("expected the reports of the Task and the Pipeline, got %+v", reports)
+			}
+			for _, r := range reports {
+				if r.Error != "" {
+					t.Errorf("unexpected error checking the %s %s: %s", r.Kind, r.Name, r.Error)
+				}
+				if r.Rewritten != tc.wantRewritten[r.Kind] {
+					t.Errorf("expected the %s to be rewritten: %t, got %t", r.Kind, tc.wantRewritten[r.Kind], r.Rewritten)
+				}
+				if len(r.Findings) == 0 {
+					t.Errorf("expected findings for the %s", r.Kind)
+				}
+			}
+
+			var updates []string
+			for _, action := range client.Actions() {
+				if action.GetVerb() == "update" {
+					updates = append(updates, action.GetResource().Version+"/"+action.GetResource().Resource)
+				}
+			}
+			var wantUpdates []string
+			if tc.rewrite {
+				wantUpdates = []string{"v1/tasks"}
+			}
+			if d := cmp.Diff(wantUpdates, updates); d != "" {
+				t.Errorf("updates diff %s", diff.PrintWantGot(d))
+			}
+			got := map[string][]string{}
+			for name, crd := range crds.crds {
+				got[name] = crd.Status.StoredVersions
+			}
+			if d := cmp.Diff(tc.wantStoredVersion, got); d != "" {
+				t.Errorf("stored versions diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
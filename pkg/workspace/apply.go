@@ -160,7 +160,7 @@ func Apply(ctx context.Context, ts v1.TaskSpec, wb []v1.WorkspaceBinding, v map[
 			Name:      vv.Name,
 			MountPath: w.GetMountPath(),
 			SubPath:   wb[i].SubPath,
-			ReadOnly:  w.ReadOnly,
+			ReadOnly:  w.ReadOnly || isReadOnlyBinding(wb[i]),
 		}
 
 		if isolatedWorkspaces.Has(w.Name) {
@@ -178,6 +178,13 @@ func Apply(ctx context.Context, ts v1.TaskSpec, wb []v1.WorkspaceBinding, v map[
 	return &ts, nil
 }
 
+// isReadOnlyBinding returns true if the volume bound to a workspace can only be
+// mounted read-only, such as the read-only CSI volumes of the Secrets Store
+// CSI driver, regardless of the readOnly field of the workspace declaration.
+func isReadOnlyBinding(wb v1.WorkspaceBinding) bool {
+	return wb.CSI != nil && wb.CSI.ReadOnly != nil && *wb.CSI.ReadOnly
+}
+
 // mountAsSharedWorkspace takes a volumeMount and adds it to all the steps and sidecars in
 // a TaskSpec.
 func mountAsSharedWorkspace(ts v1.TaskSpec, volumeMount corev1.VolumeMount) {
@@ -387,6 +394,9 @@ func applyCSIVolumeSource(csi *corev1.CSIVolumeSource, replacements map[string]s
 	if csi.NodePublishSecretRef != nil {
 		csi.NodePublishSecretRef.Name = substitution.ApplyReplacements(csi.NodePublishSecretRef.Name, replacements)
 	}
+	for key, value := range csi.VolumeAttributes {
+		csi.VolumeAttributes[key] = substitution.ApplyReplacements(value, replacements)
+	}
 	return csi
 }
 
@@ -313,6 +313,7 @@ func TestCreateVolumes(t *testing.T) {
 
 func TestApply(t *testing.T) {
 	names.TestingSeed()
+	readOnly := true
 	for _, tc := range []struct {
 		name             string
 		ts               v1.TaskSpec
@@ -738,6 +739,51 @@ func TestApply(t *testing.T) {
 				ReadOnly:  true,
 			}},
 		},
+	}, {
+		name: "binding a read-only secrets-store CSI volume to a writable workspace",
+		ts: v1.TaskSpec{
+			Workspaces: []v1.WorkspaceDeclaration{{
+				Name:      "secrets",
+				MountPath: "/mnt/secrets",
+			}},
+		},
+		workspaces: []v1.WorkspaceBinding{{
+			Name: "secrets",
+			CSI: &corev1.CSIVolumeSource{
+				Driver:   "secrets-store.csi.k8s.io",
+				ReadOnly: &readOnly,
+				VolumeAttributes: map[string]string{
+					"secretProviderClass": "vault-database",
+				},
+				NodePublishSecretRef: &corev1.LocalObjectReference{Name: "secrets-store-creds"},
+			},
+		}},
+		expectedTaskSpec: v1.TaskSpec{
+			StepTemplate: &v1.StepTemplate{
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      "ws-b7d9e",
+					MountPath: "/mnt/secrets",
+					ReadOnly:  true,
+				}},
+			},
+			Volumes: []corev1.Volume{{
+				Name: "ws-b7d9e",
+				VolumeSource: corev1.VolumeSource{
+					CSI: &corev1.CSIVolumeSource{
+						Driver:   "secrets-store.csi.k8s.io",
+						ReadOnly: &readOnly,
+						VolumeAttributes: map[string]string{
+							"secretProviderClass": "vault-database",
+						},
+						NodePublishSecretRef: &corev1.LocalObjectReference{Name: "secrets-store-creds"},
+					},
+				},
+			}},
+			Workspaces: []v1.WorkspaceDeclaration{{
+				Name:      "secrets",
+				MountPath: "/mnt/secrets",
+			}},
+		},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			vols := workspace.CreateVolumes(tc.workspaces)
@@ -1572,6 +1618,32 @@ func TestReplaceWorkspaceBindingsVars(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Replace CSI volume attributes",
+			replacements: map[string]string{
+				"params.provider-class": "vault-database",
+			},
+			workspaceBindings: []v1.WorkspaceBinding{
+				{
+					CSI: &corev1.CSIVolumeSource{
+						Driver: "secrets-store.csi.k8s.io",
+						VolumeAttributes: map[string]string{
+							"secretProviderClass": "$(params.provider-class)",
+						},
+					},
+				},
+			},
+			expected: []v1.WorkspaceBinding{
+				{
+					CSI: &corev1.CSIVolumeSource{
+						Driver: "secrets-store.csi.k8s.io",
+						VolumeAttributes: map[string]string{
+							"secretProviderClass": "vault-database",
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
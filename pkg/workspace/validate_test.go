@@ -198,6 +198,22 @@ func TestValidateOnlyOnePVCIsUsed_Valid(t *testing.T) {
 				ClaimName: "foo",
 			},
 		}},
+	}, {
+		name: "an error is not returned when CSI volumes are bound along with one PV claim",
+		bindings: []v1.WorkspaceBinding{{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: "foo",
+			},
+		}, {
+			CSI: &corev1.CSIVolumeSource{
+				Driver:           "secrets-store.csi.k8s.io",
+				VolumeAttributes: map[string]string{"secretProviderClass": "vault-database"},
+			},
+		}, {
+			CSI: &corev1.CSIVolumeSource{
+				Driver: "my-csi",
+			},
+		}},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			if err := workspace.ValidateOnlyOnePVCIsUsed(tc.bindings); err != nil {
@@ -83,6 +83,7 @@ type Data struct {
 	ResolutionRequests      []*resolutionv1alpha1.ResolutionRequest
 	ExpectedCloudEventCount int
 	VerificationPolicies    []*v1alpha1.VerificationPolicy
+	ParameterSets           []*v1alpha1.ParameterSet
 	Secrets                 []*corev1.Secret
 }
 
@@ -285,6 +286,12 @@ func SeedTestData(t *testing.T, ctx context.Context, d Data) (Clients, Informers
 			t.Fatal(err)
 		}
 	}
+	for _, ps := range d.ParameterSets {
+		ps := ps.DeepCopy() // Avoid assumptions that the client's copy is modified.
+		if _, err := c.Pipeline.TektonV1alpha1().ParameterSets(ps.Namespace).Create(ctx, ps, metav1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
 
 	c.Kube.PrependReactor("*", "secrets", AddToInformer(t, i.Secret.Informer().GetIndexer()))
 	for _, s := range d.Secrets {
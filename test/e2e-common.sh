@@ -22,7 +22,7 @@ function install_pipeline_crd() {
   echo ">> Deploying Tekton Pipelines"
   local ko_target="$(mktemp)"
   ko resolve -R -f config/ > "${ko_target}" || fail_test "Pipeline image resolve failed"
-  ko resolve -R -f optional_config/ >> "${ko_target}" || fail_test "Pod log access resolve failed"
+  ko resolve -R -f optional_config/enable-log-access-to-controller/ >> "${ko_target}" || fail_test "Pod log access resolve failed"
   cat "${ko_target}" | sed -e 's%"level": "info"%"level": "debug"%' \
       | sed -e 's%loglevel.controller: "info"%loglevel.controller: "debug"%' \
       | sed -e 's%loglevel.webhook: "info"%loglevel.webhook: "debug"%' \
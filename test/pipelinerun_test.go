@@ -1028,8 +1028,9 @@ spec:
 	if pipelineRun.Status.GetCondition(apis.ConditionSucceeded).IsTrue() {
 		t.Errorf("Expected PipelineRun to fail but found condition: %s", pipelineRun.Status.GetCondition(apis.ConditionSucceeded))
 	}
-	expectedMessage := "Tasks Completed: 1 (Failed: 1, Cancelled 0), Skipped: 0"
-	if pipelineRun.Status.GetCondition(apis.ConditionSucceeded).Message != expectedMessage {
+	// The message of the failure of the task is the one of its TaskRun
+	expectedMessage := "Tasks Completed: 1 (Failed: 1, Cancelled 0), Skipped: 0\nFailed tasks:\n- xxx (Failed): "
+	if !strings.HasPrefix(pipelineRun.Status.GetCondition(apis.ConditionSucceeded).Message, expectedMessage) {
 		t.Errorf("Expected PipelineRun to fail with condition message: %s but got: %s", expectedMessage, pipelineRun.Status.GetCondition(apis.ConditionSucceeded).Message)
 	}
 }